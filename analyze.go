@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/ljbink/ai-poker/engine/analysis"
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/stats"
+)
+
+// runAnalyze implements "ai-poker analyze [flags] files...": it reads
+// PokerStars or PHH hand histories or saved replays and prints session
+// statistics, decision timing when recorded and the biggest EV mistakes.
+// -collusion adds the pairs of players whose play against each other looks
+// coordinated, for whoever runs the table to review. -db adds the hands
+// merged into the local database by "ai-poker import".
+func runAnalyze(args []string, out io.Writer, dataDir string) error {
+	flags := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	flags.SetOutput(out)
+	player := flags.String("player", "", "only judge this player's decisions and map their showdowns")
+	top := flags.Int("top", 10, "number of mistakes to list")
+	samples := flags.Int("samples", 5000, "equity samples per preflop or flop decision")
+	seed := flags.Int64("seed", 0, "equity sampling seed, 0 for random")
+	collusion := flags.Bool("collusion", false, "flag chip dumping, folding to one opponent and soft play between pairs of players")
+	database := flags.Bool("db", false, "include the hands imported with ai-poker import")
+	flags.Usage = func() {
+		fmt.Fprintln(out, "Usage: ai-poker analyze [flags] history-files...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 && !*database {
+		flags.Usage()
+		return fmt.Errorf("no hand history files given")
+	}
+
+	hands := []*handhistory.Hand{}
+	if *database {
+		db, err := handhistory.OpenDatabase(filepath.Join(dataDir, handsDir))
+		if err != nil {
+			return err
+		}
+		hands = append(hands, db.Hands()...)
+	}
+	for _, path := range flags.Args() {
+		parsed, err := handhistory.ParseFile(path)
+		if err != nil {
+			return err
+		}
+		hands = append(hands, parsed...)
+	}
+
+	report := analysis.Analyze(hands, *player, equity.Options{Samples: *samples, Seed: *seed})
+	if err := report.Write(out, *top); err != nil {
+		return err
+	}
+	if !*collusion {
+		return nil
+	}
+	fmt.Fprintln(out)
+	return stats.WriteCollusionReport(out, stats.DetectCollusion(hands, stats.DefaultCollusionOptions))
+}
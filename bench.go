@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/ljbink/ai-poker/internal/perf"
+)
+
+// defaultBaseline is the benchmark run checked in with the engine
+const defaultBaseline = "internal/perf/baseline.txt"
+
+// runBench implements "ai-poker bench [flags] new.txt": it compares a saved
+// "go test -bench -benchmem" run against the baseline and fails when any
+// benchmark got slower or allocates more than the threshold allows
+func runBench(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	flags.SetOutput(out)
+	baseline := flags.String("baseline", defaultBaseline, "benchmark output to compare against")
+	threshold := flags.Float64("threshold", perf.DefaultThreshold, "allowed slowdown or growth, e.g. 0.1 for 10%")
+	flags.Usage = func() {
+		fmt.Fprintln(out, "Usage: ai-poker bench [flags] new.txt")
+		fmt.Fprintln(out, "Record a run with: go test -run '^$' -bench . -benchmem -count 5 ./engine/... > new.txt")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("need a benchmark run to compare")
+	}
+
+	old, err := perf.ParseFile(*baseline)
+	if err != nil {
+		return err
+	}
+	new, err := perf.ParseFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	deltas := perf.Compare(old, new)
+	if len(deltas) == 0 {
+		return fmt.Errorf("no benchmarks in common with %s", *baseline)
+	}
+	for _, delta := range deltas {
+		marker := " "
+		if delta.Regressed(*threshold) {
+			marker = "!"
+		}
+		fmt.Fprintf(out, "%s %s\n", marker, delta)
+	}
+	if regressed := perf.Regressions(deltas, *threshold); len(regressed) > 0 {
+		return fmt.Errorf("%d of %d measurements regressed by more than %.0f%%", len(regressed), len(deltas), *threshold*100)
+	}
+	fmt.Fprintf(out, "\nNo regressions beyond %.0f%%\n", *threshold*100)
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+// runChart implements "ai-poker chart [flags] hand-or-range": it works out
+// the preflop all-in equity of all 169 starting hands against the hand or
+// range and prints the grid, or writes it as CSV
+func runChart(args []string, out io.Writer, library *ranges.Library) error {
+	flags := flag.NewFlagSet("chart", flag.ContinueOnError)
+	flags.SetOutput(out)
+	samples := flags.Int("samples", equity.DefaultChartSamples, "runouts sampled per starting hand")
+	workers := flags.Int("workers", runtime.NumCPU(), "starting hands calculated in parallel")
+	seed := flags.Int64("seed", 0, "sampling seed, 0 for random")
+	csvPath := flags.String("csv", "", "write the chart as CSV to this file, - for stdout")
+	progress := flags.Bool("progress", true, "show progress on stderr")
+	flags.Usage = func() {
+		fmt.Fprintln(out, "Usage: ai-poker chart [flags] hand-or-range")
+		fmt.Fprintln(out, "e.g. ai-poker chart AsAh, or ai-poker chart \"QQ+, AKs\"")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("no hand or range given")
+	}
+
+	label := strings.Join(flags.Args(), " ")
+	opponent, err := equity.ParseHolding(label, ranges.WithLibrary(library))
+	if err != nil {
+		return err
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	opts := equity.ChartOptions{Options: equity.Options{Samples: *samples, Seed: *seed}, Workers: *workers}
+	if *progress {
+		shown := -1
+		opts.Progress = func(done, total int) {
+			if percent := done * 100 / total; percent != shown {
+				shown = percent
+				fmt.Fprintf(os.Stderr, "\r%3d%% · %d/%d hands", percent, done, total)
+			}
+			if done == total {
+				fmt.Fprintln(os.Stderr)
+			}
+		}
+	}
+
+	chart, err := equity.PreflopChart(context.Background(), opponent, label, opts)
+	if err != nil {
+		return err
+	}
+	switch *csvPath {
+	case "":
+		return chart.WriteText(out)
+	case "-":
+		return chart.WriteCSV(out)
+	}
+	file, err := os.Create(*csvPath)
+	if err != nil {
+		return err
+	}
+	if err := chart.WriteCSV(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Wrote the chart to %s\n", *csvPath)
+	return nil
+}
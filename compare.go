@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/engine/simulator"
+)
+
+// runCompare implements "ai-poker compare [flags] presetA presetB": it plays
+// heads-up duplicate deals between two bot presets and reports whether one
+// wins significantly more than the other, and whether enough deals were
+// played to tell
+func runCompare(args []string, out io.Writer, library *ranges.Library) error {
+	flags := flag.NewFlagSet("compare", flag.ContinueOnError)
+	flags.SetOutput(out)
+	deals := flags.Int("deals", simulator.DefaultCompareDeals, "duplicate deals, each played twice with seats swapped")
+	stack := flags.Int("stack", simulator.DefaultCompareStack, "starting stack in big blinds, reset every hand")
+	confidence := flags.Float64("z", simulator.DefaultConfidence, "z-score a difference must reach to be significant")
+	effect := flags.Float64("effect", 0, "smallest difference in bb/100 worth finding, the observed one when zero")
+	power := flags.Float64("power", simulator.DefaultPower, "chance of finding a real difference of that size")
+	workers := flags.Int("workers", runtime.NumCPU(), "deals played in parallel")
+	seed := flags.Int64("seed", 0, "master seed of the deals, 0 for random")
+	progress := flags.Bool("progress", true, "show progress on stderr")
+	flags.Usage = func() {
+		fmt.Fprintln(out, "Usage: ai-poker compare [flags] presetA presetB")
+		fmt.Fprintf(out, "Bot presets: %s, or the path of a CSV chart file\n", strings.Join(holdem_ai.BotNames(), ", "))
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return fmt.Errorf("need two bot presets to compare")
+	}
+
+	strategies := [2]simulator.Strategy{}
+	for i, name := range flags.Args() {
+		strategy, err := simulator.PresetStrategy(name, ranges.WithLibrary(library))
+		if err != nil {
+			return err
+		}
+		strategies[i] = strategy
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	opts := simulator.CompareOptions{Deals: *deals, StackBB: *stack, Confidence: *confidence, Effect: *effect, Power: *power, Workers: *workers, Seed: *seed}
+	if *progress {
+		opts.Progress = printProgress(os.Stderr, "deals")
+	}
+
+	result, err := simulator.Compare(context.Background(), strategies[0], strategies[1], opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%d duplicate deals, %d big blind stacks, seed %d\n\n", result.Deals, *stack, *seed)
+	fmt.Fprintln(out, result)
+	fmt.Fprintln(out, result.PowerReport())
+	if result.Underpowered() {
+		fmt.Fprintf(out, "Rerun with -deals %d for a verdict you can trust\n", (result.HandsNeeded+1)/2)
+	}
+	return nil
+}
@@ -0,0 +1,406 @@
+// Package abstraction groups hands of similar strength into buckets, so a
+// solver can treat the millions of hole card and board pairs of a street as
+// a handful of hands. Buckets are fitted to sampled hands by one of two
+// methods:
+//
+//   - Percentile splits hands into equal shares by their equity against a
+//     random hand, weakest first.
+//   - KMeans clusters hands by the distribution of their equity over the
+//     cards still to come, which tells a draw from a made hand of the same
+//     equity. On the river, with no cards to come, it clusters equity alone.
+//
+// A fitted abstraction is saved to and loaded from JSON, so it is built
+// once and shared between training runs and bots.
+package abstraction
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// Method is how hands are grouped into buckets
+type Method string
+
+const (
+	Percentile Method = "percentile" // Equal shares of hands by equity
+	KMeans     Method = "kmeans"     // Clusters of equity distributions
+)
+
+// Defaults of a Config left at zero
+const (
+	DefaultSamples  = 2000 // Hands sampled to fit the buckets
+	DefaultRollouts = 200  // Runouts per equity estimate
+	DefaultDraws    = 8    // Next-street cards an equity distribution is taken over
+	DefaultBins     = 10   // Histogram bins of an equity distribution
+)
+
+// maxIterations bounds the k-means refinement, which usually settles well before
+const maxIterations = 50
+
+// Config describes the buckets of one street
+type Config struct {
+	Street   holdem.GamePhase `json:"street"` // PhasePreflop to PhaseRiver
+	Buckets  int              `json:"buckets"`
+	Method   Method           `json:"method"`
+	Samples  int              `json:"samples,omitempty"`  // DefaultSamples when zero
+	Rollouts int              `json:"rollouts,omitempty"` // DefaultRollouts when zero
+	Draws    int              `json:"draws,omitempty"`    // KMeans only, DefaultDraws when zero
+	Bins     int              `json:"bins,omitempty"`     // KMeans only, DefaultBins when zero
+	Seed     int64            `json:"seed,omitempty"`     // Fixes the sampled hands and equity estimates
+}
+
+// withDefaults fills in the zero settings
+func (c Config) withDefaults() Config {
+	if c.Samples <= 0 {
+		c.Samples = DefaultSamples
+	}
+	if c.Rollouts <= 0 {
+		c.Rollouts = DefaultRollouts
+	}
+	if c.Draws <= 0 {
+		c.Draws = DefaultDraws
+	}
+	if c.Bins <= 0 {
+		c.Bins = DefaultBins
+	}
+	return c
+}
+
+// Validate reports settings no abstraction can be built with
+func (c Config) Validate() error {
+	if c.Street < holdem.PhasePreflop || c.Street > holdem.PhaseRiver {
+		return fmt.Errorf("street %d is not preflop, flop, turn or river", c.Street)
+	}
+	if c.Buckets < 1 {
+		return fmt.Errorf("need at least 1 bucket, got %d", c.Buckets)
+	}
+	if c.Method != Percentile && c.Method != KMeans {
+		return fmt.Errorf("unknown bucketing method %q", c.Method)
+	}
+	if samples := c.withDefaults().Samples; samples < c.Buckets {
+		return fmt.Errorf("%d samples cannot fill %d buckets", samples, c.Buckets)
+	}
+	return nil
+}
+
+// Abstraction maps the hands of a street to buckets numbered from 0, the
+// weakest, to Buckets-1
+type Abstraction struct {
+	Config     Config      `json:"config"`
+	Thresholds []float64   `json:"thresholds,omitempty"` // Percentile: the equity each bucket but the first starts at
+	Centroids  [][]float64 `json:"centroids,omitempty"`  // KMeans: the mean equity distribution of each bucket, as a CDF
+}
+
+// boardSize is how many community cards a street has
+func boardSize(street holdem.GamePhase) int {
+	switch street {
+	case holdem.PhasePreflop:
+		return 0
+	case holdem.PhaseFlop:
+		return 3
+	case holdem.PhaseTurn:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// Build samples hands of the street and fits the buckets to them
+func Build(config Config) (*Abstraction, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	a := &Abstraction{Config: config}
+	rng := rand.New(rand.NewSource(config.Seed))
+	deck := fullDeck()
+	size := boardSize(config.Street)
+	features := make([][]float64, config.withDefaults().Samples)
+	for i := range features {
+		rng.Shuffle(len(deck), func(x, y int) { deck[x], deck[y] = deck[y], deck[x] })
+		feature, err := a.features(deck[:2], deck[2:2+size])
+		if err != nil {
+			return nil, err
+		}
+		features[i] = feature
+	}
+
+	switch config.Method {
+	case Percentile:
+		a.Thresholds = percentiles(features, config.Buckets)
+	case KMeans:
+		a.Centroids = kmeans(features, config.Buckets, rng)
+	}
+	return a, nil
+}
+
+// Bucket returns the bucket of hole cards on a board of the street. The
+// same hand always lands in the same bucket: its equity is estimated from
+// a seed derived from the cards.
+func (a *Abstraction) Bucket(hole, board poker.Cards) (int, error) {
+	if len(hole) != 2 {
+		return 0, fmt.Errorf("hand has %d cards, buckets are for hold'em hands", len(hole))
+	}
+	if size := boardSize(a.Config.Street); len(board) != size {
+		return 0, fmt.Errorf("%s board has %d cards, expected %d", holdem.PhaseToString(a.Config.Street), len(board), size)
+	}
+	feature, err := a.features(hole, board)
+	if err != nil {
+		return 0, err
+	}
+	switch a.Config.Method {
+	case Percentile:
+		return sort.SearchFloat64s(a.Thresholds, feature[0]+1e-12), nil
+	case KMeans:
+		return nearest(a.Centroids, feature), nil
+	default:
+		return 0, fmt.Errorf("unknown bucketing method %q", a.Config.Method)
+	}
+}
+
+// features describes a hand the way the method compares them: its equity
+// for Percentile, the CDF of its equity over the next cards for KMeans
+func (a *Abstraction) features(hole, board poker.Cards) ([]float64, error) {
+	config := a.Config.withDefaults()
+	seed := handSeed(hole, board) ^ config.Seed
+	if seed == 0 {
+		seed = 1 // Zero asks equity for a time-based seed
+	}
+	if config.Method == Percentile || config.Street == holdem.PhaseRiver {
+		e, err := equity.CalculateVsRandom(hole, board, 1, equity.Options{Samples: config.Rollouts, Seed: seed})
+		if err != nil {
+			return nil, err
+		}
+		if config.Method == Percentile {
+			return []float64{e}, nil
+		}
+		return cdf([]float64{e}, config.Bins), nil
+	}
+
+	// The equity a hand has after each of a few cards the next street could bring
+	next := boardSize(config.Street+1) - len(board)
+	deck, err := remaining(append(append(poker.Cards{}, hole...), board...))
+	if err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewSource(seed))
+	equities := make([]float64, config.Draws)
+	for i := range equities {
+		for j := 0; j < next; j++ {
+			k := j + rng.Intn(len(deck)-j)
+			deck[j], deck[k] = deck[k], deck[j]
+		}
+		nextBoard := append(append(poker.Cards{}, board...), deck[:next]...)
+		if equities[i], err = equity.CalculateVsRandom(hole, nextBoard, 1, equity.Options{Samples: config.Rollouts, Seed: seed + int64(i) + 1}); err != nil {
+			return nil, err
+		}
+	}
+	return cdf(equities, config.Bins), nil
+}
+
+// cdf returns the cumulative histogram of equities over evenly sized bins.
+// Squared distances between CDFs track how far apart two distributions
+// are, unlike those between histograms, and their mean is a CDF again.
+func cdf(equities []float64, bins int) []float64 {
+	histogram := make([]float64, bins)
+	for _, e := range equities {
+		histogram[min(int(e*float64(bins)), bins-1)]++
+	}
+	total := 0.0
+	for i, count := range histogram {
+		total += count / float64(len(equities))
+		histogram[i] = total
+	}
+	return histogram
+}
+
+// percentiles returns the equity each bucket but the first starts at, so
+// every bucket holds an equal share of the sampled hands
+func percentiles(features [][]float64, buckets int) []float64 {
+	equities := make([]float64, len(features))
+	for i, feature := range features {
+		equities[i] = feature[0]
+	}
+	sort.Float64s(equities)
+	thresholds := make([]float64, buckets-1)
+	for i := range thresholds {
+		thresholds[i] = equities[(i+1)*len(equities)/buckets]
+	}
+	return thresholds
+}
+
+// kmeans clusters the features with Lloyd's algorithm from k-means++
+// starting centroids, and returns the centroids weakest first
+func kmeans(features [][]float64, k int, rng *rand.Rand) [][]float64 {
+	centroids := [][]float64{append([]float64{}, features[rng.Intn(len(features))]...)}
+	distances := make([]float64, len(features))
+	for len(centroids) < k {
+		total := 0.0
+		for i, feature := range features {
+			distances[i] = distance(feature, centroids[nearest(centroids, feature)])
+			total += distances[i]
+		}
+		pick := len(features) - 1
+		if total > 0 {
+			target := rng.Float64() * total
+			for i, d := range distances {
+				if target -= d; target < 0 {
+					pick = i
+					break
+				}
+			}
+		} else {
+			pick = rng.Intn(len(features)) // Fewer distinct features than buckets
+		}
+		centroids = append(centroids, append([]float64{}, features[pick]...))
+	}
+
+	assigned := make([]int, len(features))
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		moved := iteration == 0
+		for i, feature := range features {
+			if c := nearest(centroids, feature); c != assigned[i] {
+				assigned[i], moved = c, true
+			}
+		}
+		if !moved {
+			break
+		}
+		counts := make([]int, k)
+		sums := make([][]float64, k)
+		for c := range sums {
+			sums[c] = make([]float64, len(features[0]))
+		}
+		for i, feature := range features {
+			counts[assigned[i]]++
+			for j, v := range feature {
+				sums[assigned[i]][j] += v
+			}
+		}
+		for c := range centroids {
+			if counts[c] == 0 {
+				continue // An empty cluster keeps its centroid
+			}
+			for j := range sums[c] {
+				centroids[c][j] = sums[c][j] / float64(counts[c])
+			}
+		}
+	}
+
+	// A CDF that stays lower puts more weight on high equities
+	sort.SliceStable(centroids, func(i, j int) bool { return sum(centroids[i]) > sum(centroids[j]) })
+	return centroids
+}
+
+// nearest returns the index of the centroid closest to the feature
+func nearest(centroids [][]float64, feature []float64) int {
+	best, bestDistance := 0, distance(centroids[0], feature)
+	for c := 1; c < len(centroids); c++ {
+		if d := distance(centroids[c], feature); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	return best
+}
+
+func distance(a, b []float64) float64 {
+	total := 0.0
+	for i := range a {
+		total += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return total
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// handSeed derives a seed from the cards, whatever order they come in
+func handSeed(hole, board poker.Cards) int64 {
+	codes := func(cards poker.Cards) string {
+		list := make([]string, len(cards))
+		for i, card := range cards {
+			list[i] = card.Code()
+		}
+		sort.Strings(list)
+		return strings.Join(list, "")
+	}
+	h := fnv.New64a()
+	h.Write([]byte(codes(hole) + "|" + codes(board)))
+	return int64(h.Sum64())
+}
+
+// fullDeck returns the 52 cards of a standard deck
+func fullDeck() poker.Cards {
+	deck := poker.Cards{}
+	for _, card := range poker.NewDeckCards() {
+		if card.Suit != poker.SuitNone {
+			deck = append(deck, card)
+		}
+	}
+	return deck
+}
+
+// remaining returns the deck without the known cards
+func remaining(known poker.Cards) (poker.Cards, error) {
+	used := map[poker.Card]bool{}
+	for _, card := range known {
+		if card == nil {
+			return nil, fmt.Errorf("nil card")
+		}
+		if used[*card] {
+			return nil, fmt.Errorf("card %s appears more than once", card.Code())
+		}
+		used[*card] = true
+	}
+	deck := poker.Cards{}
+	for _, card := range fullDeck() {
+		if !used[*card] {
+			deck = append(deck, card)
+		}
+	}
+	return deck, nil
+}
+
+// Save writes the abstraction to a JSON file
+func (a *Abstraction) Save(path string) error {
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Load reads an abstraction saved with Save
+func Load(path string) (*Abstraction, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	a := &Abstraction{}
+	if err := json.Unmarshal(data, a); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := a.Config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	switch {
+	case a.Config.Method == Percentile && len(a.Thresholds) != a.Config.Buckets-1:
+		return nil, fmt.Errorf("%s: %d thresholds for %d buckets", path, len(a.Thresholds), a.Config.Buckets)
+	case a.Config.Method == KMeans && len(a.Centroids) != a.Config.Buckets:
+		return nil, fmt.Errorf("%s: %d centroids for %d buckets", path, len(a.Centroids), a.Config.Buckets)
+	}
+	return a, nil
+}
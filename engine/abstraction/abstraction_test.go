@@ -0,0 +1,114 @@
+package abstraction
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func mustCards(t testing.TB, s string) poker.Cards {
+	t.Helper()
+	cards, err := poker.ParseCards(s)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing %q: %v", s, err)
+	}
+	return cards
+}
+
+func mustBucket(t *testing.T, a *Abstraction, hole, board string) int {
+	t.Helper()
+	var boardCards poker.Cards
+	if board != "" {
+		boardCards = mustCards(t, board)
+	}
+	bucket, err := a.Bucket(mustCards(t, hole), boardCards)
+	if err != nil {
+		t.Fatalf("Bucket %s on %q: %v", hole, board, err)
+	}
+	return bucket
+}
+
+func TestPercentileBucketsPreflop(t *testing.T) {
+	a, err := Build(Config{Street: holdem.PhasePreflop, Buckets: 5, Method: Percentile, Samples: 200, Rollouts: 300, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Thresholds) != 4 {
+		t.Fatalf("Expected 4 thresholds, got %v", a.Thresholds)
+	}
+	if aces := mustBucket(t, a, "AsAh", ""); aces != 4 {
+		t.Errorf("Expected aces in the top bucket, got %d", aces)
+	}
+	if trash := mustBucket(t, a, "7d2c", ""); trash != 0 {
+		t.Errorf("Expected 72o in the bottom bucket, got %d", trash)
+	}
+	if first, again := mustBucket(t, a, "KhQh", ""), mustBucket(t, a, "QhKh", ""); first != again {
+		t.Errorf("Expected the same hand in the same bucket, got %d and %d", first, again)
+	}
+}
+
+func TestKMeansBucketsOnTheFlop(t *testing.T) {
+	a, err := Build(Config{Street: holdem.PhaseFlop, Buckets: 4, Method: KMeans, Samples: 150, Rollouts: 60, Draws: 6, Seed: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Centroids) != 4 {
+		t.Fatalf("Expected 4 centroids, got %d", len(a.Centroids))
+	}
+	set := mustBucket(t, a, "9s9d", "9hKc2d")
+	air := mustBucket(t, a, "4s3d", "9hKcQd")
+	if set <= air {
+		t.Errorf("Expected a set in a stronger bucket than air, got %d and %d", set, air)
+	}
+}
+
+func TestBucketRejectsTheWrongStreet(t *testing.T) {
+	a, err := Build(Config{Street: holdem.PhaseTurn, Buckets: 2, Method: Percentile, Samples: 10, Rollouts: 10, Seed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Bucket(mustCards(t, "AsAh"), mustCards(t, "2c3d4h")); err == nil {
+		t.Error("Expected a flop board to be rejected by a turn abstraction")
+	}
+	if _, err := a.Bucket(mustCards(t, "AsAh"), mustCards(t, "As3d4h5c")); err == nil {
+		t.Error("Expected a duplicate card to be rejected")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	for _, config := range []Config{
+		{Street: holdem.PhaseShowdown, Buckets: 2, Method: Percentile},
+		{Street: holdem.PhaseFlop, Buckets: 0, Method: Percentile},
+		{Street: holdem.PhaseFlop, Buckets: 2, Method: "emd"},
+		{Street: holdem.PhaseFlop, Buckets: 20, Method: KMeans, Samples: 10},
+	} {
+		if _, err := Build(config); err == nil {
+			t.Errorf("Expected %+v to be rejected", config)
+		}
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	a, err := Build(Config{Street: holdem.PhaseRiver, Buckets: 3, Method: KMeans, Samples: 60, Rollouts: 40, Seed: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "river.json")
+	if err := a.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, loaded) {
+		t.Errorf("Expected the abstraction back, got %+v", loaded)
+	}
+	hole, board := "AhKh", "Qh7h2sJd3c"
+	if got, expected := mustBucket(t, loaded, hole, board), mustBucket(t, a, hole, board); got != expected {
+		t.Errorf("Expected bucket %d after loading, got %d", expected, got)
+	}
+}
@@ -0,0 +1,135 @@
+package analysis
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// Bob calls a river bet drawing dead
+const badCall = `variant = "NT"
+hand = 1
+starting_stacks = [100, 100]
+blinds_or_straddles = [1, 2]
+players = ["Alice", "Bob"]
+actions = ["d dh p1 AsAd", "d dh p2 KsKd", "p1 cc", "p2 cc",
+  "d db 2c3c4d", "p2 cc", "p1 cc", "d db 9h", "p2 cc", "p1 cc",
+  "d db Jh", "p2 cc", "p1 cbr 20", "p2 cc"]
+`
+
+// Alice folds a set getting good odds on the flop
+const badFold = `variant = "NT"
+hand = 2
+starting_stacks = [100, 100]
+blinds_or_straddles = [1, 2]
+players = ["Alice", "Bob"]
+actions = ["d dh p1 7s7d", "d dh p2 AhKh", "p1 cc", "p2 cc",
+  "d db 7c8h2d", "p2 cbr 2", "p1 f"]
+`
+
+func parseHands(t *testing.T, inputs ...string) []*handhistory.Hand {
+	t.Helper()
+	hands := []*handhistory.Hand{}
+	for _, input := range inputs {
+		hand, err := handhistory.ParsePHH(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		hands = append(hands, hand)
+	}
+	return hands
+}
+
+func TestFindMistakesFlagsBadCall(t *testing.T) {
+	mistakes := FindMistakes(parseHands(t, badCall)[0], equity.Options{Seed: 1, Samples: 1000})
+	if len(mistakes) != 1 {
+		t.Fatalf("Expected 1 mistake, got %+v", mistakes)
+	}
+	m := mistakes[0]
+	if m.Player != "Bob" || m.Action != handhistory.ActionCall || m.Phase != holdem.PhaseRiver {
+		t.Errorf("Expected Bob's river call, got %+v", m)
+	}
+	if m.Equity != 0 || m.EVLoss != 20 || m.ToCall != 20 || m.Pot != 24 {
+		t.Errorf("Expected a dead call losing 20 into 24, got %+v", m)
+	}
+}
+
+func TestFindMistakesFlagsBadFold(t *testing.T) {
+	mistakes := FindMistakes(parseHands(t, badFold)[0], equity.Options{Seed: 1, Samples: 1000})
+	if len(mistakes) != 1 {
+		t.Fatalf("Expected 1 mistake, got %+v", mistakes)
+	}
+	m := mistakes[0]
+	if m.Player != "Alice" || m.Action != handhistory.ActionFold || m.Equity < 0.9 {
+		t.Errorf("Expected Alice's fold with a set, got %+v", m)
+	}
+	if m.PotOdds() != 2.0/8.0 {
+		t.Errorf("Expected pot odds of 25%%, got %.3f", m.PotOdds())
+	}
+}
+
+func TestAnalyzeSortsAndFilters(t *testing.T) {
+	hands := parseHands(t, badCall, badFold)
+	report := Analyze(hands, "", equity.Options{Seed: 1, Samples: 1000})
+	if len(report.Mistakes) != 2 || report.Mistakes[0].EVLoss < report.Mistakes[1].EVLoss {
+		t.Fatalf("Expected 2 mistakes sorted by loss, got %+v", report.Mistakes)
+	}
+	if report.Mistakes[0].Player != "Bob" {
+		t.Errorf("Expected Bob's call to be the biggest mistake")
+	}
+
+	report = Analyze(hands, "Alice", equity.Options{Seed: 1, Samples: 1000})
+	if len(report.Mistakes) != 1 || report.Mistakes[0].Player != "Alice" {
+		t.Errorf("Expected only Alice's mistake, got %+v", report.Mistakes)
+	}
+
+	var out bytes.Buffer
+	if err := report.Write(&out, 5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, want := range []string{"Hands analysed: 2", "VPIP", "Squeeze", "Biggest EV mistakes", "Alice"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected report to contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestCallEV(t *testing.T) {
+	// Calling 50 into 150 needs 25%: break even there, +50 with half the pot
+	if ev := CallEV(0.25, 150, 50); math.Abs(ev) > 1e-9 {
+		t.Errorf("Expected a break-even call, got %.2f", ev)
+	}
+	if ev := CallEV(0.5, 150, 50); math.Abs(ev-50) > 1e-9 {
+		t.Errorf("Expected +50, got %.2f", ev)
+	}
+}
+
+func TestReportWritesTiming(t *testing.T) {
+	hands := parseHands(t, badCall)
+	var out bytes.Buffer
+	if err := Analyze(hands, "", equity.Options{Seed: 1, Samples: 100}).Write(&out, 5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(out.String(), "Decision timing") {
+		t.Errorf("Expected no timing table without decision times:\n%s", out.String())
+	}
+
+	for i := range hands[0].Actions {
+		hands[0].Actions[i].Elapsed = 1500 * time.Millisecond
+	}
+	out.Reset()
+	if err := Analyze(hands, "", equity.Options{Seed: 1, Samples: 100}).Write(&out, 5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, want := range []string{"Decision timing", "Check/call", "1.5s"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected report to contain %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/engine/stats"
+)
+
+// BadBeatEquity is the all-in equity from which losing the pot is a bad beat
+const BadBeatEquity = 0.75
+
+// coolerHands are the starting hands that lose all in preflop to a better
+// one as a cooler rather than a mistake
+var coolerHands = map[string]bool{"AA": true, "KK": true, "QQ": true, "AKs": true, "AKo": true}
+
+// TagBeats tags the hands lost all in as bad beats or coolers, from the
+// equity of the players when the last chips went in. A player who was at
+// least BadBeatEquity to win and lost took a bad beat; one who was behind
+// with a hand too strong to get away from, a premium pair or ace-king
+// preflop or three of a kind or better after the flop, was cooled. Every
+// tagged hand gives one highlight, the biggest beat when several players
+// lost. Like the other highlights, beats are only found in Hold'em hands
+// where every live player's cards are known.
+func TagBeats(hands []*handhistory.Hand, opts equity.Options) ([]Highlight, error) {
+	beats := []Highlight{}
+	for i, hand := range hands {
+		if hand.Variant != handhistory.VariantNLHE && hand.Variant != handhistory.VariantFLHE {
+			continue
+		}
+		beat, err := tagBeat(hand, opts)
+		if err != nil {
+			return nil, fmt.Errorf("hand %s: %w", hand.ID, err)
+		}
+		if beat != nil {
+			beat.Hand = i
+			beats = append(beats, *beat)
+		}
+	}
+	return beats, nil
+}
+
+// CountBeats adds the bad beats and coolers tagged by TagBeats to the
+// statistics of the players who took them
+func CountBeats(session *stats.Session, beats []Highlight) {
+	for _, beat := range beats {
+		player := session.Players[beat.Player]
+		if player == nil {
+			continue
+		}
+		switch beat.Kind {
+		case HighlightBadBeat:
+			player.BadBeats++
+		case HighlightCooler:
+			player.Coolers++
+		}
+	}
+}
+
+// tagBeat finds the bad beat or cooler of a hand, nil when it has none
+func tagBeat(hand *handhistory.Hand, opts equity.Options) (*Highlight, error) {
+	last := allInAction(hand)
+	if last < 0 {
+		return nil, nil
+	}
+	phase := hand.Actions[last].Phase
+	names, holes, ok := liveAfter(hand, hand.Actions[:last+1])
+	if !ok || len(names) < 2 {
+		return nil, nil
+	}
+	board := hand.BoardAt(phase)
+	result, err := equity.Calculate(holes, board, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var beat, cooler *Highlight
+	evaluator := holdem.NewHandEvaluator()
+	for i, name := range names {
+		if hand.Collected[name] > 0 || !hand.ReachedShowdown(name) {
+			continue
+		}
+		share := result.Equity[i]
+		found := &Highlight{HandID: hand.ID, Player: name, Phase: phase, Pot: potSize(hand), Equity: share}
+		switch {
+		case share >= BadBeatEquity:
+			if beat == nil || share > beat.Equity {
+				found.Kind = HighlightBadBeat
+				beat = found
+			}
+		case share < 0.5:
+			if len(board) == 0 && coolerHands[ranges.HandClass(holes[i])] {
+				found.Holding = ranges.HandClass(holes[i])
+			} else if rank := evaluator.EvaluateHand(holes[i], board).Rank; len(board) > 0 && rank >= holdem.ThreeOfAKind {
+				found.Holding = holdem.HandRankToString(rank)
+			}
+			if found.Holding != "" && (cooler == nil || share > cooler.Equity) {
+				found.Kind = HighlightCooler
+				cooler = found
+			}
+		}
+	}
+	if beat != nil {
+		return beat, nil
+	}
+	return cooler, nil
+}
+
+// allInAction returns the index of the action that put the last chips into
+// a hand that went to showdown with a player all in, -1 when none did
+func allInAction(hand *handhistory.Hand) int {
+	allIn := false
+	last := -1
+	for i, action := range hand.Actions {
+		if action.AllIn && hand.ReachedShowdown(action.Player) {
+			allIn = true
+		}
+		if action.Amount > 0 {
+			last = i
+		}
+	}
+	if !allIn {
+		return -1
+	}
+	return last
+}
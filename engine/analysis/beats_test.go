@@ -0,0 +1,85 @@
+package analysis
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/stats"
+)
+
+// Alice gets her set of nines in on the flop against Bob's top set
+const setOverSet = `variant = "NT"
+hand = 5
+starting_stacks = [100, 100]
+blinds_or_straddles = [1, 2]
+players = ["Alice", "Bob"]
+actions = ["d dh p1 9s9d", "d dh p2 KsKd", "p1 cc", "p2 cc",
+  "d db Kc9h2d", "p2 cbr 98", "p1 cc", "d db 4s", "d db 7c"]
+`
+
+// Alice's queens run into Bob's aces all in preflop
+const queensIntoAces = `variant = "NT"
+hand = 6
+starting_stacks = [100, 100]
+blinds_or_straddles = [1, 2]
+players = ["Alice", "Bob"]
+actions = ["d dh p1 QsQd", "d dh p2 AhAc", "p1 cbr 100", "p2 cc",
+  "d db 2c7h9d", "d db Jc", "d db 4s"]
+`
+
+func TestTagBeats(t *testing.T) {
+	hands := parseHands(t, badCall, cooler, setOverSet, queensIntoAces)
+	beats, err := TagBeats(hands, equity.Options{Seed: 1, Samples: 2000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(beats) != 3 {
+		t.Fatalf("Expected a bad beat and two coolers, got %+v", beats)
+	}
+
+	beat, set, queens := beats[0], beats[1], beats[2]
+	if beat.Kind != HighlightBadBeat || beat.Hand != 1 || beat.Player != "Alice" || beat.Phase != holdem.PhasePreflop || beat.Equity < BadBeatEquity {
+		t.Errorf("Expected Alice's aces beaten all in preflop, got %+v", beat)
+	}
+	if set.Kind != HighlightCooler || set.Hand != 2 || set.Player != "Alice" || set.Phase != holdem.PhaseFlop || set.Holding != "Three of a Kind" {
+		t.Errorf("Expected Alice's set cooled on the flop, got %+v", set)
+	}
+	if queens.Kind != HighlightCooler || queens.Hand != 3 || queens.Holding != "QQ" || queens.Pot != 200 {
+		t.Errorf("Expected Alice's queens cooled preflop, got %+v", queens)
+	}
+	if text := set.String(); text != "Cooler: Alice lost a pot of 200 all in on the flop with Three of a Kind" {
+		t.Errorf("Expected the cooler described, got %q", text)
+	}
+
+	session := stats.Compute(hands)
+	CountBeats(session, beats)
+	if alice, bob := session.Players["Alice"], session.Players["Bob"]; alice.BadBeats != 1 || alice.Coolers != 2 || bob.BadBeats+bob.Coolers != 0 {
+		t.Errorf("Expected Alice's beats counted, got %+v and %+v", alice, bob)
+	}
+}
+
+func TestHighlightsListBeats(t *testing.T) {
+	highlights, err := Highlights(parseHands(t, cooler, queensIntoAces), equity.Options{Seed: 1, Samples: 2000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The biggest pot and the worst beat, then the cooler; the bad beat is the worst beat already
+	if len(highlights) != 3 || highlights[1].Kind != HighlightWorstBeat || highlights[2].Kind != HighlightCooler || highlights[2].Hand != 1 {
+		t.Errorf("Expected the cooler listed after the worst beat, got %+v", highlights)
+	}
+}
+
+func TestReportListsBeats(t *testing.T) {
+	var out bytes.Buffer
+	if err := Analyze(parseHands(t, setOverSet), "", equity.Options{Seed: 1, Samples: 500}).Write(&out, 5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, want := range []string{"Bad beats and coolers:", "Cooler: Alice lost a pot of 200"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected report to contain %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package analysis
+
+import "github.com/ljbink/ai-poker/engine/pokermath"
+
+// CallEV moved to package pokermath with the rest of the betting math. It
+// forwards there so code built against earlier versions keeps compiling;
+// it will be removed in a future release.
+
+// CallEV returns the chips a call is worth relative to folding. The pot
+// includes the bet faced.
+//
+// Deprecated: use pokermath.CallEV.
+func CallEV(equity float64, pot, toCall int) float64 {
+	return pokermath.CallEV(equity, pot, toCall)
+}
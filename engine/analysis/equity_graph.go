@@ -0,0 +1,90 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// BigSwing is the change in equity from one street to the next that counts
+// as a big swing
+const BigSwing = 0.25
+
+// StreetEquity is a player's all-in equity when a street is dealt, against
+// the cards of the opponents still in the hand
+type StreetEquity struct {
+	Phase  holdem.GamePhase
+	Board  poker.Cards
+	Equity float64
+	Swing  float64 // Change since the previous street, 0 preflop
+}
+
+// IsBigSwing reports whether the street moved the equity by BigSwing or more
+func (s StreetEquity) IsBigSwing() bool {
+	return s.Swing >= BigSwing || s.Swing <= -BigSwing
+}
+
+// EquityByStreet works out a player's equity street by street from the
+// all-cards-visible table views of a reviewed hand, such as the frames of
+// spectator.ReplayFrames. The series ends when the player folds or nobody
+// is left to play against.
+func EquityByStreet(views []holdem.TableView, playerID int, opts equity.Options) ([]StreetEquity, error) {
+	streets := []StreetEquity{}
+	seen := map[int]bool{} // Board sizes already measured
+	for _, view := range views {
+		if seen[len(view.Board)] || view.Phase > holdem.PhaseRiver {
+			continue
+		}
+		hero, opponents, ok := liveHands(view, playerID)
+		if !ok {
+			if len(streets) > 0 {
+				break // Hero folded
+			}
+			continue // Cards not dealt yet
+		}
+		seen[len(view.Board)] = true
+
+		street := StreetEquity{Phase: view.Phase, Board: append(poker.Cards{}, view.Board...), Equity: 1}
+		if len(opponents) > 0 {
+			holes := append([]poker.Cards{hero}, opponents...)
+			streetOpts := opts
+			if streetOpts.Evaluator == nil && view.Variant.IsKnown() {
+				streetOpts.Evaluator = view.Variant.Rules().NewEvaluator()
+			}
+			result, err := equity.Calculate(holes, view.Board, streetOpts)
+			if err != nil {
+				return streets, fmt.Errorf("%s equity: %w", holdem.PhaseToString(view.Phase), err)
+			}
+			street.Equity = result.Equity[0]
+		}
+		if len(streets) > 0 {
+			street.Swing = street.Equity - streets[len(streets)-1].Equity
+		}
+		streets = append(streets, street)
+		if len(opponents) == 0 {
+			break
+		}
+	}
+	return streets, nil
+}
+
+// liveHands returns the player's hole cards and those of the opponents who
+// have not folded, false while the player holds no cards or has folded
+func liveHands(view holdem.TableView, playerID int) (poker.Cards, []poker.Cards, bool) {
+	var hero poker.Cards
+	opponents := []poker.Cards{}
+	for _, seat := range view.Seats {
+		switch {
+		case seat.PlayerID == playerID:
+			if seat.Folded || len(seat.HoleCards) == 0 {
+				return nil, nil, false
+			}
+			hero = seat.HoleCards
+		case !seat.Folded && len(seat.HoleCards) > 0:
+			opponents = append(opponents, seat.HoleCards)
+		}
+	}
+	return hero, opponents, hero != nil
+}
@@ -0,0 +1,76 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func mustCards(t *testing.T, s string) poker.Cards {
+	t.Helper()
+	cards, err := poker.ParseCards(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cards
+}
+
+func TestEquityByStreet(t *testing.T) {
+	aces, kings := mustCards(t, "AsAd"), mustCards(t, "KsKd")
+	view := func(phase holdem.GamePhase, board string, heroFolded bool) holdem.TableView {
+		return holdem.TableView{
+			Phase: phase,
+			Board: mustCards(t, board),
+			Seats: []holdem.SeatView{
+				{Seat: 0, PlayerID: 1, HoleCards: aces, Folded: heroFolded},
+				{Seat: 1, PlayerID: 2, HoleCards: kings},
+			},
+		}
+	}
+	views := []holdem.TableView{
+		{Phase: holdem.PhasePreflop, Seats: []holdem.SeatView{{PlayerID: 1}, {PlayerID: 2}}}, // Before the deal
+		view(holdem.PhasePreflop, "", false),
+		view(holdem.PhasePreflop, "", false),
+		view(holdem.PhaseFlop, "Kc 2h 3d", false),
+		view(holdem.PhaseTurn, "Kc 2h 3d 7c", false),
+		view(holdem.PhaseTurn, "Kc 2h 3d 7c", true),
+		view(holdem.PhaseRiver, "Kc 2h 3d 7c 9s", true),
+	}
+
+	streets, err := EquityByStreet(views, 1, equity.Options{Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(streets) != 3 {
+		t.Fatalf("Expected preflop, flop and turn before the fold, got %+v", streets)
+	}
+	if streets[0].Equity < 0.75 || streets[0].IsBigSwing() {
+		t.Errorf("Expected aces to be big favourites preflop, got %+v", streets[0])
+	}
+	if streets[1].Phase != holdem.PhaseFlop || streets[1].Equity > 0.15 || !streets[1].IsBigSwing() {
+		t.Errorf("Expected the king on the flop to be a big swing, got %+v", streets[1])
+	}
+	if streets[2].Equity > 0.05 {
+		t.Errorf("Expected aces to be nearly dead on the turn, got %+v", streets[2])
+	}
+}
+
+func TestEquityByStreetEndsWhenOpponentsFold(t *testing.T) {
+	hole := mustCards(t, "7h2c")
+	views := []holdem.TableView{{
+		Phase: holdem.PhasePreflop,
+		Seats: []holdem.SeatView{
+			{PlayerID: 1, HoleCards: hole},
+			{PlayerID: 2, HoleCards: mustCards(t, "AhAc"), Folded: true},
+		},
+	}}
+	streets, err := EquityByStreet(views, 1, equity.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(streets) != 1 || streets[0].Equity != 1 {
+		t.Errorf("Expected the whole pot with nobody left, got %+v", streets)
+	}
+}
@@ -0,0 +1,162 @@
+package analysis
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// strengthRows are the heat map's rows, by the rank of the best hand at
+// showdown; the strongest ranks share the last row
+var strengthRows = []string{"High card", "One pair", "Two pair", "Three of a kind", "Straight", "Flush", "Full house+"}
+
+// potBounds are the upper bounds, in big blinds, of the heat map's pot size
+// columns; a last column holds the pots above them
+var potBounds = []int{10, 25, 50, 100}
+
+// Which cells stand out: weak hands taken to showdown in big pots, and
+// strong ones that won small pots
+const (
+	weakRows     = 2 // High card and one pair
+	strongRow    = 4 // Straight or better
+	bigPotColumn = 3 // 50 big blinds or more
+)
+
+// HeatCell is what a player's showdowns of one strength in pots of one
+// size came to
+type HeatCell struct {
+	Showdowns int
+	Net       int // Chips won or lost in those hands
+}
+
+// StrengthHeatMap counts a player's showdowns by the strength of their hand
+// against the size of the final pot. A player who plays their hands well
+// builds big pots with strong hands and keeps them small with weak ones.
+type StrengthHeatMap struct {
+	Player    string
+	Cells     [][]HeatCell // By strength row, then pot size column
+	Showdowns int
+}
+
+// HeatMap maps the showdowns of a player in Hold'em hands where their hole
+// cards and the whole board are known
+func HeatMap(hands []*handhistory.Hand, player string) *StrengthHeatMap {
+	m := &StrengthHeatMap{Player: player, Cells: make([][]HeatCell, len(strengthRows))}
+	for i := range m.Cells {
+		m.Cells[i] = make([]HeatCell, len(potBounds)+1)
+	}
+	evaluator := holdem.NewHandEvaluator()
+	for _, hand := range hands {
+		if hand.Variant != handhistory.VariantNLHE && hand.Variant != handhistory.VariantFLHE {
+			continue
+		}
+		seat := hand.GetSeat(player)
+		if seat == nil || len(seat.HoleCards) != 2 || len(hand.Board) != 5 || hand.BigBlind <= 0 || !hand.ReachedShowdown(player) {
+			continue
+		}
+		row := min(int(evaluator.EvaluateHand(seat.HoleCards, hand.Board).Rank), len(strengthRows)-1)
+		pot := 0
+		for _, other := range hand.Seats {
+			pot += hand.Contributed(other.Name)
+		}
+		column := len(potBounds)
+		for i, bound := range potBounds {
+			if pot < bound*hand.BigBlind {
+				column = i
+				break
+			}
+		}
+		cell := &m.Cells[row][column]
+		cell.Showdowns++
+		cell.Net += hand.Net(player)
+		m.Showdowns++
+	}
+	return m
+}
+
+// Overplayed sums the showdowns with high card or one pair in pots of 50
+// big blinds or more
+func (m *StrengthHeatMap) Overplayed() HeatCell {
+	total := HeatCell{}
+	for row := 0; row < weakRows; row++ {
+		for column := bigPotColumn; column < len(m.Cells[row]); column++ {
+			total.Showdowns += m.Cells[row][column].Showdowns
+			total.Net += m.Cells[row][column].Net
+		}
+	}
+	return total
+}
+
+// Underplayed sums the showdowns with a straight or better in pots under
+// 10 big blinds
+func (m *StrengthHeatMap) Underplayed() HeatCell {
+	total := HeatCell{}
+	for row := strongRow; row < len(m.Cells); row++ {
+		total.Showdowns += m.Cells[row][0].Showdowns
+		total.Net += m.Cells[row][0].Net
+	}
+	return total
+}
+
+// flagged reports whether a cell counts towards Overplayed or Underplayed
+func flagged(row, column int) bool {
+	return (row < weakRows && column >= bigPotColumn) || (row >= strongRow && column == 0)
+}
+
+// heatShades shade a cell by its share of the busiest cell's showdowns
+var heatShades = []string{"·", "░", "▒", "▓", "█"}
+
+// Write prints the heat map, each cell shaded by how often it came up and
+// the cells that stand out marked with "!"
+func (m *StrengthHeatMap) Write(w io.Writer) error {
+	fmt.Fprintf(w, "Showdown strength by pot size for %s (%d showdowns):\n", m.Player, m.Showdowns)
+	if m.Showdowns == 0 {
+		_, err := fmt.Fprintln(w, "No showdowns with known hole cards and a full board.")
+		return err
+	}
+	busiest := 0
+	for _, row := range m.Cells {
+		for _, cell := range row {
+			busiest = max(busiest, cell.Showdowns)
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	columns := []string{fmt.Sprintf("<%dbb", potBounds[0])}
+	for i := 1; i < len(potBounds); i++ {
+		columns = append(columns, fmt.Sprintf("%d-%dbb", potBounds[i-1], potBounds[i]))
+	}
+	columns = append(columns, fmt.Sprintf("%dbb+", potBounds[len(potBounds)-1]))
+	fmt.Fprintln(tw, "Hand\t"+strings.Join(columns, "\t"))
+	for row, name := range strengthRows {
+		cells := make([]string, len(m.Cells[row]))
+		for column, cell := range m.Cells[row] {
+			shade := heatShades[0]
+			if cell.Showdowns > 0 {
+				shade = heatShades[1+cell.Showdowns*(len(heatShades)-2)/busiest]
+			}
+			cells[column] = fmt.Sprintf("%s %d", strings.Repeat(shade, 2), cell.Showdowns)
+			if cell.Showdowns > 0 && flagged(row, column) {
+				cells[column] += "!"
+			}
+		}
+		fmt.Fprintln(tw, name+"\t"+strings.Join(cells, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if over := m.Overplayed(); over.Showdowns > 0 {
+		fmt.Fprintf(w, "! Overplayed: %d showdowns with high card or one pair in pots of %dbb or more, net %d\n",
+			over.Showdowns, potBounds[bigPotColumn-1], over.Net)
+	}
+	if under := m.Underplayed(); under.Showdowns > 0 {
+		fmt.Fprintf(w, "! Underplayed: %d showdowns with a straight or better in pots under %dbb, net %d\n",
+			under.Showdowns, potBounds[0], under.Net)
+	}
+	return nil
+}
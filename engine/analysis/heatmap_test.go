@@ -0,0 +1,80 @@
+package analysis
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+)
+
+// Alice builds a 60 big blind pot with one pair of aces
+const bigPair = `variant = "NT"
+hand = 3
+starting_stacks = [200, 200]
+blinds_or_straddles = [1, 2]
+players = ["Alice", "Bob"]
+actions = ["d dh p1 AsAd", "d dh p2 KsKd", "p1 cbr 60", "p2 cc",
+  "d db 2c3c4d", "p2 cc", "p1 cc", "d db 9h", "p2 cc", "p1 cc",
+  "d db Jh", "p2 cc", "p1 cc"]
+`
+
+// Alice checks a straight down in a limped pot
+const smallStraight = `variant = "NT"
+hand = 4
+starting_stacks = [200, 200]
+blinds_or_straddles = [1, 2]
+players = ["Alice", "Bob"]
+actions = ["d dh p1 5s6s", "d dh p2 KsKd", "p1 cc", "p2 cc",
+  "d db 2c3c4d", "p2 cc", "p1 cc", "d db 9h", "p2 cc", "p1 cc",
+  "d db Jh", "p2 cc", "p1 cc"]
+`
+
+func TestHeatMapFlagsOverAndUnderplayedHands(t *testing.T) {
+	hands := parseHands(t, bigPair, smallStraight, badFold)
+	m := HeatMap(hands, "Alice")
+	if m.Showdowns != 2 {
+		t.Fatalf("Expected two showdowns, the folded hand left out, got %d", m.Showdowns)
+	}
+	if cell := m.Cells[1][3]; cell.Showdowns != 1 || cell.Net != 60 {
+		t.Errorf("Expected one pair in a 60bb pot winning 60, got %+v", cell)
+	}
+	if cell := m.Cells[4][0]; cell.Showdowns != 1 || cell.Net != 2 {
+		t.Errorf("Expected a straight in a 2bb pot winning 2, got %+v", cell)
+	}
+	if over, under := m.Overplayed(), m.Underplayed(); over.Showdowns != 1 || under.Showdowns != 1 {
+		t.Errorf("Expected one overplayed and one underplayed hand, got %+v and %+v", over, under)
+	}
+
+	var out bytes.Buffer
+	if err := m.Write(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"for Alice (2 showdowns)", "50-100bb", "One pair", "██ 1!", "Overplayed: 1 showdowns", "Underplayed: 1 showdowns with a straight or better in pots under 10bb, net 2"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the heat map to contain %q:\n%s", want, out.String())
+		}
+	}
+
+	if m := HeatMap(hands, "Carol"); m.Showdowns != 0 {
+		t.Errorf("Expected no showdowns for a player not in the hands, got %d", m.Showdowns)
+	}
+}
+
+func TestReportWritesHeatMapForAPlayer(t *testing.T) {
+	hands := parseHands(t, bigPair)
+	var out bytes.Buffer
+	if err := Analyze(hands, "", equity.Options{Seed: 1, Samples: 100}).Write(&out, 5); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "Showdown strength") {
+		t.Errorf("Expected no heat map without a player:\n%s", out.String())
+	}
+	out.Reset()
+	if err := Analyze(hands, "Bob", equity.Options{Seed: 1, Samples: 100}).Write(&out, 5); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Showdown strength by pot size for Bob (1 showdowns)") {
+		t.Errorf("Expected Bob's heat map in the report:\n%s", out.String())
+	}
+}
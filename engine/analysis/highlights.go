@@ -0,0 +1,262 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// HighlightKind is what makes a hand stand out in a session
+type HighlightKind int
+
+const (
+	HighlightBiggestPot HighlightKind = iota
+	HighlightWorstBeat                // The showdown lost from the best odds
+	HighlightBestBluff                // The most chips won by a bet made behind
+	HighlightBadBeat                  // A pot lost all in as a big favourite, see TagBeats
+	HighlightCooler                   // A pot lost all in with a hand too strong to fold, see TagBeats
+)
+
+func (k HighlightKind) String() string {
+	switch k {
+	case HighlightBiggestPot:
+		return "Biggest pot"
+	case HighlightWorstBeat:
+		return "Worst beat"
+	case HighlightBestBluff:
+		return "Best bluff"
+	case HighlightBadBeat:
+		return "Bad beat"
+	case HighlightCooler:
+		return "Cooler"
+	default:
+		return "Unknown"
+	}
+}
+
+// Highlight is a notable hand of a session
+type Highlight struct {
+	Kind   HighlightKind
+	Hand   int // Index of the hand in those searched
+	HandID string
+	Player string           // Who won the biggest pot or the bluff, or took the beat
+	Phase  holdem.GamePhase // Street of the beat's best odds or of the bluff
+	Pot    int
+	Equity float64 // The beaten player's best equity, or the bluffer's when the opponents folded
+
+	// The cooled player's hand, e.g. "QQ" preflop or "Three of a Kind"
+	Holding string
+}
+
+// String describes the highlight in a line, e.g. "Worst beat: Bob lost a
+// pot of 800 with 91% on the turn"
+func (h Highlight) String() string {
+	switch h.Kind {
+	case HighlightWorstBeat:
+		return fmt.Sprintf("%s: %s lost a pot of %d with %.0f%% on the %s", h.Kind, h.Player, h.Pot, h.Equity*100, holdem.PhaseToString(h.Phase))
+	case HighlightBestBluff:
+		return fmt.Sprintf("%s: %s took down %d on the %s with %.0f%% equity", h.Kind, h.Player, h.Pot, holdem.PhaseToString(h.Phase), h.Equity*100)
+	case HighlightBadBeat:
+		return fmt.Sprintf("%s: %s lost a pot of %d all in on the %s with %.0f%%", h.Kind, h.Player, h.Pot, holdem.PhaseToString(h.Phase), h.Equity*100)
+	case HighlightCooler:
+		return fmt.Sprintf("%s: %s lost a pot of %d all in on the %s with %s", h.Kind, h.Player, h.Pot, holdem.PhaseToString(h.Phase), h.Holding)
+	default:
+		return fmt.Sprintf("%s: %s won a pot of %d", h.Kind, h.Player, h.Pot)
+	}
+}
+
+// Highlights picks the hands of a session worth another look: the biggest
+// pot, the worst beat, the showdown lost by the player whose equity was
+// highest on an earlier street, and the best bluff, the pot won by a bet
+// or raise everyone folded to that would have won least often at showdown.
+// Every bad beat and cooler TagBeats finds follows, in the order played,
+// but for the hand already picked as the worst beat. Equities are worked
+// out against the cards the opponents held, so beats and bluffs are only
+// found in Hold'em hands where they are all known, such as the engine's
+// own. A kind with no hand that qualifies is left out.
+func Highlights(hands []*handhistory.Hand, opts equity.Options) ([]Highlight, error) {
+	var biggest, beat, bluff *Highlight
+	beatSwing, bluffRealized := 0.0, 0.0
+	for i, hand := range hands {
+		pot := potSize(hand)
+		if winner := biggestWinner(hand); winner != "" && (biggest == nil || pot > biggest.Pot) {
+			biggest = &Highlight{Kind: HighlightBiggestPot, Hand: i, HandID: hand.ID, Player: winner, Pot: pot}
+		}
+		if hand.Variant != handhistory.VariantNLHE && hand.Variant != handhistory.VariantFLHE {
+			continue
+		}
+
+		found, err := worstBeat(hand, opts)
+		if err != nil {
+			return nil, fmt.Errorf("hand %s: %w", hand.ID, err)
+		}
+		if found != nil && (found.Equity > beatSwing || (found.Equity == beatSwing && pot > beat.Pot)) {
+			found.Hand, found.Pot = i, pot
+			beat, beatSwing = found, found.Equity
+		}
+
+		found, err = bestBluff(hand, opts)
+		if err != nil {
+			return nil, fmt.Errorf("hand %s: %w", hand.ID, err)
+		}
+		if found != nil {
+			if realized := float64(found.Pot) * (1 - found.Equity); realized > bluffRealized {
+				found.Hand = i
+				bluff, bluffRealized = found, realized
+			}
+		}
+	}
+
+	highlights := []Highlight{}
+	for _, h := range []*Highlight{biggest, beat, bluff} {
+		if h != nil {
+			highlights = append(highlights, *h)
+		}
+	}
+	tagged, err := TagBeats(hands, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range tagged {
+		if beat == nil || h.Hand != beat.Hand {
+			highlights = append(highlights, h)
+		}
+	}
+	return highlights, nil
+}
+
+// potSize returns the chips put into the pot, net of uncalled bets
+func potSize(hand *handhistory.Hand) int {
+	pot := 0
+	for _, seat := range hand.Seats {
+		pot += hand.Contributed(seat.Name)
+	}
+	return pot
+}
+
+// biggestWinner returns the player who collected most of the pot, "" when
+// the history does not say
+func biggestWinner(hand *handhistory.Hand) string {
+	winner, most := "", 0
+	for _, seat := range hand.Seats {
+		if collected := hand.Collected[seat.Name]; collected > most {
+			winner, most = seat.Name, collected
+		}
+	}
+	return winner
+}
+
+// beatStreets are the streets a beaten player's equity is measured on, by
+// the board cards dealt; the river decides the hand
+var beatStreets = []struct {
+	phase holdem.GamePhase
+	board int
+}{{holdem.PhasePreflop, 0}, {holdem.PhaseFlop, 3}, {holdem.PhaseTurn, 4}}
+
+// worstBeat finds the showdown loser whose equity was highest on a street
+// before the river, nil when no loser was the favourite
+func worstBeat(hand *handhistory.Hand, opts equity.Options) (*Highlight, error) {
+	if len(hand.Board) != 5 {
+		return nil, nil
+	}
+	var beat *Highlight
+	for _, street := range beatStreets {
+		before := 0
+		for before < len(hand.Actions) && hand.Actions[before].Phase < street.phase {
+			before++
+		}
+		names, holes, ok := liveAfter(hand, hand.Actions[:before])
+		if !ok || len(names) < 2 {
+			continue
+		}
+		result, err := equity.Calculate(holes, hand.Board[:street.board], opts)
+		if err != nil {
+			return nil, err
+		}
+		for i, name := range names {
+			if hand.Collected[name] > 0 || !hand.ReachedShowdown(name) {
+				continue
+			}
+			if result.Equity[i] > 0.5 && (beat == nil || result.Equity[i] > beat.Equity) {
+				beat = &Highlight{Kind: HighlightWorstBeat, HandID: hand.ID, Player: name, Phase: street.phase, Equity: result.Equity[i]}
+			}
+		}
+	}
+	return beat, nil
+}
+
+// bestBluff finds the bet or raise that won a hand without a showdown when
+// the bettor was behind the hands that folded to it, nil when there is none
+func bestBluff(hand *handhistory.Hand, opts equity.Options) (*Highlight, error) {
+	winner := ""
+	for _, seat := range hand.Seats {
+		if !hand.Folded(seat.Name) {
+			if winner != "" {
+				return nil, nil // Went to showdown
+			}
+			winner = seat.Name
+		}
+	}
+	if winner == "" {
+		return nil, nil
+	}
+	last := -1
+	for i, action := range hand.Actions {
+		switch {
+		case action.Player == winner && (action.Type == handhistory.ActionBet || action.Type == handhistory.ActionRaise):
+			last = i
+		case action.Player != winner && (action.Type == handhistory.ActionCall || action.Type == handhistory.ActionBet || action.Type == handhistory.ActionRaise):
+			last = -1 // Someone played on after the bet
+		}
+	}
+	if last < 0 {
+		return nil, nil
+	}
+
+	bet := hand.Actions[last]
+	names, holes, ok := liveAfter(hand, hand.Actions[:last])
+	if !ok || len(names) < 2 {
+		return nil, nil
+	}
+	result, err := equity.Calculate(holes, hand.BoardAt(bet.Phase), opts)
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range names {
+		if name == winner && result.Equity[i] < 0.5 {
+			return &Highlight{
+				Kind: HighlightBestBluff, HandID: hand.ID, Player: winner, Phase: bet.Phase,
+				Pot: hand.Collected[winner], Equity: result.Equity[i],
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// liveAfter returns the players who had not folded in the actions, the
+// start of the hand's, with their hole cards; false when one of them holds
+// cards the history does not show
+func liveAfter(hand *handhistory.Hand, actions []handhistory.Action) ([]string, []poker.Cards, bool) {
+	folded := map[string]bool{}
+	for _, action := range actions {
+		if action.Type == handhistory.ActionFold {
+			folded[action.Player] = true
+		}
+	}
+	names := []string{}
+	holes := []poker.Cards{}
+	for _, seat := range hand.Seats {
+		if folded[seat.Name] {
+			continue
+		}
+		if len(seat.HoleCards) != 2 {
+			return nil, nil, false
+		}
+		names = append(names, seat.Name)
+		holes = append(holes, seat.HoleCards)
+	}
+	return names, holes, true
+}
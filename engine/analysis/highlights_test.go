@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// Bob's kings catch a king against Alice's aces all in preflop
+const cooler = `variant = "NT"
+hand = 3
+starting_stacks = [100, 100]
+blinds_or_straddles = [1, 2]
+players = ["Alice", "Bob"]
+actions = ["d dh p1 AsAd", "d dh p2 KsKd", "p1 cbr 100", "p2 cc",
+  "d db 2c7h9d", "d db Kc", "d db 4s"]
+`
+
+// Alice raises seven-deuce and takes the pot on the flop from ace-king
+const flopBluff = `variant = "NT"
+hand = 4
+starting_stacks = [100, 100]
+blinds_or_straddles = [1, 2]
+players = ["Alice", "Bob"]
+actions = ["d dh p1 7c2d", "d dh p2 AhKh", "p1 cbr 6", "p2 cc",
+  "d db Qs9s3d", "p2 cc", "p1 cbr 4", "p2 f"]
+`
+
+func TestHighlightsPickNotableHands(t *testing.T) {
+	hands := parseHands(t, badFold, cooler, flopBluff, badCall)
+	highlights, err := Highlights(hands, equity.Options{Seed: 1, Samples: 2000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(highlights) != 3 {
+		t.Fatalf("Expected the three kinds of highlight, got %+v", highlights)
+	}
+
+	biggest, beat, bluff := highlights[0], highlights[1], highlights[2]
+	if biggest.Kind != HighlightBiggestPot || biggest.Hand != 1 || biggest.Player != "Bob" || biggest.Pot != 200 {
+		t.Errorf("Expected the all-in as the biggest pot, got %+v", biggest)
+	}
+	if beat.Kind != HighlightWorstBeat || beat.Hand != 1 || beat.Player != "Alice" || beat.Phase != holdem.PhaseFlop || beat.Equity < 0.85 {
+		t.Errorf("Expected Alice's aces beaten from the flop, got %+v", beat)
+	}
+	if bluff.Kind != HighlightBestBluff || bluff.Hand != 2 || bluff.Player != "Alice" || bluff.Phase != holdem.PhaseFlop || bluff.Equity > 0.3 {
+		t.Errorf("Expected Alice's flop bluff, got %+v", bluff)
+	}
+	if text := beat.String(); !strings.HasPrefix(text, "Worst beat: Alice lost a pot of 200 with") || !strings.HasSuffix(text, "on the flop") {
+		t.Errorf("Expected the beat described, got %q", text)
+	}
+}
+
+func TestHighlightsSkipWhatDidNotHappen(t *testing.T) {
+	// Bob's dead call is a loss, not a beat, and no bet took a pot uncalled
+	highlights, err := Highlights(parseHands(t, badCall), equity.Options{Seed: 1, Samples: 500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(highlights) != 1 || highlights[0].Kind != HighlightBiggestPot {
+		t.Errorf("Expected only the biggest pot, got %+v", highlights)
+	}
+	if highlights, _ := Highlights(nil, equity.Options{}); len(highlights) != 0 {
+		t.Errorf("Expected nothing from no hands, got %+v", highlights)
+	}
+}
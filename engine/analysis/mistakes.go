@@ -0,0 +1,131 @@
+package analysis
+
+import (
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/pokermath"
+)
+
+// Mistake is a call or fold that lost expected value against the hands the
+// opponents turned out to hold
+type Mistake struct {
+	HandID  string
+	Player  string
+	Phase   holdem.GamePhase
+	Action  handhistory.ActionType
+	ToCall  int     // Chips needed to continue
+	Pot     int     // Pot before the decision, including the bet faced
+	Equity  float64 // Share of the pot the player would win by calling
+	EVLoss  float64 // Chips given up compared with the better option
+	Opposed int     // Number of opponents still in the hand
+}
+
+// PotOdds returns the equity needed to break even on a call
+func (m Mistake) PotOdds() float64 {
+	return pokermath.PotOdds(m.Pot, m.ToCall)
+}
+
+// FindMistakes walks a Hold'em hand and flags calls and folds whose EV was
+// negative given the opponents' revealed cards. Decisions are judged as if
+// the remaining board were run out with no further betting, so only spots
+// where every live opponent's cards are known can be judged.
+func FindMistakes(hand *handhistory.Hand, opts equity.Options) []Mistake {
+	if hand.Variant != handhistory.VariantNLHE && hand.Variant != handhistory.VariantFLHE {
+		return nil
+	}
+
+	pot := 0
+	street := map[string]int{}
+	invested := map[string]int{}
+	folded := map[string]bool{}
+	phase := holdem.PhasePreflop
+	mistakes := []Mistake{}
+
+	for _, action := range hand.Actions {
+		if action.Phase != phase {
+			phase = action.Phase
+			street = map[string]int{}
+		}
+
+		if action.Type == handhistory.ActionCall || action.Type == handhistory.ActionFold {
+			if m, ok := judge(hand, action, pot, street, invested, folded, opts); ok {
+				mistakes = append(mistakes, m)
+			}
+		}
+
+		pot += action.Amount
+		invested[action.Player] += action.Amount
+		if action.Type != handhistory.ActionPostAnte {
+			street[action.Player] += action.Amount
+		}
+		if action.Type == handhistory.ActionFold {
+			folded[action.Player] = true
+		}
+	}
+	return mistakes
+}
+
+// judge evaluates one call or fold decision
+func judge(hand *handhistory.Hand, action handhistory.Action, pot int, street, invested map[string]int, folded map[string]bool, opts equity.Options) (Mistake, bool) {
+	seat := hand.GetSeat(action.Player)
+	if seat == nil || len(seat.HoleCards) != 2 {
+		return Mistake{}, false
+	}
+
+	highest := 0
+	for _, bet := range street {
+		highest = max(highest, bet)
+	}
+	toCall := min(highest-street[action.Player], seat.Stack-invested[action.Player])
+	if action.Type == handhistory.ActionCall {
+		toCall = action.Amount
+	}
+	if toCall <= 0 {
+		return Mistake{}, false
+	}
+
+	holes := []poker.Cards{seat.HoleCards}
+	for _, other := range hand.Seats {
+		if other.Name == action.Player || folded[other.Name] {
+			continue
+		}
+		if len(other.HoleCards) != 2 {
+			return Mistake{}, false
+		}
+		holes = append(holes, other.HoleCards)
+	}
+	if len(holes) < 2 {
+		return Mistake{}, false
+	}
+
+	result, err := equity.Calculate(holes, hand.BoardAt(action.Phase), opts)
+	if err != nil {
+		return Mistake{}, false
+	}
+
+	share := result.Equity[0]
+	evCall := pokermath.CallEV(share, pot, toCall)
+	loss := 0.0
+	switch {
+	case action.Type == handhistory.ActionCall && evCall < 0:
+		loss = -evCall
+	case action.Type == handhistory.ActionFold && evCall > 0:
+		loss = evCall
+	default:
+		return Mistake{}, false
+	}
+
+	return Mistake{
+		HandID:  hand.ID,
+		Player:  action.Player,
+		Phase:   action.Phase,
+		Action:  action.Type,
+		ToCall:  toCall,
+		Pot:     pot,
+		Equity:  share,
+		EVLoss:  loss,
+		Opposed: len(holes) - 1,
+	}, true
+}
@@ -0,0 +1,140 @@
+package analysis
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/stats"
+)
+
+// Report is the result of analysing a set of hands
+type Report struct {
+	Session  *stats.Session
+	Mistakes []Mistake        // Biggest EV loss first
+	Skipped  int              // Hands that could not be judged for mistakes
+	Beats    []Highlight      // Bad beats and coolers, in the order played
+	HeatMap  *StrengthHeatMap // The player's showdowns, nil when no player was given
+}
+
+// Analyze computes session statistics, EV mistakes and bad beats and
+// coolers for the given hands. When player is not empty only that player's
+// decisions are judged, and their showdowns are mapped by hand strength
+// against pot size.
+func Analyze(hands []*handhistory.Hand, player string, opts equity.Options) *Report {
+	report := &Report{
+		Session:  stats.Compute(hands),
+		Mistakes: []Mistake{},
+	}
+	for _, hand := range hands {
+		if hand.Variant != handhistory.VariantNLHE && hand.Variant != handhistory.VariantFLHE {
+			report.Skipped++
+			continue
+		}
+		for _, m := range FindMistakes(hand, opts) {
+			if player == "" || m.Player == player {
+				report.Mistakes = append(report.Mistakes, m)
+			}
+		}
+	}
+	if beats, err := TagBeats(hands, opts); err == nil {
+		report.Beats = beats
+		CountBeats(report.Session, beats)
+	}
+	if player != "" {
+		report.HeatMap = HeatMap(hands, player)
+	}
+	sort.SliceStable(report.Mistakes, func(i, j int) bool {
+		return report.Mistakes[i].EVLoss > report.Mistakes[j].EVLoss
+	})
+	return report
+}
+
+// Write prints the session table, the bad beats and coolers, the player's
+// showdown heat map when there is one, then the top biggest mistakes
+func (r *Report) Write(w io.Writer, top int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Hands analysed: %d\n", r.Session.Hands)
+	if r.Session.Rake > 0 {
+		fmt.Fprintf(tw, "Rake: %d from %d hands\n", r.Session.Rake, r.Session.RakedHands)
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Player\tHands\tVPIP\tPFR\tWTSD\tW$SD\tCold call\tSqueeze\tDonk\tX/R\tNet\tBB/100")
+	for _, p := range r.Session.Sorted() {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f%%\t%d\t%.2f\n",
+			p.Name, p.Hands, p.VPIP()*100, p.PFR()*100, p.WTSD()*100, p.WSD()*100,
+			p.ColdCall()*100, p.Squeeze()*100, p.DonkBet()*100, p.CheckRaise()*100, p.Net, p.BBPer100())
+	}
+
+	if r.Session.HasTiming() {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "Decision timing:")
+		fmt.Fprintln(tw, "Player\tDecisions\tAverage\tCheck/call\tBet/raise\tFold\tSnap\tTank")
+		for _, p := range r.Session.Sorted() {
+			timing := &p.Timing
+			if timing.Decisions == 0 {
+				continue
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%.1f%%\t%.1f%%\n",
+				p.Name, timing.Decisions, seconds(timing.Average()),
+				seconds(timing.AverageFor(handhistory.ActionCheck, handhistory.ActionCall)),
+				seconds(timing.AverageFor(handhistory.ActionBet, handhistory.ActionRaise)),
+				seconds(timing.AverageFor(handhistory.ActionFold)),
+				timing.SnapRate()*100, timing.TankRate()*100)
+		}
+	}
+
+	if len(r.Beats) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "Bad beats and coolers:")
+		fmt.Fprintln(tw, "Player\tBad beats\tCoolers")
+		for _, p := range r.Session.Sorted() {
+			if p.BadBeats+p.Coolers > 0 {
+				fmt.Fprintf(tw, "%s\t%d\t%d\n", p.Name, p.BadBeats, p.Coolers)
+			}
+		}
+		for _, beat := range r.Beats {
+			fmt.Fprintf(tw, "%s\t%s\n", beat.HandID, beat)
+		}
+	}
+
+	if r.HeatMap != nil {
+		fmt.Fprintln(tw)
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if err := r.HeatMap.Write(w); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(tw)
+	if len(r.Mistakes) == 0 {
+		fmt.Fprintln(tw, "No EV mistakes found in hands with revealed cards.")
+		return tw.Flush()
+	}
+	fmt.Fprintln(tw, "Biggest EV mistakes:")
+	fmt.Fprintln(tw, "Hand\tPlayer\tStreet\tAction\tTo call\tPot\tEquity\tNeeded\tEV lost")
+	for i, m := range r.Mistakes {
+		if top > 0 && i >= top {
+			break
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\t%.1f%%\t%.1f%%\t%.1f\n",
+			m.HandID, m.Player, holdem.PhaseToString(m.Phase), handhistory.ActionTypeToString(m.Action),
+			m.ToCall, m.Pot, m.Equity*100, m.PotOdds()*100, m.EVLoss)
+	}
+	return tw.Flush()
+}
+
+// seconds formats a decision time, "-" when there is none
+func seconds(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
@@ -0,0 +1,119 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/pokermath"
+)
+
+// Spot is a decision a player faced, worked out from the table as it stood:
+// the player's equity against random hands, which is what they could know,
+// and against the cards the opponents held when every one of them is known
+type Spot struct {
+	PlayerID    int
+	Phase       holdem.GamePhase
+	Pot         int     // Pot before the decision, including the bet faced
+	ToCall      int     // Chips needed to continue, 0 when the player could check
+	Opposed     int     // Number of opponents still in the hand
+	Equity      float64 // Share of the pot against random hands
+	Known       bool    // Every opponent's cards were seen, so KnownEquity is set
+	KnownEquity float64 // Share of the pot against the opponents' cards
+}
+
+// PotOdds returns the equity needed to break even on a call
+func (s Spot) PotOdds() float64 {
+	return pokermath.PotOdds(s.Pot, s.ToCall)
+}
+
+// CallEV returns the chips calling was worth relative to folding against
+// random hands
+func (s Spot) CallEV() float64 {
+	return pokermath.CallEV(s.Equity, s.Pot, s.ToCall)
+}
+
+// KnownCallEV returns the chips calling was worth relative to folding
+// against the opponents' cards, 0 when they are not known
+func (s Spot) KnownCallEV() float64 {
+	if !s.Known {
+		return 0
+	}
+	return pokermath.CallEV(s.KnownEquity, s.Pot, s.ToCall)
+}
+
+// String describes the spot, e.g. "On the flop, 40 to call into a pot of
+// 100, pot odds 29%. Equity 41% vs random hands, EV of calling +17.4; 35% vs
+// their cards, EV +9.0"
+func (s Spot) String() string {
+	text := fmt.Sprintf("On the %s, checked to with a pot of %d. Equity %.0f%% vs random hands",
+		holdem.PhaseToString(s.Phase), s.Pot, s.Equity*100)
+	if s.ToCall > 0 {
+		text = fmt.Sprintf("On the %s, %d to call into a pot of %d, pot odds %.0f%%. Equity %.0f%% vs random hands, EV of calling %+.1f",
+			holdem.PhaseToString(s.Phase), s.ToCall, s.Pot, s.PotOdds()*100, s.Equity*100, s.CallEV())
+	}
+	if !s.Known {
+		return text
+	}
+	text += fmt.Sprintf("; %.0f%% vs their cards", s.KnownEquity*100)
+	if s.ToCall > 0 {
+		text += fmt.Sprintf(", EV %+.1f", s.KnownCallEV())
+	}
+	return text
+}
+
+// AnalyzeSpot works out the decision a player faces in a view of the table
+// taken just before they act. Views showing every player's cards, such as
+// the commentator's, also give the equity against the opponents' cards.
+func AnalyzeSpot(view holdem.TableView, playerID int, opts equity.Options) (Spot, error) {
+	var hero *holdem.SeatView
+	for i := range view.Seats {
+		if view.Seats[i].PlayerID == playerID {
+			hero = &view.Seats[i]
+		}
+	}
+	if hero == nil {
+		return Spot{}, fmt.Errorf("player %d is not at the table", playerID)
+	}
+	if hero.Folded || len(hero.HoleCards) == 0 {
+		return Spot{}, fmt.Errorf("player %d holds no cards", playerID)
+	}
+
+	spot := Spot{
+		PlayerID: playerID,
+		Phase:    view.Phase,
+		Pot:      view.Pot,
+		ToCall:   max(min(view.CurrentBet-hero.Bet, hero.Chips), 0),
+	}
+	holes := []poker.Cards{hero.HoleCards}
+	known := true
+	for _, seat := range view.Seats {
+		if seat.PlayerID == playerID || seat.Folded || (len(seat.HoleCards) == 0 && !seat.CardsHidden) {
+			continue
+		}
+		spot.Opposed++
+		if len(seat.HoleCards) != len(hero.HoleCards) {
+			known = false
+			continue
+		}
+		holes = append(holes, seat.HoleCards)
+	}
+	if spot.Opposed == 0 {
+		return Spot{}, fmt.Errorf("player %d has no opponent left", playerID)
+	}
+
+	share, err := equity.CalculateVsRandom(hero.HoleCards, view.Board, spot.Opposed, opts)
+	if err != nil {
+		return Spot{}, err
+	}
+	spot.Equity = share
+	if known {
+		result, err := equity.Calculate(holes, view.Board, opts)
+		if err != nil {
+			return Spot{}, err
+		}
+		spot.Known, spot.KnownEquity = true, result.Equity[0]
+	}
+	return spot, nil
+}
@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestAnalyzeSpot(t *testing.T) {
+	// The hero's aces face a 40 chip bet into 60 from a set of kings
+	view := holdem.TableView{
+		Phase:      holdem.PhaseFlop,
+		Board:      mustCards(t, "Kc 2h 3d"),
+		Pot:        100,
+		CurrentBet: 40,
+		Seats: []holdem.SeatView{
+			{Seat: 0, PlayerID: 1, HoleCards: mustCards(t, "AsAd"), Chips: 500},
+			{Seat: 1, PlayerID: 2, HoleCards: mustCards(t, "KsKd"), Chips: 460, Bet: 40},
+			{Seat: 2, PlayerID: 3, HoleCards: mustCards(t, "7c8c"), Folded: true},
+		},
+	}
+	opts := equity.Options{Seed: 1, Samples: 2000}
+	spot, err := AnalyzeSpot(view, 1, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spot.ToCall != 40 || spot.Pot != 100 || spot.Opposed != 1 || spot.Phase != holdem.PhaseFlop {
+		t.Fatalf("Expected 40 to call into 100 against one player, got %+v", spot)
+	}
+	if spot.Equity < 0.7 || spot.CallEV() <= 0 {
+		t.Errorf("Expected aces to call profitably against random hands, got %+v", spot)
+	}
+	if !spot.Known || spot.KnownEquity > 0.15 || spot.KnownCallEV() >= 0 {
+		t.Errorf("Expected the call to lose against the set, got %+v", spot)
+	}
+	if text := spot.String(); !strings.Contains(text, "40 to call into a pot of 100, pot odds 29%") || !strings.Contains(text, "vs their cards") {
+		t.Errorf("Unexpected description %q", text)
+	}
+
+	// Hidden cards leave the equity against random hands only
+	view.Seats[1].HoleCards, view.Seats[1].CardsHidden = nil, true
+	if spot, err := AnalyzeSpot(view, 1, opts); err != nil || spot.Known || spot.Opposed != 1 {
+		t.Errorf("Expected the opponent counted without cards, got %+v, %v", spot, err)
+	}
+
+	if _, err := AnalyzeSpot(view, 3, opts); err == nil {
+		t.Error("Expected a folded player to have no spot")
+	}
+	if _, err := AnalyzeSpot(view, 9, opts); err == nil {
+		t.Error("Expected a player not seated to have no spot")
+	}
+}
@@ -0,0 +1,291 @@
+package charts
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+// DefaultStackBB is the stack depth Lookup reads charts for
+const DefaultStackBB = 100
+
+//go:embed data/*.json
+var dataFiles embed.FS
+
+// Facing is the preflop action a decision is made against
+type Facing string
+
+const (
+	Unopened       Facing = "unopened" // Folded to the player: open or fold
+	FacingOpen     Facing = "open"     // One raise in: 3-bet, call or fold
+	FacingThreeBet Facing = "3bet"     // The player's open was re-raised
+)
+
+// Facings lists every facing action in the order of a preflop round
+var Facings = []Facing{Unopened, FacingOpen, FacingThreeBet}
+
+// String returns a short description of the facing action
+func (f Facing) String() string {
+	switch f {
+	case Unopened:
+		return "Open"
+	case FacingOpen:
+		return "vs Open"
+	case FacingThreeBet:
+		return "vs 3-Bet"
+	default:
+		return string(f)
+	}
+}
+
+// Decision is how often a chart plays a hand each way
+type Decision struct {
+	Raise float64
+	Call  float64
+}
+
+// Fold returns how often the hand folds
+func (d Decision) Fold() float64 {
+	return max(0, 1-d.Raise-d.Call)
+}
+
+// Best returns the most frequent action, raising on ties
+func (d Decision) Best() holdem.ActionType {
+	switch {
+	case d.Raise > 0 && d.Raise >= d.Call && d.Raise >= d.Fold():
+		return holdem.ActionRaise
+	case d.Call > 0 && d.Call >= d.Fold():
+		return holdem.ActionCall
+	default:
+		return holdem.ActionFold
+	}
+}
+
+// Chart is the raising and calling range for one position and facing action
+type Chart struct {
+	Position Position
+	Facing   Facing
+	Raise    *ranges.Range
+	Call     *ranges.Range
+}
+
+// Decide returns how the chart plays a hand class such as "AKs"
+func (c *Chart) Decide(hand string) Decision {
+	return Decision{Raise: c.Raise.Weight(hand), Call: c.Call.Weight(hand)}
+}
+
+// ChartSet is every chart for one stack depth
+type ChartSet struct {
+	Name    string
+	StackBB int
+	charts  map[Position]map[Facing]*Chart
+}
+
+// NewChartSet creates a chart set without charts, to be filled with Set
+func NewChartSet(name string, stackBB int) *ChartSet {
+	return &ChartSet{Name: name, StackBB: stackBB, charts: map[Position]map[Facing]*Chart{}}
+}
+
+// Set adds a chart, replacing the one for the same position and facing action
+func (s *ChartSet) Set(chart *Chart) {
+	if s.charts[chart.Position] == nil {
+		s.charts[chart.Position] = map[Facing]*Chart{}
+	}
+	s.charts[chart.Position][chart.Facing] = chart
+}
+
+// chartFile is the JSON layout of a chart data file; ranges use range notation
+type chartFile struct {
+	Name    string `json:"name"`
+	StackBB int    `json:"stack_bb"`
+	Charts  []struct {
+		Position Position `json:"position"`
+		Facing   Facing   `json:"facing"`
+		Raise    string   `json:"raise"`
+		Call     string   `json:"call"`
+	} `json:"charts"`
+}
+
+// Load reads a chart set from JSON
+func Load(r io.Reader) (*ChartSet, error) {
+	var file chartFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode charts: %w", err)
+	}
+	if file.StackBB <= 0 {
+		return nil, fmt.Errorf("charts %q: stack depth must be positive, got %d", file.Name, file.StackBB)
+	}
+
+	set := NewChartSet(file.Name, file.StackBB)
+	for _, entry := range file.Charts {
+		if !entry.Position.IsKnown() {
+			return nil, fmt.Errorf("charts %q: unknown position %q", file.Name, entry.Position)
+		}
+		if !entry.Facing.isKnown() {
+			return nil, fmt.Errorf("charts %q: unknown facing action %q", file.Name, entry.Facing)
+		}
+		raise, err := ranges.Parse(entry.Raise)
+		if err != nil {
+			return nil, fmt.Errorf("charts %q %s %s raise: %w", file.Name, entry.Position, entry.Facing, err)
+		}
+		call, err := ranges.Parse(entry.Call)
+		if err != nil {
+			return nil, fmt.Errorf("charts %q %s %s call: %w", file.Name, entry.Position, entry.Facing, err)
+		}
+		for _, hand := range raise.Hands() {
+			if raise.Weight(hand)+call.Weight(hand) > 1+1e-9 {
+				return nil, fmt.Errorf("charts %q %s %s: %s is played more than 100%% of the time", file.Name, entry.Position, entry.Facing, hand)
+			}
+		}
+		set.Set(&Chart{Position: entry.Position, Facing: entry.Facing, Raise: raise, Call: call})
+	}
+	return set, nil
+}
+
+// LoadFile reads a chart set from a JSON file
+func LoadFile(filename string) (*ChartSet, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Load(file)
+}
+
+// Get returns the chart for a position and facing action, nil if the set has none
+func (s *ChartSet) Get(position Position, facing Facing) *Chart {
+	return s.charts[position][facing]
+}
+
+// Lookup returns how the set plays a hand class from a position
+func (s *ChartSet) Lookup(position Position, hand string, facing Facing) (Decision, error) {
+	if row, _ := ranges.Position(hand); row < 0 {
+		return Decision{}, fmt.Errorf("invalid hand %q", hand)
+	}
+	chart := s.Get(position, facing)
+	if chart == nil {
+		return Decision{}, fmt.Errorf("no %s chart for %s in %q", facing, position, s.Name)
+	}
+	return chart.Decide(hand), nil
+}
+
+// Book holds chart sets for several stack depths
+type Book struct {
+	sets []*ChartSet // Shallowest first
+}
+
+// NewBook creates a book from chart sets
+func NewBook(sets ...*ChartSet) *Book {
+	b := &Book{}
+	for _, set := range sets {
+		b.Add(set)
+	}
+	return b
+}
+
+// Add adds a chart set, replacing one of the same stack depth
+func (b *Book) Add(set *ChartSet) {
+	for i, existing := range b.sets {
+		if existing.StackBB == set.StackBB {
+			b.sets[i] = set
+			return
+		}
+	}
+	b.sets = append(b.sets, set)
+	sort.Slice(b.sets, func(i, j int) bool { return b.sets[i].StackBB < b.sets[j].StackBB })
+}
+
+// GetSets returns the chart sets, shallowest first
+func (b *Book) GetSets() []*ChartSet {
+	return append([]*ChartSet{}, b.sets...)
+}
+
+// ForStack returns the chart set closest to a stack depth in big blinds,
+// the deeper one on ties, or nil for an empty book
+func (b *Book) ForStack(stackBB int) *ChartSet {
+	var closest *ChartSet
+	for _, set := range b.sets {
+		if closest == nil || abs(set.StackBB-stackBB) <= abs(closest.StackBB-stackBB) {
+			closest = set
+		}
+	}
+	return closest
+}
+
+// Lookup returns how the charts closest to the stack depth play a hand
+func (b *Book) Lookup(stackBB int, position Position, hand string, facing Facing) (Decision, error) {
+	set := b.ForStack(stackBB)
+	if set == nil {
+		return Decision{}, fmt.Errorf("no charts loaded")
+	}
+	return set.Lookup(position, hand, facing)
+}
+
+var (
+	defaultBook     *Book
+	defaultBookErr  error
+	defaultBookOnce sync.Once
+)
+
+// Default returns the book of charts shipped in the data directory
+func Default() (*Book, error) {
+	defaultBookOnce.Do(func() {
+		defaultBook, defaultBookErr = loadEmbedded()
+	})
+	return defaultBook, defaultBookErr
+}
+
+func loadEmbedded() (*Book, error) {
+	entries, err := dataFiles.ReadDir("data")
+	if err != nil {
+		return nil, err
+	}
+	book := NewBook()
+	for _, entry := range entries {
+		file, err := dataFiles.Open(path.Join("data", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		set, err := Load(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		book.Add(set)
+	}
+	return book, nil
+}
+
+// Lookup returns how the shipped 100 big blind charts play a hand class
+// such as "AKs" from a position against the facing action
+func Lookup(position Position, hand string, facing Facing) (Decision, error) {
+	book, err := Default()
+	if err != nil {
+		return Decision{}, err
+	}
+	return book.Lookup(DefaultStackBB, position, hand, facing)
+}
+
+func (f Facing) isKnown() bool {
+	for _, known := range Facings {
+		if f == known {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
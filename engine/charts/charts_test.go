@@ -0,0 +1,106 @@
+package charts
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestDefaultChartsLoad(t *testing.T) {
+	book, err := Default()
+	if err != nil {
+		t.Fatalf("Unexpected error loading the shipped charts: %v", err)
+	}
+	sets := book.GetSets()
+	if len(sets) < 2 {
+		t.Fatalf("Expected charts for several stack depths, got %d", len(sets))
+	}
+	for _, set := range sets {
+		for _, position := range []Position{PositionUTG, PositionHJ, PositionCO, PositionBTN, PositionSB} {
+			if set.Get(position, Unopened) == nil {
+				t.Errorf("%s: missing %s opening chart", set.Name, position)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	testCases := []struct {
+		position Position
+		hand     string
+		facing   Facing
+		want     holdem.ActionType
+	}{
+		{PositionUTG, "AA", Unopened, holdem.ActionRaise},
+		{PositionUTG, "72o", Unopened, holdem.ActionFold},
+		{PositionBTN, "K2s", Unopened, holdem.ActionRaise},
+		{PositionBB, "98s", FacingOpen, holdem.ActionCall},
+		{PositionCO, "KK", FacingOpen, holdem.ActionRaise},
+		{PositionUTG, "JJ", FacingThreeBet, holdem.ActionCall},
+	}
+	for _, tc := range testCases {
+		decision, err := Lookup(tc.position, tc.hand, tc.facing)
+		if err != nil {
+			t.Fatalf("%s %s %s: unexpected error: %v", tc.position, tc.hand, tc.facing, err)
+		}
+		if got := decision.Best(); got != tc.want {
+			t.Errorf("%s %s %s: expected %s, got %s", tc.position, tc.hand, tc.facing,
+				holdem.ActionTypeToString(tc.want), holdem.ActionTypeToString(got))
+		}
+	}
+}
+
+func TestLookupMixedStrategy(t *testing.T) {
+	decision, err := Lookup(PositionHJ, "A5s", FacingOpen)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decision.Raise != 0.5 || math.Abs(decision.Fold()-0.5) > 1e-9 {
+		t.Errorf("Expected A5s to 3-bet half the time, got %+v", decision)
+	}
+}
+
+func TestLookupErrors(t *testing.T) {
+	if _, err := Lookup(PositionUTG, "AKx", Unopened); err == nil {
+		t.Error("Expected an error for an invalid hand")
+	}
+	if _, err := Lookup(PositionBB, "AA", Unopened); err == nil {
+		t.Error("Expected an error for a chart that does not exist")
+	}
+}
+
+func TestBookForStack(t *testing.T) {
+	book, err := Default()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if set := book.ForStack(25); set.StackBB != 40 {
+		t.Errorf("Expected the 40bb charts for 25bb, got %d", set.StackBB)
+	}
+	if set := book.ForStack(250); set.StackBB != 100 {
+		t.Errorf("Expected the 100bb charts for 250bb, got %d", set.StackBB)
+	}
+	// Shorter stacks open tighter
+	deep, _ := book.Lookup(100, PositionUTG, "65s", Unopened)
+	short, _ := book.Lookup(40, PositionUTG, "65s", Unopened)
+	if deep.Best() != holdem.ActionRaise || short.Best() != holdem.ActionFold {
+		t.Errorf("Expected 65s to open UTG at 100bb only, got %+v and %+v", deep, short)
+	}
+}
+
+func TestLoadRejectsBadCharts(t *testing.T) {
+	testCases := map[string]string{
+		"position": `{"name": "x", "stack_bb": 100, "charts": [{"position": "MP2", "facing": "unopened", "raise": "AA"}]}`,
+		"facing":   `{"name": "x", "stack_bb": 100, "charts": [{"position": "UTG", "facing": "limp", "raise": "AA"}]}`,
+		"range":    `{"name": "x", "stack_bb": 100, "charts": [{"position": "UTG", "facing": "unopened", "raise": "AZ"}]}`,
+		"overlap":  `{"name": "x", "stack_bb": 100, "charts": [{"position": "BB", "facing": "open", "raise": "AA", "call": "AA"}]}`,
+		"depth":    `{"name": "x", "charts": []}`,
+	}
+	for name, data := range testCases {
+		if _, err := Load(strings.NewReader(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package charts
+
+import (
+	"sort"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// Position is a seat relative to the button, named as at a six-handed table
+type Position string
+
+const (
+	PositionUTG Position = "UTG"
+	PositionHJ  Position = "HJ"
+	PositionCO  Position = "CO"
+	PositionBTN Position = "BTN"
+	PositionSB  Position = "SB"
+	PositionBB  Position = "BB"
+)
+
+// Positions lists every position in preflop acting order
+var Positions = []Position{PositionUTG, PositionHJ, PositionCO, PositionBTN, PositionSB, PositionBB}
+
+// IsKnown reports whether the position is one the charts cover
+func (p Position) IsKnown() bool {
+	for _, known := range Positions {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// PositionAt returns the position of the seat the given number of seats
+// after the button at a table of the given size. Heads-up the button is
+// the small blind; at full tables every seat before the hijack plays as
+// under the gun.
+func PositionAt(afterButton, players int) Position {
+	if players == 2 {
+		if afterButton == 0 {
+			return PositionSB
+		}
+		return PositionBB
+	}
+	switch afterButton {
+	case 0:
+		return PositionBTN
+	case 1:
+		return PositionSB
+	case 2:
+		return PositionBB
+	}
+	switch players - afterButton {
+	case 1:
+		return PositionCO
+	case 2:
+		return PositionHJ
+	default:
+		return PositionUTG
+	}
+}
+
+// PositionOf returns a player's position in the current hand
+func PositionOf(game *holdem.Game, playerID int) (Position, bool) {
+	seat, err := game.GetPlayerSitByID(playerID)
+	if err != nil || game.GetButton() < 0 {
+		return "", false
+	}
+	seats := []int{}
+	for _, player := range game.GetAllPlayers() {
+		if s, err := game.GetPlayerSitByID(player.GetID()); err == nil {
+			seats = append(seats, s)
+		}
+	}
+	sort.Ints(seats)
+	buttonIndex, seatIndex := -1, -1
+	for i, s := range seats {
+		if s == game.GetButton() {
+			buttonIndex = i
+		}
+		if s == seat {
+			seatIndex = i
+		}
+	}
+	if buttonIndex < 0 || seatIndex < 0 {
+		return "", false
+	}
+	return PositionAt((seatIndex-buttonIndex+len(seats))%len(seats), len(seats)), true
+}
+
+// FacingOf returns what the player faces preflop, counting the raises so far
+func FacingOf(game *holdem.Game) Facing {
+	switch game.GetUserActions().NumRaises(holdem.PhasePreflop) {
+	case 0:
+		return Unopened
+	case 1:
+		return FacingOpen
+	default:
+		return FacingThreeBet
+	}
+}
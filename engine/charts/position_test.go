@@ -0,0 +1,56 @@
+package charts
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestPositionAt(t *testing.T) {
+	six := []Position{PositionBTN, PositionSB, PositionBB, PositionUTG, PositionHJ, PositionCO}
+	for after, want := range six {
+		if got := PositionAt(after, 6); got != want {
+			t.Errorf("6-handed, %d after the button: expected %s, got %s", after, want, got)
+		}
+	}
+	if got := PositionAt(3, 9); got != PositionUTG {
+		t.Errorf("9-handed UTG+0: expected UTG, got %s", got)
+	}
+	if got := PositionAt(3, 4); got != PositionCO {
+		t.Errorf("4-handed first to act: expected CO, got %s", got)
+	}
+	if PositionAt(0, 2) != PositionSB || PositionAt(1, 2) != PositionBB {
+		t.Error("Expected heads-up button to play the small blind")
+	}
+}
+
+func TestPositionAndFacingInAGame(t *testing.T) {
+	game := holdem.NewGame(5, 10)
+	for i := 1; i <= 4; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i, "p", 1000), i-1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if _, ok := PositionOf(game, 1); ok {
+		t.Error("Expected no position before the first hand")
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := map[int]Position{1: PositionBTN, 2: PositionSB, 3: PositionBB, 4: PositionCO}
+	for id, position := range want {
+		if got, _ := PositionOf(game, id); got != position {
+			t.Errorf("Player %d: expected %s, got %s", id, position, got)
+		}
+	}
+
+	if facing := FacingOf(game); facing != Unopened {
+		t.Errorf("Expected an unopened pot, got %s", facing)
+	}
+	if err := game.TakeAction(holdem.NewRaise(4, 30)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if facing := FacingOf(game); facing != FacingOpen {
+		t.Errorf("Expected to face an open, got %s", facing)
+	}
+}
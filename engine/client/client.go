@@ -0,0 +1,126 @@
+// Package client plays a seat at a lobby table from Go, for bots and
+// alternative frontends: connect with a join code, then take updates, the
+// table's numbered events and chat since the last update together with
+// the prompt it waits on, and answer the seat's turns. Play wraps the loop
+// so that a bot is a function from an update to an action.
+//
+// The tree has no network server yet, so the client talks to a lobby in
+// the same process; the updates carry the table package's JSON types, the
+// messages a server would send.
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/session"
+	"github.com/ljbink/ai-poker/engine/table"
+)
+
+// EventBacklog is how many of the table's latest events a client keeps for
+// its next update
+const EventBacklog = 256
+
+// Update is what happened at the table since the last update and the
+// input the table now waits for
+type Update struct {
+	Events []table.Record      // Events in the order they happened, numbered by the table
+	Missed bool                // Events before these are no longer kept, see table.Resync
+	Chat   []table.ChatMessage // Lines said since the last update
+	Prompt *table.Prompt       // The player's turn, or with Result set the hand just over
+}
+
+// MyTurn reports whether the update asks for the player's action
+func (u *Update) MyTurn() bool {
+	return u.Prompt != nil && u.Prompt.Result == nil
+}
+
+// Client is one player seated at a lobby table. The client drives the
+// table: only one client may connect to it, and the table's other seats
+// are played by their decision makers. A client is not safe for
+// concurrent use.
+type Client struct {
+	lobby  *table.Lobby
+	code   string
+	table  *table.Table
+	feed   *table.Feed
+	player holdem.IPlayer
+	acked  uint64 // Latest event handed out
+	said   int    // Latest chat message handed out
+}
+
+// Connect seats a player at the table under a join code, between hands,
+// and makes the seat wait for the client's actions. The client follows the
+// table's events through its own feed, which replaces the session's
+// observer.
+func Connect(lobby *table.Lobby, code string, player holdem.IPlayer, seat int) (*Client, error) {
+	t, err := lobby.Join(code, player, seat)
+	if err != nil {
+		return nil, err
+	}
+	feed := table.NewFeed(EventBacklog)
+	t.Session().SetObserver(feed.Observe)
+	t.Play(player.GetID())
+	code = strings.ToUpper(strings.TrimSpace(code)) // As the lobby reads it
+	return &Client{lobby: lobby, code: code, table: t, feed: feed, player: player}, nil
+}
+
+// Player returns the player the client seated
+func (c *Client) Player() holdem.IPlayer {
+	return c.player
+}
+
+// Next plays the table on to the player's next turn or the end of the
+// hand, dealing one when none is in progress, and returns what happened
+// on the way. Asked again before Act, it returns the same prompt with no
+// new events.
+func (c *Client) Next(ctx context.Context) (*Update, error) {
+	prompt, err := c.table.NextPrompt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resync := c.feed.Resync(c.player.GetID(), c.acked)
+	c.acked = resync.Seq
+	chat, err := c.lobby.Chat(c.code, c.said)
+	if err != nil {
+		return nil, err
+	}
+	c.said += len(chat)
+	return &Update{Events: resync.Backlog, Missed: resync.Missed, Chat: chat, Prompt: prompt}, nil
+}
+
+// Act answers the player's turn. A raise's amount is the bet it raises
+// to. An illegal action is refused with the *holdem.ValidationError saying
+// why, and the turn stays open.
+func (c *Client) Act(actionType holdem.ActionType, amount int) error {
+	return c.table.Submit(holdem.Action{PlayerID: c.player.GetID(), Type: actionType, Amount: amount})
+}
+
+// Say adds a line to the table's chat
+func (c *Client) Say(text string) error {
+	return c.lobby.Say(c.code, c.player.GetID(), text)
+}
+
+// Play plays the given number of hands, asking decide for the player's
+// action at each turn, and returns their results. It stops early with the
+// error of a refused action or of ctx.
+func (c *Client) Play(ctx context.Context, hands int, decide func(*Update) holdem.Action) ([]*session.HandResult, error) {
+	results := []*session.HandResult{}
+	for len(results) < hands {
+		update, err := c.Next(ctx)
+		if err != nil {
+			return results, err
+		}
+		if !update.MyTurn() {
+			results = append(results, update.Prompt.Result)
+			continue
+		}
+		action := decide(update)
+		if err := c.Act(action.Type, action.Amount); err != nil {
+			return results, fmt.Errorf("hand %s: %w", update.Prompt.View.HandID, err)
+		}
+	}
+	return results, nil
+}
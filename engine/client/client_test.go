@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/session"
+	"github.com/ljbink/ai-poker/engine/table"
+)
+
+// caller checks or calls whatever it faces
+type caller struct{}
+
+func (caller) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	call := game.GetCurrentBet() - player.GetBet()
+	switch {
+	case call <= 0:
+		ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck}
+	case call >= player.GetChips():
+		ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionAllIn, Amount: player.GetChips()}
+	default:
+		ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCall, Amount: call}
+	}
+	close(ch)
+	return ch
+}
+
+// newLobbyTable opens a lobby table with a caller in seat 1 and returns its code
+func newLobbyTable(t *testing.T) (*table.Lobby, string) {
+	t.Helper()
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	game.PlayerSit(holdem.NewPlayer(2, "Caller", 1000), 1)
+	s := session.New(game)
+	s.SetDecisionMaker(2, caller{})
+	lobby := table.NewLobby(0)
+	t.Cleanup(func() { lobby.Close() })
+	code, err := lobby.Create(table.New(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lobby, code
+}
+
+// checkOrFold checks when it can and folds otherwise
+func checkOrFold(update *Update) holdem.Action {
+	if slices.Contains(update.Prompt.Actions, holdem.ActionCheck) {
+		return holdem.Action{Type: holdem.ActionCheck}
+	}
+	return holdem.Action{Type: holdem.ActionFold}
+}
+
+func TestClientPlaysHands(t *testing.T) {
+	lobby, code := newLobbyTable(t)
+	if _, err := Connect(lobby, "ZZZZZZ", holdem.NewPlayer(1, "Hero", 1000), 0); !errors.Is(err, table.ErrUnknownCode) {
+		t.Errorf("Expected an unknown code refused, got %v", err)
+	}
+	c, err := Connect(lobby, code, holdem.NewPlayer(1, "Hero", 1000), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := c.Play(context.Background(), 3, checkOrFold)
+	if err != nil {
+		t.Fatalf("Play failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 hand results, got %d", len(results))
+	}
+	net := 0
+	for _, result := range results {
+		net += result.Net[1]
+	}
+	if chips := c.Player().GetChips(); chips != 1000+net {
+		t.Errorf("Expected %d chips after netting %d, got %d", 1000+net, net, chips)
+	}
+}
+
+func TestClientUpdatesCarryEventsAndChat(t *testing.T) {
+	lobby, code := newLobbyTable(t)
+	c, err := Connect(lobby, code, holdem.NewPlayer(1, "Hero", 1000), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Say("gl"); err != nil {
+		t.Fatal(err)
+	}
+
+	update, err := c.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update.MyTurn() || update.Prompt.PlayerID != 1 {
+		t.Fatalf("Expected the hero to act first heads-up, got %+v", update.Prompt)
+	}
+	if len(update.Events) == 0 || update.Events[0].Event.Type != session.EventHandStarted {
+		t.Errorf("Expected the update to start with the deal, got %v", update.Events)
+	}
+	if len(update.Chat) != 1 || update.Chat[0].Text != "gl" {
+		t.Errorf("Expected the hero's line in the update, got %v", update.Chat)
+	}
+
+	// An illegal action keeps the turn open, and asking again repeats it
+	var invalid *holdem.ValidationError
+	if err := c.Act(holdem.ActionCheck, 0); !errors.As(err, &invalid) {
+		t.Fatalf("Expected checking facing the big blind refused, got %v", err)
+	}
+	again, err := c.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.MyTurn() || len(again.Events) != 0 || len(again.Chat) != 0 {
+		t.Errorf("Expected the same turn with nothing new, got %d events and %d lines", len(again.Events), len(again.Chat))
+	}
+
+	if err := c.Act(holdem.ActionFold, 0); err != nil {
+		t.Fatal(err)
+	}
+	over, err := c.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if over.MyTurn() || over.Prompt.Result == nil {
+		t.Fatalf("Expected the hand over after the fold, got %+v", over.Prompt)
+	}
+	if last := over.Events[len(over.Events)-1]; last.Event.Type != session.EventHandFinished || last.Seq != c.acked {
+		t.Errorf("Expected the update to end with the hand finished, got %+v", last)
+	}
+}
@@ -0,0 +1,48 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/client"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/session"
+	"github.com/ljbink/ai-poker/engine/table"
+)
+
+// A bot joins a lobby table by its code and plays a hand, folding to any
+// bet
+func ExampleClient_Play() {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 1})
+	game.PlayerSit(holdem.NewPlayer(2, "Nit", 1000), 1)
+	nit, err := holdem_ai.CreateBotByName("nit")
+	if err != nil {
+		panic(err)
+	}
+	s := session.New(game)
+	s.SetDecisionMaker(2, nit)
+	lobby := table.NewLobby(0)
+	defer lobby.Close()
+	code, err := lobby.Create(table.New(s))
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := client.Connect(lobby, code, holdem.NewPlayer(1, "Hero", 1000), 0)
+	if err != nil {
+		panic(err)
+	}
+	results, err := c.Play(context.Background(), 1, func(update *client.Update) holdem.Action {
+		if update.Prompt.Call == 0 {
+			return holdem.Action{Type: holdem.ActionCheck}
+		}
+		return holdem.Action{Type: holdem.ActionFold}
+	})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("Hero's result:", results[0].Net[1])
+	// Output:
+	// Hero's result: -5
+}
@@ -0,0 +1,153 @@
+package equity
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+// DefaultChartSamples is the number of runouts sampled per starting hand
+// when a chart's options leave it at zero
+const DefaultChartSamples = 5000
+
+// ChartOptions tunes a preflop equity chart
+type ChartOptions struct {
+	Options                        // Samples are per starting hand, DefaultChartSamples when zero
+	Workers  int                   // Starting hands calculated in parallel, runtime.NumCPU() when zero
+	Progress func(done, total int) // Called after each starting hand, never concurrently
+}
+
+// Chart is the preflop all-in equity of each of the 169 starting hands
+// against one opponent hand or range
+type Chart struct {
+	Opponent string             // The opponent's hand or range as given
+	Equity   map[string]float64 // By hand class, e.g. "AKs"
+	Samples  int                // Runouts sampled per starting hand
+}
+
+// PreflopChart calculates a chart of every starting hand against the
+// opponent, spread over a pool of workers. Each hand is sampled from a
+// seed derived from opts.Seed and its place in the grid, so a seed gives
+// the same chart whatever the number of workers.
+func PreflopChart(ctx context.Context, opponent Holding, label string, opts ChartOptions) (*Chart, error) {
+	if !opponent.IsRange() && len(opponent.Cards) != 2 {
+		return nil, fmt.Errorf("opponent has %d cards, charts are Hold'em only", len(opponent.Cards))
+	}
+	if opts.Samples <= 0 {
+		opts.Samples = DefaultChartSamples
+	}
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	hands := make([]string, 0, 169)
+	for row := range ranges.Ranks {
+		for col := range ranges.Ranks {
+			hands = append(hands, ranges.GridHand(row, col))
+		}
+	}
+	chart := &Chart{Opponent: label, Equity: make(map[string]float64, len(hands)), Samples: opts.Samples}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	jobs := make(chan int)
+	var (
+		mu       sync.Mutex
+		firstErr error
+		done     int
+		wg       sync.WaitGroup
+	)
+	for w := 0; w < min(workers, len(hands)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				hero := ranges.NewRange()
+				hero.Set(hands[i], 1)
+				handOpts := opts.Options
+				handOpts.Seed = opts.Seed + int64(i)
+				result, err := CalculateHoldings([]Holding{{Range: hero}, opponent}, nil, handOpts)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("%s: %w", hands[i], err)
+					cancel()
+				}
+				if err == nil {
+					chart.Equity[hands[i]] = result.Equity[0]
+					done++
+					if opts.Progress != nil {
+						opts.Progress(done, len(hands))
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+feed:
+	for i := range hands {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil && done < len(hands) {
+		return nil, err
+	}
+	return chart, nil
+}
+
+// WriteText writes the chart as the 13x13 grid of equities in percent,
+// suited hands above the diagonal and offsuit hands below
+func (c *Chart) WriteText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Preflop all-in equity vs %s, %d runouts per hand\n\n", c.Opponent, c.Samples)
+	b.WriteString("   ")
+	for _, rank := range ranges.Ranks {
+		fmt.Fprintf(&b, "%6c", rank)
+	}
+	b.WriteString("\n")
+	for row := range ranges.Ranks {
+		fmt.Fprintf(&b, "%c  ", ranges.Ranks[row])
+		for col := range ranges.Ranks {
+			fmt.Fprintf(&b, "%6.1f", c.Equity[ranges.GridHand(row, col)]*100)
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteCSV writes one row per starting hand in grid order, with its
+// combos and equity
+func (c *Chart) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"hand", "combos", "equity"})
+	for row := range ranges.Ranks {
+		for col := range ranges.Ranks {
+			hand := ranges.GridHand(row, col)
+			out.Write([]string{hand, strconv.Itoa(ranges.ComboCount(hand)), strconv.FormatFloat(c.Equity[hand], 'f', 4, 64)})
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
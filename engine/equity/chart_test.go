@@ -0,0 +1,77 @@
+package equity
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPreflopChartVsAces(t *testing.T) {
+	progress := 0
+	chart, err := PreflopChart(context.Background(), Holding{Cards: mustCards(t, "AsAh")}, "AsAh",
+		ChartOptions{Options: Options{Samples: 400, Seed: 3}, Progress: func(done, total int) {
+			if done != progress+1 || total != 169 {
+				t.Errorf("Expected progress %d of 169, got %d of %d", progress+1, done, total)
+			}
+			progress = done
+		}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(chart.Equity) != 169 || progress != 169 {
+		t.Fatalf("Expected all 169 hands, got %d after %d reports", len(chart.Equity), progress)
+	}
+	if aces := chart.Equity["AA"]; aces < 0.4 || aces > 0.6 {
+		t.Errorf("Expected aces to split with aces, got %.3f", aces)
+	}
+	if kings := chart.Equity["KK"]; kings < 0.1 || kings > 0.3 {
+		t.Errorf("Expected kings to have about 18%% against aces, got %.3f", kings)
+	}
+	if chart.Equity["72o"] >= chart.Equity["76s"] {
+		t.Errorf("Expected 76s to do better than 72o, got %.3f and %.3f", chart.Equity["76s"], chart.Equity["72o"])
+	}
+}
+
+func TestPreflopChartIsTheSameWhateverTheWorkers(t *testing.T) {
+	opponent := Holding{Range: mustRange(t, "QQ+,AKs")}
+	chart := func(workers int) *Chart {
+		chart, err := PreflopChart(context.Background(), opponent, "QQ+,AKs",
+			ChartOptions{Options: Options{Samples: 100, Seed: 9}, Workers: workers})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return chart
+	}
+	one, four := chart(1), chart(4)
+	for hand, equity := range one.Equity {
+		if four.Equity[hand] != equity {
+			t.Fatalf("Expected the same equity for %s, got %.4f and %.4f", hand, equity, four.Equity[hand])
+		}
+	}
+}
+
+func TestPreflopChartOutput(t *testing.T) {
+	chart := &Chart{Opponent: "AsAh", Samples: 10, Equity: map[string]float64{"AA": 0.5, "AKs": 0.125}}
+	var text, csv bytes.Buffer
+	if err := chart.WriteText(&text); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(text.String()), "\n")
+	if len(lines) != 16 || !strings.HasPrefix(lines[3], "A    50.0  12.5") {
+		t.Errorf("Expected a 13x13 grid with AA and AKs first, got:\n%s", text.String())
+	}
+	if err := chart.WriteCSV(&csv); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rows := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if len(rows) != 170 || rows[0] != "hand,combos,equity" || rows[1] != "AA,6,0.5000" || rows[2] != "AKs,4,0.1250" {
+		t.Errorf("Expected a header and 169 hands, got %d rows starting %v", len(rows), rows[:3])
+	}
+}
+
+func TestPreflopChartRejectsOmahaHands(t *testing.T) {
+	if _, err := PreflopChart(context.Background(), Holding{Cards: mustCards(t, "AsAhKsKh")}, "AsAhKsKh", ChartOptions{}); err == nil {
+		t.Error("Expected an error for a four-card hand")
+	}
+}
@@ -0,0 +1,206 @@
+package equity
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/internal/combin"
+)
+
+// DefaultSamples is the number of random runouts used when enumeration is too expensive
+const DefaultSamples = 20000
+
+// maxExactRunouts caps how many board completions are enumerated exhaustively
+const maxExactRunouts = 2000
+
+// Options tunes an equity calculation
+type Options struct {
+	Samples   int                   // Random runouts to sample, DefaultSamples when zero
+	Seed      int64                 // RNG seed for sampling, time-based when zero
+	Evaluator holdem.IHandEvaluator // Hand evaluator, holdem.HandEvaluator when nil
+	Dead      poker.Cards           // Cards known to be out of the deck
+	Exact     bool                  // Enumerate every runout, however many there are
+}
+
+// Result holds each hand's share of the pot over all runouts
+type Result struct {
+	Equity []float64 // Win probability plus split tie shares, per hand
+	Wins   []int     // Runouts won outright, per hand
+	Ties   []int     // Runouts split, per hand
+	Made   [][]int   // Runouts ending in each holdem.HandRank, per hand
+	Trials int       // Runouts evaluated
+	Exact  bool      // True when every runout was enumerated
+}
+
+// newResult creates an empty result for the given number of hands
+func newResult(hands int) *Result {
+	result := &Result{
+		Equity: make([]float64, hands),
+		Wins:   make([]int, hands),
+		Ties:   make([]int, hands),
+		Made:   make([][]int, hands),
+	}
+	for i := range result.Made {
+		result.Made[i] = make([]int, holdem.RoyalFlush+1)
+	}
+	return result
+}
+
+// MadeFraction returns how often hand i ended with the given rank
+func (r *Result) MadeFraction(i int, rank holdem.HandRank) float64 {
+	if r.Trials == 0 || i < 0 || i >= len(r.Made) || rank < 0 || int(rank) >= len(r.Made[i]) {
+		return 0
+	}
+	return float64(r.Made[i][rank]) / float64(r.Trials)
+}
+
+// Calculate returns the all-in equity of each hand on the given board. Hands
+// are Hold'em hands unless an Omaha evaluator is passed for four-card hands.
+func Calculate(holes []poker.Cards, board poker.Cards, opts Options) (*Result, error) {
+	if len(holes) < 2 {
+		return nil, fmt.Errorf("need at least 2 hands, got %d", len(holes))
+	}
+	if len(board) > 5 {
+		return nil, fmt.Errorf("board has %d cards", len(board))
+	}
+	known := poker.Cards{}
+	size := max(len(holes[0]), 2)
+	for i, hole := range holes {
+		if len(hole) != size {
+			return nil, fmt.Errorf("hand %d has %d cards, expected %d", i+1, len(hole), size)
+		}
+		known = append(known, hole...)
+	}
+	known = append(known, board...)
+	known = append(known, opts.Dead...)
+	deck, err := remainingDeck(known)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluator := opts.Evaluator
+	if evaluator == nil {
+		evaluator = holdem.NewHandEvaluator()
+	}
+	calc := &calculation{
+		holes:     holes,
+		evaluator: evaluator,
+		result:    newResult(len(holes)),
+		runout:    append(poker.Cards{}, board...),
+	}
+
+	missing := 5 - len(board)
+	if missing > len(deck) {
+		return nil, fmt.Errorf("not enough cards left to complete the board")
+	}
+	if opts.Exact || combin.Binomial(len(deck), missing) <= maxExactRunouts {
+		calc.enumerate(deck, missing)
+		calc.result.Exact = true
+	} else {
+		samples := opts.Samples
+		if samples <= 0 {
+			samples = DefaultSamples
+		}
+		seed := opts.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		calc.sample(deck, missing, samples, rand.New(rand.NewSource(seed)))
+	}
+
+	for i := range calc.result.Equity {
+		calc.result.Equity[i] /= float64(calc.result.Trials)
+	}
+	return calc.result, nil
+}
+
+// calculation accumulates results over runouts
+type calculation struct {
+	holes     []poker.Cards
+	evaluator holdem.IHandEvaluator
+	result    *Result
+	runout    poker.Cards // Board being completed
+}
+
+func (c *calculation) enumerate(deck poker.Cards, missing int) {
+	base := len(c.runout)
+	combin.Combinations(len(deck), missing, func(indices []int) {
+		c.runout = c.runout[:base]
+		for _, i := range indices {
+			c.runout = append(c.runout, deck[i])
+		}
+		c.score()
+	})
+	c.runout = c.runout[:base]
+}
+
+func (c *calculation) sample(deck poker.Cards, missing, samples int, rng *rand.Rand) {
+	base := len(c.runout)
+	shuffled := append(poker.Cards{}, deck...)
+	for s := 0; s < samples; s++ {
+		// Partial Fisher-Yates: only the first missing cards need to be random
+		for i := 0; i < missing; i++ {
+			j := i + rng.Intn(len(shuffled)-i)
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		}
+		c.runout = append(c.runout[:base], shuffled[:missing]...)
+		c.score()
+	}
+	c.runout = c.runout[:base]
+}
+
+// score evaluates every hand on the completed board and credits the winners
+func (c *calculation) score() {
+	var best *holdem.HandResult
+	winners := []int{}
+	for i, hole := range c.holes {
+		hand := c.evaluator.EvaluateHand(hole, c.runout)
+		if hand.Rank >= 0 && int(hand.Rank) < len(c.result.Made[i]) {
+			c.result.Made[i][hand.Rank]++
+		}
+		switch {
+		case best == nil || c.evaluator.CompareHands(hand, best) > 0:
+			best = hand
+			winners = append(winners[:0], i)
+		case c.evaluator.CompareHands(hand, best) == 0:
+			winners = append(winners, i)
+		}
+	}
+
+	c.result.Trials++
+	share := 1 / float64(len(winners))
+	for _, i := range winners {
+		c.result.Equity[i] += share
+		if len(winners) == 1 {
+			c.result.Wins[i]++
+		} else {
+			c.result.Ties[i]++
+		}
+	}
+}
+
+// remainingDeck returns the standard deck minus the known cards
+func remainingDeck(known poker.Cards) (poker.Cards, error) {
+	used := map[poker.Card]bool{}
+	for _, card := range known {
+		if card == nil {
+			return nil, fmt.Errorf("nil card")
+		}
+		if used[*card] {
+			return nil, fmt.Errorf("card %s appears more than once", card.Code())
+		}
+		used[*card] = true
+	}
+
+	deck := poker.Cards{}
+	for _, card := range poker.NewDeckCards() {
+		if card.Suit == poker.SuitNone || used[*card] {
+			continue
+		}
+		deck = append(deck, card)
+	}
+	return deck, nil
+}
@@ -0,0 +1,157 @@
+package equity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func mustCards(t testing.TB, s string) poker.Cards {
+	t.Helper()
+	cards, err := poker.ParseCards(s)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing %q: %v", s, err)
+	}
+	return cards
+}
+
+func TestCalculateExactOnTurn(t *testing.T) {
+	// Flush draw against a set with one card to come: 3h and Qh fill up the queens, leaving 7 outs of 44
+	result, err := Calculate(
+		[]poker.Cards{mustCards(t, "AhKh"), mustCards(t, "QsQd")},
+		mustCards(t, "2h7hQc3s"),
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Exact || result.Trials != 44 {
+		t.Fatalf("Expected 44 exact runouts, got %d (exact %t)", result.Trials, result.Exact)
+	}
+	if result.Wins[0] != 7 || result.Wins[1] != 37 {
+		t.Errorf("Expected 7/37 wins, got %v", result.Wins)
+	}
+	if math.Abs(result.Equity[0]+result.Equity[1]-1) > 1e-9 {
+		t.Errorf("Expected equities to sum to 1, got %v", result.Equity)
+	}
+}
+
+func TestCalculateSplitPot(t *testing.T) {
+	result, err := Calculate(
+		[]poker.Cards{mustCards(t, "2c3d"), mustCards(t, "2d3c")},
+		mustCards(t, "AsKsQsJsTs"),
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Trials != 1 || result.Ties[0] != 1 || result.Equity[0] != 0.5 {
+		t.Errorf("Expected a chopped royal flush board, got %+v", result)
+	}
+}
+
+func TestCalculateSampledPreflop(t *testing.T) {
+	result, err := Calculate(
+		[]poker.Cards{mustCards(t, "AsAd"), mustCards(t, "7c2h")},
+		nil,
+		Options{Samples: 3000, Seed: 1},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Exact || result.Trials != 3000 {
+		t.Errorf("Expected 3000 sampled runouts, got %d", result.Trials)
+	}
+	// Aces are roughly 88% against seven-deuce offsuit
+	if result.Equity[0] < 0.84 || result.Equity[0] > 0.92 {
+		t.Errorf("Expected aces near 88%%, got %.3f", result.Equity[0])
+	}
+
+	again, _ := Calculate(
+		[]poker.Cards{mustCards(t, "AsAd"), mustCards(t, "7c2h")},
+		nil,
+		Options{Samples: 3000, Seed: 1},
+	)
+	if again.Equity[0] != result.Equity[0] {
+		t.Error("Expected identical results for identical seeds")
+	}
+}
+
+func TestCalculateErrors(t *testing.T) {
+	testCases := map[string]struct {
+		holes []poker.Cards
+		board poker.Cards
+		opts  Options
+	}{
+		"one hand":    {[]poker.Cards{mustCards(t, "AsAd")}, nil, Options{}},
+		"short hand":  {[]poker.Cards{mustCards(t, "As"), mustCards(t, "KsKd")}, nil, Options{}},
+		"duplicate":   {[]poker.Cards{mustCards(t, "AsAd"), mustCards(t, "AsKd")}, nil, Options{}},
+		"dead card":   {[]poker.Cards{mustCards(t, "AsAd"), mustCards(t, "KsKd")}, nil, Options{Dead: mustCards(t, "Ad")}},
+		"board width": {[]poker.Cards{mustCards(t, "AsAd"), mustCards(t, "KsKd")}, mustCards(t, "2c3c4c5c6c7c"), Options{}},
+	}
+	for name, tc := range testCases {
+		if _, err := Calculate(tc.holes, tc.board, tc.opts); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestCalculateMadeHands(t *testing.T) {
+	result, err := Calculate(
+		[]poker.Cards{mustCards(t, "AhKh"), mustCards(t, "QsQd")},
+		mustCards(t, "2h7hQc3s"),
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Nine hearts left make the flush, two of them also fill up the queens
+	if result.Made[0][holdem.Flush] != 9 {
+		t.Errorf("Expected 9 flushes, got %d", result.Made[0][holdem.Flush])
+	}
+	if got := result.MadeFraction(1, holdem.FullHouse) + result.MadeFraction(1, holdem.FourOfAKind); math.Abs(got-10.0/44) > 1e-9 {
+		t.Errorf("Expected the set to improve on 10 of 44 rivers, got %.3f", got)
+	}
+}
+
+func BenchmarkCalculateFlop(b *testing.B) {
+	// Every turn and river, 990 runouts
+	holes := []poker.Cards{mustCards(b, "AhKh"), mustCards(b, "QsQd")}
+	board := mustCards(b, "2h7hQc")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Calculate(holes, board, Options{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCalculatePreflop(b *testing.B) {
+	holes := []poker.Cards{mustCards(b, "AsAd"), mustCards(b, "7c2h")}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Calculate(holes, nil, Options{Samples: 1000, Seed: 1}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestCalculateForcedExact(t *testing.T) {
+	// With 30 cards dead 8568 boards are left, more than are enumerated unasked
+	dead := mustCards(t, "2s3s4s5s6s7s8s9sTsJs2d3d4d5d6d7d8d9dTdJd2c3c4c5c6c8c9cTcJc3h")
+	holes := []poker.Cards{mustCards(t, "AsAd"), mustCards(t, "7c2h")}
+	if result, err := Calculate(holes, nil, Options{Dead: dead, Samples: 100, Seed: 1}); err != nil || result.Exact {
+		t.Fatalf("Expected sampling by default, got %+v (%v)", result, err)
+	}
+	result, err := Calculate(holes, nil, Options{Dead: dead, Exact: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Exact || result.Trials != 8568 {
+		t.Errorf("Expected all 8568 runouts enumerated, got %d", result.Trials)
+	}
+}
@@ -0,0 +1,211 @@
+package equity
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+// maxComboAttempts bounds how often a trial redraws combos that collide
+// with cards already dealt before the trial is skipped
+const maxComboAttempts = 50
+
+// Holding is one player's hand in an equity calculation: either known
+// cards or a Hold'em range
+type Holding struct {
+	Cards poker.Cards
+	Range *ranges.Range
+}
+
+// IsRange reports whether the holding is a range rather than known cards
+func (h Holding) IsRange() bool {
+	return len(h.Cards) == 0 && h.Range != nil
+}
+
+// ParseHolding reads known hole cards, two for Hold'em or four for Omaha,
+// in any notation poker.ParseCards takes, falling back to range notation.
+// Text that fails as both is reported as cards once a card was read from it
+// or it has a suit symbol, and as a range otherwise. Ranges are parsed with
+// opts, e.g. ranges.WithLibrary for saved ranges.
+func ParseHolding(text string, opts ...ranges.ParseOption) (Holding, error) {
+	cards, cardErr := poker.ParseCards(text)
+	if cardErr == nil {
+		if len(cards) != 2 && len(cards) != 4 {
+			return Holding{}, fmt.Errorf("expected 2 or 4 cards, got %d", len(cards))
+		}
+		return Holding{Cards: cards}, nil
+	}
+	r, err := ranges.Parse(text, opts...)
+	if err != nil {
+		var invalid *poker.CardError
+		if errors.As(cardErr, &invalid) && (invalid.Position > firstCard(text) || strings.ContainsAny(text, suitSymbols)) {
+			return Holding{}, cardErr
+		}
+		return Holding{}, err
+	}
+	return Holding{Range: r}, nil
+}
+
+// suitSymbols are the suit glyphs poker.ParseCards reads, which range
+// notation never has
+const suitSymbols = "♠♤♥♡♦♢♣♧"
+
+// firstCard returns the position, from 1, of the first character of text
+// that is not a space or bracket
+func firstCard(text string) int {
+	for i, r := range []rune(text) {
+		if !unicode.IsSpace(r) && r != '[' {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// CalculateHoldings returns the equity of each holding on the given board.
+// Known hands only are calculated exactly where possible like Calculate;
+// as soon as a range is involved, hands and runouts are sampled with each
+// range combo drawn by weight.
+func CalculateHoldings(holdings []Holding, board poker.Cards, opts Options) (*Result, error) {
+	if len(holdings) < 2 {
+		return nil, fmt.Errorf("need at least 2 hands, got %d", len(holdings))
+	}
+	holes := make([]poker.Cards, 0, len(holdings))
+	for _, holding := range holdings {
+		if holding.IsRange() {
+			break
+		}
+		holes = append(holes, holding.Cards)
+	}
+	if len(holes) == len(holdings) {
+		return Calculate(holes, board, opts)
+	}
+	if len(board) > 5 {
+		return nil, fmt.Errorf("board has %d cards", len(board))
+	}
+
+	// Known cards, then every range's combos that do not clash with them
+	known := append(append(poker.Cards{}, board...), opts.Dead...)
+	for i, holding := range holdings {
+		if holding.IsRange() {
+			continue
+		}
+		if len(holding.Cards) != 2 {
+			return nil, fmt.Errorf("hand %d has %d cards, ranges are Hold'em only", i+1, len(holding.Cards))
+		}
+		known = append(known, holding.Cards...)
+	}
+	deck, err := remainingDeck(known)
+	if err != nil {
+		return nil, err
+	}
+	combos := make([][]ranges.Combo, len(holdings))
+	totals := make([]float64, len(holdings))
+	for i, holding := range holdings {
+		if !holding.IsRange() {
+			continue
+		}
+		combos[i] = holding.Range.Expand(known)
+		for _, combo := range combos[i] {
+			totals[i] += combo.Weight
+		}
+		if totals[i] == 0 {
+			return nil, fmt.Errorf("range %d has no hands left", i+1)
+		}
+	}
+
+	evaluator := opts.Evaluator
+	if evaluator == nil {
+		evaluator = holdem.NewHandEvaluator()
+	}
+	samples := opts.Samples
+	if samples <= 0 {
+		samples = DefaultSamples
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+	calc := &calculation{
+		holes:     make([]poker.Cards, len(holdings)),
+		evaluator: evaluator,
+		result:    newResult(len(holdings)),
+		runout:    append(poker.Cards{}, board...),
+	}
+
+	missing := 5 - len(board)
+	shuffled := append(poker.Cards{}, deck...)
+	for s := 0; s < samples; s++ {
+		used, ok := dealCombos(calc.holes, holdings, combos, totals, rng)
+		if !ok {
+			continue
+		}
+		// Partial Fisher-Yates over the cards the ranges left in the deck
+		n := len(shuffled)
+		for i := 0; i < missing; i++ {
+			j := i + rng.Intn(n-i)
+			for used[*shuffled[j]] {
+				shuffled[j], shuffled[n-1] = shuffled[n-1], shuffled[j]
+				n--
+				j = i + rng.Intn(n-i)
+			}
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		}
+		calc.runout = append(calc.runout[:len(board)], shuffled[:missing]...)
+		calc.score()
+	}
+	if calc.result.Trials == 0 {
+		return nil, fmt.Errorf("the ranges cannot be dealt together")
+	}
+
+	for i := range calc.result.Equity {
+		calc.result.Equity[i] /= float64(calc.result.Trials)
+	}
+	return calc.result, nil
+}
+
+// dealCombos fills holes with each holding's cards, drawing a weighted
+// combo for every range. It returns the range cards dealt, or false when
+// no combination without shared cards was found.
+func dealCombos(holes []poker.Cards, holdings []Holding, combos [][]ranges.Combo, totals []float64, rng *rand.Rand) (map[poker.Card]bool, bool) {
+	for attempt := 0; attempt < maxComboAttempts; attempt++ {
+		used := map[poker.Card]bool{}
+		ok := true
+		for i, holding := range holdings {
+			if !holding.IsRange() {
+				holes[i] = holding.Cards
+				continue
+			}
+			combo := pickCombo(combos[i], totals[i], rng)
+			if used[*combo.Cards[0]] || used[*combo.Cards[1]] {
+				ok = false
+				break
+			}
+			used[*combo.Cards[0]], used[*combo.Cards[1]] = true, true
+			holes[i] = combo.Cards
+		}
+		if ok {
+			return used, true
+		}
+	}
+	return nil, false
+}
+
+// pickCombo draws a combo with probability proportional to its weight
+func pickCombo(combos []ranges.Combo, total float64, rng *rand.Rand) ranges.Combo {
+	target := rng.Float64() * total
+	for _, combo := range combos {
+		target -= combo.Weight
+		if target < 0 {
+			return combo
+		}
+	}
+	return combos[len(combos)-1]
+}
@@ -0,0 +1,120 @@
+package equity
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+func mustRange(t *testing.T, s string) *ranges.Range {
+	t.Helper()
+	r, err := ranges.Parse(s)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing %q: %v", s, err)
+	}
+	return r
+}
+
+func TestCalculateHoldingsKnownHandsAreExact(t *testing.T) {
+	result, err := CalculateHoldings(
+		[]Holding{{Cards: mustCards(t, "AhKh")}, {Cards: mustCards(t, "QsQd")}},
+		mustCards(t, "2h7hQc3s"),
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Exact || result.Wins[0] != 7 {
+		t.Errorf("Expected the exact turn result, got %d wins (exact %t)", result.Wins[0], result.Exact)
+	}
+}
+
+func TestCalculateHoldingsAgainstRange(t *testing.T) {
+	// Aces are about 81% against any single pair below them
+	result, err := CalculateHoldings(
+		[]Holding{{Cards: mustCards(t, "AsAd")}, {Range: mustRange(t, "22-KK")}},
+		nil,
+		Options{Samples: 5000, Seed: 7},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Exact || result.Trials != 5000 {
+		t.Errorf("Expected 5000 sampled trials, got %d (exact %t)", result.Trials, result.Exact)
+	}
+	if result.Equity[0] < 0.78 || result.Equity[0] > 0.86 {
+		t.Errorf("Expected aces to have about 81%% equity, got %.3f", result.Equity[0])
+	}
+	if math.Abs(result.Equity[0]+result.Equity[1]-1) > 1e-9 {
+		t.Errorf("Expected equities to sum to 1, got %v", result.Equity)
+	}
+}
+
+func TestCalculateHoldingsRangeCardsAreRemoved(t *testing.T) {
+	// Only the two remaining aces can be dealt, so the hero never makes quads
+	result, err := CalculateHoldings(
+		[]Holding{{Cards: mustCards(t, "AsAd")}, {Range: mustRange(t, "AA")}},
+		mustCards(t, "2c7d9h"),
+		Options{Samples: 2000, Seed: 3},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Made[0][holdem.FourOfAKind] != 0 {
+		t.Errorf("Expected no quads for the hero, got %d", result.Made[0][holdem.FourOfAKind])
+	}
+	if result.Ties[0] == 0 {
+		t.Error("Expected aces against aces to split")
+	}
+}
+
+func TestCalculateHoldingsEmptyRange(t *testing.T) {
+	_, err := CalculateHoldings(
+		[]Holding{{Cards: mustCards(t, "AsAd")}, {Range: ranges.NewRange()}},
+		nil,
+		Options{Samples: 100},
+	)
+	if err == nil {
+		t.Error("Expected an error for an empty range")
+	}
+}
+
+func TestCalculateHoldingsRejectsOmahaWithRanges(t *testing.T) {
+	_, err := CalculateHoldings(
+		[]Holding{{Cards: mustCards(t, "AsAdKsKd")}, {Range: mustRange(t, "QQ")}},
+		poker.Cards{},
+		Options{Samples: 100},
+	)
+	if err == nil {
+		t.Error("Expected an error mixing four-card hands with a range")
+	}
+}
+
+func TestParseHoldingNotations(t *testing.T) {
+	for _, input := range []string{"AKs", "A♠K♠", "as ks", "AhKh"} {
+		holding, err := ParseHolding(input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", input, err)
+			continue
+		}
+		if holding.IsRange() != (input == "AKs") {
+			t.Errorf("%s: expected a range only for AKs, got %+v", input, holding)
+		}
+	}
+
+	testCases := map[string]string{
+		"AhXh": `invalid card "X" at position 3`,
+		"A♠Qx": `invalid card "Qx" at position 3`,
+		"AXs":  `invalid hand "AXs"`,
+	}
+	for input, want := range testCases {
+		_, err := ParseHolding(input)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected %s, got %v", input, want, err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package equity
+
+import (
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// Outs returns the cards that would put the hero's hand strictly ahead of
+// every other hand on the next street. Hands that already lead have no outs.
+// The board must hold a flop or a turn.
+func Outs(holes []poker.Cards, board poker.Cards, hero int, opts Options) (poker.Cards, error) {
+	if len(holes) < 2 {
+		return nil, fmt.Errorf("need at least 2 hands, got %d", len(holes))
+	}
+	if hero < 0 || hero >= len(holes) {
+		return nil, fmt.Errorf("hand %d does not exist", hero+1)
+	}
+	if len(board) != 3 && len(board) != 4 {
+		return nil, fmt.Errorf("outs need a flop or a turn, board has %d cards", len(board))
+	}
+	known := append(poker.Cards{}, board...)
+	for _, hole := range holes {
+		known = append(known, hole...)
+	}
+	known = append(known, opts.Dead...)
+	deck, err := remainingDeck(known)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluator := opts.Evaluator
+	if evaluator == nil {
+		evaluator = holdem.NewHandEvaluator()
+	}
+	if leads(evaluator, holes, board, hero) {
+		return poker.Cards{}, nil
+	}
+	outs := poker.Cards{}
+	next := append(append(poker.Cards{}, board...), nil)
+	for _, card := range deck {
+		next[len(board)] = card
+		if leads(evaluator, holes, next, hero) {
+			outs = append(outs, card)
+		}
+	}
+	return outs, nil
+}
+
+// leads reports whether the hero's hand beats every other hand on the board
+func leads(evaluator holdem.IHandEvaluator, holes []poker.Cards, board poker.Cards, hero int) bool {
+	mine := evaluator.EvaluateHand(holes[hero], board)
+	for i, hole := range holes {
+		if i != hero && evaluator.CompareHands(mine, evaluator.EvaluateHand(hole, board)) <= 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package equity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestOutsFlushDrawAgainstSet(t *testing.T) {
+	// Any heart but the 3h and Qh, which fill up the queens
+	outs, err := Outs([]poker.Cards{mustCards(t, "AhKh"), mustCards(t, "QsQd")}, mustCards(t, "2h7hQc3s"), 0, Options{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(outs) != 7 {
+		t.Fatalf("Expected 7 outs, got %d: %s", len(outs), outs.Codes())
+	}
+	for _, card := range outs {
+		if !strings.HasSuffix(card.Code(), "h") || card.Code() == "3h" || card.Code() == "Qh" {
+			t.Errorf("Unexpected out %s", card.Code())
+		}
+	}
+}
+
+func TestOutsLeaderHasNone(t *testing.T) {
+	outs, err := Outs([]poker.Cards{mustCards(t, "QsQd"), mustCards(t, "AhKh")}, mustCards(t, "2h7hQc"), 0, Options{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(outs) != 0 {
+		t.Errorf("Expected no outs for the leading hand, got %s", outs.Codes())
+	}
+}
+
+func TestOutsNeedsFlopOrTurn(t *testing.T) {
+	holes := []poker.Cards{mustCards(t, "AhKh"), mustCards(t, "QsQd")}
+	if _, err := Outs(holes, nil, 0, Options{}); err == nil {
+		t.Error("Expected an error preflop")
+	}
+	if _, err := Outs(holes, mustCards(t, "2h7hQc3s4d"), 0, Options{}); err == nil {
+		t.Error("Expected an error on the river")
+	}
+}
@@ -0,0 +1,189 @@
+package equity
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// CalculateVsRandom returns the equity of one hand against a number of
+// random hands of the same size, sampling both the opponents and the board
+func CalculateVsRandom(hole, board poker.Cards, opponents int, opts Options) (float64, error) {
+	sampler, err := newRandomSampler(hole, board, opponents, opts)
+	if err != nil {
+		return 0, err
+	}
+	samples := opts.Samples
+	if samples <= 0 {
+		samples = DefaultSamples
+	}
+	total := 0.0
+	for s := 0; s < samples; s++ {
+		total += sampler.sample()
+	}
+	return total / float64(samples), nil
+}
+
+// randomSampler deals runouts of one hand against random hands and scores them
+type randomSampler struct {
+	hole, board poker.Cards
+	deck        poker.Cards
+	runout      poker.Cards
+	opponents   int
+	missing     int // Board cards to deal
+	needed      int // Cards dealt per runout, the board's and the opponents'
+	evaluator   holdem.IHandEvaluator
+	rng         *rand.Rand
+}
+
+func newRandomSampler(hole, board poker.Cards, opponents int, opts Options) (*randomSampler, error) {
+	if opponents < 1 {
+		return nil, fmt.Errorf("need at least 1 opponent, got %d", opponents)
+	}
+	if len(hole) < 2 {
+		return nil, fmt.Errorf("hand has %d cards", len(hole))
+	}
+	if len(board) > 5 {
+		return nil, fmt.Errorf("board has %d cards", len(board))
+	}
+	known := append(append(append(poker.Cards{}, hole...), board...), opts.Dead...)
+	deck, err := remainingDeck(known)
+	if err != nil {
+		return nil, err
+	}
+	missing := 5 - len(board)
+	needed := missing + opponents*len(hole)
+	if needed > len(deck) {
+		return nil, fmt.Errorf("not enough cards left for %d opponents", opponents)
+	}
+
+	evaluator := opts.Evaluator
+	if evaluator == nil {
+		evaluator = holdem.NewHandEvaluator()
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &randomSampler{
+		hole:      hole,
+		board:     board,
+		deck:      deck,
+		runout:    append(poker.Cards{}, board...),
+		opponents: opponents,
+		missing:   missing,
+		needed:    needed,
+		evaluator: evaluator,
+		rng:       rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// sample deals one runout and returns the hand's share of the pot in it
+func (s *randomSampler) sample() float64 {
+	// Partial Fisher-Yates: the board runout first, then each opponent's cards
+	for i := 0; i < s.needed; i++ {
+		j := i + s.rng.Intn(len(s.deck)-i)
+		s.deck[i], s.deck[j] = s.deck[j], s.deck[i]
+	}
+	s.runout = append(s.runout[:len(s.board)], s.deck[:s.missing]...)
+	mine := s.evaluator.EvaluateHand(s.hole, s.runout)
+
+	split := 1
+	for o := 0; o < s.opponents && split > 0; o++ {
+		start := s.missing + o*len(s.hole)
+		theirs := s.evaluator.EvaluateHand(s.deck[start:start+len(s.hole)], s.runout)
+		switch s.evaluator.CompareHands(theirs, mine) {
+		case 1:
+			split = 0
+		case 0:
+			split++
+		}
+	}
+	if split == 0 {
+		return 0
+	}
+	return 1 / float64(split)
+}
+
+// estimateCheck is how many runouts an Estimator samples between looks at the clock
+const estimateCheck = 32
+
+// Estimate is an equity worked out from the runouts sampled so far, with
+// the 95% confidence interval around it
+type Estimate struct {
+	Equity  float64
+	Margin  float64 // Half the width of the interval, 1 before any runout
+	Trials  int     // Runouts sampled
+	Partial bool    // Fewer runouts than asked for were sampled so far
+}
+
+// Low returns the bottom of the interval
+func (e Estimate) Low() float64 {
+	return max(e.Equity-e.Margin, 0)
+}
+
+// High returns the top of the interval
+func (e Estimate) High() float64 {
+	return min(e.Equity+e.Margin, 1)
+}
+
+// Estimator works out the equity of one hand against random hands like
+// CalculateVsRandom, a slice of time at a time, so a caller that has to
+// stay responsive gets an estimate after each slice instead of waiting for
+// every runout, which takes long against many opponents
+type Estimator struct {
+	sampler *randomSampler
+	samples int     // Runouts asked for
+	trials  int     // Runouts sampled
+	sum     float64 // Of the shares won
+	squares float64 // Of the shares won, squared
+}
+
+// NewEstimator prepares an estimate of opts.Samples runouts, DefaultSamples
+// when zero
+func NewEstimator(hole, board poker.Cards, opponents int, opts Options) (*Estimator, error) {
+	sampler, err := newRandomSampler(hole, board, opponents, opts)
+	if err != nil {
+		return nil, err
+	}
+	samples := opts.Samples
+	if samples <= 0 {
+		samples = DefaultSamples
+	}
+	return &Estimator{sampler: sampler, samples: samples}, nil
+}
+
+// Run samples runouts until the budget is spent, every runout asked for is
+// sampled or ctx is done, and returns the estimate so far. It may be called
+// again to refine a partial estimate.
+func (e *Estimator) Run(ctx context.Context, budget time.Duration) Estimate {
+	deadline := time.Now().Add(budget)
+	for e.trials < e.samples {
+		if e.trials%estimateCheck == 0 && (ctx.Err() != nil || !time.Now().Before(deadline)) {
+			break
+		}
+		share := e.sampler.sample()
+		e.sum += share
+		e.squares += share * share
+		e.trials++
+	}
+	return e.Estimate()
+}
+
+// Estimate returns the estimate from the runouts sampled so far
+func (e *Estimator) Estimate() Estimate {
+	estimate := Estimate{Margin: 1, Trials: e.trials, Partial: e.trials < e.samples}
+	if e.trials == 0 {
+		return estimate
+	}
+	n := float64(e.trials)
+	estimate.Equity = e.sum / n
+	variance := max(e.squares/n-estimate.Equity*estimate.Equity, 0)
+	estimate.Margin = 1.96 * math.Sqrt(variance/n)
+	return estimate
+}
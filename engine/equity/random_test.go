@@ -0,0 +1,98 @@
+package equity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestCalculateVsRandom(t *testing.T) {
+	// Aces win about 85% heads-up and about 49% against four random hands
+	headsUp, err := CalculateVsRandom(mustCards(t, "AsAd"), nil, 1, Options{Samples: 3000, Seed: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if headsUp < 0.81 || headsUp > 0.89 {
+		t.Errorf("Expected aces near 85%% heads-up, got %.3f", headsUp)
+	}
+	multiway, _ := CalculateVsRandom(mustCards(t, "AsAd"), nil, 4, Options{Samples: 3000, Seed: 1})
+	if multiway >= headsUp {
+		t.Errorf("Expected equity to drop against more opponents, got %.3f and %.3f", headsUp, multiway)
+	}
+
+	// The nuts on the river can only be tied
+	nuts, _ := CalculateVsRandom(mustCards(t, "AhKh"), mustCards(t, "QhJhTh2c3d"), 2, Options{Samples: 200, Seed: 1})
+	if nuts != 1 {
+		t.Errorf("Expected a royal flush to always win, got %.3f", nuts)
+	}
+}
+
+func TestCalculateVsRandomOmaha(t *testing.T) {
+	opts := Options{Samples: 2000, Seed: 1, Evaluator: holdem.NewOmahaEvaluator()}
+	strong, err := CalculateVsRandom(mustCards(t, "AsAhKsKh"), nil, 1, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	weak, _ := CalculateVsRandom(mustCards(t, "2c7d9hQs"), nil, 1, opts)
+	// Omaha hands run close together, double-suited aces are only about 65%
+	if strong < 0.6 || weak > 0.5 {
+		t.Errorf("Expected AAKK double-suited well ahead of a rainbow hand, got %.3f and %.3f", strong, weak)
+	}
+}
+
+func TestCalculateVsRandomErrors(t *testing.T) {
+	if _, err := CalculateVsRandom(mustCards(t, "AsAd"), nil, 0, Options{}); err == nil {
+		t.Error("Expected an error without opponents")
+	}
+	if _, err := CalculateVsRandom(mustCards(t, "As"), nil, 1, Options{}); err == nil {
+		t.Error("Expected an error for a one card hand")
+	}
+	if _, err := CalculateVsRandom(mustCards(t, "AsAd"), nil, 30, Options{}); err == nil {
+		t.Error("Expected an error when the deck runs out")
+	}
+}
+
+func TestEstimatorRefinesWithinBudget(t *testing.T) {
+	estimator, err := NewEstimator(mustCards(t, "AsAd"), nil, 1, Options{Samples: 3000, Seed: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if first := estimator.Run(context.Background(), 0); first.Trials != 0 || !first.Partial || first.Low() != 0 || first.High() != 1 {
+		t.Errorf("Expected no runouts and an interval of everything without time, got %+v", first)
+	}
+
+	estimate := estimator.Run(context.Background(), time.Minute)
+	if estimate.Partial || estimate.Trials != 3000 {
+		t.Fatalf("Expected every runout sampled, got %+v", estimate)
+	}
+	headsUp, _ := CalculateVsRandom(mustCards(t, "AsAd"), nil, 1, Options{Samples: 3000, Seed: 1})
+	if estimate.Equity != headsUp {
+		t.Errorf("Expected the same equity as CalculateVsRandom with the seed, got %.4f and %.4f", estimate.Equity, headsUp)
+	}
+	if estimate.Margin <= 0 || estimate.Margin > 0.02 || estimate.Low() > 0.85 || estimate.High() < 0.85 {
+		t.Errorf("Expected a narrow interval around 85%%, got %.3f ± %.3f", estimate.Equity, estimate.Margin)
+	}
+}
+
+func TestEstimatorStopsWhenCanceled(t *testing.T) {
+	estimator, err := NewEstimator(mustCards(t, "AsAd"), nil, 8, Options{Samples: 1 << 30})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if estimate := estimator.Run(ctx, time.Minute); estimate.Trials != 0 || !estimate.Partial {
+		t.Errorf("Expected nothing sampled once canceled, got %+v", estimate)
+	}
+
+	start := time.Now()
+	estimate := estimator.Run(context.Background(), 20*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the budget to be kept, took %v", elapsed)
+	}
+	if !estimate.Partial || estimate.Trials == 0 {
+		t.Errorf("Expected a partial estimate, got %+v", estimate)
+	}
+}
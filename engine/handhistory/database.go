@@ -0,0 +1,275 @@
+package handhistory
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ContentHash identifies a hand by how it was played: the variant, who sat
+// where with what stack, the forced bets, every decision and the board.
+// What depends on who recorded the hand or in which format is left out, so
+// the same hand exported from two devices or as PokerStars text and PHH
+// hashes the same: hole cards and showdowns, the time, the table name, the
+// hand ID and the results, which follow from the rest.
+func ContentHash(hand *Hand) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %d/%d\n", hand.Variant, hand.SmallBlind, hand.BigBlind)
+	seats := append([]Seat{}, hand.Seats...)
+	sort.Slice(seats, func(i, j int) bool { return seats[i].Seat < seats[j].Seat })
+	for _, seat := range seats {
+		fmt.Fprintf(&b, "seat %d %q %d\n", seat.Seat, seat.Name, seat.Stack)
+	}
+	// Formats list forced bets in different places, only their sum per player counts
+	forced := map[string]int{}
+	for _, action := range hand.Actions {
+		if action.Type.IsForced() {
+			forced[action.Player] += action.Amount
+		}
+	}
+	for _, seat := range seats {
+		if forced[seat.Name] > 0 {
+			fmt.Fprintf(&b, "forced %q %d\n", seat.Name, forced[seat.Name])
+		}
+	}
+	for _, action := range hand.Actions {
+		if !action.Type.IsForced() {
+			fmt.Fprintf(&b, "%d %q %s %d\n", action.Phase, action.Player, ActionTypeToString(action.Type), action.Amount)
+		}
+	}
+	fmt.Fprintf(&b, "board %s\n", hand.Board.Codes())
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// identity returns what names a hand wherever it was exported from: the
+// engine's unique hand ID, or the hand ID at its table. "" when the hand
+// carries neither.
+func identity(hand *Hand) string {
+	switch {
+	case hand.UID != "":
+		return "uid:" + hand.UID
+	case hand.ID != "":
+		return "id:" + hand.Table + "#" + hand.ID
+	}
+	return ""
+}
+
+// mergeOutcome is what became of one hand merged into a Database
+type mergeOutcome int
+
+const (
+	mergeAdded     mergeOutcome = iota // New to the database
+	mergeUpdated                       // Already stored, with hole cards or the unique ID filled in from it
+	mergeDuplicate                     // Already stored as it is
+	mergeConflict                      // Stored under the same hand ID but played differently, left out
+)
+
+// MergeReport counts what became of the hands merged into a Database
+type MergeReport struct {
+	Added      int
+	Updated    int
+	Duplicates int
+	Conflicts  []string // IDs of the hands left out as conflicts
+}
+
+// Skipped returns how many hands were not added
+func (r MergeReport) Skipped() int {
+	return r.Updated + r.Duplicates + len(r.Conflicts)
+}
+
+// Add counts another report in this one
+func (r *MergeReport) Add(other MergeReport) {
+	r.Added += other.Added
+	r.Updated += other.Updated
+	r.Duplicates += other.Duplicates
+	r.Conflicts = append(r.Conflicts, other.Conflicts...)
+}
+
+// Database keeps hand histories imported from other exports or devices in
+// a directory, one PHH file per hand, so a hand is stored once however
+// often it is imported and statistics read from the database count it once
+type Database struct {
+	dir      string
+	hands    map[string]*Hand    // By file name, without the extension
+	hashes   map[string][]string // File names by content hash
+	identity map[string]string   // File name by identity
+}
+
+// OpenDatabase opens the database in dir, creating the directory if needed
+func OpenDatabase(dir string) (*Database, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	db := &Database{dir: dir, hands: map[string]*Hand{}, hashes: map[string][]string{}, identity: map[string]string{}}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.phh"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		hands, err := ParseFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, hand := range hands {
+			db.index(strings.TrimSuffix(filepath.Base(path), ".phh"), hand)
+		}
+	}
+	return db, nil
+}
+
+// Len returns the number of hands stored
+func (db *Database) Len() int {
+	return len(db.hands)
+}
+
+// Hands returns the stored hands, ordered by file name so the order does
+// not depend on when they were imported
+func (db *Database) Hands() []*Hand {
+	keys := make([]string, 0, len(db.hands))
+	for key := range db.hands {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	hands := make([]*Hand, len(keys))
+	for i, key := range keys {
+		hands[i] = db.hands[key]
+	}
+	return hands
+}
+
+// Merge stores the hands the database does not hold yet and reports what
+// became of each. A hand is matched by its identity, the unique ID or the
+// hand ID at its table, and by its content hash only when it or the stored
+// hand has no identity, so different hands that happen to play out the
+// same, such as two walks, are both kept. A hand already stored fills in
+// the hole cards and the unique ID the stored copy lacks; one stored under
+// the same ID but played differently is left out as a conflict.
+func (db *Database) Merge(hands []*Hand) (MergeReport, error) {
+	report := MergeReport{}
+	for _, hand := range hands {
+		outcome, err := db.merge(hand)
+		if err != nil {
+			return report, err
+		}
+		switch outcome {
+		case mergeAdded:
+			report.Added++
+		case mergeUpdated:
+			report.Updated++
+		case mergeDuplicate:
+			report.Duplicates++
+		case mergeConflict:
+			report.Conflicts = append(report.Conflicts, hand.ID)
+		}
+	}
+	return report, nil
+}
+
+// merge stores one hand
+func (db *Database) merge(hand *Hand) (mergeOutcome, error) {
+	hash := ContentHash(hand)
+	id := identity(hand)
+	key, ok := db.identity[id]
+	if ok {
+		if ContentHash(db.hands[key]) != hash {
+			return mergeConflict, nil
+		}
+	} else {
+		key, ok = db.match(hash, id)
+	}
+	if !ok {
+		key = storageKey(hash, id)
+		if err := db.write(key, hand); err != nil {
+			return 0, err
+		}
+		db.index(key, hand)
+		return mergeAdded, nil
+	}
+
+	stored := db.hands[key]
+	updated := *stored
+	changed := false
+	updated.Seats = append([]Seat{}, stored.Seats...)
+	for i, seat := range updated.Seats {
+		if len(seat.HoleCards) > 0 {
+			continue
+		}
+		if other := hand.GetSeat(seat.Name); other != nil && len(other.HoleCards) > 0 {
+			updated.Seats[i].HoleCards = other.HoleCards
+			changed = true
+		}
+	}
+	if updated.UID == "" && hand.UID != "" {
+		updated.UID = hand.UID
+		changed = true
+	}
+	if hand.Shuffle != nil && (updated.Shuffle == nil || updated.Shuffle.Nonce == "" && hand.Shuffle.Nonce != "") {
+		updated.Shuffle = hand.Shuffle
+		changed = true
+	}
+	if id != "" {
+		// A hand stored without this identity answers to it from now on
+		db.identity[id] = key
+	}
+	if !changed {
+		return mergeDuplicate, nil
+	}
+	if err := db.write(key, &updated); err != nil {
+		return 0, err
+	}
+	db.index(key, &updated)
+	return mergeUpdated, nil
+}
+
+// match finds a stored hand with the given content hash that the hand with
+// the given identity may be a copy of: one of them has no identity
+func (db *Database) match(hash, id string) (string, bool) {
+	for _, key := range db.hashes[hash] {
+		if id == "" || identity(db.hands[key]) == "" {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// storageKey names the file of a new hand: its content hash, mixed with its
+// identity when it has one so hands played the same get files of their own
+func storageKey(hash, id string) string {
+	if id == "" {
+		return hash[:16]
+	}
+	sum := sha256.Sum256([]byte(hash + "\n" + id))
+	return hex.EncodeToString(sum[:8])
+}
+
+// index keeps a hand in memory under its file name, content hash and identity
+func (db *Database) index(key string, hand *Hand) {
+	if _, ok := db.hands[key]; !ok {
+		hash := ContentHash(hand)
+		db.hashes[hash] = append(db.hashes[hash], key)
+	}
+	db.hands[key] = hand
+	if id := identity(hand); id != "" {
+		db.identity[id] = key
+	}
+}
+
+// write saves a hand to its file, through a temporary file so a failed
+// write never leaves half a hand behind
+func (db *Database) write(key string, hand *Hand) error {
+	var out bytes.Buffer
+	if err := WritePHH(&out, hand); err != nil {
+		return err
+	}
+	path := filepath.Join(db.dir, key+".phh")
+	if err := os.WriteFile(path+".tmp", out.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
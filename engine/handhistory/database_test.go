@@ -0,0 +1,190 @@
+package handhistory
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestContentHashSurvivesExportFormats(t *testing.T) {
+	for _, fixture := range goldenFixtures(t) {
+		t.Run(fixture.name, func(t *testing.T) {
+			want := ContentHash(fixture.hand)
+
+			var phh bytes.Buffer
+			if err := WritePHH(&phh, fixture.hand); err != nil {
+				t.Fatalf("WritePHH failed: %v", err)
+			}
+			hand, err := ParsePHH(&phh)
+			if err != nil {
+				t.Fatalf("ParsePHH failed: %v", err)
+			}
+			if got := ContentHash(hand); got != want {
+				t.Errorf("Expected the PHH export to hash as %s, got %s", want, got)
+			}
+
+			var stars bytes.Buffer
+			if err := WritePokerStars(&stars, fixture.hand); err != nil {
+				t.Fatalf("WritePokerStars failed: %v", err)
+			}
+			hands, err := ParsePokerStars(&stars)
+			if err != nil || len(hands) != 1 {
+				t.Fatalf("ParsePokerStars failed: %v", err)
+			}
+			if got := ContentHash(hands[0]); got != want {
+				t.Errorf("Expected the PokerStars export to hash as %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestDatabaseMergesWithoutDoubleCounting(t *testing.T) {
+	dir := t.TempDir()
+	db, err := OpenDatabase(dir)
+	if err != nil {
+		t.Fatalf("OpenDatabase failed: %v", err)
+	}
+	hands, err := ParsePokerStars(strings.NewReader(pokerStarsSample))
+	if err != nil {
+		t.Fatalf("ParsePokerStars failed: %v", err)
+	}
+	report, err := db.Merge(hands)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if report.Added != 2 || report.Skipped() != 0 {
+		t.Errorf("Expected both hands added, got %+v", report)
+	}
+
+	// The same hands again, one of them as a PHH export from another device
+	var phh bytes.Buffer
+	if err := WritePHH(&phh, hands[1]); err != nil {
+		t.Fatalf("WritePHH failed: %v", err)
+	}
+	other, err := ParsePHH(&phh)
+	if err != nil {
+		t.Fatalf("ParsePHH failed: %v", err)
+	}
+	report, err = db.Merge([]*Hand{hands[0], other})
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if report.Added != 0 || report.Duplicates != 2 {
+		t.Errorf("Expected both hands skipped as duplicates, got %+v", report)
+	}
+
+	reopened, err := OpenDatabase(dir)
+	if err != nil {
+		t.Fatalf("OpenDatabase failed: %v", err)
+	}
+	if reopened.Len() != 2 {
+		t.Errorf("Expected 2 hands stored, got %d", reopened.Len())
+	}
+}
+
+func TestDatabaseKeepsHandsPlayedTheSame(t *testing.T) {
+	dir := t.TempDir()
+	db, err := OpenDatabase(dir)
+	if err != nil {
+		t.Fatalf("OpenDatabase failed: %v", err)
+	}
+	hands, err := ParsePokerStars(strings.NewReader(pokerStarsSample))
+	if err != nil {
+		t.Fatalf("ParsePokerStars failed: %v", err)
+	}
+
+	// Two hands dealt apart that played out the same way
+	first, second := *hands[0], *hands[0]
+	first.UID, second.UID = "uid-a", "uid-b"
+	report, err := db.Merge([]*Hand{&first, &second})
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if report.Added != 2 || report.Skipped() != 0 {
+		t.Errorf("Expected both hands added, got %+v", report)
+	}
+
+	// A copy that carries no identity can only be matched by how it played
+	anonymous := first
+	anonymous.UID, anonymous.ID, anonymous.Table = "", "", ""
+	if report, err = db.Merge([]*Hand{&anonymous}); err != nil || report.Duplicates != 1 {
+		t.Errorf("Expected the copy without an identity skipped as a duplicate, got %+v (%v)", report, err)
+	}
+
+	reopened, err := OpenDatabase(dir)
+	if err != nil {
+		t.Fatalf("OpenDatabase failed: %v", err)
+	}
+	if reopened.Len() != 2 {
+		t.Errorf("Expected 2 hands stored, got %d", reopened.Len())
+	}
+	if report, err = reopened.Merge([]*Hand{&second}); err != nil || report.Duplicates != 1 {
+		t.Errorf("Expected the second hand recognised after reopening, got %+v (%v)", report, err)
+	}
+}
+
+func TestDatabaseFillsInHoleCards(t *testing.T) {
+	db, err := OpenDatabase(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenDatabase failed: %v", err)
+	}
+	hands, err := ParsePokerStars(strings.NewReader(pokerStarsSample))
+	if err != nil {
+		t.Fatalf("ParsePokerStars failed: %v", err)
+	}
+	if _, err := db.Merge(hands[:1]); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+
+	// The villain's device saw their cards and not the hero's
+	villain := *hands[0]
+	villain.Seats = append([]Seat{}, hands[0].Seats...)
+	villain.Seats[0].HoleCards = nil
+	villain.Seats[1].HoleCards = mustParseCards(t, "9c9d")
+	report, err := db.Merge([]*Hand{&villain})
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if report.Updated != 1 || report.Added != 0 {
+		t.Fatalf("Expected the stored hand updated, got %+v", report)
+	}
+	stored := db.Hands()[0]
+	if stored.Seats[0].HoleCards.Codes() != "AhKd" || stored.Seats[1].HoleCards.Codes() != "9c9d" {
+		t.Errorf("Expected both players' cards kept, got %v", stored.Seats)
+	}
+}
+
+func TestDatabaseLeavesOutConflicts(t *testing.T) {
+	db, err := OpenDatabase(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenDatabase failed: %v", err)
+	}
+	hands, err := ParsePokerStars(strings.NewReader(pokerStarsSample))
+	if err != nil {
+		t.Fatalf("ParsePokerStars failed: %v", err)
+	}
+	if _, err := db.Merge(hands[:1]); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+
+	changed := *hands[0]
+	changed.Board = mustParseCards(t, "2c7dKs9hAs")
+	report, err := db.Merge([]*Hand{&changed})
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0] != hands[0].ID || db.Len() != 1 {
+		t.Errorf("Expected hand %s left out as a conflict, got %+v", hands[0].ID, report)
+	}
+}
+
+func mustParseCards(t *testing.T, codes string) poker.Cards {
+	t.Helper()
+	cards, err := poker.ParseCards(codes)
+	if err != nil {
+		t.Fatalf("ParseCards failed: %v", err)
+	}
+	return cards
+}
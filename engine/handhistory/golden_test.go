@@ -0,0 +1,257 @@
+package handhistory
+
+import (
+	"bytes"
+	"encoding/hex"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// Regenerate the expected exporter output after an intended format change
+// with: go test ./engine/handhistory/ -run Golden -update
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenFixture is a canonical hand and the name of its golden files
+type goldenFixture struct {
+	name string
+	hand *Hand
+}
+
+// goldenFixtures returns hands covering what the exporters have to handle:
+// money and chip amounts, antes, uncalled bets, all-in runouts, showdowns,
+// Omaha and hands converted from the engine's replays
+func goldenFixtures(t *testing.T) []goldenFixture {
+	t.Helper()
+	stars, err := ParsePokerStars(strings.NewReader(pokerStarsSample))
+	if err != nil {
+		t.Fatalf("ParsePokerStars failed: %v", err)
+	}
+	phh, err := ParsePHH(strings.NewReader(phhSample))
+	if err != nil {
+		t.Fatalf("ParsePHH failed: %v", err)
+	}
+	recorded := playReplayHand(t)
+	recorded.HandID = "018f3a2c-5b10-7c4e-9a21-6d0f4b8e2c17" // Fixed in place of a fresh one
+	fixShuffleNonce(t, recorded)
+	replay, err := FromReplay(recorded)
+	if err != nil {
+		t.Fatalf("FromReplay failed: %v", err)
+	}
+	return []goldenFixture{
+		{"cash_uncalled_bet", stars[0]},
+		{"tournament_all_in", stars[1]},
+		{"three_way_showdown", phh},
+		{"replay_heads_up", replay},
+		{"omaha_turn_fold", omahaFixture(t)},
+	}
+}
+
+// fixShuffleNonce commits a recorded hand's shuffle under a fixed nonce in
+// place of its random one, so the exports stay the same from run to run
+func fixShuffleNonce(t *testing.T, replay *holdem.Replay) {
+	t.Helper()
+	game, err := replay.Run()
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	dealt, err := game.RevealShuffle()
+	if err != nil {
+		t.Fatalf("RevealShuffle failed: %v", err)
+	}
+	nonce := bytes.Repeat([]byte{0x5a}, holdem.ShuffleNonceSize)
+	replay.ShuffleNonce = hex.EncodeToString(nonce)
+	replay.ShuffleCommitment = holdem.CommitShuffle(dealt.Deck, nonce)
+}
+
+// omahaFixture is a four-handed pot-limit Omaha hand won on the turn
+func omahaFixture(t *testing.T) *Hand {
+	t.Helper()
+	cards := func(codes string) poker.Cards {
+		parsed, err := poker.ParseCards(codes)
+		if err != nil {
+			t.Fatalf("ParseCards failed: %v", err)
+		}
+		return parsed
+	}
+	hand := NewHand("fixture")
+	hand.ID, hand.Variant, hand.Table = "4242", VariantPLO, "Omaha Fixture"
+	hand.SmallBlind, hand.BigBlind, hand.Button = 50, 100, 4
+	hand.Seats = []Seat{
+		{Seat: 1, Name: "Ann", Stack: 10000},
+		{Seat: 2, Name: "Ben", Stack: 8000, HoleCards: cards("AsAdKsQd")},
+		{Seat: 4, Name: "Cat", Stack: 12500},
+		{Seat: 6, Name: "Dan", Stack: 9000},
+	}
+	hand.Hero = "Ben"
+	hand.Board = cards("7s8sTdJh")
+	preflop, flop, turn := holdem.PhasePreflop, holdem.PhaseFlop, holdem.PhaseTurn
+	hand.Actions = []Action{
+		{Phase: preflop, Player: "Dan", Type: ActionPostSmallBlind, Amount: 50},
+		{Phase: preflop, Player: "Ann", Type: ActionPostBigBlind, Amount: 100},
+		{Phase: preflop, Player: "Ben", Type: ActionRaise, Amount: 350},
+		{Phase: preflop, Player: "Cat", Type: ActionCall, Amount: 350},
+		{Phase: preflop, Player: "Dan", Type: ActionFold},
+		{Phase: preflop, Player: "Ann", Type: ActionCall, Amount: 250},
+		{Phase: flop, Player: "Ann", Type: ActionCheck},
+		{Phase: flop, Player: "Ben", Type: ActionBet, Amount: 800},
+		{Phase: flop, Player: "Cat", Type: ActionCall, Amount: 800},
+		{Phase: flop, Player: "Ann", Type: ActionFold},
+		{Phase: turn, Player: "Ben", Type: ActionBet, Amount: 2000},
+		{Phase: turn, Player: "Cat", Type: ActionRaise, Amount: 8000},
+		{Phase: turn, Player: "Ben", Type: ActionFold},
+	}
+	hand.Returned["Cat"] = 6000
+	hand.Collected["Cat"] = 6500
+	hand.Rake = 200
+	return hand
+}
+
+// checkGolden compares output with a golden file, rewriting it with -update
+func checkGolden(t *testing.T, name string, output []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, output, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Reading %s failed, create it with -update: %v", path, err)
+	}
+	if !bytes.Equal(output, want) {
+		t.Errorf("%s is out of date; if the change is intended, run go test -run Golden -update and review the diff\ngot:\n%s\nwant:\n%s", path, output, want)
+	}
+}
+
+func TestGoldenPokerStars(t *testing.T) {
+	for _, fixture := range goldenFixtures(t) {
+		t.Run(fixture.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := WritePokerStars(&out, fixture.hand); err != nil {
+				t.Fatalf("WritePokerStars failed: %v", err)
+			}
+			checkGolden(t, fixture.name+".txt", out.Bytes())
+
+			hands, err := ParsePokerStars(&out)
+			if err != nil || len(hands) != 1 {
+				t.Fatalf("Reading the export back failed: %v", err)
+			}
+			checkSameHand(t, fixture.hand, hands[0], true)
+		})
+	}
+}
+
+func TestGoldenPHH(t *testing.T) {
+	for _, fixture := range goldenFixtures(t) {
+		t.Run(fixture.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := WritePHH(&out, fixture.hand); err != nil {
+				t.Fatalf("WritePHH failed: %v", err)
+			}
+			checkGolden(t, fixture.name+".phh", out.Bytes())
+
+			hand, err := ParsePHH(&out)
+			if err != nil {
+				t.Fatalf("Reading the export back failed: %v", err)
+			}
+			// PHH lists forced bets up front, so only voluntary actions keep their order
+			checkSameHand(t, fixture.hand, hand, false)
+		})
+	}
+}
+
+func TestWritePokerStarsSeparatesHands(t *testing.T) {
+	hands, err := ParsePokerStars(strings.NewReader(pokerStarsSample))
+	if err != nil {
+		t.Fatalf("ParsePokerStars failed: %v", err)
+	}
+	var out bytes.Buffer
+	if err := WritePokerStars(&out, hands...); err != nil {
+		t.Fatalf("WritePokerStars failed: %v", err)
+	}
+	again, err := ParsePokerStars(&out)
+	if err != nil || len(again) != 2 {
+		t.Fatalf("Expected 2 hands back, got %d (%v)", len(again), err)
+	}
+
+	hands[0].ID = "abc"
+	if err := WritePokerStars(&bytes.Buffer{}, hands[0]); err == nil {
+		t.Error("Expected a non-numeric hand ID to be rejected")
+	}
+}
+
+// checkSameHand compares what an export must preserve: the board, each
+// player's stack and result, and the actions
+func checkSameHand(t *testing.T, want, got *Hand, forcedBets bool) {
+	t.Helper()
+	if got.ID != want.ID || got.Variant != want.Variant || got.Board.Codes() != want.Board.Codes() {
+		t.Errorf("Expected hand %s %s on %s, got %s %s on %s", want.ID, want.Variant, want.Board.Codes(), got.ID, got.Variant, got.Board.Codes())
+	}
+	if got.SmallBlind != want.SmallBlind || got.BigBlind != want.BigBlind || got.Button != want.Button {
+		t.Errorf("Expected blinds %d/%d and button %d, got %d/%d and %d",
+			want.SmallBlind, want.BigBlind, want.Button, got.SmallBlind, got.BigBlind, got.Button)
+	}
+	for _, seat := range want.Seats {
+		other := got.GetSeat(seat.Name)
+		if other == nil {
+			t.Errorf("%s is missing", seat.Name)
+			continue
+		}
+		if other.Seat != seat.Seat || other.Stack != seat.Stack {
+			t.Errorf("%s: expected seat %d with %d, got seat %d with %d", seat.Name, seat.Seat, seat.Stack, other.Seat, other.Stack)
+		}
+		if want.Net(seat.Name) != got.Net(seat.Name) {
+			t.Errorf("%s: expected net %d, got %d", seat.Name, want.Net(seat.Name), got.Net(seat.Name))
+		}
+		if want.ReachedShowdown(seat.Name) && !want.Mucked(seat.Name) && other.HoleCards.Codes() != seat.HoleCards.Codes() {
+			t.Errorf("%s: expected shown cards %s, got %s", seat.Name, seat.HoleCards.Codes(), other.HoleCards.Codes())
+		}
+	}
+	if (want.Shuffle == nil) != (got.Shuffle == nil) {
+		t.Errorf("Expected shuffle %+v, got %+v", want.Shuffle, got.Shuffle)
+	} else if want.Shuffle != nil {
+		if got.Shuffle.Commitment != want.Shuffle.Commitment || got.Shuffle.Nonce != want.Shuffle.Nonce || got.Shuffle.Deck.Codes() != want.Shuffle.Deck.Codes() {
+			t.Errorf("Expected shuffle %+v, got %+v", want.Shuffle, got.Shuffle)
+		}
+	}
+
+	actions := func(hand *Hand) []Action {
+		kept := []Action{}
+		for _, action := range hand.Actions {
+			if forcedBets || !action.Type.IsForced() {
+				action.Elapsed = 0
+				kept = append(kept, action)
+			}
+		}
+		return kept
+	}
+	wantActions, gotActions := actions(want), actions(got)
+	if len(wantActions) != len(gotActions) {
+		t.Fatalf("Expected %d actions, got %d", len(wantActions), len(gotActions))
+	}
+	for i := range wantActions {
+		if wantActions[i] != gotActions[i] {
+			t.Errorf("Action %d: expected %+v, got %+v", i, wantActions[i], gotActions[i])
+		}
+	}
+
+	if len(want.Showdown) > 0 && len(got.Showdown) != len(want.Showdown) {
+		t.Fatalf("Expected the showdown %+v, got %+v", want.Showdown, got.Showdown)
+	}
+	for i, reveal := range want.Showdown {
+		if other := got.Showdown[i]; other.Player != reveal.Player || other.Cards.Codes() != reveal.Cards.Codes() {
+			t.Errorf("Reveal %d: expected %s [%s], got %s [%s]", i, reveal.Player, reveal.Cards.Codes(), other.Player, other.Cards.Codes())
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package handhistory
+
+import (
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// Variant names used in Hand.Variant
+const (
+	VariantNLHE = "NLHE" // No-limit Texas Hold'em
+	VariantFLHE = "FLHE" // Fixed-limit Texas Hold'em
+	VariantPLO  = "PLO"  // Pot-limit Omaha
+)
+
+// ActionType identifies what a player did in a recorded hand
+type ActionType int
+
+const (
+	ActionPostAnte  ActionType = iota
+	ActionPostBlind            // A blind the history does not name
+	ActionFold
+	ActionCheck
+	ActionCall
+	ActionBet
+	ActionRaise
+	ActionPostSmallBlind
+	ActionPostBigBlind
+	ActionPostStraddle
+)
+
+// IsBlind reports whether the action posts a blind or a straddle
+func (t ActionType) IsBlind() bool {
+	switch t {
+	case ActionPostBlind, ActionPostSmallBlind, ActionPostBigBlind, ActionPostStraddle:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsForced reports whether the action is a forced bet rather than a decision
+func (t ActionType) IsForced() bool {
+	return t == ActionPostAnte || t.IsBlind()
+}
+
+// Action is one player action in a recorded hand
+type Action struct {
+	Phase   holdem.GamePhase
+	Player  string
+	Type    ActionType
+	Amount  int           // Chips put into the pot by this action
+	AllIn   bool          // Player has no chips left behind
+	Elapsed time.Duration // Time the player took to act, 0 when the history has no timing
+}
+
+// Reveal is what a player did with their cards at showdown
+type Reveal struct {
+	Player string
+	Cards  poker.Cards // Empty when the player mucked
+}
+
+// Seat is a player as seated when the hand started
+type Seat struct {
+	Seat      int
+	Name      string
+	Stack     int
+	HoleCards poker.Cards // Empty when never revealed
+}
+
+// Hand is a format-neutral record of one played hand, whichever site or
+// program produced it. Amounts are in the smallest unit (chips or cents).
+type Hand struct {
+	ID       string
+	UID      string // Globally unique ID of a hand the engine dealt, "" for hands from elsewhere
+	Source   string // Format the hand was read from, e.g. "pokerstars" or "phh"
+	Variant  string
+	Table    string
+	Time     time.Time
+	Currency string // Empty for play or tournament chips
+
+	SmallBlind   int
+	BigBlind     int
+	Ante         int
+	BigBlindAnte bool // The big blind posted the ante for the whole table, dead money in the main pot
+	Button       int  // Button seat number, 0 when unknown
+
+	Seats    []Seat
+	Board    poker.Cards
+	Actions  []Action
+	Showdown []Reveal // Shows and mucks in the order they were made, empty when the history does not say
+
+	Collected map[string]int // Chips each player took from the pot
+	Returned  map[string]int // Uncalled bets given back
+	Rake      int
+	Hero      string // Player whose hole cards were dealt face up to the recorder, if any
+
+	// The dealer's commitment to the shuffle and, once the hand is over, the
+	// deck and nonce that open it; nil for hands dealt elsewhere
+	Shuffle *holdem.ShuffleReveal
+}
+
+// NewHand creates an empty hand record
+func NewHand(source string) *Hand {
+	return &Hand{
+		Source:    source,
+		Variant:   VariantNLHE,
+		Collected: map[string]int{},
+		Returned:  map[string]int{},
+	}
+}
+
+// GetSeat returns the seat of the named player, or nil
+func (h *Hand) GetSeat(name string) *Seat {
+	for i := range h.Seats {
+		if h.Seats[i].Name == name {
+			return &h.Seats[i]
+		}
+	}
+	return nil
+}
+
+// Contributed returns the chips a player put into the pot, net of uncalled bets
+func (h *Hand) Contributed(name string) int {
+	total := 0
+	for _, action := range h.Actions {
+		if action.Player == name {
+			total += action.Amount
+		}
+	}
+	return total - h.Returned[name]
+}
+
+// Net returns a player's profit or loss for the hand
+func (h *Hand) Net(name string) int {
+	return h.Collected[name] - h.Contributed(name)
+}
+
+// Folded reports whether the player folded at some point in the hand
+func (h *Hand) Folded(name string) bool {
+	for _, action := range h.Actions {
+		if action.Player == name && action.Type == ActionFold {
+			return true
+		}
+	}
+	return false
+}
+
+// Mucked reports whether the player threw their cards away at showdown
+func (h *Hand) Mucked(name string) bool {
+	for _, reveal := range h.Showdown {
+		if reveal.Player == name {
+			return len(reveal.Cards) == 0
+		}
+	}
+	return false
+}
+
+// ReachedShowdown reports whether the player was still in when two or more players went to showdown
+func (h *Hand) ReachedShowdown(name string) bool {
+	if h.GetSeat(name) == nil || h.Folded(name) {
+		return false
+	}
+	live := 0
+	for _, seat := range h.Seats {
+		if !h.Folded(seat.Name) {
+			live++
+		}
+	}
+	return live >= 2
+}
+
+// BoardAt returns the community cards visible during a phase
+func (h *Hand) BoardAt(phase holdem.GamePhase) poker.Cards {
+	visible := 0
+	switch phase {
+	case holdem.PhaseFlop:
+		visible = 3
+	case holdem.PhaseTurn:
+		visible = 4
+	case holdem.PhaseRiver, holdem.PhaseShowdown:
+		visible = 5
+	}
+	if visible > len(h.Board) {
+		visible = len(h.Board)
+	}
+	return h.Board[:visible]
+}
+
+// UserActions returns the hand's actions as the engine logs them, so that
+// the engine's readings of action lines apply to recorded hands. Players
+// are numbered from 1 in the order they are seated, and ids gives the
+// number of each name.
+func (h *Hand) UserActions() (actions holdem.UserActions, ids map[string]int) {
+	ids = map[string]int{}
+	for i, seat := range h.Seats {
+		ids[seat.Name] = i + 1
+	}
+	streets := map[holdem.GamePhase]*[]holdem.Action{
+		holdem.PhasePreflop: &actions.Preflop,
+		holdem.PhaseFlop:    &actions.Flop,
+		holdem.PhaseTurn:    &actions.Turn,
+		holdem.PhaseRiver:   &actions.River,
+	}
+	bets, phase := map[string]int{}, holdem.PhasePreflop
+	for _, recorded := range h.Actions {
+		street, ok := streets[recorded.Phase]
+		if !ok {
+			continue
+		}
+		if recorded.Phase != phase {
+			bets, phase = map[string]int{}, recorded.Phase
+		}
+		action := holdem.Action{PlayerID: ids[recorded.Player], Amount: recorded.Amount}
+		switch recorded.Type {
+		case ActionPostAnte:
+			action.Type = holdem.ActionPostAnte
+		case ActionPostBlind:
+			action.Type = holdem.ActionPostBlind
+		case ActionPostSmallBlind:
+			action.Type = holdem.ActionPostSmallBlind
+		case ActionPostBigBlind:
+			action.Type = holdem.ActionPostBigBlind
+		case ActionPostStraddle:
+			action.Type = holdem.ActionPostStraddle
+		case ActionFold:
+			action.Type = holdem.ActionFold
+		case ActionCheck:
+			action.Type = holdem.ActionCheck
+		case ActionCall:
+			action.Type = holdem.ActionCall
+		case ActionBet, ActionRaise:
+			action.Type, action.RaiseTo = holdem.ActionRaise, bets[recorded.Player]+recorded.Amount
+		}
+		if recorded.Type != ActionPostAnte {
+			bets[recorded.Player] += recorded.Amount
+		}
+		*street = append(*street, action)
+	}
+	return actions, ids
+}
+
+// postedBigBlindAnte reports whether the only ante of a hand was posted by
+// the big blind, for the whole table
+func postedBigBlindAnte(hand *Hand) bool {
+	anted, big := []string{}, ""
+	for _, action := range hand.Actions {
+		switch action.Type {
+		case ActionPostAnte:
+			anted = append(anted, action.Player)
+		case ActionPostBigBlind:
+			big = action.Player
+		}
+	}
+	return len(anted) == 1 && anted[0] == big
+}
+
+// ActionTypeToString returns a readable name for a hand history action type
+func ActionTypeToString(actionType ActionType) string {
+	switch actionType {
+	case ActionPostAnte:
+		return "ante"
+	case ActionPostBlind:
+		return "blind"
+	case ActionPostSmallBlind:
+		return "small blind"
+	case ActionPostBigBlind:
+		return "big blind"
+	case ActionPostStraddle:
+		return "straddle"
+	case ActionFold:
+		return "fold"
+	case ActionCheck:
+		return "check"
+	case ActionCall:
+		return "call"
+	case ActionBet:
+		return "bet"
+	case ActionRaise:
+		return "raise"
+	default:
+		return "unknown"
+	}
+}
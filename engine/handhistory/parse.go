@@ -0,0 +1,55 @@
+package handhistory
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ParseFile reads a hand history file, detecting its format from the
+// extension or, failing that, from its contents
+func ParseFile(path string) ([]*Hand, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	hands, err := Parse(data, filepath.Ext(path))
+	if err != nil {
+		return hands, fmt.Errorf("%s: %w", path, err)
+	}
+	return hands, nil
+}
+
+// Parse reads hand history data. ext is an optional file extension hint.
+func Parse(data []byte, ext string) ([]*Hand, error) {
+	if strings.EqualFold(ext, ".phh") || isPHH(data) {
+		hand, err := ParsePHH(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return []*Hand{hand}, nil
+	}
+	if isReplay(data) {
+		hand, err := parseReplay(data)
+		if err != nil {
+			return nil, err
+		}
+		return []*Hand{hand}, nil
+	}
+	if bytes.Contains(data, []byte("PokerStars ")) {
+		return ParsePokerStars(bytes.NewReader(data))
+	}
+	return nil, fmt.Errorf("unrecognised hand history format")
+}
+
+// isPHH looks for the keys every PHH file carries
+func isPHH(data []byte) bool {
+	return bytes.Contains(data, []byte("variant")) && bytes.Contains(data, []byte("starting_stacks"))
+}
+
+// isReplay looks for the keys of the engine's replay files
+func isReplay(data []byte) bool {
+	return bytes.Contains(data, []byte(`"state_hash"`)) && bytes.Contains(data, []byte(`"hand_seed"`))
+}
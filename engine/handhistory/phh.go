@@ -0,0 +1,621 @@
+package handhistory
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// SourcePHH identifies hands parsed from the Poker Hand History (.phh) format
+const SourcePHH = "phh"
+
+var phhVariants = map[string]string{
+	"NT": VariantNLHE,
+	"FT": VariantFLHE,
+	"PO": VariantPLO,
+}
+
+// ParsePHH reads a single hand in the Poker Hand History format.
+// Only the TOML subset the format uses is supported: scalars and flat arrays.
+func ParsePHH(r io.Reader) (*Hand, error) {
+	fields, err := parsePHHFields(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hand := NewHand(SourcePHH)
+	variant := fields.str("variant")
+	hand.Variant = phhVariants[variant]
+	if hand.Variant == "" {
+		return nil, fmt.Errorf("unsupported variant %q", variant)
+	}
+	hand.ID = fields.str("hand")
+	hand.UID = fields.str("_uid")
+	hand.Table = fields.str("table")
+	hand.Currency = fields.str("currency")
+	if commitment := fields.str("_shuffle_commitment"); commitment != "" {
+		hand.Shuffle = &holdem.ShuffleReveal{Commitment: commitment, Nonce: fields.str("_shuffle_nonce")}
+		if deck := fields.str("_shuffle_deck"); deck != "" {
+			if hand.Shuffle.Deck, err = poker.ParseCards(deck); err != nil {
+				return nil, fmt.Errorf("shuffle deck: %w", err)
+			}
+		}
+	}
+
+	stacks, err := fields.ints("starting_stacks")
+	if err != nil {
+		return nil, err
+	}
+	if len(stacks) < 2 {
+		return nil, fmt.Errorf("need at least 2 starting stacks")
+	}
+	blinds, err := fields.intsOf("blinds_or_straddles", len(stacks))
+	if err != nil {
+		return nil, err
+	}
+	antes, err := fields.intsOf("antes", len(stacks))
+	if err != nil {
+		return nil, err
+	}
+	seatNumbers, err := fields.intsOf("seats", len(stacks))
+	if err != nil {
+		return nil, err
+	}
+
+	names := fields.strs("players")
+	for i, stack := range stacks {
+		name := fmt.Sprintf("p%d", i+1)
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+		seat := i + 1
+		if seatNumbers[i] > 0 {
+			seat = seatNumbers[i]
+		}
+		hand.Seats = append(hand.Seats, Seat{Seat: seat, Name: name, Stack: stack})
+	}
+
+	// Players are listed from the small blind, so the button acts last
+	// except heads-up where the small blind is on the button
+	if len(hand.Seats) == 2 {
+		hand.Button = hand.Seats[0].Seat
+	} else {
+		hand.Button = hand.Seats[len(hand.Seats)-1].Seat
+	}
+
+	p := &phhPlayback{hand: hand, street: make([]int, len(stacks)), left: append([]int{}, stacks...)}
+	if phhBigBlindAnte(antes) && len(blinds) >= 2 {
+		// A big blind short of both covers the blind first
+		antes[1] = min(antes[1], max(stacks[1]-blinds[1], 0))
+	}
+	for i, ante := range antes {
+		if ante > 0 {
+			p.post(i, ActionPostAnte, ante, false)
+			hand.Ante = max(hand.Ante, ante)
+		}
+	}
+	for i, blind := range blinds {
+		if blind > 0 {
+			p.post(i, phhBlindType(i), blind, true)
+		}
+	}
+	if len(blinds) >= 2 {
+		hand.SmallBlind, hand.BigBlind = blinds[0], blinds[1]
+	}
+
+	for _, line := range fields.strs("actions") {
+		if err := p.apply(line); err != nil {
+			return nil, fmt.Errorf("action %q: %w", line, err)
+		}
+	}
+	hand.BigBlindAnte = postedBigBlindAnte(hand)
+
+	finishing, err := fields.ints("finishing_stacks")
+	if err != nil {
+		return nil, err
+	}
+	if len(finishing) == len(stacks) {
+		for i, seat := range hand.Seats {
+			contributed := hand.Contributed(seat.Name)
+			hand.Collected[seat.Name] = finishing[i] - seat.Stack + contributed
+		}
+		return hand, nil
+	}
+	if err := Settle(hand); err != nil {
+		return nil, err
+	}
+	return hand, nil
+}
+
+// phhPlayback tracks bets while PHH actions are applied in order
+type phhPlayback struct {
+	hand   *Hand
+	phase  holdem.GamePhase
+	street []int // Chips each player has in on the current street
+	left   []int // Chips each player has behind
+}
+
+func (p *phhPlayback) post(i int, actionType ActionType, amount int, live bool) {
+	amount = min(amount, p.left[i])
+	p.left[i] -= amount
+	if live {
+		p.street[i] += amount
+	}
+	p.hand.Actions = append(p.hand.Actions, Action{
+		Phase:  p.phase,
+		Player: p.hand.Seats[i].Name,
+		Type:   actionType,
+		Amount: amount,
+		AllIn:  p.left[i] == 0,
+	})
+}
+
+func (p *phhPlayback) apply(line string) error {
+	fields := strings.Fields(strings.SplitN(line, "#", 2)[0])
+	if len(fields) < 2 {
+		return fmt.Errorf("too few fields")
+	}
+
+	if fields[0] == "d" {
+		return p.deal(fields[1:])
+	}
+
+	i, err := p.playerIndex(fields[0])
+	if err != nil {
+		return err
+	}
+	name := p.hand.Seats[i].Name
+	highest := 0
+	for _, bet := range p.street {
+		highest = max(highest, bet)
+	}
+
+	action := Action{Phase: p.phase, Player: name}
+	switch fields[1] {
+	case "f":
+		action.Type = ActionFold
+	case "cc":
+		toCall := min(highest-p.street[i], p.left[i])
+		if toCall == 0 {
+			action.Type = ActionCheck
+		} else {
+			action.Type = ActionCall
+			action.Amount = toCall
+		}
+	case "cbr":
+		if len(fields) < 3 {
+			return fmt.Errorf("missing amount")
+		}
+		to, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return fmt.Errorf("invalid amount %q", fields[2])
+		}
+		action.Type = ActionBet
+		if highest > 0 {
+			action.Type = ActionRaise
+		}
+		action.Amount = to - p.street[i]
+		if action.Amount <= 0 || action.Amount > p.left[i] {
+			return fmt.Errorf("invalid bet to %d", to)
+		}
+	case "sm":
+		// "-" mucks, and cards the recorder did not see read as a muck
+		reveal := Reveal{Player: name}
+		if len(fields) > 2 && fields[2] != "-" && !strings.Contains(fields[2], "?") {
+			cards, err := poker.ParseCards(fields[2])
+			if err != nil {
+				return err
+			}
+			p.hand.Seats[i].HoleCards = cards
+			reveal.Cards = cards
+		}
+		p.hand.Showdown = append(p.hand.Showdown, reveal)
+		return nil
+	default:
+		return nil // Stand pat, discards and other draw-game actions
+	}
+
+	p.street[i] += action.Amount
+	p.left[i] -= action.Amount
+	action.AllIn = p.left[i] == 0 && action.Amount > 0
+	p.hand.Actions = append(p.hand.Actions, action)
+	return nil
+}
+
+func (p *phhPlayback) deal(fields []string) error {
+	switch fields[0] {
+	case "dh":
+		if len(fields) < 3 {
+			return fmt.Errorf("missing hole cards")
+		}
+		i, err := p.playerIndex(fields[1])
+		if err != nil {
+			return err
+		}
+		if strings.Contains(fields[2], "?") {
+			return nil
+		}
+		cards, err := poker.ParseCards(fields[2])
+		if err != nil {
+			return err
+		}
+		p.hand.Seats[i].HoleCards = cards
+	case "db":
+		if len(fields) < 2 {
+			return fmt.Errorf("missing board cards")
+		}
+		cards, err := poker.ParseCards(fields[1])
+		if err != nil {
+			return err
+		}
+		p.hand.Board = append(p.hand.Board, cards...)
+		switch {
+		case len(p.hand.Board) >= 5:
+			p.phase = holdem.PhaseRiver
+		case len(p.hand.Board) == 4:
+			p.phase = holdem.PhaseTurn
+		default:
+			p.phase = holdem.PhaseFlop
+		}
+		p.street = make([]int, len(p.street))
+	}
+	return nil
+}
+
+func (p *phhPlayback) playerIndex(token string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(token, "p"))
+	if err != nil || !strings.HasPrefix(token, "p") || n < 1 || n > len(p.hand.Seats) {
+		return 0, fmt.Errorf("unknown player %q", token)
+	}
+	return n - 1, nil
+}
+
+// WritePHH writes a hand in the Poker Hand History format, in a form
+// ParsePHH reads back. Players are listed from the seat after the button,
+// as the format expects, and unknown hole cards are written as "??".
+func WritePHH(w io.Writer, hand *Hand) error {
+	variant := ""
+	for code, name := range phhVariants {
+		if name == hand.Variant {
+			variant = code
+		}
+	}
+	if variant == "" {
+		return fmt.Errorf("hand %s: unsupported variant %q", hand.ID, hand.Variant)
+	}
+
+	seats := phhOrder(hand)
+	index := map[string]int{}
+	antes := make([]int, len(seats))
+	blinds := make([]int, len(seats))
+	for i, seat := range seats {
+		index[seat.Name] = i
+	}
+	for _, action := range hand.Actions {
+		i, ok := index[action.Player]
+		if !ok {
+			return fmt.Errorf("hand %s: action by unseated player %q", hand.ID, action.Player)
+		}
+		switch action.Type {
+		case ActionPostAnte:
+			antes[i] += action.Amount
+		case ActionPostBlind, ActionPostSmallBlind, ActionPostBigBlind, ActionPostStraddle:
+			blinds[i] += action.Amount
+		}
+	}
+
+	holeCards := 2
+	if hand.Variant == VariantPLO {
+		holeCards = 4
+	}
+	actions := []string{}
+	for i, seat := range seats {
+		cards := strings.Repeat("??", holeCards)
+		if len(seat.HoleCards) > 0 {
+			cards = seat.HoleCards.Codes()
+		}
+		actions = append(actions, fmt.Sprintf("d dh p%d %s", i+1, cards))
+	}
+	street := append([]int{}, blinds...) // Chips each player has in on the current street
+	phase := holdem.PhasePreflop
+	dealt := 0
+	deal := func(to holdem.GamePhase) {
+		for phase < to && phase < holdem.PhaseRiver {
+			next := dealt + 1
+			if phase == holdem.PhasePreflop {
+				next = 3
+			}
+			if next > len(hand.Board) {
+				return
+			}
+			actions = append(actions, "d db "+hand.Board[dealt:next].Codes())
+			dealt, phase = next, phase+1
+			street = make([]int, len(seats))
+		}
+	}
+	for _, action := range hand.Actions {
+		i := index[action.Player]
+		deal(action.Phase)
+		switch action.Type {
+		case ActionFold:
+			actions = append(actions, fmt.Sprintf("p%d f", i+1))
+		case ActionCheck, ActionCall:
+			actions = append(actions, fmt.Sprintf("p%d cc", i+1))
+		case ActionBet, ActionRaise:
+			actions = append(actions, fmt.Sprintf("p%d cbr %d", i+1, street[i]+action.Amount))
+		default:
+			continue // Forced bets are in the antes and blinds
+		}
+		street[i] += action.Amount
+	}
+	deal(holdem.PhaseRiver) // Cards run out after everyone is all-in
+	if len(hand.Showdown) > 0 {
+		for _, reveal := range hand.Showdown {
+			i, ok := index[reveal.Player]
+			if !ok {
+				return fmt.Errorf("hand %s: reveal by unseated player %q", hand.ID, reveal.Player)
+			}
+			if len(reveal.Cards) == 0 {
+				actions = append(actions, fmt.Sprintf("p%d sm -", i+1))
+			} else {
+				actions = append(actions, fmt.Sprintf("p%d sm %s", i+1, reveal.Cards.Codes()))
+			}
+		}
+	} else {
+		for i, seat := range seats {
+			if hand.ReachedShowdown(seat.Name) && len(seat.HoleCards) > 0 {
+				actions = append(actions, fmt.Sprintf("p%d sm %s", i+1, seat.HoleCards.Codes()))
+			}
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "variant = %q\n", variant)
+	fmt.Fprintf(out, "antes = %s\n", phhInts(antes))
+	fmt.Fprintf(out, "blinds_or_straddles = %s\n", phhInts(blinds))
+	fmt.Fprintf(out, "min_bet = %d\n", hand.BigBlind)
+	stacks, finishing, seatNumbers, names := []int{}, []int{}, []int{}, []string{}
+	for _, seat := range seats {
+		stacks = append(stacks, seat.Stack)
+		finishing = append(finishing, seat.Stack+hand.Net(seat.Name))
+		seatNumbers = append(seatNumbers, seat.Seat)
+		names = append(names, strconv.Quote(seat.Name))
+	}
+	fmt.Fprintf(out, "starting_stacks = %s\n", phhInts(stacks))
+	fmt.Fprintf(out, "seats = %s\n", phhInts(seatNumbers))
+	fmt.Fprintf(out, "players = [%s]\n", strings.Join(names, ", "))
+	if _, err := strconv.Atoi(hand.ID); err == nil {
+		fmt.Fprintf(out, "hand = %s\n", hand.ID)
+	} else if hand.ID != "" {
+		fmt.Fprintf(out, "hand = %q\n", hand.ID)
+	}
+	if hand.UID != "" {
+		// User-defined fields start with an underscore
+		fmt.Fprintf(out, "_uid = %q\n", hand.UID)
+	}
+	if hand.Shuffle != nil {
+		fmt.Fprintf(out, "_shuffle_commitment = %q\n", hand.Shuffle.Commitment)
+		if hand.Shuffle.Nonce != "" {
+			fmt.Fprintf(out, "_shuffle_nonce = %q\n", hand.Shuffle.Nonce)
+			fmt.Fprintf(out, "_shuffle_deck = %q\n", hand.Shuffle.Deck.Codes())
+		}
+	}
+	if hand.Table != "" {
+		fmt.Fprintf(out, "table = %q\n", hand.Table)
+	}
+	if hand.Currency != "" {
+		fmt.Fprintf(out, "currency = %q\n", hand.Currency)
+	}
+	fmt.Fprintln(out, "actions = [")
+	for _, action := range actions {
+		fmt.Fprintf(out, "  %q,\n", action)
+	}
+	fmt.Fprintln(out, "]")
+	fmt.Fprintf(out, "finishing_stacks = %s\n", phhInts(finishing))
+	return out.Flush()
+}
+
+// phhOrder returns the seats from the one after the button round to the
+// button, or from the button heads-up where it posts the small blind
+func phhOrder(hand *Hand) []Seat {
+	seats := append([]Seat{}, hand.Seats...)
+	sort.SliceStable(seats, func(i, j int) bool { return seats[i].Seat < seats[j].Seat })
+	for i, seat := range seats {
+		if seat.Seat != hand.Button {
+			continue
+		}
+		first := (i + 1) % len(seats)
+		if len(seats) == 2 {
+			first = i
+		}
+		return append(append([]Seat{}, seats[first:]...), seats[:first]...)
+	}
+	return seats
+}
+
+// phhBigBlindAnte reports whether the big blind, listed second, is the only
+// player to ante
+func phhBigBlindAnte(antes []int) bool {
+	for i, ante := range antes {
+		if (ante > 0) != (i == 1) {
+			return false
+		}
+	}
+	return len(antes) > 1
+}
+
+// phhBlindType names the blind posted by the i-th player listed: players
+// are listed from the small blind, and blinds past the big blind are straddles
+func phhBlindType(i int) ActionType {
+	switch i {
+	case 0:
+		return ActionPostSmallBlind
+	case 1:
+		return ActionPostBigBlind
+	default:
+		return ActionPostStraddle
+	}
+}
+
+func phhInts(values []int) string {
+	texts := make([]string, len(values))
+	for i, value := range values {
+		texts[i] = strconv.Itoa(value)
+	}
+	return "[" + strings.Join(texts, ", ") + "]"
+}
+
+// phhFields holds the parsed key/value pairs of a PHH file
+type phhFields map[string][]string
+
+func (f phhFields) str(key string) string {
+	if values := f[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (f phhFields) strs(key string) []string {
+	return f[key]
+}
+
+func (f phhFields) ints(key string) ([]int, error) {
+	values := make([]int, 0, len(f[key]))
+	for _, value := range f[key] {
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid number %q", key, value)
+		}
+		values = append(values, int(n))
+	}
+	return values, nil
+}
+
+// intsOf returns an int array padded with zeros to n entries
+func (f phhFields) intsOf(key string, n int) ([]int, error) {
+	values, err := f.ints(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) > n {
+		return nil, fmt.Errorf("%s: expected %d values, got %d", key, n, len(values))
+	}
+	return append(values, make([]int, n-len(values))...), nil
+}
+
+// parsePHHFields reads top-level "key = value" pairs; arrays may span lines
+func parsePHHFields(r io.Reader) (phhFields, error) {
+	fields := phhFields{}
+	scanner := bufio.NewScanner(r)
+	var key, pending string
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(stripTOMLComment(scanner.Text()))
+		if pending != "" {
+			pending += " " + line
+			if !strings.HasSuffix(pending, "]") {
+				continue
+			}
+			values, err := parseTOMLValue(pending)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			fields[key], pending = values, ""
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid line %q", line)
+		}
+		key, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if strings.HasPrefix(v, "[") && !strings.HasSuffix(v, "]") {
+			pending = v
+			continue
+		}
+		values, err := parseTOMLValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		fields[key] = values
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if pending != "" {
+		return nil, fmt.Errorf("%s: unterminated array", key)
+	}
+	return fields, nil
+}
+
+// parseTOMLValue returns a scalar as a single value and a flat array as its elements
+func parseTOMLValue(v string) ([]string, error) {
+	if !strings.HasPrefix(v, "[") {
+		value, err := unquoteTOML(v)
+		if err != nil {
+			return nil, err
+		}
+		return []string{value}, nil
+	}
+
+	inner := strings.TrimSpace(v[1 : len(v)-1])
+	values := []string{}
+	for inner != "" {
+		var item string
+		if inner[0] == '"' || inner[0] == '\'' {
+			end := strings.IndexByte(inner[1:], inner[0])
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			item, inner = inner[:end+2], inner[end+2:]
+		} else {
+			item, inner, _ = strings.Cut(inner, ",")
+			inner = "," + inner
+		}
+		value, err := unquoteTOML(strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		inner = strings.TrimSpace(inner)
+		inner = strings.TrimSpace(strings.TrimPrefix(inner, ","))
+	}
+	return values, nil
+}
+
+func unquoteTOML(v string) (string, error) {
+	if len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'' {
+		return v[1 : len(v)-1], nil
+	}
+	if strings.HasPrefix(v, `"`) {
+		return strconv.Unquote(v)
+	}
+	return v, nil
+}
+
+// stripTOMLComment removes a trailing comment that is not inside a string
+func stripTOMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,186 @@
+package handhistory
+
+import (
+	"strings"
+	"testing"
+)
+
+const phhSample = `# Hand from an external tool
+variant = "NT"
+antes = [0, 0, 0]
+blinds_or_straddles = [1, 2, 0]
+min_bet = 2
+starting_stacks = [200, 200, 200]
+players = ["Alice", "Bob", "Carol"]
+hand = 7
+actions = [
+  "d dh p1 AcAs",
+  "d dh p2 5h6h",
+  "d dh p3 ????",
+  "p3 f",  # folds to the blinds
+  "p1 cbr 6",
+  "p2 cc",
+  "d db 2h3h4d",
+  "p1 cbr 194",
+  "p2 cc",
+  "d db Ks",
+  "d db 9c",
+  "p1 sm AcAs",
+  "p2 sm 5h6h",
+]
+`
+
+func TestParsePHHSettlesShowdown(t *testing.T) {
+	hand, err := ParsePHH(strings.NewReader(phhSample))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if hand.ID != "7" || hand.Variant != VariantNLHE || hand.SmallBlind != 1 || hand.BigBlind != 2 {
+		t.Errorf("Unexpected header: %+v", hand)
+	}
+	if hand.Button != 3 {
+		t.Errorf("Expected button on the last player, got %d", hand.Button)
+	}
+	if hand.Board.Codes() != "2h3h4dKs9c" {
+		t.Errorf("Expected full board, got %s", hand.Board.Codes())
+	}
+	// Bob's straight beats Alice's aces
+	if hand.Net("Bob") != 200 || hand.Net("Alice") != -200 || hand.Net("Carol") != 0 {
+		t.Errorf("Unexpected nets: Alice %d Bob %d Carol %d", hand.Net("Alice"), hand.Net("Bob"), hand.Net("Carol"))
+	}
+	if hand.GetSeat("Bob").HoleCards.Codes() != "5h6h" {
+		t.Error("Expected Bob's cards to be known")
+	}
+}
+
+func TestParsePHHNamesBlinds(t *testing.T) {
+	input := `variant = 'NT'
+starting_stacks = [100, 100, 100]
+blinds_or_straddles = [1, 2, 4]
+actions = ['d dh p1 ????', 'd dh p2 ????', 'd dh p3 ????', 'p1 f', 'p2 f']
+`
+	hand, err := ParsePHH(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []ActionType{ActionPostSmallBlind, ActionPostBigBlind, ActionPostStraddle, ActionFold, ActionFold}
+	if len(hand.Actions) != len(want) {
+		t.Fatalf("Expected the blinds, the straddle and two folds, got %+v", hand.Actions)
+	}
+	for i, action := range hand.Actions {
+		if action.Type != want[i] || action.Type.IsForced() != (i < 3) {
+			t.Errorf("Action %d: expected %s, got %s", i, ActionTypeToString(want[i]), ActionTypeToString(action.Type))
+		}
+	}
+}
+
+func TestParsePHHFinishingStacks(t *testing.T) {
+	input := `variant = 'NT'
+starting_stacks = [100, 100]
+blinds_or_straddles = [1, 2]
+actions = ['d dh p1 ????', 'd dh p2 ????', 'p1 cbr 10', 'p2 f']
+finishing_stacks = [102, 98]
+`
+	hand, err := ParsePHH(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if hand.Net("p1") != 2 || hand.Net("p2") != -2 {
+		t.Errorf("Expected nets +2/-2, got %d/%d", hand.Net("p1"), hand.Net("p2"))
+	}
+	if hand.Button != 1 {
+		t.Errorf("Expected heads-up button on the small blind, got %d", hand.Button)
+	}
+}
+
+func TestParsePHHUncalledBetAndSidePot(t *testing.T) {
+	input := `variant = "NT"
+starting_stacks = [50, 300, 300]
+blinds_or_straddles = [1, 2, 0]
+players = ["Short", "Big", "Deep"]
+actions = [
+  "d dh p1 KhKd", "d dh p2 QhQd", "d dh p3 AsAd",
+  "p3 cbr 10", "p1 cbr 50", "p2 cbr 150", "p3 cbr 300", "p2 f",
+  "d db 2c7d8s", "d db 3c", "d db 4h",
+]
+`
+	hand, err := ParsePHH(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Deep's raise to 300 is called by nobody: 150 comes back
+	if hand.Returned["Deep"] != 150 {
+		t.Errorf("Expected 150 returned to Deep, got %d", hand.Returned["Deep"])
+	}
+	if hand.Net("Deep") != 200 || hand.Net("Short") != -50 || hand.Net("Big") != -150 {
+		t.Errorf("Unexpected nets: Short %d Big %d Deep %d", hand.Net("Short"), hand.Net("Big"), hand.Net("Deep"))
+	}
+}
+
+func TestParsePHHBigBlindAnte(t *testing.T) {
+	// The big blind antes for the table and is all in, 5 short of the ante
+	input := `variant = "NT"
+starting_stacks = [1000, 15, 1000]
+blinds_or_straddles = [5, 10, 0]
+antes = [0, 10, 0]
+players = ["Small", "Big", "Button"]
+actions = [
+  "d dh p1 QhQd", "d dh p2 AsAd", "d dh p3 KhKd",
+  "p3 cbr 100", "p1 cc",
+  "d db 2c7d8s", "p1 cc", "p3 cc", "d db 3c", "p1 cc", "p3 cc", "d db 4h", "p1 cc", "p3 cc",
+]
+`
+	hand, err := ParsePHH(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !hand.BigBlindAnte || hand.Actions[0].Type != ActionPostAnte || hand.Actions[0].Amount != 5 {
+		t.Fatalf("Expected the big blind's 5 chip ante after the blind, got %+v", hand.Actions[0])
+	}
+	// Big wins the ante and 10 from each player; Button the side pot
+	if hand.Collected["Big"] != 35 || hand.Net("Button") != 80 || hand.Net("Small") != -100 {
+		t.Errorf("Expected a 35 chip main pot to Big, got %v", hand.Collected)
+	}
+
+	game, err := ReconstructGame(hand, len(hand.Actions))
+	if err != nil {
+		t.Fatalf("ReconstructGame failed: %v", err)
+	}
+	awards, err := game.AwardPot()
+	if err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	if len(awards) != 2 || awards[0].Amount != 35 || awards[1].Amount != 180 {
+		t.Errorf("Expected the engine to split the pots the same, got %+v", awards)
+	}
+}
+
+func TestParsePHHErrors(t *testing.T) {
+	testCases := map[string]string{
+		"variant":      "variant = \"XX\"\nstarting_stacks = [1, 2]\n",
+		"stacks":       "variant = \"NT\"\nstarting_stacks = [100]\n",
+		"player":       "variant = \"NT\"\nstarting_stacks = [100, 100]\nactions = [\"p9 f\"]\n",
+		"unterminated": "variant = \"NT\"\nstarting_stacks = [100,\n",
+		"showdown":     "variant = \"NT\"\nstarting_stacks = [100, 100]\nblinds_or_straddles = [1, 2]\nactions = [\"p1 cc\", \"p2 cc\"]\n",
+	}
+	for name, input := range testCases {
+		if _, err := ParsePHH(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestParseDetectsFormat(t *testing.T) {
+	hands, err := Parse([]byte(phhSample), "")
+	if err != nil || len(hands) != 1 || hands[0].Source != SourcePHH {
+		t.Errorf("Expected PHH detection, got %v (%v)", hands, err)
+	}
+	hands, err = Parse([]byte(pokerStarsSample), ".txt")
+	if err != nil || len(hands) != 2 || hands[0].Source != SourcePokerStars {
+		t.Errorf("Expected PokerStars detection, got %v (%v)", hands, err)
+	}
+	if _, err := Parse([]byte("hello"), ".txt"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
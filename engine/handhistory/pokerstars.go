@@ -0,0 +1,668 @@
+package handhistory
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// SourcePokerStars identifies hands parsed from PokerStars text histories
+const SourcePokerStars = "pokerstars"
+
+var (
+	psHeaderRe   = regexp.MustCompile(`^PokerStars (?:Zoom |Home Game )?Hand #(\d+):`)
+	psBlindsRe   = regexp.MustCompile(`\(([$€£]?[\d.,]+)/([$€£]?[\d.,]+)`)
+	psTimeRe     = regexp.MustCompile(`(\d{4}/\d{2}/\d{2} \d{1,2}:\d{2}:\d{2})`)
+	psTableRe    = regexp.MustCompile(`^Table '([^']*)'.*Seat #(\d+) is the button`)
+	psSeatRe     = regexp.MustCompile(`^Seat (\d+): (.+) \(([$€£]?[\d.,]+) in chips`)
+	psDealtRe    = regexp.MustCompile(`^Dealt to (.+?) \[([^\]]+)\]`)
+	psUncalledRe = regexp.MustCompile(`^Uncalled bet \(([$€£]?[\d.,]+)\) returned to (.+)$`)
+	psCollectRe  = regexp.MustCompile(`^(.+?) collected ([$€£]?[\d.,]+) from`)
+	psPotRe      = regexp.MustCompile(`^Total pot [$€£]?[\d.,]+.*\| Rake ([$€£]?[\d.,]+)`)
+	psSummaryRe  = regexp.MustCompile(`^Seat \d+: (.+?) (?:\([^)]*\) )*(?:showed|mucked) \[([^\]]+)\]`)
+	psBracketsRe = regexp.MustCompile(`\[([^\]]+)\]`)
+	psCommitRe   = regexp.MustCompile(`^Shuffle commitment ([0-9a-f]+)$`)
+	psRevealRe   = regexp.MustCompile(`^Shuffle nonce ([0-9a-f]+) deck \[([^\]]+)\]$`)
+)
+
+// ParsePokerStars reads every hand in a PokerStars text hand history
+func ParsePokerStars(r io.Reader) ([]*Hand, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	hands := []*Hand{}
+	var block []string
+	flush := func() error {
+		if len(block) == 0 {
+			return nil
+		}
+		hand, err := parsePokerStarsHand(block)
+		block = nil
+		if err != nil {
+			return err
+		}
+		hands = append(hands, hand)
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if psHeaderRe.MatchString(line) {
+			if err := flush(); err != nil {
+				return hands, err
+			}
+		}
+		if line == "" || (len(block) == 0 && !psHeaderRe.MatchString(line)) {
+			continue
+		}
+		block = append(block, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return hands, err
+	}
+	if err := flush(); err != nil {
+		return hands, err
+	}
+	return hands, nil
+}
+
+// psParser holds the running state while a single hand is parsed
+type psParser struct {
+	hand      *Hand
+	phase     holdem.GamePhase
+	street    map[string]int // Chips put in on the current street
+	names     []string       // Seat names, longest first for prefix matching
+	cents     bool
+	inSummary bool
+}
+
+func parsePokerStarsHand(lines []string) (*Hand, error) {
+	header := lines[0]
+	p := &psParser{
+		hand:   NewHand(SourcePokerStars),
+		phase:  holdem.PhasePreflop,
+		street: map[string]int{},
+	}
+	hand := p.hand
+
+	hand.ID = psHeaderRe.FindStringSubmatch(header)[1]
+	switch {
+	case strings.Contains(header, "Omaha") && strings.Contains(header, "Pot Limit"):
+		hand.Variant = VariantPLO
+	case strings.Contains(header, "Hold'em") && strings.Contains(header, "No Limit"):
+		hand.Variant = VariantNLHE
+	case strings.Contains(header, "Hold'em") && strings.Contains(header, "Limit"):
+		hand.Variant = VariantFLHE
+	default:
+		return nil, fmt.Errorf("hand %s: unsupported game: %s", hand.ID, header)
+	}
+
+	blinds := psBlindsRe.FindStringSubmatch(header)
+	if blinds == nil {
+		return nil, fmt.Errorf("hand %s: missing blinds", hand.ID)
+	}
+	if strings.ContainsAny(blinds[1], "$€£") {
+		p.cents = true
+		hand.Currency = string([]rune(blinds[1])[0])
+	}
+	var err error
+	if hand.SmallBlind, err = p.amount(blinds[1]); err != nil {
+		return nil, fmt.Errorf("hand %s: %w", hand.ID, err)
+	}
+	if hand.BigBlind, err = p.amount(blinds[2]); err != nil {
+		return nil, fmt.Errorf("hand %s: %w", hand.ID, err)
+	}
+	if m := psTimeRe.FindStringSubmatch(header); m != nil {
+		hand.Time, _ = time.Parse("2006/01/02 15:04:05", m[1])
+	}
+
+	for _, line := range lines[1:] {
+		if err := p.parseLine(line); err != nil {
+			return nil, fmt.Errorf("hand %s: %w", hand.ID, err)
+		}
+	}
+	if len(hand.Seats) < 2 {
+		return nil, fmt.Errorf("hand %s: need at least 2 seats", hand.ID)
+	}
+	hand.BigBlindAnte = postedBigBlindAnte(hand)
+	return hand, nil
+}
+
+func (p *psParser) parseLine(line string) error {
+	hand := p.hand
+
+	if strings.HasPrefix(line, "*** ") {
+		return p.parseStreet(line)
+	}
+	if m := psTableRe.FindStringSubmatch(line); m != nil {
+		hand.Table = m[1]
+		hand.Button, _ = strconv.Atoi(m[2])
+		return nil
+	}
+	if p.inSummary {
+		if m := psPotRe.FindStringSubmatch(line); m != nil {
+			rake, err := p.amount(m[1])
+			hand.Rake = rake
+			return err
+		}
+		if m := psSummaryRe.FindStringSubmatch(line); m != nil {
+			return p.reveal(m[1], m[2])
+		}
+		// The engine's own lines, absent from PokerStars' histories
+		if m := psCommitRe.FindStringSubmatch(line); m != nil {
+			hand.Shuffle = &holdem.ShuffleReveal{Commitment: m[1]}
+		}
+		if m := psRevealRe.FindStringSubmatch(line); m != nil && hand.Shuffle != nil {
+			deck, err := poker.ParseCards(m[2])
+			hand.Shuffle.Deck, hand.Shuffle.Nonce = deck, m[1]
+			return err
+		}
+		return nil
+	}
+	if m := psSeatRe.FindStringSubmatch(line); m != nil && len(hand.Actions) == 0 {
+		seatNumber, _ := strconv.Atoi(m[1])
+		stack, err := p.amount(m[3])
+		if err != nil {
+			return err
+		}
+		hand.Seats = append(hand.Seats, Seat{Seat: seatNumber, Name: m[2], Stack: stack})
+		p.names = append(p.names, m[2])
+		sort.SliceStable(p.names, func(i, j int) bool { return len(p.names[i]) > len(p.names[j]) })
+		return nil
+	}
+	if m := psDealtRe.FindStringSubmatch(line); m != nil {
+		hand.Hero = m[1]
+		return p.reveal(m[1], m[2])
+	}
+	if m := psUncalledRe.FindStringSubmatch(line); m != nil {
+		returned, err := p.amount(m[1])
+		hand.Returned[m[2]] += returned
+		return err
+	}
+	if m := psCollectRe.FindStringSubmatch(line); m != nil && hand.GetSeat(m[1]) != nil {
+		collected, err := p.amount(m[2])
+		hand.Collected[m[1]] += collected
+		return err
+	}
+
+	name, rest := p.splitPlayer(line)
+	if name == "" {
+		return nil // Chat, connection notices and other lines we do not need
+	}
+	return p.parseAction(name, rest)
+}
+
+func (p *psParser) parseStreet(line string) error {
+	switch {
+	case strings.HasPrefix(line, "*** FLOP"):
+		p.newStreet(holdem.PhaseFlop)
+	case strings.HasPrefix(line, "*** TURN"):
+		p.newStreet(holdem.PhaseTurn)
+	case strings.HasPrefix(line, "*** RIVER"):
+		p.newStreet(holdem.PhaseRiver)
+	case strings.HasPrefix(line, "*** SHOW DOWN"):
+		p.phase = holdem.PhaseShowdown
+		return nil
+	case strings.HasPrefix(line, "*** SUMMARY"):
+		p.inSummary = true
+		return nil
+	default:
+		return nil
+	}
+
+	// The last bracket group on a street line holds the newly dealt cards
+	groups := psBracketsRe.FindAllStringSubmatch(line, -1)
+	if len(groups) == 0 {
+		return fmt.Errorf("missing board cards: %s", line)
+	}
+	cards, err := poker.ParseCards(groups[len(groups)-1][1])
+	if err != nil {
+		return err
+	}
+	p.hand.Board = append(p.hand.Board, cards...)
+	return nil
+}
+
+func (p *psParser) newStreet(phase holdem.GamePhase) {
+	p.phase = phase
+	p.street = map[string]int{}
+}
+
+func (p *psParser) parseAction(name, rest string) error {
+	allIn := strings.HasSuffix(rest, "and is all-in")
+	rest = strings.TrimSpace(strings.TrimSuffix(rest, "and is all-in"))
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	action := Action{Phase: p.phase, Player: name, AllIn: allIn}
+	switch {
+	case strings.HasPrefix(rest, "posts the ante"):
+		action.Type = ActionPostAnte
+		amount, err := p.amount(fields[len(fields)-1])
+		if err != nil {
+			return err
+		}
+		action.Amount = amount
+		if p.hand.Ante == 0 {
+			p.hand.Ante = amount
+		}
+		p.hand.Actions = append(p.hand.Actions, action)
+		return nil
+	case strings.HasPrefix(rest, "posts"):
+		switch {
+		case strings.HasPrefix(rest, "posts small blind"):
+			action.Type = ActionPostSmallBlind
+		case strings.HasPrefix(rest, "posts big blind"):
+			action.Type = ActionPostBigBlind
+		default:
+			action.Type = ActionPostBlind
+		}
+		amount, err := p.amount(fields[len(fields)-1])
+		if err != nil {
+			return err
+		}
+		action.Amount = amount
+		// A dead small blind posted together with the big blind does not count toward the bet
+		if strings.HasPrefix(rest, "posts small & big blinds") {
+			p.street[name] += p.hand.BigBlind
+		} else {
+			p.street[name] += amount
+		}
+	case fields[0] == "folds":
+		action.Type = ActionFold
+	case fields[0] == "checks":
+		action.Type = ActionCheck
+	case fields[0] == "calls" || fields[0] == "bets":
+		action.Type = ActionCall
+		if fields[0] == "bets" {
+			action.Type = ActionBet
+		}
+		if len(fields) < 2 {
+			return fmt.Errorf("missing amount: %s", rest)
+		}
+		amount, err := p.amount(fields[1])
+		if err != nil {
+			return err
+		}
+		action.Amount = amount
+		p.street[name] += amount
+	case fields[0] == "raises":
+		// "raises X to Y": Y is the player's total for the street
+		if len(fields) < 4 {
+			return fmt.Errorf("malformed raise: %s", rest)
+		}
+		to, err := p.amount(fields[3])
+		if err != nil {
+			return err
+		}
+		action.Type = ActionRaise
+		action.Amount = to - p.street[name]
+		p.street[name] = to
+	case fields[0] == "shows":
+		m := psBracketsRe.FindStringSubmatch(rest)
+		if m == nil {
+			return nil
+		}
+		if err := p.reveal(name, m[1]); err != nil {
+			return err
+		}
+		if p.phase == holdem.PhaseShowdown {
+			p.hand.Showdown = append(p.hand.Showdown, Reveal{Player: name, Cards: p.hand.GetSeat(name).HoleCards})
+		}
+		return nil
+	case fields[0] == "mucks" && p.phase == holdem.PhaseShowdown:
+		p.hand.Showdown = append(p.hand.Showdown, Reveal{Player: name})
+		return nil
+	default:
+		return nil // mucks, doesn't show, sits out, ...
+	}
+
+	p.hand.Actions = append(p.hand.Actions, action)
+	return nil
+}
+
+// splitPlayer splits "Name: rest" using the known seat names, which may contain colons
+func (p *psParser) splitPlayer(line string) (string, string) {
+	for _, name := range p.names {
+		if rest, ok := strings.CutPrefix(line, name+": "); ok {
+			return name, rest
+		}
+	}
+	return "", ""
+}
+
+func (p *psParser) reveal(name, cardText string) error {
+	seat := p.hand.GetSeat(name)
+	if seat == nil {
+		return nil
+	}
+	cards, err := poker.ParseCards(cardText)
+	if err != nil {
+		return err
+	}
+	seat.HoleCards = cards
+	return nil
+}
+
+// amount parses a chip or money amount into the smallest unit
+func (p *psParser) amount(s string) (int, error) {
+	s = strings.NewReplacer("$", "", "€", "", "£", "", ",", "").Replace(s)
+	if !p.cents {
+		if value, err := strconv.Atoi(s); err == nil {
+			return value, nil
+		}
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if p.cents {
+		value *= 100
+	}
+	return int(math.Round(value)), nil
+}
+
+// psGames names each variant the way PokerStars headers do
+var psGames = map[string]string{
+	VariantNLHE: "Hold'em No Limit",
+	VariantFLHE: "Hold'em Limit",
+	VariantPLO:  "Omaha Pot Limit",
+}
+
+// psCurrencies names the currency of money games in PokerStars headers
+var psCurrencies = map[string]string{"$": "USD", "€": "EUR", "£": "GBP"}
+
+// WritePokerStars writes hands as a PokerStars text hand history, a blank
+// line between hands, in a form ParsePokerStars reads back. Hand IDs must
+// be numeric. Times are written in UTC.
+func WritePokerStars(w io.Writer, hands ...*Hand) error {
+	out := bufio.NewWriter(w)
+	for i, hand := range hands {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		if err := writePokerStarsHand(out, hand); err != nil {
+			return err
+		}
+	}
+	return out.Flush()
+}
+
+func writePokerStarsHand(out io.Writer, hand *Hand) error {
+	if _, err := strconv.ParseUint(hand.ID, 10, 64); err != nil {
+		return fmt.Errorf("hand %q: PokerStars hand IDs must be numeric", hand.ID)
+	}
+	game, ok := psGames[hand.Variant]
+	if !ok {
+		return fmt.Errorf("hand %s: unsupported variant %q", hand.ID, hand.Variant)
+	}
+	w := &psWriter{out: out, hand: hand, street: map[string]int{}}
+
+	blinds := w.amount(hand.SmallBlind) + "/" + w.amount(hand.BigBlind)
+	if code := psCurrencies[hand.Currency]; code != "" {
+		blinds += " " + code
+	}
+	header := fmt.Sprintf("PokerStars Hand #%s:  %s (%s)", hand.ID, game, blinds)
+	if !hand.Time.IsZero() {
+		header += " - " + hand.Time.UTC().Format("2006/01/02 15:04:05") + " UTC"
+	}
+	w.line(header)
+	maxSeat := 2
+	for _, seat := range hand.Seats {
+		maxSeat = max(maxSeat, seat.Seat)
+	}
+	w.line("Table '%s' %d-max Seat #%d is the button", hand.Table, psTableSize(maxSeat), hand.Button)
+	for _, seat := range hand.Seats {
+		w.line("Seat %d: %s (%s in chips)", seat.Seat, seat.Name, w.amount(seat.Stack))
+	}
+
+	dealt := false
+	for _, action := range hand.Actions {
+		if !action.Type.IsForced() && !dealt {
+			w.dealHoleCards()
+			dealt = true
+		}
+		w.advance(action.Phase)
+		w.action(action)
+	}
+	if !dealt {
+		w.dealHoleCards()
+	}
+	w.advance(holdem.PhaseRiver) // Cards run out after everyone is all-in
+
+	for _, seat := range hand.Seats {
+		if returned := hand.Returned[seat.Name]; returned > 0 {
+			w.line("Uncalled bet (%s) returned to %s", w.amount(returned), seat.Name)
+		}
+	}
+	if len(hand.Showdown) > 0 {
+		w.line("*** SHOW DOWN ***")
+		for _, reveal := range hand.Showdown {
+			if len(reveal.Cards) == 0 {
+				w.line("%s: mucks hand", reveal.Player)
+			} else {
+				w.line("%s: shows [%s]", reveal.Player, psCards(reveal.Cards))
+			}
+		}
+	} else {
+		showdown := false
+		for _, seat := range hand.Seats {
+			if hand.ReachedShowdown(seat.Name) && len(seat.HoleCards) > 0 {
+				if !showdown {
+					w.line("*** SHOW DOWN ***")
+					showdown = true
+				}
+				w.line("%s: shows [%s]", seat.Name, psCards(seat.HoleCards))
+			}
+		}
+	}
+	pot := hand.Rake
+	for _, seat := range hand.Seats {
+		if collected := hand.Collected[seat.Name]; collected > 0 {
+			w.line("%s collected %s from pot", seat.Name, w.amount(collected))
+			pot += collected
+		}
+	}
+
+	w.line("*** SUMMARY ***")
+	w.line("Total pot %s | Rake %s", w.amount(pot), w.amount(hand.Rake))
+	if len(hand.Board) > 0 {
+		w.line("Board [%s]", psCards(hand.Board))
+	}
+	for _, seat := range hand.Seats {
+		w.line("Seat %d: %s%s %s", seat.Seat, seat.Name, w.positions(seat), w.outcome(seat))
+	}
+	if shuffle := hand.Shuffle; shuffle != nil {
+		w.line("Shuffle commitment %s", shuffle.Commitment)
+		if shuffle.Nonce != "" {
+			w.line("Shuffle nonce %s deck [%s]", shuffle.Nonce, psCards(shuffle.Deck))
+		}
+	}
+	return w.err
+}
+
+// psWriter holds the running state while a single hand is written
+type psWriter struct {
+	out     io.Writer
+	hand    *Hand
+	phase   holdem.GamePhase
+	street  map[string]int // Chips put in on the current street
+	highest int            // Largest street total so far
+	small   string         // Player who posted the small blind
+	big     string         // Player who posted the big blind
+	err     error
+}
+
+func (w *psWriter) line(format string, args ...any) {
+	if w.err == nil {
+		_, w.err = fmt.Fprintf(w.out, format+"\n", args...)
+	}
+}
+
+func (w *psWriter) dealHoleCards() {
+	w.line("*** HOLE CARDS ***")
+	if seat := w.hand.GetSeat(w.hand.Hero); seat != nil && len(seat.HoleCards) > 0 {
+		w.line("Dealt to %s [%s]", seat.Name, psCards(seat.HoleCards))
+	}
+}
+
+// advance writes the street lines up to phase, as far as the board goes
+func (w *psWriter) advance(phase holdem.GamePhase) {
+	streets := []struct {
+		phase holdem.GamePhase
+		name  string
+		cards int
+	}{
+		{holdem.PhaseFlop, "FLOP", 3},
+		{holdem.PhaseTurn, "TURN", 4},
+		{holdem.PhaseRiver, "RIVER", 5},
+	}
+	board := w.hand.Board
+	for _, street := range streets {
+		if street.phase <= w.phase || street.phase > phase || len(board) < street.cards {
+			continue
+		}
+		if street.cards == 3 {
+			w.line("*** FLOP *** [%s]", psCards(board[:3]))
+		} else {
+			w.line("*** %s *** [%s] [%s]", street.name, psCards(board[:street.cards-1]), psCards(board[street.cards-1:street.cards]))
+		}
+		w.phase = street.phase
+		w.street = map[string]int{}
+		w.highest = 0
+	}
+}
+
+func (w *psWriter) action(action Action) {
+	text := ""
+	switch action.Type {
+	case ActionPostAnte:
+		text = "posts the ante " + w.amount(action.Amount)
+	case ActionPostSmallBlind:
+		w.small = action.Player
+		text = "posts small blind " + w.amount(action.Amount)
+	case ActionPostBigBlind:
+		w.big = action.Player
+		text = "posts big blind " + w.amount(action.Amount)
+	case ActionPostStraddle:
+		text = "posts big blind " + w.amount(action.Amount) // PokerStars has no straddles
+	case ActionPostBlind:
+		switch {
+		case w.small == "" && action.Amount <= w.hand.SmallBlind && w.hand.SmallBlind < w.hand.BigBlind:
+			w.small = action.Player
+			text = "posts small blind " + w.amount(action.Amount)
+		case w.big == "":
+			w.big = action.Player
+			text = "posts big blind " + w.amount(action.Amount)
+		default:
+			text = "posts big blind " + w.amount(action.Amount) // A straddle or a missed blind
+		}
+	case ActionFold:
+		text = "folds"
+	case ActionCheck:
+		text = "checks"
+	case ActionCall:
+		text = "calls " + w.amount(action.Amount)
+	case ActionBet:
+		text = "bets " + w.amount(action.Amount)
+	case ActionRaise:
+		to := w.street[action.Player] + action.Amount
+		text = fmt.Sprintf("raises %s to %s", w.amount(to-w.highest), w.amount(to))
+	}
+	if action.Type != ActionPostAnte {
+		w.street[action.Player] += action.Amount
+		w.highest = max(w.highest, w.street[action.Player])
+	}
+	if action.AllIn {
+		text += " and is all-in"
+	}
+	w.line("%s: %s", action.Player, text)
+}
+
+// positions returns the summary tags of a seat, e.g. " (button) (small blind)"
+func (w *psWriter) positions(seat Seat) string {
+	tags := ""
+	if seat.Seat == w.hand.Button {
+		tags += " (button)"
+	}
+	switch seat.Name {
+	case w.small:
+		tags += " (small blind)"
+	case w.big:
+		tags += " (big blind)"
+	}
+	return tags
+}
+
+// outcome describes how a seat's hand ended in the summary
+func (w *psWriter) outcome(seat Seat) string {
+	hand := w.hand
+	collected := hand.Collected[seat.Name]
+	if hand.Folded(seat.Name) {
+		for _, action := range hand.Actions {
+			if action.Player == seat.Name && action.Type == ActionFold {
+				switch action.Phase {
+				case holdem.PhaseFlop:
+					return "folded on the Flop"
+				case holdem.PhaseTurn:
+					return "folded on the Turn"
+				case holdem.PhaseRiver:
+					return "folded on the River"
+				}
+			}
+		}
+		return "folded before Flop"
+	}
+	if hand.ReachedShowdown(seat.Name) && len(seat.HoleCards) > 0 && !hand.Mucked(seat.Name) {
+		if collected > 0 {
+			return fmt.Sprintf("showed [%s] and won (%s)", psCards(seat.HoleCards), w.amount(collected))
+		}
+		return fmt.Sprintf("showed [%s] and lost", psCards(seat.HoleCards))
+	}
+	if collected > 0 {
+		return fmt.Sprintf("collected (%s)", w.amount(collected))
+	}
+	return "mucked"
+}
+
+// amount formats chips with thousands separators, or cents as money
+func (w *psWriter) amount(value int) string {
+	if w.hand.Currency != "" {
+		return fmt.Sprintf("%s%d.%02d", w.hand.Currency, value/100, value%100)
+	}
+	digits := strconv.Itoa(value)
+	for i := len(digits) - 3; i > 0 && digits[i-1] != '-'; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return digits
+}
+
+// psTableSize returns the smallest usual table size seating every seat number
+func psTableSize(maxSeat int) int {
+	for _, size := range []int{2, 6, 9} {
+		if maxSeat <= size {
+			return size
+		}
+	}
+	return 10
+}
+
+// psCards formats cards the way PokerStars lists them, e.g. "Ah Kd"
+func psCards(cards poker.Cards) string {
+	codes := make([]string, len(cards))
+	for i, card := range cards {
+		codes[i] = poker.Cards{card}.Codes()
+	}
+	return strings.Join(codes, " ")
+}
@@ -0,0 +1,142 @@
+package handhistory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+const pokerStarsSample = `PokerStars Hand #230000000001:  Hold'em No Limit ($0.01/$0.02 USD) - 2021/06/01 20:15:30 ET
+Table 'Alcyone II' 6-max Seat #1 is the button
+Seat 1: Hero ($2.00 in chips)
+Seat 2: Villain: One ($2.50 in chips)
+Seat 3: Third ($1.00 in chips)
+Villain: One: posts small blind $0.01
+Third: posts big blind $0.02
+*** HOLE CARDS ***
+Dealt to Hero [Ah Kd]
+Hero: raises $0.04 to $0.06
+Villain: One: calls $0.05
+Third: folds
+*** FLOP *** [2c 7d Ks]
+Villain: One: checks
+Hero: bets $0.10
+Villain: One: calls $0.10
+*** TURN *** [2c 7d Ks] [9h]
+Villain: One: checks
+Hero: bets $1.84 and is all-in
+Villain: One: folds
+Uncalled bet ($1.84) returned to Hero
+Hero collected $0.33 from pot
+Hero: doesn't show hand
+*** SUMMARY ***
+Total pot $0.34 | Rake $0.01
+Board [2c 7d Ks 9h]
+Seat 1: Hero (button) collected ($0.33)
+
+PokerStars Hand #230000000002: Tournament #3000000, $1.00+$0.10 USD Hold'em No Limit - Level II (15/30) - 2021/06/01 20:16:02 ET
+Table '3000000 1' 9-max Seat #2 is the button
+Seat 1: Hero (1,500 in chips)
+Seat 2: Villain: One (1,500 in chips)
+Hero: posts the ante 5
+Villain: One: posts the ante 5
+Villain: One: posts small blind 15
+Hero: posts big blind 30
+*** HOLE CARDS ***
+Dealt to Hero [Qs Qh]
+Villain: One: raises 1,465 to 1,495 and is all-in
+Hero: calls 1,465 and is all-in
+*** FLOP *** [2c 3d 4h]
+*** TURN *** [2c 3d 4h] [5s]
+*** RIVER *** [2c 3d 4h 5s] [Jc]
+*** SHOW DOWN ***
+Villain: One: shows [Ac Kc] (a straight, Ace to Five)
+Hero: shows [Qs Qh] (a pair of Queens)
+Villain: One collected 3,000 from pot
+*** SUMMARY ***
+Total pot 3,000 | Rake 0
+Board [2c 3d 4h 5s Jc]
+Seat 1: Hero (big blind) showed [Qs Qh] and lost with a pair of Queens
+Seat 2: Villain: One (button) (small blind) showed [Ac Kc] and won (3,000) with a straight
+`
+
+func TestParsePokerStarsCashHand(t *testing.T) {
+	hands, err := ParsePokerStars(strings.NewReader(pokerStarsSample))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(hands) != 2 {
+		t.Fatalf("Expected 2 hands, got %d", len(hands))
+	}
+
+	hand := hands[0]
+	if hand.ID != "230000000001" || hand.Variant != VariantNLHE || hand.Currency != "$" {
+		t.Errorf("Unexpected header fields: %+v", hand)
+	}
+	if hand.SmallBlind != 1 || hand.BigBlind != 2 || hand.Button != 1 || hand.Table != "Alcyone II" {
+		t.Errorf("Unexpected table fields: sb %d bb %d button %d table %q", hand.SmallBlind, hand.BigBlind, hand.Button, hand.Table)
+	}
+	if len(hand.Seats) != 3 || hand.GetSeat("Villain: One") == nil {
+		t.Fatalf("Expected 3 seats including names with colons, got %+v", hand.Seats)
+	}
+	if hand.Hero != "Hero" || hand.GetSeat("Hero").HoleCards.Codes() != "AhKd" {
+		t.Errorf("Expected hero cards AhKd, got %q", hand.GetSeat("Hero").HoleCards.Codes())
+	}
+	if hand.Board.Codes() != "2c7dKs9h" {
+		t.Errorf("Expected board 2c7dKs9h, got %s", hand.Board.Codes())
+	}
+	if hand.Rake != 1 {
+		t.Errorf("Expected rake 1, got %d", hand.Rake)
+	}
+
+	// Hero put in 6 + 10, got 184 back and collected 33
+	if net := hand.Net("Hero"); net != 17 {
+		t.Errorf("Expected hero net 17, got %d", net)
+	}
+	if net := hand.Net("Villain: One"); net != -16 {
+		t.Errorf("Expected villain net -16, got %d", net)
+	}
+	if net := hand.Net("Third"); net != -2 {
+		t.Errorf("Expected third net -2, got %d", net)
+	}
+
+	raise := hand.Actions[2]
+	if raise.Type != ActionRaise || raise.Amount != 6 || raise.Phase != holdem.PhasePreflop {
+		t.Errorf("Expected preflop raise of 6, got %+v", raise)
+	}
+	if !hand.Folded("Third") || hand.ReachedShowdown("Hero") {
+		t.Error("Expected third folded and no showdown")
+	}
+}
+
+func TestParsePokerStarsTournamentShowdown(t *testing.T) {
+	hands, err := ParsePokerStars(strings.NewReader(pokerStarsSample))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	hand := hands[1]
+
+	if hand.Currency != "" || hand.SmallBlind != 15 || hand.BigBlind != 30 || hand.Ante != 5 {
+		t.Errorf("Expected chip blinds 15/30 ante 5, got %d/%d ante %d (%q)", hand.SmallBlind, hand.BigBlind, hand.Ante, hand.Currency)
+	}
+	if hand.GetSeat("Villain: One").HoleCards.Codes() != "AcKc" {
+		t.Errorf("Expected shown cards AcKc, got %s", hand.GetSeat("Villain: One").HoleCards.Codes())
+	}
+	if hand.Net("Villain: One") != 1500 || hand.Net("Hero") != -1500 {
+		t.Errorf("Expected a 1500 chip swing, got %d / %d", hand.Net("Villain: One"), hand.Net("Hero"))
+	}
+	if !hand.ReachedShowdown("Hero") {
+		t.Error("Expected hero to reach showdown")
+	}
+	if len(hand.BoardAt(holdem.PhaseTurn)) != 4 {
+		t.Errorf("Expected 4 cards on the turn")
+	}
+}
+
+func TestParsePokerStarsRejectsUnsupportedGame(t *testing.T) {
+	input := "PokerStars Hand #1: Razz Limit ($0.02/$0.04 USD) - 2021/06/01 20:15:30 ET\n"
+	if _, err := ParsePokerStars(strings.NewReader(input)); err == nil {
+		t.Error("Expected error for unsupported game")
+	}
+}
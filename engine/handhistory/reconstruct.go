@@ -0,0 +1,253 @@
+package handhistory
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// ReconstructGame deals a recorded hand again on a live game and plays it
+// up to the action at index upToAction of hand.Actions, leaving the game
+// with that action's player to act. Any point of the hand can be asked
+// what the validator allows or what a bot would do there. upToAction equal
+// to len(hand.Actions) plays every action.
+//
+// Players' IDs are their seat numbers. The forced bets are posted by the
+// game when the hand starts, whatever upToAction says, and must match the
+// recorded ones. Streets are dealt from the recorded board as the actions
+// reach them. Cards the history never shows are dealt at random, from a
+// seed taken from the hand's ID, so a hand always reconstructs the same.
+func ReconstructGame(hand *Hand, upToAction int) (*holdem.Game, error) {
+	if hand == nil {
+		return nil, fmt.Errorf("hand is nil")
+	}
+	if upToAction < 0 || upToAction > len(hand.Actions) {
+		return nil, fmt.Errorf("action %d is outside the hand's %d actions", upToAction, len(hand.Actions))
+	}
+	config := holdem.GameConfig{SmallBlind: hand.SmallBlind, BigBlind: hand.BigBlind, Ante: hand.Ante, BigBlindAnte: hand.BigBlindAnte, Seed: handSeed(hand)}
+	switch hand.Variant {
+	case VariantNLHE:
+	case VariantPLO:
+		config.Variant = holdem.VariantOmaha
+	default:
+		return nil, fmt.Errorf("cannot reconstruct a %s hand", hand.Variant)
+	}
+
+	game := holdem.NewGameWithConfig(config)
+	ids := map[string]int{}
+	for _, seat := range hand.Seats {
+		if err := game.PlayerSit(holdem.NewPlayer(seat.Seat, seat.Name, seat.Stack), seat.Seat-1); err != nil {
+			return nil, fmt.Errorf("seat %d: %w", seat.Seat, err)
+		}
+		ids[seat.Name] = seat.Seat
+	}
+	deck, err := reconstructedDeck(hand, game.GetVariant().HoleCards(), rand.New(rand.NewSource(config.Seed)))
+	if err != nil {
+		return nil, err
+	}
+	if err := game.StackDeck(deck); err != nil {
+		return nil, err
+	}
+	button, err := reconstructedButton(hand)
+	if err != nil {
+		return nil, err
+	}
+	if err := game.StartHand(button - 1); err != nil {
+		return nil, err
+	}
+
+	forced := map[string]int{}
+	for _, action := range hand.Actions {
+		if action.Type.IsForced() {
+			forced[action.Player] += action.Amount
+		}
+	}
+	for name, id := range ids {
+		player, _ := game.GetPlayerByID(id)
+		if player.GetTotalBet() != forced[name] {
+			return nil, fmt.Errorf("%s posted %d, the game posts %d", name, forced[name], player.GetTotalBet())
+		}
+	}
+
+	for i, recorded := range hand.Actions[:upToAction] {
+		if recorded.Type.IsForced() {
+			continue
+		}
+		if err := dealTo(game, recorded.Phase); err != nil {
+			return nil, fmt.Errorf("action %d: %w", i, err)
+		}
+		action, err := engineAction(game, ids, recorded)
+		if err != nil {
+			return nil, fmt.Errorf("action %d: %w", i, err)
+		}
+		if err := game.TakeTimedAction(action, recorded.Elapsed); err != nil {
+			return nil, fmt.Errorf("action %d, %s %s: %w", i, recorded.Player, ActionTypeToString(recorded.Type), err)
+		}
+	}
+	if upToAction < len(hand.Actions) {
+		if err := dealTo(game, hand.Actions[upToAction].Phase); err != nil {
+			return nil, fmt.Errorf("action %d: %w", upToAction, err)
+		}
+	}
+	return game, nil
+}
+
+// engineAction turns a recorded action into the game's action for it
+func engineAction(game *holdem.Game, ids map[string]int, recorded Action) (holdem.Action, error) {
+	id, ok := ids[recorded.Player]
+	if !ok {
+		return holdem.Action{}, fmt.Errorf("%s is not seated", recorded.Player)
+	}
+	player := game.GetCurrentPlayer()
+	if player == nil || player.GetID() != id {
+		return holdem.Action{}, fmt.Errorf("%s acts out of turn", recorded.Player)
+	}
+	switch {
+	case recorded.Type == ActionFold:
+		return holdem.Action{PlayerID: id, Type: holdem.ActionFold}, nil
+	case recorded.Type == ActionCheck:
+		return holdem.Action{PlayerID: id, Type: holdem.ActionCheck}, nil
+	case recorded.Amount >= player.GetChips():
+		return holdem.Action{PlayerID: id, Type: holdem.ActionAllIn, Amount: player.GetChips()}, nil
+	case recorded.Type == ActionCall:
+		return holdem.Action{PlayerID: id, Type: holdem.ActionCall, Amount: recorded.Amount}, nil
+	case recorded.Type == ActionBet || recorded.Type == ActionRaise:
+		return holdem.NewRaise(id, player.GetBet()+recorded.Amount), nil
+	default:
+		return holdem.Action{}, fmt.Errorf("unexpected %s", ActionTypeToString(recorded.Type))
+	}
+}
+
+// dealTo deals the streets up to the phase once their betting is over
+func dealTo(game *holdem.Game, phase holdem.GamePhase) error {
+	for game.GetCurrentPhase() < phase && !game.IsHandOver() && !game.IsBettingRoundOpen() {
+		var err error
+		switch game.GetCurrentPhase() {
+		case holdem.PhasePreflop:
+			err = game.DealFlop()
+		case holdem.PhaseFlop:
+			err = game.DealTurn()
+		case holdem.PhaseTurn:
+			err = game.DealRiver()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if game.GetCurrentPhase() != phase {
+		return fmt.Errorf("the %s betting is not over before the %s", holdem.PhaseToString(game.GetCurrentPhase()), holdem.PhaseToString(phase))
+	}
+	return nil
+}
+
+// reconstructedButton returns the recorded button seat, or when the
+// history does not say, the seat the blinds were posted after
+func reconstructedButton(hand *Hand) (int, error) {
+	if hand.Button > 0 {
+		return hand.Button, nil
+	}
+	for _, action := range hand.Actions {
+		if action.Type != ActionPostSmallBlind {
+			continue
+		}
+		small := hand.GetSeat(action.Player)
+		if len(hand.Seats) == 2 {
+			return small.Seat, nil // Heads-up the button posts the small blind
+		}
+		seats := seatOrder(hand)
+		button := seats[len(seats)-1].Seat
+		for _, seat := range seats {
+			if seat.Seat >= small.Seat {
+				break
+			}
+			button = seat.Seat
+		}
+		return button, nil
+	}
+	return 0, fmt.Errorf("the hand names no button and no small blind")
+}
+
+// reconstructedDeck stacks the recorded cards where the game deals them:
+// the hole cards a round at a time in seat order, then the board with a
+// burn card before each street. Cards never shown are drawn at random.
+func reconstructedDeck(hand *Hand, holeCards int, rng *rand.Rand) (poker.Cards, error) {
+	shown := map[string]poker.Cards{}
+	for _, seat := range hand.Seats {
+		shown[seat.Name] = seat.HoleCards
+	}
+	for _, reveal := range hand.Showdown {
+		if len(reveal.Cards) > 0 {
+			shown[reveal.Player] = reveal.Cards
+		}
+	}
+
+	known := map[poker.Card]bool{}
+	deal := func(cards poker.Cards) error {
+		for _, card := range cards {
+			if known[*card] {
+				return fmt.Errorf("%s is dealt twice", card.Code())
+			}
+			known[*card] = true
+		}
+		return nil
+	}
+	if err := deal(hand.Board); err != nil {
+		return nil, err
+	}
+	for _, cards := range shown {
+		if err := deal(cards); err != nil {
+			return nil, err
+		}
+	}
+	rest := poker.Cards{}
+	for _, card := range poker.NewDeckCards() {
+		if card.Suit != poker.SuitNone && !known[*card] {
+			rest = append(rest, card)
+		}
+	}
+	rng.Shuffle(len(rest), func(i, j int) { rest[i], rest[j] = rest[j], rest[i] })
+	draw := func() *poker.Card {
+		card := rest[0]
+		rest = rest[1:]
+		return card
+	}
+
+	deck := poker.Cards{}
+	for round := 0; round < holeCards; round++ {
+		for _, seat := range seatOrder(hand) {
+			if cards := shown[seat.Name]; len(cards) == holeCards {
+				deck = append(deck, cards[round])
+			} else {
+				deck = append(deck, draw())
+			}
+		}
+	}
+	for i, card := range hand.Board {
+		if i == 0 || i >= 3 {
+			deck = append(deck, draw()) // Burn card
+		}
+		deck = append(deck, card)
+	}
+	return deck, nil
+}
+
+// seatOrder returns the seats by seat number
+func seatOrder(hand *Hand) []Seat {
+	seats := append([]Seat{}, hand.Seats...)
+	sort.Slice(seats, func(i, j int) bool { return seats[i].Seat < seats[j].Seat })
+	return seats
+}
+
+// handSeed derives the seed dealing a hand's unknown cards from its ID
+func handSeed(hand *Hand) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(hand.Source + "/" + hand.ID + "/" + hand.UID))
+	if seed := int64(h.Sum64()); seed != 0 {
+		return seed
+	}
+	return 1
+}
@@ -0,0 +1,94 @@
+package handhistory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestReconstructGame(t *testing.T) {
+	hand, err := FromReplay(playReplayHand(t))
+	if err != nil {
+		t.Fatalf("FromReplay failed: %v", err)
+	}
+	// Blinds, then Alice raises to 30 and Bob calls; Bob bets 30 on the flop
+	flopBet := 4
+	if action := hand.Actions[flopBet]; action.Player != "Bob" || action.Phase != holdem.PhaseFlop {
+		t.Fatalf("Expected Bob's flop bet at %d, got %+v", flopBet, action)
+	}
+
+	game, err := ReconstructGame(hand, flopBet)
+	if err != nil {
+		t.Fatalf("ReconstructGame failed: %v", err)
+	}
+	if player := game.GetCurrentPlayer(); player == nil || player.GetName() != "Bob" {
+		t.Fatalf("Expected Bob to act, got %v", player)
+	}
+	if game.GetCurrentPhase() != holdem.PhaseFlop || game.GetPot() != 60 {
+		t.Errorf("Expected a pot of 60 on the flop, got %d on the %s", game.GetPot(), holdem.PhaseToString(game.GetCurrentPhase()))
+	}
+	if board := game.GetCommunityCards().Codes(); board != hand.Board[:3].Codes() {
+		t.Errorf("Expected the recorded flop %s, got %s", hand.Board[:3].Codes(), board)
+	}
+	bob, _ := game.GetPlayerByID(2)
+	if poker.Cards(bob.GetHandCards()).Codes() != hand.Seats[1].HoleCards.Codes() || bob.GetChips() != 970 {
+		t.Errorf("Expected Bob's recorded cards and 970 chips, got %s and %d", poker.Cards(bob.GetHandCards()).Codes(), bob.GetChips())
+	}
+	// The validator can be asked about the spot
+	if err := holdem.NewActionValidator().ValidateAction(game, bob, holdem.NewRaise(2, 30)); err != nil {
+		t.Errorf("Expected Bob's recorded bet to be legal, got %v", err)
+	}
+
+	// Every action replays to the recorded result
+	game, err = ReconstructGame(hand, len(hand.Actions))
+	if err != nil {
+		t.Fatalf("ReconstructGame failed: %v", err)
+	}
+	if !game.IsHandOver() && game.IsBettingRoundOpen() {
+		t.Error("Expected the betting to be over after every action")
+	}
+	if board := game.GetCommunityCards().Codes(); board != hand.Board.Codes() {
+		t.Errorf("Expected the whole board %s, got %s", hand.Board.Codes(), board)
+	}
+}
+
+func TestReconstructGameDealsUnknownCardsTheSameWay(t *testing.T) {
+	hand, err := FromReplay(playReplayHand(t))
+	if err != nil {
+		t.Fatalf("FromReplay failed: %v", err)
+	}
+	hand.Seats[0].HoleCards, hand.Showdown = nil, nil
+	first, err := ReconstructGame(hand, 2)
+	if err != nil {
+		t.Fatalf("ReconstructGame failed: %v", err)
+	}
+	again, _ := ReconstructGame(hand, 2)
+	alice, _ := first.GetPlayerByID(1)
+	aliceAgain, _ := again.GetPlayerByID(1)
+	if len(alice.GetHandCards()) != 2 || poker.Cards(alice.GetHandCards()).Codes() != poker.Cards(aliceAgain.GetHandCards()).Codes() {
+		t.Errorf("Expected Alice dealt the same two cards, got %s and %s", poker.Cards(alice.GetHandCards()).Codes(), poker.Cards(aliceAgain.GetHandCards()).Codes())
+	}
+}
+
+func TestReconstructGameRejectsBadHistories(t *testing.T) {
+	hand, err := FromReplay(playReplayHand(t))
+	if err != nil {
+		t.Fatalf("FromReplay failed: %v", err)
+	}
+	if _, err := ReconstructGame(hand, len(hand.Actions)+1); err == nil {
+		t.Error("Expected an action past the end to be rejected")
+	}
+	outOfTurn := *hand
+	outOfTurn.Actions = append([]Action{}, hand.Actions...)
+	outOfTurn.Actions[2].Player = "Bob"
+	if _, err := ReconstructGame(&outOfTurn, 3); err == nil || !strings.Contains(err.Error(), "out of turn") {
+		t.Errorf("Expected Bob acting first to be rejected, got %v", err)
+	}
+	limit := *hand
+	limit.Variant = VariantFLHE
+	if _, err := ReconstructGame(&limit, 0); err == nil {
+		t.Error("Expected a fixed-limit hand to be rejected")
+	}
+}
@@ -0,0 +1,132 @@
+package handhistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// SourceReplay identifies hands converted from the engine's own replays
+const SourceReplay = "replay"
+
+// FromReplay converts a replay of a hand played by the engine into a hand
+// record. The hand is re-run to recover the chips each action put in, and
+// since a replay knows every card all hole cards are filled in. The shuffle
+// is only revealed for a hand that is over.
+func FromReplay(replay *holdem.Replay) (*Hand, error) {
+	hand := NewHand(SourceReplay)
+	hand.ID = strconv.Itoa(replay.HandNumber)
+	hand.UID = replay.HandID
+	hand.SmallBlind, hand.BigBlind, hand.Ante = replay.Config.SmallBlind, replay.Config.BigBlind, replay.Config.Ante
+	hand.BigBlindAnte = replay.Config.BigBlindAnte && replay.Config.Ante > 0
+	if replay.Config.Variant == holdem.VariantOmaha {
+		hand.Variant = VariantPLO
+	}
+
+	names := map[int]string{}
+	for _, seat := range replay.Seats {
+		name := seat.Name
+		if name == "" {
+			name = fmt.Sprintf("Player %d", seat.PlayerID)
+		}
+		names[seat.PlayerID] = name
+		hand.Seats = append(hand.Seats, Seat{Seat: seat.Seat + 1, Name: name, Stack: seat.Chips})
+	}
+
+	totals := map[int]int{} // Chips each player had in the pot before the action
+	currentBet := 0         // Bet to match on the street before the action
+	game, err := replay.RunObserved(func(game *holdem.Game, index int) {
+		logged := replay.Actions[index]
+		defer func() { currentBet = game.GetCurrentBet() }()
+		if logged.Action.Type == holdem.ActionSystemButton {
+			hand.Button = logged.Action.Amount + 1
+		}
+		if logged.Action.PlayerID == holdem.SystemPlayerID {
+			return
+		}
+		if logged.Action.Type == holdem.ActionDisconnect {
+			return // Moves no chips and hand histories have no such action
+		}
+		player, err := game.GetPlayerByID(logged.Action.PlayerID)
+		if err != nil {
+			return
+		}
+		switch logged.Action.Type {
+		case holdem.ActionShow:
+			hand.Showdown = append(hand.Showdown, Reveal{Player: names[player.GetID()], Cards: player.GetHandCards()})
+			return
+		case holdem.ActionMuck:
+			hand.Showdown = append(hand.Showdown, Reveal{Player: names[player.GetID()]})
+			return
+		}
+		id := player.GetID()
+		amount := player.GetTotalBet() - totals[id]
+		totals[id] = player.GetTotalBet()
+
+		action := Action{
+			Phase:   logged.Phase,
+			Player:  names[id],
+			Amount:  amount,
+			AllIn:   player.GetChips() == 0 && amount > 0,
+			Elapsed: logged.Elapsed,
+		}
+		switch {
+		case logged.Action.Type == holdem.ActionPostAnte:
+			action.Type = ActionPostAnte
+		case logged.Action.Type == holdem.ActionPostBlind:
+			action.Type = ActionPostBlind
+		case logged.Action.Type == holdem.ActionPostSmallBlind:
+			action.Type = ActionPostSmallBlind
+		case logged.Action.Type == holdem.ActionPostBigBlind:
+			action.Type = ActionPostBigBlind
+		case logged.Action.Type == holdem.ActionPostStraddle:
+			action.Type = ActionPostStraddle
+		case logged.Action.Type == holdem.ActionFold:
+			action.Type = ActionFold
+		case amount == 0:
+			action.Type = ActionCheck
+		case player.GetBet() <= currentBet:
+			action.Type = ActionCall
+		case currentBet == 0:
+			action.Type = ActionBet
+		default:
+			action.Type = ActionRaise
+		}
+		hand.Actions = append(hand.Actions, action)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("hand %d: %w", replay.HandNumber, err)
+	}
+
+	hand.Board = game.GetCommunityCards()
+	for i, seat := range replay.Seats {
+		if player, err := game.GetPlayerByID(seat.PlayerID); err == nil {
+			hand.Seats[i].HoleCards = player.GetHandCards()
+		}
+	}
+	for _, award := range game.GetPotAwards() {
+		for _, id := range award.Winners {
+			hand.Collected[names[id]] += award.Share(id)
+		}
+	}
+	hand.Rake = game.GetRake()
+	if replay.ShuffleCommitment != "" {
+		hand.Shuffle = &holdem.ShuffleReveal{Commitment: replay.ShuffleCommitment}
+		// The re-run deals the recorded deck, which the recorded nonce opens
+		if dealt, err := game.RevealShuffle(); err == nil {
+			hand.Shuffle.Deck, hand.Shuffle.Nonce = dealt.Deck, replay.ShuffleNonce
+		}
+	}
+	return hand, nil
+}
+
+// parseReplay reads a replay file saved by the engine
+func parseReplay(data []byte) (*Hand, error) {
+	replay := &holdem.Replay{}
+	if err := json.Unmarshal(data, replay); err != nil {
+		return nil, fmt.Errorf("invalid replay: %w", err)
+	}
+	return FromReplay(replay)
+}
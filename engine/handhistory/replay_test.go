@@ -0,0 +1,142 @@
+package handhistory
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// playReplayHand plays a heads-up hand to showdown: raise, call, bet, call, check down
+func playReplayHand(t *testing.T) *holdem.Replay {
+	t.Helper()
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 11})
+	game.PlayerSit(holdem.NewPlayer(1, "Alice", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Bob", 1000), 1)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	act := func(id int, actionType holdem.ActionType, amount int, elapsed time.Duration) {
+		t.Helper()
+		action := holdem.Action{PlayerID: id, Type: actionType, Amount: amount}
+		if actionType == holdem.ActionRaise {
+			action = holdem.NewRaise(id, amount)
+		}
+		if err := game.TakeTimedAction(action, elapsed); err != nil {
+			t.Fatalf("Player %d %s failed: %v", id, holdem.ActionTypeToString(actionType), err)
+		}
+	}
+	act(1, holdem.ActionRaise, 30, 2*time.Second)
+	act(2, holdem.ActionCall, 20, 500*time.Millisecond)
+	game.DealFlop()
+	act(2, holdem.ActionRaise, 30, 4*time.Second)
+	act(1, holdem.ActionCall, 30, time.Second)
+	game.DealTurn()
+	act(2, holdem.ActionCheck, 0, time.Second)
+	act(1, holdem.ActionCheck, 0, time.Second)
+	game.DealRiver()
+	act(2, holdem.ActionCheck, 0, time.Second)
+	act(1, holdem.ActionCheck, 0, time.Second)
+	if _, err := game.AwardPot(); err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	for _, id := range game.ShowdownOrder() {
+		reveal := game.Show
+		if game.CanMuck(id) {
+			reveal = game.Muck
+		}
+		if err := reveal(id); err != nil {
+			t.Fatalf("Player %d revealing failed: %v", id, err)
+		}
+	}
+	replay, err := holdem.NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("NewReplay failed: %v", err)
+	}
+	return replay
+}
+
+func TestFromReplay(t *testing.T) {
+	replay := playReplayHand(t)
+	hand, err := FromReplay(replay)
+	if err != nil {
+		t.Fatalf("FromReplay failed: %v", err)
+	}
+	if hand.UID == "" || hand.UID != replay.HandID {
+		t.Errorf("Expected the hand to keep the replay's ID %q, got %q", replay.HandID, hand.UID)
+	}
+	if hand.Source != SourceReplay || hand.BigBlind != 10 || hand.Button != 1 || len(hand.Board) != 5 {
+		t.Errorf("Unexpected hand header %+v", hand)
+	}
+	want := []struct {
+		player     string
+		actionType ActionType
+		amount     int
+	}{
+		{"Alice", ActionPostSmallBlind, 5}, {"Bob", ActionPostBigBlind, 10},
+		{"Alice", ActionRaise, 25}, {"Bob", ActionCall, 20},
+		{"Bob", ActionBet, 30}, {"Alice", ActionCall, 30},
+	}
+	if len(hand.Actions) != 10 {
+		t.Fatalf("Expected 10 actions, got %d: %+v", len(hand.Actions), hand.Actions)
+	}
+	for i, w := range want {
+		got := hand.Actions[i]
+		if got.Player != w.player || got.Type != w.actionType || got.Amount != w.amount {
+			t.Errorf("Action %d: expected %s %s %d, got %s %s %d", i, w.player, ActionTypeToString(w.actionType), w.amount,
+				got.Player, ActionTypeToString(got.Type), got.Amount)
+		}
+	}
+	if hand.Actions[4].Elapsed != 4*time.Second || hand.Actions[0].Elapsed != 0 {
+		t.Errorf("Expected decision times to carry over, got %v and %v", hand.Actions[4].Elapsed, hand.Actions[0].Elapsed)
+	}
+	if net := hand.Net("Alice") + hand.Net("Bob"); net != 0 {
+		t.Errorf("Expected a zero-sum rake-free hand, got %d", net)
+	}
+	for _, seat := range hand.Seats {
+		if len(seat.HoleCards) != 2 {
+			t.Errorf("Expected %s's hole cards from the replay", seat.Name)
+		}
+	}
+	if hand.Shuffle == nil || hand.Shuffle.Commitment != replay.ShuffleCommitment {
+		t.Fatalf("Expected the replay's shuffle commitment, got %+v", hand.Shuffle)
+	}
+	if err := hand.Shuffle.Verify(); err != nil {
+		t.Errorf("Expected the finished hand's shuffle to be revealed and verify, got %v", err)
+	}
+}
+
+func TestFromReplayKeepsALiveShuffleSecret(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 11})
+	game.PlayerSit(holdem.NewPlayer(1, "Alice", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Bob", 1000), 1)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	replay, err := holdem.NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("NewReplay failed: %v", err)
+	}
+	hand, err := FromReplay(replay)
+	if err != nil {
+		t.Fatalf("FromReplay failed: %v", err)
+	}
+	if hand.Shuffle == nil || hand.Shuffle.Commitment != replay.ShuffleCommitment {
+		t.Fatalf("Expected the commitment, got %+v", hand.Shuffle)
+	}
+	if hand.Shuffle.Nonce != "" || len(hand.Shuffle.Deck) != 0 {
+		t.Error("Expected the deck and nonce of a hand in progress to stay secret")
+	}
+}
+
+func TestParseDetectsReplay(t *testing.T) {
+	data, err := json.Marshal(playReplayHand(t))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	hands, err := Parse(data, ".json")
+	if err != nil || len(hands) != 1 || hands[0].Source != SourceReplay {
+		t.Fatalf("Expected one replay hand, got %v, %v", hands, err)
+	}
+}
@@ -0,0 +1,147 @@
+package handhistory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// Settle works out Returned and Collected for a hand that does not record
+// them, returning uncalled bets and awarding each side pot at showdown.
+// A big blind ante is dead money in the main pot. Only Hold'em hands can
+// be settled at showdown.
+func Settle(hand *Hand) error {
+	hand.Returned = map[string]int{}
+	hand.Collected = map[string]int{}
+
+	contributed := map[string]int{}
+	dead := 0
+	for _, action := range hand.Actions {
+		if hand.BigBlindAnte && action.Type == ActionPostAnte {
+			dead += action.Amount
+			continue
+		}
+		contributed[action.Player] += action.Amount
+	}
+
+	// The biggest bet is only partly called when nobody matched it
+	levels := []int{}
+	for _, seat := range hand.Seats {
+		levels = append(levels, contributed[seat.Name])
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(levels)))
+	if len(levels) >= 2 && levels[0] > levels[1] {
+		for _, seat := range hand.Seats {
+			if contributed[seat.Name] == levels[0] {
+				hand.Returned[seat.Name] = levels[0] - levels[1]
+				contributed[seat.Name] = levels[1]
+			}
+		}
+	}
+
+	live := []Seat{}
+	for _, seat := range hand.Seats {
+		if !hand.Folded(seat.Name) {
+			live = append(live, seat)
+		}
+	}
+	if len(live) == 0 {
+		return fmt.Errorf("no players left to award the pot to")
+	}
+
+	// Build pots from the distinct contribution levels of live players
+	caps := []int{}
+	for _, seat := range live {
+		caps = append(caps, contributed[seat.Name])
+	}
+	sort.Ints(caps)
+
+	var evaluator holdem.IHandEvaluator = holdem.NewHandEvaluator()
+	if hand.Variant == VariantPLO {
+		evaluator = holdem.NewOmahaEvaluator()
+	}
+	results := map[string]*holdem.HandResult{}
+	previous := 0
+	var winners []string
+	for _, level := range caps {
+		if level <= previous {
+			continue
+		}
+		pot := dead
+		dead = 0
+		for _, seat := range hand.Seats {
+			pot += min(contributed[seat.Name], level) - min(contributed[seat.Name], previous)
+		}
+		eligible := []Seat{}
+		for _, seat := range live {
+			if contributed[seat.Name] >= level {
+				eligible = append(eligible, seat)
+			}
+		}
+		previous = level
+
+		var err error
+		winners, err = potWinners(hand, eligible, evaluator, results)
+		if err != nil {
+			return err
+		}
+		share, odd := pot/len(winners), pot%len(winners)
+		for i, winner := range winners {
+			hand.Collected[winner] += share
+			if i < odd {
+				hand.Collected[winner]++
+			}
+		}
+	}
+
+	// Dead money from folded players above every live stack goes to the last pot
+	if len(winners) == 0 {
+		winners = []string{live[0].Name}
+	}
+	for _, seat := range hand.Seats {
+		if extra := contributed[seat.Name] - previous; extra > 0 && hand.Folded(seat.Name) {
+			hand.Collected[winners[0]] += extra
+		}
+	}
+	return nil
+}
+
+// potWinners returns the best hands among the eligible seats, in seat order
+func potWinners(hand *Hand, eligible []Seat, evaluator holdem.IHandEvaluator, results map[string]*holdem.HandResult) ([]string, error) {
+	if len(eligible) == 1 {
+		return []string{eligible[0].Name}, nil
+	}
+	holeCards := 2
+	switch hand.Variant {
+	case VariantNLHE, VariantFLHE:
+	case VariantPLO:
+		holeCards = 4
+	default:
+		return nil, fmt.Errorf("cannot settle a %s showdown", hand.Variant)
+	}
+	if len(hand.Board) != 5 {
+		return nil, fmt.Errorf("cannot settle a showdown with %d board cards", len(hand.Board))
+	}
+
+	var best *holdem.HandResult
+	winners := []string{}
+	for _, seat := range eligible {
+		if len(seat.HoleCards) != holeCards {
+			return nil, fmt.Errorf("cannot settle: hole cards of %s are unknown", seat.Name)
+		}
+		result, ok := results[seat.Name]
+		if !ok {
+			result = evaluator.EvaluateHand(seat.HoleCards, hand.Board)
+			results[seat.Name] = result
+		}
+		switch {
+		case best == nil || evaluator.CompareHands(result, best) > 0:
+			best = result
+			winners = []string{seat.Name}
+		case evaluator.CompareHands(result, best) == 0:
+			winners = append(winners, seat.Name)
+		}
+	}
+	return winners, nil
+}
@@ -0,0 +1,71 @@
+package holdem
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// AbortPolicy decides where the chips in the pot go when a hand is
+// abandoned before it finishes
+type AbortPolicy int
+
+const (
+	AbortRefund   AbortPolicy = iota // Every player gets back what they put in, blinds and antes included
+	AbortSplitPot                    // Players still in the hand share the pot equally
+)
+
+// AbortHand ends the hand in progress without a showdown and pays the pot
+// out by the policy. It returns the chips each player got back, keyed by
+// player ID. The hand's cards are discarded; the board stays as dealt.
+func (g *Game) AbortHand(policy AbortPolicy) (map[int]int, error) {
+	if !g.handActive {
+		return nil, fmt.Errorf("no hand in progress")
+	}
+
+	returned := map[int]int{}
+	switch policy {
+	case AbortRefund:
+		for _, player := range g.players {
+			if player != nil && player.GetTotalBet() > 0 {
+				returned[player.GetID()] = player.GetTotalBet()
+			}
+		}
+	case AbortSplitPot:
+		// Odd chips go to the players left of the button first, as in AwardPot
+		live := []IPlayer{}
+		for i := 1; i <= len(g.players); i++ {
+			if player := g.players[(g.button+i)%len(g.players)]; player != nil && !player.IsFolded() {
+				live = append(live, player)
+			}
+		}
+		pot := g.GetPot()
+		for i, player := range live {
+			share := pot / len(live)
+			if i < pot%len(live) {
+				share++
+			}
+			returned[player.GetID()] = share
+		}
+	default:
+		return nil, fmt.Errorf("unknown abort policy %d", policy)
+	}
+
+	g.recordSystemAction(Action{
+		PlayerID: SystemPlayerID,
+		Type:     ActionSystemAbort,
+		Amount:   int(policy),
+	})
+	for _, player := range g.players {
+		if player == nil {
+			continue
+		}
+		player.GrandChips(returned[player.GetID()])
+		player.ResetForNewHand()
+	}
+	g.log().Info("hand aborted", slog.Int("hand", g.handNumber), slog.Any("returned", returned))
+
+	g.awards = nil
+	g.handActive = false
+	g.acting = -1
+	return returned, nil
+}
@@ -0,0 +1,57 @@
+package holdem
+
+import "testing"
+
+func TestAbortHandRefundsEveryBet(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionRaise, 30)
+	mustAct(t, game, 2, ActionCall, 25)
+	mustAct(t, game, 3, ActionFold, 0)
+
+	returned, err := game.AbortHand(AbortRefund)
+	if err != nil {
+		t.Fatalf("AbortHand failed: %v", err)
+	}
+	if returned[1] != 30 || returned[2] != 30 || returned[3] != 10 {
+		t.Errorf("Expected 30, 30 and 10 back, got %v", returned)
+	}
+	for id := 1; id <= 3; id++ {
+		if chipsOf(game, id) != 1000 {
+			t.Errorf("Expected player %d back at 1000, got %d", id, chipsOf(game, id))
+		}
+	}
+	if game.IsHandInProgress() || game.GetPot() != 0 {
+		t.Errorf("Expected the hand over with an empty pot, got in progress %v and pot %d", game.IsHandInProgress(), game.GetPot())
+	}
+	if _, err := game.AbortHand(AbortRefund); err == nil {
+		t.Error("Expected aborting with no hand in progress to fail")
+	}
+
+	replay, err := NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("NewReplay failed: %v", err)
+	}
+	if _, err := replay.Run(); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+}
+
+func TestAbortHandSplitsPotAmongLivePlayers(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionRaise, 30)
+	mustAct(t, game, 2, ActionCall, 25)
+	mustAct(t, game, 3, ActionFold, 0)
+
+	// 70 in the pot between the two players still in
+	if _, err := game.AbortHand(AbortSplitPot); err != nil {
+		t.Fatalf("AbortHand failed: %v", err)
+	}
+	if chipsOf(game, 1) != 1005 || chipsOf(game, 2) != 1005 || chipsOf(game, 3) != 990 {
+		t.Errorf("Expected 1005, 1005 and 990, got %d, %d and %d", chipsOf(game, 1), chipsOf(game, 2), chipsOf(game, 3))
+	}
+	if err := game.StartHand(1); err != nil {
+		t.Errorf("Expected a new hand to start after the abort, got %v", err)
+	}
+}
@@ -17,12 +17,117 @@ const (
 	ActionSystemDealTurn    // Deal turn card
 	ActionSystemDealRiver   // Deal river card
 	ActionSystemPhaseChange // Phase transition
+
+	// Forced bets, posted on the player's behalf when a hand starts
+	ActionPostAnte
+	ActionPostBlind // Either blind, as logged before the blinds had their own types
+
+	// Hand flow
+	ActionSystemButton   // Button placed, Amount is the seat
+	ActionSystemAwardPot // Pots paid out, Amount is the total
+	ActionSystemBombPot  // Hand is a bomb pot, Amount is the ante
+	ActionSystemRake     // House took its rake, Amount is the total
+	ActionSystemAbort    // Hand abandoned, Amount is the AbortPolicy
+
+	// Table policies
+	ActionDisconnect // Disconnected player stays in for what they put in, see DisconnectAllIn
+
+	// Forced bets naming the blind posted
+	ActionPostSmallBlind
+	ActionPostBigBlind
+	ActionPostStraddle // A blind posted after the big blind, a voluntary bet only in name
+
+	// Showdown, in the order players revealed
+	ActionShow
+	ActionMuck
 )
 
+// IsBlind reports whether the action posts a blind or a straddle
+func (t ActionType) IsBlind() bool {
+	switch t {
+	case ActionPostBlind, ActionPostSmallBlind, ActionPostBigBlind, ActionPostStraddle:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsForced reports whether the action posts chips on the player's behalf
+// rather than being the player's choice
+func (t ActionType) IsForced() bool {
+	return t == ActionPostAnte || t.IsBlind()
+}
+
 const SystemPlayerID = -1
 
+// Action is a player or system action. For player actions Amount is the
+// chips put in with the action. A raise also says the total bet it raises
+// to on the street, so "raise to 60" and "raise by 40" cannot be confused;
+// the game fills in a raise's Amount when it takes the action.
 type Action struct {
 	PlayerID int
 	Type     ActionType
 	Amount   int
+	RaiseTo  int // ActionRaise only, the player's bet on the street after raising
+}
+
+// NewDisconnect returns the action a decision maker takes for a player it
+// lost the connection to. Tables with the DisconnectAllIn policy keep them
+// in the hand; elsewhere the action is refused and the player checks or folds.
+func NewDisconnect(playerID int) Action {
+	return Action{PlayerID: playerID, Type: ActionDisconnect}
+}
+
+// NewRaise returns a raise to a total bet of raiseTo on the current street
+func NewRaise(playerID, raiseTo int) Action {
+	return Action{PlayerID: playerID, Type: ActionRaise, RaiseTo: raiseTo}
+}
+
+// streetBets follows the bets on one street from its actions
+type streetBets struct {
+	bets      map[int]int // Chips each player has in on the street
+	current   int         // Bet to match
+	lastRaise int         // Size of the last full raise
+}
+
+func newStreetBets(bigBlind int) *streetBets {
+	return &streetBets{bets: map[int]int{}, lastRaise: bigBlind}
+}
+
+// apply adds an action's chips to the player's bet. Antes are dead money
+// and do not count.
+func (s *streetBets) apply(action Action) {
+	switch {
+	case action.Type.IsBlind(), action.Type == ActionCall, action.Type == ActionAllIn:
+		s.bets[action.PlayerID] += action.Amount
+	case action.Type == ActionRaise:
+		s.bets[action.PlayerID] = action.RaiseTo
+	default:
+		return
+	}
+	if bet := s.bets[action.PlayerID]; bet > s.current {
+		if !action.Type.IsBlind() && bet-s.current >= s.lastRaise {
+			s.lastRaise = bet - s.current
+		}
+		s.current = bet
+	}
+}
+
+// UpgradeStreetActions converts one street of actions logged before raises
+// carried RaiseTo. Those raises put in the amount to call plus Amount; the
+// converted raise has every chip it put in as Amount and the bet it raised
+// to as RaiseTo. Actions that already have a RaiseTo are kept as they are.
+func UpgradeStreetActions(actions []Action) []Action {
+	upgraded := make([]Action, len(actions))
+	bets := newStreetBets(0)
+	for i, action := range actions {
+		if action.Type == ActionRaise && action.RaiseTo == 0 {
+			bet := bets.bets[action.PlayerID]
+			action.RaiseTo = max(bets.current, bet) + action.Amount
+			action.Amount = action.RaiseTo - bet
+		}
+		bets.apply(action)
+		upgraded[i] = action
+	}
+	return upgraded
 }
@@ -123,7 +123,7 @@ func TestIsValidActionType(t *testing.T) {
 	// Test invalid action types (values beyond our defined constants)
 	invalidActions := []ActionType{
 		ActionType(-1),
-		ActionType(20),
+		ActionType(30),
 		ActionType(50),
 		ActionType(100),
 	}
@@ -362,3 +362,34 @@ func TestSystemActionsInGame(t *testing.T) {
 		t.Errorf("Expected at least 3 system actions, got %d", systemActionCount)
 	}
 }
+
+func TestUpgradeStreetActions(t *testing.T) {
+	legacy := []Action{
+		{PlayerID: 2, Type: ActionPostBlind, Amount: 5},
+		{PlayerID: 3, Type: ActionPostBlind, Amount: 10},
+		{PlayerID: 1, Type: ActionRaise, Amount: 20}, // 20 over the 10 to call
+		{PlayerID: 2, Type: ActionRaise, Amount: 40}, // Calls 25 more, then 40 over
+		{PlayerID: 1, Type: ActionCall, Amount: 40},
+		{PlayerID: 3, Type: ActionRaise, RaiseTo: 200, Amount: 190}, // Already upgraded
+	}
+	want := []Action{
+		legacy[0],
+		legacy[1],
+		{PlayerID: 1, Type: ActionRaise, Amount: 30, RaiseTo: 30},
+		{PlayerID: 2, Type: ActionRaise, Amount: 65, RaiseTo: 70},
+		legacy[4],
+		legacy[5],
+	}
+	for i, action := range UpgradeStreetActions(legacy) {
+		if action != want[i] {
+			t.Errorf("Action %d upgraded to %+v, expected %+v", i, action, want[i])
+		}
+	}
+}
+
+func TestNewRaise(t *testing.T) {
+	raise := NewRaise(4, 60)
+	if raise.PlayerID != 4 || raise.Type != ActionRaise || raise.RaiseTo != 60 || raise.Amount != 0 {
+		t.Errorf("Unexpected raise %+v", raise)
+	}
+}
@@ -0,0 +1,87 @@
+package holdem
+
+import "fmt"
+
+// Pseudonym is the name and ID a player is given in anonymized replays,
+// and who they really are, which stays with the owner of the hands
+type Pseudonym struct {
+	PlayerID int    `json:"player_id"` // ID in the anonymized replays
+	Name     string `json:"name"`      // Name in the anonymized replays, e.g. "Player 2"
+	RealID   int    `json:"real_id"`
+	RealName string `json:"real_name"`
+}
+
+// Anonymizer rewrites replays for sharing in public: every player gets a
+// pseudonym, "Player 1", "Player 2" and so on in the order they first sit
+// in the replays given, and keeps it across all of them. Who controlled
+// each seat and the table's ID are left out.
+type Anonymizer struct {
+	byID  map[int]Pseudonym
+	order []int // Real IDs in the order they were given pseudonyms
+}
+
+// NewAnonymizer creates an anonymizer that has given out no pseudonyms yet
+func NewAnonymizer() *Anonymizer {
+	return &Anonymizer{byID: map[int]Pseudonym{}}
+}
+
+// Replay returns an anonymized copy of a replay. The original is verified
+// first and the copy re-run under the pseudonyms, so the copy's state
+// hashes are its own and it verifies as the original does.
+func (a *Anonymizer) Replay(r *Replay) (*Replay, error) {
+	if _, err := r.Run(); err != nil {
+		return nil, fmt.Errorf("anonymizing hand %d: %w", r.HandNumber, err)
+	}
+	anonymized := *r
+	anonymized.Config.TableID = ""
+	anonymized.Seats = make([]ReplaySeat, len(r.Seats))
+	for i, seat := range r.Seats {
+		pseudonym := a.pseudonym(seat.PlayerID, seat.Name)
+		anonymized.Seats[i] = ReplaySeat{Seat: seat.Seat, PlayerID: pseudonym.PlayerID, Name: pseudonym.Name, Chips: seat.Chips}
+	}
+	anonymized.Actions = make([]LoggedAction, len(r.Actions))
+	for i, logged := range r.Actions {
+		if pseudonym, ok := a.byID[logged.Action.PlayerID]; ok {
+			logged.Action.PlayerID = pseudonym.PlayerID
+		}
+		anonymized.Actions[i] = logged
+	}
+	anonymized.StackedDeck = copyCards(r.StackedDeck)
+
+	// Player IDs and names go into the state hashes
+	anonymized.StateHashes = nil
+	var hashes []string
+	game, err := anonymized.reproduce(func(game *Game, _ int) {
+		hashes = append(hashes, game.StateHash())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("anonymizing hand %d: %w", r.HandNumber, err)
+	}
+	anonymized.StateHash = game.replayStateHash(anonymized.Actions)
+	if r.StateHashes != nil {
+		anonymized.StateHashes = hashes
+	}
+	return &anonymized, nil
+}
+
+// Pseudonyms returns the pseudonyms given out so far, in order
+func (a *Anonymizer) Pseudonyms() []Pseudonym {
+	pseudonyms := make([]Pseudonym, len(a.order))
+	for i, id := range a.order {
+		pseudonyms[i] = a.byID[id]
+	}
+	return pseudonyms
+}
+
+// pseudonym returns the pseudonym of a player, giving them the next one the
+// first time they are seen
+func (a *Anonymizer) pseudonym(playerID int, name string) Pseudonym {
+	if pseudonym, ok := a.byID[playerID]; ok {
+		return pseudonym
+	}
+	n := len(a.order) + 1
+	pseudonym := Pseudonym{PlayerID: n, Name: fmt.Sprintf("Player %d", n), RealID: playerID, RealName: name}
+	a.byID[playerID] = pseudonym
+	a.order = append(a.order, playerID)
+	return pseudonym
+}
@@ -0,0 +1,57 @@
+package holdem
+
+import "testing"
+
+func TestAnonymizerKeepsPseudonymsAcrossHands(t *testing.T) {
+	game := NewGameWithConfig(GameConfig{TableID: "home-table", SmallBlind: 5, BigBlind: 10, Seed: 9})
+	game.PlayerSit(NewPlayer(42, "Alice", 1000), 0)
+	game.PlayerSit(NewPlayer(7, "Bob", 1000), 1)
+	anonymizer := NewAnonymizer()
+	for hand := 0; hand < 2; hand++ {
+		if err := game.StartHand(hand); err != nil {
+			t.Fatal(err)
+		}
+		mustAct(t, game, game.GetCurrentPlayer().GetID(), ActionFold, 0)
+		if _, err := game.AwardPot(); err != nil {
+			t.Fatal(err)
+		}
+		replay, err := NewReplay(game, map[int]string{42: "human", 7: "nit"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hand == 1 {
+			if err := replay.RecordStateHashes(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		anonymized, err := anonymizer.Replay(replay)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := anonymized.Run(); err != nil {
+			t.Fatalf("Expected hand %d to verify anonymized, got %v", hand+1, err)
+		}
+		if anonymized.Config.TableID != "" || replay.Config.TableID != "home-table" {
+			t.Errorf("Expected the table ID left out of the copy alone, got %q", anonymized.Config.TableID)
+		}
+		for i, seat := range anonymized.Seats {
+			if want := (ReplaySeat{Seat: i, PlayerID: i + 1, Name: []string{"Player 1", "Player 2"}[i], Chips: replay.Seats[i].Chips}); seat != want {
+				t.Errorf("Expected seat %d as %+v, got %+v", i, want, seat)
+			}
+		}
+		for _, logged := range anonymized.Actions {
+			if id := logged.Action.PlayerID; id == 42 || id == 7 {
+				t.Errorf("Expected no real player IDs in the actions, got %d", id)
+			}
+		}
+		if hand == 1 && len(anonymized.StateHashes) != len(anonymized.Actions) {
+			t.Errorf("Expected the state hashes recorded again, got %d for %d actions", len(anonymized.StateHashes), len(anonymized.Actions))
+		}
+	}
+
+	pseudonyms := anonymizer.Pseudonyms()
+	if len(pseudonyms) != 2 || pseudonyms[0] != (Pseudonym{PlayerID: 1, Name: "Player 1", RealID: 42, RealName: "Alice"}) || pseudonyms[1].RealName != "Bob" {
+		t.Errorf("Expected Alice and Bob mapped in seating order, got %+v", pseudonyms)
+	}
+}
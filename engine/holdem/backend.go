@@ -0,0 +1,201 @@
+package holdem
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/ljbink/ai-poker/engine/metrics"
+)
+
+// EvaluatorBackend names the implementation that scores Hold'em hands
+type EvaluatorBackend string
+
+const (
+	EvaluatorFast      EvaluatorBackend = "fast"      // HandEvaluator, also the zero value
+	EvaluatorReference EvaluatorBackend = "reference" // ReferenceEvaluator, slow but easy to verify
+)
+
+// EvaluatorEnv names the environment variable that picks the backend of
+// every game in the process, whatever their config says
+const EvaluatorEnv = "AI_POKER_EVALUATOR"
+
+// SelfCheckHands is how many random hands a backend must score like the
+// reference evaluator before games use it
+const SelfCheckHands = 3000
+
+// selfCheckSeed deals the self-check hands, the same ones every run
+const selfCheckSeed = 52
+
+var (
+	backendsMu sync.Mutex
+	// backends creates the Hold'em evaluator of every backend by name
+	backends = map[EvaluatorBackend]func() IHandEvaluator{
+		EvaluatorFast:      func() IHandEvaluator { return NewHandEvaluator() },
+		EvaluatorReference: func() IHandEvaluator { return NewReferenceEvaluator() },
+	}
+	// checked keeps the self-check result of every backend checked so far
+	checked = map[EvaluatorBackend]error{}
+	// selected overrides the backend of every game, see SelectEvaluator
+	selected EvaluatorBackend
+)
+
+// RegisterEvaluatorBackend makes a Hold'em evaluator available to game
+// configs by name, e.g. one built on lookup tables, replacing any backend
+// registered under the same name. It is self-checked again before use.
+func RegisterEvaluatorBackend(name EvaluatorBackend, create func() IHandEvaluator) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = create
+	delete(checked, name)
+}
+
+// EvaluatorBackends lists the registered backends by name
+func EvaluatorBackends() []EvaluatorBackend {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	names := make([]EvaluatorBackend, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// IsKnown reports whether the backend is registered
+func (b EvaluatorBackend) IsKnown() bool {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	_, ok := backends[b]
+	return b == "" || ok
+}
+
+// SelectEvaluator makes every game in the process score hands with the
+// backend, once it passes its self-check. An empty name goes back to the
+// backend of each game's config.
+func SelectEvaluator(backend EvaluatorBackend) error {
+	if backend != "" {
+		if !backend.IsKnown() {
+			return fmt.Errorf("unknown evaluator %q, want one of %v", backend, EvaluatorBackends())
+		}
+		if err := CheckEvaluator(backend); err != nil {
+			return err
+		}
+	}
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	selected = backend
+	return nil
+}
+
+// SelectEvaluatorFromEnv selects the backend named by EvaluatorEnv, if any
+func SelectEvaluatorFromEnv() error {
+	backend := EvaluatorBackend(os.Getenv(EvaluatorEnv))
+	if backend == "" {
+		return nil
+	}
+	if err := SelectEvaluator(backend); err != nil {
+		return fmt.Errorf("%s: %w", EvaluatorEnv, err)
+	}
+	return nil
+}
+
+// effectiveEvaluator returns the backend a game configured with backend
+// scores hands with: the selected one first, then its own, then the fast one.
+// A backend failing its self-check falls back to the reference.
+func effectiveEvaluator(backend EvaluatorBackend) EvaluatorBackend {
+	backendsMu.Lock()
+	if selected != "" {
+		backend = selected
+	}
+	if _, ok := backends[backend]; !ok {
+		backend = EvaluatorFast
+	}
+	backendsMu.Unlock()
+	if CheckEvaluator(backend) != nil {
+		return EvaluatorReference
+	}
+	return backend
+}
+
+// newBackendEvaluator creates the evaluator of a backend, the fast one for
+// unknown names
+func newBackendEvaluator(backend EvaluatorBackend) IHandEvaluator {
+	backendsMu.Lock()
+	create, ok := backends[backend]
+	backendsMu.Unlock()
+	if !ok {
+		return NewHandEvaluator()
+	}
+	return create()
+}
+
+// CheckEvaluator cross-validates the backend against the reference
+// evaluator over SelfCheckHands random hands, to catch a corrupted table or
+// a broken implementation before it decides a pot. Each backend is checked
+// once per process; later calls return the first result.
+func CheckEvaluator(backend EvaluatorBackend) error {
+	if backend == "" || backend == EvaluatorReference {
+		return nil
+	}
+	backendsMu.Lock()
+	err, done := checked[backend]
+	backendsMu.Unlock()
+	if done {
+		return err
+	}
+	if !backend.IsKnown() {
+		return fmt.Errorf("unknown evaluator %q", backend)
+	}
+	err = crossCheck(newBackendEvaluator(backend), SelfCheckHands, selfCheckSeed)
+	if err != nil {
+		err = fmt.Errorf("evaluator %s failed its self-check: %w", backend, err)
+	}
+	backendsMu.Lock()
+	checked[backend] = err
+	backendsMu.Unlock()
+	return err
+}
+
+// crossCheck scores random flop, turn and river hands with the evaluator and
+// the reference, and returns the first they rank or value differently
+func crossCheck(evaluator IHandEvaluator, hands int, seed int64) error {
+	reference := NewReferenceEvaluator()
+	rng := rand.New(rand.NewSource(seed))
+	deck := newStandardDeck()
+	for i := 0; i < hands; i++ {
+		rng.Shuffle(len(deck), func(a, b int) { deck[a], deck[b] = deck[b], deck[a] })
+		hole, board := deck[:2], deck[2:5+i%3]
+		got, want := evaluator.EvaluateHand(hole, board), reference.EvaluateHand(hole, board)
+		if got == nil || got.Rank != want.Rank || got.Value != want.Value || !slices.Equal(got.Kickers, want.Kickers) {
+			return fmt.Errorf("%v on %v scored %s, the reference %s (%d)", hole, board, describeResult(got), want.Description, want.Value)
+		}
+	}
+	return nil
+}
+
+// describeResult writes a result for a self-check failure
+func describeResult(result *HandResult) string {
+	if result == nil {
+		return "nothing"
+	}
+	return fmt.Sprintf("%s (%d)", result.Description, result.Value)
+}
+
+// newEvaluator creates the evaluator for the game's showdowns: the
+// configured backend for Hold'em, the variant's own evaluator otherwise.
+// Evaluators that can be measured report to the game's metrics sink.
+func (g *Game) newEvaluator() IHandEvaluator {
+	var evaluator IHandEvaluator
+	if g.config.Variant.Rules().Name() != VariantHoldem {
+		evaluator = g.GetVariant().NewEvaluator()
+	} else {
+		evaluator = newBackendEvaluator(g.config.Evaluator)
+	}
+	if measured, ok := evaluator.(interface{ SetMetrics(metrics.IMetrics) }); ok && g.metrics != nil {
+		measured.SetMetrics(g.metrics)
+	}
+	return evaluator
+}
@@ -0,0 +1,88 @@
+package holdem
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// corruptEvaluator scores flushes a point too high, like a damaged table
+type corruptEvaluator struct {
+	*HandEvaluator
+}
+
+func (e corruptEvaluator) EvaluateHand(holeCards []*poker.Card, communityCards poker.Cards) *HandResult {
+	result := e.HandEvaluator.EvaluateHand(holeCards, communityCards)
+	if result.Rank == Flush {
+		result.Value++
+	}
+	return result
+}
+
+// registerCorrupt registers the corrupt evaluator for the test
+func registerCorrupt(t *testing.T) EvaluatorBackend {
+	t.Helper()
+	const name EvaluatorBackend = "corrupt"
+	RegisterEvaluatorBackend(name, func() IHandEvaluator { return corruptEvaluator{NewHandEvaluator()} })
+	t.Cleanup(func() {
+		backendsMu.Lock()
+		defer backendsMu.Unlock()
+		delete(backends, name)
+		delete(checked, name)
+	})
+	return name
+}
+
+func TestCheckEvaluatorCatchesCorruption(t *testing.T) {
+	if err := CheckEvaluator(EvaluatorFast); err != nil {
+		t.Errorf("Expected the fast evaluator to pass, got %v", err)
+	}
+	corrupt := registerCorrupt(t)
+	err := CheckEvaluator(corrupt)
+	if err == nil || !strings.Contains(err.Error(), "Flush") {
+		t.Fatalf("Expected the corrupt flushes caught, got %v", err)
+	}
+
+	// Games configured with it fall back to the reference, and configs are refused
+	game := NewGameWithConfig(GameConfig{SmallBlind: 5, BigBlind: 10, Evaluator: corrupt})
+	if game.GetConfig().Evaluator != EvaluatorReference {
+		t.Errorf("Expected the reference evaluator instead, got %q", game.GetConfig().Evaluator)
+	}
+	if err := (GameConfig{SmallBlind: 5, BigBlind: 10, Evaluator: corrupt}).Validate(); err == nil {
+		t.Error("Expected a config with a failing evaluator to be invalid")
+	}
+	if err := SelectEvaluator(corrupt); err == nil {
+		t.Error("Expected a failing evaluator not to be selected")
+	}
+}
+
+func TestSelectEvaluator(t *testing.T) {
+	t.Cleanup(func() { SelectEvaluator("") })
+	if game := NewGame(5, 10); game.GetConfig().Evaluator != EvaluatorFast {
+		t.Errorf("Expected the fast evaluator by default, got %q", game.GetConfig().Evaluator)
+	}
+	if err := (GameConfig{SmallBlind: 5, BigBlind: 10, Evaluator: "lookup"}).Validate(); err == nil {
+		t.Error("Expected an unknown evaluator to be refused")
+	}
+
+	t.Setenv(EvaluatorEnv, string(EvaluatorReference))
+	if err := SelectEvaluatorFromEnv(); err != nil {
+		t.Fatalf("SelectEvaluatorFromEnv failed: %v", err)
+	}
+	game := NewGameWithConfig(GameConfig{SmallBlind: 5, BigBlind: 10, Evaluator: EvaluatorFast})
+	if game.GetConfig().Evaluator != EvaluatorReference {
+		t.Errorf("Expected the environment to override the config, got %q", game.GetConfig().Evaluator)
+	}
+	if _, ok := game.newEvaluator().(*ReferenceEvaluator); !ok {
+		t.Error("Expected showdowns scored by the reference evaluator")
+	}
+	if _, ok := VariantOmaha.Rules().NewEvaluator().(*OmahaEvaluator); !ok {
+		t.Error("Expected Omaha to keep its own evaluator")
+	}
+
+	t.Setenv(EvaluatorEnv, "bitset")
+	if err := SelectEvaluatorFromEnv(); err == nil || !strings.Contains(err.Error(), EvaluatorEnv) {
+		t.Errorf("Expected an unknown backend in the environment refused, got %v", err)
+	}
+}
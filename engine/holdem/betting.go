@@ -0,0 +1,582 @@
+package holdem
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+// PotAward is one pot paid out at the end of a hand
+type PotAward struct {
+	Amount  int         `json:"amount"`
+	Winners []int       `json:"winners"`        // Player IDs sharing the pot, first winner left of the button first
+	Hand    *HandResult `json:"hand,omitempty"` // Winning hand, nil when nobody else contested the pot
+	Rake    int         `json:"rake,omitempty"` // Chips the house took before paying Amount
+}
+
+// Share returns what one winner receives, including an odd chip if they get one
+func (a PotAward) Share(playerID int) int {
+	share, odd := a.Amount/len(a.Winners), a.Amount%len(a.Winners)
+	for i, winner := range a.Winners {
+		if winner == playerID {
+			if i < odd {
+				return share + 1
+			}
+			return share
+		}
+	}
+	return 0
+}
+
+// StartHand deals a new hand with the button at the given seat, posts antes
+// and blinds and hands the action to the first player. From here on the game
+// enforces turn order, moves chips on every action and pays the pot in AwardPot.
+func (g *Game) StartHand(button int) error {
+	if err := g.dealHand(button); err != nil {
+		return err
+	}
+	g.postForcedBets(button)
+	return nil
+}
+
+// StartBombPot deals a bomb pot: instead of blinds every player antes the
+// given amount and the hand goes straight to the flop
+func (g *Game) StartBombPot(button, ante int) error {
+	if ante <= 0 {
+		return fmt.Errorf("invalid bomb pot ante %d", ante)
+	}
+	if err := g.dealHand(button); err != nil {
+		return err
+	}
+	g.button = button
+	g.postBombPot(ante)
+	return g.DealFlop()
+}
+
+// dealHand checks the table can start a hand, deals the hole cards and
+// places the button
+func (g *Game) dealHand(button int) error {
+	if g.handActive {
+		return fmt.Errorf("hand %d is still in progress", g.handNumber)
+	}
+	if button < 0 || button >= len(g.players) || g.players[button] == nil {
+		return fmt.Errorf("no player at button seat %d", button)
+	}
+	for i, player := range g.players {
+		if player != nil && player.GetChips() <= 0 {
+			return fmt.Errorf("player %d at seat %d has no chips", player.GetID(), i)
+		}
+	}
+
+	if err := g.DealHoleCards(); err != nil {
+		return err
+	}
+	g.recordSystemAction(Action{
+		PlayerID: SystemPlayerID,
+		Type:     ActionSystemButton,
+		Amount:   button,
+	})
+	return nil
+}
+
+// postForcedBets opens the betting for a freshly dealt hand
+func (g *Game) postForcedBets(button int) {
+	g.handActive = true
+	g.button = button
+	g.awards = nil
+	g.reveals = nil
+	g.acted = [10]bool{}
+	g.protected = [10]bool{}
+	g.deadAnte = [10]int{}
+
+	smallBlind, bigBlind := g.blindSeats()
+	if ante := g.config.Ante; ante > 0 && g.config.BigBlindAnte {
+		g.postBigBlindAnte(bigBlind, ante)
+	} else if ante > 0 {
+		for i, player := range g.players {
+			if player != nil {
+				g.post(i, ActionPostAnte, ante)
+			}
+		}
+		// Antes are dead money and do not count toward the first bet
+		for _, player := range g.players {
+			if player != nil {
+				player.ResetBet()
+			}
+		}
+	}
+
+	g.post(smallBlind, ActionPostSmallBlind, g.smallBlind)
+	g.post(bigBlind, ActionPostBigBlind, g.bigBlind)
+	g.currentBet = g.bigBlind
+	g.lastRaise = g.bigBlind
+	g.acting = g.nextToAct(bigBlind)
+
+	g.log().Info("blinds posted",
+		slog.Int("button", button),
+		slog.Int("small_blind_seat", smallBlind),
+		slog.Int("big_blind_seat", bigBlind),
+	)
+}
+
+// postBigBlindAnte posts the ante for the whole table from the big blind.
+// A big blind short of both covers the blind first and antes what is left,
+// possibly nothing; whatever is anted is dead money in the main pot.
+func (g *Game) postBigBlindAnte(seat, ante int) {
+	player := g.players[seat]
+	ante = min(ante, max(player.GetChips()-g.bigBlind, 0))
+	if ante == 0 {
+		return
+	}
+	g.post(seat, ActionPostAnte, ante)
+	player.ResetBet()
+	g.deadAnte[seat] = ante
+}
+
+// postBombPot antes every player into the pot and closes the preflop
+// betting so the flop can be dealt
+func (g *Game) postBombPot(ante int) {
+	g.handActive = true
+	g.awards = nil
+	g.reveals = nil
+	g.acted = [10]bool{}
+	g.protected = [10]bool{}
+	g.deadAnte = [10]int{}
+	g.recordSystemAction(Action{
+		PlayerID: SystemPlayerID,
+		Type:     ActionSystemBombPot,
+		Amount:   ante,
+	})
+
+	for i, player := range g.players {
+		if player != nil {
+			g.post(i, ActionPostAnte, ante)
+		}
+	}
+	for _, player := range g.players {
+		if player != nil {
+			player.ResetBet()
+		}
+	}
+	g.currentBet = 0
+	g.lastRaise = g.bigBlind
+	g.acting = -1
+
+	g.log().Info("bomb pot posted", slog.Int("button", g.button), slog.Int("ante", ante))
+}
+
+// blindSeats returns the small and big blind seats. Heads-up the button
+// posts the small blind.
+func (g *Game) blindSeats() (int, int) {
+	if len(g.GetAllPlayers()) == 2 {
+		return g.button, g.nextSeat(g.button)
+	}
+	smallBlind := g.nextSeat(g.button)
+	return smallBlind, g.nextSeat(smallBlind)
+}
+
+// post puts a forced bet in for a player, all-in if they are short
+func (g *Game) post(seat int, actionType ActionType, amount int) {
+	player := g.players[seat]
+	amount = min(amount, player.GetChips())
+	player.Bet(amount)
+	g.recordAction(Action{PlayerID: player.GetID(), Type: actionType, Amount: amount})
+}
+
+// applyAction validates an action from the player to act, moves the chips
+// and passes the turn on
+func (g *Game) applyAction(action Action) error {
+	player := g.GetCurrentPlayer()
+	if player == nil {
+		return fmt.Errorf("no player to act")
+	}
+	if err := NewActionValidator().ValidateAction(g, player, action); err != nil {
+		g.log().Warn("action rejected", append(g.actionAttrs(action), slog.String("reason", err.Message))...)
+		return err
+	}
+
+	seat := g.acting
+	switch action.Type {
+	case ActionFold:
+		player.Fold()
+	case ActionDisconnect:
+		// Like an all-in for what is in the pot already: the chips behind
+		// stay out of the hand and the player only contests the pots up to
+		// their bet
+		g.protected[g.acting] = true
+	case ActionCall, ActionAllIn:
+		player.Bet(action.Amount)
+	case ActionRaise:
+		action.Amount = action.RaiseTo - player.GetBet()
+		player.Bet(action.Amount)
+	}
+
+	// Only a full raise reopens the betting for players who already acted
+	if raise := player.GetBet() - g.currentBet; raise > 0 {
+		if raise >= g.lastRaise {
+			g.lastRaise = raise
+			g.acted = [10]bool{}
+		}
+		g.currentBet = player.GetBet()
+	}
+	g.acted[seat] = true
+	g.faced[seat] = g.currentBet
+
+	if err := g.recordAction(action); err != nil {
+		return err
+	}
+	if g.countInHand() <= 1 {
+		g.acting = -1
+	} else {
+		g.acting = g.nextToAct(seat)
+	}
+	g.checkInvariants(action)
+	return nil
+}
+
+// startStreet resets the bets after new community cards are dealt
+func (g *Game) startStreet() {
+	for _, player := range g.players {
+		if player != nil {
+			player.ResetBet()
+		}
+	}
+	g.currentBet = 0
+	g.lastRaise = g.bigBlind
+	g.acted = [10]bool{}
+	g.acting = g.nextToAct(g.button)
+}
+
+// nextSeat returns the next occupied seat after seat
+func (g *Game) nextSeat(seat int) int {
+	for i := 1; i <= len(g.players); i++ {
+		next := (seat + i) % len(g.players)
+		if g.players[next] != nil {
+			return next
+		}
+	}
+	return seat
+}
+
+// nextToAct returns the first seat after seat that still owes an action,
+// or -1 when the betting round is over
+func (g *Game) nextToAct(seat int) int {
+	canAct := 0
+	for i := range g.players {
+		if g.canBet(i) {
+			canAct++
+		}
+	}
+
+	for i := 1; i <= len(g.players); i++ {
+		next := (seat + i) % len(g.players)
+		player := g.players[next]
+		if !g.canBet(next) {
+			continue
+		}
+		behind := player.GetBet() < g.currentBet
+		// A lone player with chips left has nobody to bet against
+		if canAct == 1 && !behind {
+			return -1
+		}
+		if !g.acted[next] || behind {
+			return next
+		}
+	}
+	return -1
+}
+
+// canBet reports whether the player in seat still bets: in the hand and
+// neither all in nor protected after disconnecting
+func (g *Game) canBet(seat int) bool {
+	player := g.players[seat]
+	return player != nil && !player.IsFolded() && player.GetChips() > 0 && !g.protected[seat]
+}
+
+// reopenedFor reports whether the player in seat may raise: they have not
+// acted since the last full raise, or the short all-ins raised since they
+// acted add up to a full raise
+func (g *Game) reopenedFor(seat int) bool {
+	return !g.acted[seat] || g.currentBet-g.faced[seat] >= g.lastRaise
+}
+
+// IsProtected reports whether a player disconnected and stays in the hand
+// all in for what they put in, see DisconnectAllIn
+func (g *Game) IsProtected(playerID int) bool {
+	seat, err := g.GetPlayerSitByID(playerID)
+	return err == nil && g.protected[seat]
+}
+
+// countInHand returns the number of players who have not folded
+func (g *Game) countInHand() int {
+	count := 0
+	for _, player := range g.players {
+		if player != nil && !player.IsFolded() {
+			count++
+		}
+	}
+	return count
+}
+
+// IsHandInProgress reports whether a hand started with StartHand is still being played
+func (g *Game) IsHandInProgress() bool {
+	return g.handActive
+}
+
+// IsBettingRoundOpen reports whether a player still has to act on this street
+func (g *Game) IsBettingRoundOpen() bool {
+	return g.handActive && g.acting >= 0
+}
+
+// IsHandOver reports whether no more cards need to be dealt: everyone but
+// one player folded, or the river betting is complete
+func (g *Game) IsHandOver() bool {
+	if !g.handActive {
+		return false
+	}
+	if g.countInHand() <= 1 {
+		return true
+	}
+	return g.acting < 0 && g.currentPhase == PhaseRiver
+}
+
+// IsAllInRunout reports whether the rest of the board is dealt without
+// more betting: two or more players are in the hand and at most one of them
+// has chips behind, with nobody left to act
+func (g *Game) IsAllInRunout() bool {
+	if !g.handActive || g.acting >= 0 {
+		return false
+	}
+	inHand, withChips := 0, 0
+	for _, player := range g.players {
+		if player == nil || player.IsFolded() || len(player.GetHandCards()) == 0 {
+			continue
+		}
+		inHand++
+		if player.GetChips() > 0 {
+			withChips++
+		}
+	}
+	return inHand >= 2 && withChips <= 1
+}
+
+// HasOption reports whether the player is the big blind preflop and nobody
+// raised: the blind already counts as their bet, so they may check to close
+// the betting or raise. The option is used up once they act.
+func (g *Game) HasOption(player IPlayer) bool {
+	if player == nil || g.currentPhase != PhasePreflop || player.IsFolded() || player.GetChips() == 0 {
+		return false
+	}
+	bigBlind := 0 // Hands logged with ActionPostBlind post the big blind last
+	for _, action := range g.userActions.Preflop {
+		switch {
+		case action.Type == ActionPostBigBlind || action.Type == ActionPostBlind:
+			bigBlind = action.PlayerID
+		case action.PlayerID == player.GetID() && !action.Type.IsForced():
+			return false
+		}
+	}
+	return bigBlind == player.GetID() && g.GetCurrentBet() <= player.GetBet()
+}
+
+// GetButton returns the button seat of the current hand, -1 before the first hand
+func (g *Game) GetButton() int {
+	return g.button
+}
+
+// GetActingSeat returns the seat whose turn it is, -1 when nobody is to act
+func (g *Game) GetActingSeat() int {
+	if !g.handActive {
+		return -1
+	}
+	return g.acting
+}
+
+// GetCurrentBet returns the highest bet on the current street
+func (g *Game) GetCurrentBet() int {
+	if g.handActive {
+		return g.currentBet
+	}
+
+	// Without a managed hand the bet is inferred from the logged actions
+	return g.loggedStreetBets().current
+}
+
+// loggedStreetBets replays the logged actions of the current street
+func (g *Game) loggedStreetBets() *streetBets {
+	bets := newStreetBets(g.bigBlind)
+	for _, action := range g.userActions.Street(g.currentPhase) {
+		bets.apply(action)
+	}
+	return bets
+}
+
+// GetPot returns every chip put in during the current or last hand
+func (g *Game) GetPot() int {
+	pot := 0
+	for _, player := range g.players {
+		if player != nil {
+			pot += player.GetTotalBet()
+		}
+	}
+	return pot
+}
+
+// PotAtStreet returns the pot when the betting on a street of the current
+// or last hand began, see UserActions.PotAtStreet. At the showdown it is
+// the whole pot; streets the hand did not reach have none.
+func (g *Game) PotAtStreet(phase GamePhase) int {
+	switch {
+	case phase < PhasePreflop || phase > g.currentPhase:
+		return 0
+	case phase == PhaseShowdown:
+		return g.GetPot()
+	default:
+		return g.userActions.PotAtStreet(phase)
+	}
+}
+
+// GetPotAwards returns the pots paid out at the end of the last hand
+func (g *Game) GetPotAwards() []PotAward {
+	awards := make([]PotAward, len(g.awards))
+	copy(awards, g.awards)
+	return awards
+}
+
+// AwardPot ends the hand, paying the main pot and any side pots to the best
+// hands among the players eligible for them. Uncalled chips go back to the
+// player who bet them as a pot of their own.
+func (g *Game) AwardPot() ([]PotAward, error) {
+	if !g.handActive {
+		return nil, fmt.Errorf("no hand in progress")
+	}
+	contested := g.countInHand() > 1
+	if contested && (g.acting >= 0 || len(g.communityCards) < 5) {
+		return nil, fmt.Errorf("hand is not finished")
+	}
+
+	g.recordSystemAction(Action{
+		PlayerID: SystemPlayerID,
+		Type:     ActionSystemAwardPot,
+		Amount:   g.GetPot(),
+	})
+
+	g.awards = g.buildPots()
+	if rake := g.takeRake(g.awards); rake > 0 {
+		g.recordSystemAction(Action{
+			PlayerID: SystemPlayerID,
+			Type:     ActionSystemRake,
+			Amount:   rake,
+		})
+		g.log().Info("rake taken", slog.Int("amount", rake))
+	}
+	for _, award := range g.awards {
+		for _, id := range award.Winners {
+			player, _ := g.GetPlayerByID(id)
+			player.GrandChips(award.Share(id))
+		}
+		g.log().Info("pot awarded",
+			slog.Int("amount", award.Amount),
+			slog.Any("winners", award.Winners),
+		)
+	}
+
+	// Showdown is not journaled, AwardPot reproduces it on replay
+	if contested {
+		g.currentPhase = PhaseShowdown
+	}
+	g.handActive = false
+	g.acting = -1
+	return g.GetPotAwards(), nil
+}
+
+// stake returns the chips a seat put in the hand that it contests, leaving
+// out a big blind ante, which every player contests in the main pot
+func (g *Game) stake(seat int) int {
+	return g.players[seat].GetTotalBet() - g.deadAnte[seat]
+}
+
+// buildPots splits the chips into pots by the contribution levels of the
+// players still in the hand and picks each pot's winners. A big blind ante
+// goes to the main pot, whatever the big blind's own stake.
+func (g *Game) buildPots() []PotAward {
+	// Players still in, starting left of the button so odd chips go there first
+	contenders := []int{}
+	for i := 1; i <= len(g.players); i++ {
+		seat := (g.button + i) % len(g.players)
+		if player := g.players[seat]; player != nil && !player.IsFolded() {
+			contenders = append(contenders, seat)
+		}
+	}
+
+	levels := []int{}
+	for _, seat := range contenders {
+		levels = append(levels, g.stake(seat))
+	}
+	sort.Ints(levels)
+	dead := 0
+	for _, ante := range g.deadAnte {
+		dead += ante
+	}
+
+	evaluator := g.newEvaluator()
+	results := map[int]*HandResult{}
+	awards := []PotAward{}
+	previous := 0
+	for i, level := range levels {
+		if level <= previous && i > 0 {
+			continue
+		}
+		amount := 0
+		if len(awards) == 0 {
+			amount = dead
+		}
+		for seat, player := range g.players {
+			if player == nil {
+				continue
+			}
+			total := g.stake(seat)
+			amount += min(total, level) - min(total, previous)
+			// Folded chips above every live stack go to the last pot
+			if i == len(levels)-1 && total > level {
+				amount += total - level
+			}
+		}
+		eligible := []int{}
+		for _, seat := range contenders {
+			if g.stake(seat) >= level {
+				eligible = append(eligible, seat)
+			}
+		}
+		previous = level
+		if amount == 0 {
+			continue
+		}
+		awards = append(awards, g.potWinners(amount, eligible, evaluator, results))
+	}
+	return awards
+}
+
+// potWinners finds the best hands among the eligible seats
+func (g *Game) potWinners(amount int, eligible []int, evaluator IHandEvaluator, results map[int]*HandResult) PotAward {
+	if len(eligible) == 1 {
+		return PotAward{Amount: amount, Winners: []int{g.players[eligible[0]].GetID()}}
+	}
+
+	award := PotAward{Amount: amount}
+	for _, seat := range eligible {
+		result, ok := results[seat]
+		if !ok {
+			result = evaluator.EvaluateHand(g.players[seat].GetHandCards(), g.communityCards)
+			results[seat] = result
+		}
+		switch {
+		case award.Hand == nil || evaluator.CompareHands(result, award.Hand) > 0:
+			award.Hand = result
+			award.Winners = []int{g.players[seat].GetID()}
+		case evaluator.CompareHands(result, award.Hand) == 0:
+			award.Winners = append(award.Winners, g.players[seat].GetID())
+		}
+	}
+	return award
+}
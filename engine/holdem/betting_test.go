@@ -0,0 +1,580 @@
+package holdem
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newManagedGame seats one player per stack at seats 0, 1, 2... with IDs 1, 2, 3...
+func newManagedGame(t testing.TB, config GameConfig, stacks ...int) *Game {
+	t.Helper()
+	if config.Seed == 0 {
+		config.Seed = 7
+	}
+	game := NewGameWithConfig(config)
+	for i, stack := range stacks {
+		if err := game.PlayerSit(NewPlayer(i+1, "", stack), i); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	return game
+}
+
+// mustAct takes an action, where a raise's amount is the bet it raises to
+func mustAct(t *testing.T, game *Game, playerID int, actionType ActionType, amount int) {
+	t.Helper()
+	action := Action{PlayerID: playerID, Type: actionType, Amount: amount}
+	if actionType == ActionRaise {
+		action = NewRaise(playerID, amount)
+	}
+	if err := game.TakeAction(action); err != nil {
+		t.Fatalf("Player %d %s %d failed: %v", playerID, ActionTypeToString(actionType), amount, err)
+	}
+}
+
+func chipsOf(game *Game, playerID int) int {
+	player, _ := game.GetPlayerByID(playerID)
+	return player.GetChips()
+}
+
+func TestStartHandPostsBlindsAndSetsTurn(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+
+	if chipsOf(game, 2) != 995 || chipsOf(game, 3) != 990 {
+		t.Errorf("Expected blinds from seats 1 and 2, got chips %d and %d", chipsOf(game, 2), chipsOf(game, 3))
+	}
+	if game.GetActingSeat() != 3 || game.GetCurrentPlayer().GetID() != 4 {
+		t.Errorf("Expected seat 3 to act first, got %d", game.GetActingSeat())
+	}
+	if game.GetCurrentBet() != 10 || game.GetPot() != 15 {
+		t.Errorf("Expected bet 10 and pot 15, got %d and %d", game.GetCurrentBet(), game.GetPot())
+	}
+	if err := game.TakeAction(Action{PlayerID: 1, Type: ActionFold}); err == nil {
+		t.Error("Expected out of turn action to be rejected")
+	}
+	if err := game.StartHand(0); err == nil {
+		t.Error("Expected StartHand to fail while a hand is in progress")
+	}
+}
+
+func TestHeadsUpButtonPostsSmallBlind(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 500, 500)
+	if err := game.StartHand(1); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	if chipsOf(game, 2) != 495 || game.GetActingSeat() != 1 {
+		t.Fatalf("Expected the button to post the small blind and act first")
+	}
+
+	mustAct(t, game, 2, ActionCall, 5)
+	// The big blind keeps the option to raise after a limp
+	if game.GetActingSeat() != 0 {
+		t.Fatalf("Expected the big blind option, acting seat is %d", game.GetActingSeat())
+	}
+	mustAct(t, game, 1, ActionCheck, 0)
+	if game.IsBettingRoundOpen() {
+		t.Fatal("Expected preflop betting to be closed")
+	}
+
+	if err := game.DealFlop(); err != nil {
+		t.Fatalf("DealFlop failed: %v", err)
+	}
+	if game.GetActingSeat() != 0 {
+		t.Errorf("Expected the big blind to act first after the flop, got seat %d", game.GetActingSeat())
+	}
+}
+
+func TestDealRequiresClosedBettingRound(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 500, 500, 500)
+	game.StartHand(0)
+	if err := game.DealFlop(); err == nil {
+		t.Error("Expected DealFlop to fail while players still have to act")
+	}
+}
+
+func TestFoldsAwardPotToLastPlayer(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000)
+	game.StartHand(0)
+
+	mustAct(t, game, 1, ActionRaise, 30)
+	mustAct(t, game, 2, ActionFold, 0)
+	mustAct(t, game, 3, ActionFold, 0)
+	if !game.IsHandOver() {
+		t.Fatal("Expected hand to be over")
+	}
+
+	awards, err := game.AwardPot()
+	if err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	if len(awards) != 1 || awards[0].Amount != 45 || awards[0].Winners[0] != 1 || awards[0].Hand != nil {
+		t.Fatalf("Expected uncontested 45 chip pot for player 1, got %+v", awards)
+	}
+	if chipsOf(game, 1) != 1015 || chipsOf(game, 2) != 995 || chipsOf(game, 3) != 990 {
+		t.Errorf("Unexpected stacks %d/%d/%d", chipsOf(game, 1), chipsOf(game, 2), chipsOf(game, 3))
+	}
+	if game.IsHandInProgress() || game.GetCurrentPhase() == PhaseShowdown {
+		t.Error("Expected hand to end without a showdown")
+	}
+}
+
+func TestBigBlindHasOption(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000)
+	game.StartHand(0)
+	validator := NewActionValidator()
+	smallBlind, _ := game.GetPlayerByID(2)
+	bigBlind, _ := game.GetPlayerByID(3)
+
+	mustAct(t, game, 1, ActionCall, 10)
+	mustAct(t, game, 2, ActionCall, 5)
+	if game.HasOption(smallBlind) || !game.HasOption(bigBlind) {
+		t.Fatal("Expected only the big blind to have the option after limps")
+	}
+	available := validator.GetAvailableActions(game, bigBlind)
+	if !slices.Contains(available, ActionCheck) || !slices.Contains(available, ActionRaise) || slices.Contains(available, ActionCall) {
+		t.Errorf("Expected check or raise on the option, got %v", available)
+	}
+	err := game.TakeAction(Action{PlayerID: 3, Type: ActionCall})
+	if err == nil || !strings.Contains(err.Error(), "option") {
+		t.Errorf("Expected calling nothing on the option to be rejected, got %v", err)
+	}
+
+	mustAct(t, game, 3, ActionCheck, 0)
+	if game.HasOption(bigBlind) || game.IsBettingRoundOpen() {
+		t.Error("Expected checking the option to close the round")
+	}
+}
+
+func TestRaiseTakesAwayTheOption(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000)
+	game.StartHand(0)
+	bigBlind, _ := game.GetPlayerByID(3)
+
+	mustAct(t, game, 1, ActionRaise, 30)
+	mustAct(t, game, 2, ActionFold, 0)
+	if game.HasOption(bigBlind) {
+		t.Error("Expected a raise to take away the option")
+	}
+	mustAct(t, game, 3, ActionCall, 20)
+}
+
+func TestUnmanagedCurrentBetCountsBlinds(t *testing.T) {
+	game := NewGame(5, 10)
+	game.PlayerSit(NewPlayer(1, "", 1000), 0)
+	game.PlayerSit(NewPlayer(2, "", 1000), 1)
+	game.userActions.Preflop = []Action{
+		{PlayerID: 1, Type: ActionPostBlind, Amount: 5},
+		{PlayerID: 2, Type: ActionPostBlind, Amount: 10},
+	}
+	if game.GetCurrentBet() != 10 {
+		t.Errorf("Expected the big blind to be the bet to call, got %d", game.GetCurrentBet())
+	}
+}
+
+func TestRaiseReopensBettingAndSetsMinimum(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000)
+	game.StartHand(0)
+	validator := NewActionValidator()
+
+	mustAct(t, game, 1, ActionRaise, 40)
+	player2, _ := game.GetPlayerByID(2)
+	if got := validator.GetMinRaiseAmount(game, player2); got != 35+30 {
+		t.Errorf("Expected minimum raise of 65 chips for the small blind, got %d", got)
+	}
+	if err := game.TakeAction(NewRaise(2, 60)); err == nil {
+		t.Error("Expected raise below the last raise size to be rejected")
+	}
+	mustAct(t, game, 2, ActionCall, 35)
+	mustAct(t, game, 3, ActionRaise, 100)
+	if game.GetActingSeat() != 0 {
+		t.Fatalf("Expected action back on the original raiser, got seat %d", game.GetActingSeat())
+	}
+	mustAct(t, game, 1, ActionCall, 60)
+	mustAct(t, game, 2, ActionCall, 60)
+	if game.IsBettingRoundOpen() || game.GetPot() != 300 {
+		t.Errorf("Expected round closed with 300 in the pot, got %d", game.GetPot())
+	}
+}
+
+func TestShortAllInDoesNotReopenBetting(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 45)
+	game.StartHand(0)
+
+	mustAct(t, game, 1, ActionRaise, 30)
+	mustAct(t, game, 2, ActionCall, 25)
+	mustAct(t, game, 3, ActionAllIn, 35)
+	if game.GetActingSeat() != 0 {
+		t.Fatalf("Expected action back on the raiser, got seat %d", game.GetActingSeat())
+	}
+
+	err := game.TakeAction(NewRaise(1, 100))
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Code != ErrorActionNotAllowed {
+		t.Fatalf("Expected a raise after a short all-in to be rejected, got %v", err)
+	}
+	if verr.Suggested != ActionCall || verr.NearestAmount != 15 {
+		t.Errorf("Expected a call of 15 suggested, got %s %d", ActionTypeToString(verr.Suggested), verr.NearestAmount)
+	}
+	if err := game.TakeAction(Action{PlayerID: 1, Type: ActionAllIn, Amount: 970}); err == nil {
+		t.Error("Expected an all-in raise after a short all-in to be rejected")
+	}
+	mustAct(t, game, 1, ActionCall, 15)
+	mustAct(t, game, 2, ActionCall, 15)
+	if game.IsBettingRoundOpen() || game.GetPot() != 135 {
+		t.Errorf("Expected round closed with 135 in the pot, got %d", game.GetPot())
+	}
+}
+
+func TestShortAllInsAddingUpToAFullRaiseReopenBetting(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 45, 60, 1000)
+	game.StartHand(0)
+
+	mustAct(t, game, 4, ActionRaise, 30)
+	mustAct(t, game, 1, ActionCall, 30)
+	mustAct(t, game, 2, ActionAllIn, 40)
+	mustAct(t, game, 3, ActionAllIn, 50)
+	mustAct(t, game, 4, ActionRaise, 100)
+}
+
+func TestSidePotsAndChipConservation(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 100, 300, 500)
+	game.StartHand(0)
+
+	mustAct(t, game, 1, ActionAllIn, 100)
+	mustAct(t, game, 2, ActionAllIn, 295)
+	mustAct(t, game, 3, ActionCall, 290)
+	if game.IsBettingRoundOpen() {
+		t.Fatal("Expected no more betting with two players all-in")
+	}
+	for _, deal := range []func() error{game.DealFlop, game.DealTurn, game.DealRiver} {
+		if err := deal(); err != nil {
+			t.Fatalf("Deal failed: %v", err)
+		}
+		if game.IsBettingRoundOpen() {
+			t.Fatal("Expected the lone player with chips not to be asked to act")
+		}
+	}
+	if !game.IsHandOver() {
+		t.Fatal("Expected hand to be over after the river")
+	}
+
+	awards, err := game.AwardPot()
+	if err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	if len(awards) != 2 || awards[0].Amount != 300 || awards[1].Amount != 400 {
+		t.Fatalf("Expected a 300 main pot and 400 side pot, got %+v", awards)
+	}
+	for _, winner := range awards[1].Winners {
+		if winner == 1 {
+			t.Error("Short stack must not win the side pot")
+		}
+	}
+	total := chipsOf(game, 1) + chipsOf(game, 2) + chipsOf(game, 3)
+	if total != 900 {
+		t.Errorf("Expected 900 chips in play, got %d", total)
+	}
+	if game.GetCurrentPhase() != PhaseShowdown {
+		t.Error("Expected a contested hand to end at showdown")
+	}
+}
+
+func TestUncalledBetIsReturned(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 200)
+	game.StartHand(0)
+
+	mustAct(t, game, 1, ActionRaise, 510) // More than the big blind has
+	mustAct(t, game, 2, ActionAllIn, 190)
+	game.DealFlop()
+	game.DealTurn()
+	game.DealRiver()
+	awards, err := game.AwardPot()
+	if err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	last := awards[len(awards)-1]
+	if last.Amount != 310 || len(last.Winners) != 1 || last.Winners[0] != 1 {
+		t.Errorf("Expected 310 uncalled chips back to player 1, got %+v", last)
+	}
+}
+
+func TestAntesAreDeadMoney(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Ante: 2}, 100, 100, 100)
+	game.StartHand(0)
+
+	if game.GetPot() != 21 {
+		t.Errorf("Expected 6 in antes plus 15 in blinds, got %d", game.GetPot())
+	}
+	player1, _ := game.GetPlayerByID(1)
+	if player1.GetBet() != 0 || player1.GetChips() != 98 {
+		t.Errorf("Expected the ante not to count as a bet, got bet %d chips %d", player1.GetBet(), player1.GetChips())
+	}
+}
+
+func TestShortAnteContestsMainPot(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Ante: 2}, 1, 1000, 1000)
+	game.StartHand(0)
+
+	// The button is all in with a one-chip ante and wins at most a chip from each player
+	mustAct(t, game, 2, ActionCall, 5)
+	mustAct(t, game, 3, ActionCheck, 0)
+	checkDown(t, game, 2, 3)
+	awards, err := game.AwardPot()
+	if err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	if len(awards) != 2 || awards[0].Amount != 3 || awards[1].Amount != 22 {
+		t.Errorf("Expected a 3 chip main pot and a 22 chip side pot, got %+v", awards)
+	}
+}
+
+func TestBigBlindAntePaysForTable(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Ante: 10, BigBlindAnte: true}, 1000, 1000, 1000)
+	game.StartHand(0)
+
+	if game.GetPot() != 25 || chipsOf(game, 1) != 1000 || chipsOf(game, 3) != 980 {
+		t.Fatalf("Expected only the big blind to ante, got a pot of %d", game.GetPot())
+	}
+	preflop := game.GetUserActions().Preflop
+	if preflop[0].Type != ActionPostAnte || preflop[0].PlayerID != 3 || preflop[1].Type != ActionPostSmallBlind || preflop[2].Type != ActionPostBigBlind {
+		t.Errorf("Expected the big blind's ante before the blinds, got %+v", preflop)
+	}
+	if big, _ := game.GetPlayerByID(3); big.GetBet() != 10 || game.GetCurrentBet() != 10 {
+		t.Errorf("Expected the ante not to count as a bet, got %d", big.GetBet())
+	}
+
+	// The ante is not an uncalled bet: the big blind wins it with the blinds
+	mustAct(t, game, 1, ActionFold, 0)
+	mustAct(t, game, 2, ActionFold, 0)
+	awards, err := game.AwardPot()
+	if err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	if len(awards) != 1 || awards[0].Amount != 25 || chipsOf(game, 3) != 1005 {
+		t.Errorf("Expected the big blind to win the 25 chip pot, got %+v", awards)
+	}
+}
+
+func TestBigBlindShortOfAnte(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Ante: 10, BigBlindAnte: true}, 1000, 1000, 15)
+	game.StartHand(0)
+
+	// The blind comes first, leaving 5 chips for the ante
+	if preflop := game.GetUserActions().Preflop; preflop[0].Amount != 5 || preflop[2].Amount != 10 || chipsOf(game, 3) != 0 {
+		t.Fatalf("Expected the big blind posted in full and 5 anted, got %+v", preflop)
+	}
+	mustAct(t, game, 1, ActionRaise, 100)
+	mustAct(t, game, 2, ActionCall, 95)
+	checkDown(t, game, 2, 1)
+	awards, err := game.AwardPot()
+	if err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	// Every player contests the short ante in the main pot, with 10 from each stake
+	if len(awards) != 2 || awards[0].Amount != 35 || awards[1].Amount != 180 {
+		t.Errorf("Expected a 35 chip main pot and a 180 chip side pot, got %+v", awards)
+	}
+	if total := chipsOf(game, 1) + chipsOf(game, 2) + chipsOf(game, 3); total != 2015 {
+		t.Errorf("Expected 2015 chips in play, got %d", total)
+	}
+}
+
+// checkDown deals the rest of the board with the players checking every street
+func checkDown(t *testing.T, game *Game, playerIDs ...int) {
+	t.Helper()
+	for _, deal := range []func() error{game.DealFlop, game.DealTurn, game.DealRiver} {
+		if err := deal(); err != nil {
+			t.Fatalf("Dealing failed: %v", err)
+		}
+		for _, id := range playerIDs {
+			mustAct(t, game, id, ActionCheck, 0)
+		}
+	}
+}
+
+func TestPotAtStreet(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Ante: 2}, 1000, 1000, 1000)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionRaise, 30)
+	mustAct(t, game, 2, ActionCall, 25)
+	mustAct(t, game, 3, ActionCall, 20)
+	game.DealFlop()
+	if view := game.SpectatorView(); view.StreetPot != 96 {
+		t.Errorf("Expected the flop to start with 96 in the pot, got %d", view.StreetPot)
+	}
+	mustAct(t, game, 2, ActionRaise, 50)
+	mustAct(t, game, 3, ActionFold, 0)
+	if view := game.SpectatorView(); view.StreetPot != 96 || view.Pot != 146 {
+		t.Errorf("Expected a 96 pot with a bet of 50 in front of it, got %d and %d", view.StreetPot, view.Pot)
+	}
+	mustAct(t, game, 1, ActionCall, 50)
+	game.DealTurn()
+
+	for phase, want := range map[GamePhase]int{PhasePreflop: 21, PhaseFlop: 96, PhaseTurn: 196, PhaseRiver: 0} {
+		if got := game.PotAtStreet(phase); got != want {
+			t.Errorf("Expected the %s to start with %d in the pot, got %d", PhaseToString(phase), want, got)
+		}
+	}
+}
+
+func TestDisconnectedPlayerContestsMainPot(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 21, Disconnect: DisconnectAllIn}, 1000, 1000, 1000)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionRaise, 100)
+	if err := game.TakeAction(NewDisconnect(2)); err != nil {
+		t.Fatalf("Disconnect failed: %v", err)
+	}
+	if !game.IsProtected(2) || game.IsProtected(3) {
+		t.Fatal("Expected only player 2 to be protected")
+	}
+	mustAct(t, game, 3, ActionCall, 90)
+
+	// The protected small blind is skipped like a player all in
+	game.DealFlop()
+	mustAct(t, game, 3, ActionRaise, 200)
+	mustAct(t, game, 1, ActionCall, 200)
+	game.DealTurn()
+	mustAct(t, game, 3, ActionCheck, 0)
+	mustAct(t, game, 1, ActionCheck, 0)
+	game.DealRiver()
+	mustAct(t, game, 3, ActionCheck, 0)
+	mustAct(t, game, 1, ActionCheck, 0)
+	if !game.IsHandOver() {
+		t.Fatal("Expected the hand to be over without player 2 acting again")
+	}
+
+	awards, err := game.AwardPot()
+	if err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	if len(awards) != 2 || awards[0].Amount != 15 || awards[1].Amount != 590 {
+		t.Fatalf("Expected a main pot of 15 and a side pot of 590, got %+v", awards)
+	}
+	for _, id := range awards[1].Winners {
+		if id == 2 {
+			t.Error("Expected the protected player to be left out of the side pot")
+		}
+	}
+	if want := 995 + awards[0].Share(2); chipsOf(game, 2) != want {
+		t.Errorf("Expected player 2 to keep their stack behind, %d, got %d", want, chipsOf(game, 2))
+	}
+
+	replay, err := NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("NewReplay failed: %v", err)
+	}
+	replayed, err := replay.Run()
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	for id := 1; id <= 3; id++ {
+		if chipsOf(replayed, id) != chipsOf(game, id) {
+			t.Errorf("Player %d: expected %d chips after replay, got %d", id, chipsOf(game, id), chipsOf(replayed, id))
+		}
+	}
+}
+
+func TestDisconnectRefusedWithoutProtection(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000)
+	game.StartHand(0)
+	err := game.TakeAction(NewDisconnect(1))
+	var validation *ValidationError
+	if !errors.As(err, &validation) || validation.Code != ErrorInvalidAction {
+		t.Fatalf("Expected the disconnect to be refused, got %v", err)
+	}
+	if game.IsProtected(1) {
+		t.Error("Expected no protection after a refused disconnect")
+	}
+}
+
+func TestManagedHandReplays(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Ante: 1, Seed: 99}, 300, 400, 500)
+	game.StartHand(1)
+	mustAct(t, game, 2, ActionCall, 10)
+	mustAct(t, game, 3, ActionCall, 5)
+	mustAct(t, game, 1, ActionCheck, 0)
+	game.DealFlop()
+	mustAct(t, game, 3, ActionRaise, 20)
+	mustAct(t, game, 1, ActionFold, 0)
+	mustAct(t, game, 2, ActionCall, 20)
+	game.DealTurn()
+	mustAct(t, game, 3, ActionCheck, 0)
+	mustAct(t, game, 2, ActionCheck, 0)
+	game.DealRiver()
+	mustAct(t, game, 3, ActionCheck, 0)
+	mustAct(t, game, 2, ActionCheck, 0)
+	if _, err := game.AwardPot(); err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+
+	replay, err := NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("NewReplay failed: %v", err)
+	}
+	replayed, err := replay.Run()
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	for id := 1; id <= 3; id++ {
+		if chipsOf(replayed, id) != chipsOf(game, id) {
+			t.Errorf("Player %d: expected %d chips after replay, got %d", id, chipsOf(game, id), chipsOf(replayed, id))
+		}
+	}
+}
+
+func TestPotAwardShare(t *testing.T) {
+	award := PotAward{Amount: 101, Winners: []int{4, 2}}
+	if award.Share(4) != 51 || award.Share(2) != 50 || award.Share(9) != 0 {
+		t.Errorf("Unexpected shares %d/%d/%d", award.Share(4), award.Share(2), award.Share(9))
+	}
+}
+
+func TestBombPotStartsOnTheFlop(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 11}, 300, 400, 15)
+	if err := game.StartBombPot(0, 0); err == nil {
+		t.Error("Expected a bomb pot without an ante to be rejected")
+	}
+	if err := game.StartBombPot(0, 20); err != nil {
+		t.Fatalf("StartBombPot failed: %v", err)
+	}
+
+	// The short stack is all in for 15, nobody posts blinds
+	if game.GetPot() != 55 || chipsOf(game, 1) != 280 || chipsOf(game, 3) != 0 {
+		t.Errorf("Expected antes of 20, 20 and 15, got pot %d", game.GetPot())
+	}
+	if game.GetCurrentPhase() != PhaseFlop || len(game.GetCommunityCards()) != 3 {
+		t.Fatalf("Expected the hand to start on the flop, got %s", PhaseToString(game.GetCurrentPhase()))
+	}
+	if game.GetCurrentBet() != 0 || game.GetCurrentPlayer().GetID() != 2 {
+		t.Errorf("Expected an unopened flop with seat 1 to act, got bet %d", game.GetCurrentBet())
+	}
+
+	mustAct(t, game, 2, ActionCheck, 0)
+	mustAct(t, game, 1, ActionCheck, 0)
+	game.DealTurn()
+	mustAct(t, game, 2, ActionRaise, 10)
+	mustAct(t, game, 1, ActionFold, 0)
+	if _, err := game.AwardPot(); err == nil {
+		t.Fatal("Expected the all-in player's pot to need a river")
+	}
+	game.DealRiver()
+	if _, err := game.AwardPot(); err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	if total := chipsOf(game, 1) + chipsOf(game, 2) + chipsOf(game, 3); total != 715 {
+		t.Errorf("Expected 715 chips in play, got %d", total)
+	}
+
+	replay, err := NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("NewReplay failed: %v", err)
+	}
+	if _, err := replay.Run(); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package holdem
+
+import "slices"
+
+// Clone returns a copy of the game, hand in progress included, that shares
+// nothing the game goes on to change. Code that reads the table on another
+// goroutine, such as a decision maker that may still be thinking when the
+// game moves on, reads a clone instead. The clone deals from its own RNG.
+func (g *Game) Clone() *Game {
+	clone := *g
+	for seat, player := range g.players {
+		if player != nil {
+			clone.players[seat] = clonePlayer(player)
+		}
+	}
+	clone.deck = slices.Clone(g.deck)
+	clone.communityCards = slices.Clone(g.communityCards)
+	clone.systemActions = SystemActions{
+		Preflop: slices.Clone(g.systemActions.Preflop),
+		Flop:    slices.Clone(g.systemActions.Flop),
+		Turn:    slices.Clone(g.systemActions.Turn),
+		River:   slices.Clone(g.systemActions.River),
+	}
+	clone.userActions = UserActions{
+		Preflop: slices.Clone(g.userActions.Preflop),
+		Flop:    slices.Clone(g.userActions.Flop),
+		Turn:    slices.Clone(g.userActions.Turn),
+		River:   slices.Clone(g.userActions.River),
+	}
+	clone.rng = nil
+	clone.handDeck = slices.Clone(g.handDeck)
+	clone.stacked = slices.Clone(g.stacked)
+	clone.handStacked = slices.Clone(g.handStacked)
+	clone.shuffleNonce = slices.Clone(g.shuffleNonce)
+	clone.journal = slices.Clone(g.journal)
+	// Finished hands are never changed, only appended to or dropped
+	clone.history = slices.Clip(g.history)
+	clone.handStartSeats = slices.Clone(g.handStartSeats)
+	clone.awards = slices.Clone(g.awards)
+	clone.reveals = slices.Clone(g.reveals)
+	return &clone
+}
+
+// clonePlayer copies a player with their cards, stack and bets
+func clonePlayer(player IPlayer) IPlayer {
+	if p, ok := player.(*Player); ok {
+		clone := *p
+		clone.cards = slices.Clone(p.cards)
+		return &clone
+	}
+	// Other implementations are rebuilt through the interface
+	clone := NewPlayer(player.GetID(), player.GetName(), player.GetChips()+player.GetTotalBet())
+	for _, card := range player.GetHandCards() {
+		clone.DealCard(card)
+	}
+	clone.Bet(player.GetTotalBet() - player.GetBet()).ResetBet().Bet(player.GetBet())
+	if player.IsFolded() {
+		clone.Fold()
+	}
+	return clone
+}
@@ -0,0 +1,34 @@
+package holdem
+
+import "testing"
+
+func TestCloneKeepsTheHandAndSharesNothing(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	mustAct(t, game, 1, ActionCall, 10)
+
+	clone := game.Clone()
+	if clone.PrivateStateHash() != game.PrivateStateHash() {
+		t.Fatal("Expected the clone to hold the same hand")
+	}
+	before := clone.PrivateStateHash()
+
+	// The game plays on to the flop without the clone seeing any of it
+	mustAct(t, game, 2, ActionCall, 5)
+	mustAct(t, game, 3, ActionCheck, 0)
+	if err := game.DealFlop(); err != nil {
+		t.Fatalf("DealFlop failed: %v", err)
+	}
+	if clone.PrivateStateHash() != before || len(clone.GetCommunityCards()) != 0 || chipsOf(clone, 2) != 995 {
+		t.Error("Expected the clone to stay as it was when cloned")
+	}
+
+	// And the clone plays on without the game seeing it
+	after := game.PrivateStateHash()
+	mustAct(t, clone, 2, ActionFold, 0)
+	if player, _ := game.GetPlayerByID(2); player.IsFolded() || game.PrivateStateHash() != after {
+		t.Error("Expected the game unchanged by the clone's action")
+	}
+}
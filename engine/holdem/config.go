@@ -0,0 +1,187 @@
+package holdem
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"time"
+)
+
+// maxSeats is the size of the biggest table the engine deals
+const maxSeats = 10
+
+// GameConfig describes the table parameters a game is created with
+type GameConfig struct {
+	TableID    string `json:"table_id,omitempty"` // Unique table ID, generated when empty
+	SmallBlind int    `json:"small_blind"`
+	BigBlind   int    `json:"big_blind"`
+	Ante       int    `json:"ante,omitempty"`
+	Seed       int64  `json:"seed"` // Master RNG seed, 0 picks a time-based seed
+
+	// The big blind posts one ante for the whole table instead of every
+	// player posting their own, as in modern tournaments. The ante is dead
+	// money in the main pot.
+	BigBlindAnte bool `json:"big_blind_ante,omitempty"`
+
+	Variant  GameVariant `json:"variant,omitempty"`   // Game dealt, Hold'em when empty
+	MaxSeats int         `json:"max_seats,omitempty"` // Table size from 2 to 10, 0 for the full ten seats
+
+	// Backend scoring Hold'em hands, the fast one when empty. EvaluatorEnv
+	// and SelectEvaluator override it for every game in the process.
+	Evaluator EvaluatorBackend `json:"evaluator,omitempty"`
+
+	// Cash game table rules, enforced by the session controller. Zero disables a rule.
+	MinBuyInBB    int           `json:"min_buy_in_bb,omitempty"`  // Smallest buy-in in big blinds
+	MaxBuyInBB    int           `json:"max_buy_in_bb,omitempty"`  // Largest buy-in in big blinds, also caps top-ups
+	MaxReentries  int           `json:"max_reentries,omitempty"`  // Buy-ins allowed after busting, -1 for none
+	RatholeWindow time.Duration `json:"rathole_window,omitempty"` // How long a player who left must bring back their stack
+
+	// Rake taken from every pot. Zero percent deals a rake-free game.
+	RakePercent  float64 `json:"rake_percent,omitempty"`    // Share of the pot taken, e.g. 5 for 5%
+	RakeCap      int     `json:"rake_cap,omitempty"`        // Most taken from one hand, 0 for no cap
+	NoFlopNoDrop bool    `json:"no_flop_no_drop,omitempty"` // Hands that end before the flop are not raked
+
+	Disconnect DisconnectPolicy `json:"disconnect,omitempty"` // What happens to a disconnected player's hand
+
+	// Fair play: seated players get no equity overlay or coaching, see
+	// TableView.NoAssistance
+	NoAssistance bool `json:"no_assistance,omitempty"`
+
+	// Check the table invariants after every action, see CheckInvariants
+	Debug bool `json:"debug,omitempty"`
+
+	// Finished hands kept in memory, see GetHandHistory. 0 keeps
+	// DefaultHandHistory, -1 none.
+	HandHistory int `json:"hand_history,omitempty"`
+}
+
+// DisconnectPolicy is what happens to the hand of a player whose decision
+// maker lost its connection
+type DisconnectPolicy int
+
+const (
+	DisconnectFold  DisconnectPolicy = iota // The hand is checked when it can be and folded otherwise
+	DisconnectAllIn                         // All-in protection: the player stays in for the chips they put in
+)
+
+// BuyInLimits returns the smallest and largest buy-in in chips, 0 when unlimited
+func (c GameConfig) BuyInLimits() (int, int) {
+	return c.MinBuyInBB * c.BigBlind, c.MaxBuyInBB * c.BigBlind
+}
+
+// Seats returns the number of seats at the table
+func (c GameConfig) Seats() int {
+	if c.MaxSeats == 0 {
+		return maxSeats
+	}
+	return c.MaxSeats
+}
+
+// Validate checks that the config describes a table the engine can deal:
+// blinds that go up, buy-in and rake rules that make sense, a table size the
+// engine supports and a known game
+func (c GameConfig) Validate() error {
+	var errs []error
+	if c.SmallBlind <= 0 {
+		errs = append(errs, fmt.Errorf("small blind must be positive, got %d", c.SmallBlind))
+	}
+	if c.BigBlind <= c.SmallBlind {
+		errs = append(errs, fmt.Errorf("big blind %d must be bigger than the small blind %d", c.BigBlind, c.SmallBlind))
+	}
+	if c.Ante < 0 {
+		errs = append(errs, fmt.Errorf("ante must not be negative, got %d", c.Ante))
+	}
+	if c.MinBuyInBB < 0 || c.MaxBuyInBB < 0 {
+		errs = append(errs, fmt.Errorf("buy-in limits must not be negative, got %d to %d big blinds", c.MinBuyInBB, c.MaxBuyInBB))
+	} else if c.MaxBuyInBB > 0 && c.MinBuyInBB > c.MaxBuyInBB {
+		errs = append(errs, fmt.Errorf("minimum buy-in of %d big blinds is above the maximum of %d", c.MinBuyInBB, c.MaxBuyInBB))
+	}
+	if c.MaxSeats != 0 && (c.MaxSeats < 2 || c.MaxSeats > maxSeats) {
+		errs = append(errs, fmt.Errorf("table size must be between 2 and %d seats, got %d", maxSeats, c.MaxSeats))
+	}
+	if c.RakePercent < 0 || c.RakePercent > 100 || c.RakeCap < 0 {
+		errs = append(errs, fmt.Errorf("rake of %g%% capped at %d is out of range", c.RakePercent, c.RakeCap))
+	}
+	if !c.Variant.IsKnown() {
+		errs = append(errs, fmt.Errorf("unknown game %q", c.Variant))
+	}
+	if !c.Evaluator.IsKnown() {
+		errs = append(errs, fmt.Errorf("unknown evaluator %q, want one of %v", c.Evaluator, EvaluatorBackends()))
+	} else if err := CheckEvaluator(c.Evaluator); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// CheckBuyIn returns an error when chips are outside the buy-in limits
+func (c GameConfig) CheckBuyIn(chips int) error {
+	minBuyIn, maxBuyIn := c.BuyInLimits()
+	switch {
+	case chips <= 0:
+		return fmt.Errorf("buy-in must be positive, got %d", chips)
+	case minBuyIn > 0 && chips < minBuyIn:
+		return fmt.Errorf("buy-in of %d is below the minimum of %d", chips, minBuyIn)
+	case maxBuyIn > 0 && chips > maxBuyIn:
+		return fmt.Errorf("buy-in of %d is above the maximum of %d", chips, maxBuyIn)
+	}
+	return nil
+}
+
+// GetConfig returns the configuration the game was created with.
+// The seed, table ID and evaluator are always the effective ones, even if
+// the config left them to be picked or they were overridden.
+func (g *Game) GetConfig() GameConfig {
+	return g.config
+}
+
+// GetTableID returns the unique ID of the table, shared by every hand dealt at it
+func (g *Game) GetTableID() string {
+	return g.config.TableID
+}
+
+// GetAnte returns the ante every player posts before a hand, or the big
+// blind posts for the table with a big blind ante
+func (g *Game) GetAnte() int {
+	return g.config.Ante
+}
+
+// SetBlinds changes the forced bets for the following hands, e.g. when a
+// tournament level goes up
+func (g *Game) SetBlinds(smallBlind, bigBlind, ante int) {
+	g.smallBlind, g.bigBlind = smallBlind, bigBlind
+	g.config.SmallBlind, g.config.BigBlind, g.config.Ante = smallBlind, bigBlind, ante
+	g.log().Info("blinds changed",
+		slog.Int("small_blind", smallBlind),
+		slog.Int("big_blind", bigBlind),
+		slog.Int("ante", ante),
+	)
+}
+
+// GetHandSeed returns the RNG seed used to shuffle the current hand
+func (g *Game) GetHandSeed() int64 {
+	return g.handSeed
+}
+
+// random returns the game RNG, creating a time-seeded one for zero-value games
+func (g *Game) random() *rand.Rand {
+	if g.rng == nil {
+		g.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return g.rng
+}
+
+// startHandRNG reseeds the game RNG for the given hand number so that every
+// hand's shuffle can be reproduced from the master seed alone
+func (g *Game) startHandRNG(handNumber int) {
+	g.handSeed = deriveHandSeed(g.config.Seed, handNumber)
+	g.rng = rand.New(rand.NewSource(g.handSeed))
+}
+
+// deriveHandSeed mixes the master seed with a hand number (splitmix64)
+func deriveHandSeed(seed int64, handNumber int) int64 {
+	z := uint64(seed) + uint64(handNumber)*0x9E3779B97F4A7C15
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return int64(z ^ (z >> 31))
+}
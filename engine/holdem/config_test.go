@@ -0,0 +1,81 @@
+package holdem
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGameConfigValidate(t *testing.T) {
+	valid := GameConfig{SmallBlind: 5, BigBlind: 10, MinBuyInBB: 40, MaxBuyInBB: 100}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Expected %+v to be valid, got %v", valid, err)
+	}
+
+	for name, tt := range map[string]struct {
+		change func(c *GameConfig)
+		want   string
+	}{
+		"no small blind":      {func(c *GameConfig) { c.SmallBlind = 0 }, "small blind"},
+		"big blind too small": {func(c *GameConfig) { c.BigBlind = 5 }, "big blind"},
+		"negative ante":       {func(c *GameConfig) { c.Ante = -1 }, "ante"},
+		"buy-ins reversed":    {func(c *GameConfig) { c.MinBuyInBB = 200 }, "minimum buy-in"},
+		"negative buy-in":     {func(c *GameConfig) { c.MaxBuyInBB = -1 }, "buy-in limits"},
+		"one seat":            {func(c *GameConfig) { c.MaxSeats = 1 }, "table size"},
+		"eleven seats":        {func(c *GameConfig) { c.MaxSeats = 11 }, "table size"},
+		"rake over 100%":      {func(c *GameConfig) { c.RakePercent = 150 }, "rake"},
+		"unknown game":        {func(c *GameConfig) { c.Variant = "stud" }, "unknown game"},
+	} {
+		config := valid
+		tt.change(&config)
+		if err := config.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected an error about %q, got %v", name, tt.want, err)
+		}
+	}
+}
+
+func TestGameConfigCheckBuyIn(t *testing.T) {
+	config := GameConfig{SmallBlind: 5, BigBlind: 10, MinBuyInBB: 40, MaxBuyInBB: 100}
+	for chips, ok := range map[int]bool{0: false, 399: false, 400: true, 1000: true, 1001: false} {
+		if err := config.CheckBuyIn(chips); (err == nil) != ok {
+			t.Errorf("Buy-in of %d: expected ok=%v, got %v", chips, ok, err)
+		}
+	}
+	if err := (GameConfig{SmallBlind: 5, BigBlind: 10}).CheckBuyIn(1_000_000); err != nil {
+		t.Errorf("Expected no limits without buy-in rules, got %v", err)
+	}
+}
+
+func TestConfigPresets(t *testing.T) {
+	presets := ConfigPresets()
+	for _, name := range []string{"micro-cash", "turbo-sng", "deepstack"} {
+		preset, ok := ConfigPresetNamed(name)
+		if !ok {
+			t.Fatalf("Expected a %s preset", name)
+		}
+		if err := preset.Config.Validate(); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if err := preset.Config.CheckBuyIn(preset.BuyIn()); err != nil {
+			t.Errorf("%s: usual buy-in refused: %v", name, err)
+		}
+	}
+	if len(presets) != 3 {
+		t.Errorf("Expected 3 presets, got %d", len(presets))
+	}
+	if micro, _ := ConfigPresetNamed("micro-cash"); micro.Config.SmallBlind != 5 || micro.Config.BigBlind != 10 {
+		t.Errorf("Expected micro cash to play 5/10, got %d/%d", micro.Config.SmallBlind, micro.Config.BigBlind)
+	}
+	if _, ok := ConfigPresetNamed("nosebleeds"); ok {
+		t.Error("Expected no nosebleeds preset")
+	}
+}
+
+func TestPlayerSitRespectsTableSize(t *testing.T) {
+	game := NewGameWithConfig(GameConfig{SmallBlind: 5, BigBlind: 10, MaxSeats: 6})
+	if err := game.PlayerSit(NewPlayer(1, "", 1000), 5); err != nil {
+		t.Errorf("Expected the sixth seat to be open, got %v", err)
+	}
+	if err := game.PlayerSit(NewPlayer(2, "", 1000), 6); err == nil {
+		t.Error("Expected no seventh seat at a 6-max table")
+	}
+}
@@ -2,7 +2,10 @@ package holdem
 
 import (
 	"sort"
+	"time"
 
+	"github.com/ljbink/ai-poker/engine/i18n"
+	"github.com/ljbink/ai-poker/engine/metrics"
 	"github.com/ljbink/ai-poker/engine/poker"
 )
 
@@ -29,6 +32,37 @@ type HandResult struct {
 	Value       int          // Numeric value for comparison (higher is better)
 	Cards       poker.Cards  // The cards that make up the hand
 	Kickers     []poker.Rank // Kicker cards for tie-breaking
+	Sources     CardSources  // Which of Cards came from the board, the rest are hole cards
+}
+
+// CardSources records which cards of a HandResult came from the board, bit
+// i standing for Cards[i]. A bit set keeps evaluations free of allocations.
+type CardSources uint8
+
+// FromBoard reports whether the i-th card of the hand came from the board
+func (s CardSources) FromBoard(i int) bool {
+	return i >= 0 && i < 8 && s&(1<<i) != 0
+}
+
+// attribute records which of the result's cards are not among the hole cards
+func (r *HandResult) attribute(holeCards []*poker.Card) *HandResult {
+	r.Sources = 0
+	for i, card := range r.Cards {
+		if card == nil || i >= 8 {
+			continue
+		}
+		fromHole := false
+		for _, hole := range holeCards {
+			if hole != nil && *hole == *card {
+				fromHole = true
+				break
+			}
+		}
+		if !fromHole {
+			r.Sources |= 1 << i
+		}
+	}
+	return r
 }
 
 type IHandEvaluator interface {
@@ -37,7 +71,9 @@ type IHandEvaluator interface {
 }
 
 // HandEvaluator provides methods for evaluating poker hands
-type HandEvaluator struct{}
+type HandEvaluator struct {
+	metrics metrics.IMetrics // Optional throughput instrumentation
+}
 
 // NewHandEvaluator creates a new hand evaluator
 func NewHandEvaluator() *HandEvaluator {
@@ -46,6 +82,10 @@ func NewHandEvaluator() *HandEvaluator {
 
 // EvaluateHand evaluates a player's best 5-card hand from hole cards and community cards
 func (e *HandEvaluator) EvaluateHand(holeCards []*poker.Card, communityCards poker.Cards) *HandResult {
+	if e.metrics != nil {
+		defer e.observeEvaluation(time.Now())
+	}
+
 	if len(holeCards) < 2 {
 		return &HandResult{
 			Rank:        HighCard,
@@ -56,16 +96,17 @@ func (e *HandEvaluator) EvaluateHand(holeCards []*poker.Card, communityCards pok
 		}
 	}
 
-	// Combine hole cards and community cards
-	allCards := poker.Cards{}
-	allCards.Append(holeCards...)
-	allCards.Append(communityCards...)
-
-	// Filter out nil cards
-	validCards := poker.Cards{}
-	for _, card := range allCards {
+	// Gather the cards on the stack; seven fit without a heap allocation
+	var buf [7]*poker.Card
+	validCards := buf[:0]
+	for _, card := range holeCards {
 		if card != nil {
-			validCards.Append(card)
+			validCards = append(validCards, card)
+		}
+	}
+	for _, card := range communityCards {
+		if card != nil {
+			validCards = append(validCards, card)
 		}
 	}
 
@@ -74,13 +115,16 @@ func (e *HandEvaluator) EvaluateHand(holeCards []*poker.Card, communityCards pok
 			Rank:        HighCard,
 			Description: "Insufficient cards",
 			Value:       0,
-			Cards:       validCards,
+			Cards:       append(poker.Cards{}, validCards...),
 			Kickers:     []poker.Rank{},
 		}
 	}
+	if len(validCards) < 5 {
+		return e.evaluatePartialHand(append(poker.Cards{}, validCards...)).attribute(holeCards)
+	}
 
 	// Evaluate the best 5-card hand
-	return e.findBestHand(validCards)
+	return e.findBestHand(validCards).attribute(holeCards)
 }
 
 // CompareHands compares two hand results and returns:
@@ -106,26 +150,43 @@ func (e *HandEvaluator) CompareHands(hand1, hand2 *HandResult) int {
 	return e.compareKickers(hand1.Kickers, hand2.Kickers)
 }
 
-// findBestHand finds the best 5-card hand from available cards
+// findBestHand finds the best 5-card hand from available cards. Every
+// combination is scored on the stack; only the winner becomes a HandResult.
 func (e *HandEvaluator) findBestHand(cards poker.Cards) *HandResult {
 	if len(cards) < 5 {
-		return e.evaluatePartialHand(cards)
+		return e.evaluatePartialHand(append(poker.Cards{}, cards...))
 	}
 
-	bestHand := &HandResult{
-		Rank:  HighCard,
-		Value: 0,
+	var best, score handScore
+	var combination [5]*poker.Card
+	var indices [5]int
+	for i := range indices {
+		indices[i] = i
 	}
-
-	// Try all combinations of 5 cards
-	e.generateCombinations(cards, 5, func(combination poker.Cards) {
-		hand := e.evaluateFiveCardHand(combination)
-		if e.CompareHands(hand, bestHand) > 0 {
-			bestHand = hand
+	n := len(cards)
+	for first := true; ; first = false {
+		for i, idx := range indices {
+			combination[i] = cards[idx]
+		}
+		e.scoreFive(combination, &score)
+		if first || e.compareScores(&score, &best) > 0 {
+			best = score
 		}
-	})
 
-	return bestHand
+		// Generate next combination
+		i := 4
+		for i >= 0 && indices[i] == n-5+i {
+			i--
+		}
+		if i < 0 {
+			break
+		}
+		indices[i]++
+		for j := i + 1; j < 5; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+	return best.result()
 }
 
 // evaluateFiveCardHand evaluates exactly 5 cards
@@ -133,44 +194,158 @@ func (e *HandEvaluator) evaluateFiveCardHand(cards poker.Cards) *HandResult {
 	if len(cards) != 5 {
 		return e.evaluatePartialHand(cards)
 	}
+	var five [5]*poker.Card
+	copy(five[:], cards)
+	var score handScore
+	e.scoreFive(five, &score)
+	return score.result()
+}
 
-	// Sort cards by rank (descending)
-	sortedCards := make(poker.Cards, len(cards))
-	copy(sortedCards, cards)
-	sort.Slice(sortedCards, func(i, j int) bool {
-		return e.rankValue(sortedCards[i].Rank) > e.rankValue(sortedCards[j].Rank)
-	})
+// handScore is a scored 5-card hand kept off the heap while combinations
+// are compared
+type handScore struct {
+	rank     HandRank
+	value    int
+	cards    [5]*poker.Card // Highest rank first
+	kickers  [5]poker.Rank
+	nKickers int
+}
 
-	// Check for each hand type (highest to lowest)
-	if result := e.checkRoyalFlush(sortedCards); result != nil {
-		return result
+// result turns the score into a HandResult, the only allocations of an evaluation
+func (s *handScore) result() *HandResult {
+	return &HandResult{
+		Rank:        s.rank,
+		Description: HandRankToString(s.rank),
+		Value:       s.value,
+		Cards:       append(make(poker.Cards, 0, 5), s.cards[:]...),
+		Kickers:     append(make([]poker.Rank, 0, s.nKickers), s.kickers[:s.nKickers]...),
 	}
-	if result := e.checkStraightFlush(sortedCards); result != nil {
-		return result
+}
+
+// setKickers records the kicker ranks in order
+func (s *handScore) setKickers(ranks ...poker.Rank) {
+	s.nKickers = copy(s.kickers[:], ranks)
+}
+
+// rankGroup is a run of equal ranks in a sorted hand
+type rankGroup struct {
+	rank  poker.Rank
+	value int
+	count int
+}
+
+// scoreFive scores five cards into score. Values and kickers match the
+// rules of CompareHands: rank first, then value, then kickers.
+func (e *HandEvaluator) scoreFive(cards [5]*poker.Card, score *handScore) {
+	// Insertion sort by rank, highest first
+	for i := 1; i < 5; i++ {
+		for j := i; j > 0 && e.rankValue(cards[j].Rank) > e.rankValue(cards[j-1].Rank); j-- {
+			cards[j], cards[j-1] = cards[j-1], cards[j]
+		}
 	}
-	if result := e.checkFourOfAKind(sortedCards); result != nil {
-		return result
+	score.cards = cards
+
+	var values [5]int
+	for i, card := range cards {
+		values[i] = e.rankValue(card.Rank)
 	}
-	if result := e.checkFullHouse(sortedCards); result != nil {
-		return result
+
+	// Equal ranks are adjacent, so groups come out highest rank first
+	var groups [5]rankGroup
+	nGroups := 0
+	for i, card := range cards {
+		if i > 0 && values[i] == values[i-1] {
+			groups[nGroups-1].count++
+			continue
+		}
+		groups[nGroups] = rankGroup{rank: card.Rank, value: values[i], count: 1}
+		nGroups++
 	}
-	if result := e.checkFlush(sortedCards); result != nil {
-		return result
+
+	// Bring the biggest group to the front, pairs before single cards
+	for i := 1; i < nGroups; i++ {
+		for j := i; j > 0 && groups[j].count > groups[j-1].count; j-- {
+			groups[j], groups[j-1] = groups[j-1], groups[j]
+		}
 	}
-	if result := e.checkStraight(sortedCards); result != nil {
-		return result
+
+	flush := e.isFlush(cards[:])
+	straightHigh := 0
+	if nGroups == 5 {
+		switch {
+		case values[0]-values[4] == 4:
+			straightHigh = values[0]
+		case values[0] == 14 && values[1] == 5:
+			straightHigh = 5 // A-2-3-4-5, the wheel
+		}
 	}
-	if result := e.checkThreeOfAKind(sortedCards); result != nil {
-		return result
+
+	switch {
+	case flush && straightHigh == 14:
+		score.rank, score.value = RoyalFlush, 9000000
+		score.setKickers()
+	case flush && straightHigh > 0:
+		score.rank, score.value = StraightFlush, 8000000+straightHigh
+		score.setKickers(e.valueToRank(straightHigh))
+	case groups[0].count == 4:
+		score.rank, score.value = FourOfAKind, 7000000+groups[0].value*1000+groups[1].value
+		score.setKickers(groups[0].rank, groups[1].rank)
+	case groups[0].count == 3 && groups[1].count == 2:
+		score.rank, score.value = FullHouse, 6000000+groups[0].value*1000+groups[1].value
+		score.setKickers(groups[0].rank, groups[1].rank)
+	case flush:
+		score.rank, score.value = Flush, 5000000+spreadValue(values[:])
+		score.setKickers(cards[0].Rank, cards[1].Rank, cards[2].Rank, cards[3].Rank, cards[4].Rank)
+	case straightHigh > 0:
+		score.rank, score.value = Straight, 4000000+straightHigh
+		score.setKickers(e.valueToRank(straightHigh))
+	case groups[0].count == 3:
+		score.rank = ThreeOfAKind
+		score.value = 3000000 + groups[0].value*1000 + groups[1].value*100 + groups[2].value*50
+		score.setKickers(groups[0].rank, groups[1].rank, groups[2].rank)
+	case groups[0].count == 2 && groups[1].count == 2:
+		score.rank = TwoPair
+		score.value = 2000000 + groups[0].value*1000 + groups[1].value*100 + groups[2].value
+		score.setKickers(groups[0].rank, groups[1].rank, groups[2].rank)
+	case groups[0].count == 2:
+		score.rank = OnePair
+		score.value = 1000000 + groups[0].value*1000 + groups[1].value*100 + groups[2].value*50 + groups[3].value*33
+		score.setKickers(groups[0].rank, groups[1].rank, groups[2].rank, groups[3].rank)
+	default:
+		score.rank, score.value = HighCard, spreadValue(values[:])
+		score.setKickers(cards[0].Rank, cards[1].Rank, cards[2].Rank, cards[3].Rank, cards[4].Rank)
 	}
-	if result := e.checkTwoPair(sortedCards); result != nil {
-		return result
+}
+
+// spreadValue weighs rank values from highest to lowest by 1000/(i+1), as
+// flushes and high-card hands are valued
+func spreadValue(values []int) int {
+	value := 0
+	for i, v := range values {
+		value += v * (1000 / (i + 1))
 	}
-	if result := e.checkOnePair(sortedCards); result != nil {
-		return result
+	return value
+}
+
+// compareScores compares two scores like CompareHands compares results
+func (e *HandEvaluator) compareScores(a, b *handScore) int {
+	switch {
+	case a.rank != b.rank:
+		return compareInts(int(a.rank), int(b.rank))
+	case a.value != b.value:
+		return compareInts(a.value, b.value)
 	}
+	return e.compareKickers(a.kickers[:a.nKickers], b.kickers[:b.nKickers])
+}
 
-	return e.checkHighCard(sortedCards)
+func compareInts(a, b int) int {
+	if a > b {
+		return 1
+	}
+	if a < b {
+		return -1
+	}
+	return 0
 }
 
 // evaluatePartialHand evaluates hands with less than 5 cards
@@ -212,42 +387,7 @@ func (e *HandEvaluator) evaluatePartialHand(cards poker.Cards) *HandResult {
 	return e.checkHighCard(sortedCards)
 }
 
-// Hand checking functions
-func (e *HandEvaluator) checkRoyalFlush(cards poker.Cards) *HandResult {
-	if !e.isFlush(cards) {
-		return nil
-	}
-	if !e.isRoyalStraight(cards) {
-		return nil
-	}
-
-	return &HandResult{
-		Rank:        RoyalFlush,
-		Description: "Royal Flush",
-		Value:       9000000,
-		Cards:       cards,
-		Kickers:     []poker.Rank{},
-	}
-}
-
-func (e *HandEvaluator) checkStraightFlush(cards poker.Cards) *HandResult {
-	if !e.isFlush(cards) {
-		return nil
-	}
-	highCard := e.getStraightHighCard(cards)
-	if highCard == poker.RankNone {
-		return nil
-	}
-
-	return &HandResult{
-		Rank:        StraightFlush,
-		Description: "Straight Flush",
-		Value:       8000000 + e.rankValue(highCard),
-		Cards:       cards,
-		Kickers:     []poker.Rank{highCard},
-	}
-}
-
+// Hand checking functions for fewer than five cards
 func (e *HandEvaluator) checkFourOfAKind(cards poker.Cards) *HandResult {
 	rankCounts := e.getRankCounts(cards)
 
@@ -275,77 +415,6 @@ func (e *HandEvaluator) checkFourOfAKind(cards poker.Cards) *HandResult {
 	}
 }
 
-func (e *HandEvaluator) checkFullHouse(cards poker.Cards) *HandResult {
-	rankCounts := e.getRankCounts(cards)
-
-	var tripRank, pairRank poker.Rank
-
-	for rank, count := range rankCounts {
-		if count == 3 {
-			tripRank = rank
-		} else if count == 2 {
-			pairRank = rank
-		}
-	}
-
-	if tripRank == poker.RankNone || pairRank == poker.RankNone {
-		return nil
-	}
-
-	return &HandResult{
-		Rank:        FullHouse,
-		Description: "Full House",
-		Value:       6000000 + e.rankValue(tripRank)*1000 + e.rankValue(pairRank),
-		Cards:       cards,
-		Kickers:     []poker.Rank{tripRank, pairRank},
-	}
-}
-
-func (e *HandEvaluator) checkFlush(cards poker.Cards) *HandResult {
-	if !e.isFlush(cards) {
-		return nil
-	}
-
-	// Get all ranks for kickers
-	var kickers []poker.Rank
-	for _, card := range cards {
-		kickers = append(kickers, card.Rank)
-	}
-
-	// Sort kickers descending
-	sort.Slice(kickers, func(i, j int) bool {
-		return e.rankValue(kickers[i]) > e.rankValue(kickers[j])
-	})
-
-	value := 5000000
-	for i, rank := range kickers {
-		value += e.rankValue(rank) * (1000 / (i + 1))
-	}
-
-	return &HandResult{
-		Rank:        Flush,
-		Description: "Flush",
-		Value:       value,
-		Cards:       cards,
-		Kickers:     kickers,
-	}
-}
-
-func (e *HandEvaluator) checkStraight(cards poker.Cards) *HandResult {
-	highCard := e.getStraightHighCard(cards)
-	if highCard == poker.RankNone {
-		return nil
-	}
-
-	return &HandResult{
-		Rank:        Straight,
-		Description: "Straight",
-		Value:       4000000 + e.rankValue(highCard),
-		Cards:       cards,
-		Kickers:     []poker.Rank{highCard},
-	}
-}
-
 func (e *HandEvaluator) checkThreeOfAKind(cards poker.Cards) *HandResult {
 	rankCounts := e.getRankCounts(cards)
 
@@ -390,53 +459,6 @@ func (e *HandEvaluator) checkThreeOfAKind(cards poker.Cards) *HandResult {
 	}
 }
 
-func (e *HandEvaluator) checkTwoPair(cards poker.Cards) *HandResult {
-	rankCounts := e.getRankCounts(cards)
-
-	var pairs []poker.Rank
-	var kickers []poker.Rank
-
-	for rank, count := range rankCounts {
-		if count == 2 {
-			pairs = append(pairs, rank)
-		} else if count >= 1 {
-			for i := 0; i < count; i++ {
-				kickers = append(kickers, rank)
-			}
-		}
-	}
-
-	if len(pairs) < 2 {
-		return nil
-	}
-
-	// Sort pairs descending
-	sort.Slice(pairs, func(i, j int) bool {
-		return e.rankValue(pairs[i]) > e.rankValue(pairs[j])
-	})
-
-	// Sort kickers descending
-	sort.Slice(kickers, func(i, j int) bool {
-		return e.rankValue(kickers[i]) > e.rankValue(kickers[j])
-	})
-
-	value := 2000000 + e.rankValue(pairs[0])*1000 + e.rankValue(pairs[1])*100
-	if len(kickers) > 0 {
-		value += e.rankValue(kickers[0])
-	}
-
-	allKickers := pairs
-	allKickers = append(allKickers, kickers...)
-
-	return &HandResult{
-		Rank:        TwoPair,
-		Description: "Two Pair",
-		Value:       value,
-		Cards:       cards,
-		Kickers:     allKickers,
-	}
-}
-
 func (e *HandEvaluator) checkOnePair(cards poker.Cards) *HandResult {
 	rankCounts := e.getRankCounts(cards)
 
@@ -524,55 +546,6 @@ func (e *HandEvaluator) isFlush(cards poker.Cards) bool {
 	return true
 }
 
-func (e *HandEvaluator) isRoyalStraight(cards poker.Cards) bool {
-	ranks := []poker.Rank{poker.RankAce, poker.RankKing, poker.RankQueen, poker.RankJack, poker.RankTen}
-	rankSet := make(map[poker.Rank]bool)
-	for _, card := range cards {
-		rankSet[card.Rank] = true
-	}
-	for _, rank := range ranks {
-		if !rankSet[rank] {
-			return false
-		}
-	}
-	return true
-}
-
-func (e *HandEvaluator) getStraightHighCard(cards poker.Cards) poker.Rank {
-	if len(cards) < 5 {
-		return poker.RankNone
-	}
-
-	ranks := make([]int, 0)
-	rankSet := make(map[int]bool)
-
-	for _, card := range cards {
-		rank := e.rankValue(card.Rank)
-		if !rankSet[rank] {
-			ranks = append(ranks, rank)
-			rankSet[rank] = true
-		}
-	}
-
-	sort.Ints(ranks)
-
-	// Check for regular straight
-	if len(ranks) >= 5 {
-		for i := len(ranks) - 5; i >= 0; i-- {
-			if ranks[i+4]-ranks[i] == 4 {
-				return e.valueToRank(ranks[i+4])
-			}
-		}
-	}
-
-	// Check for A-2-3-4-5 straight (wheel)
-	if rankSet[14] && rankSet[2] && rankSet[3] && rankSet[4] && rankSet[5] {
-		return poker.RankFive // 5-high straight
-	}
-
-	return poker.RankNone
-}
-
 func (e *HandEvaluator) getRankCounts(cards poker.Cards) map[poker.Rank]int {
 	counts := make(map[poker.Rank]int)
 	for _, card := range cards {
@@ -674,37 +647,42 @@ func (e *HandEvaluator) compareKickers(kickers1, kickers2 []poker.Rank) int {
 	return 0
 }
 
-func (e *HandEvaluator) generateCombinations(cards poker.Cards, k int, callback func(poker.Cards)) {
-	n := len(cards)
-	if k > n {
-		return
-	}
-
-	indices := make([]int, k)
-	for i := range indices {
-		indices[i] = i
+// EvaluatePlayerHand evaluates a player's best hand with the board, under
+// the showdown rules of the game their hole cards were dealt for: Hold'em
+// for two cards, Omaha for four
+func EvaluatePlayerHand(player IPlayer, board poker.Cards) *HandResult {
+	if player == nil {
+		return NewHandEvaluator().EvaluateHand(nil, board)
 	}
+	holeCards := player.GetHandCards()
+	return variantDealing(len(holeCards)).NewEvaluator().EvaluateHand(holeCards, board)
+}
 
-	for {
-		combination := make(poker.Cards, k)
-		for i, idx := range indices {
-			combination[i] = cards[idx]
+// EvaluateAllShowdownHands evaluates the hand of every player still in the
+// game's hand with the board dealt so far, keyed by player ID. Players who
+// folded or hold no cards are left out.
+func EvaluateAllShowdownHands(game *Game) map[int]*HandResult {
+	results := map[int]*HandResult{}
+	if game == nil {
+		return results
+	}
+	evaluator := game.newEvaluator()
+	for _, player := range game.players {
+		if player == nil || player.IsFolded() || len(player.GetHandCards()) == 0 {
+			continue
 		}
-		callback(combination)
+		results[player.GetID()] = evaluator.EvaluateHand(player.GetHandCards(), game.communityCards)
+	}
+	return results
+}
 
-		// Generate next combination
-		i := k - 1
-		for i >= 0 && indices[i] == n-k+i {
-			i--
-		}
-		if i < 0 {
-			break
-		}
-		indices[i]++
-		for j := i + 1; j < k; j++ {
-			indices[j] = indices[j-1] + 1
-		}
+// variantDealing returns the game that deals the given number of hole
+// cards, Hold'em when there is none
+func variantDealing(holeCards int) Variant {
+	if holeCards == (OmahaVariant{}).HoleCards() {
+		return OmahaVariant{}
 	}
+	return HoldemVariant{}
 }
 
 // HandRankToString converts hand rank to string
@@ -734,3 +712,25 @@ func HandRankToString(rank HandRank) string {
 		return "Unknown"
 	}
 }
+
+// handRankKeys are the catalog keys of hand ranks
+var handRankKeys = map[HandRank]string{
+	HighCard:      "hand.high_card",
+	OnePair:       "hand.one_pair",
+	TwoPair:       "hand.two_pair",
+	ThreeOfAKind:  "hand.three_of_a_kind",
+	Straight:      "hand.straight",
+	Flush:         "hand.flush",
+	FullHouse:     "hand.full_house",
+	FourOfAKind:   "hand.four_of_a_kind",
+	StraightFlush: "hand.straight_flush",
+	RoyalFlush:    "hand.royal_flush",
+}
+
+// HandRankName returns the translated name of a hand rank, e.g. "One Pair"
+func HandRankName(translator *i18n.Translator, rank HandRank) string {
+	if key, ok := handRankKeys[rank]; ok {
+		return translator.T(key)
+	}
+	return HandRankToString(rank)
+}
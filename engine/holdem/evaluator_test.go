@@ -1,6 +1,7 @@
 package holdem
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/ljbink/ai-poker/engine/poker"
@@ -163,6 +164,40 @@ func TestEvaluateStraight(t *testing.T) {
 	}
 }
 
+func TestEvaluateHandAttributesSources(t *testing.T) {
+	holeCards := []*poker.Card{
+		{Rank: poker.RankAce, Suit: poker.SuitHeart},
+		{Rank: poker.RankTwo, Suit: poker.SuitSpade},
+	}
+	communityCards := poker.Cards{
+		{Rank: poker.RankKing, Suit: poker.SuitHeart},
+		{Rank: poker.RankQueen, Suit: poker.SuitHeart},
+		{Rank: poker.RankJack, Suit: poker.SuitHeart},
+		{Rank: poker.RankTen, Suit: poker.SuitHeart},
+		{Rank: poker.RankThree, Suit: poker.SuitClub},
+	}
+
+	for _, evaluator := range []IHandEvaluator{NewHandEvaluator(), NewOmahaEvaluator()} {
+		result := evaluator.EvaluateHand(holeCards, communityCards)
+		if len(result.Cards) != 5 {
+			t.Fatalf("Expected five cards, got %v", result.Cards)
+		}
+		fromHole := 0
+		for i, card := range result.Cards {
+			hole := *card == *holeCards[0] || *card == *holeCards[1]
+			if result.Sources.FromBoard(i) == hole {
+				t.Errorf("%s: expected card %d from the board to be %v", result.Description, i, !hole)
+			}
+			if hole {
+				fromHole++
+			}
+		}
+		if fromHole == 0 {
+			t.Errorf("%s: expected a hole card to play", result.Description)
+		}
+	}
+}
+
 func TestEvaluateWheelStraight(t *testing.T) {
 	evaluator := NewHandEvaluator()
 
@@ -582,3 +617,186 @@ func TestIsFlushNegativeCases(t *testing.T) {
 		t.Error("isFlush should be false for mixed suits")
 	}
 }
+
+// benchmarkHands returns seven-card hands: two hole cards and a full board each
+// standardDeck returns the 52 cards of a deck without its jokers
+func standardDeck() poker.Cards {
+	deck := poker.Cards{}
+	for _, card := range poker.NewDeckCards() {
+		if card.Suit != poker.SuitNone {
+			deck = append(deck, card)
+		}
+	}
+	return deck
+}
+
+func benchmarkHands() [][2]poker.Cards {
+	rng := rand.New(rand.NewSource(1))
+	hands := make([][2]poker.Cards, 64)
+	for i := range hands {
+		deck := standardDeck()
+		rng.Shuffle(len(deck), func(a, b int) { deck[a], deck[b] = deck[b], deck[a] })
+		hands[i] = [2]poker.Cards{deck[:2], deck[2:7]}
+	}
+	return hands
+}
+
+func TestEvaluateHandAllocations(t *testing.T) {
+	evaluator := NewHandEvaluator()
+	hands := benchmarkHands()
+	i := 0
+	allocs := testing.AllocsPerRun(200, func() {
+		hand := hands[i%len(hands)]
+		evaluator.EvaluateHand(hand[0], hand[1])
+		i++
+	})
+	// The result, its cards and its kickers; the 21 combinations cost nothing
+	if allocs > 3 {
+		t.Errorf("Expected at most 3 allocations per seven-card evaluation, got %.1f", allocs)
+	}
+}
+
+func BenchmarkEvaluateHand(b *testing.B) {
+	evaluator := NewHandEvaluator()
+	hands := benchmarkHands()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hand := hands[i%len(hands)]
+		evaluator.EvaluateHand(hand[0], hand[1])
+	}
+}
+
+func BenchmarkEvaluateOmahaHand(b *testing.B) {
+	evaluator := NewOmahaEvaluator()
+	rng := rand.New(rand.NewSource(1))
+	deck := standardDeck()
+	rng.Shuffle(len(deck), func(a, c int) { deck[a], deck[c] = deck[c], deck[a] })
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		evaluator.EvaluateHand(deck[:4], deck[4:9])
+	}
+}
+
+// fuzzHands decodes up to three hands of five to seven distinct cards. Each
+// hand takes a length byte and then card bytes, indexes into a fresh deck.
+func fuzzHands(data []byte) [][]*poker.Card {
+	deck := standardDeck()
+	hands := [][]*poker.Card{}
+	for len(hands) < 3 && len(data) > 0 {
+		size := 5 + int(data[0])%3
+		data = data[1:]
+		hand := []*poker.Card{}
+		used := [52]bool{}
+		for len(hand) < size && len(data) > 0 {
+			index := int(data[0]) % len(deck)
+			data = data[1:]
+			if !used[index] {
+				used[index] = true
+				hand = append(hand, deck[index])
+			}
+		}
+		if len(hand) < size {
+			break
+		}
+		hands = append(hands, hand)
+	}
+	return hands
+}
+
+func FuzzCompareHands(f *testing.F) {
+	f.Add([]byte{2, 0, 12, 11, 10, 9, 20, 30, 1, 13, 26, 39, 1, 2, 3, 0, 5, 6, 7, 8, 9})
+	f.Add([]byte{0, 0, 13, 26, 1, 14, 0, 0, 13, 26, 2, 15, 0, 1, 14, 27, 0, 13})
+	f.Add([]byte{1, 3, 16, 29, 42, 4, 5, 1, 3, 16, 29, 42, 4, 6, 1, 8, 9, 10, 11, 12, 7})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		evaluator := NewHandEvaluator()
+		hands := fuzzHands(data)
+		results := make([]*HandResult, len(hands))
+		for i, hand := range hands {
+			results[i] = evaluator.EvaluateHand(hand[:2], hand[2:])
+
+			// The order the cards arrive in must not matter
+			reversed := make([]*poker.Card, len(hand))
+			for j, card := range hand {
+				reversed[len(hand)-1-j] = card
+			}
+			if again := evaluator.EvaluateHand(reversed[:2], reversed[2:]); evaluator.CompareHands(results[i], again) != 0 {
+				t.Fatalf("%v evaluates differently reversed: %s vs %s", hand, results[i].Description, again.Description)
+			}
+		}
+
+		for i, a := range results {
+			if evaluator.CompareHands(a, a) != 0 {
+				t.Fatalf("%s does not tie with itself", a.Description)
+			}
+			for j, b := range results {
+				ab, ba := evaluator.CompareHands(a, b), evaluator.CompareHands(b, a)
+				if ab != -ba {
+					t.Fatalf("Ordering not antisymmetric: %v vs %v gives %d and %d", hands[i], hands[j], ab, ba)
+				}
+				if a.Rank != b.Rank && (ab > 0) != (a.Rank > b.Rank) {
+					t.Fatalf("%s vs %s ordered against their ranks", a.Description, b.Description)
+				}
+				for k, c := range results {
+					if ab >= 0 && evaluator.CompareHands(b, c) >= 0 && evaluator.CompareHands(a, c) < 0 {
+						t.Fatalf("Ordering not transitive: %v >= %v >= %v but not %v >= %v", hands[i], hands[j], hands[k], hands[i], hands[k])
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestEvaluatePlayerHand(t *testing.T) {
+	board, _ := poker.ParseCards("Qs Qh 7d 2c 3h")
+	hero := NewPlayer(1, "", 1000)
+	hole, _ := poker.ParseCards("Qd 9c")
+	hero.DealCard(hole[0])
+	hero.DealCard(hole[1])
+	if result := EvaluatePlayerHand(hero, board); result.Rank != ThreeOfAKind {
+		t.Errorf("Expected trip queens, got %s", result.Description)
+	}
+
+	// Omaha plays exactly two hole cards, so four of a suit make no flush
+	omaha := NewPlayer(2, "", 1000)
+	hole, _ = poker.ParseCards("Ah Kh 5h 4h")
+	for _, card := range hole {
+		omaha.DealCard(card)
+	}
+	flushBoard, _ := poker.ParseCards("Qh 8s 7d 2c 3c")
+	if result := EvaluatePlayerHand(omaha, flushBoard); result.Rank != HighCard {
+		t.Errorf("Expected Omaha rules to leave ace high, got %s", result.Description)
+	}
+}
+
+func TestEvaluateAllShowdownHands(t *testing.T) {
+	game := NewGameWithConfig(GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 9})
+	for i := 0; i < 3; i++ {
+		game.PlayerSit(NewPlayer(i+1, "", 1000), i)
+	}
+	stack, _ := poker.ParseCards("As Kd 9c Ah Kc 9d")
+	if err := game.StackDeck(stack); err != nil {
+		t.Fatalf("StackDeck failed: %v", err)
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	folder := game.GetCurrentPlayer().GetID()
+	if err := game.TakeAction(Action{PlayerID: folder, Type: ActionFold}); err != nil {
+		t.Fatalf("Fold failed: %v", err)
+	}
+
+	results := EvaluateAllShowdownHands(game)
+	if len(results) != 2 {
+		t.Fatalf("Expected the hands of the 2 players still in, got %d", len(results))
+	}
+	if _, ok := results[folder]; ok {
+		t.Errorf("Expected player %d's folded hand to be left out", folder)
+	}
+	for id, result := range results {
+		if result.Rank != OnePair {
+			t.Errorf("Player %d: expected a pair preflop, got %s", id, result.Description)
+		}
+	}
+}
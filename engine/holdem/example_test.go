@@ -0,0 +1,52 @@
+package holdem_test
+
+import (
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// Seat players, deal a hand and play it out by hand: everyone folds to the
+// big blind, who takes the pot
+func ExampleGame() {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 1})
+	for seat, name := range []string{"Alice", "Bob", "Carol"} {
+		if err := game.PlayerSit(holdem.NewPlayer(seat+1, name, 1000), seat); err != nil {
+			panic(err)
+		}
+	}
+
+	// Alice has the button, so Bob posts the small blind and Carol the big blind
+	if err := game.StartHand(0); err != nil {
+		panic(err)
+	}
+	for !game.IsHandOver() {
+		player := game.GetCurrentPlayer()
+		fmt.Printf("%s folds\n", player.GetName())
+		if err := game.TakeAction(holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionFold}); err != nil {
+			panic(err)
+		}
+	}
+	awards, err := game.AwardPot()
+	if err != nil {
+		panic(err)
+	}
+	winner, _ := game.GetPlayerByID(awards[0].Winners[0])
+	fmt.Printf("%s wins %d and has %d\n", winner.GetName(), awards[0].Amount, winner.GetChips())
+	// Output:
+	// Alice folds
+	// Bob folds
+	// Carol wins 15 and has 1005
+}
+
+// Evaluate the best five cards out of hole cards and a board
+func ExampleHandEvaluator_EvaluateHand() {
+	hole, _ := poker.ParseCards("Ah Kh")
+	board, _ := poker.ParseCards("Qh Jh 2c 10h 3d")
+
+	evaluator := holdem.NewHandEvaluator()
+	hand := evaluator.EvaluateHand(hole, board)
+	fmt.Println(hand.Description)
+	// Output: Royal Flush
+}
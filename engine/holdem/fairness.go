@@ -0,0 +1,131 @@
+package holdem
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// ShuffleNonceSize is the number of random bytes mixed into a shuffle commitment
+const ShuffleNonceSize = 32
+
+// ShuffleReveal is published after a hand so clients can check the shuffle
+// against the commitment they received before the deal
+type ShuffleReveal struct {
+	Commitment string      `json:"commitment"`
+	Deck       poker.Cards `json:"deck"`
+	Nonce      string      `json:"nonce"` // Hex encoded
+}
+
+// CommitShuffle returns the hex encoded SHA-256 of deck||nonce
+func CommitShuffle(deck poker.Cards, nonce []byte) string {
+	h := sha256.New()
+	h.Write(encodeDeck(deck))
+	h.Write(nonce)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyShuffle checks that a revealed deck and nonce match a published commitment
+// and that the deck is a complete standard deck
+func VerifyShuffle(commitment string, deck poker.Cards, nonce []byte) error {
+	if len(nonce) < ShuffleNonceSize {
+		return fmt.Errorf("nonce too short: %d bytes", len(nonce))
+	}
+	if err := checkStandardDeck(deck); err != nil {
+		return err
+	}
+	actual := CommitShuffle(deck, nonce)
+	if subtle.ConstantTimeCompare([]byte(actual), []byte(commitment)) != 1 {
+		return fmt.Errorf("commitment mismatch: expected %s, got %s", commitment, actual)
+	}
+	return nil
+}
+
+// Verify checks the reveal against its own commitment
+func (r ShuffleReveal) Verify() error {
+	nonce, err := hex.DecodeString(r.Nonce)
+	if err != nil {
+		return fmt.Errorf("invalid nonce: %w", err)
+	}
+	return VerifyShuffle(r.Commitment, r.Deck, nonce)
+}
+
+// GetShuffleCommitment returns the commitment for the current hand's deck.
+// It is available as soon as the hand is dealt and is safe to publish.
+func (g *Game) GetShuffleCommitment() string {
+	return g.shuffleCommitment
+}
+
+// RevealShuffle returns the current hand's full deck order and nonce. It is
+// refused while a hand started with StartHand is being played, as the deck
+// holds the cards still to come.
+func (g *Game) RevealShuffle() (ShuffleReveal, error) {
+	if g.shuffleCommitment == "" {
+		return ShuffleReveal{}, fmt.Errorf("no shuffle has been committed yet")
+	}
+	if g.handActive {
+		return ShuffleReveal{}, fmt.Errorf("the shuffle is revealed once the hand is over")
+	}
+	deck := make(poker.Cards, len(g.handDeck))
+	copy(deck, g.handDeck)
+	return ShuffleReveal{
+		Commitment: g.shuffleCommitment,
+		Deck:       deck,
+		Nonce:      hex.EncodeToString(g.shuffleNonce),
+	}, nil
+}
+
+// commitShuffle snapshots the freshly shuffled deck and commits to it with a new nonce
+func (g *Game) commitShuffle() {
+	nonce := make([]byte, ShuffleNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		// crypto/rand does not fail on supported platforms
+		panic(fmt.Sprintf("failed to generate shuffle nonce: %v", err))
+	}
+	g.handDeck = copyCards(g.deck)
+	g.shuffleNonce = nonce
+	g.shuffleCommitment = CommitShuffle(g.handDeck, nonce)
+}
+
+// encodeDeck serialises a deck as suit,rank byte pairs
+func encodeDeck(deck poker.Cards) []byte {
+	data := make([]byte, 0, len(deck)*2)
+	for _, card := range deck {
+		if card == nil {
+			data = append(data, 0xff, 0xff)
+			continue
+		}
+		data = append(data, byte(card.Suit), byte(card.Rank))
+	}
+	return data
+}
+
+// checkStandardDeck verifies a deck holds each of the 52 standard cards exactly once
+func checkStandardDeck(deck poker.Cards) error {
+	standard := newStandardDeck()
+	if len(deck) != len(standard) {
+		return fmt.Errorf("deck has %d cards, expected %d", len(deck), len(standard))
+	}
+	seen := map[poker.Card]bool{}
+	for _, card := range standard {
+		seen[*card] = false
+	}
+	for _, card := range deck {
+		if card == nil {
+			return fmt.Errorf("deck contains a nil card")
+		}
+		used, ok := seen[*card]
+		if !ok {
+			return fmt.Errorf("deck contains unknown card %s", card)
+		}
+		if used {
+			return fmt.Errorf("deck contains duplicate card %s", card)
+		}
+		seen[*card] = true
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package holdem
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestShuffleCommitmentRevealVerifies(t *testing.T) {
+	game := playRecordedHand(t, 11)
+
+	commitment := game.GetShuffleCommitment()
+	if len(commitment) != 64 {
+		t.Fatalf("Expected hex sha256 commitment, got %q", commitment)
+	}
+
+	reveal, err := game.RevealShuffle()
+	if err != nil {
+		t.Fatalf("Unexpected error revealing shuffle: %v", err)
+	}
+	if reveal.Commitment != commitment {
+		t.Errorf("Expected reveal for commitment %s, got %s", commitment, reveal.Commitment)
+	}
+	if err := reveal.Verify(); err != nil {
+		t.Errorf("Expected reveal to verify, got %v", err)
+	}
+
+	// The revealed deck must explain the dealt cards
+	alice, _ := game.GetPlayerByID(1)
+	if *alice.GetHandCards()[0] != *reveal.Deck[0] {
+		t.Errorf("Expected first dealt card %s to be top of revealed deck %s", alice.GetHandCards()[0], reveal.Deck[0])
+	}
+}
+
+func TestShuffleRevealSurvivesJSON(t *testing.T) {
+	game := playRecordedHand(t, 12)
+	reveal, _ := game.RevealShuffle()
+
+	data, err := json.Marshal(reveal)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded ShuffleReveal
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := decoded.Verify(); err != nil {
+		t.Errorf("Expected decoded reveal to verify, got %v", err)
+	}
+}
+
+func TestVerifyShuffleRejectsTampering(t *testing.T) {
+	game := playRecordedHand(t, 13)
+	reveal, _ := game.RevealShuffle()
+	nonce, _ := hex.DecodeString(reveal.Nonce)
+
+	swapped := make(poker.Cards, len(reveal.Deck))
+	copy(swapped, reveal.Deck)
+	swapped[0], swapped[1] = swapped[1], swapped[0]
+	if err := VerifyShuffle(reveal.Commitment, swapped, nonce); err == nil {
+		t.Error("Expected error for reordered deck")
+	}
+
+	otherNonce := make([]byte, len(nonce))
+	copy(otherNonce, nonce)
+	otherNonce[0] ^= 1
+	if err := VerifyShuffle(reveal.Commitment, reveal.Deck, otherNonce); err == nil {
+		t.Error("Expected error for wrong nonce")
+	}
+
+	if err := VerifyShuffle(reveal.Commitment, reveal.Deck, nonce[:8]); err == nil {
+		t.Error("Expected error for short nonce")
+	}
+
+	duplicated := make(poker.Cards, len(reveal.Deck))
+	copy(duplicated, reveal.Deck)
+	duplicated[1] = duplicated[0]
+	if err := VerifyShuffle(CommitShuffle(duplicated, nonce), duplicated, nonce); err == nil {
+		t.Error("Expected error for deck with duplicate cards")
+	}
+
+	if err := VerifyShuffle(CommitShuffle(reveal.Deck[:51], nonce), reveal.Deck[:51], nonce); err == nil {
+		t.Error("Expected error for short deck")
+	}
+}
+
+func TestShuffleCommitmentChangesEveryHand(t *testing.T) {
+	game := playRecordedHand(t, 14)
+	first := game.GetShuffleCommitment()
+	if err := game.DealHoleCards(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if game.GetShuffleCommitment() == first {
+		t.Error("Expected a new commitment for the next hand")
+	}
+}
+
+func TestRevealShuffleWaitsForTheHandToEnd(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 15}, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	if _, err := game.RevealShuffle(); err == nil {
+		t.Fatal("Expected the shuffle to stay secret while the hand is played")
+	}
+	for game.IsBettingRoundOpen() {
+		if err := game.TakeAction(Action{PlayerID: game.GetCurrentPlayer().GetID(), Type: ActionFold}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if _, err := game.AwardPot(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	reveal, err := game.RevealShuffle()
+	if err != nil {
+		t.Fatalf("Expected the shuffle revealed once the hand is over, got %v", err)
+	}
+	if err := reveal.Verify(); err != nil {
+		t.Errorf("Expected the reveal to verify, got %v", err)
+	}
+}
+
+func TestRevealShuffleBeforeDeal(t *testing.T) {
+	if _, err := NewGame(5, 10).RevealShuffle(); err == nil {
+		t.Error("Expected error before any hand is dealt")
+	}
+}
+
+func TestReplayVerifiesShuffleCommitment(t *testing.T) {
+	game := playRecordedHand(t, 15)
+	replay, _ := NewReplay(game, nil)
+	if replay.ShuffleCommitment != game.GetShuffleCommitment() {
+		t.Fatalf("Expected replay to record the commitment")
+	}
+	if _, err := replay.Run(); err != nil {
+		t.Fatalf("Unexpected replay error: %v", err)
+	}
+
+	tampered := *replay
+	tampered.ShuffleNonce = hex.EncodeToString(make([]byte, ShuffleNonceSize))
+	var mismatch *ReplayMismatchError
+	if _, err := tampered.Run(); !errors.As(err, &mismatch) {
+		t.Errorf("Expected mismatch for wrong nonce, got %v", err)
+	}
+}
@@ -1,10 +1,14 @@
 package holdem
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
 	"math/rand"
 	"time"
 
+	"github.com/ljbink/ai-poker/engine/ids"
+	"github.com/ljbink/ai-poker/engine/metrics"
 	"github.com/ljbink/ai-poker/engine/poker"
 )
 
@@ -73,19 +77,91 @@ type Game struct {
 
 	systemActions SystemActions
 	userActions   UserActions
+
+	config   GameConfig // Configuration the game was created with
+	rng      *rand.Rand // Game RNG, reseeded at the start of every hand
+	handSeed int64      // Seed the current hand was shuffled with
+	handID   string     // Unique ID of the current or last hand
+	nextID   string     // ID the next hand takes instead of a new one, see Replay.Run
+
+	handDeck          poker.Cards // Full deck order the current hand was dealt from
+	stacked           poker.Cards // Cards for the top of the next hand's deck, see StackDeck
+	handStacked       poker.Cards // Cards stacked on the current hand's deck
+	shuffleNonce      []byte      // Secret mixed into the shuffle commitment
+	shuffleCommitment string      // Published H(deck||nonce) for the current hand
+
+	journal        []LoggedAction // Ordered log of every user and system action since the last ResetForNewHand
+	history        []HandHistory  // Finished hands rotated out of the journal, oldest first
+	handStartSeq   int            // Journal position where the current hand started
+	handStartSeats []ReplaySeat   // Seating and stacks when the current hand started
+	elapsed        time.Duration  // Decision time of the action being taken, see TakeTimedAction
+
+	handActive bool       // A hand started with StartHand is being played
+	button     int        // Dealer button seat of the current hand
+	acting     int        // Seat to act, -1 when the betting round is closed
+	currentBet int        // Highest bet on the current street
+	lastRaise  int        // Size of the last full bet or raise
+	acted      [10]bool   // Seats that have acted since the last full raise
+	faced      [10]int    // Bet each seat matched or raised to when it last acted, see reopenedFor
+	protected  [10]bool   // Seats all in for what they put in after disconnecting
+	deadAnte   [10]int    // Chips a seat anted for the whole table, in the main pot but not the seat's stake
+	awards     []PotAward // Pots paid out at the end of the last hand
+	reveals    []Reveal   // Shows and mucks at the last showdown, in order
+
+	handNumber int              // Number of hands dealt so far
+	logger     *slog.Logger     // Structured logger, discards by default
+	metrics    metrics.IMetrics // Optional instrumentation hook
 }
 
 func (g *Game) PlayerSit(player IPlayer, sit int) error {
 	if player == nil {
 		return fmt.Errorf("player is nil")
 	}
-	if sit < 0 || sit >= len(g.players) {
+	if sit < 0 || sit >= len(g.players) || sit >= g.config.Seats() {
 		return fmt.Errorf("invalid sit number: %d", sit)
 	}
 	if g.players[sit] != nil && g.players[sit].GetID() != player.GetID() {
 		return fmt.Errorf("player already sitting at sit: %d", sit)
 	}
+	// A player holds one seat; changing it goes through MovePlayer
+	if seated, err := g.GetPlayerSitByID(player.GetID()); err == nil && seated != sit {
+		return fmt.Errorf("player %d already sitting at sit: %d", player.GetID(), seated)
+	}
 	g.players[sit] = player
+	g.log().Info("player seated",
+		slog.Int("seat", sit),
+		slog.Int("player_id", player.GetID()),
+		slog.String("name", player.GetName()),
+		slog.Int("chips", player.GetChips()),
+	)
+	return nil
+}
+
+// MovePlayer moves a seated player to an empty seat between hands, keeping
+// their chips
+func (g *Game) MovePlayer(id int, sit int) error {
+	from, err := g.GetPlayerSitByID(id)
+	if err != nil {
+		return err
+	}
+	if g.IsHandInProgress() {
+		return fmt.Errorf("cannot change seats during a hand")
+	}
+	if sit < 0 || sit >= len(g.players) || sit >= g.config.Seats() {
+		return fmt.Errorf("invalid sit number: %d", sit)
+	}
+	if sit == from {
+		return nil
+	}
+	if g.players[sit] != nil {
+		return fmt.Errorf("player already sitting at sit: %d", sit)
+	}
+	g.players[sit], g.players[from] = g.players[from], nil
+	g.log().Info("player moved",
+		slog.Int("from_seat", from),
+		slog.Int("seat", sit),
+		slog.Int("player_id", id),
+	)
 	return nil
 }
 
@@ -96,6 +172,10 @@ func (g *Game) PlayerLeave(player IPlayer) error {
 	for i, p := range g.players {
 		if p == player {
 			g.players[i] = nil
+			g.log().Info("player left",
+				slog.Int("seat", i),
+				slog.Int("player_id", player.GetID()),
+			)
 			break
 		}
 	}
@@ -110,6 +190,17 @@ func (g *Game) GetBigBlind() int {
 	return g.bigBlind
 }
 
+// GetHandNumber returns how many hands have been dealt in this game
+func (g *Game) GetHandNumber() int {
+	return g.handNumber
+}
+
+// GetHandID returns the unique ID of the current or last hand, "" before
+// the first. Hand IDs sort in the order the hands were dealt.
+func (g *Game) GetHandID() string {
+	return g.handID
+}
+
 func (g *Game) GetCurrentPhase() GamePhase {
 	return g.currentPhase
 }
@@ -120,7 +211,7 @@ func (g *Game) SetCurrentPhase(phase GamePhase) {
 
 	// Log system action for phase change (if it's actually changing)
 	if oldPhase != phase {
-		g.TakeSystemAction(Action{
+		g.recordSystemAction(Action{
 			PlayerID: SystemPlayerID,
 			Type:     ActionSystemPhaseChange,
 			Amount:   int(phase), // Store the new phase as amount
@@ -171,6 +262,14 @@ func (g *Game) GetAllPlayers() []IPlayer {
 }
 
 func (g *Game) GetCurrentPlayer() IPlayer {
+	// Hands started with StartHand track whose turn it is
+	if g.handActive {
+		if g.acting < 0 {
+			return nil
+		}
+		return g.players[g.acting]
+	}
+
 	// Find the first non-nil, non-folded player
 	for _, player := range g.players {
 		if player != nil && !player.IsFolded() {
@@ -188,7 +287,25 @@ func (g *Game) GetUserActions() UserActions {
 	return g.userActions
 }
 
+// TakeAction records a player action. While a hand started with StartHand is
+// in progress the action is also validated and applied to the table.
 func (g *Game) TakeAction(action Action) error {
+	if g.handActive {
+		return g.applyAction(action)
+	}
+	return g.recordAction(action)
+}
+
+// TakeTimedAction behaves like TakeAction and also records in the journal
+// how long the player took to decide
+func (g *Game) TakeTimedAction(action Action, elapsed time.Duration) error {
+	g.elapsed = elapsed
+	defer func() { g.elapsed = 0 }()
+	return g.TakeAction(action)
+}
+
+// recordAction adds a player action to the phase log and the journal
+func (g *Game) recordAction(action Action) error {
 	// Add action to the appropriate phase log in userActions
 	switch g.currentPhase {
 	case PhasePreflop:
@@ -200,8 +317,12 @@ func (g *Game) TakeAction(action Action) error {
 	case PhaseRiver:
 		g.userActions.River = append(g.userActions.River, action)
 	default:
+		g.log().Warn("action rejected: invalid game phase", g.actionAttrs(action)...)
 		return fmt.Errorf("invalid game phase: %d", g.currentPhase)
 	}
+	g.appendJournal(action, g.elapsed)
+	g.log().Debug("action taken", g.actionAttrs(action)...)
+	g.getMetrics().IncCounter(metrics.ActionsTotal, metrics.Labels{"action": ActionTypeToString(action.Type)}, 1)
 	return nil
 }
 
@@ -220,27 +341,52 @@ func (g *Game) TakeSystemAction(action Action) error {
 	default:
 		return fmt.Errorf("invalid game phase: %d", g.currentPhase)
 	}
+	g.appendJournal(action, 0)
 	return nil
 }
 
+// recordSystemAction records a system action and logs it, reporting failures
+// instead of silently dropping them
+func (g *Game) recordSystemAction(action Action) {
+	if err := g.TakeSystemAction(action); err != nil {
+		g.log().Warn("system action not recorded",
+			slog.String("action", ActionTypeToString(action.Type)),
+			slog.Any("error", err),
+		)
+		return
+	}
+	g.log().Debug("system action",
+		slog.String("action", ActionTypeToString(action.Type)),
+		slog.Int("amount", action.Amount),
+	)
+}
+
 // Card dealing methods
 
 func (g *Game) ShuffleDeck() {
 	// Shuffle existing deck using Fisher-Yates algorithm
-	rand.Seed(time.Now().UnixNano())
+	rng := g.random()
 	for i := len(g.deck) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
+		j := rng.Intn(i + 1)
 		g.deck[i], g.deck[j] = g.deck[j], g.deck[i]
 	}
 
 	// Log system action for deck shuffle
-	g.TakeSystemAction(Action{
+	g.recordSystemAction(Action{
 		PlayerID: SystemPlayerID,
 		Type:     ActionSystemShuffle,
 		Amount:   0,
 	})
 }
 
+// ErrDeckExhausted is returned, wrapped, by the dealing functions when the
+// deck holds fewer cards than the deal needs. Nothing is dealt then; a hand
+// in progress stays where it was and is best aborted, see AbortHand.
+var ErrDeckExhausted = errors.New("deck exhausted")
+
+// deckSize is the number of cards in a fresh deck
+const deckSize = 52
+
 // newStandardDeck creates a standard 52-card poker deck (no jokers)
 func newStandardDeck() poker.Cards {
 	suits := []poker.Suit{
@@ -283,22 +429,49 @@ func (g *Game) ResetAndShuffleDeck() {
 func (g *Game) DealHoleCards() error {
 	activePlayers := g.GetAllPlayers()
 	if len(activePlayers) < 2 {
+		g.log().Warn("cannot deal hole cards", slog.Int("players", len(activePlayers)))
 		return fmt.Errorf("need at least 2 players to deal cards")
 	}
+	holeCards := g.GetVariant().HoleCards()
+	if need := holeCards * len(activePlayers); need > deckSize {
+		g.log().Warn("deck exhausted", slog.Int("players", len(activePlayers)), slog.Int("needed", need))
+		return fmt.Errorf("%w: %d hole cards for %d players need %d cards, the deck has %d", ErrDeckExhausted, holeCards, len(activePlayers), need, deckSize)
+	}
+
+	if err := g.ResetForNewHand(); err != nil {
+		return err
+	}
+
+	// A new hand always starts preflop on an empty board
+	g.currentPhase = PhasePreflop
+	g.communityCards = poker.Cards{}
+
+	g.handNumber++
+	g.handID, g.nextID = g.nextID, ""
+	if g.handID == "" {
+		g.handID = ids.New()
+	}
+	g.startHandRNG(g.handNumber)
+	g.handStartSeq = len(g.journal)
+	g.handStartSeats = g.snapshotSeats()
+	g.log().Info("hand started", slog.Int("players", len(activePlayers)), slog.Int64("seed", g.handSeed))
+	g.getMetrics().IncCounter(metrics.HandsDealtTotal, nil, 1)
 
 	// Reset and shuffle deck before dealing
 	g.ResetAndShuffleDeck()
+	g.applyStack()
+	g.commitShuffle()
 
 	// Clear existing cards from players
 	for _, player := range activePlayers {
 		player.ResetForNewHand()
 	}
 
-	// Deal 2 cards to each player
+	// Deal the variant's hole cards to each player, one at a time
 	cardIndex := 0
-	for round := 0; round < 2; round++ {
+	for round := 0; round < holeCards; round++ {
 		for _, player := range activePlayers {
-			if !player.IsFolded() && cardIndex < len(g.deck) {
+			if !player.IsFolded() {
 				player.DealCard(g.deck[cardIndex])
 				cardIndex++
 			}
@@ -309,89 +482,131 @@ func (g *Game) DealHoleCards() error {
 	g.deck = g.deck[cardIndex:]
 
 	// Log system action for dealing hole cards
-	g.TakeSystemAction(Action{
+	g.recordSystemAction(Action{
 		PlayerID: SystemPlayerID,
 		Type:     ActionSystemDealHole,
-		Amount:   len(activePlayers) * 2, // Number of cards dealt
+		Amount:   cardIndex, // Number of cards dealt
 	})
 
 	return nil
 }
 
+// deckExhausted reports a street the deck cannot deal, burn card included
+func (g *Game) deckExhausted(street string, need int) error {
+	g.log().Warn("deck exhausted", slog.String("street", street), slog.Int("needed", need), slog.Int("left", len(g.deck)))
+	return fmt.Errorf("%w: the %s needs %d cards, %d left", ErrDeckExhausted, street, need, len(g.deck))
+}
+
 func (g *Game) DealFlop() error {
-	if len(g.deck) < 4 {
-		return fmt.Errorf("not enough cards in deck for flop")
+	if g.handActive && g.acting >= 0 {
+		return fmt.Errorf("betting round is not complete")
+	}
+	count := g.GetVariant().BoardCards(PhaseFlop)
+	if len(g.deck) < count+1 {
+		return g.deckExhausted("flop", count+1)
 	}
 
-	// Burn one card, then deal 3 community cards
+	// Burn one card, then deal the flop to the community
 	g.deck = g.deck[1:] // Burn card
-
-	// Deal 3 cards to community
-	for i := 0; i < 3; i++ {
-		g.communityCards = append(g.communityCards, g.deck[i])
-	}
-	g.deck = g.deck[3:]
+	g.communityCards = append(g.communityCards, g.deck[:count]...)
+	g.deck = g.deck[count:]
 
 	g.currentPhase = PhaseFlop
 
 	// Log system action for dealing flop
-	g.TakeSystemAction(Action{
+	g.recordSystemAction(Action{
 		PlayerID: SystemPlayerID,
 		Type:     ActionSystemDealFlop,
-		Amount:   3, // Number of community cards dealt
+		Amount:   count, // Number of community cards dealt
 	})
 
+	if g.handActive {
+		g.startStreet()
+	}
+
 	return nil
 }
 
 func (g *Game) DealTurn() error {
-	if len(g.deck) < 2 {
-		return fmt.Errorf("not enough cards in deck for turn")
+	if g.handActive && g.acting >= 0 {
+		return fmt.Errorf("betting round is not complete")
+	}
+	count := g.GetVariant().BoardCards(PhaseTurn)
+	if len(g.deck) < count+1 {
+		return g.deckExhausted("turn", count+1)
 	}
 
-	// Burn one card, then deal 1 community card
+	// Burn one card, then deal the turn to the community
 	g.deck = g.deck[1:] // Burn card
-
-	g.communityCards = append(g.communityCards, g.deck[0])
-	g.deck = g.deck[1:]
+	g.communityCards = append(g.communityCards, g.deck[:count]...)
+	g.deck = g.deck[count:]
 
 	g.currentPhase = PhaseTurn
 
 	// Log system action for dealing turn
-	g.TakeSystemAction(Action{
+	g.recordSystemAction(Action{
 		PlayerID: SystemPlayerID,
 		Type:     ActionSystemDealTurn,
-		Amount:   1, // Number of community cards dealt
+		Amount:   count, // Number of community cards dealt
 	})
 
+	if g.handActive {
+		g.startStreet()
+	}
+
 	return nil
 }
 
 func (g *Game) DealRiver() error {
-	if len(g.deck) < 2 {
-		return fmt.Errorf("not enough cards in deck for river")
+	if g.handActive && g.acting >= 0 {
+		return fmt.Errorf("betting round is not complete")
+	}
+	count := g.GetVariant().BoardCards(PhaseRiver)
+	if len(g.deck) < count+1 {
+		return g.deckExhausted("river", count+1)
 	}
 
-	// Burn one card, then deal 1 community card
+	// Burn one card, then deal the river to the community
 	g.deck = g.deck[1:] // Burn card
-
-	g.communityCards = append(g.communityCards, g.deck[0])
-	g.deck = g.deck[1:]
+	g.communityCards = append(g.communityCards, g.deck[:count]...)
+	g.deck = g.deck[count:]
 
 	g.currentPhase = PhaseRiver
 
 	// Log system action for dealing river
-	g.TakeSystemAction(Action{
+	g.recordSystemAction(Action{
 		PlayerID: SystemPlayerID,
 		Type:     ActionSystemDealRiver,
-		Amount:   1, // Number of community cards dealt
+		Amount:   count, // Number of community cards dealt
 	})
 
+	if g.handActive {
+		g.startStreet()
+	}
+
 	return nil
 }
 
-// NewGame creates a new game with specified blinds
+// NewGame creates a new game with specified blinds and a random seed
 func NewGame(smallBlind, bigBlind int) *Game {
+	return NewGameWithConfig(GameConfig{
+		SmallBlind: smallBlind,
+		BigBlind:   bigBlind,
+	})
+}
+
+// NewGameWithConfig creates a new game from a configuration.
+// Games sharing a non-zero seed deal identical cards for identical action sequences.
+func NewGameWithConfig(config GameConfig) *Game {
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+	}
+	if config.TableID == "" {
+		config.TableID = ids.New()
+	}
+	config.Evaluator = effectiveEvaluator(config.Evaluator)
+	smallBlind, bigBlind := config.SmallBlind, config.BigBlind
+
 	game := &Game{
 		players:        [10]IPlayer{},
 		deck:           newStandardDeck(), // Use standard 52-card deck
@@ -399,6 +614,12 @@ func NewGame(smallBlind, bigBlind int) *Game {
 		currentPhase:   PhasePreflop,
 		smallBlind:     smallBlind,
 		bigBlind:       bigBlind,
+		config:         config,
+		rng:            rand.New(rand.NewSource(config.Seed)),
+		button:         -1,
+		acting:         -1,
+		journal:        []LoggedAction{},
+		logger:         NewDiscardLogger(),
 		systemActions: SystemActions{
 			Preflop: []Action{},
 			Flop:    []Action{},
@@ -414,9 +635,8 @@ func NewGame(smallBlind, bigBlind int) *Game {
 	}
 
 	// Shuffle deck on creation (without logging since it's initialization)
-	rand.Seed(time.Now().UnixNano())
 	for i := len(game.deck) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
+		j := game.rng.Intn(i + 1)
 		game.deck[i], game.deck[j] = game.deck[j], game.deck[i]
 	}
 
@@ -1,6 +1,7 @@
 package holdem
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -87,6 +88,57 @@ func TestPlayerSit(t *testing.T) {
 	if err != nil {
 		t.Errorf("Unexpected error when sitting same player in same seat: %v", err)
 	}
+
+	// Test sitting the same player, or another with their ID, at a second seat
+	if err := game.PlayerSit(player, 3); err == nil {
+		t.Error("Expected error when sitting a seated player at another seat")
+	}
+	if err := game.PlayerSit(NewPlayer(1, "Impostor", 500), 3); err == nil {
+		t.Error("Expected error when sitting a seated player's ID at another seat")
+	}
+	if len(game.GetAllPlayers()) != 1 {
+		t.Errorf("Expected one seated player, got %d", len(game.GetAllPlayers()))
+	}
+}
+
+func TestMovePlayer(t *testing.T) {
+	game := NewGame(10, 20)
+	player := NewPlayer(1, "Mover", 1000)
+	game.PlayerSit(player, 0)
+	game.PlayerSit(NewPlayer(2, "Other", 1000), 4)
+
+	if err := game.MovePlayer(1, 6); err != nil {
+		t.Fatalf("Unexpected error moving player: %v", err)
+	}
+	if sit, _ := game.GetPlayerSitByID(1); sit != 6 {
+		t.Errorf("Expected player at seat 6, got %d", sit)
+	}
+	if _, err := game.GetPlayerBySit(0); err == nil {
+		t.Error("Expected the old seat to be empty")
+	}
+	if player.GetChips() != 1000 {
+		t.Errorf("Expected the chips kept, got %d", player.GetChips())
+	}
+
+	if err := game.MovePlayer(1, 6); err != nil {
+		t.Errorf("Expected moving to the same seat to do nothing, got %v", err)
+	}
+	if err := game.MovePlayer(1, 4); err == nil {
+		t.Error("Expected error when moving to an occupied seat")
+	}
+	if err := game.MovePlayer(1, 10); err == nil {
+		t.Error("Expected error when moving to an invalid seat")
+	}
+	if err := game.MovePlayer(3, 2); err == nil {
+		t.Error("Expected error when moving a player not in game")
+	}
+
+	if err := game.StartHand(4); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	if err := game.MovePlayer(1, 2); err == nil {
+		t.Error("Expected error when changing seats during a hand")
+	}
 }
 
 func TestPlayerLeave(t *testing.T) {
@@ -512,8 +564,8 @@ func TestDealFlopInsufficientDeck(t *testing.T) {
 	game := NewGame(5, 10)
 	// Exhaust the deck so fewer than 4 cards remain
 	game.deck = game.deck[:3]
-	if err := game.DealFlop(); err == nil {
-		t.Error("Expected error when not enough cards in deck for flop")
+	if err := game.DealFlop(); !errors.Is(err, ErrDeckExhausted) {
+		t.Errorf("Expected the deck to run out for the flop, got %v", err)
 	}
 }
 
@@ -553,8 +605,8 @@ func TestDealTurnInsufficientDeck(t *testing.T) {
 	game := NewGame(5, 10)
 	// Leave fewer than 2 cards
 	game.deck = game.deck[:1]
-	if err := game.DealTurn(); err == nil {
-		t.Error("Expected error when not enough cards in deck for turn")
+	if err := game.DealTurn(); !errors.Is(err, ErrDeckExhausted) {
+		t.Errorf("Expected the deck to run out for the turn, got %v", err)
 	}
 }
 
@@ -598,8 +650,35 @@ func TestDealRiverInsufficientDeck(t *testing.T) {
 	game := NewGame(5, 10)
 	// Leave fewer than 2 cards
 	game.deck = game.deck[:1]
-	if err := game.DealRiver(); err == nil {
-		t.Error("Expected error when not enough cards in deck for river")
+	if err := game.DealRiver(); !errors.Is(err, ErrDeckExhausted) {
+		t.Errorf("Expected the deck to run out for the river, got %v", err)
+	}
+}
+
+// wideHandVariant deals more hole cards than a full table can get from one deck
+type wideHandVariant struct {
+	HoldemVariant
+}
+
+func (wideHandVariant) Name() GameVariant { return "test-wide" }
+
+func (wideHandVariant) HoleCards() int { return 6 }
+
+func TestDealHoleCardsDeckExhausted(t *testing.T) {
+	registerVariant(t, wideHandVariant{})
+
+	stacks := []int{1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000}
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Variant: "test-wide"}, stacks...)
+	if err := game.StartHand(0); !errors.Is(err, ErrDeckExhausted) {
+		t.Fatalf("Expected 60 hole cards to exhaust the deck, got %v", err)
+	}
+	if game.IsHandInProgress() || game.GetHandNumber() != 0 || game.GetPot() != 0 {
+		t.Errorf("Expected no hand started, got hand %d in progress %v", game.GetHandNumber(), game.IsHandInProgress())
+	}
+	for _, player := range game.GetAllPlayers() {
+		if len(player.GetHandCards()) != 0 {
+			t.Errorf("Expected player %d dealt nothing, got %d cards", player.GetID(), len(player.GetHandCards()))
+		}
 	}
 }
 
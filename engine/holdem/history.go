@@ -0,0 +1,139 @@
+package holdem
+
+import "strings"
+
+// Street returns the actions logged on one street
+func (u UserActions) Street(phase GamePhase) []Action {
+	switch phase {
+	case PhasePreflop:
+		return u.Preflop
+	case PhaseFlop:
+		return u.Flop
+	case PhaseTurn:
+		return u.Turn
+	case PhaseRiver:
+		return u.River
+	default:
+		return []Action{}
+	}
+}
+
+// ActionsByPlayer returns the player's actions in the order they were
+// taken, forced bets included
+func (u UserActions) ActionsByPlayer(playerID int) []Action {
+	var actions []Action
+	for phase := PhasePreflop; phase <= PhaseRiver; phase++ {
+		for _, action := range u.Street(phase) {
+			if action.PlayerID == playerID {
+				actions = append(actions, action)
+			}
+		}
+	}
+	return actions
+}
+
+// PotAtStreet returns the chips in the pot when the betting on a street
+// began: the blinds and antes before the flop, and on later streets
+// everything put in on the streets before
+func (u UserActions) PotAtStreet(phase GamePhase) int {
+	pot := 0
+	for street := PhasePreflop; street < phase && street <= PhaseRiver; street++ {
+		for _, action := range u.Street(street) {
+			pot += action.Amount
+		}
+	}
+	if phase == PhasePreflop {
+		for _, action := range u.Preflop {
+			if action.Type.IsForced() {
+				pot += action.Amount
+			}
+		}
+	}
+	return pot
+}
+
+// LastAggressor returns the player who last bet or raised on the street
+func (u UserActions) LastAggressor(phase GamePhase) (int, bool) {
+	aggressor, found := 0, false
+	walkStreet(u.Street(phase), func(action Action, raised bool, _ int) {
+		if raised {
+			aggressor, found = action.PlayerID, true
+		}
+	})
+	return aggressor, found
+}
+
+// NumRaises returns how many bets and raises were made on the street. An
+// all-in counts when it raised the bet, blinds never count.
+func (u UserActions) NumRaises(phase GamePhase) int {
+	raises := 0
+	walkStreet(u.Street(phase), func(_ Action, raised bool, _ int) {
+		if raised {
+			raises++
+		}
+	})
+	return raises
+}
+
+// StreetAction is a voluntary action on a street with whether it raised
+// the bet and the bet the player faced when taking it
+type StreetAction struct {
+	Action
+	Raised bool
+	Faced  int
+}
+
+// Voluntary returns the street's actions without the blinds and antes, in
+// the order they were taken
+func (u UserActions) Voluntary(phase GamePhase) []StreetAction {
+	actions := []StreetAction{}
+	walkStreet(u.Street(phase), func(action Action, raised bool, before int) {
+		actions = append(actions, StreetAction{Action: action, Raised: raised, Faced: before})
+	})
+	return actions
+}
+
+// LineString describes the player's line street by street, e.g.
+// "call, check-raise". All-ins read as the bet, raise or call they made,
+// and streets the player did not act on are left out.
+func (u UserActions) LineString(playerID int) string {
+	var streets []string
+	for phase := PhasePreflop; phase <= PhaseRiver; phase++ {
+		var line []string
+		walkStreet(u.Street(phase), func(action Action, raised bool, before int) {
+			if action.PlayerID != playerID {
+				return
+			}
+			switch {
+			case raised && before == 0:
+				line = append(line, "bet")
+			case raised:
+				line = append(line, "raise")
+			case action.Type == ActionFold:
+				line = append(line, "fold")
+			case action.Type == ActionCheck:
+				line = append(line, "check")
+			default:
+				line = append(line, "call")
+			}
+		})
+		if len(line) > 0 {
+			streets = append(streets, strings.Join(line, "-"))
+		}
+	}
+	return strings.Join(streets, ", ")
+}
+
+// walkStreet calls visit for every voluntary action on a street with
+// whether it raised the bet and the bet it faced
+func walkStreet(actions []Action, visit func(action Action, raised bool, before int)) {
+	bets := newStreetBets(0)
+	for _, action := range actions {
+		before := bets.current
+		bets.apply(action)
+		if action.Type.IsForced() {
+			continue
+		}
+		visit(action, bets.current > before, before)
+	}
+}
@@ -0,0 +1,64 @@
+package holdem
+
+import "testing"
+
+func TestActionHistoryQueries(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000)
+	game.StartHand(0) // Seat 0 has the button, player 1 acts first
+
+	mustAct(t, game, 1, ActionRaise, 30)
+	mustAct(t, game, 2, ActionFold, 0)
+	mustAct(t, game, 3, ActionCall, 20)
+	game.DealFlop()
+	mustAct(t, game, 3, ActionCheck, 0)
+	mustAct(t, game, 1, ActionRaise, 40)
+	mustAct(t, game, 3, ActionRaise, 120)
+	mustAct(t, game, 1, ActionCall, 80)
+	game.DealTurn()
+	mustAct(t, game, 3, ActionCheck, 0)
+	mustAct(t, game, 1, ActionCheck, 0)
+
+	history := game.GetUserActions()
+	if aggressor, ok := history.LastAggressor(PhasePreflop); !ok || aggressor != 1 {
+		t.Errorf("Expected player 1 as the preflop aggressor, got %d", aggressor)
+	}
+	if aggressor, ok := history.LastAggressor(PhaseFlop); !ok || aggressor != 3 {
+		t.Errorf("Expected player 3 as the flop aggressor, got %d", aggressor)
+	}
+	if _, ok := history.LastAggressor(PhaseTurn); ok {
+		t.Error("Expected no aggressor on a checked turn")
+	}
+	if history.NumRaises(PhasePreflop) != 1 || history.NumRaises(PhaseFlop) != 2 || history.NumRaises(PhaseTurn) != 0 {
+		t.Errorf("Expected 1, 2 and 0 raises, got %d, %d and %d",
+			history.NumRaises(PhasePreflop), history.NumRaises(PhaseFlop), history.NumRaises(PhaseTurn))
+	}
+
+	if actions := history.ActionsByPlayer(3); len(actions) != 5 || actions[0].Type != ActionPostBigBlind || actions[4].Type != ActionCheck {
+		t.Errorf("Expected player 3's blind and four actions, got %+v", actions)
+	}
+	if flop := history.Voluntary(PhaseFlop); len(flop) != 4 || flop[1].PlayerID != 1 || !flop[1].Raised || flop[1].Faced != 0 ||
+		!flop[2].Raised || flop[2].Faced != 40 || flop[3].Raised {
+		t.Errorf("Expected check, bet 40, raise and call on the flop, got %+v", flop)
+	}
+	for id, want := range map[int]string{1: "raise, bet-call, check", 2: "fold", 3: "call, check-raise, check"} {
+		if line := history.LineString(id); line != want {
+			t.Errorf("Expected player %d's line %q, got %q", id, want, line)
+		}
+	}
+}
+
+func TestNumRaisesSkipsAllInCalls(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 25)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionRaise, 100)
+	mustAct(t, game, 2, ActionFold, 0)
+	mustAct(t, game, 3, ActionAllIn, 15) // Big blind calls short
+
+	history := game.GetUserActions()
+	if raises := history.NumRaises(PhasePreflop); raises != 1 {
+		t.Errorf("Expected the short all-in not to count as a raise, got %d raises", raises)
+	}
+	if line := history.LineString(3); line != "call" {
+		t.Errorf("Expected the short all-in to read as a call, got %q", line)
+	}
+}
@@ -0,0 +1,128 @@
+package holdem
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// InvariantError reports a table no sequence of legal actions can reach,
+// found by CheckInvariants
+type InvariantError struct {
+	HandID     string
+	Violations []string // What does not hold, one per broken invariant
+	Dump       string   // The table and the hand's actions when the check ran
+}
+
+func (e *InvariantError) Error() string {
+	return fmt.Sprintf("hand %s breaks the table invariants: %s", e.HandID, strings.Join(e.Violations, "; "))
+}
+
+// CheckInvariants checks the hand in progress: the pot holds exactly the
+// chips the hand's actions put in, nobody has negative chips or bets, and
+// while a betting round is open exactly one player who still owes an
+// action is to act. It returns an *InvariantError listing what is broken,
+// nil when everything holds or no hand is in progress.
+func (g *Game) CheckInvariants() error {
+	if !g.handActive {
+		return nil
+	}
+	violations := []string{}
+
+	put := 0
+	for _, logged := range g.journal[g.handStartSeq:] {
+		action := logged.Action
+		if action.PlayerID == SystemPlayerID {
+			continue
+		}
+		switch {
+		case action.Type.IsForced(), action.Type == ActionCall, action.Type == ActionRaise, action.Type == ActionAllIn:
+			put += action.Amount
+		}
+	}
+	if pot := g.GetPot(); pot != put {
+		violations = append(violations, fmt.Sprintf("pot of %d is not the %d the hand's actions put in", pot, put))
+	}
+
+	for seat, player := range g.players {
+		if player == nil {
+			continue
+		}
+		if player.GetChips() < 0 {
+			violations = append(violations, fmt.Sprintf("player %d at seat %d has %d chips", player.GetID(), seat, player.GetChips()))
+		}
+		if player.GetBet() < 0 || player.GetTotalBet() < player.GetBet() {
+			violations = append(violations, fmt.Sprintf("player %d at seat %d bet %d of %d in total", player.GetID(), seat, player.GetBet(), player.GetTotalBet()))
+		}
+	}
+
+	switch {
+	case g.acting >= 0 && (g.acting >= len(g.players) || !g.canBet(g.acting)):
+		violations = append(violations, fmt.Sprintf("seat %d is to act but cannot bet", g.acting))
+	case g.acting >= 0 && g.acted[g.acting] && g.players[g.acting].GetBet() >= g.currentBet:
+		violations = append(violations, fmt.Sprintf("seat %d is to act but owes no action", g.acting))
+	case g.acting < 0 && g.countInHand() > 1:
+		if owes := g.nextToAct(g.button); owes >= 0 {
+			violations = append(violations, fmt.Sprintf("nobody is to act but seat %d owes an action", owes))
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &InvariantError{HandID: g.handID, Violations: violations, Dump: g.dump()}
+}
+
+// checkInvariants runs CheckInvariants after an action when the config asks
+// for it. Debug builds, built with the debug tag, panic so the broken hand
+// stops where it broke; other builds log the error and play on.
+func (g *Game) checkInvariants(action Action) {
+	if !g.config.Debug {
+		return
+	}
+	err := g.CheckInvariants()
+	if err == nil {
+		return
+	}
+	broken := err.(*InvariantError)
+	if panicOnBrokenInvariant {
+		panic(fmt.Errorf("after %s by player %d: %w\n%s", ActionTypeToString(action.Type), action.PlayerID, err, broken.Dump))
+	}
+	g.log().Error("table invariants broken",
+		append(g.actionAttrs(action),
+			slog.Any("violations", broken.Violations),
+			slog.String("dump", broken.Dump),
+		)...,
+	)
+}
+
+// dump prints the table and the hand's actions for an invariant error
+func (g *Game) dump() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "hand %s #%d, %s, button seat %d, seat %d to act, bet %d, last raise %d, pot %d\n",
+		g.handID, g.handNumber, PhaseToString(g.currentPhase), g.button, g.acting, g.currentBet, g.lastRaise, g.GetPot())
+	for seat, player := range g.players {
+		if player == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "seat %d: player %d %q, chips %d, bet %d, total %d", seat, player.GetID(), player.GetName(), player.GetChips(), player.GetBet(), player.GetTotalBet())
+		for _, flag := range []struct {
+			set  bool
+			name string
+		}{
+			{player.IsFolded(), "folded"},
+			{g.acted[seat], "acted"},
+			{g.protected[seat], "protected"},
+		} {
+			if flag.set {
+				b.WriteString(", " + flag.name)
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("actions:\n")
+	for _, logged := range g.journal[g.handStartSeq:] {
+		fmt.Fprintf(&b, "  %s: player %d %s %d\n", PhaseToString(logged.Phase), logged.Action.PlayerID, ActionTypeToString(logged.Action.Type), logged.Action.Amount)
+	}
+	return b.String()
+}
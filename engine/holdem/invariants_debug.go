@@ -0,0 +1,6 @@
+//go:build debug
+
+package holdem
+
+// panicOnBrokenInvariant makes a broken invariant panic in debug builds
+const panicOnBrokenInvariant = true
@@ -0,0 +1,6 @@
+//go:build !debug
+
+package holdem
+
+// panicOnBrokenInvariant makes a broken invariant only log outside debug builds
+const panicOnBrokenInvariant = false
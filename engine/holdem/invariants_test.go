@@ -0,0 +1,96 @@
+package holdem
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckInvariantsHoldThroughAHand(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Ante: 1, Debug: true}, 500, 500, 500)
+	if err := game.CheckInvariants(); err != nil {
+		t.Errorf("Expected no hand to check before the deal, got %v", err)
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	check := func(step string) {
+		t.Helper()
+		if err := game.CheckInvariants(); err != nil {
+			t.Fatalf("%s: %v", step, err)
+		}
+	}
+	check("blinds")
+	mustAct(t, game, 1, ActionRaise, 30)
+	check("raise")
+	mustAct(t, game, 2, ActionCall, 25)
+	mustAct(t, game, 3, ActionFold, 0)
+	check("preflop closed")
+	if err := game.DealFlop(); err != nil {
+		t.Fatal(err)
+	}
+	check("flop")
+	mustAct(t, game, 2, ActionCheck, 0)
+	mustAct(t, game, 1, ActionAllIn, game.players[0].GetChips())
+	mustAct(t, game, 2, ActionCall, game.players[1].GetChips())
+	check("all in")
+}
+
+func TestCheckInvariantsReportBrokenTables(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 500, 500, 500)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+
+	// Chips put in behind the game's back, a negative stack and a folded
+	// player to act
+	game.players[1].Bet(600)
+	game.players[game.acting].Fold()
+	err := game.CheckInvariants()
+	var broken *InvariantError
+	if !errors.As(err, &broken) {
+		t.Fatalf("Expected an invariant error, got %v", err)
+	}
+	if broken.HandID != game.GetHandID() || len(broken.Violations) != 3 {
+		t.Errorf("Expected three violations in hand %s, got %+v", game.GetHandID(), broken)
+	}
+	for _, expected := range []string{"pot of 615 is not the 15", "has -105 chips", "cannot bet"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q in %q", expected, err)
+		}
+	}
+	if !strings.Contains(broken.Dump, "seat 1: player 2") || !strings.Contains(broken.Dump, "Post Small Blind") {
+		t.Errorf("Expected the seats and actions in the dump, got:\n%s", broken.Dump)
+	}
+}
+
+func TestDebugGameReportsBrokenInvariants(t *testing.T) {
+	var buf bytes.Buffer
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Debug: true}, 500, 500)
+	game.SetLogger(newTestLogger(&buf))
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	game.players[1].GrandChips(-1000)
+
+	defer func() {
+		recovered := recover()
+		if panicOnBrokenInvariant {
+			if err, ok := recovered.(error); !ok || !errors.As(err, new(*InvariantError)) {
+				t.Errorf("Expected a debug build to panic with the invariant error, got %v", recovered)
+			}
+			return
+		}
+		if recovered != nil {
+			t.Fatalf("Expected other builds to play on, got a panic: %v", recovered)
+		}
+		for _, record := range decodeLogLines(t, &buf) {
+			if record["msg"] == "table invariants broken" && strings.Contains(record["dump"].(string), "chips -510") {
+				return
+			}
+		}
+		t.Errorf("Expected the broken invariant logged with a dump, got:\n%s", buf.String())
+	}()
+	mustAct(t, game, 1, ActionCall, 5)
+}
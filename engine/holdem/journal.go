@@ -0,0 +1,64 @@
+package holdem
+
+import "fmt"
+
+// DefaultHandHistory is how many finished hands a game keeps in memory when
+// GameConfig.HandHistory is 0
+const DefaultHandHistory = 50
+
+// HandHistory is one finished hand's action log, rotated out of the game's
+// journal when the next hand is dealt
+type HandHistory struct {
+	HandNumber int
+	HandID     string
+	Seats      []ReplaySeat   // Seating and stacks when the hand started
+	Actions    []LoggedAction // Every user and system action of the hand, in order
+}
+
+// ResetForNewHand clears the per-hand logs ahead of a deal. The last hand's
+// actions move into the hand history, the oldest hands dropping out past
+// GameConfig.HandHistory, so a game dealing hand after hand holds a bounded
+// log. DealHoleCards calls it; it fails while a hand is in progress.
+func (g *Game) ResetForNewHand() error {
+	if g.handActive {
+		return fmt.Errorf("hand %d is still in progress", g.handNumber)
+	}
+	if len(g.journal) > g.handStartSeq && g.handNumber > 0 {
+		g.history = append(g.history, HandHistory{
+			HandNumber: g.handNumber,
+			HandID:     g.handID,
+			Seats:      g.handStartSeats,
+			Actions:    g.journal[g.handStartSeq:],
+		})
+	}
+	if keep := g.historyLimit(); len(g.history) > keep {
+		g.history = append([]HandHistory(nil), g.history[len(g.history)-keep:]...)
+	}
+	g.journal, g.handStartSeq = []LoggedAction{}, 0
+	g.userActions = UserActions{Preflop: []Action{}, Flop: []Action{}, Turn: []Action{}, River: []Action{}}
+	g.systemActions = SystemActions{Preflop: []Action{}, Flop: []Action{}, Turn: []Action{}, River: []Action{}}
+	return nil
+}
+
+// historyLimit is how many finished hands the game keeps
+func (g *Game) historyLimit() int {
+	switch {
+	case g.config.HandHistory < 0:
+		return 0
+	case g.config.HandHistory == 0:
+		return DefaultHandHistory
+	}
+	return g.config.HandHistory
+}
+
+// GetHandHistory returns the finished hands the game still keeps, oldest
+// first. The hand in progress or just finished joins them when the next
+// hand is dealt.
+func (g *Game) GetHandHistory() []HandHistory {
+	history := make([]HandHistory, len(g.history))
+	for i, hand := range g.history {
+		hand.Actions = append([]LoggedAction(nil), hand.Actions...)
+		history[i] = hand
+	}
+	return history
+}
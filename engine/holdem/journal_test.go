@@ -0,0 +1,52 @@
+package holdem
+
+import "testing"
+
+// foldHands plays hands where the first player to act folds
+func foldHands(t *testing.T, game *Game, hands int) {
+	t.Helper()
+	for i := 0; i < hands; i++ {
+		if err := game.StartHand(i % 2); err != nil {
+			t.Fatalf("StartHand %d failed: %v", i, err)
+		}
+		mustAct(t, game, game.GetCurrentPlayer().GetID(), ActionFold, 0)
+		if _, err := game.AwardPot(); err != nil {
+			t.Fatalf("AwardPot failed: %v", err)
+		}
+	}
+}
+
+func TestResetForNewHandRotatesTheJournal(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, HandHistory: 2}, 1000, 1000)
+	foldHands(t, game, 4)
+
+	// The fourth hand stays in the journal until the next deal
+	history := game.GetHandHistory()
+	if len(history) != 2 || history[0].HandNumber != 2 || history[1].HandNumber != 3 {
+		t.Fatalf("Expected hands 2 and 3 kept, got %+v", history)
+	}
+	if actions := history[1].Actions; actions[0].Action.Type != ActionSystemShuffle || actions[len(actions)-2].Action.Type != ActionFold {
+		t.Errorf("Expected hand 3 from the shuffle to the fold and award, got %v", actions)
+	}
+	if len(game.GetActionLog()) != len(game.GetHandActionLog()) {
+		t.Errorf("Expected the journal to hold only the last hand")
+	}
+	if len(game.GetUserActions().Preflop) != 3 {
+		t.Errorf("Expected only the last hand's blinds and fold, got %v", game.GetUserActions().Preflop)
+	}
+
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	if err := game.ResetForNewHand(); err == nil {
+		t.Error("Expected ResetForNewHand to fail mid-hand")
+	}
+}
+
+func TestHandHistoryCanBeTurnedOff(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, HandHistory: -1}, 1000, 1000)
+	foldHands(t, game, 3)
+	if history := game.GetHandHistory(); len(history) != 0 {
+		t.Errorf("Expected no hands kept, got %d", len(history))
+	}
+}
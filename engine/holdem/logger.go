@@ -0,0 +1,83 @@
+package holdem
+
+import (
+	"io"
+	"log/slog"
+
+	"github.com/ljbink/ai-poker/engine/i18n"
+)
+
+// NewDiscardLogger returns a logger that drops every record.
+// Games and decision makers use it until a real logger is injected.
+func NewDiscardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// PhaseToString converts a game phase to a lowercase name suitable for logs
+func PhaseToString(phase GamePhase) string {
+	switch phase {
+	case PhasePreflop:
+		return "preflop"
+	case PhaseFlop:
+		return "flop"
+	case PhaseTurn:
+		return "turn"
+	case PhaseRiver:
+		return "river"
+	case PhaseShowdown:
+		return "showdown"
+	default:
+		return "unknown"
+	}
+}
+
+// PhaseName returns the translated name of a phase, e.g. "flop"
+func PhaseName(translator *i18n.Translator, phase GamePhase) string {
+	if phase < PhasePreflop || phase > PhaseShowdown {
+		return PhaseToString(phase)
+	}
+	return translator.T("phase." + PhaseToString(phase))
+}
+
+// SetLogger injects the structured logger used by the game.
+// Passing nil restores the discard logger.
+func (g *Game) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = NewDiscardLogger()
+	}
+	g.logger = logger
+}
+
+// GetLogger returns the structured logger used by the game
+func (g *Game) GetLogger() *slog.Logger {
+	if g.logger == nil {
+		return NewDiscardLogger()
+	}
+	return g.logger
+}
+
+// log returns the game logger decorated with the current hand context
+func (g *Game) log() *slog.Logger {
+	return g.GetLogger().With(
+		slog.String("table_id", g.config.TableID),
+		slog.Int("hand", g.handNumber),
+		slog.String("hand_id", g.handID),
+		slog.String("phase", PhaseToString(g.currentPhase)),
+	)
+}
+
+// actionAttrs returns the log attributes describing an action
+func (g *Game) actionAttrs(action Action) []any {
+	attrs := []any{
+		slog.Int("player_id", action.PlayerID),
+		slog.String("action", ActionTypeToString(action.Type)),
+		slog.Int("amount", action.Amount),
+	}
+	if action.Type == ActionRaise {
+		attrs = append(attrs, slog.Int("raise_to", action.RaiseTo))
+	}
+	if sit, err := g.GetPlayerSitByID(action.PlayerID); err == nil {
+		attrs = append(attrs, slog.Int("seat", sit))
+	}
+	return attrs
+}
@@ -0,0 +1,140 @@
+package holdem
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		record := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Invalid log line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestGameDefaultLogger(t *testing.T) {
+	game := NewGame(10, 20)
+	if game.GetLogger() == nil {
+		t.Fatal("Expected default logger")
+	}
+
+	// Zero-value games must not panic when logging
+	zero := &Game{}
+	if zero.GetLogger() == nil {
+		t.Fatal("Expected discard logger on zero-value game")
+	}
+	zero.TakeAction(Action{PlayerID: 1, Type: ActionCheck})
+
+	game.SetLogger(nil)
+	if game.GetLogger() == nil {
+		t.Error("Expected SetLogger(nil) to restore the discard logger")
+	}
+}
+
+func TestGameLogsActionsWithContext(t *testing.T) {
+	var buf bytes.Buffer
+	game := NewGame(10, 20)
+	game.SetLogger(newTestLogger(&buf))
+
+	player1 := NewPlayer(1, "Alice", 1000)
+	player2 := NewPlayer(2, "Bob", 1000)
+	game.PlayerSit(player1, 0)
+	game.PlayerSit(player2, 4)
+
+	if err := game.DealHoleCards(); err != nil {
+		t.Fatalf("Unexpected error dealing: %v", err)
+	}
+	game.TakeAction(NewRaise(2, 40))
+
+	records := decodeLogLines(t, &buf)
+
+	var actionRecord map[string]any
+	handStarted := false
+	for _, record := range records {
+		switch record["msg"] {
+		case "action taken":
+			actionRecord = record
+		case "hand started":
+			handStarted = true
+		}
+	}
+
+	if !handStarted {
+		t.Error("Expected a hand started record")
+	}
+	if actionRecord == nil {
+		t.Fatal("Expected an action taken record")
+	}
+	if actionRecord["hand"] != float64(1) {
+		t.Errorf("Expected hand 1, got %v", actionRecord["hand"])
+	}
+	if actionRecord["hand_id"] != game.GetHandID() || actionRecord["table_id"] != game.GetTableID() {
+		t.Errorf("Expected hand %s at table %s, got %v at %v", game.GetHandID(), game.GetTableID(), actionRecord["hand_id"], actionRecord["table_id"])
+	}
+	if actionRecord["seat"] != float64(4) {
+		t.Errorf("Expected seat 4, got %v", actionRecord["seat"])
+	}
+	if actionRecord["action"] != "Raise" || actionRecord["raise_to"] != float64(40) {
+		t.Errorf("Expected a raise to 40, got %v to %v", actionRecord["action"], actionRecord["raise_to"])
+	}
+	if actionRecord["phase"] != "preflop" {
+		t.Errorf("Expected phase preflop, got %v", actionRecord["phase"])
+	}
+}
+
+func TestGameLogsRejectedActions(t *testing.T) {
+	var buf bytes.Buffer
+	game := NewGame(10, 20)
+	game.SetLogger(newTestLogger(&buf))
+	game.currentPhase = PhaseShowdown
+
+	if err := game.TakeAction(Action{PlayerID: 1, Type: ActionCall, Amount: 20}); err == nil {
+		t.Fatal("Expected error for action during showdown")
+	}
+
+	found := false
+	for _, record := range decodeLogLines(t, &buf) {
+		if record["level"] == "WARN" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a warning record for the rejected action")
+	}
+}
+
+func TestPhaseToString(t *testing.T) {
+	tests := []struct {
+		phase    GamePhase
+		expected string
+	}{
+		{PhasePreflop, "preflop"},
+		{PhaseFlop, "flop"},
+		{PhaseTurn, "turn"},
+		{PhaseRiver, "river"},
+		{PhaseShowdown, "showdown"},
+		{GamePhase(99), "unknown"},
+	}
+
+	for _, test := range tests {
+		if result := PhaseToString(test.phase); result != test.expected {
+			t.Errorf("PhaseToString(%d) = %s, expected %s", test.phase, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package holdem
+
+import (
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/metrics"
+)
+
+// SetMetrics injects the metrics sink used by the game.
+// Passing nil disables instrumentation.
+func (g *Game) SetMetrics(m metrics.IMetrics) {
+	g.metrics = m
+}
+
+// getMetrics returns the game metrics sink, never nil
+func (g *Game) getMetrics() metrics.IMetrics {
+	if g.metrics == nil {
+		return metrics.NewNoop()
+	}
+	return g.metrics
+}
+
+// SetMetrics injects the metrics sink used to measure evaluator throughput.
+// Passing nil disables instrumentation.
+func (e *HandEvaluator) SetMetrics(m metrics.IMetrics) {
+	e.metrics = m
+}
+
+// observeEvaluation records a single hand evaluation started at start
+func (e *HandEvaluator) observeEvaluation(start time.Time) {
+	if e.metrics == nil {
+		return
+	}
+	e.metrics.IncCounter(metrics.HandEvaluationsTotal, nil, 1)
+	e.metrics.ObserveHistogram(metrics.HandEvaluationSeconds, nil, time.Since(start).Seconds())
+}
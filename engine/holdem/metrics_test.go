@@ -0,0 +1,69 @@
+package holdem
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/metrics"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestGameMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	game := NewGame(10, 20)
+	game.SetMetrics(registry)
+
+	game.PlayerSit(NewPlayer(1, "Alice", 1000), 0)
+	game.PlayerSit(NewPlayer(2, "Bob", 1000), 1)
+	game.DealHoleCards()
+	game.TakeAction(Action{PlayerID: 1, Type: ActionCall, Amount: 20})
+	game.TakeAction(Action{PlayerID: 2, Type: ActionCheck})
+	game.TakeAction(Action{PlayerID: 1, Type: ActionCall, Amount: 20})
+
+	if v := registry.CounterValue(metrics.HandsDealtTotal, nil); v != 1 {
+		t.Errorf("Expected 1 hand dealt, got %v", v)
+	}
+	if v := registry.CounterValue(metrics.ActionsTotal, metrics.Labels{"action": "Call"}); v != 2 {
+		t.Errorf("Expected 2 calls, got %v", v)
+	}
+
+	// Disabling metrics must not panic
+	game.SetMetrics(nil)
+	game.TakeAction(Action{PlayerID: 2, Type: ActionFold})
+}
+
+func TestEvaluatorMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	evaluator := NewHandEvaluator()
+	evaluator.SetMetrics(registry)
+
+	hole := []*poker.Card{
+		poker.NewCard(poker.SuitSpade, poker.RankAce),
+		poker.NewCard(poker.SuitHeart, poker.RankAce),
+	}
+	for i := 0; i < 3; i++ {
+		evaluator.EvaluateHand(hole, nil)
+	}
+
+	if v := registry.CounterValue(metrics.HandEvaluationsTotal, nil); v != 3 {
+		t.Errorf("Expected 3 evaluations, got %v", v)
+	}
+	if c := registry.HistogramCount(metrics.HandEvaluationSeconds, nil); c != 3 {
+		t.Errorf("Expected 3 timing observations, got %d", c)
+	}
+}
+
+func TestGameEvaluatorsReportToTheGameMetrics(t *testing.T) {
+	for _, variant := range []GameVariant{VariantHoldem, VariantOmaha} {
+		registry := metrics.NewRegistry()
+		game := NewGameWithConfig(GameConfig{SmallBlind: 10, BigBlind: 20, Variant: variant})
+		game.SetMetrics(registry)
+		game.PlayerSit(NewPlayer(1, "Alice", 1000), 0)
+		game.PlayerSit(NewPlayer(2, "Bob", 1000), 1)
+		game.DealHoleCards()
+
+		EvaluateAllShowdownHands(game)
+		if v := registry.CounterValue(metrics.HandEvaluationsTotal, nil); v != 2 {
+			t.Errorf("%s: expected 2 evaluations, got %v", variant, v)
+		}
+	}
+}
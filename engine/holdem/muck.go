@@ -0,0 +1,151 @@
+package holdem
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Reveal is what a player did with their cards at showdown
+type Reveal struct {
+	PlayerID int
+	Shown    bool // False when the player mucked
+}
+
+// ShowdownOrder returns the players at the last showdown in the order they
+// reveal: the last player to bet or raise on the river shows first, or the
+// first player after the button when the river was checked or never played,
+// then the rest clockwise. It is empty unless the hand went to showdown.
+func (g *Game) ShowdownOrder() []int {
+	if g.currentPhase != PhaseShowdown || g.handActive || len(g.players) == 0 {
+		return []int{}
+	}
+	first := (g.button + 1) % len(g.players)
+	if aggressor, ok := g.userActions.LastAggressor(PhaseRiver); ok {
+		for seat, player := range g.players {
+			if player != nil && player.GetID() == aggressor {
+				first = seat
+			}
+		}
+	}
+	order := []int{}
+	for i := 0; i < len(g.players); i++ {
+		if player := g.players[(first+i)%len(g.players)]; player != nil && !player.IsFolded() {
+			order = append(order, player.GetID())
+		}
+	}
+	return order
+}
+
+// Show turns a player's cards face up at showdown. Cards are public at
+// showdown unless mucked, so Show only records that, and when, the player showed.
+func (g *Game) Show(playerID int) error {
+	if err := g.checkReveal(playerID); err != nil {
+		return err
+	}
+	g.reveal(playerID, true)
+	return nil
+}
+
+// Muck lets a player who lost at showdown throw their cards away unseen, so
+// they stay hidden from spectators and opponents. A player whose river bet
+// was called must show.
+func (g *Game) Muck(playerID int) error {
+	if err := g.checkReveal(playerID); err != nil {
+		return err
+	}
+	if g.wonPot(playerID) {
+		return fmt.Errorf("player %d won a pot and must show", playerID)
+	}
+	if g.wasCalled(playerID) {
+		return fmt.Errorf("player %d was called on the river and must show", playerID)
+	}
+	g.reveal(playerID, false)
+	return nil
+}
+
+// checkReveal checks that the player is at showdown and has not shown or mucked yet
+func (g *Game) checkReveal(playerID int) error {
+	if g.currentPhase != PhaseShowdown || g.handActive {
+		return fmt.Errorf("cards can only be shown or mucked at showdown")
+	}
+	player, err := g.GetPlayerByID(playerID)
+	if err != nil {
+		return err
+	}
+	if player.IsFolded() {
+		return fmt.Errorf("player %d already folded", playerID)
+	}
+	for _, reveal := range g.reveals {
+		if reveal.PlayerID == playerID {
+			return fmt.Errorf("player %d already revealed their cards", playerID)
+		}
+	}
+	return nil
+}
+
+// reveal records a show or muck in the showdown and the hand's journal
+func (g *Game) reveal(playerID int, shown bool) {
+	g.reveals = append(g.reveals, Reveal{PlayerID: playerID, Shown: shown})
+	action := Action{PlayerID: playerID, Type: ActionMuck}
+	if shown {
+		action.Type = ActionShow
+	}
+	g.appendJournal(action, 0)
+	g.log().Debug("cards revealed", slog.Int("player_id", playerID), slog.Bool("shown", shown))
+}
+
+// GetReveals returns the shows and mucks of the last showdown in the order
+// they were made. Players who have not revealed yet are missing.
+func (g *Game) GetReveals() []Reveal {
+	reveals := make([]Reveal, len(g.reveals))
+	copy(reveals, g.reveals)
+	return reveals
+}
+
+// IsMucked reports whether the player mucked their cards this hand
+func (g *Game) IsMucked(playerID int) bool {
+	for _, reveal := range g.reveals {
+		if reveal.PlayerID == playerID {
+			return !reveal.Shown
+		}
+	}
+	return false
+}
+
+// LostShowdown reports whether the player went to showdown without winning
+// any pot
+func (g *Game) LostShowdown(playerID int) bool {
+	if g.currentPhase != PhaseShowdown || g.handActive {
+		return false
+	}
+	player, err := g.GetPlayerByID(playerID)
+	if err != nil || player.IsFolded() {
+		return false
+	}
+	return !g.wonPot(playerID)
+}
+
+// CanMuck reports whether the player may muck: they lost the showdown and
+// were not the one called on the river
+func (g *Game) CanMuck(playerID int) bool {
+	return g.LostShowdown(playerID) && !g.wasCalled(playerID)
+}
+
+// wasCalled reports whether the player made the last bet or raise on the
+// river, which the players still in called
+func (g *Game) wasCalled(playerID int) bool {
+	aggressor, ok := g.userActions.LastAggressor(PhaseRiver)
+	return ok && aggressor == playerID
+}
+
+// wonPot reports whether the player shares any pot of the last hand
+func (g *Game) wonPot(playerID int) bool {
+	for _, award := range g.awards {
+		for _, winner := range award.Winners {
+			if winner == playerID {
+				return true
+			}
+		}
+	}
+	return false
+}
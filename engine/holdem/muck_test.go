@@ -0,0 +1,137 @@
+package holdem
+
+import "testing"
+
+// playShowdown plays a three-way all-in to showdown and returns a player who won nothing
+func playShowdown(t *testing.T) (*Game, int) {
+	t.Helper()
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 100, 100, 100)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionAllIn, 100)
+	mustAct(t, game, 2, ActionAllIn, 95)
+	mustAct(t, game, 3, ActionCall, 90)
+	game.DealFlop()
+	game.DealTurn()
+	game.DealRiver()
+	if _, err := game.AwardPot(); err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	for id := 1; id <= 3; id++ {
+		if game.LostShowdown(id) {
+			return game, id
+		}
+	}
+	t.Skip("Every player shared the pot")
+	return nil, 0
+}
+
+func TestMuckHidesLosingHand(t *testing.T) {
+	game, loser := playShowdown(t)
+	if err := game.Muck(loser); err != nil {
+		t.Fatalf("Muck failed: %v", err)
+	}
+	if !game.IsMucked(loser) {
+		t.Error("Expected the loser to be recorded as mucked")
+	}
+	for _, seat := range game.SpectatorView().Seats {
+		shown := len(seat.HoleCards) > 0
+		if seat.PlayerID == loser && shown {
+			t.Error("Expected the mucked hand to stay hidden")
+		}
+		if seat.PlayerID != loser && !shown {
+			t.Errorf("Expected player %d to show at showdown", seat.PlayerID)
+		}
+	}
+	for _, seat := range game.PlayerView(loser).Seats {
+		if seat.PlayerID == loser && len(seat.HoleCards) == 0 {
+			t.Error("Expected players to still see their own mucked cards")
+		}
+	}
+}
+
+func TestMuckRejectsWinnersAndLiveHands(t *testing.T) {
+	game, loser := playShowdown(t)
+	winner := game.GetPotAwards()[0].Winners[0]
+	if err := game.Muck(winner); err == nil {
+		t.Error("Expected a pot winner not to be able to muck")
+	}
+	if game.LostShowdown(winner) {
+		t.Error("Expected the winner not to have lost the showdown")
+	}
+
+	live := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 100, 100)
+	live.StartHand(0)
+	if err := live.Muck(1); err == nil {
+		t.Error("Expected mucking to be refused during a hand")
+	}
+	if game.IsMucked(loser) {
+		t.Error("Expected no muck without asking for one")
+	}
+}
+
+func TestShowdownOrder(t *testing.T) {
+	game, _ := playShowdown(t)
+	if order := game.ShowdownOrder(); len(order) != 3 || order[0] != 2 || order[1] != 3 || order[2] != 1 {
+		t.Errorf("Expected the first player after the button to show first without a river bet, got %v", order)
+	}
+
+	// Player 3 bets the river and is called by both
+	game = newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 8}, 1000, 1000, 1000)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionCall, 10)
+	mustAct(t, game, 2, ActionCall, 5)
+	mustAct(t, game, 3, ActionCheck, 0)
+	for _, deal := range []func() error{game.DealFlop, game.DealTurn} {
+		deal()
+		for id := 2; id <= 4; id++ {
+			mustAct(t, game, (id-1)%3+1, ActionCheck, 0)
+		}
+	}
+	game.DealRiver()
+	mustAct(t, game, 2, ActionCheck, 0)
+	mustAct(t, game, 3, ActionRaise, 50)
+	mustAct(t, game, 1, ActionCall, 50)
+	mustAct(t, game, 2, ActionCall, 50)
+	if _, err := game.AwardPot(); err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+
+	order := game.ShowdownOrder()
+	if len(order) != 3 || order[0] != 3 || order[1] != 1 || order[2] != 2 {
+		t.Fatalf("Expected the river bettor to show first, then clockwise, got %v", order)
+	}
+	if game.CanMuck(3) || game.Muck(3) == nil {
+		t.Error("Expected the called river bettor to have to show")
+	}
+	for _, id := range order {
+		reveal := game.Show
+		if game.CanMuck(id) {
+			reveal = game.Muck
+		}
+		if err := reveal(id); err != nil {
+			t.Fatalf("Player %d revealing failed: %v", id, err)
+		}
+	}
+	if err := game.Show(order[0]); err == nil {
+		t.Error("Expected a player to reveal only once")
+	}
+	reveals := game.GetReveals()
+	if len(reveals) != 3 || reveals[0] != (Reveal{PlayerID: 3, Shown: true}) {
+		t.Fatalf("Expected the reveals in showdown order, got %+v", reveals)
+	}
+
+	// Replays show and muck in the same order
+	replay, err := NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("NewReplay failed: %v", err)
+	}
+	replayed, err := replay.Run()
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	for i, reveal := range replayed.GetReveals() {
+		if reveal != reveals[i] {
+			t.Errorf("Reveal %d replayed as %+v, expected %+v", i, reveal, reveals[i])
+		}
+	}
+}
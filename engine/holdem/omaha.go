@@ -0,0 +1,65 @@
+package holdem
+
+import (
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// OmahaVariant is pot-limit Omaha: four hole cards, of which exactly two
+// play, dealt on a Hold'em board
+type OmahaVariant struct {
+	HoldemVariant
+}
+
+func (OmahaVariant) Name() GameVariant { return VariantOmaha }
+
+func (OmahaVariant) String() string { return "Pot-Limit Omaha" }
+
+func (OmahaVariant) HoleCards() int { return 4 }
+
+func (OmahaVariant) BettingStructure() BettingStructure { return PotLimit }
+
+func (OmahaVariant) NewEvaluator() IHandEvaluator { return NewOmahaEvaluator() }
+
+// OmahaEvaluator scores Omaha hands, which must use exactly two hole cards
+// and three community cards
+type OmahaEvaluator struct {
+	*HandEvaluator
+}
+
+// NewOmahaEvaluator creates a new Omaha hand evaluator
+func NewOmahaEvaluator() *OmahaEvaluator {
+	return &OmahaEvaluator{HandEvaluator: NewHandEvaluator()}
+}
+
+// EvaluateHand evaluates the best hand made of two hole cards and three
+// community cards. Before the flop only the hole cards are scored.
+func (e *OmahaEvaluator) EvaluateHand(holeCards []*poker.Card, communityCards poker.Cards) *HandResult {
+	if len(communityCards) < 3 || len(holeCards) < 2 {
+		return e.HandEvaluator.EvaluateHand(holeCards, nil)
+	}
+	if e.metrics != nil {
+		defer e.observeEvaluation(time.Now())
+	}
+
+	var best, score handScore
+	var five [5]*poker.Card
+	first := true
+	for h1 := 0; h1 < len(holeCards); h1++ {
+		for h2 := h1 + 1; h2 < len(holeCards); h2++ {
+			for b1 := 0; b1 < len(communityCards); b1++ {
+				for b2 := b1 + 1; b2 < len(communityCards); b2++ {
+					for b3 := b2 + 1; b3 < len(communityCards); b3++ {
+						five = [5]*poker.Card{holeCards[h1], holeCards[h2], communityCards[b1], communityCards[b2], communityCards[b3]}
+						e.scoreFive(five, &score)
+						if first || e.compareScores(&score, &best) > 0 {
+							best, first = score, false
+						}
+					}
+				}
+			}
+		}
+	}
+	return best.result().attribute(holeCards)
+}
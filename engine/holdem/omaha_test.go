@@ -0,0 +1,77 @@
+package holdem
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func mustParse(t *testing.T, s string) poker.Cards {
+	t.Helper()
+	cards, err := poker.ParseCards(s)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing %q: %v", s, err)
+	}
+	return cards
+}
+
+func TestOmahaUsesExactlyTwoHoleCards(t *testing.T) {
+	evaluator := NewOmahaEvaluator()
+
+	// Four hearts on the board make a Hold'em flush but Omaha needs two hole hearts
+	result := evaluator.EvaluateHand(mustParse(t, "AhKsQd2c"), mustParse(t, "3h7h9hJh5s"))
+	if result.Rank == Flush {
+		t.Errorf("Expected no flush with a single heart in hand, got %s", result.Description)
+	}
+	result = evaluator.EvaluateHand(mustParse(t, "AhKhQd2c"), mustParse(t, "3h7h9hJs5s"))
+	if result.Rank != Flush {
+		t.Errorf("Expected a flush with two hole hearts, got %s", result.Description)
+	}
+
+	// Quads in hand only play as a pair of them
+	result = evaluator.EvaluateHand(mustParse(t, "AsAhAdAc"), mustParse(t, "2s7h9dJc4s"))
+	if result.Rank != OnePair {
+		t.Errorf("Expected one pair from four aces, got %s", result.Description)
+	}
+}
+
+func TestOmahaDealsFourHoleCards(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Variant: VariantOmaha}, 1000, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	for _, player := range game.GetAllPlayers() {
+		if len(player.GetHandCards()) != 4 {
+			t.Errorf("Expected player %d to hold 4 cards, got %d", player.GetID(), len(player.GetHandCards()))
+		}
+	}
+	if view := game.SpectatorView(); view.Variant != VariantOmaha {
+		t.Errorf("Expected the table view to report Omaha, got %q", view.Variant)
+	}
+}
+
+func TestPotLimitCapsRaises(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Variant: VariantOmaha}, 1000, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	validator := NewActionValidator()
+	player := game.GetCurrentPlayer()
+
+	// Calling 10 makes the pot 25, so the most the button can put in is 35
+	if maxRaise := validator.GetMaxRaiseAmount(game, player); maxRaise != 35 {
+		t.Fatalf("Expected a pot-sized raise of 35, got %d", maxRaise)
+	}
+	for _, actionType := range validator.GetAvailableActions(game, player) {
+		if actionType == ActionAllIn {
+			t.Error("Expected all-in to be unavailable above the pot limit")
+		}
+	}
+	if err := game.TakeAction(NewRaise(player.GetID(), 36)); err == nil {
+		t.Error("Expected a raise over the pot to be rejected")
+	}
+	mustAct(t, game, player.GetID(), ActionRaise, 35)
+	if game.GetCurrentBet() != 35 {
+		t.Errorf("Expected the bet to be 35, got %d", game.GetCurrentBet())
+	}
+}
@@ -0,0 +1,57 @@
+package holdem
+
+// ConfigPreset is a named table template to start a game from
+type ConfigPreset struct {
+	Name        string     `json:"name"`        // Short name, e.g. "micro-cash"
+	Title       string     `json:"title"`       // Shown in menus
+	Description string     `json:"description"` // One line on what the table plays like
+	Config      GameConfig `json:"config"`
+	BuyInBB     int        `json:"buy_in_bb"` // Usual buy-in in big blinds
+}
+
+// BuyIn returns the usual buy-in in chips
+func (p ConfigPreset) BuyIn() int {
+	return p.BuyInBB * p.Config.BigBlind
+}
+
+// configPresets are the built-in table templates, in menu order
+var configPresets = []ConfigPreset{
+	{
+		Name:        "micro-cash",
+		Title:       "Micro Cash 5/10",
+		Description: "6-max cash game buying in for 40 to 100 big blinds",
+		Config:      GameConfig{SmallBlind: 5, BigBlind: 10, MaxSeats: 6, MinBuyInBB: 40, MaxBuyInBB: 100},
+		BuyInBB:     100,
+	},
+	{
+		Name:        "turbo-sng",
+		Title:       "Turbo Sit & Go",
+		Description: "6-max table starting everyone on 75 big blinds at 10/20",
+		Config:      GameConfig{SmallBlind: 10, BigBlind: 20, MaxSeats: 6, MinBuyInBB: 75, MaxBuyInBB: 75},
+		BuyInBB:     75,
+	},
+	{
+		Name:        "deepstack",
+		Title:       "Deepstack",
+		Description: "9-handed cash game buying in for 100 to 300 big blinds",
+		Config:      GameConfig{SmallBlind: 5, BigBlind: 10, MaxSeats: 9, MinBuyInBB: 100, MaxBuyInBB: 300},
+		BuyInBB:     250,
+	},
+}
+
+// ConfigPresets returns the built-in table templates
+func ConfigPresets() []ConfigPreset {
+	presets := make([]ConfigPreset, len(configPresets))
+	copy(presets, configPresets)
+	return presets
+}
+
+// ConfigPresetNamed returns the built-in template with the given name
+func ConfigPresetNamed(name string) (ConfigPreset, bool) {
+	for _, preset := range configPresets {
+		if preset.Name == name {
+			return preset, true
+		}
+	}
+	return ConfigPreset{}, false
+}
@@ -0,0 +1,61 @@
+package holdem
+
+import "slices"
+
+// potLimitPresets are the raise sizes offered in pot-limit games, as shares
+// of the pot after the call
+var potLimitPresets = []float64{0.5, 0.75, 1}
+
+// RaiseSizes are the raises a player may choose from, in chips put in with
+// the call included, like Constraints.MinRaise. No-limit games allow any
+// amount from Min to Max. Pot-limit games offer discrete Sizes: the minimum
+// raise, half, three quarters and all of the pot. UIs and bot protocols
+// present these instead of working the betting rules out themselves.
+type RaiseSizes struct {
+	Min   int
+	Max   int
+	Sizes []int // Ascending, from Min to Max; empty when any amount in between is offered
+}
+
+// LegalRaiseSizes returns the raises open to a player at their turn, the
+// zero value when they cannot raise
+func LegalRaiseSizes(game *Game, player IPlayer) RaiseSizes {
+	c := ValidatorConstraints(game, player)
+	if !slices.Contains(c.Actions, ActionRaise) {
+		return RaiseSizes{}
+	}
+	sizes := RaiseSizes{Min: c.MinRaise, Max: max(c.MaxRaise, c.MinRaise)}
+	if game.GetVariant().BettingStructure() != PotLimit {
+		return sizes
+	}
+	sizes.Sizes = []int{sizes.Min}
+	for _, share := range potLimitPresets {
+		amount := c.Call + int(share*float64(game.GetPot()+c.Call))
+		amount = min(max(amount, sizes.Min), sizes.Max)
+		if amount > sizes.Sizes[len(sizes.Sizes)-1] {
+			sizes.Sizes = append(sizes.Sizes, amount)
+		}
+	}
+	if last := sizes.Sizes[len(sizes.Sizes)-1]; last < sizes.Max {
+		sizes.Sizes = append(sizes.Sizes, sizes.Max)
+	}
+	return sizes
+}
+
+// Continuous reports whether any amount from Min to Max is offered
+func (s RaiseSizes) Continuous() bool {
+	return len(s.Sizes) == 0
+}
+
+// Step moves a chosen amount delta options up or down: by delta times
+// increment within the range, or to the neighbouring discrete size
+func (s RaiseSizes) Step(amount, delta, increment int) int {
+	if s.Continuous() {
+		return min(max(amount+delta*increment, s.Min), s.Max)
+	}
+	i, found := slices.BinarySearch(s.Sizes, amount)
+	if !found && delta > 0 {
+		i-- // Between two sizes, the next one up is at i
+	}
+	return s.Sizes[min(max(i+delta, 0), len(s.Sizes)-1)]
+}
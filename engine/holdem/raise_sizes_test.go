@@ -0,0 +1,53 @@
+package holdem
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLegalRaiseSizesNoLimit(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	sizes := LegalRaiseSizes(game, game.GetCurrentPlayer())
+	if !sizes.Continuous() || sizes.Min != 20 || sizes.Max != 1000 {
+		t.Fatalf("Expected any raise from 20 to 1000, got %+v", sizes)
+	}
+	if got := sizes.Step(20, 1, 10); got != 30 {
+		t.Errorf("Expected a step up by the increment to 30, got %d", got)
+	}
+	if got := sizes.Step(20, -1, 10); got != 20 {
+		t.Errorf("Expected the minimum raise to stay, got %d", got)
+	}
+}
+
+func TestLegalRaiseSizesPotLimit(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Variant: VariantOmaha}, 1000, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+
+	// Calling 10 makes a pot of 25: half, three quarters and all of it on top
+	utg := game.GetCurrentPlayer()
+	sizes := LegalRaiseSizes(game, utg)
+	if want := []int{20, 22, 28, 35}; !slices.Equal(sizes.Sizes, want) || sizes.Min != 20 || sizes.Max != 35 {
+		t.Fatalf("Expected sizes %v up to 35, got %+v", want, sizes)
+	}
+	for _, step := range []struct{ amount, delta, want int }{
+		{20, 1, 22},
+		{25, 1, 28},
+		{25, -1, 22},
+		{35, 1, 35},
+		{20, -1, 20},
+	} {
+		if got := sizes.Step(step.amount, step.delta, 10); got != step.want {
+			t.Errorf("Step(%d, %d): expected %d, got %d", step.amount, step.delta, step.want, got)
+		}
+	}
+
+	utg.Fold()
+	if sizes := LegalRaiseSizes(game, utg); sizes.Max != 0 || !sizes.Continuous() {
+		t.Errorf("Expected no raises for a folded player, got %+v", sizes)
+	}
+}
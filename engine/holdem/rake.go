@@ -0,0 +1,72 @@
+package holdem
+
+// GetRake returns the chips the house took from the pots of the last hand
+func (g *Game) GetRake() int {
+	rake := 0
+	for _, award := range g.awards {
+		rake += award.Rake
+	}
+	return rake
+}
+
+// rakeDue returns the rake owed on the chips that were contested, after
+// returning any uncalled bet
+func (g *Game) rakeDue() int {
+	if g.config.RakePercent <= 0 {
+		return 0
+	}
+	if g.config.NoFlopNoDrop && len(g.communityCards) == 0 {
+		return 0
+	}
+	rake := int(float64(g.GetPot()-g.uncalledBet()) * g.config.RakePercent / 100)
+	if g.config.RakeCap > 0 {
+		rake = min(rake, g.config.RakeCap)
+	}
+	return rake
+}
+
+// uncalledBet returns the chips the biggest bettor put in that nobody matched
+func (g *Game) uncalledBet() int {
+	top, second := -1, 0
+	for i, player := range g.players {
+		if player == nil {
+			continue
+		}
+		total := g.stake(i)
+		switch {
+		case top < 0 || total > g.stake(top):
+			if top >= 0 {
+				second = g.stake(top)
+			}
+			top = i
+		case total > second:
+			second = total
+		}
+	}
+	if top < 0 || g.players[top].IsFolded() {
+		return 0
+	}
+	return g.stake(top) - second
+}
+
+// takeRake deducts the rake from the pots, main pot first, and returns the
+// total taken. The uncalled bet at the top of the last pot is never raked.
+func (g *Game) takeRake(awards []PotAward) int {
+	due := g.rakeDue()
+	rake := due
+	uncalled := g.uncalledBet()
+	for i := range awards {
+		if rake == 0 {
+			break
+		}
+		available := awards[i].Amount
+		if i == len(awards)-1 {
+			available -= uncalled
+		}
+		taken := min(rake, max(available, 0))
+		awards[i].Amount -= taken
+		awards[i].Rake = taken
+		rake -= taken
+	}
+	return due - rake
+}
@@ -0,0 +1,76 @@
+package holdem
+
+import "testing"
+
+func TestRakeSkipsUncalledBets(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, RakePercent: 5, RakeCap: 30}, 1000, 1000, 1000)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionCall, 10)
+	mustAct(t, game, 2, ActionCall, 5)
+	mustAct(t, game, 3, ActionCheck, 0)
+	game.DealFlop()
+	mustAct(t, game, 2, ActionRaise, 100)
+	mustAct(t, game, 3, ActionCall, 100)
+	mustAct(t, game, 1, ActionRaise, 600)
+	mustAct(t, game, 2, ActionFold, 0)
+	mustAct(t, game, 3, ActionFold, 0)
+
+	// 330 was contested, the 500 raise went uncalled: 5% is 16
+	awards, err := game.AwardPot()
+	if err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	if game.GetRake() != 16 || chipsOf(game, 1) != 1000+220-16 {
+		t.Errorf("Expected 16 rake and player 1 at 1204, got %d and %d", game.GetRake(), chipsOf(game, 1))
+	}
+	total := 0
+	for _, award := range awards {
+		total += award.Amount + award.Rake
+	}
+	if total != 830 {
+		t.Errorf("Expected pots and rake to add up to 830, got %d", total)
+	}
+	if log := game.GetHandActionLog(); log[len(log)-1].Action.Type != ActionSystemRake || log[len(log)-1].Action.Amount != 16 {
+		t.Errorf("Expected the rake to be journaled last, got %s", ActionTypeToString(log[len(log)-1].Action.Type))
+	}
+
+	replay, err := NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("NewReplay failed: %v", err)
+	}
+	if _, err := replay.Run(); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+}
+
+func TestRakeCapAndNoFlopNoDrop(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 50, BigBlind: 100, RakePercent: 10, RakeCap: 15, NoFlopNoDrop: true}, 1000, 1000)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionCall, 50)
+	mustAct(t, game, 2, ActionRaise, 200)
+	mustAct(t, game, 1, ActionFold, 0)
+	game.AwardPot()
+	if game.GetRake() != 0 {
+		t.Errorf("Expected no rake before the flop, got %d", game.GetRake())
+	}
+
+	game.StartHand(1)
+	mustAct(t, game, 2, ActionCall, 50)
+	mustAct(t, game, 1, ActionCheck, 0)
+	game.DealFlop()
+	mustAct(t, game, 1, ActionCheck, 0)
+	mustAct(t, game, 2, ActionCheck, 0)
+	game.DealTurn()
+	mustAct(t, game, 1, ActionCheck, 0)
+	mustAct(t, game, 2, ActionCheck, 0)
+	game.DealRiver()
+	mustAct(t, game, 1, ActionCheck, 0)
+	mustAct(t, game, 2, ActionCheck, 0)
+	game.AwardPot()
+	if game.GetRake() != 15 {
+		t.Errorf("Expected 10%% of the 200 pot capped at 15, got %d", game.GetRake())
+	}
+	if chipsOf(game, 1)+chipsOf(game, 2) != 1985 {
+		t.Errorf("Expected 15 chips to leave the table, got %d left", chipsOf(game, 1)+chipsOf(game, 2))
+	}
+}
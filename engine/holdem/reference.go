@@ -0,0 +1,327 @@
+package holdem
+
+import (
+	"sort"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/internal/combin"
+)
+
+// ReferenceEvaluator is the straightforward evaluator HandEvaluator was
+// optimized from: it builds every 5-card combination as a slice and runs
+// one check per hand rank on it. It is far slower and allocates heavily,
+// but each rule is easy to verify by reading, so tests use it as the
+// oracle the fast evaluators must agree with.
+type ReferenceEvaluator struct {
+	base HandEvaluator // Shared rank and kicker helpers
+}
+
+// NewReferenceEvaluator creates a new reference evaluator
+func NewReferenceEvaluator() *ReferenceEvaluator {
+	return &ReferenceEvaluator{}
+}
+
+// EvaluateHand evaluates a player's best 5-card hand from hole cards and community cards
+func (r *ReferenceEvaluator) EvaluateHand(holeCards []*poker.Card, communityCards poker.Cards) *HandResult {
+	if len(holeCards) < 2 {
+		return &HandResult{
+			Rank:        HighCard,
+			Description: "No cards",
+			Value:       0,
+			Cards:       poker.Cards{},
+			Kickers:     []poker.Rank{},
+		}
+	}
+
+	// Combine hole cards and community cards, leaving out nil cards
+	validCards := poker.Cards{}
+	for _, card := range append(append(poker.Cards{}, holeCards...), communityCards...) {
+		if card != nil {
+			validCards.Append(card)
+		}
+	}
+
+	if len(validCards) < 2 {
+		return &HandResult{
+			Rank:        HighCard,
+			Description: "Insufficient cards",
+			Value:       0,
+			Cards:       validCards,
+			Kickers:     []poker.Rank{},
+		}
+	}
+	if len(validCards) < 5 {
+		return r.base.evaluatePartialHand(validCards)
+	}
+
+	bestHand := &HandResult{
+		Rank:  HighCard,
+		Value: 0,
+	}
+	r.generateCombinations(validCards, 5, func(combination poker.Cards) {
+		hand := r.evaluateFiveCardHand(combination)
+		if r.CompareHands(hand, bestHand) > 0 {
+			bestHand = hand
+		}
+	})
+	return bestHand
+}
+
+// CompareHands compares two hand results like HandEvaluator.CompareHands
+func (r *ReferenceEvaluator) CompareHands(hand1, hand2 *HandResult) int {
+	return r.base.CompareHands(hand1, hand2)
+}
+
+// evaluateFiveCardHand evaluates exactly 5 cards
+func (r *ReferenceEvaluator) evaluateFiveCardHand(cards poker.Cards) *HandResult {
+	// Sort cards by rank (descending)
+	sortedCards := make(poker.Cards, len(cards))
+	copy(sortedCards, cards)
+	sort.Slice(sortedCards, func(i, j int) bool {
+		return r.base.rankValue(sortedCards[i].Rank) > r.base.rankValue(sortedCards[j].Rank)
+	})
+
+	// Check for each hand type (highest to lowest)
+	if result := r.checkRoyalFlush(sortedCards); result != nil {
+		return result
+	}
+	if result := r.checkStraightFlush(sortedCards); result != nil {
+		return result
+	}
+	if result := r.base.checkFourOfAKind(sortedCards); result != nil {
+		return result
+	}
+	if result := r.checkFullHouse(sortedCards); result != nil {
+		return result
+	}
+	if result := r.checkFlush(sortedCards); result != nil {
+		return result
+	}
+	if result := r.checkStraight(sortedCards); result != nil {
+		return result
+	}
+	if result := r.base.checkThreeOfAKind(sortedCards); result != nil {
+		return result
+	}
+	if result := r.checkTwoPair(sortedCards); result != nil {
+		return result
+	}
+	if result := r.base.checkOnePair(sortedCards); result != nil {
+		return result
+	}
+
+	return r.base.checkHighCard(sortedCards)
+}
+
+// Hand checking functions
+func (r *ReferenceEvaluator) checkRoyalFlush(cards poker.Cards) *HandResult {
+	if !r.base.isFlush(cards) {
+		return nil
+	}
+	if !r.isRoyalStraight(cards) {
+		return nil
+	}
+
+	return &HandResult{
+		Rank:        RoyalFlush,
+		Description: "Royal Flush",
+		Value:       9000000,
+		Cards:       cards,
+		Kickers:     []poker.Rank{},
+	}
+}
+
+func (r *ReferenceEvaluator) checkStraightFlush(cards poker.Cards) *HandResult {
+	if !r.base.isFlush(cards) {
+		return nil
+	}
+	highCard := r.getStraightHighCard(cards)
+	if highCard == poker.RankNone {
+		return nil
+	}
+
+	return &HandResult{
+		Rank:        StraightFlush,
+		Description: "Straight Flush",
+		Value:       8000000 + r.base.rankValue(highCard),
+		Cards:       cards,
+		Kickers:     []poker.Rank{highCard},
+	}
+}
+
+func (r *ReferenceEvaluator) checkFullHouse(cards poker.Cards) *HandResult {
+	rankCounts := r.base.getRankCounts(cards)
+
+	var tripRank, pairRank poker.Rank
+
+	for rank, count := range rankCounts {
+		if count == 3 {
+			tripRank = rank
+		} else if count == 2 {
+			pairRank = rank
+		}
+	}
+
+	if tripRank == poker.RankNone || pairRank == poker.RankNone {
+		return nil
+	}
+
+	return &HandResult{
+		Rank:        FullHouse,
+		Description: "Full House",
+		Value:       6000000 + r.base.rankValue(tripRank)*1000 + r.base.rankValue(pairRank),
+		Cards:       cards,
+		Kickers:     []poker.Rank{tripRank, pairRank},
+	}
+}
+
+func (r *ReferenceEvaluator) checkFlush(cards poker.Cards) *HandResult {
+	if !r.base.isFlush(cards) {
+		return nil
+	}
+
+	// Get all ranks for kickers
+	var kickers []poker.Rank
+	for _, card := range cards {
+		kickers = append(kickers, card.Rank)
+	}
+
+	// Sort kickers descending
+	sort.Slice(kickers, func(i, j int) bool {
+		return r.base.rankValue(kickers[i]) > r.base.rankValue(kickers[j])
+	})
+
+	value := 5000000
+	for i, rank := range kickers {
+		value += r.base.rankValue(rank) * (1000 / (i + 1))
+	}
+
+	return &HandResult{
+		Rank:        Flush,
+		Description: "Flush",
+		Value:       value,
+		Cards:       cards,
+		Kickers:     kickers,
+	}
+}
+
+func (r *ReferenceEvaluator) checkStraight(cards poker.Cards) *HandResult {
+	highCard := r.getStraightHighCard(cards)
+	if highCard == poker.RankNone {
+		return nil
+	}
+
+	return &HandResult{
+		Rank:        Straight,
+		Description: "Straight",
+		Value:       4000000 + r.base.rankValue(highCard),
+		Cards:       cards,
+		Kickers:     []poker.Rank{highCard},
+	}
+}
+
+func (r *ReferenceEvaluator) checkTwoPair(cards poker.Cards) *HandResult {
+	rankCounts := r.base.getRankCounts(cards)
+
+	var pairs []poker.Rank
+	var kickers []poker.Rank
+
+	for rank, count := range rankCounts {
+		if count == 2 {
+			pairs = append(pairs, rank)
+		} else if count >= 1 {
+			for i := 0; i < count; i++ {
+				kickers = append(kickers, rank)
+			}
+		}
+	}
+
+	if len(pairs) < 2 {
+		return nil
+	}
+
+	// Sort pairs descending
+	sort.Slice(pairs, func(i, j int) bool {
+		return r.base.rankValue(pairs[i]) > r.base.rankValue(pairs[j])
+	})
+
+	// Sort kickers descending
+	sort.Slice(kickers, func(i, j int) bool {
+		return r.base.rankValue(kickers[i]) > r.base.rankValue(kickers[j])
+	})
+
+	value := 2000000 + r.base.rankValue(pairs[0])*1000 + r.base.rankValue(pairs[1])*100
+	if len(kickers) > 0 {
+		value += r.base.rankValue(kickers[0])
+	}
+
+	allKickers := pairs
+	allKickers = append(allKickers, kickers...)
+
+	return &HandResult{
+		Rank:        TwoPair,
+		Description: "Two Pair",
+		Value:       value,
+		Cards:       cards,
+		Kickers:     allKickers,
+	}
+}
+
+func (r *ReferenceEvaluator) isRoyalStraight(cards poker.Cards) bool {
+	ranks := []poker.Rank{poker.RankAce, poker.RankKing, poker.RankQueen, poker.RankJack, poker.RankTen}
+	rankSet := make(map[poker.Rank]bool)
+	for _, card := range cards {
+		rankSet[card.Rank] = true
+	}
+	for _, rank := range ranks {
+		if !rankSet[rank] {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *ReferenceEvaluator) getStraightHighCard(cards poker.Cards) poker.Rank {
+	if len(cards) < 5 {
+		return poker.RankNone
+	}
+
+	ranks := make([]int, 0)
+	rankSet := make(map[int]bool)
+
+	for _, card := range cards {
+		rank := r.base.rankValue(card.Rank)
+		if !rankSet[rank] {
+			ranks = append(ranks, rank)
+			rankSet[rank] = true
+		}
+	}
+
+	sort.Ints(ranks)
+
+	// Check for regular straight
+	if len(ranks) >= 5 {
+		for i := len(ranks) - 5; i >= 0; i-- {
+			if ranks[i+4]-ranks[i] == 4 {
+				return r.base.valueToRank(ranks[i+4])
+			}
+		}
+	}
+
+	// Check for A-2-3-4-5 straight (wheel)
+	if rankSet[14] && rankSet[2] && rankSet[3] && rankSet[4] && rankSet[5] {
+		return poker.RankFive // 5-high straight
+	}
+
+	return poker.RankNone
+}
+
+func (r *ReferenceEvaluator) generateCombinations(cards poker.Cards, k int, callback func(poker.Cards)) {
+	combin.Combinations(len(cards), k, func(indices []int) {
+		combination := make(poker.Cards, k)
+		for i, idx := range indices {
+			combination[i] = cards[idx]
+		}
+		callback(combination)
+	})
+}
@@ -0,0 +1,131 @@
+package holdem
+
+import (
+	"flag"
+	"math/rand"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// Run the equivalence test over more hands with e.g.
+// go test ./engine/holdem/ -run Reference -reference-hands 3000000
+var referenceHands = flag.Int("reference-hands", 30000, "random hands compared against the reference evaluator")
+
+// referenceCount returns how many random hands a property test compares
+func referenceCount() int {
+	if testing.Short() {
+		return min(*referenceHands, 3000)
+	}
+	return *referenceHands
+}
+
+// sameResult reports whether two results score and describe a hand alike
+func sameResult(a, b *HandResult) bool {
+	if a.Rank != b.Rank || a.Value != b.Value || a.Description != b.Description || len(a.Kickers) != len(b.Kickers) || len(a.Cards) != len(b.Cards) {
+		return false
+	}
+	for i := range a.Kickers {
+		if a.Kickers[i] != b.Kickers[i] {
+			return false
+		}
+	}
+	for i := range a.Cards {
+		if a.Cards[i].Rank != b.Cards[i].Rank {
+			return false
+		}
+	}
+	return true
+}
+
+func TestReferenceEvaluatorKnownHands(t *testing.T) {
+	reference := NewReferenceEvaluator()
+	tests := []struct {
+		hole, board string
+		rank        HandRank
+	}{
+		{"AhKh", "QhJhTh2c3d", RoyalFlush},
+		{"5s4s", "3s2sAs", StraightFlush},
+		{"9c9d", "9h9s2c", FourOfAKind},
+		{"KcKd", "Kh2s2c7d", FullHouse},
+		{"2h7h", "9hJhQhAs", Flush},
+		{"Ac2d", "3h4s5c", Straight},
+		{"7c7d", "7hKs2c", ThreeOfAKind},
+		{"QcQd", "4h4sAc", TwoPair},
+		{"JcJd", "4h5s9c", OnePair},
+		{"Ac3d", "5h7s9c", HighCard},
+	}
+	for _, test := range tests {
+		hole, err := poker.ParseCards(test.hole)
+		if err != nil {
+			t.Fatal(err)
+		}
+		board, err := poker.ParseCards(test.board)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := reference.EvaluateHand(hole, board); got.Rank != test.rank {
+			t.Errorf("%s on %s: expected %s, got %s", test.hole, test.board, HandRankToString(test.rank), got.Description)
+		}
+	}
+}
+
+func TestEvaluatorMatchesReference(t *testing.T) {
+	fast, reference := NewHandEvaluator(), NewReferenceEvaluator()
+	rng := rand.New(rand.NewSource(1))
+	deck := standardDeck()
+	hands := referenceCount()
+	for i := 0; i < hands; i++ {
+		rng.Shuffle(len(deck), func(a, b int) { deck[a], deck[b] = deck[b], deck[a] })
+		size := 5 + i%3 // Flop, turn and river
+		want := reference.EvaluateHand(deck[:2], deck[2:size])
+		got := fast.EvaluateHand(deck[:2], deck[2:size])
+		if !sameResult(got, want) {
+			t.Fatalf("%v on %v: got %s %d %v, reference %s %d %v",
+				deck[:2], deck[2:size], got.Description, got.Value, got.Kickers, want.Description, want.Value, want.Kickers)
+		}
+	}
+}
+
+func TestOmahaEvaluatorMatchesReference(t *testing.T) {
+	omaha, reference := NewOmahaEvaluator(), NewReferenceEvaluator()
+	rng := rand.New(rand.NewSource(2))
+	deck := standardDeck()
+	hands := max(referenceCount()/20, 1) // Sixty reference evaluations each
+	for i := 0; i < hands; i++ {
+		rng.Shuffle(len(deck), func(a, b int) { deck[a], deck[b] = deck[b], deck[a] })
+		hole, board := deck[:4], deck[4:7+i%3]
+
+		// Best of every two hole cards with every three board cards
+		var want *HandResult
+		for h1 := 0; h1 < len(hole); h1++ {
+			for h2 := h1 + 1; h2 < len(hole); h2++ {
+				for b1 := 0; b1 < len(board); b1++ {
+					for b2 := b1 + 1; b2 < len(board); b2++ {
+						for b3 := b2 + 1; b3 < len(board); b3++ {
+							hand := reference.EvaluateHand(poker.Cards{hole[h1], hole[h2]}, poker.Cards{board[b1], board[b2], board[b3]})
+							if want == nil || reference.CompareHands(hand, want) > 0 {
+								want = hand
+							}
+						}
+					}
+				}
+			}
+		}
+		if got := omaha.EvaluateHand(hole, board); !sameResult(got, want) {
+			t.Fatalf("%v on %v: got %s %d %v, reference %s %d %v",
+				hole, board, got.Description, got.Value, got.Kickers, want.Description, want.Value, want.Kickers)
+		}
+	}
+}
+
+func BenchmarkReferenceEvaluateHand(b *testing.B) {
+	reference := NewReferenceEvaluator()
+	hands := benchmarkHands()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hand := hands[i%len(hands)]
+		reference.EvaluateHand(hand[0], hand[1])
+	}
+}
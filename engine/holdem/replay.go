@@ -0,0 +1,490 @@
+package holdem
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"os"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// ReplayVersion is the current replay file format version. Version 1
+// replays, whose raises carried raise-by amounts, and version 2 replays,
+// whose blinds were all ActionPostBlind, still run and are upgraded by
+// LoadReplay.
+const ReplayVersion = 3
+
+// LoggedAction is a user or system action together with the phase it was taken in.
+// The game journal keeps them in the exact order they happened.
+type LoggedAction struct {
+	Phase   GamePhase
+	Action  Action
+	Elapsed time.Duration // Time the player took to decide, 0 for system actions or when unknown
+}
+
+// MarshalJSON encodes a logged action compactly as [phase, player, type, amount],
+// followed by the decision time in milliseconds when it is known and then
+// the bet a raise raised to
+func (a LoggedAction) MarshalJSON() ([]byte, error) {
+	fields := []int64{int64(a.Phase), int64(a.Action.PlayerID), int64(a.Action.Type), int64(a.Action.Amount)}
+	if a.Elapsed > 0 || a.Action.RaiseTo > 0 {
+		fields = append(fields, a.Elapsed.Milliseconds())
+	}
+	if a.Action.RaiseTo > 0 {
+		fields = append(fields, int64(a.Action.RaiseTo))
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes the compact [phase, player, type, amount] form with
+// an optional decision time in milliseconds and raise-to bet
+func (a *LoggedAction) UnmarshalJSON(data []byte) error {
+	var fields []int64
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("invalid logged action: %w", err)
+	}
+	if len(fields) < 4 || len(fields) > 6 {
+		return fmt.Errorf("invalid logged action: expected 4 to 6 fields, got %d", len(fields))
+	}
+	a.Phase = GamePhase(fields[0])
+	a.Action = Action{
+		PlayerID: int(fields[1]),
+		Type:     ActionType(fields[2]),
+		Amount:   int(fields[3]),
+	}
+	a.Elapsed = 0
+	if len(fields) >= 5 {
+		a.Elapsed = time.Duration(fields[4]) * time.Millisecond
+	}
+	if len(fields) == 6 {
+		a.Action.RaiseTo = int(fields[5])
+	}
+	return nil
+}
+
+// UpgradeActionLog converts the action log of a version 1 replay, whose
+// raises carried the chips raised on top of the call, to raise-to actions.
+// See UpgradeStreetActions.
+func UpgradeActionLog(log []LoggedAction) []LoggedAction {
+	upgraded := make([]LoggedAction, 0, len(log))
+	for start := 0; start < len(log); {
+		// Bets start over on every street
+		end := start + 1
+		for end < len(log) && log[end].Phase == log[start].Phase {
+			end++
+		}
+		street := make([]Action, 0, end-start)
+		for _, logged := range log[start:end] {
+			street = append(street, logged.Action)
+		}
+		for i, action := range UpgradeStreetActions(street) {
+			logged := log[start+i]
+			logged.Action = action
+			upgraded = append(upgraded, logged)
+		}
+		start = end
+	}
+	return upgraded
+}
+
+// NameBlinds converts the action log of a replay older than version 3,
+// whose blinds were all logged as ActionPostBlind, to small and big blind
+// actions. The small blind was always posted first.
+func NameBlinds(log []LoggedAction) []LoggedAction {
+	named := make([]LoggedAction, len(log))
+	posted := 0
+	for i, logged := range log {
+		if logged.Action.Type == ActionPostBlind {
+			logged.Action.Type = ActionPostSmallBlind
+			if posted > 0 {
+				logged.Action.Type = ActionPostBigBlind
+			}
+			posted++
+		}
+		named[i] = logged
+	}
+	return named
+}
+
+// upgradeActionLog converts the action log of a replay of an older version
+func upgradeActionLog(version int, log []LoggedAction) []LoggedAction {
+	if version < 2 {
+		log = UpgradeActionLog(log)
+	}
+	return NameBlinds(log)
+}
+
+// ReplaySeat records who sat where, with what stack, when a hand started
+type ReplaySeat struct {
+	Seat          int    `json:"seat"`
+	PlayerID      int    `json:"player_id"`
+	Name          string `json:"name"`
+	Chips         int    `json:"chips"`
+	DecisionMaker string `json:"decision_maker,omitempty"`
+}
+
+// Replay is a self-contained record of a single hand: everything needed to
+// re-run it deterministically and check that the outcome is identical
+type Replay struct {
+	Version    int            `json:"version"`
+	Config     GameConfig     `json:"config"`
+	HandNumber int            `json:"hand_number"`
+	HandID     string         `json:"hand_id,omitempty"` // Empty in replays saved before hands had IDs
+	HandSeed   int64          `json:"hand_seed"`
+	Seats      []ReplaySeat   `json:"seats"`
+	Actions    []LoggedAction `json:"actions"`
+	StateHash  string         `json:"state_hash"`
+
+	// Cards stacked on top of the deck, see Game.StackDeck
+	StackedDeck poker.Cards `json:"stacked_deck,omitempty"`
+
+	// Public state hash after every action, see RecordStateHashes
+	StateHashes []string `json:"state_hashes,omitempty"`
+
+	ShuffleCommitment string `json:"shuffle_commitment,omitempty"`
+	ShuffleNonce      string `json:"shuffle_nonce,omitempty"`
+}
+
+// ReplayMismatchError reports the first point where a re-run diverged from the recording
+type ReplayMismatchError struct {
+	Index    int // Position in the replay action log, -1 for final state checks
+	Message  string
+	Expected string
+	Actual   string
+}
+
+func (e *ReplayMismatchError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("replay mismatch: %s (expected %s, got %s)", e.Message, e.Expected, e.Actual)
+	}
+	return fmt.Sprintf("replay mismatch at action %d: %s (expected %s, got %s)", e.Index, e.Message, e.Expected, e.Actual)
+}
+
+// GetActionLog returns a copy of every action taken since the last hand
+// history rotation, see ResetForNewHand, in order
+func (g *Game) GetActionLog() []LoggedAction {
+	log := make([]LoggedAction, len(g.journal))
+	copy(log, g.journal)
+	return log
+}
+
+// GetHandActionLog returns a copy of the actions taken since the current hand started
+func (g *Game) GetHandActionLog() []LoggedAction {
+	if g.handStartSeq > len(g.journal) {
+		return []LoggedAction{}
+	}
+	log := make([]LoggedAction, len(g.journal)-g.handStartSeq)
+	copy(log, g.journal[g.handStartSeq:])
+	return log
+}
+
+// appendJournal records an action and its decision time in the ordered game journal
+func (g *Game) appendJournal(action Action, elapsed time.Duration) {
+	g.journal = append(g.journal, LoggedAction{Phase: g.currentPhase, Action: action, Elapsed: elapsed})
+}
+
+// snapshotSeats captures the current seating and stacks
+func (g *Game) snapshotSeats() []ReplaySeat {
+	seats := []ReplaySeat{}
+	for i, player := range g.players {
+		if player == nil {
+			continue
+		}
+		seats = append(seats, ReplaySeat{
+			Seat:     i,
+			PlayerID: player.GetID(),
+			Name:     player.GetName(),
+			Chips:    player.GetChips(),
+		})
+	}
+	return seats
+}
+
+// NewReplay records the current hand of a game.
+// decisionMakers optionally describes who controlled each player, keyed by player ID.
+// The replay reveals the shuffle nonce, so it must not be shared before the hand is over.
+func NewReplay(game *Game, decisionMakers map[int]string) (*Replay, error) {
+	if game == nil {
+		return nil, fmt.Errorf("game is nil")
+	}
+	if game.handNumber == 0 {
+		return nil, fmt.Errorf("no hand has been dealt yet")
+	}
+
+	seats := make([]ReplaySeat, len(game.handStartSeats))
+	copy(seats, game.handStartSeats)
+	for i := range seats {
+		seats[i].DecisionMaker = decisionMakers[seats[i].PlayerID]
+	}
+
+	return &Replay{
+		Version:    ReplayVersion,
+		Config:     game.config,
+		HandNumber: game.handNumber,
+		HandID:     game.handID,
+		HandSeed:   game.handSeed,
+
+		StackedDeck: copyCards(game.handStacked),
+		Seats:       seats,
+		Actions:     game.GetHandActionLog(),
+		StateHash:   game.replayStateHash(game.GetHandActionLog()),
+
+		ShuffleCommitment: game.shuffleCommitment,
+		ShuffleNonce:      hex.EncodeToString(game.shuffleNonce),
+	}, nil
+}
+
+// Save writes the replay to a file
+func (r *Replay) Save(path string) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadReplay reads a replay file written by Save, upgrading older versions
+func LoadReplay(path string) (*Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	replay := &Replay{}
+	if err := json.Unmarshal(data, replay); err != nil {
+		return nil, fmt.Errorf("invalid replay file %s: %w", path, err)
+	}
+	if err := replay.Upgrade(); err != nil {
+		return nil, fmt.Errorf("replay file %s: %w", path, err)
+	}
+	return replay, nil
+}
+
+// Upgrade converts a replay of an older version to the current one. The
+// hand is re-run first, so only replays that verify are upgraded.
+func (r *Replay) Upgrade() error {
+	if r.Version == ReplayVersion {
+		return nil
+	}
+	game, err := r.Run()
+	if err != nil {
+		return fmt.Errorf("upgrading version %d: %w", r.Version, err)
+	}
+	r.Actions = upgradeActionLog(r.Version, r.Actions)
+	r.StateHash = game.replayStateHash(r.Actions)
+	r.Version = ReplayVersion
+	return nil
+}
+
+// RecordStateHashes re-runs the hand and records the public state hash
+// after every action, so Run points at the first action after which a
+// re-run's state diverged instead of only finding the final state differs
+func (r *Replay) RecordStateHashes() error {
+	r.StateHashes = nil
+	hashes := make([]string, 0, len(r.Actions))
+	if _, err := r.RunObserved(func(game *Game, _ int) {
+		hashes = append(hashes, game.StateHash())
+	}); err != nil {
+		return err
+	}
+	r.StateHashes = hashes
+	return nil
+}
+
+// ReplayFromFile loads a replay, re-runs it and verifies the result matches the recording
+func ReplayFromFile(path string) (*Game, error) {
+	replay, err := LoadReplay(path)
+	if err != nil {
+		return nil, err
+	}
+	return replay.Run()
+}
+
+// Run re-runs the recorded hand on a fresh game and verifies that every action,
+// the state after each one when the replay recorded it, and the final state
+// hash are reproduced exactly. The replayed game is returned
+// even when verification fails so it can be inspected.
+func (r *Replay) Run() (*Game, error) {
+	return r.RunObserved(nil)
+}
+
+// RunObserved behaves like Run and calls observe after each recorded action
+// has been reproduced, which lets callers step through the hand
+func (r *Replay) RunObserved(observe func(game *Game, index int)) (*Game, error) {
+	game, err := r.reproduce(observe)
+	if err != nil {
+		return game, err
+	}
+	// The hash covers the log as recorded, which every action was checked against
+	if hash := game.replayStateHash(r.Actions); hash != r.StateHash {
+		return game, &ReplayMismatchError{Index: -1, Message: "state hash differs", Expected: r.StateHash, Actual: hash}
+	}
+	if r.ShuffleCommitment != "" {
+		reveal := ShuffleReveal{Commitment: r.ShuffleCommitment, Deck: game.handDeck, Nonce: r.ShuffleNonce}
+		if err := reveal.Verify(); err != nil {
+			return game, &ReplayMismatchError{Index: -1, Message: "shuffle commitment does not verify", Expected: r.ShuffleCommitment, Actual: err.Error()}
+		}
+	}
+
+	return game, nil
+}
+
+// reproduce deals the hand again and takes its actions, checking each one
+// and the state hashes recorded after them, but not the final state hash
+// or the shuffle
+func (r *Replay) reproduce(observe func(game *Game, index int)) (*Game, error) {
+	actions := r.Actions
+	switch r.Version {
+	case ReplayVersion:
+	case 1, 2:
+		actions = upgradeActionLog(r.Version, r.Actions)
+	default:
+		return nil, fmt.Errorf("unsupported replay version %d", r.Version)
+	}
+	if r.HandNumber < 1 {
+		return nil, fmt.Errorf("invalid hand number %d", r.HandNumber)
+	}
+
+	game := NewGameWithConfig(r.Config)
+	for _, seat := range r.Seats {
+		if err := game.PlayerSit(NewPlayer(seat.PlayerID, seat.Name, seat.Chips), seat.Seat); err != nil {
+			return nil, fmt.Errorf("replay seat %d: %w", seat.Seat, err)
+		}
+	}
+	game.handNumber = r.HandNumber - 1
+	game.nextID = r.HandID
+	if err := game.StackDeck(r.StackedDeck); err != nil {
+		return nil, fmt.Errorf("replay stacked deck: %w", err)
+	}
+	start := len(game.journal)
+
+	for i, expected := range actions {
+		if len(game.journal)-start <= i {
+			if err := game.replayAction(actions, i); err != nil {
+				return game, fmt.Errorf("replay action %d: %w", i, err)
+			}
+		}
+		if len(game.journal)-start <= i {
+			return game, &ReplayMismatchError{Index: i, Message: "action not reproduced", Expected: describeLoggedAction(expected), Actual: "nothing"}
+		}
+		// Decision times are recorded but not reproduced
+		if actual := game.journal[start+i]; actual.Phase != expected.Phase || actual.Action != expected.Action {
+			return game, &ReplayMismatchError{Index: i, Message: "action differs", Expected: describeLoggedAction(expected), Actual: describeLoggedAction(actual)}
+		}
+		if i < len(r.StateHashes) {
+			if hash := game.StateHash(); hash != r.StateHashes[i] {
+				return game, &ReplayMismatchError{Index: i, Message: "state differs", Expected: r.StateHashes[i], Actual: hash}
+			}
+		}
+		if observe != nil {
+			observe(game, i)
+		}
+	}
+
+	if produced := len(game.journal) - start; produced != len(actions) {
+		return game, &ReplayMismatchError{Index: -1, Message: "action count differs", Expected: fmt.Sprint(len(actions)), Actual: fmt.Sprint(produced)}
+	}
+	if game.handSeed != r.HandSeed {
+		return game, &ReplayMismatchError{Index: -1, Message: "hand seed differs", Expected: fmt.Sprint(r.HandSeed), Actual: fmt.Sprint(game.handSeed)}
+	}
+	return game, nil
+}
+
+// replayAction invokes the game API that produces actions[i]
+func (g *Game) replayAction(actions []LoggedAction, i int) error {
+	logged := actions[i]
+	switch {
+	case logged.Action.Type == ActionShow:
+		return g.Show(logged.Action.PlayerID)
+	case logged.Action.Type == ActionMuck:
+		return g.Muck(logged.Action.PlayerID)
+	case logged.Action.PlayerID != SystemPlayerID:
+		return g.TakeAction(logged.Action)
+	}
+
+	switch logged.Action.Type {
+	case ActionSystemShuffle:
+		// DealHoleCards shuffles a fresh deck and logs the shuffle first
+		if i+1 < len(actions) && actions[i+1].Action.Type == ActionSystemDealHole {
+			return g.DealHoleCards()
+		}
+		g.ShuffleDeck()
+		return nil
+	case ActionSystemDealHole:
+		return g.DealHoleCards()
+	case ActionSystemDealFlop:
+		return g.DealFlop()
+	case ActionSystemDealTurn:
+		return g.DealTurn()
+	case ActionSystemDealRiver:
+		return g.DealRiver()
+	case ActionSystemPhaseChange:
+		g.SetCurrentPhase(GamePhase(logged.Action.Amount))
+		return nil
+	case ActionSystemButton:
+		// StartHand places the button right after dealing and posts the forced bets
+		g.recordSystemAction(logged.Action)
+		if i+1 < len(actions) && actions[i+1].Action.Type == ActionSystemBombPot {
+			g.button = logged.Action.Amount
+			return nil
+		}
+		g.postForcedBets(logged.Action.Amount)
+		return nil
+	case ActionSystemBombPot:
+		g.postBombPot(logged.Action.Amount)
+		return nil
+	case ActionSystemAwardPot:
+		_, err := g.AwardPot()
+		return err
+	case ActionSystemAbort:
+		_, err := g.AbortHand(AbortPolicy(logged.Action.Amount))
+		return err
+	default:
+		return fmt.Errorf("unknown system action %d", logged.Action.Type)
+	}
+}
+
+// replayStateHash hashes everything a replay must reproduce: deck order,
+// board, every seat's cards and chips, and the hand's action log
+func (g *Game) replayStateHash(log []LoggedAction) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "hand:%d seed:%d phase:%d\n", g.handNumber, g.handSeed, g.currentPhase)
+	writeCards(h, "deck", g.deck)
+	writeCards(h, "board", g.communityCards)
+	for i, player := range g.players {
+		if player == nil {
+			continue
+		}
+		fmt.Fprintf(h, "seat:%d id:%d chips:%d bet:%d total:%d folded:%t\n",
+			i, player.GetID(), player.GetChips(), player.GetBet(), player.GetTotalBet(), player.IsFolded())
+		writeCards(h, "hole", player.GetHandCards())
+	}
+	for _, logged := range log {
+		fmt.Fprintf(h, "action:%s\n", describeLoggedAction(logged))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeCards(h hash.Hash, label string, cards []*poker.Card) {
+	fmt.Fprintf(h, "%s:", label)
+	for _, card := range cards {
+		if card == nil {
+			fmt.Fprint(h, " nil")
+			continue
+		}
+		fmt.Fprintf(h, " %d/%d", card.Suit, card.Rank)
+	}
+	fmt.Fprintln(h)
+}
+
+func describeLoggedAction(logged LoggedAction) string {
+	description := fmt.Sprintf("%s player %d %s %d",
+		PhaseToString(logged.Phase), logged.Action.PlayerID, ActionTypeToString(logged.Action.Type), logged.Action.Amount)
+	if logged.Action.RaiseTo > 0 {
+		description += fmt.Sprintf(" to %d", logged.Action.RaiseTo)
+	}
+	return description
+}
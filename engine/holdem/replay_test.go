@@ -0,0 +1,399 @@
+package holdem
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// playRecordedHand deals a short hand on a seeded game
+func playRecordedHand(t *testing.T, seed int64) *Game {
+	t.Helper()
+	game := NewGameWithConfig(GameConfig{SmallBlind: 5, BigBlind: 10, Seed: seed})
+	game.PlayerSit(NewPlayer(1, "Alice", 1000), 0)
+	game.PlayerSit(NewPlayer(2, "Bob", 800), 3)
+	game.PlayerSit(NewPlayer(3, "Carol", 600), 7)
+
+	if err := game.DealHoleCards(); err != nil {
+		t.Fatalf("Unexpected error dealing hole cards: %v", err)
+	}
+	game.TakeAction(Action{PlayerID: 1, Type: ActionRaise, Amount: 30, RaiseTo: 30})
+	game.TakeAction(Action{PlayerID: 2, Type: ActionCall, Amount: 30})
+	game.TakeAction(Action{PlayerID: 3, Type: ActionFold})
+	if err := game.DealFlop(); err != nil {
+		t.Fatalf("Unexpected error dealing flop: %v", err)
+	}
+	game.TakeAction(Action{PlayerID: 1, Type: ActionCheck})
+	game.TakeAction(Action{PlayerID: 2, Type: ActionCheck})
+	if err := game.DealTurn(); err != nil {
+		t.Fatalf("Unexpected error dealing turn: %v", err)
+	}
+	return game
+}
+
+func TestSeededGamesDealIdenticalCards(t *testing.T) {
+	game1 := playRecordedHand(t, 42)
+	game2 := playRecordedHand(t, 42)
+	game3 := playRecordedHand(t, 43)
+
+	if game1.GetCommunityCards().String() != game2.GetCommunityCards().String() {
+		t.Error("Expected identical boards for identical seeds")
+	}
+	if game1.replayStateHash(game1.GetHandActionLog()) != game2.replayStateHash(game2.GetHandActionLog()) {
+		t.Error("Expected identical state hashes for identical seeds")
+	}
+	if game1.replayStateHash(game1.GetHandActionLog()) == game3.replayStateHash(game3.GetHandActionLog()) {
+		t.Error("Expected different state hashes for different seeds")
+	}
+}
+
+func TestNewGameWithConfigPicksSeed(t *testing.T) {
+	game := NewGameWithConfig(GameConfig{SmallBlind: 5, BigBlind: 10})
+	if game.GetConfig().Seed == 0 {
+		t.Error("Expected an effective seed to be chosen")
+	}
+	if game.GetSmallBlind() != 5 || game.GetBigBlind() != 10 {
+		t.Errorf("Expected blinds 5/10, got %d/%d", game.GetSmallBlind(), game.GetBigBlind())
+	}
+}
+
+func TestActionLogKeepsOrder(t *testing.T) {
+	game := playRecordedHand(t, 7)
+	log := game.GetHandActionLog()
+
+	if log[0].Action.Type != ActionSystemShuffle || log[1].Action.Type != ActionSystemDealHole {
+		t.Fatalf("Expected hand to start with shuffle and deal, got %v", log[:2])
+	}
+	if log[2].Action.PlayerID != 1 || log[2].Action.Type != ActionRaise {
+		t.Errorf("Expected raise from player 1 third, got %+v", log[2])
+	}
+	last := log[len(log)-1]
+	if last.Action.Type != ActionSystemDealTurn || last.Phase != PhaseTurn {
+		t.Errorf("Expected turn deal last, got %+v", last)
+	}
+	if len(game.GetActionLog()) != len(log) {
+		t.Errorf("Expected full log to equal hand log for a single hand")
+	}
+}
+
+func TestReplayRoundTrip(t *testing.T) {
+	game := playRecordedHand(t, 1234)
+	replay, err := NewReplay(game, map[int]string{1: "human", 2: "basic-bot"})
+	if err != nil {
+		t.Fatalf("Unexpected error recording replay: %v", err)
+	}
+	if replay.Seats[0].DecisionMaker != "human" || replay.Seats[2].DecisionMaker != "" {
+		t.Errorf("Unexpected decision makers: %+v", replay.Seats)
+	}
+
+	path := filepath.Join(t.TempDir(), "hand.replay.json")
+	if err := replay.Save(path); err != nil {
+		t.Fatalf("Unexpected error saving replay: %v", err)
+	}
+
+	replayed, err := ReplayFromFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected replay error: %v", err)
+	}
+	if replayed.GetCommunityCards().String() != game.GetCommunityCards().String() {
+		t.Errorf("Expected board %s, got %s", game.GetCommunityCards(), replayed.GetCommunityCards())
+	}
+	if replayed.GetHandID() != game.GetHandID() || replayed.GetTableID() != game.GetTableID() {
+		t.Errorf("Expected hand %s at table %s, got hand %s at table %s",
+			game.GetHandID(), game.GetTableID(), replayed.GetHandID(), replayed.GetTableID())
+	}
+	alice, _ := replayed.GetPlayerByID(1)
+	original, _ := game.GetPlayerByID(1)
+	if poker.Cards(alice.GetHandCards()).String() != poker.Cards(original.GetHandCards()).String() {
+		t.Errorf("Expected identical hole cards after replay")
+	}
+}
+
+func TestReplaySecondHand(t *testing.T) {
+	game := playRecordedHand(t, 99)
+	if err := game.DealHoleCards(); err != nil {
+		t.Fatalf("Unexpected error dealing second hand: %v", err)
+	}
+	game.TakeAction(Action{PlayerID: 2, Type: ActionAllIn, Amount: 800})
+
+	replay, err := NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error recording replay: %v", err)
+	}
+	if replay.HandNumber != 2 || len(replay.Actions) != 3 {
+		t.Errorf("Expected hand 2 with 3 actions, got hand %d with %d actions", replay.HandNumber, len(replay.Actions))
+	}
+	if _, err := replay.Run(); err != nil {
+		t.Errorf("Unexpected replay error: %v", err)
+	}
+}
+
+func TestReplayDetectsTampering(t *testing.T) {
+	game := playRecordedHand(t, 5)
+	replay, _ := NewReplay(game, nil)
+
+	tampered := *replay
+	tampered.StateHash = strings.Repeat("0", 64)
+	_, err := tampered.Run()
+	var mismatch *ReplayMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Index != -1 {
+		t.Errorf("Expected final state mismatch, got %v", err)
+	}
+
+	tampered = *replay
+	tampered.Config.Seed++
+	if _, err := tampered.Run(); !errors.As(err, &mismatch) {
+		t.Errorf("Expected mismatch for a different seed, got %v", err)
+	}
+
+	tampered = *replay
+	tampered.Version = 99
+	if _, err := tampered.Run(); err == nil {
+		t.Error("Expected error for unsupported version")
+	}
+}
+
+func TestNewReplayErrors(t *testing.T) {
+	if _, err := NewReplay(nil, nil); err == nil {
+		t.Error("Expected error for nil game")
+	}
+	if _, err := NewReplay(NewGame(5, 10), nil); err == nil {
+		t.Error("Expected error before any hand is dealt")
+	}
+	if _, err := LoadReplay(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestLoggedActionCompactJSON(t *testing.T) {
+	logged := LoggedAction{Phase: PhaseFlop, Action: Action{PlayerID: 3, Type: ActionRaise, Amount: 40}}
+	data, err := json.Marshal(logged)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != "[1,3,3,40]" {
+		t.Errorf("Expected compact encoding, got %s", data)
+	}
+
+	var decoded LoggedAction
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded != logged {
+		t.Errorf("Expected %+v, got %+v", logged, decoded)
+	}
+	if err := json.Unmarshal([]byte(`{"phase":1}`), &decoded); err == nil {
+		t.Error("Expected error for non-compact input")
+	}
+}
+
+func TestLoggedActionDecisionTimeJSON(t *testing.T) {
+	logged := LoggedAction{Phase: PhaseTurn, Action: Action{PlayerID: 2, Type: ActionCall, Amount: 20}, Elapsed: 2500 * time.Millisecond}
+	data, err := json.Marshal(logged)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != "[2,2,2,20,2500]" {
+		t.Errorf("Expected the decision time in milliseconds, got %s", data)
+	}
+	var decoded LoggedAction
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded != logged {
+		t.Errorf("Expected %+v, got %+v", logged, decoded)
+	}
+	if err := json.Unmarshal([]byte("[1,2,3]"), &decoded); err == nil {
+		t.Error("Expected error for a short logged action")
+	}
+}
+
+func TestReplayKeepsDecisionTimes(t *testing.T) {
+	game := NewGameWithConfig(GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 9})
+	game.PlayerSit(NewPlayer(1, "Alice", 1000), 0)
+	game.PlayerSit(NewPlayer(2, "Bob", 1000), 1)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	if err := game.TakeTimedAction(Action{PlayerID: 1, Type: ActionFold}, 4*time.Second); err != nil {
+		t.Fatalf("TakeTimedAction failed: %v", err)
+	}
+	if _, err := game.AwardPot(); err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+
+	replay, err := NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("NewReplay failed: %v", err)
+	}
+	timed := 0
+	for _, logged := range replay.Actions {
+		if logged.Elapsed > 0 {
+			timed++
+			if logged.Action.Type != ActionFold || logged.Elapsed != 4*time.Second {
+				t.Errorf("Expected only the 4s fold to be timed, got %+v", logged)
+			}
+		}
+	}
+	if timed != 1 {
+		t.Errorf("Expected one timed action, got %d", timed)
+	}
+	if _, err := replay.Run(); err != nil {
+		t.Errorf("Expected the timed replay to verify: %v", err)
+	}
+}
+
+func TestLoggedActionRaiseToJSON(t *testing.T) {
+	logged := LoggedAction{Phase: PhaseFlop, Action: Action{PlayerID: 3, Type: ActionRaise, Amount: 30, RaiseTo: 40}}
+	data, err := json.Marshal(logged)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != "[1,3,3,30,0,40]" {
+		t.Errorf("Expected the raise-to bet after the decision time, got %s", data)
+	}
+	var decoded LoggedAction
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded != logged {
+		t.Errorf("Expected %+v, got %+v", logged, decoded)
+	}
+}
+
+// legacyActionLog turns raises back into the version 1 form, which put in
+// the call plus Amount, and logged both blinds as ActionPostBlind
+func legacyActionLog(log []LoggedAction) []LoggedAction {
+	legacy := make([]LoggedAction, len(log))
+	var bets *streetBets
+	for i, logged := range log {
+		if i == 0 || logged.Phase != log[i-1].Phase {
+			bets = newStreetBets(0)
+		}
+		legacy[i] = logged
+		if logged.Action.Type.IsBlind() {
+			legacy[i].Action.Type = ActionPostBlind
+		}
+		if logged.Action.Type == ActionRaise {
+			legacy[i].Action.Amount = logged.Action.RaiseTo - bets.current
+			legacy[i].Action.RaiseTo = 0
+		}
+		bets.apply(logged.Action)
+	}
+	return legacy
+}
+
+func TestLegacyReplayUpgrades(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 21}, 1000, 1000, 1000)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionRaise, 30)
+	mustAct(t, game, 2, ActionRaise, 90)
+	mustAct(t, game, 3, ActionFold, 0)
+	mustAct(t, game, 1, ActionCall, 60)
+	game.DealFlop()
+	mustAct(t, game, 2, ActionRaise, 50)
+	mustAct(t, game, 1, ActionRaise, 150)
+	mustAct(t, game, 2, ActionCall, 100)
+	for _, deal := range []func() error{game.DealTurn, game.DealRiver} {
+		deal()
+		mustAct(t, game, 2, ActionCheck, 0)
+		mustAct(t, game, 1, ActionCheck, 0)
+	}
+	if _, err := game.AwardPot(); err != nil {
+		t.Fatalf("AwardPot failed: %v", err)
+	}
+	replay, err := NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("NewReplay failed: %v", err)
+	}
+
+	legacy := *replay
+	legacy.Version = 1
+	legacy.Actions = legacyActionLog(replay.Actions)
+	legacy.StateHash = game.replayStateHash(legacy.Actions)
+	if _, err := legacy.Run(); err != nil {
+		t.Fatalf("Expected the version 1 replay to run: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "legacy.json")
+	if err := legacy.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadReplay(path)
+	if err != nil {
+		t.Fatalf("LoadReplay failed: %v", err)
+	}
+	if loaded.Version != ReplayVersion || loaded.StateHash != replay.StateHash {
+		t.Errorf("Expected the upgraded replay to match a fresh recording, got version %d", loaded.Version)
+	}
+	for i, logged := range loaded.Actions {
+		if logged != replay.Actions[i] {
+			t.Errorf("Action %d upgraded to %+v, expected %+v", i, logged, replay.Actions[i])
+		}
+	}
+
+	for i, logged := range legacy.Actions {
+		if logged.Phase == PhaseFlop && logged.Action.Type == ActionRaise {
+			legacy.Actions[i].Action.Amount++
+		}
+	}
+	if err := legacy.Upgrade(); err == nil {
+		t.Error("Expected a tampered version 1 replay not to upgrade")
+	}
+}
+
+func TestForcedBetsAreAnnotated(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Ante: 1, Seed: 4}, 1000, 1000, 1000)
+	game.StartHand(0)
+	want := []ActionType{ActionPostAnte, ActionPostAnte, ActionPostAnte, ActionPostSmallBlind, ActionPostBigBlind}
+	preflop := game.GetUserActions().Preflop
+	if len(preflop) != len(want) {
+		t.Fatalf("Expected antes and both blinds, got %+v", preflop)
+	}
+	for i, action := range preflop {
+		if action.Type != want[i] || !action.Type.IsForced() {
+			t.Errorf("Forced bet %d: expected %s, got %s", i, ActionTypeToString(want[i]), ActionTypeToString(action.Type))
+		}
+	}
+	for _, actionType := range []ActionType{ActionFold, ActionCheck, ActionCall, ActionRaise, ActionAllIn, ActionDisconnect} {
+		if actionType.IsForced() {
+			t.Errorf("Expected %s to be voluntary", ActionTypeToString(actionType))
+		}
+	}
+	if ActionPostAnte.IsBlind() || !ActionPostStraddle.IsBlind() {
+		t.Error("Expected straddles and not antes to count as blinds")
+	}
+
+	// The big blind's bet sets the bet to match and its option survives a limp
+	if game.GetCurrentBet() != 10 {
+		t.Errorf("Expected the big blind to set the bet at 10, got %d", game.GetCurrentBet())
+	}
+	mustAct(t, game, 1, ActionCall, 10)
+	mustAct(t, game, 2, ActionCall, 5)
+	if bigBlind, _ := game.GetPlayerByID(3); !game.HasOption(bigBlind) {
+		t.Error("Expected the big blind to have the option after limps")
+	}
+
+	// Replays recorded before the blinds were named still run
+	replay, err := NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("NewReplay failed: %v", err)
+	}
+	legacy := *replay
+	legacy.Version = 2
+	legacy.Actions = legacyActionLog(replay.Actions)
+	legacy.StateHash = game.replayStateHash(legacy.Actions)
+	if err := legacy.Upgrade(); err != nil {
+		t.Fatalf("Expected the version 2 replay to upgrade: %v", err)
+	}
+	for i, logged := range legacy.Actions {
+		if logged != replay.Actions[i] {
+			t.Errorf("Action %d upgraded to %+v, expected %+v", i, logged, replay.Actions[i])
+		}
+	}
+}
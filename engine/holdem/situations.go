@@ -0,0 +1,130 @@
+package holdem
+
+// Situations counts the spots behind the situational stats and how often
+// a player took them: each chance is a hand, or a street after the flop,
+// where the player could have done it
+type Situations struct {
+	ColdCallChances   int `json:"cold_call_chances"`   // Faced a raise preflop with no chips in yet, blinds included
+	ColdCalls         int `json:"cold_calls"`          // ...and called it
+	SqueezeChances    int `json:"squeeze_chances"`     // Faced a single raise and a caller of it preflop, first to act on it
+	Squeezes          int `json:"squeezes"`            // ...and re-raised
+	DonkChances       int `json:"donk_chances"`        // First to act on a street after the flop before the last street's aggressor
+	DonkBets          int `json:"donk_bets"`           // ...and bet into them
+	CheckRaiseChances int `json:"check_raise_chances"` // Checked a street after the flop and then faced a bet
+	CheckRaises       int `json:"check_raises"`        // ...and raised it
+}
+
+// ColdCall returns how often the player called a raise preflop with no
+// chips in yet
+func (s Situations) ColdCall() float64 {
+	return share(s.ColdCalls, s.ColdCallChances)
+}
+
+// Squeeze returns how often the player re-raised an open that had been
+// called
+func (s Situations) Squeeze() float64 {
+	return share(s.Squeezes, s.SqueezeChances)
+}
+
+// DonkBet returns how often the player led into the last street's
+// aggressor
+func (s Situations) DonkBet() float64 {
+	return share(s.DonkBets, s.DonkChances)
+}
+
+// CheckRaise returns how often the player raised a bet after checking
+func (s Situations) CheckRaise() float64 {
+	return share(s.CheckRaises, s.CheckRaiseChances)
+}
+
+// Add sums other's counts into these
+func (s *Situations) Add(other Situations) {
+	s.ColdCallChances += other.ColdCallChances
+	s.ColdCalls += other.ColdCalls
+	s.SqueezeChances += other.SqueezeChances
+	s.Squeezes += other.Squeezes
+	s.DonkChances += other.DonkChances
+	s.DonkBets += other.DonkBets
+	s.CheckRaiseChances += other.CheckRaiseChances
+	s.CheckRaises += other.CheckRaises
+}
+
+// Situations reads the player's situational spots from the hand's action
+// lines so far. A hand still being played counts the spots already acted
+// on, and never loses them as it goes on.
+func (u UserActions) Situations(playerID int) Situations {
+	var s Situations
+	u.preflopSituations(playerID, &s)
+	for phase := PhaseFlop; phase <= PhaseRiver; phase++ {
+		u.streetSituations(phase, playerID, &s)
+	}
+	return s
+}
+
+// preflopSituations counts the cold-call and squeeze spots at the player's
+// first decision before the flop
+func (u UserActions) preflopSituations(playerID int, s *Situations) {
+	blind := false
+	for _, action := range u.Preflop {
+		blind = blind || action.PlayerID == playerID && action.Type.IsBlind()
+	}
+	raises, callers := 0, 0
+	for _, action := range u.Voluntary(PhasePreflop) {
+		if action.PlayerID == playerID {
+			called := !action.Raised && action.Type != ActionFold && action.Type != ActionCheck
+			if raises > 0 && !blind {
+				s.ColdCallChances++
+				s.ColdCalls += count(called)
+			}
+			if raises == 1 && callers > 0 {
+				s.SqueezeChances++
+				s.Squeezes += count(action.Raised)
+			}
+			return
+		}
+		switch {
+		case action.Raised:
+			raises++
+		case raises > 0 && action.Type != ActionFold && action.Type != ActionCheck:
+			callers++
+		}
+	}
+}
+
+// streetSituations counts the donk-bet and check-raise spots on a street
+// after the flop
+func (u UserActions) streetSituations(phase GamePhase, playerID int, s *Situations) {
+	aggressor, ok := u.LastAggressor(phase - 1)
+	donk := ok && aggressor != playerID // Until the aggressor acts
+	acted, checked := false, false
+	for _, action := range u.Voluntary(phase) {
+		if action.PlayerID != playerID {
+			donk = donk && action.PlayerID != aggressor
+			continue
+		}
+		if !acted && donk && action.Faced == 0 {
+			s.DonkChances++
+			s.DonkBets += count(action.Raised)
+		}
+		if checked && action.Faced > 0 {
+			s.CheckRaiseChances++
+			s.CheckRaises += count(action.Raised)
+			return
+		}
+		acted, checked = true, action.Type == ActionCheck
+	}
+}
+
+func share(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+func count(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
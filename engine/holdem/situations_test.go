@@ -0,0 +1,43 @@
+package holdem
+
+import "testing"
+
+func TestSituationsFromActionLines(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000, 1000)
+	game.StartHand(0) // Player 1 has the button, 2 and 3 post the blinds
+
+	mustAct(t, game, 4, ActionRaise, 30)
+	mustAct(t, game, 1, ActionCall, 30)   // Cold call
+	mustAct(t, game, 2, ActionRaise, 100) // Squeeze from the small blind
+	mustAct(t, game, 3, ActionFold, 0)
+	mustAct(t, game, 4, ActionCall, 70)
+	mustAct(t, game, 1, ActionCall, 70)
+	game.DealFlop()
+	mustAct(t, game, 2, ActionCheck, 0)
+	mustAct(t, game, 4, ActionRaise, 50)
+	mustAct(t, game, 1, ActionCall, 50)
+	mustAct(t, game, 2, ActionCall, 50) // Checks and calls rather than check-raising
+	game.DealTurn()
+	mustAct(t, game, 2, ActionRaise, 100) // Leads into the flop's aggressor
+	mustAct(t, game, 4, ActionCall, 100)
+	mustAct(t, game, 1, ActionFold, 0)
+
+	history := game.GetUserActions()
+	for id, want := range map[int]Situations{
+		1: {ColdCallChances: 1, ColdCalls: 1},
+		2: {SqueezeChances: 1, Squeezes: 1, CheckRaiseChances: 1, DonkChances: 1, DonkBets: 1},
+		3: {},
+		4: {},
+	} {
+		if got := history.Situations(id); got != want {
+			t.Errorf("Expected player %d's situations %+v, got %+v", id, want, got)
+		}
+	}
+
+	var total Situations
+	total.Add(history.Situations(2))
+	total.Add(history.Situations(2))
+	if total.Squeeze() != 1 || total.CheckRaise() != 0 || total.DonkBet() != 1 || total.ColdCall() != 0 {
+		t.Errorf("Unexpected shares %+v", total)
+	}
+}
@@ -0,0 +1,51 @@
+package holdem
+
+import "fmt"
+
+// SnapshotVersion is the current table snapshot format version
+const SnapshotVersion = 1
+
+// Snapshot is a table between hands: the rules, who sits where and with
+// what stack, enough to carry on playing later where the game stopped
+type Snapshot struct {
+	Version    int          `json:"version"`
+	Config     GameConfig   `json:"config"`      // Current blinds included
+	HandNumber int          `json:"hand_number"` // Hands dealt so far
+	Button     int          `json:"button"`      // Button of the last hand, -1 before the first
+	Seats      []ReplaySeat `json:"seats"`
+}
+
+// Snapshot records the table between hands. A hand in progress has to be
+// paid out with AwardPot or abandoned with AbortHand first.
+func (g *Game) Snapshot() (*Snapshot, error) {
+	if g.handActive {
+		return nil, fmt.Errorf("hand %d is still in progress", g.handNumber)
+	}
+	return &Snapshot{
+		Version:    SnapshotVersion,
+		Config:     g.config,
+		HandNumber: g.handNumber,
+		Button:     g.button,
+		Seats:      g.snapshotSeats(),
+	}, nil
+}
+
+// RestoreGame seats the players of a snapshot at a new game, which deals
+// the hands that follow the snapshot's last one from the same seed
+func RestoreGame(snapshot *Snapshot) (*Game, error) {
+	if snapshot == nil {
+		return nil, fmt.Errorf("snapshot is nil")
+	}
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", snapshot.Version)
+	}
+	game := NewGameWithConfig(snapshot.Config)
+	for _, seat := range snapshot.Seats {
+		if err := game.PlayerSit(NewPlayer(seat.PlayerID, seat.Name, seat.Chips), seat.Seat); err != nil {
+			return nil, fmt.Errorf("restore seat %d: %w", seat.Seat, err)
+		}
+	}
+	game.handNumber = snapshot.HandNumber
+	game.button = snapshot.Button
+	return game, nil
+}
@@ -0,0 +1,51 @@
+package holdem
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSnapshotRestoresTableBetweenHands(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 800, 1200)
+	game.StartHand(0)
+	if _, err := game.Snapshot(); err == nil {
+		t.Error("Expected no snapshot with a hand in progress")
+	}
+	mustAct(t, game, 1, ActionFold, 0)
+	mustAct(t, game, 2, ActionFold, 0)
+	game.AwardPot()
+	game.SetBlinds(10, 20, 0)
+
+	snapshot, err := game.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	loaded := &Snapshot{}
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	restored, err := RestoreGame(loaded)
+	if err != nil {
+		t.Fatalf("RestoreGame failed: %v", err)
+	}
+	if restored.GetHandNumber() != 1 || restored.GetButton() != 0 || restored.GetBigBlind() != 20 {
+		t.Errorf("Expected hand 1, button 0 and big blind 20, got %d, %d and %d", restored.GetHandNumber(), restored.GetButton(), restored.GetBigBlind())
+	}
+	for id, chips := range map[int]int{1: 1000, 2: 795, 3: 1205} {
+		if chipsOf(restored, id) != chips {
+			t.Errorf("Expected player %d restored with %d, got %d", id, chips, chipsOf(restored, id))
+		}
+	}
+
+	// The restored game deals the next hand as the original would have
+	game.StartHand(1)
+	restored.StartHand(1)
+	if game.GetHandSeed() != restored.GetHandSeed() {
+		t.Errorf("Expected the same hand seed after restoring, got %d and %d", game.GetHandSeed(), restored.GetHandSeed())
+	}
+}
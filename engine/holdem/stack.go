@@ -0,0 +1,66 @@
+package holdem
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// StackDeck puts cards on top of the next hand's deck, for debugging and
+// tests. They come off in the order given: hole cards a round at a time to
+// the players in seat order, then a burn card before each street. The rest of the deck
+// is shuffled as usual, and replays of the hand record the stacked cards.
+// No cards take the stack down again.
+func (g *Game) StackDeck(cards poker.Cards) error {
+	if len(cards) == 0 {
+		g.stacked = nil
+		return nil
+	}
+	seen := map[poker.Card]bool{}
+	for _, card := range cards {
+		if card == nil {
+			return fmt.Errorf("stacked deck has an unknown card")
+		}
+		if seen[*card] {
+			return fmt.Errorf("%s is stacked twice", card.Code())
+		}
+		seen[*card] = true
+	}
+	if len(cards) > len(newStandardDeck()) {
+		return fmt.Errorf("cannot stack %d cards", len(cards))
+	}
+	g.stacked = copyCards(cards)
+	g.log().Warn("deck stacked for the next hand", slog.String("cards", cards.Codes()))
+	return nil
+}
+
+// GetStackedDeck returns the cards stacked on the next hand's deck, if any
+func (g *Game) GetStackedDeck() poker.Cards {
+	return copyCards(g.stacked)
+}
+
+// GetDeckSize returns how many cards are left to deal in the current hand
+func (g *Game) GetDeckSize() int {
+	return len(g.deck)
+}
+
+// applyStack moves the cards stacked for this hand to the top of the
+// freshly shuffled deck
+func (g *Game) applyStack() {
+	g.handStacked, g.stacked = g.stacked, nil
+	if len(g.handStacked) == 0 {
+		return
+	}
+	stacked := map[poker.Card]bool{}
+	for _, card := range g.handStacked {
+		stacked[*card] = true
+	}
+	deck := copyCards(g.handStacked)
+	for _, card := range g.deck {
+		if !stacked[*card] {
+			deck = append(deck, card)
+		}
+	}
+	g.deck = deck
+}
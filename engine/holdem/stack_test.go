@@ -0,0 +1,62 @@
+package holdem
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestStackedDeckDealsInOrder(t *testing.T) {
+	game := NewGameWithConfig(GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 9})
+	game.PlayerSit(NewPlayer(1, "Alice", 1000), 0)
+	game.PlayerSit(NewPlayer(2, "Bob", 1000), 1)
+	stack, _ := poker.ParseCards("As Kd Ah Kc 2c Qs Qh Qd")
+	if err := game.StackDeck(stack); err != nil {
+		t.Fatalf("Unexpected error stacking: %v", err)
+	}
+	if len(game.GetStackedDeck()) != len(stack) {
+		t.Errorf("Expected %d stacked cards, got %d", len(stack), len(game.GetStackedDeck()))
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	alice, _ := game.GetPlayerByID(1)
+	bob, _ := game.GetPlayerByID(2)
+	if got := poker.Cards(alice.GetHandCards()).Codes(); got != "AsAh" {
+		t.Errorf("Expected Alice to hold AsAh, got %s", got)
+	}
+	if got := poker.Cards(bob.GetHandCards()).Codes(); got != "KdKc" {
+		t.Errorf("Expected Bob to hold KdKc, got %s", got)
+	}
+	if game.GetDeckSize() != 48 || len(game.GetStackedDeck()) != 0 {
+		t.Errorf("Expected 48 cards left and the stack used up, got %d and %v", game.GetDeckSize(), game.GetStackedDeck())
+	}
+
+	game.TakeAction(Action{PlayerID: 1, Type: ActionCall, Amount: 5})
+	game.TakeAction(Action{PlayerID: 2, Type: ActionCheck})
+	if err := game.DealFlop(); err != nil {
+		t.Fatalf("DealFlop failed: %v", err)
+	}
+	if got := game.GetCommunityCards().Codes(); got != "QsQhQd" {
+		t.Errorf("Expected the flop after the burn card, got %s", got)
+	}
+
+	replay, err := NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("NewReplay failed: %v", err)
+	}
+	if _, err := replay.Run(); err != nil {
+		t.Errorf("Expected a hand with a stacked deck to replay, got %v", err)
+	}
+}
+
+func TestStackDeckRejectsDuplicates(t *testing.T) {
+	game := NewGame(5, 10)
+	stack, _ := poker.ParseCards("As Kd As")
+	if err := game.StackDeck(stack); err == nil {
+		t.Error("Expected a card stacked twice to be refused")
+	}
+	if err := game.StackDeck(nil); err != nil || len(game.GetStackedDeck()) != 0 {
+		t.Errorf("Expected no cards to clear the stack, got %v", err)
+	}
+}
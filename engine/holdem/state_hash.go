@@ -0,0 +1,48 @@
+package holdem
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// StateHash returns a hash of the public state of the table: what every
+// player and spectator sees, hole cards left out. A client holding a view
+// of the table computes the same hash with TableView.StateHash, so comparing
+// the two after every action detects a client that fell out of step.
+func (g *Game) StateHash() string {
+	return g.SpectatorView().StateHash()
+}
+
+// PrivateStateHash returns a hash of the whole state of the table: the
+// public state plus the seed, the deck order and every hole card. Only the
+// server and the replay verifier, which know all of it, can compute it.
+func (g *Game) PrivateStateHash() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "public:%s seed:%d\n", g.StateHash(), g.handSeed)
+	writeCards(h, "deck", g.deck)
+	for i, player := range g.players {
+		if player == nil {
+			continue
+		}
+		fmt.Fprintf(h, "seat:%d ", i)
+		writeCards(h, "hole", player.GetHandCards())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// StateHash returns the hash of the public state shown in the view, equal
+// to Game.StateHash whoever the view was made for: the hole cards a player
+// sees of their own are not part of it, only whether a seat holds cards.
+func (v TableView) StateHash() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "variant:%s hand:%d phase:%d button:%d acting:%d pot:%d bet:%d\n",
+		v.Variant, v.HandNumber, v.Phase, v.Button, v.ActingSeat, v.Pot, v.CurrentBet)
+	writeCards(h, "board", v.Board)
+	for _, seat := range v.Seats {
+		holds := len(seat.HoleCards) > 0 || seat.CardsHidden
+		fmt.Fprintf(h, "seat:%d id:%d name:%q chips:%d bet:%d total:%d folded:%t protected:%t cards:%t\n",
+			seat.Seat, seat.PlayerID, seat.Name, seat.Chips, seat.Bet, seat.TotalBet, seat.Folded, seat.Protected, holds)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
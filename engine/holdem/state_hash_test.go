@@ -0,0 +1,78 @@
+package holdem
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStateHashMatchesEveryView(t *testing.T) {
+	game := playRecordedHand(t, 7)
+	hash := game.StateHash()
+	if got := game.PlayerView(1).StateHash(); got != hash {
+		t.Errorf("Expected a player's view to hash like the table, got %s want %s", got, hash)
+	}
+	if got := game.PlayerView(3).StateHash(); got != hash {
+		t.Errorf("Expected a folded player's view to hash like the table, got %s want %s", got, hash)
+	}
+	if got := game.CommentatorView().StateHash(); got != hash {
+		t.Errorf("Expected the commentator view to hash like the table, got %s want %s", got, hash)
+	}
+	if other := playRecordedHand(t, 7); other.StateHash() != hash || other.PrivateStateHash() != game.PrivateStateHash() {
+		t.Error("Expected identical hashes for identical hands")
+	}
+}
+
+func TestStateHashFollowsActions(t *testing.T) {
+	game := playRecordedHand(t, 7)
+	public, private := game.StateHash(), game.PrivateStateHash()
+	if err := game.TakeAction(Action{PlayerID: 1, Type: ActionRaise, Amount: 20, RaiseTo: 20}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if game.StateHash() == public {
+		t.Error("Expected the public hash to change after an action")
+	}
+	if game.PrivateStateHash() == private {
+		t.Error("Expected the private hash to change after an action")
+	}
+}
+
+func TestPrivateStateHashCoversHiddenCards(t *testing.T) {
+	game1 := NewGameWithConfig(GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 1})
+	game2 := NewGameWithConfig(GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 2})
+	for _, game := range []*Game{game1, game2} {
+		game.PlayerSit(NewPlayer(1, "Alice", 1000), 0)
+		game.PlayerSit(NewPlayer(2, "Bob", 1000), 1)
+		if err := game.DealHoleCards(); err != nil {
+			t.Fatalf("Unexpected error dealing hole cards: %v", err)
+		}
+	}
+	if game1.StateHash() != game2.StateHash() {
+		t.Error("Expected equal public hashes before any card is shown")
+	}
+	if game1.PrivateStateHash() == game2.PrivateStateHash() {
+		t.Error("Expected the private hashes to tell the deals apart")
+	}
+}
+
+func TestReplayPinpointsDivergedState(t *testing.T) {
+	game := playRecordedHand(t, 5)
+	replay, _ := NewReplay(game, nil)
+	if err := replay.RecordStateHashes(); err != nil {
+		t.Fatalf("Unexpected error recording state hashes: %v", err)
+	}
+	if len(replay.StateHashes) != len(replay.Actions) {
+		t.Fatalf("Expected a hash per action, got %d for %d actions", len(replay.StateHashes), len(replay.Actions))
+	}
+	if _, err := replay.Run(); err != nil {
+		t.Fatalf("Unexpected replay error: %v", err)
+	}
+
+	tampered := *replay
+	tampered.StateHashes = append([]string(nil), replay.StateHashes...)
+	tampered.StateHashes[2] = replay.StateHashes[1]
+	_, err := tampered.Run()
+	var mismatch *ReplayMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Index != 2 {
+		t.Errorf("Expected a state mismatch at action 2, got %v", err)
+	}
+}
@@ -1,19 +1,49 @@
 package holdem
 
 import (
-	"fmt"
+	"github.com/ljbink/ai-poker/engine/i18n"
+	"github.com/ljbink/ai-poker/engine/pokermath"
 )
 
-// ValidationError represents an action validation error
+// ValidationError represents an action validation error. When a legal
+// action is close to the rejected one, the error suggests it: amounts are
+// the chips for calls and all-ins and the bet raised to for raises.
 type ValidationError struct {
 	Message string
 	Code    ValidationErrorCode
+
+	CanCorrect    bool       // The fields below hold a suggestion
+	Suggested     ActionType // Action type to take instead
+	NearestAmount int        // Legal amount closest to the rejected one
+	MinAmount     int        // Legal amounts of the suggested type
+	MaxAmount     int
 }
 
 func (e *ValidationError) Error() string {
 	return e.Message
 }
 
+// Correct returns the suggested action in place of the rejected one
+func (e *ValidationError) Correct(action Action) (Action, bool) {
+	if !e.CanCorrect {
+		return action, false
+	}
+	if e.Suggested == ActionRaise {
+		return NewRaise(action.PlayerID, e.NearestAmount), true
+	}
+	return Action{PlayerID: action.PlayerID, Type: e.Suggested, Amount: e.NearestAmount}, true
+}
+
+// suggest attaches the legal action of a type with the amount nearest to
+// the rejected amount
+func (e *ValidationError) suggest(actionType ActionType, rejected, minAmount, maxAmount int) *ValidationError {
+	e.CanCorrect = true
+	e.Suggested = actionType
+	e.MinAmount, e.MaxAmount = minAmount, maxAmount
+	e.NearestAmount = min(max(rejected, minAmount), maxAmount)
+	return e
+}
+
 // ValidationErrorCode represents different types of validation errors
 type ValidationErrorCode int
 
@@ -32,16 +62,30 @@ type IActionValidator interface {
 	GetAvailableActions(game *Game, player IPlayer) []ActionType
 	GetMinRaiseAmount(game *Game, player IPlayer) int
 	GetMaxRaiseAmount(game *Game, player IPlayer) int
+	GetCallAmount(game *Game, player IPlayer) int
+	GetPotOdds(game *Game, player IPlayer) float64
 }
 
 // ActionValidator provides methods for validating poker actions
-type ActionValidator struct{}
+type ActionValidator struct {
+	translator *i18n.Translator // Language of error messages, English when nil
+}
 
 // NewActionValidator creates a new action validator
 func NewActionValidator() *ActionValidator {
 	return &ActionValidator{}
 }
 
+// SetTranslator sets the language of validation error messages
+func (v *ActionValidator) SetTranslator(translator *i18n.Translator) {
+	v.translator = translator
+}
+
+// text returns a translated validation message
+func (v *ActionValidator) text(key string, args ...any) string {
+	return v.translator.T(key, args...)
+}
+
 // ValidateAction validates if an action is legal in the current game state
 func (v *ActionValidator) ValidateAction(game *Game, player IPlayer, action Action) *ValidationError {
 	// Basic validations
@@ -73,63 +117,81 @@ func (v *ActionValidator) ValidateAction(game *Game, player IPlayer, action Acti
 		return v.validateRaise(game, player, action)
 	case ActionAllIn:
 		return v.validateAllIn(game, player, action)
+	case ActionDisconnect:
+		return v.validateDisconnect(game, action)
 	default:
 		return &ValidationError{
-			Message: fmt.Sprintf("Unknown action type: %d", action.Type),
+			Message: v.text("validation.unknown_action", action.Type),
 			Code:    ErrorInvalidAction,
 		}
 	}
 }
 
-// GetAvailableActions returns all valid actions for a player in current game state
-func (v *ActionValidator) GetAvailableActions(game *Game, player IPlayer) []ActionType {
-	var actions []ActionType
+// Constraints is everything a player may do at their turn, see
+// ValidatorConstraints
+type Constraints struct {
+	Actions   []ActionType // Legal action types, as GetAvailableActions returns them
+	Call      int          // Chips to call, 0 when checking is free
+	MinRaise  int          // Chips the smallest raise puts in, the call included
+	MaxRaise  int          // Chips the largest raise puts in, the call included
+	AllIn     int          // Chips going all-in puts in
+	FreeCheck bool         // The player may check
+}
 
-	// Basic validations
+// ValidatorConstraints works out the legal actions of a player and the
+// amounts they take in one pass. It agrees with GetAvailableActions,
+// GetCallAmount, GetMinRaiseAmount and GetMaxRaiseAmount, which outside a
+// hand run by the game each replay the street's logged actions again.
+func ValidatorConstraints(game *Game, player IPlayer) Constraints {
+	c := Constraints{}
 	if game == nil || player == nil {
-		return actions
+		return c
 	}
-
-	if player.IsFolded() {
-		return actions // No actions available for folded players
+	currentBet, lastRaise := game.currentBet, game.lastRaise
+	if !game.handActive {
+		bets := game.loggedStreetBets()
+		currentBet, lastRaise = bets.current, bets.lastRaise
 	}
-
-	currentBet := v.getCurrentBet(game)
-	playerBet := player.GetBet()
-	callAmount := currentBet - playerBet
-
-	// Ensure call amount is not negative
-	if callAmount < 0 {
-		callAmount = 0
+	chips := player.GetChips()
+	c.Call = max(currentBet-player.GetBet(), 0)
+	c.MinRaise = c.Call + lastRaise
+	c.MaxRaise = chips
+	if game.GetVariant().BettingStructure() == PotLimit {
+		c.MaxRaise = min(chips, c.Call+game.GetPot()+c.Call)
 	}
-
-	// Always can fold (unless already folded)
-	actions = append(actions, ActionFold)
-
-	// Check if player can check
-	if callAmount == 0 {
-		actions = append(actions, ActionCheck)
+	c.AllIn = chips
+	if player.IsFolded() {
+		return c // No actions available for folded players
 	}
 
-	// Check if player can call
-	if callAmount > 0 && player.GetChips() >= callAmount {
-		actions = append(actions, ActionCall)
+	c.Actions = append(c.Actions, ActionFold)
+	if c.Call == 0 {
+		c.FreeCheck = true
+		c.Actions = append(c.Actions, ActionCheck)
 	}
-
-	// Check if player can raise
-	if v.canPlayerRaise(game, player) {
-		actions = append(actions, ActionRaise)
+	if c.Call > 0 && chips >= c.Call {
+		c.Actions = append(c.Actions, ActionCall)
 	}
-
-	// Check if player can go all-in
-	if player.GetChips() > 0 {
-		actions = append(actions, ActionAllIn)
+	// Nobody left to call leaves nothing to raise, and a short all-in
+	// leaves the players who acted before it to call or fold
+	reopened := bettingReopened(game, player)
+	if chips >= c.MinRaise && reopened && othersCanBet(game, player) {
+		c.Actions = append(c.Actions, ActionRaise)
 	}
+	// All-in is capped in pot-limit games like any raise
+	if chips > 0 && chips <= c.MaxRaise && (reopened || chips <= c.Call) {
+		c.Actions = append(c.Actions, ActionAllIn)
+	}
+	return c
+}
 
-	return actions
+// GetAvailableActions returns all valid actions for a player in current game state
+func (v *ActionValidator) GetAvailableActions(game *Game, player IPlayer) []ActionType {
+	return ValidatorConstraints(game, player).Actions
 }
 
-// GetMinRaiseAmount returns the minimum raise amount for a player
+// GetMinRaiseAmount returns the chips a player puts in with the smallest
+// raise, the call included; it raises to the player's bet plus this amount
 func (v *ActionValidator) GetMinRaiseAmount(game *Game, player IPlayer) int {
 	if game == nil || player == nil {
 		return 0
@@ -143,48 +205,61 @@ func (v *ActionValidator) GetMinRaiseAmount(game *Game, player IPlayer) int {
 		callAmount = 0
 	}
 
-	// Minimum raise is typically the big blind
-	minRaise := game.GetBigBlind()
-
-	// If there's already a bet, minimum raise is the difference between current bet and previous bet
-	if currentBet > 0 {
-		// Find the previous bet amount to calculate minimum raise
-		actions := v.getCurrentPhaseActions(game)
-		prevBet := 0
-		for _, action := range actions {
-			if action.Type == ActionRaise && action.Amount < currentBet {
-				prevBet = action.Amount
-			}
-		}
-		if prevBet > 0 {
-			minRaise = currentBet - prevBet
-		}
+	// Hands run by the game track the last full raise themselves
+	if game.handActive {
+		return callAmount + game.lastRaise
 	}
 
-	return callAmount + minRaise
+	// Without a managed hand the last full raise is inferred from the logged actions
+	return callAmount + game.loggedStreetBets().lastRaise
 }
 
-// GetMaxRaiseAmount returns the maximum raise amount for a player (all-in)
+// GetMaxRaiseAmount returns the chips a player puts in with the largest
+// raise, the call included: all-in, or in pot-limit games calling and then
+// raising the size of the pot
 func (v *ActionValidator) GetMaxRaiseAmount(game *Game, player IPlayer) int {
 	if game == nil || player == nil {
 		return 0
 	}
 
-	return player.GetChips()
+	if game.GetVariant().BettingStructure() != PotLimit {
+		return player.GetChips()
+	}
+	callAmount := max(v.getCurrentBet(game)-player.GetBet(), 0)
+	return min(player.GetChips(), callAmount+game.GetPot()+callAmount)
+}
+
+// GetCallAmount returns the chips a player needs to call, 0 when they may
+// check. Antes are dead money: they are in the pot but never part of a bet,
+// so they never count towards a call.
+func (v *ActionValidator) GetCallAmount(game *Game, player IPlayer) int {
+	if game == nil || player == nil {
+		return 0
+	}
+	return max(v.getCurrentBet(game)-player.GetBet(), 0)
+}
+
+// GetPotOdds returns the share of the pot after a call that the call puts
+// in: the equity the player needs to break even. The pot includes the
+// antes; a player short of the call counts what they have. 0 when there is
+// nothing to call.
+func (v *ActionValidator) GetPotOdds(game *Game, player IPlayer) float64 {
+	call := min(v.GetCallAmount(game, player), player.GetChips())
+	return pokermath.PotOdds(game.GetPot(), call)
 }
 
 // Basic validation functions
 func (v *ActionValidator) validateBasicAction(action Action) *ValidationError {
 	if action.PlayerID <= 0 {
 		return &ValidationError{
-			Message: "Invalid player ID",
+			Message: v.text("validation.invalid_player"),
 			Code:    ErrorInvalidPlayer,
 		}
 	}
 
 	if action.Amount < 0 {
 		return &ValidationError{
-			Message: "Action amount cannot be negative",
+			Message: v.text("validation.negative_amount"),
 			Code:    ErrorInvalidAmount,
 		}
 	}
@@ -195,21 +270,21 @@ func (v *ActionValidator) validateBasicAction(action Action) *ValidationError {
 func (v *ActionValidator) validatePlayer(game *Game, player IPlayer, actionPlayerID int) *ValidationError {
 	if player == nil {
 		return &ValidationError{
-			Message: "Player is nil",
+			Message: v.text("validation.nil_player"),
 			Code:    ErrorInvalidPlayer,
 		}
 	}
 
 	if player.GetID() != actionPlayerID {
 		return &ValidationError{
-			Message: "Player ID mismatch",
+			Message: v.text("validation.player_mismatch"),
 			Code:    ErrorInvalidPlayer,
 		}
 	}
 
 	if player.IsFolded() {
 		return &ValidationError{
-			Message: "Player has already folded",
+			Message: v.text("validation.folded"),
 			Code:    ErrorActionNotAllowed,
 		}
 	}
@@ -220,7 +295,7 @@ func (v *ActionValidator) validatePlayer(game *Game, player IPlayer, actionPlaye
 func (v *ActionValidator) validateGameState(game *Game) *ValidationError {
 	if game == nil {
 		return &ValidationError{
-			Message: "Game is nil",
+			Message: v.text("validation.nil_game"),
 			Code:    ErrorGameState,
 		}
 	}
@@ -229,7 +304,7 @@ func (v *ActionValidator) validateGameState(game *Game) *ValidationError {
 	phase := game.GetCurrentPhase()
 	if phase == PhaseShowdown {
 		return &ValidationError{
-			Message: "No actions allowed during showdown",
+			Message: v.text("validation.showdown"),
 			Code:    ErrorGameState,
 		}
 	}
@@ -241,14 +316,14 @@ func (v *ActionValidator) validatePlayerTurn(game *Game, player IPlayer) *Valida
 	currentPlayer := game.GetCurrentPlayer()
 	if currentPlayer == nil {
 		return &ValidationError{
-			Message: "No current player",
+			Message: v.text("validation.no_current_player"),
 			Code:    ErrorOutOfTurn,
 		}
 	}
 
 	if currentPlayer.GetID() != player.GetID() {
 		return &ValidationError{
-			Message: "Not player's turn",
+			Message: v.text("validation.not_your_turn"),
 			Code:    ErrorOutOfTurn,
 		}
 	}
@@ -259,31 +334,31 @@ func (v *ActionValidator) validatePlayerTurn(game *Game, player IPlayer) *Valida
 // Action-specific validation functions
 func (v *ActionValidator) validateFold(game *Game, player IPlayer, action Action) *ValidationError {
 	if action.Amount != 0 {
-		return &ValidationError{
-			Message: "Fold action should have amount 0",
+		return (&ValidationError{
+			Message: v.text("validation.fold_amount"),
 			Code:    ErrorInvalidAmount,
-		}
+		}).suggest(ActionFold, 0, 0, 0)
 	}
 
 	return nil
 }
 
 func (v *ActionValidator) validateCheck(game *Game, player IPlayer, action Action) *ValidationError {
-	if action.Amount != 0 {
-		return &ValidationError{
-			Message: "Check action should have amount 0",
-			Code:    ErrorInvalidAmount,
-		}
-	}
-
 	currentBet := v.getCurrentBet(game)
 	playerBet := player.GetBet()
 
 	if currentBet > playerBet {
-		return &ValidationError{
-			Message: "Cannot check when there is a bet to call",
+		return v.suggestCall(player, currentBet-playerBet, &ValidationError{
+			Message: v.text("validation.check_facing_bet"),
 			Code:    ErrorActionNotAllowed,
-		}
+		})
+	}
+
+	if action.Amount != 0 {
+		return (&ValidationError{
+			Message: v.text("validation.check_amount"),
+			Code:    ErrorInvalidAmount,
+		}).suggest(ActionCheck, 0, 0, 0)
 	}
 
 	return nil
@@ -295,127 +370,194 @@ func (v *ActionValidator) validateCall(game *Game, player IPlayer, action Action
 	callAmount := currentBet - playerBet
 
 	if callAmount <= 0 {
-		return &ValidationError{
-			Message: "No bet to call",
-			Code:    ErrorActionNotAllowed,
-		}
-	}
-
-	if action.Amount != callAmount {
-		return &ValidationError{
-			Message: fmt.Sprintf("Call amount should be %d, got %d", callAmount, action.Amount),
-			Code:    ErrorInvalidAmount,
+		message := v.text("validation.nothing_to_call")
+		if game.HasOption(player) {
+			message = v.text("validation.big_blind_option")
 		}
+		return (&ValidationError{
+			Message: message,
+			Code:    ErrorActionNotAllowed,
+		}).suggest(ActionCheck, 0, 0, 0)
 	}
 
 	if player.GetChips() < callAmount {
-		return &ValidationError{
-			Message: "Insufficient chips to call",
+		return v.suggestCall(player, callAmount, &ValidationError{
+			Message: v.text("validation.call_short"),
 			Code:    ErrorInsufficientChips,
-		}
+		})
+	}
+
+	if action.Amount != callAmount {
+		return v.suggestCall(player, callAmount, &ValidationError{
+			Message: v.text("validation.call_amount", callAmount, action.Amount),
+			Code:    ErrorInvalidAmount,
+		})
 	}
 
 	return nil
 }
 
 func (v *ActionValidator) validateRaise(game *Game, player IPlayer, action Action) *ValidationError {
-	if action.Amount <= 0 {
-		return &ValidationError{
-			Message: "Raise amount must be positive",
-			Code:    ErrorInvalidAmount,
-		}
-	}
-
 	currentBet := v.getCurrentBet(game)
 	playerBet := player.GetBet()
-	callAmount := currentBet - playerBet
 
-	if callAmount < 0 {
-		callAmount = 0
+	if !bettingReopened(game, player) {
+		return v.suggestCall(player, max(currentBet-playerBet, 0), &ValidationError{
+			Message: v.text("validation.raise_not_reopened"),
+			Code:    ErrorActionNotAllowed,
+		})
 	}
 
-	totalRequired := callAmount + action.Amount
+	if action.RaiseTo <= currentBet {
+		return v.suggestRaise(game, player, action.RaiseTo, &ValidationError{
+			Message: v.text("validation.raise_not_above_bet", currentBet, action.RaiseTo),
+			Code:    ErrorInvalidAmount,
+		})
+	}
 
+	// Every chip the raise puts in, the call included
+	totalRequired := action.RaiseTo - playerBet
 	if player.GetChips() < totalRequired {
-		return &ValidationError{
-			Message: "Insufficient chips to raise",
+		return v.suggestRaise(game, player, action.RaiseTo, &ValidationError{
+			Message: v.text("validation.raise_short"),
 			Code:    ErrorInsufficientChips,
-		}
+		})
 	}
 
 	minRaise := v.GetMinRaiseAmount(game, player)
 	if totalRequired < minRaise {
-		return &ValidationError{
-			Message: fmt.Sprintf("Raise amount too small. Minimum: %d, got: %d", minRaise, totalRequired),
+		return v.suggestRaise(game, player, action.RaiseTo, &ValidationError{
+			Message: v.text("validation.raise_too_small", playerBet+minRaise, action.RaiseTo),
 			Code:    ErrorInvalidAmount,
-		}
+		})
 	}
 
-	return nil
-}
+	if maxRaise := v.GetMaxRaiseAmount(game, player); totalRequired > maxRaise {
+		return v.suggestRaise(game, player, action.RaiseTo, &ValidationError{
+			Message: v.text("validation.raise_over_pot_limit", playerBet+maxRaise, action.RaiseTo),
+			Code:    ErrorInvalidAmount,
+		})
+	}
 
-func (v *ActionValidator) validateAllIn(game *Game, player IPlayer, action Action) *ValidationError {
-	if action.Amount != player.GetChips() {
-		return &ValidationError{
-			Message: fmt.Sprintf("All-in amount should be %d (all chips), got %d", player.GetChips(), action.Amount),
+	if action.Amount != 0 && action.Amount != totalRequired {
+		return v.suggestRaise(game, player, action.RaiseTo, &ValidationError{
+			Message: v.text("validation.raise_chips", action.RaiseTo, totalRequired, action.Amount),
 			Code:    ErrorInvalidAmount,
-		}
+		})
 	}
 
+	return nil
+}
+
+func (v *ActionValidator) validateAllIn(game *Game, player IPlayer, action Action) *ValidationError {
 	if player.GetChips() <= 0 {
 		return &ValidationError{
-			Message: "Player has no chips to go all-in",
+			Message: v.text("validation.allin_no_chips"),
 			Code:    ErrorInsufficientChips,
 		}
 	}
 
+	// Going all in for more than the call raises
+	if callAmount := max(v.getCurrentBet(game)-player.GetBet(), 0); player.GetChips() > callAmount && !bettingReopened(game, player) {
+		return v.suggestCall(player, callAmount, &ValidationError{
+			Message: v.text("validation.raise_not_reopened"),
+			Code:    ErrorActionNotAllowed,
+		})
+	}
+
+	if maxRaise := v.GetMaxRaiseAmount(game, player); player.GetChips() > maxRaise {
+		return v.suggestRaise(game, player, player.GetBet()+maxRaise, &ValidationError{
+			Message: v.text("validation.allin_over_pot_limit", maxRaise, player.GetChips()),
+			Code:    ErrorActionNotAllowed,
+		})
+	}
+
+	if action.Amount != player.GetChips() {
+		return (&ValidationError{
+			Message: v.text("validation.allin_amount", player.GetChips(), action.Amount),
+			Code:    ErrorInvalidAmount,
+		}).suggest(ActionAllIn, player.GetChips(), player.GetChips(), player.GetChips())
+	}
+
 	return nil
 }
 
-// Helper functions
-func (v *ActionValidator) getCurrentBet(game *Game) int {
-	actions := v.getCurrentPhaseActions(game)
-	maxBet := 0
-
-	for _, action := range actions {
-		if action.Type == ActionRaise || action.Type == ActionCall {
-			if action.Amount > maxBet {
-				maxBet = action.Amount
-			}
+// validateDisconnect accepts a disconnection at tables with all-in protection
+func (v *ActionValidator) validateDisconnect(game *Game, action Action) *ValidationError {
+	if game.GetConfig().Disconnect != DisconnectAllIn {
+		return &ValidationError{
+			Message: v.text("validation.no_all_in_protection"),
+			Code:    ErrorInvalidAction,
 		}
 	}
+	if action.Amount != 0 {
+		return &ValidationError{
+			Message: v.text("validation.disconnect_amount"),
+			Code:    ErrorInvalidAmount,
+		}
+	}
+	return nil
+}
 
-	return maxBet
+// suggestCall suggests calling, going all-in for less when the player is
+// short, or checking when there is nothing to call
+func (v *ActionValidator) suggestCall(player IPlayer, callAmount int, err *ValidationError) *ValidationError {
+	if callAmount <= 0 {
+		return err.suggest(ActionCheck, 0, 0, 0)
+	}
+	if chips := player.GetChips(); chips < callAmount {
+		return err.suggest(ActionAllIn, chips, chips, chips)
+	}
+	return err.suggest(ActionCall, callAmount, callAmount, callAmount)
 }
 
-func (v *ActionValidator) getCurrentPhaseActions(game *Game) []Action {
-	userActions := game.GetUserActions()
-
-	switch game.GetCurrentPhase() {
-	case PhasePreflop:
-		return userActions.Preflop
-	case PhaseFlop:
-		return userActions.Flop
-	case PhaseTurn:
-		return userActions.Turn
-	case PhaseRiver:
-		return userActions.River
-	default:
-		return []Action{}
+// suggestRaise suggests the legal raise nearest to raiseTo, or all-in or a
+// call when the player cannot make a full raise
+func (v *ActionValidator) suggestRaise(game *Game, player IPlayer, raiseTo int, err *ValidationError) *ValidationError {
+	minRaise, maxRaise := v.GetMinRaiseAmount(game, player), v.GetMaxRaiseAmount(game, player)
+	if player.GetChips() >= minRaise && minRaise <= maxRaise {
+		return err.suggest(ActionRaise, raiseTo, player.GetBet()+minRaise, player.GetBet()+maxRaise)
+	}
+	if chips := player.GetChips(); chips <= maxRaise {
+		return err.suggest(ActionAllIn, chips, chips, chips)
 	}
+	return v.suggestCall(player, max(v.getCurrentBet(game)-player.GetBet(), 0), err)
 }
 
-func (v *ActionValidator) canPlayerRaise(game *Game, player IPlayer) bool {
-	currentBet := v.getCurrentBet(game)
-	playerBet := player.GetBet()
-	callAmount := currentBet - playerBet
+// bettingReopened reports whether the betting is open for a player to
+// raise. A short all-in does not reopen it for players who acted before it;
+// outside a hand run by the game there is nothing to tell, and raises stay
+// open.
+func bettingReopened(game *Game, player IPlayer) bool {
+	if !game.handActive {
+		return true
+	}
+	seat, err := game.GetPlayerSitByID(player.GetID())
+	return err != nil || game.reopenedFor(seat)
+}
 
-	if callAmount < 0 {
-		callAmount = 0
+// othersCanBet reports whether a player still in the hand besides player
+// has chips behind to call a raise with. Outside a hand run by the game the
+// table may be set up only in part, and raises stay open.
+func othersCanBet(game *Game, player IPlayer) bool {
+	if !game.handActive {
+		return true
 	}
+	for seat, other := range game.players {
+		if other != nil && other.GetID() != player.GetID() && game.canBet(seat) {
+			return true
+		}
+	}
+	return false
+}
 
-	minRaise := v.GetMinRaiseAmount(game, player)
-	return player.GetChips() >= minRaise
+// Helper functions
+func (v *ActionValidator) getCurrentBet(game *Game) int {
+	return game.GetCurrentBet()
+}
+
+func (v *ActionValidator) getCurrentPhaseActions(game *Game) []Action {
+	return game.GetUserActions().Street(game.GetCurrentPhase())
 }
 
 // Utility functions for external use
@@ -427,6 +569,12 @@ func IsValidActionType(actionType ActionType) bool {
 		return true
 	case ActionSystemShuffle, ActionSystemDealHole, ActionSystemDealFlop, ActionSystemDealTurn, ActionSystemDealRiver, ActionSystemPhaseChange:
 		return true
+	case ActionPostAnte, ActionPostBlind, ActionPostSmallBlind, ActionPostBigBlind, ActionPostStraddle:
+		return true
+	case ActionSystemButton, ActionSystemAwardPot, ActionSystemBombPot, ActionSystemRake, ActionSystemAbort:
+		return true
+	case ActionDisconnect, ActionShow, ActionMuck:
+		return true
 	default:
 		return false
 	}
@@ -457,11 +605,64 @@ func ActionTypeToString(actionType ActionType) string {
 		return "System: Deal River"
 	case ActionSystemPhaseChange:
 		return "System: Phase Change"
+	case ActionPostAnte:
+		return "Post Ante"
+	case ActionPostBlind:
+		return "Post Blind"
+	case ActionPostSmallBlind:
+		return "Post Small Blind"
+	case ActionPostBigBlind:
+		return "Post Big Blind"
+	case ActionPostStraddle:
+		return "Post Straddle"
+	case ActionSystemButton:
+		return "System: Button"
+	case ActionSystemAwardPot:
+		return "System: Award Pot"
+	case ActionSystemBombPot:
+		return "System: Bomb Pot"
+	case ActionSystemRake:
+		return "System: Rake"
+	case ActionSystemAbort:
+		return "System: Abort Hand"
+	case ActionDisconnect:
+		return "Disconnect"
+	case ActionShow:
+		return "Show"
+	case ActionMuck:
+		return "Muck"
 	default:
 		return "Unknown"
 	}
 }
 
+// actionKeys are the catalog keys of actions players take or post
+var actionKeys = map[ActionType]string{
+	ActionFold:           "action.fold",
+	ActionCheck:          "action.check",
+	ActionCall:           "action.call",
+	ActionRaise:          "action.raise",
+	ActionAllIn:          "action.all_in",
+	ActionPostAnte:       "action.post_ante",
+	ActionPostBlind:      "action.post_blind",
+	ActionPostSmallBlind: "action.post_small_blind",
+	ActionPostBigBlind:   "action.post_big_blind",
+	ActionPostStraddle:   "action.post_straddle",
+
+	ActionDisconnect: "action.disconnect",
+	ActionShow:       "action.show",
+	ActionMuck:       "action.muck",
+}
+
+// ActionName returns the translated name of a player action. System
+// actions are not shown to players and keep their English names.
+func ActionName(translator *i18n.Translator, actionType ActionType) string {
+	if key, ok := actionKeys[actionType]; ok {
+		return translator.T(key)
+	}
+	return ActionTypeToString(actionType)
+}
+
 // ValidationErrorCodeToString converts validation error code to string
 func ValidationErrorCodeToString(code ValidationErrorCode) string {
 	switch code {
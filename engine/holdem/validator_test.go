@@ -1,7 +1,10 @@
 package holdem
 
 import (
+	"slices"
 	"testing"
+
+	"github.com/ljbink/ai-poker/engine/i18n"
 )
 
 func TestNewActionValidator(t *testing.T) {
@@ -173,7 +176,7 @@ func TestValidateCheck(t *testing.T) {
 	}
 
 	// Create a bet scenario to test invalid check
-	game.TakeAction(Action{PlayerID: 2, Type: ActionRaise, Amount: 50})
+	game.TakeAction(NewRaise(2, 50))
 	action = Action{PlayerID: 1, Type: ActionCheck, Amount: 0}
 	err = validator.ValidateAction(game, player, action)
 	if err == nil {
@@ -190,7 +193,7 @@ func TestValidateCall(t *testing.T) {
 	game.PlayerSit(player2, 1)
 
 	// Setup a bet to call
-	game.TakeAction(Action{PlayerID: 2, Type: ActionRaise, Amount: 50})
+	game.TakeAction(NewRaise(2, 50))
 
 	// Test valid call
 	action := Action{PlayerID: 1, Type: ActionCall, Amount: 50}
@@ -215,7 +218,7 @@ func TestValidateCall(t *testing.T) {
 	player2 = NewPlayer(2, "Player 2", 1000)
 	game.PlayerSit(player1, 0)
 	game.PlayerSit(player2, 1)
-	game.TakeAction(Action{PlayerID: 2, Type: ActionRaise, Amount: 60})
+	game.TakeAction(NewRaise(2, 60))
 	action = Action{PlayerID: 1, Type: ActionCall, Amount: 50}
 	err = validator.ValidateAction(game, player1, action)
 	if err == nil {
@@ -245,27 +248,86 @@ func TestValidateRaise(t *testing.T) {
 	game.PlayerSit(player, 0)
 
 	// Test valid raise
-	action := Action{PlayerID: 1, Type: ActionRaise, Amount: 40}
+	action := NewRaise(1, 40)
 	err := validator.ValidateAction(game, player, action)
 	if err != nil {
 		t.Errorf("Unexpected error for valid raise: %v", err)
 	}
 
 	// Test raise with insufficient chips
-	action = Action{PlayerID: 1, Type: ActionRaise, Amount: 1500}
+	action = NewRaise(1, 1500)
 	err = validator.ValidateAction(game, player, action)
 	if err == nil {
 		t.Error("Expected error for raise with insufficient chips")
 	}
 
 	// Test raise below minimum
-	action = Action{PlayerID: 1, Type: ActionRaise, Amount: 5}
+	action = NewRaise(1, 5)
 	err = validator.ValidateAction(game, player, action)
 	if err == nil {
 		t.Error("Expected error for raise below minimum")
 	}
 }
 
+func TestValidationErrorSuggestsCorrection(t *testing.T) {
+	validator := NewActionValidator()
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000)
+	game.StartHand(0)
+	player := game.GetCurrentPlayer()
+	id := player.GetID()
+
+	cases := []struct {
+		name     string
+		action   Action
+		want     Action
+		min, max int
+	}{
+		{"call short", Action{PlayerID: id, Type: ActionCall, Amount: 5}, Action{PlayerID: id, Type: ActionCall, Amount: 10}, 10, 10},
+		{"check facing a bet", Action{PlayerID: id, Type: ActionCheck}, Action{PlayerID: id, Type: ActionCall, Amount: 10}, 10, 10},
+		{"raise too small", NewRaise(id, 15), NewRaise(id, 20), 20, 1000},
+		{"raise too big", NewRaise(id, 5000), NewRaise(id, 1000), 20, 1000},
+		{"raise miscounted", Action{PlayerID: id, Type: ActionRaise, Amount: 20, RaiseTo: 40}, NewRaise(id, 40), 20, 1000},
+		{"all-in short", Action{PlayerID: id, Type: ActionAllIn, Amount: 500}, Action{PlayerID: id, Type: ActionAllIn, Amount: 1000}, 1000, 1000},
+		{"fold with chips", Action{PlayerID: id, Type: ActionFold, Amount: 5}, Action{PlayerID: id, Type: ActionFold}, 0, 0},
+	}
+	for _, tc := range cases {
+		err := validator.ValidateAction(game, player, tc.action)
+		if err == nil {
+			t.Errorf("%s: expected a rejection", tc.name)
+			continue
+		}
+		corrected, ok := err.Correct(tc.action)
+		if !ok || corrected != tc.want || err.MinAmount != tc.min || err.MaxAmount != tc.max {
+			t.Errorf("%s: expected %+v in [%d, %d], got %+v in [%d, %d]", tc.name, tc.want, tc.min, tc.max, corrected, err.MinAmount, err.MaxAmount)
+		}
+		if verr := validator.ValidateAction(game, player, corrected); verr != nil {
+			t.Errorf("%s: correction rejected: %v", tc.name, verr)
+		}
+	}
+
+	other, _ := game.GetPlayerByID(2)
+	err := validator.ValidateAction(game, other, Action{PlayerID: 2, Type: ActionFold})
+	if _, ok := err.Correct(Action{PlayerID: 2, Type: ActionFold}); err == nil || ok {
+		t.Error("Expected no correction for acting out of turn")
+	}
+}
+
+func TestValidationErrorSuggestsAllInWhenShort(t *testing.T) {
+	validator := NewActionValidator()
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 30)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionRaise, 100)
+	mustAct(t, game, 2, ActionFold, 0)
+	short := game.GetCurrentPlayer()
+
+	for _, action := range []Action{{PlayerID: 3, Type: ActionCall, Amount: 90}, NewRaise(3, 200)} {
+		err := validator.ValidateAction(game, short, action)
+		if err == nil || !err.CanCorrect || err.Suggested != ActionAllIn || err.NearestAmount != 20 {
+			t.Errorf("Expected %s to be corrected to all-in for 20, got %+v", ActionTypeToString(action.Type), err)
+		}
+	}
+}
+
 func TestValidateAllIn(t *testing.T) {
 	validator := NewActionValidator()
 	game := NewGame(10, 20)
@@ -351,7 +413,7 @@ func TestGetAvailableActionsWithBet(t *testing.T) {
 	game.PlayerSit(player2, 1)
 
 	// Setup a bet
-	game.TakeAction(Action{PlayerID: 2, Type: ActionRaise, Amount: 50})
+	game.TakeAction(NewRaise(2, 50))
 
 	// Test available actions with a bet to call
 	actions := validator.GetAvailableActions(game, player1)
@@ -399,8 +461,8 @@ func TestGetMinRaiseAmount(t *testing.T) {
 	game.PlayerSit(playerA, 0)
 	game.PlayerSit(playerB, 1)
 	// Player A raises to 40, then Player B raises to 80; min next raise = 80-40 = 40
-	game.TakeAction(Action{PlayerID: 1, Type: ActionRaise, Amount: 40})
-	game.TakeAction(Action{PlayerID: 2, Type: ActionRaise, Amount: 80})
+	game.TakeAction(NewRaise(1, 40))
+	game.TakeAction(NewRaise(2, 80))
 	got := validator.GetMinRaiseAmount(game, playerA)
 	// Compute expected as callAmount + min increment (40)
 	currentBet := validator.getCurrentBet(game)
@@ -438,6 +500,76 @@ func TestGetMaxRaiseAmount(t *testing.T) {
 	}
 }
 
+func TestAnteGameValidatorMath(t *testing.T) {
+	validator := NewActionValidator()
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Ante: 5}, 1000, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+
+	// First to act calls the big blind into 15 in antes and 15 in blinds
+	utg := game.GetCurrentPlayer()
+	if call := validator.GetCallAmount(game, utg); call != 10 {
+		t.Errorf("Expected a call of 10 without the ante, got %d", call)
+	}
+	if odds := validator.GetPotOdds(game, utg); odds != 0.25 {
+		t.Errorf("Expected pot odds of 10/40, got %.3f", odds)
+	}
+	if minRaise := validator.GetMinRaiseAmount(game, utg); minRaise != 20 {
+		t.Errorf("Expected a min raise of 20 chips, got %d", minRaise)
+	}
+	actions := validator.GetAvailableActions(game, utg)
+	if !slices.Contains(actions, ActionCall) || slices.Contains(actions, ActionCheck) {
+		t.Errorf("Expected call but no check facing the big blind, got %v", actions)
+	}
+	mustAct(t, game, utg.GetID(), ActionCall, 10)
+	mustAct(t, game, game.GetCurrentPlayer().GetID(), ActionCall, 5)
+
+	// The big blind's ante does not leave them short of the bet
+	bigBlind := game.GetCurrentPlayer()
+	if call := validator.GetCallAmount(game, bigBlind); call != 0 {
+		t.Errorf("Expected nothing to call on the option, got %d", call)
+	}
+	if odds := validator.GetPotOdds(game, bigBlind); odds != 0 {
+		t.Errorf("Expected no pot odds with nothing to call, got %.3f", odds)
+	}
+	if actions := validator.GetAvailableActions(game, bigBlind); !slices.Contains(actions, ActionCheck) {
+		t.Errorf("Expected the big blind to check the option, got %v", actions)
+	}
+}
+
+func TestPotLimitRaiseIncludesAntes(t *testing.T) {
+	validator := NewActionValidator()
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Ante: 5, Variant: VariantOmaha}, 1000, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+
+	// Call 10, then raise the 40 in the pot after the call
+	utg := game.GetCurrentPlayer()
+	if maxRaise := validator.GetMaxRaiseAmount(game, utg); maxRaise != 50 {
+		t.Errorf("Expected a pot-size raise of 50 chips with the antes, got %d", maxRaise)
+	}
+}
+
+func TestPotOddsShortStack(t *testing.T) {
+	validator := NewActionValidator()
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Ante: 5}, 1000, 100, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	mustAct(t, game, game.GetCurrentPlayer().GetID(), ActionRaise, 200)
+
+	// Short of the call, the small blind can only put in the 90 they have left
+	short := game.GetCurrentPlayer()
+	if call := validator.GetCallAmount(game, short); call != 195 {
+		t.Errorf("Expected a call of 195, got %d", call)
+	}
+	if odds, want := validator.GetPotOdds(game, short), 90.0/(230+90); odds != want {
+		t.Errorf("Expected pot odds of %.3f for the rest of the stack, got %.3f", want, odds)
+	}
+}
+
 func TestUnknownActionType(t *testing.T) {
 	validator := NewActionValidator()
 	game := NewGame(10, 20)
@@ -498,7 +630,7 @@ func TestComplexGameScenario(t *testing.T) {
 	game.PlayerSit(player3, 2)
 
 	// Player 1 raises (current player)
-	action := Action{PlayerID: 1, Type: ActionRaise, Amount: 60}
+	action := NewRaise(1, 60)
 	err := validator.ValidateAction(game, player1, action)
 	if err != nil {
 		t.Errorf("Unexpected error for player 1 raise: %v", err)
@@ -537,7 +669,7 @@ func TestPlayerBettingHistory(t *testing.T) {
 
 	// Test that current bet affects validation
 	// This should set up the scenario for proper betting calculations
-	action := Action{PlayerID: 1, Type: ActionRaise, Amount: 50}
+	action := NewRaise(1, 80) // 50 on top of the 30 already in
 	err := validator.ValidateAction(game, player, action)
 	if err != nil {
 		t.Errorf("Unexpected error for raise after bet: %v", err)
@@ -680,3 +812,242 @@ func TestValidateAllInEdgeCases(t *testing.T) {
 		t.Errorf("Expected ErrorInsufficientChips for negative chips, got %d", err.Code)
 	}
 }
+
+// benchmarkValidatorGame starts a six-handed hand with the first player to act facing the big blind
+func benchmarkValidatorGame(b *testing.B) (*Game, IPlayer) {
+	game := newManagedGame(b, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 1000, 1000, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		b.Fatalf("StartHand failed: %v", err)
+	}
+	return game, game.GetCurrentPlayer()
+}
+
+func BenchmarkValidateAction(b *testing.B) {
+	validator := NewActionValidator()
+	game, player := benchmarkValidatorGame(b)
+	actions := []Action{
+		{PlayerID: player.GetID(), Type: ActionFold},
+		{PlayerID: player.GetID(), Type: ActionCall, Amount: 10},
+		NewRaise(player.GetID(), 40),
+		{PlayerID: player.GetID(), Type: ActionCheck}, // Rejected, facing a bet
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		validator.ValidateAction(game, player, actions[i%len(actions)])
+	}
+}
+
+func BenchmarkGetAvailableActions(b *testing.B) {
+	validator := NewActionValidator()
+	game, player := benchmarkValidatorGame(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		validator.GetAvailableActions(game, player)
+	}
+}
+
+// totalChips adds up the chips behind and in the pot
+func totalChips(game *Game) int {
+	total := 0
+	if game.IsHandInProgress() {
+		total = game.GetPot()
+	}
+	for _, player := range game.GetAllPlayers() {
+		total += player.GetChips()
+	}
+	return total
+}
+
+// FuzzTakeAction plays a hand from fuzzed stacks and actions. The first
+// byte picks the table size, the next ones the stacks, and every action
+// after that takes three bytes: who acts, the action type and the amount.
+func FuzzTakeAction(f *testing.F) {
+	f.Add([]byte{2, 50, 50, 0, byte(ActionCall), 5, 0, byte(ActionCheck), 0})
+	f.Add([]byte{4, 10, 200, 3, 90, 0, byte(ActionRaise), 30, 0, byte(ActionAllIn), 255, 1, byte(ActionFold), 0})
+	f.Add([]byte{3, 1, 1, 1, 0, byte(ActionAllIn), 10, 0, byte(ActionAllIn), 10, 0, byte(ActionCall), 10})
+	f.Add([]byte{6, 100, 100, 100, 100, 100, 100, 9, 9, 9, 0, 200, 200})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) < 1 {
+			return
+		}
+		seats := 2 + int(data[0])%5
+		data = data[1:]
+		if len(data) < seats {
+			return
+		}
+		stacks := make([]int, seats)
+		for i := range stacks {
+			stacks[i] = 1 + int(data[i])*10
+		}
+		data = data[seats:]
+
+		game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, stacks...)
+		total := totalChips(game)
+		if err := game.StartHand(0); err != nil {
+			return
+		}
+		validator := NewActionValidator()
+
+		for !game.IsHandOver() {
+			if !game.IsBettingRoundOpen() {
+				var err error
+				switch game.GetCurrentPhase() {
+				case PhasePreflop:
+					err = game.DealFlop()
+				case PhaseFlop:
+					err = game.DealTurn()
+				case PhaseTurn:
+					err = game.DealRiver()
+				}
+				if err != nil {
+					t.Fatalf("Dealing failed in %s: %v", PhaseToString(game.GetCurrentPhase()), err)
+				}
+				continue
+			}
+			if len(data) < 3 {
+				return
+			}
+			player := game.GetCurrentPlayer()
+			if data[0]%4 == 3 {
+				// Now and then someone acts out of turn
+				players := game.GetAllPlayers()
+				player = players[int(data[0])%len(players)]
+			}
+			action := Action{
+				PlayerID: player.GetID(),
+				Type:     ActionType(int(data[1]) % 8),
+				Amount:   int(int8(data[2])) * 5,
+			}
+			if action.Type == ActionRaise {
+				// Raises go that far over the bet and only sometimes say what they put in
+				action.RaiseTo = game.GetCurrentBet() + action.Amount
+				if data[0]%2 == 0 {
+					action.Amount = 0
+				}
+			}
+			data = data[3:]
+
+			rejection := validator.ValidateAction(game, player, action)
+			accepted := rejection == nil
+			if !accepted {
+				if corrected, ok := rejection.Correct(action); ok {
+					if err := validator.ValidateAction(game, player, corrected); err != nil {
+						t.Fatalf("Correction of %s %d to %+v rejected: %v", ActionTypeToString(action.Type), action.Amount, corrected, err)
+					}
+				}
+			}
+			validator.GetAvailableActions(game, player)
+			err := game.TakeAction(action)
+			if accepted && err != nil {
+				t.Fatalf("Validated %s %d refused: %v", ActionTypeToString(action.Type), action.Amount, err)
+			}
+			for _, p := range game.GetAllPlayers() {
+				if p.GetChips() < 0 {
+					t.Fatalf("Player %d has %d chips after %s %d", p.GetID(), p.GetChips(), ActionTypeToString(action.Type), action.Amount)
+				}
+			}
+			if got := totalChips(game); got != total {
+				t.Fatalf("Chips not conserved after %s %d: %d, expected %d", ActionTypeToString(action.Type), action.Amount, got, total)
+			}
+			if err := game.CheckInvariants(); err != nil {
+				t.Fatalf("After %s %d: %v\n%s", ActionTypeToString(action.Type), action.Amount, err, err.(*InvariantError).Dump)
+			}
+		}
+
+		if _, err := game.AwardPot(); err != nil {
+			t.Fatalf("AwardPot failed: %v", err)
+		}
+		if got := totalChips(game); got != total {
+			t.Errorf("Chips not conserved at the end of the hand: %d, expected %d", got, total)
+		}
+	})
+}
+
+func TestValidatorTranslatesMessages(t *testing.T) {
+	validator := NewActionValidator()
+	game := NewGame(10, 20)
+	player := NewPlayer(1, "Player 1", 1000)
+	game.PlayerSit(player, 0)
+	action := Action{PlayerID: 1, Type: ActionRaise, Amount: -100}
+
+	if err := validator.ValidateAction(game, player, action); err.Message != "Action amount cannot be negative" {
+		t.Errorf("Expected the English message without a translator, got %q", err.Message)
+	}
+	validator.SetTranslator(i18n.New(i18n.Spanish))
+	if err := validator.ValidateAction(game, player, action); err.Message != "La cantidad de la acción no puede ser negativa" {
+		t.Errorf("Expected the Spanish message, got %q", err.Message)
+	}
+	if name := ActionName(i18n.New(i18n.Spanish), ActionCall); name != "Igualar" {
+		t.Errorf("Expected the Spanish name of a call, got %q", name)
+	}
+}
+
+func TestValidatorConstraints(t *testing.T) {
+	validator := NewActionValidator()
+	check := func(name string, game *Game, player IPlayer) {
+		t.Helper()
+		got := ValidatorConstraints(game, player)
+		call := validator.GetCallAmount(game, player)
+		want := Constraints{
+			Actions:   validator.GetAvailableActions(game, player),
+			Call:      call,
+			MinRaise:  validator.GetMinRaiseAmount(game, player),
+			MaxRaise:  validator.GetMaxRaiseAmount(game, player),
+			AllIn:     player.GetChips(),
+			FreeCheck: call == 0 && !player.IsFolded(),
+		}
+		if !slices.Equal(got.Actions, want.Actions) || got.Call != want.Call || got.MinRaise != want.MinRaise ||
+			got.MaxRaise != want.MaxRaise || got.AllIn != want.AllIn || got.FreeCheck != want.FreeCheck {
+			t.Errorf("%s: expected %+v, got %+v", name, want, got)
+		}
+	}
+
+	// Bets inferred from the logged actions
+	game := NewGame(10, 20)
+	player1, player2 := NewPlayer(1, "Player 1", 1000), NewPlayer(2, "Player 2", 1000)
+	game.PlayerSit(player1, 0)
+	game.PlayerSit(player2, 1)
+	check("unopened", game, player1)
+	game.TakeAction(NewRaise(2, 50))
+	check("facing a raise", game, player1)
+
+	// Hands run by the game, limit raises to the pot
+	for _, variant := range []GameVariant{VariantHoldem, VariantOmaha} {
+		game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Variant: variant}, 1000, 1000, 1000)
+		if err := game.StartHand(0); err != nil {
+			t.Fatalf("StartHand failed: %v", err)
+		}
+		check(string(variant)+" first to act", game, game.GetCurrentPlayer())
+		if err := game.TakeAction(NewRaise(game.GetCurrentPlayer().GetID(), 30)); err != nil {
+			t.Fatalf("Raise failed: %v", err)
+		}
+		check(string(variant)+" facing a raise", game, game.GetCurrentPlayer())
+	}
+
+	if c := ValidatorConstraints(nil, nil); len(c.Actions) != 0 || c.FreeCheck {
+		t.Errorf("Expected no actions without a game, got %+v", c)
+	}
+}
+
+func TestValidatorConstraintsLeaveOutRaises(t *testing.T) {
+	// A short all-in leaves the raiser to call or fold
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 1000, 45)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionRaise, 30)
+	mustAct(t, game, 2, ActionCall, 25)
+	mustAct(t, game, 3, ActionAllIn, 35)
+	if got := ValidatorConstraints(game, game.GetCurrentPlayer()).Actions; !slices.Equal(got, []ActionType{ActionFold, ActionCall}) {
+		t.Errorf("Expected fold or call after a short all-in, got %v", got)
+	}
+
+	// Nobody left with chips to call a raise
+	game = newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 1000, 100)
+	game.StartHand(0)
+	mustAct(t, game, 1, ActionRaise, 30)
+	mustAct(t, game, 2, ActionAllIn, 90)
+	if got := ValidatorConstraints(game, game.GetCurrentPlayer()).Actions; slices.Contains(got, ActionRaise) {
+		t.Errorf("Expected no raise against players all in, got %v", got)
+	}
+}
@@ -0,0 +1,101 @@
+package holdem
+
+import "sync"
+
+// GameVariant names the poker game dealt at a table
+type GameVariant string
+
+const (
+	VariantHoldem GameVariant = "holdem" // No-limit Texas Hold'em, also the zero value
+	VariantOmaha  GameVariant = "omaha"  // Pot-limit Omaha
+)
+
+// BettingStructure limits how much a player may bet or raise
+type BettingStructure int
+
+const (
+	NoLimit  BettingStructure = iota // Raises up to the whole stack
+	PotLimit                         // Raises up to the size of the pot
+)
+
+// Variant provides the rules that differ between the games the engine
+// deals: hole cards, the board, the betting structure and the showdown
+type Variant interface {
+	Name() GameVariant
+	String() string
+	HoleCards() int                     // Cards dealt to each player
+	BoardCards(phase GamePhase) int     // Community cards dealt at the start of a street
+	BettingStructure() BettingStructure // Limit on bets and raises
+	NewEvaluator() IHandEvaluator       // Evaluator for the showdown rules
+}
+
+var (
+	variantsMu sync.RWMutex
+	// variants holds every game a table can be configured with
+	variants = map[GameVariant]Variant{
+		VariantHoldem: HoldemVariant{},
+		VariantOmaha:  OmahaVariant{},
+	}
+)
+
+// RegisterVariant makes a variant available to game configs by its name,
+// replacing any variant registered under the same name
+func RegisterVariant(variant Variant) {
+	variantsMu.Lock()
+	defer variantsMu.Unlock()
+	variants[variant.Name()] = variant
+}
+
+// Rules returns the variant's rules, Hold'em for empty or unknown names
+func (v GameVariant) Rules() Variant {
+	variantsMu.RLock()
+	defer variantsMu.RUnlock()
+	if rules, ok := variants[v]; ok {
+		return rules
+	}
+	return HoldemVariant{}
+}
+
+// IsKnown reports whether the engine can deal the variant
+func (v GameVariant) IsKnown() bool {
+	variantsMu.RLock()
+	defer variantsMu.RUnlock()
+	_, ok := variants[v]
+	return v == "" || ok
+}
+
+// String returns the display name of the variant
+func (v GameVariant) String() string {
+	return v.Rules().String()
+}
+
+// GetVariant returns the rules of the game being dealt
+func (g *Game) GetVariant() Variant {
+	return g.config.Variant.Rules()
+}
+
+// HoldemVariant is no-limit Texas Hold'em
+type HoldemVariant struct{}
+
+func (HoldemVariant) Name() GameVariant { return VariantHoldem }
+
+func (HoldemVariant) String() string { return "Texas Hold'em" }
+
+func (HoldemVariant) HoleCards() int { return 2 }
+
+func (HoldemVariant) BoardCards(phase GamePhase) int {
+	switch phase {
+	case PhaseFlop:
+		return 3
+	case PhaseTurn, PhaseRiver:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (HoldemVariant) BettingStructure() BettingStructure { return NoLimit }
+
+func (HoldemVariant) NewEvaluator() IHandEvaluator {
+	return newBackendEvaluator(effectiveEvaluator(""))
+}
@@ -0,0 +1,100 @@
+package holdem
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGameVariantRules(t *testing.T) {
+	game := NewGameWithConfig(GameConfig{SmallBlind: 5, BigBlind: 10})
+	if game.GetVariant().Name() != VariantHoldem {
+		t.Errorf("Expected Hold'em by default, got %q", game.GetVariant().Name())
+	}
+	if GameVariant("razz").Rules().Name() != VariantHoldem || GameVariant("razz").IsKnown() {
+		t.Error("Expected unknown variants to fall back to Hold'em")
+	}
+
+	holdem := VariantHoldem.Rules()
+	if holdem.HoleCards() != 2 || holdem.BettingStructure() != NoLimit {
+		t.Error("Expected no-limit Hold'em with 2 hole cards")
+	}
+	omaha := VariantOmaha.Rules()
+	if omaha.HoleCards() != 4 || omaha.BettingStructure() != PotLimit || omaha.String() != "Pot-Limit Omaha" {
+		t.Errorf("Expected pot-limit Omaha with 4 hole cards, got %s", omaha)
+	}
+	for _, variant := range []Variant{holdem, omaha} {
+		board := variant.BoardCards(PhaseFlop) + variant.BoardCards(PhaseTurn) + variant.BoardCards(PhaseRiver)
+		if board != 5 || variant.BoardCards(PhasePreflop) != 0 {
+			t.Errorf("Expected %s to deal a five card board, got %d", variant, board)
+		}
+	}
+}
+
+// registerVariant registers a variant for the rest of the test, then puts
+// back whatever was registered under its name before
+func registerVariant(t *testing.T, variant Variant) {
+	t.Helper()
+	variantsMu.Lock()
+	previous, known := variants[variant.Name()]
+	variants[variant.Name()] = variant
+	variantsMu.Unlock()
+	t.Cleanup(func() {
+		variantsMu.Lock()
+		defer variantsMu.Unlock()
+		if known {
+			variants[variant.Name()] = previous
+		} else {
+			delete(variants, variant.Name())
+		}
+	})
+}
+
+func TestRegisterVariantWhileGamesLookUpRules(t *testing.T) {
+	registerVariant(t, shortBoardVariant{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterVariant(shortBoardVariant{})
+		}()
+		go func() {
+			defer wg.Done()
+			if !GameVariant("test-board").IsKnown() || GameVariant("test-board").Rules().BoardCards(PhaseFlop) != 5 {
+				t.Error("Expected the registered variant's rules")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// shortBoardVariant deals the whole board on the flop
+type shortBoardVariant struct {
+	HoldemVariant
+}
+
+func (shortBoardVariant) Name() GameVariant { return "test-board" }
+
+func (shortBoardVariant) BoardCards(phase GamePhase) int {
+	if phase == PhaseFlop {
+		return 5
+	}
+	return 0
+}
+
+func TestGameDealsTheVariantBoard(t *testing.T) {
+	registerVariant(t, shortBoardVariant{})
+
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10, Variant: "test-board"}, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	mustAct(t, game, 1, ActionCall, 5)
+	mustAct(t, game, 2, ActionCheck, 0)
+	if err := game.DealFlop(); err != nil {
+		t.Fatalf("DealFlop failed: %v", err)
+	}
+	if len(game.GetCommunityCards()) != 5 {
+		t.Errorf("Expected the variant to deal 5 cards on the flop, got %d", len(game.GetCommunityCards()))
+	}
+}
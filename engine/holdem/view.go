@@ -0,0 +1,127 @@
+package holdem
+
+import "github.com/ljbink/ai-poker/engine/poker"
+
+// SeatView is a read-only snapshot of one occupied seat
+type SeatView struct {
+	Seat        int         `json:"seat"`
+	PlayerID    int         `json:"player_id"`
+	Name        string      `json:"name"`
+	Chips       int         `json:"chips"`
+	Bet         int         `json:"bet"`
+	TotalBet    int         `json:"total_bet"`
+	Folded      bool        `json:"folded"`
+	HoleCards   poker.Cards `json:"hole_cards,omitempty"` // Empty when hidden from the viewer
+	CardsHidden bool        `json:"cards_hidden"`         // True when the player holds cards the viewer may not see
+	Protected   bool        `json:"protected,omitempty"`  // Disconnected, all in for what they put in
+}
+
+// TableView is a read-only snapshot of the table as seen by one kind of viewer
+type TableView struct {
+	TableID    string      `json:"table_id"`
+	HandID     string      `json:"hand_id,omitempty"` // Empty before the first hand
+	Variant    GameVariant `json:"variant"`
+	HandNumber int         `json:"hand_number"`
+	Phase      GamePhase   `json:"phase"`
+	Board      poker.Cards `json:"board"`
+	Seats      []SeatView  `json:"seats"`
+	Button     int         `json:"button"`      // Dealer button seat, -1 before the first hand
+	ActingSeat int         `json:"acting_seat"` // Seat to act, -1 when nobody is
+	Pot        int         `json:"pot"`
+	StreetPot  int         `json:"street_pot"` // Pot when the street's betting began, the bets in front of the players left out
+	CurrentBet int         `json:"current_bet"`
+	Runout     bool        `json:"runout,omitempty"` // The hand is run out all-in, the players' cards face up
+
+	// The table allows no assistance: frontends keep equities and coaching
+	// from the players seated, and whatever serves the views leaves equity
+	// data out of theirs
+	NoAssistance bool `json:"no_assistance,omitempty"`
+}
+
+// SpectatorView returns the table with every hole card hidden until showdown
+func (g *Game) SpectatorView() TableView {
+	return g.tableView(func(player IPlayer) bool {
+		return g.isShownDown(player)
+	})
+}
+
+// PlayerView returns the table as seen by the given player: their own cards
+// plus anything shown down
+func (g *Game) PlayerView(playerID int) TableView {
+	return g.tableView(func(player IPlayer) bool {
+		return player.GetID() == playerID || g.isShownDown(player)
+	})
+}
+
+// CommentatorView returns the table with every hole card visible.
+// It is meant for post-game review and must never reach live opponents.
+func (g *Game) CommentatorView() TableView {
+	return g.tableView(func(IPlayer) bool {
+		return true
+	})
+}
+
+// isShownDown reports whether a player's cards are public: at showdown
+// unless the player lost and mucked, and face up on the table while an
+// all-in hand is run out
+func (g *Game) isShownDown(player IPlayer) bool {
+	return (g.currentPhase == PhaseShowdown || g.IsAllInRunout()) && !player.IsFolded() && !g.IsMucked(player.GetID())
+}
+
+func (g *Game) tableView(visible func(IPlayer) bool) TableView {
+	view := TableView{
+		TableID:    g.config.TableID,
+		HandID:     g.handID,
+		Variant:    g.GetVariant().Name(),
+		HandNumber: g.handNumber,
+		Phase:      g.currentPhase,
+		Board:      copyCards(g.communityCards),
+		Seats:      []SeatView{},
+		Button:     g.button,
+		ActingSeat: g.GetActingSeat(),
+		Pot:        g.GetPot(),
+		StreetPot:  g.PotAtStreet(g.currentPhase),
+		CurrentBet: g.GetCurrentBet(),
+		Runout:     g.IsAllInRunout(),
+
+		NoAssistance: g.config.NoAssistance,
+	}
+	for i, player := range g.players {
+		if player == nil {
+			continue
+		}
+		seat := SeatView{
+			Seat:     i,
+			PlayerID: player.GetID(),
+			Name:     player.GetName(),
+			Chips:    player.GetChips(),
+			Bet:      player.GetBet(),
+			TotalBet: player.GetTotalBet(),
+			Folded:   player.IsFolded(),
+
+			Protected: g.protected[i],
+		}
+		if len(player.GetHandCards()) > 0 {
+			if visible(player) {
+				seat.HoleCards = copyCards(player.GetHandCards())
+			} else {
+				seat.CardsHidden = true
+			}
+		}
+		view.Seats = append(view.Seats, seat)
+	}
+	return view
+}
+
+// copyCards deep-copies cards so snapshots cannot alias live game state
+func copyCards(cards []*poker.Card) poker.Cards {
+	copied := make(poker.Cards, 0, len(cards))
+	for _, card := range cards {
+		if card == nil {
+			continue
+		}
+		c := *card
+		copied = append(copied, &c)
+	}
+	return copied
+}
@@ -0,0 +1,110 @@
+package holdem
+
+import "testing"
+
+func TestSpectatorViewHidesHoleCards(t *testing.T) {
+	game := playRecordedHand(t, 21)
+	view := game.SpectatorView()
+
+	if len(view.Seats) != 3 {
+		t.Fatalf("Expected 3 seats, got %d", len(view.Seats))
+	}
+	for _, seat := range view.Seats {
+		if len(seat.HoleCards) != 0 || !seat.CardsHidden {
+			t.Errorf("Expected hidden cards for seat %d, got %v", seat.Seat, seat.HoleCards)
+		}
+	}
+	if len(view.Board) != 4 {
+		t.Errorf("Expected turn board to be visible, got %v", view.Board)
+	}
+	if view.HandNumber != 1 || view.Phase != PhaseTurn {
+		t.Errorf("Expected hand 1 on the turn, got hand %d phase %d", view.HandNumber, view.Phase)
+	}
+}
+
+func TestPlayerViewShowsOwnCards(t *testing.T) {
+	game := playRecordedHand(t, 22)
+	view := game.PlayerView(2)
+
+	for _, seat := range view.Seats {
+		if seat.PlayerID == 2 {
+			if len(seat.HoleCards) != 2 || seat.CardsHidden {
+				t.Errorf("Expected own cards visible, got %v", seat.HoleCards)
+			}
+		} else if len(seat.HoleCards) != 0 {
+			t.Errorf("Expected opponent %d cards hidden", seat.PlayerID)
+		}
+	}
+}
+
+func TestShowdownRevealsLiveHands(t *testing.T) {
+	game := playRecordedHand(t, 23)
+	carol, _ := game.GetPlayerByID(3)
+	carol.Fold()
+	game.SetCurrentPhase(PhaseShowdown)
+
+	for _, seat := range game.SpectatorView().Seats {
+		if seat.Folded {
+			if !seat.CardsHidden {
+				t.Errorf("Expected folded seat %d to stay hidden", seat.Seat)
+			}
+			continue
+		}
+		if len(seat.HoleCards) != 2 {
+			t.Errorf("Expected seat %d cards shown at showdown", seat.Seat)
+		}
+	}
+}
+
+func TestCommentatorViewShowsEverythingWithoutAliasing(t *testing.T) {
+	game := playRecordedHand(t, 24)
+	view := game.CommentatorView()
+
+	for _, seat := range view.Seats {
+		if len(seat.HoleCards) != 2 || seat.CardsHidden {
+			t.Errorf("Expected all cards visible for seat %d", seat.Seat)
+		}
+	}
+
+	view.Seats[0].HoleCards[0].Rank = 0
+	alice, _ := game.GetPlayerByID(1)
+	if alice.GetHandCards()[0].Rank == 0 {
+		t.Error("Expected view to be a copy of the game state")
+	}
+}
+
+func TestAllInRunoutRevealsHands(t *testing.T) {
+	game := newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 500, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	mustAct(t, game, 1, ActionAllIn, 500)
+	mustAct(t, game, 2, ActionCall, 495)
+	if game.IsAllInRunout() {
+		t.Fatal("Expected no runout while the big blind still has to act")
+	}
+	mustAct(t, game, 3, ActionCall, 490)
+	if game.IsAllInRunout() {
+		t.Fatal("Expected no runout with two players left holding chips to bet")
+	}
+
+	game = newManagedGame(t, GameConfig{SmallBlind: 5, BigBlind: 10}, 500, 1000, 1000)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	mustAct(t, game, 1, ActionAllIn, 500)
+	mustAct(t, game, 2, ActionFold, 0)
+	mustAct(t, game, 3, ActionCall, 490)
+	if !game.IsAllInRunout() {
+		t.Fatal("Expected the hand to be run out once the all-in is called")
+	}
+	view := game.SpectatorView()
+	if !view.Runout {
+		t.Error("Expected the view to mark the runout")
+	}
+	for _, seat := range view.Seats {
+		if shown := len(seat.HoleCards) == 2; shown == seat.Folded {
+			t.Errorf("Seat %d: expected the cards face up unless folded, got %v", seat.Seat, seat.HoleCards)
+		}
+	}
+}
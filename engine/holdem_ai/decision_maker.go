@@ -1,6 +1,10 @@
 package holdem_ai
 
-import "github.com/ljbink/ai-poker/engine/holdem"
+import (
+	"context"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
 
 // IDecisionMaker interface that both human players and AI bots must implement
 // Kept truly minimal with only the essential decision-making method
@@ -10,3 +14,79 @@ type IDecisionMaker interface {
 	// Takes game and player as parameters to make IDecisionMakers stateless
 	MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action
 }
+
+// IMuckDecider is implemented by decision makers that choose whether to
+// show a losing hand at showdown. Players without one always show.
+type IMuckDecider interface {
+	ShouldMuck(game *holdem.Game, player holdem.IPlayer) bool
+}
+
+// ISeedable is implemented by decision makers whose random choices can be
+// seeded, so simulations replay the same decisions
+type ISeedable interface {
+	SetSeed(seed int64)
+}
+
+// IPushFolder is implemented by decision makers that can switch to Nash
+// push/fold play preflop when their stack gets short, as tournament bots do
+type IPushFolder interface {
+	SetPushFold(enabled bool)
+}
+
+// IModeSwitcher is implemented by decision makers that can switch between
+// balanced and exploitative play at runtime
+type IModeSwitcher interface {
+	SetMode(mode Mode)
+	GetMode() Mode
+}
+
+// ITraceable is implemented by decision makers that can report a trace of
+// each decision for analysis after the game
+type ITraceable interface {
+	SetTraceSink(sink func(DecisionTrace))
+}
+
+// ITilter is implemented by decision makers that can tilt after big losses
+// and bad beats, as human-like opponents in casual play do
+type ITilter interface {
+	SetTilt(enabled bool)
+}
+
+// IResultObserver is implemented by decision makers that learn how each
+// hand ended for their player; the session reports every finished hand
+type IResultObserver interface {
+	HandFinished(game *holdem.Game, player holdem.IPlayer, net int)
+}
+
+// ITalker is implemented by decision makers that chat at the table. After
+// each hand the session asks for a line, given as a key of the message
+// catalogs in engine/i18n, and reports it as a chat event.
+type ITalker interface {
+	TableTalk(game *holdem.Game, player holdem.IPlayer, net int) (string, bool)
+}
+
+// IWarmer is implemented by decision makers that prepare before the first
+// hand, e.g. loading strategy files or building lookup tables, so their
+// first decision is as quick as the rest. The session warms each player's
+// decision maker once, before the first hand they are dealt.
+type IWarmer interface {
+	Warmup(ctx context.Context, config WarmupConfig) error
+}
+
+// As looks for a T in maker and the decision makers it wraps, following
+// Unwrap like errors.As, so optional interfaces such as IPushFolder are
+// found through wrappers like InstrumentedDecisionMaker
+func As[T any](maker IDecisionMaker) (T, bool) {
+	for maker != nil {
+		if found, ok := maker.(T); ok {
+			return found, true
+		}
+		wrapper, ok := maker.(interface{ Unwrap() IDecisionMaker })
+		if !ok {
+			break
+		}
+		maker = wrapper.Unwrap()
+	}
+	var zero T
+	return zero, false
+}
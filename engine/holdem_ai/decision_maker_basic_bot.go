@@ -1,11 +1,28 @@
 package holdem_ai
 
 import (
+	"log/slog"
 	"math/rand"
+	"sync"
 	"time"
 
+	"github.com/ljbink/ai-poker/engine/charts"
+	"github.com/ljbink/ai-poker/engine/equity"
 	"github.com/ljbink/ai-poker/engine/holdem"
 	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/pushfold"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/engine/reading"
+)
+
+// PushFoldStackBB is the effective stack in big blinds below which a bot
+// with push/fold enabled shoves or folds preflop
+const PushFoldStackBB = 15
+
+// Equity estimates used for variants without hand-rank heuristics
+const (
+	equitySamples      = 200 // Runouts sampled per decision
+	maxEquityOpponents = 3   // Random hands simulated at most
 )
 
 type BasicBotDecisionMaker struct {
@@ -13,6 +30,22 @@ type BasicBotDecisionMaker struct {
 	BluffFrequency float64                 // 0.0 = never bluff, 1.0 = always bluff
 	evaluator      holdem.IHandEvaluator   // Hand evaluator for strength calculation
 	validator      holdem.IActionValidator // Action validator for legal moves
+	logger         *slog.Logger            // Structured logger, discards by default
+	minThinking    time.Duration           // Shortest real delay before acting
+	maxThinking    time.Duration           // Longest real delay before acting
+	thinking       ThinkingStyle           // Distribution the simulated thinking time is drawn from
+	pushFold       bool                    // Play push/fold preflop below PushFoldStackBB
+	readRanges     bool                    // Weigh hands after the flop against the opponents' estimated ranges
+	model          *OpponentModel          // What the bot has seen its opponents do
+	traceSink      func(DecisionTrace)     // Receives a trace of every decision when set
+	tilt           *tiltState              // Tilt from hand results, nil when the bot never tilts
+	talk           bool                    // Comment on big pots and on being bluffed
+
+	rngMu sync.Mutex // Guards rng, as an abandoned decision may still be running
+	rng   *rand.Rand // Source of every random choice, so a seeded bot repeats itself
+
+	modeMu sync.Mutex // Guards mode, which may switch while a decision runs
+	mode   Mode       // Balanced or exploitative play
 }
 
 // NewBasicBotDecisionMaker creates a new basic bot with specified traits
@@ -22,22 +55,165 @@ func NewBasicBotDecisionMaker(aggressiveness, bluffFrequency float64) *BasicBotD
 		BluffFrequency: bluffFrequency,
 		evaluator:      holdem.NewHandEvaluator(),
 		validator:      holdem.NewActionValidator(),
+		logger:         holdem.NewDiscardLogger(),
+		minThinking:    500 * time.Millisecond,
+		maxThinking:    2000 * time.Millisecond,
+		thinking:       StyleSteady,
+		model:          NewOpponentModel(),
+		mode:           ModeBalanced,
+		rng:            rand.New(rand.NewSource(rand.Int63())),
+	}
+}
+
+// SetSeed implements the ISeedable interface
+func (d *BasicBotDecisionMaker) SetSeed(seed int64) {
+	d.rngMu.Lock()
+	defer d.rngMu.Unlock()
+	d.rng = rand.New(rand.NewSource(seed))
+}
+
+// SetPushFold implements the IPushFolder interface
+func (d *BasicBotDecisionMaker) SetPushFold(enabled bool) {
+	d.pushFold = enabled
+}
+
+// SetRangeReading makes the bot judge its Hold'em hands after the flop by
+// their equity against each opponent's range as read from their line,
+// rather than by hand rank alone
+func (d *BasicBotDecisionMaker) SetRangeReading(enabled bool) {
+	d.readRanges = enabled
+}
+
+// SetMode implements the IModeSwitcher interface. It takes effect from the
+// bot's next decision, so it can be switched mid-session, e.g. once the
+// opponent model has watched enough hands.
+func (d *BasicBotDecisionMaker) SetMode(mode Mode) {
+	d.modeMu.Lock()
+	defer d.modeMu.Unlock()
+	d.mode = mode
+}
+
+// GetMode returns the mode the bot plays in
+func (d *BasicBotDecisionMaker) GetMode() Mode {
+	d.modeMu.Lock()
+	defer d.modeMu.Unlock()
+	if d.mode == "" {
+		return ModeBalanced
+	}
+	return d.mode
+}
+
+// GetOpponentModel returns what the bot has seen its opponents do
+func (d *BasicBotDecisionMaker) GetOpponentModel() *OpponentModel {
+	return d.model
+}
+
+// SetTraceSink implements the ITraceable interface. Sinks may be called
+// from the bot's decision goroutines. Passing nil stops tracing.
+func (d *BasicBotDecisionMaker) SetTraceSink(sink func(DecisionTrace)) {
+	d.traceSink = sink
+}
+
+// random runs f with the bot's random source
+func (d *BasicBotDecisionMaker) random(f func(rng *rand.Rand)) {
+	d.rngMu.Lock()
+	defer d.rngMu.Unlock()
+	if d.rng == nil {
+		d.rng = rand.New(rand.NewSource(rand.Int63()))
+	}
+	f(d.rng)
+}
+
+// randomFloat returns the bot's next random number in [0, 1)
+func (d *BasicBotDecisionMaker) randomFloat() float64 {
+	var value float64
+	d.random(func(rng *rand.Rand) { value = rng.Float64() })
+	return value
+}
+
+// SetThinkingTime sets the range of the real delay before each decision; the
+// simulated thinking time is squeezed into it. Zero disables the delay, which
+// headless simulations want, while timed decisions still report the
+// simulated time.
+func (d *BasicBotDecisionMaker) SetThinkingTime(minDelay, maxDelay time.Duration) {
+	d.minThinking = minDelay
+	d.maxThinking = max(minDelay, maxDelay)
+}
+
+// realDelay squeezes a simulated thinking time into the real delay range.
+// Longer thinking still waits longer, so timing tells survive at the table.
+func (d *BasicBotDecisionMaker) realDelay(thinking time.Duration) time.Duration {
+	if d.maxThinking <= d.minThinking || thinking <= 0 {
+		return d.minThinking
+	}
+	share := float64(thinking) / float64(thinking+d.thinking.Median)
+	return d.minThinking + time.Duration(share*float64(d.maxThinking-d.minThinking))
+}
+
+// SetThinkingStyle sets the distribution thinking times are drawn from
+func (d *BasicBotDecisionMaker) SetThinkingStyle(style ThinkingStyle) {
+	d.thinking = style
+}
+
+// GetThinkingStyle returns the distribution thinking times are drawn from
+func (d *BasicBotDecisionMaker) GetThinkingStyle() ThinkingStyle {
+	return d.thinking
+}
+
+// SetLogger injects the structured logger used by the bot.
+// Passing nil restores the discard logger.
+func (d *BasicBotDecisionMaker) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = holdem.NewDiscardLogger()
 	}
+	d.logger = logger
 }
 
 // MakeDecision implements the IDecisionMaker interface
 func (d *BasicBotDecisionMaker) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
 	ch := make(chan holdem.Action, 1)
+	timed := d.MakeTimedDecision(game, player)
 
 	go func() {
 		defer close(ch)
+		if decision, ok := <-timed; ok {
+			ch <- decision.Action
+		}
+	}()
+
+	return ch
+}
+
+// MakeTimedDecision implements the ITimedDecisionMaker interface. The bot
+// decides first and then draws how long it thinks from its style, so the
+// time depends on the decision.
+func (d *BasicBotDecisionMaker) MakeTimedDecision(game *holdem.Game, player holdem.IPlayer) <-chan TimedDecision {
+	ch := make(chan TimedDecision, 1)
+
+	go func() {
+		defer close(ch)
+
+		action, trace := d.decide(game, player)
+		var thinking time.Duration
+		d.random(func(rng *rand.Rand) { thinking = d.thinking.Draw(rng, game, player, action) })
 
 		// Add realistic thinking time
-		thinkingTime := time.Duration(500+rand.Intn(1500)) * time.Millisecond
-		time.Sleep(thinkingTime)
+		if delay := d.realDelay(thinking); delay > 0 {
+			time.Sleep(delay)
+		}
 
-		action := d.calculateBestAction(game, player)
-		ch <- action
+		d.logger.Debug("bot decision",
+			slog.Int("player_id", action.PlayerID),
+			slog.String("action", holdem.ActionTypeToString(action.Type)),
+			slog.Int("amount", action.Amount),
+			slog.Duration("thinking", thinking),
+			slog.String("mode", string(trace.Mode)),
+			slog.Float64("hand_strength", trace.Strength),
+		)
+		if d.traceSink != nil {
+			d.traceSink(trace)
+		}
+		ch <- TimedDecision{Action: action, Thinking: thinking}
 	}()
 
 	return ch
@@ -45,6 +221,32 @@ func (d *BasicBotDecisionMaker) MakeDecision(game *holdem.Game, player holdem.IP
 
 // calculateBestAction determines the best action based on hand strength, game state, and bot personality
 func (d *BasicBotDecisionMaker) calculateBestAction(game *holdem.Game, player holdem.IPlayer) holdem.Action {
+	action, _ := d.decide(game, player)
+	return action
+}
+
+// decide chooses the bot's action and traces how it got there
+func (d *BasicBotDecisionMaker) decide(game *holdem.Game, player holdem.IPlayer) (holdem.Action, DecisionTrace) {
+	start := time.Now()
+	trace := DecisionTrace{Mode: d.GetMode(), Tilt: d.GetTilt()}
+	action := d.chooseAction(game, player, &trace)
+	trace.Profile.Total = time.Since(start)
+	trace.PlayerID = action.PlayerID
+	trace.Action = holdem.ActionTypeToString(action.Type)
+	trace.Amount = action.Amount
+	if game != nil {
+		trace.HandID = game.GetHandID()
+		trace.Phase = holdem.PhaseToString(game.GetCurrentPhase())
+	}
+	putsChipsIn := action.Type == holdem.ActionCall || action.Type == holdem.ActionRaise || action.Type == holdem.ActionAllIn
+	if d.tilt != nil && putsChipsIn {
+		d.tilt.played(trace.HandID, trace.Strength)
+	}
+	return action, trace
+}
+
+// chooseAction picks the action, filling in the trace as it goes
+func (d *BasicBotDecisionMaker) chooseAction(game *holdem.Game, player holdem.IPlayer, trace *DecisionTrace) holdem.Action {
 	// Handle nil inputs gracefully
 	if game == nil || player == nil {
 		return holdem.Action{
@@ -53,6 +255,7 @@ func (d *BasicBotDecisionMaker) calculateBestAction(game *holdem.Game, player ho
 			Amount:   0,
 		}
 	}
+	d.model.Observe(game)
 
 	// Get available actions from validator
 	availableActions := d.validator.GetAvailableActions(game, player)
@@ -79,19 +282,101 @@ func (d *BasicBotDecisionMaker) calculateBestAction(game *holdem.Game, player ho
 		}
 	}
 
+	if game.GetBigBlind() > 0 {
+		trace.Depth = depthOf(float64(d.effectiveStack(game, player)) / float64(game.GetBigBlind()))
+	}
+	if action, ok := d.pushOrFold(game, player, availableActions); ok {
+		trace.PushFold = true
+		return action
+	}
+
 	// Evaluate hand strength
-	handStrength := d.evaluateHandStrength(game, player)
+	handStrength := d.evaluateHandStrength(game, player, &trace.Profile)
+	trace.Strength = handStrength
+	exploit := d.exploits(game, player)
+	trace.Exploits = exploit.reasons
+	trace.Opponents = d.opponentsInPot(game, player)
+	adjust := multiway(trace.Opponents).and(exploit)
+	if game.GetCurrentPhase() != holdem.PhasePreflop {
+		trace.SPR = d.stackToPot(game, player)
+		adjust = adjust.and(commitment(trace.SPR))
+	}
 
 	// Get betting information
 	minRaise := d.validator.GetMinRaiseAmount(game, player)
 	maxRaise := d.validator.GetMaxRaiseAmount(game, player)
 
 	// Make decision based on hand strength and available actions
-	return d.makeDecisionBasedOnStrength(game, player, handStrength, availableActions, minRaise, maxRaise)
+	return d.makeDecisionBasedOnStrength(game, player, handStrength, availableActions, minRaise, maxRaise, adjust)
 }
 
-// evaluateHandStrength calculates the strength of the current hand (0.0 to 1.0)
-func (d *BasicBotDecisionMaker) evaluateHandStrength(game *holdem.Game, player holdem.IPlayer) float64 {
+// pushOrFold plays a short stack from the Nash push/fold tables: first in
+// it shoves or folds, and in the big blind facing a lone all-in it calls
+// or folds. Any other spot is left to the usual strength-based play.
+func (d *BasicBotDecisionMaker) pushOrFold(game *holdem.Game, player holdem.IPlayer, availableActions []holdem.ActionType) (holdem.Action, bool) {
+	if !d.pushFold || game.GetCurrentPhase() != holdem.PhasePreflop || game.GetVariant().BettingStructure() != holdem.NoLimit || game.GetBigBlind() <= 0 {
+		return holdem.Action{}, false
+	}
+	hand := ranges.HandClass(player.GetHandCards())
+	position, ok := charts.PositionOf(game, player.GetID())
+	if hand == "" || !ok {
+		return holdem.Action{}, false
+	}
+
+	// Who has put chips in voluntarily
+	var raisers, callers []int
+	for _, action := range game.GetUserActions().Preflop {
+		switch action.Type {
+		case holdem.ActionRaise, holdem.ActionAllIn:
+			raisers = append(raisers, action.PlayerID)
+		case holdem.ActionCall:
+			callers = append(callers, action.PlayerID)
+		}
+	}
+	effectiveBB := float64(d.effectiveStack(game, player)) / float64(game.GetBigBlind())
+	if effectiveBB >= PushFoldStackBB {
+		return holdem.Action{}, false
+	}
+	// Aggressive bots play as if a little shorter, so shove wider
+	effectiveBB *= 1.25 - d.aggression()/2
+
+	action := holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionFold}
+	switch {
+	case len(raisers) == 0 && len(callers) == 0:
+		if pushfold.ShouldShove(hand, position, effectiveBB) && d.isActionAvailable(holdem.ActionAllIn, availableActions) {
+			action = holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionAllIn, Amount: player.GetChips()}
+		}
+	case len(raisers) == 1 && len(callers) == 0 && position == charts.PositionBB:
+		shover, err := game.GetPlayerByID(raisers[0])
+		if err != nil || shover.GetChips() > 0 {
+			return holdem.Action{}, false
+		}
+		shoverPosition, ok := charts.PositionOf(game, shover.GetID())
+		if !ok {
+			return holdem.Action{}, false
+		}
+		if pushfold.ShouldCall(hand, shoverPosition, effectiveBB) {
+			if d.isActionAvailable(holdem.ActionCall, availableActions) {
+				action = holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCall, Amount: d.calculateCallAmount(game, player)}
+			} else if d.isActionAvailable(holdem.ActionAllIn, availableActions) {
+				action = holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionAllIn, Amount: player.GetChips()}
+			}
+		}
+	default:
+		return holdem.Action{}, false
+	}
+	if action.Type == holdem.ActionFold && d.isActionAvailable(holdem.ActionCheck, availableActions) {
+		action.Type = holdem.ActionCheck
+	}
+	if d.validator.ValidateAction(game, player, action) != nil {
+		return holdem.Action{}, false
+	}
+	return action, true
+}
+
+// evaluateHandStrength calculates the strength of the current hand (0.0 to
+// 1.0), adding the time it takes to the profile
+func (d *BasicBotDecisionMaker) evaluateHandStrength(game *holdem.Game, player holdem.IPlayer, profile *DecisionProfile) float64 {
 	holeCards := player.GetHandCards()
 	communityCards := game.GetCommunityCards()
 
@@ -99,8 +384,20 @@ func (d *BasicBotDecisionMaker) evaluateHandStrength(game *holdem.Game, player h
 		return 0.0
 	}
 
+	// The hand-rank heuristics below only understand Hold'em
+	if game.GetVariant().Name() != holdem.VariantHoldem {
+		return d.evaluateEquityStrength(game, player, profile)
+	}
+	if d.readRanges && len(communityCards) >= 3 {
+		if strength, ok := d.evaluateRangeStrength(game, player, profile); ok {
+			return strength
+		}
+	}
+
 	// Evaluate current hand
+	start := time.Now()
 	handResult := d.evaluator.EvaluateHand(holeCards, communityCards)
+	spend(&profile.Evaluation, start)
 
 	// Convert hand rank to strength percentage
 	baseStrength := d.handRankToStrength(handResult.Rank)
@@ -111,6 +408,54 @@ func (d *BasicBotDecisionMaker) evaluateHandStrength(game *holdem.Game, player h
 	return minFloat64(adjustedStrength, 1.0)
 }
 
+// evaluateEquityStrength estimates strength from equity against random
+// hands, scaled so that a fair share of the pot is worth 0.5
+func (d *BasicBotDecisionMaker) evaluateEquityStrength(game *holdem.Game, player holdem.IPlayer, profile *DecisionProfile) float64 {
+	defer spend(&profile.Equity, time.Now())
+	opponents := minInt(maxInt(d.countActivePlayers(game)-1, 1), maxEquityOpponents)
+	var seed int64
+	d.random(func(rng *rand.Rand) { seed = rng.Int63() })
+	share, err := equity.CalculateVsRandom(player.GetHandCards(), game.GetCommunityCards(), opponents, equity.Options{
+		Samples:   equitySamples,
+		Seed:      seed,
+		Evaluator: game.GetVariant().NewEvaluator(),
+	})
+	if err != nil {
+		d.logger.Warn("equity estimate failed", slog.Int("player_id", player.GetID()), slog.Any("error", err))
+		return 0.0
+	}
+	return minFloat64(share*float64(opponents+1)/2, 1.0)
+}
+
+// evaluateRangeStrength estimates strength from equity against the ranges
+// every opponent still in has shown, scaled like evaluateEquityStrength.
+// Ranges narrow as opponents act, so unlike random hands they are cheap
+// enough to simulate however many there are.
+func (d *BasicBotDecisionMaker) evaluateRangeStrength(game *holdem.Game, player holdem.IPlayer, profile *DecisionProfile) (float64, bool) {
+	start := time.Now()
+	hole := poker.Cards(player.GetHandCards())
+	holdings := []equity.Holding{{Cards: hole}}
+	for _, other := range game.GetAllPlayers() {
+		if other.GetID() == player.GetID() || other.IsFolded() {
+			continue
+		}
+		holdings = append(holdings, equity.Holding{Range: reading.Estimate(game, other.GetID(), hole)})
+	}
+	spend(&profile.Search, start)
+	if len(holdings) < 2 {
+		return 0, false
+	}
+	defer spend(&profile.Equity, time.Now())
+	var seed int64
+	d.random(func(rng *rand.Rand) { seed = rng.Int63() })
+	result, err := equity.CalculateHoldings(holdings, game.GetCommunityCards(), equity.Options{Samples: equitySamples, Seed: seed})
+	if err != nil {
+		d.logger.Warn("range equity estimate failed", slog.Int("player_id", player.GetID()), slog.Any("error", err))
+		return 0, false
+	}
+	return minFloat64(result.Equity[0]*float64(len(holdings))/2, 1.0), true
+}
+
 // handRankToStrength converts hand rank to base strength value
 func (d *BasicBotDecisionMaker) handRankToStrength(rank holdem.HandRank) float64 {
 	switch rank {
@@ -146,8 +491,12 @@ func (d *BasicBotDecisionMaker) adjustStrengthForGameState(baseStrength float64,
 	// Phase adjustments
 	switch game.GetCurrentPhase() {
 	case holdem.PhasePreflop:
-		// Pre-flop: focus on hole card quality
+		// Pre-flop: focus on hole card quality at the stack depth played
 		adjustment += d.evaluatePreflop(player.GetHandCards())
+		if game.GetBigBlind() > 0 {
+			depth := depthOf(float64(d.effectiveStack(game, player)) / float64(game.GetBigBlind()))
+			adjustment += d.preflopDepthAdjustment(player.GetHandCards(), depth)
+		}
 	case holdem.PhaseFlop, holdem.PhaseTurn, holdem.PhaseRiver:
 		// Post-flop: consider draws and hand development
 		adjustment += d.evaluatePostFlop(handResult, game.GetCommunityCards())
@@ -231,12 +580,14 @@ func (d *BasicBotDecisionMaker) evaluatePostFlop(handResult *holdem.HandResult,
 	return adjustment
 }
 
-// makeDecisionBasedOnStrength chooses action based on hand strength and personality
-func (d *BasicBotDecisionMaker) makeDecisionBasedOnStrength(game *holdem.Game, player holdem.IPlayer, handStrength float64, availableActions []holdem.ActionType, minRaise, maxRaise int) holdem.Action {
+// makeDecisionBasedOnStrength chooses action based on hand strength and
+// personality, bent by the adjustment for the pot and the opponents
+func (d *BasicBotDecisionMaker) makeDecisionBasedOnStrength(game *holdem.Game, player holdem.IPlayer, handStrength float64, availableActions []holdem.ActionType, minRaise, maxRaise int, adjust adjustment) holdem.Action {
 	// Adjust thresholds based on aggressiveness
-	foldThreshold := 0.25 - (d.Aggressiveness * 0.1)
-	callThreshold := 0.5 - (d.Aggressiveness * 0.15)
-	raiseThreshold := 0.7 - (d.Aggressiveness * 0.2)
+	aggression := d.aggression()
+	foldThreshold := 0.25 - (aggression * 0.1)
+	callThreshold := 0.5 - (aggression * 0.15)
+	raiseThreshold := 0.7 - (aggression * 0.2) - adjust.valueShift
 
 	// Default action
 	action := holdem.Action{
@@ -255,7 +606,7 @@ func (d *BasicBotDecisionMaker) makeDecisionBasedOnStrength(game *holdem.Game, p
 		}
 	} else if handStrength < callThreshold {
 		// Marginal hand - check/call or bluff
-		if d.shouldBluff(handStrength) && d.isActionAvailable(holdem.ActionRaise, availableActions) {
+		if d.bluffs(handStrength, d.bluffFrequency()*adjust.bluffScale) && d.isActionAvailable(holdem.ActionRaise, availableActions) {
 			// Bluff bet
 			action.Type = holdem.ActionRaise
 			action.Amount = d.calculateBluffAmount(game, player, minRaise)
@@ -267,7 +618,7 @@ func (d *BasicBotDecisionMaker) makeDecisionBasedOnStrength(game *holdem.Game, p
 		}
 	} else if handStrength < raiseThreshold {
 		// Good hand - bet for value or call
-		if d.isActionAvailable(holdem.ActionRaise, availableActions) && rand.Float64() < (0.5+d.Aggressiveness*0.3) {
+		if d.isActionAvailable(holdem.ActionRaise, availableActions) && d.randomFloat() < (0.5+aggression*0.3) {
 			action.Type = holdem.ActionRaise
 			action.Amount = d.calculateValueBetAmount(game, player, handStrength, minRaise)
 		} else if d.isActionAvailable(holdem.ActionCall, availableActions) {
@@ -289,71 +640,67 @@ func (d *BasicBotDecisionMaker) makeDecisionBasedOnStrength(game *holdem.Game, p
 		}
 	}
 
-	// Validate the action before returning
-	if err := d.validator.ValidateAction(game, player, action); err != nil {
-		// If action is invalid, fallback to check or fold
-		if d.isActionAvailable(holdem.ActionCheck, availableActions) {
-			action.Type = holdem.ActionCheck
-			action.Amount = 0
-		} else if d.isActionAvailable(holdem.ActionFold, availableActions) {
-			action.Type = holdem.ActionFold
-			action.Amount = 0
-		}
+	// At a low stack-to-pot ratio a good hand puts the rest in now
+	if adjust.commit && handStrength >= callThreshold &&
+		(action.Type == holdem.ActionCall || action.Type == holdem.ActionRaise) && d.isActionAvailable(holdem.ActionAllIn, availableActions) {
+		action.Type = holdem.ActionAllIn
+		action.Amount = player.GetChips()
 	}
 
-	return action
-}
+	// A short stack facing a bet it cannot call or raise commits the rest with a playable hand
+	if action.Type == holdem.ActionFold && handStrength >= callThreshold &&
+		!d.isActionAvailable(holdem.ActionCall, availableActions) && d.isActionAvailable(holdem.ActionAllIn, availableActions) {
+		action.Type = holdem.ActionAllIn
+		action.Amount = player.GetChips()
+	}
 
-// Betting amount calculation methods
-func (d *BasicBotDecisionMaker) calculateCallAmount(game *holdem.Game, player holdem.IPlayer) int {
-	if game == nil || player == nil {
-		return 0
+	// Pot-limit games cap raises at the size of the pot
+	if action.Type == holdem.ActionRaise && game.GetVariant().BettingStructure() == holdem.PotLimit {
+		action.Amount = minInt(action.Amount, maxRaise-d.calculateCallAmount(game, player))
 	}
 
-	// Get current phase actions
-	var actions []holdem.Action
-	userActions := game.GetUserActions()
-	switch game.GetCurrentPhase() {
-	case holdem.PhasePreflop:
-		actions = userActions.Preflop
-	case holdem.PhaseFlop:
-		actions = userActions.Flop
-	case holdem.PhaseTurn:
-		actions = userActions.Turn
-	case holdem.PhaseRiver:
-		actions = userActions.River
-	default:
-		return 0
+	// Raises are sized on top of the call; say what they raise to
+	if action.Type == holdem.ActionRaise {
+		action.Amount += d.calculateCallAmount(game, player)
+		action.RaiseTo = player.GetBet() + action.Amount
 	}
 
-	// Find highest bet/raise amount in current phase
-	currentBet := 0
-	for _, action := range actions {
-		if action.Type == holdem.ActionRaise || action.Type == holdem.ActionCall {
-			if action.Amount > currentBet {
-				currentBet = action.Amount
-			}
+	// Validate the action before returning
+	if err := d.validator.ValidateAction(game, player, action); err != nil {
+		d.logger.Warn("bot proposal rejected by validator",
+			slog.Int("player_id", player.GetID()),
+			slog.String("action", holdem.ActionTypeToString(action.Type)),
+			slog.Int("amount", action.Amount),
+			slog.Float64("hand_strength", handStrength),
+			slog.String("reason", err.Message),
+		)
+		// Take the validator's correction when there is one, otherwise check or fold
+		if corrected, ok := err.Correct(action); ok && d.validator.ValidateAction(game, player, corrected) == nil {
+			action = corrected
+		} else if d.isActionAvailable(holdem.ActionCheck, availableActions) {
+			action = holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck}
+		} else if d.isActionAvailable(holdem.ActionFold, availableActions) {
+			action = holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionFold}
 		}
 	}
 
-	// Calculate call amount (difference between current bet and player's bet)
-	callAmount := currentBet - player.GetBet()
-	if callAmount < 0 {
-		callAmount = 0
-	}
+	return action
+}
 
-	return callAmount
+// Betting amount calculation methods
+func (d *BasicBotDecisionMaker) calculateCallAmount(game *holdem.Game, player holdem.IPlayer) int {
+	return d.validator.GetCallAmount(game, player)
 }
 
 func (d *BasicBotDecisionMaker) calculateBluffAmount(game *holdem.Game, player holdem.IPlayer, minRaise int) int {
 	bigBlind := game.GetBigBlind()
-	bluffSize := bigBlind + int(float64(bigBlind)*d.Aggressiveness)
+	bluffSize := bigBlind + int(float64(bigBlind)*d.aggression())
 	return maxInt(bluffSize, minRaise)
 }
 
 func (d *BasicBotDecisionMaker) calculateValueBetAmount(game *holdem.Game, player holdem.IPlayer, handStrength float64, minRaise int) int {
 	bigBlind := game.GetBigBlind()
-	betSize := int(float64(bigBlind) * (1 + handStrength + d.Aggressiveness) * 2)
+	betSize := int(float64(bigBlind) * (1 + handStrength + d.aggression()) * 2)
 	maxBet := player.GetChips() / 3 // Don't bet more than 1/3 of stack
 
 	betAmount := minInt(betSize, maxBet)
@@ -364,7 +711,7 @@ func (d *BasicBotDecisionMaker) calculateAggressiveRaiseAmount(game *holdem.Game
 	bigBlind := game.GetBigBlind()
 
 	// Strong hands warrant bigger bets
-	multiplier := 3.0 + (handStrength * 2.0) + (d.Aggressiveness * 2.0)
+	multiplier := 3.0 + (handStrength * 2.0) + (d.aggression() * 2.0)
 	raiseAmount := int(float64(bigBlind) * multiplier)
 
 	// Cap at reasonable percentage of stack
@@ -376,8 +723,13 @@ func (d *BasicBotDecisionMaker) calculateAggressiveRaiseAmount(game *holdem.Game
 
 // Helper methods
 func (d *BasicBotDecisionMaker) shouldBluff(handStrength float64) bool {
+	return d.bluffs(handStrength, d.bluffFrequency())
+}
+
+// bluffs decides whether to bluff a hand at the given frequency
+func (d *BasicBotDecisionMaker) bluffs(handStrength, frequency float64) bool {
 	// Only bluff with marginal hands and based on bluff frequency
-	return handStrength > 0.1 && handStrength < 0.4 && rand.Float64() < d.BluffFrequency
+	return handStrength > 0.1 && handStrength < 0.4 && d.randomFloat() < frequency
 }
 
 func (d *BasicBotDecisionMaker) isActionAvailable(actionType holdem.ActionType, availableActions []holdem.ActionType) bool {
@@ -1,6 +1,9 @@
 package holdem_ai
 
 import (
+	"bytes"
+	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
@@ -128,7 +131,7 @@ func TestBasicBotHandStrengthEvaluation(t *testing.T) {
 		player.DealCard(card)
 	}
 
-	strongHandStrength := bot.evaluateHandStrength(game, player)
+	strongHandStrength := bot.evaluateHandStrength(game, player, &DecisionProfile{})
 
 	// Test with weak hand (2-7 offsuit)
 	player.ResetForNewHand()
@@ -140,7 +143,7 @@ func TestBasicBotHandStrengthEvaluation(t *testing.T) {
 		player.DealCard(card)
 	}
 
-	weakHandStrength := bot.evaluateHandStrength(game, player)
+	weakHandStrength := bot.evaluateHandStrength(game, player, &DecisionProfile{})
 
 	// Strong hand should have higher strength
 	if strongHandStrength <= weakHandStrength {
@@ -430,6 +433,7 @@ func TestBasicBotCalculateCallAmount(t *testing.T) {
 		PlayerID: player2.GetID(),
 		Type:     holdem.ActionRaise,
 		Amount:   50,
+		RaiseTo:  50,
 	}
 	game.TakeAction(raiseAction)
 
@@ -446,6 +450,30 @@ func TestBasicBotCalculateCallAmount(t *testing.T) {
 	}
 }
 
+func TestBasicBotCallsInAnteGame(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Ante: 5, Seed: 3})
+	for i := 0; i < 3; i++ {
+		game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i)
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+
+	// The ante is in the pot but not part of the call
+	bot := NewBasicBotDecisionMaker(0.5, 0.1)
+	bot.SetThinkingTime(0, 0)
+	player := game.GetCurrentPlayer()
+	if call := bot.calculateCallAmount(game, player); call != 10 {
+		t.Errorf("Expected a call of 10 without the ante, got %d", call)
+	}
+	for i := 0; i < 20; i++ {
+		action := bot.calculateBestAction(game, player)
+		if err := holdem.NewActionValidator().ValidateAction(game, player, action); err != nil {
+			t.Fatalf("Expected a legal decision in an ante game, got %+v: %v", action, err)
+		}
+	}
+}
+
 // Helper function to deal test cards to a player
 func dealTestCards(game *holdem.Game, player holdem.IPlayer) {
 	// Deal some reasonable hole cards
@@ -455,3 +483,220 @@ func dealTestCards(game *holdem.Game, player holdem.IPlayer) {
 	player.DealCard(card1)
 	player.DealCard(card2)
 }
+
+func TestBasicBotSetLogger(t *testing.T) {
+	var buf bytes.Buffer
+	bot := NewBasicBotDecisionMaker(0.5, 0.1)
+	bot.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	game, player, _ := createTestGameSetup()
+	dealTestCards(game, player)
+
+	select {
+	case <-bot.MakeDecision(game, player):
+	case <-time.After(5 * time.Second):
+		t.Fatal("Bot did not make decision within timeout")
+	}
+
+	if !strings.Contains(buf.String(), `"msg":"bot decision"`) {
+		t.Errorf("Expected bot decision to be logged, got %q", buf.String())
+	}
+
+	bot.SetLogger(nil)
+	if bot.logger == nil {
+		t.Error("Expected SetLogger(nil) to restore the discard logger")
+	}
+}
+
+func TestBasicBotPlaysPotLimitOmaha(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Variant: holdem.VariantOmaha, Seed: 3})
+	for i := 0; i < 3; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+
+	bot := NewBasicBotDecisionMaker(1.0, 0.0)
+	validator := holdem.NewActionValidator()
+	player := game.GetCurrentPlayer()
+	if strength := bot.evaluateHandStrength(game, player, &DecisionProfile{}); strength < 0 || strength > 1 {
+		t.Errorf("Expected equity based strength in [0, 1], got %f", strength)
+	}
+	for _, strength := range []float64{0.1, 0.5, 0.95} {
+		action := bot.makeDecisionBasedOnStrength(game, player, strength,
+			validator.GetAvailableActions(game, player),
+			validator.GetMinRaiseAmount(game, player), validator.GetMaxRaiseAmount(game, player), balanced)
+		if err := validator.ValidateAction(game, player, action); err != nil {
+			t.Errorf("Strength %.2f: expected a legal pot-limit action, got %s %d: %v",
+				strength, holdem.ActionTypeToString(action.Type), action.Amount, err)
+		}
+	}
+}
+
+func TestBasicBotPushesOrFoldsShortStacked(t *testing.T) {
+	// Heads-up with 8 big blinds: the button shoves K2s and the big blind
+	// calls with A5o, but folds 72o
+	deal := func(cards string) *holdem.Game {
+		game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 50, BigBlind: 100, Seed: 3})
+		for i := 0; i < 2; i++ {
+			if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 800), i); err != nil {
+				t.Fatalf("PlayerSit failed: %v", err)
+			}
+		}
+		stacked, err := poker.ParseCards(cards)
+		if err != nil {
+			t.Fatalf("ParseCards failed: %v", err)
+		}
+		if err := game.StackDeck(stacked); err != nil {
+			t.Fatalf("StackDeck failed: %v", err)
+		}
+		if err := game.StartHand(0); err != nil {
+			t.Fatalf("StartHand failed: %v", err)
+		}
+		return game
+	}
+	bot := NewBasicBotDecisionMaker(0.5, 0.0)
+	bot.SetPushFold(true)
+
+	for _, tt := range []struct {
+		cards string
+		call  bool
+	}{{"KsAh2s5c", true}, {"Ks7h2s2c", false}} {
+		game := deal(tt.cards)
+		button := game.GetCurrentPlayer()
+		shove := bot.calculateBestAction(game, button)
+		if shove.Type != holdem.ActionAllIn {
+			t.Fatalf("Expected the button to shove K2s, got %s %d", holdem.ActionTypeToString(shove.Type), shove.Amount)
+		}
+		if err := game.TakeAction(shove); err != nil {
+			t.Fatalf("TakeAction failed: %v", err)
+		}
+		blind := game.GetCurrentPlayer()
+		answer := bot.calculateBestAction(game, blind)
+		if called := answer.Type == holdem.ActionCall || answer.Type == holdem.ActionAllIn; called != tt.call {
+			t.Errorf("Expected the big blind with %s to call: %v, got %s",
+				poker.Cards(blind.GetHandCards()).Codes(), tt.call, holdem.ActionTypeToString(answer.Type))
+		}
+	}
+
+	// Deeper than 15 big blinds the bot plays as usual
+	game := deal("KsAh2s5c")
+	for _, player := range game.GetAllPlayers() {
+		player.GrandChips(2000)
+	}
+	if action := bot.calculateBestAction(game, game.GetCurrentPlayer()); action.Type == holdem.ActionAllIn {
+		t.Error("Expected no push/fold shove with a deep stack")
+	}
+}
+
+func TestBasicBotReadsRangesAfterTheFlop(t *testing.T) {
+	// Heads-up, the big blind holds top pair on K-7-2 against a limp or a
+	// raise and a pot-sized c-bet
+	deal := func(raise bool) (*holdem.Game, holdem.IPlayer) {
+		game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+		for i := 0; i < 2; i++ {
+			if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i); err != nil {
+				t.Fatalf("PlayerSit failed: %v", err)
+			}
+		}
+		stacked, _ := poker.ParseCards("9sKh8dQs 3c Kd7c2h")
+		if err := game.StackDeck(stacked); err != nil {
+			t.Fatalf("StackDeck failed: %v", err)
+		}
+		if err := game.StartHand(0); err != nil {
+			t.Fatalf("StartHand failed: %v", err)
+		}
+		actions := []holdem.Action{{PlayerID: 1, Type: holdem.ActionCall, Amount: 5}, {PlayerID: 2, Type: holdem.ActionCheck}}
+		if raise {
+			actions = []holdem.Action{holdem.NewRaise(1, 30), {PlayerID: 2, Type: holdem.ActionCall, Amount: 20}}
+		}
+		for _, action := range actions {
+			if err := game.TakeAction(action); err != nil {
+				t.Fatalf("%+v failed: %v", action, err)
+			}
+		}
+		game.DealFlop()
+		if raise {
+			for _, action := range []holdem.Action{{PlayerID: 2, Type: holdem.ActionCheck}, holdem.NewRaise(1, 60)} {
+				if err := game.TakeAction(action); err != nil {
+					t.Fatalf("%+v failed: %v", action, err)
+				}
+			}
+		}
+		player, _ := game.GetPlayerByID(2)
+		return game, player
+	}
+
+	bot := CreateReaderBot().(*BasicBotDecisionMaker)
+	bot.SetSeed(1)
+	limped, player := deal(false)
+	soft, ok := bot.evaluateRangeStrength(limped, player, &DecisionProfile{})
+	if !ok {
+		t.Fatal("Expected a range-based strength")
+	}
+	raised, player := deal(true)
+	hard, _ := bot.evaluateRangeStrength(raised, player, &DecisionProfile{})
+	if hard >= soft {
+		t.Errorf("Expected top pair to be weaker against a raise and a pot-sized c-bet than a limp, got %.2f and %.2f", hard, soft)
+	}
+	if action := bot.calculateBestAction(raised, player); holdem.NewActionValidator().ValidateAction(raised, player, action) != nil {
+		t.Errorf("Expected a legal decision, got %+v", action)
+	}
+
+	// The decision's profile shows the time spent reading and simulating
+	_, trace := bot.decide(raised, player)
+	profile := trace.Profile
+	if profile.Search <= 0 || profile.Equity <= 0 || profile.Total < profile.Search+profile.Equity+profile.Evaluation {
+		t.Errorf("Expected reading and equity time within the decision's, got %+v", profile)
+	}
+}
+
+func TestBasicBotSwitchesToExploitativePlay(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	for i := 0; i < 2; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	bot := NewBasicBotDecisionMaker(0.5, 0.2)
+	bot.SetThinkingTime(0, 0)
+	player := game.GetCurrentPlayer()
+	model := bot.GetOpponentModel()
+	model.stats[2] = &OpponentStats{Hands: ExploitMinHands, FacedBets: 20, FoldedToBets: 14}
+
+	if adjust := bot.exploits(game, player); adjust.bluffScale != 1 || adjust.reasons != nil {
+		t.Errorf("Expected a balanced bot to bend nothing, got %+v", adjust)
+	}
+	bot.SetMode(ModeExploitative)
+	if adjust := bot.exploits(game, player); adjust.bluffScale <= 1 || len(adjust.reasons) != 1 {
+		t.Errorf("Expected more bluffs against an overfolder, got %+v", adjust)
+	}
+	model.stats[2].FoldedToBets = 4
+	if adjust := bot.exploits(game, player); adjust.bluffScale != 0 || adjust.valueShift <= 0 {
+		t.Errorf("Expected no bluffs and thinner value against a station, got %+v", adjust)
+	}
+	model.stats[2].Hands = ExploitMinHands - 1
+	if adjust := bot.exploits(game, player); adjust.bluffScale != 1 {
+		t.Errorf("Expected no exploits before enough hands, got %+v", adjust)
+	}
+
+	// Each decision is traced with the mode it was made in
+	var traces []DecisionTrace
+	bot.SetTraceSink(func(trace DecisionTrace) { traces = append(traces, trace) })
+	bot.SetMode(ModeBalanced)
+	<-bot.MakeTimedDecision(game, player)
+	bot.SetMode(ModeExploitative)
+	<-bot.MakeTimedDecision(game, player)
+	if len(traces) != 2 || traces[0].Mode != ModeBalanced || traces[1].Mode != ModeExploitative {
+		t.Fatalf("Expected a balanced and an exploitative trace, got %+v", traces)
+	}
+	if trace := traces[1]; trace.HandID != game.GetHandID() || trace.PlayerID != player.GetID() || trace.Phase != "preflop" || trace.Action == "" {
+		t.Errorf("Expected the trace to describe the decision, got %+v", trace)
+	}
+}
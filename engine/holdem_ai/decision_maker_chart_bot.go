@@ -0,0 +1,277 @@
+package holdem_ai
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ljbink/ai-poker/engine/charts"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+// PostflopRule is how often a chart bot bets, calls and raises after the
+// flop with a made hand, each between 0 and 1
+type PostflopRule struct {
+	Bet   float64 // Betting when checked to, checking otherwise
+	Call  float64 // Calling a bet
+	Raise float64 // Raising a bet; Call+Raise is at most 1, folding the rest
+}
+
+// ChartStrategy is what a ChartBot plays: preflop charts per position and
+// postflop rules per made hand
+type ChartStrategy struct {
+	Preflop  *charts.ChartSet
+	Postflop map[holdem.HandRank]PostflopRule // Each rule also covers better hands without one
+}
+
+// LoadChartStrategy reads a strategy from CSV, one row per hand:
+//
+//	position,hand,open,call,3bet
+//	BTN,AKs,100,0,100
+//	BB,T9s,0,80,20
+//	ANY,22-55,50,50,0
+//	postflop,two pair,80,20,50
+//
+// Preflop rows give a position (UTG, HJ, CO, BTN, SB, BB or ANY for all of
+// them), a hand class or range such as "AKs", "TT+", "22-55" or "@BTN open"
+// from the library given with ranges.WithLibrary in opts, and how often in
+// percent the hand opens when folded to, calls an open and 3-bets an open,
+// scaled by the hand's weight in the range. Facing a 3-bet the bot calls as
+// often as it would have 3-bet the hand itself. Hands without a row fold.
+//
+// Postflop rows give a made hand ("high card", "one pair", "two pair" and
+// so on up to "royal flush") and how often in percent it bets when checked
+// to, calls a bet and raises a bet. A rule covers better hands that have
+// none of their own; hands without a rule check and fold.
+//
+// Lines starting with # are comments and later rows replace earlier ones.
+func LoadChartStrategy(r io.Reader, opts ...ranges.ParseOption) (*ChartStrategy, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 5
+	reader.TrimLeadingSpace = true
+
+	strategy := &ChartStrategy{Preflop: charts.NewChartSet("csv", charts.DefaultStackBB), Postflop: map[holdem.HandRank]PostflopRule{}}
+	for _, position := range charts.Positions {
+		for _, facing := range charts.Facings {
+			strategy.Preflop.Set(&charts.Chart{Position: position, Facing: facing, Raise: ranges.NewRange(), Call: ranges.NewRange()})
+		}
+	}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if strings.EqualFold(record[0], "position") {
+			continue // Header
+		}
+		percents := [3]float64{}
+		for i, field := range record[2:] {
+			value, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil || value < 0 || value > 100 {
+				return nil, fmt.Errorf("line %d: %q is not a percentage", line, field)
+			}
+			percents[i] = value / 100
+		}
+		if strings.EqualFold(record[0], "postflop") {
+			err = strategy.addPostflop(record[1], PostflopRule{Bet: percents[0], Call: percents[1], Raise: percents[2]})
+		} else {
+			err = strategy.addPreflop(record[0], record[1], percents[0], percents[1], percents[2], opts)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return strategy, nil
+}
+
+// addPreflop sets the frequencies of a row's hands at its positions
+func (s *ChartStrategy) addPreflop(position, hands string, open, call, threeBet float64, opts []ranges.ParseOption) error {
+	if call+threeBet > 1+1e-9 {
+		return fmt.Errorf("%s is played more than 100%% of the time facing an open", hands)
+	}
+	positions := []charts.Position{charts.Position(strings.ToUpper(strings.TrimSpace(position)))}
+	if positions[0] == "ANY" {
+		positions = charts.Positions
+	} else if !positions[0].IsKnown() {
+		return fmt.Errorf("unknown position %q", position)
+	}
+	parsed, err := ranges.Parse(hands, opts...)
+	if err != nil {
+		return err
+	}
+	for _, position := range positions {
+		for _, hand := range parsed.Hands() {
+			weight := parsed.Weight(hand)
+			s.Preflop.Get(position, charts.Unopened).Raise.Set(hand, open*weight)
+			s.Preflop.Get(position, charts.FacingOpen).Raise.Set(hand, threeBet*weight)
+			s.Preflop.Get(position, charts.FacingOpen).Call.Set(hand, call*weight)
+			s.Preflop.Get(position, charts.FacingThreeBet).Call.Set(hand, threeBet*weight)
+		}
+	}
+	return nil
+}
+
+// addPostflop sets the rule of a made hand
+func (s *ChartStrategy) addPostflop(hand string, rule PostflopRule) error {
+	if rule.Call+rule.Raise > 1+1e-9 {
+		return fmt.Errorf("%s is played more than 100%% of the time facing a bet", hand)
+	}
+	for rank := holdem.HighCard; rank <= holdem.RoyalFlush; rank++ {
+		if strings.EqualFold(strings.TrimSpace(hand), holdem.HandRankToString(rank)) {
+			s.Postflop[rank] = rule
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown made hand %q", hand)
+}
+
+// postflopRule returns the rule covering a made hand: its own or that of
+// the best weaker hand with one
+func (s *ChartStrategy) postflopRule(rank holdem.HandRank) PostflopRule {
+	for ; rank >= holdem.HighCard; rank-- {
+		if rule, ok := s.Postflop[rank]; ok {
+			return rule
+		}
+	}
+	return PostflopRule{}
+}
+
+// ChartBot plays from a ChartStrategy, so opponents can be written as CSV
+// charts without touching Go. It only reads its charts for Hold'em hole
+// cards; in other variants it checks and folds preflop.
+type ChartBot struct {
+	strategy  *ChartStrategy
+	validator holdem.IActionValidator
+
+	rngMu sync.Mutex // Guards rng, as an abandoned decision may still be running
+	rng   *rand.Rand
+}
+
+// NewChartBot creates a bot playing the given strategy
+func NewChartBot(strategy *ChartStrategy) *ChartBot {
+	return &ChartBot{
+		strategy:  strategy,
+		validator: holdem.NewActionValidator(),
+		rng:       rand.New(rand.NewSource(rand.Int63())),
+	}
+}
+
+// LoadChartBot creates a chart bot from a CSV file, see LoadChartStrategy
+func LoadChartBot(filename string, opts ...ranges.ParseOption) (*ChartBot, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	strategy, err := LoadChartStrategy(file, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return NewChartBot(strategy), nil
+}
+
+// SetSeed implements the ISeedable interface
+func (b *ChartBot) SetSeed(seed int64) {
+	b.rngMu.Lock()
+	defer b.rngMu.Unlock()
+	b.rng = rand.New(rand.NewSource(seed))
+}
+
+// roll returns the bot's next random number in [0, 1)
+func (b *ChartBot) roll() float64 {
+	b.rngMu.Lock()
+	defer b.rngMu.Unlock()
+	return b.rng.Float64()
+}
+
+// MakeDecision implements the IDecisionMaker interface
+func (b *ChartBot) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	ch <- b.decide(game, player)
+	close(ch)
+	return ch
+}
+
+// decide picks the action the charts or postflop rules call for
+func (b *ChartBot) decide(game *holdem.Game, player holdem.IPlayer) holdem.Action {
+	if game == nil || player == nil {
+		return holdem.Action{Type: holdem.ActionFold}
+	}
+	toCall := b.validator.GetCallAmount(game, player)
+	var raise, call float64 // How often to raise and to call or check
+	var raiseTo int
+	if game.GetCurrentPhase() == holdem.PhasePreflop {
+		decision := b.preflop(game, player)
+		raise, call = decision.Raise, decision.Call
+		raiseTo = 3 * max(game.GetCurrentBet(), game.GetBigBlind())
+	} else {
+		rule := b.strategy.postflopRule(game.GetVariant().NewEvaluator().EvaluateHand(player.GetHandCards(), game.GetCommunityCards()).Rank)
+		raise, call = rule.Raise, rule.Call
+		raiseTo = 3 * game.GetCurrentBet()
+		if toCall == 0 {
+			raise, call = rule.Bet, 0
+			raiseTo = game.GetPot() * 2 / 3
+		}
+	}
+
+	roll := b.roll()
+	action := holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionFold}
+	switch {
+	case roll < raise:
+		action = b.raise(game, player, raiseTo)
+	case toCall == 0:
+		action.Type = holdem.ActionCheck
+	case roll < raise+call:
+		action = holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCall, Amount: min(toCall, player.GetChips())}
+	}
+	if err := b.validator.ValidateAction(game, player, action); err != nil {
+		if corrected, ok := err.Correct(action); ok && b.validator.ValidateAction(game, player, corrected) == nil {
+			return corrected
+		}
+		if toCall == 0 {
+			return holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck}
+		}
+		return holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionFold}
+	}
+	return action
+}
+
+// preflop looks the hole cards up in the chart for the player's position
+// and the raises so far
+func (b *ChartBot) preflop(game *holdem.Game, player holdem.IPlayer) charts.Decision {
+	hand := ranges.HandClass(player.GetHandCards())
+	position, ok := charts.PositionOf(game, player.GetID())
+	if hand == "" || !ok {
+		return charts.Decision{}
+	}
+	decision, err := b.strategy.Preflop.Lookup(position, hand, charts.FacingOf(game))
+	if err != nil {
+		return charts.Decision{}
+	}
+	return decision
+}
+
+// raise raises to raiseTo, kept between the smallest raise and all-in
+func (b *ChartBot) raise(game *holdem.Game, player holdem.IPlayer, raiseTo int) holdem.Action {
+	bet := player.GetBet()
+	raiseTo = max(raiseTo, bet+b.validator.GetMinRaiseAmount(game, player))
+	if raiseTo >= bet+b.validator.GetMaxRaiseAmount(game, player) {
+		if game.GetVariant().BettingStructure() == holdem.NoLimit {
+			return holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionAllIn, Amount: player.GetChips()}
+		}
+		raiseTo = bet + b.validator.GetMaxRaiseAmount(game, player)
+	}
+	return holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionRaise, Amount: raiseTo - bet, RaiseTo: raiseTo}
+}
@@ -0,0 +1,141 @@
+package holdem_ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/charts"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+// chartBotGame deals a heads-up hand from a stacked deck, the button (the
+// small blind) first to act
+func chartBotGame(t *testing.T, cards string) *holdem.Game {
+	t.Helper()
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	for i := 0; i < 2; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	stacked, err := poker.ParseCards(cards)
+	if err != nil {
+		t.Fatalf("ParseCards failed: %v", err)
+	}
+	if err := game.StackDeck(stacked); err != nil {
+		t.Fatalf("StackDeck failed: %v", err)
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	return game
+}
+
+func TestLoadChartStrategy(t *testing.T) {
+	bot, err := LoadChartBot("testdata/chart_bot.csv")
+	if err != nil {
+		t.Fatalf("LoadChartBot failed: %v", err)
+	}
+	preflop := bot.strategy.Preflop
+	for _, tt := range []struct {
+		position charts.Position
+		hand     string
+		facing   charts.Facing
+		want     charts.Decision
+	}{
+		{charts.PositionUTG, "QQ", charts.Unopened, charts.Decision{Raise: 1}},
+		{charts.PositionBB, "AKo", charts.FacingOpen, charts.Decision{Raise: 1}},
+		{charts.PositionCO, "55", charts.FacingOpen, charts.Decision{Call: 1}},
+		{charts.PositionBTN, "KJs", charts.FacingOpen, charts.Decision{Raise: 0.2, Call: 0.6}},
+		{charts.PositionBTN, "KJs", charts.FacingThreeBet, charts.Decision{Call: 0.2}},
+		{charts.PositionCO, "KJs", charts.Unopened, charts.Decision{}},
+	} {
+		if got, err := preflop.Lookup(tt.position, tt.hand, tt.facing); err != nil || got != tt.want {
+			t.Errorf("%s %s %s: expected %+v, got %+v (%v)", tt.position, tt.hand, tt.facing, tt.want, got, err)
+		}
+	}
+
+	// Rules cover better hands without their own
+	for rank, want := range map[holdem.HandRank]PostflopRule{
+		holdem.HighCard:     {},
+		holdem.OnePair:      {Bet: 0.6, Call: 0.8},
+		holdem.ThreeOfAKind: {Bet: 1, Raise: 1},
+		holdem.RoyalFlush:   {Bet: 1, Raise: 1},
+	} {
+		if got := bot.strategy.postflopRule(rank); got != want {
+			t.Errorf("%s: expected %+v, got %+v", holdem.HandRankToString(rank), want, got)
+		}
+	}
+
+	for _, bad := range []string{
+		"XX,AKs,100,0,0",
+		"BTN,AKx,100,0,0",
+		"BTN,AKs,100,60,60",
+		"BTN,AKs,often,0,0",
+		"BTN,AKs,100",
+		"postflop,a monster,100,0,0",
+	} {
+		if _, err := LoadChartStrategy(strings.NewReader(bad)); err == nil {
+			t.Errorf("Expected %q to be refused", bad)
+		}
+	}
+}
+
+func TestChartBotPlaysItsCharts(t *testing.T) {
+	bot, err := CreateSeededBot("testdata/chart_bot.csv", 1)
+	if err != nil {
+		t.Fatalf("CreateSeededBot failed: %v", err)
+	}
+
+	// The small blind opens AKs to three big blinds and the big blind folds K5o
+	game := chartBotGame(t, "AsKhKs5c")
+	open := <-bot.MakeDecision(game, game.GetCurrentPlayer())
+	if open.Type != holdem.ActionRaise || open.RaiseTo != 30 {
+		t.Fatalf("Expected an open to 30, got %s to %d", holdem.ActionTypeToString(open.Type), open.RaiseTo)
+	}
+	if err := game.TakeAction(open); err != nil {
+		t.Fatalf("TakeAction failed: %v", err)
+	}
+	if answer := <-bot.MakeDecision(game, game.GetCurrentPlayer()); answer.Type != holdem.ActionFold {
+		t.Errorf("Expected K5o to fold, got %s", holdem.ActionTypeToString(answer.Type))
+	}
+
+	// With nothing the big blind checks the flop and trips bet two thirds of the pot
+	game = chartBotGame(t, "7s4h7d5c 3c 7c2c9h")
+	for _, action := range []holdem.Action{{PlayerID: 1, Type: holdem.ActionCall, Amount: 5}, {PlayerID: 2, Type: holdem.ActionCheck}} {
+		if err := game.TakeAction(action); err != nil {
+			t.Fatalf("%+v failed: %v", action, err)
+		}
+	}
+	game.DealFlop()
+	check := <-bot.MakeDecision(game, game.GetCurrentPlayer())
+	if check.Type != holdem.ActionCheck {
+		t.Fatalf("Expected high card to check, got %s", holdem.ActionTypeToString(check.Type))
+	}
+	if err := game.TakeAction(check); err != nil {
+		t.Fatalf("TakeAction failed: %v", err)
+	}
+	if bet := <-bot.MakeDecision(game, game.GetCurrentPlayer()); bet.Type != holdem.ActionRaise || bet.RaiseTo != 13 {
+		t.Errorf("Expected trips to bet 13 into 20, got %s to %d", holdem.ActionTypeToString(bet.Type), bet.RaiseTo)
+	}
+}
+
+func TestChartStrategyTakesNamedRanges(t *testing.T) {
+	steal, err := ranges.Parse("K9s+, QTs:0.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	library := ranges.NewLibrary(map[string]*ranges.Range{"steal": steal})
+
+	strategy, err := LoadChartStrategy(strings.NewReader("BTN,@steal,100,0,0\n"), ranges.WithLibrary(library))
+	if err != nil {
+		t.Fatalf("LoadChartStrategy failed: %v", err)
+	}
+	for hand, want := range map[string]float64{"KQs": 1, "QTs": 0.5, "Q9s": 0} {
+		if got, _ := strategy.Preflop.Lookup(charts.PositionBTN, hand, charts.Unopened); got.Raise != want {
+			t.Errorf("%s: expected to open %g of the time, got %g", hand, want, got.Raise)
+		}
+	}
+}
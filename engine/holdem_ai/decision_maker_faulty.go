@@ -0,0 +1,159 @@
+package holdem_ai
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// Faults are the network failures a FaultyDecisionMaker injects, each the
+// chance per decision that it happens. The zero value injects none.
+type Faults struct {
+	Drop       float64       // The decision is lost and never arrives
+	Delay      float64       // The prompt and so the decision arrive DelayBy late
+	DelayBy    time.Duration // How late a delayed decision arrives
+	Duplicate  float64       // The decision is submitted twice
+	Disconnect float64       // The player drops out mid-hand instead of deciding
+}
+
+// FaultyDecisionMaker wraps another decision maker and garbles its
+// decisions on the way to the table the way an unreliable connection
+// would, to check that a table survives seats playing over the network.
+// Faults can be switched on and off between decisions with SetFaults.
+type FaultyDecisionMaker struct {
+	inner IDecisionMaker // Wrapped decision maker
+
+	mu       sync.Mutex
+	faults   Faults
+	rng      *rand.Rand
+	injected map[string]int // Faults injected so far by kind
+}
+
+// NewFaultyDecisionMaker wraps inner, injecting faults drawn from seed
+func NewFaultyDecisionMaker(inner IDecisionMaker, faults Faults, seed int64) *FaultyDecisionMaker {
+	return &FaultyDecisionMaker{
+		inner:    inner,
+		faults:   faults,
+		rng:      rand.New(rand.NewSource(seed)),
+		injected: map[string]int{},
+	}
+}
+
+// SetFaults changes the faults injected from the next decision on.
+// Faults{} passes decisions through untouched.
+func (d *FaultyDecisionMaker) SetFaults(faults Faults) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.faults = faults
+}
+
+// Injected returns how many faults of each kind were injected so far, by
+// "drop", "delay", "duplicate" and "disconnect"
+func (d *FaultyDecisionMaker) Injected() map[string]int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	injected := map[string]int{}
+	for kind, count := range d.injected {
+		injected[kind] = count
+	}
+	return injected
+}
+
+// fault draws what goes wrong with the next decision, "" when nothing does
+func (d *FaultyDecisionMaker) fault() (string, time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	roll := d.rng.Float64()
+	for _, fault := range []struct {
+		kind   string
+		chance float64
+	}{
+		{"drop", d.faults.Drop},
+		{"delay", d.faults.Delay},
+		{"duplicate", d.faults.Duplicate},
+		{"disconnect", d.faults.Disconnect},
+	} {
+		if roll < fault.chance {
+			d.injected[fault.kind]++
+			return fault.kind, d.faults.DelayBy
+		}
+		roll -= fault.chance
+	}
+	return "", 0
+}
+
+// MakeDecision implements the IDecisionMaker interface
+func (d *FaultyDecisionMaker) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 2)
+	timed := d.MakeTimedDecision(game, player)
+
+	go func() {
+		defer close(ch)
+		for decision := range timed {
+			ch <- decision.Action
+		}
+	}()
+
+	return ch
+}
+
+// MakeTimedDecision implements the ITimedDecisionMaker interface. The
+// inner decision is always made, so a dropped or late decision still
+// leaves the wrapped bot in the state it would be in otherwise. A delayed
+// prompt reaches the wrapped decision maker late, so it reads the table
+// after the table may have stopped waiting for it.
+func (d *FaultyDecisionMaker) MakeTimedDecision(game *holdem.Game, player holdem.IPlayer) <-chan TimedDecision {
+	ch := make(chan TimedDecision, 2)
+	fault, delay := d.fault()
+	if fault == "disconnect" {
+		ch <- TimedDecision{Action: holdem.NewDisconnect(player.GetID())}
+		close(ch)
+		return ch
+	}
+
+	go func() {
+		defer close(ch)
+		if fault == "delay" {
+			time.Sleep(delay)
+		}
+
+		start := time.Now()
+		var innerCh <-chan TimedDecision
+		var plainCh <-chan holdem.Action
+		if timed, ok := d.inner.(ITimedDecisionMaker); ok {
+			innerCh = timed.MakeTimedDecision(game, player)
+		} else {
+			plainCh = d.inner.MakeDecision(game, player)
+		}
+
+		var decision TimedDecision
+		var ok bool
+		if innerCh != nil {
+			decision, ok = <-innerCh
+		} else {
+			decision.Action, ok = <-plainCh
+			decision.Thinking = time.Since(start)
+		}
+		if !ok {
+			return
+		}
+		switch fault {
+		case "drop":
+			return
+		case "delay":
+			decision.Thinking += delay
+		case "duplicate":
+			ch <- decision
+		}
+		ch <- decision
+	}()
+
+	return ch
+}
+
+// Unwrap returns the wrapped decision maker
+func (d *FaultyDecisionMaker) Unwrap() IDecisionMaker {
+	return d.inner
+}
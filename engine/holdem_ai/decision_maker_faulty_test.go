@@ -0,0 +1,66 @@
+package holdem_ai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// collect reads every decision the channel delivers, failing if it never closes
+func collect(t *testing.T, ch <-chan TimedDecision) []TimedDecision {
+	t.Helper()
+	decisions := []TimedDecision{}
+	for {
+		select {
+		case decision, ok := <-ch:
+			if !ok {
+				return decisions
+			}
+			decisions = append(decisions, decision)
+		case <-time.After(time.Second):
+			t.Fatal("Faulty decision maker never finished")
+		}
+	}
+}
+
+func TestFaultyDecisionMakerInjectsEachFault(t *testing.T) {
+	game, player, _ := createTestGameSetup()
+	maker := NewFaultyDecisionMaker(&fixedDecisionMaker{actionType: holdem.ActionCall}, Faults{}, 1)
+
+	if decisions := collect(t, maker.MakeTimedDecision(game, player)); len(decisions) != 1 || decisions[0].Action.Type != holdem.ActionCall {
+		t.Errorf("Expected the decision passed through without faults, got %+v", decisions)
+	}
+
+	maker.SetFaults(Faults{Drop: 1})
+	if decisions := collect(t, maker.MakeTimedDecision(game, player)); len(decisions) != 0 {
+		t.Errorf("Expected a dropped decision, got %+v", decisions)
+	}
+
+	maker.SetFaults(Faults{Delay: 1, DelayBy: 20 * time.Millisecond})
+	start := time.Now()
+	decisions := collect(t, maker.MakeTimedDecision(game, player))
+	if len(decisions) != 1 || time.Since(start) < 20*time.Millisecond || decisions[0].Thinking < 20*time.Millisecond {
+		t.Errorf("Expected a decision 20ms late, got %+v after %s", decisions, time.Since(start))
+	}
+
+	maker.SetFaults(Faults{Duplicate: 1})
+	if decisions := collect(t, maker.MakeTimedDecision(game, player)); len(decisions) != 2 || decisions[0] != decisions[1] {
+		t.Errorf("Expected the decision twice, got %+v", decisions)
+	}
+
+	maker.SetFaults(Faults{Disconnect: 1})
+	if decisions := collect(t, maker.MakeTimedDecision(game, player)); len(decisions) != 1 || decisions[0].Action.Type != holdem.ActionDisconnect {
+		t.Errorf("Expected a disconnection, got %+v", decisions)
+	}
+
+	expected := map[string]int{"drop": 1, "delay": 1, "duplicate": 1, "disconnect": 1}
+	for kind, count := range expected {
+		if got := maker.Injected()[kind]; got != count {
+			t.Errorf("Expected %d %s faults, got %d", count, kind, got)
+		}
+	}
+	if _, ok := maker.Unwrap().(*fixedDecisionMaker); !ok {
+		t.Error("Expected Unwrap to return the inner decision maker")
+	}
+}
@@ -1,36 +1,120 @@
 package holdem_ai
 
 import (
+	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/i18n"
 )
 
+// AutoActions are a human's standing answers to trivial decisions
+type AutoActions struct {
+	Muck   bool // Muck losing hands at showdown instead of showing them
+	Check  bool // Check whenever checking is possible
+	CallBB int  // Call bets of at most this many big blinds, 0 is off
+}
+
+// HumanDecisionTimeout is how long MakeDecision waits for the human before
+// folding for them
+const HumanDecisionTimeout = 60 * time.Second
+
 type HumanDecisionMaker struct {
 	validator     holdem.IActionValidator // Action validator for legal moves
 	actionChannel chan holdem.Action      // Channel to receive actions from external frontend
+	logger        *slog.Logger            // Structured logger, discards by default
+
+	mu         sync.Mutex
+	auto       AutoActions
+	manualHand int              // Hand number the auto actions are overridden for, 0 for none
+	now        func() time.Time // Clock the deadline is reckoned by
+	deadline   time.Time        // When the decision being waited for times out, zero when none is
 }
 
 func NewHumanDecisionMaker() *HumanDecisionMaker {
 	return &HumanDecisionMaker{
 		validator:     holdem.NewActionValidator(),
 		actionChannel: make(chan holdem.Action, 1),
+		logger:        holdem.NewDiscardLogger(),
+		now:           time.Now,
+	}
+}
+
+// SetLogger injects the structured logger used by the decision maker.
+// Passing nil restores the discard logger.
+func (d *HumanDecisionMaker) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = holdem.NewDiscardLogger()
 	}
+	d.logger = logger
+}
+
+// SetTranslator sets the language of the validation errors returned for
+// rejected actions
+func (d *HumanDecisionMaker) SetTranslator(translator *i18n.Translator) {
+	validator := holdem.NewActionValidator()
+	validator.SetTranslator(translator)
+	d.validator = validator
+}
+
+// SetClock sets the clock Deadline is reckoned by, time.Now by default.
+// The timeout itself always runs on real time.
+func (d *HumanDecisionMaker) SetClock(now func() time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.now = now
+}
+
+// Deadline returns when the decision MakeDecision is waiting for times out,
+// false while no decision is being waited for
+func (d *HumanDecisionMaker) Deadline() (time.Time, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.deadline, !d.deadline.IsZero()
 }
 
 // MakeDecision implements the IDecisionMaker interface
 // This will wait for an action to be provided via SetAction method
 func (d *HumanDecisionMaker) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
 	ch := make(chan holdem.Action, 1)
+	d.mu.Lock()
+	deadline := d.now().Add(HumanDecisionTimeout)
+	d.deadline = deadline
+	d.mu.Unlock()
 
 	go func() {
 		defer close(ch)
+		defer func() {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			if d.deadline.Equal(deadline) {
+				d.deadline = time.Time{}
+			}
+		}()
 
 		// Wait for external frontend to provide an action
 		select {
 		case action := <-d.actionChannel:
-			// Validate the action before returning
+			// Amounts are corrected, e.g. calling 45 into a bet of 50 calls 50
 			if err := d.validator.ValidateAction(game, player, action); err != nil {
+				if corrected, ok := err.Correct(action); ok && corrected.Type == action.Type && d.validator.ValidateAction(game, player, corrected) == nil {
+					d.logger.Info("human action corrected",
+						slog.Int("player_id", player.GetID()),
+						slog.String("action", holdem.ActionTypeToString(action.Type)),
+						slog.Int("amount", action.Amount),
+						slog.Int("corrected_amount", err.NearestAmount),
+						slog.String("reason", err.Message),
+					)
+					ch <- corrected
+					return
+				}
+				d.logger.Warn("human action rejected, folding",
+					slog.Int("player_id", player.GetID()),
+					slog.String("action", holdem.ActionTypeToString(action.Type)),
+					slog.Int("amount", action.Amount),
+					slog.String("reason", err.Message),
+				)
 				// If action is invalid, return a fold action as fallback
 				fallbackAction := holdem.Action{
 					PlayerID: player.GetID(),
@@ -41,7 +125,8 @@ func (d *HumanDecisionMaker) MakeDecision(game *holdem.Game, player holdem.IPlay
 			} else {
 				ch <- action
 			}
-		case <-time.After(60 * time.Second): // 60 second timeout
+		case <-time.After(HumanDecisionTimeout):
+			d.logger.Warn("human decision timed out, folding", slog.Int("player_id", player.GetID()))
 			// Timeout - return fold action
 			timeoutAction := holdem.Action{
 				PlayerID: player.GetID(),
@@ -62,15 +147,101 @@ func (d *HumanDecisionMaker) SetAction(action holdem.Action) {
 		// Action sent successfully
 	default:
 		// Channel is full or not ready, ignore
+		d.logger.Debug("human action dropped, previous action still pending", slog.Int("player_id", action.PlayerID))
 	}
 }
 
+// SetAutoActions changes the standing answers used by AutoAnswer and ShouldMuck
+func (d *HumanDecisionMaker) SetAutoActions(auto AutoActions) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.auto = auto
+}
+
+// GetAutoActions returns the standing answers
+func (d *HumanDecisionMaker) GetAutoActions() AutoActions {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.auto
+}
+
+// OverrideAutoActions switches the auto actions off for the rest of the
+// given hand, so every decision in it is asked for. Passing 0 clears it.
+func (d *HumanDecisionMaker) OverrideAutoActions(handNumber int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.manualHand = handNumber
+}
+
+// IsOverridden reports whether the auto actions are off for the given hand
+func (d *HumanDecisionMaker) IsOverridden(handNumber int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return handNumber > 0 && d.manualHand == handNumber
+}
+
+// activeAutoActions returns the standing answers that apply to the current hand
+func (d *HumanDecisionMaker) activeAutoActions(game *holdem.Game) AutoActions {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.manualHand > 0 && d.manualHand == game.GetHandNumber() {
+		return AutoActions{}
+	}
+	return d.auto
+}
+
+// AutoAnswer pre-answers the decision when the auto actions cover it: it
+// queues a check or small call for MakeDecision and returns it. It must be
+// called before MakeDecision, so the frontend knows not to prompt.
+func (d *HumanDecisionMaker) AutoAnswer(game *holdem.Game, player holdem.IPlayer) (holdem.Action, bool) {
+	auto := d.activeAutoActions(game)
+	available := d.validator.GetAvailableActions(game, player)
+	can := func(actionType holdem.ActionType) bool {
+		for _, a := range available {
+			if a == actionType {
+				return true
+			}
+		}
+		return false
+	}
+
+	call := d.GetCallAmount(game, player)
+	action := holdem.Action{PlayerID: player.GetID()}
+	switch {
+	case auto.Check && call == 0 && can(holdem.ActionCheck):
+		action.Type = holdem.ActionCheck
+	case auto.CallBB > 0 && call > 0 && call <= auto.CallBB*game.GetBigBlind() && can(holdem.ActionCall):
+		action.Type, action.Amount = holdem.ActionCall, call
+	default:
+		return holdem.Action{}, false
+	}
+	d.logger.Debug("human decision auto-answered",
+		slog.Int("player_id", player.GetID()),
+		slog.String("action", holdem.ActionTypeToString(action.Type)),
+		slog.Int("amount", action.Amount),
+	)
+	d.SetAction(action)
+	return action, true
+}
+
+// ShouldMuck implements IMuckDecider: losing hands are mucked when the
+// auto actions say so
+func (d *HumanDecisionMaker) ShouldMuck(game *holdem.Game, player holdem.IPlayer) bool {
+	return d.activeAutoActions(game).Muck && game.LostShowdown(player.GetID())
+}
+
 // GetAvailableActions returns the valid actions for the current game state
 // This can be used by external frontend to show available options
 func (d *HumanDecisionMaker) GetAvailableActions(game *holdem.Game, player holdem.IPlayer) []holdem.ActionType {
 	return d.validator.GetAvailableActions(game, player)
 }
 
+// GetConstraints returns the legal actions and their amounts in one pass,
+// for frontends building the prompt of the player's turn
+func (d *HumanDecisionMaker) GetConstraints(game *holdem.Game, player holdem.IPlayer) holdem.Constraints {
+	return holdem.ValidatorConstraints(game, player)
+}
+
 // GetMinRaiseAmount returns the minimum raise amount
 func (d *HumanDecisionMaker) GetMinRaiseAmount(game *holdem.Game, player holdem.IPlayer) int {
 	return d.validator.GetMinRaiseAmount(game, player)
@@ -89,41 +260,17 @@ func (d *HumanDecisionMaker) ValidateAction(game *holdem.Game, player holdem.IPl
 	return nil
 }
 
-// GetCallAmount calculates the amount needed to call
+// GetCallAmount returns the amount needed to call, antes left out
 func (d *HumanDecisionMaker) GetCallAmount(game *holdem.Game, player holdem.IPlayer) int {
-	actions := d.getCurrentPhaseActions(game)
-	currentBet := 0
-
-	for _, action := range actions {
-		if action.Type == holdem.ActionRaise || action.Type == holdem.ActionCall {
-			if action.Amount > currentBet {
-				currentBet = action.Amount
-			}
-		}
-	}
-
-	callAmount := currentBet - player.GetBet()
-	if callAmount < 0 {
-		callAmount = 0
-	}
+	return d.validator.GetCallAmount(game, player)
+}
 
-	return callAmount
+// GetPotOdds returns the equity needed to break even on a call, antes in the pot
+func (d *HumanDecisionMaker) GetPotOdds(game *holdem.Game, player holdem.IPlayer) float64 {
+	return d.validator.GetPotOdds(game, player)
 }
 
 // Helper function to get current phase actions
 func (d *HumanDecisionMaker) getCurrentPhaseActions(game *holdem.Game) []holdem.Action {
-	userActions := game.GetUserActions()
-
-	switch game.GetCurrentPhase() {
-	case holdem.PhasePreflop:
-		return userActions.Preflop
-	case holdem.PhaseFlop:
-		return userActions.Flop
-	case holdem.PhaseTurn:
-		return userActions.Turn
-	case holdem.PhaseRiver:
-		return userActions.River
-	default:
-		return []holdem.Action{}
-	}
+	return game.GetUserActions().Street(game.GetCurrentPhase())
 }
@@ -1,6 +1,9 @@
 package holdem_ai
 
 import (
+	"bytes"
+	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
@@ -63,6 +66,29 @@ func TestHumanDecisionMakerTimeout(t *testing.T) {
 	}
 }
 
+func TestHumanDecisionMakerDeadline(t *testing.T) {
+	human := NewHumanDecisionMaker()
+	game, player, _ := createTestGameSetup()
+	start := time.Date(2026, time.May, 1, 12, 0, 0, 0, time.UTC)
+	human.SetClock(func() time.Time { return start })
+
+	if _, ok := human.Deadline(); ok {
+		t.Fatal("Expected no deadline before a decision is asked for")
+	}
+	ch := human.MakeDecision(game, player)
+	if deadline, ok := human.Deadline(); !ok || !deadline.Equal(start.Add(HumanDecisionTimeout)) {
+		t.Errorf("Expected the deadline a timeout after the clock, got %v", deadline)
+	}
+
+	human.SetAction(holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionFold})
+	<-ch
+	for range ch {
+	}
+	if _, ok := human.Deadline(); ok {
+		t.Error("Expected the deadline cleared once the decision was made")
+	}
+}
+
 func TestHumanDecisionMakerValidAction(t *testing.T) {
 	human := NewHumanDecisionMaker()
 	game, player, _ := createTestGameSetup()
@@ -264,6 +290,7 @@ func TestHumanDecisionMakerGetCallAmount(t *testing.T) {
 		PlayerID: player2.GetID(),
 		Type:     holdem.ActionRaise,
 		Amount:   50,
+		RaiseTo:  50,
 	}
 	game.TakeAction(raiseAction)
 
@@ -323,3 +350,145 @@ func TestHumanDecisionMakerGetCurrentPhaseActions(t *testing.T) {
 		t.Errorf("Expected no actions for invalid phase, got %d", len(actions))
 	}
 }
+
+func TestHumanDecisionMakerLogsRejectedAction(t *testing.T) {
+	var buf bytes.Buffer
+	human := NewHumanDecisionMaker()
+	human.SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	game, player, _ := createTestGameSetup()
+
+	// Checking a bet is illegal, so the action falls back to fold
+	game.TakeAction(holdem.NewRaise(2, 50))
+	human.SetAction(holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck})
+
+	select {
+	case action := <-human.MakeDecision(game, player):
+		if action.Type != holdem.ActionFold {
+			t.Errorf("Expected fold fallback, got %d", action.Type)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Human decision maker did not respond")
+	}
+
+	if !strings.Contains(buf.String(), "human action rejected") {
+		t.Errorf("Expected rejected action warning, got %q", buf.String())
+	}
+}
+
+func TestHumanDecisionMakerCorrectsAmounts(t *testing.T) {
+	game := startAutoTestHand(t)
+	if err := game.TakeAction(holdem.NewRaise(1, 60)); err != nil {
+		t.Fatalf("Raise failed: %v", err)
+	}
+	bigBlind := game.GetCurrentPlayer()
+	human := NewHumanDecisionMaker()
+
+	for _, tc := range []struct {
+		proposed, want holdem.Action
+	}{
+		{holdem.Action{PlayerID: 2, Type: holdem.ActionCall, Amount: 45}, holdem.Action{PlayerID: 2, Type: holdem.ActionCall, Amount: 50}},
+		{holdem.NewRaise(2, 80), holdem.NewRaise(2, 110)}, // Below the minimum re-raise
+		{holdem.Action{PlayerID: 2, Type: holdem.ActionCheck}, holdem.Action{PlayerID: 2, Type: holdem.ActionFold}},
+	} {
+		human.SetAction(tc.proposed)
+		select {
+		case action := <-human.MakeDecision(game, bigBlind):
+			if action != tc.want {
+				t.Errorf("Expected %+v to become %+v, got %+v", tc.proposed, tc.want, action)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Human decision maker did not respond")
+		}
+	}
+}
+
+// startAutoTestHand starts a heads-up hand with 5/10 blinds; player 1 has the button and acts first
+func startAutoTestHand(t *testing.T) *holdem.Game {
+	t.Helper()
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	game.PlayerSit(holdem.NewPlayer(1, "Player 1", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Player 2", 1000), 1)
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	return game
+}
+
+func TestHumanDecisionMakerAutoAnswer(t *testing.T) {
+	game := startAutoTestHand(t)
+	button := game.GetCurrentPlayer()
+
+	human := NewHumanDecisionMaker()
+	if _, ok := human.AutoAnswer(game, button); ok {
+		t.Fatal("Expected no auto answer without auto actions")
+	}
+
+	human.SetAutoActions(AutoActions{Check: true, CallBB: 1})
+	action, ok := human.AutoAnswer(game, button)
+	if !ok || action.Type != holdem.ActionCall || action.Amount != 5 {
+		t.Fatalf("Expected an auto call of the 5 chip small blind, got %+v %v", action, ok)
+	}
+	select {
+	case decided := <-human.MakeDecision(game, button):
+		if decided != action {
+			t.Errorf("Expected MakeDecision to return the queued %+v, got %+v", action, decided)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the auto answer to be returned without waiting")
+	}
+	if err := game.TakeAction(action); err != nil {
+		t.Fatalf("Auto call was illegal: %v", err)
+	}
+
+	bigBlind := game.GetCurrentPlayer()
+	if action, ok := human.AutoAnswer(game, bigBlind); !ok || action.Type != holdem.ActionCheck {
+		t.Errorf("Expected an auto check in the big blind, got %+v %v", action, ok)
+	}
+}
+
+func TestHumanDecisionMakerAutoCallLimit(t *testing.T) {
+	game := startAutoTestHand(t)
+	if err := game.TakeAction(holdem.NewRaise(1, 55)); err != nil {
+		t.Fatalf("Raise failed: %v", err)
+	}
+	bigBlind := game.GetCurrentPlayer()
+
+	human := NewHumanDecisionMaker()
+	human.SetAutoActions(AutoActions{Check: true, CallBB: 2})
+	if action, ok := human.AutoAnswer(game, bigBlind); ok {
+		t.Errorf("Expected a 45 chip call to exceed 2 BB, got %+v", action)
+	}
+	human.SetAutoActions(AutoActions{CallBB: 5})
+	if action, ok := human.AutoAnswer(game, bigBlind); !ok || action.Amount != 45 {
+		t.Errorf("Expected an auto call of 45 chips, got %+v %v", action, ok)
+	}
+}
+
+func TestHumanDecisionMakerOverrideAutoActions(t *testing.T) {
+	game := startAutoTestHand(t)
+	human := NewHumanDecisionMaker()
+	human.SetAutoActions(AutoActions{Check: true, CallBB: 1})
+	human.OverrideAutoActions(game.GetHandNumber())
+
+	if !human.IsOverridden(game.GetHandNumber()) {
+		t.Error("Expected the current hand to be overridden")
+	}
+	if action, ok := human.AutoAnswer(game, game.GetCurrentPlayer()); ok {
+		t.Errorf("Expected no auto answer in an overridden hand, got %+v", action)
+	}
+	if human.IsOverridden(game.GetHandNumber() + 1) {
+		t.Error("Expected the override to end with the hand")
+	}
+}
+
+func TestHumanDecisionMakerShouldMuck(t *testing.T) {
+	game := startAutoTestHand(t)
+	human := NewHumanDecisionMaker()
+	human.SetAutoActions(AutoActions{Muck: true})
+	player, _ := game.GetPlayerByID(1)
+	if human.ShouldMuck(game, player) {
+		t.Error("Expected no muck before showdown")
+	}
+
+	var _ IMuckDecider = human
+}
@@ -0,0 +1,99 @@
+package holdem_ai
+
+import (
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/metrics"
+)
+
+// InstrumentedDecisionMaker wraps another decision maker and reports
+// decision latency and chosen actions to a metrics sink
+type InstrumentedDecisionMaker struct {
+	name    string           // Label identifying the bot in metric series
+	inner   IDecisionMaker   // Wrapped decision maker
+	metrics metrics.IMetrics // Metrics sink receiving the samples
+}
+
+// NewInstrumentedDecisionMaker wraps inner so every decision is measured under the given bot name
+func NewInstrumentedDecisionMaker(name string, inner IDecisionMaker, m metrics.IMetrics) *InstrumentedDecisionMaker {
+	if m == nil {
+		m = metrics.NewNoop()
+	}
+	return &InstrumentedDecisionMaker{
+		name:    name,
+		inner:   inner,
+		metrics: m,
+	}
+}
+
+// MakeDecision implements the IDecisionMaker interface
+func (d *InstrumentedDecisionMaker) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	start := time.Now()
+	innerCh := d.inner.MakeDecision(game, player)
+
+	go func() {
+		defer close(ch)
+
+		action, ok := <-innerCh
+		d.record(time.Since(start), action, ok)
+		if ok {
+			ch <- action
+		}
+	}()
+
+	return ch
+}
+
+// MakeTimedDecision implements the ITimedDecisionMaker interface. The
+// simulated time of a timed inner decision maker is passed on, otherwise
+// the measured latency is reported.
+func (d *InstrumentedDecisionMaker) MakeTimedDecision(game *holdem.Game, player holdem.IPlayer) <-chan TimedDecision {
+	ch := make(chan TimedDecision, 1)
+	start := time.Now()
+	timed, isTimed := d.inner.(ITimedDecisionMaker)
+	var innerCh <-chan TimedDecision
+	var plainCh <-chan holdem.Action
+	if isTimed {
+		innerCh = timed.MakeTimedDecision(game, player)
+	} else {
+		plainCh = d.inner.MakeDecision(game, player)
+	}
+
+	go func() {
+		defer close(ch)
+
+		var decision TimedDecision
+		var ok bool
+		if isTimed {
+			decision, ok = <-innerCh
+		} else {
+			decision.Action, ok = <-plainCh
+			decision.Thinking = time.Since(start)
+		}
+		d.record(time.Since(start), decision.Action, ok)
+		if ok {
+			ch <- decision
+		}
+	}()
+
+	return ch
+}
+
+// record reports one decision's latency and, when it was made, its action
+func (d *InstrumentedDecisionMaker) record(latency time.Duration, action holdem.Action, ok bool) {
+	d.metrics.ObserveHistogram(metrics.DecisionLatencySeconds, metrics.Labels{"bot": d.name}, latency.Seconds())
+	if !ok {
+		return
+	}
+	d.metrics.IncCounter(metrics.DecisionsTotal, metrics.Labels{
+		"bot":    d.name,
+		"action": holdem.ActionTypeToString(action.Type),
+	}, 1)
+}
+
+// Unwrap returns the wrapped decision maker
+func (d *InstrumentedDecisionMaker) Unwrap() IDecisionMaker {
+	return d.inner
+}
@@ -0,0 +1,79 @@
+package holdem_ai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/metrics"
+)
+
+// fixedDecisionMaker immediately answers with a fixed action type
+type fixedDecisionMaker struct {
+	actionType holdem.ActionType
+}
+
+func (d *fixedDecisionMaker) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	ch <- holdem.Action{PlayerID: player.GetID(), Type: d.actionType}
+	close(ch)
+	return ch
+}
+
+func TestInstrumentedDecisionMaker(t *testing.T) {
+	registry := metrics.NewRegistry()
+	game, player, _ := createTestGameSetup()
+	maker := NewInstrumentedDecisionMaker("nit", &fixedDecisionMaker{actionType: holdem.ActionFold}, registry)
+
+	var _ IDecisionMaker = maker
+
+	for i := 0; i < 2; i++ {
+		select {
+		case action := <-maker.MakeDecision(game, player):
+			if action.Type != holdem.ActionFold {
+				t.Errorf("Expected wrapped action to pass through, got %d", action.Type)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Instrumented decision maker did not respond")
+		}
+	}
+
+	if c := registry.HistogramCount(metrics.DecisionLatencySeconds, metrics.Labels{"bot": "nit"}); c != 2 {
+		t.Errorf("Expected 2 latency observations, got %d", c)
+	}
+	if v := registry.CounterValue(metrics.DecisionsTotal, metrics.Labels{"bot": "nit", "action": "Fold"}); v != 2 {
+		t.Errorf("Expected 2 fold decisions, got %v", v)
+	}
+	if _, ok := maker.Unwrap().(*fixedDecisionMaker); !ok {
+		t.Error("Expected Unwrap to return the inner decision maker")
+	}
+}
+
+func TestInstrumentedDecisionMakerNilMetrics(t *testing.T) {
+	game, player, _ := createTestGameSetup()
+	maker := NewInstrumentedDecisionMaker("nit", &fixedDecisionMaker{actionType: holdem.ActionCheck}, nil)
+
+	select {
+	case action := <-maker.MakeDecision(game, player):
+		if action.Type != holdem.ActionCheck {
+			t.Errorf("Expected check, got %d", action.Type)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Instrumented decision maker did not respond")
+	}
+}
+
+func TestAsFindsTheWrappedBot(t *testing.T) {
+	bot := NewBasicBotDecisionMaker(0.5, 0.1)
+	wrapped := NewInstrumentedDecisionMaker("basic", bot, nil)
+
+	if folder, ok := As[IPushFolder](wrapped); !ok || folder != IPushFolder(bot) {
+		t.Error("Expected As to find the bot under the instrumentation")
+	}
+	if found, ok := As[*BasicBotDecisionMaker](wrapped); !ok || found != bot {
+		t.Error("Expected As to find the bot's concrete type")
+	}
+	if _, ok := As[IPushFolder](NewInstrumentedDecisionMaker("empty", nil, nil)); ok {
+		t.Error("Expected nothing to be found without a bot")
+	}
+}
@@ -0,0 +1,113 @@
+package holdem_ai
+
+import (
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/pokermath"
+)
+
+// StackDepth buckets the effective stack in big blinds
+type StackDepth string
+
+const (
+	DepthShort  StackDepth = "short"  // Under ShortStackBB
+	DepthMedium StackDepth = "medium" // From ShortStackBB up to DeepStackBB
+	DepthDeep   StackDepth = "deep"   // Over DeepStackBB
+)
+
+// Depth bucket bounds in big blinds
+const (
+	ShortStackBB = 40
+	DeepStackBB  = 100
+)
+
+// Stack-to-pot ratios at the start of a street that change how readily
+// the bot puts its stack in
+const (
+	CommitSPR = 3  // At or below this, a good hand goes all in rather than fold later
+	DeepSPR   = 10 // At or above this, one good hand is not worth building a big pot
+)
+
+// depthPreflop is how much a starting hand's strength moves with depth:
+// speculative hands want deep stacks to be paid off when they hit, while
+// big cards hold their value when there is no room to play after the flop
+const depthPreflop = 0.05
+
+// deepValue is how much the value-raise threshold climbs at a deep SPR
+const deepValue = 0.05
+
+// depthOf buckets an effective stack in big blinds
+func depthOf(stackBB float64) StackDepth {
+	switch {
+	case stackBB < ShortStackBB:
+		return DepthShort
+	case stackBB > DeepStackBB:
+		return DepthDeep
+	default:
+		return DepthMedium
+	}
+}
+
+// effectiveStack returns the chips the player can win or lose in the hand:
+// their stack or the deepest opponent's still in, whichever is smaller,
+// counting what both have bet on the current street
+func (d *BasicBotDecisionMaker) effectiveStack(game *holdem.Game, player holdem.IPlayer) int {
+	deepest := 0
+	for _, other := range game.GetAllPlayers() {
+		if other.GetID() != player.GetID() && !other.IsFolded() {
+			deepest = maxInt(deepest, other.GetChips()+other.GetBet())
+		}
+	}
+	return minInt(player.GetChips()+player.GetBet(), deepest)
+}
+
+// stackToPot returns the stack-to-pot ratio at the start of the current
+// street, 0 when there is no pot yet
+func (d *BasicBotDecisionMaker) stackToPot(game *holdem.Game, player holdem.IPlayer) float64 {
+	pot := game.GetPot()
+	for _, other := range game.GetAllPlayers() {
+		pot -= other.GetBet()
+	}
+	return pokermath.StackToPot(d.effectiveStack(game, player), pot)
+}
+
+// preflopDepthAdjustment moves a starting hand's strength with the stack
+// depth: small pairs and suited connectors play better deep and worse
+// short, and big unpaired cards the other way round
+func (d *BasicBotDecisionMaker) preflopDepthAdjustment(holeCards []*poker.Card, depth StackDepth) float64 {
+	if len(holeCards) < 2 || depth == DepthMedium {
+		return 0
+	}
+	rank1, rank2 := d.rankToValue(holeCards[0].Rank), d.rankToValue(holeCards[1].Rank)
+	high, low := maxInt(rank1, rank2), minInt(rank1, rank2)
+	speculative := (rank1 == rank2 && high <= 9) ||
+		(holeCards[0].Suit == holeCards[1].Suit && high-low <= 2 && high <= 11)
+	bigCards := rank1 != rank2 && low >= 10
+
+	adjustment := 0.0
+	switch {
+	case speculative:
+		adjustment = depthPreflop
+	case bigCards:
+		adjustment = -depthPreflop
+	}
+	if depth == DepthShort {
+		adjustment = -adjustment
+	}
+	return adjustment
+}
+
+// commitment returns the adjustment for the stack-to-pot ratio after the
+// flop: at a low SPR good hands commit, at a deep one the bot needs a
+// stronger hand to raise for value
+func commitment(spr float64) adjustment {
+	result := balanced
+	switch {
+	case spr <= 0:
+	case spr <= CommitSPR:
+		result.commit = true
+	case spr >= DeepSPR:
+		result.valueShift = -deepValue
+	}
+	return result
+}
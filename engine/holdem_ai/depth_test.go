@@ -0,0 +1,87 @@
+package holdem_ai
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestDepthOf(t *testing.T) {
+	for _, tt := range []struct {
+		stackBB float64
+		depth   StackDepth
+	}{{10, DepthShort}, {39.5, DepthShort}, {40, DepthMedium}, {100, DepthMedium}, {101, DepthDeep}, {250, DepthDeep}} {
+		if depth := depthOf(tt.stackBB); depth != tt.depth {
+			t.Errorf("Expected %.1f BB to be %s, got %s", tt.stackBB, tt.depth, depth)
+		}
+	}
+}
+
+func TestPreflopDepthAdjustment(t *testing.T) {
+	bot := NewBasicBotDecisionMaker(0.5, 0.1)
+	hand := func(codes string) []*poker.Card {
+		cards, err := poker.ParseCards(codes)
+		if err != nil {
+			t.Fatalf("ParseCards failed: %v", err)
+		}
+		return cards
+	}
+
+	connectors, pair, broadway := hand("7h6h"), hand("4c4d"), hand("KdQc")
+	for _, cards := range [][]*poker.Card{connectors, pair} {
+		short := bot.preflopDepthAdjustment(cards, DepthShort)
+		deep := bot.preflopDepthAdjustment(cards, DepthDeep)
+		if short >= 0 || deep <= 0 {
+			t.Errorf("Expected %s to play worse short and better deep, got %+.2f and %+.2f", poker.Cards(cards).Codes(), short, deep)
+		}
+	}
+	if short, deep := bot.preflopDepthAdjustment(broadway, DepthShort), bot.preflopDepthAdjustment(broadway, DepthDeep); short <= 0 || deep >= 0 {
+		t.Errorf("Expected KQo to play better short and worse deep, got %+.2f and %+.2f", short, deep)
+	}
+	if adjustment := bot.preflopDepthAdjustment(connectors, DepthMedium); adjustment != 0 {
+		t.Errorf("Expected no adjustment at medium depth, got %+.2f", adjustment)
+	}
+}
+
+func TestBasicBotCommitsAtLowStackToPot(t *testing.T) {
+	// Heads-up, a 300 chip pot and 100 chips behind (SPR 1/3 after a raise
+	// to 150 each): the big blind flops top pair
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	for i := 0; i < 2; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 250), i); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	stacked, _ := poker.ParseCards("9sKh8dQs 3c Kd7c2h")
+	if err := game.StackDeck(stacked); err != nil {
+		t.Fatalf("StackDeck failed: %v", err)
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	for _, action := range []holdem.Action{holdem.NewRaise(1, 150), {PlayerID: 2, Type: holdem.ActionCall, Amount: 140}} {
+		if err := game.TakeAction(action); err != nil {
+			t.Fatalf("%+v failed: %v", action, err)
+		}
+	}
+	game.DealFlop()
+
+	bot := NewBasicBotDecisionMaker(0.5, 0.0)
+	bot.SetSeed(1)
+	player := game.GetCurrentPlayer()
+	if spr := bot.stackToPot(game, player); spr < 0.3 || spr > 0.4 {
+		t.Errorf("Expected an SPR of 1/3, got %.2f", spr)
+	}
+	action, trace := bot.decide(game, player)
+	if action.Type != holdem.ActionAllIn {
+		t.Errorf("Expected top pair to commit at a low SPR, got %s %d", holdem.ActionTypeToString(action.Type), action.Amount)
+	}
+	if trace.Depth != DepthShort || trace.SPR != bot.stackToPot(game, player) {
+		t.Errorf("Expected the trace to carry the depth and SPR, got %+v", trace)
+	}
+
+	if adjust := commitment(DeepSPR); adjust.commit || adjust.valueShift >= 0 {
+		t.Errorf("Expected a deep SPR to tighten value raises, got %+v", adjust)
+	}
+}
@@ -0,0 +1,82 @@
+package holdem_ai_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+// Wire a human decision maker into a session against a bot. The session
+// calls the observer before asking a player to act, which is where a
+// frontend prompts the human; whatever it hands to SetAction is played.
+func ExampleHumanDecisionMaker() {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 1})
+	game.PlayerSit(holdem.NewPlayer(1, "Hero", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Nit", 1000), 1)
+
+	human := holdem_ai.NewHumanDecisionMaker()
+	bot, err := holdem_ai.CreateBotByName("nit")
+	if err != nil {
+		panic(err)
+	}
+	s := session.New(game)
+	s.SetDecisionMakers(map[int]holdem_ai.IDecisionMaker{1: human, 2: bot})
+	s.SetObserver(func(event session.Event, game *holdem.Game) {
+		switch {
+		case event.Type == session.EventTurn && event.PlayerID == 1:
+			player, _ := game.GetPlayerByID(1)
+			actions := []string{}
+			for _, action := range human.GetAvailableActions(game, player) {
+				actions = append(actions, holdem.ActionTypeToString(action))
+			}
+			fmt.Println("Hero may:", strings.Join(actions, ", "))
+			human.SetAction(holdem.Action{PlayerID: 1, Type: holdem.ActionFold})
+		case event.Type == session.EventAction:
+			player, _ := game.GetPlayerByID(event.PlayerID)
+			fmt.Printf("%s: %s\n", player.GetName(), holdem.ActionTypeToString(event.Action.Type))
+		}
+	})
+
+	// Hero has the button, which posts the small blind and acts first heads-up
+	result, err := s.PlayHand(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Nit wins %d\n", result.Net[2])
+	// Output:
+	// Hero may: Fold, Call, Raise, All-In
+	// Hero: Fold
+	// Nit wins 5
+}
+
+// Play a hand between preset bots. The session moves the button, asks each
+// bot for its decision and deals the streets until the pot is awarded.
+func ExampleCreateBotByName() {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10})
+	s := session.New(game)
+	for seat, name := range []string{"tight", "loose", "maniac"} {
+		bot, err := holdem_ai.CreateBotByName(name)
+		if err != nil {
+			panic(err)
+		}
+		// Bots take a human-like time over each decision unless told not to
+		bot.(*holdem_ai.BasicBotDecisionMaker).SetThinkingTime(0, 0)
+		game.PlayerSit(holdem.NewPlayer(seat+1, name, 1000), seat)
+		s.SetDecisionMaker(seat+1, bot)
+	}
+
+	result, err := s.PlayHand(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	net := 0
+	for _, chips := range result.Net {
+		net += chips
+	}
+	fmt.Printf("Hand #%d played, %d chips changed hands overall\n", result.HandNumber, net)
+	// Output: Hand #1 played, 0 chips changed hands overall
+}
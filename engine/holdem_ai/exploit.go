@@ -0,0 +1,110 @@
+package holdem_ai
+
+import (
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// Mode is how a bot weighs its opponents' tendencies
+type Mode string
+
+const (
+	// ModeBalanced plays the bot's own style whoever it faces
+	ModeBalanced Mode = "balanced"
+	// ModeExploitative bends the bot's style against opponents the
+	// OpponentModel has seen enough of
+	ModeExploitative Mode = "exploitative"
+)
+
+// Samples the opponent model needs before exploitative play trusts it
+const (
+	ExploitMinHands     = 30 // Hands watched before an opponent is exploited at all
+	ExploitMinFacedBets = 10 // Bets faced before their fold-to-bet is trusted
+)
+
+// Fold-to-bet shares beyond which opponents are exploited
+const (
+	overfoldShare  = 0.55 // Bluff more against players who fold this often
+	stationShare   = 0.30 // Stop bluffing and bet thinner against players who fold this rarely
+	overfoldBluffs = 2.0  // How much more often the bot bluffs against overfolders
+	stationValue   = 0.1  // How much lower the value-raise threshold drops against stations
+)
+
+// adjustment is how a decision bends the bot's style, with the reasons
+type adjustment struct {
+	bluffScale float64 // Multiplies the bluff frequency
+	valueShift float64 // Lowers the threshold for raising for value, raises it when negative
+	commit     bool    // Go all in with a good hand rather than call or raise part of the stack
+	reasons    []string
+}
+
+// balanced is the adjustment of a decision that bends nothing
+var balanced = adjustment{bluffScale: 1}
+
+// and combines two adjustments
+func (a adjustment) and(b adjustment) adjustment {
+	return adjustment{
+		bluffScale: a.bluffScale * b.bluffScale,
+		valueShift: a.valueShift + b.valueShift,
+		commit:     a.commit || b.commit,
+		reasons:    append(append([]string(nil), a.reasons...), b.reasons...),
+	}
+}
+
+// ParseMode parses a bot mode by name
+func ParseMode(name string) (Mode, error) {
+	switch Mode(name) {
+	case ModeBalanced, ModeExploitative:
+		return Mode(name), nil
+	}
+	return "", fmt.Errorf("unknown bot mode %q, want %s or %s", name, ModeBalanced, ModeExploitative)
+}
+
+// exploits works out how to bend the bot's play against the opponents
+// still in the hand. Only opponents the model has watched for
+// ExploitMinHands hands and ExploitMinFacedBets bets count: the bot bluffs
+// more when every one of them overfolds, and stops bluffing and raises
+// thinner for value when any of them rarely folds.
+func (d *BasicBotDecisionMaker) exploits(game *holdem.Game, player holdem.IPlayer) adjustment {
+	result := balanced
+	if d.GetMode() != ModeExploitative {
+		return result
+	}
+
+	var known []OpponentStats
+	var names []string
+	for _, other := range game.GetAllPlayers() {
+		if other.GetID() == player.GetID() || other.IsFolded() {
+			continue
+		}
+		stats := d.model.Stats(other.GetID())
+		if stats.Hands < ExploitMinHands || stats.FacedBets < ExploitMinFacedBets {
+			return result // An unknown opponent could be anyone
+		}
+		known = append(known, stats)
+		names = append(names, other.GetName())
+	}
+	if len(known) == 0 {
+		return result
+	}
+
+	overfold := true
+	for i, stats := range known {
+		share := stats.FoldToBet()
+		if share <= stationShare {
+			result.bluffScale = 0
+			result.valueShift = stationValue
+			result.reasons = append(result.reasons, fmt.Sprintf("%s folds to %.0f%% of bets: no bluffs, thinner value", names[i], share*100))
+			return result
+		}
+		overfold = overfold && share >= overfoldShare
+	}
+	if overfold {
+		result.bluffScale = overfoldBluffs
+		for i, stats := range known {
+			result.reasons = append(result.reasons, fmt.Sprintf("%s folds to %.0f%% of bets: more bluffs", names[i], stats.FoldToBet()*100))
+		}
+	}
+	return result
+}
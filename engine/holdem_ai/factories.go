@@ -1,6 +1,14 @@
 package holdem_ai
 
-import "math/rand"
+import (
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
 
 // Factory functions for creating different types of decision makers
 
@@ -16,26 +24,32 @@ func CreateConservativeBot() IDecisionMaker {
 
 // CreateAggressiveBot creates an aggressive bot
 func CreateAggressiveBot() IDecisionMaker {
-	return NewBasicBotDecisionMaker(0.8, 0.25) // High aggressiveness, moderate bluff frequency
+	return withThinking(NewBasicBotDecisionMaker(0.8, 0.25), StyleSnapper) // High aggressiveness, moderate bluff frequency
 }
 
 // CreateTightBot creates a very conservative bot
 func CreateTightBot() IDecisionMaker {
-	return NewBasicBotDecisionMaker(0.1, 0.01) // Very low aggressiveness, almost no bluffs
+	return withThinking(NewBasicBotDecisionMaker(0.1, 0.01), StyleTanker) // Very low aggressiveness, almost no bluffs
 }
 
 // CreateLooseBot creates a loose aggressive bot
 func CreateLooseBot() IDecisionMaker {
-	return NewBasicBotDecisionMaker(0.9, 0.4) // Very high aggressiveness, frequent bluffs
+	return withThinking(NewBasicBotDecisionMaker(0.9, 0.4), StyleSnapper) // Very high aggressiveness, frequent bluffs
 }
 
 // CreateRandomBot creates a bot with random settings
 func CreateRandomBot() IDecisionMaker {
+	return newRandomBot(rand.New(rand.NewSource(rand.Int63())))
+}
+
+// newRandomBot draws a random bot's settings from rng
+func newRandomBot(rng *rand.Rand) IDecisionMaker {
 	// Random aggressiveness between 0.3 and 0.9
 	// Random bluff frequency between 0.05 and 0.3
-	aggressiveness := 0.3 + (0.6 * rand.Float64())
-	bluffFreq := 0.05 + (0.25 * rand.Float64())
-	return NewBasicBotDecisionMaker(aggressiveness, bluffFreq)
+	aggressiveness := 0.3 + (0.6 * rng.Float64())
+	bluffFreq := 0.05 + (0.25 * rng.Float64())
+	styles := ThinkingStyles()
+	return withThinking(NewBasicBotDecisionMaker(aggressiveness, bluffFreq), styles[rng.Intn(len(styles))])
 }
 
 // CreateCustomBot creates a bot with custom settings
@@ -47,12 +61,12 @@ func CreateCustomBot(aggressiveness, bluffFrequency float64) IDecisionMaker {
 
 // CreateNitBot creates an extremely tight/conservative bot
 func CreateNitBot() IDecisionMaker {
-	return NewBasicBotDecisionMaker(0.05, 0.0) // Extremely low aggressiveness, never bluffs
+	return withThinking(NewBasicBotDecisionMaker(0.05, 0.0), StyleTanker) // Extremely low aggressiveness, never bluffs
 }
 
 // CreateManiacBot creates an extremely loose/aggressive bot
 func CreateManiacBot() IDecisionMaker {
-	return NewBasicBotDecisionMaker(0.95, 0.5) // Maximum aggressiveness, frequent bluffs
+	return withThinking(NewBasicBotDecisionMaker(0.95, 0.5), StyleSnapper) // Maximum aggressiveness, frequent bluffs
 }
 
 // CreateBalancedBot creates a well-balanced bot
@@ -62,5 +76,90 @@ func CreateBalancedBot() IDecisionMaker {
 
 // CreateCallingStationBot creates a bot that calls frequently but rarely raises
 func CreateCallingStationBot() IDecisionMaker {
-	return NewBasicBotDecisionMaker(0.3, 0.02) // Low aggressiveness, almost no bluffs
+	return withThinking(NewBasicBotDecisionMaker(0.3, 0.02), StyleSnapper) // Low aggressiveness, almost no bluffs
+}
+
+// CreateReaderBot creates a balanced bot that plays after the flop on its
+// equity against the ranges its opponents' lines leave them
+func CreateReaderBot() IDecisionMaker {
+	bot := NewBasicBotDecisionMaker(0.6, 0.1)
+	bot.SetRangeReading(true)
+	return bot
+}
+
+// withThinking gives a preset its thinking style: maniacs and calling
+// stations snap, nits tank over big decisions and the rest think steadily
+func withThinking(bot *BasicBotDecisionMaker, style ThinkingStyle) IDecisionMaker {
+	bot.SetThinkingStyle(style)
+	return bot
+}
+
+// botFactories maps preset names to their factory functions
+var botFactories = map[string]func() IDecisionMaker{
+	"basic":           CreateBasicBot,
+	"conservative":    CreateConservativeBot,
+	"aggressive":      CreateAggressiveBot,
+	"tight":           CreateTightBot,
+	"loose":           CreateLooseBot,
+	"random":          CreateRandomBot,
+	"nit":             CreateNitBot,
+	"maniac":          CreateManiacBot,
+	"balanced":        CreateBalancedBot,
+	"calling-station": CreateCallingStationBot,
+	"reader":          CreateReaderBot,
+}
+
+// BotNames returns the preset names accepted by CreateBotByName, sorted
+func BotNames() []string {
+	names := make([]string, 0, len(botFactories))
+	for name := range botFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CreateBotByName creates a preset bot from its name, e.g. "maniac", or a
+// chart bot from the path of a CSV file, see LoadChartStrategy for opts
+func CreateBotByName(name string, opts ...ranges.ParseOption) (IDecisionMaker, error) {
+	if isChartFile(name) {
+		return LoadChartBot(name, opts...)
+	}
+	factory, ok := botFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown bot %q", name)
+	}
+	return factory(), nil
+}
+
+// CreateSeededBot creates a preset bot whose random choices, including the
+// random preset's settings, all follow from the seed; opts are as for
+// CreateBotByName
+func CreateSeededBot(name string, seed int64, opts ...ranges.ParseOption) (IDecisionMaker, error) {
+	if isChartFile(name) {
+		bot, err := LoadChartBot(name, opts...)
+		if err != nil {
+			return nil, err
+		}
+		bot.SetSeed(seed)
+		return bot, nil
+	}
+	factory, ok := botFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown bot %q", name)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	bot := factory()
+	if name == "random" {
+		bot = newRandomBot(rng)
+	}
+	if seedable, ok := bot.(ISeedable); ok {
+		seedable.SetSeed(rng.Int63())
+	}
+	return bot, nil
+}
+
+// isChartFile reports whether a bot name is the path of a chart bot's CSV file
+func isChartFile(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".csv")
 }
@@ -403,3 +403,55 @@ func TestFactoryBotsParameterConsistency(t *testing.T) {
 			basicBot1.BluffFrequency, basicBot2.BluffFrequency)
 	}
 }
+
+func TestCreateBotByName(t *testing.T) {
+	for _, name := range BotNames() {
+		bot, err := CreateBotByName(name)
+		if err != nil || bot == nil {
+			t.Errorf("CreateBotByName(%q) failed: %v", name, err)
+		}
+	}
+
+	maniac, _ := CreateBotByName("maniac")
+	if bot, ok := maniac.(*BasicBotDecisionMaker); !ok || bot.Aggressiveness != 0.95 {
+		t.Errorf("Expected the maniac preset, got %+v", maniac)
+	}
+	if _, err := CreateBotByName("shark"); err == nil {
+		t.Error("Expected error for unknown bot")
+	}
+}
+
+func TestCreateSeededBotRepeatsItself(t *testing.T) {
+	game, player, _ := createTestGameSetup()
+	play := func(name string, seed int64) (*BasicBotDecisionMaker, []TimedDecision) {
+		maker, err := CreateSeededBot(name, seed)
+		if err != nil {
+			t.Fatalf("CreateSeededBot(%q) failed: %v", name, err)
+		}
+		bot := maker.(*BasicBotDecisionMaker)
+		bot.SetThinkingTime(0, 0)
+		decisions := []TimedDecision{}
+		for i := 0; i < 20; i++ {
+			decisions = append(decisions, <-bot.MakeTimedDecision(game, player))
+		}
+		return bot, decisions
+	}
+
+	for _, name := range []string{"random", "maniac"} {
+		first, firstDecisions := play(name, 42)
+		second, secondDecisions := play(name, 42)
+		if first.Aggressiveness != second.Aggressiveness || first.BluffFrequency != second.BluffFrequency ||
+			first.GetThinkingStyle().Name != second.GetThinkingStyle().Name {
+			t.Errorf("%s: expected equal settings from one seed, got %+v and %+v", name, first, second)
+		}
+		for i := range firstDecisions {
+			if firstDecisions[i] != secondDecisions[i] {
+				t.Errorf("%s: decision %d differs between equally seeded bots: %+v vs %+v", name, i, firstDecisions[i], secondDecisions[i])
+				break
+			}
+		}
+	}
+	if _, err := CreateSeededBot("shark", 1); err == nil {
+		t.Error("Expected error for unknown bot")
+	}
+}
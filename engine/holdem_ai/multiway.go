@@ -0,0 +1,44 @@
+package holdem_ai
+
+import "github.com/ljbink/ai-poker/engine/holdem"
+
+// Multiway pots call for tighter value raises and fewer bluffs: a raise is
+// more likely to run into a better hand, and a bluff has to get through
+// every opponent
+const (
+	multiwayValueStep = 0.05 // Value-raise threshold climbs this much per opponent past the first
+	maxMultiwayValue  = 0.15 // Climbing at most this much
+)
+
+// multiway returns the adjustment for a pot against the given number of
+// opponents. Heads-up pots are left alone, and bluffs get rarer in
+// proportion to the opponents they must fold out.
+func multiway(opponents int) adjustment {
+	if opponents < 2 {
+		return balanced
+	}
+	return adjustment{
+		bluffScale: 1 / float64(opponents),
+		valueShift: -minFloat64(multiwayValueStep*float64(opponents-1), maxMultiwayValue),
+	}
+}
+
+// opponentsInPot counts the opponents the bot is playing the pot against.
+// After the flop that is everyone still in; before it, only those who have
+// put chips in voluntarily, as the players still to act have not chosen to
+// play yet and mostly fold.
+func (d *BasicBotDecisionMaker) opponentsInPot(game *holdem.Game, player holdem.IPlayer) int {
+	if game.GetCurrentPhase() != holdem.PhasePreflop {
+		return d.countActivePlayers(game) - 1
+	}
+	entered := map[int]bool{}
+	for _, action := range game.GetUserActions().Voluntary(holdem.PhasePreflop) {
+		if action.PlayerID == player.GetID() || action.Type == holdem.ActionFold || action.Type == holdem.ActionCheck {
+			continue
+		}
+		if other, err := game.GetPlayerByID(action.PlayerID); err == nil && !other.IsFolded() {
+			entered[action.PlayerID] = true
+		}
+	}
+	return len(entered)
+}
@@ -0,0 +1,72 @@
+package holdem_ai
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestMultiwayAdjustment(t *testing.T) {
+	if adjust := multiway(1); adjust.bluffScale != 1 || adjust.valueShift != 0 {
+		t.Errorf("Expected heads-up pots to be left alone, got %+v", adjust)
+	}
+	threeWay, sixWay := multiway(2), multiway(5)
+	if threeWay.valueShift >= 0 || threeWay.bluffScale >= 1 {
+		t.Errorf("Expected a three-way pot to tighten value and cut bluffs, got %+v", threeWay)
+	}
+	if sixWay.valueShift > threeWay.valueShift || sixWay.bluffScale >= threeWay.bluffScale {
+		t.Errorf("Expected a six-way pot to be played tighter than a three-way one, got %+v and %+v", sixWay, threeWay)
+	}
+	if sixWay.valueShift < -maxMultiwayValue {
+		t.Errorf("Expected the value threshold to climb at most %.2f, got %+v", maxMultiwayValue, sixWay)
+	}
+}
+
+func TestOpponentsInPot(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	for i := 0; i < 5; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	bot := NewBasicBotDecisionMaker(0.5, 0.1)
+
+	// Blinds and players still to act are not in the pot yet
+	first := game.GetCurrentPlayer()
+	if opponents := bot.opponentsInPot(game, first); opponents != 0 {
+		t.Errorf("Expected no opponents in the pot first in, got %d", opponents)
+	}
+	for _, action := range []holdem.Action{
+		{PlayerID: first.GetID(), Type: holdem.ActionCall, Amount: 10},
+	} {
+		if err := game.TakeAction(action); err != nil {
+			t.Fatalf("%+v failed: %v", action, err)
+		}
+	}
+	second := game.GetCurrentPlayer()
+	if err := game.TakeAction(holdem.Action{PlayerID: second.GetID(), Type: holdem.ActionFold}); err != nil {
+		t.Fatalf("Fold failed: %v", err)
+	}
+	if opponents := bot.opponentsInPot(game, game.GetCurrentPlayer()); opponents != 1 {
+		t.Errorf("Expected the limper to be the only opponent in the pot, got %d", opponents)
+	}
+
+	// After the flop everyone still in counts
+	for game.IsBettingRoundOpen() {
+		player := game.GetCurrentPlayer()
+		action := holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCall, Amount: 10 - player.GetBet()}
+		if action.Amount == 0 {
+			action = holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck}
+		}
+		if err := game.TakeAction(action); err != nil {
+			t.Fatalf("%+v failed: %v", action, err)
+		}
+	}
+	game.DealFlop()
+	if opponents := bot.opponentsInPot(game, game.GetCurrentPlayer()); opponents != 3 {
+		t.Errorf("Expected three opponents on a four-way flop, got %d", opponents)
+	}
+}
@@ -0,0 +1,133 @@
+package holdem_ai
+
+import (
+	"sync"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// OpponentStats counts what one opponent has done over the hands a bot has
+// watched them play
+type OpponentStats struct {
+	Hands        int // Hands watched
+	VPIP         int // Hands they put chips in preflop voluntarily
+	FacedBets    int // Bets and raises they answered after the flop
+	FoldedToBets int // Of those, the ones they folded to
+
+	holdem.Situations // Cold calls, squeezes, donk bets and check-raises
+}
+
+// FoldToBet returns the share of bets after the flop the opponent folded
+// to, 0 before they have faced any
+func (s OpponentStats) FoldToBet() float64 {
+	if s.FacedBets == 0 {
+		return 0
+	}
+	return float64(s.FoldedToBets) / float64(s.FacedBets)
+}
+
+// VPIPShare returns the share of hands the opponent played voluntarily
+func (s OpponentStats) VPIPShare() float64 {
+	if s.Hands == 0 {
+		return 0
+	}
+	return float64(s.VPIP) / float64(s.Hands)
+}
+
+// OpponentModel builds each opponent's stats from the hands a bot sees.
+// The bot observes the game whenever it is asked to act, so it learns
+// everything up to its last decision in a hand; callers who see every hand
+// through can also observe it once it ends. Observing the same actions
+// twice counts them once, and a nil model has seen nothing.
+type OpponentModel struct {
+	mu     sync.Mutex
+	stats  map[int]*OpponentStats
+	handID string                    // Hand being observed
+	seen   map[holdem.GamePhase]int  // Actions already counted on each street of handID
+	vpip   map[int]bool              // Players already counted as voluntarily in handID
+	spots  map[int]holdem.Situations // Situations of each player in handID so far, not yet in stats
+}
+
+// NewOpponentModel creates a model that has watched no hands
+func NewOpponentModel() *OpponentModel {
+	return &OpponentModel{stats: map[int]*OpponentStats{}}
+}
+
+// Observe counts the actions of the game's current hand not counted yet
+func (m *OpponentModel) Observe(game *holdem.Game) {
+	if m == nil || game == nil || game.GetHandID() == "" {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if game.GetHandID() != m.handID {
+		for playerID, spots := range m.spots {
+			m.player(playerID).Situations.Add(spots)
+		}
+		m.handID = game.GetHandID()
+		m.seen = map[holdem.GamePhase]int{}
+		m.vpip = map[int]bool{}
+		m.spots = map[int]holdem.Situations{}
+		for _, player := range game.GetAllPlayers() {
+			m.player(player.GetID()).Hands++
+		}
+	}
+
+	actions := game.GetUserActions()
+	for phase := holdem.PhasePreflop; phase <= holdem.PhaseRiver; phase++ {
+		street := actions.Voluntary(phase)
+		for i := m.seen[phase]; i < len(street); i++ {
+			action := street[i]
+			stats := m.player(action.PlayerID)
+			switch {
+			case phase == holdem.PhasePreflop:
+				voluntary := action.Type == holdem.ActionCall || action.Type == holdem.ActionRaise || action.Type == holdem.ActionAllIn
+				if voluntary && !m.vpip[action.PlayerID] {
+					m.vpip[action.PlayerID] = true
+					stats.VPIP++
+				}
+			case action.Faced > 0:
+				stats.FacedBets++
+				if action.Type == holdem.ActionFold {
+					stats.FoldedToBets++
+				}
+			}
+		}
+		m.seen[phase] = len(street)
+	}
+	for _, player := range game.GetAllPlayers() {
+		m.spots[player.GetID()] = actions.Situations(player.GetID())
+	}
+}
+
+// Stats returns what the model has counted for the player
+func (m *OpponentModel) Stats(playerID int) OpponentStats {
+	if m == nil {
+		return OpponentStats{}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var stats OpponentStats
+	if counted, ok := m.stats[playerID]; ok {
+		stats = *counted
+	}
+	stats.Situations.Add(m.spots[playerID]) // The hand being played, read from its lines so far
+	return stats
+}
+
+// Samples returns how many of the player's hands the model has watched
+func (m *OpponentModel) Samples(playerID int) int {
+	return m.Stats(playerID).Hands
+}
+
+// player returns the player's stats, adding them when first seen. The
+// caller holds mu.
+func (m *OpponentModel) player(playerID int) *OpponentStats {
+	stats, ok := m.stats[playerID]
+	if !ok {
+		stats = &OpponentStats{}
+		m.stats[playerID] = stats
+	}
+	return stats
+}
@@ -0,0 +1,49 @@
+package holdem_ai
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestOpponentModelObserve(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	for i := 0; i < 2; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	model := NewOpponentModel()
+	take := func(actions ...holdem.Action) {
+		for _, action := range actions {
+			if err := game.TakeAction(action); err != nil {
+				t.Fatalf("%+v failed: %v", action, err)
+			}
+		}
+		model.Observe(game)
+	}
+
+	// The button raises and c-bets, the big blind calls and then folds
+	take(holdem.NewRaise(1, 30), holdem.Action{PlayerID: 2, Type: holdem.ActionCall, Amount: 20})
+	game.DealFlop()
+	take(holdem.Action{PlayerID: 2, Type: holdem.ActionCheck}, holdem.NewRaise(1, 40))
+	take(holdem.Action{PlayerID: 2, Type: holdem.ActionFold})
+	model.Observe(game)
+
+	if stats := model.Stats(1); stats != (OpponentStats{Hands: 1, VPIP: 1}) {
+		t.Errorf("Expected the raiser to be counted once, got %+v", stats)
+	}
+	spots := holdem.Situations{DonkChances: 1, CheckRaiseChances: 1} // Checked to the raiser, then folded to the c-bet
+	if stats := model.Stats(2); stats != (OpponentStats{Hands: 1, VPIP: 1, FacedBets: 1, FoldedToBets: 1, Situations: spots}) {
+		t.Errorf("Expected the caller to have folded to the one bet faced, got %+v", stats)
+	}
+	if share := model.Stats(2).FoldToBet(); share != 1 {
+		t.Errorf("Expected a fold-to-bet of 1, got %.2f", share)
+	}
+	if samples := model.Samples(3); samples != 0 {
+		t.Errorf("Expected no samples of an unseen player, got %d", samples)
+	}
+}
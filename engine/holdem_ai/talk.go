@@ -0,0 +1,99 @@
+package holdem_ai
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// TalkMoment is a hand result a bot may comment on
+type TalkMoment string
+
+const (
+	MomentWonBig  TalkMoment = "won_big"  // Won at least TalkBigPotBB
+	MomentLostBig TalkMoment = "lost_big" // Lost at least TalkBigPotBB
+	MomentBluffed TalkMoment = "bluffed"  // Folded to a bet that took the pot, maybe a bluff
+)
+
+// Table talk pace
+const (
+	TalkBigPotBB  = 25   // Net big blinds that make a pot worth talking about
+	talkBluffedBB = 10   // Pot in big blinds a bot must fold out of to feel bluffed
+	talkChance    = 0.35 // How often a bot speaks up at a moment
+	talkLines     = 3    // Lines per moment and style in the catalogs
+)
+
+// SetTableTalk lets the bot comment on big pots and on being bluffed
+func (d *BasicBotDecisionMaker) SetTableTalk(enabled bool) {
+	d.talk = enabled
+}
+
+// TableTalk implements the ITalker interface. It now and then returns the
+// catalog key of a line fitting the bot's personality, e.g.
+// "talk.won_big.brash.2". Bots only go by what the table has seen, so a
+// line never gives away hidden cards.
+func (d *BasicBotDecisionMaker) TableTalk(game *holdem.Game, player holdem.IPlayer, net int) (string, bool) {
+	if !d.talk || game == nil || player == nil {
+		return "", false
+	}
+	moment, ok := talkMoment(game, player, net)
+	if !ok {
+		return "", false
+	}
+	var speak bool
+	var line int
+	d.random(func(rng *rand.Rand) {
+		speak = rng.Float64() < talkChance
+		line = rng.Intn(talkLines) + 1
+	})
+	if !speak {
+		return "", false
+	}
+	return fmt.Sprintf("talk.%s.%s.%d", moment, d.talkStyle(), line), true
+}
+
+// talkStyle is how the bot's personality comes across: aggressive bots
+// are brash, timid ones friendly and the rest dry
+func (d *BasicBotDecisionMaker) talkStyle() string {
+	switch {
+	case d.Aggressiveness >= 0.7:
+		return "brash"
+	case d.Aggressiveness <= 0.3:
+		return "friendly"
+	default:
+		return "dry"
+	}
+}
+
+// talkMoment finds what, if anything, the finished hand gives the player
+// to talk about
+func talkMoment(game *holdem.Game, player holdem.IPlayer, net int) (TalkMoment, bool) {
+	bigBlind := game.GetBigBlind()
+	if bigBlind <= 0 {
+		return "", false
+	}
+	switch {
+	case net >= TalkBigPotBB*bigBlind:
+		return MomentWonBig, true
+	case net <= -TalkBigPotBB*bigBlind:
+		return MomentLostBig, true
+	}
+
+	// Folding to a bet after the flop in a hand that ended without a
+	// showdown, in a pot worth fighting for
+	if !player.IsFolded() || game.GetCurrentPhase() == holdem.PhaseShowdown || game.GetPot() < talkBluffedBB*bigBlind {
+		return "", false
+	}
+	actions := game.GetUserActions()
+	for phase := holdem.PhaseRiver; phase >= holdem.PhasePreflop; phase-- {
+		street := actions.Voluntary(phase)
+		for i := len(street) - 1; i >= 0; i-- {
+			if street[i].PlayerID != player.GetID() {
+				continue
+			}
+			return MomentBluffed, street[i].Type == holdem.ActionFold && street[i].Faced > 0 && phase != holdem.PhasePreflop
+		}
+	}
+	return "", false
+}
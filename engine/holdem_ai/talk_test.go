@@ -0,0 +1,99 @@
+package holdem_ai
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestTalkMoment(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	for i := 0; i < 2; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	for game.IsBettingRoundOpen() {
+		player := game.GetCurrentPlayer()
+		action := holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCall, Amount: 10 - player.GetBet()}
+		if action.Amount == 0 {
+			action = holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck}
+		}
+		if err := game.TakeAction(action); err != nil {
+			t.Fatalf("%+v failed: %v", action, err)
+		}
+	}
+	game.DealFlop()
+	bettor := game.GetCurrentPlayer()
+	if err := game.TakeAction(holdem.Action{PlayerID: bettor.GetID(), Type: holdem.ActionRaise, RaiseTo: 200}); err != nil {
+		t.Fatalf("Bet failed: %v", err)
+	}
+	folder := game.GetCurrentPlayer()
+	bigPot := TalkBigPotBB * game.GetBigBlind()
+
+	if moment, ok := talkMoment(game, bettor, bigPot); !ok || moment != MomentWonBig {
+		t.Errorf("Expected a big win to be worth talking about, got %q", moment)
+	}
+	if moment, ok := talkMoment(game, bettor, -bigPot); !ok || moment != MomentLostBig {
+		t.Errorf("Expected a big loss to be worth talking about, got %q", moment)
+	}
+	if moment, ok := talkMoment(game, bettor, 10); ok {
+		t.Errorf("Expected a small pot to go without comment, got %q", moment)
+	}
+	if err := game.TakeAction(holdem.Action{PlayerID: folder.GetID(), Type: holdem.ActionFold}); err != nil {
+		t.Fatalf("Fold failed: %v", err)
+	}
+	if moment, ok := talkMoment(game, folder, -10); !ok || moment != MomentBluffed {
+		t.Errorf("Expected folding to a flop bet to feel like a bluff, got %q", moment)
+	}
+}
+
+func TestBasicBotTableTalk(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	for i := 0; i < 2; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	player, _ := game.GetPlayerByID(1)
+	bigWin := TalkBigPotBB * game.GetBigBlind()
+
+	// Quiet unless asked to talk
+	bot := NewBasicBotDecisionMaker(0.8, 0.1)
+	bot.SetSeed(1)
+	for i := 0; i < 20; i++ {
+		if line, ok := bot.TableTalk(game, player, bigWin); ok {
+			t.Fatalf("Expected a bot without table talk to stay quiet, got %q", line)
+		}
+	}
+
+	bot.SetTableTalk(true)
+	key := regexp.MustCompile(`^talk\.won_big\.brash\.[1-3]$`)
+	spoke := 0
+	for i := 0; i < 100; i++ {
+		line, ok := bot.TableTalk(game, player, bigWin)
+		if !ok {
+			continue
+		}
+		spoke++
+		if !key.MatchString(line) {
+			t.Errorf("Expected a brash line about a big win, got %q", line)
+		}
+	}
+	if spoke == 0 || spoke == 100 {
+		t.Errorf("Expected the bot to speak up now and then, spoke %d times in 100", spoke)
+	}
+
+	for aggressiveness, style := range map[float64]string{0.2: "friendly", 0.5: "dry", 0.9: "brash"} {
+		if got := NewBasicBotDecisionMaker(aggressiveness, 0.1).talkStyle(); got != style {
+			t.Errorf("Expected aggressiveness %.1f to talk %s, got %s", aggressiveness, style, got)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package holdem_ai
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// snapTime is the typical time of an instant check or call
+const snapTime = 400 * time.Millisecond
+
+// ThinkingStyle describes how long a bot takes to act. Times depend on the
+// decision, so a bot's timing gives away information like a human's does.
+type ThinkingStyle struct {
+	Name   string
+	Median time.Duration // Typical time of a routine decision
+	Spread float64       // Log-normal sigma, how much times vary around the median
+	Snap   float64       // Chance of checking or calling almost instantly
+	Tank   float64       // Time multiplier for big decisions: raises, all-ins and calls of half the pot or more
+}
+
+// Preset thinking styles
+var (
+	StyleSteady  = ThinkingStyle{Name: "steady", Median: 2 * time.Second, Spread: 0.3, Snap: 0.05, Tank: 1.5}
+	StyleSnapper = ThinkingStyle{Name: "snapper", Median: 1200 * time.Millisecond, Spread: 0.4, Snap: 0.6, Tank: 1.2}
+	StyleTanker  = ThinkingStyle{Name: "tanker", Median: 3 * time.Second, Spread: 0.5, Snap: 0.02, Tank: 4}
+)
+
+// ThinkingStyles returns the preset styles
+func ThinkingStyles() []ThinkingStyle {
+	return []ThinkingStyle{StyleSteady, StyleSnapper, StyleTanker}
+}
+
+// Draw returns a thinking time for the action about to be taken in the game,
+// using rng for every random choice
+func (s ThinkingStyle) Draw(rng *rand.Rand, game *holdem.Game, player holdem.IPlayer, action holdem.Action) time.Duration {
+	passive := action.Type == holdem.ActionCheck || action.Type == holdem.ActionCall
+	if passive && rng.Float64() < s.Snap {
+		return time.Duration(float64(snapTime) * (0.5 + rng.Float64()))
+	}
+	think := float64(s.Median) * math.Exp(s.Spread*rng.NormFloat64())
+	if isBigDecision(game, player, action) && s.Tank > 0 {
+		think *= s.Tank
+	}
+	return time.Duration(think)
+}
+
+// isBigDecision reports whether the action puts a lot at stake
+func isBigDecision(game *holdem.Game, player holdem.IPlayer, action holdem.Action) bool {
+	switch action.Type {
+	case holdem.ActionRaise, holdem.ActionAllIn:
+		return true
+	case holdem.ActionCall:
+		return game != nil && action.Amount*2 >= game.GetPot() && action.Amount > game.GetBigBlind()
+	}
+	return false
+}
+
+// TimedDecision is an action together with the simulated time it took
+type TimedDecision struct {
+	Action   holdem.Action
+	Thinking time.Duration
+}
+
+// ITimedDecisionMaker is implemented by decision makers that simulate how
+// long they think. Sessions record the simulated time rather than the wall
+// clock, so timing is available even when simulations skip the delay.
+type ITimedDecisionMaker interface {
+	IDecisionMaker
+	MakeTimedDecision(game *holdem.Game, player holdem.IPlayer) <-chan TimedDecision
+}
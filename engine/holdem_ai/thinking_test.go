@@ -0,0 +1,97 @@
+package holdem_ai
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// medianThinking draws many thinking times for one action and returns the median
+func medianThinking(style ThinkingStyle, game *holdem.Game, player holdem.IPlayer, action holdem.Action) time.Duration {
+	rng := rand.New(rand.NewSource(1))
+	times := make([]time.Duration, 501)
+	for i := range times {
+		times[i] = style.Draw(rng, game, player, action)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	return times[len(times)/2]
+}
+
+func TestThinkingStyleSnapsAndTanks(t *testing.T) {
+	game, player, _ := createTestGameSetup()
+	check := holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck}
+	raise := holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionRaise, Amount: 100}
+
+	if snap := medianThinking(StyleSnapper, game, player, check); snap > time.Second {
+		t.Errorf("Expected a snapper to check within a second, median %v", snap)
+	}
+	tankCheck := medianThinking(StyleTanker, game, player, check)
+	tankRaise := medianThinking(StyleTanker, game, player, raise)
+	if tankRaise < 2*tankCheck {
+		t.Errorf("Expected a tanker to take much longer to raise than to check, %v vs %v", tankRaise, tankCheck)
+	}
+	if steady := medianThinking(StyleSteady, game, player, check); steady < time.Second || steady > 3*time.Second {
+		t.Errorf("Expected a steady check around 2s, median %v", steady)
+	}
+}
+
+func TestBasicBotReportsThinkingWithoutDelay(t *testing.T) {
+	game, player, _ := createTestGameSetup()
+	bot := NewBasicBotDecisionMaker(0.5, 0.1)
+	bot.SetThinkingTime(0, 0)
+	bot.SetThinkingStyle(StyleTanker)
+
+	var _ ITimedDecisionMaker = bot
+	start := time.Now()
+	select {
+	case decision := <-bot.MakeTimedDecision(game, player):
+		if decision.Thinking <= 0 {
+			t.Errorf("Expected a simulated thinking time, got %v", decision.Thinking)
+		}
+		if decision.Action.PlayerID != player.GetID() {
+			t.Errorf("Expected an action for player %d, got %+v", player.GetID(), decision.Action)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Bot did not decide")
+	}
+	if waited := time.Since(start); waited > 500*time.Millisecond {
+		t.Errorf("Expected no real delay, waited %v", waited)
+	}
+}
+
+func TestPresetThinkingStyles(t *testing.T) {
+	for name, want := range map[string]string{"maniac": "snapper", "nit": "tanker", "balanced": "steady", "calling-station": "snapper"} {
+		maker, err := CreateBotByName(name)
+		if err != nil {
+			t.Fatalf("CreateBotByName(%q) failed: %v", name, err)
+		}
+		if style := maker.(*BasicBotDecisionMaker).GetThinkingStyle(); style.Name != want {
+			t.Errorf("Expected %s to think like a %s, got %s", name, want, style.Name)
+		}
+	}
+}
+
+func TestInstrumentedDecisionMakerTimed(t *testing.T) {
+	game, player, _ := createTestGameSetup()
+	bot := NewBasicBotDecisionMaker(0.5, 0.1)
+	bot.SetThinkingTime(0, 0)
+	timed := NewInstrumentedDecisionMaker("bot", bot, nil)
+	plain := NewInstrumentedDecisionMaker("fixed", &fixedDecisionMaker{actionType: holdem.ActionFold}, nil)
+
+	for _, maker := range []*InstrumentedDecisionMaker{timed, plain} {
+		select {
+		case decision := <-maker.MakeTimedDecision(game, player):
+			if maker == timed && decision.Thinking < 10*time.Millisecond {
+				t.Errorf("Expected the bot's simulated time to pass through, got %v", decision.Thinking)
+			}
+			if maker == plain && decision.Action.Type != holdem.ActionFold {
+				t.Errorf("Expected the wrapped fold, got %+v", decision.Action)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Instrumented decision maker did not respond")
+		}
+	}
+}
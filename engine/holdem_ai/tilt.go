@@ -0,0 +1,113 @@
+package holdem_ai
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// How bots tilt: big losses and bad beats push the tilt level up, and it
+// drains away hand by hand
+const (
+	TiltBigLossBB       = 25   // Losing this many big blinds in a hand tilts a bot
+	tiltBigLoss         = 0.35 // Tilt added by a big loss
+	tiltBadBeat         = 0.5  // Tilt added by losing a showdown with a strong hand
+	tiltBadBeatStrength = 0.7  // Strength a hand must have put chips in with for its loss to be a bad beat
+	tiltRecovery        = 0.8  // Share of the tilt left after each hand
+	tiltAggression      = 0.3  // Aggressiveness added at full tilt
+	tiltBluffing        = 0.25 // Bluff frequency added at full tilt
+)
+
+// tiltState is how far a bot is off its game, from 0 (calm) to 1 (full
+// tilt). Results arrive from the session while an abandoned decision may
+// still run, hence the lock.
+type tiltState struct {
+	mu        sync.Mutex
+	level     float64
+	handID    string  // Hand strongest belongs to
+	strongest float64 // Strongest hand the bot put chips in with in handID
+}
+
+// played notes the strength of a hand the bot put chips in with
+func (t *tiltState) played(handID string, strength float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if handID != t.handID {
+		t.handID = handID
+		t.strongest = 0
+	}
+	t.strongest = max(t.strongest, strength)
+}
+
+// finish settles the tilt after a hand the bot lost or won net chips in,
+// returning what tilted it, "" if nothing did
+func (t *tiltState) finish(handID string, net, bigBlind int, showdown bool) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.level *= tiltRecovery
+
+	reason := ""
+	if bigBlind > 0 && net <= -TiltBigLossBB*bigBlind {
+		t.level += tiltBigLoss
+		reason = "big loss"
+	}
+	if showdown && net < 0 && handID == t.handID && t.strongest >= tiltBadBeatStrength {
+		t.level += tiltBadBeat
+		reason = "bad beat"
+	}
+	t.level = min(t.level, 1)
+	return reason
+}
+
+// get returns the tilt level
+func (t *tiltState) get() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.level
+}
+
+// SetTilt implements the ITilter interface. A tilting bot plays more
+// aggressively and bluffs more after big losses and bad beats, calming
+// down over the following hands. It needs hand results, which the session
+// reports through HandFinished.
+func (d *BasicBotDecisionMaker) SetTilt(enabled bool) {
+	d.tilt = nil
+	if enabled {
+		d.tilt = &tiltState{}
+	}
+}
+
+// GetTilt returns how tilted the bot is, from 0 (calm, or tilt disabled)
+// to 1 (full tilt)
+func (d *BasicBotDecisionMaker) GetTilt() float64 {
+	if d.tilt == nil {
+		return 0
+	}
+	return d.tilt.get()
+}
+
+// HandFinished implements the IResultObserver interface
+func (d *BasicBotDecisionMaker) HandFinished(game *holdem.Game, player holdem.IPlayer, net int) {
+	if d.tilt == nil || game == nil || player == nil {
+		return
+	}
+	showdown := game.GetCurrentPhase() == holdem.PhaseShowdown && !player.IsFolded()
+	if reason := d.tilt.finish(game.GetHandID(), net, game.GetBigBlind(), showdown); reason != "" {
+		d.logger.Debug("bot tilted",
+			slog.Int("player_id", player.GetID()),
+			slog.String("reason", reason),
+			slog.Float64("tilt", d.tilt.get()),
+		)
+	}
+}
+
+// aggression returns the bot's aggressiveness with its tilt added
+func (d *BasicBotDecisionMaker) aggression() float64 {
+	return min(d.Aggressiveness+d.GetTilt()*tiltAggression, 1)
+}
+
+// bluffFrequency returns the bot's bluff frequency with its tilt added
+func (d *BasicBotDecisionMaker) bluffFrequency() float64 {
+	return min(d.BluffFrequency+d.GetTilt()*tiltBluffing, 1)
+}
@@ -0,0 +1,60 @@
+package holdem_ai
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestBasicBotTilts(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	for i := 0; i < 2; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	player, _ := game.GetPlayerByID(1)
+	bigLoss := -TiltBigLossBB * game.GetBigBlind()
+
+	// Without tilt results change nothing
+	bot := NewBasicBotDecisionMaker(0.4, 0.1)
+	bot.HandFinished(game, player, bigLoss)
+	if tilt := bot.GetTilt(); tilt != 0 || bot.aggression() != 0.4 || bot.bluffFrequency() != 0.1 {
+		t.Fatalf("Expected a bot without tilt to stay calm, got tilt %.2f", tilt)
+	}
+
+	bot.SetTilt(true)
+	bot.HandFinished(game, player, -game.GetBigBlind())
+	if tilt := bot.GetTilt(); tilt != 0 {
+		t.Errorf("Expected a small loss not to tilt, got %.2f", tilt)
+	}
+	bot.HandFinished(game, player, bigLoss)
+	tilted := bot.GetTilt()
+	if tilted <= 0 || bot.aggression() <= 0.4 || bot.bluffFrequency() <= 0.1 {
+		t.Fatalf("Expected a big loss to make the bot wilder, got tilt %.2f", tilted)
+	}
+
+	// Tilt is bounded, and a bad beat tilts harder than a plain big loss
+	for i := 0; i < 10; i++ {
+		bot.HandFinished(game, player, bigLoss)
+	}
+	if tilt := bot.GetTilt(); tilt > 1 || bot.aggression() > 1 || bot.bluffFrequency() > 1 {
+		t.Errorf("Expected tilt and traits bounded by 1, got tilt %.2f", tilt)
+	}
+	beat := &tiltState{}
+	beat.played("hand", 0.9)
+	if reason := beat.finish("hand", bigLoss, game.GetBigBlind(), true); reason != "bad beat" || beat.get() <= tilted {
+		t.Errorf("Expected a bad beat to tilt past %.2f, got %q at %.2f", tilted, reason, beat.get())
+	}
+
+	// It drains away as hands go by
+	for i := 0; i < 30; i++ {
+		bot.HandFinished(game, player, 0)
+	}
+	if tilt := bot.GetTilt(); tilt > 0.01 {
+		t.Errorf("Expected the bot to calm down, still at %.2f", tilt)
+	}
+}
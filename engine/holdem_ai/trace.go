@@ -0,0 +1,37 @@
+package holdem_ai
+
+import "time"
+
+// DecisionTrace records one bot decision and what went into it, for
+// analysing a session after the game
+type DecisionTrace struct {
+	HandID    string          `json:"hand_id"`
+	PlayerID  int             `json:"player_id"`
+	Phase     string          `json:"phase"`
+	Mode      Mode            `json:"mode"`      // The mode the bot was in
+	PushFold  bool            `json:"push_fold"` // Played from the push/fold tables
+	Strength  float64         `json:"strength"`  // Hand strength the decision was based on
+	Opponents int             `json:"opponents"` // Opponents in the pot
+	Depth     StackDepth      `json:"depth"`     // Effective stack depth bucket
+	SPR       float64         `json:"spr"`       // Stack-to-pot ratio at the start of the street, 0 preflop
+	Tilt      float64         `json:"tilt"`      // How tilted the bot was, 0 when calm
+	Action    string          `json:"action"`
+	Amount    int             `json:"amount"`
+	Exploits  []string        `json:"exploits,omitempty"` // How the bot bent its play against its opponents
+	Profile   DecisionProfile `json:"profile"`
+}
+
+// DecisionProfile is where the time of one decision went, so an expensive
+// bot can be tuned to fit the action clock. The thinking delay is not
+// part of it.
+type DecisionProfile struct {
+	Evaluation time.Duration `json:"evaluation_ns"` // Ranking the bot's made hand
+	Equity     time.Duration `json:"equity_ns"`     // Simulating runouts
+	Search     time.Duration `json:"search_ns"`     // Reading the opponents' ranges from their lines
+	Total      time.Duration `json:"total_ns"`      // The whole decision, the above included
+}
+
+// spend adds the time since start to one of a profile's counters
+func spend(counter *time.Duration, start time.Time) {
+	*counter += time.Since(start)
+}
@@ -0,0 +1,43 @@
+package holdem_ai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/charts"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/pushfold"
+)
+
+// WarmupConfig describes the table a decision maker warms up for
+type WarmupConfig struct {
+	Variant  holdem.GameVariant
+	Seats    int // Players seated
+	BigBlind int
+}
+
+// Warmup implements the IWarmer interface. It loads the preflop charts the
+// bot reads its opponents' ranges from and, with push/fold play on, the
+// Nash tables, so the first decision does not pay for parsing them.
+func (d *BasicBotDecisionMaker) Warmup(ctx context.Context, config WarmupConfig) error {
+	if _, err := charts.Default(); err != nil {
+		return fmt.Errorf("loading preflop charts: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d.pushFold {
+		if _, err := pushfold.Default(); err != nil {
+			return fmt.Errorf("loading push/fold tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// Warmup implements the IWarmer interface for the wrapped decision maker
+func (d *InstrumentedDecisionMaker) Warmup(ctx context.Context, config WarmupConfig) error {
+	if warmer, ok := d.inner.(IWarmer); ok {
+		return warmer.Warmup(ctx, config)
+	}
+	return nil
+}
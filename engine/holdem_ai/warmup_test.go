@@ -0,0 +1,22 @@
+package holdem_ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestBasicBotWarmup(t *testing.T) {
+	bot := NewBasicBotDecisionMaker(0.5, 0.1)
+	bot.SetPushFold(true)
+	config := WarmupConfig{Seats: 6, BigBlind: 10}
+	if err := bot.Warmup(context.Background(), config); err != nil {
+		t.Fatalf("Warmup failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := NewInstrumentedDecisionMaker("bot", bot, nil).Warmup(ctx, config); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled warm-up through the instrumented wrapper to stop, got %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+// Package hud serves a table's public events on a local socket, read-only,
+// so that HUD and overlay tools can follow a live session without being
+// part of the application. Every event goes out as one line of JSON: the
+// table package's Record, numbered and with the public state hash, and the
+// table as spectators see it after the event, every hole card hidden until
+// it is shown down. Nothing a tool writes to the socket is read.
+package hud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/session"
+	"github.com/ljbink/ai-poker/engine/table"
+)
+
+// outputSize is how many lines wait for a slow tool before it misses some
+const outputSize = 256
+
+// Message is one line of the stream
+type Message struct {
+	table.Record
+	View holdem.TableView `json:"view"` // After the event, as spectators see it
+}
+
+// writeTimeout is how long a line may take to reach a tool before the tool
+// is dropped, so a tool that stops reading cannot hold up the server
+const writeTimeout = 5 * time.Second
+
+// Redact returns what of an event tools may see with the table after it:
+// the hands pots were won with are left out unless the view shows the
+// winners' cards, so no hole card gets out through a result
+func Redact(event session.Event, view holdem.TableView) session.Event {
+	if len(event.Awards) == 0 {
+		return event
+	}
+	shown := map[int]bool{}
+	for _, seat := range view.Seats {
+		shown[seat.PlayerID] = len(seat.HoleCards) > 0
+	}
+	awards := make([]holdem.PotAward, len(event.Awards))
+	for i, award := range event.Awards {
+		for _, id := range award.Winners {
+			if !shown[id] {
+				award.Hand = nil
+			}
+		}
+		awards[i] = award
+	}
+	event.Awards = awards
+	return event
+}
+
+// conn is one attached tool
+type conn struct {
+	net   net.Conn
+	lines chan []byte
+}
+
+// Server publishes a session's events to the tools attached to its socket.
+// Register Observe as the session's observer, or call it from one. A server
+// is safe for concurrent use.
+type Server struct {
+	listener net.Listener
+
+	mu      sync.Mutex
+	conns   map[*conn]bool
+	seq     uint64
+	last    []byte // Latest line, sent to tools as they attach
+	dropped int
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// Listen opens the stream on a Unix socket at a path ("unix") or on a
+// loopback TCP address such as "127.0.0.1:7777" ("tcp"). Addresses other
+// machines could reach are refused. A socket left behind by a session that
+// crashed is replaced; one still served is not.
+func Listen(network, address string) (*Server, error) {
+	switch network {
+	case "unix":
+		if nc, err := net.Dial(network, address); err == nil {
+			nc.Close()
+			return nil, fmt.Errorf("HUD stream already served at %s", address)
+		}
+		if info, err := os.Lstat(address); err == nil && info.Mode()&os.ModeSocket != 0 {
+			os.Remove(address)
+		}
+	case "tcp", "tcp4", "tcp6":
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return nil, fmt.Errorf("HUD stream must listen on this machine only, not %s", address)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported network %q, want unix or tcp", network)
+	}
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{listener: listener, conns: map[*conn]bool{}}
+	s.wg.Add(1)
+	go s.accept()
+	return s, nil
+}
+
+// Addr returns the address tools attach to
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Observe numbers an event and sends it to every attached tool with the
+// table after it. It is a session.Observer and never blocks the hand: a
+// tool that falls behind misses lines, see Dropped.
+func (s *Server) Observe(event session.Event, game *holdem.Game) {
+	view := game.SpectatorView()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.seq++
+	line, err := json.Marshal(Message{
+		Record: table.Record{Seq: s.seq, Event: Redact(event, view), StateHash: view.StateHash()},
+		View:   view,
+	})
+	if err != nil {
+		return
+	}
+	s.last = append(line, '\n')
+	for c := range s.conns {
+		select {
+		case c.lines <- s.last:
+		default:
+			s.dropped++
+		}
+	}
+}
+
+// Attached returns the number of tools attached
+func (s *Server) Attached() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// Dropped returns how many lines tools missed for falling behind
+func (s *Server) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Close stops accepting tools and disconnects the attached ones once they
+// have been sent the lines already published
+func (s *Server) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	for c := range s.conns {
+		close(c.lines)
+	}
+	s.conns = map[*conn]bool{}
+	s.mu.Unlock()
+
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+// accept attaches tools until the listener closes
+func (s *Server) accept() {
+	defer s.wg.Done()
+	for {
+		nc, err := s.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			continue
+		}
+		c := &conn{net: nc, lines: make(chan []byte, outputSize)}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			nc.Close()
+			return
+		}
+		if s.last != nil {
+			c.lines <- s.last
+		}
+		s.conns[c] = true
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go s.send(c)
+	}
+}
+
+// send writes a tool's lines until the server closes or the tool goes away
+func (s *Server) send(c *conn) {
+	defer s.wg.Done()
+	defer c.net.Close()
+	for line := range c.lines {
+		c.net.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if _, err := c.net.Write(line); err != nil {
+			s.mu.Lock()
+			if s.conns[c] {
+				delete(s.conns, c)
+				close(c.lines)
+			}
+			s.mu.Unlock()
+			for range c.lines {
+				// Drain what was queued before the tool went away
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package hud
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+func newDealtGame(t *testing.T) *holdem.Game {
+	t.Helper()
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	game.PlayerSit(holdem.NewPlayer(1, "Alice", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Bob", 1000), 1)
+	if err := game.DealHoleCards(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return game
+}
+
+func attach(t *testing.T, s *Server) *bufio.Reader {
+	t.Helper()
+	nc, err := net.Dial(s.Addr().Network(), s.Addr().String())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { nc.Close() })
+	deadline := time.Now().Add(2 * time.Second)
+	for s.Attached() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	nc.SetReadDeadline(time.Now().Add(2 * time.Second))
+	return bufio.NewReader(nc)
+}
+
+func receive(t *testing.T, r *bufio.Reader) Message {
+	t.Helper()
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("Expected a line, got %v", err)
+	}
+	var msg Message
+	if err := json.Unmarshal(line, &msg); err != nil {
+		t.Fatalf("Invalid line %q: %v", line, err)
+	}
+	return msg
+}
+
+func TestServerStreamsNumberedRedactedEvents(t *testing.T) {
+	s, err := Listen("unix", filepath.Join(t.TempDir(), "hud.sock"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer s.Close()
+	r := attach(t, s)
+
+	game := newDealtGame(t)
+	s.Observe(session.Event{Type: session.EventHandStarted}, game)
+	s.Observe(session.Event{Type: session.EventTurn, PlayerID: 1}, game)
+
+	first, second := receive(t, r), receive(t, r)
+	if first.Seq != 1 || second.Seq != 2 {
+		t.Errorf("Expected events numbered 1 and 2, got %d and %d", first.Seq, second.Seq)
+	}
+	if second.Event.Type != session.EventTurn || second.Event.PlayerID != 1 {
+		t.Errorf("Expected Alice's turn, got %+v", second.Event)
+	}
+	for _, seat := range first.View.Seats {
+		if len(seat.HoleCards) != 0 || !seat.CardsHidden {
+			t.Errorf("Expected seat %d's cards hidden, got %v", seat.Seat, seat.HoleCards)
+		}
+	}
+	if first.StateHash != first.View.StateHash() {
+		t.Errorf("Expected the state hash of the view sent, got %s", first.StateHash)
+	}
+}
+
+func TestServerSendsLatestLineOnAttach(t *testing.T) {
+	s, err := Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer s.Close()
+
+	game := newDealtGame(t)
+	s.Observe(session.Event{Type: session.EventHandStarted}, game)
+	s.Observe(session.Event{Type: session.EventTurn, PlayerID: 1}, game)
+
+	if msg := receive(t, attach(t, s)); msg.Seq != 2 {
+		t.Errorf("Expected a late tool to start from event 2, got %d", msg.Seq)
+	}
+}
+
+func TestListenRefusesOtherMachines(t *testing.T) {
+	for _, address := range []string{"0.0.0.0:0", "192.168.1.10:7777", ":7777"} {
+		if s, err := Listen("tcp", address); err == nil {
+			s.Close()
+			t.Errorf("Expected %s to be refused", address)
+		}
+	}
+	if _, err := Listen("udp", "127.0.0.1:0"); err == nil {
+		t.Error("Expected an unsupported network to be refused")
+	}
+}
+
+func TestRedactHidesHandsNotShown(t *testing.T) {
+	game := newDealtGame(t)
+	event := session.Event{
+		Type:   session.EventHandFinished,
+		Awards: []holdem.PotAward{{Amount: 20, Winners: []int{1}, Hand: &holdem.HandResult{}}},
+	}
+
+	if got := Redact(event, game.SpectatorView()); got.Awards[0].Hand != nil {
+		t.Error("Expected the winning hand left out while the cards are hidden")
+	}
+	if event.Awards[0].Hand == nil {
+		t.Error("Expected the event passed in to be left alone")
+	}
+	if got := Redact(event, game.PlayerView(1)); got.Awards[0].Hand == nil {
+		t.Error("Expected the winning hand kept when the winner's cards are shown")
+	}
+}
+
+func TestCloseDisconnectsTools(t *testing.T) {
+	s, err := Listen("unix", filepath.Join(t.TempDir(), "hud.sock"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	r := attach(t, s)
+	s.Observe(session.Event{Type: session.EventHandStarted}, newDealtGame(t))
+	if err := s.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	receive(t, r)
+	if _, err := r.ReadBytes('\n'); err == nil {
+		t.Error("Expected the stream to end after Close")
+	}
+	s.Observe(session.Event{Type: session.EventTurn}, newDealtGame(t))
+	if s.Attached() != 0 {
+		t.Errorf("Expected no tools attached after Close, got %d", s.Attached())
+	}
+}
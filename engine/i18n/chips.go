@@ -0,0 +1,59 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NumberFormats are the ways of grouping digits amounts can be written
+// with, named by how they write a thousand
+var NumberFormats = []string{"1,000", "1.000", "1 000", "1000"}
+
+// ChipFormatter writes amounts of chips the way a locale writes numbers,
+// with its thousands separator, and with a currency symbol when chips
+// stand for money. A nil formatter writes plain digits.
+type ChipFormatter struct {
+	separator string // Between groups of three digits, "" for none
+	currency  string // Symbol, "" for chips
+	money     string // Where the symbol goes: %[1]s the amount, %[2]s the symbol
+}
+
+// NewChipFormatter returns a formatter for the translator's locale. format
+// is one of NumberFormats, or "" for the locale's own; currency is a symbol
+// such as "$", or "" for plain chips.
+func NewChipFormatter(t *Translator, format, currency string) *ChipFormatter {
+	separator := t.T("number.thousands")
+	for _, known := range NumberFormats {
+		if format == known {
+			separator = strings.Trim(known, "01")
+		}
+	}
+	return &ChipFormatter{separator: separator, currency: currency, money: t.T("number.money")}
+}
+
+// Format writes an amount, e.g. "1,000", "1.000" or "$1,000"
+func (f *ChipFormatter) Format(amount int) string {
+	if f == nil {
+		return strconv.Itoa(amount)
+	}
+	digits := strconv.Itoa(max(amount, -amount))
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + f.separator + digits[i:]
+	}
+	if f.currency != "" {
+		digits = fmt.Sprintf(f.money, digits, f.currency)
+	}
+	if amount < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Signed writes an amount with its sign, e.g. "+800" or "-800"
+func (f *ChipFormatter) Signed(amount int) string {
+	if amount < 0 {
+		return f.Format(amount)
+	}
+	return "+" + f.Format(amount)
+}
@@ -0,0 +1,29 @@
+package i18n
+
+import "testing"
+
+func TestChipFormatter(t *testing.T) {
+	for _, tt := range []struct {
+		formatter *ChipFormatter
+		want      string
+	}{
+		{nil, "-1234567"},
+		{NewChipFormatter(New(English), "", ""), "-1,234,567"},
+		{NewChipFormatter(New(Spanish), "", ""), "-1.234.567"},
+		{NewChipFormatter(New(Spanish), "1 000", ""), "-1 234 567"},
+		{NewChipFormatter(New(English), "1000", ""), "-1234567"},
+		{NewChipFormatter(New(English), "", "$"), "-$1,234,567"},
+		{NewChipFormatter(New(Spanish), "", "€"), "-1.234.567 €"},
+	} {
+		if got := tt.formatter.Format(-1234567); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+
+	english := NewChipFormatter(New(English), "", "")
+	for amount, want := range map[int]string{0: "+0", 999: "+999", 1000: "+1,000", -800: "-800"} {
+		if got := english.Signed(amount); got != want {
+			t.Errorf("Expected %d signed as %q, got %q", amount, want, got)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Package i18n holds the message catalogs for user-facing text. Code that
+// produces text takes a *Translator and looks messages up by key; a nil
+// Translator speaks English, so translation is opt-in everywhere.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// Locale identifies a message catalog, e.g. "en"
+type Locale string
+
+const (
+	English Locale = "en"
+	Spanish Locale = "es"
+)
+
+// DefaultLocale is used for unknown locales and for keys a catalog lacks
+const DefaultLocale = English
+
+// Translator looks up messages in one locale's catalog
+type Translator struct {
+	locale   Locale
+	messages map[string]string
+	fallback map[string]string
+}
+
+var (
+	catalogsOnce sync.Once
+	catalogs     map[Locale]map[string]string
+	catalogNames map[Locale]string
+	catalogErr   error
+)
+
+// loadCatalogs reads every embedded catalog once. Each file is a flat JSON
+// object of keys to fmt format strings; "locale.name" names the language
+// in itself.
+func loadCatalogs() {
+	catalogs = map[Locale]map[string]string{}
+	catalogNames = map[Locale]string{}
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		catalogErr = err
+		return
+	}
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			catalogErr = err
+			return
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			catalogErr = fmt.Errorf("invalid catalog %s: %w", entry.Name(), err)
+			return
+		}
+		locale := Locale(entry.Name()[:len(entry.Name())-len(path.Ext(entry.Name()))])
+		catalogs[locale] = messages
+		catalogNames[locale] = messages["locale.name"]
+	}
+}
+
+func catalog(locale Locale) map[string]string {
+	catalogsOnce.Do(loadCatalogs)
+	if catalogErr != nil {
+		panic(catalogErr) // The catalogs are compiled in, so this is a build mistake
+	}
+	return catalogs[locale]
+}
+
+// New returns a translator for the locale, falling back to English for
+// unknown locales
+func New(locale Locale) *Translator {
+	messages := catalog(locale)
+	if messages == nil {
+		locale, messages = DefaultLocale, catalog(DefaultLocale)
+	}
+	return &Translator{locale: locale, messages: messages, fallback: catalog(DefaultLocale)}
+}
+
+// Locale returns the translator's locale
+func (t *Translator) Locale() Locale {
+	if t == nil {
+		return DefaultLocale
+	}
+	return t.locale
+}
+
+// T returns the message for key formatted with args. Keys missing from the
+// catalog fall back to English, and unknown keys are returned as they are.
+func (t *Translator) T(key string, args ...any) string {
+	messages, fallback := catalog(DefaultLocale), map[string]string(nil)
+	if t != nil {
+		messages, fallback = t.messages, t.fallback
+	}
+	format, ok := messages[key]
+	if !ok {
+		if format, ok = fallback[key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Locales lists the locales with a catalog, sorted
+func Locales() []Locale {
+	catalog(DefaultLocale)
+	locales := make([]Locale, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Slice(locales, func(i, j int) bool { return locales[i] < locales[j] })
+	return locales
+}
+
+// Name returns the language's name in itself, e.g. "Español"
+func (l Locale) Name() string {
+	catalog(DefaultLocale)
+	if name := catalogNames[l]; name != "" {
+		return name
+	}
+	return string(l)
+}
+
+// Keys returns every key of the locale's catalog, sorted
+func Keys(locale Locale) []string {
+	messages := catalog(locale)
+	keys := make([]string, 0, len(messages))
+	for key := range messages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
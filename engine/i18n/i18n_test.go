@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCatalogsMatchEnglish(t *testing.T) {
+	english := New(English)
+	for _, locale := range Locales() {
+		translator := New(locale)
+		keys := map[string]bool{}
+		for _, key := range Keys(locale) {
+			keys[key] = true
+			if _, ok := english.messages[key]; !ok {
+				t.Errorf("%s has %q, which English lacks", locale, key)
+				continue
+			}
+			if got, want := verbs(translator.messages[key]), verbs(english.messages[key]); got != want {
+				t.Errorf("%s %q has format verbs %q, English has %q", locale, key, got, want)
+			}
+		}
+		for _, key := range Keys(English) {
+			if !keys[key] {
+				t.Errorf("%s lacks %q", locale, key)
+			}
+		}
+	}
+}
+
+// verbs returns the format verbs of a message in order, e.g. "%d%s"
+func verbs(format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format)-1; i++ {
+		if format[i] == '%' {
+			b.WriteString(format[i : i+2])
+			i++
+		}
+	}
+	return b.String()
+}
+
+func TestTranslate(t *testing.T) {
+	spanish := New(Spanish)
+	if got := spanish.T("log.calls", "Maniac", "40"); got != "Maniac iguala 40" {
+		t.Errorf("Expected a formatted Spanish message, got %q", got)
+	}
+	if got := spanish.T("no.such.key"); got != "no.such.key" {
+		t.Errorf("Expected unknown keys back as they are, got %q", got)
+	}
+	var none *Translator
+	if got := none.T("action.fold"); got != "Fold" {
+		t.Errorf("Expected a nil translator to speak English, got %q", got)
+	}
+	if got := New("xx").Locale(); got != English {
+		t.Errorf("Expected unknown locales to fall back to English, got %s", got)
+	}
+	if got := Spanish.Name(); got != "Español" {
+		t.Errorf("Expected Spanish to be named in Spanish, got %q", got)
+	}
+}
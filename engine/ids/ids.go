@@ -0,0 +1,97 @@
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Generator hands out UUIDv7 IDs (RFC 9562): a millisecond timestamp
+// followed by a counter and random bits. IDs compare in the order they were
+// generated, as strings too, so tables, sessions and hands sort by time
+// wherever they end up. It is safe for concurrent use.
+type Generator struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	last    int64  // Millisecond of the last ID
+	counter uint16 // 12-bit sequence within that millisecond
+}
+
+// NewGenerator creates a generator reading the given clock
+func NewGenerator(now func() time.Time) *Generator {
+	return &Generator{now: now}
+}
+
+var std = NewGenerator(time.Now)
+
+// New returns a new ID from the wall clock, e.g.
+// "0192a7c4-3e10-7a2b-9f4d-51c2e8b0a6d3"
+func New() string {
+	return std.New()
+}
+
+// New returns the next ID. Several IDs in the same millisecond follow a
+// counter; should it run out, or the clock step back, the timestamp is
+// moved on a millisecond so IDs never go out of order.
+func (g *Generator) New() string {
+	var id [16]byte
+	if _, err := rand.Read(id[6:]); err != nil {
+		panic(fmt.Sprintf("ids: reading random bits: %v", err)) // Never fails on supported platforms
+	}
+
+	g.mu.Lock()
+	ms := g.now().UnixMilli()
+	if ms > g.last {
+		// A random start leaves room for the counter and keeps it unguessable
+		g.last, g.counter = ms, binary.BigEndian.Uint16(id[6:8])&0x7ff
+	} else if g.counter++; g.counter > 0xfff {
+		g.last, g.counter = g.last+1, 0
+	}
+	ms, counter := g.last, g.counter
+	g.mu.Unlock()
+
+	binary.BigEndian.PutUint64(id[:8], uint64(ms)<<16|0x7000|uint64(counter))
+	id[8] = id[8]&0x3f | 0x80 // RFC 9562 variant
+
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], id[0:4])
+	hex.Encode(buf[9:13], id[4:6])
+	hex.Encode(buf[14:18], id[6:8])
+	hex.Encode(buf[19:23], id[8:10])
+	hex.Encode(buf[24:], id[10:])
+	buf[8], buf[13], buf[18], buf[23] = '-', '-', '-', '-'
+	return string(buf)
+}
+
+// Time returns when an ID was generated, to the millisecond
+func Time(id string) (time.Time, error) {
+	if err := Validate(id); err != nil {
+		return time.Time{}, err
+	}
+	raw, _ := hex.DecodeString(id[0:8] + id[9:13])
+	ms := int64(binary.BigEndian.Uint64(append([]byte{0, 0}, raw...)))
+	return time.UnixMilli(ms), nil
+}
+
+// Validate reports whether id is a UUIDv7 in canonical lowercase form
+func Validate(id string) error {
+	if len(id) != 36 || id[8] != '-' || id[13] != '-' || id[18] != '-' || id[23] != '-' {
+		return fmt.Errorf("invalid ID %q", id)
+	}
+	for i, c := range id {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			continue
+		}
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return fmt.Errorf("invalid ID %q", id)
+		}
+	}
+	if id[14] != '7' || !strings.ContainsRune("89ab", rune(id[19])) {
+		return fmt.Errorf("ID %q is not a UUIDv7", id)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package ids
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestNewIsValidUUIDv7(t *testing.T) {
+	id := New()
+	if err := Validate(id); err != nil {
+		t.Fatalf("Expected a valid ID, got %v", err)
+	}
+	if New() == id {
+		t.Error("Expected a different ID each time")
+	}
+}
+
+func TestIDsSortInOrderOfGeneration(t *testing.T) {
+	clock := time.UnixMilli(1_700_000_000_000)
+	gen := NewGenerator(func() time.Time { return clock })
+
+	var generated []string
+	for i := 0; i < 5000; i++ { // More than the counter holds in one millisecond
+		generated = append(generated, gen.New())
+		if i == 4000 {
+			clock = clock.Add(-time.Second) // Clock stepped back
+		}
+	}
+	sorted := append([]string(nil), generated...)
+	sort.Strings(sorted)
+	for i := range generated {
+		if generated[i] != sorted[i] {
+			t.Fatalf("Expected IDs in generation order, ID %d is out of place", i)
+		}
+	}
+	for i := 1; i < len(generated); i++ {
+		if generated[i] == generated[i-1] {
+			t.Fatalf("Expected unique IDs, got %s twice", generated[i])
+		}
+	}
+}
+
+func TestTimeOfID(t *testing.T) {
+	at := time.UnixMilli(1_700_000_123_456)
+	got, err := Time(NewGenerator(func() time.Time { return at }).New())
+	if err != nil || !got.Equal(at) {
+		t.Errorf("Expected %v, got %v (%v)", at, got, err)
+	}
+}
+
+func TestValidateRejectsOtherIDs(t *testing.T) {
+	for _, id := range []string{
+		"",
+		"42",
+		"0192a7c4-3e10-4a2b-9f4d-51c2e8b0a6d3", // UUIDv4
+		"0192a7c4-3e10-7a2b-cf4d-51c2e8b0a6d3", // Wrong variant
+		"0192A7C4-3E10-7A2B-9F4D-51C2E8B0A6D3", // Not canonical
+		"0192a7c43e107a2b9f4d51c2e8b0a6d3----",
+	} {
+		if err := Validate(id); err == nil {
+			t.Errorf("Expected %q to be rejected", id)
+		}
+	}
+}
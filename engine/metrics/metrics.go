@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Labels are the key/value dimensions attached to a metric sample
+type Labels map[string]string
+
+// IMetrics is the optional instrumentation hook used by the engine.
+// Implementations must be safe for concurrent use.
+type IMetrics interface {
+	// IncCounter adds delta to the counter identified by name and labels
+	IncCounter(name string, labels Labels, delta float64)
+	// ObserveHistogram records a single observation, typically a duration in seconds
+	ObserveHistogram(name string, labels Labels, value float64)
+}
+
+// Metric names emitted by the engine
+const (
+	HandsDealtTotal        = "holdem_hands_dealt_total"
+	ActionsTotal           = "holdem_actions_total"
+	HandEvaluationsTotal   = "holdem_hand_evaluations_total"
+	HandEvaluationSeconds  = "holdem_hand_evaluation_seconds"
+	DecisionsTotal         = "holdem_ai_decisions_total"
+	DecisionLatencySeconds = "holdem_ai_decision_seconds"
+)
+
+// DefaultBuckets are the histogram upper bounds in seconds, covering
+// microsecond evaluator calls up to slow human decisions
+var DefaultBuckets = []float64{0.00001, 0.0001, 0.001, 0.01, 0.1, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Noop discards every sample. It is the default for games and decision makers.
+type Noop struct{}
+
+// NewNoop creates a metrics sink that records nothing
+func NewNoop() *Noop {
+	return &Noop{}
+}
+
+func (n *Noop) IncCounter(name string, labels Labels, delta float64)       {}
+func (n *Noop) ObserveHistogram(name string, labels Labels, value float64) {}
+
+type histogram struct {
+	counts []uint64 // cumulative counts per bucket
+	sum    float64
+	count  uint64
+}
+
+// Registry is an in-memory IMetrics implementation that keeps every series
+// and can be exported in the Prometheus text format
+type Registry struct {
+	lock       sync.RWMutex
+	buckets    []float64
+	counters   map[string]map[string]float64
+	histograms map[string]map[string]*histogram
+}
+
+// NewRegistry creates an empty registry using DefaultBuckets
+func NewRegistry() *Registry {
+	return NewRegistryWithBuckets(DefaultBuckets)
+}
+
+// NewRegistryWithBuckets creates an empty registry with custom histogram buckets
+func NewRegistryWithBuckets(buckets []float64) *Registry {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &Registry{
+		buckets:    sorted,
+		counters:   make(map[string]map[string]float64),
+		histograms: make(map[string]map[string]*histogram),
+	}
+}
+
+// IncCounter implements IMetrics
+func (r *Registry) IncCounter(name string, labels Labels, delta float64) {
+	key := labels.key()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	series, ok := r.counters[name]
+	if !ok {
+		series = make(map[string]float64)
+		r.counters[name] = series
+	}
+	series[key] += delta
+}
+
+// ObserveHistogram implements IMetrics
+func (r *Registry) ObserveHistogram(name string, labels Labels, value float64) {
+	key := labels.key()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	series, ok := r.histograms[name]
+	if !ok {
+		series = make(map[string]*histogram)
+		r.histograms[name] = series
+	}
+	h, ok := series[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(r.buckets))}
+		series[key] = h
+	}
+	for i, bound := range r.buckets {
+		if value <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+// CounterValue returns the current value of a counter series (0 if unknown)
+func (r *Registry) CounterValue(name string, labels Labels) float64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.counters[name][labels.key()]
+}
+
+// HistogramCount returns the number of observations in a histogram series
+func (r *Registry) HistogramCount(name string, labels Labels) uint64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if h, ok := r.histograms[name][labels.key()]; ok {
+		return h.count
+	}
+	return 0
+}
+
+// HistogramSum returns the sum of observations in a histogram series
+func (r *Registry) HistogramSum(name string, labels Labels) float64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if h, ok := r.histograms[name][labels.key()]; ok {
+		return h.sum
+	}
+	return 0
+}
+
+// key serializes labels in a canonical, Prometheus-compatible form:
+// name="value" pairs sorted by name and joined by commas
+func (l Labels) key() string {
+	if len(l) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(l))
+	for name := range l {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(name)
+		b.WriteString(`="`)
+		b.WriteString(escapeLabelValue(l[name]))
+		b.WriteString(`"`)
+	}
+	return b.String()
+}
+
+func escapeLabelValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return strings.ReplaceAll(value, `"`, `\"`)
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestNoopImplementsIMetrics(t *testing.T) {
+	var m IMetrics = NewNoop()
+	m.IncCounter("anything", Labels{"a": "b"}, 1)
+	m.ObserveHistogram("anything", nil, 1)
+}
+
+func TestRegistryCounters(t *testing.T) {
+	r := NewRegistry()
+
+	r.IncCounter(ActionsTotal, Labels{"action": "Call"}, 1)
+	r.IncCounter(ActionsTotal, Labels{"action": "Call"}, 2)
+	r.IncCounter(ActionsTotal, Labels{"action": "Fold"}, 1)
+
+	if v := r.CounterValue(ActionsTotal, Labels{"action": "Call"}); v != 3 {
+		t.Errorf("Expected Call counter 3, got %v", v)
+	}
+	if v := r.CounterValue(ActionsTotal, Labels{"action": "Fold"}); v != 1 {
+		t.Errorf("Expected Fold counter 1, got %v", v)
+	}
+	if v := r.CounterValue(ActionsTotal, Labels{"action": "Raise"}); v != 0 {
+		t.Errorf("Expected unknown series to be 0, got %v", v)
+	}
+}
+
+func TestRegistryHistograms(t *testing.T) {
+	r := NewRegistryWithBuckets([]float64{1, 0.1})
+
+	r.ObserveHistogram(DecisionLatencySeconds, Labels{"bot": "nit"}, 0.05)
+	r.ObserveHistogram(DecisionLatencySeconds, Labels{"bot": "nit"}, 0.5)
+	r.ObserveHistogram(DecisionLatencySeconds, Labels{"bot": "nit"}, 5)
+
+	if c := r.HistogramCount(DecisionLatencySeconds, Labels{"bot": "nit"}); c != 3 {
+		t.Errorf("Expected 3 observations, got %d", c)
+	}
+	if s := r.HistogramSum(DecisionLatencySeconds, Labels{"bot": "nit"}); s != 5.55 {
+		t.Errorf("Expected sum 5.55, got %v", s)
+	}
+	if c := r.HistogramCount(DecisionLatencySeconds, Labels{"bot": "maniac"}); c != 0 {
+		t.Errorf("Expected no observations for unknown series, got %d", c)
+	}
+}
+
+func TestLabelsKeyIsCanonical(t *testing.T) {
+	a := Labels{"bot": "nit", "action": "Call"}
+	b := Labels{"action": "Call", "bot": "nit"}
+	if a.key() != b.key() {
+		t.Errorf("Expected identical keys, got %q and %q", a.key(), b.key())
+	}
+	if a.key() != `action="Call",bot="nit"` {
+		t.Errorf("Unexpected key %q", a.key())
+	}
+	if (Labels{"name": `say "hi"`}).key() != `name="say \"hi\""` {
+		t.Errorf("Expected quotes to be escaped, got %q", Labels{"name": `say "hi"`}.key())
+	}
+}
+
+func TestRegistryConcurrentUse(t *testing.T) {
+	r := NewRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				r.IncCounter(HandsDealtTotal, nil, 1)
+				r.ObserveHistogram(HandEvaluationSeconds, nil, 0.001)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if v := r.CounterValue(HandsDealtTotal, nil); v != 8000 {
+		t.Errorf("Expected 8000 hands, got %v", v)
+	}
+	if c := r.HistogramCount(HandEvaluationSeconds, nil); c != 8000 {
+		t.Errorf("Expected 8000 observations, got %d", c)
+	}
+}
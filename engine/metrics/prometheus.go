@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// WritePrometheus writes every series in the Prometheus text exposition format.
+// Output is sorted by metric name and labels so it is stable between scrapes.
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	bw := bufio.NewWriter(w)
+
+	for _, name := range sortedKeys(r.counters) {
+		fmt.Fprintf(bw, "# TYPE %s counter\n", name)
+		series := r.counters[name]
+		for _, labels := range sortedKeys(series) {
+			fmt.Fprintf(bw, "%s%s %s\n", name, wrapLabels(labels), formatFloat(series[labels]))
+		}
+	}
+
+	for _, name := range sortedKeys(r.histograms) {
+		fmt.Fprintf(bw, "# TYPE %s histogram\n", name)
+		series := r.histograms[name]
+		for _, labels := range sortedKeys(series) {
+			h := series[labels]
+			for i, bound := range r.buckets {
+				fmt.Fprintf(bw, "%s_bucket%s %d\n", name, wrapLabels(joinLabels(labels, `le="`+formatFloat(bound)+`"`)), h.counts[i])
+			}
+			fmt.Fprintf(bw, "%s_bucket%s %d\n", name, wrapLabels(joinLabels(labels, `le="+Inf"`)), h.count)
+			fmt.Fprintf(bw, "%s_sum%s %s\n", name, wrapLabels(labels), formatFloat(h.sum))
+			fmt.Fprintf(bw, "%s_count%s %d\n", name, wrapLabels(labels), h.count)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Handler returns an http.Handler serving the registry for Prometheus scrapes
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := r.WritePrometheus(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func wrapLabels(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func joinLabels(labels, extra string) string {
+	if labels == "" {
+		return extra
+	}
+	return labels + "," + extra
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
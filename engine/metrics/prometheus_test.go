@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	r := NewRegistryWithBuckets([]float64{0.1, 1})
+	r.IncCounter(HandsDealtTotal, nil, 2)
+	r.IncCounter(DecisionsTotal, Labels{"bot": "nit", "action": "Fold"}, 1)
+	r.ObserveHistogram(DecisionLatencySeconds, Labels{"bot": "nit"}, 0.5)
+
+	var b strings.Builder
+	if err := r.WritePrometheus(&b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `# TYPE holdem_ai_decisions_total counter
+holdem_ai_decisions_total{action="Fold",bot="nit"} 1
+# TYPE holdem_hands_dealt_total counter
+holdem_hands_dealt_total 2
+# TYPE holdem_ai_decision_seconds histogram
+holdem_ai_decision_seconds_bucket{bot="nit",le="0.1"} 0
+holdem_ai_decision_seconds_bucket{bot="nit",le="1"} 1
+holdem_ai_decision_seconds_bucket{bot="nit",le="+Inf"} 1
+holdem_ai_decision_seconds_sum{bot="nit"} 0.5
+holdem_ai_decision_seconds_count{bot="nit"} 1
+`
+	if b.String() != expected {
+		t.Errorf("Unexpected exposition output:\n%s\nexpected:\n%s", b.String(), expected)
+	}
+}
+
+func TestPrometheusHandler(t *testing.T) {
+	r := NewRegistry()
+	r.IncCounter(HandsDealtTotal, nil, 1)
+
+	recorder := httptest.NewRecorder()
+	r.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	if recorder.Code != 200 {
+		t.Errorf("Expected status 200, got %d", recorder.Code)
+	}
+	if ct := recorder.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Expected text/plain content type, got %q", ct)
+	}
+	body, _ := io.ReadAll(recorder.Body)
+	if !strings.Contains(string(body), "holdem_hands_dealt_total 1") {
+		t.Errorf("Expected counter in body, got %q", body)
+	}
+}
@@ -0,0 +1,61 @@
+// Package perf compares benchmark runs.
+//
+// Deprecated: it moved to an internal package behind the bench command,
+// "ai-poker bench". These forward to it so code built against earlier
+// versions keeps compiling; they will be removed in a future release.
+package perf
+
+import (
+	"io"
+
+	"github.com/ljbink/ai-poker/internal/perf"
+)
+
+// DefaultThreshold is how much slower or bigger a benchmark may get before
+// it counts as a regression.
+//
+// Deprecated: use "ai-poker bench -threshold".
+const DefaultThreshold = perf.DefaultThreshold
+
+// Units are the measurements compared.
+//
+// Deprecated: use "ai-poker bench".
+var Units = perf.Units
+
+// Benchmark is one benchmark's measurements over a run.
+//
+// Deprecated: use "ai-poker bench".
+type Benchmark = perf.Benchmark
+
+// Delta is how one unit of one benchmark changed between two runs.
+//
+// Deprecated: use "ai-poker bench".
+type Delta = perf.Delta
+
+// Parse reads "go test -bench -benchmem" output.
+//
+// Deprecated: use "ai-poker bench".
+func Parse(r io.Reader) ([]*Benchmark, error) {
+	return perf.Parse(r)
+}
+
+// ParseFile reads "go test -bench -benchmem" output from a file.
+//
+// Deprecated: use "ai-poker bench".
+func ParseFile(path string) ([]*Benchmark, error) {
+	return perf.ParseFile(path)
+}
+
+// Compare pairs up the benchmarks found in both runs.
+//
+// Deprecated: use "ai-poker bench".
+func Compare(old, new []*Benchmark) []Delta {
+	return perf.Compare(old, new)
+}
+
+// Regressions returns the deltas worse than the threshold.
+//
+// Deprecated: use "ai-poker bench".
+func Regressions(deltas []Delta, threshold float64) []Delta {
+	return perf.Regressions(deltas, threshold)
+}
@@ -0,0 +1,206 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var (
+	rankNotation = map[Rank]byte{
+		RankAce:   'A',
+		RankTwo:   '2',
+		RankThree: '3',
+		RankFour:  '4',
+		RankFive:  '5',
+		RankSix:   '6',
+		RankSeven: '7',
+		RankEight: '8',
+		RankNine:  '9',
+		RankTen:   'T',
+		RankJack:  'J',
+		RankQueen: 'Q',
+		RankKing:  'K',
+	}
+	suitNotation = map[Suit]byte{
+		SuitHeart:   'h',
+		SuitDiamond: 'd',
+		SuitClub:    'c',
+		SuitSpade:   's',
+	}
+)
+
+// Code returns the two-character notation used by hand histories, e.g. "Ah" or "Td"
+func (r Card) Code() string {
+	rank, okRank := rankNotation[r.Rank]
+	suit, okSuit := suitNotation[r.Suit]
+	if !okRank || !okSuit {
+		return "??"
+	}
+	return string([]byte{rank, suit})
+}
+
+// Codes returns the cards in hand history notation without separators, e.g. "AhKd"
+func (c Cards) Codes() string {
+	var sb strings.Builder
+	for _, card := range c {
+		if card == nil {
+			sb.WriteString("??")
+			continue
+		}
+		sb.WriteString(card.Code())
+	}
+	return sb.String()
+}
+
+// suitSymbols are the suit glyphs cards may be written with instead of
+// letters, outlined ones included
+var suitSymbols = map[rune]Suit{
+	'♠': SuitSpade, '♤': SuitSpade,
+	'♥': SuitHeart, '♡': SuitHeart,
+	'♦': SuitDiamond, '♢': SuitDiamond,
+	'♣': SuitClub, '♧': SuitClub,
+}
+
+// emojiVariation follows a suit symbol typed or pasted as an emoji, "♠️"
+const emojiVariation = '\uFE0F'
+
+// CardError is a card that could not be parsed, with where it starts in the
+// text so that the user can be pointed at it
+type CardError struct {
+	Token    string // The card as typed, or as much of it as there was
+	Position int    // Of the card's first character in the text, from 1
+	Reason   string
+}
+
+func (e *CardError) Error() string {
+	return fmt.Sprintf("invalid card %q at position %d: %s", e.Token, e.Position, e.Reason)
+}
+
+// ParseCard parses a card in hand history notation such as "Ah", "td" or
+// "10s", or with a suit symbol such as "A♥"
+func ParseCard(s string) (*Card, error) {
+	cards, err := ParseCards(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(cards) != 1 {
+		return nil, fmt.Errorf("expected one card, got %d in %q", len(cards), strings.TrimSpace(s))
+	}
+	return cards[0], nil
+}
+
+// ParseCards parses cards separated by spaces or commas, or packed together
+// like "AhKd". Ranks and suit letters may be either case and suits may be
+// symbols, so "as ks", "AhKh" and "A♠ K♠" all read. Surrounding brackets
+// are ignored. A card that does not read is returned as a *CardError.
+func ParseCards(s string) (Cards, error) {
+	text := []rune(s)
+	cards := Cards{}
+	for i := 0; i < len(text); {
+		if isCardSeparator(text[i]) || text[i] == '[' {
+			i++
+			continue
+		}
+		card, n, reason := scanCard(text[i:])
+		if reason != "" {
+			return nil, &CardError{Token: string(text[i : i+n]), Position: i + 1, Reason: reason}
+		}
+		cards.Append(card)
+		i += n
+	}
+	return cards, nil
+}
+
+// scanCard reads the card text starts with and how many runes it takes,
+// or why it is not a card and how many runes of it to show
+func scanCard(text []rune) (*Card, int, string) {
+	card := &Card{}
+	n := 1
+	if len(text) >= 2 && text[0] == '1' && text[1] == '0' {
+		card.Rank, n = RankTen, 2
+	} else {
+		for rank, b := range rankNotation {
+			if unicode.ToUpper(text[0]) == rune(b) {
+				card.Rank = rank
+			}
+		}
+	}
+	if card.Rank == RankNone {
+		return nil, 1, fmt.Sprintf("%q is not a rank, expected 2-9, T (or 10), J, Q, K or A", text[0])
+	}
+	if n == len(text) || isCardSeparator(text[n]) {
+		return nil, n, "the suit is missing"
+	}
+	suit := text[n]
+	if symbol, ok := suitSymbols[suit]; ok {
+		card.Suit = symbol
+	} else {
+		for s, b := range suitNotation {
+			if unicode.ToLower(suit) == rune(b) {
+				card.Suit = s
+			}
+		}
+	}
+	n++
+	if card.Suit == SuitNone {
+		return nil, n, fmt.Sprintf("%q is not a suit, expected s, h, d, c or ♠ ♥ ♦ ♣", suit)
+	}
+	if n < len(text) && text[n] == emojiVariation {
+		n++
+	}
+	return card, n, ""
+}
+
+// isCardSeparator reports whether r may stand between cards
+func isCardSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == ',' || r == ']'
+}
+
+var (
+	rankNames = map[Rank]string{
+		RankAce:   "Ace",
+		RankTwo:   "Two",
+		RankThree: "Three",
+		RankFour:  "Four",
+		RankFive:  "Five",
+		RankSix:   "Six",
+		RankSeven: "Seven",
+		RankEight: "Eight",
+		RankNine:  "Nine",
+		RankTen:   "Ten",
+		RankJack:  "Jack",
+		RankQueen: "Queen",
+		RankKing:  "King",
+	}
+	suitNames = map[Suit]string{
+		SuitHeart:   "hearts",
+		SuitDiamond: "diamonds",
+		SuitClub:    "clubs",
+		SuitSpade:   "spades",
+	}
+)
+
+// Name returns the card in plain words, e.g. "Ace of spades", for screen
+// readers that skip the playing card glyphs
+func (r Card) Name() string {
+	rank, okRank := rankNames[r.Rank]
+	suit, okSuit := suitNames[r.Suit]
+	if !okRank || !okSuit {
+		return "Unknown card"
+	}
+	return rank + " of " + suit
+}
+
+// Names returns the cards in plain words separated by commas
+func (c Cards) Names() string {
+	names := make([]string, 0, len(c))
+	for _, card := range c {
+		if card == nil {
+			names = append(names, "Unknown card")
+			continue
+		}
+		names = append(names, card.Name())
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,126 @@
+package poker_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestParseCard(t *testing.T) {
+	testCases := []struct {
+		input string
+		suit  poker.Suit
+		rank  poker.Rank
+	}{
+		{"Ah", poker.SuitHeart, poker.RankAce},
+		{"td", poker.SuitDiamond, poker.RankTen},
+		{"10s", poker.SuitSpade, poker.RankTen},
+		{"2C", poker.SuitClub, poker.RankTwo},
+		{" Kd ", poker.SuitDiamond, poker.RankKing},
+		{"A♠", poker.SuitSpade, poker.RankAce},
+		{"q♡", poker.SuitHeart, poker.RankQueen},
+		{"10♦\uFE0F", poker.SuitDiamond, poker.RankTen},
+	}
+
+	for _, tc := range testCases {
+		card, err := poker.ParseCard(tc.input)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %v", tc.input, err)
+			continue
+		}
+		if card.Suit != tc.suit || card.Rank != tc.rank {
+			t.Errorf("Expected %q to parse as %d/%d, got %d/%d", tc.input, tc.suit, tc.rank, card.Suit, card.Rank)
+		}
+	}
+
+	for _, input := range []string{"", "A", "Ax", "1h", "Zz", "AAh", "Ah Kd"} {
+		if _, err := poker.ParseCard(input); err == nil {
+			t.Errorf("Expected error parsing %q", input)
+		}
+	}
+}
+
+func TestParseCards(t *testing.T) {
+	cards, err := poker.ParseCards("[Ah Kd, 10c]")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cards.Codes() != "AhKdTc" {
+		t.Errorf("Expected AhKdTc, got %s", cards.Codes())
+	}
+
+	packed, err := poker.ParseCards("2h3h4h")
+	if err != nil || packed.Length() != 3 {
+		t.Errorf("Expected 3 packed cards, got %v (%v)", packed, err)
+	}
+
+	if _, err := poker.ParseCards("AhK"); err == nil {
+		t.Error("Expected error for truncated card")
+	}
+	if empty, err := poker.ParseCards(" "); err != nil || empty.Length() != 0 {
+		t.Errorf("Expected no cards, got %v (%v)", empty, err)
+	}
+}
+
+func TestParseCardsNotations(t *testing.T) {
+	for _, input := range []string{"AhKh", "ah kh", "AH,KH", "A♥K♥", "a♡ k♡", "[Ah] [Kh]", "A♥️K♥️"} {
+		cards, err := poker.ParseCards(input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", input, err)
+			continue
+		}
+		if cards.Codes() != "AhKh" {
+			t.Errorf("%s: expected AhKh, got %s", input, cards.Codes())
+		}
+	}
+}
+
+func TestParseCardsPinpointsInvalidCard(t *testing.T) {
+	testCases := []struct {
+		input    string
+		token    string
+		position int
+		reason   string
+	}{
+		{"AhXh", "X", 3, "not a rank"},
+		{"As Kx Qd", "Kx", 4, "not a suit"},
+		{"A♠ K", "K", 4, "suit is missing"},
+		{"10h 1s", "1", 5, "not a rank"},
+		{"AKs", "AK", 1, "not a suit"},
+	}
+	for _, tc := range testCases {
+		_, err := poker.ParseCards(tc.input)
+		var invalid *poker.CardError
+		if !errors.As(err, &invalid) {
+			t.Errorf("%s: expected a CardError, got %v", tc.input, err)
+			continue
+		}
+		if invalid.Token != tc.token || invalid.Position != tc.position || !strings.Contains(invalid.Reason, tc.reason) {
+			t.Errorf("%s: expected %q at %d (%s), got %v", tc.input, tc.token, tc.position, tc.reason, err)
+		}
+	}
+}
+
+func TestCardCode(t *testing.T) {
+	if code := poker.NewCard(poker.SuitSpade, poker.RankTen).Code(); code != "Ts" {
+		t.Errorf("Expected Ts, got %s", code)
+	}
+	if code := poker.NewCard(poker.SuitNone, poker.RankJoker).Code(); code != "??" {
+		t.Errorf("Expected ?? for a joker, got %s", code)
+	}
+}
+
+func TestCardName(t *testing.T) {
+	if name := poker.NewCard(poker.SuitSpade, poker.RankAce).Name(); name != "Ace of spades" {
+		t.Errorf("Expected Ace of spades, got %s", name)
+	}
+	cards, err := poker.ParseCards("Th 2c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := cards.Names(); names != "Ten of hearts, Two of clubs" {
+		t.Errorf("Expected Ten of hearts, Two of clubs, got %s", names)
+	}
+}
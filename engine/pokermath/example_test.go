@@ -0,0 +1,25 @@
+package pokermath_test
+
+import (
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/pokermath"
+)
+
+// Facing a half-pot bet on the turn with a flush draw: the call needs a
+// quarter of the pot, more than the draw's nine outs give, so the rest has
+// to come from later streets
+func Example() {
+	pot, bet := 100, 50
+	need := pokermath.EquityToCall(pot, bet)
+	draw := 9.0 / 46
+	fmt.Printf("equity needed %.0f%%, have %.0f%%\n", need*100, draw*100)
+	fmt.Printf("call EV %+.1f chips\n", pokermath.CallEV(draw, pot+bet, bet))
+	fmt.Printf("implied winnings needed %.0f chips\n", pokermath.ImpliedWinnings(draw, pot+bet, bet))
+	fmt.Printf("defend %.0f%% against it\n", pokermath.MDF(pot, bet)*100)
+	// Output:
+	// equity needed 25%, have 20%
+	// call EV -10.9 chips
+	// implied winnings needed 56 chips
+	// defend 67% against it
+}
@@ -0,0 +1,113 @@
+// Package pokermath holds the arithmetic of betting decisions that bots,
+// the spot review and the training quizzes share: counting combinations,
+// pot odds and implied odds, minimum defense frequency and the equity a
+// bet of a given size asks for.
+//
+// Amounts are chips. Where a function takes the pot, the doc comment says
+// whether the bet faced is already in it: pot odds are worked out at the
+// decision, with the bet in the pot, and bet sizes against the pot before
+// the bet, the way players quote them ("half pot").
+package pokermath
+
+import (
+	"math"
+
+	"github.com/ljbink/ai-poker/internal/combin"
+)
+
+// Choose returns n choose k, the number of ways to pick k cards out of n,
+// 0 when k is out of range. Counts too big for an int saturate at
+// math.MaxInt.
+func Choose(n, k int) int {
+	return combin.Binomial(n, k)
+}
+
+// PotOdds returns the equity needed to break even on a call: the share of
+// the pot after the call that the call puts in. The pot includes the bet
+// faced. 0 when there is nothing to call.
+func PotOdds(pot, toCall int) float64 {
+	if toCall <= 0 {
+		return 0
+	}
+	return float64(toCall) / float64(pot+toCall)
+}
+
+// ImpliedOdds returns the equity needed to break even on a call that also
+// wins the future chips the player expects to take on later streets when
+// they hit. The pot includes the bet faced. 0 when there is nothing to call.
+func ImpliedOdds(pot, toCall, future int) float64 {
+	if toCall <= 0 {
+		return 0
+	}
+	return float64(toCall) / float64(pot+toCall+future)
+}
+
+// ImpliedWinnings returns the chips a call with the given equity has to win
+// on later streets to break even, 0 when the pot already pays for it and
+// +Inf when the hand cannot win. The pot includes the bet faced.
+func ImpliedWinnings(equity float64, pot, toCall int) float64 {
+	switch {
+	case toCall <= 0 || equity >= PotOdds(pot, toCall):
+		return 0
+	case equity <= 0:
+		return math.Inf(1)
+	}
+	return float64(toCall)/equity - float64(pot+toCall)
+}
+
+// CallEV returns the chips a call is worth relative to folding: the share
+// of the pot plus the call that the equity wins, minus the call. The pot
+// includes the bet faced.
+func CallEV(equity float64, pot, toCall int) float64 {
+	return equity*float64(pot+toCall) - float64(toCall)
+}
+
+// MDF returns the minimum defense frequency against a bet: how often the
+// player has to continue so that a bluff of that size does not profit
+// outright. The pot is the pot before the bet. 1 when there is no bet.
+func MDF(pot, bet int) float64 {
+	if bet <= 0 {
+		return 1
+	}
+	return float64(pot) / float64(pot+bet)
+}
+
+// BluffBreakEven returns how often a bet has to make everyone fold to
+// break even as a pure bluff, 1 - MDF. The pot is the pot before the bet.
+func BluffBreakEven(pot, bet int) float64 {
+	return 1 - MDF(pot, bet)
+}
+
+// EquityToCall returns the equity needed to call a bet of the given size.
+// The pot is the pot before the bet, so a pot-sized bet asks for a third.
+func EquityToCall(pot, bet int) float64 {
+	return PotOdds(pot+bet, bet)
+}
+
+// EquityForBetSize returns the equity needed to call a bet of the given
+// fraction of the pot, 0.5 for half pot: f / (1 + 2f)
+func EquityForBetSize(fraction float64) float64 {
+	if fraction <= 0 {
+		return 0
+	}
+	return fraction / (1 + 2*fraction)
+}
+
+// BetSizeForEquity returns the bet, as a fraction of the pot, that offers
+// a caller exactly the given equity: the largest bet a hand with that much
+// equity can call. The inverse of EquityForBetSize; bets can ask for less
+// than a half, so 0.5 or more has no answer and returns 0.
+func BetSizeForEquity(equity float64) float64 {
+	if equity <= 0 || equity >= 0.5 {
+		return 0
+	}
+	return equity / (1 - 2*equity)
+}
+
+// StackToPot returns the stack-to-pot ratio, 0 when there is no pot
+func StackToPot(stack, pot int) float64 {
+	if pot <= 0 {
+		return 0
+	}
+	return float64(stack) / float64(pot)
+}
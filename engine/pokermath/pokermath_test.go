@@ -0,0 +1,160 @@
+package pokermath
+
+import (
+	"math"
+	"testing"
+)
+
+// near compares floats to the precision the tables below are written in
+func near(got, want float64) bool {
+	return math.Abs(got-want) < 1e-4
+}
+
+func TestChoose(t *testing.T) {
+	tests := []struct {
+		n, k, expected int
+	}{
+		{52, 2, 1326},
+		{50, 3, 19600},
+		{47, 2, 1081},
+		{4, 2, 6},
+		{5, 0, 1},
+		{2, 3, 0},
+		{3, -1, 0},
+	}
+	for _, tt := range tests {
+		if got := Choose(tt.n, tt.k); got != tt.expected {
+			t.Errorf("Choose(%d, %d) = %d, expected %d", tt.n, tt.k, got, tt.expected)
+		}
+	}
+}
+
+func TestPotOdds(t *testing.T) {
+	tests := []struct {
+		name        string
+		pot, toCall int
+		expected    float64
+	}{
+		{"pot-sized bet", 200, 100, 1.0 / 3},
+		{"half-pot bet", 150, 50, 0.25},
+		{"big blind completing", 15, 5, 0.25},
+		{"nothing to call", 100, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := PotOdds(tt.pot, tt.toCall); !near(got, tt.expected) {
+			t.Errorf("%s: PotOdds(%d, %d) = %.4f, expected %.4f", tt.name, tt.pot, tt.toCall, got, tt.expected)
+		}
+	}
+}
+
+func TestImpliedOdds(t *testing.T) {
+	tests := []struct {
+		name                string
+		pot, toCall, future int
+		expected            float64
+	}{
+		{"no future winnings is pot odds", 200, 100, 0, 1.0 / 3},
+		{"a stack behind to win", 200, 100, 300, 1.0 / 6},
+		{"nothing to call", 200, 0, 300, 0},
+	}
+	for _, tt := range tests {
+		if got := ImpliedOdds(tt.pot, tt.toCall, tt.future); !near(got, tt.expected) {
+			t.Errorf("%s: ImpliedOdds(%d, %d, %d) = %.4f, expected %.4f", tt.name, tt.pot, tt.toCall, tt.future, got, tt.expected)
+		}
+	}
+}
+
+func TestImpliedWinnings(t *testing.T) {
+	tests := []struct {
+		name        string
+		equity      float64
+		pot, toCall int
+		expected    float64
+	}{
+		{"flush draw on the turn", 0.2, 200, 100, 200},
+		{"already priced in", 0.4, 200, 100, 0},
+		{"nothing to call", 0.1, 200, 0, 0},
+		{"drawing dead", 0, 200, 100, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		got := ImpliedWinnings(tt.equity, tt.pot, tt.toCall)
+		if got != tt.expected && !near(got, tt.expected) {
+			t.Errorf("%s: ImpliedWinnings(%.2f, %d, %d) = %.4f, expected %.4f", tt.name, tt.equity, tt.pot, tt.toCall, got, tt.expected)
+		}
+	}
+}
+
+func TestCallEV(t *testing.T) {
+	tests := []struct {
+		equity      float64
+		pot, toCall int
+		expected    float64
+	}{
+		{0.5, 200, 100, 50},
+		{1.0 / 3, 200, 100, 0},
+		{0.2, 200, 100, -40},
+		{0.8, 100, 0, 80},
+	}
+	for _, tt := range tests {
+		if got := CallEV(tt.equity, tt.pot, tt.toCall); !near(got, tt.expected) {
+			t.Errorf("CallEV(%.2f, %d, %d) = %.4f, expected %.4f", tt.equity, tt.pot, tt.toCall, got, tt.expected)
+		}
+	}
+}
+
+func TestMDFAndBluffBreakEven(t *testing.T) {
+	tests := []struct {
+		name       string
+		pot, bet   int
+		mdf, bluff float64
+	}{
+		{"pot-sized bet", 100, 100, 0.5, 0.5},
+		{"half-pot bet", 100, 50, 2.0 / 3, 1.0 / 3},
+		{"overbet", 100, 200, 1.0 / 3, 2.0 / 3},
+		{"no bet", 100, 0, 1, 0},
+	}
+	for _, tt := range tests {
+		if got := MDF(tt.pot, tt.bet); !near(got, tt.mdf) {
+			t.Errorf("%s: MDF(%d, %d) = %.4f, expected %.4f", tt.name, tt.pot, tt.bet, got, tt.mdf)
+		}
+		if got := BluffBreakEven(tt.pot, tt.bet); !near(got, tt.bluff) {
+			t.Errorf("%s: BluffBreakEven(%d, %d) = %.4f, expected %.4f", tt.name, tt.pot, tt.bet, got, tt.bluff)
+		}
+	}
+}
+
+func TestBetSizeConversions(t *testing.T) {
+	tests := []struct {
+		name     string
+		fraction float64
+		equity   float64
+	}{
+		{"third pot", 1.0 / 3, 0.2},
+		{"half pot", 0.5, 0.25},
+		{"pot", 1, 1.0 / 3},
+		{"double pot", 2, 0.4},
+	}
+	for _, tt := range tests {
+		if got := EquityForBetSize(tt.fraction); !near(got, tt.equity) {
+			t.Errorf("%s: EquityForBetSize(%.3f) = %.4f, expected %.4f", tt.name, tt.fraction, got, tt.equity)
+		}
+		if got := BetSizeForEquity(tt.equity); !near(got, tt.fraction) {
+			t.Errorf("%s: BetSizeForEquity(%.4f) = %.4f, expected %.4f", tt.name, tt.equity, got, tt.fraction)
+		}
+		if got := EquityToCall(300, int(300*tt.fraction)); !near(got, tt.equity) {
+			t.Errorf("%s: EquityToCall(300, %d) = %.4f, expected %.4f", tt.name, int(300*tt.fraction), got, tt.equity)
+		}
+	}
+	if EquityForBetSize(0) != 0 || BetSizeForEquity(0.5) != 0 || BetSizeForEquity(0) != 0 {
+		t.Error("Expected 0 for no bet and for equities no bet asks for")
+	}
+}
+
+func TestStackToPot(t *testing.T) {
+	if got := StackToPot(900, 300); got != 3 {
+		t.Errorf("Expected an SPR of 3, got %.2f", got)
+	}
+	if got := StackToPot(900, 0); got != 0 {
+		t.Errorf("Expected 0 without a pot, got %.2f", got)
+	}
+}
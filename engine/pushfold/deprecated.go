@@ -0,0 +1,55 @@
+package pushfold
+
+import (
+	"github.com/ljbink/ai-poker/engine/charts"
+	"github.com/ljbink/ai-poker/internal/nash"
+)
+
+// The solver behind the shipped tables moved to an internal package. These
+// forward to it so code built against earlier versions keeps compiling;
+// they will be removed in a future release.
+
+// Hands is the number of starting hand classes.
+//
+// Deprecated: the solver is internal; the grid in package ranges lists
+// the hand classes.
+const Hands = nash.Hands
+
+// Matrix holds the preflop all-in equity of every starting hand class
+// against every other.
+//
+// Deprecated: the solver is internal; use Default for the solved tables.
+type Matrix = nash.Matrix
+
+// Strategy is how often each hand class shoves and is called.
+//
+// Deprecated: the solver is internal; use Default for the solved tables.
+type Strategy = nash.Strategy
+
+// HandIndex returns the grid order index of a hand class such as "AKs".
+//
+// Deprecated: use ranges.Position.
+func HandIndex(hand string) int {
+	return nash.HandIndex(hand)
+}
+
+// HandClass returns the hand class at a grid order index.
+//
+// Deprecated: use ranges.GridHand.
+func HandClass(i int) string {
+	return nash.HandClass(i)
+}
+
+// NewMatrix samples the equity of every pair of hand classes.
+//
+// Deprecated: the solver is internal; use Default for the solved tables.
+func NewMatrix(samples int, seed int64) *Matrix {
+	return nash.NewMatrix(samples, seed)
+}
+
+// Solve approximates the push/fold equilibrium from a position.
+//
+// Deprecated: the solver is internal; use Default for the solved tables.
+func Solve(m *Matrix, position charts.Position, stackBB float64, iterations int) (*Strategy, error) {
+	return nash.Solve(m, position, stackBB, iterations)
+}
@@ -0,0 +1,62 @@
+//go:build ignore
+
+// gen solves push/fold at every stack depth the tables cover and writes
+// data/nash.json. Run it with go generate after changing the solver.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ljbink/ai-poker/engine/charts"
+	"github.com/ljbink/ai-poker/engine/pushfold"
+	"github.com/ljbink/ai-poker/internal/nash"
+)
+
+const (
+	samples    = 1000 // Runouts per pair of hand classes
+	iterations = 300  // Fictitious play rounds per stack depth
+	step       = 0.5  // Stack depths solved, in big blinds
+)
+
+func main() {
+	log.Printf("sampling the equity matrix, %d runouts per pair", samples)
+	m := nash.NewMatrix(samples, 1)
+
+	table := pushfold.Table{
+		Name:       fmt.Sprintf("Push/fold, %d runouts per pair, %d iterations", samples, iterations),
+		MaxStackBB: pushfold.MaxStackBB,
+		Shove:      map[charts.Position]map[string]float64{},
+		Call:       map[charts.Position]map[string]float64{},
+	}
+	for _, position := range []charts.Position{charts.PositionUTG, charts.PositionHJ, charts.PositionCO, charts.PositionBTN, charts.PositionSB} {
+		shove, call := map[string]float64{}, map[string]float64{}
+		for stack := 1.0; stack <= pushfold.MaxStackBB; stack += step {
+			strategy, err := nash.Solve(m, position, stack, iterations)
+			if err != nil {
+				log.Fatal(err)
+			}
+			for i := 0; i < nash.Hands; i++ {
+				hand := nash.HandClass(i)
+				if strategy.Shove[i] >= 0.5 {
+					shove[hand] = stack
+				}
+				if strategy.Call[i] >= 0.5 {
+					call[hand] = stack
+				}
+			}
+		}
+		log.Printf("%s: %d hands shove at some stack", position, len(shove))
+		table.Shove[position], table.Call[position] = shove, call
+	}
+
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("data/nash.json", append(data, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,116 @@
+// Package pushfold plays short stacks as push or fold: every hand either
+// moves all in first into the pot or folds, and the big blind calls or
+// folds against it. The thresholds shipped in data/nash.json are solved
+// offline, see gen.go.
+package pushfold
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/ljbink/ai-poker/engine/charts"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+//go:generate go run gen.go
+
+// MaxStackBB is the deepest stack the tables cover, in big blinds
+const MaxStackBB = 20
+
+//go:embed data/nash.json
+var nashData []byte
+
+// Table holds, for each position first in, the deepest stack in big
+// blinds each hand class shoves at, and the deepest stack the big blind
+// calls a shove from that position with it. Hands missing never play.
+type Table struct {
+	Name       string                                 `json:"name"`
+	MaxStackBB float64                                `json:"max_stack_bb"`
+	Shove      map[charts.Position]map[string]float64 `json:"shove"`
+	Call       map[charts.Position]map[string]float64 `json:"call"`
+}
+
+var (
+	defaultTable     *Table
+	defaultTableErr  error
+	defaultTableOnce sync.Once
+)
+
+// Default returns the table shipped in the data directory
+func Default() (*Table, error) {
+	defaultTableOnce.Do(func() {
+		defaultTable = &Table{}
+		if err := json.Unmarshal(nashData, defaultTable); err != nil {
+			defaultTable, defaultTableErr = nil, fmt.Errorf("nash.json: %w", err)
+		}
+	})
+	return defaultTable, defaultTableErr
+}
+
+// ShouldShove reports whether a hand class such as "AKs" moves all in
+// when the pot is folded to a position and the effective stack is
+// stackBB big blinds. Stacks deeper than the table covers never shove.
+func (t *Table) ShouldShove(hand string, position charts.Position, stackBB float64) bool {
+	return plays(t.Shove[position], hand, stackBB, t.MaxStackBB)
+}
+
+// ShouldCall reports whether the big blind calls an all in of stackBB
+// big blinds from the shover's position with a hand class
+func (t *Table) ShouldCall(hand string, shover charts.Position, stackBB float64) bool {
+	return plays(t.Call[shover], hand, stackBB, t.MaxStackBB)
+}
+
+// ShoveRange returns every hand class that shoves from a position at a
+// stack depth, for showing on a range grid
+func (t *Table) ShoveRange(position charts.Position, stackBB float64) *ranges.Range {
+	return toRange(t.Shove[position], stackBB, t.MaxStackBB)
+}
+
+// CallRange returns every hand class the big blind calls a shove from
+// the shover's position with at a stack depth
+func (t *Table) CallRange(shover charts.Position, stackBB float64) *ranges.Range {
+	return toRange(t.Call[shover], stackBB, t.MaxStackBB)
+}
+
+func plays(thresholds map[string]float64, hand string, stackBB, maxStackBB float64) bool {
+	if stackBB > maxStackBB {
+		return false
+	}
+	threshold, ok := thresholds[hand]
+	return ok && stackBB <= threshold
+}
+
+func toRange(thresholds map[string]float64, stackBB, maxStackBB float64) *ranges.Range {
+	r := ranges.NewRange()
+	for hand := range thresholds {
+		if plays(thresholds, hand, stackBB, maxStackBB) {
+			r.Set(hand, 1)
+		}
+	}
+	return r
+}
+
+// ShouldShove reports whether the shipped Nash tables move a hand class
+// all in first into the pot from a position with stackBB big blinds.
+// Full tables play their early seats as under the gun and heads-up the
+// button plays as the small blind (see charts.PositionAt).
+func ShouldShove(hand string, position charts.Position, stackBB float64) bool {
+	table, err := Default()
+	if err != nil {
+		return false
+	}
+	return table.ShouldShove(hand, position, stackBB)
+}
+
+// ShouldCall reports whether the shipped Nash tables call an all in of
+// stackBB big blinds from the shover's position with a hand class in the
+// big blind
+func ShouldCall(hand string, shover charts.Position, stackBB float64) bool {
+	table, err := Default()
+	if err != nil {
+		return false
+	}
+	return table.ShouldCall(hand, shover, stackBB)
+}
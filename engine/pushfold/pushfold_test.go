@@ -0,0 +1,57 @@
+package pushfold
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/charts"
+)
+
+func TestShouldShove(t *testing.T) {
+	tests := []struct {
+		hand     string
+		position charts.Position
+		stackBB  float64
+		want     bool
+	}{
+		{"AA", charts.PositionUTG, 20, true},
+		{"AA", charts.PositionSB, 1, true},
+		{"72o", charts.PositionUTG, 10, false},
+		{"72o", charts.PositionSB, 1, true},
+		{"K2s", charts.PositionSB, 15, true},
+		{"K2s", charts.PositionUTG, 15, false},
+		{"AA", charts.PositionBB, 10, false},
+		{"AA", charts.PositionSB, MaxStackBB + 1, false},
+	}
+	for _, tt := range tests {
+		if got := ShouldShove(tt.hand, tt.position, tt.stackBB); got != tt.want {
+			t.Errorf("ShouldShove(%s, %s, %g) = %v, want %v", tt.hand, tt.position, tt.stackBB, got, tt.want)
+		}
+	}
+}
+
+func TestShouldCall(t *testing.T) {
+	if !ShouldCall("QQ", charts.PositionUTG, 20) {
+		t.Error("Expected queens to call a 20 big blind shove")
+	}
+	if ShouldCall("K2s", charts.PositionUTG, 15) {
+		t.Error("Expected K2s to fold to an under the gun shove")
+	}
+	if !ShouldCall("K2s", charts.PositionSB, 8) {
+		t.Error("Expected K2s to call a small blind shove at 8 big blinds")
+	}
+}
+
+func TestRangesWidenAsStacksShrinkAndSeatsGetLater(t *testing.T) {
+	table, err := Default()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, position := range []charts.Position{charts.PositionUTG, charts.PositionBTN, charts.PositionSB} {
+		if deep, short := table.ShoveRange(position, 15).Fraction(), table.ShoveRange(position, 5).Fraction(); deep >= short {
+			t.Errorf("Expected %s to shove wider at 5 than 15 big blinds, got %.3f and %.3f", position, short, deep)
+		}
+	}
+	if utg, sb := table.ShoveRange(charts.PositionUTG, 10).Fraction(), table.ShoveRange(charts.PositionSB, 10).Fraction(); utg >= sb {
+		t.Errorf("Expected the small blind to shove wider than under the gun, got %.3f and %.3f", sb, utg)
+	}
+}
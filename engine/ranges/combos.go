@@ -0,0 +1,87 @@
+package ranges
+
+import (
+	"strings"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// Combo is one pair of hole cards from a range
+type Combo struct {
+	Cards  poker.Cards
+	Weight float64
+}
+
+var suits = []poker.Suit{poker.SuitSpade, poker.SuitHeart, poker.SuitDiamond, poker.SuitClub}
+
+// Expand lists every card combination in the range, leaving out combos
+// that use a dead card
+func (r *Range) Expand(dead poker.Cards) []Combo {
+	blocked := map[poker.Card]bool{}
+	for _, card := range dead {
+		if card != nil {
+			blocked[*card] = true
+		}
+	}
+
+	combos := []Combo{}
+	for _, hand := range r.Hands() {
+		weight := r.weights[hand]
+		high, low := cardRank(hand[0]), cardRank(hand[1])
+		for i, s1 := range suits {
+			for j, s2 := range suits {
+				switch {
+				case high == low && j <= i:
+					continue
+				case strings.HasSuffix(hand, "s") && s1 != s2:
+					continue
+				case strings.HasSuffix(hand, "o") && s1 == s2:
+					continue
+				}
+				a, b := poker.NewCard(s1, high), poker.NewCard(s2, low)
+				if blocked[*a] || blocked[*b] {
+					continue
+				}
+				combos = append(combos, Combo{Cards: poker.Cards{a, b}, Weight: weight})
+			}
+		}
+	}
+	return combos
+}
+
+// HandClass returns the grid class of two hole cards, e.g. "AKs" or "77"
+func HandClass(cards poker.Cards) string {
+	if len(cards) != 2 || cards[0] == nil || cards[1] == nil {
+		return ""
+	}
+	a, b := rankIndex(cards[0].Rank), rankIndex(cards[1].Rank)
+	if a < 0 || b < 0 {
+		return ""
+	}
+	if a > b {
+		a, b = b, a
+	}
+	switch {
+	case a == b:
+		return GridHand(a, a)
+	case cards[0].Suit == cards[1].Suit:
+		return GridHand(a, b)
+	default:
+		return GridHand(b, a)
+	}
+}
+
+// cardRank converts a rank character to the poker rank
+func cardRank(c byte) poker.Rank {
+	card, err := poker.ParseCard(string(c) + "s")
+	if err != nil {
+		return poker.RankNone
+	}
+	return card.Rank
+}
+
+// rankIndex returns the grid row of a poker rank, -1 for jokers
+func rankIndex(rank poker.Rank) int {
+	code := poker.NewCard(poker.SuitSpade, rank).Code()
+	return strings.IndexByte(Ranks, code[0])
+}
@@ -0,0 +1,64 @@
+package ranges
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ExportFormat is a text notation study tools import ranges in
+type ExportFormat string
+
+const (
+	// FormatWeighted lists hands with a ":weight" suffix below 1, e.g.
+	// "AA,AKs:0.5", as PioSolver, GTO Wizard and Hand2Note read them
+	FormatWeighted ExportFormat = "weighted"
+	// FormatBracket wraps hands below full weight in percentage brackets,
+	// e.g. "AA,[50]AKs,AQs[/50]", as GTO+ and Flopzilla read them
+	FormatBracket ExportFormat = "bracket"
+)
+
+// ExportFormats lists the notations Export writes
+func ExportFormats() []ExportFormat {
+	return []ExportFormat{FormatWeighted, FormatBracket}
+}
+
+// Export writes the range in a study tool's notation, hands in grid order
+func (r *Range) Export(format ExportFormat) (string, error) {
+	switch format {
+	case FormatWeighted:
+		return r.String(), nil
+	case FormatBracket:
+		return r.bracketed(), nil
+	}
+	return "", fmt.Errorf("unknown range format %q", format)
+}
+
+// bracketed groups neighbouring hands of the same weight in one bracket
+func (r *Range) bracketed() string {
+	parts := []string{}
+	open, group := "", []string{}
+	flush := func() {
+		if len(group) > 0 {
+			parts = append(parts, fmt.Sprintf("[%s]%s[/%s]", open, strings.Join(group, ","), open))
+		}
+		group = nil
+	}
+	for _, hand := range r.Hands() {
+		weight := r.weights[hand]
+		if weight >= 1 {
+			flush()
+			parts = append(parts, hand)
+			continue
+		}
+		percent := strconv.FormatFloat(math.Round(weight*1000)/10, 'f', -1, 64)
+		if percent != open {
+			flush()
+			open = percent
+		}
+		group = append(group, hand)
+	}
+	flush()
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,104 @@
+package ranges
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NamedPrefix marks a named range in range notation, e.g. "@BTN open"
+const NamedPrefix = "@"
+
+// Library holds the named ranges range notation may refer to with
+// NamedPrefix, such as the ones a player saved. Pass it to Parse with
+// WithLibrary, and the equity calculator, chart files and scenarios then
+// take "@BTN open, 22+" or "@BTN open:0.5". A nil library has no ranges.
+type Library struct {
+	named map[string]*Range // By lowercase name
+}
+
+// NewLibrary creates a library holding copies of the named ranges
+func NewLibrary(named map[string]*Range) *Library {
+	library := &Library{named: make(map[string]*Range, len(named))}
+	for name, r := range named {
+		library.named[strings.ToLower(name)] = r.Clone()
+	}
+	return library
+}
+
+// Named returns a copy of the named range, matching the name in any case
+func (l *Library) Named(name string) (*Range, bool) {
+	if l == nil {
+		return nil, false
+	}
+	r, ok := l.named[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, false
+	}
+	return r.Clone(), true
+}
+
+// Names lists the named ranges, sorted
+func (l *Library) Names() []string {
+	if l == nil {
+		return nil
+	}
+	names := make([]string, 0, len(l.named))
+	for name := range l.named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseOption changes how Parse reads range notation
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	library *Library
+}
+
+// WithLibrary lets the notation merge in the library's ranges as "@name"
+func WithLibrary(library *Library) ParseOption {
+	return func(o *parseOptions) { o.library = library }
+}
+
+// CheckName reports whether a name can be referred to in range notation
+func CheckName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("a range needs a name")
+	case strings.ContainsAny(name, ",:"+NamedPrefix):
+		return fmt.Errorf("range name %q cannot hold a comma, colon or %s", name, NamedPrefix)
+	}
+	return nil
+}
+
+// Clone returns a copy of the range
+func (r *Range) Clone() *Range {
+	clone := NewRange()
+	for hand, weight := range r.weights {
+		clone.weights[hand] = weight
+	}
+	return clone
+}
+
+// Merge adds every hand of other to the range at its weight scaled by
+// weight, keeping the higher weight of hands in both
+func (r *Range) Merge(other *Range, weight float64) {
+	for hand, w := range other.weights {
+		if w*weight > r.weights[hand] {
+			r.Set(hand, w*weight)
+		}
+	}
+}
+
+// parseNamed merges the named range of a "@name" token into r
+func (r *Range) parseNamed(token string, weight float64, library *Library) error {
+	named, ok := library.Named(strings.TrimPrefix(token, NamedPrefix))
+	if !ok {
+		return fmt.Errorf("unknown range %q", token)
+	}
+	r.Merge(named, weight)
+	return nil
+}
@@ -0,0 +1,255 @@
+package ranges
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Ranks lists the rank characters from highest to lowest, which is also the
+// row and column order of the 13x13 grid
+const Ranks = "AKQJT98765432"
+
+// Range assigns a weight between 0 and 1 to each of the 169 starting hand
+// classes, e.g. "AA", "AKs" or "T9o"
+type Range struct {
+	weights map[string]float64
+}
+
+// NewRange creates an empty range
+func NewRange() *Range {
+	return &Range{weights: map[string]float64{}}
+}
+
+// Parse reads a range in the usual notation: comma separated hands such as
+// "AA", "AKs", "AKo" or "AK" (both), plus ranges such as "TT+", "A2s+",
+// "22-55" or "K9o-KJo". A ":weight" suffix sets a weight other than 1.
+// "@name" merges in a named range from the library given WithLibrary, its
+// weights scaled by any weight suffix.
+func Parse(s string, opts ...ParseOption) (*Range, error) {
+	var o parseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	r := NewRange()
+	for _, token := range strings.Split(s, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		weight := 1.0
+		if i := strings.IndexByte(token, ':'); i >= 0 {
+			w, err := strconv.ParseFloat(token[i+1:], 64)
+			if err != nil || w < 0 || w > 1 {
+				return nil, fmt.Errorf("invalid weight in %q", token)
+			}
+			token, weight = token[:i], w
+		}
+		if strings.HasPrefix(token, NamedPrefix) {
+			if err := r.parseNamed(token, weight, o.library); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		hands, err := expand(token)
+		if err != nil {
+			return nil, err
+		}
+		for _, hand := range hands {
+			r.Set(hand, weight)
+		}
+	}
+	return r, nil
+}
+
+// Set changes the weight of a hand class, removing it at weight 0
+func (r *Range) Set(hand string, weight float64) {
+	if weight <= 0 {
+		delete(r.weights, hand)
+		return
+	}
+	r.weights[hand] = min(weight, 1)
+}
+
+// Weight returns the weight of a hand class, 0 when it is not in the range
+func (r *Range) Weight(hand string) float64 {
+	return r.weights[hand]
+}
+
+// Hands returns the hand classes in the range, in grid order
+func (r *Range) Hands() []string {
+	hands := make([]string, 0, len(r.weights))
+	for hand := range r.weights {
+		hands = append(hands, hand)
+	}
+	sort.Slice(hands, func(i, j int) bool {
+		ri, ci := Position(hands[i])
+		rj, cj := Position(hands[j])
+		return ri < rj || (ri == rj && ci < cj)
+	})
+	return hands
+}
+
+// Combos returns the weighted number of two-card combinations in the range
+func (r *Range) Combos() float64 {
+	total := 0.0
+	for hand, weight := range r.weights {
+		total += float64(ComboCount(hand)) * weight
+	}
+	return total
+}
+
+// Fraction returns the share of all 1326 starting hands the range holds
+func (r *Range) Fraction() float64 {
+	return r.Combos() / 1326
+}
+
+// String returns the range as a comma separated list of hand classes
+func (r *Range) String() string {
+	parts := []string{}
+	for _, hand := range r.Hands() {
+		if weight := r.weights[hand]; weight < 1 {
+			parts = append(parts, fmt.Sprintf("%s:%g", hand, weight))
+		} else {
+			parts = append(parts, hand)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// GridHand returns the hand class shown at a grid cell. Pairs run down the
+// diagonal, suited hands sit above it and offsuit hands below.
+func GridHand(row, col int) string {
+	switch {
+	case row == col:
+		return string([]byte{Ranks[row], Ranks[col]})
+	case col > row:
+		return string([]byte{Ranks[row], Ranks[col], 's'})
+	default:
+		return string([]byte{Ranks[col], Ranks[row], 'o'})
+	}
+}
+
+// Position returns the grid cell of a hand class, or -1, -1 if it is not one
+func Position(hand string) (int, int) {
+	if len(hand) < 2 {
+		return -1, -1
+	}
+	high, low := strings.IndexByte(Ranks, hand[0]), strings.IndexByte(Ranks, hand[1])
+	if high < 0 || low < 0 {
+		return -1, -1
+	}
+	switch {
+	case len(hand) == 2 && high == low:
+		return high, low
+	case len(hand) == 3 && high < low && hand[2] == 's':
+		return high, low
+	case len(hand) == 3 && high < low && hand[2] == 'o':
+		return low, high
+	}
+	return -1, -1
+}
+
+// ComboCount returns how many card combinations a hand class stands for
+func ComboCount(hand string) int {
+	switch {
+	case len(hand) == 2:
+		return 6
+	case strings.HasSuffix(hand, "s"):
+		return 4
+	default:
+		return 12
+	}
+}
+
+// expand turns one token of range notation into hand classes
+func expand(token string) ([]string, error) {
+	if from, to, ok := strings.Cut(token, "-"); ok {
+		return expandSpan(token, from, to)
+	}
+	plus := strings.HasSuffix(token, "+")
+	base := strings.TrimSuffix(token, "+")
+	hands, err := classes(base)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hand %q", token)
+	}
+	if !plus {
+		return hands, nil
+	}
+
+	// Pairs go up to aces, other hands raise the kicker up to just below the top card
+	result := []string{}
+	for _, hand := range hands {
+		high, low := strings.IndexByte(Ranks, hand[0]), strings.IndexByte(Ranks, hand[1])
+		if high == low {
+			for i := high; i >= 0; i-- {
+				result = append(result, GridHand(i, i))
+			}
+			continue
+		}
+		for i := low; i > high; i-- {
+			result = append(result, string(Ranks[high])+string(Ranks[i])+hand[2:])
+		}
+	}
+	return result, nil
+}
+
+// expandSpan expands ranges such as "22-55" or "K9o-KJo"
+func expandSpan(token, from, to string) ([]string, error) {
+	start, err := classes(from)
+	if err != nil || len(start) != 1 {
+		return nil, fmt.Errorf("invalid range %q", token)
+	}
+	end, err := classes(to)
+	if err != nil || len(end) != 1 {
+		return nil, fmt.Errorf("invalid range %q", token)
+	}
+	a, b := start[0], end[0]
+	aHigh, aLow := strings.IndexByte(Ranks, a[0]), strings.IndexByte(Ranks, a[1])
+	bHigh, bLow := strings.IndexByte(Ranks, b[0]), strings.IndexByte(Ranks, b[1])
+
+	result := []string{}
+	switch {
+	case aHigh == aLow && bHigh == bLow:
+		for i := max(aHigh, bHigh); i >= min(aHigh, bHigh); i-- {
+			result = append(result, GridHand(i, i))
+		}
+	case aHigh == bHigh && aHigh != aLow && bHigh != bLow && a[2:] == b[2:]:
+		for i := max(aLow, bLow); i >= min(aLow, bLow); i-- {
+			result = append(result, string(Ranks[aHigh])+string(Ranks[i])+a[2:])
+		}
+	default:
+		return nil, fmt.Errorf("invalid range %q", token)
+	}
+	return result, nil
+}
+
+// classes parses "AA", "AKs", "AKo" or "AK", which stands for both, in
+// either case and with tens written "T" or "10"
+func classes(s string) ([]string, error) {
+	s = strings.ReplaceAll(s, "10", "T")
+	if len(s) < 2 || len(s) > 3 {
+		return nil, fmt.Errorf("invalid hand %q", s)
+	}
+	s = strings.ToUpper(s[:2]) + strings.ToLower(s[2:])
+	high, low := strings.IndexByte(Ranks, s[0]), strings.IndexByte(Ranks, s[1])
+	if high < 0 || low < 0 {
+		return nil, fmt.Errorf("invalid hand %q", s)
+	}
+	if high > low {
+		high, low = low, high
+		s = string([]byte{Ranks[high], Ranks[low]}) + s[2:]
+	}
+	switch {
+	case high == low && len(s) == 2:
+		return []string{s}, nil
+	case high == low:
+		return nil, fmt.Errorf("pairs cannot be suited or offsuit: %q", s)
+	case len(s) == 2:
+		return []string{s + "s", s + "o"}, nil
+	case s[2] == 's' || s[2] == 'o':
+		return []string{s}, nil
+	}
+	return nil, fmt.Errorf("invalid hand %q", s)
+}
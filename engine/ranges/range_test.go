@@ -0,0 +1,160 @@
+package ranges
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestParseNotation(t *testing.T) {
+	testCases := map[string]struct {
+		hands  []string
+		combos float64
+	}{
+		"AA":          {[]string{"AA"}, 6},
+		"ak":          {[]string{"AKs", "AKo"}, 16},
+		"TT+":         {[]string{"AA", "KK", "QQ", "JJ", "TT"}, 30},
+		"A9s+":        {[]string{"AKs", "AQs", "AJs", "ATs", "A9s"}, 20},
+		"22-44":       {[]string{"44", "33", "22"}, 18},
+		"K9o-KJo":     {[]string{"KJo", "KTo", "K9o"}, 36},
+		"QQ, AKs:0.5": {[]string{"QQ", "AKs"}, 8},
+		"KA":          {[]string{"AKs", "AKo"}, 16},
+		"A10s, 1010":  {[]string{"ATs", "TT"}, 10},
+	}
+	for input, tc := range testCases {
+		r, err := Parse(input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", input, err)
+			continue
+		}
+		if len(r.Hands()) != len(tc.hands) {
+			t.Errorf("%s: expected %v, got %v", input, tc.hands, r.Hands())
+		}
+		for _, hand := range tc.hands {
+			if r.Weight(hand) == 0 {
+				t.Errorf("%s: expected %s in the range", input, hand)
+			}
+		}
+		if math.Abs(r.Combos()-tc.combos) > 1e-9 {
+			t.Errorf("%s: expected %.1f combos, got %.1f", input, tc.combos, r.Combos())
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{"AX", "AAs", "AK:2", "22-AKs", "A2s-K2s", "AKQ"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
+
+func TestGridLayout(t *testing.T) {
+	if GridHand(0, 0) != "AA" || GridHand(0, 1) != "AKs" || GridHand(1, 0) != "AKo" || GridHand(12, 12) != "22" {
+		t.Error("Unexpected grid corners")
+	}
+	for row := 0; row < 13; row++ {
+		for col := 0; col < 13; col++ {
+			if r, c := Position(GridHand(row, col)); r != row || c != col {
+				t.Errorf("Cell %d,%d does not round trip: %d,%d", row, col, r, c)
+			}
+		}
+	}
+
+	all := NewRange()
+	for row := 0; row < 13; row++ {
+		for col := 0; col < 13; col++ {
+			all.Set(GridHand(row, col), 1)
+		}
+	}
+	if all.Combos() != 1326 || all.Fraction() != 1 {
+		t.Errorf("Expected the full grid to hold 1326 combos, got %.0f", all.Combos())
+	}
+}
+
+func TestRangeString(t *testing.T) {
+	r, _ := Parse("AKo, QQ+, 72o:0.25")
+	if s := r.String(); s != "AA,AKo,KK,QQ,72o:0.25" {
+		t.Errorf("Unexpected range string %q", s)
+	}
+}
+
+func TestExpandCombos(t *testing.T) {
+	r, _ := Parse("AA, AKs, 72o")
+	dead, _ := poker.ParseCards("As")
+	counts := map[string]int{}
+	for _, combo := range r.Expand(dead) {
+		counts[HandClass(combo.Cards)]++
+		if combo.Cards[0].Code() == "As" || combo.Cards[1].Code() == "As" {
+			t.Errorf("Expected the dead ace of spades to be skipped, got %s", combo.Cards.Codes())
+		}
+	}
+	// The dead ace removes half the aces and one suited ace-king
+	if counts["AA"] != 3 || counts["AKs"] != 3 || counts["72o"] != 12 {
+		t.Errorf("Unexpected combo counts %v", counts)
+	}
+}
+
+func TestHandClass(t *testing.T) {
+	testCases := map[string]string{"AsKs": "AKs", "KdAh": "AKo", "7c7d": "77", "2h7h": "72s", "TcJd": "JTo"}
+	for cards, want := range testCases {
+		parsed, _ := poker.ParseCards(cards)
+		if got := HandClass(parsed); got != want {
+			t.Errorf("%s: expected %s, got %s", cards, want, got)
+		}
+	}
+}
+
+func TestExportFormats(t *testing.T) {
+	r, err := Parse("AA,KK,AKs:0.5,AQs:0.5,KQs:0.25,AKo:0.333")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[ExportFormat]string{
+		FormatWeighted: "AA,AKs:0.5,AQs:0.5,AKo:0.333,KK,KQs:0.25",
+		FormatBracket:  "AA,[50]AKs,AQs[/50],[33.3]AKo[/33.3],KK,[25]KQs[/25]",
+	}
+	for _, format := range ExportFormats() {
+		got, err := r.Export(format)
+		if err != nil || got != expected[format] {
+			t.Errorf("%s: expected %q, got %q (%v)", format, expected[format], got, err)
+		}
+	}
+	if _, err := r.Export("flopzilla"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestNamedRangesMerge(t *testing.T) {
+	open, err := Parse("TT+, AKs, AQs:0.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	library := NewLibrary(map[string]*Range{"BTN open": open})
+	open.Set("AA", 0) // The library keeps its own copy
+
+	r, err := Parse("@btn open:0.5, AKs, 22", WithLibrary(library))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	for hand, want := range map[string]float64{"AA": 0.5, "TT": 0.5, "AKs": 1, "AQs": 0.25, "22": 1, "99": 0} {
+		if got := r.Weight(hand); got != want {
+			t.Errorf("%s: expected weight %g, got %g", hand, want, got)
+		}
+	}
+	if _, err := Parse("@CO open", WithLibrary(library)); err == nil {
+		t.Error("Expected an unknown named range to be refused")
+	}
+	if _, err := Parse("@BTN open"); err == nil {
+		t.Error("Expected named ranges to be refused without a library")
+	}
+	if names := library.Names(); len(names) != 1 || names[0] != "btn open" {
+		t.Errorf("Expected the one named range, got %v", names)
+	}
+	for _, name := range []string{"", "BTN, CO", "3bet:50", "@BTN"} {
+		if CheckName(name) == nil {
+			t.Errorf("Expected the name %q to be refused", name)
+		}
+	}
+}
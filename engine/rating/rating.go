@@ -0,0 +1,118 @@
+// Package rating keeps Elo ratings of bot profiles and players from the
+// matches they play against each other, so a profile's strength is a
+// number rather than a label. A match between several players is rated as
+// a head-to-head game between every pair of them: whoever finished ahead,
+// by chips won or by finishing place, beat the other.
+package rating
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultRating is the rating of a player before their first match
+const DefaultRating = 1500.0
+
+// DefaultK is how far one match moves a rating at most, spread over the
+// opponents of a multi-way match
+const DefaultK = 32.0
+
+// Rating is a player's Elo rating and the matches it is based on
+type Rating struct {
+	Rating float64 `json:"rating"`
+	Games  int     `json:"games"`
+}
+
+// Result is how one player did in a match
+type Result struct {
+	Name  string  // Bot profile or player name
+	Score float64 // Higher beats lower: chips won, or minus the finishing place
+}
+
+// Standing is a player's place on the leaderboard
+type Standing struct {
+	Name string
+	Rating
+}
+
+// Ratings are the ratings of every player rated so far, by name. The zero
+// value is not usable, create them with make or New.
+type Ratings map[string]Rating
+
+// New returns empty ratings
+func New() Ratings {
+	return Ratings{}
+}
+
+// Get returns a player's rating, DefaultRating with no games when they
+// were never rated
+func (r Ratings) Get(name string) Rating {
+	if rating, ok := r[name]; ok {
+		return rating
+	}
+	return Rating{Rating: DefaultRating}
+}
+
+// Expected returns the score a player rated a expects against one rated b,
+// from 0 for a certain loss to 1 for a certain win
+func Expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// Record rates a match, moving every player's rating by at most k. Each
+// result is a seat, so a profile playing several seats is rated on all of
+// them but never against itself. A match with fewer than two different
+// players changes nothing.
+func (r Ratings) Record(results []Result, k float64) {
+	names := map[string]bool{}
+	for _, result := range results {
+		names[result.Name] = true
+	}
+	if len(names) < 2 {
+		return
+	}
+
+	// Every pair is rated from the ratings before the match, so the order
+	// of the results does not matter
+	before := map[string]float64{}
+	for name := range names {
+		before[name] = r.Get(name).Rating
+	}
+	share := k / float64(len(results)-1)
+	change := map[string]float64{}
+	for i, a := range results {
+		for j, b := range results {
+			if i == j || a.Name == b.Name {
+				continue
+			}
+			score := 0.5
+			if a.Score > b.Score {
+				score = 1
+			} else if a.Score < b.Score {
+				score = 0
+			}
+			change[a.Name] += share * (score - Expected(before[a.Name], before[b.Name]))
+		}
+	}
+	for name := range names {
+		rating := r.Get(name)
+		rating.Rating += change[name]
+		rating.Games++
+		r[name] = rating
+	}
+}
+
+// Leaderboard returns the players best rated first, ties by name
+func (r Ratings) Leaderboard() []Standing {
+	standings := make([]Standing, 0, len(r))
+	for name, rating := range r {
+		standings = append(standings, Standing{Name: name, Rating: rating})
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].Rating.Rating != standings[j].Rating.Rating {
+			return standings[i].Rating.Rating > standings[j].Rating.Rating
+		}
+		return standings[i].Name < standings[j].Name
+	})
+	return standings
+}
@@ -0,0 +1,87 @@
+package rating
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestExpected(t *testing.T) {
+	if got := Expected(1500, 1500); got != 0.5 {
+		t.Errorf("Expected even players to expect 0.5, got %f", got)
+	}
+	if got := Expected(1900, 1500); math.Abs(got-0.909) > 0.001 {
+		t.Errorf("Expected a 400 point favourite to expect 0.909, got %f", got)
+	}
+	if got := Expected(1500, 1900) + Expected(1900, 1500); math.Abs(got-1) > 1e-9 {
+		t.Errorf("Expected both sides' expectations to add up to 1, got %f", got)
+	}
+}
+
+func TestRecordHeadsUp(t *testing.T) {
+	ratings := New()
+	ratings.Record([]Result{{Name: "tight", Score: 200}, {Name: "maniac", Score: -200}}, DefaultK)
+
+	tight, maniac := ratings.Get("tight"), ratings.Get("maniac")
+	if tight.Rating != 1516 || maniac.Rating != 1484 {
+		t.Errorf("Expected an even match to move each rating by 16, got %+v and %+v", tight, maniac)
+	}
+	if tight.Games != 1 || maniac.Games != 1 {
+		t.Errorf("Expected one game each, got %+v and %+v", tight, maniac)
+	}
+	if unrated := ratings.Get("nit"); unrated.Rating != DefaultRating || unrated.Games != 0 {
+		t.Errorf("Expected an unrated player at the default, got %+v", unrated)
+	}
+
+	// Beating a weaker player earns less than beating an equal one
+	ratings.Record([]Result{{Name: "tight", Score: 1}, {Name: "maniac", Score: 0}}, DefaultK)
+	if gain := ratings.Get("tight").Rating - tight.Rating; gain <= 0 || gain >= 16 {
+		t.Errorf("Expected the favourite to gain less than 16, gained %f", gain)
+	}
+}
+
+func TestRecordMultiway(t *testing.T) {
+	ratings := New()
+	// Places as negative scores: reader wins, tight second, random last
+	ratings.Record([]Result{{Name: "random", Score: -3}, {Name: "reader", Score: -1}, {Name: "tight", Score: -2}}, DefaultK)
+	board := ratings.Leaderboard()
+	if len(board) != 3 || board[0].Name != "reader" || board[1].Name != "tight" || board[2].Name != "random" {
+		t.Fatalf("Expected reader, tight, random, got %+v", board)
+	}
+	if board[1].Rating.Rating != DefaultRating {
+		t.Errorf("Expected the middle finisher to stay put, got %f", board[1].Rating.Rating)
+	}
+	total := 0.0
+	for _, standing := range board {
+		total += standing.Rating.Rating
+	}
+	if math.Abs(total-3*DefaultRating) > 1e-9 {
+		t.Errorf("Expected rating points to be conserved, got a total of %f", total)
+	}
+
+	// Seats of the same profile are not rated against each other
+	ratings.Record([]Result{{Name: "tight", Score: 10}, {Name: "tight", Score: -10}}, DefaultK)
+	if got := ratings.Get("tight"); got.Games != 1 {
+		t.Errorf("Expected a match against itself not to count, got %+v", got)
+	}
+	ratings.Record([]Result{{Name: "tight", Score: 0}, {Name: "tight", Score: 0}, {Name: "nit", Score: 10}}, DefaultK)
+	if got := ratings.Get("tight"); got.Games != 2 || got.Rating >= DefaultRating {
+		t.Errorf("Expected both tight seats rated as one loss to the nit, got %+v", got)
+	}
+}
+
+func TestRatingsRoundTripJSON(t *testing.T) {
+	ratings := New()
+	ratings.Record([]Result{{Name: "human", Score: 50}, {Name: "nit", Score: -50}}, DefaultK)
+	encoded, err := json.Marshal(ratings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := New()
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Get("human") != ratings.Get("human") || decoded.Get("nit") != ratings.Get("nit") {
+		t.Errorf("Expected the ratings back, got %+v from %s", decoded, encoded)
+	}
+}
@@ -0,0 +1,194 @@
+// Package reading estimates an opponent's range from their line in the
+// current hand. Every holding starts equally likely; preflop actions weigh
+// it by how often the position-aware charts play it that way, and later
+// streets by how strong it is on the board against the size of the bet.
+package reading
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ljbink/ai-poker/engine/charts"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+// Floor is the least one action keeps of a holding's weight, so a hand the
+// model does not expect in a line is made unlikely rather than ruled out
+const Floor = 0.05
+
+// boardSize is how many community cards each street is played on
+var boardSize = map[holdem.GamePhase]int{holdem.PhaseFlop: 3, holdem.PhaseTurn: 4, holdem.PhaseRiver: 5}
+
+// Estimate returns the player's range in the current Hold'em hand, with
+// the likeliest hand class at weight 1. Dead cards, such as the caller's
+// own hole cards, and the board are left out of it.
+func Estimate(game *holdem.Game, playerID int, dead poker.Cards) *ranges.Range {
+	board := game.GetCommunityCards()
+	known := append(append(poker.Cards{}, dead...), board...)
+	full := ranges.NewRange()
+	for row := range ranges.Ranks {
+		for col := range ranges.Ranks {
+			full.Set(ranges.GridHand(row, col), 1)
+		}
+	}
+	combos := full.Expand(known)
+
+	history := game.GetUserActions()
+	pot := 0
+	for _, action := range history.Preflop {
+		pot += action.Amount
+	}
+	weighPreflop(game, playerID, history, combos)
+
+	evaluator := holdem.NewHandEvaluator()
+	for phase := holdem.PhaseFlop; phase <= holdem.PhaseRiver; phase++ {
+		actions := history.Voluntary(phase)
+		if len(actions) == 0 || len(board) < boardSize[phase] {
+			break
+		}
+		strength := strengths(evaluator, combos, board[:boardSize[phase]])
+		for _, action := range actions {
+			if action.PlayerID == playerID {
+				for i := range combos {
+					combos[i].Weight *= Floor + (1-Floor)*postflop(action, pot, strength[i])
+				}
+			}
+			pot += action.Amount
+		}
+	}
+	return collect(combos)
+}
+
+// weighPreflop weighs each combo by how often the charts for the player's
+// position and stack play its class the way the player did
+func weighPreflop(game *holdem.Game, playerID int, history holdem.UserActions, combos []ranges.Combo) {
+	book, err := charts.Default()
+	position, ok := charts.PositionOf(game, playerID)
+	player, perr := game.GetPlayerByID(playerID)
+	if err != nil || !ok || perr != nil || game.GetBigBlind() <= 0 {
+		return
+	}
+	set := book.ForStack((player.GetChips() + player.GetTotalBet()) / game.GetBigBlind())
+	if set == nil {
+		return
+	}
+
+	raises := 0
+	for _, action := range history.Voluntary(holdem.PhasePreflop) {
+		if action.PlayerID == playerID && action.Type != holdem.ActionFold && action.Type != holdem.ActionCheck {
+			facing := charts.Unopened
+			switch {
+			case raises == 1:
+				facing = charts.FacingOpen
+			case raises > 1:
+				facing = charts.FacingThreeBet
+			}
+			for i := range combos {
+				decision, err := set.Lookup(position, ranges.HandClass(combos[i].Cards), facing)
+				if err != nil {
+					continue
+				}
+				played := decision.Call
+				switch {
+				case action.Raised:
+					played = decision.Raise
+				case facing == charts.Unopened:
+					// The charts never limp, so a limp is any hand they play
+					played = decision.Raise + decision.Call
+				}
+				combos[i].Weight *= Floor + (1-Floor)*min(played, 1)
+			}
+		}
+		if action.Raised {
+			raises++
+		}
+	}
+}
+
+// strengths ranks every combo's made hand on the board, as the share of
+// the other combos it beats with ties counted half
+func strengths(evaluator holdem.IHandEvaluator, combos []ranges.Combo, board poker.Cards) []float64 {
+	type made struct {
+		index  int
+		result *holdem.HandResult
+	}
+	hands := make([]made, 0, len(combos))
+	blocked := map[poker.Card]bool{}
+	for _, card := range board {
+		blocked[*card] = true
+	}
+	for i, combo := range combos {
+		if !blocked[*combo.Cards[0]] && !blocked[*combo.Cards[1]] {
+			hands = append(hands, made{i, evaluator.EvaluateHand(combo.Cards, board)})
+		}
+	}
+	sort.Slice(hands, func(i, j int) bool {
+		return evaluator.CompareHands(hands[i].result, hands[j].result) < 0
+	})
+
+	strength := make([]float64, len(combos))
+	for start := 0; start < len(hands); {
+		end := start + 1
+		for end < len(hands) && evaluator.CompareHands(hands[start].result, hands[end].result) == 0 {
+			end++
+		}
+		share := (float64(start) + float64(end-start-1)/2) / float64(max(len(hands)-1, 1))
+		for _, hand := range hands[start:end] {
+			strength[hand.index] = share
+		}
+		start = end
+	}
+	return strength
+}
+
+// postflop returns how likely a holding of the given strength is to take
+// an action into a pot. Bets and raises come from strong hands, more so
+// the bigger they are; calls from middling hands and up; checks mostly
+// from hands not strong enough to bet.
+func postflop(action holdem.StreetAction, pot int, strength float64) float64 {
+	size := 0.0
+	if pot > 0 {
+		size = min(float64(action.Amount)/float64(pot), 2)
+	}
+	switch {
+	case action.Raised && action.Faced > 0:
+		return math.Pow(strength, 2+size)
+	case action.Raised:
+		return math.Pow(strength, 1+size)
+	case action.Type == holdem.ActionCall || action.Type == holdem.ActionAllIn:
+		faced := 0.0
+		if pot > 0 {
+			faced = min(float64(action.Faced)/float64(pot), 2)
+		}
+		return math.Pow(strength, 0.5+faced/2)
+	case action.Type == holdem.ActionCheck:
+		return 1 - 0.6*math.Pow(strength, 4)
+	}
+	return 1
+}
+
+// collect averages the combo weights of each hand class, scaled so the
+// likeliest class has weight 1
+func collect(combos []ranges.Combo) *ranges.Range {
+	sums, counts := map[string]float64{}, map[string]int{}
+	for _, combo := range combos {
+		hand := ranges.HandClass(combo.Cards)
+		sums[hand] += combo.Weight
+		counts[hand]++
+	}
+	top := 0.0
+	for hand, sum := range sums {
+		sums[hand] = sum / float64(counts[hand])
+		top = max(top, sums[hand])
+	}
+	r := ranges.NewRange()
+	if top == 0 {
+		return r
+	}
+	for hand, weight := range sums {
+		r.Set(hand, weight/top)
+	}
+	return r
+}
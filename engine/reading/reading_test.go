@@ -0,0 +1,97 @@
+package reading
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// newHand deals a three-handed hand of 100 big blinds with the stacked
+// cards on top. Player 1 has the button and acts first.
+func newHand(t *testing.T, cards string) *holdem.Game {
+	t.Helper()
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 7})
+	for i := 0; i < 3; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	stacked, err := poker.ParseCards(cards)
+	if err != nil {
+		t.Fatalf("ParseCards failed: %v", err)
+	}
+	if err := game.StackDeck(stacked); err != nil {
+		t.Fatalf("StackDeck failed: %v", err)
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	return game
+}
+
+func act(t *testing.T, game *holdem.Game, action holdem.Action) {
+	t.Helper()
+	if err := game.TakeAction(action); err != nil {
+		t.Fatalf("%+v failed: %v", action, err)
+	}
+}
+
+// Hole cards for players 1 to 3, a burn card and the flop A-K-7
+const flopAK7 = "QhJh2c QdJd3c 9s Ah Kd 7c"
+
+func TestEstimatePreflopFollowsTheCharts(t *testing.T) {
+	game := newHand(t, flopAK7)
+	act(t, game, holdem.NewRaise(1, 25))
+
+	button := Estimate(game, 1, nil)
+	if button.Weight("AA") != 1 || button.Weight("72o") > 2*Floor {
+		t.Errorf("Expected a button open to hold aces and hardly ever 72o, got %.2f and %.2f", button.Weight("AA"), button.Weight("72o"))
+	}
+	if fraction := button.Fraction(); fraction < 0.3 || fraction > 0.7 {
+		t.Errorf("Expected a button opening range, got %.0f%% of hands", fraction*100)
+	}
+
+	act(t, game, holdem.NewRaise(2, 90))
+	threeBet := Estimate(game, 2, nil)
+	if threeBet.Fraction() >= button.Fraction()/2 || threeBet.Weight("KK") < 0.9 {
+		t.Errorf("Expected a much tighter 3-bet range with kings, got %.0f%% and KK at %.2f", threeBet.Fraction()*100, threeBet.Weight("KK"))
+	}
+}
+
+func TestEstimateNarrowsWithBetsAndChecks(t *testing.T) {
+	game := newHand(t, flopAK7)
+	act(t, game, holdem.NewRaise(1, 25))
+	act(t, game, holdem.Action{PlayerID: 2, Type: holdem.ActionFold})
+	act(t, game, holdem.Action{PlayerID: 3, Type: holdem.ActionCall, Amount: 15})
+	game.DealFlop()
+	preflop := Estimate(game, 1, nil)
+
+	act(t, game, holdem.Action{PlayerID: 3, Type: holdem.ActionCheck})
+	act(t, game, holdem.NewRaise(1, 55)) // About the pot
+	bet := Estimate(game, 1, nil)
+	if bet.Weight("AKo") <= bet.Weight("22") || bet.Fraction() >= preflop.Fraction() {
+		t.Errorf("Expected a pot-sized bet to favour AK over 22 and narrow the range, got %.2f and %.2f, %.0f%% from %.0f%%",
+			bet.Weight("AKo"), bet.Weight("22"), bet.Fraction()*100, preflop.Fraction()*100)
+	}
+	if caller := Estimate(game, 3, nil); caller.Weight("77") >= caller.Weight("QJo") {
+		t.Errorf("Expected checking to make a set less likely than a gutshot, got %.2f and %.2f", caller.Weight("77"), caller.Weight("QJo"))
+	}
+}
+
+func TestEstimateLeavesOutDeadCards(t *testing.T) {
+	game := newHand(t, flopAK7)
+	act(t, game, holdem.NewRaise(1, 25))
+	act(t, game, holdem.Action{PlayerID: 2, Type: holdem.ActionFold})
+	act(t, game, holdem.Action{PlayerID: 3, Type: holdem.ActionCall, Amount: 15})
+	game.DealFlop()
+
+	dead, _ := poker.ParseCards("AsAc")
+	r := Estimate(game, 1, dead)
+	if r.Weight("AA") != 0 {
+		t.Errorf("Expected no aces with three of them seen, got %.2f", r.Weight("AA"))
+	}
+	if r.Weight("77") == 0 {
+		t.Error("Expected sevens to stay in the range")
+	}
+}
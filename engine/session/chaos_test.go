@@ -0,0 +1,91 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+)
+
+// TestTableSurvivesNetworkFaults plays bots whose decisions are dropped,
+// delayed, submitted twice or replaced by disconnections, and checks that
+// every hand finishes without chips appearing or vanishing
+func TestTableSurvivesNetworkFaults(t *testing.T) {
+	// Delays outlast the clock, so bots read the table after it stopped
+	// waiting for them, which the race detector checks under -race
+	faults := holdem_ai.Faults{Drop: 0.05, Delay: 0.05, DelayBy: 150 * time.Millisecond, Duplicate: 0.1, Disconnect: 0.05}
+	for name, policy := range map[string]holdem.DisconnectPolicy{"fold": holdem.DisconnectFold, "all-in": holdem.DisconnectAllIn} {
+		t.Run(name, func(t *testing.T) {
+			game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 11, Disconnect: policy})
+			makers := []*holdem_ai.FaultyDecisionMaker{}
+			for id := 1; id <= 6; id++ {
+				if err := game.PlayerSit(holdem.NewPlayer(id, "", 1000), id-1); err != nil {
+					t.Fatalf("PlayerSit failed: %v", err)
+				}
+			}
+			s := New(game)
+			for id := 1; id <= 6; id++ {
+				bot := holdem_ai.NewBasicBotDecisionMaker(float64(id)/6, 0.1)
+				bot.SetThinkingTime(0, 0)
+				bot.SetSeed(int64(id))
+				maker := holdem_ai.NewFaultyDecisionMaker(bot, faults, int64(id))
+				makers = append(makers, maker)
+				s.SetDecisionMaker(id, maker)
+			}
+			s.SetDecisionClock(100*time.Millisecond, 0)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			for hand := 0; hand < 60 && len(game.GetAllPlayers()) >= 2; hand++ {
+				result, err := s.PlayHand(ctx)
+				if err != nil {
+					t.Fatalf("Hand %d: %v", hand+1, err)
+				}
+				net := result.Rake
+				for _, chips := range result.Net {
+					net += chips
+				}
+				if net != 0 {
+					t.Fatalf("Hand %d paid out %d more than was bet: %+v", result.HandNumber, net, result.Awards)
+				}
+				total := s.GetRake()
+				for _, player := range game.GetAllPlayers() {
+					if player.GetChips() < 0 {
+						t.Fatalf("Hand %d left player %d with %d chips", result.HandNumber, player.GetID(), player.GetChips())
+					}
+					total += player.GetChips()
+				}
+				s.RemoveBusted()
+				if total != 6000 {
+					t.Fatalf("Hand %d left %d chips in play, expected 6000", result.HandNumber, total)
+				}
+			}
+
+			// No decision is left hanging
+			done := make(chan struct{})
+			go func() {
+				s.WaitForDecisions()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Abandoned decisions never came in")
+			}
+
+			injected := map[string]int{}
+			for _, maker := range makers {
+				for kind, count := range maker.Injected() {
+					injected[kind] += count
+				}
+			}
+			for _, kind := range []string{"drop", "delay", "duplicate", "disconnect"} {
+				if injected[kind] == 0 {
+					t.Errorf("Expected some %s faults to be injected, got %v", kind, injected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package session
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// IHandRule is an optional table rule, e.g. a home-game side game, that the
+// session consults around every hand
+type IHandRule interface {
+	// StartHand may deal the hand itself, returning true if it did
+	StartHand(game *holdem.Game, button int) (bool, error)
+	// FinishHand runs after the pot is awarded and may move chips between players
+	FinishHand(game *holdem.Game, result *HandResult) error
+}
+
+// AddRule adds a table rule. Rules are consulted in the order they were added
+// and the first one to deal a hand wins.
+func (s *Session) AddRule(rule IHandRule) {
+	s.rules = append(s.rules, rule)
+}
+
+// startHand lets the table rules deal the hand, falling back to a regular one
+func (s *Session) startHand(button int) error {
+	for _, rule := range s.rules {
+		started, err := rule.StartHand(s.game, button)
+		if err != nil {
+			return err
+		}
+		if started {
+			return nil
+		}
+	}
+	return s.game.StartHand(button)
+}
+
+// finishHand runs the table rules over a finished hand
+func (s *Session) finishHand(result *HandResult) error {
+	for _, rule := range s.rules {
+		if err := rule.FinishHand(s.game, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BombPot makes every Nth hand a bomb pot: every player antes a fixed amount
+// and the hand starts on the flop
+type BombPot struct {
+	Every int // Hands between bomb pots
+	Ante  int // Chips every player puts in
+}
+
+// NewBombPot creates a bomb pot rule
+func NewBombPot(every, ante int) *BombPot {
+	return &BombPot{Every: every, Ante: ante}
+}
+
+// StartHand deals a bomb pot when the next hand number is a multiple of Every
+func (b *BombPot) StartHand(game *holdem.Game, button int) (bool, error) {
+	if b.Every <= 0 || (game.GetHandNumber()+1)%b.Every != 0 {
+		return false, nil
+	}
+	if err := game.StartBombPot(button, b.Ante); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// FinishHand does nothing, bomb pots are paid like any other pot
+func (b *BombPot) FinishHand(game *holdem.Game, result *HandResult) error {
+	return nil
+}
+
+// SevenDeuceBounty pays a player who wins the pot holding seven-deuce a
+// bounty from every other player dealt into the hand
+type SevenDeuceBounty struct {
+	Amount int
+	logger *slog.Logger
+}
+
+// NewSevenDeuceBounty creates a seven-deuce bounty rule
+func NewSevenDeuceBounty(amount int) *SevenDeuceBounty {
+	return &SevenDeuceBounty{Amount: amount, logger: holdem.NewDiscardLogger()}
+}
+
+// SetLogger injects the structured logger used to report bounties.
+// Passing nil restores the discard logger.
+func (b *SevenDeuceBounty) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = holdem.NewDiscardLogger()
+	}
+	b.logger = logger
+}
+
+// StartHand never deals, the bounty only changes how a hand ends
+func (b *SevenDeuceBounty) StartHand(game *holdem.Game, button int) (bool, error) {
+	return false, nil
+}
+
+// FinishHand collects the bounty when the sole winner of the main pot holds
+// a seven and a deuce. Players pay what they can from their remaining stack.
+func (b *SevenDeuceBounty) FinishHand(game *holdem.Game, result *HandResult) error {
+	if b.Amount <= 0 || len(result.Awards) == 0 || len(result.Awards[0].Winners) != 1 {
+		return nil
+	}
+	winner, err := game.GetPlayerByID(result.Awards[0].Winners[0])
+	if err != nil {
+		return fmt.Errorf("seven-deuce bounty: %w", err)
+	}
+	if !isSevenDeuce(winner.GetHandCards()) {
+		return nil
+	}
+
+	collected := 0
+	for _, player := range game.GetAllPlayers() {
+		if player == winner || len(player.GetHandCards()) == 0 {
+			continue
+		}
+		paid := min(b.Amount, player.GetChips())
+		// Bounties are settled outside the pot, straight between stacks
+		player.GrandChips(-paid)
+		collected += paid
+	}
+	winner.GrandChips(collected)
+	result.Bounties = append(result.Bounties, Bounty{PlayerID: winner.GetID(), Amount: collected})
+	b.logger.Info("seven-deuce bounty paid", slog.Int("player_id", winner.GetID()), slog.Int("amount", collected))
+	return nil
+}
+
+// isSevenDeuce reports whether two hole cards are a seven and a deuce
+func isSevenDeuce(cards []*poker.Card) bool {
+	if len(cards) != 2 {
+		return false
+	}
+	a, b := cards[0].Rank, cards[1].Rank
+	return (a == poker.RankSeven && b == poker.RankTwo) || (a == poker.RankTwo && b == poker.RankSeven)
+}
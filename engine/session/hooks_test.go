@@ -0,0 +1,82 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestBombPotEveryNthHand(t *testing.T) {
+	s := newTestSession(t, 500, 500, 500)
+	for id := 1; id <= 3; id++ {
+		s.SetDecisionMaker(id, callingStation{})
+	}
+	s.AddRule(NewBombPot(2, 20))
+
+	for hand := 1; hand <= 4; hand++ {
+		bombPot := false
+		s.SetObserver(func(event Event, game *holdem.Game) {
+			if event.Type == EventHandStarted {
+				bombPot = game.GetCurrentPhase() == holdem.PhaseFlop
+			}
+		})
+		result, err := s.PlayHand(context.Background())
+		if err != nil {
+			t.Fatalf("PlayHand failed: %v", err)
+		}
+		if bombPot != (hand%2 == 0) {
+			t.Errorf("Hand %d: expected bomb pot %t, got %t", hand, hand%2 == 0, bombPot)
+		}
+		net := 0
+		for _, chips := range result.Net {
+			net += chips
+		}
+		if net != 0 {
+			t.Errorf("Hand %d: chips not conserved (net %d)", hand, net)
+		}
+		if len(s.RemoveBusted()) > 0 {
+			break
+		}
+	}
+}
+
+func TestSevenDeuceBountyPaysTheWinner(t *testing.T) {
+	s := newTestSession(t, 500, 500, 8)
+	game := s.GetGame()
+	hands := map[int]string{1: "7h2c", 2: "AsAd", 3: "KsKd"}
+	for id, cards := range hands {
+		player, _ := game.GetPlayerByID(id)
+		parsed, _ := poker.ParseCards(cards)
+		for _, card := range parsed {
+			player.DealCard(card)
+		}
+	}
+
+	bounty := NewSevenDeuceBounty(10)
+	result := &HandResult{Awards: []holdem.PotAward{{Amount: 100, Winners: []int{1}}}}
+	if err := bounty.FinishHand(game, result); err != nil {
+		t.Fatalf("FinishHand failed: %v", err)
+	}
+	// The short stack pays what it has left
+	if chips := []int{chipsOf(game, 1), chipsOf(game, 2), chipsOf(game, 3)}; chips[0] != 518 || chips[1] != 490 || chips[2] != 0 {
+		t.Errorf("Expected stacks 518/490/0 after the bounty, got %v", chips)
+	}
+	if len(result.Bounties) != 1 || result.Bounties[0] != (Bounty{PlayerID: 1, Amount: 18}) {
+		t.Errorf("Expected an 18 chip bounty for player 1, got %v", result.Bounties)
+	}
+
+	// Aces winning, or a chopped pot, pays nothing
+	for _, winners := range [][]int{{2}, {1, 2}} {
+		result = &HandResult{Awards: []holdem.PotAward{{Amount: 100, Winners: winners}}}
+		if err := bounty.FinishHand(game, result); err != nil || len(result.Bounties) != 0 {
+			t.Errorf("Winners %v: expected no bounty, got %v (%v)", winners, result.Bounties, err)
+		}
+	}
+}
+
+func chipsOf(game *holdem.Game, playerID int) int {
+	player, _ := game.GetPlayerByID(playerID)
+	return player.GetChips()
+}
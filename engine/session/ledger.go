@@ -0,0 +1,282 @@
+package session
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/i18n"
+)
+
+// LedgerKind identifies a chip movement between a player and the bank
+type LedgerKind int
+
+const (
+	LedgerBuyIn   LedgerKind = iota // Chips brought to the table, re-buys included
+	LedgerTopUp                     // Chips added to a seated stack
+	LedgerCashOut                   // Chips taken away when standing up
+)
+
+func (k LedgerKind) String() string {
+	switch k {
+	case LedgerBuyIn:
+		return "buy-in"
+	case LedgerTopUp:
+		return "top-up"
+	case LedgerCashOut:
+		return "cash-out"
+	default:
+		return fmt.Sprintf("ledger kind %d", k)
+	}
+}
+
+// LedgerEntry is one chip movement of the session
+type LedgerEntry struct {
+	PlayerID int
+	Name     string
+	Kind     LedgerKind
+	Amount   int
+	At       time.Time
+}
+
+// Ledger returns every buy-in, top-up and cash-out of the session in the
+// order they happened. Players seated before New count as bought in for
+// the stack they had then.
+func (s *Session) Ledger() []LedgerEntry {
+	return append([]LedgerEntry{}, s.ledger...)
+}
+
+// record adds an entry to the ledger. Chips coming or going rule out
+// undoing the last hand.
+func (s *Session) record(player holdem.IPlayer, kind LedgerKind, amount int) {
+	s.last = nil
+	s.ledger = append(s.ledger, LedgerEntry{
+		PlayerID: player.GetID(),
+		Name:     player.GetName(),
+		Kind:     kind,
+		Amount:   amount,
+		At:       s.now(),
+	})
+}
+
+// PlayerWinnings is what a player had won or lost after every hand of the
+// session: their stack plus the chips they cashed out, less the chips they
+// bought in for
+type PlayerWinnings struct {
+	PlayerID int
+	Name     string
+	Hands    []int // After each hand, 0 for the hands before they sat down
+}
+
+// Final returns the player's winnings after the last hand
+func (w PlayerWinnings) Final() int {
+	if len(w.Hands) == 0 {
+		return 0
+	}
+	return w.Hands[len(w.Hands)-1]
+}
+
+// Winnings returns every player's winnings hand by hand, worked out from
+// the ledger and the stacks after each hand, in the order they sat down.
+// Players who left keep the result they left with.
+func (s *Session) Winnings() []PlayerWinnings {
+	players := []PlayerWinnings{}
+	index := map[int]int{}
+	for _, entry := range s.ledger {
+		if i, ok := index[entry.PlayerID]; ok {
+			players[i].Name = entry.Name
+			continue
+		}
+		index[entry.PlayerID] = len(players)
+		players = append(players, PlayerWinnings{PlayerID: entry.PlayerID, Name: entry.Name, Hands: make([]int, len(s.winnings))})
+	}
+	for hand, winnings := range s.winnings {
+		for id, chips := range winnings {
+			if i, ok := index[id]; ok {
+				players[i].Hands[hand] = chips
+			}
+		}
+	}
+	return players
+}
+
+// recordWinnings adds every player's winnings after the hand just finished
+func (s *Session) recordWinnings() {
+	winnings := map[int]int{}
+	for _, entry := range s.ledger {
+		switch entry.Kind {
+		case LedgerBuyIn, LedgerTopUp:
+			winnings[entry.PlayerID] -= entry.Amount
+		case LedgerCashOut:
+			winnings[entry.PlayerID] += entry.Amount
+		}
+	}
+	for _, player := range s.game.GetAllPlayers() {
+		winnings[player.GetID()] += player.GetChips()
+	}
+	s.winnings = append(s.winnings, winnings)
+}
+
+// PlayerBalance is a player's result over the session
+type PlayerBalance struct {
+	PlayerID  int
+	Name      string
+	BoughtIn  int // Buy-ins and top-ups
+	CashedOut int // Chips taken away when standing up
+	Stack     int // Chips still at the table
+	Net       int // CashedOut plus Stack minus BoughtIn
+}
+
+// Settlement is one payment squaring the session up
+type Settlement struct {
+	From, To         int // Player IDs
+	FromName, ToName string
+	Amount           int
+}
+
+func (s Settlement) String() string {
+	return fmt.Sprintf("%s pays %s %d", s.FromName, s.ToName, s.Amount)
+}
+
+// HomeGameReport is the bookkeeping of a home game: what every player put
+// in and took out, and who pays whom to settle up
+type HomeGameReport struct {
+	Balances    []PlayerBalance // Biggest winner first
+	Settlements []Settlement
+	Rake        int // Taken by the house, which leaves the nets short by as much
+}
+
+// HomeGameReport works out every player's result from the ledger and
+// their stacks at the table and the fewest payments settling them. Stacks
+// are taken as they are, so it is meant for between hands.
+func (s *Session) HomeGameReport() HomeGameReport {
+	balances := map[int]*PlayerBalance{}
+	order := []int{}
+	balance := func(id int, name string) *PlayerBalance {
+		b, ok := balances[id]
+		if !ok {
+			b = &PlayerBalance{PlayerID: id}
+			balances[id] = b
+			order = append(order, id)
+		}
+		b.Name = name
+		return b
+	}
+	for _, entry := range s.ledger {
+		b := balance(entry.PlayerID, entry.Name)
+		switch entry.Kind {
+		case LedgerBuyIn, LedgerTopUp:
+			b.BoughtIn += entry.Amount
+		case LedgerCashOut:
+			b.CashedOut += entry.Amount
+		}
+	}
+	for _, player := range s.game.GetAllPlayers() {
+		balance(player.GetID(), player.GetName()).Stack = player.GetChips()
+	}
+
+	report := HomeGameReport{Rake: s.rake}
+	for _, id := range order {
+		b := balances[id]
+		b.Net = b.CashedOut + b.Stack - b.BoughtIn
+		report.Balances = append(report.Balances, *b)
+	}
+	sort.SliceStable(report.Balances, func(i, j int) bool {
+		return report.Balances[i].Net > report.Balances[j].Net
+	})
+	report.Settlements = Settle(report.Balances)
+	return report
+}
+
+// Settle returns payments from the losers to the winners that square up
+// the balances, at most one fewer than the players involved. The biggest
+// loser pays the biggest winner first, as much as either is owed or owes.
+// When the nets do not add up to zero, e.g. after rake, what cannot be
+// matched is left out.
+func Settle(balances []PlayerBalance) []Settlement {
+	type side struct {
+		id     int
+		name   string
+		amount int
+	}
+	var winners, losers []side
+	for _, b := range balances {
+		switch {
+		case b.Net > 0:
+			winners = append(winners, side{b.PlayerID, b.Name, b.Net})
+		case b.Net < 0:
+			losers = append(losers, side{b.PlayerID, b.Name, -b.Net})
+		}
+	}
+	biggest := func(sides []side) {
+		sort.SliceStable(sides, func(i, j int) bool { return sides[i].amount > sides[j].amount })
+	}
+
+	settlements := []Settlement{}
+	for len(winners) > 0 && len(losers) > 0 {
+		biggest(winners)
+		biggest(losers)
+		winner, loser := &winners[0], &losers[0]
+		amount := min(winner.amount, loser.amount)
+		settlements = append(settlements, Settlement{
+			From: loser.id, To: winner.id, FromName: loser.name, ToName: winner.name, Amount: amount,
+		})
+		winner.amount -= amount
+		loser.amount -= amount
+		if winner.amount == 0 {
+			winners = winners[1:]
+		}
+		if loser.amount == 0 {
+			losers = losers[1:]
+		}
+	}
+	return settlements
+}
+
+// WriteText prints the report for the table, e.g.
+//
+//	Player  Bought in  Cashed out  Net
+//	Alice        1000        1800  +800
+//	Bob          2000        1200  -800
+//
+//	Bob pays Alice 800
+func (r HomeGameReport) WriteText(w io.Writer) error {
+	return r.WriteFormatted(w, nil)
+}
+
+// WriteFormatted prints the report like WriteText with amounts written by
+// chips, e.g. "Bob pays Alice $1,800"
+func (r HomeGameReport) WriteFormatted(w io.Writer, chips *i18n.ChipFormatter) error {
+	width := len("Player")
+	for _, b := range r.Balances {
+		width = max(width, len(b.Name))
+	}
+	if _, err := fmt.Fprintf(w, "%-*s  %9s  %10s  %s\n", width, "Player", "Bought in", "Cashed out", "Net"); err != nil {
+		return err
+	}
+	for _, b := range r.Balances {
+		if _, err := fmt.Fprintf(w, "%-*s  %9s  %10s  %s\n", width, b.Name, chips.Format(b.BoughtIn), chips.Format(b.CashedOut+b.Stack), chips.Signed(b.Net)); err != nil {
+			return err
+		}
+	}
+	if r.Rake > 0 {
+		if _, err := fmt.Fprintf(w, "\nRake taken: %s\n", chips.Format(r.Rake)); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	if len(r.Settlements) == 0 {
+		_, err := fmt.Fprintln(w, "Everyone is square")
+		return err
+	}
+	for _, s := range r.Settlements {
+		if _, err := fmt.Fprintf(w, "%s pays %s %s\n", s.FromName, s.ToName, chips.Format(s.Amount)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package session
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestLedgerRecordsChipMovements(t *testing.T) {
+	s := newRulesSession(holdem.GameConfig{})
+	alice, bob := holdem.NewPlayer(1, "Alice", 1000), holdem.NewPlayer(2, "Bob", 500)
+	for seat, player := range []holdem.IPlayer{alice, bob} {
+		if err := s.SitDown(player, seat); err != nil {
+			t.Fatalf("Sit down: %v", err)
+		}
+	}
+	if err := s.TopUp(2, 300); err != nil {
+		t.Fatalf("Top up: %v", err)
+	}
+	if _, err := s.StandUp(1); err != nil {
+		t.Fatalf("Stand up: %v", err)
+	}
+
+	kinds := []LedgerKind{}
+	for _, entry := range s.Ledger() {
+		kinds = append(kinds, entry.Kind)
+	}
+	expected := []LedgerKind{LedgerBuyIn, LedgerBuyIn, LedgerTopUp, LedgerCashOut}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("Expected ledger %v, got %v", expected, kinds)
+	}
+	if entry := s.Ledger()[2]; entry.Name != "Bob" || entry.Amount != 300 {
+		t.Errorf("Expected Bob's top-up of 300, got %+v", entry)
+	}
+}
+
+func TestLedgerCountsPlayersSeatedBeforeNew(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10})
+	game.PlayerSit(holdem.NewPlayer(1, "Alice", 750), 0)
+
+	ledger := New(game).Ledger()
+	if len(ledger) != 1 || ledger[0].Kind != LedgerBuyIn || ledger[0].Amount != 750 {
+		t.Errorf("Expected Alice's stack as a buy-in, got %+v", ledger)
+	}
+}
+
+func TestWinningsFollowStacksAndLedger(t *testing.T) {
+	s := newTestSession(t, 500, 500, 500)
+	for id := 1; id <= 3; id++ {
+		s.SetDecisionMaker(id, callingStation{})
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := s.PlayHand(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.StandUp(2); err != nil {
+		t.Fatalf("Stand up: %v", err)
+	}
+	if err := s.SitDown(holdem.NewPlayer(4, "Dave", 400), 1); err != nil {
+		t.Fatalf("Sit down: %v", err)
+	}
+	if _, err := s.PlayHand(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	winnings := s.Winnings()
+	if len(winnings) != 4 || winnings[3].Name != "Dave" {
+		t.Fatalf("Expected the three players and then Dave, got %+v", winnings)
+	}
+	total := 0
+	for _, player := range winnings {
+		if len(player.Hands) != 4 {
+			t.Errorf("Expected 4 hands for %s, got %v", player.Name, player.Hands)
+		}
+		total += player.Final()
+	}
+	if total != -s.GetRake() {
+		t.Errorf("Expected the winnings to add up to the rake, got %d", total)
+	}
+	bob, dave := winnings[1], winnings[3]
+	if bob.Hands[3] != bob.Hands[2] || dave.Hands[0] != 0 || dave.Hands[2] != 0 {
+		t.Errorf("Expected Bob's result kept after he left and Dave's zero before he came, got %v and %v", bob.Hands, dave.Hands)
+	}
+	player, _ := s.GetGame().GetPlayerByID(1)
+	if winnings[0].Final() != player.GetChips()-500 {
+		t.Errorf("Expected the first player's winnings to be their stack less the buy-in, got %d", winnings[0].Final())
+	}
+
+	if _, err := s.PlayHand(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UndoLastHand(); err != nil {
+		t.Fatal(err)
+	}
+	if hands := len(s.Winnings()[0].Hands); hands != 4 {
+		t.Errorf("Expected the undone hand dropped from the winnings, got %d hands", hands)
+	}
+}
+
+func TestHomeGameReport(t *testing.T) {
+	s := newRulesSession(holdem.GameConfig{})
+	players := []holdem.IPlayer{
+		holdem.NewPlayer(1, "Alice", 1000),
+		holdem.NewPlayer(2, "Bob", 1000),
+		holdem.NewPlayer(3, "Carol", 1000),
+	}
+	for seat, player := range players {
+		if err := s.SitDown(player, seat); err != nil {
+			t.Fatalf("Sit down: %v", err)
+		}
+	}
+	// Carol busts to Alice and buys in again, Bob cashes out a loser
+	players[2].GrandChips(-1000)
+	players[0].GrandChips(1000)
+	s.RemoveBusted()
+	if err := s.SitDown(holdem.NewPlayer(3, "Carol", 500), 2); err != nil {
+		t.Fatalf("Re-buy: %v", err)
+	}
+	players[1].GrandChips(-400)
+	players[0].GrandChips(400)
+	if _, err := s.StandUp(2); err != nil {
+		t.Fatalf("Stand up: %v", err)
+	}
+
+	report := s.HomeGameReport()
+	nets := map[string]int{}
+	for _, b := range report.Balances {
+		nets[b.Name] = b.Net
+	}
+	if expected := map[string]int{"Alice": 1400, "Bob": -400, "Carol": -1000}; !reflect.DeepEqual(nets, expected) {
+		t.Errorf("Expected nets %v, got %v", expected, nets)
+	}
+	if report.Balances[0].Name != "Alice" || report.Balances[0].Stack != 2400 {
+		t.Errorf("Expected Alice first with 2400 at the table, got %+v", report.Balances[0])
+	}
+	expected := []Settlement{
+		{From: 3, To: 1, FromName: "Carol", ToName: "Alice", Amount: 1000},
+		{From: 2, To: 1, FromName: "Bob", ToName: "Alice", Amount: 400},
+	}
+	if !reflect.DeepEqual(report.Settlements, expected) {
+		t.Errorf("Expected settlements %v, got %v", expected, report.Settlements)
+	}
+
+	var text strings.Builder
+	if err := report.WriteText(&text); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	for _, line := range []string{"Alice        1000        2400  +1400", "Carol pays Alice 1000"} {
+		if !strings.Contains(text.String(), line) {
+			t.Errorf("Expected %q in the report:\n%s", line, text.String())
+		}
+	}
+}
+
+func TestSettle(t *testing.T) {
+	tests := []struct {
+		name     string
+		nets     []int
+		payments int
+	}{
+		{"square", []int{0, 0}, 0},
+		{"one loser", []int{300, 200, -500}, 2},
+		{"one winner", []int{-100, -200, 300}, 2},
+		{"pairs", []int{500, -500, 200, -200}, 2},
+		{"mixed", []int{700, -300, -250, 100, -250}, 4},
+		{"rake left out", []int{400, -500}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balances := []PlayerBalance{}
+			for i, net := range tt.nets {
+				balances = append(balances, PlayerBalance{PlayerID: i + 1, Net: net})
+			}
+			settlements := Settle(balances)
+			if len(settlements) != tt.payments {
+				t.Errorf("Expected %d payments, got %v", tt.payments, settlements)
+			}
+
+			// Everyone is square, but for losers whose chips went to the rake
+			owed := map[int]int{}
+			for _, b := range balances {
+				owed[b.PlayerID] = b.Net
+			}
+			for _, settlement := range settlements {
+				owed[settlement.From] += settlement.Amount
+				owed[settlement.To] -= settlement.Amount
+			}
+			for id, left := range owed {
+				if left > 0 || left < 0 && tt.name != "rake left out" {
+					t.Errorf("Player %d is left with %d", id, left)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package session
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// Limits end a player's session once their result or time at the table
+// crosses a bound. Zero turns a limit off.
+type Limits struct {
+	StopLoss int           // Chips down on the session
+	StopWin  int           // Chips up on the session
+	Duration time.Duration // Time since the limits were set
+}
+
+// IsZero reports whether every limit is off
+func (l Limits) IsZero() bool {
+	return l.StopLoss <= 0 && l.StopWin <= 0 && l.Duration <= 0
+}
+
+// LimitKind identifies which limit a player reached
+type LimitKind int
+
+const (
+	LimitStopLoss LimitKind = iota
+	LimitStopWin
+	LimitTime
+)
+
+// LimitReached reports that a player hit one of their session limits
+type LimitReached struct {
+	PlayerID int
+	Kind     LimitKind
+	Net      int           // Chips won or lost since the limits were set
+	Played   time.Duration // Time since the limits were set
+}
+
+func (l LimitReached) String() string {
+	switch l.Kind {
+	case LimitStopLoss:
+		return fmt.Sprintf("stop-loss reached: down %d", -l.Net)
+	case LimitStopWin:
+		return fmt.Sprintf("stop-win reached: up %d", l.Net)
+	case LimitTime:
+		return fmt.Sprintf("time limit reached after %s, net %+d", l.Played.Round(time.Minute), l.Net)
+	default:
+		return fmt.Sprintf("session limit %d reached", l.Kind)
+	}
+}
+
+// playerLimits tracks one player's limits and result since they were set
+type playerLimits struct {
+	limits  Limits
+	started time.Time
+	net     int
+	reached bool // Reported already, so it is only reported once
+}
+
+// SetLimits sets a player's session limits and starts counting their result
+// and time from now. Zero limits remove them.
+func (s *Session) SetLimits(playerID int, limits Limits) {
+	if limits.IsZero() {
+		delete(s.limits, playerID)
+		return
+	}
+	s.limits[playerID] = &playerLimits{limits: limits, started: s.now()}
+}
+
+// GetSessionNet returns the chips a player won or lost in hands played
+// since their limits were set, leaving out buy-ins and top-ups
+func (s *Session) GetSessionNet(playerID int) int {
+	if tracked, ok := s.limits[playerID]; ok {
+		return tracked.net
+	}
+	return 0
+}
+
+// checkLimits adds the hand to every tracked result and reports each
+// player that reached a limit for the first time
+func (s *Session) checkLimits(result *HandResult) {
+	ids := make([]int, 0, len(s.limits))
+	for playerID := range s.limits {
+		ids = append(ids, playerID)
+	}
+	sort.Ints(ids)
+	for _, playerID := range ids {
+		tracked := s.limits[playerID]
+		tracked.net += result.Net[playerID]
+		if tracked.reached {
+			continue
+		}
+		played := s.now().Sub(tracked.started)
+		reached := LimitReached{PlayerID: playerID, Net: tracked.net, Played: played}
+		switch limits := tracked.limits; {
+		case limits.StopLoss > 0 && -tracked.net >= limits.StopLoss:
+			reached.Kind = LimitStopLoss
+		case limits.StopWin > 0 && tracked.net >= limits.StopWin:
+			reached.Kind = LimitStopWin
+		case limits.Duration > 0 && played >= limits.Duration:
+			reached.Kind = LimitTime
+		default:
+			continue
+		}
+		tracked.reached = true
+		result.Limits = append(result.Limits, reached)
+		s.logger.Info("session limit reached", slog.Int("player_id", playerID), slog.String("limit", reached.String()))
+	}
+}
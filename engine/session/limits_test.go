@@ -0,0 +1,106 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestLimitsReachedOnce(t *testing.T) {
+	s := newTestSession(t, 500, 500)
+	s.SetLimits(1, Limits{StopLoss: 100, StopWin: 200})
+	s.SetLimits(2, Limits{StopWin: 150})
+
+	result := &HandResult{Net: map[int]int{1: -60, 2: 60}}
+	s.checkLimits(result)
+	if len(result.Limits) != 0 {
+		t.Fatalf("Expected no limits yet, got %v", result.Limits)
+	}
+
+	result = &HandResult{Net: map[int]int{1: -90, 2: 90}}
+	s.checkLimits(result)
+	if len(result.Limits) != 2 {
+		t.Fatalf("Expected both players to reach a limit, got %v", result.Limits)
+	}
+	if got := result.Limits[0]; got.PlayerID != 1 || got.Kind != LimitStopLoss || got.Net != -150 {
+		t.Errorf("Expected player 1 at the stop-loss down 150, got %+v", got)
+	}
+	if got := result.Limits[1]; got.PlayerID != 2 || got.Kind != LimitStopWin || got.Net != 150 {
+		t.Errorf("Expected player 2 at the stop-win up 150, got %+v", got)
+	}
+
+	// Reached limits are not reported again, but the result keeps counting
+	result = &HandResult{Net: map[int]int{1: -10, 2: 10}}
+	s.checkLimits(result)
+	if len(result.Limits) != 0 {
+		t.Errorf("Expected limits to be reported once, got %v", result.Limits)
+	}
+	if net := s.GetSessionNet(1); net != -160 {
+		t.Errorf("Expected player 1 down 160, got %d", net)
+	}
+}
+
+func TestTimeLimit(t *testing.T) {
+	s := newTestSession(t, 500, 500)
+	now := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	s.SetClock(func() time.Time { return now })
+	s.SetLimits(1, Limits{Duration: time.Hour})
+
+	now = now.Add(59 * time.Minute)
+	result := &HandResult{Net: map[int]int{}}
+	s.checkLimits(result)
+	if len(result.Limits) != 0 {
+		t.Fatalf("Expected no limit before the hour, got %v", result.Limits)
+	}
+	now = now.Add(time.Minute)
+	s.checkLimits(result)
+	if len(result.Limits) != 1 || result.Limits[0].Kind != LimitTime || result.Limits[0].Played != time.Hour {
+		t.Errorf("Expected the time limit after an hour, got %v", result.Limits)
+	}
+}
+
+func TestZeroLimitsRemoveTracking(t *testing.T) {
+	s := newTestSession(t, 500, 500)
+	s.SetLimits(1, Limits{StopLoss: 10})
+	s.SetLimits(1, Limits{})
+	result := &HandResult{Net: map[int]int{1: -100}}
+	s.checkLimits(result)
+	if len(result.Limits) != 0 || s.GetSessionNet(1) != 0 {
+		t.Errorf("Expected no tracking after clearing limits, got %v", result.Limits)
+	}
+}
+
+func TestPlayHandEmitsLimitReached(t *testing.T) {
+	s := newTestSession(t, 500, 500)
+	for id := 1; id <= 2; id++ {
+		s.SetDecisionMaker(id, callingStation{})
+		s.SetLimits(id, Limits{StopLoss: 1, StopWin: 1})
+	}
+	events := []Event{}
+	s.SetObserver(func(event Event, game *holdem.Game) {
+		if event.Type == EventSessionLimitReached {
+			events = append(events, event)
+		}
+	})
+
+	for hand := 0; hand < 10 && len(events) == 0; hand++ {
+		result, err := s.PlayHand(context.Background())
+		if err != nil {
+			t.Fatalf("PlayHand failed: %v", err)
+		}
+		if len(result.Limits) != len(events) {
+			t.Fatalf("Expected an event per limit, got %d limits and %d events", len(result.Limits), len(events))
+		}
+	}
+	// Heads-up, one player's win is the other's loss
+	if len(events) != 2 {
+		t.Fatalf("Expected both players to reach a limit, got %d events", len(events))
+	}
+	for _, event := range events {
+		if event.Limit == nil || event.Limit.PlayerID != event.PlayerID {
+			t.Errorf("Expected the event to carry the player's limit, got %+v", event)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package session
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// heapInUse returns the live heap after a collection
+func heapInUse() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+func TestLongSessionMemoryStaysBounded(t *testing.T) {
+	if testing.Short() {
+		t.Skip("plays 10,000 hands")
+	}
+	s := newTestSession(t, 100000, 100000, 100000, 100000)
+	for id := 1; id <= 4; id++ {
+		s.SetDecisionMaker(id, callingStation{})
+	}
+	play := func(hands int) {
+		for i := 0; i < hands; i++ {
+			if _, err := s.PlayHand(context.Background()); err != nil {
+				t.Fatalf("PlayHand failed: %v", err)
+			}
+		}
+	}
+
+	// Every hand logs a few dozen actions, around a kilobyte, so 9,000 more
+	// hands would grow an unbounded log by megabytes
+	play(1000)
+	before := heapInUse()
+	play(9000)
+	after := heapInUse()
+	if after > before+2<<20 {
+		t.Errorf("Expected the heap to stay flat over 9,000 hands, grew from %d to %d bytes", before, after)
+	}
+
+	game := s.GetGame()
+	if got := len(game.GetHandHistory()); got != holdem.DefaultHandHistory {
+		t.Errorf("Expected %d hands kept, got %d", holdem.DefaultHandHistory, got)
+	}
+	if len(game.GetActionLog()) != len(game.GetHandActionLog()) {
+		t.Errorf("Expected the journal to hold only the last hand, got %d actions", len(game.GetActionLog()))
+	}
+}
@@ -0,0 +1,197 @@
+package session
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+)
+
+// Rule identifies a cash game table rule
+type Rule int
+
+const (
+	RuleHandInProgress Rule = iota // Stack changes wait until the hand is over
+	RuleMinBuyIn                   // Buy-in below the table minimum
+	RuleMaxBuyIn                   // Buy-in above the table maximum
+	RuleTopUp                      // Top-up would take the stack above the maximum buy-in
+	RuleRathole                    // Player left recently and must bring back their old stack
+	RuleReentry                    // Player busted and has no re-entries left
+)
+
+// RuleError reports a buy-in, top-up or seat change the table rules forbid
+type RuleError struct {
+	Rule     Rule
+	PlayerID int
+	Amount   int // Chips the player asked for
+	Limit    int // The bound that was broken, in chips or re-entries
+}
+
+func (e *RuleError) Error() string {
+	switch e.Rule {
+	case RuleHandInProgress:
+		return fmt.Sprintf("player %d must wait for the hand to finish", e.PlayerID)
+	case RuleMinBuyIn:
+		return fmt.Sprintf("buy-in of %d is below the table minimum of %d", e.Amount, e.Limit)
+	case RuleMaxBuyIn:
+		return fmt.Sprintf("buy-in of %d is above the table maximum of %d", e.Amount, e.Limit)
+	case RuleTopUp:
+		return fmt.Sprintf("top-up of %d exceeds the %d allowed before reaching the maximum buy-in", e.Amount, e.Limit)
+	case RuleRathole:
+		return fmt.Sprintf("player %d left with %d chips and must buy in for at least that, not %d", e.PlayerID, e.Limit, e.Amount)
+	case RuleReentry:
+		if e.Limit == 0 {
+			return fmt.Sprintf("player %d may not re-enter after busting", e.PlayerID)
+		}
+		return fmt.Sprintf("player %d has used all %d re-entries", e.PlayerID, e.Limit)
+	default:
+		return fmt.Sprintf("table rule %d broken by player %d", e.Rule, e.PlayerID)
+	}
+}
+
+// departure remembers the stack a player left the table with
+type departure struct {
+	chips int
+	at    time.Time
+}
+
+// SetClock replaces the clock used for the rathole window and time limits,
+// e.g. in tests or simulations
+func (s *Session) SetClock(now func() time.Time) {
+	s.now = now
+}
+
+// SitDown seats a player whose chips are their buy-in, enforcing the
+// buy-in limits, the rathole window and the re-entry limit
+func (s *Session) SitDown(player holdem.IPlayer, seat int) error {
+	if player == nil {
+		return fmt.Errorf("player is nil")
+	}
+	id, chips := player.GetID(), player.GetChips()
+	if err := s.checkBetweenHands(id); err != nil {
+		return err
+	}
+	config := s.game.GetConfig()
+
+	if busts := s.busts[id]; busts > 0 && config.MaxReentries != 0 {
+		if config.MaxReentries < 0 || busts > config.MaxReentries {
+			return &RuleError{Rule: RuleReentry, PlayerID: id, Amount: busts, Limit: max(config.MaxReentries, 0)}
+		}
+	}
+
+	minBuyIn, maxBuyIn := config.BuyInLimits()
+	if left, ok := s.departures[id]; ok && config.RatholeWindow > 0 && s.now().Sub(left.at) < config.RatholeWindow {
+		// Bringing back the old stack is allowed even above the maximum buy-in
+		if chips < left.chips {
+			return &RuleError{Rule: RuleRathole, PlayerID: id, Amount: chips, Limit: left.chips}
+		}
+		maxBuyIn = max(maxBuyIn, left.chips)
+	}
+	if minBuyIn > 0 && chips < minBuyIn {
+		return &RuleError{Rule: RuleMinBuyIn, PlayerID: id, Amount: chips, Limit: minBuyIn}
+	}
+	if maxBuyIn > 0 && chips > maxBuyIn {
+		return &RuleError{Rule: RuleMaxBuyIn, PlayerID: id, Amount: chips, Limit: maxBuyIn}
+	}
+
+	if err := s.game.PlayerSit(player, seat); err != nil {
+		return err
+	}
+	delete(s.departures, id)
+	s.record(player, LedgerBuyIn, chips)
+	s.logger.Info("player sat down", slog.Int("player_id", id), slog.Int("seat", seat), slog.Int("buy_in", chips))
+	return nil
+}
+
+// TopUp adds chips to a seated player's stack between hands, up to the
+// maximum buy-in
+func (s *Session) TopUp(playerID, amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("invalid top-up amount %d", amount)
+	}
+	player, err := s.game.GetPlayerByID(playerID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkBetweenHands(playerID); err != nil {
+		return err
+	}
+	if _, maxBuyIn := s.game.GetConfig().BuyInLimits(); maxBuyIn > 0 && player.GetChips()+amount > maxBuyIn {
+		return &RuleError{Rule: RuleTopUp, PlayerID: playerID, Amount: amount, Limit: max(maxBuyIn-player.GetChips(), 0)}
+	}
+	player.GrandChips(amount)
+	s.record(player, LedgerTopUp, amount)
+	s.logger.Info("player topped up", slog.Int("player_id", playerID), slog.Int("amount", amount))
+	return nil
+}
+
+// StandUp removes a player between hands and returns the chips they leave
+// with. The stack is remembered for the rathole window.
+func (s *Session) StandUp(playerID int) (int, error) {
+	player, err := s.game.GetPlayerByID(playerID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.checkBetweenHands(playerID); err != nil {
+		return 0, err
+	}
+	if err := s.game.PlayerLeave(player); err != nil {
+		return 0, err
+	}
+	chips := player.GetChips()
+	s.departures[playerID] = departure{chips: chips, at: s.now()}
+	s.record(player, LedgerCashOut, chips)
+	s.logger.Info("player stood up", slog.Int("player_id", playerID), slog.Int("chips", chips))
+	return chips, nil
+}
+
+// MovePlayer moves a seated player to an empty seat between hands, keeping
+// their stack and their place in the ledger
+func (s *Session) MovePlayer(playerID, seat int) error {
+	from, err := s.game.GetPlayerSitByID(playerID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkBetweenHands(playerID); err != nil {
+		return err
+	}
+	if seat == from {
+		return nil
+	}
+	if err := s.game.MovePlayer(playerID, seat); err != nil {
+		return err
+	}
+	s.logger.Info("player changed seats", slog.Int("player_id", playerID), slog.Int("from_seat", from), slog.Int("seat", seat))
+	s.emit(Event{Type: EventSeatChanged, PlayerID: playerID, Seat: seat, FromSeat: from})
+	return nil
+}
+
+// ReplaceDecisionMaker hands a seated player over to another decision
+// maker between hands, e.g. a stronger bot or one that does not misbehave.
+// The player keeps their seat, stack and ID, so the ledger and their stats
+// carry on as before.
+func (s *Session) ReplaceDecisionMaker(playerID int, maker holdem_ai.IDecisionMaker) error {
+	if _, err := s.game.GetPlayerByID(playerID); err != nil {
+		return err
+	}
+	if err := s.checkBetweenHands(playerID); err != nil {
+		return err
+	}
+	s.SetDecisionMaker(playerID, maker)
+	s.logger.Info("decision maker replaced", slog.Int("player_id", playerID))
+	return nil
+}
+
+// GetBusts returns how many times a player has busted at this table
+func (s *Session) GetBusts(playerID int) int {
+	return s.busts[playerID]
+}
+
+func (s *Session) checkBetweenHands(playerID int) error {
+	if s.game.IsHandInProgress() {
+		return &RuleError{Rule: RuleHandInProgress, PlayerID: playerID}
+	}
+	return nil
+}
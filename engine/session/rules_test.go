@@ -0,0 +1,175 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// newRulesSession creates an empty 5/10 table buying in for 400 to 1000 chips
+func newRulesSession(config holdem.GameConfig) *Session {
+	config.SmallBlind, config.BigBlind, config.Seed = 5, 10, 3
+	config.MinBuyInBB, config.MaxBuyInBB = 40, 100
+	return New(holdem.NewGameWithConfig(config))
+}
+
+func ruleOf(err error) (Rule, bool) {
+	var ruleErr *RuleError
+	if !errors.As(err, &ruleErr) {
+		return 0, false
+	}
+	return ruleErr.Rule, true
+}
+
+func TestSitDownEnforcesBuyInLimits(t *testing.T) {
+	s := newRulesSession(holdem.GameConfig{})
+
+	if rule, ok := ruleOf(s.SitDown(holdem.NewPlayer(1, "", 399), 0)); !ok || rule != RuleMinBuyIn {
+		t.Errorf("Expected a minimum buy-in error, got %v", rule)
+	}
+	if rule, ok := ruleOf(s.SitDown(holdem.NewPlayer(1, "", 1001), 0)); !ok || rule != RuleMaxBuyIn {
+		t.Errorf("Expected a maximum buy-in error, got %v", rule)
+	}
+	if err := s.SitDown(holdem.NewPlayer(1, "", 1000), 0); err != nil {
+		t.Errorf("Expected a 100 big blind buy-in to be accepted, got %v", err)
+	}
+}
+
+func TestTopUpIsCappedAndWaitsForHandEnd(t *testing.T) {
+	s := newRulesSession(holdem.GameConfig{})
+	s.SitDown(holdem.NewPlayer(1, "", 600), 0)
+	s.SitDown(holdem.NewPlayer(2, "", 600), 1)
+
+	if rule, ok := ruleOf(s.TopUp(1, 401)); !ok || rule != RuleTopUp {
+		t.Errorf("Expected a top-up above the maximum buy-in to be refused, got %v", rule)
+	}
+	if err := s.TopUp(1, 400); err != nil {
+		t.Fatalf("TopUp failed: %v", err)
+	}
+
+	s.GetGame().StartHand(0)
+	if rule, ok := ruleOf(s.TopUp(2, 100)); !ok || rule != RuleHandInProgress {
+		t.Errorf("Expected a top-up during a hand to be refused, got %v", rule)
+	}
+	if _, err := s.StandUp(2); err == nil {
+		t.Error("Expected standing up during a hand to be refused")
+	}
+}
+
+func TestRatholingIsPrevented(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := newRulesSession(holdem.GameConfig{RatholeWindow: time.Hour})
+	s.SetClock(func() time.Time { return now })
+	s.SitDown(holdem.NewPlayer(1, "", 1000), 0)
+	player, _ := s.GetGame().GetPlayerByID(1)
+	player.GrandChips(500)
+
+	chips, err := s.StandUp(1)
+	if err != nil || chips != 1500 {
+		t.Fatalf("Expected to leave with 1500 chips, got %d (%v)", chips, err)
+	}
+	now = now.Add(30 * time.Minute)
+	if rule, ok := ruleOf(s.SitDown(holdem.NewPlayer(1, "", 1000), 0)); !ok || rule != RuleRathole {
+		t.Errorf("Expected a rathole error when returning short, got %v", rule)
+	}
+	if err := s.SitDown(holdem.NewPlayer(1, "", 1500), 0); err != nil {
+		t.Errorf("Expected returning with the old stack above the maximum to be allowed, got %v", err)
+	}
+
+	s.StandUp(1)
+	now = now.Add(2 * time.Hour)
+	if err := s.SitDown(holdem.NewPlayer(1, "", 1000), 0); err != nil {
+		t.Errorf("Expected a normal buy-in after the rathole window, got %v", err)
+	}
+}
+
+func TestReentryLimit(t *testing.T) {
+	s := newRulesSession(holdem.GameConfig{MaxReentries: 1})
+	s.SitDown(holdem.NewPlayer(1, "", 400), 0)
+	s.SitDown(holdem.NewPlayer(2, "", 1000), 1)
+
+	player1, _ := s.GetGame().GetPlayerByID(1)
+	player1.GrandChips(-player1.GetChips())
+	s.RemoveBusted()
+	if s.GetBusts(1) != 1 {
+		t.Fatalf("Expected one bust, got %d", s.GetBusts(1))
+	}
+	if err := s.SitDown(holdem.NewPlayer(1, "", 400), 0); err != nil {
+		t.Fatalf("Expected the first re-entry to be allowed, got %v", err)
+	}
+
+	player1, _ = s.GetGame().GetPlayerByID(1)
+	player1.GrandChips(-player1.GetChips())
+	s.RemoveBusted()
+	err := s.SitDown(holdem.NewPlayer(1, "", 400), 0)
+	if rule, ok := ruleOf(err); !ok || rule != RuleReentry {
+		t.Errorf("Expected the second re-entry to be refused, got %v", err)
+	}
+}
+
+func TestMovePlayerChangesSeatsBetweenHands(t *testing.T) {
+	s := newRulesSession(holdem.GameConfig{})
+	s.SitDown(holdem.NewPlayer(1, "", 600), 0)
+	s.SitDown(holdem.NewPlayer(2, "", 600), 1)
+	events := []Event{}
+	s.SetObserver(func(event Event, game *holdem.Game) { events = append(events, event) })
+
+	if err := s.SitDown(holdem.NewPlayer(1, "", 600), 4); err == nil {
+		t.Error("Expected sitting a seated player at a second seat to be refused")
+	}
+	if err := s.MovePlayer(1, 4); err != nil {
+		t.Fatalf("MovePlayer failed: %v", err)
+	}
+	if seat, _ := s.GetGame().GetPlayerSitByID(1); seat != 4 {
+		t.Errorf("Expected player 1 at seat 4, got %d", seat)
+	}
+	if len(events) != 1 || events[0].Type != EventSeatChanged || events[0].PlayerID != 1 || events[0].FromSeat != 0 || events[0].Seat != 4 {
+		t.Errorf("Expected one seat change from 0 to 4, got %+v", events)
+	}
+	if len(s.Ledger()) != 2 {
+		t.Errorf("Expected a move to leave the ledger alone, got %+v", s.Ledger())
+	}
+
+	s.GetGame().StartHand(1)
+	if rule, ok := ruleOf(s.MovePlayer(2, 5)); !ok || rule != RuleHandInProgress {
+		t.Errorf("Expected changing seats during a hand to be refused, got %v", rule)
+	}
+}
+
+func TestReplaceDecisionMakerKeepsTheSeat(t *testing.T) {
+	s := newRulesSession(holdem.GameConfig{})
+	s.SitDown(holdem.NewPlayer(1, "", 600), 0)
+	s.SitDown(holdem.NewPlayer(2, "Nit", 600), 3)
+	s.SetDecisionMaker(1, callingStation{})
+	s.SetDecisionMaker(2, silentMaker{})
+
+	if err := s.ReplaceDecisionMaker(3, folder{}); err == nil {
+		t.Error("Expected replacing the decision maker of a player not seated to be refused")
+	}
+	if err := s.ReplaceDecisionMaker(2, folder{}); err != nil {
+		t.Fatalf("ReplaceDecisionMaker failed: %v", err)
+	}
+	if _, ok := s.GetDecisionMaker(2).(folder); !ok {
+		t.Fatalf("Expected the folder to decide for player 2, got %T", s.GetDecisionMaker(2))
+	}
+	// The silent maker would have hung the hand
+	result, err := s.PlayHand(context.Background())
+	if err != nil {
+		t.Fatalf("PlayHand failed: %v", err)
+	}
+	player, _ := s.GetGame().GetPlayerByID(2)
+	if seat, _ := s.GetGame().GetPlayerSitByID(2); seat != 3 || player.GetName() != "Nit" || player.GetChips() != 600+result.Net[2] {
+		t.Errorf("Expected player 2 to keep seat, name and stack, got seat %d and %+v", seat, player)
+	}
+	if len(s.Ledger()) != 2 {
+		t.Errorf("Expected a new decision maker to leave the ledger alone, got %+v", s.Ledger())
+	}
+
+	s.GetGame().StartHand(0)
+	if rule, ok := ruleOf(s.ReplaceDecisionMaker(2, callingStation{})); !ok || rule != RuleHandInProgress {
+		t.Errorf("Expected replacing a decision maker during a hand to be refused, got %v", rule)
+	}
+}
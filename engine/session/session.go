@@ -0,0 +1,596 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/ids"
+)
+
+// EventType identifies what just happened at the table
+type EventType int
+
+const (
+	EventHandStarted         EventType = iota // Cards dealt and blinds posted
+	EventTurn                                 // A player is about to be asked for a decision
+	EventAction                               // A player acted
+	EventStreet                               // Community cards were dealt
+	EventHandFinished                         // The pot was awarded
+	EventSessionLimitReached                  // A player hit a stop-loss, stop-win or time limit
+	EventChat                                 // A player said something after the hand
+	EventTimeWarning                          // The player to act is running out of time
+	EventShowdown                             // A player showed or mucked, in showdown order
+	EventSeatChanged                          // A player moved to another seat between hands
+	EventLastHands                            // A timed session is down to its last hands, see SetEndTime
+)
+
+// The decision clock every seat plays against, whoever decides for it
+const (
+	DecisionTimeout = holdem_ai.HumanDecisionTimeout // Time to act before the player checks or folds
+	DecisionWarning = 10 * time.Second               // How long before the timeout the player is warned
+)
+
+// Event describes one step of a hand
+type Event struct {
+	Type      EventType
+	HandID    string            // Hand the event belongs to, or that reached the limit
+	PlayerID  int               // Player to act or who acted
+	Action    holdem.Action     // EventAction only
+	Elapsed   time.Duration     // EventAction only, how long the player took to decide
+	TimedOut  bool              // EventAction only, the action was taken for the player when time ran out
+	Left      time.Duration     // EventTimeWarning only, time left to act
+	Awards    []holdem.PotAward // EventHandFinished only
+	Bounties  []Bounty          // EventHandFinished only
+	Mucked    []int             // EventHandFinished only, players who mucked at showdown
+	Net       map[int]int       // EventHandFinished only, chips won or lost by player ID
+	Limit     *LimitReached     // EventSessionLimitReached only
+	Option    bool              // EventTurn only, the big blind may check or raise preflop
+	Timeout   time.Duration     // EventTurn only, time the player has to act, 0 when the clock is off
+	Message   string            // EventChat only, catalog key of what the player said
+	Shown     bool              // EventShowdown only, the player showed rather than mucked
+	Seat      int               // EventSeatChanged only, seat the player moved to
+	FromSeat  int               // EventSeatChanged only, seat the player left
+	HandsLeft int               // EventLastHands only, hands left including the one dealt
+}
+
+// Observer is called synchronously from the goroutine playing the hand, so
+// it may read the game but must not keep references into it
+type Observer func(event Event, game *holdem.Game)
+
+// HandResult summarises a finished hand
+type HandResult struct {
+	HandNumber int
+	HandID     string
+	Button     int
+	Awards     []holdem.PotAward
+	Net        map[int]int // Chips won or lost by player ID, including bounties
+	Showdown   bool
+	Rake       int            // Chips the house took from the pots
+	Bounties   []Bounty       // Side payments made by table rules
+	Limits     []LimitReached // Session limits players reached with this hand
+	Mucked     []int          // Players who mucked a losing hand at showdown
+}
+
+// Bounty is a payment to a player outside the pot
+type Bounty struct {
+	PlayerID int
+	Amount   int
+}
+
+// Session plays hands at one table: it moves the button, asks each player's
+// decision maker for actions in turn and settles the pot
+type Session struct {
+	id       string // Unique session ID, see GetID
+	game     *holdem.Game
+	makers   map[int]holdem_ai.IDecisionMaker
+	button   int
+	observer Observer
+	logger   *slog.Logger
+	rules    []IHandRule
+	rake     int // Rake taken over every hand played
+	limits   map[int]*playerLimits
+	timeout  time.Duration // Time each decision may take, 0 for no limit
+	warning  time.Duration // How long before the timeout EventTimeWarning goes out
+	warmed   map[int]bool  // Players whose decision makers have warmed up
+
+	abandoned sync.WaitGroup // Bot decisions still running after PlayHand was cancelled or timed out
+	forcing   atomic.Bool    // Check or call down the street in play, see ForceStreet
+
+	// Cash game rule state
+	busts      map[int]int
+	departures map[int]departure
+	ledger     []LedgerEntry // Chips in and out of the table, see Ledger
+	winnings   []map[int]int // Winnings by player ID after every hand, see Winnings
+	last       *handStart    // Table before the last hand, see UndoLastHand
+	timed      *timedSession // End of a timed session, see SetEndTime
+	now        func() time.Time
+}
+
+// New creates a session around a game whose players are already seated
+func New(game *holdem.Game) *Session {
+	s := &Session{
+		id:         ids.New(),
+		game:       game,
+		makers:     map[int]holdem_ai.IDecisionMaker{},
+		button:     -1,
+		logger:     holdem.NewDiscardLogger(),
+		busts:      map[int]int{},
+		departures: map[int]departure{},
+		limits:     map[int]*playerLimits{},
+		warmed:     map[int]bool{},
+		timeout:    DecisionTimeout,
+		warning:    DecisionWarning,
+		now:        time.Now,
+	}
+	for _, player := range game.GetAllPlayers() {
+		s.record(player, LedgerBuyIn, player.GetChips())
+	}
+	return s
+}
+
+// GetID returns the unique ID of the session. Like table and hand IDs it
+// sorts by creation time.
+func (s *Session) GetID() string {
+	return s.id
+}
+
+// GetGame returns the game the session plays
+func (s *Session) GetGame() *holdem.Game {
+	return s.game
+}
+
+// SetDecisionMaker assigns who decides for a player. Players without a
+// decision maker check when they can and fold otherwise.
+func (s *Session) SetDecisionMaker(playerID int, maker holdem_ai.IDecisionMaker) {
+	s.makers[playerID] = maker
+	delete(s.warmed, playerID)
+}
+
+// SetDecisionMakers shares a decision maker map, e.g. between tournament
+// tables that players move between
+func (s *Session) SetDecisionMakers(makers map[int]holdem_ai.IDecisionMaker) {
+	s.makers = makers
+	s.warmed = map[int]bool{}
+}
+
+// GetDecisionMaker returns the decision maker of a player, nil if none
+func (s *Session) GetDecisionMaker(playerID int) holdem_ai.IDecisionMaker {
+	return s.makers[playerID]
+}
+
+// SetObserver registers a callback for every step of each hand
+func (s *Session) SetObserver(observer Observer) {
+	s.observer = observer
+}
+
+// SetLogger injects the structured logger used by the session.
+// Passing nil restores the discard logger.
+func (s *Session) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = holdem.NewDiscardLogger()
+	}
+	s.logger = logger.With(slog.String("session_id", s.id), slog.String("table_id", s.game.GetTableID()))
+}
+
+// SetDecisionClock sets how long each player has to act, DecisionTimeout
+// by default, and how long before the timeout they are warned. A timeout of
+// 0 lets players take as long as they like; a warning of 0, or not shorter
+// than the timeout, is never given.
+func (s *Session) SetDecisionClock(timeout, warning time.Duration) {
+	s.timeout = timeout
+	s.warning = warning
+}
+
+// GetRake returns the rake taken over every hand the session played
+func (s *Session) GetRake() int {
+	return s.rake
+}
+
+// GetButton returns the seat of the last hand's button, -1 before the first hand
+func (s *Session) GetButton() int {
+	return s.button
+}
+
+// SetButton places the button as if the last hand had been dealt from seat,
+// e.g. on a game restored from a snapshot, so the next hand moves it on
+func (s *Session) SetButton(seat int) {
+	s.button = seat
+}
+
+// Warmup warms up the decision makers of the seated players that have not
+// warmed up yet, see holdem_ai.IWarmer, one after the other. progress, if
+// not nil, is called before the first and after each one with how many are
+// done. PlayHand warms up any left before dealing, so Warmup is only needed
+// to show progress or to warm up ahead of the first hand.
+func (s *Session) Warmup(ctx context.Context, progress func(done, total int)) error {
+	var pending []int
+	for _, player := range s.game.GetAllPlayers() {
+		if _, ok := holdem_ai.As[holdem_ai.IWarmer](s.makers[player.GetID()]); ok && !s.warmed[player.GetID()] {
+			pending = append(pending, player.GetID())
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	config := holdem_ai.WarmupConfig{
+		Variant:  s.game.GetConfig().Variant,
+		Seats:    len(s.game.GetAllPlayers()),
+		BigBlind: s.game.GetBigBlind(),
+	}
+	for i, id := range pending {
+		if progress != nil {
+			progress(i, len(pending))
+		}
+		start := time.Now()
+		warmer, _ := holdem_ai.As[holdem_ai.IWarmer](s.makers[id])
+		if err := warmer.Warmup(ctx, config); err != nil {
+			return fmt.Errorf("warming up player %d: %w", id, err)
+		}
+		s.warmed[id] = true
+		s.logger.Debug("decision maker warmed up", slog.Int("player_id", id), slog.Duration("took", time.Since(start)))
+	}
+	if progress != nil {
+		progress(len(pending), len(pending))
+	}
+	return nil
+}
+
+// PlayHand plays one complete hand. It returns early with ctx's error if the
+// context is cancelled while waiting for a decision. A hand the deck runs
+// out of cards for is aborted with every player's chips refunded, and the
+// error wraps holdem.ErrDeckExhausted.
+func (s *Session) PlayHand(ctx context.Context) (*HandResult, error) {
+	game := s.game
+	start := map[int]int{}
+	for _, player := range game.GetAllPlayers() {
+		start[player.GetID()] = player.GetChips()
+	}
+
+	button := s.nextButton()
+	if button < 0 {
+		return nil, fmt.Errorf("no players seated")
+	}
+	if err := s.Warmup(ctx, nil); err != nil {
+		return nil, err
+	}
+	if err := s.startTimedHand(); err != nil {
+		return nil, err
+	}
+	s.rememberHandStart()
+	if err := s.startHand(button); err != nil {
+		s.last = nil
+		return nil, s.abortExhausted(err)
+	}
+	s.button = button
+	s.emit(Event{Type: EventHandStarted})
+	s.announceLastHands()
+
+	for !game.IsHandOver() {
+		if game.IsBettingRoundOpen() {
+			if err := s.playTurn(ctx); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var err error
+		switch game.GetCurrentPhase() {
+		case holdem.PhasePreflop:
+			err = game.DealFlop()
+		case holdem.PhaseFlop:
+			err = game.DealTurn()
+		case holdem.PhaseTurn:
+			err = game.DealRiver()
+		default:
+			err = fmt.Errorf("cannot deal in phase %s", holdem.PhaseToString(game.GetCurrentPhase()))
+		}
+		if err != nil {
+			s.last = nil
+			return nil, s.abortExhausted(err)
+		}
+		s.forcing.Store(false)
+		s.emit(Event{Type: EventStreet})
+	}
+	s.forcing.Store(false)
+
+	awards, err := game.AwardPot()
+	if err != nil {
+		return nil, err
+	}
+	result := &HandResult{
+		HandNumber: game.GetHandNumber(),
+		HandID:     game.GetHandID(),
+		Button:     button,
+		Awards:     awards,
+		Net:        map[int]int{},
+		Showdown:   game.GetCurrentPhase() == holdem.PhaseShowdown,
+		Rake:       game.GetRake(),
+	}
+	s.rake += result.Rake
+	if result.Showdown {
+		result.Mucked = s.showdown()
+	}
+	if err := s.finishHand(result); err != nil {
+		return nil, err
+	}
+	s.recordWinnings()
+	s.finishTimedHand()
+	for _, player := range game.GetAllPlayers() {
+		result.Net[player.GetID()] = player.GetChips() - start[player.GetID()]
+		if observer, ok := holdem_ai.As[holdem_ai.IResultObserver](s.makers[player.GetID()]); ok {
+			observer.HandFinished(game, player, result.Net[player.GetID()])
+		}
+	}
+	s.emit(Event{Type: EventHandFinished, Awards: awards, Bounties: result.Bounties, Mucked: result.Mucked, Net: result.Net})
+	for _, player := range game.GetAllPlayers() {
+		if talker, ok := holdem_ai.As[holdem_ai.ITalker](s.makers[player.GetID()]); ok {
+			if message, ok := talker.TableTalk(game, player, result.Net[player.GetID()]); ok {
+				s.emit(Event{Type: EventChat, PlayerID: player.GetID(), Message: message})
+			}
+		}
+	}
+	s.checkLimits(result)
+	s.rememberHandResult(result)
+	for i := range result.Limits {
+		s.emit(Event{Type: EventSessionLimitReached, PlayerID: result.Limits[i].PlayerID, Limit: &result.Limits[i]})
+	}
+	s.logger.Info("hand finished", slog.Int("hand", result.HandNumber), slog.String("hand_id", result.HandID), slog.Int("pots", len(awards)))
+	return result, nil
+}
+
+// playTurn asks the player to act for a decision and applies it, falling
+// back to check or fold when the decision is illegal
+func (s *Session) playTurn(ctx context.Context) error {
+	game := s.game
+	player := game.GetCurrentPlayer()
+	option := game.HasOption(player)
+	s.emit(Event{Type: EventTurn, PlayerID: player.GetID(), Option: option, Timeout: max(s.timeout, 0)})
+
+	action, elapsed, timedOut, err := s.decide(ctx, player)
+	if err != nil {
+		return err
+	}
+	if s.forcing.Load() {
+		// Whatever the player decided meanwhile, the street is called down
+		action, elapsed, timedOut = calledDown(game, player), 0, false
+	}
+	if option && action.Type == holdem.ActionCall && action.Amount == 0 {
+		// Calling nothing on the option is a check
+		action = holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck}
+	}
+
+	if err := game.TakeTimedAction(action, elapsed); err != nil {
+		s.logger.Warn("illegal decision replaced",
+			slog.Int("player_id", player.GetID()),
+			slog.String("action", holdem.ActionTypeToString(action.Type)),
+			slog.Int("amount", action.Amount),
+			slog.Any("error", err),
+		)
+		action = passiveAction(game, player)
+		if err := game.TakeTimedAction(action, elapsed); err != nil {
+			return err
+		}
+	}
+	s.emit(Event{Type: EventAction, PlayerID: player.GetID(), Action: action, Elapsed: elapsed, TimedOut: timedOut})
+	return nil
+}
+
+// decide waits for the player's decision maker and returns its action with
+// the time it took: the simulated thinking time of timed decision makers,
+// the wall-clock latency otherwise. Players without one act passively at
+// once, and so do players who run out of time, reported as timed out.
+//
+// The decision maker reads a clone of the game, so one that is still
+// thinking when the session stops waiting never reads the table as the
+// hand goes on without it.
+func (s *Session) decide(ctx context.Context, player holdem.IPlayer) (holdem.Action, time.Duration, bool, error) {
+	action := passiveAction(s.game, player)
+	maker := s.makers[player.GetID()]
+	if maker == nil || s.forcing.Load() {
+		return action, 0, false, nil
+	}
+	view := s.game.Clone()
+	seat, err := view.GetPlayerByID(player.GetID())
+	if err != nil {
+		return action, 0, false, err
+	}
+
+	// The clock starts before the decision maker is asked, so it runs out
+	// before any timeout of the decision maker's own
+	warning, deadline, stop := s.startClock()
+	defer stop()
+	start := time.Now()
+	var timedDecision <-chan holdem_ai.TimedDecision
+	var decision <-chan holdem.Action
+	if timed, ok := maker.(holdem_ai.ITimedDecisionMaker); ok {
+		timedDecision = timed.MakeTimedDecision(view, seat)
+	} else {
+		decision = maker.MakeDecision(view, seat)
+	}
+
+	for {
+		select {
+		case decided, ok := <-timedDecision:
+			if ok {
+				return decided.Action, decided.Thinking, false, nil
+			}
+			return action, time.Since(start), false, nil
+		case decided, ok := <-decision:
+			if ok {
+				action = decided
+			}
+			return action, time.Since(start), false, nil
+		case <-warning:
+			warning = nil
+			s.emit(Event{Type: EventTimeWarning, PlayerID: player.GetID(), Left: s.warning})
+		case <-deadline:
+			s.logger.Warn("decision timed out",
+				slog.Int("player_id", player.GetID()),
+				slog.String("action", holdem.ActionTypeToString(action.Type)),
+				slog.Duration("timeout", s.timeout),
+			)
+			s.abandon(timedDecision)
+			return action, s.timeout, true, nil
+		case <-ctx.Done():
+			s.abandon(timedDecision)
+			return action, 0, false, ctx.Err()
+		}
+	}
+}
+
+// startClock starts the decision clock and returns channels that fire when
+// the player is to be warned and when their time is up, nil when there is
+// no warning or no timeout, and a function that stops the clock
+func (s *Session) startClock() (warning, deadline <-chan time.Time, stop func()) {
+	if s.timeout <= 0 {
+		return nil, nil, func() {}
+	}
+	timeout := time.NewTimer(s.timeout)
+	if s.warning <= 0 || s.warning >= s.timeout {
+		return nil, timeout.C, func() { timeout.Stop() }
+	}
+	warn := time.NewTimer(s.timeout - s.warning)
+	return warn.C, timeout.C, func() {
+		warn.Stop()
+		timeout.Stop()
+	}
+}
+
+// abandon stops waiting for a timed decision, which is drained in the
+// background, see WaitForDecisions. Untimed decision makers answer on a
+// buffered channel and are left to it.
+func (s *Session) abandon(decision <-chan holdem_ai.TimedDecision) {
+	if decision == nil {
+		return
+	}
+	s.abandoned.Add(1)
+	go func() {
+		defer s.abandoned.Done()
+		for range decision {
+		}
+	}()
+}
+
+// ForceStreet ends the betting on the street in play for debugging: every
+// player still to act checks or calls without being asked, and the next
+// street is dealt. A decision already being made still has to come in, and
+// is then replaced. Between hands it applies to the next hand's preflop.
+// It is safe to call from any goroutine.
+func (s *Session) ForceStreet() {
+	s.forcing.Store(true)
+}
+
+// WaitForDecisions waits for the timed bot decisions a cancelled PlayHand,
+// or a timeout, stopped waiting for, e.g. before shutting down. The bots
+// read a clone of the game, so the game may be changed meanwhile.
+func (s *Session) WaitForDecisions() {
+	s.abandoned.Wait()
+}
+
+// showdown reveals the hands in showdown order. Players who may muck are
+// asked by their decision makers, the rest show. It returns the players who
+// mucked.
+func (s *Session) showdown() []int {
+	mucked := []int{}
+	for _, id := range s.game.ShowdownOrder() {
+		player, err := s.game.GetPlayerByID(id)
+		if err != nil {
+			continue
+		}
+		shown := true
+		if decider, ok := holdem_ai.As[holdem_ai.IMuckDecider](s.makers[id]); ok && s.game.CanMuck(id) && decider.ShouldMuck(s.game, player) {
+			shown = false
+		}
+		if shown {
+			err = s.game.Show(id)
+		} else {
+			err = s.game.Muck(id)
+		}
+		if err != nil {
+			s.logger.Warn("reveal refused", slog.Int("player_id", id), slog.Any("error", err))
+			continue
+		}
+		if !shown {
+			mucked = append(mucked, id)
+		}
+		s.emit(Event{Type: EventShowdown, PlayerID: id, Shown: shown})
+	}
+	return mucked
+}
+
+// passiveAction checks when that is legal and folds otherwise
+func passiveAction(game *holdem.Game, player holdem.IPlayer) holdem.Action {
+	if game.GetCurrentBet() <= player.GetBet() {
+		return holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck}
+	}
+	return holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionFold}
+}
+
+// calledDown checks when that is legal and otherwise calls, all in when
+// the call takes every chip
+func calledDown(game *holdem.Game, player holdem.IPlayer) holdem.Action {
+	call := game.GetCurrentBet() - player.GetBet()
+	switch {
+	case call <= 0:
+		return holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck}
+	case call >= player.GetChips():
+		return holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionAllIn, Amount: player.GetChips()}
+	}
+	return holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCall, Amount: call}
+}
+
+// nextButton moves the button to the next occupied seat
+func (s *Session) nextButton() int {
+	for i := 1; i <= 10; i++ {
+		seat := (s.button + i) % 10
+		if s.button < 0 {
+			seat = i - 1
+		}
+		if player, err := s.game.GetPlayerBySit(seat); err == nil && player != nil {
+			return seat
+		}
+	}
+	return -1
+}
+
+// RemoveBusted unseats every player without chips and returns them.
+// Each bust counts against the player's re-entries.
+func (s *Session) RemoveBusted() []holdem.IPlayer {
+	busted := []holdem.IPlayer{}
+	for _, player := range s.game.GetAllPlayers() {
+		if player.GetChips() <= 0 {
+			s.game.PlayerLeave(player)
+			s.busts[player.GetID()]++
+			busted = append(busted, player)
+		}
+	}
+	return busted
+}
+
+func (s *Session) emit(event Event) {
+	event.HandID = s.game.GetHandID()
+	if s.observer != nil {
+		s.observer(event, s.game)
+	}
+}
+
+// abortExhausted calls off the hand in progress when err is the deck running
+// out, refunding what everyone put in, and passes err on
+func (s *Session) abortExhausted(err error) error {
+	if !errors.Is(err, holdem.ErrDeckExhausted) || !s.game.IsHandInProgress() {
+		return err
+	}
+	s.forcing.Store(false)
+	if _, abortErr := s.game.AbortHand(holdem.AbortRefund); abortErr != nil {
+		return errors.Join(err, abortErr)
+	}
+	s.logger.Warn("hand aborted", slog.String("hand_id", s.game.GetHandID()), slog.String("error", err.Error()))
+	return fmt.Errorf("hand %s aborted: %w", s.game.GetHandID(), err)
+}
@@ -0,0 +1,736 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+)
+
+// callingStation calls every bet and checks otherwise
+type callingStation struct{}
+
+func (callingStation) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	toCall := game.GetCurrentBet() - player.GetBet()
+	switch {
+	case toCall <= 0:
+		ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck}
+	case toCall >= player.GetChips():
+		ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionAllIn, Amount: player.GetChips()}
+	default:
+		ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCall, Amount: toCall}
+	}
+	close(ch)
+	return ch
+}
+
+// limper calls whatever is owed, even nothing, and never checks
+type limper struct{}
+
+func (limper) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCall, Amount: max(game.GetCurrentBet()-player.GetBet(), 0)}
+	close(ch)
+	return ch
+}
+
+// illegalMaker always proposes a raise bigger than any stack
+type illegalMaker struct{}
+
+func (illegalMaker) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	ch <- holdem.NewRaise(player.GetID(), 1<<30)
+	close(ch)
+	return ch
+}
+
+// silentMaker never decides
+type silentMaker struct{}
+
+func (silentMaker) MakeDecision(*holdem.Game, holdem.IPlayer) <-chan holdem.Action {
+	return make(chan holdem.Action)
+}
+
+// folder folds at every turn
+type folder struct{}
+
+func (folder) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionFold}
+	close(ch)
+	return ch
+}
+
+// disconnectedMaker has lost its player, as a remote decision maker whose
+// connection dropped
+type disconnectedMaker struct{}
+
+func (disconnectedMaker) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	ch <- holdem.NewDisconnect(player.GetID())
+	close(ch)
+	return ch
+}
+
+func newTestSession(t *testing.T, stacks ...int) *Session {
+	t.Helper()
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	for i, stack := range stacks {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", stack), i*2); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	return New(game)
+}
+
+func TestPlayHandConservesChipsAndMovesButton(t *testing.T) {
+	s := newTestSession(t, 500, 500, 500, 500)
+	for id := 1; id <= 4; id++ {
+		s.SetDecisionMaker(id, callingStation{})
+	}
+
+	buttons := []int{}
+	for hand := 0; hand < 30; hand++ {
+		if len(s.GetGame().GetAllPlayers()) < 2 {
+			break
+		}
+		result, err := s.PlayHand(context.Background())
+		if err != nil {
+			t.Fatalf("PlayHand failed: %v", err)
+		}
+		buttons = append(buttons, result.Button)
+
+		net, total := 0, 0
+		for _, chips := range result.Net {
+			net += chips
+		}
+		for _, player := range s.GetGame().GetAllPlayers() {
+			total += player.GetChips()
+		}
+		if net != 0 || total != 2000 {
+			t.Fatalf("Hand %d: chips not conserved (net %d, total %d)", result.HandNumber, net, total)
+		}
+		if !result.Showdown {
+			t.Errorf("Hand %d: calling stations should always reach showdown", result.HandNumber)
+		}
+		s.RemoveBusted()
+	}
+	if len(buttons) < 2 || buttons[0] != 0 || buttons[1] != 2 {
+		t.Errorf("Expected the button to move from seat 0 to seat 2, got %v", buttons)
+	}
+}
+
+func TestIllegalDecisionFallsBackToFold(t *testing.T) {
+	s := newTestSession(t, 500, 500, 500)
+	s.SetDecisionMaker(1, illegalMaker{})
+	s.SetDecisionMaker(2, callingStation{})
+	s.SetDecisionMaker(3, callingStation{})
+
+	var actions []holdem.Action
+	s.SetObserver(func(event Event, game *holdem.Game) {
+		if event.Type == EventAction && event.PlayerID == 1 {
+			actions = append(actions, event.Action)
+		}
+	})
+	if _, err := s.PlayHand(context.Background()); err != nil {
+		t.Fatalf("PlayHand failed: %v", err)
+	}
+	if len(actions) != 1 || actions[0].Type != holdem.ActionFold {
+		t.Errorf("Expected the illegal raise to be replaced by a fold, got %+v", actions)
+	}
+}
+
+func TestDisconnectPolicy(t *testing.T) {
+	for _, policy := range []holdem.DisconnectPolicy{holdem.DisconnectFold, holdem.DisconnectAllIn} {
+		game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3, Disconnect: policy})
+		for i := 0; i < 3; i++ {
+			game.PlayerSit(holdem.NewPlayer(i+1, "", 500), i*2)
+		}
+		s := New(game)
+		s.SetDecisionMaker(1, disconnectedMaker{})
+		s.SetDecisionMaker(2, callingStation{})
+		s.SetDecisionMaker(3, callingStation{})
+
+		var actions []holdem.Action
+		s.SetObserver(func(event Event, game *holdem.Game) {
+			if event.Type == EventAction && event.PlayerID == 1 {
+				actions = append(actions, event.Action)
+			}
+		})
+		result, err := s.PlayHand(context.Background())
+		if err != nil {
+			t.Fatalf("PlayHand failed: %v", err)
+		}
+
+		// The button faces the big blind: folded, or kept in for nothing
+		want := holdem.ActionFold
+		if policy == holdem.DisconnectAllIn {
+			want = holdem.ActionDisconnect
+		}
+		if len(actions) != 1 || actions[0].Type != want {
+			t.Errorf("Policy %d: expected a single %s, got %+v", policy, holdem.ActionTypeToString(want), actions)
+		}
+		player, _ := game.GetPlayerByID(1)
+		if policy == holdem.DisconnectAllIn && (player.IsFolded() || player.GetChips() != 500 || !result.Showdown) {
+			t.Errorf("Expected the protected button at the showdown with 500 behind, got folded %v chips %d", player.IsFolded(), player.GetChips())
+		}
+	}
+}
+
+// runItTwiceVariant deals Omaha hands and, on the river, a whole second
+// board, as a table running it twice at the river would. It registers as
+// Omaha, which it stands in for while a test runs.
+type runItTwiceVariant struct {
+	holdem.OmahaVariant
+}
+
+func (v runItTwiceVariant) BoardCards(phase holdem.GamePhase) int {
+	if phase == holdem.PhaseRiver {
+		return 6
+	}
+	return v.OmahaVariant.BoardCards(phase)
+}
+
+func TestPlayHandAbortsWhenDeckRunsOut(t *testing.T) {
+	omaha := holdem.VariantOmaha.Rules()
+	holdem.RegisterVariant(runItTwiceVariant{})
+	t.Cleanup(func() { holdem.RegisterVariant(omaha) })
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3, Variant: holdem.VariantOmaha})
+	for seat := 0; seat < 10; seat++ {
+		if err := game.PlayerSit(holdem.NewPlayer(seat+1, "", 500), seat); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	s := New(game)
+	for id := 1; id <= 10; id++ {
+		s.SetDecisionMaker(id, callingStation{})
+	}
+	streets := 0
+	s.SetObserver(func(event Event, game *holdem.Game) {
+		if event.Type == EventStreet {
+			streets++
+		}
+	})
+
+	// 40 hole cards, the flop and the turn leave 6 cards for a river of 7
+	if _, err := s.PlayHand(context.Background()); !errors.Is(err, holdem.ErrDeckExhausted) {
+		t.Fatalf("Expected the river to exhaust the deck, got %v", err)
+	}
+	if streets != 2 || game.IsHandInProgress() {
+		t.Errorf("Expected the hand aborted after the turn, got %d streets and in progress %v", streets, game.IsHandInProgress())
+	}
+	for _, player := range game.GetAllPlayers() {
+		if player.GetChips() != 500 {
+			t.Errorf("Expected player %d refunded to 500, got %d", player.GetID(), player.GetChips())
+		}
+	}
+	if _, err := s.UndoLastHand(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Expected nothing to undo after an aborted hand, got %v", err)
+	}
+}
+
+func TestPlayHandStopsOnCancel(t *testing.T) {
+	s := newTestSession(t, 500, 500)
+	s.SetDecisionMaker(1, silentMaker{})
+	s.SetDecisionMaker(2, silentMaker{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := s.PlayHand(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+
+	// The interrupted hand is abandoned and play carries on from a restored button
+	if _, err := s.GetGame().AbortHand(holdem.AbortRefund); err != nil {
+		t.Fatalf("AbortHand failed: %v", err)
+	}
+	s.SetDecisionMaker(1, callingStation{})
+	s.SetDecisionMaker(2, callingStation{})
+	s.SetButton(2)
+	result, err := s.PlayHand(context.Background())
+	if err != nil {
+		t.Fatalf("PlayHand after abort failed: %v", err)
+	}
+	if result.Button != 0 {
+		t.Errorf("Expected the button to move on from seat 2 to seat 0, got %d", result.Button)
+	}
+}
+
+func TestObserverSeesHandFlow(t *testing.T) {
+	s := newTestSession(t, 500, 500)
+	s.SetDecisionMaker(1, callingStation{})
+	s.SetDecisionMaker(2, callingStation{})
+
+	counts := map[EventType]int{}
+	s.SetObserver(func(event Event, game *holdem.Game) {
+		counts[event.Type]++
+	})
+	if _, err := s.PlayHand(context.Background()); err != nil {
+		t.Fatalf("PlayHand failed: %v", err)
+	}
+	if counts[EventHandStarted] != 1 || counts[EventStreet] != 3 || counts[EventHandFinished] != 1 {
+		t.Errorf("Unexpected event counts %v", counts)
+	}
+	if counts[EventTurn] != counts[EventAction] {
+		t.Errorf("Expected a turn event before every action, got %v", counts)
+	}
+}
+
+func TestEventsCarryHandID(t *testing.T) {
+	s := newTestSession(t, 500, 500)
+	s.SetDecisionMaker(1, callingStation{})
+	s.SetDecisionMaker(2, callingStation{})
+
+	handIDs := map[string]bool{}
+	s.SetObserver(func(event Event, game *holdem.Game) {
+		handIDs[event.HandID] = true
+	})
+	first, err := s.PlayHand(context.Background())
+	if err != nil {
+		t.Fatalf("PlayHand failed: %v", err)
+	}
+	if len(handIDs) != 1 || !handIDs[first.HandID] || first.HandID == "" {
+		t.Errorf("Expected every event stamped with hand %q, got %v", first.HandID, handIDs)
+	}
+	second, err := s.PlayHand(context.Background())
+	if err != nil {
+		t.Fatalf("PlayHand failed: %v", err)
+	}
+	if second.HandID <= first.HandID {
+		t.Errorf("Expected hand IDs to sort in order, got %q after %q", second.HandID, first.HandID)
+	}
+	if s.GetID() == "" || s.GetID() == New(s.GetGame()).GetID() {
+		t.Errorf("Expected a unique session ID, got %q", s.GetID())
+	}
+}
+
+func TestForceStreetCallsDownOneStreet(t *testing.T) {
+	s := newTestSession(t, 500, 500, 500)
+	for id := 1; id <= 3; id++ {
+		s.SetDecisionMaker(id, folder{})
+	}
+
+	actions := map[holdem.GamePhase][]holdem.ActionType{}
+	s.SetObserver(func(event Event, game *holdem.Game) {
+		if event.Type == EventAction {
+			actions[game.GetCurrentPhase()] = append(actions[game.GetCurrentPhase()], event.Action.Type)
+		}
+	})
+	s.ForceStreet()
+	if _, err := s.PlayHand(context.Background()); err != nil {
+		t.Fatalf("PlayHand failed: %v", err)
+	}
+	for _, actionType := range actions[holdem.PhasePreflop] {
+		if actionType != holdem.ActionCall && actionType != holdem.ActionCheck {
+			t.Errorf("Expected preflop to be called down, got %v", actions[holdem.PhasePreflop])
+		}
+	}
+	if flop := actions[holdem.PhaseFlop]; len(flop) != 2 || flop[0] != holdem.ActionFold {
+		t.Errorf("Expected the players to decide again on the flop, got %v", flop)
+	}
+}
+
+func TestBigBlindOptionCallBecomesCheck(t *testing.T) {
+	s := newTestSession(t, 500, 500)
+	s.SetDecisionMaker(1, limper{})
+	s.SetDecisionMaker(2, limper{})
+	var logs strings.Builder
+	s.SetLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	optionPlayer := 0
+	var optionAction *holdem.Action
+	s.SetObserver(func(event Event, game *holdem.Game) {
+		switch {
+		case event.Type == EventTurn && event.Option:
+			optionPlayer = event.PlayerID
+		case event.Type == EventAction && event.PlayerID == optionPlayer && optionAction == nil:
+			optionAction = &event.Action
+		}
+	})
+	if _, err := s.PlayHand(context.Background()); err != nil {
+		t.Fatalf("PlayHand failed: %v", err)
+	}
+	if optionAction == nil {
+		t.Fatal("Expected the big blind to get the option after a limp")
+	}
+	if optionAction.Type != holdem.ActionCheck {
+		t.Errorf("Expected calling nothing on the option to check, got %s", holdem.ActionTypeToString(optionAction.Type))
+	}
+	if strings.Contains(logs.String(), "illegal decision") && strings.Contains(logs.String(), "option") {
+		t.Errorf("Expected the option call to be converted, not replaced: %s", logs.String())
+	}
+}
+
+func TestSessionTotalsRake(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3, RakePercent: 5})
+	for i := 0; i < 3; i++ {
+		game.PlayerSit(holdem.NewPlayer(i+1, "", 500), i)
+	}
+	s := New(game)
+	for id := 1; id <= 3; id++ {
+		s.SetDecisionMaker(id, callingStation{})
+	}
+	total := 0
+	for hand := 0; hand < 3; hand++ {
+		result, err := s.PlayHand(context.Background())
+		if err != nil {
+			t.Fatalf("PlayHand failed: %v", err)
+		}
+		net := 0
+		for _, chips := range result.Net {
+			net += chips
+		}
+		if result.Rake == 0 || net != -result.Rake {
+			t.Errorf("Hand %d: expected the rake to leave the table, got rake %d and net %d", result.HandNumber, result.Rake, net)
+		}
+		total += result.Rake
+	}
+	if s.GetRake() != total {
+		t.Errorf("Expected a session rake of %d, got %d", total, s.GetRake())
+	}
+}
+
+// mucker calls down like a calling station and mucks every losing hand
+type mucker struct{ callingStation }
+
+func (mucker) ShouldMuck(*holdem.Game, holdem.IPlayer) bool {
+	return true
+}
+
+func TestShowdownLosersMuck(t *testing.T) {
+	s := newTestSession(t, 500, 500, 500)
+	for id := 1; id <= 3; id++ {
+		s.SetDecisionMaker(id, mucker{})
+	}
+	var mucked []int
+	var net map[int]int
+	var reveals []holdem.Reveal
+	s.SetObserver(func(event Event, game *holdem.Game) {
+		switch event.Type {
+		case EventShowdown:
+			reveals = append(reveals, holdem.Reveal{PlayerID: event.PlayerID, Shown: event.Shown})
+		case EventHandFinished:
+			mucked, net = event.Mucked, event.Net
+		}
+	})
+	result, err := s.PlayHand(context.Background())
+	if err != nil {
+		t.Fatalf("PlayHand failed: %v", err)
+	}
+	if !result.Showdown {
+		t.Fatal("Expected calling stations to reach showdown")
+	}
+	if len(result.Mucked) != len(mucked) {
+		t.Errorf("Expected the event to carry the mucks %v, got %v", result.Mucked, mucked)
+	}
+	for id, chips := range result.Net {
+		if net[id] != chips {
+			t.Errorf("Expected the event to carry player %d's net %d, got %d", id, chips, net[id])
+		}
+	}
+
+	winners := map[int]bool{}
+	for _, award := range result.Awards {
+		for _, id := range award.Winners {
+			winners[id] = true
+		}
+	}
+	if len(result.Mucked) != 3-len(winners) {
+		t.Errorf("Expected every loser to muck, winners %v mucked %v", winners, result.Mucked)
+	}
+	for _, id := range result.Mucked {
+		if winners[id] {
+			t.Errorf("Winner %d must not muck", id)
+		}
+		if !s.GetGame().IsMucked(id) {
+			t.Errorf("Expected player %d mucked in the game", id)
+		}
+	}
+
+	order := s.GetGame().ShowdownOrder()
+	if len(reveals) != len(order) {
+		t.Fatalf("Expected a showdown event for each of %v, got %+v", order, reveals)
+	}
+	for i, reveal := range reveals {
+		if reveal.PlayerID != order[i] || reveal.Shown != !s.GetGame().IsMucked(reveal.PlayerID) {
+			t.Errorf("Showdown event %d: expected player %d in showdown order, got %+v", i, order[i], reveal)
+		}
+	}
+}
+
+// slowStation calls down like a calling station and claims to think for 3s
+type slowStation struct{ callingStation }
+
+func (m slowStation) MakeTimedDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem_ai.TimedDecision {
+	ch := make(chan holdem_ai.TimedDecision, 1)
+	ch <- holdem_ai.TimedDecision{Action: <-m.MakeDecision(game, player), Thinking: 3 * time.Second}
+	close(ch)
+	return ch
+}
+
+func TestDecisionTimesAreRecorded(t *testing.T) {
+	s := newTestSession(t, 500, 500)
+	s.SetDecisionMaker(1, slowStation{})
+	s.SetDecisionMaker(2, callingStation{})
+
+	elapsed := map[int][]time.Duration{}
+	s.SetObserver(func(event Event, game *holdem.Game) {
+		if event.Type == EventAction {
+			elapsed[event.PlayerID] = append(elapsed[event.PlayerID], event.Elapsed)
+		}
+	})
+	if _, err := s.PlayHand(context.Background()); err != nil {
+		t.Fatalf("PlayHand failed: %v", err)
+	}
+	for _, d := range elapsed[1] {
+		if d != 3*time.Second {
+			t.Errorf("Expected the simulated 3s for the timed player, got %v", d)
+		}
+	}
+	for _, d := range elapsed[2] {
+		if d >= time.Second {
+			t.Errorf("Expected the wall-clock latency for the instant player, got %v", d)
+		}
+	}
+	timed := 0
+	for _, logged := range s.GetGame().GetHandActionLog() {
+		if logged.Action.PlayerID == 1 && logged.Elapsed == 3*time.Second {
+			timed++
+		}
+	}
+	if timed == 0 || timed != len(elapsed[1]) {
+		t.Errorf("Expected every timed decision in the journal, got %d of %d", timed, len(elapsed[1]))
+	}
+}
+
+func BenchmarkPlayHand(b *testing.B) {
+	// Six calling stations see every street and a showdown; stacks are
+	// reset between hands so nobody busts
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	for i := 0; i < 6; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i); err != nil {
+			b.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	s := New(game)
+	for id := 1; id <= 6; id++ {
+		s.SetDecisionMaker(id, callingStation{})
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.PlayHand(context.Background()); err != nil {
+			b.Fatalf("PlayHand failed: %v", err)
+		}
+		for _, player := range game.GetAllPlayers() {
+			player.GrandChips(1000 - player.GetChips())
+		}
+	}
+}
+
+// resultStation is a calling station that records the result of each hand
+type resultStation struct {
+	callingStation
+	nets map[int][]int
+}
+
+func (r *resultStation) HandFinished(game *holdem.Game, player holdem.IPlayer, net int) {
+	r.nets[player.GetID()] = append(r.nets[player.GetID()], net)
+}
+
+func TestDecisionMakersLearnHandResults(t *testing.T) {
+	s := newTestSession(t, 500, 500)
+	station := &resultStation{nets: map[int][]int{}}
+	s.SetDecisionMaker(1, station)
+	s.SetDecisionMaker(2, station)
+	result, err := s.PlayHand(context.Background())
+	if err != nil {
+		t.Fatalf("PlayHand failed: %v", err)
+	}
+	for id, net := range result.Net {
+		if got := station.nets[id]; len(got) != 1 || got[0] != net {
+			t.Errorf("Expected player %d to learn a net of %d, got %v", id, net, got)
+		}
+	}
+}
+
+// chattyStation is a calling station that has something to say after every hand
+type chattyStation struct {
+	callingStation
+}
+
+func (chattyStation) TableTalk(game *holdem.Game, player holdem.IPlayer, net int) (string, bool) {
+	return "talk.won_big.dry.1", net > 0
+}
+
+func TestTalkersChatAfterTheHand(t *testing.T) {
+	s := newTestSession(t, 500, 500)
+	s.SetDecisionMaker(1, chattyStation{})
+	s.SetDecisionMaker(2, chattyStation{})
+	var types []EventType
+	var chats []Event
+	s.SetObserver(func(event Event, game *holdem.Game) {
+		types = append(types, event.Type)
+		if event.Type == EventChat {
+			chats = append(chats, event)
+		}
+	})
+	result, err := s.PlayHand(context.Background())
+	if err != nil {
+		t.Fatalf("PlayHand failed: %v", err)
+	}
+	for _, chat := range chats {
+		if result.Net[chat.PlayerID] <= 0 || chat.Message != "talk.won_big.dry.1" {
+			t.Errorf("Expected only the winner to chat, got %+v", chat)
+		}
+	}
+	if len(chats) != 1 || types[len(types)-2] != EventHandFinished {
+		t.Errorf("Expected the winner to chat once right after the hand, got events %v", types)
+	}
+}
+
+// hungBot thinks until released, then decides like a calling station
+type hungBot struct {
+	callingStation
+	release chan struct{}
+}
+
+func (m hungBot) MakeTimedDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem_ai.TimedDecision {
+	ch := make(chan holdem_ai.TimedDecision, 1)
+	go func() {
+		defer close(ch)
+		<-m.release
+	}()
+	return ch
+}
+
+func TestDecisionsTimeOut(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	for _, maker := range []holdem_ai.IDecisionMaker{silentMaker{}, hungBot{release: release}} {
+		s := newTestSession(t, 500, 500)
+		s.SetDecisionMaker(1, maker)
+		s.SetDecisionMaker(2, maker)
+		s.SetDecisionClock(40*time.Millisecond, 20*time.Millisecond)
+		var events []Event
+		s.SetObserver(func(event Event, game *holdem.Game) {
+			switch event.Type {
+			case EventTurn:
+				if event.Timeout != 40*time.Millisecond {
+					t.Errorf("%T: Expected the turn to carry the clock, got %v", maker, event.Timeout)
+				}
+			case EventTimeWarning, EventAction:
+				events = append(events, event)
+			}
+		})
+		if _, err := s.PlayHand(context.Background()); err != nil {
+			t.Fatalf("%T: PlayHand failed: %v", maker, err)
+		}
+
+		// The small blind is warned, then folds to the big blind's bet
+		if len(events) != 2 || events[0].Type != EventTimeWarning || events[0].Left != 20*time.Millisecond {
+			t.Fatalf("%T: Expected a warning and a timed out action, got %+v", maker, events)
+		}
+		if action := events[1]; !action.TimedOut || action.PlayerID != events[0].PlayerID || action.Action.Type != holdem.ActionFold {
+			t.Errorf("%T: Expected the warned player to fold when time ran out, got %+v", maker, action)
+		}
+	}
+}
+
+// lateReader thinks past the clock, then reads the table to decide like a
+// calling station, as a hanging bot that comes back after its time ran out
+type lateReader struct {
+	delay   time.Duration
+	reading *sync.WaitGroup
+}
+
+func (m lateReader) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	m.reading.Add(1)
+	go func() {
+		defer m.reading.Done()
+		defer close(ch)
+		time.Sleep(m.delay)
+		chips := 0
+		for _, other := range game.GetAllPlayers() {
+			chips += other.GetChips() + other.GetBet()
+		}
+		if game.GetPot() < 0 || chips < 0 || len(game.GetCommunityCards()) > 5 {
+			return
+		}
+		ch <- <-callingStation{}.MakeDecision(game, player)
+	}()
+	return ch
+}
+
+func TestLateDecisionsDoNotRaceTheTable(t *testing.T) {
+	var reading sync.WaitGroup
+	s := newTestSession(t, 500, 500, 500)
+	for id := 1; id <= 3; id++ {
+		s.SetDecisionMaker(id, lateReader{delay: 20 * time.Millisecond, reading: &reading})
+	}
+	s.SetDecisionClock(5*time.Millisecond, 0)
+	for hand := 0; hand < 5; hand++ {
+		if _, err := s.PlayHand(context.Background()); err != nil {
+			t.Fatalf("PlayHand failed: %v", err)
+		}
+	}
+	reading.Wait()
+	total := 0
+	for _, player := range s.GetGame().GetAllPlayers() {
+		total += player.GetChips()
+	}
+	if total != 1500 {
+		t.Errorf("Expected 1500 chips in play, got %d", total)
+	}
+}
+
+// warmer is a calling station that counts its warm-ups
+type warmer struct {
+	callingStation
+	configs []holdem_ai.WarmupConfig
+}
+
+func (w *warmer) Warmup(ctx context.Context, config holdem_ai.WarmupConfig) error {
+	w.configs = append(w.configs, config)
+	return nil
+}
+
+func TestDecisionMakersWarmUpOnce(t *testing.T) {
+	s := newTestSession(t, 500, 500)
+	first, second := &warmer{}, &warmer{}
+	s.SetDecisionMaker(1, first)
+	s.SetDecisionMaker(2, second)
+
+	var progress [][2]int
+	if err := s.Warmup(context.Background(), func(done, total int) {
+		progress = append(progress, [2]int{done, total})
+	}); err != nil {
+		t.Fatalf("Warmup failed: %v", err)
+	}
+	if len(progress) != 3 || progress[0] != [2]int{0, 2} || progress[2] != [2]int{2, 2} {
+		t.Errorf("Expected progress from 0/2 to 2/2, got %v", progress)
+	}
+	if len(first.configs) != 1 || first.configs[0].Seats != 2 || first.configs[0].BigBlind != s.GetGame().GetBigBlind() {
+		t.Errorf("Expected one warm-up for a two-seat table, got %+v", first.configs)
+	}
+
+	// Hands warm up only decision makers that have not yet
+	third := &warmer{}
+	s.SetDecisionMaker(2, third)
+	for i := 0; i < 2; i++ {
+		if _, err := s.PlayHand(context.Background()); err != nil {
+			t.Fatalf("PlayHand failed: %v", err)
+		}
+	}
+	if len(first.configs) != 1 || len(second.configs) != 1 || len(third.configs) != 1 {
+		t.Errorf("Expected every decision maker warmed up once, got %d, %d and %d", len(first.configs), len(second.configs), len(third.configs))
+	}
+}
@@ -0,0 +1,120 @@
+package session
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// ErrSessionOver is returned by PlayHand once a timed session played its
+// last hand, see SetEndTime
+var ErrSessionOver = errors.New("session is over")
+
+// LastHands is how many hands a timed session announces before it ends
+const LastHands = 3
+
+// timedSession tracks a session that ends at a wall-clock time
+type timedSession struct {
+	end      time.Time
+	handTime time.Duration // Time taken by the hands played so far
+	hands    int           // Hands played so far
+	left     int           // Hands left once the last hands were announced, 0 before
+	started  time.Time     // Start of the hand in play
+}
+
+// average returns the average time a hand took, 0 before the first
+func (t *timedSession) average() time.Duration {
+	if t.hands == 0 {
+		return 0
+	}
+	return t.handTime / time.Duration(t.hands)
+}
+
+// SetEndTime makes the session a timed ring game ending at the time, as home
+// games often are. Once the hands left at the average pace no longer fill
+// the time, the last LastHands hands are announced with EventLastHands, and
+// a hand still dealt after the time is the last one. PlayHand then returns
+// ErrSessionOver. The zero time plays on without end.
+func (s *Session) SetEndTime(at time.Time) {
+	if at.IsZero() {
+		s.timed = nil
+		return
+	}
+	s.timed = &timedSession{end: at}
+}
+
+// GetEndTime returns when the session ends, the zero time when it is not timed
+func (s *Session) GetEndTime() time.Time {
+	if s.timed == nil {
+		return time.Time{}
+	}
+	return s.timed.end
+}
+
+// HandsLeft returns how many hands a timed session has left, counting the
+// one in play, once the last hands were announced. It is 0 before.
+func (s *Session) HandsLeft() int {
+	if s.timed == nil {
+		return 0
+	}
+	return max(s.timed.left, 0)
+}
+
+// IsOver reports whether a timed session played its last hand
+func (s *Session) IsOver() bool {
+	return s.timed != nil && s.timed.left < 0
+}
+
+// startTimedHand refuses to deal once the session is over and otherwise
+// starts timing the hand
+func (s *Session) startTimedHand() error {
+	if s.timed == nil {
+		return nil
+	}
+	if s.IsOver() {
+		return ErrSessionOver
+	}
+	s.timed.started = s.now()
+	return nil
+}
+
+// announceLastHands counts down the hand just dealt once the time runs out,
+// emitting EventLastHands with the hands left including it
+func (s *Session) announceLastHands() {
+	timed := s.timed
+	if timed == nil {
+		return
+	}
+	if timed.left == 0 {
+		remaining := timed.end.Sub(timed.started)
+		switch {
+		case remaining <= 0:
+			timed.left = 1
+		case remaining <= LastHands*timed.average():
+			timed.left = LastHands
+		default:
+			return
+		}
+		s.logger.Info("last hands announced", slog.Int("hands", timed.left), slog.Time("end", timed.end))
+	}
+	s.emit(Event{Type: EventLastHands, HandsLeft: timed.left})
+}
+
+// finishTimedHand adds the hand to the average pace and, when it was the last
+// one, ends the session
+func (s *Session) finishTimedHand() {
+	timed := s.timed
+	if timed == nil {
+		return
+	}
+	timed.handTime += s.now().Sub(timed.started)
+	timed.hands++
+	if timed.left == 0 {
+		return
+	}
+	timed.left--
+	if timed.left == 0 {
+		timed.left = -1
+		s.logger.Info("timed session over", slog.Int("hands", timed.hands))
+	}
+}
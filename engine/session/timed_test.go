@@ -0,0 +1,83 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// newTimedSession starts a heads-up timed session at 20:00 whose hands take
+// ten minutes each, and records the hands left announced at every hand
+func newTimedSession(t *testing.T, end time.Duration) (*Session, *[]int) {
+	t.Helper()
+	s := newTestSession(t, 5000, 5000)
+	for id := 1; id <= 2; id++ {
+		s.SetDecisionMaker(id, callingStation{})
+	}
+	now := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	s.SetClock(func() time.Time { return now })
+	s.SetEndTime(now.Add(end))
+	announced := []int{}
+	s.SetObserver(func(event Event, game *holdem.Game) {
+		switch event.Type {
+		case EventHandStarted:
+			now = now.Add(10 * time.Minute)
+			announced = append(announced, 0)
+		case EventLastHands:
+			announced[len(announced)-1] = event.HandsLeft
+		}
+	})
+	return s, &announced
+}
+
+func TestTimedSessionAnnouncesLastHands(t *testing.T) {
+	s, announced := newTimedSession(t, time.Hour)
+	for hand := 0; hand < 6; hand++ {
+		if _, err := s.PlayHand(context.Background()); err != nil {
+			t.Fatalf("PlayHand %d failed: %v", hand+1, err)
+		}
+	}
+	// At ten minutes a hand, three more fill the half hour left after hand 3
+	if want := []int{0, 0, 0, 3, 2, 1}; !slices.Equal(*announced, want) {
+		t.Errorf("Expected the last three hands announced from hand 4, got %v", *announced)
+	}
+	if !s.IsOver() || s.HandsLeft() != 0 {
+		t.Errorf("Expected the session over, got %d hands left", s.HandsLeft())
+	}
+	if _, err := s.PlayHand(context.Background()); !errors.Is(err, ErrSessionOver) {
+		t.Errorf("Expected no hand dealt after the last, got %v", err)
+	}
+
+	// Undoing the last hand deals it again
+	if _, err := s.UndoLastHand(); err != nil {
+		t.Fatalf("UndoLastHand failed: %v", err)
+	}
+	if s.IsOver() || s.HandsLeft() != 1 {
+		t.Errorf("Expected the last hand to be played again, got %d hands left", s.HandsLeft())
+	}
+}
+
+func TestTimedSessionFinishesHandPastEndTime(t *testing.T) {
+	s, announced := newTimedSession(t, 5*time.Minute)
+	// The first hand has no pace to go by and runs past the end time
+	for hand := 0; hand < 2; hand++ {
+		if _, err := s.PlayHand(context.Background()); err != nil {
+			t.Fatalf("PlayHand %d failed: %v", hand+1, err)
+		}
+	}
+	if want := []int{0, 1}; !slices.Equal(*announced, want) {
+		t.Errorf("Expected the hand dealt after the end time to be the last, got %v", *announced)
+	}
+	if !s.IsOver() {
+		t.Error("Expected the session over")
+	}
+
+	s.SetEndTime(time.Time{})
+	if s.IsOver() || !s.GetEndTime().IsZero() {
+		t.Error("Expected the zero time to play on without end")
+	}
+}
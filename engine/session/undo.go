@@ -0,0 +1,94 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// ErrNothingToUndo is returned by UndoLastHand when no hand can be undone
+var ErrNothingToUndo = errors.New("no hand to undo")
+
+// handStart is the table as the last hand found it, see UndoLastHand
+type handStart struct {
+	table  *holdem.Snapshot
+	button int // Session button before the hand
+	rake   int // Session rake before the hand
+	left   int // Hands left of a timed session before the hand
+
+	hand   int         // Number of the hand, set once it finished
+	handID string      // ID of the hand, set once it finished
+	net    map[int]int // The hand's result, taken back from the session limits
+}
+
+// UndoLastHand puts the table back as it was before the last hand, for a
+// misdeal or a rules dispute found after the pot was pushed: stacks are
+// restored, players who busted in the hand sit down again and the button
+// moves back so the hand is dealt again from the same seat. Rake, the
+// session limits, the winnings and the last hands countdown forget the hand too.
+//
+// Only the last hand can be undone, between hands and until a player buys
+// in, tops up or cashes out. It returns the number of the hand undone.
+func (s *Session) UndoLastHand() (int, error) {
+	last := s.last
+	if last == nil || last.handID == "" {
+		return 0, ErrNothingToUndo
+	}
+	if s.game.IsHandInProgress() {
+		return 0, fmt.Errorf("hand %d is still in progress", s.game.GetHandNumber())
+	}
+	for _, seat := range last.table.Seats {
+		if player, err := s.game.GetPlayerByID(seat.PlayerID); err == nil {
+			player.GrandChips(seat.Chips - player.GetChips())
+			continue
+		}
+		// Busted in the hand and removed since
+		if err := s.game.PlayerSit(holdem.NewPlayer(seat.PlayerID, seat.Name, seat.Chips), seat.Seat); err != nil {
+			return 0, fmt.Errorf("reseat player %d: %w", seat.PlayerID, err)
+		}
+		if s.busts[seat.PlayerID] > 0 {
+			s.busts[seat.PlayerID]--
+		}
+	}
+	s.button, s.rake = last.button, last.rake
+	if len(s.winnings) > 0 {
+		s.winnings = s.winnings[:len(s.winnings)-1]
+	}
+	for id, tracked := range s.limits {
+		tracked.net -= last.net[id]
+	}
+	if s.timed != nil {
+		s.timed.left = last.left
+	}
+	s.last = nil
+	s.logger.Info("hand undone", slog.Int("hand", last.hand), slog.String("hand_id", last.handID))
+	return last.hand, nil
+}
+
+// CanUndo reports whether UndoLastHand has a hand to undo
+func (s *Session) CanUndo() bool {
+	return s.last != nil && s.last.handID != "" && !s.game.IsHandInProgress()
+}
+
+// rememberHandStart keeps the table before a hand is dealt for UndoLastHand
+func (s *Session) rememberHandStart() {
+	s.last = nil
+	table, err := s.game.Snapshot()
+	if err != nil {
+		return
+	}
+	s.last = &handStart{table: table, button: s.button, rake: s.rake}
+	if s.timed != nil {
+		s.last.left = s.timed.left
+	}
+}
+
+// rememberHandResult makes the hand that just finished the one UndoLastHand undoes
+func (s *Session) rememberHandResult(result *HandResult) {
+	if s.last == nil {
+		return
+	}
+	s.last.hand, s.last.handID, s.last.net = result.HandNumber, result.HandID, result.Net
+}
@@ -0,0 +1,110 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestUndoLastHandRestoresTheTable(t *testing.T) {
+	s := newTestSession(t, 500, 500, 500)
+	for id := 1; id <= 3; id++ {
+		s.SetDecisionMaker(id, callingStation{})
+	}
+	s.SetLimits(1, Limits{StopLoss: 1000})
+	if _, err := s.UndoLastHand(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("Expected nothing to undo before the first hand, got %v", err)
+	}
+	if _, err := s.PlayHand(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	stacks := map[int]int{}
+	for _, player := range s.GetGame().GetAllPlayers() {
+		stacks[player.GetID()] = player.GetChips()
+	}
+	net := s.GetSessionNet(1)
+	result, err := s.PlayHand(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.CanUndo() {
+		t.Fatal("Expected the hand to be undoable")
+	}
+	hand, err := s.UndoLastHand()
+	if err != nil || hand != result.HandNumber {
+		t.Fatalf("Expected hand %d undone, got %d: %v", result.HandNumber, hand, err)
+	}
+	for _, player := range s.GetGame().GetAllPlayers() {
+		if player.GetChips() != stacks[player.GetID()] {
+			t.Errorf("Expected player %d back at %d, got %d", player.GetID(), stacks[player.GetID()], player.GetChips())
+		}
+	}
+	if got := s.GetSessionNet(1); got != net {
+		t.Errorf("Expected the session net back at %d, got %d", net, got)
+	}
+	if _, err := s.UndoLastHand(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Expected only one hand to be undone, got %v", err)
+	}
+
+	// The hand is dealt again with the same button
+	again, err := s.PlayHand(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Button != result.Button {
+		t.Errorf("Expected the button on seat %d again, got %d", result.Button, again.Button)
+	}
+}
+
+func TestUndoLastHandReseatsBustedPlayers(t *testing.T) {
+	s := newTestSession(t, 500, 10)
+	for id := 1; id <= 2; id++ {
+		s.SetDecisionMaker(id, callingStation{})
+	}
+	for hand := 0; hand < 200 && len(s.GetGame().GetAllPlayers()) == 2; hand++ {
+		if _, err := s.PlayHand(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		s.RemoveBusted()
+	}
+	busted := 1
+	if _, err := s.GetGame().GetPlayerByID(1); err == nil {
+		busted = 2
+	}
+	if s.GetBusts(busted) != 1 {
+		t.Fatalf("Expected player %d to bust, got %d busts", busted, s.GetBusts(busted))
+	}
+
+	if _, err := s.UndoLastHand(); err != nil {
+		t.Fatal(err)
+	}
+	player, err := s.GetGame().GetPlayerByID(busted)
+	if err != nil || player.GetChips() == 0 {
+		t.Fatalf("Expected player %d back with chips, got %v", busted, err)
+	}
+	if s.GetBusts(busted) != 0 {
+		t.Errorf("Expected the bust to be forgotten, got %d", s.GetBusts(busted))
+	}
+}
+
+func TestTopUpRulesOutUndo(t *testing.T) {
+	s := newRulesSession(holdem.GameConfig{})
+	for seat, id := range []int{1, 2} {
+		if err := s.SitDown(holdem.NewPlayer(id, "", 500), seat); err != nil {
+			t.Fatal(err)
+		}
+		s.SetDecisionMaker(id, callingStation{})
+	}
+	if _, err := s.PlayHand(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.TopUp(1, 100); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UndoLastHand(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Expected a top-up to rule out undoing, got %v", err)
+	}
+}
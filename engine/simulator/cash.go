@@ -0,0 +1,184 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/metrics"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+// DefaultHandDuration is how long a simulated hand takes against time limits
+const DefaultHandDuration = 90 * time.Second
+
+// CashGameConfig describes a simulated cash game
+type CashGameConfig struct {
+	Game         holdem.GameConfig
+	BuyIn        int
+	Limits       session.Limits   // Applied to every entrant
+	MaxHands     int              // DefaultMaxHands when zero
+	HandDuration time.Duration    // Simulated time per hand, DefaultHandDuration when zero
+	Recorder     *Recorder        // Collects every hand for export when set
+	ExploitAfter int              // Hands after which bots that can switch play exploitatively, never when zero
+	Metrics      metrics.IMetrics // Receives the game's hands, actions and evaluations when set
+}
+
+// CashResult is how one entrant's cash game ended
+type CashResult struct {
+	Name  string
+	Net   int // Chips won or lost
+	Hands int // Hands dealt in
+	Limit *session.LimitReached
+}
+
+// CashGameResult is the outcome of one simulated cash game
+type CashGameResult struct {
+	Players []CashResult // In entrant order
+	Hands   int
+}
+
+// RunCashGame plays a cash game between the entrants, who get player IDs
+// 1..n in order, until fewer than two are left or MaxHands is reached.
+// Players who reach a session limit cash out, and busted players leave,
+// so limits are enforced without anyone at the keyboard. Time limits run
+// on a simulated clock that advances HandDuration per hand. With
+// ExploitAfter set, bots switch to exploitative play once that many hands
+// have given their opponent models something to go on.
+func RunCashGame(ctx context.Context, entrants []Entrant, config CashGameConfig) (*CashGameResult, error) {
+	if len(entrants) < 2 || len(entrants) > 10 {
+		return nil, fmt.Errorf("need 2 to 10 entrants, got %d", len(entrants))
+	}
+	maxHands := config.MaxHands
+	if maxHands <= 0 {
+		maxHands = DefaultMaxHands
+	}
+	handDuration := config.HandDuration
+	if handDuration <= 0 {
+		handDuration = DefaultHandDuration
+	}
+
+	game := holdem.NewGameWithConfig(config.Game)
+	if config.Metrics != nil {
+		game.SetMetrics(config.Metrics)
+	}
+	s := session.New(game)
+	clock := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.SetClock(func() time.Time { return clock })
+
+	config.Recorder.startRun()
+	result := &CashGameResult{Players: make([]CashResult, len(entrants))}
+	for i, entrant := range entrants {
+		id := i + 1
+		if err := s.SitDown(holdem.NewPlayer(id, entrant.Name, config.BuyIn), i); err != nil {
+			return nil, fmt.Errorf("%s: %w", entrant.Name, err)
+		}
+		s.SetDecisionMaker(id, entrant.Maker)
+		s.SetLimits(id, config.Limits)
+		result.Players[i].Name = entrant.Name
+	}
+
+	for len(game.GetAllPlayers()) >= 2 && result.Hands < maxHands {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if config.ExploitAfter > 0 && result.Hands == config.ExploitAfter {
+			for _, entrant := range entrants {
+				if switcher, ok := holdem_ai.As[holdem_ai.IModeSwitcher](entrant.Maker); ok {
+					switcher.SetMode(holdem_ai.ModeExploitative)
+				}
+			}
+		}
+		for _, player := range game.GetAllPlayers() {
+			result.Players[player.GetID()-1].Hands++
+		}
+		// The hand ends, and limits are checked, once its time has passed
+		clock = clock.Add(handDuration)
+		hand, err := s.PlayHand(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result.Hands++
+		config.Recorder.record(game, hand)
+
+		for _, player := range s.RemoveBusted() {
+			result.Players[player.GetID()-1].Net = -config.BuyIn
+		}
+		for i := range hand.Limits {
+			limit := hand.Limits[i]
+			player := &result.Players[limit.PlayerID-1]
+			player.Limit = &limit
+			if _, err := game.GetPlayerByID(limit.PlayerID); err != nil {
+				continue // Busted on the hand that hit the stop-loss
+			}
+			chips, err := s.StandUp(limit.PlayerID)
+			if err != nil {
+				return nil, err
+			}
+			player.Net = chips - config.BuyIn
+		}
+	}
+	for _, player := range game.GetAllPlayers() {
+		result.Players[player.GetID()-1].Net = player.GetChips() - config.BuyIn
+	}
+	return result, nil
+}
+
+// CashSummary is how one bot did over a batch of cash games. Bots are
+// identified by name, so seats sharing a preset are summed together.
+type CashSummary struct {
+	Name       string
+	Sessions   int
+	Hands      int
+	Net        int // Chips won or lost
+	StopLosses int // Sessions left at each limit
+	StopWins   int
+	TimeLimits int
+}
+
+// PerHundred returns the bot's result in big blinds per 100 hands
+func (s CashSummary) PerHundred(bigBlind int) float64 {
+	if s.Hands == 0 || bigBlind <= 0 {
+		return 0
+	}
+	return float64(s.Net) / float64(bigBlind) / float64(s.Hands) * 100
+}
+
+// SummarizeCashGames adds up each bot's results, best net first, ties in
+// the order the bots were first seated
+func SummarizeCashGames(results []*CashGameResult) []CashSummary {
+	summaries := map[string]*CashSummary{}
+	order := []string{}
+	for _, result := range results {
+		for _, player := range result.Players {
+			summary, ok := summaries[player.Name]
+			if !ok {
+				summary = &CashSummary{Name: player.Name}
+				summaries[player.Name] = summary
+				order = append(order, player.Name)
+			}
+			summary.Sessions++
+			summary.Hands += player.Hands
+			summary.Net += player.Net
+			if player.Limit != nil {
+				switch player.Limit.Kind {
+				case session.LimitStopLoss:
+					summary.StopLosses++
+				case session.LimitStopWin:
+					summary.StopWins++
+				case session.LimitTime:
+					summary.TimeLimits++
+				}
+			}
+		}
+	}
+	rows := make([]CashSummary, 0, len(order))
+	for _, name := range order {
+		rows = append(rows, *summaries[name])
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Net > rows[j].Net })
+	return rows
+}
@@ -0,0 +1,104 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+func cashEntrants() []Entrant {
+	return []Entrant{
+		{Name: "tight", Maker: quickBot(0.1, 0.01)},
+		{Name: "loose", Maker: quickBot(0.9, 0.4)},
+		{Name: "maniac", Maker: quickBot(0.95, 0.5)},
+		{Name: "station", Maker: quickBot(0.3, 0.02)},
+	}
+}
+
+func TestRunCashGameEnforcesLimits(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	result, err := RunCashGame(ctx, cashEntrants(), CashGameConfig{
+		Game:     holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 9},
+		BuyIn:    1000,
+		Limits:   session.Limits{StopLoss: 300, StopWin: 300},
+		MaxHands: 500,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	net, limited := 0, 0
+	for _, player := range result.Players {
+		net += player.Net
+		if player.Limit == nil {
+			continue
+		}
+		limited++
+		// A limit is checked after each hand, so it can be overshot by the last pot
+		switch player.Limit.Kind {
+		case session.LimitStopLoss:
+			if player.Net > -300 {
+				t.Errorf("%s stopped at a loss of %d, before the stop-loss", player.Name, -player.Net)
+			}
+		case session.LimitStopWin:
+			if player.Net < 300 {
+				t.Errorf("%s stopped at a win of %d, before the stop-win", player.Name, player.Net)
+			}
+		}
+	}
+	if limited == 0 {
+		t.Error("Expected somebody to reach a limit")
+	}
+	if net != 0 {
+		t.Errorf("Expected chips to be conserved, net %d", net)
+	}
+}
+
+func TestRunCashGameTimeLimit(t *testing.T) {
+	// Without decision makers both players check or fold, so nobody busts
+	entrants := []Entrant{{Name: "first"}, {Name: "second"}}
+	result, err := RunCashGame(context.Background(), entrants, CashGameConfig{
+		Game:         holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 4},
+		BuyIn:        100000,
+		Limits:       session.Limits{Duration: time.Hour},
+		HandDuration: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Hands != 60 {
+		t.Errorf("Expected an hour of one-minute hands, got %d hands", result.Hands)
+	}
+	for _, player := range result.Players {
+		if player.Limit == nil || player.Limit.Kind != session.LimitTime {
+			t.Errorf("Expected %s to stop at the time limit, got %+v", player.Name, player.Limit)
+		}
+	}
+}
+
+func TestRunCashGameSwitchesToExploitativePlay(t *testing.T) {
+	// Deep stacks, so nobody busts before the switch
+	entrants := cashEntrants()
+	result, err := RunCashGame(context.Background(), entrants, CashGameConfig{
+		Game:         holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 9},
+		BuyIn:        100000,
+		MaxHands:     20,
+		ExploitAfter: 5,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Hands <= 5 {
+		t.Fatalf("Expected more than 5 hands, got %d", result.Hands)
+	}
+	for _, entrant := range entrants {
+		if mode := entrant.Maker.(holdem_ai.IModeSwitcher).GetMode(); mode != holdem_ai.ModeExploitative {
+			t.Errorf("Expected %s to play exploitatively after 5 hands, got %s", entrant.Name, mode)
+		}
+	}
+}
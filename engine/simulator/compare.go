@@ -0,0 +1,230 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+// Comparison defaults
+const (
+	DefaultCompareDeals = 2000 // Duplicate deals, each played twice
+	DefaultCompareStack = 100  // Starting stack in big blinds
+	DefaultConfidence   = 1.96 // z-score for 95% two-sided confidence
+	DefaultPower        = 0.8  // Chance of finding a real difference of the size looked for
+	compareBigBlind     = 10
+)
+
+// Strategy is one side of a comparison. New is called for every hand with
+// a seed, so seeded decision makers make the comparison reproducible.
+type Strategy struct {
+	Name string
+	New  func(seed int64) holdem_ai.IDecisionMaker
+}
+
+// PresetStrategy returns a strategy playing a bot preset without delays;
+// opts are as for holdem_ai.CreateBotByName
+func PresetStrategy(name string, opts ...ranges.ParseOption) (Strategy, error) {
+	if _, err := holdem_ai.CreateSeededBot(name, 0, opts...); err != nil {
+		return Strategy{}, err
+	}
+	return Strategy{Name: name, New: func(seed int64) holdem_ai.IDecisionMaker {
+		maker, _ := holdem_ai.CreateSeededBot(name, seed, opts...)
+		if bot, ok := maker.(*holdem_ai.BasicBotDecisionMaker); ok {
+			bot.SetThinkingTime(0, 0)
+		}
+		return maker
+	}}, nil
+}
+
+// CompareOptions tunes a comparison; zero values pick the defaults
+type CompareOptions struct {
+	Deals      int     // DefaultCompareDeals when zero
+	StackBB    int     // DefaultCompareStack when zero
+	Confidence float64 // z-score a difference must reach, DefaultConfidence when zero
+	Effect     float64 // Smallest difference in bb/100 worth finding, the observed one when zero
+	Power      float64 // Chance of finding a real Effect, DefaultPower when zero
+	Workers    int     // runtime.NumCPU() when zero
+	Seed       int64   // Master seed of the deals
+	Progress   func(Progress)
+}
+
+// Comparison is the outcome of a duplicate match between two strategies
+type Comparison struct {
+	A, B        string
+	Deals       int
+	Hands       int
+	BBPer100    float64 // A's win rate against B, negative when B wins
+	StdErr      float64 // Standard error of BBPer100
+	Z           float64 // BBPer100 in standard errors
+	Confidence  float64 // z-score the verdict was judged against
+	Significant bool    // |Z| reached Confidence
+	StdDev      float64 // Of the win rate over 100 hands, in big blinds
+	Effect      float64 // Difference in bb/100 HandsNeeded is worked out for
+	Power       float64 // Chance HandsNeeded gives of finding a real Effect
+	HandsNeeded int     // Hands that find Effect at Confidence with Power, 0 without a difference to find
+}
+
+// Underpowered reports whether the comparison played too few hands to find
+// the difference looked for, so "no significant difference" means little
+func (c Comparison) Underpowered() bool {
+	return c.HandsNeeded > c.Hands
+}
+
+// PowerReport says how many hands the comparison needs, e.g.
+// "SD 92.4 bb/100: 13400 hands find 5.0 bb/100 at z 1.96 with 80% power".
+// Underpowered comparisons end with a warning.
+func (c Comparison) PowerReport() string {
+	if c.HandsNeeded == 0 {
+		return fmt.Sprintf("SD %.1f bb/100: no difference to find", c.StdDev)
+	}
+	report := fmt.Sprintf("SD %.1f bb/100: %d hands find %.1f bb/100 at z %.2f with %.0f%% power",
+		c.StdDev, c.HandsNeeded, c.Effect, c.Confidence, c.Power*100)
+	if c.Underpowered() {
+		report += fmt.Sprintf("; warning: only %d hands played, too few to trust the verdict", c.Hands)
+	}
+	return report
+}
+
+// HandsNeeded returns how many hands a z-test needs to find a win rate
+// difference of effect bb/100, with a standard deviation of stdDev bb/100,
+// at the confidence z-score with the given power. It is 0 without a
+// difference to find.
+func HandsNeeded(stdDev, effect, confidence, power float64) int {
+	if effect == 0 {
+		return 0
+	}
+	z := confidence + math.Sqrt2*math.Erfinv(2*power-1)
+	hands := 100 * math.Pow(z*stdDev/effect, 2)
+	return max(2*int(math.Ceil(hands/2)), 2) // Whole deals
+}
+
+// Verdict says in words which strategy is better, if either
+func (c Comparison) Verdict() string {
+	switch {
+	case !c.Significant:
+		return fmt.Sprintf("no significant difference between %s and %s", c.A, c.B)
+	case c.BBPer100 > 0:
+		return fmt.Sprintf("%s beats %s", c.A, c.B)
+	default:
+		return fmt.Sprintf("%s beats %s", c.B, c.A)
+	}
+}
+
+// String summarises the comparison, e.g.
+// "maniac vs tight: +12.3 ± 4.5 bb/100 over 4000 hands (z 2.73), maniac beats tight"
+func (c Comparison) String() string {
+	return fmt.Sprintf("%s vs %s: %+.1f ± %.1f bb/100 over %d hands (z %.2f), %s",
+		c.A, c.B, c.BBPer100, c.StdErr, c.Hands, c.Z, c.Verdict())
+}
+
+// Compare plays heads-up duplicate deals between two strategies. Every deal
+// is dealt twice from the same seed with the strategies swapping seats, so
+// each gets the other's cards and position and most of the luck cancels
+// out. Stacks are reset for every hand. The win-rate difference is judged
+// with a z-test over the per-deal results.
+func Compare(ctx context.Context, a, b Strategy, opts CompareOptions) (*Comparison, error) {
+	if a.New == nil || b.New == nil {
+		return nil, fmt.Errorf("strategies need a decision maker factory")
+	}
+	deals := opts.Deals
+	if deals <= 0 {
+		deals = DefaultCompareDeals
+	}
+	if deals < 2 {
+		return nil, fmt.Errorf("need at least 2 deals, got %d", deals)
+	}
+	stack := opts.StackBB
+	if stack <= 0 {
+		stack = DefaultCompareStack
+	}
+	confidence := opts.Confidence
+	if confidence <= 0 {
+		confidence = DefaultConfidence
+	}
+	power := opts.Power
+	if power <= 0 || power >= 1 {
+		power = DefaultPower
+	}
+
+	nets := make([]int, deals) // A's chips over both hands of each deal
+	batch := BatchConfig{Runs: deals, Workers: opts.Workers, Seed: opts.Seed, Progress: opts.Progress}
+	err := RunBatch(ctx, batch, func(ctx context.Context, deal int, seed int64, _ *Recorder) (int, error) {
+		net, err := playDuplicate(ctx, a, b, seed, stack*compareBigBlind)
+		nets[deal] = net
+		return 2, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mean, variance := 0.0, 0.0
+	for _, net := range nets {
+		mean += float64(net)
+	}
+	mean /= float64(deals)
+	for _, net := range nets {
+		variance += (float64(net) - mean) * (float64(net) - mean)
+	}
+	variance /= float64(deals - 1)
+
+	// A deal is two hands, and results are reported in big blinds per 100 hands
+	scale := 100.0 / 2 / compareBigBlind
+	result := &Comparison{
+		A:          a.Name,
+		B:          b.Name,
+		Deals:      deals,
+		Hands:      2 * deals,
+		BBPer100:   mean * scale,
+		StdErr:     math.Sqrt(variance/float64(deals)) * scale,
+		Confidence: confidence,
+		StdDev:     math.Sqrt(variance*50) / compareBigBlind, // 50 deals are 100 hands
+		Effect:     math.Abs(opts.Effect),
+		Power:      power,
+	}
+	if result.Effect == 0 {
+		result.Effect = math.Abs(result.BBPer100)
+	}
+	result.HandsNeeded = HandsNeeded(result.StdDev, result.Effect, confidence, power)
+	switch {
+	case result.StdErr > 0:
+		result.Z = result.BBPer100 / result.StdErr
+	case result.BBPer100 != 0:
+		result.Z = math.Copysign(math.Inf(1), result.BBPer100) // Every deal went the same way
+	}
+	result.Significant = math.Abs(result.Z) >= confidence
+	return result, nil
+}
+
+// playDuplicate plays one deal twice, A in the first seat and then B, and
+// returns A's net chips. Seats keep their player IDs and decision maker
+// seeds, so only the strategies change places.
+func playDuplicate(ctx context.Context, a, b Strategy, seed int64, stack int) (int, error) {
+	net := 0
+	for mirror := 0; mirror < 2; mirror++ {
+		seats := [2]Strategy{a, b}
+		if mirror == 1 {
+			seats = [2]Strategy{b, a}
+		}
+		game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: compareBigBlind / 2, BigBlind: compareBigBlind, Seed: seed})
+		s := session.New(game)
+		for seat, strategy := range seats {
+			id := seat + 1
+			if err := s.SitDown(holdem.NewPlayer(id, strategy.Name, stack), seat); err != nil {
+				return 0, err
+			}
+			s.SetDecisionMaker(id, strategy.New(DeriveSeed(seed, seat)))
+		}
+		hand, err := s.PlayHand(ctx)
+		if err != nil {
+			return 0, err
+		}
+		net += hand.Net[mirror+1] // A sits in the first seat, then the second
+	}
+	return net, nil
+}
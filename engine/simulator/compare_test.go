@@ -0,0 +1,112 @@
+package simulator
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+)
+
+// folder gives up every hand it can
+type folder struct{}
+
+func (folder) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionFold}
+	close(ch)
+	return ch
+}
+
+func preset(t *testing.T, name string) Strategy {
+	t.Helper()
+	strategy, err := PresetStrategy(name)
+	if err != nil {
+		t.Fatalf("PresetStrategy(%q) failed: %v", name, err)
+	}
+	return strategy
+}
+
+func TestCompareAgainstItselfCancelsOut(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	maniac := preset(t, "maniac")
+	result, err := Compare(ctx, maniac, maniac, CompareOptions{Deals: 200, Seed: 3})
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	// Duplicate deals with equal seeds replay each hand with the roles swapped
+	if result.BBPer100 != 0 || result.StdErr != 0 || result.Significant {
+		t.Errorf("Expected a strategy to exactly tie itself, got %s", result)
+	}
+	if result.Hands != 400 || result.Deals != 200 {
+		t.Errorf("Expected 200 deals of 2 hands, got %+v", result)
+	}
+	if result.HandsNeeded != 0 || result.Underpowered() {
+		t.Errorf("Expected no hands needed without a difference, got %s", result.PowerReport())
+	}
+}
+
+func TestCompareFindsTheBetterStrategy(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	folds := Strategy{Name: "folder", New: func(int64) holdem_ai.IDecisionMaker { return folder{} }}
+	station := preset(t, "calling-station")
+
+	result, err := Compare(ctx, folds, station, CompareOptions{Deals: 300, Seed: 5, Workers: 2})
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if !result.Significant || result.BBPer100 >= 0 || result.Verdict() != "calling-station beats folder" {
+		t.Errorf("Expected the calling station to beat the folder, got %s", result)
+	}
+	if math.Abs(result.Z) < result.Confidence {
+		t.Errorf("Expected |z| %.2f to reach %.2f", result.Z, result.Confidence)
+	}
+
+	again, err := Compare(ctx, folds, station, CompareOptions{Deals: 300, Seed: 5, Workers: 1})
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if result.StdDev <= 0 || result.HandsNeeded <= 0 || result.Effect != math.Abs(result.BBPer100) {
+		t.Errorf("Expected the hands needed for the observed difference, got %+v", result)
+	}
+
+	// Looking for a tiny edge, 600 hands are far too few
+	tiny, err := Compare(ctx, folds, station, CompareOptions{Deals: 300, Seed: 5, Effect: 0.5})
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if !tiny.Underpowered() || !strings.Contains(tiny.PowerReport(), "warning: only 600 hands played") {
+		t.Errorf("Expected a warning for an underpowered run, got %q", tiny.PowerReport())
+	}
+
+	if *again != *result {
+		t.Errorf("Expected the same seed to give the same result, got %s and %s", result, again)
+	}
+}
+
+func TestCompareRejectsBadInput(t *testing.T) {
+	if _, err := Compare(context.Background(), Strategy{Name: "a"}, Strategy{Name: "b"}, CompareOptions{}); err == nil {
+		t.Error("Expected an error for strategies without a factory")
+	}
+	if _, err := PresetStrategy("shark"); err == nil {
+		t.Error("Expected an error for an unknown preset")
+	}
+}
+
+func TestHandsNeeded(t *testing.T) {
+	// (1.96 + 0.84)² × (100 / 10)² × 100 hands, rounded up to whole deals
+	if got := HandsNeeded(100, 10, DefaultConfidence, DefaultPower); got != 78492 {
+		t.Errorf("Expected 78492 hands, got %d", got)
+	}
+	if got := HandsNeeded(100, 20, DefaultConfidence, DefaultPower); got != 19624 {
+		t.Errorf("Expected a quarter of the hands for twice the difference, got %d", got)
+	}
+	if got := HandsNeeded(100, 0, DefaultConfidence, DefaultPower); got != 0 {
+		t.Errorf("Expected no hands without a difference, got %d", got)
+	}
+}
@@ -0,0 +1,359 @@
+package simulator
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+// ExportSchemaVersion is written with every export and goes up whenever a
+// field or column changes meaning, is renamed or is removed
+const ExportSchemaVersion = 1
+
+// HandPlayer is one player's result in a recorded hand
+type HandPlayer struct {
+	Name   string `json:"name"`
+	Net    int    `json:"net"` // Chips won or lost, including bounties
+	Winner bool   `json:"winner"`
+}
+
+// HandRecord is one simulated hand
+type HandRecord struct {
+	Run      int          `json:"run"` // 1-based game the hand was played in
+	Hand     int          `json:"hand"`
+	BigBlind int          `json:"big_blind"`
+	Pot      int          `json:"pot"` // Chips awarded plus rake
+	Rake     int          `json:"rake"`
+	Showdown bool         `json:"showdown"`
+	Winners  []string     `json:"winners"`
+	Players  []HandPlayer `json:"players"`
+	TableID  string       `json:"table_id"`
+	HandID   string       `json:"hand_id"`
+}
+
+// ActionCounts counts a bot's voluntary actions; blinds and antes are left out
+type ActionCounts struct {
+	Fold  int `json:"fold"`
+	Check int `json:"check"`
+	Call  int `json:"call"`
+	Raise int `json:"raise"`
+	AllIn int `json:"all_in"`
+}
+
+// Total returns the number of counted actions
+func (c ActionCounts) Total() int {
+	return c.Fold + c.Check + c.Call + c.Raise + c.AllIn
+}
+
+// Frequency returns count as a share of all counted actions, 0 when there are none
+func (c ActionCounts) Frequency(count int) float64 {
+	if c.Total() == 0 {
+		return 0
+	}
+	return float64(count) / float64(c.Total())
+}
+
+// BotSummary aggregates every hand a bot played across runs. Bots are
+// identified by name, so seats sharing a preset are summed together.
+type BotSummary struct {
+	Name        string       `json:"name"`
+	Hands       int          `json:"hands"`
+	Wins        int          `json:"wins"` // Hands in which the bot won at least one pot
+	Net         int          `json:"net"`
+	NetBB       float64      `json:"net_bb"` // Each hand's result in that hand's big blinds
+	BBPer100    float64      `json:"bb_per_100"`
+	Actions     ActionCounts `json:"actions"`
+	Frequencies Frequencies  `json:"frequencies"`
+}
+
+// Export is everything a Recorder collected, ready to be written out
+type Export struct {
+	SchemaVersion int          `json:"schema_version"`
+	Runs          int          `json:"runs"`
+	Hands         []HandRecord `json:"hands"`
+	Bots          []BotSummary `json:"bots"` // In order of first appearance
+}
+
+// Recorder collects per-hand results from simulated games. Pass one to
+// RunCashGame or RunSitAndGo and export it once the games are done. It is
+// safe for concurrent use, but games recorded at the same time interleave
+// their hands; batches give each game its own recorder and merge them.
+type Recorder struct {
+	mu    sync.Mutex
+	runs  int
+	hands []HandRecord
+	bots  map[string]*BotSummary
+	order []string
+}
+
+// NewRecorder creates an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{bots: map[string]*BotSummary{}}
+}
+
+// startRun numbers the hands of the next game
+func (r *Recorder) startRun() {
+	if r != nil {
+		r.mu.Lock()
+		r.runs++
+		r.mu.Unlock()
+	}
+}
+
+// record adds a finished hand. It must be called before busted players
+// leave the table so every player dealt in still has a name.
+func (r *Recorder) record(game *holdem.Game, hand *session.HandResult) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record := HandRecord{
+		Run:      r.runs,
+		Hand:     hand.HandNumber,
+		BigBlind: game.GetBigBlind(),
+		Rake:     hand.Rake,
+		Showdown: hand.Showdown,
+		Winners:  []string{},
+		Players:  []HandPlayer{},
+		TableID:  game.GetTableID(),
+		HandID:   hand.HandID,
+	}
+	winners := map[int]bool{}
+	record.Pot = hand.Rake
+	for _, award := range hand.Awards {
+		record.Pot += award.Amount
+		for _, id := range award.Winners {
+			if !winners[id] {
+				winners[id] = true
+				record.Winners = append(record.Winners, nameOf(game, id))
+			}
+		}
+	}
+
+	for _, player := range game.GetAllPlayers() {
+		net, dealt := hand.Net[player.GetID()]
+		if !dealt {
+			continue
+		}
+		record.Players = append(record.Players, HandPlayer{Name: player.GetName(), Net: net, Winner: winners[player.GetID()]})
+		bot := r.bot(player.GetName())
+		bot.Hands++
+		bot.Net += net
+		if record.BigBlind > 0 {
+			bot.NetBB += float64(net) / float64(record.BigBlind)
+		}
+		if winners[player.GetID()] {
+			bot.Wins++
+		}
+		countFrequencies(&bot.Frequencies, game, player.GetID(), hand.Showdown)
+	}
+
+	for _, logged := range game.GetHandActionLog() {
+		if !isVoluntary(logged.Action.Type) {
+			continue
+		}
+		counts := &r.bot(nameOf(game, logged.Action.PlayerID)).Actions
+		switch logged.Action.Type {
+		case holdem.ActionFold:
+			counts.Fold++
+		case holdem.ActionCheck:
+			counts.Check++
+		case holdem.ActionCall:
+			counts.Call++
+		case holdem.ActionRaise:
+			counts.Raise++
+		case holdem.ActionAllIn:
+			counts.AllIn++
+		}
+	}
+	r.hands = append(r.hands, record)
+}
+
+// bot returns the summary for a name, creating it on first sight
+func (r *Recorder) bot(name string) *BotSummary {
+	bot, ok := r.bots[name]
+	if !ok {
+		bot = &BotSummary{Name: name}
+		r.bots[name] = bot
+		r.order = append(r.order, name)
+	}
+	return bot
+}
+
+// Merge appends everything another recorder collected, numbering its runs
+// after the ones recorded so far
+func (r *Recorder) Merge(other *Recorder) {
+	other.mu.Lock()
+	defer other.mu.Unlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, hand := range other.hands {
+		hand.Run += r.runs
+		r.hands = append(r.hands, hand)
+	}
+	r.runs += other.runs
+	for _, name := range other.order {
+		theirs := other.bots[name]
+		bot := r.bot(name)
+		bot.Hands += theirs.Hands
+		bot.Wins += theirs.Wins
+		bot.Net += theirs.Net
+		bot.NetBB += theirs.NetBB
+		bot.Actions.Fold += theirs.Actions.Fold
+		bot.Actions.Check += theirs.Actions.Check
+		bot.Actions.Call += theirs.Actions.Call
+		bot.Actions.Raise += theirs.Actions.Raise
+		bot.Actions.AllIn += theirs.Actions.AllIn
+		bot.Frequencies.add(theirs.Frequencies)
+	}
+}
+
+// Export returns a copy of everything recorded so far with bb/100 filled in
+func (r *Recorder) Export() *Export {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	export := &Export{
+		SchemaVersion: ExportSchemaVersion,
+		Runs:          r.runs,
+		Hands:         append([]HandRecord{}, r.hands...),
+		Bots:          make([]BotSummary, 0, len(r.order)),
+	}
+	for _, name := range r.order {
+		bot := *r.bots[name]
+		if bot.Hands > 0 {
+			bot.BBPer100 = bot.NetBB / float64(bot.Hands) * 100
+		}
+		export.Bots = append(export.Bots, bot)
+	}
+	return export
+}
+
+// WriteJSON writes the export as one indented JSON document
+func (e *Export) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(e)
+}
+
+// WriteHandsCSV writes one row per player per hand, so each hand takes as
+// many rows as players were dealt in
+func (e *Export) WriteHandsCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"schema_version", "run", "hand", "big_blind", "pot", "rake", "showdown", "winners", "player", "net", "winner", "table_id", "hand_id"})
+	version := strconv.Itoa(e.SchemaVersion)
+	for _, hand := range e.Hands {
+		winners := strings.Join(hand.Winners, ";")
+		for _, player := range hand.Players {
+			out.Write([]string{
+				version,
+				strconv.Itoa(hand.Run),
+				strconv.Itoa(hand.Hand),
+				strconv.Itoa(hand.BigBlind),
+				strconv.Itoa(hand.Pot),
+				strconv.Itoa(hand.Rake),
+				strconv.FormatBool(hand.Showdown),
+				winners,
+				player.Name,
+				strconv.Itoa(player.Net),
+				strconv.FormatBool(player.Winner),
+				hand.TableID,
+				hand.HandID,
+			})
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// WriteBotsCSV writes one row per bot with its aggregate results, action
+// counts, action frequencies and HUD statistics
+func (e *Export) WriteBotsCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"schema_version", "bot", "hands", "wins", "net", "net_bb", "bb_per_100",
+		"folds", "checks", "calls", "raises", "all_ins",
+		"fold_freq", "check_freq", "call_freq", "raise_freq", "all_in_freq",
+		"vpip", "pfr", "three_bet", "cbet", "wtsd", "af",
+		"cold_call", "squeeze", "donk_bet", "check_raise"})
+	version := strconv.Itoa(e.SchemaVersion)
+	for _, bot := range e.Bots {
+		actions := bot.Actions
+		row := []string{
+			version,
+			bot.Name,
+			strconv.Itoa(bot.Hands),
+			strconv.Itoa(bot.Wins),
+			strconv.Itoa(bot.Net),
+			formatFloat(bot.NetBB),
+			formatFloat(bot.BBPer100),
+		}
+		counts := []int{actions.Fold, actions.Check, actions.Call, actions.Raise, actions.AllIn}
+		for _, count := range counts {
+			row = append(row, strconv.Itoa(count))
+		}
+		for _, count := range counts {
+			row = append(row, formatFloat(actions.Frequency(count)))
+		}
+		situations := bot.Frequencies.Situations
+		for _, stat := range []float64{bot.VPIP(), bot.PFR(), bot.ThreeBet(), bot.CBet(), bot.WTSD(), bot.AF(),
+			situations.ColdCall(), situations.Squeeze(), situations.DonkBet(), situations.CheckRaise()} {
+			row = append(row, formatFloat(stat))
+		}
+		out.Write(row)
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// SaveJSON writes the export to a JSON file
+func (e *Export) SaveJSON(path string) error {
+	return writeFile(path, e.WriteJSON)
+}
+
+// SaveCSV writes the per-hand and per-bot tables to two CSV files
+func (e *Export) SaveCSV(handsPath, botsPath string) error {
+	if err := writeFile(handsPath, e.WriteHandsCSV); err != nil {
+		return err
+	}
+	return writeFile(botsPath, e.WriteBotsCSV)
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// isVoluntary reports whether an action is a player's decision rather than
+// a forced bet or a dealer step
+func isVoluntary(actionType holdem.ActionType) bool {
+	switch actionType {
+	case holdem.ActionFold, holdem.ActionCheck, holdem.ActionCall, holdem.ActionRaise, holdem.ActionAllIn:
+		return true
+	}
+	return false
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 4, 64)
+}
+
+func nameOf(game *holdem.Game, playerID int) string {
+	if player, err := game.GetPlayerByID(playerID); err == nil {
+		return player.GetName()
+	}
+	return strconv.Itoa(playerID)
+}
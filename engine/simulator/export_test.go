@@ -0,0 +1,140 @@
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/tournament"
+)
+
+func recordCashGame(t *testing.T) (*CashGameResult, *Export) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	recorder := NewRecorder()
+	result, err := RunCashGame(ctx, cashEntrants(), CashGameConfig{
+		Game:     holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 4},
+		BuyIn:    1000,
+		MaxHands: 50,
+		Recorder: recorder,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return result, recorder.Export()
+}
+
+func TestRecorderMatchesCashGame(t *testing.T) {
+	result, export := recordCashGame(t)
+	if export.SchemaVersion != ExportSchemaVersion || export.Runs != 1 {
+		t.Errorf("Expected schema %d and 1 run, got %d and %d", ExportSchemaVersion, export.SchemaVersion, export.Runs)
+	}
+	if len(export.Hands) != result.Hands {
+		t.Fatalf("Expected %d recorded hands, got %d", result.Hands, len(export.Hands))
+	}
+	for _, hand := range export.Hands {
+		net, won := 0, 0
+		for _, player := range hand.Players {
+			net += player.Net
+			if player.Winner {
+				won++
+			}
+		}
+		if net != -hand.Rake {
+			t.Errorf("Hand %d: expected players' net to lose only the rake, got %d", hand.Hand, net)
+		}
+		if won != len(hand.Winners) || won == 0 {
+			t.Errorf("Hand %d: %d winners flagged for %v", hand.Hand, won, hand.Winners)
+		}
+		if hand.Pot < hand.BigBlind {
+			t.Errorf("Hand %d: pot %d smaller than the big blind", hand.Hand, hand.Pot)
+		}
+	}
+
+	for _, bot := range export.Bots {
+		var player *CashResult
+		for i := range result.Players {
+			if result.Players[i].Name == bot.Name {
+				player = &result.Players[i]
+			}
+		}
+		if player == nil {
+			t.Fatalf("Unexpected bot %q", bot.Name)
+		}
+		if bot.Hands != player.Hands || bot.Net != player.Net {
+			t.Errorf("%s: recorded %d hands net %d, game says %d hands net %d", bot.Name, bot.Hands, bot.Net, player.Hands, player.Net)
+		}
+		if want := float64(bot.Net) / 10 / float64(bot.Hands) * 100; math.Abs(bot.BBPer100-want) > 1e-9 {
+			t.Errorf("%s: expected %.2f bb/100, got %.2f", bot.Name, want, bot.BBPer100)
+		}
+		if bot.Actions.Total() == 0 {
+			t.Errorf("%s: no actions counted", bot.Name)
+		}
+	}
+}
+
+func TestExportWriters(t *testing.T) {
+	_, export := recordCashGame(t)
+
+	var out bytes.Buffer
+	if err := export.WriteJSON(&out); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var decoded Export
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("Unexpected JSON: %v", err)
+	}
+	if decoded.SchemaVersion != ExportSchemaVersion || len(decoded.Hands) != len(export.Hands) || len(decoded.Bots) != len(export.Bots) {
+		t.Errorf("JSON round trip lost data: %+v", decoded)
+	}
+
+	out.Reset()
+	if err := export.WriteHandsCSV(&out); err != nil {
+		t.Fatalf("WriteHandsCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected CSV: %v", err)
+	}
+	players := 0
+	for _, hand := range export.Hands {
+		players += len(hand.Players)
+	}
+	if len(rows) != players+1 || rows[0][0] != "schema_version" || rows[1][0] != "1" {
+		t.Errorf("Expected a header and %d rows, got %d rows starting %v", players, len(rows), rows[0])
+	}
+
+	out.Reset()
+	if err := export.WriteBotsCSV(&out); err != nil {
+		t.Fatalf("WriteBotsCSV failed: %v", err)
+	}
+	rows, err = csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected CSV: %v", err)
+	}
+	if len(rows) != len(export.Bots)+1 || len(rows[0]) != len(rows[1]) {
+		t.Errorf("Expected a header and %d bot rows of equal width, got %v", len(export.Bots), rows)
+	}
+}
+
+func TestRecorderNumbersRuns(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	recorder := NewRecorder()
+	for run := 0; run < 2; run++ {
+		entrants := cashEntrants()
+		if _, err := RunSitAndGo(ctx, 6, tournament.BlindStructure{}, entrants, 100, int64(run+1), recorder); err != nil {
+			t.Fatalf("RunSitAndGo failed: %v", err)
+		}
+	}
+	export := recorder.Export()
+	if export.Runs != 2 || export.Hands[0].Run != 1 || export.Hands[len(export.Hands)-1].Run != 2 {
+		t.Errorf("Expected hands numbered across 2 runs, got %d runs", export.Runs)
+	}
+}
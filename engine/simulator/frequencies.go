@@ -0,0 +1,149 @@
+package simulator
+
+import "github.com/ljbink/ai-poker/engine/holdem"
+
+// Frequencies counts the spots behind a bot's standard HUD statistics, so
+// a preset's play can be checked against the archetype it is meant to be
+type Frequencies struct {
+	VPIP           int `json:"vpip"`             // Hands with money put in voluntarily preflop
+	PFR            int `json:"pfr"`              // Hands raised preflop
+	ThreeBetChance int `json:"three_bet_chance"` // Hands that faced a single raise preflop
+	ThreeBet       int `json:"three_bet"`        // ...and re-raised it
+	CBetChance     int `json:"cbet_chance"`      // Hands as the preflop raiser first to bet on the flop
+	CBet           int `json:"cbet"`             // ...and bet
+	SawFlop        int `json:"saw_flop"`
+	WentToShowdown int `json:"went_to_showdown"` // Hands that saw the flop and reached showdown
+	PostflopAggro  int `json:"postflop_aggro"`   // Bets and raises after the flop
+	PostflopCalls  int `json:"postflop_calls"`
+
+	holdem.Situations // Cold calls, squeezes, donk bets and check-raises
+}
+
+// add sums another bot's counts into these
+func (f *Frequencies) add(other Frequencies) {
+	f.VPIP += other.VPIP
+	f.PFR += other.PFR
+	f.ThreeBetChance += other.ThreeBetChance
+	f.ThreeBet += other.ThreeBet
+	f.CBetChance += other.CBetChance
+	f.CBet += other.CBet
+	f.SawFlop += other.SawFlop
+	f.WentToShowdown += other.WentToShowdown
+	f.PostflopAggro += other.PostflopAggro
+	f.PostflopCalls += other.PostflopCalls
+	f.Situations.Add(other.Situations)
+}
+
+// VPIP returns the share of hands the bot put money in voluntarily preflop
+func (b BotSummary) VPIP() float64 {
+	return share(b.Frequencies.VPIP, b.Hands)
+}
+
+// PFR returns the share of hands the bot raised preflop
+func (b BotSummary) PFR() float64 {
+	return share(b.Frequencies.PFR, b.Hands)
+}
+
+// ThreeBet returns how often the bot re-raised a single preflop raise
+func (b BotSummary) ThreeBet() float64 {
+	return share(b.Frequencies.ThreeBet, b.Frequencies.ThreeBetChance)
+}
+
+// CBet returns how often the preflop raiser bet the flop when checked to
+func (b BotSummary) CBet() float64 {
+	return share(b.Frequencies.CBet, b.Frequencies.CBetChance)
+}
+
+// WTSD returns how often the bot went to showdown after seeing the flop
+func (b BotSummary) WTSD() float64 {
+	return share(b.Frequencies.WentToShowdown, b.Frequencies.SawFlop)
+}
+
+// AF returns the postflop aggression factor, bets and raises per call. A
+// bot that never called has the number of its bets and raises.
+func (b BotSummary) AF() float64 {
+	if b.Frequencies.PostflopCalls == 0 {
+		return float64(b.Frequencies.PostflopAggro)
+	}
+	return float64(b.Frequencies.PostflopAggro) / float64(b.Frequencies.PostflopCalls)
+}
+
+// countFrequencies adds one finished hand to the frequencies of a player
+// dealt into it
+func countFrequencies(f *Frequencies, game *holdem.Game, playerID int, showdown bool) {
+	history := game.GetUserActions()
+	f.Situations.Add(history.Situations(playerID))
+
+	raises, folded := 0, false
+	vpip, pfr, threeBetChance, threeBet := false, false, false, false
+	for _, action := range history.Voluntary(holdem.PhasePreflop) {
+		if action.PlayerID == playerID {
+			switch {
+			case action.Type == holdem.ActionFold:
+				folded = true
+			case action.Type != holdem.ActionCheck:
+				vpip = true
+			}
+			if raises == 1 && !threeBetChance {
+				threeBetChance, threeBet = true, action.Raised
+			}
+			pfr = pfr || action.Raised
+		}
+		if action.Raised {
+			raises++
+		}
+	}
+	f.VPIP += boolCount(vpip)
+	f.PFR += boolCount(pfr)
+	f.ThreeBetChance += boolCount(threeBetChance)
+	f.ThreeBet += boolCount(threeBet)
+
+	if folded || len(game.GetCommunityCards()) < 3 {
+		return
+	}
+	f.SawFlop++
+	if aggressor, ok := history.LastAggressor(holdem.PhasePreflop); ok && aggressor == playerID {
+		for _, action := range history.Voluntary(holdem.PhaseFlop) {
+			if action.Faced > 0 {
+				break
+			}
+			if action.PlayerID == playerID {
+				f.CBetChance++
+				f.CBet += boolCount(action.Raised)
+				break
+			}
+		}
+	}
+	for phase := holdem.PhaseFlop; phase <= holdem.PhaseRiver; phase++ {
+		for _, action := range history.Voluntary(phase) {
+			if action.PlayerID != playerID {
+				continue
+			}
+			switch {
+			case action.Raised:
+				f.PostflopAggro++
+			case action.Type == holdem.ActionCall || action.Type == holdem.ActionAllIn:
+				f.PostflopCalls++
+			case action.Type == holdem.ActionFold:
+				folded = true
+			}
+		}
+	}
+	if !folded && showdown {
+		f.WentToShowdown++
+	}
+}
+
+func share(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+func boolCount(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,81 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestCountFrequencies(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 7})
+	for i := 0; i < 3; i++ {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, "", 1000), i); err != nil {
+			t.Fatalf("PlayerSit failed: %v", err)
+		}
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	act := func(action holdem.Action) {
+		t.Helper()
+		if err := game.TakeAction(action); err != nil {
+			t.Fatalf("%+v failed: %v", action, err)
+		}
+	}
+	// The button opens, the small blind 3-bets and c-bets, then calls a turn bet
+	act(holdem.NewRaise(1, 30))
+	act(holdem.NewRaise(2, 90))
+	act(holdem.Action{PlayerID: 3, Type: holdem.ActionFold})
+	act(holdem.Action{PlayerID: 1, Type: holdem.ActionCall, Amount: 60})
+	game.DealFlop()
+	act(holdem.NewRaise(2, 40))
+	act(holdem.Action{PlayerID: 1, Type: holdem.ActionCall, Amount: 40})
+	game.DealTurn()
+	act(holdem.Action{PlayerID: 2, Type: holdem.ActionCheck})
+	act(holdem.NewRaise(1, 100))
+	act(holdem.Action{PlayerID: 2, Type: holdem.ActionCall, Amount: 100})
+
+	want := map[int]Frequencies{
+		1: {VPIP: 1, PFR: 1, SawFlop: 1, WentToShowdown: 1, PostflopAggro: 1, PostflopCalls: 1},
+		2: {VPIP: 1, PFR: 1, ThreeBetChance: 1, ThreeBet: 1, CBetChance: 1, CBet: 1, SawFlop: 1, WentToShowdown: 1, PostflopAggro: 1, PostflopCalls: 1,
+			Situations: holdem.Situations{CheckRaiseChances: 1}},
+		3: {},
+	}
+	for id, freq := range want {
+		var got Frequencies
+		countFrequencies(&got, game, id, true)
+		if got != freq {
+			t.Errorf("Player %d: expected %+v, got %+v", id, freq, got)
+		}
+	}
+}
+
+func TestFrequenciesTellArchetypesApart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	// Stacks go fast, so pool enough games for every bot to play a few hundred hands
+	recorder := NewRecorder()
+	for seed := int64(1); seed <= 20; seed++ {
+		if _, err := RunCashGame(ctx, cashEntrants(), CashGameConfig{
+			Game:     holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: seed},
+			BuyIn:    1000,
+			MaxHands: 200,
+			Recorder: recorder,
+		}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	bots := map[string]BotSummary{}
+	for _, bot := range recorder.Export().Bots {
+		bots[bot.Name] = bot
+		if bot.PFR() > bot.VPIP() || bot.VPIP() == 0 {
+			t.Errorf("%s: expected 0 < PFR <= VPIP, got PFR %.2f VPIP %.2f", bot.Name, bot.PFR(), bot.VPIP())
+		}
+	}
+	if tight, maniac := bots["tight"], bots["maniac"]; tight.VPIP() >= maniac.VPIP() || tight.AF() >= maniac.AF() {
+		t.Errorf("Expected the tight bot to play fewer hands less aggressively than the maniac, got VPIP %.2f vs %.2f and AF %.2f vs %.2f",
+			tight.VPIP(), maniac.VPIP(), tight.AF(), maniac.AF())
+	}
+}
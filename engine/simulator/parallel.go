@@ -0,0 +1,249 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/metrics"
+	"github.com/ljbink/ai-poker/engine/tournament"
+)
+
+// Progress reports how far a batch of simulated games has got
+type Progress struct {
+	Done    int // Games finished
+	Total   int
+	Hands   int // Hands played in the finished games
+	Elapsed time.Duration
+}
+
+// Fraction returns the share of games finished, between 0 and 1
+func (p Progress) Fraction() float64 {
+	if p.Total == 0 {
+		return 1
+	}
+	return float64(p.Done) / float64(p.Total)
+}
+
+// BatchConfig describes a batch of games played on a pool of workers
+type BatchConfig struct {
+	Runs     int
+	Workers  int              // runtime.NumCPU() when zero
+	Seed     int64            // Master seed every game's seed is derived from
+	Progress func(Progress)   // Called after each finished game, never concurrently
+	Recorder *Recorder        // Collects every hand, in game order, when set
+	Metrics  metrics.IMetrics // Receives every game's hands, actions and evaluations when set
+}
+
+// DeriveSeed returns the seed of the index-th game of a batch, or of the
+// index-th bot in a game. Seeds follow from the master seed and the index
+// alone, so a batch plays the same games whatever the number of workers.
+func DeriveSeed(master int64, index int) int64 {
+	// SplitMix64, which spreads neighbouring indexes far apart
+	z := uint64(master) + uint64(index+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
+
+// RunBatch plays config.Runs games on a pool of workers. play is called
+// with the game's zero-based index, its derived seed and a recorder of its
+// own, nil unless config.Recorder is set, and returns the hands it played.
+// Game recorders are merged into config.Recorder in game order once all
+// games are done. The first error stops the batch.
+func RunBatch(ctx context.Context, config BatchConfig, play func(ctx context.Context, run int, seed int64, recorder *Recorder) (int, error)) error {
+	if config.Runs <= 0 {
+		return fmt.Errorf("need at least one run, got %d", config.Runs)
+	}
+	workers := config.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = min(workers, config.Runs)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	recorders := make([]*Recorder, config.Runs)
+	jobs := make(chan int)
+	started := time.Now()
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		progress = Progress{Total: config.Runs}
+		wg       sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for run := range jobs {
+				if config.Recorder != nil {
+					recorders[run] = NewRecorder()
+				}
+				hands, err := play(ctx, run, DeriveSeed(config.Seed, run), recorders[run])
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("run %d: %w", run+1, err)
+					}
+					cancel()
+				} else {
+					progress.Done++
+					progress.Hands += hands
+					progress.Elapsed = time.Since(started)
+					if config.Progress != nil {
+						config.Progress(progress)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for run := 0; run < config.Runs; run++ {
+		select {
+		case jobs <- run:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, recorder := range recorders {
+		if recorder != nil {
+			config.Recorder.Merge(recorder)
+		}
+	}
+	return nil
+}
+
+// RunSitAndGos plays a batch of sit-and-gos. Each game's entrants are
+// created from its seed, so seeded bots make the batch reproducible.
+// Results are in game order.
+func RunSitAndGos(ctx context.Context, config BatchConfig, seats int, structure tournament.BlindStructure, buyIn int, entrants func(seed int64) ([]Entrant, error)) ([]*TournamentResult, error) {
+	results := make([]*TournamentResult, max(config.Runs, 0))
+	err := RunBatch(ctx, config, func(ctx context.Context, run int, seed int64, recorder *Recorder) (int, error) {
+		field, err := entrants(seed)
+		if err != nil {
+			return 0, err
+		}
+		result, err := runSitAndGo(ctx, seats, structure, field, buyIn, seed, recorder, config.Metrics)
+		if err != nil {
+			return 0, err
+		}
+		results[run] = result
+		return result.Hands, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// RunCashGames plays a batch of cash games like RunSitAndGos does
+// sit-and-gos. Each game deals with its own seed, replacing game.Game.Seed.
+func RunCashGames(ctx context.Context, config BatchConfig, game CashGameConfig, entrants func(seed int64) ([]Entrant, error)) ([]*CashGameResult, error) {
+	results := make([]*CashGameResult, max(config.Runs, 0))
+	err := RunBatch(ctx, config, func(ctx context.Context, run int, seed int64, recorder *Recorder) (int, error) {
+		field, err := entrants(seed)
+		if err != nil {
+			return 0, err
+		}
+		runConfig := game
+		runConfig.Game.Seed = seed
+		runConfig.Recorder = recorder
+		if config.Metrics != nil {
+			runConfig.Metrics = config.Metrics
+		}
+		result, err := RunCashGame(ctx, field, runConfig)
+		if err != nil {
+			return 0, err
+		}
+		results[run] = result
+		return result.Hands, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// SitAndGoSummary is how one bot did over a batch of sit-and-gos. Bots are
+// identified by name, so seats sharing a preset are summed together.
+type SitAndGoSummary struct {
+	Name    string
+	Entries int
+	Wins    int
+	Cashes  int // Finishes in the money
+	Places  int // Sum of finishing places
+	Prizes  int
+}
+
+// ITM returns the share of entries that finished in the money
+func (s SitAndGoSummary) ITM() float64 {
+	return float64(s.Cashes) / float64(max(s.Entries, 1))
+}
+
+// AveragePlace returns the average finishing place
+func (s SitAndGoSummary) AveragePlace() float64 {
+	return float64(s.Places) / float64(max(s.Entries, 1))
+}
+
+// ROI returns the return on the buy-ins paid, e.g. 0.5 for a 50% profit
+func (s SitAndGoSummary) ROI(buyIn int) float64 {
+	invested := s.Entries * buyIn
+	if invested == 0 {
+		return 0
+	}
+	return float64(s.Prizes-invested) / float64(invested)
+}
+
+// SummarizeSitAndGos adds up each bot's finishes, best return first
+func SummarizeSitAndGos(results []*TournamentResult) []SitAndGoSummary {
+	summaries := map[string]*SitAndGoSummary{}
+	for _, result := range results {
+		for _, standing := range result.Standings {
+			summary, ok := summaries[standing.Name]
+			if !ok {
+				summary = &SitAndGoSummary{Name: standing.Name}
+				summaries[standing.Name] = summary
+			}
+			summary.Entries++
+			summary.Places += standing.Place
+			summary.Prizes += standing.Prize
+			if standing.Place == 1 {
+				summary.Wins++
+			}
+			if standing.Prize > 0 {
+				summary.Cashes++
+			}
+		}
+	}
+
+	rows := make([]SitAndGoSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		rows = append(rows, *summary)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		// Best return on investment first, ties by name so the order is stable
+		left, right := rows[i].Prizes*rows[j].Entries, rows[j].Prizes*rows[i].Entries
+		if left != right {
+			return left > right
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
@@ -0,0 +1,172 @@
+package simulator
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/metrics"
+	"github.com/ljbink/ai-poker/engine/tournament"
+)
+
+// seededEntrants creates a field of seeded preset bots without delays
+func seededEntrants(names ...string) func(seed int64) ([]Entrant, error) {
+	return func(seed int64) ([]Entrant, error) {
+		entrants := []Entrant{}
+		for i, name := range names {
+			maker, err := holdem_ai.CreateSeededBot(name, DeriveSeed(seed, i))
+			if err != nil {
+				return nil, err
+			}
+			maker.(*holdem_ai.BasicBotDecisionMaker).SetThinkingTime(0, 0)
+			entrants = append(entrants, Entrant{Name: name, Maker: maker})
+		}
+		return entrants, nil
+	}
+}
+
+func TestDeriveSeed(t *testing.T) {
+	seen := map[int64]bool{}
+	for i := 0; i < 1000; i++ {
+		seed := DeriveSeed(7, i)
+		if seen[seed] {
+			t.Fatalf("Seed %d repeated at index %d", seed, i)
+		}
+		seen[seed] = true
+		if DeriveSeed(7, i) != seed {
+			t.Fatalf("Expected DeriveSeed to be stable at index %d", i)
+		}
+	}
+	if DeriveSeed(7, 0) == DeriveSeed(8, 0) {
+		t.Error("Expected different master seeds to derive different seeds")
+	}
+}
+
+func TestRunSitAndGosIsReproducible(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	entrants := seededEntrants("tight", "loose", "maniac", "calling-station", "random", "balanced")
+
+	play := func(workers int) ([]*TournamentResult, *Export, []Progress) {
+		recorder := NewRecorder()
+		var mu sync.Mutex
+		updates := []Progress{}
+		results, err := RunSitAndGos(ctx, BatchConfig{
+			Runs:     6,
+			Workers:  workers,
+			Seed:     99,
+			Recorder: recorder,
+			Progress: func(p Progress) {
+				mu.Lock()
+				updates = append(updates, p)
+				mu.Unlock()
+			},
+		}, 6, tournament.BlindStructure{}, 100, entrants)
+		if err != nil {
+			t.Fatalf("RunSitAndGos with %d workers failed: %v", workers, err)
+		}
+		return results, recorder.Export(), updates
+	}
+
+	serial, serialExport, serialProgress := play(1)
+	parallel, parallelExport, parallelProgress := play(4)
+	for i := range serial {
+		if !reflect.DeepEqual(serial[i].Standings, parallel[i].Standings) || serial[i].Hands != parallel[i].Hands {
+			t.Errorf("Run %d differs between 1 and 4 workers", i+1)
+		}
+	}
+	if !reflect.DeepEqual(withoutIDs(t, serialExport), withoutIDs(t, parallelExport)) {
+		t.Error("Expected the recorded hands not to depend on the worker count")
+	}
+
+	for _, updates := range [][]Progress{serialProgress, parallelProgress} {
+		if len(updates) != 6 {
+			t.Fatalf("Expected a progress update per run, got %d", len(updates))
+		}
+		last := updates[len(updates)-1]
+		if last.Done != 6 || last.Total != 6 || last.Fraction() != 1 || last.Hands != len(serialExport.Hands) {
+			t.Errorf("Expected the last update to cover every run and hand, got %+v", last)
+		}
+	}
+
+	summaries := SummarizeSitAndGos(serial)
+	entries, prizes := 0, 0
+	for _, summary := range summaries {
+		entries += summary.Entries
+		prizes += summary.Prizes
+	}
+	if len(summaries) != 6 || entries != 36 || prizes != 6*600 {
+		t.Errorf("Expected 6 bots with 36 entries sharing 3600, got %d bots, %d entries, %d prizes", len(summaries), entries, prizes)
+	}
+	if summaries[0].ROI(100) < summaries[len(summaries)-1].ROI(100) {
+		t.Errorf("Expected the best return first, got %+v", summaries)
+	}
+}
+
+func TestRunBatchStopsOnError(t *testing.T) {
+	played := 0
+	var mu sync.Mutex
+	err := RunBatch(context.Background(), BatchConfig{Runs: 50, Workers: 2}, func(ctx context.Context, run int, seed int64, recorder *Recorder) (int, error) {
+		mu.Lock()
+		played++
+		mu.Unlock()
+		if run == 3 {
+			return 0, context.DeadlineExceeded
+		}
+		return 1, ctx.Err()
+	})
+	if err == nil {
+		t.Fatal("Expected the failing run's error")
+	}
+	if played == 50 {
+		t.Error("Expected the batch to stop early")
+	}
+	if err := RunBatch(context.Background(), BatchConfig{}, nil); err == nil {
+		t.Error("Expected an error for an empty batch")
+	}
+}
+
+// withoutIDs clears the table and hand IDs, unique to every game played,
+// from an export after checking each hand has them
+func withoutIDs(t *testing.T, export *Export) *Export {
+	t.Helper()
+	for i := range export.Hands {
+		if export.Hands[i].TableID == "" || export.Hands[i].HandID == "" {
+			t.Fatalf("Expected hand %d to have a table and hand ID", i)
+		}
+		export.Hands[i].TableID, export.Hands[i].HandID = "", ""
+	}
+	return export
+}
+
+func TestRunSitAndGosReportsMetrics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	registry := metrics.NewRegistry()
+	seeded := seededEntrants("tight", "loose", "maniac")
+	entrants := func(seed int64) ([]Entrant, error) {
+		field, err := seeded(seed)
+		for i := range field {
+			field[i].Maker = holdem_ai.NewInstrumentedDecisionMaker(field[i].Name, field[i].Maker, registry)
+		}
+		return field, err
+	}
+
+	results, err := RunSitAndGos(ctx, BatchConfig{Runs: 2, Workers: 2, Seed: 5, Metrics: registry}, 6, tournament.BlindStructure{}, 100, entrants)
+	if err != nil {
+		t.Fatalf("RunSitAndGos failed: %v", err)
+	}
+	hands := results[0].Hands + results[1].Hands
+	if v := registry.CounterValue(metrics.HandsDealtTotal, nil); v != float64(hands) {
+		t.Errorf("Expected %d hands dealt, got %v", hands, v)
+	}
+	if v := registry.CounterValue(metrics.HandEvaluationsTotal, nil); v == 0 {
+		t.Error("Expected the showdowns' evaluations to be counted")
+	}
+	if c := registry.HistogramCount(metrics.DecisionLatencySeconds, metrics.Labels{"bot": "tight"}); c == 0 {
+		t.Error("Expected the wrapped bots' decisions to be timed")
+	}
+}
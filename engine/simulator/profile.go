@@ -0,0 +1,99 @@
+package simulator
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+)
+
+// ProfileSummary is where a bot's decision time went over a simulation.
+// Times are totals, divide by Decisions for the average decision.
+type ProfileSummary struct {
+	Name       string        `json:"name"`
+	Decisions  int           `json:"decisions"`
+	Evaluation time.Duration `json:"evaluation_ns"`
+	Equity     time.Duration `json:"equity_ns"`
+	Search     time.Duration `json:"search_ns"`
+	Total      time.Duration `json:"total_ns"`
+	P95        time.Duration `json:"p95_ns"` // 95th percentile of one decision's time
+	Max        time.Duration `json:"max_ns"`
+	OverBudget int           `json:"over_budget"` // Decisions slower than the profiler's budget
+}
+
+// Mean returns the average time of one decision
+func (p ProfileSummary) Mean() time.Duration {
+	if p.Decisions == 0 {
+		return 0
+	}
+	return p.Total / time.Duration(p.Decisions)
+}
+
+// Profiler aggregates the decision profiles of traceable bots by name, to
+// find the bots too slow for the action clock. It is safe for concurrent
+// use, so one profiler can watch every game of a batch.
+type Profiler struct {
+	budget time.Duration
+
+	mu    sync.Mutex
+	bots  map[string]*ProfileSummary
+	times map[string][]time.Duration // Every decision's time, for the percentile
+	order []string
+}
+
+// NewProfiler creates a profiler counting decisions slower than budget, or
+// none when budget is zero
+func NewProfiler(budget time.Duration) *Profiler {
+	return &Profiler{budget: budget, bots: map[string]*ProfileSummary{}, times: map[string][]time.Duration{}}
+}
+
+// Sink returns a trace sink adding a bot's decisions under its name, for
+// holdem_ai.ITraceable.SetTraceSink
+func (p *Profiler) Sink(bot string) func(holdem_ai.DecisionTrace) {
+	return func(trace holdem_ai.DecisionTrace) {
+		p.Add(bot, trace.Profile)
+	}
+}
+
+// Add counts one decision of a bot
+func (p *Profiler) Add(bot string, profile holdem_ai.DecisionProfile) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	summary, ok := p.bots[bot]
+	if !ok {
+		summary = &ProfileSummary{Name: bot}
+		p.bots[bot] = summary
+		p.order = append(p.order, bot)
+	}
+	summary.Decisions++
+	summary.Evaluation += profile.Evaluation
+	summary.Equity += profile.Equity
+	summary.Search += profile.Search
+	summary.Total += profile.Total
+	summary.Max = max(summary.Max, profile.Total)
+	if p.budget > 0 && profile.Total > p.budget {
+		summary.OverBudget++
+	}
+	p.times[bot] = append(p.times[bot], profile.Total)
+}
+
+// Budget returns the decision time the profiler counts overruns of
+func (p *Profiler) Budget() time.Duration {
+	return p.budget
+}
+
+// Summaries returns every bot's profile in order of first decision
+func (p *Profiler) Summaries() []ProfileSummary {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	summaries := make([]ProfileSummary, 0, len(p.order))
+	for _, name := range p.order {
+		summary := *p.bots[name]
+		times := append([]time.Duration{}, p.times[name]...)
+		sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+		summary.P95 = times[(len(times)*95-1)/100]
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
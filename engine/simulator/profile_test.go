@@ -0,0 +1,57 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+)
+
+func TestProfilerSummarizesDecisions(t *testing.T) {
+	profiler := NewProfiler(50 * time.Millisecond)
+	for i := 1; i <= 20; i++ {
+		profiler.Add("slow", holdem_ai.DecisionProfile{Equity: time.Duration(i) * 4 * time.Millisecond, Total: time.Duration(i) * 5 * time.Millisecond})
+	}
+	profiler.Add("fast", holdem_ai.DecisionProfile{Total: time.Millisecond})
+
+	summaries := profiler.Summaries()
+	if len(summaries) != 2 || summaries[0].Name != "slow" {
+		t.Fatalf("Expected slow then fast, got %+v", summaries)
+	}
+	slow := summaries[0]
+	if slow.Decisions != 20 || slow.Mean() != 52500*time.Microsecond {
+		t.Errorf("Expected 20 decisions of 52.5ms on average, got %d of %s", slow.Decisions, slow.Mean())
+	}
+	if slow.P95 != 95*time.Millisecond || slow.Max != 100*time.Millisecond {
+		t.Errorf("Expected a p95 of 95ms and a max of 100ms, got %s and %s", slow.P95, slow.Max)
+	}
+	if slow.OverBudget != 10 || summaries[1].OverBudget != 0 {
+		t.Errorf("Expected 10 slow decisions over budget and no fast ones, got %d and %d", slow.OverBudget, summaries[1].OverBudget)
+	}
+}
+
+func TestProfilerWatchesACashGame(t *testing.T) {
+	profiler := NewProfiler(0)
+	entrants := cashEntrants()
+	for _, entrant := range entrants {
+		entrant.Maker.(holdem_ai.ITraceable).SetTraceSink(profiler.Sink(entrant.Name))
+	}
+	if _, err := RunCashGame(context.Background(), entrants, CashGameConfig{
+		Game:     holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 4},
+		BuyIn:    1000,
+		MaxHands: 20,
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	summaries := profiler.Summaries()
+	if len(summaries) != len(entrants) {
+		t.Fatalf("Expected every bot profiled, got %+v", summaries)
+	}
+	for _, summary := range summaries {
+		if summary.Decisions == 0 || summary.Total <= 0 || summary.Total < summary.Evaluation+summary.Equity+summary.Search {
+			t.Errorf("Expected %s's decisions to add up, got %+v", summary.Name, summary)
+		}
+	}
+}
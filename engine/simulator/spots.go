@@ -0,0 +1,76 @@
+package simulator
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/pokermath"
+)
+
+// spotBigBlind scales generated pots and bets
+const spotBigBlind = 10
+
+// maxSpotDeals bounds how many deals RandomSpot tries before giving up
+const maxSpotDeals = 1000
+
+// betFractions are the bet sizes, as a share of the pot, a spot can face
+var betFractions = []float64{1.0 / 3, 0.5, 2.0 / 3, 0.75, 1}
+
+// Spot is a heads-up decision on the flop or turn: the hero is behind the
+// villain's face-up hand, has outs to win, and faces a bet
+type Spot struct {
+	Hero    poker.Cards
+	Villain poker.Cards
+	Board   poker.Cards
+	Pot     int         // Pot before the bet
+	Bet     int         // Bet the hero has to call
+	Outs    poker.Cards // Next cards that put the hero ahead
+	Equity  float64     // Hero's share of the pot if the board runs out
+}
+
+// PotOdds returns the equity the hero needs to break even on a call
+func (s *Spot) PotOdds() float64 {
+	return pokermath.EquityToCall(s.Pot, s.Bet)
+}
+
+// RandomSpot deals random hands until the hero is behind with at least one
+// out, then sizes a pot and a bet
+func RandomSpot(rng *rand.Rand) (*Spot, error) {
+	for deal := 0; deal < maxSpotDeals; deal++ {
+		deck := poker.NewDeckCards()
+		cards := poker.Cards{}
+		for _, card := range deck {
+			if card.Suit != poker.SuitNone {
+				cards = append(cards, card)
+			}
+		}
+		rng.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+
+		spot := &Spot{
+			Hero:    cards[0:2],
+			Villain: cards[2:4],
+			Board:   cards[4 : 7+rng.Intn(2)],
+		}
+		holes := []poker.Cards{spot.Hero, spot.Villain}
+		outs, err := equity.Outs(holes, spot.Board, 0, equity.Options{})
+		if err != nil {
+			return nil, err
+		}
+		// Ties count as behind but leave nothing to draw to
+		if len(outs) == 0 {
+			continue
+		}
+		result, err := equity.Calculate(holes, spot.Board, equity.Options{})
+		if err != nil {
+			return nil, err
+		}
+		spot.Outs = outs
+		spot.Equity = result.Equity[0]
+		spot.Pot = spotBigBlind * (4 + rng.Intn(37))
+		spot.Bet = max(spotBigBlind, int(float64(spot.Pot)*betFractions[rng.Intn(len(betFractions))])/5*5)
+		return spot, nil
+	}
+	return nil, fmt.Errorf("no drawing spot found in %d deals", maxSpotDeals)
+}
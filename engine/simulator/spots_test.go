@@ -0,0 +1,47 @@
+package simulator
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestRandomSpotIsADrawingHand(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	for i := 0; i < 20; i++ {
+		spot, err := RandomSpot(rng)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(spot.Board) != 3 && len(spot.Board) != 4 {
+			t.Fatalf("Expected a flop or turn, got %s", spot.Board.Codes())
+		}
+		seen := map[poker.Card]bool{}
+		for _, card := range append(append(append(poker.Cards{}, spot.Hero...), spot.Villain...), spot.Board...) {
+			if seen[*card] {
+				t.Fatalf("Card %s dealt twice", card.Code())
+			}
+			seen[*card] = true
+		}
+		if len(spot.Outs) == 0 || spot.Equity <= 0 || spot.Equity >= 1 {
+			t.Errorf("Expected a drawing hand, got %d outs and %.3f equity", len(spot.Outs), spot.Equity)
+		}
+		if spot.Bet <= 0 || spot.Bet > spot.Pot {
+			t.Errorf("Expected a bet up to pot size, got %d into %d", spot.Bet, spot.Pot)
+		}
+		result, _ := equity.Calculate([]poker.Cards{spot.Hero, spot.Villain}, spot.Board, equity.Options{})
+		if math.Abs(result.Equity[0]-spot.Equity) > 1e-9 {
+			t.Errorf("Expected equity %.3f, got %.3f", result.Equity[0], spot.Equity)
+		}
+	}
+}
+
+func TestSpotPotOdds(t *testing.T) {
+	spot := &Spot{Pot: 100, Bet: 50}
+	if odds := spot.PotOdds(); math.Abs(odds-0.25) > 1e-9 {
+		t.Errorf("Expected 25%%, got %.3f", odds)
+	}
+}
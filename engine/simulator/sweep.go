@@ -0,0 +1,195 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+)
+
+// SweepParameter is a setting a sweep changes from one batch of games to the next
+type SweepParameter string
+
+const (
+	// SweepAggression sets the aggressiveness of the first entrant, from 0 to 1
+	SweepAggression SweepParameter = "aggression"
+	// SweepBluff sets the bluff frequency of the first entrant, from 0 to 1
+	SweepBluff SweepParameter = "bluff"
+	// SweepStack sets every entrant's buy-in, in big blinds
+	SweepStack SweepParameter = "stack"
+)
+
+// SweepParameters lists the parameters a sweep can change
+func SweepParameters() []SweepParameter {
+	return []SweepParameter{SweepAggression, SweepBluff, SweepStack}
+}
+
+// maxSweepValues caps the batches of a sweep, so a mistyped step does not
+// start thousands of them
+const maxSweepValues = 100
+
+// Sweep is a parameter and the values to play a batch of games at
+type Sweep struct {
+	Parameter SweepParameter
+	Values    []float64
+}
+
+// ParseSweep reads a sweep written as "parameter=from:to:step", e.g.
+// "aggression=0.1:0.9:0.1", or as a list of values, e.g. "stack=20,50,100"
+func ParseSweep(spec string) (Sweep, error) {
+	name, values, ok := strings.Cut(spec, "=")
+	if !ok {
+		return Sweep{}, fmt.Errorf("sweep %q is not parameter=from:to:step or parameter=v1,v2,…", spec)
+	}
+	sweep := Sweep{Parameter: SweepParameter(strings.TrimSpace(name))}
+	if !slices.Contains(SweepParameters(), sweep.Parameter) {
+		return Sweep{}, fmt.Errorf("unknown sweep parameter %q, want one of %v", name, SweepParameters())
+	}
+	var err error
+	if bounds := strings.Split(values, ":"); len(bounds) == 3 {
+		sweep.Values, err = sweepRange(bounds)
+	} else {
+		sweep.Values, err = sweepList(values)
+	}
+	if err != nil {
+		return Sweep{}, fmt.Errorf("sweep %s: %w", sweep.Parameter, err)
+	}
+	if err := sweep.Validate(); err != nil {
+		return Sweep{}, err
+	}
+	return sweep, nil
+}
+
+// sweepRange returns the values from bounds[0] to bounds[1] in steps of bounds[2]
+func sweepRange(bounds []string) ([]float64, error) {
+	numbers := make([]float64, 3)
+	for i, bound := range bounds {
+		n, err := strconv.ParseFloat(strings.TrimSpace(bound), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", bound)
+		}
+		numbers[i] = n
+	}
+	from, to, step := numbers[0], numbers[1], numbers[2]
+	if step <= 0 || to < from {
+		return nil, fmt.Errorf("need from ≤ to and a positive step, got %g:%g:%g", from, to, step)
+	}
+	// The epsilon keeps the last value when steps like 0.1 do not add up exactly
+	count := int(math.Floor((to-from)/step+1e-9)) + 1
+	if count > maxSweepValues {
+		return nil, fmt.Errorf("%d values, at most %d are played", count, maxSweepValues)
+	}
+	values := make([]float64, count)
+	for i := range values {
+		values[i] = math.Round((from+float64(i)*step)*1e9) / 1e9
+	}
+	return values, nil
+}
+
+// sweepList parses comma-separated values
+func sweepList(list string) ([]float64, error) {
+	values := []float64{}
+	for _, field := range strings.Split(list, ",") {
+		n, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", field)
+		}
+		values = append(values, n)
+	}
+	if len(values) > maxSweepValues {
+		return nil, fmt.Errorf("%d values, at most %d are played", len(values), maxSweepValues)
+	}
+	return values, nil
+}
+
+// Validate checks every value is one the parameter can take
+func (s Sweep) Validate() error {
+	if len(s.Values) == 0 {
+		return fmt.Errorf("sweep %s has no values", s.Parameter)
+	}
+	for _, value := range s.Values {
+		switch s.Parameter {
+		case SweepAggression, SweepBluff:
+			if value < 0 || value > 1 {
+				return fmt.Errorf("sweep %s: %g is not between 0 and 1", s.Parameter, value)
+			}
+		case SweepStack:
+			if value < 1 {
+				return fmt.Errorf("sweep %s: %g is less than a big blind", s.Parameter, value)
+			}
+		default:
+			return fmt.Errorf("unknown sweep parameter %q", s.Parameter)
+		}
+	}
+	return nil
+}
+
+// SweptSuffix marks the name of the entrant an aggression or bluff sweep
+// changes, telling it apart from seats playing the same preset
+const SweptSuffix = "*"
+
+// applyToGame sets a stack sweep's value in a game's config
+func (s Sweep) applyToGame(value float64, game *CashGameConfig) {
+	if s.Parameter == SweepStack {
+		game.BuyIn = int(math.Round(value * float64(game.Game.BigBlind)))
+	}
+}
+
+// applyToBot sets an aggression or bluff sweep's value on the first
+// entrant, which must be a basic bot
+func (s Sweep) applyToBot(value float64, entrants []Entrant) error {
+	if s.Parameter != SweepAggression && s.Parameter != SweepBluff {
+		return nil
+	}
+	if len(entrants) == 0 {
+		return fmt.Errorf("no entrant to sweep the %s of", s.Parameter)
+	}
+	bot, ok := holdem_ai.As[*holdem_ai.BasicBotDecisionMaker](entrants[0].Maker)
+	if !ok {
+		return fmt.Errorf("%s sweeps change the first bot, and %s has no %s to change", s.Parameter, entrants[0].Name, s.Parameter)
+	}
+	if s.Parameter == SweepAggression {
+		bot.Aggressiveness = value
+	} else {
+		bot.BluffFrequency = value
+	}
+	entrants[0].Name += SweptSuffix
+	return nil
+}
+
+// SweepPoint is how the bots did in the batch played at one value
+type SweepPoint struct {
+	Value float64
+	Bots  []CashSummary // Best net first
+}
+
+// RunCashSweep plays a batch of cash games at every value of the sweep, in
+// order. Every batch is played from the same seeds, so the cards only
+// change once the changed play does. config.Progress follows each batch
+// in turn, and config.Recorder collects the hands of all of them.
+func RunCashSweep(ctx context.Context, config BatchConfig, game CashGameConfig, sweep Sweep, entrants func(seed int64) ([]Entrant, error)) ([]SweepPoint, error) {
+	if err := sweep.Validate(); err != nil {
+		return nil, err
+	}
+	points := make([]SweepPoint, 0, len(sweep.Values))
+	for _, value := range sweep.Values {
+		pointGame := game
+		sweep.applyToGame(value, &pointGame)
+		results, err := RunCashGames(ctx, config, pointGame, func(seed int64) ([]Entrant, error) {
+			field, err := entrants(seed)
+			if err != nil {
+				return nil, err
+			}
+			return field, sweep.applyToBot(value, field)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s %g: %w", sweep.Parameter, value, err)
+		}
+		points = append(points, SweepPoint{Value: value, Bots: SummarizeCashGames(results)})
+	}
+	return points, nil
+}
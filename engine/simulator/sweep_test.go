@@ -0,0 +1,88 @@
+package simulator
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+)
+
+// wrappedBot hides the kind of bot it plays for
+type wrappedBot struct {
+	holdem_ai.IDecisionMaker
+}
+
+func TestParseSweep(t *testing.T) {
+	sweep, err := ParseSweep("aggression=0.1:0.9:0.2")
+	if err != nil {
+		t.Fatalf("ParseSweep failed: %v", err)
+	}
+	if want := []float64{0.1, 0.3, 0.5, 0.7, 0.9}; sweep.Parameter != SweepAggression || !slices.Equal(sweep.Values, want) {
+		t.Errorf("Expected aggression at %v, got %+v", want, sweep)
+	}
+	sweep, err = ParseSweep("stack=20, 50,200")
+	if err != nil || sweep.Parameter != SweepStack || !slices.Equal(sweep.Values, []float64{20, 50, 200}) {
+		t.Errorf("Expected stacks of 20, 50 and 200, got %+v (%v)", sweep, err)
+	}
+	for _, spec := range []string{"aggression", "speed=1,2", "bluff=0.5:1.5:0.5", "stack=0:100:10", "stack=10:5:1", "stack=1:1000:1", "aggression=a,b"} {
+		if _, err := ParseSweep(spec); err == nil {
+			t.Errorf("Expected %q to be rejected", spec)
+		}
+	}
+}
+
+func TestRunCashSweep(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	batch := BatchConfig{Runs: 2, Workers: 2, Seed: 5}
+	game := CashGameConfig{Game: holdem.GameConfig{SmallBlind: 5, BigBlind: 10}, BuyIn: 1000, MaxHands: 50}
+	var mu sync.Mutex // Games are played on two workers
+	aggressions := []*holdem_ai.BasicBotDecisionMaker{}
+	entrants := func(seed int64) ([]Entrant, error) {
+		hero := quickBot(0.5, 0.1).(*holdem_ai.BasicBotDecisionMaker)
+		mu.Lock()
+		aggressions = append(aggressions, hero)
+		mu.Unlock()
+		return []Entrant{{Name: "hero", Maker: hero}, {Name: "tight", Maker: quickBot(0.1, 0.01)}}, nil
+	}
+
+	points, err := RunCashSweep(ctx, batch, game, Sweep{Parameter: SweepAggression, Values: []float64{0.2, 0.8}}, entrants)
+	if err != nil {
+		t.Fatalf("RunCashSweep failed: %v", err)
+	}
+	if len(points) != 2 || points[0].Value != 0.2 || points[1].Value != 0.8 {
+		t.Fatalf("Expected a point per value, got %+v", points)
+	}
+	for _, point := range points {
+		names := []string{point.Bots[0].Name, point.Bots[1].Name}
+		if !slices.Contains(names, "hero"+SweptSuffix) || !slices.Contains(names, "tight") || point.Bots[0].Sessions != 2 {
+			t.Errorf("Expected the swept hero and the tight bot over 2 sessions, got %+v", point.Bots)
+		}
+	}
+	if len(aggressions) != 4 || aggressions[0].Aggressiveness != 0.2 || aggressions[3].Aggressiveness != 0.8 {
+		t.Errorf("Expected the hero played at 0.2 and then 0.8")
+	}
+
+	// Stacks change every buy-in, so nobody loses more than the shortest
+	points, err = RunCashSweep(ctx, batch, game, Sweep{Parameter: SweepStack, Values: []float64{5}}, entrants)
+	if err != nil {
+		t.Fatalf("RunCashSweep failed: %v", err)
+	}
+	for _, bot := range points[0].Bots {
+		if bot.Net < -2*50 {
+			t.Errorf("Expected %s to lose at most two 5 big blind buy-ins, lost %d", bot.Name, -bot.Net)
+		}
+	}
+
+	// Only basic bots have a bluff frequency to sweep
+	wrapped := func(seed int64) ([]Entrant, error) {
+		return []Entrant{{Name: "wrapped", Maker: wrappedBot{quickBot(0.5, 0.1)}}, {Name: "tight", Maker: quickBot(0.1, 0.01)}}, nil
+	}
+	if _, err := RunCashSweep(ctx, batch, game, Sweep{Parameter: SweepBluff, Values: []float64{0.5}}, wrapped); err == nil {
+		t.Error("Expected a bluff sweep of another kind of bot to be refused")
+	}
+}
@@ -0,0 +1,134 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/metrics"
+	"github.com/ljbink/ai-poker/engine/session"
+	"github.com/ljbink/ai-poker/engine/tournament"
+)
+
+// DefaultMaxHands stops a tournament that never finishes, e.g. because every bot only checks
+const DefaultMaxHands = 5000
+
+// Entrant is a player entered into a simulated tournament
+type Entrant struct {
+	Name  string
+	Maker holdem_ai.IDecisionMaker
+}
+
+// TournamentResult is the outcome of one simulated tournament
+type TournamentResult struct {
+	Standings []tournament.Standing
+	Hands     int
+	Level     int // Zero-based level the tournament ended on
+}
+
+// RunSitAndGo plays a complete sit-and-go between the entrants, who get
+// player IDs 1..n in order. Levels progress by hand count through the
+// blind structure, the standard one when it has no levels. Hands are
+// recorded for export when recorder is not nil.
+func RunSitAndGo(ctx context.Context, seats int, structure tournament.BlindStructure, entrants []Entrant, buyIn int, seed int64, recorder *Recorder) (*TournamentResult, error) {
+	return runSitAndGo(ctx, seats, structure, entrants, buyIn, seed, recorder, nil)
+}
+
+// runSitAndGo is RunSitAndGo reporting the tables' play to sink, if any
+func runSitAndGo(ctx context.Context, seats int, structure tournament.BlindStructure, entrants []Entrant, buyIn int, seed int64, recorder *Recorder, sink metrics.IMetrics) (*TournamentResult, error) {
+	if len(entrants) < 2 || len(entrants) > seats {
+		return nil, fmt.Errorf("a %d-max sit-and-go needs 2 to %d entrants, got %d", seats, seats, len(entrants))
+	}
+	config, err := tournament.SitAndGo(seats, tournament.ProgressByHands, buyIn)
+	if err != nil {
+		return nil, err
+	}
+	config.Seed = seed
+	if len(structure.Levels) > 0 {
+		config.Structure = structure
+	}
+	if len(config.Payouts) > len(entrants) {
+		config.Payouts = nil
+	}
+
+	t, err := tournament.New(config)
+	if err != nil {
+		return nil, err
+	}
+	makers := map[int]holdem_ai.IDecisionMaker{}
+	for i, entrant := range entrants {
+		if err := t.Register(i+1, entrant.Name); err != nil {
+			return nil, err
+		}
+		makers[i+1] = entrant.Maker
+	}
+	if err := t.Start(); err != nil {
+		return nil, err
+	}
+	return runTournament(ctx, t, makers, DefaultMaxHands, recorder, sink)
+}
+
+// RunTournament plays a started tournament to the end. Decision makers are
+// keyed by player ID and follow players when tables are balanced, and bots
+// that can are switched to push/fold play when short-stacked. Hands are
+// recorded for export when recorder is not nil.
+func RunTournament(ctx context.Context, t *tournament.Tournament, makers map[int]holdem_ai.IDecisionMaker, maxHands int, recorder *Recorder) (*TournamentResult, error) {
+	return runTournament(ctx, t, makers, maxHands, recorder, nil)
+}
+
+// runTournament is RunTournament reporting the tables' play to sink, if any
+func runTournament(ctx context.Context, t *tournament.Tournament, makers map[int]holdem_ai.IDecisionMaker, maxHands int, recorder *Recorder, sink metrics.IMetrics) (*TournamentResult, error) {
+	if maxHands <= 0 {
+		maxHands = DefaultMaxHands
+	}
+	for _, maker := range makers {
+		if folder, ok := holdem_ai.As[holdem_ai.IPushFolder](maker); ok {
+			folder.SetPushFold(true)
+		}
+	}
+	recorder.startRun()
+	sessions := map[int]*session.Session{}
+
+	for !t.IsFinished() {
+		played := false
+		for _, table := range t.ActiveTables() {
+			game := t.Tables()[table]
+			if len(game.GetAllPlayers()) < 2 {
+				continue
+			}
+			s, ok := sessions[table]
+			if !ok {
+				if sink != nil {
+					game.SetMetrics(sink)
+				}
+				s = session.New(game)
+				s.SetDecisionMakers(makers)
+				sessions[table] = s
+			}
+			hand, err := s.PlayHand(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("table %d: %w", table, err)
+			}
+			recorder.record(game, hand)
+			if _, _, err := t.HandCompleted(table); err != nil {
+				return nil, fmt.Errorf("table %d: %w", table, err)
+			}
+			played = true
+			if t.HandsPlayed() >= maxHands {
+				return nil, fmt.Errorf("tournament not finished after %d hands", maxHands)
+			}
+			if t.IsFinished() {
+				break
+			}
+		}
+		if !played {
+			return nil, fmt.Errorf("no table has enough players to deal")
+		}
+	}
+
+	return &TournamentResult{
+		Standings: t.Standings(),
+		Hands:     t.HandsPlayed(),
+		Level:     t.LevelIndex(),
+	}, nil
+}
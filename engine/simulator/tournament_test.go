@@ -0,0 +1,86 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/tournament"
+)
+
+func quickBot(aggressiveness, bluff float64) holdem_ai.IDecisionMaker {
+	bot := holdem_ai.NewBasicBotDecisionMaker(aggressiveness, bluff)
+	bot.SetThinkingTime(0, 0)
+	return bot
+}
+
+func TestRunSitAndGoFinishes(t *testing.T) {
+	entrants := []Entrant{
+		{Name: "tight", Maker: quickBot(0.1, 0.01)},
+		{Name: "loose", Maker: quickBot(0.9, 0.4)},
+		{Name: "balanced", Maker: quickBot(0.6, 0.15)},
+		{Name: "maniac", Maker: quickBot(0.95, 0.5)},
+		{Name: "station", Maker: quickBot(0.3, 0.02)},
+		{Name: "basic", Maker: quickBot(0.5, 0.1)},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	result, err := RunSitAndGo(ctx, 6, tournament.BlindStructure{}, entrants, 100, 11, nil)
+	if err != nil {
+		t.Fatalf("RunSitAndGo failed: %v", err)
+	}
+
+	t.Logf("finished after %d hands on level %d", result.Hands, result.Level+1)
+	if len(result.Standings) != 6 {
+		t.Fatalf("Expected 6 standings, got %d", len(result.Standings))
+	}
+	prizes := 0
+	for i, standing := range result.Standings {
+		if standing.Place != i+1 {
+			t.Errorf("Expected place %d, got %+v", i+1, standing)
+		}
+		prizes += standing.Prize
+	}
+	if prizes != 600 {
+		t.Errorf("Expected the 600 prize pool to be paid out, got %d", prizes)
+	}
+	if winner := result.Standings[0]; winner.Chips != 6*1500 || winner.Prize != 300 {
+		t.Errorf("Expected the winner to hold every chip and take 300, got %+v", winner)
+	}
+}
+
+func TestRunSitAndGoRejectsBadField(t *testing.T) {
+	if _, err := RunSitAndGo(context.Background(), 6, tournament.BlindStructure{}, []Entrant{{Name: "alone"}}, 0, 1, nil); err == nil {
+		t.Error("Expected error for a single entrant")
+	}
+	if _, err := RunSitAndGo(context.Background(), 7, tournament.BlindStructure{}, make([]Entrant, 7), 0, 1, nil); err == nil {
+		t.Error("Expected error for a 7-max table")
+	}
+}
+
+func TestRunSitAndGoPlaysStructure(t *testing.T) {
+	hyper, err := tournament.Structure("hyper")
+	if err != nil {
+		t.Fatalf("Structure failed: %v", err)
+	}
+	entrants := []Entrant{
+		{Name: "tight", Maker: quickBot(0.1, 0.01)},
+		{Name: "loose", Maker: quickBot(0.9, 0.4)},
+		{Name: "station", Maker: quickBot(0.3, 0.02)},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	recorder := NewRecorder()
+	if _, err := RunSitAndGo(ctx, 6, hyper, entrants, 100, 5, recorder); err != nil {
+		t.Fatalf("RunSitAndGo failed: %v", err)
+	}
+	hands := recorder.Export().Hands
+	if hands[0].BigBlind != hyper.Level(0).BigBlind {
+		t.Errorf("Expected the first hand at the hyper's %d big blind, got %d", hyper.Level(0).BigBlind, hands[0].BigBlind)
+	}
+	if level := hyper.Level(0); len(hands) > level.Hands && hands[level.Hands].BigBlind != hyper.Level(1).BigBlind {
+		t.Errorf("Expected the blinds to go up after %d hands, got %+v", level.Hands, hands[level.Hands])
+	}
+}
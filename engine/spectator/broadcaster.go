@@ -0,0 +1,252 @@
+package spectator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// Mode selects what a subscriber is allowed to see
+type Mode int
+
+const (
+	ModeRedacted    Mode = iota // Hole cards hidden until showdown
+	ModeCommentator             // Every hole card visible, held back until the hand is over
+)
+
+const (
+	queueSize  = 256 // Frames waiting out their delay per subscriber
+	outputSize = 64  // Frames ready but not yet read by a subscriber
+)
+
+// frame is a snapshot stamped with the time it was published
+type frame struct {
+	at   time.Time
+	view holdem.TableView
+}
+
+// Subscription delivers table snapshots to one spectator
+type Subscription struct {
+	id      int
+	mode    Mode
+	delay   time.Duration
+	queue   chan frame
+	out     chan holdem.TableView
+	owner   *Broadcaster
+	held    []frame // Commentator frames of the hand in progress, guarded by the broadcaster
+	dropped int
+	mu      sync.Mutex
+	once    sync.Once
+}
+
+// Views returns the channel snapshots are delivered on.
+// It is closed when the subscription ends.
+func (s *Subscription) Views() <-chan holdem.TableView {
+	return s.out
+}
+
+// Dropped returns how many snapshots were discarded because the spectator fell behind
+func (s *Subscription) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Unsubscribe stops delivery and closes the views channel
+func (s *Subscription) Unsubscribe() {
+	s.owner.remove(s.id)
+	s.stop()
+}
+
+func (s *Subscription) stop() {
+	s.once.Do(func() {
+		close(s.queue)
+	})
+}
+
+func (s *Subscription) drop() {
+	s.mu.Lock()
+	s.dropped++
+	s.mu.Unlock()
+}
+
+// send queues a frame to wait out its delay, dropping it when the queue is full
+func (s *Subscription) send(f frame) {
+	select {
+	case s.queue <- f:
+	default:
+		s.drop()
+	}
+}
+
+// hold keeps a commentator frame back until the hand is over
+func (s *Subscription) hold(f frame) {
+	if len(s.held) >= queueSize {
+		s.drop()
+		return
+	}
+	s.held = append(s.held, f)
+}
+
+// release queues the frames held back during the hand that just ended
+func (s *Subscription) release() {
+	for _, f := range s.held {
+		s.send(f)
+	}
+	s.held = nil
+}
+
+// run holds each frame back until its delay has passed, then hands it to the spectator
+func (s *Subscription) run() {
+	defer close(s.out)
+	for f := range s.queue {
+		if wait := time.Until(f.at.Add(s.delay)); wait > 0 {
+			time.Sleep(wait)
+		}
+		select {
+		case s.out <- f.view:
+		default:
+			s.drop()
+		}
+	}
+}
+
+// Broadcaster fans table snapshots out to spectators. It is transport
+// agnostic: the TUI reads subscriptions directly and a network server can
+// forward them to remote clients.
+type Broadcaster struct {
+	mu     sync.Mutex
+	subs   map[int]*Subscription
+	nextID int
+	closed bool
+	now    func() time.Time
+}
+
+// NewBroadcaster creates a broadcaster with no subscribers
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{
+		subs: map[int]*Subscription{},
+		now:  time.Now,
+	}
+}
+
+// Subscribe registers a spectator. Snapshots are delivered delay after they
+// are published, which keeps live spectators from relaying information to
+// players. Commentator snapshots show every hole card, so those published
+// while a hand is being played are only delivered once it is over.
+func (b *Broadcaster) Subscribe(mode Mode, delay time.Duration) *Subscription {
+	if delay < 0 {
+		delay = 0
+	}
+	sub := &Subscription{
+		mode:  mode,
+		delay: delay,
+		queue: make(chan frame, queueSize),
+		out:   make(chan holdem.TableView, outputSize),
+		owner: b,
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(sub.queue)
+		close(sub.out)
+		return sub
+	}
+	b.nextID++
+	sub.id = b.nextID
+	b.subs[sub.id] = sub
+	b.mu.Unlock()
+
+	go sub.run()
+	return sub
+}
+
+// Publish snapshots the game once per mode and queues it for every subscriber.
+// Snapshots are taken immediately, so delayed spectators see the table as it was.
+// Commentator snapshots are held back while the hand is live, see Subscribe.
+func (b *Broadcaster) Publish(game *holdem.Game) {
+	if game == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed || len(b.subs) == 0 {
+		return
+	}
+
+	at := b.now()
+	live := HandLive(game)
+	views := map[Mode]holdem.TableView{}
+	for _, sub := range b.subs {
+		view, ok := views[sub.mode]
+		if !ok {
+			view = Snapshot(game, sub.mode)
+			views[sub.mode] = view
+		}
+		if sub.mode == ModeCommentator {
+			if live {
+				sub.hold(frame{at: at, view: view})
+				continue
+			}
+			sub.release()
+		}
+		sub.send(frame{at: at, view: view})
+	}
+}
+
+// Subscribers returns the number of active subscriptions
+func (b *Broadcaster) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+// Close ends every subscription. Frames already queued are still delivered,
+// commentator frames of a hand that never finished are not.
+func (b *Broadcaster) Close() {
+	b.mu.Lock()
+	subs := b.subs
+	b.subs = map[int]*Subscription{}
+	b.closed = true
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.stop()
+	}
+}
+
+func (b *Broadcaster) remove(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subs, id)
+}
+
+// HandLive reports whether a hand is being played, so its hole cards are
+// still secret: from StartHand until the pot is awarded, or for cards dealt
+// without it, while two or more players are in the hand before the showdown
+func HandLive(game *holdem.Game) bool {
+	if game.IsHandInProgress() {
+		return true
+	}
+	if game.GetCurrentPhase() == holdem.PhaseShowdown {
+		return false
+	}
+	inHand := 0
+	for _, player := range game.GetAllPlayers() {
+		if !player.IsFolded() && len(player.GetHandCards()) > 0 {
+			inHand++
+		}
+	}
+	return inHand >= 2
+}
+
+// Snapshot returns the game as seen in the given mode
+func Snapshot(game *holdem.Game, mode Mode) holdem.TableView {
+	if mode == ModeCommentator {
+		return game.CommentatorView()
+	}
+	return game.SpectatorView()
+}
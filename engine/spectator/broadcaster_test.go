@@ -0,0 +1,140 @@
+package spectator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func newDealtGame(t *testing.T) *holdem.Game {
+	t.Helper()
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	game.PlayerSit(holdem.NewPlayer(1, "Alice", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Bob", 1000), 1)
+	if err := game.DealHoleCards(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return game
+}
+
+func receive(t *testing.T, sub *Subscription) holdem.TableView {
+	t.Helper()
+	select {
+	case view, ok := <-sub.Views():
+		if !ok {
+			t.Fatal("Expected a view, channel closed")
+		}
+		return view
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for a view")
+	}
+	return holdem.TableView{}
+}
+
+func TestBroadcasterRedactsPerMode(t *testing.T) {
+	b := NewBroadcaster()
+	defer b.Close()
+	spectator := b.Subscribe(ModeRedacted, 0)
+	commentator := b.Subscribe(ModeCommentator, 0)
+
+	game := newDealtGame(t)
+	b.Publish(game)
+
+	for _, seat := range receive(t, spectator).Seats {
+		if len(seat.HoleCards) != 0 {
+			t.Errorf("Expected redacted cards for seat %d", seat.Seat)
+		}
+	}
+	select {
+	case <-commentator.Views():
+		t.Fatal("Expected the commentator to see nothing while the hand is live")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	alice, err := game.GetPlayerByID(1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	alice.Fold()
+	b.Publish(game)
+	for _, seat := range receive(t, commentator).Seats {
+		if len(seat.HoleCards) != 2 {
+			t.Errorf("Expected visible cards for seat %d", seat.Seat)
+		}
+	}
+	if view := receive(t, commentator); view.Seats[0].Folded != true {
+		t.Error("Expected the held frame first, then the one that ended the hand")
+	}
+}
+
+func TestBroadcasterDelaysAndSnapshotsAtPublish(t *testing.T) {
+	b := NewBroadcaster()
+	defer b.Close()
+	sub := b.Subscribe(ModeRedacted, 50*time.Millisecond)
+
+	game := newDealtGame(t)
+	start := time.Now()
+	b.Publish(game)
+	if err := game.DealFlop(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	view := receive(t, sub)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected delivery after the delay, got %v", elapsed)
+	}
+	if len(view.Board) != 0 {
+		t.Errorf("Expected the preflop snapshot, got board %v", view.Board)
+	}
+}
+
+func TestUnsubscribeClosesChannel(t *testing.T) {
+	b := NewBroadcaster()
+	sub := b.Subscribe(ModeRedacted, 0)
+	if b.Subscribers() != 1 {
+		t.Fatalf("Expected 1 subscriber, got %d", b.Subscribers())
+	}
+
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+	if b.Subscribers() != 0 {
+		t.Errorf("Expected no subscribers, got %d", b.Subscribers())
+	}
+	select {
+	case _, ok := <-sub.Views():
+		if ok {
+			t.Error("Expected closed channel")
+		}
+	case <-time.After(time.Second):
+		t.Error("Timed out waiting for channel close")
+	}
+	b.Publish(newDealtGame(t))
+}
+
+func TestSlowSpectatorDropsFrames(t *testing.T) {
+	b := NewBroadcaster()
+	defer b.Close()
+	sub := b.Subscribe(ModeRedacted, 0)
+	game := newDealtGame(t)
+
+	for i := 0; i < queueSize+outputSize+50; i++ {
+		b.Publish(game)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for sub.Dropped() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if sub.Dropped() == 0 {
+		t.Error("Expected frames to be dropped for a slow spectator")
+	}
+}
+
+func TestSubscribeAfterClose(t *testing.T) {
+	b := NewBroadcaster()
+	b.Close()
+	sub := b.Subscribe(ModeRedacted, 0)
+	if _, ok := <-sub.Views(); ok {
+		t.Error("Expected closed channel after broadcaster close")
+	}
+}
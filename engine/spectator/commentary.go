@@ -0,0 +1,32 @@
+package spectator
+
+import (
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// CommentaryFrame is one step of a reviewed hand
+type CommentaryFrame struct {
+	Action holdem.LoggedAction // Action that produced this state
+	View   holdem.TableView    // Table after the action, all cards visible
+}
+
+// ReplayFrames re-runs a recorded hand and returns an all-cards-visible
+// snapshot after every action, for post-game review
+func ReplayFrames(replay *holdem.Replay) ([]CommentaryFrame, error) {
+	if replay == nil {
+		return nil, fmt.Errorf("replay is nil")
+	}
+	frames := make([]CommentaryFrame, 0, len(replay.Actions))
+	_, err := replay.RunObserved(func(game *holdem.Game, index int) {
+		frames = append(frames, CommentaryFrame{
+			Action: replay.Actions[index],
+			View:   game.CommentatorView(),
+		})
+	})
+	if err != nil {
+		return frames, err
+	}
+	return frames, nil
+}
@@ -0,0 +1,41 @@
+package spectator
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestReplayFramesShowEveryStep(t *testing.T) {
+	game := newDealtGame(t)
+	game.TakeAction(holdem.Action{PlayerID: 1, Type: holdem.ActionCall, Amount: 10})
+	game.TakeAction(holdem.Action{PlayerID: 2, Type: holdem.ActionCheck})
+	if err := game.DealFlop(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	replay, err := holdem.NewReplay(game, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	frames, err := ReplayFrames(replay)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(frames) != len(replay.Actions) {
+		t.Fatalf("Expected %d frames, got %d", len(replay.Actions), len(frames))
+	}
+
+	last := frames[len(frames)-1]
+	if last.Action.Action.Type != holdem.ActionSystemDealFlop || len(last.View.Board) != 3 {
+		t.Errorf("Expected final frame on the flop, got %+v", last.Action)
+	}
+	for _, seat := range last.View.Seats {
+		if len(seat.HoleCards) != 2 {
+			t.Errorf("Expected visible cards for seat %d", seat.Seat)
+		}
+	}
+	if _, err := ReplayFrames(nil); err == nil {
+		t.Error("Expected error for nil replay")
+	}
+}
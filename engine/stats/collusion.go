@@ -0,0 +1,362 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// CollusionKind is the pattern a pair of players was flagged for
+type CollusionKind int
+
+const (
+	ChipDumping CollusionKind = iota // One player's losses go mostly to another
+	PairFolding                      // A player folds to one opponent's bets far more than to anyone else's
+	SoftPlay                         // Two players check it down against each other far more than against others
+)
+
+func (k CollusionKind) String() string {
+	switch k {
+	case ChipDumping:
+		return "Chip dumping"
+	case PairFolding:
+		return "Pair folding"
+	case SoftPlay:
+		return "Soft play"
+	default:
+		return "Unknown"
+	}
+}
+
+// CollusionOptions are the thresholds a pair has to cross to be flagged
+type CollusionOptions struct {
+	MinSpots      int     // Heads-up spots a rate needs, both between the pair and against the others
+	DumpBigBlinds float64 // Big blinds one player has to lose to another
+	DumpShare     float64 // Share of the loser's losses that went to that player
+	FoldMargin    float64 // How much more often a player folds to the opponent than to the others
+	CheckMargin   float64 // How much more often the pair checks streets down than against the others
+}
+
+// DefaultCollusionOptions flag patterns that rarely come up in a few
+// hundred hands of honest play
+var DefaultCollusionOptions = CollusionOptions{
+	MinSpots:      10,
+	DumpBigBlinds: 50,
+	DumpShare:     0.6,
+	FoldMargin:    0.3,
+	CheckMargin:   0.3,
+}
+
+// CollusionFlag is a pair of players whose play against each other looks
+// coordinated. It is a lead for the table admin to review, not proof.
+type CollusionFlag struct {
+	Kind      CollusionKind
+	Players   [2]string // Chip dumping: loser, then winner. Pair folding: folder, then bettor.
+	Spots     int       // Hands or heads-up spots between the pair the flag rests on
+	Rate      float64   // Share of losses, fold rate or check-down rate between the pair
+	Baseline  float64   // The same rate against everyone else, 0 for chip dumping
+	BigBlinds float64   // Chip dumping only, big blinds lost to the winner
+	Hands     []string  // IDs of the hands involved
+}
+
+// String describes the flag in a line, e.g. "Pair folding: Carol folds to
+// Dave's bets 90% of 12 times heads-up, to others' 30%"
+func (f CollusionFlag) String() string {
+	switch f.Kind {
+	case ChipDumping:
+		return fmt.Sprintf("%s: %s lost %.1f big blinds to %s over %d hands, %.0f%% of their losses",
+			f.Kind, f.Players[0], f.BigBlinds, f.Players[1], f.Spots, f.Rate*100)
+	case PairFolding:
+		return fmt.Sprintf("%s: %s folds to %s's bets %.0f%% of %d times heads-up, to others' %.0f%%",
+			f.Kind, f.Players[0], f.Players[1], f.Rate*100, f.Spots, f.Baseline*100)
+	default:
+		return fmt.Sprintf("%s: %s and %s check down %.0f%% of %d heads-up streets, %.0f%% against others",
+			f.Kind, f.Players[0], f.Players[1], f.Rate*100, f.Spots, f.Baseline*100)
+	}
+}
+
+// pair is two players, ordered when the pattern has a direction
+type pair struct {
+	a, b string
+}
+
+// unordered returns the pair with its names sorted
+func unordered(a, b string) pair {
+	if b < a {
+		a, b = b, a
+	}
+	return pair{a, b}
+}
+
+// rate counts how often something happened out of the spots for it
+type rate struct {
+	spots, hits int
+	hands       []string
+}
+
+func (r *rate) add(hit bool, hand string) {
+	r.spots++
+	if hit {
+		r.hits++
+		r.hands = appendHand(r.hands, hand)
+	}
+}
+
+// CollusionMonitor accumulates what passes between each pair of players,
+// hand by hand, so a table that keeps running can be checked at any time
+type CollusionMonitor struct {
+	transfers map[pair]float64   // Big blinds the first player lost to the second
+	losses    map[string]float64 // Big blinds each player lost in all
+	dumped    map[pair][]string  // Hands the transfers came from
+	folds     map[pair]*rate     // Folds to the second player's bets heads-up
+	checks    map[pair]*rate     // Heads-up postflop streets between an unordered pair checked down
+}
+
+// NewCollusionMonitor creates a monitor that has seen no hands
+func NewCollusionMonitor() *CollusionMonitor {
+	return &CollusionMonitor{
+		transfers: map[pair]float64{},
+		losses:    map[string]float64{},
+		dumped:    map[pair][]string{},
+		folds:     map[pair]*rate{},
+		checks:    map[pair]*rate{},
+	}
+}
+
+// DetectCollusion runs the collusion heuristics over recorded hands
+func DetectCollusion(hands []*handhistory.Hand, opts CollusionOptions) []CollusionFlag {
+	monitor := NewCollusionMonitor()
+	for _, hand := range hands {
+		monitor.Add(hand)
+	}
+	return monitor.Flags(opts)
+}
+
+// Add folds one hand into the monitor
+func (m *CollusionMonitor) Add(hand *handhistory.Hand) {
+	m.addTransfers(hand)
+	m.addHeadsUp(hand)
+}
+
+// addTransfers splits each loser's loss between the winners by their share
+// of the pot
+func (m *CollusionMonitor) addTransfers(hand *handhistory.Hand) {
+	bigBlind := float64(max(hand.BigBlind, 1))
+	collected := 0
+	for _, chips := range hand.Collected {
+		collected += chips
+	}
+	if collected == 0 {
+		return
+	}
+	for _, loser := range hand.Seats {
+		lost := -hand.Net(loser.Name)
+		if lost <= 0 {
+			continue
+		}
+		m.losses[loser.Name] += float64(lost) / bigBlind
+		for _, winner := range hand.Seats {
+			if share := hand.Collected[winner.Name]; share > 0 && winner.Name != loser.Name {
+				key := pair{loser.Name, winner.Name}
+				m.transfers[key] += float64(lost) * float64(share) / float64(collected) / bigBlind
+				m.dumped[key] = appendHand(m.dumped[key], hand.ID)
+			}
+		}
+	}
+}
+
+// addHeadsUp records every bet or raise a player faced with only its
+// maker left in the hand, and every postflop street two players played
+// alone
+func (m *CollusionMonitor) addHeadsUp(hand *handhistory.Hand) {
+	live := map[string]bool{}
+	for _, seat := range hand.Seats {
+		live[seat.Name] = true
+	}
+	phase, aggressor := holdem.PhasePreflop, ""
+	var street []handhistory.Action // Actions of the street so far while only two are live
+	headsUp := false
+	endStreet := func() {
+		if !headsUp || phase == holdem.PhasePreflop || len(street) == 0 {
+			return
+		}
+		players := livePlayers(live)
+		checked := true
+		for _, action := range street {
+			checked = checked && action.Type == handhistory.ActionCheck
+		}
+		key := unordered(players[0], players[1])
+		if m.checks[key] == nil {
+			m.checks[key] = &rate{}
+		}
+		m.checks[key].add(checked, hand.ID)
+	}
+
+	for _, action := range hand.Actions {
+		if action.Type.IsForced() {
+			continue
+		}
+		if action.Phase != phase {
+			endStreet()
+			phase, aggressor, street = action.Phase, "", nil
+			headsUp = len(live) == 2
+		}
+		if aggressor != "" && aggressor != action.Player && len(live) == 2 {
+			key := pair{action.Player, aggressor}
+			if m.folds[key] == nil {
+				m.folds[key] = &rate{}
+			}
+			m.folds[key].add(action.Type == handhistory.ActionFold, hand.ID)
+		}
+		street = append(street, action)
+		switch action.Type {
+		case handhistory.ActionFold:
+			delete(live, action.Player)
+			headsUp = false // Only streets played to their end by the two count
+		case handhistory.ActionBet, handhistory.ActionRaise:
+			aggressor = action.Player
+		}
+	}
+	endStreet()
+}
+
+// livePlayers returns the names of the players still in the hand, sorted
+func livePlayers(live map[string]bool) []string {
+	names := make([]string, 0, len(live))
+	for name := range live {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Flags returns the pairs that cross the thresholds: chip dumping first,
+// then pair folding, then soft play, the most suspicious first within each
+func (m *CollusionMonitor) Flags(opts CollusionOptions) []CollusionFlag {
+	flags := m.chipDumping(opts)
+	flags = append(flags, m.pairFolding(opts)...)
+	flags = append(flags, m.softPlay(opts)...)
+	return flags
+}
+
+// chipDumping flags the players who lost most of their losses, and a lot
+// of big blinds, to one opponent who gave little back
+func (m *CollusionMonitor) chipDumping(opts CollusionOptions) []CollusionFlag {
+	flags := []CollusionFlag{}
+	for key, lost := range m.transfers {
+		share := lost / m.losses[key.a]
+		if lost < opts.DumpBigBlinds || share < opts.DumpShare || m.transfers[pair{key.b, key.a}] > lost/4 {
+			continue
+		}
+		flags = append(flags, CollusionFlag{
+			Kind: ChipDumping, Players: [2]string{key.a, key.b}, Spots: len(m.dumped[key]),
+			Rate: share, BigBlinds: lost, Hands: m.dumped[key],
+		})
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		if flags[i].BigBlinds != flags[j].BigBlinds {
+			return flags[i].BigBlinds > flags[j].BigBlinds
+		}
+		return flags[i].Players[0] < flags[j].Players[0]
+	})
+	return flags
+}
+
+// pairFolding flags the players who fold to one opponent heads-up far more
+// often than to everyone else
+func (m *CollusionMonitor) pairFolding(opts CollusionOptions) []CollusionFlag {
+	flags := []CollusionFlag{}
+	for key, folds := range m.folds {
+		others := rate{}
+		for other, r := range m.folds {
+			if other.a == key.a && other.b != key.b {
+				others.spots += r.spots
+				others.hits += r.hits
+			}
+		}
+		if folds.spots < opts.MinSpots || others.spots < opts.MinSpots {
+			continue
+		}
+		foldRate, baseline := ratio(folds.hits, folds.spots), ratio(others.hits, others.spots)
+		if foldRate-baseline < opts.FoldMargin {
+			continue
+		}
+		flags = append(flags, CollusionFlag{
+			Kind: PairFolding, Players: [2]string{key.a, key.b}, Spots: folds.spots,
+			Rate: foldRate, Baseline: baseline, Hands: folds.hands,
+		})
+	}
+	sortByMargin(flags)
+	return flags
+}
+
+// softPlay flags the pairs who check streets down against each other far
+// more often than either does against other players
+func (m *CollusionMonitor) softPlay(opts CollusionOptions) []CollusionFlag {
+	flags := []CollusionFlag{}
+	for key, checks := range m.checks {
+		others := rate{}
+		for other, r := range m.checks {
+			if other != key && (other.a == key.a || other.b == key.a || other.a == key.b || other.b == key.b) {
+				others.spots += r.spots
+				others.hits += r.hits
+			}
+		}
+		if checks.spots < opts.MinSpots || others.spots < opts.MinSpots {
+			continue
+		}
+		checkRate, baseline := ratio(checks.hits, checks.spots), ratio(others.hits, others.spots)
+		if checkRate-baseline < opts.CheckMargin {
+			continue
+		}
+		flags = append(flags, CollusionFlag{
+			Kind: SoftPlay, Players: [2]string{key.a, key.b}, Spots: checks.spots,
+			Rate: checkRate, Baseline: baseline, Hands: checks.hands,
+		})
+	}
+	sortByMargin(flags)
+	return flags
+}
+
+// sortByMargin orders flags by how far their rate is above the baseline
+func sortByMargin(flags []CollusionFlag) {
+	sort.Slice(flags, func(i, j int) bool {
+		mi, mj := flags[i].Rate-flags[i].Baseline, flags[j].Rate-flags[j].Baseline
+		if mi != mj {
+			return mi > mj
+		}
+		return flags[i].Players[0]+flags[i].Players[1] < flags[j].Players[0]+flags[j].Players[1]
+	})
+}
+
+// appendHand adds a hand ID once, hands being added in order
+func appendHand(hands []string, id string) []string {
+	if len(hands) > 0 && hands[len(hands)-1] == id {
+		return hands
+	}
+	return append(hands, id)
+}
+
+// WriteCollusionReport writes the flags for the table admin, each with the
+// first hands to review
+func WriteCollusionReport(w io.Writer, flags []CollusionFlag) error {
+	if len(flags) == 0 {
+		_, err := fmt.Fprintln(w, "No collusion patterns found.")
+		return err
+	}
+	fmt.Fprintf(w, "Collusion flags: %d, leads to review rather than proof\n", len(flags))
+	for _, f := range flags {
+		hands := f.Hands
+		if len(hands) > 5 {
+			hands = append(hands[:5:5], "…")
+		}
+		if _, err := fmt.Fprintf(w, "- %s\n  Hands: %s\n", f, strings.Join(hands, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/handhistory"
+)
+
+// collusionHand is a hand between Alice in the small blind, Bob in the big
+// blind and Carol on the button
+func collusionHand(t *testing.T, id int, cards [3]string, actions ...string) *handhistory.Hand {
+	t.Helper()
+	dealt := []string{}
+	for i, hole := range cards {
+		dealt = append(dealt, fmt.Sprintf("%q", fmt.Sprintf("d dh p%d %s", i+1, hole)))
+	}
+	for _, action := range actions {
+		dealt = append(dealt, fmt.Sprintf("%q", action))
+	}
+	hand := parse(t, fmt.Sprintf(`variant = "NT"
+hand = %d
+starting_stacks = [1000, 1000, 1000]
+blinds_or_straddles = [1, 2, 0]
+players = ["Alice", "Bob", "Carol"]
+actions = [%s]
+`, id, strings.Join(dealt, ", ")))
+	return hand
+}
+
+func TestDetectCollusion(t *testing.T) {
+	hidden := [3]string{"????", "????", "????"}
+	hands := []*handhistory.Hand{}
+	add := func(n int, cards [3]string, actions ...string) {
+		for i := 0; i < n; i++ {
+			hands = append(hands, collusionHand(t, len(hands)+1, cards, actions...))
+		}
+	}
+	// Alice raises and folds to Bob's reraise, handing him 30 big blinds
+	add(3, hidden, "p3 f", "p1 cbr 60", "p2 cbr 180", "p1 f")
+	// Alice and Bob check every street down against each other
+	add(4, [3]string{"AsAd", "KsKd", "????"}, "p3 f", "p1 cc", "p2 cc",
+		"d db 2c7h9d", "p1 cc", "p2 cc", "d db Jc", "p1 cc", "p2 cc", "d db 4s", "p1 cc", "p2 cc",
+		"p1 sm AsAd", "p2 sm KsKd")
+	// Bob bets into Carol, who calls him down
+	add(4, [3]string{"????", "QsQd", "JsJd"}, "p3 cc", "p1 f", "p2 cc",
+		"d db 2c7h9d", "p2 cbr 4", "p3 cc", "d db Kc", "p2 cbr 4", "p3 cc", "d db 4s", "p2 cc", "p3 cc",
+		"p2 sm QsQd", "p3 sm JsJd")
+	// Carol folds to Alice's every bet
+	add(4, hidden, "p3 cc", "p1 cc", "p2 f", "d db 2c7h9d", "p1 cbr 4", "p3 f")
+
+	opts := DefaultCollusionOptions
+	opts.MinSpots, opts.DumpBigBlinds = 3, 40
+	flags := DetectCollusion(hands, opts)
+	if len(flags) != 3 {
+		t.Fatalf("Expected a flag of each kind, got %+v", flags)
+	}
+
+	dump, folding, soft := flags[0], flags[1], flags[2]
+	if dump.Kind != ChipDumping || dump.Players != [2]string{"Alice", "Bob"} || dump.BigBlinds != 92 || dump.Spots != 7 || dump.Rate != 1 {
+		// The small blinds Alice folded to Bob's pots count too
+		t.Errorf("Expected Alice's 92 big blinds dumped to Bob, got %+v", dump)
+	}
+	if folding.Kind != PairFolding || folding.Players != [2]string{"Carol", "Alice"} || folding.Rate != 1 || folding.Baseline != 0 || folding.Spots != 4 {
+		t.Errorf("Expected Carol folding to Alice alone, got %+v", folding)
+	}
+	if soft.Kind != SoftPlay || soft.Players != [2]string{"Alice", "Bob"} || soft.Rate != 1 || soft.Spots != 12 || soft.Baseline > 0.34 {
+		t.Errorf("Expected Alice and Bob soft-playing each other, got %+v", soft)
+	}
+
+	var report strings.Builder
+	if err := WriteCollusionReport(&report, flags); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Collusion flags: 3",
+		"- Chip dumping: Alice lost 92.0 big blinds to Bob over 7 hands, 100% of their losses",
+		"  Hands: 1, 2, 3, 8, 9, …",
+		"- Soft play: Alice and Bob check down 100% of 12 heads-up streets, 33% against others",
+		"  Hands: 4, 5, 6, 7",
+	} {
+		if !strings.Contains(report.String(), want) {
+			t.Errorf("Expected %q in the report, got:\n%s", want, report.String())
+		}
+	}
+}
+
+func TestDetectCollusionNeedsEnoughSpots(t *testing.T) {
+	hands := []*handhistory.Hand{parse(t, sessionHands), parse(t, foldedHand)}
+	if flags := DetectCollusion(hands, DefaultCollusionOptions); len(flags) != 0 {
+		t.Errorf("Expected nothing flagged from two hands, got %+v", flags)
+	}
+	var report strings.Builder
+	WriteCollusionReport(&report, nil)
+	if report.String() != "No collusion patterns found.\n" {
+		t.Errorf("Expected the empty report to say so, got %q", report.String())
+	}
+}
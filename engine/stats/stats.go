@@ -0,0 +1,163 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// PlayerStats summarises one player's play over a set of hands
+type PlayerStats struct {
+	Name           string
+	Hands          int
+	VPIPHands      int     // Hands where money went in voluntarily preflop
+	PFRHands       int     // Hands raised preflop
+	SawFlop        int     // Hands still live when the flop was dealt
+	WentToShowdown int     // Hands that reached showdown
+	WonAtShowdown  int     // Showdowns that won chips
+	Net            int     // Total profit in chips or cents
+	NetBigBlinds   float64 // Total profit in big blinds
+	Timing         Timing  // Decision times, empty when the histories have none
+	BadBeats       int     // Pots lost all in as a big favourite, counted by analysis.CountBeats
+	Coolers        int     // Pots lost all in with a hand too strong to fold, counted by analysis.CountBeats
+
+	holdem.Situations // Cold calls, squeezes, donk bets and check-raises
+}
+
+// VPIP returns the share of hands the player voluntarily put money in preflop
+func (p *PlayerStats) VPIP() float64 {
+	return ratio(p.VPIPHands, p.Hands)
+}
+
+// PFR returns the share of hands the player raised preflop
+func (p *PlayerStats) PFR() float64 {
+	return ratio(p.PFRHands, p.Hands)
+}
+
+// WTSD returns how often the player went to showdown after seeing the flop
+func (p *PlayerStats) WTSD() float64 {
+	return ratio(p.WentToShowdown, p.SawFlop)
+}
+
+// WSD returns how often the player won chips when reaching showdown
+func (p *PlayerStats) WSD() float64 {
+	return ratio(p.WonAtShowdown, p.WentToShowdown)
+}
+
+// BBPer100 returns the win rate in big blinds per 100 hands
+func (p *PlayerStats) BBPer100() float64 {
+	if p.Hands == 0 {
+		return 0
+	}
+	return p.NetBigBlinds / float64(p.Hands) * 100
+}
+
+// Session holds statistics for every player seen in a set of hands
+type Session struct {
+	Hands      int
+	Players    map[string]*PlayerStats
+	Rake       int // Total rake taken, in chips or cents
+	RakedHands int // Hands the house took rake from
+}
+
+// NewSession creates an empty session
+func NewSession() *Session {
+	return &Session{Players: map[string]*PlayerStats{}}
+}
+
+// Compute builds session statistics from recorded hands
+func Compute(hands []*handhistory.Hand) *Session {
+	session := NewSession()
+	for _, hand := range hands {
+		session.Add(hand)
+	}
+	return session
+}
+
+// Add folds one hand into the session
+func (s *Session) Add(hand *handhistory.Hand) {
+	s.Hands++
+	s.Rake += hand.Rake
+	if hand.Rake > 0 {
+		s.RakedHands++
+	}
+	lines, ids := hand.UserActions()
+	for _, seat := range hand.Seats {
+		player := s.Players[seat.Name]
+		if player == nil {
+			player = &PlayerStats{Name: seat.Name}
+			s.Players[seat.Name] = player
+		}
+		player.Hands++
+
+		vpip, pfr := false, false
+		for _, action := range hand.Actions {
+			if action.Player != seat.Name || action.Phase != holdem.PhasePreflop {
+				continue
+			}
+			switch action.Type {
+			case handhistory.ActionCall, handhistory.ActionBet:
+				vpip = true
+			case handhistory.ActionRaise:
+				vpip, pfr = true, true
+			}
+		}
+		sawFlop := len(hand.Board) >= 3 && !foldedBy(hand, seat.Name, holdem.PhasePreflop)
+
+		if vpip {
+			player.VPIPHands++
+		}
+		if pfr {
+			player.PFRHands++
+		}
+		if sawFlop {
+			player.SawFlop++
+		}
+		net := hand.Net(seat.Name)
+		if hand.ReachedShowdown(seat.Name) {
+			player.WentToShowdown++
+			if hand.Collected[seat.Name] > 0 {
+				player.WonAtShowdown++
+			}
+		}
+		addTiming(player, hand)
+		player.Situations.Add(lines.Situations(ids[seat.Name]))
+		player.Net += net
+		if hand.BigBlind > 0 {
+			player.NetBigBlinds += float64(net) / float64(hand.BigBlind)
+		}
+	}
+}
+
+// Sorted returns the players ordered by profit, biggest winner first
+func (s *Session) Sorted() []*PlayerStats {
+	players := make([]*PlayerStats, 0, len(s.Players))
+	for _, player := range s.Players {
+		players = append(players, player)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Net != players[j].Net {
+			return players[i].Net > players[j].Net
+		}
+		return players[i].Name < players[j].Name
+	})
+	return players
+}
+
+// foldedBy reports whether the player folded during or before the given phase
+func foldedBy(hand *handhistory.Hand, name string, phase holdem.GamePhase) bool {
+	for _, action := range hand.Actions {
+		if action.Player == name && action.Type == handhistory.ActionFold && action.Phase <= phase {
+			return true
+		}
+	}
+	return false
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
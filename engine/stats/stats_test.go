@@ -0,0 +1,106 @@
+package stats
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+const sessionHands = `variant = "NT"
+starting_stacks = [100, 100, 100]
+blinds_or_straddles = [1, 2, 0]
+players = ["Alice", "Bob", "Carol"]
+actions = ["d dh p1 AsAd", "d dh p2 KsKd", "d dh p3 7c2h", "p3 f", "p1 cbr 6", "p2 cc",
+  "d db 2c3c4d", "p1 cbr 10", "p2 cc", "d db 9h", "d db Jc", "p1 cc", "p2 cc",
+  "p1 sm AsAd", "p2 sm KsKd"]
+`
+
+const foldedHand = `variant = "NT"
+starting_stacks = [100, 100, 100]
+blinds_or_straddles = [1, 2, 0]
+players = ["Alice", "Bob", "Carol"]
+actions = ["d dh p1 ????", "d dh p2 ????", "d dh p3 ????", "p3 cbr 6", "p1 f", "p2 f"]
+`
+
+func parse(t *testing.T, input string) *handhistory.Hand {
+	t.Helper()
+	hand, err := handhistory.ParsePHH(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return hand
+}
+
+func TestComputeSession(t *testing.T) {
+	session := Compute([]*handhistory.Hand{parse(t, sessionHands), parse(t, foldedHand)})
+	if session.Hands != 2 || len(session.Players) != 3 {
+		t.Fatalf("Expected 2 hands and 3 players, got %d and %d", session.Hands, len(session.Players))
+	}
+
+	alice := session.Players["Alice"]
+	if alice.VPIPHands != 1 || alice.PFRHands != 1 || alice.SawFlop != 1 {
+		t.Errorf("Unexpected Alice counts: %+v", alice)
+	}
+	if alice.WentToShowdown != 1 || alice.WonAtShowdown != 1 || alice.WSD() != 1 {
+		t.Errorf("Expected Alice to win her showdown: %+v", alice)
+	}
+	if alice.Net != 15 || math.Abs(alice.BBPer100()-375) > 1e-9 {
+		t.Errorf("Expected Alice +15 (375 bb/100), got %d (%.2f)", alice.Net, alice.BBPer100())
+	}
+
+	bob := session.Players["Bob"]
+	if bob.VPIPHands != 1 || bob.PFRHands != 0 || bob.Net != -18 {
+		t.Errorf("Unexpected Bob stats: %+v", bob)
+	}
+
+	carol := session.Players["Carol"]
+	if carol.VPIP() != 0.5 || carol.PFR() != 0.5 || carol.Net != 3 || carol.WTSD() != 0 {
+		t.Errorf("Unexpected Carol stats: %+v", carol)
+	}
+
+	sorted := session.Sorted()
+	if sorted[0].Name != "Alice" || sorted[2].Name != "Bob" {
+		t.Errorf("Expected players sorted by profit, got %s..%s", sorted[0].Name, sorted[2].Name)
+	}
+}
+
+func TestEmptyStats(t *testing.T) {
+	p := &PlayerStats{}
+	if p.VPIP() != 0 || p.WTSD() != 0 || p.BBPer100() != 0 {
+		t.Error("Expected zero ratios for an empty player")
+	}
+}
+
+func TestSessionRake(t *testing.T) {
+	raked := parse(t, sessionHands)
+	raked.Rake = 3
+	session := Compute([]*handhistory.Hand{raked, parse(t, foldedHand)})
+	if session.Rake != 3 || session.RakedHands != 1 {
+		t.Errorf("Expected 3 rake from 1 hand, got %d from %d", session.Rake, session.RakedHands)
+	}
+}
+
+const checkRaisedHand = `variant = "NT"
+starting_stacks = [100, 100, 100, 100]
+blinds_or_straddles = [1, 2, 0, 0]
+players = ["Alice", "Bob", "Carol", "Dave"]
+actions = ["d dh p1 ????", "d dh p2 ????", "d dh p3 ????", "d dh p4 ????", "p3 cbr 6", "p4 cc", "p1 f", "p2 cc",
+  "d db 2c3c4d", "p2 cc", "p3 cbr 8", "p4 cc", "p2 cbr 24", "p3 f", "p4 f"]
+`
+
+func TestSessionSituations(t *testing.T) {
+	session := Compute([]*handhistory.Hand{parse(t, checkRaisedHand)})
+	if dave := session.Players["Dave"]; dave.ColdCallChances != 1 || dave.ColdCall() != 1 {
+		t.Errorf("Expected Dave to cold call the open, got %+v", dave.Situations)
+	}
+	bob := session.Players["Bob"]
+	if bob.ColdCallChances != 0 || bob.CheckRaise() != 1 || bob.DonkChances != 1 || bob.DonkBets != 0 {
+		t.Errorf("Expected Bob to check-raise rather than lead from the big blind, got %+v", bob.Situations)
+	}
+	if carol := session.Players["Carol"]; carol.Situations != (holdem.Situations{}) {
+		t.Errorf("Expected no spots for the opener, got %+v", carol.Situations)
+	}
+}
@@ -0,0 +1,104 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/handhistory"
+)
+
+// Decision times at or beyond these count as snaps and tanks
+const (
+	SnapThreshold = time.Second
+	TankThreshold = 10 * time.Second
+)
+
+// ActionTiming is the decision time spent on one kind of action
+type ActionTiming struct {
+	Count int
+	Total time.Duration
+}
+
+// Timing summarises how long a player takes to act. Comparing the time
+// spent on calls with the time spent on raises exposes timing tells.
+type Timing struct {
+	Decisions int
+	Total     time.Duration
+	Snaps     int // Decisions quicker than SnapThreshold
+	Tanks     int // Decisions of TankThreshold or longer
+	ByAction  map[handhistory.ActionType]*ActionTiming
+}
+
+// Record adds one timed decision
+func (t *Timing) Record(actionType handhistory.ActionType, elapsed time.Duration) {
+	if t.ByAction == nil {
+		t.ByAction = map[handhistory.ActionType]*ActionTiming{}
+	}
+	t.Decisions++
+	t.Total += elapsed
+	switch {
+	case elapsed < SnapThreshold:
+		t.Snaps++
+	case elapsed >= TankThreshold:
+		t.Tanks++
+	}
+	byAction := t.ByAction[actionType]
+	if byAction == nil {
+		byAction = &ActionTiming{}
+		t.ByAction[actionType] = byAction
+	}
+	byAction.Count++
+	byAction.Total += elapsed
+}
+
+// Average returns the mean decision time, 0 without timed decisions
+func (t *Timing) Average() time.Duration {
+	if t.Decisions == 0 {
+		return 0
+	}
+	return t.Total / time.Duration(t.Decisions)
+}
+
+// AverageFor returns the mean time of decisions of the given types
+func (t *Timing) AverageFor(actionTypes ...handhistory.ActionType) time.Duration {
+	count, total := 0, time.Duration(0)
+	for _, actionType := range actionTypes {
+		if byAction := t.ByAction[actionType]; byAction != nil {
+			count += byAction.Count
+			total += byAction.Total
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / time.Duration(count)
+}
+
+// SnapRate returns the share of decisions made in under SnapThreshold
+func (t *Timing) SnapRate() float64 {
+	return ratio(t.Snaps, t.Decisions)
+}
+
+// TankRate returns the share of decisions that took TankThreshold or longer
+func (t *Timing) TankRate() float64 {
+	return ratio(t.Tanks, t.Decisions)
+}
+
+// HasTiming reports whether any hand in the session carried decision times
+func (s *Session) HasTiming() bool {
+	for _, player := range s.Players {
+		if player.Timing.Decisions > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// addTiming records the player's timed decisions in a hand; forced bets are not decisions
+func addTiming(player *PlayerStats, hand *handhistory.Hand) {
+	for _, action := range hand.Actions {
+		if action.Player != player.Name || action.Elapsed <= 0 || action.Type.IsForced() {
+			continue
+		}
+		player.Timing.Record(action.Type, action.Elapsed)
+	}
+}
@@ -0,0 +1,49 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/handhistory"
+)
+
+func TestTiming(t *testing.T) {
+	var timing Timing
+	if timing.Average() != 0 || timing.SnapRate() != 0 {
+		t.Error("Expected empty timing to report zeros")
+	}
+	timing.Record(handhistory.ActionCall, 500*time.Millisecond)
+	timing.Record(handhistory.ActionCheck, 1500*time.Millisecond)
+	timing.Record(handhistory.ActionRaise, 12*time.Second)
+
+	if timing.Decisions != 3 || timing.Snaps != 1 || timing.Tanks != 1 {
+		t.Errorf("Unexpected counts %+v", timing)
+	}
+	if avg := timing.Average(); avg != 14*time.Second/3 {
+		t.Errorf("Expected an average of 4.67s, got %v", avg)
+	}
+	if avg := timing.AverageFor(handhistory.ActionCheck, handhistory.ActionCall); avg != time.Second {
+		t.Errorf("Expected passive decisions to average 1s, got %v", avg)
+	}
+	if avg := timing.AverageFor(handhistory.ActionBet); avg != 0 {
+		t.Errorf("Expected no bets, got %v", avg)
+	}
+}
+
+func TestSessionTimingFromHands(t *testing.T) {
+	hand := parse(t, sessionHands)
+	if Compute([]*handhistory.Hand{hand}).HasTiming() {
+		t.Error("Expected PHH hands without timing")
+	}
+	for i := range hand.Actions {
+		hand.Actions[i].Elapsed = 2 * time.Second
+	}
+	session := Compute([]*handhistory.Hand{hand})
+	if !session.HasTiming() {
+		t.Fatal("Expected timed decisions")
+	}
+	alice := session.Players["Alice"].Timing
+	if alice.Decisions != 3 || alice.ByAction[handhistory.ActionPostBlind] != nil {
+		t.Errorf("Expected Alice's 3 decisions without the blind, got %+v", alice)
+	}
+}
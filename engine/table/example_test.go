@@ -0,0 +1,47 @@
+package table_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/session"
+	"github.com/ljbink/ai-poker/engine/table"
+)
+
+// Drive a hand with a request/response loop, as a web handler would: ask
+// for the next prompt, answer it, and repeat until the hand is over.
+func ExampleTable() {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 1})
+	game.PlayerSit(holdem.NewPlayer(1, "Hero", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Nit", 1000), 1)
+	bot, err := holdem_ai.CreateBotByName("nit")
+	if err != nil {
+		panic(err)
+	}
+
+	t := table.New(session.New(game))
+	defer t.Close()
+	t.Session().SetDecisionMaker(2, bot)
+	t.Play(1)
+
+	for {
+		prompt, err := t.NextPrompt(context.Background())
+		if err != nil {
+			panic(err)
+		}
+		if prompt.Result != nil {
+			fmt.Println("Hero's result:", prompt.Result.Net[1])
+			return
+		}
+		fmt.Printf("Hero to call %d, may raise to %d-%d\n",
+			prompt.Call, prompt.View.Seats[prompt.Seat].Bet+prompt.MinRaise, prompt.View.Seats[prompt.Seat].Bet+prompt.MaxRaise)
+		if err := t.Submit(holdem.Action{PlayerID: 1, Type: holdem.ActionFold}); err != nil {
+			panic(err)
+		}
+	}
+	// Output:
+	// Hero to call 5, may raise to 20-1000
+	// Hero's result: -5
+}
@@ -0,0 +1,238 @@
+package table
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+var (
+	// ErrUnknownCode is returned for a join code no open table has
+	ErrUnknownCode = errors.New("no table with that code")
+	// ErrHandInProgress is returned by Join while a hand is being played;
+	// players are seated between hands
+	ErrHandInProgress = errors.New("a hand is in progress")
+	// ErrNotSeated is returned by Say for a player not seated at the table
+	ErrNotSeated = errors.New("player is not seated at the table")
+)
+
+// codeAlphabet leaves out letters and digits easily mistaken for each
+// other, 0 and O, 1 and I
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// codeLength is how many characters a join code has, some 10^9 codes
+const codeLength = 6
+
+// maxChatLength is the most runes a chat message keeps
+const maxChatLength = 200
+
+// ChatMessage is a line said at a table, numbered from 1 in the order it
+// was said
+type ChatMessage struct {
+	Seq      int
+	PlayerID int
+	Name     string
+	Text     string
+	At       time.Time
+}
+
+// private is a table in the lobby with its chat and when it was last used
+type private struct {
+	table  *Table
+	chat   []ChatMessage
+	active time.Time
+}
+
+// Lobby keeps private tables under short join codes: the creator shares
+// the code and players give it to be seated. A table nobody has used for
+// the idle time is closed by CloseIdle. Each table has a chat, read with
+// Chat as the table's prompts are, by asking for what is new.
+//
+// The lobby is safe for concurrent use, but calls on one table still must
+// not overlap, Join included.
+type Lobby struct {
+	mu     sync.Mutex
+	idle   time.Duration
+	now    func() time.Time
+	tables map[string]*private
+}
+
+// NewLobby creates a lobby closing tables idle for the given time; 0 keeps
+// them open until Close
+func NewLobby(idle time.Duration) *Lobby {
+	return &Lobby{idle: idle, now: time.Now, tables: map[string]*private{}}
+}
+
+// SetClock sets the clock idle times are measured by, for tests and
+// replays
+func (l *Lobby) SetClock(now func() time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.now = now
+}
+
+// Create adds a table to the lobby and returns its join code, e.g. "K7WQ3M"
+func (l *Lobby) Create(t *Table) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for {
+		code, err := newCode()
+		if err != nil {
+			return "", err
+		}
+		if _, taken := l.tables[code]; !taken {
+			l.tables[code] = &private{table: t, active: l.now()}
+			return code, nil
+		}
+	}
+}
+
+// Table returns the table under a join code. Looking it up counts as using
+// it.
+func (l *Lobby) Table(code string) (*Table, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	p, err := l.use(code)
+	if err != nil {
+		return nil, err
+	}
+	return p.table, nil
+}
+
+// Join seats a player at the table under a join code, between hands. The
+// seat plays by the decision maker set for it afterwards, or by Play.
+func (l *Lobby) Join(code string, player holdem.IPlayer, seat int) (*Table, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	p, err := l.use(code)
+	if err != nil {
+		return nil, err
+	}
+	if p.table.closed {
+		return nil, ErrClosed
+	}
+	if p.table.done != nil || p.table.session.GetGame().IsHandInProgress() {
+		return nil, ErrHandInProgress
+	}
+	if err := p.table.session.GetGame().PlayerSit(player, seat); err != nil {
+		return nil, err
+	}
+	return p.table, nil
+}
+
+// Say adds a seated player's line to the table's chat. Blank lines are
+// dropped and long ones cut.
+func (l *Lobby) Say(code string, playerID int, text string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	p, err := l.use(code)
+	if err != nil {
+		return err
+	}
+	player, err := p.table.session.GetGame().GetPlayerByID(playerID)
+	if err != nil {
+		return fmt.Errorf("%w: %d", ErrNotSeated, playerID)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	if runes := []rune(text); len(runes) > maxChatLength {
+		text = string(runes[:maxChatLength])
+	}
+	p.chat = append(p.chat, ChatMessage{
+		Seq:      len(p.chat) + 1,
+		PlayerID: playerID,
+		Name:     player.GetName(),
+		Text:     text,
+		At:       l.now(),
+	})
+	return nil
+}
+
+// Chat returns the table's chat said after the message numbered since; 0
+// for all of it
+func (l *Lobby) Chat(code string, since int) ([]ChatMessage, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	p, ok := l.tables[code]
+	if !ok {
+		return nil, ErrUnknownCode
+	}
+	since = min(max(since, 0), len(p.chat))
+	return append([]ChatMessage{}, p.chat[since:]...), nil
+}
+
+// CloseIdle closes and drops the tables nobody has used for the lobby's
+// idle time, and returns their codes, sorted
+func (l *Lobby) CloseIdle() ([]string, error) {
+	l.mu.Lock()
+	if l.idle <= 0 {
+		l.mu.Unlock()
+		return nil, nil
+	}
+	now := l.now()
+	idle := map[string]*private{}
+	for code, p := range l.tables {
+		if now.Sub(p.active) >= l.idle {
+			idle[code] = p
+			delete(l.tables, code)
+		}
+	}
+	l.mu.Unlock()
+	return closeAll(idle)
+}
+
+// Close closes every table and empties the lobby
+func (l *Lobby) Close() error {
+	l.mu.Lock()
+	tables := l.tables
+	l.tables = map[string]*private{}
+	l.mu.Unlock()
+	_, err := closeAll(tables)
+	return err
+}
+
+// use returns the table under a code and marks it used now
+func (l *Lobby) use(code string) (*private, error) {
+	p, ok := l.tables[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return nil, ErrUnknownCode
+	}
+	p.active = l.now()
+	return p, nil
+}
+
+// closeAll closes the tables, outside the lobby's lock as a hand being
+// called off takes a while, and returns their codes sorted
+func closeAll(tables map[string]*private) ([]string, error) {
+	codes := make([]string, 0, len(tables))
+	var errs []error
+	for code, p := range tables {
+		codes = append(codes, code)
+		if err := p.table.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("table %s: %w", code, err))
+		}
+	}
+	sort.Strings(codes)
+	return codes, errors.Join(errs...)
+}
+
+// newCode returns a random join code
+func newCode() (string, error) {
+	var random [codeLength]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		return "", fmt.Errorf("join code: %w", err)
+	}
+	code := make([]byte, codeLength)
+	for i, b := range random {
+		code[i] = codeAlphabet[int(b)%len(codeAlphabet)]
+	}
+	return string(code), nil
+}
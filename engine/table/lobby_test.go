@@ -0,0 +1,95 @@
+package table
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+func TestLobbySeatsPlayersByCode(t *testing.T) {
+	lobby := NewLobby(time.Minute)
+	defer lobby.Close()
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	game.PlayerSit(holdem.NewPlayer(1, "Host", 1000), 0)
+	code, err := lobby.Create(New(session.New(game)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) != codeLength {
+		t.Fatalf("Expected a %d character code, got %q", codeLength, code)
+	}
+
+	if _, err := lobby.Join("ZZZZZZ", holdem.NewPlayer(2, "Guest", 1000), 1); !errors.Is(err, ErrUnknownCode) {
+		t.Errorf("Expected an unknown code refused, got %v", err)
+	}
+	// Codes are read the way people type them
+	table, err := lobby.Join(" "+strings.ToLower(code)+" ", holdem.NewPlayer(2, "Guest", 1000), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seat, err := table.Session().GetGame().GetPlayerSitByID(2); err != nil || seat != 1 {
+		t.Errorf("Expected the guest in seat 1, got %d (%v)", seat, err)
+	}
+	if _, err := lobby.Join(code, holdem.NewPlayer(3, "Late", 1000), 1); err == nil {
+		t.Error("Expected a taken seat refused")
+	}
+}
+
+func TestLobbyRelaysChat(t *testing.T) {
+	lobby := NewLobby(0)
+	defer lobby.Close()
+	code, err := lobby.Create(newTable(t, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lobby.Say(code, 9, "hi"); !errors.Is(err, ErrNotSeated) {
+		t.Errorf("Expected chat from outside the table refused, got %v", err)
+	}
+	for _, line := range []string{"gl all", "   ", "nh"} {
+		if err := lobby.Say(code, 2, line); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chat, err := lobby.Chat(code, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chat) != 2 || chat[0].Text != "gl all" || chat[1].Seq != 2 || chat[1].PlayerID != 2 {
+		t.Fatalf("Expected both lines numbered in order, got %+v", chat)
+	}
+	if chat, _ := lobby.Chat(code, 1); len(chat) != 1 || chat[0].Text != "nh" {
+		t.Errorf("Expected only what is new since the first line, got %+v", chat)
+	}
+}
+
+func TestLobbyClosesIdleTables(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	lobby := NewLobby(10 * time.Minute)
+	lobby.SetClock(func() time.Time { return now })
+	defer lobby.Close()
+	quiet, _ := lobby.Create(newTable(t, 2))
+	busy, _ := lobby.Create(newTable(t, 2))
+
+	now = now.Add(8 * time.Minute)
+	if err := lobby.Say(busy, 1, "still here"); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(3 * time.Minute)
+	closed, err := lobby.CloseIdle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(closed) != 1 || closed[0] != quiet {
+		t.Fatalf("Expected only the quiet table closed, got %v", closed)
+	}
+	if _, err := lobby.Table(quiet); !errors.Is(err, ErrUnknownCode) {
+		t.Errorf("Expected the closed table gone, got %v", err)
+	}
+	if _, err := lobby.Table(busy); err != nil {
+		t.Errorf("Expected the busy table open, got %v", err)
+	}
+}
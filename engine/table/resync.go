@@ -0,0 +1,113 @@
+package table
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+// Record is a session event numbered in the order it happened, with the
+// public state hash of the table right after it
+type Record struct {
+	Seq       uint64        `json:"seq"`
+	Event     session.Event `json:"event"`
+	StateHash string        `json:"state_hash"`
+}
+
+// Resync is what a client that reconnects is sent: the table as its player
+// sees it now, and the events it missed since the last one it acknowledged
+type Resync struct {
+	Seq       uint64           `json:"seq"`        // Latest event, the one View is taken after; 0 before any
+	View      holdem.TableView `json:"view"`       // Other players' hole cards hidden unless shown down
+	StateHash string           `json:"state_hash"` // Public state hash after Seq, for checking View
+	Backlog   []Record         `json:"backlog"`    // Events after the acknowledged one up to Seq, in order
+	Missed    bool             `json:"missed"`     // Events right after the acknowledged one are no longer kept, so the backlog starts later
+}
+
+// Verify checks a resync the way a client should before using it: the view
+// hashes to the state hash, and the backlog runs in order up to Seq and ends
+// in the same state
+func (r Resync) Verify() error {
+	if hash := r.View.StateHash(); hash != r.StateHash {
+		return fmt.Errorf("resync view hashes to %s, not %s", hash, r.StateHash)
+	}
+	for i, record := range r.Backlog {
+		if i > 0 && record.Seq != r.Backlog[i-1].Seq+1 {
+			return fmt.Errorf("resync backlog skips from event %d to %d", r.Backlog[i-1].Seq, record.Seq)
+		}
+	}
+	if n := len(r.Backlog); n > 0 {
+		if last := r.Backlog[n-1]; last.Seq != r.Seq || last.StateHash != r.StateHash {
+			return fmt.Errorf("resync backlog ends at event %d, not %d", last.Seq, r.Seq)
+		}
+	}
+	return nil
+}
+
+// Feed numbers a session's events and keeps the latest of them, with the
+// table as each player saw it after the last one, so that a client coming
+// back can be brought up to date with Resync. Register Observe as the
+// session's observer. A feed is safe for concurrent use: the hand feeds it
+// while handlers read it.
+type Feed struct {
+	mu      sync.Mutex
+	limit   int
+	records []Record                 // Latest events, oldest first
+	seq     uint64                   // Number of the latest event
+	views   map[int]holdem.TableView // Table after the latest event by player ID, spectators under 0
+}
+
+// NewFeed creates a feed keeping the given number of latest events; 0 or
+// less keeps them all
+func NewFeed(backlog int) *Feed {
+	return &Feed{limit: backlog, views: map[int]holdem.TableView{}}
+}
+
+// Observe numbers an event and takes the table's views after it. It is a
+// session.Observer.
+func (f *Feed) Observe(event session.Event, game *holdem.Game) {
+	views := map[int]holdem.TableView{0: game.SpectatorView()}
+	for _, player := range game.GetAllPlayers() {
+		views[player.GetID()] = game.PlayerView(player.GetID())
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seq++
+	f.records = append(f.records, Record{Seq: f.seq, Event: event, StateHash: views[0].StateHash()})
+	if f.limit > 0 && len(f.records) > f.limit {
+		f.records = append(f.records[:0], f.records[len(f.records)-f.limit:]...)
+	}
+	f.views = views
+}
+
+// Seq returns the number of the latest event, 0 before any
+func (f *Feed) Seq() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.seq
+}
+
+// Resync returns the table as a player sees it and the events since the
+// one numbered acked, the last the client acknowledged; 0 when it saw
+// none. Players not seated get the spectators' view. An acknowledgement
+// ahead of the feed, from a client of an earlier table, gets the view
+// alone to start over from.
+func (f *Feed) Resync(playerID int, acked uint64) Resync {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	view, ok := f.views[playerID]
+	if !ok {
+		view = f.views[0]
+	}
+	resync := Resync{Seq: f.seq, View: view, StateHash: view.StateHash(), Backlog: []Record{}}
+	if acked >= f.seq {
+		return resync
+	}
+	first := f.seq - uint64(len(f.records)) + 1
+	resync.Missed = acked+1 < first
+	resync.Backlog = append(resync.Backlog, f.records[max(acked+1, first)-first:]...)
+	return resync
+}
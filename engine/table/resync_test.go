@@ -0,0 +1,64 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestFeedResyncsReconnectingPlayers(t *testing.T) {
+	table := newTable(t, 2)
+	feed := NewFeed(4)
+	table.Session().SetObserver(feed.Observe)
+
+	// Player 1 drops while facing the big blind
+	prompt := nextPrompt(t, table)
+	seen := feed.Seq()
+	if seen == 0 {
+		t.Fatal("Expected the deal and the turn numbered")
+	}
+	resync := feed.Resync(1, seen)
+	if err := resync.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if len(resync.Backlog) != 0 || resync.View.Seats[prompt.Seat].CardsHidden || len(resync.View.Seats[prompt.Seat].HoleCards) != 2 {
+		t.Errorf("Expected nothing missed and the player's own cards, got %+v", resync)
+	}
+	for _, seat := range feed.Resync(2, seen).View.Seats {
+		if seat.PlayerID == 1 && (!seat.CardsHidden || len(seat.HoleCards) != 0) {
+			t.Errorf("Expected player 1's cards hidden from player 2, got %+v", seat)
+		}
+	}
+
+	// The hand carries on while they are gone
+	if err := table.Submit(holdem.Action{PlayerID: 1, Type: holdem.ActionCall, Amount: prompt.Call}); err != nil {
+		t.Fatal(err)
+	}
+	for nextPrompt(t, table).Result == nil {
+		if err := table.Submit(holdem.Action{PlayerID: 1, Type: holdem.ActionCheck}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resync = feed.Resync(1, seen)
+	if err := resync.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if !resync.Missed || len(resync.Backlog) != 4 || resync.Seq != feed.Seq() {
+		t.Errorf("Expected the last 4 of %d events with the rest marked missed, got %d (missed %v)", resync.Seq-seen, len(resync.Backlog), resync.Missed)
+	}
+	if last := resync.Backlog[len(resync.Backlog)-1]; last.StateHash != table.Session().GetGame().StateHash() {
+		t.Errorf("Expected the backlog to end in the table's state, got %s", last.StateHash)
+	}
+
+	// A spectator catching up on a short gap gets it all
+	resync = feed.Resync(9, feed.Seq()-2)
+	if err := resync.Verify(); err != nil || resync.Missed || len(resync.Backlog) != 2 {
+		t.Errorf("Expected the last 2 events, got %d (missed %v, %v)", len(resync.Backlog), resync.Missed, err)
+	}
+
+	// A tampered view fails the check
+	resync.View.Pot++
+	if resync.Verify() == nil {
+		t.Error("Expected a view not matching the state hash refused")
+	}
+}
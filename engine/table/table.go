@@ -0,0 +1,206 @@
+// Package table drives a session one input at a time, for frontends that
+// answer requests rather than wait on channels, such as a web or mobile
+// app: NextPrompt says whose input the table needs and what they may do,
+// Submit applies it. Seats played by bots act on their own in between, and
+// the goroutine playing the hand never shows through the API.
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+var (
+	// ErrNoPrompt is returned by Submit when no seat is waiting for input
+	ErrNoPrompt = errors.New("no seat is waiting for an action")
+	// ErrNotEnoughPlayers is returned by NextPrompt between hands when
+	// fewer than two players are seated to deal the next one
+	ErrNotEnoughPlayers = errors.New("not enough players to deal a hand")
+	// ErrClosed is returned once the table is closed
+	ErrClosed = errors.New("table closed")
+)
+
+// Prompt is the input the table waits for: the action of a seat played
+// from outside, or, with no seat to act, the result of the hand just over
+type Prompt struct {
+	PlayerID           int // Player to act, 0 once the hand is over
+	Seat               int
+	holdem.Constraints                  // What the player may do and for how many chips
+	View               holdem.TableView // The table as the player to act sees it, or as spectators do once the hand is over
+
+	Result *session.HandResult // The hand just finished, when no player is to act
+	Busted []int               // Players unseated with the result for having no chips left
+}
+
+// decision is a prompt waiting for its answer
+type decision struct {
+	prompt Prompt
+	answer chan holdem.Action
+}
+
+// handDone is how a hand played in the background ended
+type handDone struct {
+	result *session.HandResult
+	err    error
+}
+
+// Table plays the hands of a session, stopping wherever a seat played from
+// outside is to act. Between a Submit and the NextPrompt that returns the
+// next prompt the hand is being played, so read the table from the
+// prompts' views rather than the game meanwhile. A table is not safe for
+// concurrent use.
+type Table struct {
+	session   *session.Session
+	validator *holdem.ActionValidator
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	decisions chan decision // Asked for by the hand being played
+	done      chan handDone // The hand's end, nil between hands
+	pending   *decision     // Prompt returned and not yet answered
+	closed    bool
+}
+
+// New creates a table around a session whose players are seated. Seats
+// given to Play wait for Submit; every other seat is played by its decision
+// maker. The session's decision clock is switched off, as the frontend
+// keeps its own time.
+func New(s *session.Session) *Table {
+	s.SetDecisionClock(0, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Table{
+		session:   s,
+		validator: holdem.NewActionValidator(),
+		ctx:       ctx,
+		cancel:    cancel,
+		decisions: make(chan decision, 1),
+	}
+}
+
+// Session returns the session the table plays, to seat players and set
+// their decision makers between hands
+func (t *Table) Session() *session.Session {
+	return t.session
+}
+
+// Play makes a player's seat wait for Submit at each of their turns
+func (t *Table) Play(playerID int) {
+	t.session.SetDecisionMaker(playerID, prompter{t})
+}
+
+// NextPrompt returns the input the table needs next, dealing a hand when
+// none is in progress and letting bots act until a seat played from
+// outside is to act or the hand is over. Asked again before Submit, it
+// returns the same prompt. It returns ctx's error if ctx is done first,
+// and the hand carries on in the background.
+func (t *Table) NextPrompt(ctx context.Context) (*Prompt, error) {
+	if t.closed {
+		return nil, ErrClosed
+	}
+	if t.pending != nil {
+		return &t.pending.prompt, nil
+	}
+	if t.done == nil {
+		if len(t.session.GetGame().GetAllPlayers()) < 2 {
+			return nil, ErrNotEnoughPlayers
+		}
+		done := make(chan handDone, 1)
+		t.done = done
+		go func() {
+			result, err := t.session.PlayHand(t.ctx)
+			done <- handDone{result, err}
+		}()
+	}
+
+	select {
+	case decision := <-t.decisions:
+		t.pending = &decision
+		return &decision.prompt, nil
+	case hand := <-t.done:
+		t.done = nil
+		if hand.err != nil {
+			return nil, hand.err
+		}
+		prompt := &Prompt{Seat: -1, Result: hand.result}
+		for _, player := range t.session.RemoveBusted() {
+			prompt.Busted = append(prompt.Busted, player.GetID())
+		}
+		prompt.View = t.session.GetGame().SpectatorView()
+		return prompt, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Submit answers the pending prompt with the action of the player to act.
+// An illegal action is refused with the *holdem.ValidationError saying
+// why, and the prompt stays pending. A raise's amount is the bet it raises
+// to.
+func (t *Table) Submit(action holdem.Action) error {
+	if t.closed {
+		return ErrClosed
+	}
+	if t.pending == nil || t.pending.prompt.PlayerID == 0 {
+		return ErrNoPrompt
+	}
+	game := t.session.GetGame()
+	player, err := game.GetPlayerByID(t.pending.prompt.PlayerID)
+	if err != nil {
+		return err
+	}
+	if action.PlayerID != player.GetID() {
+		return fmt.Errorf("player %d is to act, not player %d", player.GetID(), action.PlayerID)
+	}
+	if invalid := t.validator.ValidateAction(game, player, action); invalid != nil {
+		return invalid
+	}
+	t.pending.answer <- action
+	t.pending = nil
+	return nil
+}
+
+// Close stops the table. A hand in progress is called off and the chips
+// in it go back to the players.
+func (t *Table) Close() error {
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	t.cancel()
+	if t.done != nil {
+		<-t.done
+		t.done = nil
+	}
+	t.pending = nil
+	if game := t.session.GetGame(); game.IsHandInProgress() {
+		if _, err := game.AbortHand(holdem.AbortRefund); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prompter is the decision maker of a seat played from outside: it hands
+// the decision to NextPrompt and waits for Submit
+type prompter struct {
+	table *Table
+}
+
+func (p prompter) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	answer := make(chan holdem.Action, 1)
+	seat, _ := game.GetPlayerSitByID(player.GetID())
+	p.table.decisions <- decision{
+		prompt: Prompt{
+			PlayerID:    player.GetID(),
+			Seat:        seat,
+			Constraints: holdem.ValidatorConstraints(game, player),
+			View:        game.PlayerView(player.GetID()),
+		},
+		answer: answer,
+	}
+	return answer
+}
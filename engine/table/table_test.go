@@ -0,0 +1,179 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+// caller checks or calls whatever it faces
+type caller struct{}
+
+func (caller) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	call := game.GetCurrentBet() - player.GetBet()
+	switch {
+	case call <= 0:
+		ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck}
+	case call >= player.GetChips():
+		ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionAllIn, Amount: player.GetChips()}
+	default:
+		ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCall, Amount: call}
+	}
+	close(ch)
+	return ch
+}
+
+// stuck never decides
+type stuck struct{}
+
+func (stuck) MakeDecision(*holdem.Game, holdem.IPlayer) <-chan holdem.Action {
+	return make(chan holdem.Action)
+}
+
+// newTable seats players 1..n with 1000 chips each, player 1 played from
+// outside and the rest by callers
+func newTable(t *testing.T, players int) *Table {
+	t.Helper()
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	for id := 1; id <= players; id++ {
+		if err := game.PlayerSit(holdem.NewPlayer(id, "", 1000), id-1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	table := New(session.New(game))
+	for id := 2; id <= players; id++ {
+		table.Session().SetDecisionMaker(id, caller{})
+	}
+	table.Play(1)
+	t.Cleanup(func() { table.Close() })
+	return table
+}
+
+func nextPrompt(t *testing.T, table *Table) *Prompt {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	prompt, err := table.NextPrompt(ctx)
+	if err != nil {
+		t.Fatalf("NextPrompt failed: %v", err)
+	}
+	return prompt
+}
+
+func TestTablePromptsUntilTheHandIsOver(t *testing.T) {
+	table := newTable(t, 3)
+	if err := table.Submit(holdem.Action{PlayerID: 1, Type: holdem.ActionCheck}); !errors.Is(err, ErrNoPrompt) {
+		t.Errorf("Expected nothing to submit before the first prompt, got %v", err)
+	}
+
+	hands, prompts := 0, 0
+	for hands < 3 {
+		prompt := nextPrompt(t, table)
+		if prompt.Result != nil {
+			hands++
+			if prompt.PlayerID != 0 || prompt.View.HandID != prompt.Result.HandID {
+				t.Errorf("Expected the result alone with the finished hand's view, got %+v", prompt)
+			}
+			continue
+		}
+		prompts++
+		if prompt.PlayerID != 1 || len(prompt.Actions) == 0 || prompt.View.Seats[prompt.Seat].PlayerID != 1 {
+			t.Fatalf("Expected player 1 prompted with their actions, got %+v", prompt)
+		}
+		if again := nextPrompt(t, table); again != prompt {
+			t.Errorf("Expected the same prompt until it is answered")
+		}
+		action := holdem.Action{PlayerID: 1, Type: holdem.ActionCheck}
+		if !prompt.FreeCheck {
+			action = holdem.Action{PlayerID: 1, Type: holdem.ActionCall, Amount: prompt.Call}
+		}
+		if err := table.Submit(action); err != nil {
+			t.Fatalf("Submit failed: %v", err)
+		}
+	}
+	if prompts < 3 {
+		t.Errorf("Expected player 1 prompted in every hand, got %d prompts", prompts)
+	}
+
+	total := 0
+	for _, player := range table.Session().GetGame().GetAllPlayers() {
+		total += player.GetChips()
+	}
+	if total != 3000 {
+		t.Errorf("Expected the chips conserved, got %d", total)
+	}
+}
+
+func TestTableRefusesIllegalActions(t *testing.T) {
+	table := newTable(t, 2)
+	prompt := nextPrompt(t, table)
+	if prompt.PlayerID != 1 || prompt.FreeCheck {
+		t.Fatalf("Expected player 1 to face the big blind, got %+v", prompt)
+	}
+
+	var invalid *holdem.ValidationError
+	if err := table.Submit(holdem.Action{PlayerID: 1, Type: holdem.ActionCheck}); !errors.As(err, &invalid) {
+		t.Errorf("Expected checking a bet refused with a validation error, got %v", err)
+	}
+	if err := table.Submit(holdem.Action{PlayerID: 2, Type: holdem.ActionFold}); err == nil {
+		t.Error("Expected an action for another player refused")
+	}
+	if again := nextPrompt(t, table); again != prompt {
+		t.Error("Expected the prompt still pending after a refused action")
+	}
+	if err := table.Submit(holdem.Action{PlayerID: 1, Type: holdem.ActionFold}); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if result := nextPrompt(t, table).Result; result == nil || result.Net[1] != -5 {
+		t.Errorf("Expected player 1 to lose the small blind, got %+v", result)
+	}
+}
+
+func TestTableCloseRefundsTheHand(t *testing.T) {
+	table := newTable(t, 2)
+	nextPrompt(t, table)
+	if err := table.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	game := table.Session().GetGame()
+	for _, player := range game.GetAllPlayers() {
+		if player.GetChips() != 1000 {
+			t.Errorf("Expected the blinds refunded, player %d has %d", player.GetID(), player.GetChips())
+		}
+	}
+	if game.IsHandInProgress() {
+		t.Error("Expected no hand in progress after Close")
+	}
+	if _, err := table.NextPrompt(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected a closed table to refuse, got %v", err)
+	}
+}
+
+func TestTableNeedsTwoPlayers(t *testing.T) {
+	table := newTable(t, 1)
+	if _, err := table.NextPrompt(context.Background()); !errors.Is(err, ErrNotEnoughPlayers) {
+		t.Errorf("Expected a lone player refused a hand, got %v", err)
+	}
+}
+
+func TestNextPromptGivesUpWithItsContext(t *testing.T) {
+	table := newTable(t, 2)
+	table.Session().SetDecisionMaker(2, stuck{})
+	prompt := nextPrompt(t, table)
+	if prompt.Result != nil {
+		t.Fatal("Expected a prompt before the bot acts")
+	}
+	if err := table.Submit(holdem.Action{PlayerID: 1, Type: holdem.ActionCall, Amount: prompt.Call}); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := table.NextPrompt(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected waiting on a stuck bot to give up with the context, got %v", err)
+	}
+}
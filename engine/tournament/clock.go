@@ -0,0 +1,310 @@
+package tournament
+
+import (
+	"fmt"
+	"time"
+)
+
+// Breaks schedules the breaks from play. The level clock stops for a
+// break, so a level interrupted by one carries on where it stopped.
+type Breaks struct {
+	Every        time.Duration `json:"every,omitempty"`  // Play between breaks, no breaks when zero
+	Length       time.Duration `json:"length,omitempty"` // How long a break lasts
+	Synchronized bool          `json:"synchronized,omitempty"`
+	// Synchronized breaks keep to the wall clock instead of the time played:
+	// every break ends on a multiple of Every, e.g. 5 minutes at :55 of every
+	// hour, however long the tournament was paused. A hand still running
+	// when the break starts shortens it.
+}
+
+// Validate checks that breaks, if any, fit between the play they interrupt
+func (b Breaks) Validate() error {
+	if b.Every < 0 || b.Length < 0 {
+		return fmt.Errorf("negative break schedule")
+	}
+	if b.Every > 0 && b.Length == 0 {
+		return fmt.Errorf("breaks every %s need a length", b.Every)
+	}
+	if b.Synchronized && b.Length >= b.Every {
+		return fmt.Errorf("synchronized breaks of %s do not fit every %s", b.Length, b.Every)
+	}
+	return nil
+}
+
+// ClockEventType says what happened to the tournament clock
+type ClockEventType int
+
+const (
+	ClockPaused  ClockEventType = iota // Pause stopped the clock
+	ClockResumed                       // Resume restarted it
+	BreakStarted                       // Play stopped for a break
+	BreakEnded                         // Play restarts after a break
+	LevelStarted                       // New blinds apply from the next hand
+)
+
+// ClockEvent is a change of the tournament clock. Like moves, clock events
+// are announced by whoever drives the tables, see ClockEvents.
+type ClockEvent struct {
+	Type   ClockEventType
+	Level  int           // Zero-based level when it happened
+	Blinds Level         // Of that level
+	Left   time.Duration // Time left in the level, or in the break once one started
+}
+
+// ClockSnapshot is the tournament clock in relative time, so a tournament
+// restored later carries on with the same level and time remaining
+type ClockSnapshot struct {
+	Level        int           `json:"level"`
+	LevelHands   int           `json:"level_hands"`   // Hands played in the level
+	LevelElapsed time.Duration `json:"level_elapsed"` // Clock time played in the level
+	SinceBreak   time.Duration `json:"since_break"`   // Play since the last break, or the start
+	BreakElapsed time.Duration `json:"break_elapsed,omitempty"`
+	BreakLeft    time.Duration `json:"break_left,omitempty"` // Of a synchronized break
+	OnBreak      bool          `json:"on_break,omitempty"`
+	Paused       bool          `json:"paused,omitempty"`
+}
+
+// stopwatch adds up the time it runs, with its start and stop times given
+type stopwatch struct {
+	elapsed time.Duration // Up to since, or in total when stopped
+	since   time.Time
+	running bool
+}
+
+func (s *stopwatch) start(now time.Time) {
+	if !s.running {
+		s.since, s.running = now, true
+	}
+}
+
+func (s *stopwatch) stop(now time.Time) {
+	if s.running {
+		s.elapsed, s.running = s.read(now), false
+	}
+}
+
+// read returns the time run up to now
+func (s *stopwatch) read(now time.Time) time.Duration {
+	if !s.running {
+		return s.elapsed
+	}
+	return s.elapsed + now.Sub(s.since)
+}
+
+// set makes the time run up to now d, running or not
+func (s *stopwatch) set(now time.Time, d time.Duration) {
+	s.elapsed, s.since = d, now
+}
+
+// TimeToNextLevel returns how long the current level still runs on the clock
+func (t *Tournament) TimeToNextLevel() time.Duration {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	return t.levelLeft(t.now())
+}
+
+// levelLeft returns the clock time left in the level, zero without one
+func (t *Tournament) levelLeft(now time.Time) time.Duration {
+	level := t.CurrentLevel()
+	if t.config.Progression != ProgressByClock || level.Duration == 0 {
+		return 0
+	}
+	return max(level.Duration-t.levelClock.read(now), 0)
+}
+
+// Pause stops the blind clock, and the break clock during a break.
+// Pause and Resume can be called from any goroutine, e.g. a pause menu's
+// while another deals the hands.
+func (t *Tournament) Pause() {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	if t.paused {
+		return
+	}
+	now := t.now()
+	t.paused = true
+	t.levelClock.stop(now)
+	t.playClock.stop(now)
+	t.breakClock.stop(now)
+	t.event(ClockPaused, now)
+}
+
+// Resume restarts the blind clock, extending the current level by the pause
+func (t *Tournament) Resume() {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	if !t.paused {
+		return
+	}
+	t.paused = false
+	t.run(t.now())
+	t.event(ClockResumed, t.now())
+}
+
+// IsPaused reports whether the blind clock is stopped
+func (t *Tournament) IsPaused() bool {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	return t.paused
+}
+
+// OnBreak reports whether play is stopped for a break. Tick ends it once
+// its time is up.
+func (t *Tournament) OnBreak() bool {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	return t.onBreak
+}
+
+// BreakLeft returns the time left in the break, zero when not on one
+func (t *Tournament) BreakLeft() time.Duration {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	if !t.onBreak {
+		return 0
+	}
+	return t.breakLeft(t.now())
+}
+
+// TimeToBreak returns how long until the next break is due, zero without
+// breaks or during one
+func (t *Tournament) TimeToBreak() time.Duration {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	breaks := t.config.Breaks
+	if breaks.Every == 0 || t.onBreak {
+		return 0
+	}
+	now := t.now()
+	if breaks.Synchronized {
+		start := now.Truncate(breaks.Every).Add(breaks.Every - breaks.Length)
+		return max(start.Sub(now), 0)
+	}
+	return max(breaks.Every-t.playClock.read(now), 0)
+}
+
+// Tick advances the level when its clock has run out and reports whether it changed.
+// New blinds are applied to every table and take effect from the next hand.
+// Between hands, it also starts a break that is due and ends one whose
+// time is up.
+func (t *Tournament) Tick() bool {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	if !t.started || t.paused {
+		return false
+	}
+	now := t.now()
+	if t.onBreak {
+		if t.breakLeft(now) > 0 {
+			return false
+		}
+		t.onBreak = false
+		t.playClock.set(now, 0)
+		t.run(now)
+		t.event(BreakEnded, now)
+	}
+
+	changed := false
+	if t.config.Progression == ProgressByClock {
+		for {
+			level := t.CurrentLevel()
+			played := t.levelClock.read(now)
+			if level.Duration == 0 || t.level >= len(t.config.Structure.Levels)-1 || played < level.Duration {
+				break
+			}
+			t.level++
+			t.levelClock.set(now, played-level.Duration)
+			changed = true
+		}
+	}
+	if changed {
+		t.applyLevel()
+		t.event(LevelStarted, now)
+	}
+	t.startBreak(now)
+	return changed
+}
+
+// startBreak stops play for a break that is due
+func (t *Tournament) startBreak(now time.Time) {
+	breaks := t.config.Breaks
+	if breaks.Every == 0 {
+		return
+	}
+	if breaks.Synchronized {
+		end := now.Truncate(breaks.Every).Add(breaks.Every)
+		if end.Sub(now) > breaks.Length {
+			return
+		}
+		t.breakEnds = end
+	} else if t.playClock.read(now) < breaks.Every {
+		return
+	}
+	t.onBreak = true
+	t.levelClock.stop(now)
+	t.playClock.stop(now)
+	t.breakClock = stopwatch{}
+	t.breakClock.start(now)
+	t.event(BreakStarted, now)
+}
+
+// breakLeft returns the time left in the current break
+func (t *Tournament) breakLeft(now time.Time) time.Duration {
+	if t.config.Breaks.Synchronized {
+		return max(t.breakEnds.Sub(now), 0)
+	}
+	return max(t.config.Breaks.Length-t.breakClock.read(now), 0)
+}
+
+// run starts the clocks that run while the tournament is not paused
+func (t *Tournament) run(now time.Time) {
+	if !t.started {
+		return
+	}
+	if t.onBreak {
+		t.breakClock.start(now)
+		return
+	}
+	t.levelClock.start(now)
+	t.playClock.start(now)
+}
+
+// event queues a clock event for ClockEvents
+func (t *Tournament) event(kind ClockEventType, now time.Time) {
+	left := t.levelLeft(now)
+	if t.onBreak {
+		left = t.breakLeft(now)
+	}
+	t.events = append(t.events, ClockEvent{Type: kind, Level: t.level, Blinds: t.CurrentLevel(), Left: left})
+}
+
+// ClockEvents returns the clock events since the last call, oldest first
+func (t *Tournament) ClockEvents() []ClockEvent {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	events := t.events
+	t.events = nil
+	return events
+}
+
+// Clock records the tournament clock, see ClockSnapshot
+func (t *Tournament) Clock() ClockSnapshot {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	now := t.now()
+	snapshot := ClockSnapshot{
+		Level:        t.level,
+		LevelHands:   t.levelHands,
+		LevelElapsed: t.levelClock.read(now),
+		SinceBreak:   t.playClock.read(now),
+		OnBreak:      t.onBreak,
+		Paused:       t.paused,
+	}
+	if t.onBreak {
+		snapshot.BreakElapsed = t.breakClock.read(now)
+		if t.config.Breaks.Synchronized {
+			snapshot.BreakLeft = t.breakLeft(now)
+		}
+	}
+	return snapshot
+}
@@ -0,0 +1,150 @@
+package tournament
+
+import (
+	"testing"
+	"time"
+)
+
+// newClockTournament starts a heads-up tournament on a fake clock with
+// 10-minute levels
+func newClockTournament(t *testing.T, now *time.Time, breaks Breaks) *Tournament {
+	t.Helper()
+	tournament, err := New(Config{Progression: ProgressByClock, StartingStack: 1000, Seed: 1, Breaks: breaks, Structure: BlindStructure{Levels: []Level{
+		{SmallBlind: 10, BigBlind: 20, Duration: 10 * time.Minute},
+		{SmallBlind: 20, BigBlind: 40, Duration: 10 * time.Minute},
+		{SmallBlind: 50, BigBlind: 100, Duration: 10 * time.Minute},
+	}}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	tournament.SetClock(func() time.Time { return *now })
+	tournament.Register(1, "a")
+	tournament.Register(2, "b")
+	if err := tournament.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	return tournament
+}
+
+func eventTypes(events []ClockEvent) []ClockEventType {
+	types := []ClockEventType{}
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestBreaksValidate(t *testing.T) {
+	for _, breaks := range []Breaks{
+		{Every: -time.Minute},
+		{Every: time.Hour},
+		{Every: 10 * time.Minute, Length: 10 * time.Minute, Synchronized: true},
+	} {
+		if err := breaks.Validate(); err == nil {
+			t.Errorf("Expected %+v to be rejected", breaks)
+		}
+	}
+	if err := (Breaks{Every: time.Hour, Length: 5 * time.Minute, Synchronized: true}).Validate(); err != nil {
+		t.Errorf("Expected hourly breaks to be valid: %v", err)
+	}
+}
+
+func TestClockEventsForPauseAndLevels(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tournament := newClockTournament(t, &now, Breaks{})
+
+	now = now.Add(4 * time.Minute)
+	tournament.Pause()
+	tournament.Pause()
+	now = now.Add(time.Hour)
+	tournament.Resume()
+	now = now.Add(6 * time.Minute)
+	tournament.Tick()
+
+	events := tournament.ClockEvents()
+	if got := eventTypes(events); len(got) != 3 || got[0] != ClockPaused || got[1] != ClockResumed || got[2] != LevelStarted {
+		t.Fatalf("Expected paused, resumed and level started, got %v", got)
+	}
+	if events[0].Left != 6*time.Minute {
+		t.Errorf("Expected 6m left when paused, got %v", events[0].Left)
+	}
+	if events[2].Level != 1 || events[2].Left != 10*time.Minute {
+		t.Errorf("Expected level 1 with 10m left, got %+v", events[2])
+	}
+	if len(tournament.ClockEvents()) != 0 {
+		t.Error("Expected the events to be taken once")
+	}
+}
+
+func TestBreakStopsLevelClock(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tournament := newClockTournament(t, &now, Breaks{Every: 15 * time.Minute, Length: 5 * time.Minute})
+
+	now = now.Add(15 * time.Minute)
+	tournament.Tick()
+	if !tournament.OnBreak() {
+		t.Fatal("Expected a break after 15 minutes of play")
+	}
+	if got := tournament.BreakLeft(); got != 5*time.Minute {
+		t.Errorf("Expected 5m of break, got %v", got)
+	}
+
+	// A pause stops the break clock too
+	now = now.Add(2 * time.Minute)
+	tournament.Pause()
+	now = now.Add(time.Hour)
+	tournament.Resume()
+	now = now.Add(2 * time.Minute)
+	if tournament.Tick(); !tournament.OnBreak() {
+		t.Fatal("Expected the break to go on after 4 minutes")
+	}
+	now = now.Add(time.Minute)
+	tournament.Tick()
+	if tournament.OnBreak() {
+		t.Fatal("Expected the break to end after 5 minutes")
+	}
+	if tournament.LevelIndex() != 1 || tournament.TimeToNextLevel() != 5*time.Minute {
+		t.Errorf("Expected level 1 with 5m left, got %d with %v", tournament.LevelIndex(), tournament.TimeToNextLevel())
+	}
+	if got := tournament.TimeToBreak(); got != 15*time.Minute {
+		t.Errorf("Expected the next break in 15m, got %v", got)
+	}
+
+	got := eventTypes(tournament.ClockEvents())
+	want := []ClockEventType{LevelStarted, BreakStarted, ClockPaused, ClockResumed, BreakEnded}
+	if len(got) != len(want) {
+		t.Fatalf("Expected events %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected events %v, got %v", want, got)
+		}
+	}
+}
+
+func TestSynchronizedBreaks(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 40, 0, 0, time.UTC)
+	tournament := newClockTournament(t, &now, Breaks{Every: time.Hour, Length: 5 * time.Minute, Synchronized: true})
+	if got := tournament.TimeToBreak(); got != 15*time.Minute {
+		t.Errorf("Expected the break at 12:55, got it in %v", got)
+	}
+
+	// A hand running into the break shortens it
+	now = time.Date(2024, 1, 1, 12, 57, 0, 0, time.UTC)
+	tournament.Tick()
+	if !tournament.OnBreak() || tournament.BreakLeft() != 3*time.Minute {
+		t.Fatalf("Expected a break until 13:00, got %v left", tournament.BreakLeft())
+	}
+
+	// Pauses do not move synchronized breaks
+	tournament.Pause()
+	now = now.Add(10 * time.Minute)
+	tournament.Resume()
+	tournament.Tick()
+	if tournament.OnBreak() {
+		t.Fatal("Expected the break to end at 13:00")
+	}
+	if got := tournament.TimeToBreak(); got != 48*time.Minute {
+		t.Errorf("Expected the next break at 13:55, got it in %v", got)
+	}
+}
@@ -0,0 +1,136 @@
+package tournament
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// SnapshotVersion is the current tournament snapshot format version
+const SnapshotVersion = 1
+
+// Snapshot is a tournament between hands: its tables, who is still in or
+// finished where, and the clock, enough to carry on playing it later
+type Snapshot struct {
+	Version     int                `json:"version"`
+	Config      Config             `json:"config"`
+	Players     []PlayerSnapshot   `json:"players"` // In registration order
+	Tables      []*holdem.Snapshot `json:"tables"`  // Nil for broken tables
+	HandsPlayed int                `json:"hands_played"`
+	Clock       ClockSnapshot      `json:"clock"`
+}
+
+// PlayerSnapshot is a registered player, their chips kept by their table
+type PlayerSnapshot struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Table int    `json:"table"` // -1 once eliminated
+	Place int    `json:"place,omitempty"`
+}
+
+// Snapshot records the tournament once every table is between hands
+func (t *Tournament) Snapshot() (*Snapshot, error) {
+	if !t.started {
+		return nil, fmt.Errorf("tournament not started")
+	}
+	snapshot := &Snapshot{
+		Version:     SnapshotVersion,
+		Config:      t.config,
+		HandsPlayed: t.handsPlayed,
+		Clock:       t.Clock(),
+	}
+	for _, e := range t.entries {
+		snapshot.Players = append(snapshot.Players, PlayerSnapshot{
+			ID:    e.player.GetID(),
+			Name:  e.player.GetName(),
+			Table: e.table,
+			Place: e.place,
+		})
+	}
+	for i, game := range t.tables {
+		if t.closed[i] {
+			snapshot.Tables = append(snapshot.Tables, nil)
+			continue
+		}
+		table, err := game.Snapshot()
+		if err != nil {
+			return nil, fmt.Errorf("table %d: %w", i, err)
+		}
+		snapshot.Tables = append(snapshot.Tables, table)
+	}
+	return snapshot, nil
+}
+
+// RestoreTournament carries on a tournament from a snapshot. Its clock
+// starts again from the level and time remaining when the snapshot was
+// taken, stopped if it was paused then. Hands are dealt from the table
+// seeds and seats drawn from a new seed.
+func RestoreTournament(snapshot *Snapshot) (*Tournament, error) {
+	if snapshot == nil {
+		return nil, fmt.Errorf("snapshot is nil")
+	}
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", snapshot.Version)
+	}
+	t, err := New(snapshot.Config)
+	if err != nil {
+		return nil, err
+	}
+	t.rng = rand.New(rand.NewSource(t.config.Seed + int64(snapshot.HandsPlayed)))
+
+	seated := map[int]holdem.IPlayer{}
+	for i, table := range snapshot.Tables {
+		if table == nil {
+			t.tables = append(t.tables, holdem.NewGameWithConfig(holdem.GameConfig{}))
+			t.closed = append(t.closed, true)
+			continue
+		}
+		game, err := holdem.RestoreGame(table)
+		if err != nil {
+			return nil, fmt.Errorf("table %d: %w", i, err)
+		}
+		for _, p := range game.GetAllPlayers() {
+			seated[p.GetID()] = p
+		}
+		t.tables = append(t.tables, game)
+		t.closed = append(t.closed, false)
+	}
+	for _, p := range snapshot.Players {
+		player := seated[p.ID]
+		switch {
+		case p.Table < 0:
+			player = holdem.NewPlayer(p.ID, p.Name, 0)
+		case p.Table >= len(t.tables) || player == nil:
+			return nil, fmt.Errorf("player %d is not seated at table %d", p.ID, p.Table)
+		}
+		e := &entry{player: player, table: p.Table, place: p.Place, lastChips: player.GetChips()}
+		t.entries = append(t.entries, e)
+		t.byID[p.ID] = e
+		if p.Table >= 0 {
+			t.remaining++
+		}
+	}
+	t.handsPlayed = snapshot.HandsPlayed
+	t.started = true
+	t.restoreClock(snapshot.Clock)
+	return t, nil
+}
+
+// restoreClock carries on the clock of a snapshot from now
+func (t *Tournament) restoreClock(clock ClockSnapshot) {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	now := t.now()
+	t.level, t.levelHands = clock.Level, clock.LevelHands
+	t.levelClock.set(now, clock.LevelElapsed)
+	t.playClock.set(now, clock.SinceBreak)
+	t.onBreak, t.paused = clock.OnBreak, clock.Paused
+	if t.onBreak {
+		t.breakClock.set(now, clock.BreakElapsed)
+		t.breakEnds = now.Add(clock.BreakLeft)
+	}
+	if !t.paused {
+		t.run(now)
+	}
+}
@@ -0,0 +1,80 @@
+package tournament
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSnapshotKeepsClock(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tournament := newClockTournament(t, &now, Breaks{Every: 30 * time.Minute, Length: 5 * time.Minute})
+	now = now.Add(14 * time.Minute)
+	tournament.Tick()
+	tournament.Pause()
+
+	snapshot, err := tournament.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	loaded := &Snapshot{}
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	// Restored a day later, the tournament carries on paused where it stopped
+	later := now.Add(24 * time.Hour)
+	restored, err := RestoreTournament(loaded)
+	if err != nil {
+		t.Fatalf("RestoreTournament failed: %v", err)
+	}
+	restored.SetClock(func() time.Time { return later })
+	if !restored.IsPaused() || restored.LevelIndex() != 1 {
+		t.Fatalf("Expected level 1 paused, got level %d paused %v", restored.LevelIndex(), restored.IsPaused())
+	}
+	if got := restored.TimeToNextLevel(); got != 6*time.Minute {
+		t.Errorf("Expected 6m left in the level, got %v", got)
+	}
+	if got := restored.Tables()[0].GetBigBlind(); got != 40 {
+		t.Errorf("Expected the level 1 big blind of 40, got %d", got)
+	}
+	restored.Resume()
+	later = later.Add(16 * time.Minute)
+	restored.Tick()
+	if !restored.OnBreak() || restored.LevelIndex() != 2 {
+		t.Errorf("Expected the break on level 2 after 30m of play, got level %d break %v", restored.LevelIndex(), restored.OnBreak())
+	}
+
+	if restored.Remaining() != 2 || len(restored.Tables()[0].GetAllPlayers()) != 2 {
+		t.Errorf("Expected both players back at the table")
+	}
+}
+
+func TestSnapshotKeepsResults(t *testing.T) {
+	tournament := newTestTournament(t, 3, Config{SeatsPerTable: 3, Payouts: []float64{0.7, 0.3}, BuyIn: 10})
+	bust(t, tournament, 3, 1)
+	if _, _, err := tournament.HandCompleted(0); err != nil {
+		t.Fatalf("HandCompleted failed: %v", err)
+	}
+	snapshot, err := tournament.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	restored, err := RestoreTournament(snapshot)
+	if err != nil {
+		t.Fatalf("RestoreTournament failed: %v", err)
+	}
+	want, got := tournament.Standings(), restored.Standings()
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Standing %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+	if restored.HandsPlayed() != 1 || restored.Remaining() != 2 {
+		t.Errorf("Expected 1 hand played and 2 left, got %d and %d", restored.HandsPlayed(), restored.Remaining())
+	}
+}
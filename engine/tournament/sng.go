@@ -0,0 +1,62 @@
+package tournament
+
+import (
+	"fmt"
+	"time"
+)
+
+// Sit-and-go defaults
+const (
+	SNGStartingStack = 1500
+	SNGLevelDuration = 5 * time.Minute // Level length when played on the clock
+	SNGLevelHands    = 10              // Level length when played by hand count
+)
+
+// SNGPayouts is the standard top-three split
+var SNGPayouts = []float64{0.5, 0.3, 0.2}
+
+// SNGStructure returns the escalating blind structure used by sit-and-gos
+func SNGStructure() BlindStructure {
+	blinds := []struct{ small, big, ante int }{
+		{10, 20, 0},
+		{15, 30, 0},
+		{25, 50, 0},
+		{50, 100, 0},
+		{75, 150, 0},
+		{100, 200, 25},
+		{150, 300, 25},
+		{200, 400, 50},
+		{300, 600, 75},
+		{400, 800, 100},
+		{600, 1200, 150},
+		{1000, 2000, 250},
+	}
+	structure := BlindStructure{Name: "Sit & Go"}
+	for _, b := range blinds {
+		structure.Levels = append(structure.Levels, Level{
+			SmallBlind: b.small,
+			BigBlind:   b.big,
+			Ante:       b.ante,
+			Duration:   SNGLevelDuration,
+			Hands:      SNGLevelHands,
+		})
+	}
+	return structure
+}
+
+// SitAndGo returns the configuration of a single-table sit-and-go for 6 or 9
+// players. Headless simulations progress by hand count, live play by clock.
+func SitAndGo(seats int, progression Progression, buyIn int) (Config, error) {
+	if seats != 6 && seats != 9 {
+		return Config{}, fmt.Errorf("sit-and-go tables are 6-max or 9-max, got %d", seats)
+	}
+	return Config{
+		Name:          fmt.Sprintf("%d-max Sit & Go", seats),
+		Structure:     SNGStructure(),
+		Progression:   progression,
+		StartingStack: SNGStartingStack,
+		SeatsPerTable: seats,
+		BuyIn:         buyIn,
+		Payouts:       SNGPayouts,
+	}, nil
+}
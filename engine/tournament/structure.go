@@ -0,0 +1,93 @@
+package tournament
+
+import (
+	"fmt"
+	"time"
+)
+
+// Level is one step of a blind structure
+type Level struct {
+	SmallBlind int           `json:"small_blind"`
+	BigBlind   int           `json:"big_blind"`
+	Ante       int           `json:"ante,omitempty"`
+	Duration   time.Duration `json:"duration,omitempty"` // Length of the level when progressing by clock
+	Hands      int           `json:"hands,omitempty"`    // Length of the level when progressing by hand count
+}
+
+// BlindStructure is an ordered list of levels. The last level repeats forever.
+type BlindStructure struct {
+	Name   string  `json:"name"`
+	Levels []Level `json:"levels"`
+
+	// The big blind posts the levels' antes for the whole table, see
+	// holdem.GameConfig.BigBlindAnte
+	BigBlindAnte bool `json:"big_blind_ante,omitempty"`
+}
+
+// Validate checks that blinds are positive and never go down
+func (s BlindStructure) Validate() error {
+	if len(s.Levels) == 0 {
+		return fmt.Errorf("blind structure %q has no levels", s.Name)
+	}
+	for i, level := range s.Levels {
+		if level.SmallBlind <= 0 || level.BigBlind < level.SmallBlind {
+			return fmt.Errorf("level %d: invalid blinds %d/%d", i+1, level.SmallBlind, level.BigBlind)
+		}
+		if level.Ante < 0 || level.Duration < 0 || level.Hands < 0 {
+			return fmt.Errorf("level %d: negative ante or length", i+1)
+		}
+		if i > 0 && level.BigBlind < s.Levels[i-1].BigBlind {
+			return fmt.Errorf("level %d: big blind goes down from %d to %d", i+1, s.Levels[i-1].BigBlind, level.BigBlind)
+		}
+	}
+	return nil
+}
+
+// Level returns the level at index i, repeating the last level past the end
+func (s BlindStructure) Level(i int) Level {
+	if i >= len(s.Levels) {
+		i = len(s.Levels) - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	return s.Levels[i]
+}
+
+// Progression selects what moves a tournament to its next level
+type Progression int
+
+const (
+	ProgressByClock Progression = iota // Levels last Level.Duration of wall-clock time
+	ProgressByHands                    // Levels last Level.Hands hands, counted across all tables
+)
+
+// CalculatePayouts splits a prize pool by the given fractions. Rounding
+// leftovers go to the top finishers so the whole pool is always paid out.
+func CalculatePayouts(prizePool int, fractions []float64) ([]int, error) {
+	total := 0.0
+	for i, fraction := range fractions {
+		if fraction <= 0 {
+			return nil, fmt.Errorf("payout %d must be positive", i+1)
+		}
+		if i > 0 && fraction > fractions[i-1] {
+			return nil, fmt.Errorf("payout %d is larger than payout %d", i+1, i)
+		}
+		total += fraction
+	}
+	if len(fractions) > 0 && (total < 0.999 || total > 1.001) {
+		return nil, fmt.Errorf("payouts add up to %.3f, expected 1", total)
+	}
+
+	payouts := make([]int, len(fractions))
+	paid := 0
+	for i, fraction := range fractions {
+		payouts[i] = int(float64(prizePool) * fraction / total)
+		paid += payouts[i]
+	}
+	for i := 0; paid < prizePool && len(payouts) > 0; i = (i + 1) % len(payouts) {
+		payouts[i]++
+		paid++
+	}
+	return payouts, nil
+}
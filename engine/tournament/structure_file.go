@@ -0,0 +1,227 @@
+package tournament
+
+import (
+	"embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StandardStructure names the sit-and-go structure, SNGStructure
+const StandardStructure = "standard"
+
+//go:embed data/*
+var presetFiles embed.FS
+
+// handsPerMinute turns a level's duration into hands for files that leave
+// the hands out, at the pace of the sit-and-go levels
+const handsPerMinute = SNGLevelHands / int(SNGLevelDuration/time.Minute)
+
+// structureFile is a blind structure as written in JSON files, with
+// durations such as "5m"
+type structureFile struct {
+	Name         string `json:"name"`
+	BigBlindAnte bool   `json:"big_blind_ante"`
+	Levels       []struct {
+		Level      int    `json:"level"`
+		SmallBlind int    `json:"small_blind"`
+		BigBlind   int    `json:"big_blind"`
+		Ante       int    `json:"ante"`
+		Duration   string `json:"duration"`
+		Hands      int    `json:"hands"`
+	} `json:"levels"`
+}
+
+// LoadStructureCSV reads a blind structure from CSV, one level per row:
+//
+//	level,small_blind,big_blind,ante,duration,hands
+//	1,10,20,0,5m,10
+//
+// Levels are numbered from 1 in order. The duration is a Go duration such
+// as "5m" or a number of minutes; hands, how long the level lasts when
+// played by hand count, may be left out and then follows from the duration.
+// The header is optional and lines starting with # are comments.
+func LoadStructureCSV(r io.Reader, name string) (BlindStructure, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	structure := BlindStructure{Name: name}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return BlindStructure{}, err
+		}
+		line, _ := reader.FieldPos(0)
+		if strings.EqualFold(record[0], "level") {
+			continue // Header
+		}
+		if len(record) != 5 && len(record) != 6 {
+			return BlindStructure{}, fmt.Errorf("line %d: want level, small blind, big blind, ante, duration and hands, got %d fields", line, len(record))
+		}
+		numbers := [6]int{} // Duration's place left 0
+		for i, field := range record {
+			if i == 4 {
+				continue
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				return BlindStructure{}, fmt.Errorf("line %d: %q is not a number", line, field)
+			}
+			numbers[i] = n
+		}
+		duration, err := parseLevelDuration(record[4])
+		if err != nil {
+			return BlindStructure{}, fmt.Errorf("line %d: %w", line, err)
+		}
+		level, err := fileLevel(len(structure.Levels)+1, numbers[0], numbers[1], numbers[2], numbers[3], duration, numbers[5])
+		if err != nil {
+			return BlindStructure{}, fmt.Errorf("line %d: %w", line, err)
+		}
+		structure.Levels = append(structure.Levels, level)
+	}
+	return structure, structure.Validate()
+}
+
+// LoadStructureJSON reads a blind structure from JSON:
+//
+//	{"name": "turbo", "levels": [{"level": 1, "small_blind": 10, "big_blind": 20, "ante": 0, "duration": "5m", "hands": 10}]}
+//
+// The fields mean what the columns of LoadStructureCSV do. With
+// "big_blind_ante": true the big blind posts the ante for the whole table.
+func LoadStructureJSON(r io.Reader) (BlindStructure, error) {
+	var file structureFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return BlindStructure{}, fmt.Errorf("failed to decode blind structure: %w", err)
+	}
+	structure := BlindStructure{Name: file.Name, BigBlindAnte: file.BigBlindAnte}
+	for i, entry := range file.Levels {
+		duration, err := parseLevelDuration(entry.Duration)
+		if err != nil {
+			return BlindStructure{}, fmt.Errorf("level %d: %w", i+1, err)
+		}
+		level, err := fileLevel(i+1, entry.Level, entry.SmallBlind, entry.BigBlind, entry.Ante, duration, entry.Hands)
+		if err != nil {
+			return BlindStructure{}, fmt.Errorf("level %d: %w", i+1, err)
+		}
+		structure.Levels = append(structure.Levels, level)
+	}
+	return structure, structure.Validate()
+}
+
+// LoadStructureFile reads a blind structure from a .csv or .json file. A
+// structure without a name is named after the file.
+func LoadStructureFile(filename string) (BlindStructure, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return BlindStructure{}, err
+	}
+	defer file.Close()
+	structure, err := loadStructure(file, filepath.Base(filename))
+	if err != nil {
+		return BlindStructure{}, fmt.Errorf("%s: %w", filename, err)
+	}
+	return structure, nil
+}
+
+// StructurePresets returns the names of the bundled blind structures,
+// standard first and the rest sorted
+func StructurePresets() []string {
+	entries, _ := presetFiles.ReadDir("data")
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	sort.Strings(names)
+	return append([]string{StandardStructure}, names...)
+}
+
+// Structure returns a bundled blind structure by name, e.g. "turbo", or
+// reads one from the path of a .csv or .json file. "" is the standard
+// sit-and-go structure.
+func Structure(name string) (BlindStructure, error) {
+	if name == "" || name == StandardStructure {
+		return SNGStructure(), nil
+	}
+	if isStructureFile(name) {
+		return LoadStructureFile(name)
+	}
+	for _, ext := range []string{".csv", ".json"} {
+		if file, err := presetFiles.Open("data/" + name + ext); err == nil {
+			defer file.Close()
+			return loadStructure(file, name+ext)
+		}
+	}
+	return BlindStructure{}, fmt.Errorf("unknown blind structure %q, want one of %s or a .csv or .json file",
+		name, strings.Join(StructurePresets(), ", "))
+}
+
+// loadStructure reads a structure in the format its file name's extension
+// says
+func loadStructure(r io.Reader, filename string) (BlindStructure, error) {
+	ext := path.Ext(filename)
+	name := strings.TrimSuffix(filename, ext)
+	switch strings.ToLower(ext) {
+	case ".csv":
+		return LoadStructureCSV(r, name)
+	case ".json":
+		structure, err := LoadStructureJSON(r)
+		if structure.Name == "" {
+			structure.Name = name
+		}
+		return structure, err
+	default:
+		return BlindStructure{}, fmt.Errorf("blind structures are .csv or .json files, not %q", ext)
+	}
+}
+
+// isStructureFile reports whether a name is the path of a structure file
+// rather than a preset
+func isStructureFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".csv" || ext == ".json"
+}
+
+// fileLevel checks a level read from a file and fills in its hands
+func fileLevel(want, number, small, big, ante int, duration time.Duration, hands int) (Level, error) {
+	if number != want {
+		return Level{}, fmt.Errorf("level %d is numbered %d", want, number)
+	}
+	if duration <= 0 {
+		return Level{}, fmt.Errorf("level %d needs a duration", want)
+	}
+	if hands < 0 {
+		return Level{}, fmt.Errorf("level %d lasts %d hands", want, hands)
+	}
+	if hands == 0 {
+		hands = max(int(duration/time.Minute)*handsPerMinute, 1)
+	}
+	return Level{SmallBlind: small, BigBlind: big, Ante: ante, Duration: duration, Hands: hands}, nil
+}
+
+// parseLevelDuration reads a level's duration, a Go duration such as "5m"
+// or a number of minutes
+func parseLevelDuration(field string) (time.Duration, error) {
+	field = strings.TrimSpace(field)
+	if minutes, err := strconv.Atoi(field); err == nil {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+	duration, err := time.ParseDuration(field)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a duration", field)
+	}
+	return duration, nil
+}
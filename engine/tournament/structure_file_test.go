@@ -0,0 +1,90 @@
+package tournament
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadStructureCSV(t *testing.T) {
+	structure, err := LoadStructureCSV(strings.NewReader(`# A short one
+level,small_blind,big_blind,ante,duration,hands
+1,10,20,0,5m,8
+2,20,40,5,4
+`), "short")
+	if err != nil {
+		t.Fatalf("LoadStructureCSV failed: %v", err)
+	}
+	want := []Level{
+		{SmallBlind: 10, BigBlind: 20, Duration: 5 * time.Minute, Hands: 8},
+		{SmallBlind: 20, BigBlind: 40, Ante: 5, Duration: 4 * time.Minute, Hands: 8}, // Hands follow from the minutes
+	}
+	if structure.Name != "short" || len(structure.Levels) != len(want) {
+		t.Fatalf("Expected 2 levels named short, got %+v", structure)
+	}
+	for i := range want {
+		if structure.Levels[i] != want[i] {
+			t.Errorf("Level %d: expected %+v, got %+v", i+1, want[i], structure.Levels[i])
+		}
+	}
+
+	for name, input := range map[string]string{
+		"skipped level":  "1,10,20,0,5m\n3,20,40,0,5m\n",
+		"no duration":    "1,10,20,0,0\n",
+		"bad duration":   "1,10,20,0,soon\n",
+		"blinds go down": "1,20,40,0,5m\n2,10,20,0,5m\n",
+		"missing ante":   "1,10,20,5m\n",
+		"empty":          "level,small_blind,big_blind,ante,duration\n",
+	} {
+		if _, err := LoadStructureCSV(strings.NewReader(input), name); err == nil {
+			t.Errorf("Expected an error for a structure with %s", name)
+		}
+	}
+}
+
+func TestLoadStructureFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weekly.json")
+	if err := os.WriteFile(path, []byte(`{"levels": [{"level": 1, "small_blind": 25, "big_blind": 50, "duration": "15m"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	structure, err := Structure(path)
+	if err != nil {
+		t.Fatalf("Structure failed: %v", err)
+	}
+	if structure.Name != "weekly" || structure.Level(0) != (Level{SmallBlind: 25, BigBlind: 50, Duration: 15 * time.Minute, Hands: 30}) {
+		t.Errorf("Expected the weekly structure, got %+v", structure)
+	}
+	if _, err := LoadStructureFile(filepath.Join(t.TempDir(), "blinds.txt")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestStructurePresets(t *testing.T) {
+	names := StructurePresets()
+	if strings.Join(names, ",") != "standard,deep,hyper,modern,turbo" {
+		t.Errorf("Unexpected presets %v", names)
+	}
+	for _, name := range names {
+		structure, err := Structure(name)
+		if err != nil {
+			t.Errorf("Preset %s: %v", name, err)
+			continue
+		}
+		for i, level := range structure.Levels {
+			if level.Duration <= 0 || level.Hands <= 0 {
+				t.Errorf("Preset %s level %d has no length: %+v", name, i+1, level)
+			}
+		}
+	}
+	if turbo, _ := Structure("turbo"); turbo.Level(0).Hands >= SNGLevelHands {
+		t.Errorf("Expected turbo levels shorter than the standard ones, got %+v", turbo.Level(0))
+	}
+	if modern, _ := Structure("modern"); !modern.BigBlindAnte || modern.Level(2).Ante != modern.Level(2).BigBlind {
+		t.Errorf("Expected the modern structure to have a big blind ante, got %+v", modern)
+	}
+	if _, err := Structure("glacial"); err == nil || !strings.Contains(err.Error(), "turbo") {
+		t.Errorf("Expected an unknown preset to list the presets, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package tournament
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlindStructureValidate(t *testing.T) {
+	valid := BlindStructure{Name: "turbo", Levels: []Level{
+		{SmallBlind: 10, BigBlind: 20, Duration: 5 * time.Minute},
+		{SmallBlind: 20, BigBlind: 40, Ante: 5, Duration: 5 * time.Minute},
+	}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Expected valid structure, got %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		structure BlindStructure
+	}{
+		{"no levels", BlindStructure{Name: "empty"}},
+		{"zero small blind", BlindStructure{Levels: []Level{{SmallBlind: 0, BigBlind: 20}}}},
+		{"big below small", BlindStructure{Levels: []Level{{SmallBlind: 20, BigBlind: 10}}}},
+		{"negative ante", BlindStructure{Levels: []Level{{SmallBlind: 10, BigBlind: 20, Ante: -1}}}},
+		{"blinds go down", BlindStructure{Levels: []Level{{SmallBlind: 20, BigBlind: 40}, {SmallBlind: 10, BigBlind: 20}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.structure.Validate(); err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+func TestBlindStructureLevelRepeatsLast(t *testing.T) {
+	structure := BlindStructure{Levels: []Level{{SmallBlind: 10, BigBlind: 20}, {SmallBlind: 25, BigBlind: 50}}}
+	if got := structure.Level(0).BigBlind; got != 20 {
+		t.Errorf("Expected level 0 big blind 20, got %d", got)
+	}
+	if got := structure.Level(7).BigBlind; got != 50 {
+		t.Errorf("Expected last level to repeat, got big blind %d", got)
+	}
+}
+
+func TestCalculatePayouts(t *testing.T) {
+	payouts, err := CalculatePayouts(1000, []float64{0.5, 0.3, 0.2})
+	if err != nil {
+		t.Fatalf("CalculatePayouts failed: %v", err)
+	}
+	want := []int{500, 300, 200}
+	for i := range want {
+		if payouts[i] != want[i] {
+			t.Errorf("Place %d: expected %d, got %d", i+1, want[i], payouts[i])
+		}
+	}
+
+	// Rounding leftovers go to the top places
+	payouts, err = CalculatePayouts(100, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3})
+	if err != nil {
+		t.Fatalf("CalculatePayouts failed: %v", err)
+	}
+	if payouts[0] != 34 || payouts[1] != 33 || payouts[2] != 33 {
+		t.Errorf("Expected 34/33/33, got %v", payouts)
+	}
+
+	if _, err := CalculatePayouts(100, []float64{0.3, 0.7}); err == nil {
+		t.Error("Expected error for increasing payouts")
+	}
+	if _, err := CalculatePayouts(100, []float64{0.5, 0.3}); err == nil {
+		t.Error("Expected error for payouts not adding up to 1")
+	}
+	if _, err := CalculatePayouts(100, []float64{1, 0}); err == nil {
+		t.Error("Expected error for zero payout")
+	}
+}
+
+func TestSitAndGoPreset(t *testing.T) {
+	for _, seats := range []int{6, 9} {
+		config, err := SitAndGo(seats, ProgressByHands, 50)
+		if err != nil {
+			t.Fatalf("SitAndGo(%d) failed: %v", seats, err)
+		}
+		if _, err := New(config); err != nil {
+			t.Errorf("Expected a valid %d-max preset, got %v", seats, err)
+		}
+		if config.SeatsPerTable != seats || config.StartingStack != SNGStartingStack || len(config.Payouts) != 3 {
+			t.Errorf("Unexpected preset %+v", config)
+		}
+	}
+	if _, err := SitAndGo(8, ProgressByClock, 0); err == nil {
+		t.Error("Expected error for an 8-max sit-and-go")
+	}
+}
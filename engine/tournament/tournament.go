@@ -0,0 +1,520 @@
+package tournament
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// MaxSeats is the most players a single table can hold
+const MaxSeats = 10
+
+// Config describes a tournament
+type Config struct {
+	Name          string
+	Structure     BlindStructure
+	Progression   Progression
+	StartingStack int
+	SeatsPerTable int       // Players per table, MaxSeats when zero
+	BuyIn         int       // Prize pool contribution per entrant
+	Payouts       []float64 // Share of the prize pool by finishing place, e.g. 0.5, 0.3, 0.2
+	Seed          int64     // Seeds the seat draw and every table's deck, time-based when zero
+	Breaks        Breaks    // Breaks from play, none by default
+}
+
+// Standing is a player's final or current result
+type Standing struct {
+	PlayerID int
+	Name     string
+	Place    int // 0 while still playing
+	Prize    int
+	Chips    int
+}
+
+// MoveReason says why a player changed seats
+type MoveReason int
+
+const (
+	MoveBalance     MoveReason = iota // Moved from the biggest table to the smallest
+	MoveTableBroken                   // Their table was broken up
+	MoveRedraw                        // Seats were drawn again for the final table
+)
+
+// Move records a player moved between tables for balancing, or to a new
+// seat at the final table. Moves are the tournament's events: whoever
+// drives the tables announces them, e.g. "You are moving to Table 2".
+type Move struct {
+	PlayerID  int
+	FromTable int
+	FromSeat  int
+	ToTable   int // The same as FromTable for a redraw
+	ToSeat    int
+	Reason    MoveReason
+}
+
+// entry tracks one registered player
+type entry struct {
+	player    holdem.IPlayer
+	table     int // Index into tables, -1 once eliminated
+	place     int
+	lastChips int // Chips when the current hand started
+}
+
+// Tournament runs the blind clock, eliminations, table balancing and payouts
+// for a set of games. It does not deal or bet: whoever drives the games
+// reports each finished hand with HandCompleted.
+type Tournament struct {
+	config  Config
+	entries []*entry
+	byID    map[int]*entry
+	tables  []*holdem.Game
+	closed  []bool // Tables broken during balancing
+
+	level       int
+	levelHands  int
+	handsPlayed int
+	remaining   int
+	started     bool
+
+	clock      sync.Mutex // Guards the clock fields, see Pause
+	paused     bool
+	onBreak    bool
+	levelClock stopwatch // Clock time played in the level
+	playClock  stopwatch // Play since the last break
+	breakClock stopwatch // Time in the current break
+	breakEnds  time.Time // Of a synchronized break
+	events     []ClockEvent
+
+	now func() time.Time
+	rng *rand.Rand
+}
+
+// New validates the configuration and creates a tournament open for registration
+func New(config Config) (*Tournament, error) {
+	if err := config.Structure.Validate(); err != nil {
+		return nil, err
+	}
+	if err := config.Breaks.Validate(); err != nil {
+		return nil, err
+	}
+	if config.StartingStack <= 0 {
+		return nil, fmt.Errorf("starting stack must be positive")
+	}
+	if config.SeatsPerTable == 0 {
+		config.SeatsPerTable = MaxSeats
+	}
+	if config.SeatsPerTable < 2 || config.SeatsPerTable > MaxSeats {
+		return nil, fmt.Errorf("seats per table must be between 2 and %d", MaxSeats)
+	}
+	if _, err := CalculatePayouts(0, config.Payouts); err != nil {
+		return nil, err
+	}
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+	}
+	return &Tournament{
+		config: config,
+		byID:   map[int]*entry{},
+		now:    time.Now,
+		rng:    rand.New(rand.NewSource(config.Seed)),
+	}, nil
+}
+
+// SetClock replaces the time source, for tests and simulations
+func (t *Tournament) SetClock(now func() time.Time) {
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	// Running clocks carry on from the new time source
+	for _, clock := range []*stopwatch{&t.levelClock, &t.playClock, &t.breakClock} {
+		clock.set(now(), clock.read(t.now()))
+	}
+	t.now = now
+}
+
+// Register adds a player before the tournament starts
+func (t *Tournament) Register(id int, name string) error {
+	if t.started {
+		return fmt.Errorf("tournament already started")
+	}
+	if _, ok := t.byID[id]; ok {
+		return fmt.Errorf("player %d already registered", id)
+	}
+	e := &entry{player: holdem.NewPlayer(id, name, t.config.StartingStack), table: -1, lastChips: t.config.StartingStack}
+	t.entries = append(t.entries, e)
+	t.byID[id] = e
+	return nil
+}
+
+// Start draws seats, creates the tables and starts the first level
+func (t *Tournament) Start() error {
+	if t.started {
+		return fmt.Errorf("tournament already started")
+	}
+	if len(t.entries) < 2 {
+		return fmt.Errorf("need at least 2 players, got %d", len(t.entries))
+	}
+	if len(t.config.Payouts) > len(t.entries) {
+		return fmt.Errorf("%d places paid but only %d players", len(t.config.Payouts), len(t.entries))
+	}
+
+	tableCount := (len(t.entries) + t.config.SeatsPerTable - 1) / t.config.SeatsPerTable
+	level := t.config.Structure.Level(0)
+	for i := 0; i < tableCount; i++ {
+		t.tables = append(t.tables, holdem.NewGameWithConfig(holdem.GameConfig{
+			SmallBlind: level.SmallBlind,
+			BigBlind:   level.BigBlind,
+			Ante:       level.Ante,
+			Seed:       t.config.Seed + int64(i) + 1,
+
+			BigBlindAnte: t.config.Structure.BigBlindAnte,
+		}))
+		t.closed = append(t.closed, false)
+	}
+
+	// Random seat draw, dealing players round-robin so tables start balanced
+	order := t.rng.Perm(len(t.entries))
+	seats := make([][]int, tableCount)
+	for i := range seats {
+		seats[i] = t.rng.Perm(t.config.SeatsPerTable)
+	}
+	for n, idx := range order {
+		e := t.entries[idx]
+		table := n % tableCount
+		if err := t.tables[table].PlayerSit(e.player, seats[table][n/tableCount]); err != nil {
+			return err
+		}
+		e.table = table
+	}
+
+	t.started = true
+	t.remaining = len(t.entries)
+	t.clock.Lock()
+	defer t.clock.Unlock()
+	if !t.paused {
+		t.run(t.now())
+	}
+	return nil
+}
+
+// Tables returns every table, including broken ones which no longer have players
+func (t *Tournament) Tables() []*holdem.Game {
+	return t.tables
+}
+
+// ActiveTables returns the indexes of tables that still have players
+func (t *Tournament) ActiveTables() []int {
+	active := []int{}
+	for i := range t.tables {
+		if !t.closed[i] {
+			active = append(active, i)
+		}
+	}
+	return active
+}
+
+// TableOf returns the table index a player sits at, or -1 once eliminated
+func (t *Tournament) TableOf(playerID int) int {
+	if e, ok := t.byID[playerID]; ok {
+		return e.table
+	}
+	return -1
+}
+
+// LevelIndex returns the zero-based current level
+func (t *Tournament) LevelIndex() int {
+	return t.level
+}
+
+// CurrentLevel returns the blinds currently in play
+func (t *Tournament) CurrentLevel() Level {
+	return t.config.Structure.Level(t.level)
+}
+
+// HandsPlayed returns the number of hands completed across all tables
+func (t *Tournament) HandsPlayed() int {
+	return t.handsPlayed
+}
+
+// Remaining returns the number of players still in the tournament
+func (t *Tournament) Remaining() int {
+	return t.remaining
+}
+
+// HandCompleted must be called between hands once a table finishes one.
+// It eliminates busted players, advances hand-count levels, balances tables
+// and returns the players eliminated by this hand, best finisher first.
+func (t *Tournament) HandCompleted(table int) ([]Standing, []Move, error) {
+	if !t.started {
+		return nil, nil, fmt.Errorf("tournament not started")
+	}
+	if table < 0 || table >= len(t.tables) || t.closed[table] {
+		return nil, nil, fmt.Errorf("invalid table %d", table)
+	}
+
+	t.handsPlayed++
+	t.levelHands++
+
+	// Players busting in the same hand are placed by their stack at its start
+	busted := []*entry{}
+	for _, e := range t.entries {
+		if e.table == table && e.player.GetChips() <= 0 {
+			busted = append(busted, e)
+		}
+	}
+	sort.SliceStable(busted, func(i, j int) bool {
+		return busted[i].lastChips > busted[j].lastChips
+	})
+	eliminated := []Standing{}
+	for i := len(busted) - 1; i >= 0; i-- {
+		e := busted[i]
+		t.tables[table].PlayerLeave(e.player)
+		e.table = -1
+		e.place = t.remaining
+		t.remaining--
+	}
+	for _, e := range busted {
+		eliminated = append(eliminated, t.standing(e))
+	}
+	for _, e := range t.entries {
+		if e.table == table {
+			e.lastChips = e.player.GetChips()
+		}
+	}
+
+	if t.remaining == 1 {
+		for _, e := range t.entries {
+			if e.table >= 0 {
+				e.place = 1
+			}
+		}
+	}
+
+	if t.config.Progression == ProgressByHands {
+		level := t.CurrentLevel()
+		if level.Hands > 0 && t.levelHands >= level.Hands && t.level < len(t.config.Structure.Levels)-1 {
+			t.clock.Lock()
+			t.level++
+			t.levelHands = 0
+			t.applyLevel()
+			t.event(LevelStarted, t.now())
+			t.clock.Unlock()
+		}
+	}
+	t.Tick()
+
+	moves, err := t.Balance()
+	return eliminated, moves, err
+}
+
+// Balance breaks tables when the field fits on fewer of them and otherwise
+// moves players from the biggest to the smallest table until no table has
+// two or more players than another. When the last break leaves a single
+// table, seats are drawn again for the final table.
+//
+// Players only move between hands: while a table involved is dealing a
+// hand, its moves wait for a later call, such as the HandCompleted of that
+// table.
+func (t *Tournament) Balance() ([]Move, error) {
+	moves := []Move{}
+	if t.remaining <= 1 {
+		return moves, nil
+	}
+
+	needed := (t.remaining + t.config.SeatsPerTable - 1) / t.config.SeatsPerTable
+	broke := false
+	for len(t.ActiveTables()) > needed && t.allIdle() {
+		// Break the smallest table, highest index first on ties
+		active := t.ActiveTables()
+		smallest := active[len(active)-1]
+		for _, i := range active {
+			if t.tableSize(i) < t.tableSize(smallest) {
+				smallest = i
+			}
+		}
+		t.closed[smallest] = true
+		broke = true
+		for _, e := range t.entries {
+			if e.table != smallest {
+				continue
+			}
+			move, err := t.move(e, t.smallestTable(), MoveTableBroken)
+			if err != nil {
+				return moves, err
+			}
+			moves = append(moves, move)
+		}
+	}
+	if active := t.ActiveTables(); broke && len(active) == 1 {
+		redraw, err := t.redraw(active[0])
+		return append(moves, redraw...), err
+	}
+
+	for {
+		largest, smallest := t.largestTable(), t.smallestTable()
+		if t.tableSize(largest)-t.tableSize(smallest) < 2 || !t.idle(largest) || !t.idle(smallest) {
+			break
+		}
+		var mover *entry
+		for _, e := range t.entries {
+			if e.table == largest {
+				mover = e
+			}
+		}
+		move, err := t.move(mover, smallest, MoveBalance)
+		if err != nil {
+			return moves, err
+		}
+		moves = append(moves, move)
+	}
+	return moves, nil
+}
+
+// redraw seats the players of the final table again in random seats
+func (t *Tournament) redraw(final int) ([]Move, error) {
+	game := t.tables[final]
+	players := []*entry{}
+	for _, e := range t.entries {
+		if e.table == final {
+			players = append(players, e)
+		}
+	}
+	moves := make([]Move, 0, len(players))
+	for _, e := range players {
+		seat, _ := game.GetPlayerSitByID(e.player.GetID())
+		moves = append(moves, Move{PlayerID: e.player.GetID(), FromTable: final, FromSeat: seat, ToTable: final, Reason: MoveRedraw})
+		game.PlayerLeave(e.player)
+	}
+	seats := t.rng.Perm(t.config.SeatsPerTable)
+	for i, e := range players {
+		if err := game.PlayerSit(e.player, seats[i]); err != nil {
+			return moves[:i], err
+		}
+		moves[i].ToSeat = seats[i]
+	}
+	return moves, nil
+}
+
+// idle reports whether a table is between hands, so players can move
+func (t *Tournament) idle(table int) bool {
+	return !t.tables[table].IsHandInProgress()
+}
+
+// allIdle reports whether every table still playing is between hands
+func (t *Tournament) allIdle() bool {
+	for _, i := range t.ActiveTables() {
+		if !t.idle(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFinished reports whether a winner has been decided
+func (t *Tournament) IsFinished() bool {
+	return t.started && t.remaining <= 1
+}
+
+// Standings returns every player ordered by finishing place, players still
+// in ordered by chips. Prizes are only assigned to finished places.
+func (t *Tournament) Standings() []Standing {
+	standings := make([]Standing, 0, len(t.entries))
+	for _, e := range t.entries {
+		standings = append(standings, t.standing(e))
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		switch {
+		case a.Place == 0 && b.Place == 0:
+			return a.Chips > b.Chips
+		case a.Place == 0 || b.Place == 0:
+			return a.Place == 0
+		default:
+			return a.Place < b.Place
+		}
+	})
+	return standings
+}
+
+// PrizePool returns the total prize money
+func (t *Tournament) PrizePool() int {
+	return t.config.BuyIn * len(t.entries)
+}
+
+func (t *Tournament) standing(e *entry) Standing {
+	standing := Standing{
+		PlayerID: e.player.GetID(),
+		Name:     e.player.GetName(),
+		Place:    e.place,
+		Chips:    e.player.GetChips(),
+	}
+	if e.place > 0 && e.place <= len(t.config.Payouts) {
+		payouts, _ := CalculatePayouts(t.PrizePool(), t.config.Payouts)
+		standing.Prize = payouts[e.place-1]
+	}
+	return standing
+}
+
+func (t *Tournament) applyLevel() {
+	level := t.CurrentLevel()
+	for _, table := range t.tables {
+		table.SetBlinds(level.SmallBlind, level.BigBlind, level.Ante)
+	}
+}
+
+func (t *Tournament) move(e *entry, to int, reason MoveReason) (Move, error) {
+	from := t.tables[e.table]
+	target := t.tables[to]
+	seat := -1
+	free := t.rng.Perm(t.config.SeatsPerTable)
+	for _, s := range free {
+		if p, _ := target.GetPlayerBySit(s); p == nil {
+			seat = s
+			break
+		}
+	}
+	if seat < 0 {
+		return Move{}, fmt.Errorf("table %d has no free seat", to)
+	}
+	fromSeat, _ := from.GetPlayerSitByID(e.player.GetID())
+	move := Move{PlayerID: e.player.GetID(), FromTable: e.table, FromSeat: fromSeat, ToTable: to, ToSeat: seat, Reason: reason}
+	from.PlayerLeave(e.player)
+	if err := target.PlayerSit(e.player, seat); err != nil {
+		return Move{}, err
+	}
+	e.table = to
+	return move, nil
+}
+
+func (t *Tournament) tableSize(i int) int {
+	size := 0
+	for _, e := range t.entries {
+		if e.table == i {
+			size++
+		}
+	}
+	return size
+}
+
+func (t *Tournament) smallestTable() int {
+	best := -1
+	for _, i := range t.ActiveTables() {
+		if best < 0 || t.tableSize(i) < t.tableSize(best) {
+			best = i
+		}
+	}
+	return best
+}
+
+func (t *Tournament) largestTable() int {
+	best := -1
+	for _, i := range t.ActiveTables() {
+		if best < 0 || t.tableSize(i) > t.tableSize(best) {
+			best = i
+		}
+	}
+	return best
+}
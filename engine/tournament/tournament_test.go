@@ -0,0 +1,315 @@
+package tournament
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func newTestTournament(t *testing.T, players int, config Config) *Tournament {
+	t.Helper()
+	if config.Structure.Levels == nil {
+		config.Structure = BlindStructure{Name: "test", Levels: []Level{
+			{SmallBlind: 10, BigBlind: 20, Duration: 10 * time.Minute, Hands: 2},
+			{SmallBlind: 20, BigBlind: 40, Ante: 5, Duration: 10 * time.Minute, Hands: 2},
+			{SmallBlind: 50, BigBlind: 100, Ante: 10},
+		}}
+	}
+	if config.StartingStack == 0 {
+		config.StartingStack = 1000
+	}
+	config.Seed = 42
+	tournament, err := New(config)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	for i := 1; i <= players; i++ {
+		if err := tournament.Register(i, "player"); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	if err := tournament.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	return tournament
+}
+
+// bust moves all of a player's chips to another player at the same table
+func bust(t *testing.T, tournament *Tournament, loser, winner int) {
+	t.Helper()
+	var from, to holdem.IPlayer
+	for _, table := range tournament.Tables() {
+		for _, p := range table.GetAllPlayers() {
+			switch p.GetID() {
+			case loser:
+				from = p
+			case winner:
+				to = p
+			}
+		}
+	}
+	if from == nil || to == nil {
+		t.Fatalf("players %d and %d must both be seated", loser, winner)
+	}
+	to.GrandChips(from.GetChips())
+	from.GrandChips(-from.GetChips())
+}
+
+func tableSizes(tournament *Tournament) []int {
+	sizes := []int{}
+	for _, i := range tournament.ActiveTables() {
+		sizes = append(sizes, tournament.tableSize(i))
+	}
+	return sizes
+}
+
+func TestStartSeatsPlayersAcrossTables(t *testing.T) {
+	tournament := newTestTournament(t, 14, Config{SeatsPerTable: 6})
+	sizes := tableSizes(tournament)
+	if len(sizes) != 3 {
+		t.Fatalf("Expected 3 tables, got %d", len(sizes))
+	}
+	for _, size := range sizes {
+		if size < 4 || size > 5 {
+			t.Errorf("Expected balanced tables, got sizes %v", sizes)
+		}
+	}
+	for _, table := range tournament.Tables() {
+		if table.GetBigBlind() != 20 {
+			t.Errorf("Expected big blind 20, got %d", table.GetBigBlind())
+		}
+	}
+	if err := tournament.Register(99, "late"); err == nil {
+		t.Error("Expected registration to fail after start")
+	}
+}
+
+func TestNewRejectsBadConfig(t *testing.T) {
+	structure := BlindStructure{Levels: []Level{{SmallBlind: 10, BigBlind: 20}}}
+	if _, err := New(Config{Structure: structure}); err == nil {
+		t.Error("Expected error for missing starting stack")
+	}
+	if _, err := New(Config{Structure: structure, StartingStack: 100, SeatsPerTable: 11}); err == nil {
+		t.Error("Expected error for too many seats")
+	}
+	if _, err := New(Config{Structure: structure, StartingStack: 100, Payouts: []float64{0.4, 0.4}}); err == nil {
+		t.Error("Expected error for payouts not adding up")
+	}
+}
+
+func TestHandProgression(t *testing.T) {
+	tournament := newTestTournament(t, 4, Config{Progression: ProgressByHands})
+	for i := 0; i < 2; i++ {
+		if _, _, err := tournament.HandCompleted(0); err != nil {
+			t.Fatalf("HandCompleted failed: %v", err)
+		}
+	}
+	if tournament.LevelIndex() != 1 {
+		t.Fatalf("Expected level 1 after 2 hands, got %d", tournament.LevelIndex())
+	}
+	game := tournament.Tables()[0]
+	if game.GetSmallBlind() != 20 || game.GetBigBlind() != 40 || game.GetAnte() != 5 {
+		t.Errorf("Expected 20/40 ante 5, got %d/%d ante %d", game.GetSmallBlind(), game.GetBigBlind(), game.GetAnte())
+	}
+
+	// The last level lasts forever
+	for i := 0; i < 10; i++ {
+		tournament.HandCompleted(0)
+	}
+	if tournament.LevelIndex() != 2 {
+		t.Errorf("Expected to stay on the last level, got %d", tournament.LevelIndex())
+	}
+}
+
+func TestClockProgression(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	config := Config{Progression: ProgressByClock, StartingStack: 1000, Seed: 1, Structure: BlindStructure{Levels: []Level{
+		{SmallBlind: 10, BigBlind: 20, Duration: 10 * time.Minute},
+		{SmallBlind: 20, BigBlind: 40, Duration: 10 * time.Minute},
+		{SmallBlind: 50, BigBlind: 100, Duration: 10 * time.Minute},
+	}}}
+	tournament, err := New(config)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	tournament.SetClock(func() time.Time { return now })
+	tournament.Register(1, "a")
+	tournament.Register(2, "b")
+	if err := tournament.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	now = now.Add(9 * time.Minute)
+	if tournament.Tick() {
+		t.Error("Level should not change before its clock runs out")
+	}
+	if got := tournament.TimeToNextLevel(); got != time.Minute {
+		t.Errorf("Expected 1m to next level, got %v", got)
+	}
+
+	// A pause extends the current level
+	tournament.Pause()
+	now = now.Add(30 * time.Minute)
+	if tournament.Tick() {
+		t.Error("Level should not change while paused")
+	}
+	tournament.Resume()
+	if got := tournament.TimeToNextLevel(); got != time.Minute {
+		t.Errorf("Expected 1m to next level after pause, got %v", got)
+	}
+
+	// Skipping past several levels at once lands on the right one
+	now = now.Add(11 * time.Minute)
+	if !tournament.Tick() {
+		t.Fatal("Expected level to change")
+	}
+	if tournament.LevelIndex() != 2 {
+		t.Errorf("Expected level 2, got %d", tournament.LevelIndex())
+	}
+	if got := tournament.Tables()[0].GetBigBlind(); got != 100 {
+		t.Errorf("Expected big blind 100, got %d", got)
+	}
+}
+
+func TestEliminationsAndResults(t *testing.T) {
+	tournament := newTestTournament(t, 4, Config{BuyIn: 100, Payouts: []float64{0.65, 0.35}})
+	game := tournament.Tables()[0]
+
+	bust(t, tournament, 4, 1)
+	eliminated, _, err := tournament.HandCompleted(0)
+	if err != nil {
+		t.Fatalf("HandCompleted failed: %v", err)
+	}
+	if len(eliminated) != 1 || eliminated[0].PlayerID != 4 || eliminated[0].Place != 4 {
+		t.Fatalf("Expected player 4 out in 4th, got %+v", eliminated)
+	}
+	if tournament.TableOf(4) != -1 || len(game.GetAllPlayers()) != 3 {
+		t.Error("Expected player 4 to leave the table")
+	}
+
+	// Two players busting on one hand are placed by their starting stacks
+	p2, _ := findPlayer(game, 2)
+	p3, _ := findPlayer(game, 3)
+	p2.GrandChips(-500)
+	p3.GrandChips(500)
+	tournament.HandCompleted(0)
+	bust(t, tournament, 2, 1)
+	bust(t, tournament, 3, 1)
+	eliminated, _, _ = tournament.HandCompleted(0)
+	if len(eliminated) != 2 || eliminated[0].PlayerID != 3 || eliminated[0].Place != 2 || eliminated[1].Place != 3 {
+		t.Fatalf("Expected player 3 second and player 2 third, got %+v", eliminated)
+	}
+
+	if !tournament.IsFinished() {
+		t.Fatal("Expected tournament to be finished")
+	}
+	standings := tournament.Standings()
+	if standings[0].PlayerID != 1 || standings[0].Place != 1 || standings[0].Prize != 260 {
+		t.Errorf("Expected player 1 to win 260, got %+v", standings[0])
+	}
+	if standings[1].Prize != 140 || standings[2].Prize != 0 {
+		t.Errorf("Expected 140 for second and nothing for third, got %+v", standings[1:])
+	}
+}
+
+func TestBalanceMovesAndBreaksTables(t *testing.T) {
+	tournament := newTestTournament(t, 12, Config{SeatsPerTable: 6})
+	if sizes := tableSizes(tournament); len(sizes) != 2 || sizes[0] != 6 || sizes[1] != 6 {
+		t.Fatalf("Expected two full tables, got %v", sizes)
+	}
+
+	// Two busts on one table leave it short by two, so one player moves over
+	table := tournament.Tables()[0]
+	players := table.GetAllPlayers()
+	bust(t, tournament, players[0].GetID(), players[2].GetID())
+	bust(t, tournament, players[1].GetID(), players[2].GetID())
+	_, moves, err := tournament.HandCompleted(0)
+	if err != nil {
+		t.Fatalf("HandCompleted failed: %v", err)
+	}
+	if len(moves) != 1 || moves[0].FromTable != 1 || moves[0].ToTable != 0 || moves[0].Reason != MoveBalance {
+		t.Fatalf("Expected one move from table 1 to table 0, got %+v", moves)
+	}
+	if tournament.TableOf(moves[0].PlayerID) != 0 {
+		t.Error("Expected moved player to sit at table 0")
+	}
+
+	// Once six players are left they fit on one table
+	for _, p := range table.GetAllPlayers()[:4] {
+		bust(t, tournament, p.GetID(), table.GetAllPlayers()[4].GetID())
+	}
+	_, moves, err = tournament.HandCompleted(0)
+	if err != nil {
+		t.Fatalf("HandCompleted failed: %v", err)
+	}
+	if tournament.Remaining() != 6 {
+		t.Fatalf("Expected 6 players left, got %d", tournament.Remaining())
+	}
+	if active := tournament.ActiveTables(); len(active) != 1 {
+		t.Fatalf("Expected one table left, got %v", active)
+	}
+	if sizes := tableSizes(tournament); sizes[0] != 6 {
+		t.Errorf("Expected final table of 6, got %v", sizes)
+	}
+	// The broken table's players move over, then every seat is drawn again
+	final := tournament.ActiveTables()[0]
+	redrawn := map[int]bool{}
+	for _, move := range moves {
+		switch move.Reason {
+		case MoveTableBroken:
+			if move.ToTable != final {
+				t.Errorf("Expected the broken table's players moved to the final table, got %+v", move)
+			}
+		case MoveRedraw:
+			if seat, _ := tournament.Tables()[final].GetPlayerSitByID(move.PlayerID); seat != move.ToSeat || move.FromTable != final {
+				t.Errorf("Expected player %d redrawn to seat %d, sits at %d", move.PlayerID, move.ToSeat, seat)
+			}
+			redrawn[move.PlayerID] = true
+		default:
+			t.Errorf("Unexpected move %+v", move)
+		}
+	}
+	if len(redrawn) != 6 {
+		t.Errorf("Expected all 6 final table seats redrawn, got %v", redrawn)
+	}
+	broken := 1 - tournament.ActiveTables()[0]
+	if _, _, err := tournament.HandCompleted(broken); err == nil {
+		t.Error("Expected completing a hand on a broken table to fail")
+	}
+}
+
+func TestBalanceWaitsForHandsInProgress(t *testing.T) {
+	tournament := newTestTournament(t, 12, Config{SeatsPerTable: 6})
+	table := tournament.Tables()[0]
+	players := table.GetAllPlayers()
+	bust(t, tournament, players[0].GetID(), players[2].GetID())
+	bust(t, tournament, players[1].GetID(), players[2].GetID())
+
+	// Table 1 is mid-hand, so nobody leaves it yet
+	other := tournament.Tables()[1]
+	button, _ := other.GetPlayerSitByID(other.GetAllPlayers()[0].GetID())
+	if err := other.StartHand(button); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	if _, moves, err := tournament.HandCompleted(0); err != nil || len(moves) != 0 {
+		t.Fatalf("Expected no moves while table 1 deals, got %+v, %v", moves, err)
+	}
+
+	if _, err := other.AbortHand(holdem.AbortRefund); err != nil {
+		t.Fatalf("AbortHand failed: %v", err)
+	}
+	moves, err := tournament.Balance()
+	if err != nil || len(moves) != 1 || moves[0].FromTable != 1 || moves[0].ToTable != 0 {
+		t.Errorf("Expected the move once table 1 is between hands, got %+v, %v", moves, err)
+	}
+}
+
+func findPlayer(game *holdem.Game, id int) (holdem.IPlayer, bool) {
+	for _, p := range game.GetAllPlayers() {
+		if p.GetID() == id {
+			return p, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,59 @@
+package training
+
+// Score counts answers to one kind of question
+type Score struct {
+	Answered int `json:"answered"`
+	Correct  int `json:"correct"`
+}
+
+// Accuracy returns the share of answers that were correct
+func (s Score) Accuracy() float64 {
+	if s.Answered == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Answered)
+}
+
+// Progress tracks quiz results across sessions
+type Progress struct {
+	Total      Score                  `json:"total"`
+	ByKind     map[QuestionKind]Score `json:"by_kind"`
+	Streak     int                    `json:"streak"` // Correct answers in a row
+	BestStreak int                    `json:"best_streak"`
+}
+
+// Record adds an answer to the progress
+func (p *Progress) Record(kind QuestionKind, correct bool) {
+	if p.ByKind == nil {
+		p.ByKind = map[QuestionKind]Score{}
+	}
+	score := p.ByKind[kind]
+	score.Answered++
+	p.Total.Answered++
+	if correct {
+		score.Correct++
+		p.Total.Correct++
+		p.Streak++
+		p.BestStreak = max(p.BestStreak, p.Streak)
+	} else {
+		p.Streak = 0
+	}
+	p.ByKind[kind] = score
+}
+
+// Weakest returns the kind of question answered least accurately, so
+// practice can focus on it. Kinds never asked come first.
+func (p *Progress) Weakest() QuestionKind {
+	weakest, lowest := QuestionKinds[0], 2.0
+	for _, kind := range QuestionKinds {
+		score := p.ByKind[kind]
+		accuracy := score.Accuracy()
+		if score.Answered == 0 {
+			accuracy = -1
+		}
+		if accuracy < lowest {
+			weakest, lowest = kind, accuracy
+		}
+	}
+	return weakest
+}
@@ -0,0 +1,32 @@
+package training
+
+import "testing"
+
+func TestProgressRecord(t *testing.T) {
+	p := &Progress{}
+	p.Record(QuestionOuts, true)
+	p.Record(QuestionOuts, true)
+	p.Record(QuestionPotOdds, false)
+	p.Record(QuestionAction, true)
+
+	if p.Total.Answered != 4 || p.Total.Correct != 3 {
+		t.Errorf("Expected 3 of 4 correct, got %+v", p.Total)
+	}
+	if p.Streak != 1 || p.BestStreak != 2 {
+		t.Errorf("Expected streak 1 and best 2, got %d and %d", p.Streak, p.BestStreak)
+	}
+	if accuracy := p.ByKind[QuestionOuts].Accuracy(); accuracy != 1 {
+		t.Errorf("Expected perfect outs accuracy, got %.2f", accuracy)
+	}
+	if weakest := p.Weakest(); weakest != QuestionPotOdds {
+		t.Errorf("Expected pot odds to be weakest, got %s", weakest)
+	}
+}
+
+func TestProgressWeakestPrefersUnasked(t *testing.T) {
+	p := &Progress{}
+	p.Record(QuestionPotOdds, false)
+	if weakest := p.Weakest(); weakest != QuestionOuts {
+		t.Errorf("Expected outs, never asked, to come first, got %s", weakest)
+	}
+}
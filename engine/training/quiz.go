@@ -0,0 +1,125 @@
+package training
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/ljbink/ai-poker/engine/pokermath"
+	"github.com/ljbink/ai-poker/engine/simulator"
+)
+
+// QuestionKind is what a quiz question asks about a spot
+type QuestionKind int
+
+const (
+	QuestionPotOdds QuestionKind = iota // Equity needed to call
+	QuestionOuts                        // Cards that put the hero ahead
+	QuestionAction                      // Call or fold
+)
+
+// QuestionKinds lists every kind of question in quiz order
+var QuestionKinds = []QuestionKind{QuestionPotOdds, QuestionOuts, QuestionAction}
+
+// String returns the question kind name
+func (k QuestionKind) String() string {
+	switch k {
+	case QuestionPotOdds:
+		return "Pot Odds"
+	case QuestionOuts:
+		return "Outs"
+	case QuestionAction:
+		return "Call or Fold"
+	default:
+		return "Unknown"
+	}
+}
+
+// Question is a multiple choice question about a spot
+type Question struct {
+	Kind        QuestionKind
+	Spot        *simulator.Spot
+	Prompt      string
+	Choices     []string
+	Answer      int    // Index of the correct choice
+	Explanation string // Why the answer is right, shown after answering
+}
+
+// Check reports whether the choice is the correct answer
+func (q *Question) Check(choice int) bool {
+	return choice == q.Answer
+}
+
+// NewQuestion asks a question of the given kind about the spot
+func NewQuestion(kind QuestionKind, spot *simulator.Spot, rng *rand.Rand) (*Question, error) {
+	q := &Question{Kind: kind, Spot: spot}
+	switch kind {
+	case QuestionPotOdds:
+		odds := percent(spot.PotOdds())
+		q.Prompt = fmt.Sprintf("Villain bets %d into %d. What equity do you need to call?", spot.Bet, spot.Pot)
+		q.Explanation = fmt.Sprintf("You call %d to win %d, so you need %d / %d = %d%%",
+			spot.Bet, spot.Pot+spot.Bet, spot.Bet, spot.Pot+2*spot.Bet, odds)
+		// Common mistakes: leaving out your own call, or comparing the bet to the pot
+		wrong := []int{percent(pokermath.PotOdds(spot.Pot, spot.Bet)), percent(float64(spot.Bet) / float64(spot.Pot)), odds + 10, odds - 10}
+		q.setChoices(odds, wrong, "%d%%", rng)
+	case QuestionOuts:
+		outs := len(spot.Outs)
+		q.Prompt = "How many cards on the next street put you ahead?"
+		codes := make([]string, 0, outs)
+		for _, card := range spot.Outs {
+			codes = append(codes, card.Code())
+		}
+		q.Explanation = fmt.Sprintf("%d outs: %s", outs, strings.Join(codes, " "))
+		q.setChoices(outs, []int{outs - 2, outs + 2, outs + 4, outs - 4, outs + 1}, "%d", rng)
+	case QuestionAction:
+		ev := pokermath.CallEV(spot.Equity, spot.Pot+spot.Bet, spot.Bet)
+		q.Prompt = fmt.Sprintf("Villain bets %d into %d and you are all-in if you call. Call or fold?", spot.Bet, spot.Pot)
+		q.Choices = []string{"Call", "Fold"}
+		if ev < 0 {
+			q.Answer = 1
+		}
+		q.Explanation = fmt.Sprintf("You have %.1f%% equity and need %.1f%%, so calling is worth %+.1f chips",
+			spot.Equity*100, spot.PotOdds()*100, ev)
+	default:
+		return nil, fmt.Errorf("unknown question kind %d", kind)
+	}
+	return q, nil
+}
+
+// setChoices offers the answer among up to three distinct positive
+// distractors, in ascending order
+func (q *Question) setChoices(answer int, wrong []int, format string, rng *rand.Rand) {
+	values := []int{answer}
+	rng.Shuffle(len(wrong), func(i, j int) { wrong[i], wrong[j] = wrong[j], wrong[i] })
+	for _, value := range wrong {
+		if len(values) == 4 {
+			break
+		}
+		if value > 0 && !contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	sort.Ints(values)
+	for i, value := range values {
+		if value == answer {
+			q.Answer = i
+		}
+		q.Choices = append(q.Choices, fmt.Sprintf(format, value))
+	}
+}
+
+// percent rounds a fraction to a whole percentage
+func percent(fraction float64) int {
+	return int(math.Round(fraction * 100))
+}
+
+func contains(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package training
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/simulator"
+)
+
+func flushDrawSpot(t *testing.T, pot, bet int, equity float64) *simulator.Spot {
+	t.Helper()
+	outs, err := poker.ParseCards("4h5h6h8h9hThJh")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return &simulator.Spot{Pot: pot, Bet: bet, Outs: outs, Equity: equity}
+}
+
+func TestPotOddsQuestion(t *testing.T) {
+	q, err := NewQuestion(QuestionPotOdds, flushDrawSpot(t, 100, 50, 0.2), rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if q.Choices[q.Answer] != "25%" {
+		t.Errorf("Expected 25%% to be the answer, got %s from %v", q.Choices[q.Answer], q.Choices)
+	}
+	if len(q.Choices) != 4 {
+		t.Errorf("Expected 4 choices, got %v", q.Choices)
+	}
+	if !q.Check(q.Answer) || q.Check((q.Answer+1)%len(q.Choices)) {
+		t.Error("Expected only the answer to check out")
+	}
+}
+
+func TestOutsQuestionChoicesArePositiveAndDistinct(t *testing.T) {
+	spot := flushDrawSpot(t, 100, 50, 0.2)
+	spot.Outs = spot.Outs[:1]
+	q, err := NewQuestion(QuestionOuts, spot, rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if q.Choices[q.Answer] != "1" {
+		t.Errorf("Expected 1 out, got %s", q.Choices[q.Answer])
+	}
+	seen := map[string]bool{}
+	for _, choice := range q.Choices {
+		if seen[choice] || strings.HasPrefix(choice, "-") || choice == "0" {
+			t.Errorf("Unexpected choices %v", q.Choices)
+		}
+		seen[choice] = true
+	}
+}
+
+func TestActionQuestionFollowsEV(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	// 25% is needed: 30% equity calls, 20% folds
+	call, _ := NewQuestion(QuestionAction, flushDrawSpot(t, 100, 50, 0.3), rng)
+	if call.Choices[call.Answer] != "Call" {
+		t.Errorf("Expected a call with 30%% equity, got %s", call.Choices[call.Answer])
+	}
+	fold, _ := NewQuestion(QuestionAction, flushDrawSpot(t, 100, 50, 0.2), rng)
+	if fold.Choices[fold.Answer] != "Fold" {
+		t.Errorf("Expected a fold with 20%% equity, got %s", fold.Choices[fold.Answer])
+	}
+}
+
+func TestQuestionsAboutRandomSpots(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	spot, err := simulator.RandomSpot(rng)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, kind := range QuestionKinds {
+		q, err := NewQuestion(kind, spot, rng)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", kind, err)
+		}
+		if q.Prompt == "" || q.Explanation == "" || q.Answer < 0 || q.Answer >= len(q.Choices) {
+			t.Errorf("%s: incomplete question %+v", kind, q)
+		}
+	}
+}
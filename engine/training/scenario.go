@@ -0,0 +1,438 @@
+package training
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ljbink/ai-poker/engine/charts"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+// Scenario is a Hold'em decision point written down in YAML, for coaching
+// drills and for reproducing reported bugs:
+//
+//	name: Facing a flop c-bet
+//	blinds: [5, 10]
+//	seats:
+//	  - {name: Hero, position: BB, stack: 1000, cards: Ah Qd}
+//	  - {name: Villain, position: SB, stack: 1000}
+//	board: Qs 7c 2d
+//	actions:
+//	  - Villain raises to 30
+//	  - Hero calls
+//	  - Hero checks
+//	  - Villain bets 40
+//	question: Villain c-bets 40 into 60. What do you do?
+//
+// Seats are listed in table order. Cards left out are dealt at random,
+// from the seed, or from a seat's range such as "range: 22+, @BTN open". Actions name the player by name or position, and the
+// streets are dealt from the board as the betting closes them.
+type Scenario struct {
+	Name     string         `yaml:"name"`
+	Blinds   [2]int         `yaml:"blinds"` // Small and big blind
+	Ante     int            `yaml:"ante,omitempty"`
+	Seed     int64          `yaml:"seed,omitempty"` // Deals the unknown cards, 0 deals them like 1 does
+	Seats    []ScenarioSeat `yaml:"seats"`
+	Board    string         `yaml:"board,omitempty"`
+	Actions  []string       `yaml:"actions,omitempty"`
+	Hero     string         `yaml:"hero,omitempty"` // Player asked the question, whoever acts next when empty
+	Question string         `yaml:"question"`
+
+	board poker.Cards
+}
+
+// ScenarioSeat is a player in a scenario
+type ScenarioSeat struct {
+	Name     string          `yaml:"name"`
+	Position charts.Position `yaml:"position,omitempty"` // Places the button, seat 0 has it when no seat says
+	Stack    int             `yaml:"stack"`              // Chips before the blinds
+	Cards    string          `yaml:"cards,omitempty"`    // Hole cards, dealt at random when empty
+	Range    string          `yaml:"range,omitempty"`    // Range the hole cards are dealt from when Cards is empty
+
+	cards poker.Cards
+	hands *ranges.Range
+}
+
+// LoadScenario reads a scenario file, parsing seat ranges with opts
+func LoadScenario(path string, opts ...ranges.ParseOption) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := ParseScenario(data, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("scenario %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// ParseScenario parses and checks a scenario written in YAML, parsing seat
+// ranges with opts, e.g. ranges.WithLibrary for saved ranges
+func ParseScenario(data []byte, opts ...ranges.ParseOption) (*Scenario, error) {
+	s := &Scenario{}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid scenario: %w", err)
+	}
+	if err := s.validate(opts); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// validate checks everything about the scenario short of playing the actions
+func (s *Scenario) validate(opts []ranges.ParseOption) error {
+	if s.Blinds[0] <= 0 || s.Blinds[1] < s.Blinds[0] {
+		return fmt.Errorf("invalid blinds %d/%d", s.Blinds[0], s.Blinds[1])
+	}
+	if s.Ante < 0 {
+		return fmt.Errorf("invalid ante %d", s.Ante)
+	}
+	if len(s.Seats) < 2 || len(s.Seats) > 10 {
+		return fmt.Errorf("need 2 to 10 seats, got %d", len(s.Seats))
+	}
+	if s.Question == "" {
+		return fmt.Errorf("no question")
+	}
+
+	dealt := map[poker.Card]bool{}
+	deal := func(cards poker.Cards) error {
+		for _, card := range cards {
+			if dealt[*card] {
+				return fmt.Errorf("%s is dealt twice", card.Code())
+			}
+			dealt[*card] = true
+		}
+		return nil
+	}
+	names := map[string]bool{}
+	for i := range s.Seats {
+		seat := &s.Seats[i]
+		if seat.Name == "" || names[seat.Name] {
+			return fmt.Errorf("seat %d needs a name of its own", i)
+		}
+		names[seat.Name] = true
+		if seat.Stack <= 0 {
+			return fmt.Errorf("%s has no chips", seat.Name)
+		}
+		if seat.Position != "" && !seat.Position.IsKnown() {
+			return fmt.Errorf("%s has unknown position %q", seat.Name, seat.Position)
+		}
+		cards, err := poker.ParseCards(seat.Cards)
+		if err != nil {
+			return fmt.Errorf("%s's cards: %w", seat.Name, err)
+		}
+		if len(cards) != 0 && len(cards) != 2 {
+			return fmt.Errorf("%s holds %d cards, expected 2", seat.Name, len(cards))
+		}
+		if err := deal(cards); err != nil {
+			return err
+		}
+		seat.cards = cards
+		seat.hands = nil
+		if seat.Range != "" {
+			if len(cards) > 0 {
+				return fmt.Errorf("%s has both cards and a range", seat.Name)
+			}
+			if seat.hands, err = ranges.Parse(seat.Range, opts...); err != nil {
+				return fmt.Errorf("%s's range: %w", seat.Name, err)
+			}
+		}
+	}
+	if s.Hero != "" && !names[s.Hero] {
+		return fmt.Errorf("hero %s is not seated", s.Hero)
+	}
+
+	board, err := poker.ParseCards(s.Board)
+	if err != nil {
+		return fmt.Errorf("board: %w", err)
+	}
+	switch len(board) {
+	case 0, 3, 4, 5:
+	default:
+		return fmt.Errorf("board of %d cards", len(board))
+	}
+	if err := deal(board); err != nil {
+		return err
+	}
+	s.board = board
+
+	dead := poker.Cards{}
+	for card := range dealt {
+		dead = append(dead, &card)
+	}
+	for _, seat := range s.Seats {
+		if seat.hands != nil && len(seat.hands.Expand(dead)) == 0 {
+			return fmt.Errorf("%s's range has no hand left once the known cards are dealt", seat.Name)
+		}
+	}
+	return nil
+}
+
+// Button returns the seat of the button, placed by the seats' positions
+func (s *Scenario) Button() (int, error) {
+	for button := range s.Seats {
+		fits := true
+		for i, seat := range s.Seats {
+			after := (i - button + len(s.Seats)) % len(s.Seats)
+			if seat.Position != "" && charts.PositionAt(after, len(s.Seats)) != seat.Position {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			return button, nil
+		}
+	}
+	return 0, fmt.Errorf("no button seat fits the positions")
+}
+
+// NewGame deals the scenario and plays its actions, returning the game at
+// the decision point with the hero to act. Players' IDs are their seat
+// numbers plus one.
+func (s *Scenario) NewGame() (*holdem.Game, error) {
+	button, err := s.Button()
+	if err != nil {
+		return nil, err
+	}
+	seed := s.Seed
+	if seed == 0 {
+		seed = 1
+	}
+	game := holdem.NewGameWithConfig(holdem.GameConfig{
+		SmallBlind: s.Blinds[0],
+		BigBlind:   s.Blinds[1],
+		Ante:       s.Ante,
+		Seed:       seed,
+	})
+	for i, seat := range s.Seats {
+		if err := game.PlayerSit(holdem.NewPlayer(i+1, seat.Name, seat.Stack), i); err != nil {
+			return nil, err
+		}
+	}
+	if err := game.StackDeck(s.deck(rand.New(rand.NewSource(seed)))); err != nil {
+		return nil, err
+	}
+	if err := game.StartHand(button); err != nil {
+		return nil, err
+	}
+
+	for i, line := range s.Actions {
+		if err := s.dealStreets(game); err != nil {
+			return nil, fmt.Errorf("action %d %q: %w", i+1, line, err)
+		}
+		if game.IsHandOver() {
+			return nil, fmt.Errorf("action %d %q: the hand is over", i+1, line)
+		}
+		if !game.IsBettingRoundOpen() {
+			return nil, fmt.Errorf("action %d %q: the board runs out first", i+1, line)
+		}
+		action, err := s.parseAction(game, line)
+		if err != nil {
+			return nil, fmt.Errorf("action %d %q: %w", i+1, line, err)
+		}
+		if err := game.TakeAction(action); err != nil {
+			return nil, fmt.Errorf("action %d %q: %w", i+1, line, err)
+		}
+	}
+	if err := s.dealStreets(game); err != nil {
+		return nil, err
+	}
+
+	if game.IsHandOver() || !game.IsBettingRoundOpen() {
+		return nil, fmt.Errorf("the actions leave nobody to act")
+	}
+	if dealt := len(game.GetCommunityCards()); dealt != len(s.board) {
+		return nil, fmt.Errorf("the board has %d cards but the action stops with %d dealt", len(s.board), dealt)
+	}
+	if hero := game.GetCurrentPlayer(); s.Hero != "" && hero.GetName() != s.Hero {
+		return nil, fmt.Errorf("the actions leave %s to act, not %s", hero.GetName(), s.Hero)
+	}
+	return game, nil
+}
+
+// deck returns the cards to stack: the hole cards a round at a time in
+// seat order, then the board with a burn card before each street. Seats
+// with a range are dealt a combo of it by weight, and cards the scenario
+// leaves out are drawn at random from the rest of the deck.
+func (s *Scenario) deck(rng *rand.Rand) poker.Cards {
+	known := map[poker.Card]bool{}
+	for _, card := range s.board {
+		known[*card] = true
+	}
+	for _, seat := range s.Seats {
+		for _, card := range seat.cards {
+			known[*card] = true
+		}
+	}
+	holes := make([]poker.Cards, len(s.Seats))
+	for i, seat := range s.Seats {
+		holes[i] = seat.cards
+		if seat.hands == nil {
+			continue
+		}
+		dead := poker.Cards{}
+		for card := range known {
+			dead = append(dead, &card)
+		}
+		if combo, ok := pickCombo(seat.hands.Expand(dead), rng); ok {
+			holes[i] = combo
+			for _, card := range combo {
+				known[*card] = true
+			}
+		}
+	}
+	rest := poker.Cards{}
+	for _, card := range poker.NewDeckCards() {
+		if card.Suit != poker.SuitNone && !known[*card] {
+			rest = append(rest, card)
+		}
+	}
+	rng.Shuffle(len(rest), func(i, j int) { rest[i], rest[j] = rest[j], rest[i] })
+	draw := func() *poker.Card {
+		card := rest[0]
+		rest = rest[1:]
+		return card
+	}
+
+	deck := poker.Cards{}
+	for round := 0; round < 2; round++ {
+		for _, hole := range holes {
+			if len(hole) > 0 {
+				deck = append(deck, hole[round])
+			} else {
+				deck = append(deck, draw())
+			}
+		}
+	}
+	for i, card := range s.board {
+		if i == 0 || i >= 3 {
+			deck = append(deck, draw()) // Burn card
+		}
+		deck = append(deck, card)
+	}
+	return deck
+}
+
+// pickCombo draws one of the combos by weight, false when there are none
+func pickCombo(combos []ranges.Combo, rng *rand.Rand) (poker.Cards, bool) {
+	total := 0.0
+	for _, combo := range combos {
+		total += combo.Weight
+	}
+	if total <= 0 {
+		return nil, false
+	}
+	at := rng.Float64() * total
+	for _, combo := range combos {
+		if at -= combo.Weight; at < 0 {
+			return combo.Cards, true
+		}
+	}
+	return combos[len(combos)-1].Cards, true
+}
+
+// dealStreets deals the streets whose betting is over, as far as the board goes
+func (s *Scenario) dealStreets(game *holdem.Game) error {
+	for !game.IsHandOver() && !game.IsBettingRoundOpen() {
+		if len(game.GetCommunityCards()) >= len(s.board) {
+			return nil
+		}
+		var err error
+		switch game.GetCurrentPhase() {
+		case holdem.PhasePreflop:
+			err = game.DealFlop()
+		case holdem.PhaseFlop:
+			err = game.DealTurn()
+		case holdem.PhaseTurn:
+			err = game.DealRiver()
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseAction reads an action such as "Villain raises to 30", "BB calls"
+// or "Hero all-in". Amounts are the total bet on the street.
+func (s *Scenario) parseAction(game *holdem.Game, line string) (holdem.Action, error) {
+	words := strings.Fields(line)
+	verb := -1
+	for i, word := range words {
+		if _, ok := actionVerbs[strings.ToLower(word)]; ok && i > 0 {
+			verb = i
+			break
+		}
+	}
+	if verb < 0 {
+		return holdem.Action{}, fmt.Errorf("no fold, check, call, bet, raise or all-in")
+	}
+	player, err := s.player(game, strings.Join(words[:verb], " "))
+	if err != nil {
+		return holdem.Action{}, err
+	}
+	if acting := game.GetCurrentPlayer(); acting == nil || acting.GetID() != player.GetID() {
+		return holdem.Action{}, fmt.Errorf("it is not %s's turn", player.GetName())
+	}
+
+	id := player.GetID()
+	args := words[verb+1:]
+	if len(args) > 0 && strings.ToLower(args[0]) == "to" {
+		args = args[1:]
+	}
+	switch actionVerbs[strings.ToLower(words[verb])] {
+	case holdem.ActionFold:
+		return holdem.Action{PlayerID: id, Type: holdem.ActionFold}, nil
+	case holdem.ActionCheck:
+		return holdem.Action{PlayerID: id, Type: holdem.ActionCheck}, nil
+	case holdem.ActionCall:
+		call := game.GetCurrentBet() - player.GetBet()
+		if call >= player.GetChips() {
+			return holdem.Action{PlayerID: id, Type: holdem.ActionAllIn, Amount: player.GetChips()}, nil
+		}
+		return holdem.Action{PlayerID: id, Type: holdem.ActionCall, Amount: call}, nil
+	case holdem.ActionAllIn:
+		return holdem.Action{PlayerID: id, Type: holdem.ActionAllIn, Amount: player.GetChips()}, nil
+	}
+	if len(args) != 1 {
+		return holdem.Action{}, fmt.Errorf("expected the amount bet")
+	}
+	amount, err := strconv.Atoi(args[0])
+	if err != nil || amount <= 0 {
+		return holdem.Action{}, fmt.Errorf("invalid amount %q", args[0])
+	}
+	return holdem.NewRaise(id, amount), nil
+}
+
+// actionVerbs maps the words of an action to what the player does. Bets
+// are raises from nothing.
+var actionVerbs = map[string]holdem.ActionType{
+	"fold": holdem.ActionFold, "folds": holdem.ActionFold,
+	"check": holdem.ActionCheck, "checks": holdem.ActionCheck,
+	"call": holdem.ActionCall, "calls": holdem.ActionCall,
+	"bet": holdem.ActionRaise, "bets": holdem.ActionRaise,
+	"raise": holdem.ActionRaise, "raises": holdem.ActionRaise,
+	"all-in": holdem.ActionAllIn, "shoves": holdem.ActionAllIn,
+}
+
+// player finds a seated player by name or position
+func (s *Scenario) player(game *holdem.Game, who string) (holdem.IPlayer, error) {
+	for _, player := range game.GetAllPlayers() {
+		if player.GetName() == who {
+			return player, nil
+		}
+		if position, ok := charts.PositionOf(game, player.GetID()); ok && strings.EqualFold(string(position), who) {
+			return player, nil
+		}
+	}
+	return nil, fmt.Errorf("no player %q", who)
+}
@@ -0,0 +1,156 @@
+package training
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+func TestLoadScenarioStopsAtTheDecision(t *testing.T) {
+	s, err := LoadScenario("testdata/flop_cbet.yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	game, err := s.NewGame()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	hero := game.GetCurrentPlayer()
+	if hero == nil || hero.GetName() != "Hero" {
+		t.Fatalf("Expected the hero to act, got %v", hero)
+	}
+	if got := poker.Cards(hero.GetHandCards()).Codes(); got != "AhQd" {
+		t.Errorf("Expected the hero to hold AhQd, got %s", got)
+	}
+	if got := game.GetCommunityCards().Codes(); got != "Qs7c2d" {
+		t.Errorf("Expected the board Qs7c2d, got %s", got)
+	}
+	if game.GetCurrentPhase() != holdem.PhaseFlop || game.GetPot() != 100 || game.GetCurrentBet() != 40 {
+		t.Errorf("Expected a 40 bet into 60 on the flop, got %s pot %d bet %d",
+			holdem.PhaseToString(game.GetCurrentPhase()), game.GetPot(), game.GetCurrentBet())
+	}
+	if hero.GetChips() != 970 {
+		t.Errorf("Expected the hero to have 970 chips behind, got %d", hero.GetChips())
+	}
+}
+
+func TestScenarioDealsUnknownCardsFromTheSeed(t *testing.T) {
+	s, err := LoadScenario("testdata/flop_cbet.yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	deal := func() string {
+		game, err := s.NewGame()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		villain, err := game.GetPlayerByID(2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return poker.Cards(villain.GetHandCards()).Codes()
+	}
+	cards := deal()
+	if len(cards) != 4 {
+		t.Fatalf("Expected the villain dealt two cards, got %s", cards)
+	}
+	for _, known := range []string{"Ah", "Qd", "Qs", "7c", "2d"} {
+		if strings.Contains(cards, known) {
+			t.Errorf("Expected the villain dealt cards nobody else holds, got %s", cards)
+		}
+	}
+	if again := deal(); again != cards {
+		t.Errorf("Expected the same cards every load, got %s then %s", cards, again)
+	}
+}
+
+func TestScenarioDealsFromRanges(t *testing.T) {
+	library := ranges.NewLibrary(map[string]*ranges.Range{"Jam": mustParseRange(t, "QQ+")})
+	s, err := ParseScenario([]byte("blinds: [5, 10]\nseats:\n  - {name: Hero, stack: 1000, cards: Ah Kd}\n  - {name: Villain, stack: 1000, range: \"@jam, JJ:0.5\"}\nquestion: What now?"), ranges.WithLibrary(library))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for seed := int64(1); seed <= 20; seed++ {
+		s.Seed = seed
+		game, err := s.NewGame()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		villain, _ := game.GetPlayerByID(2)
+		if hand := ranges.HandClass(villain.GetHandCards()); !slices.Contains([]string{"AA", "KK", "QQ", "JJ"}, hand) {
+			t.Errorf("Seed %d: expected villain dealt from the range, got %s", seed, hand)
+		}
+	}
+}
+
+// mustParseRange parses range notation or fails the test
+func mustParseRange(t *testing.T, notation string) *ranges.Range {
+	t.Helper()
+	r, err := ranges.Parse(notation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestScenarioButtonFollowsPositions(t *testing.T) {
+	s, err := ParseScenario([]byte(`
+blinds: [1, 2]
+seats:
+  - {name: A, stack: 100}
+  - {name: B, position: CO, stack: 100}
+  - {name: C, stack: 100}
+  - {name: D, stack: 100}
+actions: [CO calls]
+question: What now?
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if button, err := s.Button(); err != nil || button != 2 {
+		t.Errorf("Expected the button at seat 2, got %d (%v)", button, err)
+	}
+	game, err := s.NewGame()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := game.GetCurrentPlayer().GetName(); got != "C" {
+		t.Errorf("Expected the button to act after the cutoff limps, got %s", got)
+	}
+}
+
+func TestScenarioErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario string
+		want     string
+	}{
+		{"blinds", "blinds: [0, 10]\nseats: [{name: A, stack: 1}, {name: B, stack: 1}]\nquestion: What now?", "invalid blinds"},
+		{"dealt twice", "blinds: [5, 10]\nseats: [{name: A, stack: 100, cards: AhAd}, {name: B, stack: 100}]\nboard: Ah 2c 3c\nquestion: What now?", "Ah is dealt twice"},
+		{"position", "blinds: [5, 10]\nseats: [{name: A, stack: 100, position: MP}, {name: B, stack: 100}]\nquestion: What now?", "unknown position"},
+		{"positions", "blinds: [5, 10]\nseats: [{name: A, stack: 100, position: SB}, {name: B, stack: 100, position: SB}]\nquestion: What now?", "no button seat"},
+		{"turn", "blinds: [5, 10]\nseats: [{name: A, stack: 100}, {name: B, stack: 100}]\nactions: [B calls]\nquestion: What now?", "not B's turn"},
+		{"verb", "blinds: [5, 10]\nseats: [{name: A, stack: 100}, {name: B, stack: 100}]\nactions: [A limps]\nquestion: What now?", "no fold, check"},
+		{"board", "blinds: [5, 10]\nseats: [{name: A, stack: 100}, {name: B, stack: 100}]\nactions: [A calls, B checks, B checks]\nquestion: What now?", "board runs out"},
+		{"over", "blinds: [5, 10]\nseats: [{name: A, stack: 100}, {name: B, stack: 100}]\nactions: [A folds]\nquestion: What now?", "nobody to act"},
+		{"hero", "blinds: [5, 10]\nseats: [{name: A, stack: 100}, {name: B, stack: 100}]\nhero: B\nquestion: What now?", "leave A to act, not B"},
+		{"cards and range", "blinds: [5, 10]\nseats: [{name: A, stack: 100, cards: AhAd, range: KK}, {name: B, stack: 100}]\nquestion: What now?", "both cards and a range"},
+		{"range", "blinds: [5, 10]\nseats: [{name: A, stack: 100, range: \"@nobody\"}, {name: B, stack: 100}]\nquestion: What now?", "unknown range"},
+		{"blocked range", "blinds: [5, 10]\nseats: [{name: A, stack: 100, range: AA}, {name: B, stack: 100, cards: AhAd}]\nboard: As 2c 3c\nquestion: What now?", "no hand left"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseScenario([]byte(tt.scenario))
+			if err == nil {
+				_, err = s.NewGame()
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
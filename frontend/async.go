@@ -0,0 +1,109 @@
+package frontend
+
+import (
+	"context"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AsyncTask is work running off the Bubble Tea update loop, started with
+// RunAsync. Its progress and result callbacks run on the update loop.
+type AsyncTask struct {
+	cancel   context.CancelFunc
+	canceled atomic.Bool
+	progress chan asyncProgress // Latest progress, older reports are dropped
+	done     chan asyncMsg
+
+	onProgress func(done, total int)
+}
+
+type asyncProgress struct {
+	done, total int
+}
+
+// asyncMsg delivers a task's progress or result to the update loop
+type asyncMsg struct {
+	task  *AsyncTask
+	apply func() tea.Cmd
+	final bool
+}
+
+// RunAsync runs work on its own goroutine and returns the task and the
+// command that delivers its messages. work should report progress and stop
+// early once ctx is done; a panic in it comes back as an error. onProgress may be nil; onDone receives the result
+// and returns the view's next command. Neither is called after Cancel.
+func RunAsync[T any](
+	work func(ctx context.Context, report func(done, total int)) (T, error),
+	onProgress func(done, total int),
+	onDone func(result T, err error) tea.Cmd,
+) (*AsyncTask, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background())
+	task := &AsyncTask{
+		cancel:     cancel,
+		progress:   make(chan asyncProgress, 1),
+		done:       make(chan asyncMsg, 1),
+		onProgress: onProgress,
+	}
+	go func() {
+		defer cancel()
+		result, err := safely(func() (T, error) { return work(ctx, task.report) })
+		task.done <- asyncMsg{task: task, final: true, apply: func() tea.Cmd { return onDone(result, err) }}
+	}()
+	return task, task.wait()
+}
+
+// Cancel stops the task; its callbacks are not called any more
+func (t *AsyncTask) Cancel() {
+	if t == nil {
+		return
+	}
+	t.canceled.Store(true)
+	t.cancel()
+}
+
+// report replaces any progress the update loop has not picked up yet
+func (t *AsyncTask) report(done, total int) {
+	progress := asyncProgress{done: done, total: total}
+	for {
+		select {
+		case t.progress <- progress:
+			return
+		default:
+		}
+		select {
+		case <-t.progress:
+		default:
+		}
+	}
+}
+
+// wait blocks until the task reports progress or finishes
+func (t *AsyncTask) wait() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case msg := <-t.done:
+			return msg
+		case progress := <-t.progress:
+			return asyncMsg{task: t, apply: func() tea.Cmd {
+				if t.onProgress != nil {
+					t.onProgress(progress.done, progress.total)
+				}
+				return nil
+			}}
+		}
+	}
+}
+
+// receive runs a task message on the update loop and keeps listening until
+// the task is done
+func (msg asyncMsg) receive() tea.Cmd {
+	if msg.task.canceled.Load() {
+		return nil
+	}
+	cmd := msg.apply()
+	if msg.final {
+		return cmd
+	}
+	return tea.Batch(cmd, msg.task.wait())
+}
@@ -0,0 +1,134 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// drain runs a command and every command its messages lead to, the way
+// the Bubble Tea loop would, and returns the messages in order
+func drain(t *testing.T, model *Model, cmd tea.Cmd) []tea.Msg {
+	t.Helper()
+	var msgs []tea.Msg
+	queue := []tea.Cmd{cmd}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if next == nil {
+			continue
+		}
+		switch msg := next().(type) {
+		case nil:
+		case tea.BatchMsg:
+			queue = append(queue, msg...)
+		default:
+			msgs = append(msgs, msg)
+			_, followUp := model.Update(msg)
+			queue = append(queue, followUp)
+		}
+	}
+	return msgs
+}
+
+func TestRunAsyncReportsProgressThenResult(t *testing.T) {
+	model := newTestModel(t, nil)
+	step := make(chan struct{})
+	var progress []int
+	result := 0
+
+	_, cmd := RunAsync(
+		func(ctx context.Context, report func(done, total int)) (int, error) {
+			for i := 1; i <= 3; i++ {
+				report(i, 3)
+				<-step
+			}
+			return 42, nil
+		},
+		func(done, total int) {
+			progress = append(progress, done)
+			go func() { step <- struct{}{} }()
+		},
+		func(value int, err error) tea.Cmd {
+			result = value
+			return nil
+		},
+	)
+	drain(t, model, cmd)
+
+	if result != 42 || len(progress) != 3 || progress[2] != 3 {
+		t.Errorf("Expected progress 1..3 then 42, got %v then %d", progress, result)
+	}
+}
+
+func TestRunAsyncCancel(t *testing.T) {
+	model := newTestModel(t, nil)
+	started := make(chan struct{})
+	called := false
+
+	task, cmd := RunAsync(
+		func(ctx context.Context, report func(done, total int)) (int, error) {
+			close(started)
+			<-ctx.Done()
+			return 0, ctx.Err()
+		},
+		nil,
+		func(value int, err error) tea.Cmd {
+			called = true
+			return nil
+		},
+	)
+	<-started
+	task.Cancel()
+	msgs := drain(t, model, cmd)
+
+	if called || len(msgs) != 1 {
+		t.Errorf("Expected the result of a canceled task to be dropped, got %d messages", len(msgs))
+	}
+}
+
+func TestRunAsyncPassesErrors(t *testing.T) {
+	model := newTestModel(t, nil)
+	failure := errors.New("disk full")
+	var got error
+
+	_, cmd := RunAsync(
+		func(ctx context.Context, report func(done, total int)) (string, error) {
+			return "", failure
+		},
+		nil,
+		func(value string, err error) tea.Cmd {
+			got = err
+			return nil
+		},
+	)
+	drain(t, model, cmd)
+
+	if !errors.Is(got, failure) {
+		t.Errorf("Expected the work error, got %v", got)
+	}
+}
+
+func TestRunAsyncRecoversPanics(t *testing.T) {
+	model := newTestModel(t, nil)
+	var got error
+
+	_, cmd := RunAsync(
+		func(ctx context.Context, report func(done, total int)) (string, error) {
+			panic("out of cards")
+		},
+		nil,
+		func(value string, err error) tea.Cmd {
+			got = err
+			return nil
+		},
+	)
+	drain(t, model, cmd)
+
+	var crash *panicError
+	if !errors.As(got, &crash) || crash.value != "out of cards" || len(crash.stack) == 0 {
+		t.Errorf("Expected the panic back as an error, got %v", got)
+	}
+}
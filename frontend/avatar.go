@@ -0,0 +1,86 @@
+package frontend
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// botAvatars give each bot profile, by preset name, the same look at every table
+var botAvatars = map[string]component.Avatar{
+	"basic":           {Emoji: "🤖", Color: "#94A3B8"}, // Slate
+	"conservative":    {Emoji: "🐢", Color: "#34D399"}, // Green
+	"aggressive":      {Emoji: "🦈", Color: "#F87171"}, // Red
+	"tight":           {Emoji: "🔒", Color: "#60A5FA"}, // Blue
+	"loose":           {Emoji: "🎲", Color: "#FB923C"}, // Orange
+	"random":          {Emoji: "🃏", Color: "#A78BFA"}, // Purple
+	"nit":             {Emoji: "🪨", Color: "#9CA3AF"}, // Gray
+	"maniac":          {Emoji: "🔥", Color: "#EF4444"}, // Bright red
+	"balanced":        {Emoji: "🎯", Color: "#2DD4BF"}, // Teal
+	"calling-station": {Emoji: "📞", Color: "#FBBF24"}, // Amber
+	"reader":          {Emoji: "🔍", Color: "#818CF8"}, // Indigo
+}
+
+// Choices offered for the human's avatar in the settings
+var (
+	avatarEmojis = []string{"🙂", "😎", "🦊", "🐻", "🦉", "👑", "🎩", "🍀"}
+	avatarColors = []string{"#60A5FA", "#F472B6", "#34D399", "#FBBF24", "#A78BFA", "#F87171"}
+)
+
+// avatarColorNames name the avatar colors in the catalog, "settings.color.<name>",
+// for accessibility mode
+var avatarColorNames = map[string]string{
+	"#60A5FA": "blue",
+	"#F472B6": "pink",
+	"#34D399": "green",
+	"#FBBF24": "amber",
+	"#A78BFA": "purple",
+	"#F87171": "red",
+}
+
+// humanAvatar returns the avatar saved with the human's profile, with the
+// first choices standing in for what was never picked
+func humanAvatar(user *UserData) component.Avatar {
+	avatar := component.Avatar{Emoji: avatarEmojis[0], Color: lipgloss.Color(avatarColors[0])}
+	if user == nil {
+		return avatar
+	}
+	if user.Avatar != "" {
+		avatar.Emoji = user.Avatar
+	}
+	if user.AvatarColor != "" {
+		avatar.Color = lipgloss.Color(user.AvatarColor)
+	}
+	return avatar
+}
+
+// avatarFor returns the avatar of a decision maker by its recorded name:
+// "human", a bot preset, or anything else for the player's default avatar
+func avatarFor(maker string, playerID int, user *UserData) component.Avatar {
+	if maker == "human" {
+		return humanAvatar(user)
+	}
+	if avatar, ok := botAvatars[maker]; ok {
+		return avatar
+	}
+	return component.DefaultAvatar(playerID)
+}
+
+// replayAvatars returns the avatars of the players in a recorded hand
+func replayAvatars(replay *holdem.Replay, user *UserData) map[int]component.Avatar {
+	avatars := map[int]component.Avatar{}
+	for _, seat := range replay.Seats {
+		avatars[seat.PlayerID] = avatarFor(seat.DecisionMaker, seat.PlayerID, user)
+	}
+	return avatars
+}
+
+// cycleString steps through choices from the current value, wrapping around
+func cycleString(choices []string, current string, delta int) string {
+	for i, choice := range choices {
+		if choice == current {
+			return choices[((i+delta)%len(choices)+len(choices))%len(choices)]
+		}
+	}
+	return choices[0]
+}
@@ -1,8 +1,16 @@
 package frontend
 
 import (
+	"errors"
+	"log/slog"
+	"sync"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/i18n"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/frontend/component"
+	"github.com/ljbink/ai-poker/internal/paths"
 )
 
 // ViewType represents different screens in the app
@@ -14,26 +22,76 @@ const (
 	ViewGameSetup
 	ViewSettings
 	ViewGame
+	ViewSpectator
+	ViewRange
+	ViewEquity
+	ViewTraining
+	ViewCharts
+	ViewSimulation
+	ViewLeaderboard
+	ViewStats
+	ViewBookmarks
 )
 
 // Model represents the main application state
 type Model struct {
-	currentView   ViewType
-	indexView     View
-	loginView     View
-	gameSetupView View
-	settingsView  View
-	gameView      View
+	currentView     ViewType
+	indexView       View
+	loginView       View
+	gameSetupView   View
+	settingsView    View
+	gameView        View
+	spectatorView   View
+	rangeView       View
+	equityView      View
+	trainingView    View
+	chartsView      View
+	simulationView  View
+	leaderboardView View
+	statsView       View
+	bookmarksView   View
 
 	width  int
 	height int
+	frame  string // Last frame drawn, for finding what the mouse points at
+
+	logger  *slog.Logger // Shared with engine games and decision makers
+	data    *Data        // Player profile and settings
+	changes chan string  // Data keys changed since the last dataChangedMsg
+
+	background sync.WaitGroup // Work to finish before the application exits, see track
+
+	translator *i18n.Translator    // Language chosen in the settings
+	chips      *i18n.ChipFormatter // Number format and currency chosen in the settings
+	accessible bool                // Accessibility mode chosen in the settings
+	fourColor  bool                // Four-color deck chosen in the settings
+}
+
+// dataChangedMsg tells the views that data was changed, by this or another view
+type dataChangedMsg struct {
+	key string
+}
+
+// dataObserver is implemented by views that keep a copy of data and must
+// refresh it when it changes
+type dataObserver interface {
+	DataChanged(key string)
 }
 
-// NewModel creates a new TUI model
-func NewModel() *Model {
+// NewModel creates a new TUI model on top of the application data
+func NewModel(data *Data) *Model {
 	model := &Model{
 		currentView: ViewIndex,
+		data:        data,
+		changes:     make(chan string, 16),
 	}
+	model.applySettings()
+	data.Subscribe(func(key string) {
+		select {
+		case model.changes <- key:
+		default: // Observers reload everything, so a dropped key is not missed
+		}
+	})
 
 	// Initialize views with the model reference
 	model.indexView = NewIndexView(model)
@@ -41,13 +99,38 @@ func NewModel() *Model {
 	model.gameSetupView = NewGameSetupView(model)
 	model.settingsView = NewSettingsView(model)
 	model.gameView = NewGameView(model)
+	model.spectatorView = NewSpectatorView(model)
+	model.rangeView = NewRangeView(model)
+	model.equityView = NewEquityView(model)
+	model.trainingView = NewTrainingView(model)
+	model.chartsView = NewChartsView(model)
+	model.simulationView = NewSimulationView(model)
+	model.leaderboardView = NewLeaderboardView(model)
+	model.statsView = NewStatsView(model)
+	model.bookmarksView = NewBookmarksView(model)
 
 	return model
 }
 
 // Init initializes the model (required by Bubble Tea)
 func (m *Model) Init() tea.Cmd {
-	return nil
+	return m.waitForDataChange()
+}
+
+// waitForDataChange delivers the next data change as a dataChangedMsg
+func (m *Model) waitForDataChange() tea.Cmd {
+	return func() tea.Msg {
+		return dataChangedMsg{key: <-m.changes}
+	}
+}
+
+// views returns every view of the model
+func (m *Model) views() []View {
+	return []View{
+		m.indexView, m.loginView, m.gameSetupView, m.settingsView, m.gameView, m.spectatorView,
+		m.rangeView, m.equityView, m.trainingView, m.chartsView, m.simulationView,
+		m.leaderboardView, m.statsView, m.bookmarksView,
+	}
 }
 
 // Update handles all messages and updates the model state
@@ -58,24 +141,78 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case NavigateMsg:
+		return m, m.navigate(msg.To, msg.Params)
+
+	case asyncMsg:
+		return m, msg.receive()
+
+	case dataChangedMsg:
+		if msg.key == settingsKey {
+			m.applySettings()
+		}
+		for _, view := range m.views() {
+			if observer, ok := view.(dataObserver); ok {
+				observer.DataChanged(msg.key)
+			}
+		}
+		return m, m.waitForDataChange()
+
+	case spectatorViewMsg:
+		if v, ok := m.spectatorView.(*SpectatorView); ok {
+			return m, v.receive(msg)
+		}
+		return m, nil
+
+	case simulationMsg:
+		if v, ok := m.simulationView.(*SimulationView); ok {
+			return m, v.receive(msg)
+		}
+		return m, nil
+
+	case gameUpdateMsg:
+		if v, ok := m.gameView.(*GameView); ok {
+			return m, v.receive(msg)
+		}
+		return m, nil
+
+	case flashMsg:
+		if v, ok := m.gameView.(*GameView); ok {
+			return m, v.stepFlash(msg)
+		}
+		return m, nil
+
+	case chipStepMsg:
+		if v, ok := m.gameView.(*GameView); ok {
+			return m, v.stepChips(msg)
+		}
+		return m, nil
+
+	case peekEndedMsg, oddsShownMsg:
+		// Redrawn by the runtime, which hides cards peeked at for long enough
+		return m, nil
+
+	case statusTickMsg:
+		if v, ok := m.gameView.(*GameView); ok {
+			return m, v.tick(msg)
+		}
+		return m, nil
+
+	case tea.MouseMsg:
+		if view, ok := m.view(m.currentView).(mouseView); ok {
+			return view.Mouse(msg, m.rowAt(msg.Y))
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
 		}
 
-		// Route to appropriate view handler using interface
-		switch m.currentView {
-		case ViewIndex:
-			return m.indexView.Update(msg)
-		case ViewLogin:
-			return m.loginView.Update(msg)
-		case ViewGameSetup:
-			return m.gameSetupView.Update(msg)
-		case ViewSettings:
-			return m.settingsView.Update(msg)
-		case ViewGame:
-			return m.gameView.Update(msg)
+		// Route to the current view
+		if view := m.view(m.currentView); view != nil {
+			return view.Update(msg)
 		}
 	}
 
@@ -84,28 +221,128 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the current view
 func (m *Model) View() string {
-	switch m.currentView {
-	case ViewIndex:
-		return m.indexView.Render(m.width, m.height)
-	case ViewLogin:
-		return m.loginView.Render(m.width, m.height)
-	case ViewGameSetup:
-		return m.gameSetupView.Render(m.width, m.height)
-	case ViewSettings:
-		return m.settingsView.Render(m.width, m.height)
-	case ViewGame:
-		return m.gameView.Render(m.width, m.height)
+	m.frame = "Unknown view"
+	if view := m.view(m.currentView); view != nil {
+		m.frame = view.Render(m.width, m.height)
+	}
+	return m.frame
+}
+
+// GetData returns the application data
+func (m *Model) GetData() *Data {
+	return m.data
+}
+
+// T returns the message for key in the chosen language
+func (m *Model) T(key string, args ...any) string {
+	return m.translator.T(key, args...)
+}
+
+// Chips returns how amounts of chips are written in the chosen language,
+// number format and currency
+func (m *Model) Chips() *i18n.ChipFormatter {
+	return m.chips
+}
+
+// Accessible reports whether accessibility mode is on: plain text instead
+// of glyphs, and no cues that rely on color
+func (m *Model) Accessible() bool {
+	return m.accessible
+}
+
+// Cards returns how cards are drawn. Accessibility mode wins over the
+// four-color deck, since screen readers need words rather than colors.
+func (m *Model) Cards() component.CardRenderer {
+	switch {
+	case m.accessible:
+		return component.TextCards{}
+	case m.fourColor:
+		return component.FourColorCards{}
 	default:
-		return "Unknown view"
+		return component.GlyphCards{}
+	}
+}
+
+// icon prefixes text with an icon, leaving the icon out in accessibility mode
+func (m *Model) icon(icon, text string) string {
+	if m.accessible {
+		return text
+	}
+	return icon + " " + text
+}
+
+// applySettings picks up the display settings
+func (m *Model) applySettings() {
+	settings := m.data.GetSettings()
+	m.translator = newTranslator(settings)
+	m.chips = newChipFormatter(settings)
+	m.accessible = settings.Accessibility
+	m.fourColor = settings.FourColorDeck
+}
+
+// newTranslator returns a translator for the language in the settings
+func newTranslator(settings *SettingsData) *i18n.Translator {
+	return i18n.New(i18n.Locale(settings.Language))
+}
+
+// newChipFormatter returns a formatter for the number format and currency
+// in the settings, the language's own format unless one is chosen
+func newChipFormatter(settings *SettingsData) *i18n.ChipFormatter {
+	return i18n.NewChipFormatter(newTranslator(settings), settings.NumberFormat, settings.Currency)
+}
+
+// GetLogger returns the application logger
+func (m *Model) GetLogger() *slog.Logger {
+	if m.logger == nil {
+		return slog.Default()
 	}
+	return m.logger
 }
 
-// RunTUI starts the Bubble Tea application
-func RunTUI() error {
-	model := NewModel()
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	_, err := p.Run()
-	return err
+// LoadSavedRanges returns the ranges saved with the profile in dataFile,
+// for the command line tools to take "@name" in range notation as the TUI
+// does
+func LoadSavedRanges(dataFile string) (*ranges.Library, error) {
+	store, err := NewFileStore(dataFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewData(store).rangeLibrary(), nil
+}
+
+// RunTUI starts the Bubble Tea application with the profile and settings
+// kept in the config directory and the files they name in the data directory
+func RunTUI(dirs paths.Dirs) error {
+	store, err := NewFileStore(dirs.DataFile())
+	if err != nil {
+		return err
+	}
+	data := NewData(store)
+	data.SetDirs(dirs)
+	logger, closer, err := newLogger(data)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+	data.SetLogger(logger)
+
+	model := NewModel(data)
+	model.logger = logger
+	logger.Info("application started")
+
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	_, err = p.Run()
+	// Ctrl-C arrives as an interrupt rather than a key press when the input
+	// is not a terminal, and quits like one
+	if err != nil && !errors.Is(err, tea.ErrInterrupted) {
+		// The recovery point of a game in play is kept for the next start
+		model.shutdown(false)
+		logger.Error("application exited with error", slog.Any("error", err))
+		return err
+	}
+	model.shutdown(true)
+	logger.Info("application stopped")
+	return nil
 }
 
 // Common styles
@@ -0,0 +1,119 @@
+package frontend
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/analysis"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/spectator"
+)
+
+// maxBookmarks is how many bookmarked decisions are kept, the oldest
+// dropped first
+const maxBookmarks = 50
+
+// Bookmark is a decision the human marked at the table to review later,
+// kept with the hand it was made in
+type Bookmark struct {
+	HandID     string           `json:"hand_id"`
+	HandNumber int              `json:"hand_number"`
+	Action     int              `json:"action"` // Index of the human's action in the replay's actions
+	Phase      holdem.GamePhase `json:"phase"`
+	Saved      time.Time        `json:"saved"`
+	Replay     *holdem.Replay   `json:"replay"`
+}
+
+// spotMark is a decision bookmarked in the hand in play, saved once the
+// hand is over
+type spotMark struct {
+	hand   int // Hand number
+	action int // Actions taken in the hand before the decision
+}
+
+// AddBookmark queues a decision for review, dropping the oldest beyond
+// maxBookmarks
+func (d *Data) AddBookmark(bookmark Bookmark) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	bookmarks := append(d.bookmarks(), bookmark)
+	if len(bookmarks) > maxBookmarks {
+		bookmarks = bookmarks[len(bookmarks)-maxBookmarks:]
+	}
+	d.save(bookmarksKey, bookmarks)
+}
+
+// GetBookmarks returns the decisions queued for review, oldest first
+func (d *Data) GetBookmarks() []Bookmark {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.bookmarks()
+}
+
+// RemoveBookmark takes a reviewed decision off the queue
+func (d *Data) RemoveBookmark(handID string, action int) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	bookmarks := []Bookmark{}
+	for _, bookmark := range d.bookmarks() {
+		if bookmark.HandID != handID || bookmark.Action != action {
+			bookmarks = append(bookmarks, bookmark)
+		}
+	}
+	d.save(bookmarksKey, bookmarks)
+}
+
+// bookmarks loads the stored bookmarks, empty when there are none
+func (d *Data) bookmarks() []Bookmark {
+	bookmarks := []Bookmark{}
+	if !d.load(bookmarksKey, &bookmarks) || bookmarks == nil {
+		return []Bookmark{}
+	}
+	return bookmarks
+}
+
+// bookmark marks the human's decision in the hand in play for review. It
+// is false when the decision is already marked.
+func (r *gameRunner) bookmark(hand, action int) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	mark := spotMark{hand: hand, action: action}
+	for _, marked := range r.marks {
+		if marked == mark {
+			return false
+		}
+	}
+	r.marks = append(r.marks, mark)
+	return true
+}
+
+// saveBookmarks queues the decisions marked in the finished hand for review
+// with its replay
+func (r *gameRunner) saveBookmarks(replay *holdem.Replay) {
+	r.lock.Lock()
+	marks := r.marks
+	r.marks = nil
+	r.lock.Unlock()
+	for _, mark := range marks {
+		if mark.hand != replay.HandNumber || mark.action >= len(replay.Actions) {
+			continue
+		}
+		r.data.AddBookmark(Bookmark{
+			HandID:     replay.HandID,
+			HandNumber: replay.HandNumber,
+			Action:     mark.action,
+			Phase:      replay.Actions[mark.action].Phase,
+			Saved:      time.Now(),
+			Replay:     replay,
+		})
+	}
+}
+
+// analyzeDecision works out the EV of the human's decision at an action of
+// a reviewed hand, from the table just before it
+func analyzeDecision(frames []spectator.CommentaryFrame, replay *holdem.Replay, action int) (analysis.Spot, error) {
+	if action < 1 || action > len(frames) {
+		return analysis.Spot{}, fmt.Errorf("the hand has no action %d", action+1)
+	}
+	return analysis.AnalyzeSpot(frames[action-1].View, replayHero(replay), equityGraphOptions)
+}
@@ -0,0 +1,205 @@
+package frontend
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/engine/rating"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+// botSwap is a bot replacement asked for from the game view
+type botSwap struct {
+	playerID int
+	profile  string // Bot to play the seat from now on, "" to send the bot away
+}
+
+// kickChoice is the last choice of the bot prompt, sending the bot away
+// rather than replacing it
+const kickChoice = "leave the table"
+
+// swapBot queues a bot replacement for the end of the hand; it replaces
+// one asked for earlier that was not applied yet
+func (r *gameRunner) swapBot(swap botSwap) {
+	r.lock.Lock()
+	r.swap = &swap
+	r.lock.Unlock()
+	r.request(requestSwapBot)
+}
+
+// applySwap hands a bot's seat to the bot asked for, or sends it away, and
+// returns the log line saying how it went. The seat keeps its player, so
+// its stack and stats carry on; the old bot's result so far is rated under
+// its own profile.
+func (r *gameRunner) applySwap(s *session.Session) string {
+	r.lock.Lock()
+	swap := r.swap
+	r.swap = nil
+	r.lock.Unlock()
+	if swap == nil {
+		return ""
+	}
+	game := s.GetGame()
+	name := r.playerName(game, swap.playerID)
+	if swap.profile == "" {
+		if len(game.GetAllPlayers()) <= 2 {
+			return name + " stays, the table needs an opponent"
+		}
+		chips, err := s.StandUp(swap.playerID)
+		if err != nil {
+			return "Sending " + name + " away refused: " + err.Error()
+		}
+		r.retire(swap.playerID)
+		return fmt.Sprintf("%s leaves the table with %d chips", name, chips)
+	}
+
+	maker, err := holdem_ai.CreateBotByName(swap.profile, ranges.WithLibrary(r.data.rangeLibrary()))
+	if err != nil {
+		return "Replacing " + name + " refused: " + err.Error()
+	}
+	r.setupBot(maker)
+	if err := s.ReplaceDecisionMaker(swap.playerID, maker); err != nil {
+		return "Replacing " + name + " refused: " + err.Error()
+	}
+	r.retire(swap.playerID)
+	r.lock.Lock()
+	r.names[swap.playerID] = swap.profile
+	r.lock.Unlock()
+	r.paceBots()
+	return fmt.Sprintf("%s is now played by the %s bot", name, swap.profile)
+}
+
+// retire sets aside what a bot won or lost so far, to rate it under its
+// own profile once the game is over
+func (r *gameRunner) retire(playerID int) {
+	if chips, ok := r.chips[playerID]; ok {
+		r.retired = append(r.retired, rating.Result{Name: r.names[playerID], Score: float64(chips)})
+		delete(r.chips, playerID)
+	}
+	delete(r.last, playerID)
+}
+
+// botPrompt picks a bot at the table and the bot to play its seat instead
+type botPrompt struct {
+	open    bool
+	bots    []botSeat // Bots at the table when the prompt opened
+	row     int       // Bot picked
+	choices []string  // Preset bots, then kickChoice
+	choice  int       // Replacement picked for the bot
+}
+
+// botSeat is a bot listed by the bot prompt
+type botSeat struct {
+	playerID int
+	name     string
+	profile  string
+}
+
+// botPromptKeys answer the bot prompt
+var botPromptKeys = struct {
+	Up     key.Binding
+	Down   key.Binding
+	Prev   key.Binding
+	Next   key.Binding
+	Apply  key.Binding
+	Cancel key.Binding
+}{
+	Up:     key.NewBinding(key.WithKeys("up", "k")),
+	Down:   key.NewBinding(key.WithKeys("down", "j")),
+	Prev:   key.NewBinding(key.WithKeys("left", "h")),
+	Next:   key.NewBinding(key.WithKeys("right", "l")),
+	Apply:  key.NewBinding(key.WithKeys("enter")),
+	Cancel: key.NewBinding(key.WithKeys("esc")),
+}
+
+// openBotPrompt lists the bots at the table, as they are in view, to pick
+// one to replace. Only cash games change bots, a tournament's field is set.
+func (v *GameView) openBotPrompt() {
+	if v.runner == nil || len(v.seats) == 0 {
+		return
+	}
+	if !v.runner.canSave() {
+		v.appendLog("Bots can only be replaced in cash games")
+		return
+	}
+	bots := []botSeat{}
+	v.runner.lock.Lock()
+	for _, seat := range v.seats {
+		if profile, ok := v.runner.names[seat.PlayerID]; ok && seat.PlayerID != humanPlayerID {
+			bots = append(bots, botSeat{playerID: seat.PlayerID, name: seat.Name, profile: profile})
+		}
+	}
+	v.runner.lock.Unlock()
+	if len(bots) == 0 {
+		return
+	}
+	v.bots = botPrompt{open: true, bots: bots, choices: append(holdem_ai.BotNames(), kickChoice)}
+	v.bots.pickBot(0)
+}
+
+// pickBot moves the prompt to a bot, offering the bot after its own
+func (p *botPrompt) pickBot(row int) {
+	p.row = (row + len(p.bots)) % len(p.bots)
+	p.choice = 0
+	for i, choice := range p.choices {
+		if choice == p.bots[p.row].profile {
+			p.choice = (i + 1) % len(p.choices)
+		}
+	}
+}
+
+// updateBotPrompt picks the bot and its replacement, and queues the swap
+func (v *GameView) updateBotPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &v.bots
+	switch {
+	case key.Matches(msg, botPromptKeys.Up):
+		p.pickBot(p.row - 1)
+	case key.Matches(msg, botPromptKeys.Down):
+		p.pickBot(p.row + 1)
+	case key.Matches(msg, botPromptKeys.Prev):
+		p.choice = (p.choice - 1 + len(p.choices)) % len(p.choices)
+	case key.Matches(msg, botPromptKeys.Next):
+		p.choice = (p.choice + 1) % len(p.choices)
+	case key.Matches(msg, botPromptKeys.Apply):
+		p.open = false
+		bot, choice := p.bots[p.row], p.choices[p.choice]
+		switch {
+		case choice == bot.profile:
+			v.appendLog(fmt.Sprintf("%s already plays as %s", bot.name, choice))
+		case choice == kickChoice:
+			v.runner.swapBot(botSwap{playerID: bot.playerID})
+			v.appendLog(fmt.Sprintf("%s will leave the table after this hand", bot.name))
+		default:
+			v.runner.swapBot(botSwap{playerID: bot.playerID, profile: choice})
+			v.appendLog(fmt.Sprintf("%s will be played by the %s bot from the next hand", bot.name, choice))
+		}
+	case key.Matches(msg, botPromptKeys.Cancel):
+		p.open = false
+	}
+	return v.model, nil
+}
+
+// renderBotPrompt draws the bots at the table and the replacement picked
+func (v *GameView) renderBotPrompt() string {
+	picked := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED")).Bold(true)
+	lines := []string{lipgloss.NewStyle().Bold(true).Render("Replace a bot")}
+	for i, bot := range v.bots.bots {
+		line := fmt.Sprintf("  %s (%s)", bot.name, bot.profile)
+		if i == v.bots.row {
+			line = picked.Render(fmt.Sprintf("> %s (%s) → %s", bot.name, bot.profile, v.bots.choices[v.bots.choice]))
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Render("↑/↓ pick a bot · ←/→ pick its replacement · enter to swap between hands · esc to cancel"))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -0,0 +1,121 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+// swapTable seats the human and bots tight and nit at a cash table
+func swapTable(t *testing.T, runner *gameRunner) *session.Session {
+	t.Helper()
+	s := session.New(holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3}))
+	s.SetDecisionMakers(runner.makers)
+	for id, profile := range map[int]string{2: "tight", 3: "nit"} {
+		name, err := runner.addBot(id, profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SitDown(holdem.NewPlayer(id, name, 1000), id-1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.SitDown(holdem.NewPlayer(humanPlayerID, "", 1000), 0); err != nil {
+		t.Fatal(err)
+	}
+	runner.setTable(s, true)
+	return s
+}
+
+func TestRunnerSwapsBots(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	runner := newGameRunner(holdem.NewDiscardLogger(), data, nil)
+	s := swapTable(t, runner)
+	runner.chips = map[int]int{humanPlayerID: -50, 2: 150, 3: -100}
+	if line := runner.applySwap(s); line != "" {
+		t.Errorf("Expected nothing to do without a swap queued, got %q", line)
+	}
+
+	runner.swapBot(botSwap{playerID: 2, profile: "maniac"})
+	if line := runner.applySwap(s); !strings.HasSuffix(line, "Tight is now played by the maniac bot") {
+		t.Errorf("Expected the swap logged, got %q", line)
+	}
+	if _, ok := s.GetDecisionMaker(2).(*holdem_ai.BasicBotDecisionMaker); !ok || runner.names[2] != "maniac" {
+		t.Errorf("Expected the maniac to play player 2, got %T named %q", s.GetDecisionMaker(2), runner.names[2])
+	}
+	if player, _ := s.GetGame().GetPlayerByID(2); player.GetChips() != 1000 || player.GetName() != "Tight" {
+		t.Errorf("Expected the seat to keep its stack and name, got %+v", player)
+	}
+
+	runner.swapBot(botSwap{playerID: 2, profile: "nobody"})
+	if line := runner.applySwap(s); !strings.Contains(line, "refused: unknown bot") {
+		t.Errorf("Expected an unknown bot refused, got %q", line)
+	}
+
+	runner.swapBot(botSwap{playerID: 3})
+	if line := runner.applySwap(s); !strings.HasSuffix(line, "Nit leaves the table with 1000 chips") {
+		t.Errorf("Expected the nit sent away, got %q", line)
+	}
+	if _, err := s.GetGame().GetPlayerByID(3); err == nil {
+		t.Error("Expected the nit gone from the table")
+	}
+	runner.swapBot(botSwap{playerID: 2})
+	if line := runner.applySwap(s); !strings.HasSuffix(line, "stays, the table needs an opponent") {
+		t.Errorf("Expected the last opponent kept, got %q", line)
+	}
+
+	// The tight bot's and the nit's results count for them, not for the maniac
+	runner.rate()
+	ratings := data.GetRatings()
+	if ratings.Get("tight").Games != 1 || ratings.Get("nit").Games != 1 || ratings.Get("maniac").Games != 0 {
+		t.Errorf("Expected the replaced bots rated under their own profiles, got %+v", ratings)
+	}
+	if ratings.Get("tight").Rating <= ratings.Get("nit").Rating {
+		t.Errorf("Expected the winning tight bot rated above the nit, got %+v", ratings)
+	}
+}
+
+func TestBotPromptQueuesSwap(t *testing.T) {
+	model := newTestModel(t, nil)
+	gv := model.gameView.(*GameView)
+	gv.runner = newGameRunner(holdem.NewDiscardLogger(), model.GetData(), nil)
+	s := swapTable(t, gv.runner)
+	gv.seats = s.GetGame().PlayerView(humanPlayerID).Seats
+
+	gv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if !gv.bots.open || len(gv.bots.bots) != 2 || gv.bots.bots[0].profile != "tight" {
+		t.Fatalf("Expected the prompt to list the two bots, got %+v", gv.bots)
+	}
+	// Each bot is offered the preset after its own first
+	gv.Update(tea.KeyMsg{Type: tea.KeyDown})
+	gv.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if choice := gv.bots.choices[gv.bots.choice]; choice != "nit" {
+		t.Errorf("Expected the nit's own profile one step back, got %q", choice)
+	}
+	gv.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if gv.bots.open || gv.runner.swap != nil || !strings.HasSuffix(gv.log[len(gv.log)-1], "Nit already plays as nit") {
+		t.Errorf("Expected keeping the same bot to queue nothing, got %+v and %q", gv.runner.swap, gv.log)
+	}
+
+	gv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	for i := 0; i < len(gv.bots.choices) && gv.bots.choices[gv.bots.choice] != kickChoice; i++ {
+		gv.Update(tea.KeyMsg{Type: tea.KeyRight})
+	}
+	if choice := gv.bots.choices[gv.bots.choice]; choice != kickChoice {
+		t.Fatalf("Expected to reach the kick choice, got %q", choice)
+	}
+	gv.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if gv.runner.swap == nil || *gv.runner.swap != (botSwap{playerID: 2}) {
+		t.Errorf("Expected the tight bot queued to leave, got %+v", gv.runner.swap)
+	}
+
+	gv.runner.setTable(s, false)
+	gv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if gv.bots.open {
+		t.Error("Expected tournament bots to stay as they are")
+	}
+}
@@ -0,0 +1,52 @@
+package frontend
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// chipStep is the time between two steps of the chip piles moving
+const chipStep = 80 * time.Millisecond
+
+// chipStepMsg moves the chip piles on by a step
+type chipStepMsg struct {
+	runner *gameRunner
+}
+
+// tableStacks returns the players' stacks in seat order, followed by the pot
+func tableStacks(view holdem.TableView) []component.ChipStack {
+	stacks := make([]component.ChipStack, 0, len(view.Seats)+1)
+	for _, seat := range view.Seats {
+		stacks = append(stacks, component.ChipStack{Label: seat.Name, Chips: seat.Chips})
+	}
+	return append(stacks, component.ChipStack{Label: "Pot", Chips: view.Pot})
+}
+
+// stackChips moves the chip piles to the table's stacks, sliding them
+// there when animations are on, as bets go in and pots are pushed
+func (v *GameView) stackChips(view holdem.TableView) tea.Cmd {
+	animate := v.model.GetData().GetSettings().AnimationsEnabled && !v.model.Accessible()
+	v.chips.SetStacks(tableStacks(view), animate)
+	if v.stacking || !v.chips.Animating() {
+		return nil
+	}
+	v.stacking = true
+	return v.chipTick()
+}
+
+func (v *GameView) chipTick() tea.Cmd {
+	runner := v.runner
+	return tea.Tick(chipStep, func(time.Time) tea.Msg { return chipStepMsg{runner: runner} })
+}
+
+// stepChips moves the piles on until they reach their stacks
+func (v *GameView) stepChips(msg chipStepMsg) tea.Cmd {
+	if msg.runner != v.runner || !v.chips.Step() {
+		v.stacking = false
+		return nil
+	}
+	return v.chipTick()
+}
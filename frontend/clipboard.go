@@ -0,0 +1,56 @@
+package frontend
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// Where copyToClipboard put the text
+const (
+	copiedToClipboard = "the clipboard"
+	copiedToTerminal  = "the terminal's clipboard"
+)
+
+// copyToClipboard puts text on the system clipboard and says where it went.
+// Over SSH, or without a clipboard tool such as xclip installed, it asks
+// the terminal to copy the text instead with an OSC 52 escape sequence,
+// which most terminals and tmux honour.
+func copyToClipboard(text string) (string, error) {
+	remote := os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+	if !remote && !clipboard.Unsupported && clipboard.WriteAll(text) == nil {
+		return copiedToClipboard, nil
+	}
+	sequence := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		sequence = sequence.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		sequence = sequence.Screen()
+	}
+	// The terminal reads it from stderr as well as it would from the
+	// renderer's stdout, without getting in the way of the frames
+	if _, err := sequence.WriteTo(os.Stderr); err != nil {
+		return "", err
+	}
+	return copiedToTerminal, nil
+}
+
+// handText writes a recorded hand as a PokerStars hand history, the text
+// forums and hand converters read
+func handText(replay *holdem.Replay) (string, error) {
+	hand, err := handhistory.FromReplay(replay)
+	if err != nil {
+		return "", err
+	}
+	var text bytes.Buffer
+	if err := handhistory.WritePokerStars(&text, hand); err != nil {
+		return "", err
+	}
+	return text.String(), nil
+}
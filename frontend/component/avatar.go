@@ -0,0 +1,54 @@
+package component
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Avatar identifies a player wherever they show up: at the table, in the
+// action log and in hand reviews
+type Avatar struct {
+	Emoji string         // Empty for the initial of the player's name
+	Color lipgloss.Color // Color of the player's name
+}
+
+// avatarPalette colors players without an avatar of their own, by player ID
+var avatarPalette = []lipgloss.Color{
+	"#60A5FA", // Blue
+	"#F472B6", // Pink
+	"#34D399", // Green
+	"#FBBF24", // Amber
+	"#A78BFA", // Purple
+	"#F87171", // Red
+	"#2DD4BF", // Teal
+	"#FB923C", // Orange
+}
+
+// DefaultAvatar returns the avatar of a player without one of their own:
+// their initial in a color picked by player ID
+func DefaultAvatar(playerID int) Avatar {
+	return Avatar{Color: avatarPalette[(playerID%len(avatarPalette)+len(avatarPalette))%len(avatarPalette)]}
+}
+
+// Icon returns the avatar's emoji, or the initial of name in brackets
+func (a Avatar) Icon(name string) string {
+	if a.Emoji != "" {
+		return a.Emoji
+	}
+	initial, _ := utf8.DecodeRuneInString(name)
+	if initial == utf8.RuneError {
+		initial = '?'
+	}
+	return "[" + strings.ToUpper(string(initial)) + "]"
+}
+
+// Badge renders name behind the avatar, in the avatar's color. Plain badges
+// are the name alone, for screen readers.
+func (a Avatar) Badge(name string, plain bool) string {
+	if plain {
+		return name
+	}
+	return a.Icon(name) + " " + lipgloss.NewStyle().Foreground(a.Color).Render(name)
+}
@@ -0,0 +1,28 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestAvatarsLineUpSeats(t *testing.T) {
+	if got := (Avatar{}).Icon("maniac"); got != "[M]" {
+		t.Errorf("Expected the initial without an emoji, got %q", got)
+	}
+	if got := (Avatar{Emoji: "🔥"}).Badge("Maniac", true); got != "Maniac" {
+		t.Errorf("Expected plain badges to be the name alone, got %q", got)
+	}
+
+	table := NewTableComponent(80)
+	table.SetView(holdem.TableView{Button: -1, ActingSeat: -1, Seats: []holdem.SeatView{
+		{Seat: 0, PlayerID: 1, Name: "Hero"},
+		{Seat: 1, PlayerID: 2, Name: "Maniac"},
+	}})
+	table.SetAvatars(map[int]Avatar{1: {Emoji: "🙂"}})
+	name, _ := table.seatName(table.View().Seats[0])
+	other, _ := table.seatName(table.View().Seats[1])
+	if name != "🙂  Hero           " || other != "[M] Maniac         " {
+		t.Errorf("Expected avatars padded to one column, got %q and %q", name, other)
+	}
+}
@@ -0,0 +1,139 @@
+package component
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// bigCardSize is the outline of a card box, borders included
+type bigCardSize struct {
+	width, height int
+}
+
+// Card sizes from largest to smallest; the largest that fits is used
+var bigCardSizes = []bigCardSize{
+	{width: 11, height: 9},
+	{width: 9, height: 7},
+	{width: 7, height: 5},
+}
+
+// bigCardGap is the space between two cards
+const bigCardGap = 1
+
+// redSuitStyle colors hearts and diamonds in a two-color deck
+var redSuitStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#EF4444")) // Red
+
+// BigCardComponent renders cards as ASCII boxes with the rank in the
+// corners and the suit in the middle, as large as the width allows
+type BigCardComponent struct {
+	cards     []*poker.Card
+	width     int
+	fourColor bool
+	faceDown  bool
+}
+
+// NewBigCardComponent creates a big card component
+func NewBigCardComponent(width int) *BigCardComponent {
+	return &BigCardComponent{width: width}
+}
+
+// SetCards updates the cards being rendered
+func (b *BigCardComponent) SetCards(cards []*poker.Card) {
+	b.cards = cards
+}
+
+// SetWidth updates the width the cards have to fit in
+func (b *BigCardComponent) SetWidth(width int) {
+	b.width = width
+}
+
+// SetFourColor colors every suit differently instead of hearts and diamonds red
+func (b *BigCardComponent) SetFourColor(fourColor bool) {
+	b.fourColor = fourColor
+}
+
+// SetFaceDown draws the backs of the cards instead of their faces
+func (b *BigCardComponent) SetFaceDown(faceDown bool) {
+	b.faceDown = faceDown
+}
+
+// Render renders the cards side by side, empty when there are none. When
+// even the smallest boxes do not fit, the cards are written on one line.
+func (b *BigCardComponent) Render() string {
+	if len(b.cards) == 0 {
+		return ""
+	}
+	size, ok := b.size()
+	if !ok {
+		if b.faceDown {
+			return RenderBacks(FourColorCards{}, len(b.cards))
+		}
+		return RenderCards(FourColorCards{}, b.cards)
+	}
+	boxes := make([]string, 0, 2*len(b.cards))
+	for i, card := range b.cards {
+		if i > 0 {
+			boxes = append(boxes, strings.Repeat(" ", bigCardGap))
+		}
+		if b.faceDown {
+			boxes = append(boxes, renderBack(size))
+			continue
+		}
+		boxes = append(boxes, b.renderCard(card, size))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, boxes...)
+}
+
+// size returns the largest card size that fits all cards in the width
+func (b *BigCardComponent) size() (bigCardSize, bool) {
+	for _, size := range bigCardSizes {
+		if len(b.cards)*(size.width+bigCardGap)-bigCardGap <= b.width {
+			return size, true
+		}
+	}
+	return bigCardSize{}, false
+}
+
+// renderCard draws one box:
+//
+//	+-----+
+//	|A    |
+//	|  ♠  |
+//	|    A|
+//	+-----+
+func (b *BigCardComponent) renderCard(card *poker.Card, size bigCardSize) string {
+	inner := size.width - 2
+	rank := poker.RankMap[card.Rank]
+	symbol := suitSymbols[card.Suit]
+	if b.fourColor {
+		symbol = fourColorStyles[card.Suit].Render(symbol)
+	} else if card.Suit == poker.SuitHeart || card.Suit == poker.SuitDiamond {
+		symbol = redSuitStyle.Render(symbol)
+	}
+
+	border := "+" + strings.Repeat("-", inner) + "+"
+	blank := "|" + strings.Repeat(" ", inner) + "|"
+	lines := make([]string, size.height)
+	for i := range lines {
+		lines[i] = blank
+	}
+	lines[0], lines[size.height-1] = border, border
+	lines[1] = "|" + rank + strings.Repeat(" ", inner-len(rank)) + "|"
+	lines[size.height-2] = "|" + strings.Repeat(" ", inner-len(rank)) + rank + "|"
+	left := (inner - 1) / 2
+	lines[size.height/2] = "|" + strings.Repeat(" ", left) + symbol + strings.Repeat(" ", inner-1-left) + "|"
+	return strings.Join(lines, "\n")
+}
+
+// renderBack draws the back of a card as a box filled with a pattern
+func renderBack(size bigCardSize) string {
+	border := "+" + strings.Repeat("-", size.width-2) + "+"
+	lines := make([]string, size.height)
+	for i := range lines {
+		lines[i] = "|" + strings.Repeat("▒", size.width-2) + "|"
+	}
+	lines[0], lines[size.height-1] = border, border
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,39 @@
+package component
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestBigCardsScaleWithWidth(t *testing.T) {
+	cards, err := poker.ParseCards("Ts 9h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	big := NewBigCardComponent(80)
+	big.SetCards(cards)
+
+	for _, tc := range []struct {
+		width, height int
+	}{
+		{width: 80, height: 9},
+		{width: 19, height: 7},
+		{width: 15, height: 5},
+		{width: 10, height: 1},
+	} {
+		big.SetWidth(tc.width)
+		rendered := big.Render()
+		if got := lipgloss.Height(rendered); got != tc.height {
+			t.Errorf("Width %d: expected cards %d lines high, got %d:\n%s", tc.width, tc.height, got, rendered)
+		}
+		if got := lipgloss.Width(rendered); got > tc.width {
+			t.Errorf("Width %d: cards take %d columns", tc.width, got)
+		}
+		if !strings.Contains(rendered, "10") || !strings.Contains(rendered, "♥") {
+			t.Errorf("Width %d: expected the ranks and suits, got:\n%s", tc.width, rendered)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package component
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// CardRenderer turns cards into text. Views and components draw every card
+// through one, so display settings apply to all of them.
+type CardRenderer interface {
+	Card(card *poker.Card) string // A face-up card
+	Back() string                 // A face-down card
+	Separator() string            // Goes between cards
+}
+
+// GlyphCards draws cards as Unicode playing card glyphs
+type GlyphCards struct{}
+
+func (GlyphCards) Card(card *poker.Card) string { return card.String() }
+func (GlyphCards) Back() string                 { return "🂠" }
+func (GlyphCards) Separator() string            { return " " }
+
+// TextCards draws cards in plain words, e.g. "Ace of spades", for screen readers
+type TextCards struct{}
+
+func (TextCards) Card(card *poker.Card) string { return card.Name() }
+func (TextCards) Back() string                 { return "hidden card" }
+func (TextCards) Separator() string            { return ", " }
+
+// RenderCards renders visible cards, or "-" when there are none
+func RenderCards(renderer CardRenderer, cards []*poker.Card) string {
+	if len(cards) == 0 {
+		return "-"
+	}
+	parts := make([]string, 0, len(cards))
+	for _, card := range cards {
+		parts = append(parts, renderer.Card(card))
+	}
+	return strings.Join(parts, renderer.Separator())
+}
+
+// RenderBacks renders the given number of face-down cards
+func RenderBacks(renderer CardRenderer, count int) string {
+	parts := make([]string, count)
+	for i := range parts {
+		parts[i] = renderer.Back()
+	}
+	return strings.Join(parts, renderer.Separator())
+}
+
+// suitSymbols are the suit shapes FourColorCards draws next to the rank
+var suitSymbols = map[poker.Suit]string{
+	poker.SuitSpade:   "♠",
+	poker.SuitHeart:   "♥",
+	poker.SuitDiamond: "♦",
+	poker.SuitClub:    "♣",
+}
+
+// fourColorStyles give every suit its own color, as in a four-color deck
+var fourColorStyles = map[poker.Suit]lipgloss.Style{
+	poker.SuitSpade:   lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#E5E7EB")), // Light gray
+	poker.SuitHeart:   lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#EF4444")), // Red
+	poker.SuitDiamond: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#3B82F6")), // Blue
+	poker.SuitClub:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#10B981")), // Green
+}
+
+// FourColorCards draws cards as rank and suit shape, e.g. "A♠", colored by
+// suit so hearts and diamonds or spades and clubs are never confused
+type FourColorCards struct{}
+
+func (FourColorCards) Card(card *poker.Card) string {
+	symbol, ok := suitSymbols[card.Suit]
+	if !ok {
+		return card.String()
+	}
+	rank := poker.RankMap[card.Rank]
+	return fourColorStyles[card.Suit].Render(rank + symbol)
+}
+
+func (FourColorCards) Back() string      { return "▒▒" }
+func (FourColorCards) Separator() string { return " " }
@@ -0,0 +1,274 @@
+package component
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/i18n"
+)
+
+// chipDenomination is a chip value and the color it is drawn in
+type chipDenomination struct {
+	value int
+	color lipgloss.Color
+}
+
+// chipDenominations are the chips stacks are made of, largest first, in
+// the usual casino colors
+var chipDenominations = []chipDenomination{
+	{value: 1000, color: lipgloss.Color("#FBBF24")}, // Yellow
+	{value: 500, color: lipgloss.Color("#A855F7")},  // Purple
+	{value: 100, color: lipgloss.Color("#6B7280")},  // Gray, for black
+	{value: 25, color: lipgloss.Color("#10B981")},   // Green
+	{value: 5, color: lipgloss.Color("#EF4444")},    // Red
+	{value: 1, color: lipgloss.Color("#E5E7EB")},    // White
+}
+
+// Sizes of a pile: the most rows the largest stack is drawn with, and the
+// cells a pile's column takes, gap included
+const (
+	chipPileRows  = 6
+	chipPileWidth = 14
+)
+
+// chipSteps is how many steps piles take to reach new amounts
+const chipSteps = 4
+
+// ChipCount is how many chips of a value make up part of a stack
+type ChipCount struct {
+	Value int
+	Count int
+}
+
+// ChipBreakdown returns the fewest chips that make up the amount, largest
+// value first; nothing for amounts of 0 or less
+func ChipBreakdown(amount int) []ChipCount {
+	counts := []ChipCount{}
+	for _, denomination := range chipDenominations {
+		if n := amount / denomination.value; n > 0 {
+			counts = append(counts, ChipCount{Value: denomination.value, Count: n})
+			amount -= n * denomination.value
+		}
+	}
+	return counts
+}
+
+// ChipStack is a labelled amount of chips, a player's stack or the pot
+type ChipStack struct {
+	Label string
+	Chips int
+}
+
+// ChipStackComponent draws stacks as piles of chips side by side, each
+// pile as tall as its share of the largest stack and split by
+// denomination. Piles slide to new amounts a step at a time when animated.
+// When the width or height does not fit the piles the stacks are written as
+// numbers on one line.
+type ChipStackComponent struct {
+	stacks []ChipStack
+	shown  []int // Chips drawn per stack, on their way to stacks while animating
+	from   []int // Chips drawn when the animation started
+	step   int   // Steps taken since, chipSteps once settled
+	width  int
+	height int // Lines the piles may take, 0 for no limit
+	chips  *i18n.ChipFormatter
+
+	labelStyle lipgloss.Style
+	countStyle lipgloss.Style
+}
+
+// NewChipStackComponent creates a chip stack component
+func NewChipStackComponent(width int) *ChipStackComponent {
+	return &ChipStackComponent{
+		width: width,
+		labelStyle: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#D1D5DB")), // Light gray
+		countStyle: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")), // Medium gray
+	}
+}
+
+// SetStacks updates the stacks being drawn. Animated, the piles move to
+// the new amounts with each Step; otherwise, or when the stacks are not
+// the same ones as before, they jump there.
+func (c *ChipStackComponent) SetStacks(stacks []ChipStack, animate bool) {
+	same := len(stacks) == len(c.stacks)
+	for i := 0; same && i < len(stacks); i++ {
+		same = stacks[i].Label == c.stacks[i].Label
+	}
+	c.stacks = append([]ChipStack{}, stacks...)
+	if animate && same {
+		c.from, c.step = append([]int{}, c.shown...), 0
+		return
+	}
+	c.shown, c.step = make([]int, len(stacks)), chipSteps
+	for i, stack := range stacks {
+		c.shown[i] = stack.Chips
+	}
+}
+
+// Step moves every pile a step closer to its amount, and returns whether
+// any still has some way to go
+func (c *ChipStackComponent) Step() bool {
+	if c.step >= chipSteps {
+		return false
+	}
+	c.step++
+	for i, stack := range c.stacks {
+		c.shown[i] = c.from[i] + (stack.Chips-c.from[i])*c.step/chipSteps
+	}
+	return c.Animating()
+}
+
+// Animating tells whether a pile is still on its way to its amount
+func (c *ChipStackComponent) Animating() bool {
+	for i, stack := range c.stacks {
+		if c.shown[i] != stack.Chips {
+			return true
+		}
+	}
+	return false
+}
+
+// SetWidth updates the width the piles have to fit in
+func (c *ChipStackComponent) SetWidth(width int) {
+	c.width = width
+}
+
+// SetChipFormatter sets how the amounts under the piles are written
+func (c *ChipStackComponent) SetChipFormatter(chips *i18n.ChipFormatter) {
+	c.chips = chips
+}
+
+// SetHeight limits the lines the piles may take; 0 lifts the limit
+func (c *ChipStackComponent) SetHeight(height int) {
+	c.height = height
+}
+
+// Height returns the lines the piles take when drawn in full
+func (c *ChipStackComponent) Height() int {
+	return c.rows() + 2 // Label and amount under the piles
+}
+
+// Render draws the piles side by side, or the amounts on a line when they
+// do not fit; empty when there are no stacks
+func (c *ChipStackComponent) Render() string {
+	if len(c.stacks) == 0 {
+		return ""
+	}
+	if len(c.stacks)*chipPileWidth > c.width || (c.height > 0 && c.Height() > c.height) {
+		return c.renderCompact()
+	}
+	rows := c.rows()
+	largest := 0
+	for _, chips := range c.shown {
+		largest = max(largest, chips)
+	}
+	piles := make([]string, len(c.stacks))
+	for i, stack := range c.stacks {
+		lines := c.renderPile(c.shown[i], largest, rows)
+		lines = append(lines, c.labelStyle.Render(fitLabel(stack.Label, chipPileWidth-1)), c.countStyle.Render(c.chips.Format(c.shown[i])))
+		piles[i] = lipgloss.NewStyle().Width(chipPileWidth).Render(strings.Join(lines, "\n"))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Bottom, piles...)
+}
+
+// rows returns the rows the tallest pile takes, enough to show every
+// denomination of any stack
+func (c *ChipStackComponent) rows() int {
+	rows := chipPileRows
+	for _, chips := range c.shown {
+		rows = max(rows, len(ChipBreakdown(chips)))
+	}
+	return rows
+}
+
+// renderPile draws the chips bottom up, largest value at the bottom, as
+// many rows as the stack's share of the largest one. Each value gets rows
+// by its share of the stack, at least one, labelled with its count.
+func (c *ChipStackComponent) renderPile(chips, largest, rows int) []string {
+	breakdown := ChipBreakdown(chips)
+	height := 0
+	if largest > 0 && chips > 0 {
+		height = max(int(math.Ceil(float64(chips)/float64(largest)*float64(rows))), len(breakdown))
+	}
+	groups := make([]int, len(breakdown))
+	used := 0
+	for i, count := range breakdown {
+		groups[i] = max(int(math.Round(float64(count.Value*count.Count)/float64(chips)*float64(height))), 1)
+		used += groups[i]
+	}
+	// Even out the rounding on the largest groups
+	for used != height {
+		biggest := 0
+		for i := range groups {
+			if groups[i] > groups[biggest] {
+				biggest = i
+			}
+		}
+		if used > height {
+			groups[biggest]--
+			used--
+		} else {
+			groups[biggest]++
+			used++
+		}
+	}
+
+	lines := make([]string, 0, rows)
+	for i := len(breakdown) - 1; i >= 0; i-- {
+		color := chipColor(breakdown[i].Value)
+		for row := 0; row < groups[i]; row++ {
+			line := lipgloss.NewStyle().Foreground(color).Render("▄▄▄▄")
+			if row == 0 {
+				line += c.countStyle.Render(fmt.Sprintf(" %s×%d", chipValue(breakdown[i].Value), breakdown[i].Count))
+			}
+			lines = append(lines, line)
+		}
+	}
+	for len(lines) < rows {
+		lines = append([]string{""}, lines...)
+	}
+	return lines
+}
+
+// renderCompact writes the stacks as numbers on one line
+func (c *ChipStackComponent) renderCompact() string {
+	parts := make([]string, len(c.stacks))
+	for i, stack := range c.stacks {
+		parts[i] = stack.Label + " " + c.chips.Format(stack.Chips)
+	}
+	return c.countStyle.Render(strings.Join(parts, " · "))
+}
+
+// chipColor returns the color of the chip of a value
+func chipColor(value int) lipgloss.Color {
+	for _, denomination := range chipDenominations {
+		if denomination.value == value {
+			return denomination.color
+		}
+	}
+	return ""
+}
+
+// chipValue writes a chip's value short, e.g. 1k
+func chipValue(value int) string {
+	if value >= 1000 && value%1000 == 0 {
+		return fmt.Sprintf("%dk", value/1000)
+	}
+	return fmt.Sprint(value)
+}
+
+// fitLabel cuts a label to the width, ending it with … when it is cut
+func fitLabel(label string, width int) string {
+	if lipgloss.Width(label) <= width {
+		return label
+	}
+	runes := []rune(label)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
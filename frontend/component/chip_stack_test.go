@@ -0,0 +1,82 @@
+package component
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestChipBreakdown(t *testing.T) {
+	expected := []ChipCount{{Value: 1000, Count: 2}, {Value: 100, Count: 4}, {Value: 25, Count: 3}, {Value: 5, Count: 1}, {Value: 1, Count: 2}}
+	if breakdown := ChipBreakdown(2482); !reflect.DeepEqual(breakdown, expected) {
+		t.Errorf("Expected the fewest chips making 2482, got %+v", breakdown)
+	}
+	if breakdown := ChipBreakdown(0); len(breakdown) != 0 {
+		t.Errorf("Expected no chips for nothing, got %+v", breakdown)
+	}
+}
+
+func TestChipStacksDrawPilesBySize(t *testing.T) {
+	piles := NewChipStackComponent(80)
+	piles.SetStacks([]ChipStack{{Label: "Hero", Chips: 1000}, {Label: "Villain", Chips: 500}, {Label: "Pot", Chips: 0}}, false)
+	rendered := piles.Render()
+	lines := strings.Split(rendered, "\n")
+	if len(lines) != piles.Height() {
+		t.Fatalf("Expected %d lines, got\n%s", piles.Height(), rendered)
+	}
+	hero, villain := 0, 0
+	for _, line := range lines {
+		if strings.HasPrefix(line, "▄") {
+			hero++
+		}
+		if strings.Contains(line[min(len(line), len("▄▄▄▄")*2):], "▄") {
+			villain++
+		}
+	}
+	if hero != chipPileRows || villain != chipPileRows/2 {
+		t.Errorf("Expected the half stack half as tall, got %d and %d rows:\n%s", hero, villain, rendered)
+	}
+	for _, want := range []string{"1k×1", "500×1", "Villain", "Pot"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("Expected %q in the piles, got\n%s", want, rendered)
+		}
+	}
+
+	// Too narrow or too short, the amounts are written out
+	for _, setup := range []func(){
+		func() { piles.SetWidth(30) },
+		func() { piles.SetWidth(80); piles.SetHeight(4) },
+	} {
+		setup()
+		if compact := piles.Render(); compact != "Hero 1000 · Villain 500 · Pot 0" {
+			t.Errorf("Expected the stacks as numbers, got\n%s", compact)
+		}
+	}
+}
+
+func TestChipStacksAnimateToNewAmounts(t *testing.T) {
+	piles := NewChipStackComponent(80)
+	piles.SetStacks([]ChipStack{{Label: "Hero", Chips: 1000}, {Label: "Pot", Chips: 0}}, true)
+	if piles.Animating() {
+		t.Fatal("Expected new stacks drawn straight away")
+	}
+
+	// Hero bets 300
+	piles.SetStacks([]ChipStack{{Label: "Hero", Chips: 700}, {Label: "Pot", Chips: 300}}, true)
+	if !piles.Step() || piles.shown[0] != 925 || piles.shown[1] != 75 {
+		t.Fatalf("Expected a quarter of the bet moved, got %v", piles.shown)
+	}
+	steps := 1
+	for piles.Animating() {
+		piles.Step()
+		steps++
+	}
+	if piles.shown[0] != 700 || piles.shown[1] != 300 || piles.Animating() || steps != chipSteps {
+		t.Errorf("Expected the piles settled in %d steps, got %v after %d", chipSteps, piles.shown, steps)
+	}
+
+	piles.SetStacks([]ChipStack{{Label: "Hero", Chips: 1000}, {Label: "Pot", Chips: 0}}, false)
+	if piles.Animating() {
+		t.Error("Expected the piles to jump when not animated")
+	}
+}
@@ -0,0 +1,105 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/analysis"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// equityGraphColumn is the width of one street in the graph
+const equityGraphColumn = 10
+
+// sparkLevels are the bar heights from 0% to 100% equity
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// EquityGraphComponent renders a player's equity street by street as a
+// sparkline, marking the streets where it swung the most
+type EquityGraphComponent struct {
+	streets []analysis.StreetEquity
+	plain   bool
+
+	upStyle   lipgloss.Style
+	downStyle lipgloss.Style
+}
+
+// NewEquityGraphComponent creates an equity graph component
+func NewEquityGraphComponent() *EquityGraphComponent {
+	return &EquityGraphComponent{
+		upStyle:   lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#10B981")), // Green
+		downStyle: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#EF4444")), // Red
+	}
+}
+
+// SetStreets updates the equities being graphed
+func (g *EquityGraphComponent) SetStreets(streets []analysis.StreetEquity) {
+	g.streets = streets
+}
+
+// SetPlain writes the graph as a sentence for screen readers
+func (g *EquityGraphComponent) SetPlain(plain bool) {
+	g.plain = plain
+}
+
+// Render renders the graph, empty when there is nothing to show
+func (g *EquityGraphComponent) Render() string {
+	if len(g.streets) == 0 {
+		return ""
+	}
+	if g.plain {
+		return g.renderPlain()
+	}
+	var percents, bars, labels strings.Builder
+	for _, street := range g.streets {
+		percent := fmt.Sprintf("%.0f%%", street.Equity*100)
+		bar := strings.Repeat(string(sparkLevel(street.Equity)), 3)
+		if street.IsBigSwing() {
+			style, arrow := g.upStyle, "▲"
+			if street.Swing < 0 {
+				style, arrow = g.downStyle, "▼"
+			}
+			percent = style.Render(percent + arrow)
+			bar = style.Render(bar)
+		}
+		percents.WriteString(column(percent))
+		bars.WriteString(column(bar))
+		labels.WriteString(column(holdem.PhaseToString(street.Phase)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, "Equity by street", percents.String(), bars.String(), labels.String())
+}
+
+// renderPlain describes the equities in words, e.g. "flop 31%, down 52 points"
+func (g *EquityGraphComponent) renderPlain() string {
+	parts := make([]string, 0, len(g.streets))
+	for _, street := range g.streets {
+		part := fmt.Sprintf("%s %.0f%%", holdem.PhaseToString(street.Phase), street.Equity*100)
+		if street.IsBigSwing() {
+			direction := "up"
+			if street.Swing < 0 {
+				direction = "down"
+			}
+			part += fmt.Sprintf(", big swing %s %.0f points", direction, abs(street.Swing)*100)
+		}
+		parts = append(parts, part)
+	}
+	return "Equity by street: " + strings.Join(parts, "; ")
+}
+
+// column centers text in a graph column
+func column(text string) string {
+	return lipgloss.PlaceHorizontal(equityGraphColumn, lipgloss.Center, text)
+}
+
+func sparkLevel(equity float64) rune {
+	level := int(equity*float64(len(sparkLevels)-1) + 0.5)
+	return sparkLevels[max(0, min(level, len(sparkLevels)-1))]
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
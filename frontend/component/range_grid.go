@@ -0,0 +1,137 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+// Colors the grid blends between for weights 0 and 1
+var (
+	gridEmpty = [3]int{0x1F, 0x29, 0x37} // Dark gray
+	gridFull  = [3]int{0x7C, 0x3A, 0xED} // Purple
+	gridCall  = [3]int{0x05, 0x96, 0x69} // Green
+)
+
+// RangeGridComponent renders a range as the 13x13 starting hand matrix,
+// shading each cell by its weight, with a movable cursor. An optional
+// calling range is shaded green where the main range does not cover it.
+type RangeGridComponent struct {
+	rng      *ranges.Range
+	calls    *ranges.Range // Nil unless a calling range is shown
+	row, col int
+	width    int
+
+	cursorStyle lipgloss.Style
+	infoStyle   lipgloss.Style
+}
+
+// NewRangeGridComponent creates an empty range grid
+func NewRangeGridComponent(width int) *RangeGridComponent {
+	return &RangeGridComponent{
+		rng:   ranges.NewRange(),
+		width: width,
+		cursorStyle: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#111827")). // Near black
+			Background(lipgloss.Color("#F59E0B")), // Yellow/Orange
+		infoStyle: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#D1D5DB")), // Light gray
+	}
+}
+
+// SetRange updates the range being shown
+func (g *RangeGridComponent) SetRange(r *ranges.Range) {
+	if r == nil {
+		r = ranges.NewRange()
+	}
+	g.rng = r
+}
+
+// SetCallRange shows a calling range alongside the main range, nil hides it
+func (g *RangeGridComponent) SetCallRange(r *ranges.Range) {
+	g.calls = r
+}
+
+// GetRange returns the range being shown
+func (g *RangeGridComponent) GetRange() *ranges.Range {
+	return g.rng
+}
+
+// SetWidth updates the grid width
+func (g *RangeGridComponent) SetWidth(width int) {
+	g.width = width
+}
+
+// Move shifts the cursor, wrapping around the edges of the grid
+func (g *RangeGridComponent) Move(rows, cols int) {
+	g.row = (g.row + rows + len(ranges.Ranks)) % len(ranges.Ranks)
+	g.col = (g.col + cols + len(ranges.Ranks)) % len(ranges.Ranks)
+}
+
+// GetSelected returns the hand class under the cursor
+func (g *RangeGridComponent) GetSelected() string {
+	return ranges.GridHand(g.row, g.col)
+}
+
+// Render renders the grid followed by the selected hand and range totals
+func (g *RangeGridComponent) Render() string {
+	lines := make([]string, 0, len(ranges.Ranks)+3)
+	for row := range ranges.Ranks {
+		cells := make([]string, 0, len(ranges.Ranks))
+		for col := range ranges.Ranks {
+			hand := ranges.GridHand(row, col)
+			label := fmt.Sprintf(" %-3s", hand)
+			if row == g.row && col == g.col {
+				cells = append(cells, g.cursorStyle.Render(label))
+				continue
+			}
+			style := cellStyle(g.rng.Weight(hand), gridFull)
+			if g.rng.Weight(hand) == 0 && g.calls != nil {
+				style = cellStyle(g.calls.Weight(hand), gridCall)
+			}
+			cells = append(cells, style.Render(label))
+		}
+		lines = append(lines, strings.Join(cells, ""))
+	}
+
+	selected := g.GetSelected()
+	weight := g.rng.Weight(selected)
+	if g.calls != nil {
+		call := g.calls.Weight(selected)
+		lines = append(lines, "",
+			g.infoStyle.Render(fmt.Sprintf("%s · raise %.0f%% · call %.0f%% · fold %.0f%%",
+				selected, weight*100, call*100, max(0, 1-weight-call)*100)),
+			g.infoStyle.Render(fmt.Sprintf("Raise: %.1f%% of hands · Call: %.1f%% of hands",
+				g.rng.Fraction()*100, g.calls.Fraction()*100)),
+		)
+	} else {
+		lines = append(lines, "",
+			g.infoStyle.Render(fmt.Sprintf("%s · weight %.0f%% · %.1f of %d combos",
+				selected, weight*100, weight*float64(ranges.ComboCount(selected)), ranges.ComboCount(selected))),
+			g.infoStyle.Render(fmt.Sprintf("Range: %.1f combos (%.1f%% of hands)", g.rng.Combos(), g.rng.Fraction()*100)),
+		)
+	}
+
+	return lipgloss.NewStyle().
+		Width(g.width).
+		Align(lipgloss.Center).
+		Render(strings.Join(lines, "\n"))
+}
+
+// cellStyle shades a cell between the empty and full colors by weight
+func cellStyle(weight float64, full [3]int) lipgloss.Style {
+	var rgb [3]int
+	for i := range rgb {
+		rgb[i] = gridEmpty[i] + int(float64(full[i]-gridEmpty[i])*weight)
+	}
+	foreground := "#6B7280" // Gray
+	if weight > 0 {
+		foreground = "#F3F4F6" // White
+	}
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color(foreground)).
+		Background(lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", rgb[0], rgb[1], rgb[2])))
+}
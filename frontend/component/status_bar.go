@@ -0,0 +1,144 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/i18n"
+)
+
+// StatusBarComponent renders a one-line summary of the session. It only
+// formats what its setters were last given, so the values change when the
+// game or the clock reports something new, not on every render.
+type StatusBarComponent struct {
+	width int
+	plain bool // Words instead of symbols
+
+	started time.Time
+	elapsed string // Session duration, e.g. "1:05:12"
+	clock   string // Time of day, e.g. "14:05"
+	hands   int
+	stack   int
+	trend   int  // Sign of the last hand's result
+	level   int  // Tournament blind level, 0 outside tournaments
+	masked  bool // Stack and trend hidden, for streaming
+	chips   *i18n.ChipFormatter
+
+	style lipgloss.Style
+}
+
+// NewStatusBarComponent creates a status bar for a session starting now
+func NewStatusBarComponent(width int) *StatusBarComponent {
+	bar := &StatusBarComponent{
+		width: width,
+		style: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#D1D5DB")). // Light gray
+			Background(lipgloss.Color("#374151")), // Dark gray
+	}
+	bar.Start(time.Now())
+	return bar
+}
+
+// SetWidth updates the status bar width
+func (s *StatusBarComponent) SetWidth(width int) {
+	s.width = width
+}
+
+// SetPlain switches to words for screen readers
+func (s *StatusBarComponent) SetPlain(plain bool) {
+	s.plain = plain
+}
+
+// Start begins a new session at now, with no hands played
+func (s *StatusBarComponent) Start(now time.Time) {
+	s.started = now
+	s.hands, s.stack, s.trend, s.level = 0, 0, 0, 0
+	s.SetClock(now)
+}
+
+// SetClock moves the clock and the session duration on to now
+func (s *StatusBarComponent) SetClock(now time.Time) {
+	elapsed := max(now.Sub(s.started), 0).Round(time.Second)
+	s.elapsed = fmt.Sprintf("%d:%02d:%02d", int(elapsed.Hours()), int(elapsed.Minutes())%60, int(elapsed.Seconds())%60)
+	s.clock = now.Format("15:04")
+}
+
+// SetHands sets how many hands were played
+func (s *StatusBarComponent) SetHands(hands int) {
+	s.hands = hands
+}
+
+// SetStack sets the hero's stack and the chips won or lost in the last hand
+func (s *StatusBarComponent) SetStack(stack, lastNet int) {
+	s.stack = stack
+	s.trend = 0
+	switch {
+	case lastNet > 0:
+		s.trend = 1
+	case lastNet < 0:
+		s.trend = -1
+	}
+}
+
+// SetChipFormatter sets how the stack is written
+func (s *StatusBarComponent) SetChipFormatter(chips *i18n.ChipFormatter) {
+	s.chips = chips
+}
+
+// SetMasked hides the stack and how the last hand went, for streaming
+func (s *StatusBarComponent) SetMasked(masked bool) {
+	s.masked = masked
+}
+
+// SetLevel sets the tournament blind level, 0 to hide it
+func (s *StatusBarComponent) SetLevel(level int) {
+	s.level = level
+}
+
+// Render renders the status bar across the full width
+func (s *StatusBarComponent) Render() string {
+	if s.plain {
+		return s.renderPlain()
+	}
+	trend := map[int]string{1: "▲", 0: "▶", -1: "▼"}[s.trend]
+	stack := fmt.Sprintf("Stack %s %s", s.chips.Format(s.stack), trend)
+	if s.masked {
+		stack = "Stack •••"
+	}
+	parts := []string{
+		"⏱ " + s.elapsed,
+		s.handCount(),
+		stack,
+	}
+	if s.level > 0 {
+		parts = append(parts, fmt.Sprintf("Level %d", s.level))
+	}
+	parts = append(parts, "🕐 "+s.clock)
+	return s.style.
+		Width(s.width).
+		Align(lipgloss.Center).
+		Render(strings.Join(parts, " · "))
+}
+
+// renderPlain renders the status bar as a sentence
+func (s *StatusBarComponent) renderPlain() string {
+	trend := map[int]string{1: "up", 0: "even", -1: "down"}[s.trend]
+	text := fmt.Sprintf("Session %s, %s, stack %s %s", s.elapsed, s.handCount(), s.chips.Format(s.stack), trend)
+	if s.masked {
+		text = fmt.Sprintf("Session %s, %s, stack hidden", s.elapsed, s.handCount())
+	}
+	if s.level > 0 {
+		text += fmt.Sprintf(", level %d", s.level)
+	}
+	return lipgloss.NewStyle().Width(s.width).Render(text + ", time " + s.clock)
+}
+
+// handCount renders the hands played, e.g. "1 hand" or "12 hands"
+func (s *StatusBarComponent) handCount() string {
+	if s.hands == 1 {
+		return "1 hand"
+	}
+	return fmt.Sprintf("%d hands", s.hands)
+}
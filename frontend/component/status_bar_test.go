@@ -0,0 +1,52 @@
+package component
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/i18n"
+)
+
+func TestStatusBarFollowsSetters(t *testing.T) {
+	start := time.Date(2026, time.March, 1, 21, 0, 0, 0, time.UTC)
+	bar := NewStatusBarComponent(80)
+	bar.Start(start)
+	bar.SetHands(1)
+	bar.SetStack(1200, 200)
+	bar.SetLevel(3)
+	bar.SetClock(start.Add(65*time.Minute + 7*time.Second))
+
+	got := bar.Render()
+	for _, want := range []string{"1:05:07", "1 hand ", "Stack 1200 ▲", "Level 3", "22:05"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in the status bar, got %q", want, got)
+		}
+	}
+
+	bar.SetStack(900, -300)
+	bar.SetLevel(0)
+	bar.SetPlain(true)
+	got = bar.Render()
+	if !strings.Contains(got, "stack 900 down") || strings.Contains(got, "level") {
+		t.Errorf("Expected a falling stack and no level in words, got %q", got)
+	}
+
+	bar.SetMasked(true)
+	if got = bar.Render(); strings.Contains(got, "900") || !strings.Contains(got, "stack hidden") {
+		t.Errorf("Expected the stack hidden in streamer mode, got %q", got)
+	}
+	bar.SetPlain(false)
+	if got = bar.Render(); strings.Contains(got, "900") || !strings.Contains(got, "Stack •••") {
+		t.Errorf("Expected the stack masked in streamer mode, got %q", got)
+	}
+}
+
+func TestStatusBarFormatsStackForLocale(t *testing.T) {
+	bar := NewStatusBarComponent(80)
+	bar.SetStack(12500, 0)
+	bar.SetChipFormatter(i18n.NewChipFormatter(i18n.New(i18n.Locale("es")), "", "€"))
+	if got := bar.Render(); !strings.Contains(got, "Stack 12.500 €") {
+		t.Errorf("Expected the stack in euros with Spanish grouping, got %q", got)
+	}
+}
@@ -0,0 +1,304 @@
+package component
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/i18n"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// TableComponent renders a read-only snapshot of a poker table
+type TableComponent struct {
+	view  holdem.TableView
+	width int
+	cards CardRenderer
+	plain bool                // Text badges instead of glyphs and colors
+	chips *i18n.ChipFormatter // How amounts are written, plain digits when nil
+
+	avatars map[int]Avatar // By player ID, nil to show names only
+	covered map[int]bool   // Players whose hole cards are drawn face down
+	flashed int            // Player whose seat is lit up, 0 for none
+	winner  int            // Player whose winning hand is highlighted, 0 for none
+	winning *holdem.HandResult
+	equity  map[int]float64 // Chance to win the pot by player ID, shown next to the cards
+	timed   int             // Player whose time to act is shown under their seat, 0 for none
+	left    time.Duration
+	total   time.Duration
+
+	boardStyle  lipgloss.Style
+	seatStyle   lipgloss.Style
+	foldedStyle lipgloss.Style
+	flashStyle  lipgloss.Style
+	winStyle    lipgloss.Style
+	deadStyle   lipgloss.Style
+}
+
+// NewTableComponent creates a table component with consistent styling
+func NewTableComponent(width int) *TableComponent {
+	return &TableComponent{
+		width: width,
+		cards: GlyphCards{},
+		boardStyle: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#E5E7EB")), // Light gray
+		seatStyle: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#D1D5DB")), // Light gray
+		foldedStyle: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6B7280")), // Gray
+		flashStyle: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#111827")). // Near black
+			Background(lipgloss.Color("#FBBF24")), // Amber
+		winStyle: lipgloss.NewStyle().
+			Bold(true).
+			Underline(true).
+			Foreground(lipgloss.Color("#FBBF24")), // Amber
+		deadStyle: lipgloss.NewStyle().
+			Faint(true).
+			Foreground(lipgloss.Color("#6B7280")), // Gray
+	}
+}
+
+// SetView updates the snapshot being rendered
+func (t *TableComponent) SetView(view holdem.TableView) {
+	t.view = view
+}
+
+// View returns the snapshot being rendered
+func (t *TableComponent) View() holdem.TableView {
+	return t.view
+}
+
+// SetWidth updates the table width
+func (t *TableComponent) SetWidth(width int) {
+	t.width = width
+}
+
+// SetCardRenderer sets how cards are drawn
+func (t *TableComponent) SetCardRenderer(cards CardRenderer) {
+	t.cards = cards
+}
+
+// SetChipFormatter sets how stacks, bets and the pot are written
+func (t *TableComponent) SetChipFormatter(chips *i18n.ChipFormatter) {
+	t.chips = chips
+}
+
+// SetAvatars sets the avatars shown next to the players' names, by player
+// ID. Players missing from avatars get their DefaultAvatar; nil shows names
+// without avatars.
+func (t *TableComponent) SetAvatars(avatars map[int]Avatar) {
+	t.avatars = avatars
+}
+
+// SetCovered draws the hole cards of the given players face down, even
+// when the view shows them; no players uncovers every seat
+func (t *TableComponent) SetCovered(playerIDs ...int) {
+	t.covered = map[int]bool{}
+	for _, id := range playerIDs {
+		t.covered[id] = true
+	}
+}
+
+// SetFlash lights up a player's seat, e.g. to call their attention to
+// their turn; 0 lights up none
+func (t *TableComponent) SetFlash(playerID int) {
+	t.flashed = playerID
+}
+
+// SetWinningHand highlights the five cards of the player's winning hand on
+// the board and in their hole cards and dims the cards that did not play;
+// a nil hand clears the highlight
+func (t *TableComponent) SetWinningHand(playerID int, hand *holdem.HandResult) {
+	t.winner, t.winning = playerID, hand
+	if hand == nil {
+		t.winner = 0
+	}
+}
+
+// SetEquities shows each player's chance to win the pot next to their hole
+// cards, e.g. while an all-in hand is run out; nil shows none
+func (t *TableComponent) SetEquities(equities map[int]float64) {
+	t.equity = equities
+}
+
+// SetTimer shows how long the player has left to act out of total as a bar
+// that empties under their seat, while it is their turn; a zero total hides
+// it
+func (t *TableComponent) SetTimer(playerID int, left, total time.Duration) {
+	t.timed, t.left, t.total = playerID, max(left, 0), total
+	if total <= 0 {
+		t.timed = 0
+	}
+}
+
+// SetPlain switches to plain text for screen readers: seat markers become
+// words and nothing relies on color
+func (t *TableComponent) SetPlain(plain bool) {
+	t.plain = plain
+}
+
+// Render renders the board followed by one line per seat
+func (t *TableComponent) Render() string {
+	if t.plain {
+		return t.renderPlain()
+	}
+	title := fmt.Sprintf("Hand #%d · %s", t.view.HandNumber, holdem.PhaseToString(t.view.Phase))
+	if t.view.Pot > 0 {
+		title += " · Pot " + t.chips.Format(t.view.Pot)
+	}
+	board := t.boardStyle.Render("Board: " + RenderCards(t.cards, t.view.Board))
+	if t.winning != nil && len(t.view.Board) > 0 {
+		board = t.boardStyle.Render("Board: ") + t.emphasize(t.view.Board, true)
+	}
+	lines := []string{title, board, ""}
+
+	for _, seat := range t.view.Seats {
+		cards := t.seatCards(seat)
+		// ▶ marks the seat to act, D the dealer button
+		marker, button := "  ", " "
+		if seat.Seat == t.view.ActingSeat {
+			marker = "▶ "
+		}
+		if seat.Seat == t.view.Button {
+			button = "D"
+		}
+		prefix := fmt.Sprintf("%s%s Seat %d  ", marker, button, seat.Seat+1)
+		suffix := fmt.Sprintf(" %6s chips  bet %-5s %s", t.chips.Format(seat.Chips), t.chips.Format(seat.Bet), cards)
+		if equity, ok := t.equity[seat.PlayerID]; ok && !seat.Folded {
+			suffix += fmt.Sprintf("  %5.1f%%", equity*100)
+		}
+		name, color := t.seatName(seat)
+		switch {
+		case seat.Folded:
+			lines = append(lines, t.foldedStyle.Render(prefix+name+suffix+"  (folded)"))
+		case t.flashed != 0 && seat.PlayerID == t.flashed:
+			lines = append(lines, t.flashStyle.Render(prefix+name+suffix))
+		default:
+			nameStyle := t.seatStyle
+			if color != "" {
+				nameStyle = nameStyle.Foreground(color)
+			}
+			lines = append(lines, t.seatStyle.Render(prefix)+nameStyle.Render(name)+t.seatStyle.Render(suffix))
+		}
+		if t.timing(seat) {
+			lines = append(lines, t.renderTimer(lipgloss.Width(prefix+name+suffix)))
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Width(t.width).
+		Align(lipgloss.Center).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderPlain renders the table as plain sentences, with the seat to act,
+// the button and folded players marked in words
+func (t *TableComponent) renderPlain() string {
+	title := fmt.Sprintf("Hand %d, %s", t.view.HandNumber, holdem.PhaseToString(t.view.Phase))
+	if t.view.Pot > 0 {
+		title += ", pot " + t.chips.Format(t.view.Pot)
+	}
+	lines := []string{title, "Board: " + RenderCards(t.cards, t.view.Board), ""}
+	if t.winning != nil {
+		lines[2] = "Winning hand: " + RenderCards(t.cards, t.winning.Cards)
+		lines = append(lines, "")
+	}
+	for _, seat := range t.view.Seats {
+		line := fmt.Sprintf("Seat %d, %s, %s chips, bet %s, %s", seat.Seat+1, seat.Name, t.chips.Format(seat.Chips), t.chips.Format(seat.Bet), t.seatCards(seat))
+		if seat.Seat == t.view.Button {
+			line += " [dealer]"
+		}
+		if seat.Seat == t.view.ActingSeat {
+			line += " [to act]"
+		}
+		if t.timing(seat) {
+			line += fmt.Sprintf(" [%d seconds left]", seconds(t.left))
+		}
+		if seat.Folded {
+			line += " [folded]"
+		} else if equity, ok := t.equity[seat.PlayerID]; ok {
+			line += fmt.Sprintf(", %.1f%% to win", equity*100)
+		}
+		lines = append(lines, line)
+	}
+	return lipgloss.NewStyle().Width(t.width).Render(strings.Join(lines, "\n"))
+}
+
+// timing tells whether the seat's time to act is shown
+func (t *TableComponent) timing(seat holdem.SeatView) bool {
+	return t.timed != 0 && seat.PlayerID == t.timed && seat.Seat == t.view.ActingSeat && !seat.Folded
+}
+
+// renderTimer draws the time left to act as a bar that empties, turning
+// from green to amber to red, as wide as the seat line above it
+func (t *TableComponent) renderTimer(width int) string {
+	label := fmt.Sprintf(" %3ds", seconds(t.left))
+	cells := max(width-lipgloss.Width("    ⏱ ")-len(label), 10)
+	share := min(float64(t.left)/float64(t.total), 1)
+	filled := int(math.Ceil(share * float64(cells)))
+	color := lipgloss.Color("#10B981") // Green
+	switch {
+	case share <= 0.2:
+		color = lipgloss.Color("#EF4444") // Red
+	case share <= 0.5:
+		color = lipgloss.Color("#F59E0B") // Yellow/Orange
+	}
+	return t.seatStyle.Render("    ⏱ ") +
+		lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		t.foldedStyle.Render(strings.Repeat("░", cells-filled)+label)
+}
+
+// seconds rounds a time left up to whole seconds, so 0 means it ran out
+func seconds(left time.Duration) int {
+	return int(math.Ceil(left.Seconds()))
+}
+
+// seatName renders a seat's name padded to its column, behind the avatar
+// when there are avatars, and returns the color to draw it in
+func (t *TableComponent) seatName(seat holdem.SeatView) (string, lipgloss.Color) {
+	name := fmt.Sprintf("%-15s", seat.Name)
+	if t.avatars == nil {
+		return name, ""
+	}
+	avatar, ok := t.avatars[seat.PlayerID]
+	if !ok {
+		avatar = DefaultAvatar(seat.PlayerID)
+	}
+	icon := avatar.Icon(seat.Name)
+	icon += strings.Repeat(" ", max(3-lipgloss.Width(icon), 0))
+	return icon + " " + name, avatar.Color
+}
+
+// seatCards renders a seat's hole cards, or backs for cards it keeps hidden
+func (t *TableComponent) seatCards(seat holdem.SeatView) string {
+	if seat.CardsHidden || (t.covered[seat.PlayerID] && len(seat.HoleCards) > 0) {
+		return RenderBacks(t.cards, t.view.Variant.Rules().HoleCards())
+	}
+	if t.winning != nil && seat.PlayerID == t.winner && len(seat.HoleCards) > 0 && !t.plain {
+		return t.emphasize(seat.HoleCards, false)
+	}
+	return RenderCards(t.cards, seat.HoleCards)
+}
+
+// emphasize renders board or hole cards with the ones that play in the
+// winning hand highlighted and the rest dimmed
+func (t *TableComponent) emphasize(cards []*poker.Card, board bool) string {
+	parts := make([]string, 0, len(cards))
+	for _, card := range cards {
+		style := t.deadStyle
+		for i, played := range t.winning.Cards {
+			if card != nil && played != nil && *played == *card && t.winning.Sources.FromBoard(i) == board {
+				style = t.winStyle
+				break
+			}
+		}
+		parts = append(parts, style.Render(t.cards.Card(card)))
+	}
+	return strings.Join(parts, t.cards.Separator())
+}
@@ -0,0 +1,122 @@
+package component
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestTableHighlightsWinningHand(t *testing.T) {
+	cards := func(codes string) poker.Cards {
+		parsed, err := poker.ParseCards(codes)
+		if err != nil {
+			t.Fatalf("ParseCards failed: %v", err)
+		}
+		return parsed
+	}
+	board := cards("QhJhTh2c3d")
+	view := holdem.TableView{Button: 0, ActingSeat: -1, Phase: holdem.PhaseShowdown, Board: board, Seats: []holdem.SeatView{
+		{Seat: 0, PlayerID: 1, Name: "Hero", HoleCards: cards("AhKh")},
+		{Seat: 1, PlayerID: 2, Name: "Villain", HoleCards: cards("2d2s")},
+	}}
+	hand := holdem.NewHandEvaluator().EvaluateHand(view.Seats[0].HoleCards, board)
+
+	table := NewTableComponent(120)
+	table.SetCardRenderer(TextCards{})
+	table.SetView(view)
+	// Mark the styles in words, as tests render without colors
+	table.winStyle = lipgloss.NewStyle().Transform(func(s string) string { return "<" + s + ">" })
+	table.deadStyle = lipgloss.NewStyle().Transform(func(s string) string { return "~" + s + "~" })
+	table.SetWinningHand(1, hand)
+	rendered := table.Render()
+
+	for _, want := range []string{
+		"<Queen of hearts>, <Jack of hearts>, <Ten of hearts>, ~Two of clubs~, ~Three of diamonds~",
+		"<Ace of hearts>, <King of hearts>",
+		"Two of diamonds, Two of spades",
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("Expected %q in the table, got\n%s", want, rendered)
+		}
+	}
+
+	table.SetPlain(true)
+	if plain := table.Render(); !strings.Contains(plain, "Winning hand: ") || strings.Contains(plain, "<") {
+		t.Errorf("Expected the winning hand in words for screen readers, got\n%s", plain)
+	}
+
+	table.SetPlain(false)
+	table.SetWinningHand(0, nil)
+	if rendered := table.Render(); strings.Contains(rendered, "<") || strings.Contains(rendered, "~") {
+		t.Errorf("Expected no highlight once cleared, got\n%s", rendered)
+	}
+}
+
+func TestTableShowsEquities(t *testing.T) {
+	hole, _ := poker.ParseCards("AhAs KhKs")
+	view := holdem.TableView{Button: 0, ActingSeat: -1, Runout: true, Seats: []holdem.SeatView{
+		{Seat: 0, PlayerID: 1, Name: "Hero", HoleCards: hole[:2]},
+		{Seat: 1, PlayerID: 2, Name: "Villain", HoleCards: hole[2:]},
+		{Seat: 2, PlayerID: 3, Name: "Folder", Folded: true, CardsHidden: true},
+	}}
+	table := NewTableComponent(120)
+	table.SetView(view)
+	table.SetEquities(map[int]float64{1: 0.8163, 2: 0.1837, 3: 0})
+
+	rendered := table.Render()
+	if !strings.Contains(rendered, " 81.6%") || !strings.Contains(rendered, " 18.4%") || strings.Contains(rendered, "0.0%") {
+		t.Errorf("Expected the equities of the hands still in, got\n%s", rendered)
+	}
+	table.SetPlain(true)
+	if plain := table.Render(); !strings.Contains(plain, "81.6% to win") {
+		t.Errorf("Expected the equities in words, got\n%s", plain)
+	}
+	table.SetEquities(nil)
+	if rendered := table.Render(); strings.Contains(rendered, "%") {
+		t.Errorf("Expected no equities once cleared, got\n%s", rendered)
+	}
+}
+
+func TestTableShowsTimerUnderActingSeat(t *testing.T) {
+	view := holdem.TableView{Button: 0, ActingSeat: 1, Seats: []holdem.SeatView{
+		{Seat: 0, PlayerID: 1, Name: "Hero", CardsHidden: true},
+		{Seat: 1, PlayerID: 2, Name: "Villain", CardsHidden: true},
+	}}
+	table := NewTableComponent(120)
+	table.SetView(view)
+	table.SetTimer(2, 15*time.Second, time.Minute)
+
+	lines := strings.Split(table.Render(), "\n")
+	timer := -1
+	for i, line := range lines {
+		if strings.Contains(line, "⏱") {
+			timer = i
+		}
+	}
+	if timer < 1 || !strings.Contains(lines[timer-1], "Villain") || !strings.Contains(lines[timer], " 15s") {
+		t.Fatalf("Expected the time left under the seat to act, got\n%s", strings.Join(lines, "\n"))
+	}
+	if bar := lines[timer]; strings.Count(bar, "█")*3 > strings.Count(bar, "░") {
+		t.Errorf("Expected a quarter of the bar left, got %q", bar)
+	}
+	table.SetPlain(true)
+	if plain := table.Render(); !strings.Contains(plain, "[to act] [15 seconds left]") {
+		t.Errorf("Expected the time left in words, got\n%s", plain)
+	}
+
+	// Once the action moves on the timer goes with it
+	table.SetPlain(false)
+	view.ActingSeat = 0
+	table.SetView(view)
+	if rendered := table.Render(); strings.Contains(rendered, "⏱") {
+		t.Errorf("Expected no timer away from the seat to act, got\n%s", rendered)
+	}
+	table.SetTimer(1, 0, 0)
+	if rendered := table.Render(); strings.Contains(rendered, "⏱") {
+		t.Errorf("Expected no timer without a clock, got\n%s", rendered)
+	}
+}
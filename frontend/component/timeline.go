@@ -0,0 +1,209 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// Timeline ticks for actions already played, the one shown and those to come
+const (
+	timelinePlayed   = "●"
+	timelineCurrent  = "◆"
+	timelineUpcoming = "·"
+)
+
+// timelineStreet is a run of actions taken on the same street
+type timelineStreet struct {
+	phase      holdem.GamePhase
+	start, end int // Actions start to end-1
+}
+
+// TimelineComponent renders every action of a reviewed hand as a tick,
+// grouped by street, marking the action shown. A click on a tick or a
+// street name can be turned back into the action to seek to with TickAt.
+type TimelineComponent struct {
+	phases  []holdem.GamePhase // Street of every action
+	current int
+	width   int
+	plain   bool
+
+	labelStyle   lipgloss.Style
+	playedStyle  lipgloss.Style
+	currentStyle lipgloss.Style
+}
+
+// NewTimelineComponent creates a timeline component
+func NewTimelineComponent(width int) *TimelineComponent {
+	return &TimelineComponent{
+		width:        width,
+		labelStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")),            // Gray
+		playedStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED")),            // Purple
+		currentStyle: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F59E0B")), // Amber
+	}
+}
+
+// SetActions sets the street every action of the hand was taken on, in order
+func (t *TimelineComponent) SetActions(phases []holdem.GamePhase) {
+	t.phases = phases
+	t.current = max(0, min(t.current, len(phases)-1))
+}
+
+// SetCurrent marks the action shown
+func (t *TimelineComponent) SetCurrent(index int) {
+	t.current = index
+}
+
+// SetWidth updates the width the timeline must fit in
+func (t *TimelineComponent) SetWidth(width int) {
+	t.width = width
+}
+
+// SetPlain writes the timeline as a sentence for screen readers
+func (t *TimelineComponent) SetPlain(plain bool) {
+	t.plain = plain
+}
+
+// streets groups the actions by street, in order
+func (t *TimelineComponent) streets() []timelineStreet {
+	streets := []timelineStreet{}
+	for i, phase := range t.phases {
+		if len(streets) == 0 || streets[len(streets)-1].phase != phase {
+			streets = append(streets, timelineStreet{phase: phase, start: i})
+		}
+		streets[len(streets)-1].end = i + 1
+	}
+	return streets
+}
+
+// NextStreet returns the first action of the street after the one of index,
+// or index on the last street
+func (t *TimelineComponent) NextStreet(index int) int {
+	for _, street := range t.streets() {
+		if street.start > index {
+			return street.start
+		}
+	}
+	return index
+}
+
+// PreviousStreet returns the first action of the street of index, or of the
+// street before when index already is the first
+func (t *TimelineComponent) PreviousStreet(index int) int {
+	previous := 0
+	for _, street := range t.streets() {
+		if street.start >= index {
+			break
+		}
+		previous = street.start
+	}
+	return previous
+}
+
+// label names a street, by its initial when the full names do not fit
+func (t *TimelineComponent) label(phase holdem.GamePhase, short bool) string {
+	name := holdem.PhaseToString(phase)
+	if short {
+		return strings.ToUpper(name[:1])
+	}
+	return name
+}
+
+// layout writes the timeline unstyled and returns the action at each of its
+// columns, -1 for the gaps between streets
+func (t *TimelineComponent) layout(short bool) (string, []int) {
+	var line strings.Builder
+	columns := []int{}
+	for i, street := range t.streets() {
+		if i > 0 {
+			line.WriteString("  ")
+			columns = append(columns, -1, -1)
+		}
+		label := t.label(street.phase, short) + " "
+		line.WriteString(label)
+		for range label {
+			columns = append(columns, street.start)
+		}
+		for action := street.start; action < street.end; action++ {
+			line.WriteString(t.tick(action))
+			columns = append(columns, action)
+		}
+	}
+	return line.String(), columns
+}
+
+// short reports whether the street names must be shortened to fit the width
+func (t *TimelineComponent) short() bool {
+	_, columns := t.layout(false)
+	return t.width > 0 && len(columns) > t.width
+}
+
+// tick returns the tick of an action, unstyled
+func (t *TimelineComponent) tick(action int) string {
+	switch {
+	case action < t.current:
+		return timelinePlayed
+	case action == t.current:
+		return timelineCurrent
+	}
+	return timelineUpcoming
+}
+
+// Render renders the timeline, empty when there are no actions
+func (t *TimelineComponent) Render() string {
+	if len(t.phases) == 0 {
+		return ""
+	}
+	if t.plain {
+		return t.renderPlain()
+	}
+	short := t.short()
+	var line strings.Builder
+	for i, street := range t.streets() {
+		if i > 0 {
+			line.WriteString("  ")
+		}
+		line.WriteString(t.labelStyle.Render(t.label(street.phase, short) + " "))
+		for action := street.start; action < street.end; action++ {
+			style := t.playedStyle
+			if action == t.current {
+				style = t.currentStyle
+			}
+			line.WriteString(style.Render(t.tick(action)))
+		}
+	}
+	return line.String()
+}
+
+// renderPlain describes the timeline in words, e.g. "Action 4 of 9, on
+// the flop. Actions by street: preflop 3, flop 4, turn 2"
+func (t *TimelineComponent) renderPlain() string {
+	parts := []string{}
+	for _, street := range t.streets() {
+		parts = append(parts, fmt.Sprintf("%s %d", holdem.PhaseToString(street.phase), street.end-street.start))
+	}
+	return fmt.Sprintf("Action %d of %d, on the %s. Actions by street: %s",
+		t.current+1, len(t.phases), holdem.PhaseToString(t.phases[t.current]), strings.Join(parts, ", "))
+}
+
+// TickAt returns the action clicked at column x of a row of the screen,
+// styling stripped: the action of a tick, or the first of a street for its
+// name. It is false when the click missed the timeline.
+func (t *TimelineComponent) TickAt(row string, x int) (int, bool) {
+	if len(t.phases) == 0 || t.plain {
+		return 0, false
+	}
+	line, columns := t.layout(t.short())
+	at := strings.Index(row, line)
+	if at < 0 {
+		return 0, false
+	}
+	column := x - ansi.StringWidth(row[:at])
+	if column < 0 || column >= len(columns) || columns[column] < 0 {
+		return 0, false
+	}
+	return columns[column], true
+}
@@ -0,0 +1,59 @@
+package component
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestTimelineStreetsAndClicks(t *testing.T) {
+	timeline := NewTimelineComponent(80)
+	timeline.SetActions([]holdem.GamePhase{
+		holdem.PhasePreflop, holdem.PhasePreflop, holdem.PhasePreflop,
+		holdem.PhaseFlop, holdem.PhaseFlop, holdem.PhaseTurn, holdem.PhaseRiver,
+	})
+	timeline.SetCurrent(4)
+	line := ansi.Strip(timeline.Render())
+	if line != "preflop ●●●  flop ●◆  turn ·  river ·" {
+		t.Errorf("Expected the ticks grouped by street, got %q", line)
+	}
+
+	if next := timeline.NextStreet(4); next != 5 {
+		t.Errorf("Expected down to jump to the turn, got %d", next)
+	}
+	if timeline.NextStreet(6) != 6 || timeline.PreviousStreet(4) != 3 || timeline.PreviousStreet(3) != 0 || timeline.PreviousStreet(0) != 0 {
+		t.Error("Expected up to go back to the start of the street, then the street before")
+	}
+
+	// The row is the screen's, so the timeline can be anywhere in it
+	row := "   " + line + "   "
+	for _, test := range []struct {
+		at   string
+		want int
+		ok   bool
+	}{{"●◆", 3, true}, {"◆", 4, true}, {"turn", 5, true}, {"river ·", 6, true}, {" flop", 0, false}} {
+		x := ansi.StringWidth(row[:strings.Index(row, test.at)])
+		if got, ok := timeline.TickAt(row, x); got != test.want || ok != test.ok {
+			t.Errorf("Clicking %q: expected %d %v, got %d %v", test.at, test.want, test.ok, got, ok)
+		}
+	}
+	if _, ok := timeline.TickAt("Action 5/7", 2); ok {
+		t.Error("Expected a click off the timeline to be ignored")
+	}
+
+	// Long hands shorten the street names to fit
+	timeline.SetWidth(20)
+	if line := ansi.Strip(timeline.Render()); !strings.HasPrefix(line, "P ●●●  F ●◆") {
+		t.Errorf("Expected initials on a narrow screen, got %q", line)
+	}
+	if got, ok := timeline.TickAt("P ●●●  F ●◆  T ·  R ·", 9); got != 3 || !ok {
+		t.Errorf("Expected the narrow timeline clickable, got %d %v", got, ok)
+	}
+
+	timeline.SetPlain(true)
+	if got := timeline.Render(); got != "Action 5 of 7, on the flop. Actions by street: preflop 3, flop 2, turn 1, river 1" {
+		t.Errorf("Expected the timeline in words, got %q", got)
+	}
+}
@@ -0,0 +1,184 @@
+package component
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/i18n"
+)
+
+// WinningsLine is a player's chips won or lost after every hand of a session
+type WinningsLine struct {
+	Name  string
+	Hands []int
+}
+
+// final returns the winnings after the last hand
+func (l WinningsLine) final() int {
+	if len(l.Hands) == 0 {
+		return 0
+	}
+	return l.Hands[len(l.Hands)-1]
+}
+
+// extremes returns the most won and lost and the hands, counted from 1,
+// they were reached on
+func (l WinningsLine) extremes() (peak, peakHand, low, lowHand int) {
+	for i, chips := range l.Hands {
+		if chips > peak {
+			peak, peakHand = chips, i+1
+		}
+		if chips < low {
+			low, lowHand = chips, i+1
+		}
+	}
+	return peak, peakHand, low, lowHand
+}
+
+// WinningsGraphComponent renders every player's winnings over a session as
+// a sparkline, biggest winner first, all on the same scale so who ran over
+// the table, and when, shows at a glance
+type WinningsGraphComponent struct {
+	lines  []WinningsLine
+	width  int
+	plain  bool
+	masked bool
+	chips  *i18n.ChipFormatter
+
+	upStyle   lipgloss.Style
+	downStyle lipgloss.Style
+}
+
+// NewWinningsGraphComponent creates a winnings graph component
+func NewWinningsGraphComponent(width int) *WinningsGraphComponent {
+	return &WinningsGraphComponent{
+		width:     width,
+		upStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")), // Green
+		downStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")), // Red
+	}
+}
+
+// SetLines updates the players being graphed
+func (g *WinningsGraphComponent) SetLines(lines []WinningsLine) {
+	g.lines = append([]WinningsLine{}, lines...)
+	sort.SliceStable(g.lines, func(i, j int) bool { return g.lines[i].final() > g.lines[j].final() })
+}
+
+// SetWidth updates the width the graph must fit in
+func (g *WinningsGraphComponent) SetWidth(width int) {
+	g.width = width
+}
+
+// SetPlain writes the graph as sentences for screen readers
+func (g *WinningsGraphComponent) SetPlain(plain bool) {
+	g.plain = plain
+}
+
+// SetMasked hides the amounts, for streaming
+func (g *WinningsGraphComponent) SetMasked(masked bool) {
+	g.masked = masked
+}
+
+// SetChipFormatter sets how amounts are written
+func (g *WinningsGraphComponent) SetChipFormatter(chips *i18n.ChipFormatter) {
+	g.chips = chips
+}
+
+// hands returns the number of hands graphed
+func (g *WinningsGraphComponent) hands() int {
+	hands := 0
+	for _, line := range g.lines {
+		hands = max(hands, len(line.Hands))
+	}
+	return hands
+}
+
+// amount writes chips with their sign, or hides them
+func (g *WinningsGraphComponent) amount(chips int) string {
+	if g.masked {
+		return "•••"
+	}
+	return g.chips.Signed(chips)
+}
+
+// Render renders the graph, empty until a hand was played
+func (g *WinningsGraphComponent) Render() string {
+	hands := g.hands()
+	if hands == 0 {
+		return ""
+	}
+	if g.plain {
+		return g.renderPlain()
+	}
+	nameWidth, amountWidth := 0, 0
+	lowest, highest := 0, 0
+	for _, line := range g.lines {
+		nameWidth = max(nameWidth, lipgloss.Width(line.Name))
+		amountWidth = max(amountWidth, lipgloss.Width(g.amount(line.final())))
+		for _, chips := range line.Hands {
+			lowest, highest = min(lowest, chips), max(highest, chips)
+		}
+	}
+	columns := max(min(hands, g.width-nameWidth-amountWidth-4), 1)
+
+	rows := []string{"Chips won over " + countHands(hands)}
+	for _, line := range g.lines {
+		var spark strings.Builder
+		for column := 0; column < columns; column++ {
+			// Each column shows the last hand it covers
+			hand := min((column+1)*hands/columns, len(line.Hands)) - 1
+			chips := 0
+			if hand >= 0 {
+				chips = line.Hands[hand]
+			}
+			level := 0.5
+			if highest > lowest {
+				level = float64(chips-lowest) / float64(highest-lowest)
+			}
+			spark.WriteRune(sparkLevel(level))
+		}
+		style := lipgloss.NewStyle()
+		switch final := line.final(); {
+		case final > 0:
+			style = g.upStyle
+		case final < 0:
+			style = g.downStyle
+		}
+		amount := g.amount(line.final())
+		rows = append(rows, fmt.Sprintf("%s%s  %s  %s%s", line.Name, strings.Repeat(" ", nameWidth-lipgloss.Width(line.Name)),
+			style.Render(spark.String()), strings.Repeat(" ", amountWidth-lipgloss.Width(amount)), amount))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// renderPlain describes each player's session, e.g. "Alice +300, up to +450
+// on hand 12, down to -50 on hand 3"
+func (g *WinningsGraphComponent) renderPlain() string {
+	parts := []string{}
+	for _, line := range g.lines {
+		part := line.Name + " " + g.amount(line.final())
+		if g.masked {
+			parts = append(parts, part)
+			continue
+		}
+		peak, peakHand, low, lowHand := line.extremes()
+		if peak > 0 {
+			part += fmt.Sprintf(", up to %s on hand %d", g.amount(peak), peakHand)
+		}
+		if low < 0 {
+			part += fmt.Sprintf(", down to %s on hand %d", g.amount(low), lowHand)
+		}
+		parts = append(parts, part)
+	}
+	return fmt.Sprintf("Chips won over %s: %s", countHands(g.hands()), strings.Join(parts, "; "))
+}
+
+// countHands writes a number of hands, e.g. "1 hand" or "12 hands"
+func countHands(hands int) string {
+	if hands == 1 {
+		return "1 hand"
+	}
+	return fmt.Sprintf("%d hands", hands)
+}
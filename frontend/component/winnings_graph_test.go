@@ -0,0 +1,50 @@
+package component
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestWinningsGraph(t *testing.T) {
+	graph := NewWinningsGraphComponent(40)
+	if graph.Render() != "" {
+		t.Error("Expected nothing to graph before a hand")
+	}
+	graph.SetLines([]WinningsLine{
+		{Name: "Hero", Hands: []int{-50, -100, -100, 200}},
+		{Name: "Maniac", Hands: []int{100, 300, 500, 250}},
+		{Name: "Nit", Hands: []int{-50, -200, -400, -450}},
+	})
+	rows := strings.Split(ansi.Strip(graph.Render()), "\n")
+	want := []string{
+		"Chips won over 4 hands",
+		"Maniac  ▅▇█▆  +250",
+		"Hero    ▄▄▄▆  +200",
+		"Nit     ▄▃▁▁  -450",
+	}
+	if strings.Join(rows, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected the biggest winner first on one scale, got:\n%s", strings.Join(rows, "\n"))
+	}
+
+	// Long sessions are squeezed into the width
+	long := make([]int, 500)
+	for i := range long {
+		long[i] = i
+	}
+	graph.SetLines([]WinningsLine{{Name: "Hero", Hands: long}})
+	if width := ansi.StringWidth(strings.Split(graph.Render(), "\n")[1]); width > 40 {
+		t.Errorf("Expected the graph to fit 40 columns, got %d", width)
+	}
+
+	graph.SetLines([]WinningsLine{{Name: "Hero", Hands: []int{-50, 300, 200}}})
+	graph.SetPlain(true)
+	if got := graph.Render(); got != "Chips won over 3 hands: Hero +200, up to +300 on hand 2, down to -50 on hand 1" {
+		t.Errorf("Expected the session in words, got %q", got)
+	}
+	graph.SetMasked(true)
+	if got := graph.Render(); strings.Contains(got, "200") || !strings.Contains(got, "Hero •••") {
+		t.Errorf("Expected the amounts hidden for streaming, got %q", got)
+	}
+}
@@ -1,8 +1,20 @@
 package frontend
 
 import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/i18n"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/engine/rating"
+	"github.com/ljbink/ai-poker/engine/tournament"
+	"github.com/ljbink/ai-poker/engine/training"
+	"github.com/ljbink/ai-poker/internal/paths"
 )
 
 // UserData represents player information
@@ -12,84 +24,312 @@ type UserData struct {
 	LastSeen    time.Time `json:"last_seen"`
 	GamesPlayed int       `json:"games_played"`
 	GamesWon    int       `json:"games_won"`
+
+	Training training.Progress `json:"training"` // Odds quiz results
+
+	// Avatar shown at the table, in the log and in hand reviews
+	Avatar      string `json:"avatar"`       // Emoji, empty for the default
+	AvatarColor string `json:"avatar_color"` // Hex color of the name, empty for the default
+
+	// Ranges saved from the range editor, in the order they were first saved
+	Ranges []SavedRange `json:"ranges,omitempty"`
+}
+
+// SavedRange is a named hand range, referred to as "@name" wherever a range
+// is typed
+type SavedRange struct {
+	Name  string `json:"name"`
+	Hands string `json:"hands"` // Range notation, see ranges.Parse
 }
 
 // SettingsData represents application settings
 type SettingsData struct {
-	Theme             string `json:"theme"` // "dark", "light", "auto"
+	Theme             string `json:"theme"`                  // "dark", "light", "auto"
+	Language          string `json:"language"`               // Locale of the catalog, e.g. "en"
+	NumberFormat      string `json:"number_format"`          // One of i18n.NumberFormats, "" for the language's own
+	Currency          string `json:"currency"`               // Symbol amounts are written with, "" for chips
+	Accessibility     bool   `json:"accessibility"`          // Plain text for screen readers and no-color terminals
+	FourColorDeck     bool   `json:"four_color_deck"`        // A color per suit
+	HideHoleCards     bool   `json:"hide_hole_cards"`        // Hole cards face down until peeked at
+	StreamerMode      bool   `json:"streamer_mode"`          // Safe to broadcast: cards face down, bankroll masked, equity delayed
+	StreamerDelay     int    `json:"streamer_delay_seconds"` // Seconds the equity overlay waits in streamer mode
 	SoundEnabled      bool   `json:"sound_enabled"`
 	AnimationsEnabled bool   `json:"animations_enabled"`
 	AutoSave          bool   `json:"auto_save"`
 	DefaultBuyIn      int    `json:"default_buy_in"`
 	ShowProbabilities bool   `json:"show_probabilities"`
+	LogLevel          string `json:"log_level"` // "off", "error", "warn", "info", "debug"
+	LogFile           string `json:"log_file"`
+	ReplayFile        string `json:"replay_file"`       // Hand reviewed from the main menu
+	ExportDir         string `json:"export_dir"`        // Where exported sessions are written
+	AnonymizeExports  bool   `json:"anonymize_exports"` // Players exported under pseudonyms, for sharing in public
+	HUDStream         bool   `json:"hud_stream"`        // Tables published for HUD tools, see hud.Listen
+	HUDAddress        string `json:"hud_address"`       // Socket path, or a loopback host:port, the stream listens on
 
 	// Game Setup Settings
-	SmallBlind int `json:"small_blind"`
-	BigBlind   int `json:"big_blind"`
-	NumBots    int `json:"num_bots"`
+	SmallBlind  int    `json:"small_blind"`
+	BigBlind    int    `json:"big_blind"`
+	NumBots     int    `json:"num_bots"`
+	Variant     string `json:"variant"`      // "holdem" or "omaha"
+	SNGSeats    int    `json:"sng_seats"`    // 6 or 9
+	SNGBlinds   string `json:"sng_blinds"`   // Blind structure of Sit & Gos, a preset or a structure file, see tournament.Structure
+	SNGBreaks   string `json:"sng_breaks"`   // Break schedule of Sit & Gos, see sngBreaks, empty for none
+	TablePreset string `json:"table_preset"` // Template of the table rules, empty for the default cash game
+	Lineup      string `json:"lineup"`       // Saved lineup cash games are played against, empty for random bots
+
+	// Opponent lineups saved from the table, in the order they were first saved
+	Lineups []BotLineup `json:"lineups,omitempty"`
+
+	// Home-game table rules for cash games, 0 turns a rule off
+	HomeGame           bool `json:"home_game"`             // Rake-free, with a settlement of who owes whom at the end
+	BombPotEvery       int  `json:"bomb_pot_every"`        // Hands between bomb pots
+	SevenDeuceBountyBB int  `json:"seven_deuce_bounty_bb"` // Bounty in big blinds
+
+	// Cash game session limits, 0 turns a limit off
+	StopLossBB       int `json:"stop_loss_bb"`       // Big blinds down before cashing out
+	StopWinBB        int `json:"stop_win_bb"`        // Big blinds up before cashing out
+	TimeLimitMinutes int `json:"time_limit_minutes"` // Minutes at the table
+
+	// Minutes until a timed cash game ends after its last three hands, 0 for no end
+	TimedSessionMinutes int `json:"timed_session_minutes"`
+
+	// Hands a day before a reminder to take a break, 0 for none
+	DailyHandLimit int `json:"daily_hand_limit"`
+
+	// Auto actions that pre-answer trivial decisions, 0 turns auto-call off
+	AutoMuck   bool `json:"auto_muck"`    // Muck losing hands at showdown
+	AutoCheck  bool `json:"auto_check"`   // Check whenever possible
+	AutoCallBB int  `json:"auto_call_bb"` // Call bets up to this many big blinds
+
+	// How long bots think and the table waits after streets and hands
+	GameSpeed string `json:"game_speed"` // "instant", "fast", "normal" or "slow"
+
+	// Bots play looser after big losses and bad beats, never in simulations
+	BotTilt bool `json:"bot_tilt"`
+
+	// Bots comment on big pots and on being bluffed in the action log
+	TableTalk bool `json:"table_talk"`
 }
 
-// Data represents the central data store for the application
+// LineupBot is one opponent of a saved lineup
+type LineupBot struct {
+	Profile string `json:"profile"` // Bot preset or chart file, as holdem_ai.CreateBotByName takes
+	Stack   int    `json:"stack"`   // Chips the bot buys in for
+}
+
+// BotLineup is a named set of opponents to play against again, such as
+// "Tough 6-max" or "Loose home game"
+type BotLineup struct {
+	Name string      `json:"name"`
+	Bots []LineupBot `json:"bots"`
+}
+
+// maxLineupBots is the most opponents a lineup holds, as many as the game setup allows
+const maxLineupBots = 8
+
+// Keys Data keeps its values under in the Store
+const (
+	userKey        = "user"
+	settingsKey    = "settings"
+	savedTableKey  = "saved_table"
+	recoveryKey    = "recovery"
+	ratingsKey     = "ratings"
+	leaderboardKey = "leaderboard"
+	playLogKey     = "play_log"
+	lastSessionKey = "last_session"
+	bookmarksKey   = "bookmarks"
+)
+
+// Data is the application data, kept in a Store so it can live in memory
+// or on disk. Views reach it through the model and may subscribe to
+// changes made elsewhere.
 type Data struct {
-	lock     sync.RWMutex
-	user     *UserData
-	settings *SettingsData
+	lock   sync.Mutex // Makes read-modify-write updates atomic
+	store  Store
+	logger *slog.Logger
+	dirs   paths.Dirs // Where files named in settings are kept, the working directory when unset
+}
+
+// NewData creates the application data on top of a store
+func NewData(store Store) *Data {
+	return &Data{store: store, logger: holdem.NewDiscardLogger()}
+}
+
+// SetLogger sets where store failures are reported
+func (d *Data) SetLogger(logger *slog.Logger) {
+	d.logger = logger
+}
+
+// SetDirs sets the directories the application keeps its files in
+func (d *Data) SetDirs(dirs paths.Dirs) {
+	d.dirs = dirs
+}
+
+// GetDirs returns the directories the application keeps its files in
+func (d *Data) GetDirs() paths.Dirs {
+	return d.dirs
+}
+
+// Path resolves a file or directory named in settings, such as the replay
+// file or the export directory, against the data directory. Absolute paths
+// are kept as they are.
+func (d *Data) Path(name string) string {
+	if name == "" || d.dirs.Data == "" || filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(d.dirs.Data, name)
+}
+
+// Subscribe calls fn with the changed key ("user", "settings", "saved_table",
+// "recovery", "ratings" or "leaderboard") after every change until the returned function is called
+func (d *Data) Subscribe(fn func(key string)) (unsubscribe func()) {
+	return d.store.Subscribe(fn)
 }
 
 // User Data Methods
 func (d *Data) SetUser(user *UserData) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
-	if user != nil {
-		user.LastSeen = time.Now()
+	if user == nil {
+		d.remove(userKey)
+		return
 	}
-	d.user = user
+	user.LastSeen = time.Now()
+	d.save(userKey, user)
 }
 
 func (d *Data) GetUser() *UserData {
-	d.lock.RLock()
-	defer d.lock.RUnlock()
-	if d.user != nil {
-		// Return a copy to prevent external modification
-		userCopy := *d.user
-		return &userCopy
-	}
-	return nil
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.user()
 }
 
 func (d *Data) SetPlayerName(name string) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
-	if d.user == nil {
-		d.user = &UserData{
-			Name:      name,
-			CreatedAt: time.Now(),
-			LastSeen:  time.Now(),
-		}
-	} else {
-		d.user.Name = name
-		d.user.LastSeen = time.Now()
+	user := d.user()
+	if user == nil {
+		user = &UserData{Name: name, CreatedAt: time.Now()}
 	}
+	user.Name = name
+	user.LastSeen = time.Now()
+	d.save(userKey, user)
 }
 
 func (d *Data) GetPlayerName() string {
-	d.lock.RLock()
-	defer d.lock.RUnlock()
-	if d.user != nil {
-		return d.user.Name
+	if user := d.GetUser(); user != nil {
+		return user.Name
 	}
 	return ""
 }
 
+// SetAvatar saves the avatar and name color of the player's profile
+func (d *Data) SetAvatar(emoji, color string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	user := d.user()
+	if user == nil {
+		user = &UserData{CreatedAt: time.Now()}
+	}
+	user.Avatar = emoji
+	user.AvatarColor = color
+	user.LastSeen = time.Now()
+	d.save(userKey, user)
+}
+
+// SaveRange keeps a range with the profile, replacing any saved under the
+// same name
+func (d *Data) SaveRange(name string, r *ranges.Range) error {
+	name = strings.TrimSpace(name)
+	if err := ranges.CheckName(name); err != nil {
+		return err
+	}
+	if len(r.Hands()) == 0 {
+		return fmt.Errorf("range %s is empty", name)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	user := d.user()
+	if user == nil {
+		user = &UserData{CreatedAt: time.Now()}
+	}
+	saved := SavedRange{Name: name, Hands: r.String()}
+	user.LastSeen = time.Now()
+	for i, existing := range user.Ranges {
+		if strings.EqualFold(existing.Name, name) {
+			user.Ranges[i] = saved
+			d.save(userKey, user)
+			return nil
+		}
+	}
+	user.Ranges = append(user.Ranges, saved)
+	d.save(userKey, user)
+	return nil
+}
+
+// GetSavedRanges returns the ranges saved with the profile
+func (d *Data) GetSavedRanges() []SavedRange {
+	if user := d.GetUser(); user != nil {
+		return user.Ranges
+	}
+	return nil
+}
+
+// DeleteRange forgets a saved range
+func (d *Data) DeleteRange(name string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	user := d.user()
+	if user == nil {
+		return
+	}
+	kept := user.Ranges[:0]
+	for _, saved := range user.Ranges {
+		if !strings.EqualFold(saved.Name, name) {
+			kept = append(kept, saved)
+		}
+	}
+	user.Ranges = kept
+	d.save(userKey, user)
+}
+
+// rangeLibrary parses the saved ranges for range notation to refer to as
+// "@name", see ranges.WithLibrary, leaving out any that no longer parse
+func (d *Data) rangeLibrary() *ranges.Library {
+	library := map[string]*ranges.Range{}
+	for _, saved := range d.GetSavedRanges() {
+		r, err := ranges.Parse(saved.Hands)
+		if err != nil {
+			d.logger.Warn("saved range skipped", slog.String("range", saved.Name), slog.String("error", err.Error()))
+			continue
+		}
+		library[saved.Name] = r
+	}
+	return ranges.NewLibrary(library)
+}
+
 func (d *Data) UpdateGameStats(won bool) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
-	if d.user != nil {
-		d.user.GamesPlayed++
+	if user := d.user(); user != nil {
+		user.GamesPlayed++
 		if won {
-			d.user.GamesWon++
+			user.GamesWon++
 		}
-		d.user.LastSeen = time.Now()
+		user.LastSeen = time.Now()
+		d.save(userKey, user)
+	}
+}
+
+// RecordQuizAnswer adds an odds quiz answer to the player's progress
+func (d *Data) RecordQuizAnswer(kind training.QuestionKind, correct bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if user := d.user(); user != nil {
+		user.Training.Record(kind, correct)
+		user.LastSeen = time.Now()
+		d.save(userKey, user)
 	}
 }
 
@@ -97,97 +337,235 @@ func (d *Data) UpdateGameStats(won bool) {
 func (d *Data) SetSettings(settings *SettingsData) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
-	d.settings = settings
+	if settings == nil {
+		d.remove(settingsKey)
+		return
+	}
+	d.save(settingsKey, settings)
 }
 
+// GetSettings returns a copy of the settings, the defaults when none are stored
 func (d *Data) GetSettings() *SettingsData {
-	d.lock.RLock()
-	defer d.lock.RUnlock()
-	if d.settings != nil {
-		// Return a copy to prevent external modification
-		settingsCopy := *d.settings
-		return &settingsCopy
-	}
-	// Return default settings if none set
-	return &SettingsData{
-		Theme:             "dark",
-		SoundEnabled:      true,
-		AnimationsEnabled: true,
-		AutoSave:          true,
-		DefaultBuyIn:      1000,
-		ShowProbabilities: false,
-		SmallBlind:        5,
-		BigBlind:          10,
-		NumBots:           3,
-	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.settings()
 }
 
 func (d *Data) UpdateSetting(key string, value interface{}) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
-	if d.settings == nil {
-		d.settings = d.getDefaultSettings()
-	}
+	settings := d.settings()
 
 	switch key {
 	case "theme":
 		if v, ok := value.(string); ok {
-			d.settings.Theme = v
+			settings.Theme = v
+		}
+	case "language":
+		if v, ok := value.(string); ok {
+			settings.Language = v
+		}
+	case "accessibility":
+		if v, ok := value.(bool); ok {
+			settings.Accessibility = v
+		}
+	case "four_color_deck":
+		if v, ok := value.(bool); ok {
+			settings.FourColorDeck = v
 		}
 	case "sound_enabled":
 		if v, ok := value.(bool); ok {
-			d.settings.SoundEnabled = v
+			settings.SoundEnabled = v
 		}
 	case "animations_enabled":
 		if v, ok := value.(bool); ok {
-			d.settings.AnimationsEnabled = v
+			settings.AnimationsEnabled = v
 		}
 	case "auto_save":
 		if v, ok := value.(bool); ok {
-			d.settings.AutoSave = v
+			settings.AutoSave = v
 		}
 	case "default_buy_in":
 		if v, ok := value.(int); ok {
-			d.settings.DefaultBuyIn = v
+			settings.DefaultBuyIn = v
 		}
 	case "show_probabilities":
 		if v, ok := value.(bool); ok {
-			d.settings.ShowProbabilities = v
+			settings.ShowProbabilities = v
+		}
+	case "log_level":
+		if v, ok := value.(string); ok {
+			settings.LogLevel = v
+		}
+	case "log_file":
+		if v, ok := value.(string); ok {
+			settings.LogFile = v
+		}
+	case "replay_file":
+		if v, ok := value.(string); ok {
+			settings.ReplayFile = v
+		}
+	case "export_dir":
+		if v, ok := value.(string); ok {
+			settings.ExportDir = v
+		}
+	case "anonymize_exports":
+		if v, ok := value.(bool); ok {
+			settings.AnonymizeExports = v
+		}
+	case "hud_stream":
+		if v, ok := value.(bool); ok {
+			settings.HUDStream = v
+		}
+	case "hud_address":
+		if v, ok := value.(string); ok {
+			settings.HUDAddress = v
 		}
 	case "small_blind":
 		if v, ok := value.(int); ok {
-			d.settings.SmallBlind = v
+			settings.SmallBlind = v
 		}
 	case "big_blind":
 		if v, ok := value.(int); ok {
-			d.settings.BigBlind = v
+			settings.BigBlind = v
 		}
 	case "num_bots":
 		if v, ok := value.(int); ok {
-			d.settings.NumBots = v
+			settings.NumBots = v
+		}
+	case "variant":
+		if v, ok := value.(string); ok {
+			settings.Variant = v
+		}
+	case "table_preset":
+		if v, ok := value.(string); ok {
+			settings.TablePreset = v
+		}
+	case "lineup":
+		if v, ok := value.(string); ok {
+			settings.Lineup = v
+		}
+	case "sng_seats":
+		if v, ok := value.(int); ok {
+			settings.SNGSeats = v
+		}
+	case "sng_blinds":
+		if v, ok := value.(string); ok {
+			settings.SNGBlinds = v
+		}
+	case "sng_breaks":
+		if v, ok := value.(string); ok {
+			settings.SNGBreaks = v
+		}
+	case "bomb_pot_every":
+		if v, ok := value.(int); ok {
+			settings.BombPotEvery = v
+		}
+	case "seven_deuce_bounty_bb":
+		if v, ok := value.(int); ok {
+			settings.SevenDeuceBountyBB = v
+		}
+	case "stop_loss_bb":
+		if v, ok := value.(int); ok {
+			settings.StopLossBB = v
+		}
+	case "stop_win_bb":
+		if v, ok := value.(int); ok {
+			settings.StopWinBB = v
+		}
+	case "time_limit_minutes":
+		if v, ok := value.(int); ok {
+			settings.TimeLimitMinutes = v
+		}
+	case "timed_session_minutes":
+		if v, ok := value.(int); ok {
+			settings.TimedSessionMinutes = v
+		}
+	case "daily_hand_limit":
+		if v, ok := value.(int); ok {
+			settings.DailyHandLimit = v
+		}
+	case "auto_muck":
+		if v, ok := value.(bool); ok {
+			settings.AutoMuck = v
+		}
+	case "auto_check":
+		if v, ok := value.(bool); ok {
+			settings.AutoCheck = v
+		}
+	case "home_game":
+		if v, ok := value.(bool); ok {
+			settings.HomeGame = v
+		}
+	case "bot_tilt":
+		if v, ok := value.(bool); ok {
+			settings.BotTilt = v
+		}
+	case "table_talk":
+		if v, ok := value.(bool); ok {
+			settings.TableTalk = v
+		}
+	case "hide_hole_cards":
+		if v, ok := value.(bool); ok {
+			settings.HideHoleCards = v
+		}
+	case "number_format":
+		if v, ok := value.(string); ok {
+			settings.NumberFormat = v
+		}
+	case "currency":
+		if v, ok := value.(string); ok {
+			settings.Currency = v
+		}
+	case "streamer_mode":
+		if v, ok := value.(bool); ok {
+			settings.StreamerMode = v
+		}
+	case "streamer_delay_seconds":
+		if v, ok := value.(int); ok {
+			settings.StreamerDelay = v
+		}
+	case "auto_call_bb":
+		if v, ok := value.(int); ok {
+			settings.AutoCallBB = v
+		}
+	case "game_speed":
+		if v, ok := value.(string); ok {
+			settings.GameSpeed = v
 		}
 	}
+	d.save(settingsKey, settings)
 }
 
-// Helper method to get default settings
-func (d *Data) getDefaultSettings() *SettingsData {
+// defaultSettings returns the settings used until the player changes them
+func defaultSettings() *SettingsData {
 	return &SettingsData{
 		Theme:             "dark",
+		Language:          string(i18n.DefaultLocale),
 		SoundEnabled:      true,
 		AnimationsEnabled: true,
 		AutoSave:          true,
 		DefaultBuyIn:      1000,
 		ShowProbabilities: false,
+		StreamerDelay:     10,
+		LogLevel:          "info",
+		LogFile:           "debug.log",
+		ReplayFile:        "last_hand.replay.json",
+		ExportDir:         "exports",
+		HUDAddress:        "hud.sock",
 		SmallBlind:        5,
 		BigBlind:          10,
 		NumBots:           3,
+		SNGSeats:          6,
+		SNGBlinds:         tournament.StandardStructure,
+		GameSpeed:         "normal",
+		BotTilt:           true,
+		TableTalk:         true,
 	}
 }
 
 // Game Setup Methods
 func (d *Data) GetGameSetup() (smallBlind, bigBlind, numBots int) {
-	d.lock.RLock()
-	defer d.lock.RUnlock()
 	settings := d.GetSettings()
 	return settings.SmallBlind, settings.BigBlind, settings.NumBots
 }
@@ -195,33 +573,217 @@ func (d *Data) GetGameSetup() (smallBlind, bigBlind, numBots int) {
 func (d *Data) SetGameSetup(smallBlind, bigBlind, numBots int) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
-	if d.settings == nil {
-		d.settings = d.getDefaultSettings()
+	settings := d.settings()
+	settings.SmallBlind = smallBlind
+	settings.BigBlind = bigBlind
+	settings.NumBots = numBots
+	d.save(settingsKey, settings)
+}
+
+// Lineup Methods
+
+// SaveLineup keeps a lineup with the settings, replacing any saved under
+// the same name
+func (d *Data) SaveLineup(lineup BotLineup) error {
+	lineup.Name = strings.TrimSpace(lineup.Name)
+	if lineup.Name == "" {
+		return fmt.Errorf("a lineup needs a name")
+	}
+	if len(lineup.Bots) == 0 || len(lineup.Bots) > maxLineupBots {
+		return fmt.Errorf("a lineup holds 1 to %d bots, got %d", maxLineupBots, len(lineup.Bots))
+	}
+	for _, bot := range lineup.Bots {
+		if bot.Profile == "" || bot.Stack <= 0 {
+			return fmt.Errorf("every bot of a lineup needs a profile and chips, got %q with %d", bot.Profile, bot.Stack)
+		}
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	settings := d.settings()
+	for i, saved := range settings.Lineups {
+		if saved.Name == lineup.Name {
+			settings.Lineups[i] = lineup
+			d.save(settingsKey, settings)
+			return nil
+		}
+	}
+	settings.Lineups = append(settings.Lineups, lineup)
+	d.save(settingsKey, settings)
+	return nil
+}
+
+// GetLineup returns the saved lineup with the given name
+func (d *Data) GetLineup(name string) (BotLineup, bool) {
+	return d.GetSettings().findLineup(name)
+}
+
+// lineup returns the saved lineup cash games are played against, if any
+func (s *SettingsData) lineup() (BotLineup, bool) {
+	return s.findLineup(s.Lineup)
+}
+
+// findLineup returns the saved lineup with the given name
+func (s *SettingsData) findLineup(name string) (BotLineup, bool) {
+	for _, lineup := range s.Lineups {
+		if name != "" && lineup.Name == name {
+			return lineup, true
+		}
+	}
+	return BotLineup{}, false
+}
+
+// DeleteLineup forgets a saved lineup, and stops playing against it
+func (d *Data) DeleteLineup(name string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	settings := d.settings()
+	lineups := settings.Lineups[:0]
+	for _, lineup := range settings.Lineups {
+		if lineup.Name != name {
+			lineups = append(lineups, lineup)
+		}
+	}
+	settings.Lineups = lineups
+	if settings.Lineup == name {
+		settings.Lineup = ""
+	}
+	d.save(settingsKey, settings)
+}
+
+// Saved Table Methods
+
+// SaveTable keeps a cash game table to resume later, replacing any saved before
+func (d *Data) SaveTable(snapshot *holdem.Snapshot) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.save(savedTableKey, snapshot)
+}
+
+// GetSavedTable returns the saved cash game table, nil when there is none
+func (d *Data) GetSavedTable() *holdem.Snapshot {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	snapshot := &holdem.Snapshot{}
+	if !d.load(savedTableKey, snapshot) {
+		return nil
+	}
+	return snapshot
+}
+
+// ClearSavedTable forgets the saved table once it is resumed
+func (d *Data) ClearSavedTable() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.remove(savedTableKey)
+}
+
+// SaveRecovery autosaves the cash game being played, replacing the last
+// recovery point
+func (d *Data) SaveRecovery(point *RecoveryPoint) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.save(recoveryKey, point)
+}
+
+// GetRecovery returns the recovery point of a game that did not shut down
+// cleanly, nil when there is none
+func (d *Data) GetRecovery() *RecoveryPoint {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	point := &RecoveryPoint{}
+	if !d.load(recoveryKey, point) || point.Table == nil {
+		return nil
 	}
-	d.settings.SmallBlind = smallBlind
-	d.settings.BigBlind = bigBlind
-	d.settings.NumBots = numBots
+	return point
+}
+
+// ClearRecovery forgets the recovery point once the game stops cleanly
+func (d *Data) ClearRecovery() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.remove(recoveryKey)
+}
+
+// GetRatings returns the Elo ratings of the bot profiles and of the human,
+// rated as "human"
+func (d *Data) GetRatings() rating.Ratings {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.ratings()
+}
+
+// RecordMatches rates the matches, each the results of its players, and
+// saves the ratings
+func (d *Data) RecordMatches(matches ...[]rating.Result) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	ratings := d.ratings()
+	for _, results := range matches {
+		ratings.Record(results, rating.DefaultK)
+	}
+	d.save(ratingsKey, ratings)
 }
 
 // Utility Methods
 func (d *Data) Reset() {
 	d.lock.Lock()
 	defer d.lock.Unlock()
-	d.user = nil
-	d.settings = nil
+	d.remove(userKey)
+	d.remove(settingsKey)
+	d.remove(savedTableKey)
+	d.remove(recoveryKey)
+	d.remove(ratingsKey)
+	d.remove(leaderboardKey)
+	d.remove(playLogKey)
+	d.remove(lastSessionKey)
+	d.remove(bookmarksKey)
 }
 
-// Singleton pattern
-var (
-	dataInstance *Data
-	once         sync.Once
-)
+// user loads the stored user, nil when there is none
+func (d *Data) user() *UserData {
+	user := &UserData{}
+	if !d.load(userKey, user) {
+		return nil
+	}
+	return user
+}
 
-func GetData() *Data {
-	once.Do(func() {
-		dataInstance = &Data{
-			lock: sync.RWMutex{},
-		}
-	})
-	return dataInstance
+// settings loads the stored settings, filling in defaults when there are none
+func (d *Data) settings() *SettingsData {
+	settings := defaultSettings()
+	if !d.load(settingsKey, settings) {
+		return defaultSettings()
+	}
+	return settings
+}
+
+// ratings loads the stored ratings, empty when there are none
+func (d *Data) ratings() rating.Ratings {
+	ratings := rating.New()
+	if !d.load(ratingsKey, &ratings) || ratings == nil {
+		return rating.New()
+	}
+	return ratings
+}
+
+func (d *Data) load(key string, value any) bool {
+	ok, err := d.store.Get(key, value)
+	if err != nil {
+		d.logger.Warn("reading data failed", slog.String("key", key), slog.Any("error", err))
+		return false
+	}
+	return ok
+}
+
+func (d *Data) save(key string, value any) {
+	if err := d.store.Set(key, value); err != nil {
+		d.logger.Warn("saving data failed", slog.String("key", key), slog.Any("error", err))
+	}
+}
+
+func (d *Data) remove(key string) {
+	if err := d.store.Delete(key); err != nil {
+		d.logger.Warn("deleting data failed", slog.String("key", key), slog.Any("error", err))
+	}
 }
@@ -0,0 +1,260 @@
+package frontend
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// DebugKeyMap defines keybindings for the debug console, which takes the
+// keys while it is open
+type DebugKeyMap struct {
+	Street key.Binding
+	Stack  key.Binding
+	Apply  key.Binding
+	Close  key.Binding
+}
+
+var debugKeys = DebugKeyMap{
+	Street: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next street"),
+	),
+	Stack: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "stack the deck"),
+	),
+	Apply: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "stack"),
+	),
+	Close: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "close"),
+	),
+}
+
+// debugState is the engine state the debug console shows, read by the
+// runner goroutine with every update
+type debugState struct {
+	table   holdem.TableView // Spectator view, so no hole cards
+	deck    int              // Cards left to deal
+	stacked poker.Cards      // Stacked on the next hand's deck
+	pending string           // Player being asked to decide and by what, "" for nobody
+}
+
+// debugConsole is the hidden view of the raw engine state, opened with
+// ctrl+d, which can also stack the deck and force the hand on a street
+type debugConsole struct {
+	open    bool
+	editing bool // Typing the cards to stack
+	input   textinput.Model
+	note    string // Outcome of the last command
+	state   *debugState
+}
+
+func newDebugConsole() debugConsole {
+	ti := textinput.New()
+	ti.Placeholder = "e.g. As Kd Ah Kc, top card first"
+	ti.Width = 40
+	ti.Prompt = "Stack: "
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED"))
+	return debugConsole{input: ti}
+}
+
+// debugState reads the engine state for the console. pending is the
+// player being asked to decide, 0 for nobody.
+func (r *gameRunner) debugState(game *holdem.Game, pending int) *debugState {
+	state := &debugState{
+		table: game.SpectatorView(),
+		deck:  game.GetDeckSize(),
+	}
+	if pending != 0 {
+		state.pending = fmt.Sprintf("%s (%s)", r.playerName(game, pending), r.names[pending])
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	state.stacked = r.stack
+	return state
+}
+
+// stackDeck stacks cards on the deck of the next hand dealt, none to
+// take the stack down
+func (r *gameRunner) stackDeck(cards poker.Cards) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.stack = cards
+}
+
+// applyStack hands the stacked cards to the game before a hand is dealt
+func (r *gameRunner) applyStack(game *holdem.Game) {
+	r.lock.Lock()
+	cards := r.stack
+	r.stack = nil
+	r.lock.Unlock()
+	if len(cards) == 0 {
+		return
+	}
+	if err := game.StackDeck(cards); err != nil {
+		r.logger.Warn("stacking the deck failed", slog.Any("error", err))
+	}
+}
+
+// forceStreet has the street in play called down, if a table is being played
+func (r *gameRunner) forceStreet() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.table == nil {
+		return false
+	}
+	r.table.ForceStreet()
+	return true
+}
+
+// toggleDebug opens or closes the debug console
+func (v *GameView) toggleDebug() {
+	v.debug.open = !v.debug.open
+	v.debug.editing = false
+	v.debug.input.Blur()
+	if v.runner != nil {
+		v.runner.debugging.Store(v.debug.open)
+	}
+}
+
+// updateDebug handles the keys while the debug console is open
+func (v *GameView) updateDebug(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	console := &v.debug
+	if console.editing {
+		switch {
+		case key.Matches(msg, debugKeys.Apply):
+			v.stackDeck()
+		case key.Matches(msg, debugKeys.Close):
+			console.editing = false
+			console.input.Blur()
+		default:
+			var cmd tea.Cmd
+			console.input, cmd = console.input.Update(msg)
+			return v.model, cmd
+		}
+		return v.model, nil
+	}
+	switch {
+	case key.Matches(msg, debugKeys.Close):
+		v.toggleDebug()
+	case key.Matches(msg, debugKeys.Stack):
+		console.editing = true
+		console.input.SetValue("")
+		return v.model, console.input.Focus()
+	case key.Matches(msg, debugKeys.Street):
+		if v.runner == nil || !v.runner.forceStreet() {
+			console.note = "No table to force"
+			break
+		}
+		console.note = "Calling down the street"
+		if v.prompt != nil {
+			// The session replaces the human's decision too, it just has to come in
+			v.checkOrCall()
+		}
+	}
+	return v.model, nil
+}
+
+// stackDeck stacks the typed cards on the next hand's deck
+func (v *GameView) stackDeck() {
+	console := &v.debug
+	cards, err := poker.ParseCards(console.input.Value())
+	if err == nil {
+		err = checkStack(cards)
+	}
+	if err != nil {
+		console.note = "✗ " + err.Error()
+		return
+	}
+	console.editing = false
+	console.input.Blur()
+	if v.runner == nil {
+		console.note = "No table to stack"
+		return
+	}
+	v.runner.stackDeck(cards)
+	console.note = "Deck unstacked"
+	if len(cards) > 0 {
+		console.note = fmt.Sprintf("The next hand deals %s from the top", cards.Codes())
+	}
+}
+
+// checkStack refuses a stack holding a card twice, which the engine would
+// only refuse once the next hand is dealt
+func checkStack(cards poker.Cards) error {
+	seen := map[poker.Card]bool{}
+	for _, card := range cards {
+		if seen[*card] {
+			return fmt.Errorf("%s is stacked twice", card.Code())
+		}
+		seen[*card] = true
+	}
+	return nil
+}
+
+// renderDebug draws the debug console
+func (v *GameView) renderDebug(width int) string {
+	console := v.debug
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(v.model.icon("🐞", "Debug console"))}
+	if state := console.state; state != nil {
+		table := state.table
+		lines = append(lines,
+			fmt.Sprintf("Table %s", table.TableID),
+			fmt.Sprintf("Hand #%d %s · %s", table.HandNumber, table.HandID, holdem.PhaseToString(table.Phase)),
+			fmt.Sprintf("Deck %d cards · Pot %d · Bet %d · Button %d · Acting %d", state.deck, table.Pot, table.CurrentBet, table.Button, table.ActingSeat),
+		)
+		for _, seat := range table.Seats {
+			flags := []string{}
+			if seat.Folded {
+				flags = append(flags, "folded")
+			}
+			if seat.Chips == 0 && !seat.Folded {
+				flags = append(flags, "all in")
+			}
+			if seat.Protected {
+				flags = append(flags, "protected")
+			}
+			lines = append(lines, fmt.Sprintf("Seat %d  %-12s chips %5d  bet %4d  in %5d  %s",
+				seat.Seat, seat.Name, seat.Chips, seat.Bet, seat.TotalBet, strings.Join(flags, ", ")))
+		}
+		pending := state.pending
+		if pending == "" {
+			pending = "nobody"
+		}
+		lines = append(lines, "Deciding: "+pending)
+		if len(state.stacked) > 0 {
+			lines = append(lines, "Stacked for the next hand: "+state.stacked.Codes())
+		}
+	}
+	if console.editing {
+		lines = append(lines, console.input.View())
+	} else if console.note != "" {
+		lines = append(lines, console.note)
+	}
+	help := "n next street · s stack the deck · esc close"
+	if console.editing {
+		help = "enter stack, nothing to unstack · esc cancel"
+	}
+	lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(help)) // Medium gray
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#F59E0B")). // Yellow/Orange
+		Padding(0, 2).
+		Align(lipgloss.Left)
+	if v.model.Accessible() {
+		box = lipgloss.NewStyle().Align(lipgloss.Left)
+	}
+	return box.MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
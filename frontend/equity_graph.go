@@ -0,0 +1,50 @@
+package frontend
+
+import (
+	"github.com/ljbink/ai-poker/engine/analysis"
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/spectator"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// equityGraphOptions keep the equity graph quick to work out, and the same
+// every time a hand is looked at
+var equityGraphOptions = equity.Options{Samples: 5000, Seed: 1}
+
+// reviewedHand is a recorded hand ready to step through
+type reviewedHand struct {
+	frames  []spectator.CommentaryFrame
+	equity  []analysis.StreetEquity  // The human's equity by street
+	avatars map[int]component.Avatar // By player ID
+	replay  *holdem.Replay
+	spot    string // EV analysis of the decision reviewed, see ReviewBookmark
+}
+
+// reviewHand re-runs a recorded hand and works out the human's equity on
+// every street it saw
+func reviewHand(replay *holdem.Replay) (reviewedHand, error) {
+	frames, err := spectator.ReplayFrames(replay)
+	if err != nil {
+		return reviewedHand{}, err
+	}
+	views := make([]holdem.TableView, len(frames))
+	for i, frame := range frames {
+		views[i] = frame.View
+	}
+	streets, err := analysis.EquityByStreet(views, replayHero(replay), equityGraphOptions)
+	if err != nil {
+		return reviewedHand{}, err
+	}
+	return reviewedHand{frames: frames, equity: streets}, nil
+}
+
+// replayHero returns the player the human played in a replay
+func replayHero(replay *holdem.Replay) int {
+	for _, seat := range replay.Seats {
+		if seat.DecisionMaker == "human" {
+			return seat.PlayerID
+		}
+	}
+	return humanPlayerID
+}
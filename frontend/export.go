@@ -0,0 +1,177 @@
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ljbink/ai-poker/engine/analysis"
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/stats"
+)
+
+// sessionStats is one player's line in an exported stats.json
+type sessionStats struct {
+	Name       string  `json:"name"`
+	Hands      int     `json:"hands"`
+	VPIP       float64 `json:"vpip"`
+	PFR        float64 `json:"pfr"`
+	WTSD       float64 `json:"wtsd"`
+	WSD        float64 `json:"wsd"`
+	ColdCall   float64 `json:"cold_call"`
+	Squeeze    float64 `json:"squeeze"`
+	DonkBet    float64 `json:"donk_bet"`
+	CheckRaise float64 `json:"check_raise"`
+	BadBeats   int     `json:"bad_beats"`
+	Coolers    int     `json:"coolers"`
+	Net        int     `json:"net"`
+	BBPer100   float64 `json:"bb_per_100"`
+}
+
+// sessionSummary is the stats.json of an exported session
+type sessionSummary struct {
+	SessionID string         `json:"session_id"`
+	Hands     int            `json:"hands"`
+	Rake      int            `json:"rake"`
+	Players   []sessionStats `json:"players"` // Biggest winner first
+}
+
+// recordHand keeps the finished hand for exportSession
+func (r *gameRunner) recordHand(replay *holdem.Replay) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.hands = append(r.hands, replay)
+}
+
+// exportSession writes every hand played at the table so far into a
+// directory named after the session, under dir, for sharing:
+//
+//	session-<id>/replays/hand-001.replay.json  one replay per hand
+//	session-<id>/hands.txt                     PokerStars hand histories
+//	session-<id>/phh/hand-001.phh              PHH hand histories
+//	session-<id>/stats.json                    the session statistics
+//	session-<id>/settlement.txt                who owes whom, home games only
+//
+// Anonymized, the players are written under pseudonyms, Player 1, Player 2
+// and so on, into session-<id>-anonymous without the settlement, and who
+// is who goes to session-<id>-pseudonyms.json beside it, for the owner
+// alone. It returns the directory written.
+func (r *gameRunner) exportSession(dir string, anonymize bool) (string, error) {
+	r.lock.Lock()
+	table, replays := r.table, append([]*holdem.Replay{}, r.hands...)
+	r.lock.Unlock()
+	if table == nil || len(replays) == 0 {
+		return "", fmt.Errorf("no hands played yet")
+	}
+	if anonymize {
+		return exportAnonymized(dir, table.GetID(), replays)
+	}
+	dir, err := exportSession(filepath.Join(dir, "session-"+table.GetID()), table.GetID(), replays)
+	if err != nil {
+		return "", err
+	}
+	if report := r.settlement(); report != nil {
+		if err := writeFile(filepath.Join(dir, "settlement.txt"), func(f *os.File) error {
+			return report.WriteFormatted(f, r.format)
+		}); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+// exportAnonymized writes the replays of a session under pseudonyms into
+// a directory of their own under dir, and the pseudonyms beside it
+func exportAnonymized(dir, sessionID string, replays []*holdem.Replay) (string, error) {
+	anonymizer := holdem.NewAnonymizer()
+	anonymized := make([]*holdem.Replay, len(replays))
+	for i, replay := range replays {
+		var err error
+		if anonymized[i], err = anonymizer.Replay(replay); err != nil {
+			return "", err
+		}
+	}
+	out, err := exportSession(filepath.Join(dir, "session-"+sessionID+"-anonymous"), sessionID, anonymized)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(anonymizer.Pseudonyms(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "session-"+sessionID+"-pseudonyms.json"), data, 0o600); err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+// exportSession writes the replays of a session and their hand histories and
+// statistics into dir
+func exportSession(dir, sessionID string, replays []*holdem.Replay) (string, error) {
+	for _, sub := range []string{"replays", "phh"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return "", err
+		}
+	}
+	hands := make([]*handhistory.Hand, 0, len(replays))
+	for i, replay := range replays {
+		name := fmt.Sprintf("hand-%03d", i+1)
+		if err := replay.Save(filepath.Join(dir, "replays", name+".replay.json")); err != nil {
+			return "", err
+		}
+		hand, err := handhistory.FromReplay(replay)
+		if err != nil {
+			return "", fmt.Errorf("hand %d: %w", replay.HandNumber, err)
+		}
+		if err := writeFile(filepath.Join(dir, "phh", name+".phh"), func(f *os.File) error {
+			return handhistory.WritePHH(f, hand)
+		}); err != nil {
+			return "", err
+		}
+		hands = append(hands, hand)
+	}
+	if err := writeFile(filepath.Join(dir, "hands.txt"), func(f *os.File) error {
+		return handhistory.WritePokerStars(f, hands...)
+	}); err != nil {
+		return "", err
+	}
+
+	session := stats.Compute(hands)
+	beats, err := analysis.TagBeats(hands, highlightOptions)
+	if err != nil {
+		return "", err
+	}
+	analysis.CountBeats(session, beats)
+	summary := sessionSummary{SessionID: sessionID, Hands: session.Hands, Rake: session.Rake, Players: []sessionStats{}}
+	for _, p := range session.Sorted() {
+		summary.Players = append(summary.Players, sessionStats{
+			Name: p.Name, Hands: p.Hands, VPIP: p.VPIP(), PFR: p.PFR(),
+			WTSD: p.WTSD(), WSD: p.WSD(), ColdCall: p.ColdCall(), Squeeze: p.Squeeze(),
+			DonkBet: p.DonkBet(), CheckRaise: p.CheckRaise(), BadBeats: p.BadBeats, Coolers: p.Coolers,
+			Net: p.Net, BBPer100: p.BBPer100(),
+		})
+	}
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "stats.json"), data, 0o644); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// writeFile creates path and writes it with write
+func writeFile(path string, write func(f *os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
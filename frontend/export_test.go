@@ -0,0 +1,206 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+func TestExportSession(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	game.PlayerSit(holdem.NewPlayer(humanPlayerID, "Hero", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Bot", 1000), 1)
+	replays := []*holdem.Replay{}
+	for i := 0; i < 2; i++ {
+		if err := game.DealHoleCards(); err != nil {
+			t.Fatal(err)
+		}
+		folder := game.GetCurrentPlayer().GetID()
+		if err := game.TakeAction(holdem.Action{PlayerID: folder, Type: holdem.ActionFold}); err != nil {
+			t.Fatal(err)
+		}
+		replay, err := holdem.NewReplay(game, map[int]string{humanPlayerID: "human"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		replays = append(replays, replay)
+	}
+
+	dir, err := exportSession(filepath.Join(t.TempDir(), "session-abc"), "abc", replays)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"replays/hand-001.replay.json", "replays/hand-002.replay.json", "phh/hand-001.phh", "phh/hand-002.phh"} {
+		hands, err := handhistory.ParseFile(filepath.Join(dir, name))
+		if err != nil || len(hands) != 1 {
+			t.Errorf("Expected %s to hold a hand, got %d hands: %v", name, len(hands), err)
+		}
+	}
+	hands, err := handhistory.ParseFile(filepath.Join(dir, "hands.txt"))
+	if err != nil || len(hands) != 2 {
+		t.Errorf("Expected both hands in hands.txt, got %d: %v", len(hands), err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "stats.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary := sessionSummary{}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.SessionID != "abc" || summary.Hands != 2 || len(summary.Players) != 2 {
+		t.Errorf("Expected stats of 2 players over 2 hands of session abc, got %+v", summary)
+	}
+}
+
+func TestExportCountsBadBeats(t *testing.T) {
+	// Both players are all in from the blinds, and the hero's aces lose to a king on the turn
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	s := session.New(game)
+	for seat, player := range []holdem.IPlayer{holdem.NewPlayer(humanPlayerID, "Hero", 10), holdem.NewPlayer(2, "Bot", 10)} {
+		if err := s.SitDown(player, seat); err != nil {
+			t.Fatal(err)
+		}
+		s.SetDecisionMaker(player.GetID(), callBot{})
+	}
+	stacked, _ := poker.ParseCards("AsKsAdKd 3c2c7h9d 3dKc 3h4s")
+	if err := game.StackDeck(stacked); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.PlayHand(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	replay, err := holdem.NewReplay(game, map[int]string{humanPlayerID: "human"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := exportSession(filepath.Join(t.TempDir(), "session-abc"), "abc", []*holdem.Replay{replay})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "stats.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary := sessionSummary{}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatal(err)
+	}
+	for _, player := range summary.Players {
+		if beats := map[string]int{"Hero": 1, "Bot": 0}[player.Name]; player.BadBeats != beats || player.Coolers != 0 {
+			t.Errorf("Expected %d bad beats for %s, got %+v", beats, player.Name, player)
+		}
+	}
+}
+
+func TestExportHomeGameSettlement(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	data.UpdateSetting("home_game", true)
+	runner := newGameRunner(holdem.NewDiscardLogger(), data, nil)
+	s := session.New(holdem.NewGameWithConfig(cashGameConfig(data.GetSettings())))
+	hero, bot := holdem.NewPlayer(humanPlayerID, "Hero", 1000), holdem.NewPlayer(2, "Bot", 1000)
+	for seat, player := range []holdem.IPlayer{hero, bot} {
+		if err := s.SitDown(player, seat); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hero.GrandChips(250)
+	bot.GrandChips(-250)
+	runner.setTable(s, true)
+	if text := runner.settlementText(); !strings.Contains(text, "Bot pays Hero 250") {
+		t.Errorf("Expected the bot to pay the hero, got:\n%s", text)
+	}
+
+	// Any hand will do, the settlement comes from the table
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	game.PlayerSit(holdem.NewPlayer(humanPlayerID, "Hero", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Bot", 1000), 1)
+	if err := game.DealHoleCards(); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.TakeAction(holdem.Action{PlayerID: game.GetCurrentPlayer().GetID(), Type: holdem.ActionFold}); err != nil {
+		t.Fatal(err)
+	}
+	replay, err := holdem.NewReplay(game, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runner.recordHand(replay)
+	dir, err := runner.exportSession(t.TempDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text, err := os.ReadFile(filepath.Join(dir, "settlement.txt")); err != nil || !strings.Contains(string(text), "Bot pays Hero 250") {
+		t.Errorf("Expected the settlement in settlement.txt, got %q: %v", text, err)
+	}
+
+	// Anonymized, who owes whom stays out
+	out := t.TempDir()
+	if dir, err = runner.exportSession(out, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "settlement.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected no settlement in an anonymized export, got %v", err)
+	}
+
+	runner.homeGame = false
+	if text := runner.settlementText(); text != "" {
+		t.Errorf("Expected no settlement outside home games, got:\n%s", text)
+	}
+}
+
+func TestExportAnonymized(t *testing.T) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	game.PlayerSit(holdem.NewPlayer(humanPlayerID, "Hero", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Bot", 1000), 1)
+	if err := game.DealHoleCards(); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.TakeAction(holdem.Action{PlayerID: game.GetCurrentPlayer().GetID(), Type: holdem.ActionFold}); err != nil {
+		t.Fatal(err)
+	}
+	replay, err := holdem.NewReplay(game, map[int]string{humanPlayerID: "human"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := t.TempDir()
+	dir, err := exportAnonymized(out, "abc", []*holdem.Replay{replay})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != filepath.Join(out, "session-abc-anonymous") {
+		t.Errorf("Expected the anonymized export in a directory of its own, got %s", dir)
+	}
+	for _, name := range []string{"replays/hand-001.replay.json", "hands.txt", "phh/hand-001.phh", "stats.json"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if text := string(data); strings.Contains(text, "Hero") || strings.Contains(text, "Bot") || strings.Contains(text, "human") || !strings.Contains(text, "Player 2") {
+			t.Errorf("Expected %s to name the players by pseudonym alone, got:\n%s", name, text)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(out, "session-abc-pseudonyms.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pseudonyms := []holdem.Pseudonym{}
+	if err := json.Unmarshal(data, &pseudonyms); err != nil {
+		t.Fatal(err)
+	}
+	if len(pseudonyms) != 2 || pseudonyms[0].RealName != "Hero" || pseudonyms[1].Name != "Player 2" {
+		t.Errorf("Expected the owner's mapping beside the export, got %+v", pseudonyms)
+	}
+}
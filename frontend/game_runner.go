@@ -0,0 +1,1336 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ljbink/ai-poker/engine/analysis"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/hud"
+	"github.com/ljbink/ai-poker/engine/i18n"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/engine/rating"
+	"github.com/ljbink/ai-poker/engine/session"
+	"github.com/ljbink/ai-poker/engine/tournament"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// humanPlayerID is the player ID of the person at the keyboard
+const humanPlayerID = 1
+
+// sngBuyIn is the buy-in of TUI sit-and-gos, paid back 50/30/20
+const sngBuyIn = 100
+
+// sngBreaks are the break schedules of TUI sit-and-gos by setting
+var sngBreaks = map[string]tournament.Breaks{
+	"hourly":       {Every: 55 * time.Minute, Length: 5 * time.Minute},
+	"synchronized": {Every: time.Hour, Length: 5 * time.Minute, Synchronized: true},
+}
+
+// Cash game table rules: buy in for 40 to 100 big blinds
+const (
+	cashMinBuyInBB = 40
+	cashMaxBuyInBB = 100
+)
+
+// bombPotAnteBB is what every player antes into a bomb pot, in big blinds
+const bombPotAnteBB = 2
+
+// actionPrompt describes the decision the human has to make
+type actionPrompt struct {
+	actions  []holdem.ActionType
+	bet      int               // Chips already in on the street
+	call     int               // Chips needed to call
+	chips    int               // Chips behind
+	minRaise int               // Smallest raise on top of the call
+	sizes    holdem.RaiseSizes // Raises offered, see stepRaise
+	bigBlind int
+	option   bool // Big blind preflop, may check or raise
+	hand     int  // Hand number, with action identifies the decision for bookmarks
+	action   int  // Actions taken in the hand before the decision
+}
+
+// can reports whether the action type is currently legal
+func (p *actionPrompt) can(actionType holdem.ActionType) bool {
+	for _, available := range p.actions {
+		if available == actionType {
+			return true
+		}
+	}
+	return false
+}
+
+// stepRaise moves the raise on top of the call to the next size offered
+// up or down, a big blind at a time in no-limit games
+func (p *actionPrompt) stepRaise(raiseBy, delta int) int {
+	if !p.can(holdem.ActionRaise) {
+		return raiseBy
+	}
+	return p.sizes.Step(p.call+raiseBy, delta, p.bigBlind) - p.call
+}
+
+// gameUpdateMsg carries table state from the goroutine playing hands
+type gameUpdateMsg struct {
+	view     holdem.TableView
+	log      []string      // Lines describing what just happened
+	status   string        // Blinds, level and players left
+	prompt   *actionPrompt // Set when the human has to act
+	busted   bool          // Set when the human may buy in again
+	limit    string        // Set when a session limit offers the human to cash out
+	result   string        // Set once the game is over for the human
+	settle   string        // Set with result at the end of a home game, see settlement
+	winnings *LastSession  // Set with result once a hand was played
+	summary  *handSummary  // Set when a hand finishes
+	winner   int           // Set with winning when a hand finishes at showdown
+	winning  *holdem.HandResult
+	session  *sessionInfo   // Set when a hand starts or finishes
+	debug    *debugState    // Set by every step of a hand
+	reads    []opponentRead // Set with the prompt
+	avatars  map[int]component.Avatar
+	turn     *turnClock // Set when a player, bot or human, starts to act
+	warning  bool       // Set by a time warning, which leaves everything but the log as it is
+	ok       bool       // False once the runner stopped
+
+	runner *gameRunner // Sender, so updates from an abandoned game are ignored
+}
+
+// sessionInfo is what the status bar shows about the session so far
+type sessionInfo struct {
+	hands int // Hands played since the game started
+	stack int // The human's stack, before the blinds while a hand is on
+	net   int // The human's result in the last hand
+	level int // Tournament blind level, 0 in cash games
+}
+
+// tableRequest is a stack change the human asked for, applied between hands
+type tableRequest int
+
+const (
+	requestTopUp       tableRequest = iota // Top up to the maximum buy-in
+	requestRebuy                           // Buy in again after busting
+	requestCashOut                         // Leave the table at a session limit
+	requestKeepPlaying                     // Play on past a session limit
+	requestUndo                            // Take back the hand just played
+	requestChangeSeat                      // Move to the next empty seat
+	requestSwapBot                         // Replace or send away the bot queued with swapBot
+)
+
+// gameRunner plays hands in the background against bots, sending every
+// step to the game view and taking the human's decisions from it
+type gameRunner struct {
+	updates  chan gameUpdateMsg
+	requests chan tableRequest
+	human    *holdem_ai.HumanDecisionMaker
+	makers   map[int]holdem_ai.IDecisionMaker
+	names    map[int]string // Decision maker names recorded in replays, changed under lock once the game runs
+	cancel   context.CancelFunc
+	logger   *slog.Logger
+	data     *Data
+
+	translator *i18n.Translator         // Language of the log and validation errors
+	format     *i18n.ChipFormatter      // How the log and the settlement write amounts
+	cards      component.CardRenderer   // How the log shows cards
+	avatars    map[int]component.Avatar // By player ID, filled in before the first hand
+	plain      bool                     // The log names players without avatars
+	tilt       bool                     // Bots tilt after big losses and bad beats
+	homeGame   bool                     // Cash tables are settled up at the end, see settlement
+
+	status  func() string   // Called from the runner goroutine only
+	level   func() int      // Tournament blind level, runner goroutine only
+	played  int             // Hands finished, runner goroutine only
+	net     int             // The human's result in the last hand, runner goroutine only
+	chips   map[int]int     // Chips won or lost by player ID over the game, runner goroutine only
+	last    map[int]int     // Chips won or lost by player ID in the last hand, runner goroutine only
+	retired []rating.Result // Results of the bots replaced or sent away, runner goroutine only
+	auto    bool            // The human's current turn was auto-answered, runner goroutine only
+	done    chan struct{}   // Closed once the game has stopped
+	hud     *hud.Server     // Stream of the table for HUD tools, nil when off
+
+	handStarted time.Time // When the hand in play was dealt, runner goroutine only
+	dailyLimit  bool      // The daily hand limit was just reached at a cash table, runner goroutine only
+
+	recovery *RecoveryPoint        // Autosave of the hand in play, runner goroutine only
+	script   []holdem.LoggedAction // Recovered actions left to replay, runner goroutine only
+	replayed bool                  // The last action was replayed, runner goroutine only
+
+	lock     sync.Mutex
+	held     chan struct{}    // Closed by release, nil while not held
+	clock    pausable         // Tournament clock stopped while held, nil in cash games
+	table    *session.Session // Table being played
+	saveable bool             // The table can be saved with saveTable
+	hands    []*holdem.Replay // Hands finished at the table, see exportSession
+	pace     gameSpeed        // Delays between steps, see setSpeed
+	swap     *botSwap         // Bot replacement waiting for the end of the hand
+	marks    []spotMark       // Decisions bookmarked in the hand in play, see bookmark
+
+	stopped     bool // Stopped cleanly, nothing more is autosaved
+	recoverable bool // A recovery point of this game is saved
+
+	stack     poker.Cards // Stacked on the next hand's deck from the debug console
+	debugging atomic.Bool // The debug console is open, so bot turns are sent too
+}
+
+// pausable is a clock the pause menu stops, such as a tournament's
+type pausable interface {
+	Pause()
+	Resume()
+}
+
+// abandonPolicy is how the chips in a hand abandoned from the pause menu go back
+const abandonPolicy = holdem.AbortRefund
+
+func newGameRunner(logger *slog.Logger, data *Data, cards component.CardRenderer) *gameRunner {
+	human := holdem_ai.NewHumanDecisionMaker()
+	human.SetLogger(logger)
+	settings := data.GetSettings()
+	translator := newTranslator(settings)
+	human.SetAutoActions(autoActions(settings))
+	human.SetTranslator(translator)
+	return &gameRunner{
+		updates:    make(chan gameUpdateMsg, 16),
+		requests:   make(chan tableRequest, 1),
+		human:      human,
+		makers:     map[int]holdem_ai.IDecisionMaker{humanPlayerID: human},
+		names:      map[int]string{humanPlayerID: "human"},
+		logger:     logger,
+		data:       data,
+		translator: translator,
+		format:     newChipFormatter(settings),
+		cards:      cards,
+		avatars:    map[int]component.Avatar{humanPlayerID: humanAvatar(data.GetUser())},
+		plain:      settings.Accessibility,
+		tilt:       settings.BotTilt,
+		homeGame:   settings.HomeGame,
+		status:     func() string { return "" },
+		level:      func() int { return 0 },
+		done:       make(chan struct{}),
+		chips:      map[int]int{},
+		pace:       speedNamed(settings.GameSpeed),
+	}
+}
+
+// addBots registers bots with player IDs 2..count+1 and returns their names
+func (r *gameRunner) addBots(count int) map[int]string {
+	presets := holdem_ai.BotNames()
+	offset := rand.Intn(len(presets))
+	bots := map[int]string{}
+	for i := 0; i < count; i++ {
+		preset := presets[(offset+i)%len(presets)]
+		if name, err := r.addBot(i+2, preset); err == nil {
+			bots[i+2] = name
+		}
+	}
+	return bots
+}
+
+// addLineup registers the bots of a saved lineup with player IDs
+// 2..len(bots)+1 and returns their names and the stacks they buy in for
+func (r *gameRunner) addLineup(lineup BotLineup) (map[int]string, map[int]int, error) {
+	bots, stacks := map[int]string{}, map[int]int{}
+	for i, bot := range lineup.Bots {
+		name, err := r.addBot(i+2, bot.Profile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("lineup %s: %w", lineup.Name, err)
+		}
+		bots[i+2], stacks[i+2] = name, bot.Stack
+	}
+	return bots, stacks, nil
+}
+
+// addBot registers a bot created from a preset name or chart file and
+// returns its display name
+func (r *gameRunner) addBot(id int, profile string) (string, error) {
+	maker, err := holdem_ai.CreateBotByName(profile, ranges.WithLibrary(r.data.rangeLibrary()))
+	if err != nil {
+		return "", err
+	}
+	r.setupBot(maker)
+	r.makers[id] = maker
+	r.names[id] = profile
+	r.avatars[id] = avatarFor(profile, id, nil)
+	name := strings.TrimSuffix(filepath.Base(profile), filepath.Ext(profile))
+	return strings.ToUpper(name[:1]) + name[1:], nil
+}
+
+// setupBot lets a bot tilt when the settings allow it and chat at the
+// table, which the action log shows unless table talk is off. Simulations
+// never call it, so their bots play the same every hand.
+func (r *gameRunner) setupBot(maker holdem_ai.IDecisionMaker) {
+	if tilter, ok := maker.(holdem_ai.ITilter); ok {
+		tilter.SetTilt(r.tilt)
+	}
+	if talker, ok := maker.(interface{ SetTableTalk(bool) }); ok {
+		talker.SetTableTalk(true)
+	}
+}
+
+// start runs play in the background and returns the command that delivers the first update
+func (r *gameRunner) start(play func(ctx context.Context) (string, error)) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.hud = openHUD(r.logger, r.data)
+	go func() {
+		defer close(r.done)
+		defer close(r.updates)
+		defer r.closeHUD()
+		defer r.clearRecovery()
+		defer r.rate()
+		result, err := safely(func() (string, error) { return play(ctx) })
+		winnings := r.lastSession()
+		if winnings != nil {
+			r.data.SaveLastSession(*winnings)
+		}
+		var crash *panicError
+		if errors.As(err, &crash) {
+			// The hand can be replayed up to the crash on the next start
+			r.keepRecovery()
+			r.logger.Error("game crashed", slog.Any("panic", crash.value), slog.String("stack", string(crash.stack)))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			r.logger.Error("game stopped", slog.Any("error", err))
+			result = "Game stopped: " + err.Error()
+		}
+		r.send(ctx, gameUpdateMsg{result: result, settle: r.settlementText(), winnings: winnings})
+	}()
+	return r.wait()
+}
+
+// closeHUD disconnects the HUD tools once the game is over
+func (r *gameRunner) closeHUD() {
+	if r.hud != nil {
+		r.hud.Close()
+	}
+}
+
+// rate records the game in the ratings of the bots and the human, every
+// player scored by the chips they won or lost, once a hand was played
+func (r *gameRunner) rate() {
+	if len(r.chips)+len(r.retired) == 0 {
+		return
+	}
+	results := append([]rating.Result{}, r.retired...)
+	for id, chips := range r.chips {
+		results = append(results, rating.Result{Name: r.names[id], Score: float64(chips)})
+	}
+	r.data.RecordMatches(results)
+	if r.canSave() {
+		r.recordCashGame()
+	}
+}
+
+// request queues a top-up or re-buy; it is dropped while another is pending
+func (r *gameRunner) request(req tableRequest) {
+	select {
+	case r.requests <- req:
+	default:
+	}
+}
+
+// stop abandons the game and forgets its recovery point
+func (r *gameRunner) stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.clearRecovery()
+}
+
+// halt abandons the game and keeps its recovery point for the next start
+func (r *gameRunner) halt() {
+	r.keepRecovery()
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// hold stops the game at its next update until release is called
+func (r *gameRunner) hold() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.held == nil {
+		r.held = make(chan struct{})
+	}
+	if r.clock != nil {
+		r.clock.Pause()
+	}
+}
+
+// release lets a held game carry on
+func (r *gameRunner) release() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.clock != nil {
+		r.clock.Resume()
+	}
+	if r.held != nil {
+		close(r.held)
+		r.held = nil
+	}
+}
+
+// setTable records the table being played, called by the game loop
+func (r *gameRunner) setTable(s *session.Session, saveable bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.table, r.saveable = s, saveable
+}
+
+// settlement returns who owes whom at the cash table, nil unless it is a
+// home game
+func (r *gameRunner) settlement() *session.HomeGameReport {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	// Only cash tables can be saved, tournaments pay out by standings
+	if !r.homeGame || r.table == nil || !r.saveable {
+		return nil
+	}
+	report := r.table.HomeGameReport()
+	return &report
+}
+
+// settlementText prints the settlement, empty unless it is a home game
+func (r *gameRunner) settlementText() string {
+	report := r.settlement()
+	if report == nil {
+		return ""
+	}
+	var text strings.Builder
+	report.WriteFormatted(&text, r.format)
+	return strings.TrimRight(text.String(), "\n")
+}
+
+// canSave reports whether the game can be saved and resumed later
+func (r *gameRunner) canSave() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.saveable
+}
+
+// stopTable stops the game, waits for it and returns the table it was
+// played on. Bots still deciding read a clone of the game, so once stopped
+// the table is safe to change from the caller.
+func (r *gameRunner) stopTable() (*session.Session, bool) {
+	r.stop()
+	<-r.done
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.table, r.saveable
+}
+
+// saveTable stops the game and returns the table to carry on from later.
+// A hand in progress is abandoned first and its chips go back by abandonPolicy.
+func (r *gameRunner) saveTable() (*holdem.Snapshot, error) {
+	s, saveable := r.stopTable()
+	if s == nil || !saveable {
+		return nil, fmt.Errorf("only cash games can be saved")
+	}
+	game := s.GetGame()
+	if game.IsHandInProgress() {
+		if _, err := game.AbortHand(abandonPolicy); err != nil {
+			return nil, err
+		}
+	}
+	return r.snapshotTable(game)
+}
+
+// snapshotTable records the table between hands with the decision maker
+// of every seat, so resumeCashGame can bring the same bots back
+func (r *gameRunner) snapshotTable(game *holdem.Game) (*holdem.Snapshot, error) {
+	snapshot, err := game.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	for i := range snapshot.Seats {
+		snapshot.Seats[i].DecisionMaker = r.names[snapshot.Seats[i].PlayerID]
+	}
+	return snapshot, nil
+}
+
+// abandon stops the game, giving back the chips in the hand in progress by
+// abandonPolicy, and returns the stack the human leaves with
+func (r *gameRunner) abandon() (int, error) {
+	s, _ := r.stopTable()
+	if s == nil {
+		return 0, nil
+	}
+	game := s.GetGame()
+	if game.IsHandInProgress() {
+		if _, err := game.AbortHand(abandonPolicy); err != nil {
+			return 0, err
+		}
+	}
+	player, err := game.GetPlayerByID(humanPlayerID)
+	if err != nil {
+		return 0, nil // Busted
+	}
+	return player.GetChips(), nil
+}
+
+// wait blocks until the runner sends an update
+func (r *gameRunner) wait() tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-r.updates
+		msg.ok, msg.runner = ok, r
+		return msg
+	}
+}
+
+func (r *gameRunner) send(ctx context.Context, msg gameUpdateMsg) {
+	msg.avatars = r.avatars
+	r.lock.Lock()
+	held := r.held
+	r.lock.Unlock()
+	if held != nil {
+		select {
+		case <-held:
+		case <-ctx.Done():
+			return
+		}
+	}
+	select {
+	case r.updates <- msg:
+	case <-ctx.Done():
+	}
+}
+
+// playSitAndGo plays a single-table sit-and-go until the human busts or wins
+func (r *gameRunner) playSitAndGo(ctx context.Context, seats int, name string) (string, error) {
+	config, err := tournament.SitAndGo(seats, tournament.ProgressByClock, sngBuyIn)
+	if err != nil {
+		return "", err
+	}
+	if config.Structure, err = tournament.Structure(r.data.GetSettings().SNGBlinds); err != nil {
+		return "", err
+	}
+	config.Seed = time.Now().UnixNano()
+	config.Breaks = sngBreaks[r.data.GetSettings().SNGBreaks]
+	t, err := tournament.New(config)
+	if err != nil {
+		return "", err
+	}
+	if err := t.Register(humanPlayerID, name); err != nil {
+		return "", err
+	}
+	for id, botName := range r.addBots(seats - 1) {
+		if err := t.Register(id, botName); err != nil {
+			return "", err
+		}
+		if folder, ok := r.makers[id].(holdem_ai.IPushFolder); ok {
+			folder.SetPushFold(true)
+		}
+	}
+	if err := t.Start(); err != nil {
+		return "", err
+	}
+	r.status = func() string {
+		level := t.CurrentLevel()
+		status := fmt.Sprintf("Level %d · Blinds %d/%d", t.LevelIndex()+1, level.SmallBlind, level.BigBlind)
+		if level.Ante > 0 && config.Structure.BigBlindAnte {
+			status += fmt.Sprintf(" BB ante %d", level.Ante)
+		} else if level.Ante > 0 {
+			status += fmt.Sprintf(" ante %d", level.Ante)
+		}
+		if t.OnBreak() {
+			status += fmt.Sprintf(" · on break for %s", t.BreakLeft().Round(time.Second))
+		} else if left := t.TimeToNextLevel(); left > 0 {
+			status += fmt.Sprintf(" · next level in %s", left.Round(time.Second))
+		}
+		if next := t.TimeToBreak(); next > 0 {
+			status += fmt.Sprintf(" · break in %s", next.Round(time.Minute))
+		}
+		return status + fmt.Sprintf(" · %d/%d players left", t.Remaining(), seats)
+	}
+	r.level = func() int { return t.LevelIndex() + 1 }
+	r.lock.Lock()
+	r.clock = t
+	r.lock.Unlock()
+
+	game := t.Tables()[0]
+	game.SetLogger(r.logger)
+	s := session.New(game)
+	s.SetDecisionMakers(r.makers)
+	s.SetLogger(r.logger)
+	s.SetObserver(r.observer(ctx))
+	r.setTable(s, false)
+	if err := r.warmup(ctx, s); err != nil {
+		return "", err
+	}
+
+	for !t.IsFinished() {
+		if _, err := r.playHand(ctx, s); err != nil {
+			return "", err
+		}
+		r.saveReplay(game)
+		eliminated, moves, err := t.HandCompleted(0)
+		if err != nil {
+			return "", err
+		}
+		if lines := append(r.moveLines(t, moves), r.clockLines(t.ClockEvents())...); len(lines) > 0 {
+			r.send(ctx, gameUpdateMsg{view: game.PlayerView(humanPlayerID), status: r.status(), log: lines})
+		}
+		for _, standing := range eliminated {
+			if standing.PlayerID == humanPlayerID {
+				r.recordTournament(t.Standings())
+				return r.finishMessage(standing, seats), nil
+			}
+		}
+		if err := r.waitOutBreak(ctx, t, game); err != nil {
+			return "", err
+		}
+		if err := sleep(ctx, r.speed().hand); err != nil {
+			return "", err
+		}
+	}
+	r.recordTournament(t.Standings())
+	for _, standing := range t.Standings() {
+		if standing.PlayerID == humanPlayerID {
+			return r.finishMessage(standing, seats), nil
+		}
+	}
+	return "Tournament finished", nil
+}
+
+// playCashGame plays hands with the game setup blinds until the human
+// leaves or has no opponents left. Buy-ins, top-ups and re-buys go through
+// the session, which enforces the table rules.
+func (r *gameRunner) playCashGame(ctx context.Context, settings *SettingsData, name string) (string, error) {
+	config := cashGameConfig(settings)
+	config.Seed = time.Now().UnixNano()
+	s := r.cashSession(ctx, holdem.NewGameWithConfig(config), settings)
+	if err := s.SitDown(holdem.NewPlayer(humanPlayerID, name, settings.DefaultBuyIn), 0); err != nil {
+		return "", err
+	}
+	// Bots buy in as close to their lineup stack, or the default buy-in, as
+	// the rules allow
+	bots, stacks := map[int]string{}, map[int]int{}
+	if lineup, ok := settings.lineup(); ok {
+		var err error
+		if bots, stacks, err = r.addLineup(lineup); err != nil {
+			return "", err
+		}
+	} else {
+		bots = r.addBots(settings.NumBots)
+	}
+	for id, botName := range bots {
+		buyIn, ok := stacks[id]
+		if !ok {
+			buyIn = settings.DefaultBuyIn
+		}
+		if err := s.SitDown(holdem.NewPlayer(id, botName, clampBuyIn(config, buyIn)), id-1); err != nil {
+			return "", err
+		}
+	}
+	return r.playCashHands(ctx, s, settings, name)
+}
+
+// clampBuyIn brings chips within the buy-in limits of the table
+func clampBuyIn(config holdem.GameConfig, chips int) int {
+	minBuyIn, maxBuyIn := config.BuyInLimits()
+	chips = max(chips, minBuyIn)
+	if maxBuyIn > 0 {
+		chips = min(chips, maxBuyIn)
+	}
+	return chips
+}
+
+// cashGameConfig returns the table rules of a cash game with the game setup
+// blinds and game, those of its template or the default ones. Home games
+// are rake-free.
+func cashGameConfig(settings *SettingsData) holdem.GameConfig {
+	config := holdem.GameConfig{MinBuyInBB: cashMinBuyInBB, MaxBuyInBB: cashMaxBuyInBB}
+	if preset, ok := holdem.ConfigPresetNamed(settings.TablePreset); ok {
+		config = preset.Config
+	}
+	config.SmallBlind, config.BigBlind = settings.SmallBlind, settings.BigBlind
+	config.Variant = holdem.GameVariant(settings.Variant)
+	if settings.HomeGame {
+		config.RakePercent, config.RakeCap = 0, 0
+	}
+	return config
+}
+
+// resumeCashGame carries on a cash game saved with saveTable, with the same
+// bots in the same seats. The actions of replay, a hand interrupted by a
+// crash, are taken again in the first hand.
+func (r *gameRunner) resumeCashGame(ctx context.Context, snapshot *holdem.Snapshot, replay []holdem.LoggedAction, settings *SettingsData, name string) (string, error) {
+	game, err := holdem.RestoreGame(snapshot)
+	if err != nil {
+		return "", err
+	}
+	for _, seat := range snapshot.Seats {
+		if seat.PlayerID == humanPlayerID {
+			continue
+		}
+		maker, err := holdem_ai.CreateBotByName(seat.DecisionMaker, ranges.WithLibrary(r.data.rangeLibrary()))
+		if err != nil {
+			return "", fmt.Errorf("seat %d: %w", seat.Seat, err)
+		}
+		r.setupBot(maker)
+		r.makers[seat.PlayerID] = maker
+		r.names[seat.PlayerID] = seat.DecisionMaker
+		r.avatars[seat.PlayerID] = avatarFor(seat.DecisionMaker, seat.PlayerID, nil)
+	}
+	s := r.cashSession(ctx, game, settings)
+	s.SetButton(snapshot.Button)
+	line := fmt.Sprintf("Resuming after hand #%d", snapshot.HandNumber)
+	if len(replay) > 0 {
+		r.script = replay
+		line = fmt.Sprintf("Recovering hand #%d", snapshot.HandNumber+1)
+	}
+	r.send(ctx, gameUpdateMsg{
+		view:   game.PlayerView(humanPlayerID),
+		status: r.status(),
+		log:    []string{line},
+	})
+	return r.playCashHands(ctx, s, settings, name)
+}
+
+// warmup lets the bots prepare before the first hand, showing how far they
+// got in the status line
+func (r *gameRunner) warmup(ctx context.Context, s *session.Session) error {
+	game := s.GetGame()
+	return s.Warmup(ctx, func(done, total int) {
+		r.send(ctx, gameUpdateMsg{
+			view:   game.PlayerView(humanPlayerID),
+			status: r.translator.T("game.warming_up", done, total),
+		})
+	})
+}
+
+// cashSession sets up a cash game session with the table rules from the settings
+func (r *gameRunner) cashSession(ctx context.Context, game *holdem.Game, settings *SettingsData) *session.Session {
+	game.SetLogger(r.logger)
+	s := session.New(game)
+	s.SetDecisionMakers(r.makers)
+	s.SetLogger(r.logger)
+	s.SetObserver(r.observer(ctx))
+	if settings.BombPotEvery > 0 {
+		s.AddRule(session.NewBombPot(settings.BombPotEvery, bombPotAnteBB*settings.BigBlind))
+	}
+	if settings.SevenDeuceBountyBB > 0 {
+		bounty := session.NewSevenDeuceBounty(settings.SevenDeuceBountyBB * settings.BigBlind)
+		bounty.SetLogger(r.logger)
+		s.AddRule(bounty)
+	}
+	s.SetLimits(humanPlayerID, session.Limits{
+		StopLoss: settings.StopLossBB * settings.BigBlind,
+		StopWin:  settings.StopWinBB * settings.BigBlind,
+		Duration: time.Duration(settings.TimeLimitMinutes) * time.Minute,
+	})
+	if settings.TimedSessionMinutes > 0 {
+		s.SetEndTime(time.Now().Add(time.Duration(settings.TimedSessionMinutes) * time.Minute))
+	}
+	return s
+}
+
+// playCashHands deals hands until the human leaves or has no opponents left
+func (r *gameRunner) playCashHands(ctx context.Context, s *session.Session, settings *SettingsData, name string) (string, error) {
+	game := s.GetGame()
+	r.setTable(s, true)
+	minBuyIn, maxBuyIn := game.GetConfig().BuyInLimits()
+	r.status = func() string {
+		return fmt.Sprintf("%s · Blinds %d/%d · Buy-in %d-%d · %d players%s",
+			game.GetVariant(), game.GetSmallBlind(), game.GetBigBlind(), minBuyIn, maxBuyIn, len(game.GetAllPlayers()), r.sessionCountdown(s))
+	}
+	if err := r.warmup(ctx, s); err != nil {
+		return "", err
+	}
+
+	for {
+		r.checkpoint(game)
+		hand, err := r.playHand(ctx, s)
+		if err != nil {
+			return "", err
+		}
+		r.saveReplay(game)
+		if s.IsOver() {
+			return r.translator.T("game.session_over"), nil
+		}
+		// A stop-loss hit by busting is left to the re-buy prompt
+		for _, limit := range hand.Limits {
+			if player, err := game.GetPlayerByID(humanPlayerID); limit.PlayerID != humanPlayerID || err != nil || player.GetChips() == 0 {
+				continue
+			}
+			if result, err := r.offerCashOut(ctx, s, limitText(limit)); result != "" || err != nil {
+				return result, err
+			}
+		}
+		if r.dailyLimit {
+			r.dailyLimit = false
+			if result, err := r.offerCashOut(ctx, s, r.translator.T("game.daily_limit", settings.DailyHandLimit)); result != "" || err != nil {
+				return result, err
+			}
+		}
+		for _, player := range s.RemoveBusted() {
+			if player.GetID() != humanPlayerID {
+				continue
+			}
+			if result, err := r.rebuy(ctx, s, name, settings.DefaultBuyIn); result != "" || err != nil {
+				return result, err
+			}
+		}
+		if len(game.GetAllPlayers()) < 2 {
+			return "You cleaned out the table!", nil
+		}
+		if err := r.pauseForRequests(ctx, s); err != nil {
+			return "", err
+		}
+	}
+}
+
+// sessionCountdown writes what is left of a timed session for the status
+// line, e.g. " · Ends in 42 min" or " · Last hand", empty when it is not timed
+func (r *gameRunner) sessionCountdown(s *session.Session) string {
+	end := s.GetEndTime()
+	switch left := s.HandsLeft(); {
+	case end.IsZero():
+		return ""
+	case left == 1:
+		return " · " + r.translator.T("game.last_hand")
+	case left > 1:
+		return " · " + r.translator.T("game.hands_left", left)
+	}
+	return " · " + r.translator.T("game.session_ends", int(max(time.Until(end), 0).Round(time.Minute).Minutes()))
+}
+
+// rebuy waits until the busted human buys in again. It returns a result
+// when the table rules do not allow another buy-in.
+func (r *gameRunner) rebuy(ctx context.Context, s *session.Session, name string, buyIn int) (string, error) {
+	r.send(ctx, gameUpdateMsg{
+		view:   s.GetGame().PlayerView(humanPlayerID),
+		status: r.status(),
+		log:    []string{"You are out of chips"},
+		busted: true,
+	})
+	for {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case req := <-r.requests:
+			if req != requestRebuy {
+				continue
+			}
+			err := s.SitDown(holdem.NewPlayer(humanPlayerID, name, buyIn), 0)
+			var ruleErr *session.RuleError
+			switch {
+			case err == nil:
+				r.send(ctx, gameUpdateMsg{
+					view:   s.GetGame().PlayerView(humanPlayerID),
+					status: r.status(),
+					log:    []string{fmt.Sprintf("You buy in again for %d", buyIn)},
+				})
+				return "", nil
+			case errors.As(err, &ruleErr) && ruleErr.Rule == session.RuleReentry:
+				return "You are out of chips and re-entries", nil
+			default:
+				r.send(ctx, gameUpdateMsg{
+					view:   s.GetGame().PlayerView(humanPlayerID),
+					status: r.status(),
+					log:    []string{"Buy-in refused: " + err.Error()},
+					busted: true,
+				})
+			}
+		}
+	}
+}
+
+// offerCashOut waits for the human to cash out or play on after reaching a
+// session or daily limit, described by text. It returns a result when they
+// cash out.
+func (r *gameRunner) offerCashOut(ctx context.Context, s *session.Session, text string) (string, error) {
+	r.send(ctx, gameUpdateMsg{
+		view:   s.GetGame().PlayerView(humanPlayerID),
+		status: r.status(),
+		limit:  text,
+	})
+	for {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case req := <-r.requests:
+			switch req {
+			case requestCashOut:
+				chips, err := s.StandUp(humanPlayerID)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("You cashed out %d chips, %+d on the session", chips, s.GetSessionNet(humanPlayerID)), nil
+			case requestKeepPlaying:
+				return "", nil
+			}
+		}
+	}
+}
+
+// limitText describes a reached session limit, e.g. "Stop-loss reached: down 500"
+func limitText(limit session.LimitReached) string {
+	text := limit.String()
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+// pauseForRequests leaves the finished hand on screen, applying top-ups
+// and undos asked for in the meantime
+func (r *gameRunner) pauseForRequests(ctx context.Context, s *session.Session) error {
+	deadline := time.After(r.speed().hand)
+	for {
+		select {
+		case <-deadline:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case req := <-r.requests:
+			msg := gameUpdateMsg{status: r.status()}
+			switch req {
+			case requestTopUp:
+				line, err := r.topUp(s)
+				if err != nil {
+					return err
+				}
+				msg.log = []string{line}
+			case requestUndo:
+				msg.log = []string{r.undoHand(s)}
+				msg.session = r.sessionInfo(s.GetGame(), false)
+			case requestChangeSeat:
+				line := r.changeSeat(s)
+				if line == "" {
+					continue // The seat change event brought the view up to date
+				}
+				msg.log = []string{line}
+			case requestSwapBot:
+				line := r.applySwap(s)
+				if line == "" {
+					continue
+				}
+				msg.log = []string{line}
+			default:
+				continue
+			}
+			msg.view = s.GetGame().PlayerView(humanPlayerID)
+			r.send(ctx, msg)
+		}
+	}
+}
+
+// topUp brings the human to the maximum buy-in and returns the log line saying so
+func (r *gameRunner) topUp(s *session.Session) (string, error) {
+	player, err := s.GetGame().GetPlayerByID(humanPlayerID)
+	if err != nil {
+		return "", err
+	}
+	_, maxBuyIn := s.GetGame().GetConfig().BuyInLimits()
+	amount := maxBuyIn - player.GetChips()
+	if amount <= 0 {
+		return "Already at the maximum buy-in", nil
+	}
+	if err := s.TopUp(humanPlayerID, amount); err != nil {
+		return "Top-up refused: " + err.Error(), nil
+	}
+	return fmt.Sprintf("You top up %d", amount), nil
+}
+
+// changeSeat moves the human to the next empty seat clockwise and returns
+// the log line saying why it could not, "" when it did
+func (r *gameRunner) changeSeat(s *session.Session) string {
+	game := s.GetGame()
+	from, err := game.GetPlayerSitByID(humanPlayerID)
+	if err != nil {
+		return "Seat change refused: " + err.Error()
+	}
+	seats := game.GetConfig().Seats()
+	for i := 1; i < seats; i++ {
+		seat := (from + i) % seats
+		if _, err := game.GetPlayerBySit(seat); err == nil {
+			continue
+		}
+		if err := s.MovePlayer(humanPlayerID, seat); err != nil {
+			return "Seat change refused: " + err.Error()
+		}
+		return ""
+	}
+	return "No empty seat to move to"
+}
+
+// undoHand takes back the hand just played, for a misdeal or a rules
+// dispute found after the pot was pushed, and returns the log line saying
+// so. The hand is left out of exports too.
+func (r *gameRunner) undoHand(s *session.Session) string {
+	hand, err := s.UndoLastHand()
+	if err != nil {
+		return "Undo refused: " + err.Error()
+	}
+	r.net = 0
+	for id, chips := range r.last {
+		r.chips[id] -= chips
+	}
+	r.last = nil
+	r.lock.Lock()
+	if n := len(r.hands); n > 0 && r.hands[n-1].HandNumber == hand {
+		r.hands = r.hands[:n-1]
+	}
+	r.lock.Unlock()
+	r.logger.Info("hand undone at the human's request", slog.Int("hand", hand))
+	return fmt.Sprintf("Hand #%d undone, the stacks are back as they were", hand)
+}
+
+// observer turns session events into updates for the view
+func (r *gameRunner) observer(ctx context.Context) session.Observer {
+	return func(event session.Event, game *holdem.Game) {
+		if r.hud != nil {
+			r.hud.Observe(event, game)
+		}
+		msg := gameUpdateMsg{
+			view:   game.PlayerView(humanPlayerID),
+			status: r.status(),
+			debug:  r.debugState(game, 0),
+		}
+		switch event.Type {
+		case session.EventHandStarted:
+			r.paceBots()
+			r.handStarted = time.Now()
+			msg.session = r.sessionInfo(game, true)
+			msg.log = []string{r.translator.T("log.hand", game.GetHandNumber())}
+			if line := r.bigBlindAnteLine(game); line != "" {
+				msg.log = append(msg.log, line)
+			}
+			if board := game.GetCommunityCards(); len(board) > 0 {
+				msg.log = append(msg.log, r.translator.T("log.bomb_pot", component.RenderCards(r.cards, board)))
+			}
+		case session.EventTurn:
+			if r.scripted(event.PlayerID) {
+				if r.debugging.Load() {
+					// Show the debug console who the hand waits for
+					msg.debug = r.debugState(game, event.PlayerID)
+					r.send(ctx, msg)
+				}
+				return
+			}
+			msg.turn = &turnClock{player: event.PlayerID, timeout: event.Timeout}
+			if event.PlayerID != humanPlayerID {
+				// Time the bot under its seat while it thinks
+				if r.debugging.Load() {
+					msg.debug = r.debugState(game, event.PlayerID)
+				}
+				r.send(ctx, msg)
+				return
+			}
+			if player, err := game.GetPlayerByID(humanPlayerID); err == nil {
+				if _, ok := r.human.AutoAnswer(game, player); ok {
+					r.auto = true
+					return
+				}
+			}
+			msg.prompt = r.prompt(game)
+			if msg.prompt != nil {
+				msg.prompt.option = event.Option
+				msg.reads = r.reads(game)
+			}
+			msg.debug = r.debugState(game, humanPlayerID)
+		case session.EventAction:
+			r.recordAction(holdem.LoggedAction{Phase: game.GetCurrentPhase(), Action: event.Action, Elapsed: event.Elapsed})
+			line := r.describeAction(game, event.Action)
+			switch {
+			case event.TimedOut:
+				line += " (timed out)"
+			case r.replayed:
+				line += " (replayed)"
+				r.replayed = false
+			case r.auto && event.PlayerID == humanPlayerID:
+				line += " (auto)"
+				r.auto = false
+			case event.PlayerID != humanPlayerID && event.Elapsed > 0:
+				// Bots think for longer over some decisions than others
+				line += fmt.Sprintf(" (%.1fs)", event.Elapsed.Seconds())
+			}
+			msg.log = []string{line}
+		case session.EventStreet:
+			msg.log = []string{fmt.Sprintf("%s: %s", holdem.PhaseName(r.translator, game.GetCurrentPhase()), component.RenderCards(r.cards, game.GetCommunityCards()))}
+			r.send(ctx, msg)
+			// Leave the new cards on screen before anyone acts on them,
+			// unless the actions are replayed
+			if len(r.script) == 0 {
+				sleep(ctx, r.speed().street)
+			}
+			return
+		case session.EventTimeWarning:
+			msg.warning = true
+			msg.log = []string{r.translator.T("log.time_warning", r.playerName(game, event.PlayerID), int(event.Left.Seconds()))}
+		case session.EventLastHands:
+			if event.HandsLeft == 1 {
+				msg.log = []string{r.translator.T("log.last_hand")}
+			} else {
+				msg.log = []string{r.translator.T("log.last_hands", event.HandsLeft)}
+			}
+		case session.EventSessionLimitReached:
+			if event.PlayerID != humanPlayerID || event.Limit == nil {
+				return
+			}
+			msg.log = []string{limitText(*event.Limit)}
+		case session.EventHandFinished:
+			r.played++
+			r.net = event.Net[humanPlayerID]
+			r.last = event.Net
+			for id, chips := range event.Net {
+				r.chips[id] += chips
+			}
+			msg.session = r.sessionInfo(game, false)
+			msg.summary = r.summarize(game, event)
+			for _, award := range event.Awards {
+				msg.log = append(msg.log, r.describeAward(game, award))
+				// The first pot won at showdown is the main pot, which the best hand takes
+				if award.Hand != nil && msg.winning == nil {
+					msg.winner, msg.winning = award.Winners[0], award.Hand
+				}
+			}
+			for _, bounty := range event.Bounties {
+				msg.log = append(msg.log, r.translator.T("log.bounty", r.playerName(game, bounty.PlayerID), r.format.Format(bounty.Amount)))
+			}
+			if net, dealt := event.Net[humanPlayerID]; dealt {
+				if reminder := r.recordPlay(net); reminder != "" {
+					msg.log = append(msg.log, reminder)
+				}
+			}
+		case session.EventSeatChanged:
+			msg.log = []string{r.translator.T("log.seat_changed", r.playerName(game, event.PlayerID), event.Seat+1)}
+		case session.EventShowdown:
+			player, err := game.GetPlayerByID(event.PlayerID)
+			if err != nil {
+				return
+			}
+			if event.Shown {
+				msg.log = []string{r.translator.T("log.shows", r.playerName(game, event.PlayerID), component.RenderCards(r.cards, player.GetHandCards()))}
+			} else {
+				msg.log = []string{r.translator.T("log.mucks", r.playerName(game, event.PlayerID))}
+			}
+		case session.EventChat:
+			// Checked here rather than when seating bots, so silencing
+			// them takes effect mid-game
+			if !r.data.GetSettings().TableTalk {
+				return
+			}
+			msg.log = []string{r.translator.T("log.says", r.playerName(game, event.PlayerID), r.translator.T(event.Message))}
+		}
+		r.send(ctx, msg)
+	}
+}
+
+// sessionInfo sums up the session for the status bar. While a hand is on,
+// the chips the human put in count towards their stack.
+func (r *gameRunner) sessionInfo(game *holdem.Game, handOn bool) *sessionInfo {
+	info := &sessionInfo{hands: r.played, net: r.net, level: r.level()}
+	if player, err := game.GetPlayerByID(humanPlayerID); err == nil {
+		info.stack = player.GetChips()
+		if handOn {
+			info.stack += player.GetTotalBet()
+		}
+	}
+	return info
+}
+
+// autoActions turns the auto action settings into the human's standing answers
+func autoActions(settings *SettingsData) holdem_ai.AutoActions {
+	return holdem_ai.AutoActions{
+		Muck:   settings.AutoMuck,
+		Check:  settings.AutoCheck,
+		CallBB: settings.AutoCallBB,
+	}
+}
+
+// prompt describes the human's options at their turn
+func (r *gameRunner) prompt(game *holdem.Game) *actionPrompt {
+	player, err := game.GetPlayerByID(humanPlayerID)
+	if err != nil {
+		return nil
+	}
+	constraints := r.human.GetConstraints(game, player)
+	return &actionPrompt{
+		actions:  constraints.Actions,
+		bet:      player.GetBet(),
+		call:     constraints.Call,
+		chips:    constraints.AllIn,
+		minRaise: constraints.MinRaise - constraints.Call,
+		sizes:    holdem.LegalRaiseSizes(game, player),
+		bigBlind: game.GetBigBlind(),
+		hand:     game.GetHandNumber(),
+		action:   len(game.GetHandActionLog()),
+	}
+}
+
+// handEquity works out the human's equity street by street in the finished
+// hand, for the hand summary
+func (r *gameRunner) handEquity(game *holdem.Game) []analysis.StreetEquity {
+	replay, err := holdem.NewReplay(game, r.names)
+	if err != nil {
+		r.logger.Warn("recording hand for the equity graph failed", slog.Any("error", err))
+		return nil
+	}
+	hand, err := reviewHand(replay)
+	if err != nil {
+		r.logger.Warn("working out the equity graph failed", slog.Any("error", err))
+		return nil
+	}
+	return hand.equity
+}
+
+// saveReplay writes the finished hand for review from the main menu, keeps
+// it for exportSession and queues the decisions bookmarked in it
+func (r *gameRunner) saveReplay(game *holdem.Game) {
+	replay, err := holdem.NewReplay(game, r.names)
+	if err == nil {
+		err = replay.RecordStateHashes()
+	}
+	if err != nil {
+		r.logger.Warn("recording hand failed", slog.Any("error", err))
+		return
+	}
+	r.recordHand(replay)
+	r.saveBookmarks(replay)
+	settings := r.data.GetSettings()
+	if !settings.AutoSave || settings.ReplayFile == "" {
+		return
+	}
+	if err := replay.Save(r.data.Path(settings.ReplayFile)); err != nil {
+		r.logger.Warn("saving replay failed", slog.Any("error", err))
+	}
+}
+
+// describeAction renders an action as a log line, e.g. "Maniac raises to 40"
+func (r *gameRunner) describeAction(game *holdem.Game, action holdem.Action) string {
+	name := r.playerName(game, action.PlayerID)
+	switch action.Type {
+	case holdem.ActionFold:
+		return r.translator.T("log.folds", name)
+	case holdem.ActionCheck:
+		return r.translator.T("log.checks", name)
+	case holdem.ActionCall:
+		return r.translator.T("log.calls", name, r.format.Format(action.Amount))
+	case holdem.ActionRaise:
+		return r.translator.T("log.raises", name, r.format.Format(action.RaiseTo))
+	case holdem.ActionAllIn:
+		return r.translator.T("log.all_in", name, r.format.Format(action.Amount))
+	default:
+		return fmt.Sprintf("%s: %s %d", name, holdem.ActionName(r.translator, action.Type), action.Amount)
+	}
+}
+
+// bigBlindAnteLine announces the ante the big blind posted for the whole
+// table, "" when the hand has none
+func (r *gameRunner) bigBlindAnteLine(game *holdem.Game) string {
+	if !game.GetConfig().BigBlindAnte {
+		return ""
+	}
+	for _, action := range game.GetUserActions().Preflop {
+		if action.Type == holdem.ActionPostAnte {
+			return r.translator.T("log.big_blind_ante", r.playerName(game, action.PlayerID), r.format.Format(action.Amount))
+		}
+	}
+	return ""
+}
+
+// describeAward renders a pot award as a log line
+func (r *gameRunner) describeAward(game *holdem.Game, award holdem.PotAward) string {
+	names := make([]string, 0, len(award.Winners))
+	for _, id := range award.Winners {
+		names = append(names, r.playerName(game, id))
+	}
+	winners := strings.Join(names, r.translator.T("log.and"))
+	if award.Hand != nil {
+		return r.translator.T("log.wins_with", winners, r.format.Format(award.Amount), holdem.HandRankName(r.translator, award.Hand.Rank))
+	}
+	return r.translator.T("log.wins", winners, r.format.Format(award.Amount))
+}
+
+// playerName renders a player's name behind their avatar for the log
+func (r *gameRunner) playerName(game *holdem.Game, playerID int) string {
+	name := r.translator.T("log.player", playerID)
+	if player, err := game.GetPlayerByID(playerID); err == nil && player.GetName() != "" {
+		name = player.GetName()
+	}
+	avatar, ok := r.avatars[playerID]
+	if !ok {
+		avatar = component.DefaultAvatar(playerID)
+	}
+	return avatar.Badge(name, r.plain)
+}
+
+// moveLines announces the players moved by table balancing or seated again
+// for the final table, e.g. "You are moving to Table 2, seat 5"
+func (r *gameRunner) moveLines(t *tournament.Tournament, moves []tournament.Move) []string {
+	lines := make([]string, 0, len(moves))
+	for _, move := range moves {
+		game := t.Tables()[move.ToTable]
+		switch {
+		case move.Reason == tournament.MoveRedraw && move.PlayerID == humanPlayerID:
+			lines = append(lines, r.translator.T("log.redraw_you", move.ToSeat+1))
+		case move.Reason == tournament.MoveRedraw:
+			lines = append(lines, r.translator.T("log.redraw", r.playerName(game, move.PlayerID), move.ToSeat+1))
+		case move.PlayerID == humanPlayerID:
+			lines = append(lines, r.translator.T("log.move_you", move.ToTable+1, move.ToSeat+1))
+		default:
+			lines = append(lines, r.translator.T("log.move", r.playerName(game, move.PlayerID), move.ToTable+1))
+		}
+	}
+	return lines
+}
+
+// waitOutBreak holds the table while the tournament is on a break,
+// counting it down in the status bar, and announces the end of it
+func (r *gameRunner) waitOutBreak(ctx context.Context, t *tournament.Tournament, game *holdem.Game) error {
+	if !t.OnBreak() {
+		return nil
+	}
+	for t.OnBreak() {
+		r.send(ctx, gameUpdateMsg{view: game.PlayerView(humanPlayerID), status: r.status()})
+		if err := sleep(ctx, min(t.BreakLeft(), time.Second)); err != nil {
+			return err
+		}
+		t.Tick()
+	}
+	r.send(ctx, gameUpdateMsg{view: game.PlayerView(humanPlayerID), status: r.status(), log: r.clockLines(t.ClockEvents())})
+	return nil
+}
+
+// clockLines announces the changes of the tournament clock
+func (r *gameRunner) clockLines(events []tournament.ClockEvent) []string {
+	lines := make([]string, 0, len(events))
+	for _, event := range events {
+		left := event.Left.Round(time.Second).String()
+		switch event.Type {
+		case tournament.ClockPaused:
+			lines = append(lines, r.translator.T("log.clock_paused", left))
+		case tournament.ClockResumed:
+			lines = append(lines, r.translator.T("log.clock_resumed"))
+		case tournament.BreakStarted:
+			lines = append(lines, r.translator.T("log.break_started", left))
+		case tournament.BreakEnded:
+			lines = append(lines, r.translator.T("log.break_ended", event.Level+1, left))
+		case tournament.LevelStarted:
+			lines = append(lines, r.translator.T("log.level_up", event.Level+1, r.format.Format(event.Blinds.SmallBlind), r.format.Format(event.Blinds.BigBlind)))
+		}
+	}
+	return lines
+}
+
+// finishMessage tells the human where they finished a tournament
+func (r *gameRunner) finishMessage(standing tournament.Standing, entrants int) string {
+	message := r.translator.T("game.finished", standing.Place, entrants)
+	if standing.Place == 1 {
+		message = r.translator.T("game.won_sng")
+	}
+	if standing.Prize > 0 {
+		message += r.translator.T("game.prizes", r.format.Format(standing.Prize))
+	}
+	return message
+}
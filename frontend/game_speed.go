@@ -0,0 +1,84 @@
+package frontend
+
+import (
+	"context"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+)
+
+// gameSpeed is how long the table waits before moving on by itself
+type gameSpeed struct {
+	botMin, botMax time.Duration // Range of the bots' thinking delay
+	street         time.Duration // Pause after the flop, turn and river are dealt
+	hand           time.Duration // How long the finished hand stays on screen
+}
+
+// gameSpeedNames lists the speeds in the order the settings cycle through them
+var gameSpeedNames = []string{"instant", "fast", "normal", "slow"}
+
+// gameSpeeds are the game speed settings, "normal" is the default
+var gameSpeeds = map[string]gameSpeed{
+	"instant": {},
+	"fast":    {botMin: 150 * time.Millisecond, botMax: 600 * time.Millisecond, street: 250 * time.Millisecond, hand: time.Second},
+	"normal":  {botMin: 500 * time.Millisecond, botMax: 2 * time.Second, street: 500 * time.Millisecond, hand: 2500 * time.Millisecond},
+	"slow":    {botMin: time.Second, botMax: 4 * time.Second, street: 1500 * time.Millisecond, hand: 5 * time.Second},
+}
+
+// speedNamed returns the speed with a name, "normal" for unknown names such
+// as the empty one in settings saved before the speed could be chosen
+func speedNamed(name string) gameSpeed {
+	if speed, ok := gameSpeeds[name]; ok {
+		return speed
+	}
+	return gameSpeeds["normal"]
+}
+
+// nextGameSpeed steps through the speeds from name, wrapping around.
+// Unknown names count as "normal".
+func nextGameSpeed(name string, delta int) string {
+	current := 2
+	for i, speed := range gameSpeedNames {
+		if speed == name {
+			current = i
+		}
+	}
+	return gameSpeedNames[((current+delta)%len(gameSpeedNames)+len(gameSpeedNames))%len(gameSpeedNames)]
+}
+
+// setSpeed changes the game speed; bots pick it up from the next hand
+func (r *gameRunner) setSpeed(speed gameSpeed) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.pace = speed
+}
+
+func (r *gameRunner) speed() gameSpeed {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.pace
+}
+
+// paceBots gives the bots the thinking delay of the current speed. Called
+// from the runner goroutine between decisions, so no bot is thinking.
+func (r *gameRunner) paceBots() {
+	speed := r.speed()
+	for _, maker := range r.makers {
+		if bot, ok := maker.(*holdem_ai.BasicBotDecisionMaker); ok {
+			bot.SetThinkingTime(speed.botMin, speed.botMax)
+		}
+	}
+}
+
+// sleep waits for d unless ctx is cancelled first
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
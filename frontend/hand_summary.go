@@ -0,0 +1,160 @@
+package frontend
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/analysis"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/session"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// handSummary is what the popup after each hand shows
+type handSummary struct {
+	hand    int
+	awards  []string    // One line per pot
+	board   poker.Cards // Final board
+	winner  string      // Name of the main pot's winner
+	hole    poker.Cards // Hole cards of the main pot's winner, empty when not shown
+	winning poker.Cards // Cards making the winning hand, empty when uncontested
+	pot     int
+	net     int    // The human's result
+	note    string // How much equity the human's chips went in with
+	equity  []analysis.StreetEquity
+}
+
+// summarize describes a finished hand for the popup
+func (r *gameRunner) summarize(game *holdem.Game, event session.Event) *handSummary {
+	summary := &handSummary{
+		hand:   game.GetHandNumber(),
+		board:  append(poker.Cards{}, game.GetCommunityCards()...),
+		net:    event.Net[humanPlayerID],
+		equity: r.handEquity(game),
+	}
+	for _, award := range event.Awards {
+		summary.awards = append(summary.awards, r.describeAward(game, award))
+		summary.pot += award.Amount + award.Rake
+	}
+	if len(event.Awards) > 0 && len(event.Awards[0].Winners) > 0 {
+		main := event.Awards[0]
+		summary.winner = r.playerName(game, main.Winners[0])
+		if main.Hand != nil {
+			summary.winning = main.Hand.Cards
+			if player, err := game.GetPlayerByID(main.Winners[0]); err == nil {
+				summary.hole = append(poker.Cards{}, player.GetHandCards()...)
+			}
+		}
+	}
+	summary.note = r.equityNote(game, summary.equity)
+	return summary
+}
+
+// equityNote tells the human how good their chips were when they last put
+// some in, e.g. "You got it in on the flop with 80% equity"
+func (r *gameRunner) equityNote(game *holdem.Game, streets []analysis.StreetEquity) string {
+	actions := game.GetUserActions()
+	last, allIn, invested := holdem.PhasePreflop, false, false
+	for phase := holdem.PhasePreflop; phase <= holdem.PhaseRiver; phase++ {
+		for _, action := range actions.Street(phase) {
+			if action.PlayerID != humanPlayerID {
+				continue
+			}
+			switch action.Type {
+			case holdem.ActionAllIn:
+				allIn = true
+				fallthrough
+			case holdem.ActionCall, holdem.ActionRaise:
+				last, invested = phase, true
+			}
+		}
+	}
+	if !invested {
+		return ""
+	}
+	for _, street := range streets {
+		if street.Phase != last {
+			continue
+		}
+		key := "summary.last_chips"
+		if allIn {
+			key = "summary.all_in"
+		}
+		return r.translator.T(key, holdem.PhaseName(r.translator, last), street.Equity*100)
+	}
+	return ""
+}
+
+// renderSummary draws the popup for the finished hand
+func (v *GameView) renderSummary(width int) string {
+	s := v.summary
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(v.model.T("summary.title", s.hand, v.model.Chips().Format(s.pot)))}
+	lines = append(lines, s.awards...)
+	if len(s.winning) > 0 {
+		lines = append(lines, "", v.model.T("summary.board", v.highlightCards(s.board, s.winning)))
+		if len(s.hole) > 0 {
+			lines = append(lines, s.winner+": "+v.highlightCards(s.hole, s.winning))
+		}
+	}
+
+	lines = append(lines, "")
+	switch {
+	case s.net > 0:
+		lines = append(lines, v.model.T("summary.won", v.model.Chips().Format(s.net)))
+	case s.net < 0:
+		lines = append(lines, v.model.T("summary.lost", v.model.Chips().Format(-s.net)))
+	default:
+		lines = append(lines, v.model.T("summary.even"))
+	}
+	if s.note != "" {
+		lines = append(lines, s.note)
+	}
+	v.graph.SetStreets(s.equity)
+	v.graph.SetPlain(v.model.Accessible())
+	if graph := v.graph.Render(); graph != "" {
+		lines = append(lines, "", graph)
+	}
+	lines = append(lines, "", lipgloss.NewStyle().Italic(true).Render(v.model.T("summary.dismiss")))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#F59E0B")). // Yellow/Orange
+		Padding(0, 2)
+	if v.model.Accessible() {
+		box = lipgloss.NewStyle()
+	}
+	return box.MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
+// highlightCards renders cards, marking the ones in the winning hand: in
+// reverse video, or in words in accessibility mode
+func (v *GameView) highlightCards(cards, winning poker.Cards) string {
+	renderer := v.model.Cards()
+	if len(cards) == 0 {
+		return component.RenderCards(renderer, cards)
+	}
+	highlight := lipgloss.NewStyle().Reverse(true).Bold(true)
+	parts := make([]string, 0, len(cards))
+	for _, card := range cards {
+		text := renderer.Card(card)
+		if containsCard(winning, card) {
+			if v.model.Accessible() {
+				text = v.model.T("summary.winning_card", text)
+			} else {
+				text = highlight.Render(text)
+			}
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, renderer.Separator())
+}
+
+func containsCard(cards poker.Cards, card *poker.Card) bool {
+	for _, c := range cards {
+		if c.Suit == card.Suit && c.Rank == card.Rank {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+package frontend
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var update = flag.Bool("update", false, "rewrite snapshot files")
+
+// harnessTimeout bounds how long WaitFor waits for the screen to change
+const harnessTimeout = 5 * time.Second
+
+// harnessClock is the time of day the game view shows in tests
+var harnessClock = time.Date(2026, time.January, 2, 20, 30, 0, 0, time.UTC)
+
+// tuiHarness drives a model the way the Bubble Tea runtime does: messages
+// go through Update and the commands they return run in the background,
+// feeding their messages back in. Tests send synthetic key presses and
+// window sizes and look at the rendered screen.
+type tuiHarness struct {
+	t     *testing.T
+	model *Model
+	msgs  chan tea.Msg
+	done  chan struct{}
+}
+
+// newTestModel creates a model on its own in-memory store, so no state is
+// shared between tests, with the given settings changed from the defaults.
+// Any game it starts is stopped when the test ends.
+func newTestModel(t *testing.T, settings map[string]any) *Model {
+	t.Helper()
+	data := NewData(NewMemoryStore())
+	for key, value := range settings {
+		data.UpdateSetting(key, value)
+	}
+	model := NewModel(data)
+	t.Cleanup(func() {
+		if gv, ok := model.gameView.(*GameView); ok {
+			gv.stop()
+		}
+	})
+	return model
+}
+
+// newTUIHarness creates a model on an in-memory store with a window of the given size
+func newTUIHarness(t *testing.T, width, height int) *tuiHarness {
+	t.Helper()
+	h := &tuiHarness{
+		t:     t,
+		model: newTestModel(t, nil),
+		msgs:  make(chan tea.Msg, 64),
+		done:  make(chan struct{}),
+	}
+	if gv, ok := h.model.gameView.(*GameView); ok {
+		// Keep the clocks out of the snapshots and the bell quiet
+		gv.clock = func() time.Time { return harnessClock }
+		gv.bell = func() {}
+	}
+	t.Cleanup(func() { close(h.done) })
+	h.run(h.model.Init())
+	h.Send(tea.WindowSizeMsg{Width: width, Height: height})
+	return h
+}
+
+// Send passes a message to the model and runs the command it returns
+func (h *tuiHarness) Send(msg tea.Msg) {
+	_, cmd := h.model.Update(msg)
+	h.run(cmd)
+}
+
+// Keys sends key presses by name: "enter", "esc", "up" and the other
+// special keys, or the typed characters
+func (h *tuiHarness) Keys(keys ...string) {
+	for _, name := range keys {
+		h.Send(keyMsg(name))
+	}
+}
+
+// Press sends the same key a number of times
+func (h *tuiHarness) Press(name string, times int) {
+	for i := 0; i < times; i++ {
+		h.Send(keyMsg(name))
+	}
+}
+
+// Click presses the left mouse button on the first place the screen shows
+// text
+func (h *tuiHarness) Click(text string) {
+	h.t.Helper()
+	for y, row := range strings.Split(h.Screen(), "\n") {
+		row = ansi.Strip(row)
+		if i := strings.Index(row, text); i >= 0 {
+			h.Send(tea.MouseMsg{X: ansi.StringWidth(row[:i]), Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+			return
+		}
+	}
+	h.t.Fatalf("Nothing to click on shows %q, screen:\n%s", text, h.Screen())
+}
+
+// Wheel turns the mouse wheel a number of notches, up when negative
+func (h *tuiHarness) Wheel(notches int) {
+	button := tea.MouseButtonWheelDown
+	if notches < 0 {
+		button, notches = tea.MouseButtonWheelUp, -notches
+	}
+	for i := 0; i < notches; i++ {
+		h.Send(tea.MouseMsg{Action: tea.MouseActionPress, Button: button})
+	}
+}
+
+// Screen returns the current rendering
+func (h *tuiHarness) Screen() string {
+	return h.model.View()
+}
+
+// WaitFor processes messages until the screen shows text and returns it
+func (h *tuiHarness) WaitFor(text string) string {
+	h.t.Helper()
+	deadline := time.After(harnessTimeout)
+	for {
+		if screen := h.Screen(); strings.Contains(screen, text) {
+			return screen
+		}
+		select {
+		case msg := <-h.msgs:
+			h.Send(msg)
+		case <-deadline:
+			h.t.Fatalf("Timed out waiting for %q, screen:\n%s", text, h.Screen())
+		}
+	}
+}
+
+// Snapshot compares the screen with testdata/snapshots/name.txt,
+// rewriting the file with -update
+func (h *tuiHarness) Snapshot(name string) {
+	h.t.Helper()
+	screen := trimLines(h.Screen())
+	path := filepath.Join("testdata", "snapshots", name+".txt")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			h.t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(screen), 0o644); err != nil {
+			h.t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		h.t.Fatalf("Reading %s failed, create it with -update: %v", path, err)
+	}
+	if screen != string(want) {
+		h.t.Errorf("%s is out of date; if the change is intended, run go test -update and review the diff\ngot:\n%s\nwant:\n%s", path, screen, want)
+	}
+}
+
+// run executes a command in the background and queues its messages
+func (h *tuiHarness) run(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	go func() {
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, cmd := range batch {
+				h.run(cmd)
+			}
+			return
+		}
+		if msg == nil {
+			return
+		}
+		select {
+		case h.msgs <- msg:
+		case <-h.done:
+		}
+	}()
+}
+
+// keyNames are the special keys Keys understands
+var keyNames = map[string]tea.KeyType{
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEsc,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"tab":       tea.KeyTab,
+	"shift+tab": tea.KeyShiftTab,
+	"backspace": tea.KeyBackspace,
+	"space":     tea.KeySpace,
+	"ctrl+d":    tea.KeyCtrlD,
+	"ctrl+g":    tea.KeyCtrlG,
+	"pgup":      tea.KeyPgUp,
+	"pgdown":    tea.KeyPgDown,
+}
+
+func keyMsg(name string) tea.KeyMsg {
+	if keyType, ok := keyNames[name]; ok {
+		return tea.KeyMsg{Type: keyType}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+}
+
+// trimLines drops trailing spaces, which depend on padding rather than content
+func trimLines(screen string) string {
+	lines := strings.Split(screen, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
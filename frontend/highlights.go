@@ -0,0 +1,127 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/analysis"
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/handhistory"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// highlightOptions keep picking a session's highlights quick, and the same
+// every time
+var highlightOptions = equity.Options{Samples: 2000, Seed: 1}
+
+// maxHighlights is how many highlights a game lists, one for each of the
+// keys 1 to 9
+const maxHighlights = 9
+
+// sessionHighlight is a notable hand of the session with its replay, to
+// review it
+type sessionHighlight struct {
+	analysis.Highlight
+	replay *holdem.Replay
+}
+
+// replays returns the hands played at the table so far
+func (r *gameRunner) replays() []*holdem.Replay {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*holdem.Replay{}, r.hands...)
+}
+
+// sessionHighlights picks the biggest pot, the worst beat, the best bluff
+// and the bad beats and coolers among the hands of a session, up to
+// maxHighlights
+func sessionHighlights(ctx context.Context, replays []*holdem.Replay) ([]sessionHighlight, error) {
+	hands := make([]*handhistory.Hand, 0, len(replays))
+	for _, replay := range replays {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		hand, err := handhistory.FromReplay(replay)
+		if err != nil {
+			return nil, fmt.Errorf("hand %d: %w", replay.HandNumber, err)
+		}
+		hands = append(hands, hand)
+	}
+	found, err := analysis.Highlights(hands, highlightOptions)
+	if err != nil {
+		return nil, err
+	}
+	found = found[:min(len(found), maxHighlights)]
+	highlights := make([]sessionHighlight, len(found))
+	for i, highlight := range found {
+		highlights[i] = sessionHighlight{Highlight: highlight, replay: replays[highlight.Hand]}
+	}
+	return highlights, nil
+}
+
+// findHighlights picks the highlights of the game just over off the update
+// loop, to list under its result
+func (v *GameView) findHighlights() tea.Cmd {
+	v.highlights = nil
+	if v.played == nil {
+		return nil
+	}
+	replays := v.played.replays()
+	if len(replays) == 0 {
+		return nil
+	}
+	var cmd tea.Cmd
+	v.finding, cmd = RunAsync(func(ctx context.Context, report func(done, total int)) ([]sessionHighlight, error) {
+		return sessionHighlights(ctx, replays)
+	}, nil, func(found []sessionHighlight, err error) tea.Cmd {
+		v.finding = nil
+		if err != nil {
+			v.appendLog("Picking highlights failed: " + err.Error())
+			return nil
+		}
+		v.highlights = found
+		return nil
+	})
+	return cmd
+}
+
+// reviewHighlight opens the replayer on a highlight, coming back to the
+// result of the game
+func (v *GameView) reviewHighlight(msg tea.KeyMsg) tea.Cmd {
+	i := int(msg.String()[0] - '1')
+	if i < 0 || i >= len(v.highlights) {
+		return nil
+	}
+	return Navigate(ViewSpectator, SpectatorParams{
+		Replay:     v.highlights[i].replay,
+		Back:       ViewGame,
+		BackParams: GameParams{Highlights: true},
+	})
+}
+
+// renderHighlights lists the session's highlights under the result
+func (v *GameView) renderHighlights() string {
+	gray := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	if v.finding != nil {
+		return gray.Render("Picking the highlights of the session…")
+	}
+	if len(v.highlights) == 0 {
+		return ""
+	}
+	lines := []string{lipgloss.NewStyle().Bold(true).Render("Highlights of the session")}
+	for i, highlight := range v.highlights {
+		lines = append(lines, fmt.Sprintf("%d  Hand #%d · %s", i+1, highlight.replay.HandNumber, highlight))
+	}
+	lines = append(lines, gray.Render(fmt.Sprintf("Press 1-%d to replay a hand", len(v.highlights))))
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")). // Purple
+		Padding(0, 1)
+	if v.model.Accessible() {
+		box = lipgloss.NewStyle()
+	}
+	return box.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,72 @@
+package frontend
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ljbink/ai-poker/engine/analysis"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestGameViewReplaysHighlights(t *testing.T) {
+	// The hero folds the first hand and wins a raised pot in the second
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	game.PlayerSit(holdem.NewPlayer(humanPlayerID, "Hero", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Bot", 1000), 1)
+	model := newTestModel(t, nil)
+	gv := model.gameView.(*GameView)
+	gv.played = newGameRunner(holdem.NewDiscardLogger(), model.GetData(), nil)
+	for i := 0; i < 2; i++ {
+		if err := game.StartHand(i % 2); err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 {
+			if err := game.TakeAction(holdem.NewRaise(game.GetCurrentPlayer().GetID(), 40)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := game.TakeAction(holdem.Action{PlayerID: game.GetCurrentPlayer().GetID(), Type: holdem.ActionFold}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := game.AwardPot(); err != nil {
+			t.Fatal(err)
+		}
+		replay, err := holdem.NewReplay(game, map[int]string{humanPlayerID: "human"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		gv.played.recordHand(replay)
+	}
+
+	highlights, err := sessionHighlights(context.Background(), gv.played.replays())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(highlights) != 1 || highlights[0].Kind != analysis.HighlightBiggestPot || highlights[0].replay != gv.played.hands[1] {
+		t.Fatalf("Expected the raised pot as the only highlight, got %+v", highlights)
+	}
+
+	gv.result, gv.highlights = "You cashed out 1005 chips", highlights
+	if !strings.Contains(gv.Render(120, 60), "1  Hand #2 · Biggest pot") {
+		t.Errorf("Expected the highlight listed under the result, got:\n%s", gv.Render(120, 60))
+	}
+	if cmd := gv.reviewHighlight(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")}); cmd != nil {
+		t.Error("Expected no highlight under 2")
+	}
+	_, cmd := gv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	if cmd == nil {
+		t.Fatal("Expected 1 to open the replayer")
+	}
+	navigate, ok := cmd().(NavigateMsg)
+	params, _ := navigate.Params.(SpectatorParams)
+	if !ok || navigate.To != ViewSpectator || params.Replay != highlights[0].replay || params.Back != ViewGame {
+		t.Fatalf("Expected the highlight replayed with a way back to the game, got %+v", navigate)
+	}
+
+	// Coming back shows the result and its highlights again
+	if cmd := gv.OnEnter(params.BackParams); cmd != nil || gv.result == "" || len(gv.highlights) != 1 {
+		t.Errorf("Expected the finished game kept, got result %q and %d highlights", gv.result, len(gv.highlights))
+	}
+}
@@ -0,0 +1,41 @@
+package frontend
+
+import (
+	"log/slog"
+	"net"
+	"strconv"
+
+	"github.com/ljbink/ai-poker/engine/hud"
+)
+
+// hudNetwork tells a loopback host:port from a socket path, which is kept
+// in the data directory unless it is absolute
+func hudNetwork(data *Data, address string) (network, resolved string) {
+	if _, port, err := net.SplitHostPort(address); err == nil {
+		if _, err := strconv.Atoi(port); err == nil {
+			return "tcp", address
+		}
+	}
+	return "unix", data.Path(address)
+}
+
+// openHUD starts the stream HUD tools attach to when the settings turn it
+// on. A stream that cannot be opened is logged and the game goes on without.
+func openHUD(logger *slog.Logger, data *Data) *hud.Server {
+	settings := data.GetSettings()
+	if !settings.HUDStream {
+		return nil
+	}
+	address := settings.HUDAddress
+	if address == "" {
+		address = defaultSettings().HUDAddress
+	}
+	network, address := hudNetwork(data, address)
+	server, err := hud.Listen(network, address)
+	if err != nil {
+		logger.Warn("HUD stream not opened", slog.String("address", address), slog.Any("error", err))
+		return nil
+	}
+	logger.Info("HUD stream open", slog.String("network", network), slog.String("address", server.Addr().String()))
+	return server
+}
@@ -0,0 +1,51 @@
+package frontend
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/internal/paths"
+)
+
+func TestHUDStreamFollowsSettings(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	data.SetDirs(paths.Dirs{Data: t.TempDir()})
+	if server := openHUD(holdem.NewDiscardLogger(), data); server != nil {
+		server.Close()
+		t.Fatal("Expected no HUD stream while the setting is off")
+	}
+
+	data.UpdateSetting("hud_stream", true)
+	server := openHUD(holdem.NewDiscardLogger(), data)
+	if server == nil {
+		t.Fatal("Expected the HUD stream to open")
+	}
+	defer server.Close()
+	if want := filepath.Join(data.GetDirs().Data, "hud.sock"); server.Addr().String() != want {
+		t.Errorf("Expected the socket in the data directory at %s, got %s", want, server.Addr())
+	}
+	nc, err := net.Dial("unix", server.Addr().String())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	nc.Close()
+}
+
+func TestHUDNetworkTellsAddressesFromPaths(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	data.SetDirs(paths.Dirs{Data: "/data"})
+	tests := []struct{ address, network, resolved string }{
+		{"127.0.0.1:7777", "tcp", "127.0.0.1:7777"},
+		{"localhost:7777", "tcp", "localhost:7777"},
+		{"hud.sock", "unix", filepath.Join("/data", "hud.sock")},
+		{"/tmp/poker:hud", "unix", "/tmp/poker:hud"},
+	}
+	for _, tt := range tests {
+		network, resolved := hudNetwork(data, tt.address)
+		if network != tt.network || resolved != tt.resolved {
+			t.Errorf("%s: expected %s %s, got %s %s", tt.address, tt.network, tt.resolved, network, resolved)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package frontend
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/ljbink/ai-poker/engine/tournament"
+)
+
+// LeaderboardEntry is what a player's profile or a bot achieved over every
+// game recorded on this machine
+type LeaderboardEntry struct {
+	Name        string  `json:"name"` // Profile name, or bot profile as holdem_ai.CreateBotByName takes
+	Bot         bool    `json:"bot"`
+	Bankroll    int     `json:"bankroll"` // Chips won or lost in cash games
+	CashGames   int     `json:"cash_games"`
+	Tournaments int     `json:"tournaments"` // Sit-and-gos finished
+	Wins        int     `json:"wins"`        // Sit-and-gos won
+	Places      int     `json:"places"`      // Sum of the finishing places, for the average
+	Rating      float64 `json:"rating"`      // Elo, 0 while unrated
+}
+
+// AverageFinish returns the entry's average finishing place in
+// sit-and-gos, 0 before the first
+func (e LeaderboardEntry) AverageFinish() float64 {
+	if e.Tournaments == 0 {
+		return 0
+	}
+	return float64(e.Places) / float64(e.Tournaments)
+}
+
+// GameResult is how a player did in a game recorded on the leaderboard
+type GameResult struct {
+	Name  string
+	Bot   bool
+	Chips int // Chips won or lost in a cash game
+	Place int // Finishing place in a sit-and-go, 1 for the winner
+}
+
+// leaderboardOrder is what the leaderboard is ranked by
+type leaderboardOrder int
+
+const (
+	byBankroll leaderboardOrder = iota
+	byFinishes                  // Most wins, then the best average finish
+	byRating
+	leaderboardOrders
+)
+
+func (o leaderboardOrder) String() string {
+	switch o {
+	case byBankroll:
+		return "Bankroll"
+	case byFinishes:
+		return "Tournament finishes"
+	case byRating:
+		return "Rating"
+	default:
+		return "Unknown"
+	}
+}
+
+// leaderboardID keys an entry, so a profile and a bot can share a name
+func leaderboardID(name string, bot bool) string {
+	if bot {
+		return "bot:" + name
+	}
+	return "player:" + name
+}
+
+// RecordCashGame adds what each player won or lost in a cash game to the
+// leaderboard
+func (d *Data) RecordCashGame(results []GameResult) {
+	d.recordGame(results, func(entry *LeaderboardEntry, result GameResult) {
+		entry.Bankroll += result.Chips
+		entry.CashGames++
+	})
+}
+
+// RecordTournament adds the finishing places of a sit-and-go to the
+// leaderboard; players with no place yet are left out
+func (d *Data) RecordTournament(results []GameResult) {
+	finished := []GameResult{}
+	for _, result := range results {
+		if result.Place > 0 {
+			finished = append(finished, result)
+		}
+	}
+	d.recordGame(finished, func(entry *LeaderboardEntry, result GameResult) {
+		entry.Tournaments++
+		entry.Places += result.Place
+		if result.Place == 1 {
+			entry.Wins++
+		}
+	})
+}
+
+// recordGame applies a game's results to their entries. A profile's Elo is
+// the human's rating at the time, as the ratings know every profile as
+// "human".
+func (d *Data) recordGame(results []GameResult, apply func(entry *LeaderboardEntry, result GameResult)) {
+	if len(results) == 0 {
+		return
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	entries := d.leaderboard()
+	ratings := d.ratings()
+	for _, result := range results {
+		id := leaderboardID(result.Name, result.Bot)
+		entry, ok := entries[id]
+		if !ok {
+			entry = LeaderboardEntry{Name: result.Name, Bot: result.Bot}
+		}
+		apply(&entry, result)
+		if !result.Bot {
+			if rated := ratings.Get("human"); rated.Games > 0 {
+				entry.Rating = rated.Rating
+			}
+		}
+		entries[id] = entry
+	}
+	d.save(leaderboardKey, entries)
+}
+
+// GetLeaderboard returns the leaderboard ranked by bankroll. Bots carry
+// their current Elo, and bots rated in games recorded before the
+// leaderboard are listed too.
+func (d *Data) GetLeaderboard() []LeaderboardEntry {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	entries := d.leaderboard()
+	ratings := d.ratings()
+	for name, rated := range ratings {
+		if name == "human" {
+			continue
+		}
+		id := leaderboardID(name, true)
+		entry, ok := entries[id]
+		if !ok {
+			entry = LeaderboardEntry{Name: name, Bot: true}
+		}
+		entry.Rating = rated.Rating
+		entries[id] = entry
+	}
+	board := make([]LeaderboardEntry, 0, len(entries))
+	for _, entry := range entries {
+		board = append(board, entry)
+	}
+	sortLeaderboard(board, byBankroll)
+	return board
+}
+
+// ResetLeaderboard forgets the leaderboard and the Elo ratings it shows
+func (d *Data) ResetLeaderboard() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.remove(leaderboardKey)
+	d.remove(ratingsKey)
+}
+
+// leaderboard loads the stored leaderboard by entry ID, empty when there is none
+func (d *Data) leaderboard() map[string]LeaderboardEntry {
+	entries := map[string]LeaderboardEntry{}
+	if !d.load(leaderboardKey, &entries) || entries == nil {
+		return map[string]LeaderboardEntry{}
+	}
+	return entries
+}
+
+// sortLeaderboard ranks the entries best first, ties by name
+func sortLeaderboard(board []LeaderboardEntry, order leaderboardOrder) {
+	sort.SliceStable(board, func(i, j int) bool {
+		a, b := board[i], board[j]
+		switch order {
+		case byFinishes:
+			if a.Wins != b.Wins {
+				return a.Wins > b.Wins
+			}
+			if (a.Tournaments == 0) != (b.Tournaments == 0) {
+				return a.Tournaments > 0
+			}
+			if a.AverageFinish() != b.AverageFinish() {
+				return a.AverageFinish() < b.AverageFinish()
+			}
+		case byRating:
+			if a.Rating != b.Rating {
+				return a.Rating > b.Rating
+			}
+		default:
+			if a.Bankroll != b.Bankroll {
+				return a.Bankroll > b.Bankroll
+			}
+		}
+		return a.Name < b.Name
+	})
+}
+
+// exportLeaderboard writes the leaderboard as leaderboard.csv under dir and
+// returns the file written
+func exportLeaderboard(dir string, board []LeaderboardEntry) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "leaderboard.csv")
+	err := writeFile(path, func(f *os.File) error {
+		w := csv.NewWriter(f)
+		w.Write([]string{"name", "kind", "bankroll", "cash_games", "tournaments", "wins", "average_finish", "rating"})
+		for _, entry := range board {
+			kind, average, elo := "player", "", ""
+			if entry.Bot {
+				kind = "bot"
+			}
+			if entry.Tournaments > 0 {
+				average = strconv.FormatFloat(entry.AverageFinish(), 'f', 2, 64)
+			}
+			if entry.Rating > 0 {
+				elo = fmt.Sprintf("%.0f", entry.Rating)
+			}
+			w.Write([]string{
+				entry.Name, kind, strconv.Itoa(entry.Bankroll), strconv.Itoa(entry.CashGames),
+				strconv.Itoa(entry.Tournaments), strconv.Itoa(entry.Wins), average, elo,
+			})
+		}
+		w.Flush()
+		return w.Error()
+	})
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// profileName is the name the human's games go on the leaderboard under
+func (r *gameRunner) profileName() string {
+	if name := r.data.GetPlayerName(); name != "" {
+		return name
+	}
+	return "Hero"
+}
+
+// recordCashGame puts what every player won or lost at the cash table on
+// the leaderboard, bots replaced along the way under their own profiles
+func (r *gameRunner) recordCashGame() {
+	results := make([]GameResult, 0, len(r.retired)+len(r.chips))
+	for _, retired := range r.retired {
+		results = append(results, GameResult{Name: retired.Name, Bot: true, Chips: int(retired.Score)})
+	}
+	for id, chips := range r.chips {
+		result := GameResult{Name: r.names[id], Bot: true, Chips: chips}
+		if id == humanPlayerID {
+			result = GameResult{Name: r.profileName(), Chips: chips}
+		}
+		results = append(results, result)
+	}
+	r.data.RecordCashGame(results)
+}
+
+// recordTournament puts the places of the players out of the sit-and-go on
+// the leaderboard, or of everyone once it is won
+func (r *gameRunner) recordTournament(standings []tournament.Standing) {
+	results := make([]GameResult, 0, len(standings))
+	r.lock.Lock()
+	for _, standing := range standings {
+		result := GameResult{Name: r.names[standing.PlayerID], Bot: true, Place: standing.Place}
+		if standing.PlayerID == humanPlayerID {
+			result = GameResult{Place: standing.Place}
+		}
+		results = append(results, result)
+	}
+	r.lock.Unlock()
+	for i := range results {
+		if !results[i].Bot {
+			results[i].Name = r.profileName()
+		}
+	}
+	r.data.RecordTournament(results)
+}
@@ -0,0 +1,122 @@
+package frontend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/rating"
+	"github.com/ljbink/ai-poker/engine/tournament"
+)
+
+func TestLeaderboardRecordsGames(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	data.SetPlayerName("Alice")
+	runner := newGameRunner(holdem.NewDiscardLogger(), data, nil)
+	for id, profile := range map[int]string{2: "tight", 3: "nit"} {
+		if _, err := runner.addBot(id, profile); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runner.saveable = true
+	runner.chips = map[int]int{humanPlayerID: 250, 2: -100, 3: -150}
+	runner.retired = []rating.Result{{Name: "maniac", Score: 40}}
+	runner.rate()
+
+	runner.saveable = false
+	runner.recordTournament([]tournament.Standing{
+		{PlayerID: humanPlayerID, Place: 1},
+		{PlayerID: 2, Place: 2},
+		{PlayerID: 3}, // Still playing, left out
+	})
+	data.RecordTournament([]GameResult{{Name: "nit", Bot: true, Place: 3}, {Name: "Alice", Place: 2}})
+
+	entries := map[string]LeaderboardEntry{}
+	for _, entry := range data.GetLeaderboard() {
+		entries[leaderboardID(entry.Name, entry.Bot)] = entry
+	}
+	alice := entries["player:Alice"]
+	if alice.Bankroll != 250 || alice.CashGames != 1 || alice.Tournaments != 2 || alice.Wins != 1 || alice.AverageFinish() != 1.5 {
+		t.Errorf("Expected Alice's cash game and two sit-and-gos, got %+v", alice)
+	}
+	if alice.Rating != data.GetRatings().Get("human").Rating {
+		t.Errorf("Expected Alice to carry the human's Elo, got %+v", alice)
+	}
+	if maniac := entries["bot:maniac"]; maniac.Bankroll != 40 || maniac.CashGames != 1 {
+		t.Errorf("Expected the replaced maniac under its own profile, got %+v", maniac)
+	}
+	if nit := entries["bot:nit"]; nit.Bankroll != -150 || nit.Tournaments != 1 || nit.Places != 3 {
+		t.Errorf("Expected the nit's cash game and finished sit-and-go only, got %+v", nit)
+	}
+	if len(entries) != 4 {
+		t.Errorf("Expected Alice and three bots, got %+v", entries)
+	}
+}
+
+func TestSortLeaderboard(t *testing.T) {
+	board := []LeaderboardEntry{
+		{Name: "a", Bankroll: 100, Rating: 1480},
+		{Name: "b", Bankroll: -50, Tournaments: 2, Wins: 1, Places: 4, Rating: 1530},
+		{Name: "c", Bankroll: 300, Tournaments: 2, Wins: 1, Places: 3},
+		{Name: "d", Tournaments: 1, Places: 2, Rating: 1510},
+	}
+	for order, expected := range map[leaderboardOrder]string{
+		byBankroll: "cadb",
+		byFinishes: "cbda",
+		byRating:   "bdac",
+	} {
+		sortLeaderboard(board, order)
+		names := ""
+		for _, entry := range board {
+			names += entry.Name
+		}
+		if names != expected {
+			t.Errorf("Expected %s to rank %s, got %s", order, expected, names)
+		}
+	}
+}
+
+func TestLeaderboardViewExportsAndResets(t *testing.T) {
+	dir := t.TempDir()
+	model := newTestModel(t, map[string]any{"export_dir": dir})
+	data := model.GetData()
+	data.RecordCashGame([]GameResult{{Name: "Hero", Chips: 120}, {Name: "nit", Bot: true, Chips: -120}})
+	data.RecordMatches([]rating.Result{{Name: "human", Score: 120}, {Name: "nit", Score: -120}, {Name: "tight", Score: 0}})
+	view := model.leaderboardView.(*LeaderboardView)
+	view.OnEnter(nil)
+	if len(view.board) != 3 || view.board[0].Name != "Hero" {
+		t.Fatalf("Expected the rated tight bot listed with the players, got %+v", view.board)
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyTab})
+	view.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if view.order != byRating || view.board[0].Name != "tight" {
+		t.Errorf("Expected the board ranked by rating, got %s %+v", view.order, view.board)
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	csv, err := os.ReadFile(filepath.Join(dir, "leaderboard.csv"))
+	if err != nil {
+		t.Fatalf("Expected the leaderboard exported, got %v (%s)", err, view.status)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(csv)), "\n"); len(lines) != 4 || !strings.HasPrefix(lines[0], "name,kind,bankroll") || !strings.HasPrefix(lines[1], "tight,bot,0,0,0,0,,15") {
+		t.Errorf("Expected a header and a line per entry, got:\n%s", csv)
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if len(data.GetLeaderboard()) != 3 {
+		t.Fatal("Expected a reset left unconfirmed to keep the leaderboard")
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if len(view.board) != 0 || len(data.GetLeaderboard()) != 0 || len(data.GetRatings()) != 0 {
+		t.Errorf("Expected the leaderboard and ratings reset, got %+v", view.board)
+	}
+	if !strings.Contains(view.Render(120, 40), "No games recorded yet") {
+		t.Error("Expected the empty leaderboard to say how to fill it")
+	}
+}
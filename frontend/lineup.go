@@ -0,0 +1,106 @@
+package frontend
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// lineupPrompt asks for the name to save the table's opponents under
+type lineupPrompt struct {
+	open  bool
+	input textinput.Model
+}
+
+func newLineupPrompt() lineupPrompt {
+	ti := textinput.New()
+	ti.Placeholder = "e.g. Tough 6-max"
+	ti.Width = 30
+	ti.CharLimit = 40
+	ti.Prompt = "Lineup name: "
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED"))
+	return lineupPrompt{input: ti}
+}
+
+// lineupKeys answer the lineup name prompt
+var lineupKeys = struct {
+	Save   key.Binding
+	Cancel key.Binding
+}{
+	Save:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "save lineup")),
+	Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+}
+
+// opponents returns the bots seated at the table with their stacks, as
+// they are in view, to save as a lineup. A busted bot keeps the default
+// buy-in.
+func (r *gameRunner) opponents(seats []holdem.SeatView, defaultBuyIn int) []LineupBot {
+	bots := []LineupBot{}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, seat := range seats {
+		profile, ok := r.names[seat.PlayerID]
+		if seat.PlayerID == humanPlayerID || !ok {
+			continue
+		}
+		stack := seat.Chips + seat.TotalBet
+		if stack <= 0 {
+			stack = defaultBuyIn
+		}
+		bots = append(bots, LineupBot{Profile: profile, Stack: stack})
+	}
+	return bots
+}
+
+// openLineupPrompt starts naming the table's opponents as a lineup
+func (v *GameView) openLineupPrompt() tea.Cmd {
+	if v.runner == nil || len(v.seats) == 0 {
+		return nil
+	}
+	v.lineup.open = true
+	v.lineup.input.SetValue("")
+	return v.lineup.input.Focus()
+}
+
+// updateLineupPrompt types the lineup name and saves or cancels it
+func (v *GameView) updateLineupPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, lineupKeys.Save):
+		v.saveLineup(v.lineup.input.Value())
+	case key.Matches(msg, lineupKeys.Cancel):
+	default:
+		var cmd tea.Cmd
+		v.lineup.input, cmd = v.lineup.input.Update(msg)
+		return v.model, cmd
+	}
+	v.lineup.open = false
+	v.lineup.input.Blur()
+	return v.model, nil
+}
+
+// saveLineup keeps the bots at the table and their stacks under name, to
+// play against them again from the game setup
+func (v *GameView) saveLineup(name string) {
+	data := v.model.GetData()
+	lineup := BotLineup{Name: name, Bots: v.runner.opponents(v.seats, data.GetSettings().DefaultBuyIn)}
+	if err := data.SaveLineup(lineup); err != nil {
+		v.appendLog("Saving the lineup failed: " + err.Error())
+		return
+	}
+	v.appendLog(fmt.Sprintf("Lineup %q saved with %d bots", lineup.Name, len(lineup.Bots)))
+}
+
+// renderLineupPrompt draws the lineup name prompt
+func (v *GameView) renderLineupPrompt() string {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Render(v.lineup.input.View() + "\n" + lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")).
+			Render("enter to save the opponents and their stacks · esc to cancel"))
+}
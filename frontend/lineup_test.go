@@ -0,0 +1,100 @@
+package frontend
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+func TestSaveLineup(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	tough := BotLineup{Name: " Tough 6-max ", Bots: []LineupBot{{Profile: "tight", Stack: 1000}, {Profile: "nit", Stack: 800}}}
+	if err := data.SaveLineup(tough); err != nil {
+		t.Fatalf("SaveLineup failed: %v", err)
+	}
+	if err := data.SaveLineup(BotLineup{Name: "Loose home game", Bots: []LineupBot{{Profile: "maniac", Stack: 2000}}}); err != nil {
+		t.Fatalf("SaveLineup failed: %v", err)
+	}
+	// Saving under a name again replaces the lineup in place
+	if err := data.SaveLineup(BotLineup{Name: "Tough 6-max", Bots: []LineupBot{{Profile: "tight", Stack: 1500}}}); err != nil {
+		t.Fatalf("SaveLineup failed: %v", err)
+	}
+	lineups := data.GetSettings().Lineups
+	if len(lineups) != 2 || lineups[0].Name != "Tough 6-max" || lineups[0].Bots[0].Stack != 1500 {
+		t.Fatalf("Expected the tough lineup replaced first, got %+v", lineups)
+	}
+
+	for name, bad := range map[string]BotLineup{
+		"no name":     {Bots: []LineupBot{{Profile: "tight", Stack: 1000}}},
+		"no bots":     {Name: "Empty"},
+		"no chips":    {Name: "Broke", Bots: []LineupBot{{Profile: "tight"}}},
+		"too many":    {Name: "Crowd", Bots: make([]LineupBot, maxLineupBots+1)},
+		"no profiles": {Name: "Ghosts", Bots: []LineupBot{{Stack: 1000}}},
+	} {
+		if err := data.SaveLineup(bad); err == nil {
+			t.Errorf("%s: expected the lineup to be refused", name)
+		}
+	}
+
+	data.UpdateSetting("lineup", "Loose home game")
+	data.DeleteLineup("Loose home game")
+	if _, ok := data.GetLineup("Loose home game"); ok || data.GetSettings().Lineup != "" {
+		t.Errorf("Expected the deleted lineup forgotten and deselected, got %+v", data.GetSettings())
+	}
+}
+
+func TestLineupRoundTrip(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	runner := newGameRunner(holdem.NewDiscardLogger(), data, nil)
+	bots, stacks, err := runner.addLineup(BotLineup{Name: "Mixed", Bots: []LineupBot{{Profile: "tight", Stack: 1200}, {Profile: "../engine/holdem_ai/testdata/chart_bot.csv", Stack: 600}}})
+	if err != nil {
+		t.Fatalf("addLineup failed: %v", err)
+	}
+	if bots[2] != "Tight" || bots[3] != "Chart_bot" || stacks[2] != 1200 || stacks[3] != 600 {
+		t.Errorf("Unexpected bots %v with stacks %v", bots, stacks)
+	}
+	if _, _, err := runner.addLineup(BotLineup{Name: "Bad", Bots: []LineupBot{{Profile: "nobody", Stack: 1000}}}); err == nil {
+		t.Error("Expected an unknown bot profile to be refused")
+	}
+
+	// The table's opponents come back with their stacks, a busted one with the default buy-in
+	seats := []holdem.SeatView{
+		{PlayerID: humanPlayerID, Chips: 900},
+		{PlayerID: 2, Chips: 1100, TotalBet: 50},
+		{PlayerID: 3},
+	}
+	got := runner.opponents(seats, 1000)
+	if len(got) != 2 || got[0] != (LineupBot{Profile: "tight", Stack: 1150}) || got[1].Stack != 1000 {
+		t.Errorf("Unexpected opponents %+v", got)
+	}
+}
+
+func TestGameSetupSelectsLineup(t *testing.T) {
+	model := newTestModel(t, nil)
+	if err := model.GetData().SaveLineup(BotLineup{Name: "Tough 6-max", Bots: []LineupBot{{Profile: "tight", Stack: 1000}, {Profile: "nit", Stack: 1000}}}); err != nil {
+		t.Fatal(err)
+	}
+	view := model.gameSetupView.(*GameSetupView)
+	view.focused = gameSetupLineupField
+	view.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if view.lineup != "Tough 6-max" || view.numBotsInput.Value() != "2" {
+		t.Fatalf("Expected the lineup selected with 2 bots, got %q with %s", view.lineup, view.numBotsInput.Value())
+	}
+	view.numBotsInput.SetValue("3")
+	if err := view.validateInputs(); err == nil {
+		t.Error("Expected the bots to have to match the lineup")
+	}
+	view.numBotsInput.SetValue("2")
+
+	view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	settings := model.GetData().GetSettings()
+	if lineup, ok := settings.lineup(); !ok || lineup.Name != "Tough 6-max" {
+		t.Errorf("Expected cash games to be played against the lineup, got %q", settings.Lineup)
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyDelete})
+	if _, ok := model.GetData().GetLineup("Tough 6-max"); ok || view.lineup != "" {
+		t.Error("Expected delete to forget the lineup")
+	}
+}
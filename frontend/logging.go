@@ -0,0 +1,64 @@
+package frontend
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// Log levels selectable in settings, in cycling order
+var logLevels = []string{"off", "error", "warn", "info", "debug"}
+
+// parseLogLevel converts a settings log level to a slog level.
+// The second return value is false when logging is disabled.
+func parseLogLevel(level string) (slog.Level, bool) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	default:
+		return slog.LevelInfo, false
+	}
+}
+
+// nextLogLevel returns the log level following the given one in the cycle
+func nextLogLevel(level string) string {
+	for i, l := range logLevels {
+		if l == level {
+			return logLevels[(i+1)%len(logLevels)]
+		}
+	}
+	return logLevels[0]
+}
+
+// nopCloser is returned when there is no log file to close
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+// newLogger builds the application logger from settings.
+// Records are written as JSON lines to the configured log file, resolved
+// against the data directory; the returned closer must be closed on shutdown.
+func newLogger(data *Data) (*slog.Logger, io.Closer, error) {
+	settings := data.GetSettings()
+	level, enabled := parseLogLevel(settings.LogLevel)
+	if !enabled || settings.LogFile == "" {
+		return holdem.NewDiscardLogger(), nopCloser{}, nil
+	}
+
+	file, err := os.OpenFile(data.Path(settings.LogFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return holdem.NewDiscardLogger(), nopCloser{}, err
+	}
+
+	handler := slog.NewJSONHandler(file, &slog.HandlerOptions{Level: level})
+	return slog.New(handler), file, nil
+}
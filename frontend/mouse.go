@@ -0,0 +1,86 @@
+package frontend
+
+import (
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// mouseView is implemented by views that take the mouse as well as the
+// keyboard. Every click and wheel turn is handled as the key that does the
+// same, so the keyboard still reaches everything. row is the text of the
+// frame's row under the pointer, styling stripped.
+type mouseView interface {
+	Mouse(msg tea.MouseMsg, row string) (tea.Model, tea.Cmd)
+}
+
+// keyHint matches a key shown in brackets, as in "[f]old"
+var keyHint = regexp.MustCompile(`\[([^\[\]\s])\]`)
+
+// rowAt returns the text of a row of the last frame drawn, styling stripped
+func (m *Model) rowAt(y int) string {
+	rows := strings.Split(m.frame, "\n")
+	if y < 0 || y >= len(rows) {
+		return ""
+	}
+	return ansi.Strip(rows[y])
+}
+
+// leftClick reports whether the event is a press of the left button
+func leftClick(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+}
+
+// wheelKey returns the key a turn of the wheel stands for, up or down as
+// given, and false for any other event
+func wheelKey(msg tea.MouseMsg, up, down tea.KeyType) (tea.KeyMsg, bool) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return tea.KeyMsg{Type: up}, true
+	case tea.MouseButtonWheelDown:
+		return tea.KeyMsg{Type: down}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// clickedKey returns the key of the hint clicked at column x of a row, as
+// in the game prompt's "[f]old  [c]all 20". A hint reaches from its
+// bracket to the next run of two spaces.
+func clickedKey(row string, x int) (tea.KeyMsg, bool) {
+	runes := []rune(row)
+	at, col := -1, 0
+	for i, r := range runes {
+		width := ansi.StringWidth(string(r))
+		if x >= col && x < col+max(width, 1) {
+			at = i
+			break
+		}
+		col += width
+	}
+	gap := func(i int) bool { return i >= 0 && i < len(runes) && runes[i] == ' ' }
+	if at < 0 || gap(at) && (gap(at-1) || gap(at+1)) {
+		return tea.KeyMsg{}, false
+	}
+	start, end := at, at
+	for start > 0 && !(gap(start-1) && gap(start-2)) {
+		start--
+	}
+	for end < len(runes) && !(gap(end) && gap(end+1)) {
+		end++
+	}
+	// The hint is the last one starting before the click, so that the text
+	// leading up to the first, "Your turn:", presses nothing
+	segment, clicked := string(runes[start:end]), len(string(runes[start:at]))
+	hint := ""
+	for _, match := range keyHint.FindAllStringSubmatchIndex(segment, -1) {
+		if match[0] <= clicked {
+			hint = segment[match[2]:match[3]]
+		}
+	}
+	if hint == "" {
+		return tea.KeyMsg{}, false
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(hint)}, true
+}
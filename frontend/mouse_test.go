@@ -0,0 +1,47 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// columnOf returns the screen column text starts at in a row
+func columnOf(t *testing.T, row, text string) int {
+	t.Helper()
+	i := strings.Index(row, text)
+	if i < 0 {
+		t.Fatalf("%q is not in %q", text, row)
+	}
+	return ansi.StringWidth(row[:i])
+}
+
+func TestClickedKey(t *testing.T) {
+	row := "   Your turn: [f]old  [c]all 10  [r]aise to 20 (↑/↓)  [a]ll-in 990"
+	for text, want := range map[string]string{"[f]old": "f", "old": "f", "all 10": "c", "(↑/↓)": "r", "990": "a"} {
+		key, ok := clickedKey(row, columnOf(t, row, text))
+		if !ok || key.String() != want {
+			t.Errorf("Expected a click on %q to press %q, got %q", text, want, key.String())
+		}
+	}
+	for _, x := range []int{0, columnOf(t, row, "Your"), columnOf(t, row, "  [c]"), ansi.StringWidth(row) + 5} {
+		if key, ok := clickedKey(row, x); ok {
+			t.Errorf("Expected nothing pressed at column %d, got %q", x, key.String())
+		}
+	}
+}
+
+func TestIndexViewMouse(t *testing.T) {
+	h := newTUIHarness(t, 100, 40)
+	h.WaitFor("Start Game")
+	h.Wheel(1)
+	if got := h.model.indexView.(*IndexView).list.Index(); got != 1 {
+		t.Errorf("Expected the wheel to move to the second item, got %d", got)
+	}
+	h.Click("Start Game")
+	h.WaitFor("Login")
+	if h.model.currentView != ViewLogin {
+		t.Errorf("Expected clicking Start Game to open the login, got view %d", h.model.currentView)
+	}
+}
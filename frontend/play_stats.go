@@ -0,0 +1,266 @@
+package frontend
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// dayLayout is how days are keyed in the play log, in local time
+const dayLayout = "2006-01-02"
+
+// PlayDay is how much the human played on one calendar day
+type PlayDay struct {
+	Hands   int `json:"hands"`
+	Net     int `json:"net"`     // Chips won or lost in cash games; tournament chips are not money
+	Seconds int `json:"seconds"` // Time spent in hands
+}
+
+// PlayPeriod is the play of a day or a week in the play statistics
+type PlayPeriod struct {
+	Start time.Time // Midnight of the day, or of the Monday starting the week
+	PlayDay
+	Days int // Days played in the period
+}
+
+// PlayStats sums up the play log for the stats view
+type PlayStats struct {
+	Today       PlayDay
+	Week        PlayDay // Since Monday
+	Streak      int     // Days in a row played up to today, or up to yesterday until today's first hand
+	BestStreak  int
+	DaysPlayed  int
+	Hands       int
+	Net         int
+	HandsPerDay float64 // Over the days played
+}
+
+// RecordPlay adds a hand played at a time to its day of the play log and
+// returns the day so far
+func (d *Data) RecordPlay(at time.Time, net int, played time.Duration) PlayDay {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	days := d.playLog()
+	key := at.Format(dayLayout)
+	day := days[key]
+	day.Hands++
+	day.Net += net
+	day.Seconds += int(played.Seconds())
+	days[key] = day
+	d.save(playLogKey, days)
+	return day
+}
+
+// GetPlayLog returns the play log by day, keyed like "2024-01-31"
+func (d *Data) GetPlayLog() map[string]PlayDay {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.playLog()
+}
+
+// ResetPlayLog forgets every day played and the last game's winnings
+func (d *Data) ResetPlayLog() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.remove(playLogKey)
+	d.remove(lastSessionKey)
+}
+
+// playLog loads the stored play log, empty when there is none
+func (d *Data) playLog() map[string]PlayDay {
+	days := map[string]PlayDay{}
+	if !d.load(playLogKey, &days) || days == nil {
+		return map[string]PlayDay{}
+	}
+	return days
+}
+
+// LastSession is every player's winnings hand by hand in the last game
+// played, kept for the stats view
+type LastSession struct {
+	Ended   time.Time       `json:"ended"`
+	Players []SessionPlayer `json:"players"`
+}
+
+// SessionPlayer is a player's chips won or lost after every hand of a game
+type SessionPlayer struct {
+	Name  string `json:"name"`
+	Hands []int  `json:"hands"`
+}
+
+// lines returns the players for the winnings graph
+func (s *LastSession) lines() []component.WinningsLine {
+	if s == nil {
+		return nil
+	}
+	lines := make([]component.WinningsLine, len(s.Players))
+	for i, player := range s.Players {
+		lines[i] = component.WinningsLine{Name: player.Name, Hands: player.Hands}
+	}
+	return lines
+}
+
+// SaveLastSession keeps the winnings of the game just played, replacing
+// the last one
+func (d *Data) SaveLastSession(session LastSession) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.save(lastSessionKey, session)
+}
+
+// GetLastSession returns the winnings of the last game played, nil before
+// the first
+func (d *Data) GetLastSession() *LastSession {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	session := &LastSession{}
+	if !d.load(lastSessionKey, session) {
+		return nil
+	}
+	return session
+}
+
+// midnight returns the start of the day of t
+func midnight(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// weekStart returns the Monday starting the week of t
+func weekStart(t time.Time) time.Time {
+	return midnight(t).AddDate(0, 0, -(int(t.Weekday())+6)%7)
+}
+
+// playDays parses the days of the play log in loc, oldest first, skipping
+// keys that are not dates and days without hands
+func playDays(days map[string]PlayDay, loc *time.Location) []PlayPeriod {
+	periods := make([]PlayPeriod, 0, len(days))
+	for key, day := range days {
+		start, err := time.ParseInLocation(dayLayout, key, loc)
+		if err != nil || day.Hands == 0 {
+			continue
+		}
+		periods = append(periods, PlayPeriod{Start: start, PlayDay: day, Days: 1})
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Start.Before(periods[j].Start) })
+	return periods
+}
+
+// summarizePlay works out the totals and streaks of the play log as of now.
+// Days after today, logged before a change of time zone, are left out.
+func summarizePlay(days map[string]PlayDay, now time.Time) PlayStats {
+	stats := PlayStats{}
+	today, monday := midnight(now), weekStart(now)
+	run, previous := 0, time.Time{}
+	for _, day := range playDays(days, now.Location()) {
+		if day.Start.After(today) {
+			break
+		}
+		stats.DaysPlayed++
+		stats.Hands += day.Hands
+		stats.Net += day.Net
+		if day.Start.Equal(today) {
+			stats.Today = day.PlayDay
+		}
+		if !day.Start.Before(monday) {
+			stats.Week.Hands += day.Hands
+			stats.Week.Net += day.Net
+			stats.Week.Seconds += day.Seconds
+		}
+		// AddDate rather than 24 hours, so daylight saving days still follow on
+		if run > 0 && previous.AddDate(0, 0, 1).Equal(day.Start) {
+			run++
+		} else {
+			run = 1
+		}
+		previous = day.Start
+		stats.BestStreak = max(stats.BestStreak, run)
+	}
+	if previous.Equal(today) || previous.AddDate(0, 0, 1).Equal(today) {
+		stats.Streak = run
+	}
+	if stats.DaysPlayed > 0 {
+		stats.HandsPerDay = float64(stats.Hands) / float64(stats.DaysPlayed)
+	}
+	return stats
+}
+
+// recentDays returns the last count days up to now, newest first, days
+// not played included
+func recentDays(days map[string]PlayDay, now time.Time, count int) []PlayPeriod {
+	periods := make([]PlayPeriod, 0, count)
+	for i := 0; i < count; i++ {
+		start := midnight(now).AddDate(0, 0, -i)
+		day := days[start.Format(dayLayout)]
+		period := PlayPeriod{Start: start, PlayDay: day}
+		if day.Hands > 0 {
+			period.Days = 1
+		}
+		periods = append(periods, period)
+	}
+	return periods
+}
+
+// recentWeeks returns the last count weeks up to now, newest first
+func recentWeeks(days map[string]PlayDay, now time.Time, count int) []PlayPeriod {
+	periods := make([]PlayPeriod, 0, count)
+	for i := 0; i < count; i++ {
+		periods = append(periods, PlayPeriod{Start: weekStart(now).AddDate(0, 0, -7*i)})
+	}
+	for _, day := range playDays(days, now.Location()) {
+		if day.Start.After(now) {
+			break
+		}
+		for i := range periods {
+			week := &periods[i]
+			if day.Start.Before(week.Start) || !day.Start.Before(week.Start.AddDate(0, 0, 7)) {
+				continue
+			}
+			week.Hands += day.Hands
+			week.Net += day.Net
+			week.Seconds += day.Seconds
+			week.Days++
+		}
+	}
+	return periods
+}
+
+// lastSession returns every player's winnings hand by hand at the table,
+// nil before the first hand. Only once the game has stopped.
+func (r *gameRunner) lastSession() *LastSession {
+	r.lock.Lock()
+	table := r.table
+	r.lock.Unlock()
+	if table == nil {
+		return nil
+	}
+	session := &LastSession{Ended: time.Now()}
+	for _, player := range table.Winnings() {
+		if len(player.Hands) == 0 {
+			return nil
+		}
+		session.Players = append(session.Players, SessionPlayer{Name: player.Name, Hands: player.Hands})
+	}
+	return session
+}
+
+// recordPlay adds the hand just finished to today's play, its net only in
+// cash games, and returns the reminder to show when it reaches the daily
+// hand limit. Runner goroutine only.
+func (r *gameRunner) recordPlay(net int) string {
+	now := time.Now()
+	played := time.Duration(0)
+	if !r.handStarted.IsZero() {
+		played = now.Sub(r.handStarted)
+	}
+	if r.level() > 0 {
+		net = 0
+	}
+	day := r.data.RecordPlay(now, net, played)
+	if limit := r.data.GetSettings().DailyHandLimit; limit > 0 && day.Hands == limit {
+		r.dailyLimit = r.level() == 0
+		return r.translator.T("log.daily_limit", day.Hands)
+	}
+	return ""
+}
@@ -0,0 +1,117 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// playLog is two weeks of play up to Wednesday 2024-01-17: four days in a
+// row the week before, then Monday and Tuesday
+var playLog = map[string]PlayDay{
+	"2024-01-07": {Hands: 50, Net: -100, Seconds: 1800},
+	"2024-01-08": {Hands: 100, Net: 200, Seconds: 3600},
+	"2024-01-09": {Hands: 30, Net: 0, Seconds: 900},
+	"2024-01-10": {Hands: 20, Net: -50, Seconds: 600},
+	"2024-01-15": {Hands: 80, Net: 300, Seconds: 2700},
+	"2024-01-16": {Hands: 40, Net: -20, Seconds: 1500},
+	"2024-01-20": {Hands: 10}, // Ahead of the clock, after a time zone change
+	"not a day":  {Hands: 10},
+}
+
+func TestSummarizePlay(t *testing.T) {
+	wednesday := time.Date(2024, 1, 17, 21, 0, 0, 0, time.UTC)
+	stats := summarizePlay(playLog, wednesday)
+	if stats.Streak != 2 || stats.BestStreak != 4 {
+		t.Errorf("Expected a 2-day streak kept up to yesterday and a best of 4, got %d and %d", stats.Streak, stats.BestStreak)
+	}
+	if stats.Today.Hands != 0 || stats.Week.Hands != 120 || stats.Week.Net != 280 {
+		t.Errorf("Expected nothing today and 120 hands for +280 this week, got %+v and %+v", stats.Today, stats.Week)
+	}
+	if stats.DaysPlayed != 6 || stats.Hands != 320 || stats.Net != 330 {
+		t.Errorf("Expected 320 hands over 6 days for +330, got %+v", stats)
+	}
+
+	// A day without play breaks the streak
+	if stats := summarizePlay(playLog, wednesday.AddDate(0, 0, 1)); stats.Streak != 0 || stats.BestStreak != 4 {
+		t.Errorf("Expected the streak lost on Thursday, got %d", stats.Streak)
+	}
+}
+
+func TestRecentPlayPeriods(t *testing.T) {
+	wednesday := time.Date(2024, 1, 17, 9, 0, 0, 0, time.UTC)
+	days := recentDays(playLog, wednesday, 3)
+	if len(days) != 3 || days[0].Hands != 0 || days[1].Hands != 40 || days[2].Hands != 80 || days[2].Start.Weekday() != time.Monday {
+		t.Errorf("Expected today, Tuesday and Monday newest first, got %+v", days)
+	}
+	weeks := recentWeeks(playLog, wednesday, 3)
+	if weeks[0].Hands != 120 || weeks[0].Days != 2 || weeks[1].Hands != 150 || weeks[1].Days != 3 || weeks[2].Hands != 50 {
+		t.Errorf("Expected weeks of 120, 150 and 50 hands, got %+v", weeks)
+	}
+	if !weeks[1].Start.Equal(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected last week to start on Monday the 8th, got %v", weeks[1].Start)
+	}
+}
+
+func TestRunnerRemindsAtDailyLimit(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	data.UpdateSetting("daily_hand_limit", 2)
+	runner := newGameRunner(holdem.NewDiscardLogger(), data, nil)
+	if reminder := runner.recordPlay(40); reminder != "" {
+		t.Errorf("Expected no reminder after the first hand, got %q", reminder)
+	}
+	reminder := runner.recordPlay(-10)
+	if !strings.Contains(reminder, "2 hands today") || !runner.dailyLimit {
+		t.Errorf("Expected the limit reminder and a cash-out offer, got %q", reminder)
+	}
+	today := data.GetPlayLog()[time.Now().Format(dayLayout)]
+	if today.Hands != 2 || today.Net != 30 {
+		t.Errorf("Expected 2 hands for +30 today, got %+v", today)
+	}
+
+	// Tournament chips are not counted, and nobody cashes out of a sit & go
+	runner.dailyLimit = false
+	data.UpdateSetting("daily_hand_limit", 3)
+	runner.level = func() int { return 1 }
+	if reminder := runner.recordPlay(500); reminder == "" || runner.dailyLimit {
+		t.Errorf("Expected only the reminder in a tournament, got %q", reminder)
+	}
+	if today := data.GetPlayLog()[time.Now().Format(dayLayout)]; today.Net != 30 {
+		t.Errorf("Expected tournament chips left out of the net, got %+v", today)
+	}
+}
+
+func TestStatsViewShowsAndResets(t *testing.T) {
+	model := newTestModel(t, map[string]any{"daily_hand_limit": 100})
+	if err := model.GetData().store.Set(playLogKey, playLog); err != nil {
+		t.Fatal(err)
+	}
+	model.GetData().SaveLastSession(LastSession{
+		Ended:   time.Date(2024, 1, 16, 11, 30, 0, 0, time.Local),
+		Players: []SessionPlayer{{Name: "Hero", Hands: []int{-20, 40}}, {Name: "Maniac", Hands: []int{20, -40}}},
+	})
+	view := model.statsView.(*StatsView)
+	view.now = func() time.Time { return time.Date(2024, 1, 16, 12, 0, 0, 0, time.Local) }
+	view.OnEnter(nil)
+
+	screen := view.Render(120, 50)
+	for _, want := range []string{"40 hands · net -20 · 25m", "2 days in a row · best 4 days", "60 left today", "Tue Jan 16",
+		"Last game · Tue Jan 16 11:30", "Hero    ▃█  +40", "Maniac  ▆▁  -40"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q on the stats screen:\n%s", want, screen)
+		}
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if screen := view.Render(120, 50); !strings.Contains(screen, "Last 8 weeks") || !strings.Contains(screen, "Jan 8") {
+		t.Errorf("Expected tab to list weeks:\n%s", screen)
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if len(model.GetData().GetPlayLog()) != 0 || model.GetData().GetLastSession() != nil || !strings.Contains(view.Render(120, 50), "No hands played yet") {
+		t.Error("Expected the play log reset")
+	}
+}
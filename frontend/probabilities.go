@@ -0,0 +1,153 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// The probability overlay samples up to probabilitySamples runouts, a
+// refresh's worth of time at a time so the table redraws with the estimate
+// so far however many players are in the hand. It settles for what it has
+// once probabilityBudget is spent.
+const (
+	probabilitySamples = 20000
+	probabilityRefresh = 50 * time.Millisecond
+	probabilityBudget  = 2 * time.Second
+)
+
+// probabilityOverlay shows the human's equity against random hands while
+// they decide, calculated in the background
+type probabilityOverlay struct {
+	task      *AsyncTask
+	opponents int
+	estimate  equity.Estimate // So far while the task runs
+	err       error
+	barred    bool      // The table allows no assistance
+	until     time.Time // Hidden until then, streamer mode's delay
+}
+
+// start abandons any running calculation and works out the hero's equity
+// in the table view against the players still in the hand
+func (o *probabilityOverlay) start(view holdem.TableView) tea.Cmd {
+	o.stop()
+	if view.NoAssistance {
+		o.barred = true
+		return nil
+	}
+	var hole poker.Cards
+	opponents := 0
+	for _, seat := range view.Seats {
+		switch {
+		case seat.PlayerID == humanPlayerID:
+			hole = seat.HoleCards
+		case !seat.Folded:
+			opponents++
+		}
+	}
+	if len(hole) == 0 || opponents == 0 {
+		return nil
+	}
+	*o = probabilityOverlay{opponents: opponents, estimate: equity.Estimate{Margin: 1, Partial: true}}
+	opts := equity.Options{Samples: probabilitySamples}
+	if view.Variant.IsKnown() {
+		opts.Evaluator = view.Variant.Rules().NewEvaluator()
+	}
+	estimator, err := equity.NewEstimator(hole, view.Board, opponents, opts)
+	if err != nil {
+		o.err = err
+		return nil
+	}
+
+	var latest atomic.Pointer[equity.Estimate] // Handed from the task to the update loop
+	var task *AsyncTask
+	task, cmd := RunAsync(
+		func(ctx context.Context, report func(done, total int)) (equity.Estimate, error) {
+			deadline := time.Now().Add(probabilityBudget)
+			estimate := estimator.Estimate()
+			for estimate.Partial && time.Now().Before(deadline) {
+				if err := ctx.Err(); err != nil {
+					return estimate, err
+				}
+				estimate = estimator.Run(ctx, min(probabilityRefresh, time.Until(deadline)))
+				published := estimate // The next pass overwrites estimate while the update loop reads this
+				latest.Store(&published)
+				report(estimate.Trials, probabilitySamples)
+			}
+			return estimate, nil
+		},
+		func(done, total int) {
+			if estimate := latest.Load(); estimate != nil {
+				o.estimate = *estimate
+			}
+		},
+		func(result equity.Estimate, err error) tea.Cmd {
+			if o.task == task {
+				o.estimate, o.err, o.task = result, err, nil
+			}
+			return nil
+		},
+	)
+	o.task = task
+	return cmd
+}
+
+// delay keeps the overlay hidden for a while and returns the command that
+// redraws it once it shows
+func (o *probabilityOverlay) delay(now time.Time, delay time.Duration) tea.Cmd {
+	if delay <= 0 {
+		return nil
+	}
+	o.until = now.Add(delay)
+	return tea.Tick(delay, func(time.Time) tea.Msg { return oddsShownMsg{} })
+}
+
+// visible tells whether the overlay is past its delay at now
+func (o *probabilityOverlay) visible(now time.Time) bool {
+	return !now.Before(o.until)
+}
+
+// oddsShownMsg redraws the game once the overlay's delay is over
+type oddsShownMsg struct{}
+
+// stop abandons the calculation and hides the overlay
+func (o *probabilityOverlay) stop() {
+	o.task.Cancel()
+	*o = probabilityOverlay{}
+}
+
+// line describes the calculation, empty when there is nothing to show
+func (o *probabilityOverlay) line() string {
+	switch {
+	case o.barred:
+		return "Equity off: this table allows no assistance"
+	case o.opponents == 0:
+		return ""
+	case o.err != nil:
+		return "Equity unavailable: " + o.err.Error()
+	case o.task != nil && o.estimate.Trials == 0:
+		return fmt.Sprintf("Equity vs %d random %s: estimating…", o.opponents, handsWord(o.opponents))
+	case o.task != nil:
+		return fmt.Sprintf("Equity vs %d random %s: estimating… %.1f%% (%.1f–%.1f%%)", o.opponents, handsWord(o.opponents),
+			o.estimate.Equity*100, o.estimate.Low()*100, o.estimate.High()*100)
+	case o.estimate.Partial:
+		// Out of time: the interval says how far to trust it
+		return fmt.Sprintf("Equity vs %d random %s: %.1f%% ± %.1f%%", o.opponents, handsWord(o.opponents),
+			o.estimate.Equity*100, o.estimate.Margin*100)
+	default:
+		return fmt.Sprintf("Equity vs %d random %s: %.1f%%", o.opponents, handsWord(o.opponents), o.estimate.Equity*100)
+	}
+}
+
+func handsWord(count int) string {
+	if count == 1 {
+		return "hand"
+	}
+	return "hands"
+}
@@ -0,0 +1,70 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestProbabilityOverlayCalculatesInBackground(t *testing.T) {
+	model := newTestModel(t, nil)
+	aces, _ := poker.ParseCards("AhAs")
+	view := holdem.TableView{
+		Variant: holdem.VariantHoldem,
+		Seats: []holdem.SeatView{
+			{PlayerID: humanPlayerID, HoleCards: aces},
+			{PlayerID: 2, CardsHidden: true},
+			{PlayerID: 3, Folded: true},
+		},
+	}
+
+	overlay := &probabilityOverlay{}
+	cmd := overlay.start(view)
+	if !strings.Contains(overlay.line(), "estimating…") {
+		t.Errorf("Expected the overlay to show progress while calculating, got %q", overlay.line())
+	}
+	drain(t, model, cmd)
+
+	if equity := overlay.estimate.Equity; overlay.opponents != 1 || equity < 0.8 || equity > 0.9 {
+		t.Errorf("Expected aces to have about 85%% against one random hand, got %.3f vs %d", equity, overlay.opponents)
+	}
+	if line := overlay.line(); !strings.HasPrefix(line, "Equity vs 1 random hand: 8") {
+		t.Errorf("Unexpected overlay line %q", line)
+	}
+
+	overlay.stop()
+	if overlay.line() != "" {
+		t.Error("Expected a stopped overlay to be hidden")
+	}
+	// Tables that allow no assistance get no equity
+	view.NoAssistance = true
+	if cmd := overlay.start(view); cmd != nil || overlay.line() != "Equity off: this table allows no assistance" {
+		t.Errorf("Expected no calculation at a no-assistance table, got %q", overlay.line())
+	}
+	runout := &runoutEquity{}
+	view.Runout = true
+	if cmd := runout.start(view); cmd != nil {
+		t.Error("Expected no runout equities at a no-assistance table")
+	}
+}
+
+func TestProbabilityOverlayShowsInterval(t *testing.T) {
+	overlay := &probabilityOverlay{opponents: 8, task: &AsyncTask{}}
+	if line := overlay.line(); line != "Equity vs 8 random hands: estimating…" {
+		t.Errorf("Expected a bare estimating flag before any runout, got %q", line)
+	}
+
+	overlay.estimate = equity.Estimate{Equity: 0.231, Margin: 0.012, Trials: 1500, Partial: true}
+	if line := overlay.line(); line != "Equity vs 8 random hands: estimating… 23.1% (21.9–24.3%)" {
+		t.Errorf("Expected the estimate so far with its interval, got %q", line)
+	}
+
+	// Out of time before every runout was sampled
+	overlay.task = nil
+	if line := overlay.line(); line != "Equity vs 8 random hands: 23.1% ± 1.2%" {
+		t.Errorf("Expected the partial result with its margin, got %q", line)
+	}
+}
@@ -0,0 +1,133 @@
+package frontend
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/engine/reading"
+)
+
+// opponentRead is an opponent's range as estimated from their line in the
+// hand, shown on the range grid while the human decides
+type opponentRead struct {
+	name string
+	rng  *ranges.Range
+}
+
+// reads estimates the range of every opponent still in the hand, leaving
+// out the human's own cards. Only Hold'em ranges can be read.
+func (r *gameRunner) reads(game *holdem.Game) []opponentRead {
+	human, err := game.GetPlayerByID(humanPlayerID)
+	if err != nil || game.GetVariant().Name() != holdem.VariantHoldem {
+		return nil
+	}
+	reads := []opponentRead{}
+	for _, player := range game.GetAllPlayers() {
+		if player.GetID() == humanPlayerID || player.IsFolded() || len(player.GetHandCards()) == 0 {
+			continue
+		}
+		reads = append(reads, opponentRead{
+			name: r.playerName(game, player.GetID()),
+			rng:  reading.Estimate(game, player.GetID(), poker.Cards(human.GetHandCards())),
+		})
+	}
+	return reads
+}
+
+// cycleRead shows the next opponent's read, then hides the grid again
+func (v *GameView) cycleRead() {
+	if len(v.reads) == 0 {
+		v.reading = 0
+		return
+	}
+	v.reading = (v.reading + 1) % (len(v.reads) + 1)
+}
+
+// renderRead draws the opponent's estimated range on the range grid, or
+// as one line in accessibility mode
+func (v *GameView) renderRead(width int) string {
+	if v.reading == 0 || v.reading > len(v.reads) {
+		return ""
+	}
+	read := v.reads[v.reading-1]
+	title := fmt.Sprintf("Read on %s · %.0f%% of hands", read.name, read.rng.Fraction()*100)
+	if v.model.Accessible() {
+		return title
+	}
+	v.grid.SetWidth(width)
+	v.grid.SetRange(read.rng)
+	return lipgloss.NewStyle().Bold(true).Render(v.model.icon("🔍", title)) + "\n\n" + v.grid.Render()
+}
+
+// exportRanges writes the ranges at a decision point of a reviewed hand,
+// after the action at index, for study tools: what the hero's line there
+// tells the table, and the estimated range of every opponent still in,
+// in each of ranges.ExportFormats. It returns the file written, under dir.
+func exportRanges(dir string, replay *holdem.Replay, index int) (string, error) {
+	hero := replayHero(replay)
+	var reads []opponentRead
+	var header string
+	_, err := replay.RunObserved(func(game *holdem.Game, i int) {
+		if i != index || game.GetVariant().Name() != holdem.VariantHoldem {
+			return
+		}
+		header = fmt.Sprintf("Hand #%d after action %d/%d", replay.HandNumber, index+1, len(replay.Actions))
+		if board := poker.Cards(game.GetCommunityCards()); len(board) > 0 {
+			header += ", board " + board.String()
+		}
+		if next := game.GetCurrentPlayer(); next != nil {
+			header += ", " + next.GetName() + " to act"
+		}
+		var dead poker.Cards
+		if player, err := game.GetPlayerByID(hero); err == nil {
+			dead = poker.Cards(player.GetHandCards())
+			reads = append(reads, opponentRead{name: player.GetName() + " (hero)", rng: reading.Estimate(game, hero, nil)})
+		}
+		for _, player := range game.GetAllPlayers() {
+			if player.GetID() == hero || player.IsFolded() || len(player.GetHandCards()) == 0 {
+				continue
+			}
+			reads = append(reads, opponentRead{name: player.GetName(), rng: reading.Estimate(game, player.GetID(), dead)})
+		}
+	})
+	if header == "" {
+		if err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("ranges can only be read in Hold'em")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("ranges-hand-%03d-action-%02d.txt", replay.HandNumber, index+1))
+	err = writeFile(path, func(f *os.File) error {
+		fmt.Fprintln(f, header)
+		for _, format := range ranges.ExportFormats() {
+			fmt.Fprintf(f, "\n%s\n", rangeFormatTitles[format])
+			for _, read := range reads {
+				text, err := read.rng.Export(format)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(f, "%s: %s\n", read.name, text)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// rangeFormatTitles names the study tools each range notation is for
+var rangeFormatTitles = map[ranges.ExportFormat]string{
+	ranges.FormatWeighted: "PioSolver, GTO Wizard, Hand2Note",
+	ranges.FormatBracket:  "GTO+, Flopzilla",
+}
@@ -0,0 +1,78 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/rating"
+	"github.com/ljbink/ai-poker/engine/simulator"
+	"github.com/ljbink/ai-poker/engine/tournament"
+)
+
+func TestGameRunnerRatesTheGame(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	runner := newGameRunner(holdem.NewDiscardLogger(), data, nil)
+	runner.rate()
+	if len(data.GetRatings()) != 0 {
+		t.Fatal("Expected a game without hands to go unrated")
+	}
+
+	if _, err := runner.addBot(2, "tight"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runner.addBot(3, "maniac"); err != nil {
+		t.Fatal(err)
+	}
+	runner.chips = map[int]int{humanPlayerID: 300, 2: -100, 3: -200}
+	runner.rate()
+	ratings := data.GetRatings()
+	human, tight, maniac := ratings.Get("human"), ratings.Get("tight"), ratings.Get("maniac")
+	if human.Games != 1 || !(human.Rating > tight.Rating && tight.Rating > maniac.Rating) {
+		t.Errorf("Expected the human rated above tight above maniac, got %+v", ratings)
+	}
+
+	data.Reset()
+	if len(data.GetRatings()) != 0 {
+		t.Error("Expected Reset to clear the ratings")
+	}
+}
+
+func TestSitAndGoMatches(t *testing.T) {
+	matches := sitAndGoMatches([]*simulator.TournamentResult{{Standings: []tournament.Standing{
+		{Name: "nit", Place: 3},
+		{Name: "reader", Chips: 2000},
+		{Name: "tight", Chips: 1000},
+	}}})
+	if len(matches) != 1 || len(matches[0]) != 3 {
+		t.Fatalf("Expected one match of three, got %+v", matches)
+	}
+	ratings := rating.New()
+	ratings.Record(matches[0], rating.DefaultK)
+	board := ratings.Leaderboard()
+	if board[0].Name != "reader" || board[1].Name != "tight" || board[2].Name != "nit" {
+		t.Errorf("Expected the bots still in ranked by chips ahead of the one out, got %+v", board)
+	}
+}
+
+func TestGameSetupShowsRatings(t *testing.T) {
+	model := newTestModel(t, nil)
+	view := model.gameSetupView.(*GameSetupView)
+	if _, bots := view.lineupSummary(); bots != "" {
+		t.Errorf("Expected nothing listed before any bot is rated, got %q", bots)
+	}
+
+	data := model.GetData()
+	data.RecordMatches([]rating.Result{{Name: "human", Score: 500}, {Name: "reader", Score: 100}, {Name: "nit", Score: -600}})
+	if _, bots := view.lineupSummary(); bots != "Best rated by Elo: reader 1500 · nit 1484" {
+		t.Errorf("Expected the rated bots listed without the human, got %q", bots)
+	}
+
+	if err := data.SaveLineup(BotLineup{Name: "Tough", Bots: []LineupBot{{Profile: "nit", Stack: 1000}, {Profile: "tight", Stack: 800}}}); err != nil {
+		t.Fatal(err)
+	}
+	view.lineup = "Tough"
+	if name, bots := view.lineupSummary(); name != "Tough" || !strings.Contains(bots, "nit 1000 (Elo 1484)") || !strings.Contains(bots, "tight 800 (unrated)") {
+		t.Errorf("Expected the lineup's bots with their ratings, got %q", bots)
+	}
+}
@@ -0,0 +1,166 @@
+package frontend
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/session"
+)
+
+// RecoveryPoint is the autosave of a cash game in play: the table before
+// the hand being dealt and every action taken in it so far. Hands are dealt
+// from the table's seed, so replaying the actions on the restored table
+// brings the game back to where it stopped.
+type RecoveryPoint struct {
+	Table   *holdem.Snapshot      `json:"table"`
+	Actions []holdem.LoggedAction `json:"actions"`
+}
+
+// checkpoint autosaves the table between hands, when autosave is on
+func (r *gameRunner) checkpoint(game *holdem.Game) {
+	r.recovery = nil
+	if !r.data.GetSettings().AutoSave {
+		return
+	}
+	snapshot, err := r.snapshotTable(game)
+	if err != nil {
+		r.logger.Warn("autosaving the table failed", slog.Any("error", err))
+		return
+	}
+	r.recovery = &RecoveryPoint{Table: snapshot}
+	r.saveRecovery(r.recovery)
+}
+
+// recordAction adds an action of the hand in play to the recovery point
+func (r *gameRunner) recordAction(action holdem.LoggedAction) {
+	if r.recovery == nil {
+		return
+	}
+	r.recovery.Actions = append(r.recovery.Actions, action)
+	r.saveRecovery(r.recovery)
+}
+
+// saveRecovery autosaves point unless the game stopped in the meantime
+func (r *gameRunner) saveRecovery(point *RecoveryPoint) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.stopped {
+		return
+	}
+	r.data.SaveRecovery(point)
+	r.recoverable = true
+}
+
+// clearRecovery forgets the recovery point of a game that stopped cleanly.
+// Nothing is autosaved after it, so a crash is all that leaves one behind.
+func (r *gameRunner) clearRecovery() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.stopped = true
+	if r.recoverable {
+		r.data.ClearRecovery()
+		r.recoverable = false
+	}
+}
+
+// keepRecovery stops autosaving and leaves the recovery point for the next
+// start, as if the application had crashed
+func (r *gameRunner) keepRecovery() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.stopped = true
+	r.recoverable = false // Not this game's to clear any more
+}
+
+// scripted reports whether the next decision of a player is replayed
+func (r *gameRunner) scripted(playerID int) bool {
+	return len(r.script) > 0 && r.script[0].Action.PlayerID == playerID
+}
+
+// playHand plays the next hand on the deck stacked from the debug console,
+// if any, replaying the recovered actions before anyone is asked to decide
+func (r *gameRunner) playHand(ctx context.Context, s *session.Session) (*session.HandResult, error) {
+	r.applyStack(s.GetGame())
+	if len(r.script) == 0 {
+		return s.PlayHand(ctx)
+	}
+	makers := map[int]holdem_ai.IDecisionMaker{}
+	for id, maker := range r.makers {
+		scripted := &scriptedMaker{runner: r, inner: maker}
+		if _, ok := maker.(holdem_ai.ITimedDecisionMaker); ok {
+			makers[id] = &scriptedTimedMaker{scripted}
+		} else {
+			makers[id] = scripted
+		}
+	}
+	s.SetDecisionMakers(makers)
+	defer func() {
+		s.SetDecisionMakers(r.makers)
+		r.script = nil
+	}()
+	return s.PlayHand(ctx)
+}
+
+// scriptedMaker replays the runner's script while it has the player's next
+// action. Once the game goes another way, the script is dropped and the
+// player's own decision maker takes over.
+type scriptedMaker struct {
+	runner *gameRunner
+	inner  holdem_ai.IDecisionMaker
+}
+
+// next takes the player's replayed action off the script
+func (m *scriptedMaker) next(player holdem.IPlayer) (holdem.Action, bool) {
+	r := m.runner
+	if len(r.script) == 0 {
+		return holdem.Action{}, false
+	}
+	if !r.scripted(player.GetID()) {
+		r.script = nil
+		return holdem.Action{}, false
+	}
+	action := r.script[0].Action
+	r.script = r.script[1:]
+	r.replayed = true
+	return action, true
+}
+
+// MakeDecision implements the IDecisionMaker interface
+func (m *scriptedMaker) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	action, ok := m.next(player)
+	if !ok {
+		return m.inner.MakeDecision(game, player)
+	}
+	ch := make(chan holdem.Action, 1)
+	ch <- action
+	close(ch)
+	return ch
+}
+
+// ShouldMuck implements the IMuckDecider interface for players whose own
+// decision maker does
+func (m *scriptedMaker) ShouldMuck(game *holdem.Game, player holdem.IPlayer) bool {
+	decider, ok := m.inner.(holdem_ai.IMuckDecider)
+	return ok && decider.ShouldMuck(game, player)
+}
+
+// scriptedTimedMaker is the scriptedMaker of bots, which report how long
+// they thought
+type scriptedTimedMaker struct {
+	*scriptedMaker
+}
+
+// MakeTimedDecision implements the ITimedDecisionMaker interface. Replayed
+// actions take no time.
+func (m *scriptedTimedMaker) MakeTimedDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem_ai.TimedDecision {
+	action, ok := m.next(player)
+	if !ok {
+		return m.inner.(holdem_ai.ITimedDecisionMaker).MakeTimedDecision(game, player)
+	}
+	ch := make(chan holdem_ai.TimedDecision, 1)
+	ch <- holdem_ai.TimedDecision{Action: action}
+	close(ch)
+	return ch
+}
@@ -0,0 +1,85 @@
+package frontend
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/spectator"
+)
+
+// NavigateMsg asks the model to switch to another view, passing it Params.
+// Params is nil or the view's own params type, e.g. GameParams.
+type NavigateMsg struct {
+	To     ViewType
+	Params any
+}
+
+// Navigate returns the command that opens a view with params
+func Navigate(to ViewType, params any) tea.Cmd {
+	return func() tea.Msg {
+		return NavigateMsg{To: to, Params: params}
+	}
+}
+
+// Lifecycle is implemented by views that prepare when they are opened or
+// clean up when they are left
+type Lifecycle interface {
+	// OnEnter is called when the view is opened, with the params it was
+	// opened with, and returns the view's first command
+	OnEnter(params any) tea.Cmd
+
+	// OnExit is called when another view is opened
+	OnExit()
+}
+
+// GameParams opens the game view on a new game
+type GameParams struct {
+	SitAndGo bool // Sit-and-go instead of a cash game with the setup settings
+	Seats    int  // Sit-and-go table size
+	Resume   bool // Carry on the cash game saved from the pause menu
+	Recover  bool // Carry on the cash game an unclean shutdown interrupted
+
+	// Back from replaying a highlight: show the game just over again
+	Highlights bool
+}
+
+// SimulationParams opens the simulation view on a new bot tournament
+type SimulationParams struct {
+	Seats int
+}
+
+// SpectatorParams opens the spectator view on a live feed, or on a saved
+// hand when Feed is nil
+type SpectatorParams struct {
+	Feed       *spectator.Subscription
+	Bots       bool // Watch a table of bots started for the spectator, see watchBots
+	ReplayFile string
+	Replay     *holdem.Replay // Reviewed instead of ReplayFile when set
+	Bookmark   *Bookmark      // Reviewed from its decision, with its EV analysis, when set
+
+	// Where esc leaves the review for, the menu by default
+	Back       ViewType
+	BackParams any
+}
+
+// navigate leaves the current view and opens another one
+func (m *Model) navigate(to ViewType, params any) tea.Cmd {
+	if current, ok := m.view(m.currentView).(Lifecycle); ok {
+		current.OnExit()
+	}
+	m.currentView = to
+	if next, ok := m.view(to).(Lifecycle); ok {
+		return next.OnEnter(params)
+	}
+	return nil
+}
+
+// view returns the view of a type
+func (m *Model) view(viewType ViewType) View {
+	for _, view := range m.views() {
+		if view != nil && view.GetType() == viewType {
+			return view
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package frontend
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recordingView is a charts view that records its lifecycle calls
+type recordingView struct {
+	calls  []string
+	params any
+}
+
+func (v *recordingView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) { return nil, nil }
+func (v *recordingView) Render(width, height int) string            { return "recording" }
+func (v *recordingView) GetType() ViewType                          { return ViewCharts }
+
+func (v *recordingView) OnEnter(params any) tea.Cmd {
+	v.calls, v.params = append(v.calls, "enter"), params
+	return nil
+}
+
+func (v *recordingView) OnExit() {
+	v.calls = append(v.calls, "exit")
+}
+
+func TestNavigateRunsLifecycleHooks(t *testing.T) {
+	model := newTestModel(t, nil)
+	recorder := &recordingView{}
+	model.chartsView = recorder
+
+	model.Update(NavigateMsg{To: ViewCharts, Params: SimulationParams{Seats: 9}})
+	if model.currentView != ViewCharts || model.View() != "recording" {
+		t.Fatalf("Expected the charts view to be shown, got view %d", model.currentView)
+	}
+	if params, ok := recorder.params.(SimulationParams); !ok || params.Seats != 9 {
+		t.Errorf("Expected the params to reach OnEnter, got %+v", recorder.params)
+	}
+
+	model.Update(NavigateMsg{To: ViewIndex})
+	if model.currentView != ViewIndex || len(recorder.calls) != 2 || recorder.calls[1] != "exit" {
+		t.Errorf("Expected enter then exit, got %v", recorder.calls)
+	}
+}
+
+func TestNavigateOpensViewsWithParams(t *testing.T) {
+	model := newTestModel(t, nil)
+
+	_, cmd := model.Update(NavigateMsg{To: ViewSpectator, Params: SpectatorParams{ReplayFile: "missing.replay.json"}})
+	if msg := cmd(); msg != nil {
+		model.Update(msg)
+	}
+	if spectator := model.spectatorView.(*SpectatorView); spectator.err == nil {
+		t.Error("Expected the spectator to try loading the replay it was opened with")
+	}
+
+	model.Update(NavigateMsg{To: ViewTraining})
+	if training := model.trainingView.(*TrainingView); training.question == nil {
+		t.Error("Expected the quiz to ask a question when opened")
+	}
+}
+
+func TestNavigateCommandDeliversMessage(t *testing.T) {
+	msg := Navigate(ViewSettings, nil)()
+	if nav, ok := msg.(NavigateMsg); !ok || nav.To != ViewSettings {
+		t.Errorf("Expected a NavigateMsg to settings, got %#v", msg)
+	}
+}
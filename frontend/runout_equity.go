@@ -0,0 +1,91 @@
+package frontend
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+// runoutEquity shows the equity of the hands turned face up when a hand is
+// run out all-in, worked out again in the background as every street lands.
+// From the flop on every runout is enumerated; preflop there are too many,
+// so they are sampled.
+type runoutEquity struct {
+	task   *AsyncTask
+	hand   int // Hand number and board size the equities are for
+	board  int
+	equity map[int]float64 // By player ID, nil until calculated
+}
+
+// start works out the equities for a runout view when its board is new,
+// and forgets them once the next hand starts. Tables that allow no
+// assistance get none.
+func (r *runoutEquity) start(view holdem.TableView) tea.Cmd {
+	if view.NoAssistance {
+		r.stop()
+		return nil
+	}
+	if !view.Runout {
+		if view.HandNumber != r.hand {
+			r.stop()
+		}
+		return nil
+	}
+	if view.HandNumber == r.hand && len(view.Board) == r.board && (r.task != nil || r.equity != nil) {
+		return nil
+	}
+	players, holes := []int{}, []poker.Cards{}
+	for _, seat := range view.Seats {
+		if !seat.Folded && len(seat.HoleCards) > 0 {
+			players = append(players, seat.PlayerID)
+			holes = append(holes, seat.HoleCards)
+		}
+	}
+	if len(holes) < 2 {
+		return nil
+	}
+	r.task.Cancel()
+	if view.HandNumber != r.hand {
+		r.equity = nil // The last street's equities stay up until the new ones are in
+	}
+	r.hand, r.board = view.HandNumber, len(view.Board)
+	opts := equity.Options{Exact: len(view.Board) >= 3, Samples: probabilitySamples}
+	if view.Variant.IsKnown() {
+		opts.Evaluator = view.Variant.Rules().NewEvaluator()
+	}
+	board := view.Board
+
+	var task *AsyncTask
+	task, cmd := RunAsync(
+		func(ctx context.Context, report func(done, total int)) (*equity.Result, error) {
+			return equity.Calculate(holes, board, opts)
+		},
+		nil,
+		func(result *equity.Result, err error) tea.Cmd {
+			if r.task != task {
+				return nil
+			}
+			r.task = nil
+			if err != nil {
+				r.equity = nil
+				return nil
+			}
+			r.equity = map[int]float64{}
+			for i, id := range players {
+				r.equity[id] = result.Equity[i]
+			}
+			return nil
+		},
+	)
+	r.task = task
+	return cmd
+}
+
+// stop abandons the calculation and hides the equities
+func (r *runoutEquity) stop() {
+	r.task.Cancel()
+	*r = runoutEquity{}
+}
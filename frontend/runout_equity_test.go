@@ -0,0 +1,54 @@
+package frontend
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+)
+
+func TestRunoutEquityFollowsTheBoard(t *testing.T) {
+	model := newTestModel(t, nil)
+	aces, _ := poker.ParseCards("AhAs")
+	kings, _ := poker.ParseCards("KhKs")
+	board, _ := poker.ParseCards("Kd 7c 2h 9s")
+	view := holdem.TableView{
+		Variant:    holdem.VariantHoldem,
+		HandNumber: 4,
+		Runout:     true,
+		Board:      board[:3],
+		Seats: []holdem.SeatView{
+			{PlayerID: humanPlayerID, HoleCards: aces},
+			{PlayerID: 2, HoleCards: kings},
+			{PlayerID: 3, Folded: true, CardsHidden: true},
+		},
+	}
+
+	runout := &runoutEquity{}
+	drain(t, model, runout.start(view))
+	// Kings hit a set on the flop, aces need one of the two aces left
+	if len(runout.equity) != 2 || runout.equity[humanPlayerID] > 0.1 || math.Abs(runout.equity[humanPlayerID]+runout.equity[2]-1) > 1e-9 {
+		t.Fatalf("Unexpected flop equities %v", runout.equity)
+	}
+	if runout.start(view) != nil {
+		t.Error("Expected no new calculation for the same board")
+	}
+
+	view.Board = board
+	drain(t, model, runout.start(view))
+	if want := 2.0 / 44; math.Abs(runout.equity[humanPlayerID]-want) > 1e-9 {
+		t.Errorf("Expected aces to win with 2 of 44 rivers on the turn, got %.4f", runout.equity[humanPlayerID])
+	}
+
+	// Equities stay up through the showdown and go with the next hand
+	view.Runout = false
+	runout.start(view)
+	if runout.equity == nil {
+		t.Error("Expected the equities kept through the showdown")
+	}
+	runout.start(holdem.TableView{HandNumber: 5})
+	if runout.equity != nil {
+		t.Error("Expected the equities gone with the next hand")
+	}
+}
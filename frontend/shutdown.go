@@ -0,0 +1,63 @@
+package frontend
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// panicError is a panic caught in work running off the update loop
+type panicError struct {
+	value any
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.value)
+}
+
+// safely runs work and returns a panic in it as a *panicError. Bubble Tea
+// restores the terminal after panics in the update loop and in commands,
+// but a panic on any other goroutine kills the application with the
+// terminal still in raw mode, so goroutines the frontend starts run their
+// work through safely.
+func safely[T any](work func() (T, error)) (result T, err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = &panicError{value: value, stack: debug.Stack()}
+		}
+	}()
+	return work()
+}
+
+// shutdown stops the work still running when the application quits and
+// waits for it, so nothing is left half-saved: the game in play, which
+// forgets its recovery point unless the application crashed, simulations,
+// charts, hands being loaded and tables being saved
+func (m *Model) shutdown(clean bool) {
+	if v, ok := m.gameView.(*GameView); ok {
+		if clean {
+			v.stop()
+		} else {
+			v.halt()
+		}
+	}
+	if v, ok := m.simulationView.(*SimulationView); ok {
+		v.stop()
+	}
+	if v, ok := m.spectatorView.(*SpectatorView); ok {
+		v.stopWatching()
+	}
+	if v, ok := m.equityView.(*EquityView); ok {
+		v.stopChart()
+	}
+	m.background.Wait()
+}
+
+// track keeps the application from exiting before done is closed
+func (m *Model) track(done <-chan struct{}) {
+	m.background.Add(1)
+	go func() {
+		defer m.background.Done()
+		<-done
+	}()
+}
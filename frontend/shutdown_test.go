@@ -0,0 +1,66 @@
+package frontend
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// recoverableRunner returns a runner of a game that autosaved a recovery point
+func recoverableRunner(t *testing.T) (*gameRunner, *Data) {
+	t.Helper()
+	data := NewData(NewMemoryStore())
+	runner := newGameRunner(holdem.NewDiscardLogger(), data, nil)
+	table, err := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10}).Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	runner.saveRecovery(&RecoveryPoint{Table: table})
+	if data.GetRecovery() == nil {
+		t.Fatal("Expected the recovery point saved")
+	}
+	return runner, data
+}
+
+func TestGameRunnerCrashKeepsRecoveryPoint(t *testing.T) {
+	runner, data := recoverableRunner(t)
+	runner.start(func(ctx context.Context) (string, error) {
+		panic("deck ran out")
+	})
+	msg, ok := <-runner.updates
+	<-runner.done
+
+	if !ok || !strings.HasPrefix(msg.result, "Game stopped: panic:") {
+		t.Errorf("Expected the panic to stop the game, got %q", msg.result)
+	}
+	runner.stop()
+	if data.GetRecovery() == nil {
+		t.Error("Expected a crashed game to keep its recovery point")
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	for _, clean := range []bool{true, false} {
+		model := newTestModel(t, nil)
+		gv := model.gameView.(*GameView)
+		runner, data := recoverableRunner(t)
+		gv.runner = runner
+		model.track(runner.done)
+		runner.start(func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		})
+
+		model.shutdown(clean)
+		select {
+		case <-runner.done:
+		default:
+			t.Fatalf("Expected shutdown(%v) to wait for the game to stop", clean)
+		}
+		if kept := data.GetRecovery() != nil; kept == clean {
+			t.Errorf("Expected shutdown(%v) to keep the recovery point only after a crash, kept %v", clean, kept)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package frontend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Store keeps application data as JSON values under string keys and tells
+// subscribers when a key changes
+type Store interface {
+	// Get decodes the value stored under key into value, reporting false
+	// when nothing is stored
+	Get(key string, value any) (bool, error)
+
+	// Set stores value under key and notifies subscribers
+	Set(key string, value any) error
+
+	// Delete removes the value stored under key and notifies subscribers
+	Delete(key string) error
+
+	// Subscribe calls fn with the key after every change until the returned
+	// function is called. fn runs on the goroutine making the change.
+	Subscribe(fn func(key string)) (unsubscribe func())
+}
+
+// MemoryStore is a Store that keeps values in memory only
+type MemoryStore struct {
+	lock   sync.RWMutex
+	values map[string]json.RawMessage
+
+	subscribers subscribers
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{values: map[string]json.RawMessage{}}
+}
+
+func (s *MemoryStore) Get(key string, value any) (bool, error) {
+	s.lock.RLock()
+	data, ok := s.values[key]
+	s.lock.RUnlock()
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, value)
+}
+
+func (s *MemoryStore) Set(key string, value any) error {
+	if err := s.put(key, value); err != nil {
+		return err
+	}
+	s.subscribers.notify(key)
+	return nil
+}
+
+func (s *MemoryStore) Delete(key string) error {
+	s.remove(key)
+	s.subscribers.notify(key)
+	return nil
+}
+
+func (s *MemoryStore) Subscribe(fn func(key string)) func() {
+	return s.subscribers.add(fn)
+}
+
+// put stores the JSON encoding of value without notifying subscribers
+func (s *MemoryStore) put(key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.values[key] = data
+	return nil
+}
+
+// remove deletes the value under key without notifying subscribers
+func (s *MemoryStore) remove(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.values, key)
+}
+
+// FileStore is a Store that keeps every value in one JSON file, rewritten
+// on each change
+type FileStore struct {
+	path   string
+	memory *MemoryStore
+	lock   sync.Mutex // Serializes writes to the file
+}
+
+// NewFileStore opens the store kept in the file at path. A missing file is
+// an empty store; it is created on the first change.
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{path: path, memory: NewMemoryStore()}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store.memory.values); err != nil {
+		return nil, fmt.Errorf("invalid data file %s: %w", path, err)
+	}
+	if store.memory.values == nil {
+		store.memory.values = map[string]json.RawMessage{}
+	}
+	return store, nil
+}
+
+func (s *FileStore) Get(key string, value any) (bool, error) {
+	return s.memory.Get(key, value)
+}
+
+func (s *FileStore) Set(key string, value any) error {
+	s.lock.Lock()
+	err := s.memory.put(key, value)
+	if err == nil {
+		err = s.save()
+	}
+	s.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	s.memory.subscribers.notify(key)
+	return nil
+}
+
+func (s *FileStore) Delete(key string) error {
+	s.lock.Lock()
+	s.memory.remove(key)
+	err := s.save()
+	s.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	s.memory.subscribers.notify(key)
+	return nil
+}
+
+func (s *FileStore) Subscribe(fn func(key string)) func() {
+	return s.memory.Subscribe(fn)
+}
+
+// save writes every value to a temporary file and moves it over the store,
+// so a crash never leaves a half-written file behind
+func (s *FileStore) save() error {
+	s.memory.lock.RLock()
+	data, err := json.MarshalIndent(s.memory.values, "", "  ")
+	s.memory.lock.RUnlock()
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := writeSynced(tmp, data); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// writeSynced writes data to the file at path and flushes it to disk, so
+// the rename that follows never swaps in a file the OS has yet to write
+func writeSynced(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// subscribers is a set of change callbacks
+type subscribers struct {
+	lock sync.Mutex
+	next int
+	fns  map[int]func(key string)
+}
+
+func (s *subscribers) add(fn func(key string)) func() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.fns == nil {
+		s.fns = map[int]func(key string){}
+	}
+	id := s.next
+	s.next++
+	s.fns[id] = fn
+	return func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		delete(s.fns, id)
+	}
+}
+
+func (s *subscribers) notify(key string) {
+	s.lock.Lock()
+	fns := make([]func(key string), 0, len(s.fns))
+	for _, fn := range s.fns {
+		fns = append(fns, fn)
+	}
+	s.lock.Unlock()
+	for _, fn := range fns {
+		fn(key)
+	}
+}
@@ -0,0 +1,80 @@
+package frontend
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ljbink/ai-poker/internal/paths"
+)
+
+func TestFileStoreKeepsDataBetweenRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	data := NewData(store)
+	if settings := data.GetSettings(); settings.BigBlind != 10 || settings.SNGSeats != 6 {
+		t.Errorf("Expected default settings, got %+v", settings)
+	}
+	data.SetPlayerName("Alice")
+	data.UpdateSetting("big_blind", 50)
+	data.UpdateGameStats(true)
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("Reopening the store failed: %v", err)
+	}
+	data = NewData(reopened)
+	if user := data.GetUser(); user == nil || user.Name != "Alice" || user.GamesWon != 1 {
+		t.Errorf("Expected Alice with one win, got %+v", user)
+	}
+	if settings := data.GetSettings(); settings.BigBlind != 50 || settings.LogLevel != "info" {
+		t.Errorf("Expected the big blind changed and other settings kept, got %+v", settings)
+	}
+
+	data.Reset()
+	if data.GetUser() != nil || data.GetSettings().BigBlind != 10 {
+		t.Error("Expected Reset to clear the user and settings")
+	}
+}
+
+func TestDataNotifiesSubscribers(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	var changed []string
+	unsubscribe := data.Subscribe(func(key string) { changed = append(changed, key) })
+
+	data.SetPlayerName("Bob")
+	data.UpdateSetting("auto_check", true)
+	unsubscribe()
+	data.UpdateSetting("auto_muck", true)
+
+	if len(changed) != 2 || changed[0] != userKey || changed[1] != settingsKey {
+		t.Errorf("Expected user then settings changes, got %v", changed)
+	}
+}
+
+func TestDataReturnsCopies(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	data.GetSettings().BigBlind = 1000
+	if data.GetSettings().BigBlind != 10 {
+		t.Error("Expected changes to a returned copy not to reach the store")
+	}
+}
+
+func TestDataResolvesPathsInDataDir(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	if got := data.Path("exports"); got != "exports" {
+		t.Errorf("Expected the working directory without a data directory, got %q", got)
+	}
+
+	dir := t.TempDir()
+	data.SetDirs(paths.Dirs{Config: dir, Data: dir})
+	if got := data.Path("exports"); got != filepath.Join(dir, "exports") {
+		t.Errorf("Expected the export directory in the data directory, got %q", got)
+	}
+	absolute := filepath.Join(t.TempDir(), "hand.json")
+	if got := data.Path(absolute); got != absolute {
+		t.Errorf("Expected an absolute path kept, got %q", got)
+	}
+}
@@ -0,0 +1,124 @@
+package frontend
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+)
+
+// Flashing the hero's seat when the action reaches them: lit and dark in
+// turn, flashSteps times flashStep apart
+const (
+	flashSteps = 6
+	flashStep  = 150 * time.Millisecond
+)
+
+// countdownWidth is how many cells the full countdown bar takes
+const countdownWidth = 30
+
+// turnClock is how long the player who just started to act has to do it,
+// 0 when the clock is off
+type turnClock struct {
+	player  int
+	timeout time.Duration
+	started time.Time // When the view received it
+}
+
+// flashMsg moves the seat flash on by a step
+type flashMsg struct {
+	runner *gameRunner
+}
+
+// ringBell rings the terminal bell
+func ringBell() {
+	fmt.Fprint(os.Stdout, "\a")
+}
+
+// cueTurn tells the human the action is on them: the bell when sound is on,
+// a flashing seat when animations are
+func (v *GameView) cueTurn() tea.Cmd {
+	settings := v.model.GetData().GetSettings()
+	var cmds []tea.Cmd
+	if settings.SoundEnabled {
+		bell := v.bell
+		cmds = append(cmds, func() tea.Msg {
+			bell()
+			return nil
+		})
+	}
+	if settings.AnimationsEnabled && !v.model.Accessible() {
+		v.flash = flashSteps
+		cmds = append(cmds, v.flashTick())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (v *GameView) flashTick() tea.Cmd {
+	runner := v.runner
+	return tea.Tick(flashStep, func(time.Time) tea.Msg { return flashMsg{runner: runner} })
+}
+
+// stepFlash lights the hero's seat on odd steps until the flash is over
+func (v *GameView) stepFlash(msg flashMsg) tea.Cmd {
+	if msg.runner != v.runner || v.flash == 0 {
+		return nil
+	}
+	v.flash--
+	if v.flash == 0 {
+		return nil
+	}
+	return v.flashTick()
+}
+
+// timeLeft returns how long the human has left to act, by the clock of the
+// decision maker that folds for them when it runs out
+func (v *GameView) timeLeft() time.Duration {
+	deadline, ok := v.runner.human.Deadline()
+	if !ok {
+		// Still on its way to the decision maker
+		return holdem_ai.HumanDecisionTimeout
+	}
+	return max(deadline.Sub(v.clock()), 0)
+}
+
+// renderCountdown draws the time left to act as a bar that empties, turning
+// from green to amber to red
+func (v *GameView) renderCountdown() string {
+	left := v.timeLeft()
+	seconds := int(math.Ceil(left.Seconds()))
+	if v.model.Accessible() {
+		return v.model.T("game.time_left", seconds)
+	}
+	share := float64(left) / float64(holdem_ai.HumanDecisionTimeout)
+	filled := int(math.Ceil(share * countdownWidth))
+	color := lipgloss.Color("#10B981") // Green
+	switch {
+	case share <= 0.2:
+		color = lipgloss.Color("#EF4444") // Red
+	case share <= 0.5:
+		color = lipgloss.Color("#F59E0B") // Yellow/Orange
+	}
+	bar := lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#4B5563")).Render(strings.Repeat("░", countdownWidth-filled)) // Dark gray
+	return fmt.Sprintf("⏳ %s %ds", bar, seconds)
+}
+
+// turnLeft returns how long the player to act has left out of how long
+// they had, zeros when nobody is timed. The human's time is the decision
+// maker's, the bots' runs from when the view heard of their turn.
+func (v *GameView) turnLeft() (int, time.Duration, time.Duration) {
+	turn := v.turn
+	if turn == nil || turn.timeout <= 0 {
+		return 0, 0, 0
+	}
+	if turn.player == humanPlayerID && v.runner != nil {
+		return turn.player, min(v.timeLeft(), turn.timeout), turn.timeout
+	}
+	return turn.player, max(turn.timeout-v.clock().Sub(turn.started), 0), turn.timeout
+}
@@ -0,0 +1,202 @@
+package frontend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// BookmarksKeyMap defines keybindings for the review queue
+type BookmarksKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Review key.Binding
+	Remove key.Binding
+	Back   key.Binding
+	Quit   key.Binding
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view.
+func (k BookmarksKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Review, k.Remove, k.Back, k.Quit}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (k BookmarksKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.Review, k.Remove},
+		{k.Back, k.Quit},
+	}
+}
+
+var bookmarksKeys = BookmarksKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"),
+	),
+	Review: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "review"),
+	),
+	Remove: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "done, remove"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// BookmarksView is the review queue of the decisions the human bookmarked
+// at the table, each reopened in the replayer with its EV analysis
+type BookmarksView struct {
+	model     *Model
+	keys      BookmarksKeyMap
+	bookmarks []Bookmark
+	cursor    int
+	status    string
+
+	// Components
+	header *component.HeaderComponent
+	helper *component.HelperComponent
+}
+
+// NewBookmarksView creates a new review queue view
+func NewBookmarksView(model *Model) *BookmarksView {
+	return &BookmarksView{
+		model: model,
+		keys:  bookmarksKeys,
+
+		// Initialize components with default width (will be updated in Render)
+		header: component.NewHeaderComponent("🔖 Bookmarks", 80),
+		helper: component.NewHelperComponent(bookmarksKeys, 80),
+	}
+}
+
+// OnEnter loads the queue, keeping the cursor on the decision just reviewed
+func (v *BookmarksView) OnEnter(params any) tea.Cmd {
+	v.status = ""
+	v.reload()
+	return nil
+}
+
+// DataChanged reloads the queue when a game bookmarks a decision
+func (v *BookmarksView) DataChanged(key string) {
+	if key == bookmarksKey {
+		v.reload()
+	}
+}
+
+// reload reads the queue
+func (v *BookmarksView) reload() {
+	v.bookmarks = v.model.GetData().GetBookmarks()
+	v.cursor = max(min(v.cursor, len(v.bookmarks)-1), 0)
+}
+
+// Update handles input for the review queue
+func (v *BookmarksView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		return v.model, Navigate(ViewIndex, nil)
+	case key.Matches(msg, v.keys.Quit):
+		return v.model, tea.Quit
+	case key.Matches(msg, v.keys.Up):
+		v.cursor = max(v.cursor-1, 0)
+	case key.Matches(msg, v.keys.Down):
+		v.cursor = max(min(v.cursor+1, len(v.bookmarks)-1), 0)
+	case len(v.bookmarks) == 0:
+		// Nothing to review
+	case key.Matches(msg, v.keys.Review):
+		bookmark := v.bookmarks[v.cursor]
+		return v.model, Navigate(ViewSpectator, SpectatorParams{Bookmark: &bookmark, Back: ViewBookmarks})
+	case key.Matches(msg, v.keys.Remove):
+		bookmark := v.bookmarks[v.cursor]
+		v.model.GetData().RemoveBookmark(bookmark.HandID, bookmark.Action)
+		v.reload()
+		v.status = fmt.Sprintf("Hand #%d taken off the queue", bookmark.HandNumber)
+	}
+	return v.model, nil
+}
+
+// Render renders the review queue
+func (v *BookmarksView) Render(width, height int) string {
+	// Update component widths for current screen size
+	v.header.SetWidth(width)
+	v.helper.SetWidth(width)
+
+	titleAtTop := v.header.Render()
+	helpAtBottom := v.helper.Render()
+	availableHeight := height - lipgloss.Height(titleAtTop) - lipgloss.Height(helpAtBottom)
+
+	gray := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	sections := []string{v.renderQueue(availableHeight - 6)}
+	if v.status != "" {
+		sections = append(sections, gray.Render(v.status))
+	}
+	content := lipgloss.NewStyle().
+		Width(width).
+		Align(lipgloss.Center).
+		Render(strings.Join(sections, "\n\n"))
+
+	// Center the queue in the middle of available space
+	centeredContent := lipgloss.Place(
+		width, availableHeight,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+
+	// Apply full screen style
+	fullScreenContainer := GetFullScreenStyle(width, height)
+	return fullScreenContainer.Render(titleAtTop + centeredContent + helpAtBottom)
+}
+
+// renderQueue lists as many bookmarks as fit in rows, keeping the cursor
+// in view
+func (v *BookmarksView) renderQueue(rows int) string {
+	if len(v.bookmarks) == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).
+			Render("No decisions bookmarked. Press B at your turn in a game to review the spot later.")
+	}
+	rows = max(rows, 1)
+	first := max(v.cursor-rows+1, 0)
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("%d decisions to review", len(v.bookmarks)))}
+	if len(v.bookmarks) == 1 {
+		lines[0] = lipgloss.NewStyle().Bold(true).Render("1 decision to review")
+	}
+	for i := first; i < len(v.bookmarks) && i < first+rows; i++ {
+		bookmark := v.bookmarks[i]
+		line := fmt.Sprintf("Hand #%-4d %-8s %s", bookmark.HandNumber,
+			holdem.PhaseToString(bookmark.Phase), bookmark.Saved.Local().Format("2 Jan 15:04"))
+		if i == v.cursor {
+			line = selectedItemStyle.Render("▸ " + line)
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#6B7280")). // Gray
+		Padding(0, 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+// GetType returns the view type
+func (v *BookmarksView) GetType() ViewType {
+	return ViewBookmarks
+}
@@ -0,0 +1,67 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/internal/paths"
+)
+
+func TestBookmarkedDecisionIsReviewed(t *testing.T) {
+	h := newTUIHarness(t, 100, 50)
+	data := h.model.GetData()
+	data.SetDirs(paths.Dirs{Data: t.TempDir()}) // The replay is saved there, not in the source tree
+	runner := newGameRunner(holdem.NewDiscardLogger(), data, nil)
+
+	// The bot on the button raises, and the hero bookmarks the decision before folding
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	game.PlayerSit(holdem.NewPlayer(humanPlayerID, "Hero", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Bot", 1000), 1)
+	if err := game.StartHand(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.TakeAction(holdem.NewRaise(2, 40)); err != nil {
+		t.Fatal(err)
+	}
+	prompt := runner.prompt(game)
+	if !runner.bookmark(prompt.hand, prompt.action) || runner.bookmark(prompt.hand, prompt.action) {
+		t.Fatal("Expected the decision bookmarked once")
+	}
+	if err := game.TakeAction(holdem.Action{PlayerID: humanPlayerID, Type: holdem.ActionFold}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := game.AwardPot(); err != nil {
+		t.Fatal(err)
+	}
+	runner.saveReplay(game)
+
+	bookmarks := data.GetBookmarks()
+	if len(bookmarks) != 1 {
+		t.Fatalf("Expected one bookmark saved with the hand, got %+v", bookmarks)
+	}
+	bookmark := bookmarks[0]
+	if bookmark.HandNumber != 1 || bookmark.Phase != holdem.PhasePreflop || bookmark.Replay.Actions[bookmark.Action].Action.Type != holdem.ActionFold {
+		t.Errorf("Expected the hero's preflop fold bookmarked, got %+v", bookmark)
+	}
+
+	// The queue reopens the spot in the replayer, with the call worked out
+	h.Send(NavigateMsg{To: ViewBookmarks})
+	h.WaitFor("1 decision to review")
+	h.Keys("enter")
+	screen := h.WaitFor("Bookmarked decision")
+	if !strings.Contains(strings.Join(strings.Fields(screen), " "), "30 to call into a pot of 50") {
+		t.Errorf("Expected the EV of calling the raise, got:\n%s", screen)
+	}
+	if sv := h.model.spectatorView.(*SpectatorView); sv.index != bookmark.Action-1 {
+		t.Errorf("Expected the review opened before the hero's action %d, got %d", bookmark.Action, sv.index)
+	}
+
+	h.Keys("esc")
+	h.WaitFor("1 decision to review")
+	h.Keys("x")
+	h.WaitFor("taken off the queue")
+	if len(data.GetBookmarks()) != 0 {
+		t.Errorf("Expected the queue emptied, got %+v", data.GetBookmarks())
+	}
+}
@@ -0,0 +1,245 @@
+package frontend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/charts"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// ChartsKeyMap defines keybindings for the preflop chart viewer
+type ChartsKeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	Left     key.Binding
+	Right    key.Binding
+	Position key.Binding
+	Facing   key.Binding
+	Stack    key.Binding
+	Back     key.Binding
+	Quit     key.Binding
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view.
+func (k ChartsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Left, k.Right, k.Position, k.Facing, k.Stack, k.Back}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (k ChartsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Left, k.Right},
+		{k.Position, k.Facing, k.Stack},
+		{k.Back, k.Quit},
+	}
+}
+
+var chartsKeys = ChartsKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up"),
+		key.WithHelp("↑", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down"),
+		key.WithHelp("↓", "down"),
+	),
+	Left: key.NewBinding(
+		key.WithKeys("left"),
+		key.WithHelp("←", "left"),
+	),
+	Right: key.NewBinding(
+		key.WithKeys("right"),
+		key.WithHelp("→", "right"),
+	),
+	Position: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "position"),
+	),
+	Facing: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "facing action"),
+	),
+	Stack: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "stack depth"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// ChartsView shows the shipped preflop charts on the range grid: raises in
+// purple, calls in green, by position, facing action and stack depth
+type ChartsView struct {
+	model    *Model
+	keys     ChartsKeyMap
+	book     *charts.Book
+	set      int // Index into the book's chart sets
+	position int // Index into charts.Positions
+	facing   int // Index into charts.Facings
+	err      string
+
+	// Components
+	header *component.HeaderComponent
+	helper *component.HelperComponent
+	grid   *component.RangeGridComponent
+}
+
+// NewChartsView creates a new preflop chart viewer
+func NewChartsView(model *Model) *ChartsView {
+	v := &ChartsView{
+		model: model,
+		keys:  chartsKeys,
+
+		// Initialize components with default width (will be updated in Render)
+		header: component.NewHeaderComponent("📊 Preflop Charts", 80),
+		helper: component.NewHelperComponent(chartsKeys, 80),
+		grid:   component.NewRangeGridComponent(80),
+	}
+	book, err := charts.Default()
+	if err != nil {
+		v.err = err.Error()
+		return v
+	}
+	v.book = book
+	// Start on the deepest charts
+	v.set = len(book.GetSets()) - 1
+	v.show()
+	return v
+}
+
+// current returns the chart set being shown, nil when none loaded
+func (v *ChartsView) current() *charts.ChartSet {
+	if v.book == nil {
+		return nil
+	}
+	sets := v.book.GetSets()
+	if v.set < 0 || v.set >= len(sets) {
+		return nil
+	}
+	return sets[v.set]
+}
+
+// show puts the selected chart on the grid
+func (v *ChartsView) show() {
+	set := v.current()
+	if set == nil {
+		return
+	}
+	v.err = ""
+	chart := set.Get(charts.Positions[v.position], charts.Facings[v.facing])
+	if chart == nil {
+		v.err = fmt.Sprintf("No %s chart for %s", charts.Facings[v.facing], charts.Positions[v.position])
+		v.grid.SetRange(nil)
+		v.grid.SetCallRange(nil)
+		return
+	}
+	v.grid.SetRange(chart.Raise)
+	v.grid.SetCallRange(chart.Call)
+}
+
+// Update handles input for the chart viewer
+func (v *ChartsView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		return v.model, Navigate(ViewIndex, nil)
+	case key.Matches(msg, v.keys.Quit):
+		return v.model, tea.Quit
+	case key.Matches(msg, v.keys.Up):
+		v.grid.Move(-1, 0)
+	case key.Matches(msg, v.keys.Down):
+		v.grid.Move(1, 0)
+	case key.Matches(msg, v.keys.Left):
+		v.grid.Move(0, -1)
+	case key.Matches(msg, v.keys.Right):
+		v.grid.Move(0, 1)
+	case key.Matches(msg, v.keys.Position):
+		v.position = (v.position + 1) % len(charts.Positions)
+		v.show()
+	case key.Matches(msg, v.keys.Facing):
+		v.facing = (v.facing + 1) % len(charts.Facings)
+		v.show()
+	case key.Matches(msg, v.keys.Stack):
+		if v.book != nil {
+			v.set = (v.set + 1) % len(v.book.GetSets())
+			v.show()
+		}
+	}
+	return v.model, nil
+}
+
+// Render renders the chart viewer
+func (v *ChartsView) Render(width, height int) string {
+	// Update component widths for current screen size
+	v.header.SetWidth(width)
+	v.helper.SetWidth(width)
+	v.grid.SetWidth(width)
+
+	sections := []string{}
+	if set := v.current(); set != nil {
+		sections = append(sections, lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#F3F4F6")).
+			Render(fmt.Sprintf("%s · %s · %s", set.Name, charts.Positions[v.position], charts.Facings[v.facing])))
+	}
+	if v.err != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#EF4444")). // Red
+			Render("✗ "+v.err))
+	}
+	sections = append(sections, v.grid.Render())
+
+	content := lipgloss.NewStyle().
+		Width(width).
+		Align(lipgloss.Center).
+		Render(strings.Join(sections, "\n\n"))
+
+	// Title at the top using header component
+	titleAtTop := v.header.Render()
+
+	// Help view at the bottom using helper component
+	helpAtBottom := v.helper.Render()
+
+	// Calculate actual space used by header and helper
+	headerHeight := lipgloss.Height(titleAtTop)
+	helperHeight := lipgloss.Height(helpAtBottom)
+	availableHeight := height - headerHeight - helperHeight
+
+	// Center the grid in the middle of available space
+	centeredContent := lipgloss.Place(
+		width, availableHeight,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+
+	// Combine title, content, and help without extra spacing
+	fullContent := titleAtTop + centeredContent + helpAtBottom
+
+	// Apply full screen style
+	fullScreenContainer := GetFullScreenStyle(width, height)
+	return fullScreenContainer.Render(fullContent)
+}
+
+// GetType returns the view type
+func (v *ChartsView) GetType() ViewType {
+	return ViewCharts
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view
+func (v *ChartsView) ShortHelp() []key.Binding {
+	return v.keys.ShortHelp()
+}
+
+// FullHelp returns keybindings for the expanded help view
+func (v *ChartsView) FullHelp() [][]key.Binding {
+	return v.keys.FullHelp()
+}
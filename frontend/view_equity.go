@@ -0,0 +1,447 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// equityHands is how many hands or ranges the calculator takes
+const equityHands = 3
+
+// equitySamples is how many runouts are sampled when a calculation is not exact
+const equitySamples = 10000
+
+// equityChartSamples is how many runouts are sampled per starting hand in a
+// preflop chart, fewer than the command line takes so it is ready quickly
+const equityChartSamples = 1000
+
+// EquityKeyMap defines keybindings for the equity calculator
+type EquityKeyMap struct {
+	Next      key.Binding
+	Previous  key.Binding
+	Calculate key.Binding
+	Chart     key.Binding
+	Back      key.Binding
+	Quit      key.Binding
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view.
+func (k EquityKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Next, k.Previous, k.Calculate, k.Chart, k.Back}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (k EquityKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Next, k.Previous},
+		{k.Calculate, k.Chart},
+		{k.Back, k.Quit},
+	}
+}
+
+var equityKeys = EquityKeyMap{
+	Next: key.NewBinding(
+		key.WithKeys("tab", "down"),
+		key.WithHelp("tab/↓", "next field"),
+	),
+	Previous: key.NewBinding(
+		key.WithKeys("shift+tab", "up"),
+		key.WithHelp("shift+tab/↑", "previous field"),
+	),
+	Calculate: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "calculate"),
+	),
+	Chart: key.NewBinding(
+		key.WithKeys("ctrl+g"),
+		key.WithHelp("ctrl+g", "chart vs hand 1"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("ctrl+c"),
+		key.WithHelp("ctrl+c", "quit"),
+	),
+}
+
+// equityLine is one hand's row in the calculator output
+type equityLine struct {
+	label  string
+	equity float64
+	win    float64
+	tie    float64
+	made   []string // Most frequent final hands, e.g. "Flush 35%"
+	outs   poker.Cards
+	known  bool // Outs were calculated for this hand
+}
+
+// EquityView is a standalone equity calculator: hands are typed as cards
+// (AhKh, or four cards for Omaha) or as ranges (TT+, AQs+), with an
+// optional board, and the view shows each hand's equity, outs and the
+// hands it ends up making. It also charts the preflop equity of every
+// starting hand against the first hand.
+type EquityView struct {
+	model  *Model
+	keys   EquityKeyMap
+	inputs []textinput.Model // Hands, then the board
+	focus  int
+	lines  []equityLine
+	note   string // Exact or sampled
+	err    string // Last input or calculation error
+
+	chart        *equity.Chart
+	chartTask    *AsyncTask // Chart being calculated, nil when none is
+	chartHands   int        // Starting hands charted so far
+	chartSamples int        // Runouts per starting hand
+
+	// Components
+	header *component.HeaderComponent
+	helper *component.HelperComponent
+}
+
+// NewEquityView creates a new equity calculator
+func NewEquityView(model *Model) *EquityView {
+	v := &EquityView{
+		model:        model,
+		keys:         equityKeys,
+		chartSamples: equityChartSamples,
+
+		// Initialize components with default width (will be updated in Render)
+		header: component.NewHeaderComponent("🧮 Equity Calculator", 80),
+		helper: component.NewHelperComponent(equityKeys, 80),
+	}
+	for i := 0; i <= equityHands; i++ {
+		ti := textinput.New()
+		ti.Width = 40
+		ti.Prompt = fmt.Sprintf("Hand %d: ", i+1)
+		ti.Placeholder = "cards like AhKh or A♥K♥, or a range like TT+, AQs+"
+		if i == equityHands {
+			ti.Prompt = "Board:  "
+			ti.Placeholder = "optional, e.g. 2h7hQc"
+		}
+		ti.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED"))
+		ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F3F4F6"))
+		ti.CursorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A78BFA"))
+		v.inputs = append(v.inputs, ti)
+	}
+	v.inputs[0].SetValue("AhKh")
+	v.inputs[1].SetValue("QQ+, AKs")
+	v.inputs[0].Focus()
+	return v
+}
+
+// Update handles input for the equity calculator
+func (v *EquityView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		v.stopChart()
+		return v.model, Navigate(ViewIndex, nil)
+	case key.Matches(msg, v.keys.Quit):
+		return v.model, tea.Quit
+	case key.Matches(msg, v.keys.Next):
+		v.setFocus((v.focus + 1) % len(v.inputs))
+		return v.model, nil
+	case key.Matches(msg, v.keys.Previous):
+		v.setFocus((v.focus + len(v.inputs) - 1) % len(v.inputs))
+		return v.model, nil
+	case key.Matches(msg, v.keys.Calculate):
+		v.stopChart()
+		v.calculate()
+		return v.model, nil
+	case key.Matches(msg, v.keys.Chart):
+		return v.model, v.startChart()
+	}
+
+	var cmd tea.Cmd
+	v.inputs[v.focus], cmd = v.inputs[v.focus].Update(msg)
+	return v.model, cmd
+}
+
+func (v *EquityView) setFocus(focus int) {
+	v.inputs[v.focus].Blur()
+	v.focus = focus
+	v.inputs[v.focus].Focus()
+}
+
+// calculate parses the inputs and runs the equity and outs calculators
+func (v *EquityView) calculate() {
+	v.lines, v.note, v.err = nil, "", ""
+
+	holdings := []equity.Holding{}
+	labels := []string{}
+	for i := 0; i < equityHands; i++ {
+		text := strings.TrimSpace(v.inputs[i].Value())
+		if text == "" {
+			continue
+		}
+		holding, err := equity.ParseHolding(text, ranges.WithLibrary(v.model.GetData().rangeLibrary()))
+		if err != nil {
+			v.err = fmt.Sprintf("Hand %d: %v", i+1, err)
+			return
+		}
+		holdings = append(holdings, holding)
+		labels = append(labels, text)
+	}
+	board, err := poker.ParseCards(v.inputs[equityHands].Value())
+	if err != nil {
+		v.err = "Board: " + err.Error()
+		return
+	}
+
+	opts := equity.Options{Samples: equitySamples}
+	if len(holdings) > 0 && len(holdings[0].Cards) == 4 {
+		opts.Evaluator = holdem.VariantOmaha.Rules().NewEvaluator()
+	}
+	result, err := equity.CalculateHoldings(holdings, board, opts)
+	if err != nil {
+		v.err = err.Error()
+		return
+	}
+	v.note = fmt.Sprintf("%d runouts sampled", result.Trials)
+	if result.Exact {
+		v.note = fmt.Sprintf("exact over %d runouts", result.Trials)
+	}
+
+	// Outs only make sense between known hands on the flop or turn
+	holes := []poker.Cards{}
+	for _, holding := range holdings {
+		if !holding.IsRange() {
+			holes = append(holes, holding.Cards)
+		}
+	}
+	withOuts := len(holes) == len(holdings) && (len(board) == 3 || len(board) == 4)
+
+	for i, label := range labels {
+		line := equityLine{
+			label:  label,
+			equity: result.Equity[i],
+			win:    float64(result.Wins[i]) / float64(result.Trials),
+			tie:    float64(result.Ties[i]) / float64(result.Trials),
+			made:   madeHands(result, i),
+		}
+		if withOuts {
+			if outs, err := equity.Outs(holes, board, i, opts); err == nil {
+				line.outs, line.known = outs, true
+			}
+		}
+		v.lines = append(v.lines, line)
+	}
+}
+
+// startChart charts every starting hand against the first hand or range
+// in the background
+func (v *EquityView) startChart() tea.Cmd {
+	v.stopChart()
+	v.lines, v.note, v.err = nil, "", ""
+	label := strings.TrimSpace(v.inputs[0].Value())
+	opponent, err := equity.ParseHolding(label, ranges.WithLibrary(v.model.GetData().rangeLibrary()))
+	if err != nil {
+		v.err = "Hand 1: " + err.Error()
+		return nil
+	}
+
+	samples := v.chartSamples
+	var task *AsyncTask
+	task, cmd := RunAsync(
+		func(ctx context.Context, report func(done, total int)) (*equity.Chart, error) {
+			opts := equity.ChartOptions{Options: equity.Options{Samples: samples}, Progress: report}
+			return equity.PreflopChart(ctx, opponent, label, opts)
+		},
+		func(done, total int) {
+			v.chartHands = done
+		},
+		func(chart *equity.Chart, err error) tea.Cmd {
+			if v.chartTask != task {
+				return nil
+			}
+			v.chartTask, v.chart = nil, chart
+			if err != nil {
+				v.err = err.Error()
+			}
+			return nil
+		},
+	)
+	v.chartTask = task
+	return cmd
+}
+
+// stopChart abandons any chart being calculated and hides the last one
+func (v *EquityView) stopChart() {
+	v.chartTask.Cancel()
+	v.chartTask, v.chart, v.chartHands = nil, nil, 0
+}
+
+// madeHands lists the final hands a hand makes most often, strongest first
+func madeHands(result *equity.Result, i int) []string {
+	made := []string{}
+	for rank := holdem.RoyalFlush; rank >= holdem.HighCard; rank-- {
+		if fraction := result.MadeFraction(i, rank); fraction >= 0.005 {
+			made = append(made, fmt.Sprintf("%s %.0f%%", holdem.HandRankToString(rank), fraction*100))
+		}
+	}
+	return made
+}
+
+// Render renders the equity calculator
+func (v *EquityView) Render(width, height int) string {
+	// Update component widths for current screen size
+	v.header.SetWidth(width)
+	v.helper.SetWidth(width)
+
+	fields := make([]string, 0, len(v.inputs))
+	for _, input := range v.inputs {
+		fields = append(fields, input.View())
+	}
+	sections := []string{
+		lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#7C3AED")). // Purple
+			Padding(0, 1).
+			Render(strings.Join(fields, "\n")),
+	}
+	if v.err != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#EF4444")). // Red
+			Render("✗ "+v.err))
+	}
+	if len(v.lines) > 0 {
+		sections = append(sections, v.renderResults())
+	}
+	if v.chartTask != nil {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")). // Medium gray
+			Render(fmt.Sprintf("Charting %d/169 starting hands...", v.chartHands)))
+	}
+	if v.chart != nil {
+		sections = append(sections, v.renderChart())
+	}
+
+	content := lipgloss.NewStyle().
+		Width(width).
+		Align(lipgloss.Center).
+		Render(strings.Join(sections, "\n\n"))
+
+	// Title at the top using header component
+	titleAtTop := v.header.Render()
+
+	// Help view at the bottom using helper component
+	helpAtBottom := v.helper.Render()
+
+	// Calculate actual space used by header and helper
+	headerHeight := lipgloss.Height(titleAtTop)
+	helperHeight := lipgloss.Height(helpAtBottom)
+	availableHeight := height - headerHeight - helperHeight
+
+	// Center the calculator in the middle of available space
+	centeredContent := lipgloss.Place(
+		width, availableHeight,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+
+	// Combine title, content, and help without extra spacing
+	fullContent := titleAtTop + centeredContent + helpAtBottom
+
+	// Apply full screen style
+	fullScreenContainer := GetFullScreenStyle(width, height)
+	return fullScreenContainer.Render(fullContent)
+}
+
+// renderResults draws one block per hand with its equity, outs and made hands
+func (v *EquityView) renderResults() string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F3F4F6")).Bold(true)
+	equityStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Bold(true) // Green
+	detailStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))            // Medium gray
+
+	blocks := []string{}
+	for _, line := range v.lines {
+		rows := []string{
+			labelStyle.Render(line.label) + "  " + equityStyle.Render(fmt.Sprintf("%.1f%%", line.equity*100)) +
+				detailStyle.Render(fmt.Sprintf("  win %.1f%% · tie %.1f%%", line.win*100, line.tie*100)),
+		}
+		if line.known {
+			outs := "none"
+			if len(line.outs) > 0 {
+				outs = fmt.Sprintf("%d (%s)", len(line.outs), line.outs.Codes())
+			}
+			rows = append(rows, detailStyle.Render("Outs: "+outs))
+		}
+		if len(line.made) > 0 {
+			rows = append(rows, detailStyle.Render("Makes: "+strings.Join(line.made, ", ")))
+		}
+		blocks = append(blocks, strings.Join(rows, "\n"))
+	}
+	blocks = append(blocks, detailStyle.Render(v.note))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#6B7280")). // Gray
+		Padding(0, 1).
+		Width(72).
+		Render(strings.Join(blocks, "\n\n"))
+}
+
+// renderChart draws the preflop chart as the 13x13 grid of equities,
+// greener where a hand does better
+func (v *EquityView) renderChart() string {
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")) // Medium gray
+	rows := []string{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#F3F4F6")).Bold(true).
+			Render(fmt.Sprintf("Preflop equity vs %s", v.chart.Opponent)),
+	}
+	header := "  "
+	for _, rank := range ranges.Ranks {
+		header += fmt.Sprintf("%4c", rank)
+	}
+	rows = append(rows, headerStyle.Render(header))
+	for row := range ranges.Ranks {
+		line := headerStyle.Render(string(ranges.Ranks[row]) + " ")
+		for col := range ranges.Ranks {
+			share := v.chart.Equity[ranges.GridHand(row, col)]
+			color := lipgloss.Color("#EF4444") // Red
+			switch {
+			case share >= 0.5:
+				color = lipgloss.Color("#10B981") // Green
+			case share >= 0.35:
+				color = lipgloss.Color("#F59E0B") // Yellow/Orange
+			}
+			line += lipgloss.NewStyle().Foreground(color).Render(fmt.Sprintf("%4.0f", share*100))
+		}
+		rows = append(rows, line)
+	}
+	rows = append(rows, headerStyle.Render(fmt.Sprintf("Suited above the diagonal · %d runouts per hand", v.chart.Samples)))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#6B7280")). // Gray
+		Padding(0, 1).
+		Render(strings.Join(rows, "\n"))
+}
+
+// GetType returns the view type
+func (v *EquityView) GetType() ViewType {
+	return ViewEquity
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view
+func (v *EquityView) ShortHelp() []key.Binding {
+	return v.keys.ShortHelp()
+}
+
+// FullHelp returns keybindings for the expanded help view
+func (v *EquityView) FullHelp() [][]key.Binding {
+	return v.keys.FullHelp()
+}
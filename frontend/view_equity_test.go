@@ -0,0 +1,30 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEquityViewChartsStartingHands(t *testing.T) {
+	h := newTUIHarness(t, 100, 50)
+	ev := h.model.equityView.(*EquityView)
+	ev.chartSamples = 20
+	h.run(Navigate(ViewEquity, nil))
+	h.WaitFor("Equity Calculator")
+
+	h.Keys("ctrl+g")
+	screen := h.WaitFor("Preflop equity vs AhKh")
+	if !strings.Contains(screen, "Suited above the diagonal · 20 runouts per hand") {
+		t.Errorf("Expected the chart legend, screen:\n%s", screen)
+	}
+	if len(ev.chart.Equity) != 169 {
+		t.Errorf("Expected all 169 starting hands charted, got %d", len(ev.chart.Equity))
+	}
+
+	// Calculating the hands again takes the chart down
+	h.Keys("enter")
+	screen = h.WaitFor("win")
+	if strings.Contains(screen, "Preflop equity vs") {
+		t.Errorf("Expected the chart gone, screen:\n%s", screen)
+	}
+}
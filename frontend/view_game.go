@@ -1,51 +1,245 @@
 package frontend
 
 import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/poker"
 	"github.com/ljbink/ai-poker/frontend/component"
 )
 
+// gameLogLines is how many recent log lines the game view shows
+const gameLogLines = 6
+
+// gameLogHistory is how many log lines the game view keeps to scroll back through
+const gameLogHistory = 200
+
 // GameKeyMap defines keybindings for the game view
 type GameKeyMap struct {
-	Back key.Binding
-	Quit key.Binding
+	Fold      key.Binding
+	CheckCall key.Binding
+	Raise     key.Binding
+	AllIn     key.Binding
+	More      key.Binding
+	Less      key.Binding
+	Bookmark  key.Binding
+	TopUp     key.Binding
+	Undo      key.Binding
+	Seat      key.Binding
+	SwapBot   key.Binding
+	Rebuy     key.Binding
+	CashOut   key.Binding
+	PlayOn    key.Binding
+	Manual    key.Binding
+	Peek      key.Binding
+	Read      key.Binding
+	Dismiss   key.Binding
+	Export    key.Binding
+	Highlight key.Binding
+	Lineup    key.Binding
+	LogBack   key.Binding
+	LogAhead  key.Binding
+	Save      key.Binding
+	Abandon   key.Binding
+	Back      key.Binding
+	Quit      key.Binding
+	Debug     key.Binding // Left out of the help
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view.
 func (k GameKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Back, k.Quit}
+	return []key.Binding{k.Fold, k.CheckCall, k.Raise, k.More, k.Less, k.AllIn, k.TopUp, k.Back, k.Quit}
 }
 
 // FullHelp returns keybindings for the expanded help view.
 func (k GameKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
+		{k.Fold, k.CheckCall, k.Raise, k.AllIn},
+		{k.More, k.Less, k.Bookmark},
+		{k.TopUp, k.Rebuy, k.Undo, k.Seat, k.SwapBot},
+		{k.CashOut, k.PlayOn},
+		{k.Manual, k.Peek, k.Read, k.Dismiss},
+		{k.Export, k.Highlight, k.Lineup, k.Save, k.Abandon},
+		{k.LogBack, k.LogAhead},
 		{k.Back, k.Quit},
 	}
 }
 
 var gameKeys = GameKeyMap{
+	Fold: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "fold"),
+	),
+	CheckCall: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "check/call"),
+	),
+	Raise: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "raise"),
+	),
+	AllIn: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "all-in"),
+	),
+	More: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑", "raise more"),
+	),
+	Less: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓", "raise less"),
+	),
+	Bookmark: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "bookmark this decision for review"),
+	),
+	TopUp: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "top up"),
+	),
+	Undo: key.NewBinding(
+		key.WithKeys("u"),
+		key.WithHelp("u", "undo the last hand (cash games)"),
+	),
+	Seat: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "move to the next empty seat"),
+	),
+	SwapBot: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "replace or send away a bot (cash games)"),
+	),
+	Rebuy: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "buy in again"),
+	),
+	CashOut: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "cash out"),
+	),
+	PlayOn: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "keep playing"),
+	),
+	Manual: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "play this hand manually"),
+	),
+	Peek: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p (hold)", "peek at hidden cards"),
+	),
+	Read: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "read the next opponent's range"),
+	),
+	Dismiss: key.NewBinding(
+		key.WithKeys("enter", " "),
+		key.WithHelp("enter", "close hand summary"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "export session"),
+	),
+	Highlight: key.NewBinding(
+		key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+		key.WithHelp("1-9", "replay a highlight (game over)"),
+	),
+	Lineup: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "save opponents as a lineup"),
+	),
+	LogBack: key.NewBinding(
+		key.WithKeys("pgup"),
+		key.WithHelp("pgup/wheel", "scroll the log back"),
+	),
+	LogAhead: key.NewBinding(
+		key.WithKeys("pgdown"),
+		key.WithHelp("pgdn/wheel", "scroll the log ahead"),
+	),
+	Save: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "save and quit (paused)"),
+	),
+	Abandon: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "abandon table (paused)"),
+	),
 	Back: key.NewBinding(
 		key.WithKeys("esc"),
-		key.WithHelp("esc", "back to menu"),
+		key.WithHelp("esc", "pause"),
 	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
 	),
+	Debug: key.NewBinding(
+		key.WithKeys("ctrl+d"),
+		key.WithHelp("ctrl+d", "debug console"),
+	),
 }
 
-// GameView represents the game screen
+// GameView represents the game screen where the human plays against bots
 type GameView struct {
 	model *Model
 	keys  GameKeyMap
 	help  help.Model
 
+	runner   *gameRunner
+	played   *gameRunner // The last runner started, kept to export its hands once it stops
+	log      []string
+	scroll   int // Log lines scrolled back from the latest
+	status   string
+	prompt   *actionPrompt // Non-nil while waiting for the human
+	raiseBy  int           // Raise on top of the call chosen with ↑/↓
+	busted   bool          // Waiting for the human to buy in again
+	limit    string        // Session limit reached, waiting to cash out or play on
+	result   string        // Set once the game is over
+	settle   string        // Who owes whom, shown with the result of a home game
+	winnings bool          // The winnings graph shows with the result
+	hand     int           // Number of the hand on the table
+	summary  *handSummary  // Last finished hand, until dismissed or the human acts again
+	paused   bool          // Pause menu open, the runner is held
+	odds     probabilityOverlay
+	runout   runoutEquity     // Equities of the hands face up in an all-in runout
+	clock    func() time.Time // Time shown in the status bar
+	private  bool             // The hero's cards stay face down unless peeked at
+	peek     time.Time        // The hero's cards show until then
+	flash    int              // Steps left of the seat flash, lit on odd ones
+	turn     *turnClock       // Times the player to act under their seat, nil between turns
+	stacking bool             // A step of the chip piles is on its way
+	bell     func()           // Rings when the action reaches the human
+	debug    debugConsole
+	reads    []opponentRead    // Opponents' ranges at the human's last decision
+	reading  int               // 1-based read shown on the grid, 0 for none
+	seats    []holdem.SeatView // Seats of the last table update, saved by the lineup prompt
+	lineup   lineupPrompt
+	bots     botPrompt
+
+	highlights []sessionHighlight // Notable hands of the game just over
+	finding    *AsyncTask         // Picking the highlights, nil once done
+
 	// Components
 	header *component.HeaderComponent
 	helper *component.HelperComponent
+	table  *component.TableComponent
+	chips  *component.ChipStackComponent
+	board  *component.BigCardComponent
+	hole   *component.BigCardComponent
+	graph  *component.EquityGraphComponent   // In the hand summary
+	won    *component.WinningsGraphComponent // With the result
+	bar    *component.StatusBarComponent
+	grid   *component.RangeGridComponent // Opponent reads
 }
 
 // NewGameView creates a new game view
@@ -63,41 +257,747 @@ func NewGameView(model *Model) *GameView {
 		// Initialize components with default width (will be updated in Render)
 		header: component.NewHeaderComponent("🎮 Game View", 80),
 		helper: component.NewHelperComponent(gameKeys, 80),
+		table:  component.NewTableComponent(80),
+		chips:  component.NewChipStackComponent(80),
+		board:  component.NewBigCardComponent(80),
+		hole:   component.NewBigCardComponent(80),
+		graph:  component.NewEquityGraphComponent(),
+		won:    component.NewWinningsGraphComponent(80),
+		bar:    component.NewStatusBarComponent(80),
+		grid:   component.NewRangeGridComponent(80),
+		clock:  time.Now,
+		bell:   ringBell,
+		debug:  newDebugConsole(),
+		lineup: newLineupPrompt(),
+	}
+}
+
+// StartCashGame seats the human with the game setup bots and starts dealing
+func (v *GameView) StartCashGame() tea.Cmd {
+	settings := v.model.GetData().GetSettings()
+	title := v.model.icon("🎮", fmt.Sprintf("Cash Game %d/%d", settings.SmallBlind, settings.BigBlind))
+	return v.start(title, func(ctx context.Context, runner *gameRunner) (string, error) {
+		return runner.playCashGame(ctx, settings, v.playerName())
+	})
+}
+
+// ResumeCashGame carries on the cash game saved from the pause menu, or
+// starts a new one when there is none. The save is used up.
+func (v *GameView) ResumeCashGame() tea.Cmd {
+	snapshot := v.model.GetData().GetSavedTable()
+	if snapshot == nil {
+		return v.StartCashGame()
+	}
+	v.model.GetData().ClearSavedTable()
+	settings := v.model.GetData().GetSettings()
+	title := v.model.icon("🎮", fmt.Sprintf("Cash Game %d/%d", snapshot.Config.SmallBlind, snapshot.Config.BigBlind))
+	return v.start(title, func(ctx context.Context, runner *gameRunner) (string, error) {
+		return runner.resumeCashGame(ctx, snapshot, nil, settings, v.playerName())
+	})
+}
+
+// RecoverCashGame carries on the cash game an unclean shutdown interrupted,
+// replaying the hand that was in play, or starts a new one when there is none
+func (v *GameView) RecoverCashGame() tea.Cmd {
+	point := v.model.GetData().GetRecovery()
+	if point == nil {
+		return v.StartCashGame()
+	}
+	v.model.GetData().ClearRecovery()
+	settings := v.model.GetData().GetSettings()
+	title := v.model.icon("🎮", fmt.Sprintf("Cash Game %d/%d", point.Table.Config.SmallBlind, point.Table.Config.BigBlind))
+	return v.start(title, func(ctx context.Context, runner *gameRunner) (string, error) {
+		return runner.resumeCashGame(ctx, point.Table, point.Actions, settings, v.playerName())
+	})
+}
+
+// StartSitAndGo starts a single-table sit-and-go against bots
+func (v *GameView) StartSitAndGo(seats int) tea.Cmd {
+	return v.start(v.model.icon("🏆", fmt.Sprintf("%d-max Sit & Go", seats)), func(ctx context.Context, runner *gameRunner) (string, error) {
+		return runner.playSitAndGo(ctx, seats, v.playerName())
+	})
+}
+
+// start abandons any running game and plays a new one on a fresh runner
+func (v *GameView) start(title string, play func(ctx context.Context, runner *gameRunner) (string, error)) tea.Cmd {
+	v.header.SetTitle(title)
+	runner := v.reset()
+	v.model.track(runner.done)
+	return tea.Batch(runner.start(func(ctx context.Context) (string, error) {
+		return play(ctx, runner)
+	}), tickStatus(runner))
+}
+
+// peekWindow is how long the hero's cards show after p. Terminals report
+// no key releases, but a held key repeats faster than this, so the cards
+// show while p is held.
+const peekWindow = 600 * time.Millisecond
+
+// peekEndedMsg redraws the table once a peek may have run out
+type peekEndedMsg struct{}
+
+// peekAtCards shows the hero's hidden cards for the peek window
+func (v *GameView) peekAtCards() tea.Cmd {
+	if !v.private {
+		return nil
+	}
+	v.peek = v.clock().Add(peekWindow)
+	return tea.Tick(peekWindow, func(time.Time) tea.Msg { return peekEndedMsg{} })
+}
+
+// exportSession writes the hands played so far to the export directory off
+// the update loop and logs where they went
+func (v *GameView) exportSession() tea.Cmd {
+	if v.played == nil {
+		return nil
+	}
+	settings := v.model.GetData().GetSettings()
+	runner, dir, anonymize := v.played, v.model.GetData().Path(settings.ExportDir), settings.AnonymizeExports
+	v.appendLog("Exporting the session…")
+	_, cmd := RunAsync(func(ctx context.Context, report func(done, total int)) (string, error) {
+		return runner.exportSession(dir, anonymize)
+	}, nil, func(path string, err error) tea.Cmd {
+		if err != nil {
+			v.appendLog("Export failed: " + err.Error())
+		} else {
+			v.appendLog("Session exported to " + path)
+		}
+		return nil
+	})
+	return cmd
+}
+
+// coverHoleCards keeps the hero's cards face down in privacy mode, except
+// while peeking and at the showdown, where everyone sees them anyway
+func (v *GameView) coverHoleCards() {
+	covered := v.private && v.table.View().Phase != holdem.PhaseShowdown && !v.clock().Before(v.peek)
+	if covered {
+		v.table.SetCovered(humanPlayerID)
+	} else {
+		v.table.SetCovered()
+	}
+	v.hole.SetFaceDown(covered)
+}
+
+// statusTickMsg moves the status bar clock on while runner plays
+type statusTickMsg struct {
+	runner *gameRunner
+}
+
+// tickStatus delivers the next status bar tick in a second
+func tickStatus(runner *gameRunner) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return statusTickMsg{runner: runner}
+	})
+}
+
+// tick moves the status bar clock on until the game stops
+func (v *GameView) tick(msg statusTickMsg) tea.Cmd {
+	if v.runner == nil || msg.runner != v.runner {
+		return nil
+	}
+	v.bar.SetClock(v.clock())
+	return tickStatus(v.runner)
+}
+
+// reset abandons any running game and prepares a new runner
+func (v *GameView) reset() *gameRunner {
+	v.stop()
+	v.log, v.status, v.prompt, v.busted, v.limit, v.result, v.hand, v.summary, v.paused = nil, "", nil, false, "", "", 0, nil, false
+	v.scroll = 0
+	v.settle, v.winnings = "", false
+	v.finding.Cancel()
+	v.finding, v.highlights = nil, nil
+	v.table.SetView(holdem.TableView{Button: -1, ActingSeat: -1})
+	v.chips.SetStacks(nil, false)
+	v.bar.Start(v.clock())
+	settings := v.model.GetData().GetSettings()
+	v.private, v.peek, v.flash, v.turn = settings.HideHoleCards || settings.StreamerMode, time.Time{}, 0, nil
+	v.bar.SetMasked(settings.StreamerMode)
+	v.runner = newGameRunner(v.model.GetLogger(), v.model.GetData(), v.model.Cards())
+	v.played = v.runner
+	v.runner.human.SetClock(v.clock)
+	v.runner.debugging.Store(v.debug.open)
+	v.debug.state, v.debug.note = nil, ""
+	v.reads, v.reading = nil, 0
+	v.seats, v.lineup.open, v.bots.open = nil, false, false
+	return v.runner
+}
+
+// OnEnter starts the game described by GameParams
+func (v *GameView) OnEnter(params any) tea.Cmd {
+	game, _ := params.(GameParams)
+	switch {
+	case game.Highlights && v.result != "":
+		return nil
+	case game.Resume:
+		return v.ResumeCashGame()
+	case game.Recover:
+		return v.RecoverCashGame()
+	case game.SitAndGo:
+		return v.StartSitAndGo(game.Seats)
+	}
+	return v.StartCashGame()
+}
+
+// OnExit leaves the table
+func (v *GameView) OnExit() {
+	v.stop()
+}
+
+// DataChanged hands changed auto-action and speed settings to the running game
+func (v *GameView) DataChanged(key string) {
+	if key == settingsKey && v.runner != nil {
+		settings := v.model.GetData().GetSettings()
+		v.runner.human.SetAutoActions(autoActions(settings))
+		v.runner.setSpeed(speedNamed(settings.GameSpeed))
+	}
+	if key == settingsKey {
+		settings := v.model.GetData().GetSettings()
+		v.private = settings.HideHoleCards || settings.StreamerMode
+		v.bar.SetMasked(settings.StreamerMode)
+	}
+}
+
+// showWinnings graphs every player's winnings with the result, when a hand
+// was played
+func (v *GameView) showWinnings(session *LastSession) {
+	v.winnings = session != nil
+	v.won.SetLines(session.lines())
+}
+
+func (v *GameView) stop() {
+	v.odds.stop()
+	v.runout.stop()
+	if v.runner != nil {
+		v.runner.stop()
+		v.runner = nil
+	}
+}
+
+// halt stops the game like stop, keeping its recovery point
+func (v *GameView) halt() {
+	v.odds.stop()
+	v.runout.stop()
+	if v.runner != nil {
+		v.runner.halt()
+		v.runner = nil
+	}
+}
+
+func (v *GameView) playerName() string {
+	if name := v.model.GetData().GetPlayerName(); name != "" {
+		return name
+	}
+	return "Hero"
+}
+
+// receive applies an update from the runner and keeps listening while it plays
+func (v *GameView) receive(msg gameUpdateMsg) tea.Cmd {
+	if v.runner == nil || msg.runner != v.runner {
+		return nil
+	}
+	if !msg.ok {
+		v.runner = nil
+		return nil
+	}
+	if msg.warning {
+		// The player warned is still deciding, the human maybe
+		v.appendLog(msg.log...)
+		return v.runner.wait()
+	}
+	var cue, highlights, chips tea.Cmd
+	v.turn = msg.turn
+	if v.turn != nil {
+		v.turn.started = v.clock()
+	}
+	if msg.result != "" {
+		v.result, v.settle = msg.result, msg.settle
+		v.showWinnings(msg.winnings)
+		highlights = v.findHighlights()
+	} else {
+		if msg.prompt != nil && v.prompt == nil {
+			cue = v.cueTurn()
+		}
+		switch {
+		case msg.summary != nil:
+			v.summary = msg.summary
+		case msg.prompt != nil:
+			v.summary = nil // Out of the way of the next decision
+		}
+		v.table.SetView(msg.view)
+		chips = v.stackChips(msg.view)
+		v.seats = msg.view.Seats
+		v.table.SetAvatars(msg.avatars)
+		if msg.debug != nil {
+			v.debug.state = msg.debug
+		}
+		if info := msg.session; info != nil {
+			v.bar.SetHands(info.hands)
+			v.bar.SetStack(info.stack, info.net)
+			v.bar.SetLevel(info.level)
+		}
+		if msg.view.HandNumber != v.hand {
+			v.reads, v.reading = nil, 0
+			v.table.SetWinningHand(0, nil)
+		}
+		if msg.winning != nil {
+			// Stays up through the pause before the next hand
+			v.table.SetWinningHand(msg.winner, msg.winning)
+		}
+		if msg.prompt != nil {
+			v.reads = msg.reads
+			v.reading = min(v.reading, len(v.reads))
+		}
+		v.hand = msg.view.HandNumber
+		v.status = msg.status
+		v.prompt = msg.prompt
+		v.busted = msg.busted
+		v.limit = msg.limit
+		if v.prompt != nil {
+			v.raiseBy = v.prompt.minRaise
+		}
+	}
+	v.appendLog(msg.log...)
+	return tea.Batch(v.updateOdds(msg), v.runout.start(msg.view), cue, highlights, chips, v.runner.wait())
+}
+
+// updateOdds starts the probability overlay for a new decision when it is
+// turned on, and hides it once the human is not deciding any more
+func (v *GameView) updateOdds(msg gameUpdateMsg) tea.Cmd {
+	if v.prompt == nil {
+		v.odds.stop()
+		return nil
+	}
+	settings := v.model.GetData().GetSettings()
+	if msg.prompt == nil || !settings.ShowProbabilities {
+		return nil
+	}
+	cmd := v.odds.start(msg.view)
+	if settings.StreamerMode {
+		return tea.Batch(cmd, v.odds.delay(v.clock(), time.Duration(settings.StreamerDelay)*time.Second))
+	}
+	return cmd
+}
+
+// appendLog adds lines to the log, keeping only the most recent ones
+func (v *GameView) appendLog(lines ...string) {
+	v.log = append(v.log, lines...)
+	if len(v.log) > gameLogHistory {
+		v.log = v.log[len(v.log)-gameLogHistory:]
+	}
+	if v.scroll > 0 {
+		// Stay on the lines being read
+		v.scrollLog(len(lines))
 	}
 }
 
+// scrollLog moves the log window back by lines, ahead when negative
+func (v *GameView) scrollLog(lines int) {
+	v.scroll = min(max(v.scroll+lines, 0), max(len(v.log)-gameLogLines, 0))
+}
+
+// logWindow returns the log lines in view
+func (v *GameView) logWindow() []string {
+	end := len(v.log) - v.scroll
+	return v.log[max(end-gameLogLines, 0):end]
+}
+
+// act sends the human's decision if it is legal right now. A raise's
+// amount is the bet it raises to.
+func (v *GameView) act(actionType holdem.ActionType, amount int) {
+	if v.prompt == nil || v.runner == nil || !v.prompt.can(actionType) {
+		return
+	}
+	action := holdem.Action{PlayerID: humanPlayerID, Type: actionType, Amount: amount}
+	if actionType == holdem.ActionRaise {
+		action = holdem.NewRaise(humanPlayerID, amount)
+	}
+	v.runner.human.SetAction(action)
+	v.prompt = nil
+	v.odds.stop()
+}
+
+// checkOrCall checks when the human can and calls otherwise
+func (v *GameView) checkOrCall() {
+	if v.prompt.can(holdem.ActionCheck) {
+		v.act(holdem.ActionCheck, 0)
+	} else {
+		v.act(holdem.ActionCall, v.prompt.call)
+	}
+}
+
+// bookmark marks the decision the human faces for review once the hand is
+// over, from the bookmarks in the main menu
+func (v *GameView) bookmark() {
+	if v.runner == nil || !v.runner.bookmark(v.prompt.hand, v.prompt.action) {
+		return
+	}
+	v.appendLog("Decision bookmarked, review it from Bookmarks in the menu once the hand is over")
+}
+
+// toggleManual switches the auto actions off for the current hand, or back on
+func (v *GameView) toggleManual() {
+	if v.runner == nil || v.hand == 0 || v.runner.human.GetAutoActions() == (holdem_ai.AutoActions{}) {
+		return
+	}
+	if v.runner.human.IsOverridden(v.hand) {
+		v.runner.human.OverrideAutoActions(0)
+		v.appendLog("Auto actions back on")
+		return
+	}
+	v.runner.human.OverrideAutoActions(v.hand)
+	v.appendLog(fmt.Sprintf("Auto actions off for hand #%d", v.hand))
+}
+
 // Update handles input for the game view
 func (v *GameView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if v.paused {
+		return v.updatePaused(msg)
+	}
+	if key.Matches(msg, v.keys.Debug) {
+		v.toggleDebug()
+		return v.model, nil
+	}
+	if v.debug.open {
+		return v.updateDebug(msg)
+	}
+	if v.lineup.open {
+		return v.updateLineupPrompt(msg)
+	}
+	if v.bots.open {
+		return v.updateBotPrompt(msg)
+	}
 	switch {
 	case key.Matches(msg, v.keys.Back):
-		// Go back to index
-		v.model.currentView = ViewIndex
+		if v.runner != nil && v.result == "" {
+			v.paused = true
+			v.runner.hold()
+			return v.model, nil
+		}
+		// The game is over, go back to index
+		return v.model, Navigate(ViewIndex, nil)
 	case key.Matches(msg, v.keys.Quit):
+		v.stop()
 		return v.model, tea.Quit
+	case key.Matches(msg, v.keys.TopUp):
+		if v.runner != nil && !v.busted {
+			v.runner.request(requestTopUp)
+			v.appendLog("Top-up requested, it applies between hands")
+		}
+	case key.Matches(msg, v.keys.Seat):
+		if v.runner != nil && !v.busted {
+			v.runner.request(requestChangeSeat)
+			v.appendLog("Seat change requested, it applies between hands")
+		}
+	case key.Matches(msg, v.keys.SwapBot):
+		v.openBotPrompt()
+	case key.Matches(msg, v.keys.Undo):
+		switch {
+		case v.runner == nil || v.busted:
+		case !v.runner.canSave():
+			// Tournament results count, so their hands stand
+			v.appendLog("Hands can only be undone in cash games")
+		default:
+			v.runner.request(requestUndo)
+			v.appendLog("Undo requested, it applies once the hand is over")
+		}
+	case key.Matches(msg, v.keys.Rebuy):
+		if v.runner != nil && v.busted {
+			v.runner.request(requestRebuy)
+			v.busted = false
+		}
+	case key.Matches(msg, v.keys.CashOut):
+		if v.runner != nil && v.limit != "" {
+			v.runner.request(requestCashOut)
+			v.limit = ""
+		}
+	case key.Matches(msg, v.keys.PlayOn):
+		if v.runner != nil && v.limit != "" {
+			v.runner.request(requestKeepPlaying)
+			v.limit = ""
+		}
+	case key.Matches(msg, v.keys.Manual):
+		v.toggleManual()
+	case key.Matches(msg, v.keys.Peek):
+		return v.model, v.peekAtCards()
+	case key.Matches(msg, v.keys.Read):
+		v.cycleRead()
+	case key.Matches(msg, v.keys.Dismiss) && v.summary != nil:
+		v.summary = nil
+	case key.Matches(msg, v.keys.Export):
+		return v.model, v.exportSession()
+	case key.Matches(msg, v.keys.Highlight) && v.result != "":
+		return v.model, v.reviewHighlight(msg)
+	case key.Matches(msg, v.keys.Lineup):
+		return v.model, v.openLineupPrompt()
+	case key.Matches(msg, v.keys.LogBack):
+		v.scrollLog(1)
+	case key.Matches(msg, v.keys.LogAhead):
+		v.scrollLog(-1)
+	case v.prompt == nil:
+		// Nothing to decide
+	case key.Matches(msg, v.keys.Fold):
+		v.act(holdem.ActionFold, 0)
+	case key.Matches(msg, v.keys.CheckCall):
+		v.checkOrCall()
+	case key.Matches(msg, v.keys.Raise):
+		v.act(holdem.ActionRaise, v.prompt.bet+v.prompt.call+v.raiseBy)
+	case key.Matches(msg, v.keys.AllIn):
+		v.act(holdem.ActionAllIn, v.prompt.chips)
+	case key.Matches(msg, v.keys.More):
+		v.raiseBy = v.prompt.stepRaise(v.raiseBy, 1)
+	case key.Matches(msg, v.keys.Less):
+		v.raiseBy = v.prompt.stepRaise(v.raiseBy, -1)
+	case key.Matches(msg, v.keys.Bookmark):
+		v.bookmark()
 	}
 	return v.model, nil
 }
 
+// Mouse takes a click on a key shown in brackets, such as the prompt's
+// "[c]all", as a press of that key, and scrolls the log with the wheel
+func (v *GameView) Mouse(msg tea.MouseMsg, row string) (tea.Model, tea.Cmd) {
+	if v.paused || v.debug.open || v.lineup.open || v.bots.open {
+		return v.model, nil
+	}
+	if key, ok := wheelKey(msg, tea.KeyPgUp, tea.KeyPgDown); ok {
+		return v.Update(key)
+	}
+	if key, ok := clickedKey(row, msg.X); ok && leftClick(msg) {
+		return v.Update(key)
+	}
+	return v.model, nil
+}
+
+// updatePaused handles the pause menu: resume, save the table for later or
+// abandon it
+func (v *GameView) updatePaused(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		v.paused = false
+		v.runner.release()
+	case key.Matches(msg, v.keys.Save):
+		if !v.runner.canSave() {
+			break
+		}
+		// Stopping waits for the bots, so it runs off the update loop
+		runner, data := v.runner, v.model.GetData()
+		v.leave("Saving the table…")
+		v.model.background.Add(1)
+		_, cmd := RunAsync(func(ctx context.Context, report func(done, total int)) (*holdem.Snapshot, error) {
+			defer v.model.background.Done()
+			snapshot, err := runner.saveTable()
+			if err == nil {
+				data.SaveTable(snapshot)
+			}
+			return snapshot, err
+		}, nil, func(_ *holdem.Snapshot, err error) tea.Cmd {
+			if err != nil {
+				v.result = "Saving failed: " + err.Error()
+				return nil
+			}
+			return Navigate(ViewIndex, nil)
+		})
+		return v.model, cmd
+	case key.Matches(msg, v.keys.Abandon):
+		runner := v.runner
+		v.leave("Leaving the table…")
+		_, cmd := RunAsync(func(ctx context.Context, report func(done, total int)) (int, error) {
+			return runner.abandon()
+		}, nil, func(chips int, err error) tea.Cmd {
+			v.result = fmt.Sprintf("You left the table with %d chips", chips)
+			if err != nil {
+				v.result = "Abandoning failed: " + err.Error()
+			} else {
+				v.settle = runner.settlementText()
+				v.showWinnings(runner.lastSession())
+				return v.findHighlights()
+			}
+			return nil
+		})
+		return v.model, cmd
+	case key.Matches(msg, v.keys.Quit):
+		v.stop()
+		return v.model, tea.Quit
+	}
+	return v.model, nil
+}
+
+// leave forgets the runner being stopped from the pause menu
+func (v *GameView) leave(result string) {
+	v.odds.stop()
+	v.runner, v.paused, v.prompt, v.busted, v.limit, v.result = nil, false, nil, false, "", result
+}
+
+// renderPauseMenu lists what can be done with the paused game
+func (v *GameView) renderPauseMenu(width int) string {
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render(v.model.icon("⏸", "Game paused")),
+		"",
+		"esc  resume",
+	}
+	if v.runner.canSave() {
+		lines = append(lines, "s    save and quit, any hand in progress is called off")
+	} else {
+		lines = append(lines, "     tournaments cannot be saved")
+	}
+	lines = append(lines,
+		"x    abandon the table",
+		"",
+		"Bets in a hand that is called off go back to the players.",
+	)
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")). // Purple
+		Padding(0, 2)
+	if v.model.Accessible() {
+		box = lipgloss.NewStyle()
+	}
+	return box.MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
 // Render renders the game view
 func (v *GameView) Render(width, height int) string {
 	// Update component widths for current screen size
 	v.header.SetWidth(width)
 	v.helper.SetWidth(width)
+	v.table.SetWidth(width)
+	v.table.SetCardRenderer(v.model.Cards())
+	v.table.SetPlain(v.model.Accessible())
+	v.table.SetChipFormatter(v.model.Chips())
+	v.coverHoleCards()
+	v.table.SetEquities(v.runout.equity)
+	v.table.SetTimer(v.turnLeft())
+	v.table.SetFlash(0)
+	if v.flash%2 == 1 {
+		v.table.SetFlash(humanPlayerID)
+	}
 
-	content := "Welcome, " + GetData().GetPlayerName() + "!\n\n" +
-		"Game logic will be implemented here."
+	sections := []string{}
+	if v.model.Accessible() {
+		// One line that always announces whose turn it is, so a screen
+		// reader finds it in the same place
+		sections = append(sections, v.model.T("game.status", v.announcement()))
+	}
+	if v.status != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#A78BFA")). // Light purple
+			Render(v.status))
+	}
+	if cards := v.renderBigCards(width); cards != "" {
+		sections = append(sections, cards)
+	}
+	sections = append(sections, v.table.Render())
+	chipsAt := len(sections)
+	if v.debug.open {
+		sections = append(sections, v.renderDebug(width))
+	}
+	if v.lineup.open {
+		sections = append(sections, v.renderLineupPrompt())
+	}
+	if v.bots.open {
+		sections = append(sections, v.renderBotPrompt())
+	}
+	if read := v.renderRead(width); read != "" {
+		sections = append(sections, read)
+	}
+	if len(v.log) > 0 {
+		lines := v.logWindow()
+		if v.scroll > 0 {
+			lines = append(slices.Clone(lines), v.model.icon("▼", fmt.Sprintf("%d newer lines, pgdn to scroll ahead", v.scroll)))
+		}
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")). // Medium gray
+			Render(strings.Join(lines, "\n")))
+	}
+	if v.summary != nil {
+		sections = append(sections, v.renderSummary(width))
+	}
+	switch {
+	case v.paused:
+		sections = append(sections, v.renderPauseMenu(width))
+	case v.result != "":
+		sections = append(sections, lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#F59E0B")). // Yellow/Orange
+			Render(v.result+" · press esc to return to the menu"))
+		if v.settle != "" {
+			sections = append(sections, lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("#F59E0B")). // Yellow/Orange
+				Padding(0, 1).
+				Render("Settle up\n\n"+v.settle))
+		}
+		if v.winnings {
+			v.won.SetWidth(width - 4)
+			v.won.SetPlain(v.model.Accessible())
+			v.won.SetChipFormatter(v.model.Chips())
+			v.won.SetMasked(v.model.GetData().GetSettings().StreamerMode)
+			sections = append(sections, lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("#6B7280")). // Gray
+				Padding(0, 1).
+				Render(v.won.Render()))
+		}
+		if highlights := v.renderHighlights(); highlights != "" {
+			sections = append(sections, highlights)
+		}
+	case v.busted:
+		sections = append(sections, lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#F59E0B")). // Yellow/Orange
+			Render("Press b to buy in again or esc to leave the table"))
+	case v.limit != "":
+		sections = append(sections, lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#F59E0B")). // Yellow/Orange
+			Render(v.limit+" · press y to cash out or n to keep playing"))
+	case v.prompt != nil:
+		if !v.model.Accessible() {
+			sections = append(sections, lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#10B981")). // Green
+				Render(v.promptLine()))
+		}
+		sections = append(sections, v.renderCountdown())
+		if odds := v.odds.line(); odds != "" && v.odds.visible(v.clock()) {
+			sections = append(sections, lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#A78BFA")). // Light purple
+				Render(odds))
+		}
+	}
+	contentStyle := lipgloss.NewStyle().
+		Width(width).
+		Align(lipgloss.Center)
+	content := contentStyle.Render(strings.Join(sections, "\n\n"))
 
 	// Title at the top using header component
 	titleAtTop := v.header.Render()
 
-	// Help view at the bottom using helper component
-	helpAtBottom := v.helper.Render()
+	// Help view at the bottom using helper component, under the status bar
+	v.bar.SetWidth(width)
+	v.bar.SetPlain(v.model.Accessible())
+	v.bar.SetChipFormatter(v.model.Chips())
+	helpAtBottom := "\n" + v.bar.Render() + "\n" + v.helper.Render()
 
 	// Calculate actual space used by header and helper
 	headerHeight := lipgloss.Height(titleAtTop)
 	helperHeight := lipgloss.Height(helpAtBottom)
 	availableHeight := height - headerHeight - helperHeight
 
+	// Chip piles under the table in the room left, the stacks on a line when
+	// there is too little. Accessibility mode leaves them to the table.
+	if !v.model.Accessible() {
+		v.chips.SetWidth(width)
+		v.chips.SetChipFormatter(v.model.Chips())
+		v.chips.SetHeight(max(availableHeight-lipgloss.Height(content)-2, 1))
+		if piles := v.chips.Render(); piles != "" {
+			sections = slices.Insert(sections, chipsAt, piles)
+			content = contentStyle.Render(strings.Join(sections, "\n\n"))
+		}
+	}
+
 	// Center the game content in the middle of available space
 	centeredContent := lipgloss.Place(
 		width, availableHeight,
@@ -113,6 +1013,87 @@ func (v *GameView) Render(width, height int) string {
 	return fullScreenContainer.Render(fullContent)
 }
 
+// bigCardsGap separates the board from the hero's hole cards
+const bigCardsGap = 4
+
+// renderBigCards draws the board and the hero's hole cards large enough to
+// read at a glance, sharing the width between them. Accessibility mode
+// leaves them to the table's plain text.
+func (v *GameView) renderBigCards(width int) string {
+	if v.model.Accessible() {
+		return ""
+	}
+	view := v.table.View()
+	var hole []*poker.Card
+	for _, seat := range view.Seats {
+		if seat.PlayerID == humanPlayerID {
+			hole = seat.HoleCards
+		}
+	}
+	// Size for a full board so the cards keep their size from street to street
+	boardWidth := (width - bigCardsGap) * 5 / (5 + max(len(hole), 2))
+	_, fourColor := v.model.Cards().(component.FourColorCards)
+	v.board.SetWidth(boardWidth)
+	v.board.SetCards(view.Board)
+	v.board.SetFourColor(fourColor)
+	v.hole.SetWidth(width - bigCardsGap - boardWidth)
+	v.hole.SetCards(hole)
+	v.hole.SetFourColor(fourColor)
+
+	parts := []string{}
+	for _, cards := range []string{v.board.Render(), v.hole.Render()} {
+		if cards == "" {
+			continue
+		}
+		if len(parts) > 0 {
+			parts = append(parts, strings.Repeat(" ", bigCardsGap))
+		}
+		parts = append(parts, cards)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+}
+
+// announcement says whose turn it is, or how the game ended
+func (v *GameView) announcement() string {
+	switch {
+	case v.paused:
+		return "Game paused"
+	case v.result != "":
+		return v.result
+	case v.prompt != nil:
+		return v.promptLine()
+	}
+	for _, seat := range v.table.View().Seats {
+		if seat.Seat == v.table.View().ActingSeat {
+			return v.model.T("game.waiting_for", seat.Name)
+		}
+	}
+	return "-"
+}
+
+// promptLine lists what the human can do right now
+func (v *GameView) promptLine() string {
+	options := []string{}
+	if v.prompt.can(holdem.ActionFold) {
+		options = append(options, v.model.T("game.fold"))
+	}
+	if v.prompt.can(holdem.ActionCheck) {
+		options = append(options, v.model.T("game.check"))
+	} else if v.prompt.can(holdem.ActionCall) {
+		options = append(options, v.model.T("game.call", v.model.Chips().Format(v.prompt.call)))
+	}
+	if v.prompt.can(holdem.ActionRaise) {
+		options = append(options, v.model.T("game.raise", v.model.Chips().Format(v.prompt.bet+v.prompt.call+v.raiseBy)))
+	}
+	if v.prompt.can(holdem.ActionAllIn) {
+		options = append(options, v.model.T("game.all_in", v.model.Chips().Format(v.prompt.chips)))
+	}
+	if v.prompt.option {
+		return v.model.T("game.your_option", strings.Join(options, "  "))
+	}
+	return v.model.T("game.your_turn", strings.Join(options, "  "))
+}
+
 // GetType returns the view type
 func (v *GameView) GetType() ViewType {
 	return ViewGame
@@ -1,6 +1,7 @@
 package frontend
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -9,13 +10,28 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/rating"
 	"github.com/ljbink/ai-poker/frontend/component"
 )
 
+// Setup form fields: the template, the text inputs, the game and the opponents
+const (
+	gameSetupTemplateField   = 0
+	gameSetupSmallBlindField = 1
+	gameSetupBigBlindField   = 2
+	gameSetupNumBotsField    = 3
+	gameSetupVariantField    = 4
+	gameSetupLineupField     = 5
+	gameSetupFields          = 6
+)
+
 // GameSetupKeyMap defines keybindings for the game setup view
 type GameSetupKeyMap struct {
 	Up       key.Binding
 	Down     key.Binding
+	Change   key.Binding
+	Forget   key.Binding
 	Continue key.Binding
 	Back     key.Binding
 	Quit     key.Binding
@@ -23,13 +39,13 @@ type GameSetupKeyMap struct {
 
 // ShortHelp returns keybindings to be shown in the mini help view.
 func (k GameSetupKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Up, k.Down, k.Continue, k.Back, k.Quit}
+	return []key.Binding{k.Up, k.Down, k.Change, k.Continue, k.Back, k.Quit}
 }
 
 // FullHelp returns keybindings for the expanded help view.
 func (k GameSetupKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.Continue},
+		{k.Up, k.Down, k.Change, k.Forget, k.Continue},
 		{k.Back, k.Quit},
 	}
 }
@@ -43,6 +59,14 @@ var gameSetupKeys = GameSetupKeyMap{
 		key.WithKeys("down", "j"),
 		key.WithHelp("↓/j", "move down"),
 	),
+	Change: key.NewBinding(
+		key.WithKeys("left", "right"),
+		key.WithHelp("←/→", "change template, game or opponents"),
+	),
+	Forget: key.NewBinding(
+		key.WithKeys("delete"),
+		key.WithHelp("del", "forget lineup"),
+	),
 	Continue: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "start game"),
@@ -60,10 +84,13 @@ var gameSetupKeys = GameSetupKeyMap{
 // GameSetupView represents the game setup screen
 type GameSetupView struct {
 	model           *Model
-	focused         int // which input field is focused (0=small blind, 1=big blind, 2=num bots)
+	focused         int    // which field is focused (0=template, 1=small blind, 2=big blind, 3=num bots, 4=game, 5=opponents)
+	preset          string // Table template, empty for the default cash game
+	lineup          string // Saved lineup to play against, empty for random bots
 	smallBlindInput textinput.Model
 	bigBlindInput   textinput.Model
 	numBotsInput    textinput.Model
+	variant         holdem.GameVariant
 	keys            GameSetupKeyMap
 	help            help.Model
 
@@ -75,7 +102,7 @@ type GameSetupView struct {
 // NewGameSetupView creates a new game setup view
 func NewGameSetupView(model *Model) *GameSetupView {
 	// Get current settings
-	settings := GetData().GetSettings()
+	settings := model.GetData().GetSettings()
 
 	// Small blind input
 	smallBlind := textinput.New()
@@ -86,7 +113,6 @@ func NewGameSetupView(model *Model) *GameSetupView {
 	smallBlind.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F3F4F6"))
 	smallBlind.CursorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A78BFA"))
 	smallBlind.SetValue(strconv.Itoa(settings.SmallBlind)) // Load from settings
-	smallBlind.Focus()
 
 	// Big blind input
 	bigBlind := textinput.New()
@@ -115,10 +141,13 @@ func NewGameSetupView(model *Model) *GameSetupView {
 
 	return &GameSetupView{
 		model:           model,
-		focused:         0,
+		focused:         gameSetupTemplateField,
+		preset:          settings.TablePreset,
+		lineup:          settings.Lineup,
 		smallBlindInput: smallBlind,
 		bigBlindInput:   bigBlind,
 		numBotsInput:    numBots,
+		variant:         holdem.GameVariant(settings.Variant),
 		keys:            gameSetupKeys,
 		help:            h,
 
@@ -134,44 +163,55 @@ func (v *GameSetupView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch {
 	case key.Matches(msg, v.keys.Continue):
-		if v.validateInputs() {
+		if v.validateInputs() == nil {
 			// Store game settings
 			v.saveGameSettings()
-			// Move to game view
-			v.model.currentView = ViewGame
-			return v.model, nil
+			// Move to game view and start dealing
+			return v.model, Navigate(ViewGame, GameParams{})
 		}
 	case key.Matches(msg, v.keys.Back):
 		// Go back to login
-		v.model.currentView = ViewLogin
-		return v.model, nil
+		return v.model, Navigate(ViewLogin, nil)
 	case key.Matches(msg, v.keys.Up):
 		v.focused--
 		if v.focused < 0 {
-			v.focused = 2
+			v.focused = gameSetupFields - 1
 		}
 		v.updateFocus()
 	case key.Matches(msg, v.keys.Down):
 		v.focused++
-		if v.focused > 2 {
+		if v.focused >= gameSetupFields {
 			v.focused = 0
 		}
 		v.updateFocus()
+	case key.Matches(msg, v.keys.Change) && v.focused == gameSetupTemplateField:
+		v.applyPreset(nextPreset(v.preset, msg.String() == "left"))
+		return v.model, nil
+	case key.Matches(msg, v.keys.Change) && v.focused == gameSetupVariantField:
+		v.variant = nextVariant(v.variant)
+		return v.model, nil
+	case key.Matches(msg, v.keys.Change) && v.focused == gameSetupLineupField:
+		v.applyLineup(nextLineup(v.model.GetData().GetSettings().Lineups, v.lineup, msg.String() == "left"))
+		return v.model, nil
+	case key.Matches(msg, v.keys.Forget) && v.focused == gameSetupLineupField && v.lineup != "":
+		v.model.GetData().DeleteLineup(v.lineup)
+		v.lineup = ""
+		return v.model, nil
 	case key.Matches(msg, v.keys.Quit):
 		return v.model, tea.Quit
 	}
 
 	// Handle text input updates based on focused field
 	switch v.focused {
-	case 0:
+	case gameSetupSmallBlindField:
 		v.smallBlindInput, cmd = v.smallBlindInput.Update(msg)
 		// Auto-update big blind to be 2x small blind
 		if val, err := strconv.Atoi(v.smallBlindInput.Value()); err == nil && val > 0 {
 			v.bigBlindInput.SetValue(strconv.Itoa(val * 2))
 		}
-	case 1:
+	case gameSetupBigBlindField:
 		v.bigBlindInput, cmd = v.bigBlindInput.Update(msg)
-	case 2:
+	case gameSetupNumBotsField:
 		v.numBotsInput, cmd = v.numBotsInput.Update(msg)
 	}
 
@@ -185,24 +225,149 @@ func (v *GameSetupView) updateFocus() {
 	v.numBotsInput.Blur()
 
 	switch v.focused {
-	case 0:
+	case gameSetupSmallBlindField:
 		v.smallBlindInput.Focus()
-	case 1:
+	case gameSetupBigBlindField:
 		v.bigBlindInput.Focus()
-	case 2:
+	case gameSetupNumBotsField:
 		v.numBotsInput.Focus()
 	}
 }
 
-// validateInputs checks if all inputs are valid
-func (v *GameSetupView) validateInputs() bool {
+// applyPreset selects a table template and prefills the fields from it
+func (v *GameSetupView) applyPreset(name string) {
+	v.preset = name
+	preset, ok := holdem.ConfigPresetNamed(name)
+	if !ok {
+		return
+	}
+	v.smallBlindInput.SetValue(strconv.Itoa(preset.Config.SmallBlind))
+	v.bigBlindInput.SetValue(strconv.Itoa(preset.Config.BigBlind))
+	v.numBotsInput.SetValue(strconv.Itoa(min(preset.Config.Seats()-1, 8)))
+	v.variant = preset.Config.Variant
+}
+
+// nextPreset cycles through the default cash game and the table templates
+func nextPreset(name string, backwards bool) string {
+	names := []string{""}
+	for _, preset := range holdem.ConfigPresets() {
+		names = append(names, preset.Name)
+	}
+	step := 1
+	if backwards {
+		step = len(names) - 1
+	}
+	for i, n := range names {
+		if n == name {
+			return names[(i+step)%len(names)]
+		}
+	}
+	return names[0]
+}
+
+// applyLineup selects the opponents to play against, setting the number of
+// bots to the size of a saved lineup
+func (v *GameSetupView) applyLineup(name string) {
+	v.lineup = name
+	if lineup, ok := v.model.GetData().GetLineup(name); ok {
+		v.numBotsInput.SetValue(strconv.Itoa(len(lineup.Bots)))
+	}
+}
+
+// nextLineup cycles through random bots and the saved lineups
+func nextLineup(lineups []BotLineup, name string, backwards bool) string {
+	names := []string{""}
+	for _, lineup := range lineups {
+		names = append(names, lineup.Name)
+	}
+	step := 1
+	if backwards {
+		step = len(names) - 1
+	}
+	for i, n := range names {
+		if n == name {
+			return names[(i+step)%len(names)]
+		}
+	}
+	return names[0]
+}
+
+// lineupSummary names the selected opponents and, for a saved lineup,
+// lists its bots, their stacks and their ratings. Random bots list the
+// best rated presets.
+func (v *GameSetupView) lineupSummary() (string, string) {
+	ratings := v.model.GetData().GetRatings()
+	lineup, ok := v.model.GetData().GetLineup(v.lineup)
+	if !ok {
+		best := []string{}
+		for _, standing := range ratings.Leaderboard() {
+			if standing.Name != "human" && len(best) < ratedBotsShown {
+				best = append(best, fmt.Sprintf("%s %.0f", standing.Name, standing.Rating.Rating))
+			}
+		}
+		if len(best) == 0 {
+			return "Random bots", ""
+		}
+		return "Random bots", "Best rated by Elo: " + strings.Join(best, " · ")
+	}
+	bots := make([]string, 0, len(lineup.Bots))
+	for _, bot := range lineup.Bots {
+		bots = append(bots, fmt.Sprintf("%s %d (%s)", bot.Profile, bot.Stack, ratingText(ratings.Get(bot.Profile))))
+	}
+	return lineup.Name, strings.Join(bots, " · ")
+}
+
+// ratedBotsShown is how many of the best rated bots the game setup lists
+const ratedBotsShown = 3
+
+// ratingText prints a rating, e.g. "Elo 1620", or "unrated" before its first game
+func ratingText(r rating.Rating) string {
+	if r.Games == 0 {
+		return "unrated"
+	}
+	return fmt.Sprintf("Elo %.0f", r.Rating)
+}
+
+// presetTitle names the selected table template
+func (v *GameSetupView) presetTitle() string {
+	if preset, ok := holdem.ConfigPresetNamed(v.preset); ok {
+		return preset.Title
+	}
+	return "Custom"
+}
+
+// validateInputs checks the fields make a table the engine can deal and the
+// player can buy into, returning what is wrong
+func (v *GameSetupView) validateInputs() error {
 	smallBlind, err1 := strconv.Atoi(strings.TrimSpace(v.smallBlindInput.Value()))
 	bigBlind, err2 := strconv.Atoi(strings.TrimSpace(v.bigBlindInput.Value()))
 	numBots, err3 := strconv.Atoi(strings.TrimSpace(v.numBotsInput.Value()))
+	if err1 != nil || err2 != nil || err3 != nil {
+		return fmt.Errorf("blinds and bots must be whole numbers")
+	}
+
+	settings := *v.model.GetData().GetSettings()
+	settings.TablePreset, settings.SmallBlind, settings.BigBlind, settings.Variant = v.preset, smallBlind, bigBlind, string(v.variant)
+	config := cashGameConfig(&settings)
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if lineup, ok := settings.findLineup(v.lineup); ok && numBots != len(lineup.Bots) {
+		return fmt.Errorf("the %s lineup has %d bots", lineup.Name, len(lineup.Bots))
+	}
+	if numBots < 1 || numBots > min(config.Seats()-1, 8) {
+		return fmt.Errorf("the table seats 1 to %d bots", min(config.Seats()-1, 8))
+	}
+	return config.CheckBuyIn(v.buyIn(&settings, config))
+}
 
-	return err1 == nil && err2 == nil && err3 == nil &&
-		smallBlind > 0 && bigBlind > smallBlind &&
-		numBots >= 1 && numBots <= 8
+// buyIn returns the human's buy-in: the template's usual one, or the
+// default buy-in from the settings
+func (v *GameSetupView) buyIn(settings *SettingsData, config holdem.GameConfig) int {
+	if preset, ok := holdem.ConfigPresetNamed(v.preset); ok {
+		return preset.BuyInBB * config.BigBlind
+	}
+	return settings.DefaultBuyIn
 }
 
 // saveGameSettings stores the game configuration
@@ -212,10 +377,38 @@ func (v *GameSetupView) saveGameSettings() {
 	numBots, _ := strconv.Atoi(strings.TrimSpace(v.numBotsInput.Value()))
 
 	// Store in centralized data store (we might need to add these methods)
-	data := GetData()
+	data := v.model.GetData()
+	if preset, ok := holdem.ConfigPresetNamed(v.preset); ok {
+		data.UpdateSetting("default_buy_in", preset.BuyInBB*bigBlind)
+	}
+	data.UpdateSetting("table_preset", v.preset)
+	data.UpdateSetting("lineup", v.lineup)
 	data.UpdateSetting("small_blind", smallBlind)
 	data.UpdateSetting("big_blind", bigBlind)
 	data.UpdateSetting("num_bots", numBots)
+	data.UpdateSetting("variant", string(v.variant))
+}
+
+// DataChanged reloads the inputs when the game settings change elsewhere
+func (v *GameSetupView) DataChanged(key string) {
+	if key != settingsKey {
+		return
+	}
+	settings := v.model.GetData().GetSettings()
+	v.smallBlindInput.SetValue(strconv.Itoa(settings.SmallBlind))
+	v.bigBlindInput.SetValue(strconv.Itoa(settings.BigBlind))
+	v.numBotsInput.SetValue(strconv.Itoa(settings.NumBots))
+	v.variant = holdem.GameVariant(settings.Variant)
+	v.preset = settings.TablePreset
+	v.lineup = settings.Lineup
+}
+
+// nextVariant cycles through the games the TUI can deal
+func nextVariant(variant holdem.GameVariant) holdem.GameVariant {
+	if variant == holdem.VariantOmaha {
+		return holdem.VariantHoldem
+	}
+	return holdem.VariantOmaha
 }
 
 // Render renders the game setup view
@@ -233,6 +426,22 @@ func (v *GameSetupView) Render(width, height int) string {
 	b.WriteString(instructions)
 	b.WriteString("\n\n")
 
+	// Template section
+	templateLabel := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#E5E7EB")).
+		Bold(true).
+		Render("Template:")
+	b.WriteString(templateLabel)
+	b.WriteString("\n")
+
+	templateBox := v.createBox("◀ "+v.presetTitle()+" ▶", v.focused == gameSetupTemplateField)
+	b.WriteString(templateBox)
+	if preset, ok := holdem.ConfigPresetNamed(v.preset); ok {
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(preset.Description))
+	}
+	b.WriteString("\n\n")
+
 	// Small Blind section
 	smallBlindLabel := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#E5E7EB")).
@@ -241,7 +450,7 @@ func (v *GameSetupView) Render(width, height int) string {
 	b.WriteString(smallBlindLabel)
 	b.WriteString("\n")
 
-	smallBlindBox := v.createInputBox(v.smallBlindInput, v.focused == 0)
+	smallBlindBox := v.createInputBox(v.smallBlindInput, v.focused == gameSetupSmallBlindField)
 	b.WriteString(smallBlindBox)
 	b.WriteString("\n\n")
 
@@ -253,7 +462,7 @@ func (v *GameSetupView) Render(width, height int) string {
 	b.WriteString(bigBlindLabel)
 	b.WriteString("\n")
 
-	bigBlindBox := v.createInputBox(v.bigBlindInput, v.focused == 1)
+	bigBlindBox := v.createInputBox(v.bigBlindInput, v.focused == gameSetupBigBlindField)
 	b.WriteString(bigBlindBox)
 	b.WriteString("\n\n")
 
@@ -265,12 +474,41 @@ func (v *GameSetupView) Render(width, height int) string {
 	b.WriteString(numBotsLabel)
 	b.WriteString("\n")
 
-	numBotsBox := v.createInputBox(v.numBotsInput, v.focused == 2)
+	numBotsBox := v.createInputBox(v.numBotsInput, v.focused == gameSetupNumBotsField)
 	b.WriteString(numBotsBox)
 	b.WriteString("\n\n")
 
+	// Game section
+	variantLabel := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#E5E7EB")).
+		Bold(true).
+		Render("Game:")
+	b.WriteString(variantLabel)
+	b.WriteString("\n")
+
+	variantBox := v.createBox("◀ "+v.variant.String()+" ▶", v.focused == gameSetupVariantField)
+	b.WriteString(variantBox)
+	b.WriteString("\n\n")
+
+	// Opponents section
+	lineupLabel := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#E5E7EB")).
+		Bold(true).
+		Render("Opponents:")
+	b.WriteString(lineupLabel)
+	b.WriteString("\n")
+
+	lineupName, lineupBots := v.lineupSummary()
+	lineupBox := v.createBox("◀ "+lineupName+" ▶", v.focused == gameSetupLineupField)
+	b.WriteString(lineupBox)
+	if lineupBots != "" {
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(lineupBots))
+	}
+	b.WriteString("\n\n")
+
 	// Validation status
-	if v.validateInputs() {
+	if err := v.validateInputs(); err == nil {
 		statusMsg := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#10B981")). // Green
 			Render("✓ Ready to start game")
@@ -278,7 +516,7 @@ func (v *GameSetupView) Render(width, height int) string {
 	} else {
 		statusMsg := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#EF4444")). // Red
-			Render("⚠ Please check your input values")
+			Render("⚠ " + strings.ReplaceAll(err.Error(), "\n", "\n⚠ "))
 		b.WriteString(statusMsg)
 	}
 
@@ -311,6 +549,11 @@ func (v *GameSetupView) Render(width, height int) string {
 
 // createInputBox creates a styled input box
 func (v *GameSetupView) createInputBox(input textinput.Model, focused bool) string {
+	return v.createBox(input.View(), focused)
+}
+
+// createBox frames a field's content, highlighting the focused field
+func (v *GameSetupView) createBox(content string, focused bool) string {
 	borderColor := "#6B7280" // Gray
 	if focused {
 		borderColor = "#7C3AED" // Purple when focused
@@ -320,7 +563,7 @@ func (v *GameSetupView) createInputBox(input textinput.Model, focused bool) stri
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color(borderColor)).
 		Padding(0, 1).
-		Render(input.View())
+		Render(content)
 }
 
 // GetType returns the view type
@@ -0,0 +1,90 @@
+package frontend
+
+import (
+	"strconv"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestGameSetupTemplatesPrefillFields(t *testing.T) {
+	model := newTestModel(t, nil)
+	view := model.gameSetupView.(*GameSetupView)
+	if err := view.validateInputs(); err != nil {
+		t.Fatalf("Expected the default setup to be valid, got %v", err)
+	}
+
+	// Micro cash, then turbo sit & go
+	view.Update(tea.KeyMsg{Type: tea.KeyRight})
+	view.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if view.preset != "turbo-sng" || view.smallBlindInput.Value() != "10" || view.bigBlindInput.Value() != "20" || view.numBotsInput.Value() != "5" {
+		t.Fatalf("Expected the turbo template to prefill 10/20 with 5 bots, got %s %s/%s with %s bots",
+			view.preset, view.smallBlindInput.Value(), view.bigBlindInput.Value(), view.numBotsInput.Value())
+	}
+
+	view.numBotsInput.SetValue("8")
+	if err := view.validateInputs(); err == nil {
+		t.Error("Expected 8 bots not to fit a 6-max table")
+	}
+	view.numBotsInput.SetValue("5")
+	view.bigBlindInput.SetValue("10")
+	if err := view.validateInputs(); err == nil {
+		t.Error("Expected a big blind no bigger than the small blind to be refused")
+	}
+	view.bigBlindInput.SetValue("20")
+
+	view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	settings := model.GetData().GetSettings()
+	if settings.TablePreset != "turbo-sng" || settings.DefaultBuyIn != 1500 || settings.SmallBlind != 10 {
+		t.Errorf("Expected the turbo table saved with a 1500 buy-in, got %+v", settings)
+	}
+	if config := cashGameConfig(settings); config.MaxSeats != 6 || config.MinBuyInBB != 75 {
+		t.Errorf("Expected the turbo table rules, got %+v", config)
+	}
+
+	// Back to the default cash game, whose buy-in of 1500 is above 100 big blinds
+	view.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	view.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if view.preset != "" {
+		t.Fatalf("Expected to cycle back to the custom table, got %q", view.preset)
+	}
+	view.smallBlindInput.SetValue("5")
+	view.bigBlindInput.SetValue("10")
+	if err := view.validateInputs(); err == nil {
+		t.Error("Expected a 150 big blind buy-in to be refused")
+	}
+}
+
+func TestGameSetupValidation(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		settings map[string]any
+		preset   string
+		sb, bb   int
+		bots     int
+		ok       bool
+	}{
+		{name: "defaults", sb: 5, bb: 10, bots: 3, ok: true},
+		{name: "no small blind", sb: 0, bb: 10, bots: 3},
+		{name: "big blind equal to small blind", sb: 10, bb: 10, bots: 3},
+		{name: "no bots", sb: 5, bb: 10, bots: 0},
+		{name: "nine bots", sb: 5, bb: 10, bots: 9},
+		{name: "buy-in under 40 big blinds", settings: map[string]any{"default_buy_in": 300}, sb: 5, bb: 10, bots: 3},
+		{name: "buy-in over 100 big blinds", sb: 50, bb: 5, bots: 3},
+		{name: "higher blinds with a bigger buy-in", settings: map[string]any{"default_buy_in": 5000}, sb: 25, bb: 50, bots: 3, ok: true},
+		{name: "deepstack seats eight bots", preset: "deepstack", sb: 5, bb: 10, bots: 8, ok: true},
+		{name: "micro cash seats five bots", preset: "micro-cash", sb: 5, bb: 10, bots: 6},
+		{name: "templates buy in by big blinds", preset: "micro-cash", sb: 50, bb: 100, bots: 5, ok: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			view := newTestModel(t, tt.settings).gameSetupView.(*GameSetupView)
+			view.preset = tt.preset
+			view.smallBlindInput.SetValue(strconv.Itoa(tt.sb))
+			view.bigBlindInput.SetValue(strconv.Itoa(tt.bb))
+			view.numBotsInput.SetValue(strconv.Itoa(tt.bots))
+			if err := view.validateInputs(); (err == nil) != tt.ok {
+				t.Errorf("Expected ok=%v, got %v", tt.ok, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,600 @@
+package frontend
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/session"
+	"github.com/ljbink/ai-poker/engine/tournament"
+	"github.com/ljbink/ai-poker/internal/paths"
+)
+
+// callBot checks or calls every decision and reports a fixed thinking time,
+// so scripted games render the same every run
+type callBot struct{}
+
+func (callBot) MakeDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem.Action {
+	ch := make(chan holdem.Action, 1)
+	if toCall := game.GetCurrentBet() - player.GetBet(); toCall > 0 {
+		ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCall, Amount: min(toCall, player.GetChips())}
+	} else {
+		ch <- holdem.Action{PlayerID: player.GetID(), Type: holdem.ActionCheck}
+	}
+	close(ch)
+	return ch
+}
+
+func (b callBot) MakeTimedDecision(game *holdem.Game, player holdem.IPlayer) <-chan holdem_ai.TimedDecision {
+	ch := make(chan holdem_ai.TimedDecision, 1)
+	ch <- holdem_ai.TimedDecision{Action: <-b.MakeDecision(game, player), Thinking: 2 * time.Second}
+	close(ch)
+	return ch
+}
+
+// playScriptedHand deals one seeded heads-up hand between the human on the
+// button and a calling bot
+func playScriptedHand(ctx context.Context, runner *gameRunner) (string, error) {
+	s, err := scriptedTable(ctx, runner)
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.PlayHand(ctx); err != nil {
+		return "", err
+	}
+	return "Scripted hand over", nil
+}
+
+// scriptedTable seats the human and a calling bot at a seeded heads-up table
+func scriptedTable(ctx context.Context, runner *gameRunner) (*session.Session, error) {
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 42})
+	runner.makers[2] = callBot{}
+	runner.names[2] = "calling-station" // Who sits in when the table is resumed
+	s := session.New(game)
+	s.SetDecisionMakers(runner.makers)
+	s.SetObserver(runner.observer(ctx))
+	runner.setTable(s, true)
+	if err := s.SitDown(holdem.NewPlayer(humanPlayerID, "Hero", 1000), 0); err != nil {
+		return nil, err
+	}
+	if err := s.SitDown(holdem.NewPlayer(2, "Callbot", 1000), 1); err != nil {
+		return nil, err
+	}
+	runner.status = func() string { return "Scripted game · Blinds 5/10" }
+	return s, nil
+}
+
+func TestGameViewPlaysScriptedHand(t *testing.T) {
+	h := newTUIHarness(t, 100, 40)
+	gv := h.model.gameView.(*GameView)
+	h.model.currentView = ViewGame
+	h.run(gv.start("🎮 Scripted Game", playScriptedHand))
+
+	h.WaitFor("Your turn")
+	h.Snapshot("game_preflop")
+
+	// Call preflop, then check the flop, turn and river behind the bot
+	for street := 0; street < 4; street++ {
+		h.WaitFor("Your turn")
+		h.Keys("c")
+	}
+	h.WaitFor("Scripted hand over")
+	h.WaitFor("You last put chips in on the preflop")
+	h.Snapshot("game_finished")
+
+	h.Keys("enter")
+	h.WaitFor("Scripted hand over")
+	if gv.summary != nil {
+		t.Error("Expected enter to close the hand summary")
+	}
+
+	h.Keys("esc")
+	h.WaitFor("Texas Hold'em Poker")
+	if gv.runner != nil {
+		t.Error("Expected leaving the table to stop the game")
+	}
+}
+
+func TestGameViewRaiseSizing(t *testing.T) {
+	h := newTUIHarness(t, 100, 40)
+	gv := h.model.gameView.(*GameView)
+	h.model.currentView = ViewGame
+	h.run(gv.start("🎮 Scripted Game", playScriptedHand))
+
+	h.WaitFor("[r]aise to 20")
+	h.Keys("up", "up")
+	h.WaitFor("[r]aise to 40")
+	h.Keys("down")
+	h.WaitFor("[r]aise to 30")
+	h.Keys("r")
+	h.WaitFor("Hero raises to 30")
+}
+
+func TestGameViewMouse(t *testing.T) {
+	h := newTUIHarness(t, 100, 40)
+	gv := h.model.gameView.(*GameView)
+	h.model.currentView = ViewGame
+	h.run(gv.start("🎮 Scripted Game", playScriptedHand))
+
+	// Clicking the prompt's call and checks plays the hand as c does
+	for street := 0; street < 4; street++ {
+		h.WaitFor("Your turn")
+		h.Click("[c]")
+	}
+	h.WaitFor("Scripted hand over")
+	if len(gv.log) <= gameLogLines+2 {
+		t.Fatalf("Expected more log than fits, got %d lines", len(gv.log))
+	}
+	latest := gv.log[len(gv.log)-1]
+
+	h.Wheel(-3)
+	h.WaitFor("3 newer lines")
+	if window := gv.logWindow(); window[len(window)-1] != gv.log[len(gv.log)-4] {
+		t.Errorf("Expected the log three lines back, got %q", window)
+	}
+	h.Keys("pgdown")
+	h.WaitFor("2 newer lines")
+	h.Wheel(5)
+	if gv.scroll != 0 {
+		t.Errorf("Expected the wheel to stop at the latest line, got %d back", gv.scroll)
+	}
+	h.WaitFor(latest)
+}
+
+func TestGameViewAccessibilityMode(t *testing.T) {
+	h := newTUIHarness(t, 100, 40)
+	h.model.GetData().UpdateSetting("accessibility", true)
+	h.Send(dataChangedMsg{key: settingsKey})
+	gv := h.model.gameView.(*GameView)
+	h.model.currentView = ViewGame
+	h.run(gv.start("Scripted Game", playScriptedHand))
+
+	h.WaitFor("Status: Your turn")
+	h.Snapshot("game_accessible")
+	h.Keys("c")
+	h.WaitFor("Hero calls 5")
+}
+
+func TestGameViewRecoversInterruptedHand(t *testing.T) {
+	h := newTUIHarness(t, 100, 40)
+	data := h.model.GetData()
+	data.UpdateSetting("game_speed", "instant")
+
+	// The game stopped after the hero raised in the first hand
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 42})
+	if err := game.PlayerSit(holdem.NewPlayer(humanPlayerID, "Hero", 1000), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.PlayerSit(holdem.NewPlayer(2, "Callbot", 1000), 1); err != nil {
+		t.Fatal(err)
+	}
+	table, err := game.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Seats[1].DecisionMaker = "calling-station"
+	data.SaveRecovery(&RecoveryPoint{
+		Table:   table,
+		Actions: []holdem.LoggedAction{{Phase: holdem.PhasePreflop, Action: holdem.NewRaise(humanPlayerID, 30)}},
+	})
+	h.Send(dataChangedMsg{key: recoveryKey})
+
+	h.WaitFor("Recover Interrupted Game")
+	h.Keys("enter")
+	h.WaitFor("Recovering hand #1")
+	h.WaitFor("Hero raises to 30 (replayed)")
+	h.WaitFor("Your turn")
+	point := data.GetRecovery()
+	if point == nil {
+		t.Fatal("Expected the recovered game to autosave")
+	}
+	if point.Table.HandNumber != 0 || len(point.Actions) < 2 || point.Actions[0].Action != holdem.NewRaise(humanPlayerID, 30) {
+		t.Errorf("Expected the replayed raise and the bot's answer autosaved, got %+v", point)
+	}
+
+	// Leaving cleanly forgets the recovery point
+	h.Keys("esc")
+	h.WaitFor("Game paused")
+	h.Keys("x")
+	h.WaitFor("You left the table with")
+	if data.GetRecovery() != nil {
+		t.Error("Expected abandoning the table to forget the recovery point")
+	}
+}
+
+func TestGameViewHidesHoleCardsUntilPeek(t *testing.T) {
+	h := newTUIHarness(t, 100, 40)
+	h.model.GetData().UpdateSetting("hide_hole_cards", true)
+	gv := h.model.gameView.(*GameView)
+	h.model.currentView = ViewGame
+	h.run(gv.start("🎮 Scripted Game", playScriptedHand))
+
+	h.WaitFor("Your turn")
+	if strings.Contains(h.Screen(), "🂥 🃍") {
+		t.Fatal("Expected the hero's cards face down")
+	}
+	h.Keys("p")
+	h.WaitFor("🂥 🃍")
+
+	// Once p is let go, the next redraw covers the cards again
+	gv.clock = func() time.Time { return harnessClock.Add(peekWindow) }
+	if strings.Contains(h.Screen(), "🂥 🃍") {
+		t.Error("Expected the cards covered after the peek")
+	}
+}
+
+func TestGameViewStreamerMode(t *testing.T) {
+	h := newTUIHarness(t, 100, 40)
+	h.model.GetData().UpdateSetting("streamer_mode", true)
+	h.model.GetData().UpdateSetting("show_probabilities", true)
+	gv := h.model.gameView.(*GameView)
+	h.model.currentView = ViewGame
+	h.run(gv.start("🎮 Scripted Game", playScriptedHand))
+
+	h.WaitFor("Your turn")
+	screen := h.Screen()
+	if strings.Contains(screen, "🂥 🃍") || !strings.Contains(screen, "Stack •••") || strings.Contains(screen, "Equity vs") {
+		t.Fatalf("Expected the cards face down, the stack masked and no equity yet, got:\n%s", screen)
+	}
+
+	// The overlay shows once the delay is over
+	gv.clock = func() time.Time { return harnessClock.Add(10 * time.Second) }
+	h.WaitFor("Equity vs 1 random hand")
+}
+
+func TestGameViewCuesHumanTurn(t *testing.T) {
+	h := newTUIHarness(t, 100, 40)
+	gv := h.model.gameView.(*GameView)
+	rang := make(chan struct{}, 4)
+	gv.bell = func() { rang <- struct{}{} }
+	h.model.currentView = ViewGame
+	h.run(gv.start("🎮 Scripted Game", playScriptedHand))
+
+	h.WaitFor("Your turn")
+	select {
+	case <-rang:
+	case <-time.After(harnessTimeout):
+		t.Fatal("Expected the bell when the action reached the human")
+	}
+	if gv.flash == 0 {
+		t.Error("Expected the hero's seat to flash")
+	}
+
+	// The countdown follows the clock the decision maker times out by
+	gv.clock = func() time.Time { return harnessClock.Add(holdem_ai.HumanDecisionTimeout - 10*time.Second) }
+	h.WaitFor("10s")
+}
+
+func TestGameViewTimesBotsUnderTheirSeat(t *testing.T) {
+	model := newTestModel(t, nil)
+	gv := model.gameView.(*GameView)
+	now := harnessClock
+	gv.clock = func() time.Time { return now }
+	runner := gv.reset()
+	view := holdem.TableView{HandNumber: 1, Button: 0, ActingSeat: 1, Seats: []holdem.SeatView{
+		{Seat: 0, PlayerID: humanPlayerID, Name: "Hero", Chips: 1000},
+		{Seat: 1, PlayerID: 2, Name: "Thinker", Chips: 1000, CardsHidden: true},
+	}}
+	gv.receive(gameUpdateMsg{view: view, turn: &turnClock{player: 2, timeout: time.Minute}, ok: true, runner: runner})
+
+	now = now.Add(45 * time.Second)
+	if screen := gv.Render(120, 40); !strings.Contains(screen, "⏱") || !strings.Contains(screen, " 15s") {
+		t.Errorf("Expected the bot's time left under its seat, got:\n%s", screen)
+	}
+	// The bot's action ends its turn
+	view.ActingSeat = 0
+	gv.receive(gameUpdateMsg{view: view, log: []string{"Thinker checks"}, ok: true, runner: runner})
+	if screen := gv.Render(120, 40); strings.Contains(screen, " 15s") {
+		t.Errorf("Expected the timer gone with the action, got:\n%s", screen)
+	}
+}
+
+func TestGameViewSlidesChipsIntoThePot(t *testing.T) {
+	model := newTestModel(t, map[string]any{"animations_enabled": true})
+	gv := model.gameView.(*GameView)
+	runner := gv.reset()
+	view := holdem.TableView{HandNumber: 1, Button: 0, ActingSeat: 0, Pot: 0, Seats: []holdem.SeatView{
+		{Seat: 0, PlayerID: humanPlayerID, Name: "Hero", Chips: 1000},
+		{Seat: 1, PlayerID: 2, Name: "Bot", Chips: 1000},
+	}}
+	gv.receive(gameUpdateMsg{view: view, ok: true, runner: runner})
+
+	view.Seats[0].Chips, view.Pot = 600, 400
+	gv.receive(gameUpdateMsg{view: view, log: []string{"Hero bets 400"}, ok: true, runner: runner})
+	if !gv.stacking {
+		t.Fatal("Expected the bet to slide into the pot")
+	}
+	if screen := gv.Render(120, 60); !strings.Contains(screen, "▄▄▄▄") || strings.Contains(screen, "100×4") {
+		t.Errorf("Expected the piles drawn with room to spare, the bet still on its way, got:\n%s", screen)
+	}
+	for gv.stepChips(chipStepMsg{runner: runner}) != nil {
+	}
+	if gv.stacking || !strings.Contains(gv.Render(120, 60), "100×4") {
+		t.Errorf("Expected the pot's four hundreds once the chips settled, got:\n%s", gv.Render(120, 60))
+	}
+}
+
+func TestGameViewPauseMenu(t *testing.T) {
+	h := newTUIHarness(t, 100, 40)
+	gv := h.model.gameView.(*GameView)
+	h.model.currentView = ViewGame
+	h.run(gv.start("🎮 Scripted Game", playScriptedHand))
+
+	h.WaitFor("Your turn")
+	h.Keys("esc")
+	h.WaitFor("Game paused")
+	h.Snapshot("game_paused")
+	h.Keys("c")
+	if strings.Contains(h.Screen(), "Hero calls") {
+		t.Error("Expected no actions while paused")
+	}
+	h.Keys("esc", "c")
+	h.WaitFor("Hero calls 5")
+
+	// Saving mid-hand calls the hand off and gives the blinds back
+	h.Keys("esc", "s")
+	h.WaitFor("Resume Saved Table")
+	snapshot := h.model.GetData().GetSavedTable()
+	if snapshot == nil {
+		t.Fatal("Expected the table to be saved")
+	}
+	if snapshot.HandNumber != 1 || len(snapshot.Seats) != 2 {
+		t.Fatalf("Expected hand 1 with two seats saved, got %+v", snapshot)
+	}
+	for _, seat := range snapshot.Seats {
+		if seat.Chips != 1000 {
+			t.Errorf("Expected %s back at 1000, got %d", seat.Name, seat.Chips)
+		}
+	}
+	if snapshot.Seats[1].DecisionMaker != "calling-station" {
+		t.Errorf("Expected the bot saved as calling-station, got %q", snapshot.Seats[1].DecisionMaker)
+	}
+
+	// Resuming uses the save up; abandoning leaves with the stack
+	h.Keys("enter")
+	h.WaitFor("Resuming after hand #1")
+	if h.model.GetData().GetSavedTable() != nil {
+		t.Error("Expected resuming to use the saved table up")
+	}
+	h.Keys("esc")
+	h.WaitFor("Game paused")
+	h.Keys("x")
+	h.WaitFor("You left the table with")
+	if gv.runner != nil {
+		t.Error("Expected abandoning to stop the game")
+	}
+}
+
+func TestGameViewDebugConsole(t *testing.T) {
+	h := newTUIHarness(t, 100, 50)
+	h.model.GetData().UpdateSetting("game_speed", "instant")
+	gv := h.model.gameView.(*GameView)
+	h.model.currentView = ViewGame
+	h.run(gv.start("🎮 Scripted Game", func(ctx context.Context, runner *gameRunner) (string, error) {
+		s, err := scriptedTable(ctx, runner)
+		if err != nil {
+			return "", err
+		}
+		for hand := 0; hand < 2; hand++ {
+			if _, err := runner.playHand(ctx, s); err != nil {
+				return "", err
+			}
+		}
+		return "Scripted hands over", nil
+	}))
+
+	h.WaitFor("Your turn")
+	if strings.Contains(h.Screen(), "Debug console") {
+		t.Fatal("Expected the debug console hidden")
+	}
+	h.Keys("ctrl+d")
+	h.WaitFor("Debug console")
+	h.WaitFor("Deciding: 🙂 Hero (human)")
+
+	h.Keys("s", "As Kd Ah Kc", "enter")
+	h.WaitFor("The next hand deals AsKdAhKc from the top")
+
+	// Forcing the street answers the hero's turn and calls preflop down
+	h.Keys("n")
+	h.WaitFor("Calling down the street")
+	h.WaitFor("· flop")
+	h.WaitFor("Deck 44 cards") // Burnt one, dealt three
+
+	h.Keys("esc")
+	h.WaitFor("Your turn")
+	h.Keys("f")
+	h.WaitFor("🂡 🂱")
+}
+
+func TestGameViewReadsOpponentRanges(t *testing.T) {
+	h := newTUIHarness(t, 100, 60)
+	h.model.GetData().UpdateSetting("game_speed", "instant")
+	gv := h.model.gameView.(*GameView)
+	h.model.currentView = ViewGame
+	h.run(gv.start("🎮 Scripted Game", playScriptedHand))
+
+	// The big blind has only posted, so anything is possible
+	h.WaitFor("Your turn")
+	h.Keys("v")
+	h.WaitFor("Read on")
+	h.WaitFor("100% of hands")
+	h.Keys("v")
+	if strings.Contains(h.Screen(), "Read on") {
+		t.Error("Expected a second press to hide the grid with one opponent")
+	}
+
+	// Checking the flop makes the big hands less likely
+	h.Keys("c")
+	h.WaitFor("Your turn")
+	h.Keys("v")
+	h.WaitFor("Read on")
+	if gv.reading != 1 || len(gv.reads) != 1 || gv.reads[0].rng.Weight("72o") <= gv.reads[0].rng.Weight("AA") {
+		t.Errorf("Expected the checked range to favour weak hands, got %+v", gv.reads)
+	}
+}
+
+func TestRunnerUndoesLastHand(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	data.SetDirs(paths.Dirs{Data: t.TempDir()}) // The replay is saved there, not in the source tree
+	runner := newGameRunner(holdem.NewDiscardLogger(), data, nil)
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	s := session.New(game)
+	for seat, id := range []int{humanPlayerID, 2} {
+		if err := s.SitDown(holdem.NewPlayer(id, "", 1000), seat); err != nil {
+			t.Fatal(err)
+		}
+		s.SetDecisionMaker(id, callBot{})
+	}
+	if _, err := s.PlayHand(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	runner.saveReplay(game)
+
+	if line := runner.undoHand(s); line != "Hand #1 undone, the stacks are back as they were" {
+		t.Errorf("Expected hand #1 undone, got %q", line)
+	}
+	for _, player := range game.GetAllPlayers() {
+		if player.GetChips() != 1000 {
+			t.Errorf("Expected player %d back at 1000, got %d", player.GetID(), player.GetChips())
+		}
+	}
+	if len(runner.hands) != 0 {
+		t.Errorf("Expected the undone hand left out of exports, got %d hands", len(runner.hands))
+	}
+	if line := runner.undoHand(s); !strings.HasPrefix(line, "Undo refused") {
+		t.Errorf("Expected a second undo to be refused, got %q", line)
+	}
+}
+
+func TestRunnerChangesSeats(t *testing.T) {
+	runner := newGameRunner(holdem.NewDiscardLogger(), NewData(NewMemoryStore()), nil)
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, MaxSeats: 3, Seed: 3})
+	s := session.New(game)
+	for seat, id := range map[int]int{0: humanPlayerID, 1: 2} {
+		if err := s.SitDown(holdem.NewPlayer(id, "", 1000), seat); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.SetObserver(runner.observer(context.Background()))
+
+	if line := runner.changeSeat(s); line != "" {
+		t.Fatalf("Expected the seat change to go through, got %q", line)
+	}
+	if seat, _ := game.GetPlayerSitByID(humanPlayerID); seat != 2 {
+		t.Errorf("Expected the human moved past the bot to seat 2, got %d", seat)
+	}
+	if msg := <-runner.updates; len(msg.log) != 1 || !strings.HasSuffix(msg.log[0], "moves to seat 3") {
+		t.Errorf("Expected the move logged, got %q", msg.log)
+	}
+
+	s.SitDown(holdem.NewPlayer(3, "", 1000), 0)
+	if line := runner.changeSeat(s); line != "No empty seat to move to" {
+		t.Errorf("Expected a full table to refuse, got %q", line)
+	}
+}
+
+func TestRunnerCountsDownTimedSession(t *testing.T) {
+	runner := newGameRunner(holdem.NewDiscardLogger(), NewData(NewMemoryStore()), nil)
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	s := session.New(game)
+	if got := runner.sessionCountdown(s); got != "" {
+		t.Errorf("Expected no countdown without an end time, got %q", got)
+	}
+	s.SetEndTime(time.Now().Add(42*time.Minute + time.Second))
+	if got := runner.sessionCountdown(s); got != " · Ends in 42 min" {
+		t.Errorf("Expected the minutes left, got %q", got)
+	}
+
+	runner.status = func() string { return "" }
+	runner.observer(context.Background())(session.Event{Type: session.EventLastHands, HandsLeft: 3}, game)
+	if msg := <-runner.updates; len(msg.log) != 1 || msg.log[0] != "📣 Last 3 hands of the session" {
+		t.Errorf("Expected the last hands announced, got %q", msg.log)
+	}
+}
+
+func TestRunnerAnnouncesBigBlindAnte(t *testing.T) {
+	runner := newGameRunner(holdem.NewDiscardLogger(), NewData(NewMemoryStore()), nil)
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 50, BigBlind: 100, Ante: 100, BigBlindAnte: true, Seed: 3})
+	for id, name := range []string{"Alice", "Bob", "Carol"} {
+		if err := game.PlayerSit(holdem.NewPlayer(id+1, name, 5000), id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := game.StartHand(0); err != nil {
+		t.Fatal(err)
+	}
+
+	runner.status = func() string { return "" }
+	runner.observer(context.Background())(session.Event{Type: session.EventHandStarted}, game)
+	msg := <-runner.updates
+	if len(msg.log) != 2 || !strings.HasSuffix(msg.log[1], "Carol posts the 100 ante for the table") {
+		t.Errorf("Expected the big blind's ante announced, got %q", msg.log)
+	}
+}
+
+func TestRunnerAnnouncesTableMoves(t *testing.T) {
+	runner := newGameRunner(holdem.NewDiscardLogger(), NewData(NewMemoryStore()), nil)
+	config, err := tournament.SitAndGo(6, tournament.ProgressByHands, sngBuyIn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.SeatsPerTable = 3
+	tourney, err := tournament.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id := 1; id <= 6; id++ {
+		if err := tourney.Register(id, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tourney.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := runner.moveLines(tourney, []tournament.Move{
+		{PlayerID: humanPlayerID, FromTable: 0, ToTable: 1, ToSeat: 2, Reason: tournament.MoveBalance},
+		{PlayerID: humanPlayerID, ToSeat: 4, Reason: tournament.MoveRedraw},
+	})
+	expected := []string{"You are moving to Table 2, seat 3", "Final table! You draw seat 5"}
+	if len(lines) != 2 || lines[0] != expected[0] || lines[1] != expected[1] {
+		t.Errorf("Expected %q, got %q", expected, lines)
+	}
+}
+
+func TestRunnerPausesTournamentClock(t *testing.T) {
+	runner := newGameRunner(holdem.NewDiscardLogger(), NewData(NewMemoryStore()), nil)
+	config, err := tournament.SitAndGo(6, tournament.ProgressByClock, sngBuyIn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tourney, err := tournament.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id := 1; id <= 6; id++ {
+		if err := tourney.Register(id, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tourney.Start(); err != nil {
+		t.Fatal(err)
+	}
+	runner.clock = tourney
+
+	runner.hold()
+	if !tourney.IsPaused() {
+		t.Fatal("Expected the pause menu to stop the tournament clock")
+	}
+	runner.release()
+	lines := runner.clockLines(append(tourney.ClockEvents(), tournament.ClockEvent{
+		Type: tournament.BreakStarted, Left: 5 * time.Minute,
+	}))
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "⏸ Tournament clock paused with ") ||
+		lines[1] != "▶ Tournament clock running" || lines[2] != "☕ Break, play resumes in 5m0s" {
+		t.Errorf("Expected the pause, resume and break announced, got %q", lines)
+	}
+}
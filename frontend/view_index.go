@@ -3,6 +3,7 @@ package frontend
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -17,6 +18,7 @@ type MenuItem struct {
 	title       string
 	description string
 	action      ViewType
+	params      any // Passed to the view, built from the settings when nil
 }
 
 func (i MenuItem) FilterValue() string { return i.title }
@@ -24,7 +26,9 @@ func (i MenuItem) Title() string       { return i.title }
 func (i MenuItem) Description() string { return i.description }
 
 // Custom item delegate for styling
-type menuItemDelegate struct{}
+type menuItemDelegate struct {
+	plain bool // Mark the selection with text rather than a glyph and color
+}
 
 func (d menuItemDelegate) Height() int                             { return 2 }
 func (d menuItemDelegate) Spacing() int                            { return 1 }
@@ -40,7 +44,11 @@ func (d menuItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 
 	if index == m.Index() {
 		// Selected item styling
-		str = selectedItemStyle.Render("▶ " + str)
+		marker := "▶ "
+		if d.plain {
+			marker = "> "
+		}
+		str = selectedItemStyle.Render(marker + str)
 		desc = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#D1D5DB")). // Light gray
 			Render("  " + desc)
@@ -109,25 +117,7 @@ type IndexView struct {
 
 // NewIndexView creates a new index view
 func NewIndexView(model *Model) *IndexView {
-	items := []list.Item{
-		MenuItem{
-			title:       "🎮 Start Game",
-			description: "Begin a new poker game",
-			action:      ViewLogin,
-		},
-		MenuItem{
-			title:       "⚙️  Settings",
-			description: "Configure game preferences",
-			action:      ViewSettings,
-		},
-		MenuItem{
-			title:       "🚪 Quit",
-			description: "Exit the application",
-			action:      ViewIndex, // Special case for quit
-		},
-	}
-
-	l := list.New(items, menuItemDelegate{}, 0, 0)
+	l := list.New(menuItems(model), menuItemDelegate{plain: model.Accessible()}, 0, 0)
 
 	// Disable all list features and title to avoid any status indicators
 	l.SetShowStatusBar(false)
@@ -151,22 +141,86 @@ func NewIndexView(model *Model) *IndexView {
 		help:  h,
 
 		// Initialize components with default width (will be updated in Render)
-		header: component.NewHeaderComponent("🃏 Texas Hold'em Poker", 80),
+		header: component.NewHeaderComponent(model.icon("🃏", "Texas Hold'em Poker"), 80),
 		helper: component.NewHelperComponent(indexKeys, 80),
 	}
 }
 
+// menuItems lists the main menu in the chosen language
+func menuItems(model *Model) []list.Item {
+	item := func(icon, key string, action ViewType) MenuItem {
+		return MenuItem{
+			title:       model.icon(icon, model.T(key)),
+			description: model.T(key + ".description"),
+			action:      action,
+		}
+	}
+	items := []list.Item{}
+	if model.GetData().GetRecovery() != nil {
+		recover := item("🩹", "menu.recover", ViewGame)
+		recover.params = GameParams{Recover: true}
+		items = append(items, recover)
+	}
+	if model.GetData().GetSavedTable() != nil {
+		resume := item("▶", "menu.resume", ViewGame)
+		resume.params = GameParams{Resume: true}
+		items = append(items, resume)
+	}
+	watch := item("👁", "menu.watch", ViewSpectator)
+	watch.params = SpectatorParams{Bots: true}
+	return append(items,
+		item("🎮", "menu.start_game", ViewLogin),
+		item("🏆", "menu.sit_and_go", ViewGame),
+		watch,
+		item("🎙", "menu.review", ViewSpectator),
+		item("🔖", "menu.bookmarks", ViewBookmarks),
+		item("🤖", "menu.simulation", ViewSimulation),
+		item("🥇", "menu.leaderboard", ViewLeaderboard),
+		item("📅", "menu.stats", ViewStats),
+		item("🔢", "menu.ranges", ViewRange),
+		item("📊", "menu.charts", ViewCharts),
+		item("🧮", "menu.equity", ViewEquity),
+		item("🎯", "menu.training", ViewTraining),
+		item("⚙️ ", "menu.settings", ViewSettings),
+		item("🚪", "menu.quit", ViewIndex), // Special case for quit
+	)
+}
+
+// DataChanged relabels the menu when the language or accessibility mode
+// changes, and offers to resume a saved table or recover an interrupted one
+func (v *IndexView) DataChanged(key string) {
+	if key == savedTableKey || key == recoveryKey {
+		v.list.SetItems(menuItems(v.model))
+	}
+	if key == settingsKey {
+		v.list.SetItems(menuItems(v.model))
+		v.list.SetDelegate(menuItemDelegate{plain: v.model.Accessible()})
+		v.header.SetTitle(v.model.icon("🃏", "Texas Hold'em Poker"))
+	}
+}
+
 // Update handles input for the index view
 func (v *IndexView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, v.keys.Select):
 		selectedItem, ok := v.list.SelectedItem().(MenuItem)
 		if ok {
+			settings := v.model.GetData().GetSettings()
 			switch selectedItem.action {
-			case ViewLogin:
-				v.model.currentView = ViewLogin
-			case ViewSettings:
-				v.model.currentView = ViewSettings
+			case ViewGame:
+				if selectedItem.params != nil {
+					return v.model, Navigate(ViewGame, selectedItem.params)
+				}
+				return v.model, Navigate(ViewGame, GameParams{SitAndGo: true, Seats: settings.SNGSeats})
+			case ViewSimulation:
+				return v.model, Navigate(ViewSimulation, SimulationParams{Seats: settings.SNGSeats})
+			case ViewSpectator:
+				if selectedItem.params != nil {
+					return v.model, Navigate(ViewSpectator, selectedItem.params)
+				}
+				return v.model, Navigate(ViewSpectator, SpectatorParams{ReplayFile: v.model.GetData().Path(settings.ReplayFile)})
+			case ViewLogin, ViewSettings, ViewRange, ViewCharts, ViewEquity, ViewTraining, ViewLeaderboard, ViewStats, ViewBookmarks:
+				return v.model, Navigate(selectedItem.action, nil)
 			default: // Quit case
 				return v.model, tea.Quit
 			}
@@ -181,6 +235,33 @@ func (v *IndexView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return v.model, cmd
 }
 
+// Mouse opens the menu item clicked, as enter does, and moves through the
+// menu with the wheel
+func (v *IndexView) Mouse(msg tea.MouseMsg, row string) (tea.Model, tea.Cmd) {
+	if key, ok := wheelKey(msg, tea.KeyUp, tea.KeyDown); ok {
+		return v.Update(key)
+	}
+	if !leftClick(msg) {
+		return v.model, nil
+	}
+	// The longest label in the row wins, should one item's be part of another's
+	clicked, label := -1, ""
+	for i, listItem := range v.list.Items() {
+		item := listItem.(MenuItem)
+		for _, text := range []string{item.title, item.description} {
+			text = strings.TrimSpace(text)
+			if text != "" && len(text) > len(label) && strings.Contains(row, text) {
+				clicked, label = i, text
+			}
+		}
+	}
+	if clicked < 0 {
+		return v.model, nil
+	}
+	v.list.Select(clicked)
+	return v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+}
+
 // Render renders the index view
 func (v *IndexView) Render(width, height int) string {
 	// Update component widths for current screen size
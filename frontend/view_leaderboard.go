@@ -0,0 +1,265 @@
+package frontend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// LeaderboardKeyMap defines keybindings for the leaderboard
+type LeaderboardKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Order  key.Binding
+	Export key.Binding
+	Reset  key.Binding
+	Back   key.Binding
+	Quit   key.Binding
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view.
+func (k LeaderboardKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Order, k.Export, k.Reset, k.Back, k.Quit}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (k LeaderboardKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Order},
+		{k.Export, k.Reset},
+		{k.Back, k.Quit},
+	}
+}
+
+var leaderboardKeys = LeaderboardKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "scroll up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "scroll down"),
+	),
+	Order: key.NewBinding(
+		key.WithKeys("tab", "s"),
+		key.WithHelp("tab", "rank by"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "export CSV"),
+	),
+	Reset: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "reset"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// confirmResetKey confirms a leaderboard reset; any other key keeps it
+var confirmResetKey = key.NewBinding(key.WithKeys("y"))
+
+// LeaderboardView ranks the player profiles and the bots of this machine
+// by cash game winnings, sit-and-go finishes or Elo rating
+type LeaderboardView struct {
+	model      *Model
+	keys       LeaderboardKeyMap
+	board      []LeaderboardEntry
+	order      leaderboardOrder
+	offset     int  // First row shown
+	confirming bool // Reset asked for, waiting for confirmation
+	status     string
+
+	// Components
+	header *component.HeaderComponent
+	helper *component.HelperComponent
+}
+
+// NewLeaderboardView creates a new leaderboard view
+func NewLeaderboardView(model *Model) *LeaderboardView {
+	return &LeaderboardView{
+		model: model,
+		keys:  leaderboardKeys,
+
+		// Initialize components with default width (will be updated in Render)
+		header: component.NewHeaderComponent("🥇 Leaderboard", 80),
+		helper: component.NewHelperComponent(leaderboardKeys, 80),
+	}
+}
+
+// OnEnter loads the leaderboard
+func (v *LeaderboardView) OnEnter(params any) tea.Cmd {
+	v.offset, v.confirming, v.status = 0, false, ""
+	v.reload()
+	return nil
+}
+
+// OnExit drops a reset left unconfirmed
+func (v *LeaderboardView) OnExit() {
+	v.confirming = false
+}
+
+// DataChanged reloads the leaderboard when a game or simulation elsewhere
+// records results
+func (v *LeaderboardView) DataChanged(key string) {
+	if key == leaderboardKey || key == ratingsKey {
+		v.reload()
+	}
+}
+
+// reload reads the leaderboard and ranks it by the current order
+func (v *LeaderboardView) reload() {
+	v.board = v.model.GetData().GetLeaderboard()
+	sortLeaderboard(v.board, v.order)
+}
+
+// Update handles input for the leaderboard
+func (v *LeaderboardView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if v.confirming {
+		v.confirming = false
+		if key.Matches(msg, confirmResetKey) {
+			v.model.GetData().ResetLeaderboard()
+			v.reload()
+			v.offset, v.status = 0, "Leaderboard and ratings reset"
+		} else {
+			v.status = "Reset cancelled"
+		}
+		return v.model, nil
+	}
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		return v.model, Navigate(ViewIndex, nil)
+	case key.Matches(msg, v.keys.Quit):
+		return v.model, tea.Quit
+	case key.Matches(msg, v.keys.Up):
+		v.offset = max(v.offset-1, 0)
+	case key.Matches(msg, v.keys.Down):
+		v.offset = min(v.offset+1, max(len(v.board)-1, 0))
+	case key.Matches(msg, v.keys.Order):
+		v.order = (v.order + 1) % leaderboardOrders
+		v.offset = 0
+		sortLeaderboard(v.board, v.order)
+	case key.Matches(msg, v.keys.Export):
+		data := v.model.GetData()
+		path, err := exportLeaderboard(data.Path(data.GetSettings().ExportDir), v.board)
+		if err != nil {
+			v.status = "Export failed: " + err.Error()
+		} else {
+			v.status = "Leaderboard exported to " + path
+		}
+	case key.Matches(msg, v.keys.Reset):
+		if len(v.board) > 0 {
+			v.confirming = true
+			v.status = "Reset the leaderboard and every Elo rating? Press y to confirm, any other key to keep them"
+		}
+	}
+	return v.model, nil
+}
+
+// Render renders the leaderboard
+func (v *LeaderboardView) Render(width, height int) string {
+	// Update component widths for current screen size
+	v.header.SetWidth(width)
+	v.helper.SetWidth(width)
+
+	titleAtTop := v.header.Render()
+	helpAtBottom := v.helper.Render()
+	availableHeight := height - lipgloss.Height(titleAtTop) - lipgloss.Height(helpAtBottom)
+
+	sections := []string{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).
+			Render(fmt.Sprintf("Ranked by: ◀ %s ▶", v.order)),
+		v.renderTable(availableHeight - 8),
+	}
+	if v.status != "" {
+		color := lipgloss.Color("#9CA3AF")
+		if v.confirming {
+			color = lipgloss.Color("#F59E0B") // Amber
+		}
+		sections = append(sections, lipgloss.NewStyle().Foreground(color).Render(v.status))
+	}
+	content := lipgloss.NewStyle().
+		Width(width).
+		Align(lipgloss.Center).
+		Render(strings.Join(sections, "\n\n"))
+
+	// Center the leaderboard in the middle of available space
+	centeredContent := lipgloss.Place(
+		width, availableHeight,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+
+	// Apply full screen style
+	fullScreenContainer := GetFullScreenStyle(width, height)
+	return fullScreenContainer.Render(titleAtTop + centeredContent + helpAtBottom)
+}
+
+// renderTable lists as many entries as fit in rows, from the scroll offset
+func (v *LeaderboardView) renderTable(rows int) string {
+	if len(v.board) == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).
+			Render("No games recorded yet. Play a cash game or a sit & go, or run a bot simulation.")
+	}
+	rows = max(rows, 1)
+	lines := []string{lipgloss.NewStyle().Bold(true).
+		Render(fmt.Sprintf("%4s  %-16s %-6s %9s %5s %5s %5s %7s %6s", "#", "Name", "Kind", "Bankroll", "Cash", "SnGs", "Wins", "Avg fin", "Elo"))}
+	profile := v.model.GetData().GetPlayerName()
+	streaming := v.model.GetData().GetSettings().StreamerMode // Bankrolls are masked on stream
+	for i := v.offset; i < len(v.board) && i < v.offset+rows; i++ {
+		entry := v.board[i]
+		kind, average, elo := "player", "-", "-"
+		if entry.Bot {
+			kind = "bot"
+		}
+		if entry.Tournaments > 0 {
+			average = fmt.Sprintf("%.1f", entry.AverageFinish())
+		}
+		if entry.Rating > 0 {
+			elo = fmt.Sprintf("%.0f", entry.Rating)
+		}
+		bankroll := fmt.Sprintf("%+d", entry.Bankroll)
+		if streaming {
+			bankroll = "•••"
+		}
+		line := fmt.Sprintf("%4d  %-16s %-6s %9s %5d %5d %5d %7s %6s",
+			i+1, fitName(entry.Name, 16), kind, bankroll, entry.CashGames, entry.Tournaments, entry.Wins, average, elo)
+		if !entry.Bot && entry.Name == profile {
+			line = selectedItemStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if len(v.board) > rows {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).
+			Render(fmt.Sprintf("%d-%d of %d", v.offset+1, min(v.offset+rows, len(v.board)), len(v.board))))
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#6B7280")). // Gray
+		Padding(0, 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+// GetType returns the view type
+func (v *LeaderboardView) GetType() ViewType {
+	return ViewLeaderboard
+}
+
+// fitName cuts a name to width runes, marking the cut with an ellipsis
+func fitName(name string, width int) string {
+	runes := []rune(name)
+	if len(runes) <= width {
+		return name
+	}
+	return string(runes[:width-1]) + "…"
+}
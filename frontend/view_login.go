@@ -95,15 +95,13 @@ func (v *LoginView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, v.keys.Continue):
 		if strings.TrimSpace(v.textInput.Value()) != "" {
 			// Store the player name in the centralized data store
-			GetData().SetPlayerName(v.textInput.Value())
+			v.model.GetData().SetPlayerName(v.textInput.Value())
 			// Move to game setup view to configure the game
-			v.model.currentView = ViewGameSetup
-			return v.model, nil
+			return v.model, Navigate(ViewGameSetup, nil)
 		}
 	case key.Matches(msg, v.keys.Back):
 		// Go back to index
-		v.model.currentView = ViewIndex
-		return v.model, nil
+		return v.model, Navigate(ViewIndex, nil)
 	case key.Matches(msg, v.keys.Quit):
 		return v.model, tea.Quit
 	}
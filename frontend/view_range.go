@@ -0,0 +1,427 @@
+package frontend
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// defaultRange is shown the first time the range viewer opens
+const defaultRange = "22+, A2s+, K9s+, QTs+, JTs, T9s, 98s, ATo+, KJo+"
+
+// weightStep is how much + and - change the weight of the selected hand
+const weightStep = 0.25
+
+// RangeKeyMap defines keybindings for the range viewer
+type RangeKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	Left    key.Binding
+	Right   key.Binding
+	Toggle  key.Binding
+	Heavier key.Binding
+	Lighter key.Binding
+	Edit    key.Binding
+	Apply   key.Binding
+	Save    key.Binding
+	Pick    key.Binding
+	Load    key.Binding
+	Merge   key.Binding
+	Delete  key.Binding
+	Back    key.Binding
+	Quit    key.Binding
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view.
+func (k RangeKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Toggle, k.Heavier, k.Lighter, k.Edit, k.Save, k.Pick, k.Load, k.Merge, k.Back}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (k RangeKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Left, k.Right},
+		{k.Toggle, k.Heavier, k.Lighter, k.Edit, k.Apply},
+		{k.Save, k.Pick, k.Load, k.Merge, k.Delete},
+		{k.Back, k.Quit},
+	}
+}
+
+var rangeKeys = RangeKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up"),
+		key.WithHelp("↑", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down"),
+		key.WithHelp("↓", "down"),
+	),
+	Left: key.NewBinding(
+		key.WithKeys("left"),
+		key.WithHelp("←", "left"),
+	),
+	Right: key.NewBinding(
+		key.WithKeys("right"),
+		key.WithHelp("→", "right"),
+	),
+	Toggle: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "toggle hand"),
+	),
+	Heavier: key.NewBinding(
+		key.WithKeys("+", "="),
+		key.WithHelp("+", "weight up"),
+	),
+	Lighter: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "weight down"),
+	),
+	Edit: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "edit range"),
+	),
+	Apply: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "show range"),
+	),
+	Save: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "save as"),
+	),
+	Pick: key.NewBinding(
+		key.WithKeys("[", "]"),
+		key.WithHelp("[/]", "pick saved"),
+	),
+	Load: key.NewBinding(
+		key.WithKeys("l"),
+		key.WithHelp("l", "load"),
+	),
+	Merge: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "merge in"),
+	),
+	Delete: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "delete saved"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// RangeView shows a hand range on the 13x13 grid. The range is typed in
+// range notation or built by toggling and weighting hands on the grid, and
+// can be saved with the profile under a name, loaded again or merged into
+// another. Saved ranges are referred to as "@name" wherever a range is
+// typed: in the equity calculator, chart files and training scenarios.
+type RangeView struct {
+	model   *Model
+	keys    RangeKeyMap
+	input   textinput.Model
+	editing bool   // Typing into the range input instead of moving on the grid
+	err     string // Last parse error
+
+	name   textinput.Model // Name the range is saved as
+	naming bool            // Typing the name to save the range as
+	picked int             // Saved range load, merge and delete act on
+	note   string          // What the last save, load or merge did
+
+	// Components
+	header *component.HeaderComponent
+	helper *component.HelperComponent
+	grid   *component.RangeGridComponent
+}
+
+// NewRangeView creates a new range viewer
+func NewRangeView(model *Model) *RangeView {
+	ti := textinput.New()
+	ti.Placeholder = "e.g. TT+, AQs+, KQo"
+	ti.Width = 50
+	ti.Prompt = "Range: "
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED"))
+	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F3F4F6"))
+	ti.CursorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A78BFA"))
+	ti.SetValue(defaultRange)
+
+	name := textinput.New()
+	name.Placeholder = "e.g. BTN open"
+	name.Width = 30
+	name.Prompt = "Save as: "
+	name.PromptStyle = ti.PromptStyle
+
+	v := &RangeView{
+		model: model,
+		keys:  rangeKeys,
+		input: ti,
+		name:  name,
+
+		// Initialize components with default width (will be updated in Render)
+		header: component.NewHeaderComponent("🔢 Range Viewer", 80),
+		helper: component.NewHelperComponent(rangeKeys, 80),
+		grid:   component.NewRangeGridComponent(80),
+	}
+	v.apply()
+	return v
+}
+
+// apply parses the typed range and shows it on the grid
+func (v *RangeView) apply() {
+	r, err := ranges.Parse(v.input.Value(), ranges.WithLibrary(v.model.GetData().rangeLibrary()))
+	if err != nil {
+		v.err = err.Error()
+		return
+	}
+	v.err = ""
+	v.grid.SetRange(r)
+}
+
+// Update handles input for the range viewer
+func (v *RangeView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if v.naming {
+		return v.updateName(msg)
+	}
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		return v.model, Navigate(ViewIndex, nil)
+	case key.Matches(msg, v.keys.Quit):
+		return v.model, tea.Quit
+	case key.Matches(msg, v.keys.Edit):
+		v.editing = !v.editing
+		if v.editing {
+			v.input.Focus()
+		} else {
+			v.input.Blur()
+		}
+		return v.model, nil
+	case key.Matches(msg, v.keys.Apply):
+		v.apply()
+		v.editing = false
+		v.input.Blur()
+		return v.model, nil
+	}
+
+	if v.editing {
+		var cmd tea.Cmd
+		v.input, cmd = v.input.Update(msg)
+		return v.model, cmd
+	}
+
+	switch {
+	case key.Matches(msg, v.keys.Up):
+		v.grid.Move(-1, 0)
+	case key.Matches(msg, v.keys.Down):
+		v.grid.Move(1, 0)
+	case key.Matches(msg, v.keys.Left):
+		v.grid.Move(0, -1)
+	case key.Matches(msg, v.keys.Right):
+		v.grid.Move(0, 1)
+	case key.Matches(msg, v.keys.Toggle):
+		r, hand := v.grid.GetRange(), v.grid.GetSelected()
+		if r.Weight(hand) > 0 {
+			r.Set(hand, 0)
+		} else {
+			r.Set(hand, 1)
+		}
+		v.input.SetValue(r.String())
+	case key.Matches(msg, v.keys.Heavier):
+		v.adjustWeight(weightStep)
+	case key.Matches(msg, v.keys.Lighter):
+		v.adjustWeight(-weightStep)
+	case key.Matches(msg, v.keys.Save):
+		v.naming, v.note = true, ""
+		if saved, ok := v.pickedRange(); ok && v.name.Value() == "" {
+			v.name.SetValue(saved.Name)
+		}
+		return v.model, v.name.Focus()
+	case key.Matches(msg, v.keys.Pick):
+		if saved := v.model.GetData().GetSavedRanges(); len(saved) > 0 {
+			step := 1
+			if msg.String() == "[" {
+				step = -1
+			}
+			v.picked = (v.picked + step + len(saved)) % len(saved)
+		}
+	case key.Matches(msg, v.keys.Load), key.Matches(msg, v.keys.Merge):
+		saved, ok := v.pickedRange()
+		if !ok {
+			v.note = "No saved ranges yet, press s to save one"
+			break
+		}
+		notation := "@" + saved.Name
+		if key.Matches(msg, v.keys.Merge) {
+			notation = v.grid.GetRange().String() + "," + notation
+			v.note = "Merged in " + saved.Name
+		} else {
+			v.note = "Loaded " + saved.Name
+		}
+		v.input.SetValue(notation)
+		v.apply()
+		v.input.SetValue(v.grid.GetRange().String())
+	case key.Matches(msg, v.keys.Delete):
+		if saved, ok := v.pickedRange(); ok {
+			v.model.GetData().DeleteRange(saved.Name)
+			v.note = "Deleted " + saved.Name
+		}
+	}
+	return v.model, nil
+}
+
+// updateName handles input while typing the name to save the range as
+func (v *RangeView) updateName(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.naming = false
+		v.name.Blur()
+		return v.model, nil
+	case "enter":
+		name := v.name.Value()
+		if err := v.model.GetData().SaveRange(name, v.grid.GetRange()); err != nil {
+			v.note = "✗ " + err.Error()
+			return v.model, nil
+		}
+		v.naming = false
+		v.name.Blur()
+		v.note = fmt.Sprintf("Saved as %s, use it as @%s", name, name)
+		for i, saved := range v.model.GetData().GetSavedRanges() {
+			if strings.EqualFold(saved.Name, name) {
+				v.picked = i
+			}
+		}
+		return v.model, nil
+	}
+	var cmd tea.Cmd
+	v.name, cmd = v.name.Update(msg)
+	return v.model, cmd
+}
+
+// adjustWeight changes the weight of the selected hand by delta, in steps
+func (v *RangeView) adjustWeight(delta float64) {
+	r, hand := v.grid.GetRange(), v.grid.GetSelected()
+	weight := math.Round((r.Weight(hand)+delta)/weightStep) * weightStep
+	r.Set(hand, max(0, min(weight, 1)))
+	v.input.SetValue(r.String())
+}
+
+// pickedRange returns the saved range load, merge and delete act on
+func (v *RangeView) pickedRange() (SavedRange, bool) {
+	saved := v.model.GetData().GetSavedRanges()
+	if len(saved) == 0 {
+		return SavedRange{}, false
+	}
+	v.picked = min(v.picked, len(saved)-1)
+	return saved[v.picked], true
+}
+
+// renderSaved lists the saved ranges, the picked one highlighted
+func (v *RangeView) renderSaved() string {
+	saved := v.model.GetData().GetSavedRanges()
+	if len(saved) == 0 {
+		return ""
+	}
+	v.picked = min(v.picked, len(saved)-1)
+	names := make([]string, 0, len(saved))
+	for i, s := range saved {
+		if i == v.picked {
+			names = append(names, lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F59E0B")).Render("▸@"+s.Name))
+			continue
+		}
+		names = append(names, lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render("@"+s.Name))
+	}
+	return "Saved: " + strings.Join(names, "  ")
+}
+
+// Render renders the range viewer
+func (v *RangeView) Render(width, height int) string {
+	// Update component widths for current screen size
+	v.header.SetWidth(width)
+	v.helper.SetWidth(width)
+	v.grid.SetWidth(width)
+
+	borderColor := lipgloss.Color("#6B7280") // Gray
+	if v.editing {
+		borderColor = lipgloss.Color("#7C3AED") // Purple
+	}
+	sections := []string{
+		lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(borderColor).
+			Padding(0, 1).
+			Render(v.input.View()),
+	}
+	if v.err != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#EF4444")). // Red
+			Render("✗ "+v.err))
+	}
+	sections = append(sections, v.grid.Render())
+	if v.naming {
+		sections = append(sections, lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#7C3AED")).
+			Padding(0, 1).
+			Render(v.name.View()))
+	}
+	if saved := v.renderSaved(); saved != "" {
+		sections = append(sections, saved)
+	}
+	if v.note != "" {
+		sections = append(sections, lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Render(v.note))
+	}
+
+	content := lipgloss.NewStyle().
+		Width(width).
+		Align(lipgloss.Center).
+		Render(strings.Join(sections, "\n\n"))
+
+	// Title at the top using header component
+	titleAtTop := v.header.Render()
+
+	// Help view at the bottom using helper component
+	helpAtBottom := v.helper.Render()
+
+	// Calculate actual space used by header and helper
+	headerHeight := lipgloss.Height(titleAtTop)
+	helperHeight := lipgloss.Height(helpAtBottom)
+	availableHeight := height - headerHeight - helperHeight
+
+	// Center the grid in the middle of available space
+	centeredContent := lipgloss.Place(
+		width, availableHeight,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+
+	// Combine title, content, and help without extra spacing
+	fullContent := titleAtTop + centeredContent + helpAtBottom
+
+	// Apply full screen style
+	fullScreenContainer := GetFullScreenStyle(width, height)
+	return fullScreenContainer.Render(fullContent)
+}
+
+// GetType returns the view type
+func (v *RangeView) GetType() ViewType {
+	return ViewRange
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view
+func (v *RangeView) ShortHelp() []key.Binding {
+	return v.keys.ShortHelp()
+}
+
+// FullHelp returns keybindings for the expanded help view
+func (v *RangeView) FullHelp() [][]key.Binding {
+	return v.keys.FullHelp()
+}
@@ -0,0 +1,68 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/equity"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+// typeKeys sends each key in turn to the range editor
+func typeKeys(v *RangeView, keys ...string) {
+	for _, name := range keys {
+		v.Update(keyMsg(name))
+	}
+}
+
+func TestRangeEditorSavesWeightsAndMerges(t *testing.T) {
+	model := newTestModel(t, nil)
+	v := NewRangeView(model)
+
+	// AA sits under the cursor: halve it, then save the range
+	typeKeys(v, "-", "-")
+	if got := v.grid.GetRange().Weight("AA"); got != 0.5 {
+		t.Fatalf("Expected AA at half weight, got %g", got)
+	}
+	typeKeys(v, "s")
+	for _, r := range "BTN open" {
+		typeKeys(v, string(r))
+	}
+	typeKeys(v, "enter")
+	saved := model.GetData().GetSavedRanges()
+	if len(saved) != 1 || saved[0].Name != "BTN open" || !strings.HasPrefix(saved[0].Hands, "AA:0.5,") {
+		t.Fatalf("Expected the range saved with the profile, got %+v", saved)
+	}
+
+	// The saved range is known wherever ranges are typed
+	holding, err := equity.ParseHolding("@btn open", ranges.WithLibrary(model.GetData().rangeLibrary()))
+	if err != nil || holding.Range.Weight("AA") != 0.5 {
+		t.Fatalf("Expected the equity calculator to read @btn open, got %v", err)
+	}
+
+	// Merging keeps the heavier weight of hands in both
+	v.input.SetValue("AA, 72o")
+	v.apply()
+	typeKeys(v, "m")
+	if r := v.grid.GetRange(); r.Weight("AA") != 1 || r.Weight("72o") != 1 || r.Weight("KQs") != 1 {
+		t.Errorf("Expected the saved range merged in, got %s", r)
+	}
+	typeKeys(v, "l")
+	if r := v.grid.GetRange(); r.Weight("72o") != 0 || r.Weight("AA") != 0.5 {
+		t.Errorf("Expected the saved range loaded as it was, got %s", r)
+	}
+
+	typeKeys(v, "x")
+	if len(model.GetData().GetSavedRanges()) != 0 {
+		t.Error("Expected the range deleted")
+	}
+	if _, err := ranges.Parse("@btn open", ranges.WithLibrary(model.GetData().rangeLibrary())); err == nil {
+		t.Error("Expected a deleted range to be forgotten")
+	}
+
+	// Names that cannot be typed back in notation are refused
+	typeKeys(v, "s", "@", "enter")
+	if !v.naming || !strings.Contains(v.note, "cannot hold") {
+		t.Errorf("Expected the name to be refused, got %q", v.note)
+	}
+}
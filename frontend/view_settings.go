@@ -8,7 +8,10 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/i18n"
+	"github.com/ljbink/ai-poker/engine/tournament"
 	"github.com/ljbink/ai-poker/frontend/component"
+	"github.com/ljbink/ai-poker/internal/paths"
 )
 
 // SettingsKeyMap defines keybindings for the settings view
@@ -73,6 +76,8 @@ type SettingsView struct {
 	options  []SettingOption
 	keys     SettingsKeyMap
 	help     help.Model
+	open     func(dir string) error // Shows a folder in the file manager, see paths.Open
+	status   string                 // Outcome of opening the data folder
 
 	// Components
 	header *component.HeaderComponent
@@ -83,7 +88,7 @@ type SettingsView struct {
 type SettingOption struct {
 	Label       string
 	Key         string
-	ValueType   string // "bool", "int", "string"
+	ValueType   string // "bool", "int", "string", "action"
 	Description string
 	Icon        string
 }
@@ -99,54 +104,72 @@ func NewSettingsView(model *Model) *SettingsView {
 		selected: 0,
 		keys:     settingsKeys,
 		help:     h,
+		open:     paths.Open,
 
 		// Initialize components with default width (will be updated in Render)
-		header: component.NewHeaderComponent("⚙️  Settings", 80),
-		helper: component.NewHelperComponent(settingsKeys, 80),
-		options: []SettingOption{
-			{
-				Label:       "Theme",
-				Key:         "theme",
-				ValueType:   "string",
-				Description: "Application theme (dark/light/auto)",
-				Icon:        "🎨",
-			},
-			{
-				Label:       "Sound Effects",
-				Key:         "sound_enabled",
-				ValueType:   "bool",
-				Description: "Enable sound effects",
-				Icon:        "🔊",
-			},
-			{
-				Label:       "Animations",
-				Key:         "animations_enabled",
-				ValueType:   "bool",
-				Description: "Enable UI animations",
-				Icon:        "✨",
-			},
-			{
-				Label:       "Auto Save",
-				Key:         "auto_save",
-				ValueType:   "bool",
-				Description: "Automatically save game progress",
-				Icon:        "💾",
-			},
-			{
-				Label:       "Default Buy-in",
-				Key:         "default_buy_in",
-				ValueType:   "int",
-				Description: "Default chip amount when starting a game",
-				Icon:        "💰",
-			},
-			{
-				Label:       "Show Probabilities",
-				Key:         "show_probabilities",
-				ValueType:   "bool",
-				Description: "Display hand probability information",
-				Icon:        "📊",
-			},
-		},
+		header:  component.NewHeaderComponent(model.icon("⚙️ ", model.T("menu.settings")), 80),
+		helper:  component.NewHelperComponent(settingsKeys, 80),
+		options: settingOptions(model),
+	}
+}
+
+// settingOptions lists the settings with labels in the chosen language
+func settingOptions(model *Model) []SettingOption {
+	option := func(icon, key, valueType string) SettingOption {
+		return SettingOption{
+			Label:       model.T("settings." + key),
+			Key:         key,
+			ValueType:   valueType,
+			Description: model.T("settings." + key + ".description"),
+			Icon:        icon,
+		}
+	}
+	return []SettingOption{
+		option("🎨", "theme", "string"),
+		option("🌐", "language", "string"),
+		option("🔢", "number_format", "string"),
+		option("💱", "currency", "string"),
+		option("♿", "accessibility", "bool"),
+		option("🃏", "four_color_deck", "bool"),
+		option("🔊", "sound_enabled", "bool"),
+		option("✨", "animations_enabled", "bool"),
+		option("💾", "auto_save", "bool"),
+		option("💰", "default_buy_in", "int"),
+		option("🏆", "sng_seats", "int"),
+		option("📈", "sng_blinds", "string"),
+		option("☕", "sng_breaks", "string"),
+		option("🏠", "home_game", "bool"),
+		option("💣", "bomb_pot_every", "int"),
+		option("🎯", "seven_deuce_bounty_bb", "int"),
+		option("🛑", "stop_loss_bb", "int"),
+		option("🏁", "stop_win_bb", "int"),
+		option("⏱", "time_limit_minutes", "int"),
+		option("⌛", "timed_session_minutes", "int"),
+		option("📅", "daily_hand_limit", "int"),
+		option("🙈", "auto_muck", "bool"),
+		option("✅", "auto_check", "bool"),
+		option("📞", "auto_call_bb", "int"),
+		option("⏩", "game_speed", "string"),
+		option("😤", "bot_tilt", "bool"),
+		option("💬", "table_talk", "bool"),
+		option("🙂", "avatar", "string"),
+		option("🖍", "avatar_color", "string"),
+		option("🫣", "hide_hole_cards", "bool"),
+		option("📺", "streamer_mode", "bool"),
+		option("⏳", "streamer_delay_seconds", "int"),
+		option("📊", "show_probabilities", "bool"),
+		option("🥸", "anonymize_exports", "bool"),
+		option("📡", "hud_stream", "bool"),
+		option("📝", "log_level", "string"),
+		option("📂", "data_folder", "action"),
+	}
+}
+
+// DataChanged relabels the settings when the language or accessibility mode changes
+func (v *SettingsView) DataChanged(key string) {
+	if key == settingsKey {
+		v.options = settingOptions(v.model)
+		v.header.SetTitle(v.model.icon("⚙️ ", v.model.T("menu.settings")))
 	}
 }
 
@@ -154,10 +177,12 @@ func NewSettingsView(model *Model) *SettingsView {
 func (v *SettingsView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, v.keys.Up):
+		v.status = ""
 		if v.selected > 0 {
 			v.selected--
 		}
 	case key.Matches(msg, v.keys.Down):
+		v.status = ""
 		if v.selected < len(v.options)-1 {
 			v.selected++
 		}
@@ -166,7 +191,7 @@ func (v *SettingsView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		v.toggleSetting(v.selected)
 	case key.Matches(msg, v.keys.Back):
 		// Go back to index
-		v.model.currentView = ViewIndex
+		return v.model, Navigate(ViewIndex, nil)
 	case key.Matches(msg, v.keys.Left):
 		// Decrease value for numeric settings
 		v.adjustSetting(v.selected, -1)
@@ -188,7 +213,7 @@ func (v *SettingsView) Render(width, height int) string {
 	var b strings.Builder
 
 	// Get current settings
-	settings := GetData().GetSettings()
+	settings := v.model.GetData().GetSettings()
 
 	// Settings options with enhanced styling
 	for i, option := range v.options {
@@ -201,45 +226,156 @@ func (v *SettingsView) Render(width, height int) string {
 		case "theme":
 			currentValue = settings.Theme
 			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A78BFA")).Bold(true) // Light purple
-		case "sound_enabled":
-			if settings.SoundEnabled {
-				currentValue = "✓ enabled"
-				valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")) // Green
-			} else {
-				currentValue = "✗ disabled"
-				valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")) // Red
+		case "language":
+			currentValue = i18n.Locale(settings.Language).Name()
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A78BFA")).Bold(true) // Light purple
+		case "number_format":
+			currentValue = settings.NumberFormat
+			if currentValue == "" {
+				currentValue = v.model.T("settings.by_language", i18n.NewChipFormatter(v.model.translator, "", "").Format(1000))
 			}
-		case "animations_enabled":
-			if settings.AnimationsEnabled {
-				currentValue = "✓ enabled"
-				valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")) // Green
-			} else {
-				currentValue = "✗ disabled"
-				valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")) // Red
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A78BFA")).Bold(true) // Light purple
+		case "currency":
+			currentValue = settings.Currency
+			if currentValue == "" {
+				currentValue = v.model.T("settings.no_currency")
 			}
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A78BFA")).Bold(true) // Light purple
+		case "accessibility":
+			currentValue, valueStyle = v.toggleValue(settings.Accessibility)
+		case "four_color_deck":
+			currentValue, valueStyle = v.toggleValue(settings.FourColorDeck)
+		case "sound_enabled":
+			currentValue, valueStyle = v.toggleValue(settings.SoundEnabled)
+		case "animations_enabled":
+			currentValue, valueStyle = v.toggleValue(settings.AnimationsEnabled)
 		case "auto_save":
-			if settings.AutoSave {
-				currentValue = "✓ enabled"
-				valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")) // Green
-			} else {
-				currentValue = "✗ disabled"
-				valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")) // Red
-			}
+			currentValue, valueStyle = v.toggleValue(settings.AutoSave)
 		case "default_buy_in":
-			currentValue = fmt.Sprintf("%d chips", settings.DefaultBuyIn)
+			currentValue = v.model.T("settings.chips", v.model.Chips().Format(settings.DefaultBuyIn))
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")) // Yellow/Orange
+		case "sng_seats":
+			currentValue = fmt.Sprintf("%d-max", settings.SNGSeats)
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")) // Yellow/Orange
+		case "sng_blinds":
+			currentValue = settings.SNGBlinds
+			if currentValue == "" {
+				currentValue = tournament.StandardStructure
+			}
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")) // Yellow/Orange
+		case "sng_breaks":
+			currentValue = v.model.T("settings.off")
+			if settings.SNGBreaks != "" {
+				currentValue = v.model.T("settings.breaks." + settings.SNGBreaks)
+			}
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")) // Yellow/Orange
+		case "bomb_pot_every":
+			currentValue = v.model.T("settings.off")
+			if settings.BombPotEvery > 0 {
+				currentValue = v.model.T("settings.every_hands", settings.BombPotEvery)
+			}
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")) // Yellow/Orange
+		case "seven_deuce_bounty_bb":
+			currentValue = v.model.T("settings.off")
+			if settings.SevenDeuceBountyBB > 0 {
+				currentValue = fmt.Sprintf("%d BB", settings.SevenDeuceBountyBB)
+			}
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")) // Yellow/Orange
+		case "stop_loss_bb", "stop_win_bb":
+			limit := settings.StopLossBB
+			if option.Key == "stop_win_bb" {
+				limit = settings.StopWinBB
+			}
+			currentValue = v.model.T("settings.off")
+			if limit > 0 {
+				currentValue = fmt.Sprintf("%d BB", limit)
+			}
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")) // Yellow/Orange
+		case "time_limit_minutes", "timed_session_minutes":
+			minutes := settings.TimeLimitMinutes
+			if option.Key == "timed_session_minutes" {
+				minutes = settings.TimedSessionMinutes
+			}
+			currentValue = v.model.T("settings.off")
+			if minutes > 0 {
+				currentValue = v.model.T("settings.minutes", minutes)
+			}
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")) // Yellow/Orange
+		case "daily_hand_limit":
+			currentValue = v.model.T("settings.off")
+			if settings.DailyHandLimit > 0 {
+				currentValue = v.model.T("settings.hands_a_day", settings.DailyHandLimit)
+			}
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")) // Yellow/Orange
+		case "auto_muck", "auto_check":
+			enabled := settings.AutoMuck
+			if option.Key == "auto_check" {
+				enabled = settings.AutoCheck
+			}
+			currentValue, valueStyle = v.toggleValue(enabled)
+		case "auto_call_bb":
+			currentValue = v.model.T("settings.off")
+			if settings.AutoCallBB > 0 {
+				currentValue = v.model.T("settings.up_to_bb", settings.AutoCallBB)
+			}
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")) // Yellow/Orange
+		case "game_speed":
+			currentValue = v.model.T("settings.speed." + nextGameSpeed(settings.GameSpeed, 0))
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A78BFA")).Bold(true) // Light purple
+		case "home_game":
+			currentValue, valueStyle = v.toggleValue(settings.HomeGame)
+		case "bot_tilt":
+			currentValue, valueStyle = v.toggleValue(settings.BotTilt)
+		case "table_talk":
+			currentValue, valueStyle = v.toggleValue(settings.TableTalk)
+		case "avatar", "avatar_color":
+			// Both show the name as it appears at the table
+			avatar := humanAvatar(v.model.GetData().GetUser())
+			currentValue = avatar.Badge(v.model.T("settings.you"), false)
+			if v.model.Accessible() {
+				currentValue = avatar.Emoji
+				if option.Key == "avatar_color" {
+					currentValue = v.model.T("settings.color." + avatarColorNames[string(avatar.Color)])
+				}
+			}
+			valueStyle = lipgloss.NewStyle().Foreground(avatar.Color).Bold(true)
+		case "hide_hole_cards":
+			currentValue, valueStyle = v.toggleValue(settings.HideHoleCards)
+		case "streamer_mode":
+			currentValue, valueStyle = v.toggleValue(settings.StreamerMode)
+		case "streamer_delay_seconds":
+			currentValue = v.model.T("settings.off")
+			if settings.StreamerDelay > 0 {
+				currentValue = v.model.T("settings.seconds", settings.StreamerDelay)
+			}
 			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")) // Yellow/Orange
 		case "show_probabilities":
-			if settings.ShowProbabilities {
-				currentValue = "✓ enabled"
-				valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")) // Green
-			} else {
-				currentValue = "✗ disabled"
-				valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")) // Red
+			currentValue, valueStyle = v.toggleValue(settings.ShowProbabilities)
+		case "anonymize_exports":
+			currentValue, valueStyle = v.toggleValue(settings.AnonymizeExports)
+		case "hud_stream":
+			currentValue, valueStyle = v.toggleValue(settings.HUDStream)
+			if settings.HUDStream && !v.model.Accessible() {
+				currentValue += " → " + settings.HUDAddress
+			}
+		case "log_level":
+			currentValue = fmt.Sprintf("%s → %s", settings.LogLevel, settings.LogFile)
+			if v.model.Accessible() {
+				currentValue = fmt.Sprintf("%s, written to %s", settings.LogLevel, settings.LogFile)
 			}
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A78BFA")).Bold(true) // Light purple
+		case "data_folder":
+			currentValue = v.dataFolder()
+			valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A78BFA")).Bold(true) // Light purple
 		}
 
 		// Format the line with icon
 		line = fmt.Sprintf("%s %-18s: %s", option.Icon, option.Label, valueStyle.Render(currentValue))
+		marker := "▶ "
+		if v.model.Accessible() {
+			line = fmt.Sprintf("%s: %s", option.Label, currentValue)
+			marker = "> "
+		}
 
 		if i == v.selected {
 			// Selected item styling with border
@@ -248,18 +384,22 @@ func (v *SettingsView) Render(width, height int) string {
 				Background(lipgloss.Color("#7C3AED")). // Purple background
 				Padding(0, 1).
 				Bold(true)
-			b.WriteString(selectedStyle.Render("▶ " + line))
+			b.WriteString(selectedStyle.Render(marker + line))
 			b.WriteString("\n")
 		} else {
 			b.WriteString(itemStyle.Render("  " + line))
 			b.WriteString("\n")
 		}
 
-		// Show description for selected item
+		// Show description for selected item, or what opening the folder did
+		text := option.Description
+		if option.Key == "data_folder" && v.status != "" {
+			text = v.status
+		}
 		description := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#9CA3AF")). // Medium gray
 			Italic(true).
-			Render("  " + option.Description)
+			Render("  " + text)
 		b.WriteString(description)
 		b.WriteString("\n")
 		b.WriteString("\n")
@@ -292,6 +432,20 @@ func (v *SettingsView) Render(width, height int) string {
 	return fullScreenContainer.Render(fullContent)
 }
 
+// toggleValue renders an on/off setting, in words only in accessibility mode
+func (v *SettingsView) toggleValue(enabled bool) (string, lipgloss.Style) {
+	switch {
+	case v.model.Accessible() && enabled:
+		return v.model.T("settings.enabled.plain"), lipgloss.NewStyle()
+	case v.model.Accessible():
+		return v.model.T("settings.disabled.plain"), lipgloss.NewStyle()
+	case enabled:
+		return v.model.T("settings.enabled"), lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")) // Green
+	default:
+		return v.model.T("settings.disabled"), lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")) // Red
+	}
+}
+
 // GetType returns the view type
 func (v *SettingsView) GetType() ViewType {
 	return ViewSettings
@@ -311,43 +465,239 @@ func (v *SettingsView) FullHelp() [][]key.Binding {
 func (v *SettingsView) toggleSetting(index int) {
 	if index >= 0 && index < len(v.options) {
 		option := v.options[index]
-		settings := GetData().GetSettings()
+		settings := v.model.GetData().GetSettings()
 
 		switch option.Key {
 		case "theme":
 			// Cycle through themes
 			switch settings.Theme {
 			case "dark":
-				GetData().UpdateSetting("theme", "light")
+				v.model.GetData().UpdateSetting("theme", "light")
 			case "light":
-				GetData().UpdateSetting("theme", "auto")
+				v.model.GetData().UpdateSetting("theme", "auto")
 			case "auto":
-				GetData().UpdateSetting("theme", "dark")
+				v.model.GetData().UpdateSetting("theme", "dark")
 			default:
-				GetData().UpdateSetting("theme", "dark")
+				v.model.GetData().UpdateSetting("theme", "dark")
 			}
+		case "language":
+			v.model.GetData().UpdateSetting("language", string(nextLocale(i18n.Locale(settings.Language))))
+		case "number_format":
+			v.model.GetData().UpdateSetting("number_format", cycleString(numberFormatChoices, settings.NumberFormat, 1))
+		case "currency":
+			v.model.GetData().UpdateSetting("currency", cycleString(currencyChoices, settings.Currency, 1))
+		case "accessibility":
+			v.model.GetData().UpdateSetting("accessibility", !settings.Accessibility)
+		case "four_color_deck":
+			v.model.GetData().UpdateSetting("four_color_deck", !settings.FourColorDeck)
 		case "sound_enabled":
-			GetData().UpdateSetting("sound_enabled", !settings.SoundEnabled)
+			v.model.GetData().UpdateSetting("sound_enabled", !settings.SoundEnabled)
 		case "animations_enabled":
-			GetData().UpdateSetting("animations_enabled", !settings.AnimationsEnabled)
+			v.model.GetData().UpdateSetting("animations_enabled", !settings.AnimationsEnabled)
 		case "auto_save":
-			GetData().UpdateSetting("auto_save", !settings.AutoSave)
+			v.model.GetData().UpdateSetting("auto_save", !settings.AutoSave)
+		case "sng_seats":
+			v.model.GetData().UpdateSetting("sng_seats", otherSNGSize(settings.SNGSeats))
+		case "sng_blinds":
+			v.model.GetData().UpdateSetting("sng_blinds", cycleString(tournament.StructurePresets(), settings.SNGBlinds, 1))
+		case "sng_breaks":
+			v.model.GetData().UpdateSetting("sng_breaks", cycleString(sngBreakChoices, settings.SNGBreaks, 1))
+		case "bomb_pot_every":
+			v.model.GetData().UpdateSetting("bomb_pot_every", cycleChoice(bombPotChoices, settings.BombPotEvery, 1))
+		case "seven_deuce_bounty_bb":
+			v.model.GetData().UpdateSetting("seven_deuce_bounty_bb", cycleChoice(bountyChoices, settings.SevenDeuceBountyBB, 1))
+		case "stop_loss_bb":
+			v.model.GetData().UpdateSetting("stop_loss_bb", cycleChoice(sessionLimitChoices, settings.StopLossBB, 1))
+		case "stop_win_bb":
+			v.model.GetData().UpdateSetting("stop_win_bb", cycleChoice(sessionLimitChoices, settings.StopWinBB, 1))
+		case "time_limit_minutes":
+			v.model.GetData().UpdateSetting("time_limit_minutes", cycleChoice(timeLimitChoices, settings.TimeLimitMinutes, 1))
+		case "timed_session_minutes":
+			v.model.GetData().UpdateSetting("timed_session_minutes", cycleChoice(timedSessionChoices, settings.TimedSessionMinutes, 1))
+		case "daily_hand_limit":
+			v.model.GetData().UpdateSetting("daily_hand_limit", cycleChoice(dailyLimitChoices, settings.DailyHandLimit, 1))
+		case "auto_muck":
+			v.model.GetData().UpdateSetting("auto_muck", !settings.AutoMuck)
+		case "auto_check":
+			v.model.GetData().UpdateSetting("auto_check", !settings.AutoCheck)
+		case "auto_call_bb":
+			v.model.GetData().UpdateSetting("auto_call_bb", cycleChoice(autoCallChoices, settings.AutoCallBB, 1))
+		case "game_speed":
+			v.model.GetData().UpdateSetting("game_speed", nextGameSpeed(settings.GameSpeed, 1))
+		case "home_game":
+			v.model.GetData().UpdateSetting("home_game", !settings.HomeGame)
+		case "bot_tilt":
+			v.model.GetData().UpdateSetting("bot_tilt", !settings.BotTilt)
+		case "table_talk":
+			v.model.GetData().UpdateSetting("table_talk", !settings.TableTalk)
+		case "avatar", "avatar_color":
+			v.cycleAvatar(option.Key, 1)
+		case "hide_hole_cards":
+			v.model.GetData().UpdateSetting("hide_hole_cards", !settings.HideHoleCards)
+		case "streamer_mode":
+			v.model.GetData().UpdateSetting("streamer_mode", !settings.StreamerMode)
+		case "streamer_delay_seconds":
+			v.model.GetData().UpdateSetting("streamer_delay_seconds", cycleChoice(streamerDelayChoices, settings.StreamerDelay, 1))
 		case "show_probabilities":
-			GetData().UpdateSetting("show_probabilities", !settings.ShowProbabilities)
+			v.model.GetData().UpdateSetting("show_probabilities", !settings.ShowProbabilities)
+		case "anonymize_exports":
+			v.model.GetData().UpdateSetting("anonymize_exports", !settings.AnonymizeExports)
+		case "hud_stream":
+			v.model.GetData().UpdateSetting("hud_stream", !settings.HUDStream)
+		case "log_level":
+			v.model.GetData().UpdateSetting("log_level", nextLogLevel(settings.LogLevel))
+		case "data_folder":
+			dir := v.dataFolder()
+			if err := v.open(dir); err != nil {
+				v.status = v.model.T("settings.data_folder.failed", err)
+			} else {
+				v.status = v.model.T("settings.data_folder.opened", dir)
+			}
 		}
 	}
 }
 
+// dataFolder returns the directory replays, logs and exports are kept in
+func (v *SettingsView) dataFolder() string {
+	if dir := v.model.GetData().GetDirs().Data; dir != "" {
+		return dir
+	}
+	return "."
+}
+
 // adjustSetting adjusts numeric settings
 func (v *SettingsView) adjustSetting(index int, delta int) {
 	if index >= 0 && index < len(v.options) {
 		option := v.options[index]
+		settings := v.model.GetData().GetSettings()
+		switch option.Key {
+		case "sng_seats":
+			v.model.GetData().UpdateSetting("sng_seats", otherSNGSize(settings.SNGSeats))
+			return
+		case "sng_blinds":
+			v.model.GetData().UpdateSetting("sng_blinds", cycleString(tournament.StructurePresets(), settings.SNGBlinds, delta))
+			return
+		case "sng_breaks":
+			v.model.GetData().UpdateSetting("sng_breaks", cycleString(sngBreakChoices, settings.SNGBreaks, delta))
+			return
+		case "bomb_pot_every":
+			v.model.GetData().UpdateSetting("bomb_pot_every", cycleChoice(bombPotChoices, settings.BombPotEvery, delta))
+			return
+		case "seven_deuce_bounty_bb":
+			v.model.GetData().UpdateSetting("seven_deuce_bounty_bb", cycleChoice(bountyChoices, settings.SevenDeuceBountyBB, delta))
+			return
+		case "stop_loss_bb":
+			v.model.GetData().UpdateSetting("stop_loss_bb", cycleChoice(sessionLimitChoices, settings.StopLossBB, delta))
+			return
+		case "stop_win_bb":
+			v.model.GetData().UpdateSetting("stop_win_bb", cycleChoice(sessionLimitChoices, settings.StopWinBB, delta))
+			return
+		case "time_limit_minutes":
+			v.model.GetData().UpdateSetting("time_limit_minutes", cycleChoice(timeLimitChoices, settings.TimeLimitMinutes, delta))
+			return
+		case "timed_session_minutes":
+			v.model.GetData().UpdateSetting("timed_session_minutes", cycleChoice(timedSessionChoices, settings.TimedSessionMinutes, delta))
+			return
+		case "daily_hand_limit":
+			v.model.GetData().UpdateSetting("daily_hand_limit", cycleChoice(dailyLimitChoices, settings.DailyHandLimit, delta))
+			return
+		case "auto_call_bb":
+			v.model.GetData().UpdateSetting("auto_call_bb", cycleChoice(autoCallChoices, settings.AutoCallBB, delta))
+			return
+		case "streamer_delay_seconds":
+			v.model.GetData().UpdateSetting("streamer_delay_seconds", cycleChoice(streamerDelayChoices, settings.StreamerDelay, delta))
+			return
+		case "game_speed":
+			v.model.GetData().UpdateSetting("game_speed", nextGameSpeed(settings.GameSpeed, delta))
+			return
+		case "number_format":
+			v.model.GetData().UpdateSetting("number_format", cycleString(numberFormatChoices, settings.NumberFormat, delta))
+			return
+		case "currency":
+			v.model.GetData().UpdateSetting("currency", cycleString(currencyChoices, settings.Currency, delta))
+			return
+		case "avatar", "avatar_color":
+			v.cycleAvatar(option.Key, delta)
+			return
+		}
 		if option.ValueType == "int" && option.Key == "default_buy_in" {
-			settings := GetData().GetSettings()
 			newValue := settings.DefaultBuyIn + (delta * 100) // Adjust by 100 chips
 			if newValue >= 100 && newValue <= 10000 {         // Reasonable limits
-				GetData().UpdateSetting("default_buy_in", newValue)
+				v.model.GetData().UpdateSetting("default_buy_in", newValue)
 			}
 		}
 	}
 }
+
+// cycleAvatar steps the emoji or the color of the player's avatar
+func (v *SettingsView) cycleAvatar(key string, delta int) {
+	avatar := humanAvatar(v.model.GetData().GetUser())
+	emoji, color := avatar.Emoji, string(avatar.Color)
+	if key == "avatar" {
+		emoji = cycleString(avatarEmojis, emoji, delta)
+	} else {
+		color = cycleString(avatarColors, color, delta)
+	}
+	v.model.GetData().SetAvatar(emoji, color)
+}
+
+// Choices offered for the home-game rules, 0 is off
+var (
+	bombPotChoices = []int{0, 5, 10, 20}
+	bountyChoices  = []int{0, 1, 2, 5}
+)
+
+// Choices offered for the cash game session limits, 0 is off
+var (
+	sessionLimitChoices = []int{0, 50, 100, 200}      // Big blinds
+	timeLimitChoices    = []int{0, 30, 60, 120}       // Minutes
+	timedSessionChoices = []int{0, 60, 120, 180, 240} // Minutes
+)
+
+// dailyLimitChoices are the daily hand limits offered, 0 is off
+var dailyLimitChoices = []int{0, 100, 250, 500, 1000}
+
+// numberFormatChoices are the number formats, "" following the language
+var numberFormatChoices = append([]string{""}, i18n.NumberFormats...)
+
+// sngBreakChoices are the break schedules of Sit & Gos, "" for none
+var sngBreakChoices = []string{"", "hourly", "synchronized"}
+
+// currencyChoices are the symbols amounts can be shown in, "" for chips
+var currencyChoices = []string{"", "$", "€", "£"}
+
+// streamerDelayChoices are the seconds the equity overlay waits in streamer
+// mode, 0 is off
+var streamerDelayChoices = []int{0, 5, 10, 30, 60}
+
+// autoCallChoices are the largest calls, in big blinds, made without asking; 0 is off
+var autoCallChoices = []int{0, 1, 2, 3}
+
+// cycleChoice steps through choices from the current value, wrapping around
+func cycleChoice(choices []int, current, delta int) int {
+	for i, choice := range choices {
+		if choice == current {
+			return choices[((i+delta)%len(choices)+len(choices))%len(choices)]
+		}
+	}
+	return choices[0]
+}
+
+// nextLocale cycles through the languages with a catalog
+func nextLocale(locale i18n.Locale) i18n.Locale {
+	locales := i18n.Locales()
+	for i, l := range locales {
+		if l == locale {
+			return locales[(i+1)%len(locales)]
+		}
+	}
+	return locales[0]
+}
+
+// otherSNGSize switches between the 6-max and 9-max sit-and-go
+func otherSNGSize(seats int) int {
+	if seats == 6 {
+		return 9
+	}
+	return 6
+}
@@ -0,0 +1,189 @@
+package frontend
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/internal/paths"
+)
+
+func TestSettingsReachedFromMenuAndToggled(t *testing.T) {
+	h := newTUIHarness(t, 100, 50)
+	h.WaitFor("Texas Hold'em Poker")
+	h.Snapshot("index")
+
+	h.Press("down", 12)
+	h.Keys("enter")
+	h.WaitFor("Auto-Check")
+
+	h.Press("down", 22)
+	h.Keys("enter")
+	if !h.model.GetData().GetSettings().AutoCheck {
+		t.Fatal("Expected enter on Auto-Check to turn it on")
+	}
+	h.Snapshot("settings_auto_check")
+
+	h.Keys("esc")
+	h.WaitFor("Texas Hold'em Poker")
+}
+
+func TestSettingsSwitchLanguage(t *testing.T) {
+	h := newTUIHarness(t, 100, 50)
+	h.Press("down", 12)
+	h.Keys("enter")
+	h.WaitFor("Language")
+
+	h.Keys("down", "enter")
+	h.WaitFor("Idioma")
+	h.WaitFor("Español")
+
+	h.Keys("esc")
+	h.WaitFor("Configura las preferencias de juego")
+}
+
+func TestCardRendererFollowsSettings(t *testing.T) {
+	model := newTestModel(t, nil)
+	ace := poker.NewCard(poker.SuitSpade, poker.RankAce)
+	if got := model.Cards().Card(ace); got != "🂡" {
+		t.Errorf("Expected the card glyph by default, got %q", got)
+	}
+
+	model.GetData().UpdateSetting("four_color_deck", true)
+	model.Update(dataChangedMsg{key: settingsKey})
+	if got := model.Cards().Card(poker.NewCard(poker.SuitDiamond, poker.RankTen)); got != "10♦" {
+		t.Errorf("Expected rank and suit shape with the four-color deck, got %q", got)
+	}
+
+	model.GetData().UpdateSetting("accessibility", true)
+	model.Update(dataChangedMsg{key: settingsKey})
+	if got := model.Cards().Card(ace); got != "Ace of spades" {
+		t.Errorf("Expected accessibility mode to win over the four-color deck, got %q", got)
+	}
+}
+
+func TestSettingsCycleGameSpeed(t *testing.T) {
+	h := newTUIHarness(t, 100, 50)
+	h.Press("down", 12)
+	h.Keys("enter")
+	h.WaitFor("Game Speed")
+
+	h.Press("down", 24)
+	h.Keys("enter")
+	h.WaitFor("Slow")
+	if got := h.model.GetData().GetSettings().GameSpeed; got != "slow" {
+		t.Fatalf("Expected enter to step from normal to slow, got %q", got)
+	}
+	h.Keys("right")
+	h.WaitFor("Instant")
+	h.Keys("left", "left")
+	if got := h.model.GetData().GetSettings().GameSpeed; got != "normal" {
+		t.Errorf("Expected left to step back down to normal, got %q", got)
+	}
+}
+
+func TestGameSpeedFollowsSettings(t *testing.T) {
+	data := NewData(NewMemoryStore())
+	data.UpdateSetting("game_speed", "instant")
+	runner := newGameRunner(holdem.NewDiscardLogger(), data, nil)
+	if speed := runner.speed(); speed != (gameSpeed{}) {
+		t.Errorf("Expected no delays at instant speed, got %+v", speed)
+	}
+	if speedNamed("") != gameSpeeds["normal"] {
+		t.Error("Expected settings saved without a speed to play at normal speed")
+	}
+}
+
+func TestSettingsCycleAvatar(t *testing.T) {
+	h := newTUIHarness(t, 100, 50)
+	h.Press("down", 12)
+	h.Keys("enter")
+	h.WaitFor("Name Color")
+
+	h.Press("down", 27)
+	h.Keys("enter")
+	h.Keys("down", "left")
+	user := h.model.GetData().GetUser()
+	if user == nil || user.Avatar != avatarEmojis[1] || user.AvatarColor != avatarColors[len(avatarColors)-1] {
+		t.Fatalf("Expected the next emoji and the last color to be saved, got %+v", user)
+	}
+	if got := humanAvatar(user).Badge("Hero", false); got != avatarEmojis[1]+" Hero" {
+		t.Errorf("Expected the saved avatar at the table, got %q", got)
+	}
+}
+
+func TestSettingsToggle(t *testing.T) {
+	for _, tt := range []struct {
+		key  string
+		want func(s *SettingsData) bool
+	}{
+		{"accessibility", func(s *SettingsData) bool { return s.Accessibility }},
+		{"four_color_deck", func(s *SettingsData) bool { return s.FourColorDeck }},
+		{"number_format", func(s *SettingsData) bool { return s.NumberFormat == "1,000" }},
+		{"currency", func(s *SettingsData) bool { return s.Currency == "$" }},
+		{"sound_enabled", func(s *SettingsData) bool { return !s.SoundEnabled }},
+		{"auto_save", func(s *SettingsData) bool { return !s.AutoSave }},
+		{"sng_seats", func(s *SettingsData) bool { return s.SNGSeats == 9 }},
+		{"sng_blinds", func(s *SettingsData) bool { return s.SNGBlinds == "deep" }},
+		{"sng_breaks", func(s *SettingsData) bool { return s.SNGBreaks == "hourly" }},
+		{"auto_muck", func(s *SettingsData) bool { return s.AutoMuck }},
+		{"auto_check", func(s *SettingsData) bool { return s.AutoCheck }},
+		{"auto_call_bb", func(s *SettingsData) bool { return s.AutoCallBB == 1 }},
+		{"game_speed", func(s *SettingsData) bool { return s.GameSpeed == "slow" }},
+		{"bot_tilt", func(s *SettingsData) bool { return !s.BotTilt }},
+		{"hide_hole_cards", func(s *SettingsData) bool { return s.HideHoleCards }},
+		{"streamer_mode", func(s *SettingsData) bool { return s.StreamerMode }},
+		{"streamer_delay_seconds", func(s *SettingsData) bool { return s.StreamerDelay == 30 }},
+		{"log_level", func(s *SettingsData) bool { return s.LogLevel == "debug" }},
+	} {
+		t.Run(tt.key, func(t *testing.T) {
+			model := newTestModel(t, nil)
+			view := model.settingsView.(*SettingsView)
+			index := -1
+			for i, option := range view.options {
+				if option.Key == tt.key {
+					index = i
+				}
+			}
+			if index < 0 {
+				t.Fatalf("No %s setting", tt.key)
+			}
+			view.toggleSetting(index)
+			if settings := model.GetData().GetSettings(); !tt.want(settings) {
+				t.Errorf("Unexpected settings after toggling %s: %+v", tt.key, settings)
+			}
+		})
+	}
+}
+
+func TestSettingsOpenDataFolder(t *testing.T) {
+	model := newTestModel(t, nil)
+	dir := t.TempDir()
+	model.GetData().SetDirs(paths.Dirs{Config: dir, Data: dir})
+	view := model.settingsView.(*SettingsView)
+	opened := ""
+	view.open = func(dir string) error {
+		opened = dir
+		return nil
+	}
+
+	index := len(view.options) - 1
+	if view.options[index].Key != "data_folder" {
+		t.Fatalf("Expected the data folder last, got %+v", view.options[index])
+	}
+	if screen := view.Render(200, 200); !strings.Contains(screen, dir) {
+		t.Errorf("Expected the data folder shown, got:\n%s", screen)
+	}
+	view.toggleSetting(index)
+	if opened != dir || view.status != "Opened "+dir {
+		t.Errorf("Expected %s opened, got %q (%q)", dir, opened, view.status)
+	}
+
+	view.open = func(string) error { return errors.New("no file manager") }
+	view.toggleSetting(index)
+	if view.status != "Cannot open the folder: no file manager" {
+		t.Errorf("Expected the failure shown, got %q", view.status)
+	}
+}
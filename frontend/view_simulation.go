@@ -0,0 +1,325 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/engine/rating"
+	"github.com/ljbink/ai-poker/engine/simulator"
+	"github.com/ljbink/ai-poker/engine/tournament"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// simulationRuns is how many sit-and-gos a simulation from the menu plays
+const simulationRuns = 100
+
+// SimulationKeyMap defines keybindings for the simulation view
+type SimulationKeyMap struct {
+	Restart key.Binding
+	Back    key.Binding
+	Quit    key.Binding
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view.
+func (k SimulationKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Restart, k.Back, k.Quit}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (k SimulationKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Restart},
+		{k.Back, k.Quit},
+	}
+}
+
+var simulationKeys = SimulationKeyMap{
+	Restart: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "run again"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back to menu"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// simulationMsg carries progress or the outcome of a background simulation
+type simulationMsg struct {
+	progress simulator.Progress
+	results  []simulator.SitAndGoSummary // Set once the batch is done
+	ratings  rating.Ratings              // Set with results, after rating the batch's games
+	err      error
+	done     bool
+	ok       bool
+
+	updates chan simulationMsg // Sender, so messages from a cancelled batch are ignored
+}
+
+// SimulationView plays a batch of sit-and-gos between every bot preset on
+// all CPU cores, showing a progress bar and then how each bot did
+type SimulationView struct {
+	model *Model
+	keys  SimulationKeyMap
+
+	updates  chan simulationMsg
+	cancel   context.CancelFunc
+	seats    int
+	seed     int64
+	progress simulator.Progress
+	results  []simulator.SitAndGoSummary
+	ratings  rating.Ratings
+	err      error
+
+	// Components
+	header *component.HeaderComponent
+	helper *component.HelperComponent
+	bar    progress.Model
+}
+
+// NewSimulationView creates a new simulation view
+func NewSimulationView(model *Model) *SimulationView {
+	return &SimulationView{
+		model: model,
+		keys:  simulationKeys,
+
+		// Initialize components with default width (will be updated in Render)
+		header: component.NewHeaderComponent("🤖 Bot Simulation", 80),
+		helper: component.NewHelperComponent(simulationKeys, 80),
+		bar:    progress.New(progress.WithDefaultGradient()),
+	}
+}
+
+// Start plays a new batch in the background, abandoning any running one,
+// and returns the command that delivers its first update
+func (v *SimulationView) Start(seats int) tea.Cmd {
+	v.stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan simulationMsg, 1)
+	v.updates, v.cancel = updates, cancel
+	v.seats, v.seed = seats, time.Now().UnixNano()
+	v.progress = simulator.Progress{Total: simulationRuns}
+	v.results, v.err = nil, nil
+
+	batch := simulator.BatchConfig{
+		Runs:    simulationRuns,
+		Workers: runtime.NumCPU(),
+		Seed:    v.seed,
+	}
+	names := holdem_ai.BotNames()
+	data := v.model.GetData()
+	blinds := data.GetSettings().SNGBlinds
+	library := ranges.WithLibrary(data.rangeLibrary())
+	var last simulator.Progress
+	batch.Progress = func(p simulator.Progress) {
+		last = p
+		// Drop updates the view has not caught up with; a later one follows
+		select {
+		case updates <- simulationMsg{progress: p}:
+		default:
+		}
+	}
+	go func() {
+		defer close(updates)
+		structure, err := tournament.Structure(blinds)
+		if err != nil {
+			updates <- simulationMsg{progress: last, done: true, err: err}
+			return
+		}
+		results, err := simulator.RunSitAndGos(ctx, batch, seats, structure, sngBuyIn, func(seed int64) ([]simulator.Entrant, error) {
+			entrants := []simulator.Entrant{}
+			for i := 0; i < seats; i++ {
+				name := names[i%len(names)]
+				maker, err := holdem_ai.CreateSeededBot(name, simulator.DeriveSeed(seed, i), library)
+				if err != nil {
+					return nil, err
+				}
+				if bot, ok := maker.(*holdem_ai.BasicBotDecisionMaker); ok {
+					bot.SetThinkingTime(0, 0)
+				}
+				entrants = append(entrants, simulator.Entrant{Name: name, Maker: maker})
+			}
+			return entrants, nil
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		msg := simulationMsg{progress: last, done: true, err: err}
+		if err == nil {
+			msg.results = simulator.SummarizeSitAndGos(results)
+			data.RecordMatches(sitAndGoMatches(results)...)
+			data.RecordTournament(sitAndGoFinishes(results))
+			msg.ratings = data.GetRatings()
+		}
+		select {
+		case updates <- msg:
+		case <-ctx.Done():
+		}
+	}()
+	return waitForSimulation(updates)
+}
+
+// sitAndGoMatches turns sit-and-gos into matches to rate, each bot scored
+// by its finishing place. Bots still in when the hand limit stopped a game
+// rank ahead of those out, by their chips.
+func sitAndGoMatches(results []*simulator.TournamentResult) [][]rating.Result {
+	matches := make([][]rating.Result, 0, len(results))
+	for _, result := range results {
+		match := make([]rating.Result, 0, len(result.Standings))
+		for _, standing := range result.Standings {
+			score := float64(-standing.Place)
+			if standing.Place == 0 {
+				score = float64(standing.Chips)
+			}
+			match = append(match, rating.Result{Name: standing.Name, Score: score})
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// sitAndGoFinishes turns the places of the bots in sit-and-gos into
+// results for the leaderboard, which leaves out those a hand limit stopped
+func sitAndGoFinishes(results []*simulator.TournamentResult) []GameResult {
+	finishes := []GameResult{}
+	for _, result := range results {
+		for _, standing := range result.Standings {
+			finishes = append(finishes, GameResult{Name: standing.Name, Bot: true, Place: standing.Place})
+		}
+	}
+	return finishes
+}
+
+// waitForSimulation blocks until the batch sends an update
+func waitForSimulation(updates chan simulationMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-updates
+		msg.ok, msg.updates = ok, updates
+		return msg
+	}
+}
+
+// receive applies an update and keeps listening until the batch is done
+func (v *SimulationView) receive(msg simulationMsg) tea.Cmd {
+	if msg.updates != v.updates || !msg.ok {
+		return nil
+	}
+	v.progress = msg.progress
+	if msg.done {
+		v.results, v.ratings, v.err = msg.results, msg.ratings, msg.err
+		return nil
+	}
+	return waitForSimulation(v.updates)
+}
+
+func (v *SimulationView) stop() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+}
+
+// OnEnter starts a simulation with the SimulationParams table size
+func (v *SimulationView) OnEnter(params any) tea.Cmd {
+	simulation, _ := params.(SimulationParams)
+	return v.Start(simulation.Seats)
+}
+
+// OnExit abandons a running simulation
+func (v *SimulationView) OnExit() {
+	v.stop()
+	v.updates = nil
+}
+
+// Update handles input for the simulation view
+func (v *SimulationView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		return v.model, Navigate(ViewIndex, nil)
+	case key.Matches(msg, v.keys.Quit):
+		return v.model, tea.Quit
+	case key.Matches(msg, v.keys.Restart):
+		return v.model, v.Start(v.seats)
+	}
+	return v.model, nil
+}
+
+// Render renders the simulation view
+func (v *SimulationView) Render(width, height int) string {
+	// Update component widths for current screen size
+	v.header.SetWidth(width)
+	v.helper.SetWidth(width)
+	v.bar.Width = min(max(width-20, 10), 60)
+
+	titleAtTop := v.header.Render()
+	helpAtBottom := v.helper.Render()
+
+	headerHeight := lipgloss.Height(titleAtTop)
+	helperHeight := lipgloss.Height(helpAtBottom)
+	availableHeight := height - headerHeight - helperHeight
+
+	lines := []string{
+		fmt.Sprintf("%d x %d-max sit-and-go between every bot preset, seed %d", simulationRuns, v.seats, v.seed),
+		"",
+		v.bar.ViewAs(v.progress.Fraction()),
+		fmt.Sprintf("%d/%d games · %d hands · %s", v.progress.Done, v.progress.Total, v.progress.Hands, v.progress.Elapsed.Round(time.Second)),
+	}
+	switch {
+	case v.err != nil:
+		lines = append(lines, "", lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F87171")). // Red
+			Render("Simulation failed: "+v.err.Error()))
+	case v.results != nil:
+		lines = append(lines, "", v.renderResults())
+	}
+
+	centeredContent := lipgloss.Place(
+		width, availableHeight,
+		lipgloss.Center, lipgloss.Center,
+		strings.Join(lines, "\n"),
+	)
+
+	fullContent := titleAtTop + centeredContent + helpAtBottom
+
+	fullScreenContainer := GetFullScreenStyle(width, height)
+	return fullScreenContainer.Render(fullContent)
+}
+
+// renderResults lays out each bot's finishes, best return first, and its
+// rating after the batch
+func (v *SimulationView) renderResults() string {
+	rows := []string{fmt.Sprintf("%-16s %7s %5s %6s %9s %7s %5s", "Bot", "Entries", "Wins", "ITM %", "Avg place", "ROI %", "Elo")}
+	for _, bot := range v.results {
+		rows = append(rows, fmt.Sprintf("%-16s %7d %5d %6.1f %9.2f %+7.1f %5.0f",
+			bot.Name, bot.Entries, bot.Wins, bot.ITM()*100, bot.AveragePlace(), bot.ROI(sngBuyIn)*100, v.ratings.Get(bot.Name).Rating))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// GetType returns the view type
+func (v *SimulationView) GetType() ViewType {
+	return ViewSimulation
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view
+func (v *SimulationView) ShortHelp() []key.Binding {
+	return v.keys.ShortHelp()
+}
+
+// FullHelp returns keybindings for the expanded help view
+func (v *SimulationView) FullHelp() [][]key.Binding {
+	return v.keys.FullHelp()
+}
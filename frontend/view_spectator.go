@@ -0,0 +1,489 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/spectator"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// SpectatorKeyMap defines keybindings for the spectator view
+type SpectatorKeyMap struct {
+	Prev   key.Binding
+	Next   key.Binding
+	Street key.Binding
+	First  key.Binding
+	Last   key.Binding
+	Export key.Binding
+	Copy   key.Binding
+	Back   key.Binding
+	Quit   key.Binding
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view.
+func (k SpectatorKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Prev, k.Next, k.Street, k.First, k.Last, k.Export, k.Copy, k.Back}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (k SpectatorKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Prev, k.Next, k.Street, k.First, k.Last},
+		{k.Export, k.Copy, k.Back, k.Quit},
+	}
+}
+
+var spectatorKeys = SpectatorKeyMap{
+	Prev: key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←/h", "previous action"),
+	),
+	Next: key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "next action"),
+	),
+	Street: key.NewBinding(
+		key.WithKeys("up", "down", "k", "j"),
+		key.WithHelp("↑/↓", "street"),
+	),
+	First: key.NewBinding(
+		key.WithKeys("home", "g"),
+		key.WithHelp("g", "first"),
+	),
+	Last: key.NewBinding(
+		key.WithKeys("end", "G"),
+		key.WithHelp("G", "last"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "export ranges"),
+	),
+	Copy: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "copy hand"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back to menu"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// spectatorViewMsg carries a live table snapshot from a broadcaster subscription
+type spectatorViewMsg struct {
+	view holdem.TableView
+	ok   bool
+}
+
+// SpectatorView shows a table without taking part in it: either a live,
+// redacted broadcast or a recorded hand reviewed with all cards visible
+type SpectatorView struct {
+	model *Model
+	keys  SpectatorKeyMap
+
+	frames  []spectator.CommentaryFrame // Commentator mode steps
+	index   int                         // Current step in frames
+	replay  *holdem.Replay              // Hand reviewed in commentator mode
+	status  string                      // Outcome of the last range export or copy
+	spot    string                      // EV analysis of the bookmarked decision, see ReviewBookmark
+	avatars map[int]component.Avatar    // Players of the reviewed hand, by ID
+	sub     *spectator.Subscription     // Live feed, nil in commentator mode
+	bots    func()                      // Stops the bot table being watched, if any
+	load    *AsyncTask                  // Replay being read, nil once loaded
+	err     error
+	back    ViewType                          // View esc returns to
+	backTo  any                               // Params to open back with
+	copy    func(text string) (string, error) // Puts text on the clipboard, see copyToClipboard
+
+	// Components
+	header   *component.HeaderComponent
+	helper   *component.HelperComponent
+	table    *component.TableComponent
+	graph    *component.EquityGraphComponent
+	timeline *component.TimelineComponent
+}
+
+// NewSpectatorView creates a new spectator view
+func NewSpectatorView(model *Model) *SpectatorView {
+	return &SpectatorView{
+		model: model,
+		keys:  spectatorKeys,
+
+		// Initialize components with default width (will be updated in Render)
+		header:   component.NewHeaderComponent("👁 Spectator", 80),
+		helper:   component.NewHelperComponent(spectatorKeys, 80),
+		table:    component.NewTableComponent(80),
+		graph:    component.NewEquityGraphComponent(),
+		timeline: component.NewTimelineComponent(80),
+		copy:     copyToClipboard,
+	}
+}
+
+// LoadReplay switches to commentator mode for a recorded hand and returns
+// the command that reads it in the background
+func (v *SpectatorView) LoadReplay(path string) tea.Cmd {
+	return v.review(func() (*holdem.Replay, error) {
+		return holdem.LoadReplay(path)
+	}, -1)
+}
+
+// ReviewReplay switches to commentator mode for a hand recorded in memory,
+// such as one of the session just played
+func (v *SpectatorView) ReviewReplay(replay *holdem.Replay) tea.Cmd {
+	return v.review(func() (*holdem.Replay, error) {
+		return replay, nil
+	}, -1)
+}
+
+// ReviewBookmark switches to commentator mode at a bookmarked decision,
+// showing the table as the human faced it and the EV of calling
+func (v *SpectatorView) ReviewBookmark(bookmark Bookmark) tea.Cmd {
+	return v.review(func() (*holdem.Replay, error) {
+		return bookmark.Replay, nil
+	}, bookmark.Action)
+}
+
+// review re-runs the hand load returns in the background, opening at the
+// table before the decision at an action when it is not negative
+func (v *SpectatorView) review(load func() (*holdem.Replay, error), decision int) tea.Cmd {
+	v.stopWatching()
+	v.frames, v.index, v.err, v.replay, v.status, v.spot = nil, 0, nil, nil, "", ""
+	v.graph.SetStreets(nil)
+	v.timeline.SetActions(nil)
+	v.header.SetTitle("🎙 Hand Review")
+	user := v.model.GetData().GetUser()
+
+	var cmd tea.Cmd
+	v.load, cmd = RunAsync(
+		func(ctx context.Context, report func(done, total int)) (reviewedHand, error) {
+			replay, err := load()
+			if err != nil {
+				return reviewedHand{}, err
+			}
+			hand, err := reviewHand(replay)
+			hand.avatars = replayAvatars(replay, user)
+			hand.replay = replay
+			if err != nil || decision < 0 {
+				return hand, err
+			}
+			spot, err := analyzeDecision(hand.frames, replay, decision)
+			if err != nil {
+				hand.spot = "Cannot analyze the decision: " + err.Error()
+				return hand, nil
+			}
+			hand.spot = spot.String()
+			return hand, nil
+		},
+		nil,
+		func(hand reviewedHand, err error) tea.Cmd {
+			v.load, v.frames, v.err, v.replay = nil, hand.frames, err, hand.replay
+			v.graph.SetStreets(hand.equity)
+			v.avatars = hand.avatars
+			v.table.SetAvatars(v.avatars)
+			v.timeline.SetActions(framePhases(v.frames))
+			v.spot = hand.spot
+			if decision > 0 && decision <= len(v.frames) {
+				v.index = decision - 1
+			}
+			v.showFrame()
+			return nil
+		},
+	)
+	return cmd
+}
+
+// Watch switches to live mode and returns the command that delivers the first snapshot
+func (v *SpectatorView) Watch(sub *spectator.Subscription) tea.Cmd {
+	v.stopWatching()
+	v.frames, v.index, v.err, v.replay, v.status, v.spot = nil, 0, nil, nil, "", ""
+	v.graph.SetStreets(nil)
+	v.timeline.SetActions(nil)
+	v.header.SetTitle("👁 Spectator")
+	v.table.SetView(holdem.TableView{})
+	v.avatars = nil
+	v.table.SetAvatars(nil)
+	v.sub = sub
+	return waitForSpectatorView(sub)
+}
+
+// waitForSpectatorView blocks until the subscription delivers a snapshot
+func waitForSpectatorView(sub *spectator.Subscription) tea.Cmd {
+	return func() tea.Msg {
+		view, ok := <-sub.Views()
+		return spectatorViewMsg{view: view, ok: ok}
+	}
+}
+
+// receive applies a live snapshot and keeps listening while the feed is open
+func (v *SpectatorView) receive(msg spectatorViewMsg) tea.Cmd {
+	if v.sub == nil {
+		return nil
+	}
+	if !msg.ok {
+		v.sub = nil
+		return nil
+	}
+	v.table.SetView(msg.view)
+	return waitForSpectatorView(v.sub)
+}
+
+func (v *SpectatorView) stopWatching() {
+	v.load.Cancel()
+	v.load = nil
+	if v.sub != nil {
+		v.sub.Unsubscribe()
+		v.sub = nil
+	}
+	if v.bots != nil {
+		v.bots()
+		v.bots = nil
+	}
+}
+
+func (v *SpectatorView) showFrame() {
+	if len(v.frames) == 0 {
+		return
+	}
+	v.table.SetView(v.frames[v.index].View)
+	v.timeline.SetCurrent(v.index)
+}
+
+// framePhases returns the street of every action of a reviewed hand, for
+// the timeline
+func framePhases(frames []spectator.CommentaryFrame) []holdem.GamePhase {
+	phases := make([]holdem.GamePhase, len(frames))
+	for i, frame := range frames {
+		phases[i] = frame.Action.Phase
+	}
+	return phases
+}
+
+// OnEnter watches the SpectatorParams feed or bot table, or reviews its
+// replay file
+func (v *SpectatorView) OnEnter(params any) tea.Cmd {
+	spectate, _ := params.(SpectatorParams)
+	v.back, v.backTo = spectate.Back, spectate.BackParams
+	switch {
+	case spectate.Bots:
+		feed, stop := watchBots(v.model.GetData().GetSettings())
+		cmd := v.Watch(feed)
+		v.bots = stop
+		return cmd
+	case spectate.Feed != nil:
+		return v.Watch(spectate.Feed)
+	case spectate.Bookmark != nil:
+		return v.ReviewBookmark(*spectate.Bookmark)
+	case spectate.Replay != nil:
+		return v.ReviewReplay(spectate.Replay)
+	}
+	return v.LoadReplay(spectate.ReplayFile)
+}
+
+// OnExit stops following a live feed
+func (v *SpectatorView) OnExit() {
+	v.stopWatching()
+}
+
+// Update handles input for the spectator view
+func (v *SpectatorView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		return v.model, Navigate(v.back, v.backTo)
+	case key.Matches(msg, v.keys.Quit):
+		return v.model, tea.Quit
+	case key.Matches(msg, v.keys.Prev):
+		if v.index > 0 {
+			v.index--
+		}
+		v.showFrame()
+	case key.Matches(msg, v.keys.Next):
+		if v.index < len(v.frames)-1 {
+			v.index++
+		}
+		v.showFrame()
+	case key.Matches(msg, v.keys.Street):
+		if msg.String() == "up" || msg.String() == "k" {
+			v.index = v.timeline.PreviousStreet(v.index)
+		} else {
+			v.index = v.timeline.NextStreet(v.index)
+		}
+		v.showFrame()
+	case key.Matches(msg, v.keys.First):
+		v.index = 0
+		v.showFrame()
+	case key.Matches(msg, v.keys.Last):
+		v.index = max(len(v.frames)-1, 0)
+		v.showFrame()
+	case key.Matches(msg, v.keys.Export):
+		return v.model, v.exportRanges()
+	case key.Matches(msg, v.keys.Copy):
+		return v.model, v.copyHand()
+	}
+	return v.model, nil
+}
+
+// Mouse steps through the reviewed hand's actions with the wheel, back
+// when turned up, and seeks to the action clicked on the timeline
+func (v *SpectatorView) Mouse(msg tea.MouseMsg, row string) (tea.Model, tea.Cmd) {
+	if key, ok := wheelKey(msg, tea.KeyLeft, tea.KeyRight); ok {
+		return v.Update(key)
+	}
+	if index, ok := v.timeline.TickAt(row, msg.X); ok && leftClick(msg) && index < len(v.frames) {
+		v.index = index
+		v.showFrame()
+	}
+	return v.model, nil
+}
+
+// exportRanges writes the ranges at the action shown for study tools, in
+// the background
+func (v *SpectatorView) exportRanges() tea.Cmd {
+	if v.replay == nil || len(v.frames) == 0 {
+		return nil
+	}
+	data := v.model.GetData()
+	replay, index, dir := v.replay, v.index, data.Path(data.GetSettings().ExportDir)
+	v.status = "Exporting ranges…"
+	_, cmd := RunAsync(func(ctx context.Context, report func(done, total int)) (string, error) {
+		return exportRanges(dir, replay, index)
+	}, nil, func(path string, err error) tea.Cmd {
+		v.status = "Ranges exported to " + path
+		if err != nil {
+			v.status = "Range export failed: " + err.Error()
+		}
+		return nil
+	})
+	return cmd
+}
+
+// copyHand copies the reviewed hand to the clipboard as a text hand
+// history, for sharing in chats and forums
+func (v *SpectatorView) copyHand() tea.Cmd {
+	if v.replay == nil {
+		return nil
+	}
+	replay, copy := v.replay, v.copy
+	v.status = "Copying the hand…"
+	_, cmd := RunAsync(func(ctx context.Context, report func(done, total int)) (string, error) {
+		text, err := handText(replay)
+		if err != nil {
+			return "", err
+		}
+		return copy(text)
+	}, nil, func(where string, err error) tea.Cmd {
+		v.status = "Hand history copied to " + where
+		if err != nil {
+			v.status = "Copying failed: " + err.Error()
+		}
+		return nil
+	})
+	return cmd
+}
+
+// Render renders the spectator view
+func (v *SpectatorView) Render(width, height int) string {
+	// Update component widths for current screen size
+	v.header.SetWidth(width)
+	v.helper.SetWidth(width)
+	v.table.SetWidth(width)
+	v.table.SetCardRenderer(v.model.Cards())
+	v.table.SetPlain(v.model.Accessible())
+	v.graph.SetPlain(v.model.Accessible())
+	v.timeline.SetWidth(width)
+	v.timeline.SetPlain(v.model.Accessible())
+
+	titleAtTop := v.header.Render()
+	helpAtBottom := v.helper.Render()
+
+	headerHeight := lipgloss.Height(titleAtTop)
+	helperHeight := lipgloss.Height(helpAtBottom)
+	availableHeight := height - headerHeight - helperHeight
+
+	content := v.table.Render()
+	switch {
+	case v.err != nil:
+		content = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F87171")). // Red
+			Render("Cannot review hand: " + v.err.Error())
+	case v.load != nil:
+		content = "Loading hand..."
+	case len(v.frames) > 0:
+		frame := v.frames[v.index]
+		status := fmt.Sprintf("Action %d/%d: %s %s",
+			v.index+1, len(v.frames), v.actorName(frame), holdem.ActionTypeToString(frame.Action.Action.Type))
+		if action := frame.Action.Action; action.Type == holdem.ActionRaise {
+			status += fmt.Sprintf(" to %d", action.RaiseTo)
+		} else if action.Amount > 0 {
+			status += fmt.Sprintf(" %d", action.Amount)
+		}
+		content = status + "\n" + v.timeline.Render() + "\n\n" + content
+		if graph := v.graph.Render(); graph != "" {
+			content += "\n\n" + graph
+		}
+		if v.spot != "" {
+			content += "\n\n" + lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#F59E0B")). // Amber
+				Width(min(width, 80)).
+				Render("Bookmarked decision: "+v.spot)
+		}
+		if v.status != "" {
+			content += "\n\n" + v.status
+		}
+	case v.sub == nil:
+		content = "Nothing to watch right now."
+	}
+
+	centeredContent := lipgloss.Place(
+		width, availableHeight,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+
+	fullContent := titleAtTop + centeredContent + helpAtBottom
+
+	fullScreenContainer := GetFullScreenStyle(width, height)
+	return fullScreenContainer.Render(fullContent)
+}
+
+// actorName returns the name of the player behind a frame's action, behind
+// their avatar
+func (v *SpectatorView) actorName(frame spectator.CommentaryFrame) string {
+	id := frame.Action.Action.PlayerID
+	if id == holdem.SystemPlayerID {
+		return "Dealer"
+	}
+	name := fmt.Sprintf("Player %d", id)
+	for _, seat := range frame.View.Seats {
+		if seat.PlayerID == id {
+			name = seat.Name
+		}
+	}
+	avatar, ok := v.avatars[id]
+	if !ok {
+		avatar = component.DefaultAvatar(id)
+	}
+	return avatar.Badge(name, v.model.Accessible())
+}
+
+// GetType returns the view type
+func (v *SpectatorView) GetType() ViewType {
+	return ViewSpectator
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view
+func (v *SpectatorView) ShortHelp() []key.Binding {
+	return v.keys.ShortHelp()
+}
+
+// FullHelp returns keybindings for the expanded help view
+func (v *SpectatorView) FullHelp() [][]key.Binding {
+	return v.keys.FullHelp()
+}
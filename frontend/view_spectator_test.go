@@ -0,0 +1,148 @@
+package frontend
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+)
+
+// savedFlopReplay saves a hand the human limped and saw a flop in, and
+// returns its path
+func savedFlopReplay(t *testing.T) string {
+	t.Helper()
+	game := holdem.NewGameWithConfig(holdem.GameConfig{SmallBlind: 5, BigBlind: 10, Seed: 3})
+	game.PlayerSit(holdem.NewPlayer(humanPlayerID, "Hero", 1000), 0)
+	game.PlayerSit(holdem.NewPlayer(2, "Bot", 1000), 1)
+	if err := game.DealHoleCards(); err != nil {
+		t.Fatal(err)
+	}
+	game.TakeAction(holdem.Action{PlayerID: humanPlayerID, Type: holdem.ActionCall, Amount: 10})
+	game.TakeAction(holdem.Action{PlayerID: 2, Type: holdem.ActionCheck})
+	if err := game.DealFlop(); err != nil {
+		t.Fatal(err)
+	}
+	replay, err := holdem.NewReplay(game, map[int]string{humanPlayerID: "human"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "hand.replay.json")
+	if err := replay.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSpectatorGraphsReviewedHand(t *testing.T) {
+	h := newTUIHarness(t, 100, 50)
+	h.Send(NavigateMsg{To: ViewSpectator, Params: SpectatorParams{ReplayFile: savedFlopReplay(t)}})
+	screen := h.WaitFor("Equity by street")
+	graph := strings.Fields(screen[strings.Index(screen, "Equity by street"):])
+	if !slices.Contains(graph, "preflop") || !slices.Contains(graph, "flop") {
+		t.Errorf("Expected the graph to show the preflop and the flop, got:\n%s", screen)
+	}
+}
+
+func TestSpectatorExportsRanges(t *testing.T) {
+	h := newTUIHarness(t, 100, 50)
+	dir := t.TempDir()
+	h.model.GetData().UpdateSetting("export_dir", dir)
+	h.Send(NavigateMsg{To: ViewSpectator, Params: SpectatorParams{ReplayFile: savedFlopReplay(t)}})
+	h.WaitFor("Equity by street")
+	h.Keys("G", "e")
+	h.WaitFor("Ranges exported to")
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "ranges-hand-*.txt"))
+	if len(paths) != 1 {
+		t.Fatalf("Expected one range file, got %v", paths)
+	}
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(data)
+	for _, want := range []string{"board ", "PioSolver, GTO Wizard, Hand2Note\nHero (hero): ", "GTO+, Flopzilla\nHero (hero): ", "\nBot: "} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in the export, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestSpectatorCopiesHandHistory(t *testing.T) {
+	h := newTUIHarness(t, 100, 50)
+	sv := h.model.spectatorView.(*SpectatorView)
+	copied := make(chan string, 1)
+	sv.copy = func(text string) (string, error) {
+		copied <- text
+		return copiedToTerminal, nil
+	}
+	h.Send(NavigateMsg{To: ViewSpectator, Params: SpectatorParams{ReplayFile: savedFlopReplay(t)}})
+	h.WaitFor("Equity by street")
+	h.Keys("c")
+	h.WaitFor("Hand history copied to the terminal's clipboard")
+
+	text := <-copied
+	for _, want := range []string{"Hold'em No Limit", "*** HOLE CARDS ***", "*** FLOP ***", "*** SUMMARY ***"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in the copied hand, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestSpectatorTimelineSeeks(t *testing.T) {
+	h := newTUIHarness(t, 100, 50)
+	h.Send(NavigateMsg{To: ViewSpectator, Params: SpectatorParams{ReplayFile: savedFlopReplay(t)}})
+	h.WaitFor("preflop ◆···  flop ·")
+
+	h.Keys("down")
+	h.WaitFor("Action 5/5")
+	h.Keys("up")
+	h.WaitFor("Action 1/5")
+
+	h.Click("flop ·")
+	h.WaitFor("preflop ●●●●  flop ◆")
+	h.Click("●  flop") // The last preflop action
+	h.WaitFor("Action 4/5")
+}
+
+func TestWatchedBotsKeepTheirCardsHidden(t *testing.T) {
+	model := newTestModel(t, map[string]any{"game_speed": "fast"})
+	feed, stop := watchBots(model.GetData().GetSettings())
+	defer stop()
+
+	for i := 0; i < 5; i++ {
+		select {
+		case view, ok := <-feed.Views():
+			if !ok {
+				t.Fatal("Expected the table to keep playing")
+			}
+			if len(view.Seats) != 4 {
+				t.Fatalf("Expected the three bots of the setup and one in the human's seat, got %d seats", len(view.Seats))
+			}
+			for _, seat := range view.Seats {
+				if len(seat.HoleCards) > 0 && view.Phase != holdem.PhaseShowdown && !view.Runout {
+					t.Errorf("Seat %d's cards are visible on the %v", seat.Seat, view.Phase)
+				}
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("Timed out waiting for the table")
+		}
+	}
+
+	stop()
+	deadline := time.After(15 * time.Second)
+	for {
+		select {
+		case _, ok := <-feed.Views():
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("Expected the feed to close once the table stopped")
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package frontend
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// StatsKeyMap defines keybindings for the play statistics
+type StatsKeyMap struct {
+	Period key.Binding
+	Reset  key.Binding
+	Back   key.Binding
+	Quit   key.Binding
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view.
+func (k StatsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Period, k.Reset, k.Back, k.Quit}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (k StatsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Period, k.Reset},
+		{k.Back, k.Quit},
+	}
+}
+
+var statsKeys = StatsKeyMap{
+	Period: key.NewBinding(
+		key.WithKeys("tab", "left", "right"),
+		key.WithHelp("tab", "days/weeks"),
+	),
+	Reset: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "reset"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// How far back the stats view lists play
+const (
+	statsDays  = 14
+	statsWeeks = 8
+)
+
+// statsBarWidth is the width of the longest hands bar
+const statsBarWidth = 20
+
+// StatsView shows how much the human plays: today, this week, streaks of
+// days in a row, hands and results day by day or week by week, and how
+// every player's chips went in the last game
+type StatsView struct {
+	model      *Model
+	keys       StatsKeyMap
+	days       map[string]PlayDay
+	last       *LastSession // Winnings of the last game played, nil before the first
+	weekly     bool         // Weeks listed rather than days
+	confirming bool         // Reset asked for, waiting for confirmation
+	status     string
+	now        func() time.Time
+
+	// Components
+	header *component.HeaderComponent
+	helper *component.HelperComponent
+	won    *component.WinningsGraphComponent
+}
+
+// NewStatsView creates a new play statistics view
+func NewStatsView(model *Model) *StatsView {
+	return &StatsView{
+		model: model,
+		keys:  statsKeys,
+		now:   time.Now,
+
+		// Initialize components with default width (will be updated in Render)
+		header: component.NewHeaderComponent("📅 Play Stats", 80),
+		helper: component.NewHelperComponent(statsKeys, 80),
+		won:    component.NewWinningsGraphComponent(80),
+	}
+}
+
+// OnEnter loads the play log and the last game
+func (v *StatsView) OnEnter(params any) tea.Cmd {
+	v.confirming, v.status = false, ""
+	v.days = v.model.GetData().GetPlayLog()
+	v.last = v.model.GetData().GetLastSession()
+	return nil
+}
+
+// OnExit drops a reset left unconfirmed
+func (v *StatsView) OnExit() {
+	v.confirming = false
+}
+
+// DataChanged reloads the play log when a game elsewhere records hands,
+// and the last game when one ends
+func (v *StatsView) DataChanged(key string) {
+	switch key {
+	case playLogKey:
+		v.days = v.model.GetData().GetPlayLog()
+	case lastSessionKey:
+		v.last = v.model.GetData().GetLastSession()
+	}
+}
+
+// Update handles input for the play statistics
+func (v *StatsView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if v.confirming {
+		v.confirming = false
+		if key.Matches(msg, confirmResetKey) {
+			v.model.GetData().ResetPlayLog()
+			v.days, v.last, v.status = map[string]PlayDay{}, nil, "Play statistics reset"
+		} else {
+			v.status = "Reset cancelled"
+		}
+		return v.model, nil
+	}
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		return v.model, Navigate(ViewIndex, nil)
+	case key.Matches(msg, v.keys.Quit):
+		return v.model, tea.Quit
+	case key.Matches(msg, v.keys.Period):
+		v.weekly = !v.weekly
+	case key.Matches(msg, v.keys.Reset):
+		if len(v.days) > 0 {
+			v.confirming = true
+			v.status = "Forget every day played? Press y to confirm, any other key to keep them"
+		}
+	}
+	return v.model, nil
+}
+
+// Render renders the play statistics
+func (v *StatsView) Render(width, height int) string {
+	// Update component widths for current screen size
+	v.header.SetWidth(width)
+	v.helper.SetWidth(width)
+
+	titleAtTop := v.header.Render()
+	helpAtBottom := v.helper.Render()
+	availableHeight := height - lipgloss.Height(titleAtTop) - lipgloss.Height(helpAtBottom)
+
+	gray := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	sections := []string{}
+	if len(v.days) == 0 {
+		sections = append(sections, gray.Render("No hands played yet. Every hand you play at a cash table or a sit & go counts."))
+	} else {
+		period := "Last 14 days"
+		if v.weekly {
+			period = "Last 8 weeks"
+		}
+		sections = append(sections, v.renderSummary(), gray.Render(fmt.Sprintf("◀ %s ▶", period)), v.renderPeriods())
+	}
+	if v.last != nil {
+		sections = append(sections, v.renderLastSession(width))
+	}
+	if v.status != "" {
+		color := lipgloss.Color("#9CA3AF")
+		if v.confirming {
+			color = lipgloss.Color("#F59E0B") // Amber
+		}
+		sections = append(sections, lipgloss.NewStyle().Foreground(color).Render(v.status))
+	}
+	content := lipgloss.NewStyle().
+		Width(width).
+		Align(lipgloss.Center).
+		Render(strings.Join(sections, "\n\n"))
+
+	// Center the statistics in the middle of available space
+	centeredContent := lipgloss.Place(
+		width, availableHeight,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+
+	// Apply full screen style
+	fullScreenContainer := GetFullScreenStyle(width, height)
+	return fullScreenContainer.Render(titleAtTop + centeredContent + helpAtBottom)
+}
+
+// renderSummary shows today, this week, the streaks and the daily limit
+func (v *StatsView) renderSummary() string {
+	stats := summarizePlay(v.days, v.now())
+	label := lipgloss.NewStyle().Bold(true).Width(11)
+	lines := []string{
+		label.Render("Today") + v.playLine(stats.Today),
+		label.Render("This week") + v.playLine(stats.Week),
+		label.Render("Streak") + fmt.Sprintf("%s in a row · best %s", plural(stats.Streak, "day"), plural(stats.BestStreak, "day")),
+		label.Render("Overall") + fmt.Sprintf("%s over %s · %.0f a day · net %s",
+			plural(stats.Hands, "hand"), plural(stats.DaysPlayed, "day"), stats.HandsPerDay, v.net(stats.Net)),
+	}
+	if limit := v.model.GetData().GetSettings().DailyHandLimit; limit > 0 {
+		left := "reached"
+		if stats.Today.Hands < limit {
+			left = fmt.Sprintf("%d left today", limit-stats.Today.Hands)
+		}
+		lines = append(lines, label.Render("Daily limit")+fmt.Sprintf("%s · %s", plural(limit, "hand"), left))
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#6B7280")). // Gray
+		Padding(0, 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+// playLine describes a day's or a week's play, e.g. "120 hands · net +350 · 1h 05m"
+func (v *StatsView) playLine(day PlayDay) string {
+	return fmt.Sprintf("%s · net %s · %s", plural(day.Hands, "hand"), v.net(day.Net), playedTime(day.Seconds))
+}
+
+// renderPeriods lists the recent days or weeks, newest first, with a bar
+// of the hands played
+func (v *StatsView) renderPeriods() string {
+	periods := recentDays(v.days, v.now(), statsDays)
+	if v.weekly {
+		periods = recentWeeks(v.days, v.now(), statsWeeks)
+	}
+	most := 1
+	for _, period := range periods {
+		most = max(most, period.Hands)
+	}
+	lines := []string{lipgloss.NewStyle().Bold(true).
+		Render(fmt.Sprintf("%-10s %6s %8s %7s  %-*s", v.periodHeading(), "Hands", "Net", "Time", statsBarWidth, ""))}
+	bar := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED")) // Purple
+	for _, period := range periods {
+		date := period.Start.Format("Mon Jan 2")
+		if v.weekly {
+			date = period.Start.Format("Jan 2")
+		}
+		filled := (period.Hands*statsBarWidth + most - 1) / most
+		lines = append(lines, fmt.Sprintf("%-10s %6d %8s %7s  %s", date, period.Hands, v.net(period.Net), playedTime(period.Seconds),
+			bar.Render(strings.Repeat("█", filled))+strings.Repeat(" ", statsBarWidth-filled)))
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#6B7280")). // Gray
+		Padding(0, 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderLastSession graphs every player's winnings in the last game
+func (v *StatsView) renderLastSession(width int) string {
+	v.won.SetLines(v.last.lines())
+	v.won.SetWidth(min(width-6, 80))
+	v.won.SetPlain(v.model.Accessible())
+	v.won.SetChipFormatter(v.model.Chips())
+	v.won.SetMasked(v.model.GetData().GetSettings().StreamerMode)
+	title := lipgloss.NewStyle().Bold(true).Render("Last game · " + v.last.Ended.In(v.now().Location()).Format("Mon Jan 2 15:04"))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#6B7280")). // Gray
+		Padding(0, 2).
+		Render(title + "\n" + v.won.Render())
+}
+
+// periodHeading names the first column
+func (v *StatsView) periodHeading() string {
+	if v.weekly {
+		return "Week of"
+	}
+	return "Day"
+}
+
+// net writes a result with its sign, masked in streamer mode like the
+// leaderboard's bankrolls
+func (v *StatsView) net(chips int) string {
+	if v.model.GetData().GetSettings().StreamerMode {
+		return "•••"
+	}
+	return fmt.Sprintf("%+d", chips)
+}
+
+// GetType returns the view type
+func (v *StatsView) GetType() ViewType {
+	return ViewStats
+}
+
+// playedTime writes seconds played as hours and minutes, e.g. "1h 05m"
+func playedTime(seconds int) string {
+	d := time.Duration(seconds) * time.Second
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// plural counts things, e.g. "1 day" or "3 days"
+func plural(count int, thing string) string {
+	if count == 1 {
+		return "1 " + thing
+	}
+	return fmt.Sprintf("%d %ss", count, thing)
+}
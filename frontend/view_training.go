@@ -0,0 +1,344 @@
+package frontend
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ljbink/ai-poker/engine/simulator"
+	"github.com/ljbink/ai-poker/engine/training"
+	"github.com/ljbink/ai-poker/frontend/component"
+)
+
+// quizMode picks the kind of each question
+type quizMode int
+
+const (
+	quizMixed   quizMode = iota // Every kind in turn
+	quizWeakest                 // The kind answered least accurately
+	quizPotOdds
+	quizOuts
+	quizAction
+	quizModes
+)
+
+func (m quizMode) String() string {
+	switch m {
+	case quizMixed:
+		return "Mixed"
+	case quizWeakest:
+		return "Weakest First"
+	case quizPotOdds:
+		return training.QuestionPotOdds.String()
+	case quizOuts:
+		return training.QuestionOuts.String()
+	case quizAction:
+		return training.QuestionAction.String()
+	default:
+		return "Unknown"
+	}
+}
+
+// TrainingKeyMap defines keybindings for the odds quiz
+type TrainingKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Answer key.Binding
+	Mode   key.Binding
+	Back   key.Binding
+	Quit   key.Binding
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view.
+func (k TrainingKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Answer, k.Mode, k.Back, k.Quit}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (k TrainingKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Answer},
+		{k.Mode},
+		{k.Back, k.Quit},
+	}
+}
+
+var trainingKeys = TrainingKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"),
+	),
+	Answer: key.NewBinding(
+		key.WithKeys("enter", " "),
+		key.WithHelp("enter", "answer / next"),
+	),
+	Mode: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "question type"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// TrainingView quizzes the player on pot odds, outs and call-or-fold
+// decisions in random spots, keeping score in the player's profile
+type TrainingView struct {
+	model    *Model
+	keys     TrainingKeyMap
+	rng      *rand.Rand
+	mode     quizMode
+	asked    int // Questions asked, to rotate kinds in mixed mode
+	question *training.Question
+	selected int
+	answered bool
+	correct  bool
+	session  training.Progress // This visit's answers, also kept when not logged in
+	err      string
+
+	// Components
+	header *component.HeaderComponent
+	helper *component.HelperComponent
+}
+
+// NewTrainingView creates a new odds quiz view
+func NewTrainingView(model *Model) *TrainingView {
+	return &TrainingView{
+		model: model,
+		keys:  trainingKeys,
+		rng:   rand.New(rand.NewSource(time.Now().UnixNano())),
+
+		// Initialize components with default width (will be updated in Render)
+		header: component.NewHeaderComponent("🎯 Odds Quiz", 80),
+		helper: component.NewHelperComponent(trainingKeys, 80),
+	}
+}
+
+// Start deals the first spot unless a question is already waiting
+func (v *TrainingView) Start() {
+	if v.question == nil {
+		v.nextQuestion()
+	}
+}
+
+// nextQuestion deals a new spot and asks about it
+func (v *TrainingView) nextQuestion() {
+	v.question, v.selected, v.answered, v.err = nil, 0, false, ""
+	spot, err := simulator.RandomSpot(v.rng)
+	if err != nil {
+		v.err = err.Error()
+		return
+	}
+	question, err := training.NewQuestion(v.questionKind(), spot, v.rng)
+	if err != nil {
+		v.err = err.Error()
+		return
+	}
+	v.question = question
+	v.asked++
+}
+
+// questionKind picks the kind of the next question for the current mode
+func (v *TrainingView) questionKind() training.QuestionKind {
+	switch v.mode {
+	case quizWeakest:
+		progress := v.session
+		if user := v.model.GetData().GetUser(); user != nil {
+			progress = user.Training
+		}
+		return progress.Weakest()
+	case quizPotOdds:
+		return training.QuestionPotOdds
+	case quizOuts:
+		return training.QuestionOuts
+	case quizAction:
+		return training.QuestionAction
+	default:
+		return training.QuestionKinds[v.asked%len(training.QuestionKinds)]
+	}
+}
+
+// OnEnter asks the first question, or returns to the one left unanswered
+func (v *TrainingView) OnEnter(params any) tea.Cmd {
+	v.Start()
+	return nil
+}
+
+// OnExit keeps the question for when the player comes back
+func (v *TrainingView) OnExit() {}
+
+// Update handles input for the odds quiz
+func (v *TrainingView) Update(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		return v.model, Navigate(ViewIndex, nil)
+	case key.Matches(msg, v.keys.Quit):
+		return v.model, tea.Quit
+	case key.Matches(msg, v.keys.Mode):
+		v.mode = (v.mode + 1) % quizModes
+		v.nextQuestion()
+	case v.question == nil:
+		v.nextQuestion()
+	case key.Matches(msg, v.keys.Up) && !v.answered:
+		v.selected = (v.selected + len(v.question.Choices) - 1) % len(v.question.Choices)
+	case key.Matches(msg, v.keys.Down) && !v.answered:
+		v.selected = (v.selected + 1) % len(v.question.Choices)
+	case key.Matches(msg, v.keys.Answer):
+		if v.answered {
+			v.nextQuestion()
+			break
+		}
+		v.answered = true
+		v.correct = v.question.Check(v.selected)
+		v.session.Record(v.question.Kind, v.correct)
+		v.model.GetData().RecordQuizAnswer(v.question.Kind, v.correct)
+	}
+	return v.model, nil
+}
+
+// Render renders the odds quiz
+func (v *TrainingView) Render(width, height int) string {
+	// Update component widths for current screen size
+	v.header.SetWidth(width)
+	v.helper.SetWidth(width)
+
+	sections := []string{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).
+			Render(fmt.Sprintf("Question type: ◀ %s ▶", v.mode)),
+	}
+	switch {
+	case v.err != "":
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#EF4444")). // Red
+			Render("✗ "+v.err))
+	case v.question != nil:
+		sections = append(sections, v.renderSpot(), v.renderQuestion())
+	}
+	sections = append(sections, v.renderScore())
+
+	content := lipgloss.NewStyle().
+		Width(width).
+		Align(lipgloss.Center).
+		Render(strings.Join(sections, "\n\n"))
+
+	// Title at the top using header component
+	titleAtTop := v.header.Render()
+
+	// Help view at the bottom using helper component
+	helpAtBottom := v.helper.Render()
+
+	// Calculate actual space used by header and helper
+	headerHeight := lipgloss.Height(titleAtTop)
+	helperHeight := lipgloss.Height(helpAtBottom)
+	availableHeight := height - headerHeight - helperHeight
+
+	// Center the quiz in the middle of available space
+	centeredContent := lipgloss.Place(
+		width, availableHeight,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+
+	// Combine title, content, and help without extra spacing
+	fullContent := titleAtTop + centeredContent + helpAtBottom
+
+	// Apply full screen style
+	fullScreenContainer := GetFullScreenStyle(width, height)
+	return fullScreenContainer.Render(fullContent)
+}
+
+// renderSpot shows both hands face up, the board and the betting
+func (v *TrainingView) renderSpot() string {
+	spot := v.question.Spot
+	lines := []string{
+		"Board:   " + component.RenderCards(v.model.Cards(), spot.Board),
+		"You:     " + component.RenderCards(v.model.Cards(), spot.Hero),
+		"Villain: " + component.RenderCards(v.model.Cards(), spot.Villain),
+		fmt.Sprintf("Pot %d · Villain bets %d", spot.Pot, spot.Bet),
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#6B7280")). // Gray
+		Padding(0, 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderQuestion shows the prompt, the choices and, once answered, the explanation
+func (v *TrainingView) renderQuestion() string {
+	q := v.question
+	lines := []string{lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F3F4F6")).Render(q.Prompt), ""}
+	for i, choice := range q.Choices {
+		style := itemStyle
+		prefix := "  "
+		switch {
+		case v.answered && i == q.Answer:
+			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Bold(true) // Green
+			prefix = "✓ "
+		case v.answered && i == v.selected:
+			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")) // Red
+			prefix = "✗ "
+		case !v.answered && i == v.selected:
+			style = selectedItemStyle
+			prefix = "▶ "
+		}
+		lines = append(lines, style.Render(prefix+choice+" "))
+	}
+	if v.answered {
+		verdict := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Render("Correct!")
+		if !v.correct {
+			verdict = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render("Not quite.")
+		}
+		lines = append(lines, "", verdict+" "+q.Explanation)
+	}
+	return lipgloss.NewStyle().Width(70).Render(strings.Join(lines, "\n"))
+}
+
+// renderScore shows this visit's score and the saved progress
+func (v *TrainingView) renderScore() string {
+	line := fmt.Sprintf("This session: %d/%d · streak %d", v.session.Total.Correct, v.session.Total.Answered, v.session.Streak)
+	if user := v.model.GetData().GetUser(); user != nil {
+		progress := user.Training
+		line += fmt.Sprintf("\n%s: %d/%d (%.0f%%) · best streak %d",
+			user.Name, progress.Total.Correct, progress.Total.Answered, progress.Total.Accuracy()*100, progress.BestStreak)
+		parts := []string{}
+		for _, kind := range training.QuestionKinds {
+			if score := progress.ByKind[kind]; score.Answered > 0 {
+				parts = append(parts, fmt.Sprintf("%s %.0f%%", kind, score.Accuracy()*100))
+			}
+		}
+		if len(parts) > 0 {
+			line += "\n" + strings.Join(parts, " · ")
+		}
+	} else {
+		line += "\nStart a game with your name to keep progress in your profile"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(line)
+}
+
+// GetType returns the view type
+func (v *TrainingView) GetType() ViewType {
+	return ViewTraining
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view
+func (v *TrainingView) ShortHelp() []key.Binding {
+	return v.keys.ShortHelp()
+}
+
+// FullHelp returns keybindings for the expanded help view
+func (v *TrainingView) FullHelp() [][]key.Binding {
+	return v.keys.FullHelp()
+}
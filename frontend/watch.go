@@ -0,0 +1,70 @@
+package frontend
+
+import (
+	"context"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/session"
+	"github.com/ljbink/ai-poker/engine/spectator"
+)
+
+// spectatorDelay holds a watched table back, as broadcasts of real games
+// are, so nobody watching can tell a player what the others did
+const spectatorDelay = 2 * time.Second
+
+// watchBots plays a cash game between the game setup's bots, plus the bot
+// taking the human's seat, in the background and returns a redacted feed of
+// it and the function that stops it. The feed closes when the table stops
+// or fewer than two bots are left. The table plays at the settings' game
+// speed, normal when that is instant, which is too fast to follow.
+func watchBots(settings *SettingsData) (*spectator.Subscription, func()) {
+	speed := speedNamed(settings.GameSpeed)
+	if speed.hand == 0 {
+		speed = gameSpeeds["normal"]
+	}
+	config := cashGameConfig(settings)
+	config.Seed = time.Now().UnixNano()
+	game := holdem.NewGameWithConfig(config)
+	s := session.New(game)
+
+	presets := holdem_ai.BotNames()
+	for i := 0; i < min(settings.NumBots+1, config.Seats()); i++ {
+		maker, err := holdem_ai.CreateBotByName(presets[i%len(presets)])
+		if err != nil {
+			continue
+		}
+		if bot, ok := holdem_ai.As[*holdem_ai.BasicBotDecisionMaker](maker); ok {
+			bot.SetThinkingTime(speed.botMin, speed.botMax)
+		}
+		if err := s.SitDown(holdem.NewPlayer(i+1, presets[i%len(presets)], clampBuyIn(config, settings.DefaultBuyIn)), i); err != nil {
+			continue
+		}
+		s.SetDecisionMaker(i+1, maker)
+	}
+
+	broadcaster := spectator.NewBroadcaster()
+	feed := broadcaster.Subscribe(spectator.ModeRedacted, spectatorDelay)
+	s.SetObserver(func(session.Event, *holdem.Game) { broadcaster.Publish(game) })
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer broadcaster.Close()
+		if err := s.Warmup(ctx, nil); err != nil {
+			return
+		}
+		for len(game.GetAllPlayers()) >= 2 {
+			if _, err := s.PlayHand(ctx); err != nil {
+				return
+			}
+			s.RemoveBusted()
+			broadcaster.Publish(game)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(speed.hand):
+			}
+		}
+	}()
+	return feed, cancel
+}
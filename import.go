@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/ljbink/ai-poker/engine/handhistory"
+)
+
+// handsDir is the directory under the data directory keeping imported hands
+const handsDir = "hands"
+
+// runImport implements "ai-poker import [flags] files...": it merges
+// PokerStars or PHH hand histories or saved replays into the local hand
+// database, skipping hands it already holds however they were exported,
+// and prints what became of each file's hands
+func runImport(args []string, out io.Writer, dataDir string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(out)
+	dir := flags.String("db", filepath.Join(dataDir, handsDir), "directory of the hand database")
+	flags.Usage = func() {
+		fmt.Fprintln(out, "Usage: ai-poker import [flags] history-files...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("no hand history files given")
+	}
+
+	db, err := handhistory.OpenDatabase(*dir)
+	if err != nil {
+		return err
+	}
+	total := handhistory.MergeReport{}
+	for _, path := range flags.Args() {
+		hands, err := handhistory.ParseFile(path)
+		if err != nil {
+			return err
+		}
+		report, err := db.Merge(hands)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fmt.Fprintf(out, "%s: %d hands, %s\n", path, len(hands), describeMerge(report))
+		total.Add(report)
+	}
+	fmt.Fprintf(out, "\nMerged: %s\n", describeMerge(total))
+	if len(total.Conflicts) > 0 {
+		fmt.Fprintf(out, "Conflicts, stored under the same ID but played differently: %s\n", strings.Join(total.Conflicts, ", "))
+	}
+	fmt.Fprintf(out, "%d hands in %s\n", db.Len(), *dir)
+	return nil
+}
+
+// describeMerge sums up a merge report in one line
+func describeMerge(report handhistory.MergeReport) string {
+	return fmt.Sprintf("%d added, %d skipped (%d duplicates, %d filled in, %d conflicts)",
+		report.Added, report.Skipped(), report.Duplicates, report.Updated, len(report.Conflicts))
+}
@@ -0,0 +1,53 @@
+// Package combin counts and lists the k-element combinations of n items,
+// for enumerating board runouts and five-card hands. It is internal to the
+// module; the engine packages built on it are the supported API.
+package combin
+
+import "math"
+
+// Binomial returns n choose k, 0 when k is out of range. Results too big
+// for an int saturate at math.MaxInt, so it is safe for deciding whether a
+// count is small enough to enumerate.
+func Binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	k = min(k, n-k)
+	result := 1
+	for i := 1; i <= k; i++ {
+		factor := n - k + i
+		if result > math.MaxInt/factor {
+			return math.MaxInt
+		}
+		// result*factor is divisible by i: it is (n-k+i choose i) times i
+		result = result * factor / i
+	}
+	return result
+}
+
+// Combinations calls visit with the indices of every k-element combination
+// of n items, in lexicographic order. The slice is reused between calls,
+// so visit must copy it to keep it.
+func Combinations(n, k int, visit func(indices []int)) {
+	if k < 0 || k > n {
+		return
+	}
+	indices := make([]int, k)
+	for i := range indices {
+		indices[i] = i
+	}
+	for {
+		visit(indices)
+		i := k - 1
+		for i >= 0 && indices[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		indices[i]++
+		for j := i + 1; j < k; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+}
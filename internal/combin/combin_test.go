@@ -0,0 +1,48 @@
+package combin
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestBinomial(t *testing.T) {
+	tests := []struct {
+		n, k, expected int
+	}{
+		{52, 5, 2598960},
+		{48, 5, 1712304},
+		{7, 0, 1},
+		{7, 7, 1},
+		{5, 6, 0},
+		{5, -1, 0},
+		{200, 100, math.MaxInt},
+	}
+	for _, tt := range tests {
+		if got := Binomial(tt.n, tt.k); got != tt.expected {
+			t.Errorf("Binomial(%d, %d) = %d, expected %d", tt.n, tt.k, got, tt.expected)
+		}
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	var got [][]int
+	Combinations(4, 2, func(indices []int) {
+		got = append(got, append([]int{}, indices...))
+	})
+	expected := [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	count := 0
+	Combinations(7, 5, func([]int) { count++ })
+	if count != Binomial(7, 5) {
+		t.Errorf("Expected %d five-card hands out of seven cards, got %d", Binomial(7, 5), count)
+	}
+	Combinations(3, 0, func(indices []int) { count = len(indices) })
+	if count != 0 {
+		t.Errorf("Expected the empty combination once, got %d indices", count)
+	}
+	Combinations(2, 3, func([]int) { t.Error("Expected no combinations of 3 out of 2") })
+}
@@ -0,0 +1,231 @@
+// Package nash solves push/fold preflop games by fictitious play over a
+// sampled equity matrix of the starting hand classes. It builds the lookup
+// tables engine/pushfold ships, see its gen.go, and is internal to the
+// module: the solved tables are the supported API.
+package nash
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/ljbink/ai-poker/engine/charts"
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/ranges"
+)
+
+// Hands is the number of starting hand classes
+const Hands = 169
+
+// Matrix holds the preflop all-in equity of every starting hand class
+// against every other, indexed in grid order (see HandIndex)
+type Matrix struct {
+	Equity [Hands][Hands]float64 // Share of the pot the first hand wins
+	Combos [Hands][Hands]float64 // Pairs of combos the two hands can be dealt as
+}
+
+// HandIndex returns the grid order index of a hand class such as "AKs",
+// -1 if it is not one
+func HandIndex(hand string) int {
+	row, col := ranges.Position(hand)
+	if row < 0 {
+		return -1
+	}
+	return row*len(ranges.Ranks) + col
+}
+
+// HandClass returns the hand class at a grid order index
+func HandClass(i int) string {
+	return ranges.GridHand(i/len(ranges.Ranks), i%len(ranges.Ranks))
+}
+
+// NewMatrix samples the equity of every pair of hand classes over the
+// given number of runouts, dealing each pair as combos without shared cards
+func NewMatrix(samples int, seed int64) *Matrix {
+	combos := make([][]poker.Cards, Hands)
+	for i := range combos {
+		r := ranges.NewRange()
+		r.Set(HandClass(i), 1)
+		for _, combo := range r.Expand(nil) {
+			combos[i] = append(combos[i], combo.Cards)
+		}
+	}
+	disjoint := func(a, b poker.Cards) bool {
+		for _, x := range a {
+			for _, y := range b {
+				if *x == *y {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	m := &Matrix{}
+	rng := rand.New(rand.NewSource(seed))
+	evaluator := holdem.NewHandEvaluator()
+	deck := newDeck()
+	for i := 0; i < Hands; i++ {
+		for j := i; j < Hands; j++ {
+			pairs := [][2]poker.Cards{}
+			for _, a := range combos[i] {
+				for _, b := range combos[j] {
+					if disjoint(a, b) {
+						pairs = append(pairs, [2]poker.Cards{a, b})
+					}
+				}
+			}
+			if i == j {
+				// Both orders of a pair of combos are counted
+				m.Combos[i][j] = float64(len(pairs))
+			} else {
+				m.Combos[i][j], m.Combos[j][i] = float64(len(pairs)), float64(len(pairs))
+			}
+			won := 0.0
+			for s := 0; s < samples; s++ {
+				pair := pairs[rng.Intn(len(pairs))]
+				board := dealBoard(deck, pair, rng)
+				switch evaluator.CompareHands(evaluator.EvaluateHand(pair[0], board), evaluator.EvaluateHand(pair[1], board)) {
+				case 1:
+					won++
+				case 0:
+					won += 0.5
+				}
+			}
+			m.Equity[i][j] = won / float64(samples)
+			m.Equity[j][i] = 1 - m.Equity[i][j]
+		}
+	}
+	return m
+}
+
+// newDeck returns the 52 cards of a standard deck
+func newDeck() poker.Cards {
+	deck := poker.Cards{}
+	for _, card := range poker.NewDeckCards() {
+		if card.Suit != poker.SuitNone {
+			deck = append(deck, card)
+		}
+	}
+	return deck
+}
+
+// dealBoard deals five board cards from the deck, leaving out the hole cards
+func dealBoard(deck poker.Cards, hole [2]poker.Cards, rng *rand.Rand) poker.Cards {
+	used := map[poker.Card]bool{*hole[0][0]: true, *hole[0][1]: true, *hole[1][0]: true, *hole[1][1]: true}
+	board := make(poker.Cards, 0, 5)
+	for len(board) < 5 {
+		card := deck[rng.Intn(len(deck))]
+		if !used[*card] {
+			used[*card] = true
+			board = append(board, card)
+		}
+	}
+	return board
+}
+
+// blinds are what each position has posted before the shove, in big blinds
+var blinds = map[charts.Position]float64{charts.PositionSB: 0.5, charts.PositionBB: 1}
+
+// behind lists the players left to act after a shove from each position at
+// a six-handed table, in order
+var behind = map[charts.Position][]charts.Position{
+	charts.PositionUTG: {charts.PositionHJ, charts.PositionCO, charts.PositionBTN, charts.PositionSB, charts.PositionBB},
+	charts.PositionHJ:  {charts.PositionCO, charts.PositionBTN, charts.PositionSB, charts.PositionBB},
+	charts.PositionCO:  {charts.PositionBTN, charts.PositionSB, charts.PositionBB},
+	charts.PositionBTN: {charts.PositionSB, charts.PositionBB},
+	charts.PositionSB:  {charts.PositionBB},
+}
+
+// Strategy is how often each hand class, in grid order, shoves from a
+// position and how often the big blind calls it
+type Strategy struct {
+	Shove [Hands]float64
+	Call  [Hands]float64 // The big blind's calls, the last player to act
+}
+
+// Solve approximates the push/fold equilibrium for the first player in
+// to shove stackBB big blinds from a position, by fictitious play over the
+// given number of iterations. Every player behind calls or folds; at most
+// one of them calls, and the blinds are the only dead money. Heads-up this
+// is the exact small blind against big blind game.
+func Solve(m *Matrix, position charts.Position, stackBB float64, iterations int) (*Strategy, error) {
+	callers, ok := behind[position]
+	if !ok {
+		return nil, fmt.Errorf("no player shoves first in from %s", position)
+	}
+	if stackBB < 1 {
+		return nil, fmt.Errorf("stack of %g big blinds is below the big blind", stackBB)
+	}
+	const dead = 1.5 // Both blinds
+	own := blinds[position]
+	// pot is what the shover and a caller play for: both stacks, which
+	// include what they posted, and the other blinds
+	pot := func(caller charts.Position) float64 {
+		return 2*stackBB + dead - own - blinds[caller]
+	}
+	shove := make([]float64, Hands)
+	calls := make([][]float64, len(callers))
+	for i := range shove {
+		shove[i] = 1
+	}
+	for c := range calls {
+		calls[c] = make([]float64, Hands)
+		for j := range calls[c] {
+			calls[c][j] = 1
+		}
+	}
+
+	for t := 1; t <= iterations; t++ {
+		step := 1 / float64(t+1)
+		// The shover's best response to the average calls
+		shoveBR := make([]float64, Hands)
+		for i := 0; i < Hands; i++ {
+			reached, ev := 1.0, 0.0
+			for c, caller := range callers {
+				total, called, won := 0.0, 0.0, 0.0
+				for j := 0; j < Hands; j++ {
+					w := m.Combos[i][j]
+					total += w
+					called += w * calls[c][j]
+					won += w * calls[c][j] * m.Equity[i][j]
+				}
+				if total == 0 || called == 0 {
+					continue
+				}
+				share := called / total
+				ev += reached * share * (won/called*pot(caller) - stackBB)
+				reached *= 1 - share
+			}
+			// Everyone folding leaves the shover the other blinds
+			ev += reached * (dead - own)
+			if ev > -own {
+				shoveBR[i] = 1
+			}
+		}
+		// Each caller's best response to the average shoves
+		for c, caller := range callers {
+			for j := 0; j < Hands; j++ {
+				total, won := 0.0, 0.0
+				for i := 0; i < Hands; i++ {
+					w := m.Combos[j][i] * shove[i]
+					total += w
+					won += w * m.Equity[j][i]
+				}
+				br := 0.0
+				if total > 0 && won/total*pot(caller)-stackBB > -blinds[caller] {
+					br = 1
+				}
+				calls[c][j] += step * (br - calls[c][j])
+			}
+		}
+		for i := range shove {
+			shove[i] += step * (shoveBR[i] - shove[i])
+		}
+	}
+
+	strategy := &Strategy{}
+	copy(strategy.Shove[:], shove)
+	copy(strategy.Call[:], calls[len(calls)-1])
+	return strategy, nil
+}
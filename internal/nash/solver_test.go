@@ -0,0 +1,28 @@
+package nash
+
+import (
+	"testing"
+
+	"github.com/ljbink/ai-poker/engine/charts"
+)
+
+func TestSolveHeadsUp(t *testing.T) {
+	m := NewMatrix(50, 1)
+	if m.Combos[HandIndex("AA")][HandIndex("AA")] != 6 || m.Combos[HandIndex("AKs")][HandIndex("72o")] != 48 {
+		t.Errorf("Expected 6 AA vs AA and 48 AKs vs 72o combos, got %g and %g",
+			m.Combos[HandIndex("AA")][HandIndex("AA")], m.Combos[HandIndex("AKs")][HandIndex("72o")])
+	}
+	strategy, err := Solve(m, charts.PositionSB, 10, 50)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strategy.Shove[HandIndex("AA")] < 0.9 || strategy.Call[HandIndex("AA")] < 0.9 {
+		t.Errorf("Expected aces to shove and call, got %.2f and %.2f", strategy.Shove[HandIndex("AA")], strategy.Call[HandIndex("AA")])
+	}
+	if strategy.Call[HandIndex("72o")] > 0.1 {
+		t.Errorf("Expected 72o to fold to a 10 big blind shove, got %.2f", strategy.Call[HandIndex("72o")])
+	}
+	if _, err := Solve(m, charts.PositionBB, 10, 1); err == nil {
+		t.Error("Expected an error for a shove from the big blind")
+	}
+}
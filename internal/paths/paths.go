@@ -0,0 +1,186 @@
+// Package paths works out where the application keeps its files: the
+// profile and settings in the config directory, and the replays, logs and
+// exports in the data directory, both in the places the operating system
+// expects unless overridden. It is internal to the module.
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+// EnvHome overrides the config and data directories with a single one
+const EnvHome = "AI_POKER_HOME"
+
+// App names the application's directories under the OS ones
+const App = "ai-poker"
+
+// DataFileName is the file keeping the player profile and settings
+const DataFileName = "ai-poker.json"
+
+// legacyFiles are the files earlier versions kept in the working directory,
+// by the directory they now belong in
+var legacyFiles = []struct {
+	name   string
+	config bool
+}{
+	{DataFileName, true},
+	{"last_hand.replay.json", false},
+	{"debug.log", false},
+	{"exports", false},
+}
+
+// Dirs are the directories the application keeps its files in
+type Dirs struct {
+	Config string // Profile and settings
+	Data   string // Replays, logs and exports
+}
+
+// DataFile returns the path of the profile and settings file
+func (d Dirs) DataFile() string {
+	return filepath.Join(d.Config, DataFileName)
+}
+
+// Create makes the directories when they do not exist yet
+func (d Dirs) Create() error {
+	for _, dir := range []string{d.Config, d.Data} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Default returns the OS-appropriate directories: the user config
+// directory for the profile, and ~/.local/share (or $XDG_DATA_HOME) on
+// Linux, ~/Library/Application Support on macOS and %LocalAppData% on
+// Windows for the rest.
+func Default() (Dirs, error) {
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return Dirs{}, err
+	}
+	data, err := dataDir()
+	if err != nil {
+		return Dirs{}, err
+	}
+	return Dirs{Config: filepath.Join(config, App), Data: filepath.Join(data, App)}, nil
+}
+
+// dataDir returns the OS directory for application data
+func dataDir() (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("LocalAppData"); dir != "" {
+			return dir, nil
+		}
+		return "", errors.New("%LocalAppData% is not defined")
+	case "darwin", "ios":
+		return os.UserConfigDir() // ~/Library/Application Support
+	case "plan9":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, "lib"), nil
+	default:
+		if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+			if !filepath.IsAbs(dir) {
+				return "", errors.New("path in $XDG_DATA_HOME is relative")
+			}
+			return dir, nil
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, ".local", "share"), nil
+	}
+}
+
+// Resolve returns the directories to use: override when set, from the
+// --data-dir flag, then the directory in $AI_POKER_HOME, then the defaults.
+// An overriding directory holds both the profile and the data.
+func Resolve(override string) (Dirs, error) {
+	if override == "" {
+		override = os.Getenv(EnvHome)
+	}
+	if override != "" {
+		dir, err := filepath.Abs(override)
+		if err != nil {
+			return Dirs{}, err
+		}
+		return Dirs{Config: dir, Data: dir}, nil
+	}
+	return Default()
+}
+
+// Migrate moves the files earlier versions kept in legacyDir into dirs,
+// leaving any already in place untouched. It returns the paths moved.
+//
+// Only a legacyDir holding an earlier version's profile is an install to
+// move, and only while dirs has no profile yet, so the migration runs once
+// and never takes a debug.log or exports folder from an unrelated
+// directory the application happens to be started in.
+func Migrate(dirs Dirs, legacyDir string) ([]string, error) {
+	moved := []string{}
+	profile := filepath.Join(legacyDir, DataFileName)
+	if same(profile, dirs.DataFile()) || !exists(profile) || exists(dirs.DataFile()) {
+		return moved, nil
+	}
+	for _, file := range legacyFiles {
+		from := filepath.Join(legacyDir, file.name)
+		to := filepath.Join(dirs.Data, file.name)
+		if file.config {
+			to = filepath.Join(dirs.Config, file.name)
+		}
+		if same(from, to) || !exists(from) || exists(to) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+			return moved, err
+		}
+		if err := os.Rename(from, to); err != nil {
+			return moved, fmt.Errorf("moving %s to %s: %w", from, to, err)
+		}
+		moved = append(moved, to)
+	}
+	return moved, nil
+}
+
+// exists reports whether a file or directory is at path
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
+
+// same reports whether two paths name the same file
+func same(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// Open shows a directory in the system file manager
+func Open(dir string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("explorer", dir)
+	case "darwin":
+		cmd = exec.Command("open", dir)
+	default:
+		cmd = exec.Command("xdg-open", dir)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// The file manager outlives the command; reap it in the background
+	go cmd.Wait()
+	return nil
+}
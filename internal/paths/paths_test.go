@@ -0,0 +1,120 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolvePrefersOverride(t *testing.T) {
+	env := t.TempDir()
+	t.Setenv(EnvHome, env)
+
+	dirs, err := Resolve("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dirs.Config != env || dirs.Data != env || dirs.DataFile() != filepath.Join(env, DataFileName) {
+		t.Errorf("Expected $%s used for everything, got %+v", EnvHome, dirs)
+	}
+
+	flag := t.TempDir()
+	if dirs, err = Resolve(flag); err != nil || dirs.Config != flag || dirs.Data != flag {
+		t.Errorf("Expected the flag to win over $%s, got %+v (%v)", EnvHome, dirs, err)
+	}
+}
+
+func TestResolveDefaults(t *testing.T) {
+	t.Setenv(EnvHome, "")
+	dirs, err := Resolve("")
+	if err != nil {
+		t.Skipf("No home directory: %v", err)
+	}
+	if filepath.Base(dirs.Config) != App || filepath.Base(dirs.Data) != App {
+		t.Errorf("Expected application directories, got %+v", dirs)
+	}
+}
+
+func TestMigrateMovesLegacyFiles(t *testing.T) {
+	legacy := t.TempDir()
+	for _, name := range []string{DataFileName, "debug.log"} {
+		if err := os.WriteFile(filepath.Join(legacy, name), []byte("legacy"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(legacy, "exports"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	root := t.TempDir()
+	dirs := Dirs{Config: filepath.Join(root, "config"), Data: filepath.Join(root, "data")}
+	// A newer log in place is kept
+	if err := os.MkdirAll(dirs.Data, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirs.Data, "debug.log"), []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := Migrate(dirs, legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(moved) != 2 || moved[0] != dirs.DataFile() || moved[1] != filepath.Join(dirs.Data, "exports") {
+		t.Errorf("Expected the profile and exports moved, got %v", moved)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dirs.Data, "debug.log")); string(content) != "new" {
+		t.Errorf("Expected the newer log kept, got %q", content)
+	}
+	if !exists(filepath.Join(legacy, "debug.log")) || exists(filepath.Join(legacy, DataFileName)) {
+		t.Error("Expected only the moved files gone from the legacy directory")
+	}
+
+	// Running again finds nothing left to move
+	if moved, err = Migrate(dirs, legacy); err != nil || len(moved) != 0 {
+		t.Errorf("Expected nothing moved twice, got %v (%v)", moved, err)
+	}
+}
+
+func TestMigrateLeavesOtherDirectoriesAlone(t *testing.T) {
+	// Another project's log and exports, with no profile beside them
+	project := t.TempDir()
+	if err := os.WriteFile(filepath.Join(project, "debug.log"), []byte("theirs"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(project, "exports"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	dirs := Dirs{Config: filepath.Join(root, "config"), Data: filepath.Join(root, "data")}
+	moved, err := Migrate(dirs, project)
+	if err != nil || len(moved) != 0 || !exists(filepath.Join(project, "debug.log")) || !exists(filepath.Join(project, "exports")) {
+		t.Errorf("Expected a directory without a profile left alone, got %v (%v)", moved, err)
+	}
+
+	// An old install found after the new directories have a profile is too
+	if err := os.WriteFile(filepath.Join(project, DataFileName), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := dirs.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dirs.DataFile(), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	moved, err = Migrate(dirs, project)
+	if err != nil || len(moved) != 0 || !exists(filepath.Join(project, "debug.log")) {
+		t.Errorf("Expected nothing moved once migrated, got %v (%v)", moved, err)
+	}
+}
+
+func TestMigrateInPlace(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, DataFileName), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	moved, err := Migrate(Dirs{Config: dir, Data: dir}, dir)
+	if err != nil || len(moved) != 0 || !exists(filepath.Join(dir, DataFileName)) {
+		t.Errorf("Expected files already in place left alone, got %v (%v)", moved, err)
+	}
+}
@@ -0,0 +1,197 @@
+// Package perf compares "go test -bench -benchmem" runs against the
+// baseline.txt recorded with the engine, for the bench command, and sums up
+// the pprof profiles the simulate command writes. It is internal to the
+// module.
+package perf
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultThreshold is how much slower or bigger a benchmark may get before
+// it counts as a regression, as a fraction of the baseline
+const DefaultThreshold = 0.10
+
+// Units compared between runs, in report order
+var Units = []string{"ns/op", "B/op", "allocs/op"}
+
+// Benchmark is every sample of one benchmark in a "go test -bench" output.
+// Running with -count gives several samples per unit.
+type Benchmark struct {
+	Name    string // Package-qualified, e.g. "engine/holdem.EvaluateHand"
+	Samples map[string][]float64
+}
+
+// Median returns the median sample of a unit and whether there was any
+func (b *Benchmark) Median(unit string) (float64, bool) {
+	samples := append([]float64{}, b.Samples[unit]...)
+	if len(samples) == 0 {
+		return 0, false
+	}
+	sort.Float64s(samples)
+	middle := len(samples) / 2
+	if len(samples)%2 == 0 {
+		return (samples[middle-1] + samples[middle]) / 2, true
+	}
+	return samples[middle], true
+}
+
+// Parse reads "go test -bench" output, the same text benchstat reads. Lines
+// that are not benchmark results are skipped; "pkg:" lines qualify the
+// names of the benchmarks after them. Benchmarks are in order of first
+// appearance.
+func Parse(r io.Reader) ([]*Benchmark, error) {
+	benchmarks := []*Benchmark{}
+	byName := map[string]*Benchmark{}
+	pkg := ""
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(text, "pkg:"); ok {
+			pkg = shortPackage(strings.TrimSpace(rest))
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue // A benchmark's own log output
+		}
+
+		name := trimProcs(strings.TrimPrefix(fields[0], "Benchmark"))
+		if pkg != "" {
+			name = pkg + "." + name
+		}
+		benchmark, ok := byName[name]
+		if !ok {
+			benchmark = &Benchmark{Name: name, Samples: map[string][]float64{}}
+			byName[name] = benchmark
+			benchmarks = append(benchmarks, benchmark)
+		}
+		// Value and unit pairs follow the iteration count
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: bad %s value %q", line, fields[i+1], fields[i])
+			}
+			benchmark.Samples[fields[i+1]] = append(benchmark.Samples[fields[i+1]], value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return benchmarks, nil
+}
+
+// ParseFile reads "go test -bench" output saved to a file
+func ParseFile(path string) ([]*Benchmark, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+// Delta is how one unit of one benchmark changed between two runs
+type Delta struct {
+	Name     string
+	Unit     string
+	Old, New float64 // Medians
+}
+
+// Change returns the relative change, e.g. 0.25 for 25% more. Growing from
+// zero is an infinite change.
+func (d Delta) Change() float64 {
+	switch {
+	case d.Old == d.New:
+		return 0
+	case d.Old == 0:
+		return math.Inf(1)
+	}
+	return (d.New - d.Old) / d.Old
+}
+
+// Regressed reports whether the change is worse than the threshold. Every
+// compared unit is better when smaller.
+func (d Delta) Regressed(threshold float64) bool {
+	return d.Change() > threshold
+}
+
+// String formats the delta, e.g. "engine/holdem.EvaluateHand ns/op: 5645 -> 6100 (+8.1%)"
+func (d Delta) String() string {
+	change := "new"
+	if !math.IsInf(d.Change(), 1) {
+		change = fmt.Sprintf("%+.1f%%", d.Change()*100)
+	}
+	return fmt.Sprintf("%s %s: %s -> %s (%s)", d.Name, d.Unit, formatValue(d.Old), formatValue(d.New), change)
+}
+
+// Compare pairs up the benchmarks found in both runs and returns a delta
+// per compared unit, in the order of the new run. Benchmarks missing from
+// either run are left out.
+func Compare(old, new []*Benchmark) []Delta {
+	baseline := map[string]*Benchmark{}
+	for _, benchmark := range old {
+		baseline[benchmark.Name] = benchmark
+	}
+	deltas := []Delta{}
+	for _, benchmark := range new {
+		before, ok := baseline[benchmark.Name]
+		if !ok {
+			continue
+		}
+		for _, unit := range Units {
+			oldValue, hadOld := before.Median(unit)
+			newValue, hasNew := benchmark.Median(unit)
+			if hadOld && hasNew {
+				deltas = append(deltas, Delta{Name: benchmark.Name, Unit: unit, Old: oldValue, New: newValue})
+			}
+		}
+	}
+	return deltas
+}
+
+// Regressions returns the deltas worse than the threshold
+func Regressions(deltas []Delta, threshold float64) []Delta {
+	regressed := []Delta{}
+	for _, delta := range deltas {
+		if delta.Regressed(threshold) {
+			regressed = append(regressed, delta)
+		}
+	}
+	return regressed
+}
+
+// shortPackage drops the module path, e.g. "github.com/ljbink/ai-poker/engine/holdem" -> "engine/holdem"
+func shortPackage(pkg string) string {
+	if i := strings.Index(pkg, "/engine/"); i >= 0 {
+		return pkg[i+1:]
+	}
+	return pkg
+}
+
+// trimProcs drops the GOMAXPROCS suffix, e.g. "EvaluateHand-8" -> "EvaluateHand"
+func trimProcs(name string) string {
+	if i := strings.LastIndex(name, "-"); i >= 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			return name[:i]
+		}
+	}
+	return name
+}
+
+func formatValue(value float64) string {
+	if value >= 100 || value == math.Trunc(value) {
+		return strconv.FormatFloat(value, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
@@ -0,0 +1,108 @@
+package perf
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+const sampleOutput = `goos: linux
+goarch: amd64
+pkg: github.com/ljbink/ai-poker/engine/holdem
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkEvaluateHand-8      	  200000	      6000 ns/op	     132 B/op	       3 allocs/op
+BenchmarkEvaluateHand-8      	  200000	      5000 ns/op	     132 B/op	       3 allocs/op
+BenchmarkEvaluateHand-8      	  200000	      7000 ns/op	     132 B/op	       3 allocs/op
+BenchmarkValidateAction-8    	31943835	        39.15 ns/op
+PASS
+ok  	github.com/ljbink/ai-poker/engine/holdem	25.898s
+pkg: github.com/ljbink/ai-poker/engine/session
+BenchmarkPlayHand 	    7455	    160741 ns/op	   58521 B/op	     823 allocs/op
+`
+
+func TestParse(t *testing.T) {
+	benchmarks, err := Parse(strings.NewReader(sampleOutput))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	names := []string{}
+	for _, benchmark := range benchmarks {
+		names = append(names, benchmark.Name)
+	}
+	want := "engine/holdem.EvaluateHand engine/holdem.ValidateAction engine/session.PlayHand"
+	if strings.Join(names, " ") != want {
+		t.Fatalf("Expected %s, got %v", want, names)
+	}
+	if median, _ := benchmarks[0].Median("ns/op"); median != 6000 {
+		t.Errorf("Expected a median of 6000 ns/op, got %v", median)
+	}
+	if _, ok := benchmarks[1].Median("allocs/op"); ok {
+		t.Error("Expected no allocs/op without -benchmem")
+	}
+}
+
+func TestParseRejectsBadValues(t *testing.T) {
+	if _, err := Parse(strings.NewReader("BenchmarkX 10 fast ns/op\n")); err == nil {
+		t.Error("Expected an error for a non-numeric value")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	old, _ := Parse(strings.NewReader(sampleOutput))
+	new, _ := Parse(strings.NewReader(strings.NewReplacer(
+		"6000 ns/op", "6900 ns/op", // Median moves from 6000 to 6900, +15%
+		"823 allocs/op", "820 allocs/op",
+		"BenchmarkValidateAction", "BenchmarkRenamed",
+	).Replace(sampleOutput)))
+
+	deltas := Compare(old, new)
+	if len(deltas) != 6 {
+		t.Fatalf("Expected 3 units of 2 shared benchmarks, got %v", deltas)
+	}
+	regressed := Regressions(deltas, DefaultThreshold)
+	if len(regressed) != 1 || regressed[0].Name != "engine/holdem.EvaluateHand" || regressed[0].Unit != "ns/op" {
+		t.Fatalf("Expected only EvaluateHand ns/op to regress, got %v", regressed)
+	}
+	if got := regressed[0].String(); got != "engine/holdem.EvaluateHand ns/op: 6000 -> 6900 (+15.0%)" {
+		t.Errorf("Unexpected delta text %q", got)
+	}
+}
+
+func TestDeltaFromZero(t *testing.T) {
+	delta := Delta{Name: "x", Unit: "allocs/op", Old: 0, New: 1}
+	if !math.IsInf(delta.Change(), 1) || !delta.Regressed(DefaultThreshold) {
+		t.Errorf("Expected the first allocation to be a regression, got change %v", delta.Change())
+	}
+	if (Delta{Old: 0, New: 0}).Change() != 0 {
+		t.Error("Expected no change between zeros")
+	}
+}
+
+func TestBaselineCoversHotPaths(t *testing.T) {
+	baseline, err := ParseFile("baseline.txt")
+	if err != nil {
+		t.Fatalf("Reading the baseline failed: %v", err)
+	}
+	found := map[string]bool{}
+	for _, benchmark := range baseline {
+		for _, unit := range Units {
+			if _, ok := benchmark.Median(unit); !ok {
+				t.Errorf("%s has no %s; record the baseline with -benchmem", benchmark.Name, unit)
+			}
+		}
+		found[benchmark.Name] = true
+	}
+	for _, name := range []string{
+		"engine/holdem.EvaluateHand",
+		"engine/holdem.EvaluateOmahaHand",
+		"engine/holdem.ValidateAction",
+		"engine/holdem.GetAvailableActions",
+		"engine/equity.CalculateFlop",
+		"engine/equity.CalculatePreflop",
+		"engine/session.PlayHand",
+	} {
+		if !found[name] {
+			t.Errorf("Baseline is missing %s", name)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package perf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Profile is what hotspots and flame graphs are worked out from in a pprof
+// profile, as written by runtime/pprof: the kinds of value measured and
+// the samples with the stacks they were taken in
+type Profile struct {
+	SampleTypes []ValueType // What each of a sample's values is, e.g. cpu in nanoseconds
+	Samples     []Sample
+}
+
+// ValueType is a kind of value measured and its unit
+type ValueType struct {
+	Type string
+	Unit string
+}
+
+// Sample is one set of values and the functions on the stack, innermost
+// first, inlined calls included
+type Sample struct {
+	Stack  []string
+	Values []int64
+}
+
+// Hotspot is the share of a value spent in a function: Flat in its own
+// code, Cum in it and everything it called
+type Hotspot struct {
+	Function string
+	Flat     int64
+	Cum      int64
+}
+
+// ParseProfile reads a pprof profile, gzipped as runtime/pprof writes it or
+// not. Only what Hotspots and WriteFolded need is kept.
+func ParseProfile(r io.Reader) (*Profile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 1 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("profile: %w", err)
+		}
+		if data, err = io.ReadAll(gz); err != nil {
+			return nil, fmt.Errorf("profile: %w", err)
+		}
+	}
+
+	// Fields of profile.proto; strings are indices into the string table,
+	// which comes last, so everything is resolved once it is read
+	type rawSample struct {
+		locations []uint64
+		values    []int64
+	}
+	var (
+		types     [][2]int64
+		samples   []rawSample
+		locations = map[uint64][]uint64{} // Location ID to its functions' IDs, innermost first
+		functions = map[uint64]int64{}    // Function ID to its name
+		strs      []string
+	)
+	err = readMessage(data, func(field, _ int, _ uint64, body []byte) error {
+		switch field {
+		case 1: // sample_type
+			vt := [2]int64{}
+			err := readMessage(body, func(field, _ int, value uint64, _ []byte) error {
+				if field == 1 || field == 2 {
+					vt[field-1] = int64(value)
+				}
+				return nil
+			})
+			types = append(types, vt)
+			return err
+		case 2: // sample
+			sample := rawSample{}
+			err := readMessage(body, func(field, wire int, value uint64, packed []byte) error {
+				switch field {
+				case 1:
+					return readUints(wire, value, packed, func(v uint64) { sample.locations = append(sample.locations, v) })
+				case 2:
+					return readUints(wire, value, packed, func(v uint64) { sample.values = append(sample.values, int64(v)) })
+				}
+				return nil
+			})
+			samples = append(samples, sample)
+			return err
+		case 4: // location
+			var id uint64
+			lines := []uint64{}
+			err := readMessage(body, func(field, _ int, value uint64, line []byte) error {
+				switch field {
+				case 1:
+					id = value
+				case 4:
+					return readMessage(line, func(field, _ int, value uint64, _ []byte) error {
+						if field == 1 {
+							lines = append(lines, value)
+						}
+						return nil
+					})
+				}
+				return nil
+			})
+			locations[id] = lines
+			return err
+		case 5: // function
+			var id uint64
+			var name int64
+			err := readMessage(body, func(field, _ int, value uint64, _ []byte) error {
+				switch field {
+				case 1:
+					id = value
+				case 2:
+					name = int64(value)
+				}
+				return nil
+			})
+			functions[id] = name
+			return err
+		case 6: // string_table
+			strs = append(strs, string(body))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("profile: %w", err)
+	}
+
+	str := func(i int64) string {
+		if i < 0 || int(i) >= len(strs) {
+			return ""
+		}
+		return strs[i]
+	}
+	profile := &Profile{}
+	for _, vt := range types {
+		profile.SampleTypes = append(profile.SampleTypes, ValueType{Type: str(vt[0]), Unit: str(vt[1])})
+	}
+	for _, raw := range samples {
+		sample := Sample{Values: raw.values}
+		for _, location := range raw.locations {
+			for _, function := range locations[location] {
+				sample.Stack = append(sample.Stack, str(functions[function]))
+			}
+		}
+		profile.Samples = append(profile.Samples, sample)
+	}
+	return profile, nil
+}
+
+// Value returns the index of a sample type in the samples' values, e.g.
+// "cpu" or "alloc_space", and whether the profile measures it
+func (p *Profile) Value(sampleType string) (int, bool) {
+	for i, vt := range p.SampleTypes {
+		if vt.Type == sampleType {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Total returns the sum of a value over every sample
+func (p *Profile) Total(value int) int64 {
+	total := int64(0)
+	for _, sample := range p.Samples {
+		if value < len(sample.Values) {
+			total += sample.Values[value]
+		}
+	}
+	return total
+}
+
+// Hotspots returns the n functions with the most of a value spent in their
+// own code, most first, the way pprof's top lists them
+func (p *Profile) Hotspots(value, n int) []Hotspot {
+	byFunction := map[string]*Hotspot{}
+	spot := func(function string) *Hotspot {
+		h, ok := byFunction[function]
+		if !ok {
+			h = &Hotspot{Function: function}
+			byFunction[function] = h
+		}
+		return h
+	}
+	for _, sample := range p.Samples {
+		if value >= len(sample.Values) || len(sample.Stack) == 0 {
+			continue
+		}
+		v := sample.Values[value]
+		spot(sample.Stack[0]).Flat += v
+		// Recursion counts a function once per sample
+		seen := map[string]bool{}
+		for _, function := range sample.Stack {
+			if !seen[function] {
+				seen[function] = true
+				spot(function).Cum += v
+			}
+		}
+	}
+	hotspots := make([]Hotspot, 0, len(byFunction))
+	for _, h := range byFunction {
+		hotspots = append(hotspots, *h)
+	}
+	sort.Slice(hotspots, func(i, j int) bool {
+		if hotspots[i].Flat != hotspots[j].Flat {
+			return hotspots[i].Flat > hotspots[j].Flat
+		}
+		if hotspots[i].Cum != hotspots[j].Cum {
+			return hotspots[i].Cum > hotspots[j].Cum
+		}
+		return hotspots[i].Function < hotspots[j].Function
+	})
+	return hotspots[:min(n, len(hotspots))]
+}
+
+// WriteFolded writes a value as folded stacks, one "outer;...;inner value"
+// line per distinct stack, sorted, the input flamegraph.pl, speedscope and
+// inferno draw flame graphs from
+func (p *Profile) WriteFolded(w io.Writer, value int) error {
+	folded := map[string]int64{}
+	for _, sample := range p.Samples {
+		if value >= len(sample.Values) || len(sample.Stack) == 0 || sample.Values[value] == 0 {
+			continue
+		}
+		stack := make([]string, len(sample.Stack))
+		for i, function := range sample.Stack {
+			stack[len(stack)-1-i] = strings.ReplaceAll(function, ";", ":")
+		}
+		folded[strings.Join(stack, ";")] += sample.Values[value]
+	}
+	stacks := make([]string, 0, len(folded))
+	for stack := range folded {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+	buffered := bufio.NewWriter(w)
+	for _, stack := range stacks {
+		fmt.Fprintf(buffered, "%s %d\n", stack, folded[stack])
+	}
+	return buffered.Flush()
+}
+
+// readMessage calls field for each field of a protocol buffer message, with
+// the value of varint and fixed-size fields or the bytes of length-delimited
+// ones
+func readMessage(data []byte, field func(number, wire int, value uint64, body []byte) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return fmt.Errorf("bad field key")
+		}
+		data = data[n:]
+		number, wire := int(key>>3), int(key&7)
+		var value uint64
+		var body []byte
+		switch wire {
+		case 0:
+			value, n = binary.Uvarint(data)
+			if n <= 0 {
+				return fmt.Errorf("bad varint in field %d", number)
+			}
+			data = data[n:]
+		case 1:
+			if len(data) < 8 {
+				return fmt.Errorf("short field %d", number)
+			}
+			value, data = binary.LittleEndian.Uint64(data), data[8:]
+		case 2:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return fmt.Errorf("short field %d", number)
+			}
+			body, data = data[n:n+int(length)], data[n+int(length):]
+		case 5:
+			if len(data) < 4 {
+				return fmt.Errorf("short field %d", number)
+			}
+			value, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+		default:
+			return fmt.Errorf("unsupported wire type %d in field %d", wire, number)
+		}
+		if err := field(number, wire, value, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readUints reads a repeated integer field, one varint or a packed run of them
+func readUints(wire int, value uint64, packed []byte, add func(uint64)) error {
+	if wire != 2 {
+		add(value)
+		return nil
+	}
+	for len(packed) > 0 {
+		v, n := binary.Uvarint(packed)
+		if n <= 0 {
+			return fmt.Errorf("bad packed varint")
+		}
+		add(v)
+		packed = packed[n:]
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package perf
+
+import (
+	"bytes"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"testing"
+)
+
+var kept [][]byte
+
+//go:noinline
+func allocateForProfile() {
+	for i := 0; i < 64; i++ {
+		kept = append(kept, make([]byte, 64<<10))
+	}
+}
+
+func TestParseProfileFindsHotspots(t *testing.T) {
+	rate := runtime.MemProfileRate
+	runtime.MemProfileRate = 1
+	defer func() { runtime.MemProfileRate = rate }()
+	allocateForProfile()
+	runtime.GC()
+	var buf bytes.Buffer
+	if err := pprof.Lookup("allocs").WriteTo(&buf, 0); err != nil {
+		t.Fatal(err)
+	}
+	kept = nil
+
+	profile, err := ParseProfile(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	space, ok := profile.Value("alloc_space")
+	if !ok || profile.SampleTypes[space].Unit != "bytes" {
+		t.Fatalf("Expected allocated bytes among %+v", profile.SampleTypes)
+	}
+	hotspots := profile.Hotspots(space, 10)
+	if len(hotspots) == 0 || len(hotspots) > 10 || !strings.HasSuffix(hotspots[0].Function, "perf.allocateForProfile") {
+		t.Fatalf("Expected the allocating function on top, got %+v", hotspots)
+	}
+	if top := hotspots[0]; top.Flat < 64*64<<10 || top.Cum < top.Flat || top.Cum > profile.Total(space) {
+		t.Errorf("Expected every allocation counted once, got %+v of %d", top, profile.Total(space))
+	}
+
+	var folded bytes.Buffer
+	if err := profile.WriteFolded(&folded, space); err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, line := range strings.Split(strings.TrimSpace(folded.String()), "\n") {
+		stack, _, ok := strings.Cut(line, " ")
+		if !ok {
+			t.Fatalf("Expected stack and value on each line, got %q", line)
+		}
+		if strings.HasSuffix(stack, "TestParseProfileFindsHotspots;github.com/ljbink/ai-poker/internal/perf.allocateForProfile") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the test calling the allocation, outermost first, got:\n%s", folded.String())
+	}
+}
+
+func TestParseProfileRejectsGarbage(t *testing.T) {
+	if _, err := ParseProfile(strings.NewReader("\x0a\xff")); err == nil {
+		t.Error("Expected a truncated profile to fail")
+	}
+}
@@ -2,15 +2,130 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/ranges"
 	"github.com/ljbink/ai-poker/frontend"
+	"github.com/ljbink/ai-poker/internal/paths"
 )
 
 func main() {
-	// Start the TUI application
-	if err := frontend.RunTUI(); err != nil {
-		fmt.Printf("Error running TUI: %v\n", err)
+	// Files live in the OS config and data directories unless --data-dir or
+	// $AI_POKER_HOME names another; an earlier version's install in the
+	// working directory is moved over on the first start
+	override, args, err := parseDataDir(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	dirs, err := paths.Resolve(override)
+	if err == nil {
+		err = dirs.Create()
+	}
+	var moved []string
+	if err == nil {
+		moved, err = paths.Migrate(dirs, ".")
+	}
+	for _, path := range moved {
+		fmt.Fprintf(os.Stderr, "Moved %s from an earlier version to %s\n", filepath.Base(path), filepath.Dir(path))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	// Check the hand evaluator picked from the environment before any game uses it
+	if err := holdem.SelectEvaluatorFromEnv(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(args) == 0 {
+		// Start the TUI application
+		if err := frontend.RunTUI(dirs); err != nil {
+			fmt.Printf("Error running TUI: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Saved ranges can be referred to as @name by the commands taking range
+	// notation, in hands, scenarios and chart bot files
+	var library *ranges.Library
+	switch args[0] {
+	case "-h", "--help", "help":
+		usage(os.Stdout)
+		return
+	case "analyze":
+		err = runAnalyze(args[1:], os.Stdout, dirs.Data)
+	case "bench":
+		err = runBench(args[1:], os.Stdout)
+	case "chart":
+		if library, err = frontend.LoadSavedRanges(dirs.DataFile()); err == nil {
+			err = runChart(args[1:], os.Stdout, library)
+		}
+	case "compare":
+		if library, err = frontend.LoadSavedRanges(dirs.DataFile()); err == nil {
+			err = runCompare(args[1:], os.Stdout, library)
+		}
+	case "import":
+		err = runImport(args[1:], os.Stdout, dirs.Data)
+	case "scenario":
+		if library, err = frontend.LoadSavedRanges(dirs.DataFile()); err == nil {
+			err = runScenario(args[1:], os.Stdout, library)
+		}
+	case "simulate":
+		if library, err = frontend.LoadSavedRanges(dirs.DataFile()); err == nil {
+			err = runSimulate(args[1:], os.Stdout, library)
+		}
+	default:
+		usage(os.Stderr)
+		err = fmt.Errorf("unknown command %q", args[0])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// usage lists the commands; without one ai-poker starts the TUI
+func usage(out io.Writer) {
+	fmt.Fprintln(out, "Usage: ai-poker [--data-dir DIR] [command [flags] [args...]]")
+	fmt.Fprintln(out, "Without a command ai-poker starts the game. Commands:")
+	for _, command := range []struct{ name, help string }{
+		{"analyze", "statistics and mistakes from hand histories and replays"},
+		{"bench", "compare a benchmark run against the baseline"},
+		{"chart", "preflop equity of every starting hand against a hand or range"},
+		{"compare", "play two bots against each other on duplicate deals"},
+		{"import", "merge hand histories into the hand database"},
+		{"scenario", "play a scenario file up to its decision point"},
+		{"simulate", "play sit-and-gos or cash games between bots"},
+	} {
+		fmt.Fprintf(out, "  %-9s %s\n", command.name, command.help)
+	}
+	fmt.Fprintln(out, "Run ai-poker command -h for a command's flags.")
+}
+
+// parseDataDir takes a leading "--data-dir DIR" or "--data-dir=DIR" off the
+// command line, returning the directory and the remaining arguments
+func parseDataDir(args []string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", args, nil
+	}
+	dir, ok := strings.CutPrefix(args[0], "--data-dir=")
+	rest := args[1:]
+	if !ok {
+		if args[0] != "--data-dir" {
+			return "", args, nil
+		}
+		if len(rest) > 0 {
+			dir, rest = rest[0], rest[1:]
+		}
+	}
+	if dir == "" {
+		return "", nil, fmt.Errorf("--data-dir needs a directory")
+	}
+	return dir, rest, nil
 }
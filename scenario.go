@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/poker"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/engine/training"
+)
+
+// runScenario implements "ai-poker scenario file": it loads a scenario,
+// plays it up to its decision point and prints the table as the hero sees
+// it, which is quick to check when writing drills or reproducing bugs
+func runScenario(args []string, out io.Writer, library *ranges.Library) error {
+	flags := flag.NewFlagSet("scenario", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.Usage = func() {
+		fmt.Fprintln(out, "Usage: ai-poker scenario scenario-file.yaml")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("need one scenario file")
+	}
+
+	scenario, err := training.LoadScenario(flags.Arg(0), ranges.WithLibrary(library))
+	if err != nil {
+		return err
+	}
+	game, err := scenario.NewGame()
+	if err != nil {
+		return err
+	}
+	hero := game.GetCurrentPlayer()
+	if scenario.Name != "" {
+		fmt.Fprintln(out, scenario.Name)
+	}
+	fmt.Fprintf(out, "%s · Pot %d · Board %s\n", holdem.PhaseToString(game.GetCurrentPhase()), game.GetPot(),
+		poker.Cards(game.GetCommunityCards()).Codes())
+	for _, player := range game.GetAllPlayers() {
+		seat, _ := game.GetPlayerSitByID(player.GetID())
+		marker := " "
+		if seat == game.GetButton() {
+			marker = "D"
+		}
+		cards := "??"
+		if player.GetID() == hero.GetID() {
+			cards = poker.Cards(player.GetHandCards()).Codes()
+		}
+		status := ""
+		if player.IsFolded() {
+			status = "  folded"
+		}
+		fmt.Fprintf(out, "%s Seat %d  %-12s %5d chips  bet %4d  %s%s\n",
+			marker, seat, player.GetName(), player.GetChips(), player.GetBet(), cards, status)
+	}
+
+	validator := holdem.NewActionValidator()
+	actions := []string{}
+	for _, action := range validator.GetAvailableActions(game, hero) {
+		actions = append(actions, holdem.ActionTypeToString(action))
+	}
+	fmt.Fprintf(out, "%s to act: %s (call %d)\n", hero.GetName(), strings.Join(actions, ", "), validator.GetCallAmount(game, hero))
+	fmt.Fprintln(out, scenario.Question)
+	return nil
+}
@@ -0,0 +1,686 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ljbink/ai-poker/engine/holdem"
+	"github.com/ljbink/ai-poker/engine/holdem_ai"
+	"github.com/ljbink/ai-poker/engine/metrics"
+	"github.com/ljbink/ai-poker/engine/ranges"
+	"github.com/ljbink/ai-poker/engine/session"
+	"github.com/ljbink/ai-poker/engine/simulator"
+	"github.com/ljbink/ai-poker/engine/tournament"
+	"github.com/ljbink/ai-poker/internal/perf"
+)
+
+// runSimulate implements "ai-poker simulate [flags]": it plays sit-and-gos,
+// or cash games with -cash, between preset bots without any delays and
+// prints how each bot finished. -structure picks the sit-and-go blinds, a
+// bundled preset or a structure file. Games run in parallel on -workers
+// goroutines and the same -seed replays the same games whatever the worker
+// count. -json and -csv also export every hand and each bot's totals for
+// analysis in other tools, and -report prints each bot's HUD statistics.
+// -traces writes every bot decision with the mode it was made in, so a
+// session switched to exploitative play with -exploit-after can be
+// analysed before and after the switch. -profile prints where each bot's
+// decision time went, and -budget how many decisions overran the action
+// clock. -cpuprofile and -memprofile write pprof profiles of the whole run
+// and print its ten hottest functions, and -flame the CPU profile as folded
+// stacks for flame graphs. -sweep plays a batch of cash games at each value
+// of a parameter, such as the first bot's aggression or the stack depth, and
+// prints each bot's bb/100 by value, which -sweep-csv writes out for plotting.
+// -evaluator picks the backend scoring showdowns, over AI_POKER_EVALUATOR.
+// -metrics-addr serves the run's hand, action, evaluation and decision
+// metrics for Prometheus at /metrics on that address while it plays.
+func runSimulate(args []string, out io.Writer, library *ranges.Library) error {
+	flags := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	flags.SetOutput(out)
+	seats := flags.Int("seats", 9, "sit-and-go size, 6 or 9")
+	runs := flags.Int("runs", 10, "number of tournaments to play")
+	bots := flags.String("bots", "", "comma-separated bot presets, cycled to fill the table (default: every preset)")
+	buyIn := flags.Int("buyin", 100, "buy-in per entrant")
+	structureName := flags.String("structure", tournament.StandardStructure, "blind structure: "+strings.Join(tournament.StructurePresets(), ", ")+", or the path of a .csv or .json file")
+	seed := flags.Int64("seed", 0, "master seed for seat draws, shuffles and bots, 0 for random")
+	cash := flags.Bool("cash", false, "play 100 big blind cash games with blinds 5/10 instead")
+	stopLoss := flags.Int("stoploss", 0, "cash games: leave after losing this many big blinds, 0 for no limit")
+	stopWin := flags.Int("stopwin", 0, "cash games: leave after winning this many big blinds, 0 for no limit")
+	timeLimit := flags.Duration("time", 0, "cash games: leave after this much simulated time, 0 for no limit")
+	hands := flags.Int("hands", 1000, "cash games: hands to play at most")
+	jsonPath := flags.String("json", "", "write per-hand and per-bot results to this JSON file")
+	workers := flags.Int("workers", runtime.NumCPU(), "games played in parallel")
+	progress := flags.Bool("progress", true, "show progress on stderr")
+	csvPrefix := flags.String("csv", "", "write PREFIX_hands.csv and PREFIX_bots.csv with per-hand and per-bot results")
+	report := flags.Bool("report", false, "print each bot's VPIP, PFR, 3-bet, c-bet, WTSD and aggression factor")
+	exploitAfter := flags.Int("exploit-after", 0, "cash games: bots switch to exploitative play after this many hands, 0 to stay balanced")
+	tracePath := flags.String("traces", "", "write every bot decision to this JSON lines file")
+	profile := flags.Bool("profile", false, "print the time each bot spends on evaluation, equity and search per decision")
+	budget := flags.Duration("budget", 0, "with -profile, count the decisions slower than this, 0 for no budget")
+	cpuProfile := flags.String("cpuprofile", "", "write a pprof CPU profile of the run to this file and print its hotspots")
+	memProfile := flags.String("memprofile", "", "write a pprof heap profile at the end of the run to this file and print where it allocated most")
+	flamePath := flags.String("flame", "", "with -cpuprofile, write the CPU profile as folded stacks for flamegraph.pl or speedscope to this file")
+	sweepSpec := flags.String("sweep", "", "with -cash, play -runs games at each value of PARAM=FROM:TO:STEP or PARAM=V1,V2,…, where PARAM is "+sweepParameterNames())
+	sweepCSV := flags.String("sweep-csv", "", "with -sweep, write each bot's results by value to this CSV file for plotting")
+	metricsAddr := flags.String("metrics-addr", "", "serve Prometheus metrics of the run at /metrics on this address, e.g. localhost:9090")
+	evaluator := flags.String("evaluator", "", "hand evaluator backend: "+evaluatorNames()+" (default: $"+holdem.EvaluatorEnv+", then fast)")
+	flags.Usage = func() {
+		fmt.Fprintln(out, "Usage: ai-poker simulate [flags]")
+		fmt.Fprintf(out, "Bot presets: %s, or the path of a CSV chart file\n", strings.Join(holdem_ai.BotNames(), ", "))
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *flamePath != "" && *cpuProfile == "" {
+		return fmt.Errorf("-flame needs -cpuprofile")
+	}
+	if *evaluator != "" {
+		if err := holdem.SelectEvaluator(holdem.EvaluatorBackend(*evaluator)); err != nil {
+			return err
+		}
+	}
+	var sweep *simulator.Sweep
+	if *sweepSpec != "" {
+		if !*cash {
+			return fmt.Errorf("-sweep needs -cash")
+		}
+		parsed, err := simulator.ParseSweep(*sweepSpec)
+		if err != nil {
+			return err
+		}
+		sweep = &parsed
+	} else if *sweepCSV != "" {
+		return fmt.Errorf("-sweep-csv needs -sweep")
+	}
+
+	names := holdem_ai.BotNames()
+	if *bots != "" {
+		names = strings.Split(*bots, ",")
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	var recorder *simulator.Recorder
+	if *jsonPath != "" || *csvPrefix != "" || *report {
+		recorder = simulator.NewRecorder()
+	}
+	batch := simulator.BatchConfig{Runs: *runs, Workers: *workers, Seed: *seed, Recorder: recorder}
+	if *progress {
+		batch.Progress = printProgress(os.Stderr, "games")
+	}
+	var registry *metrics.Registry
+	if *metricsAddr != "" {
+		registry = metrics.NewRegistry()
+		stop, err := serveMetrics(*metricsAddr, registry)
+		if err != nil {
+			return err
+		}
+		defer stop()
+		batch.Metrics = registry
+	}
+	var traces *traceWriter
+	if *tracePath != "" {
+		file, err := os.Create(*tracePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		traces = newTraceWriter(file)
+	}
+	var profiler *simulator.Profiler
+	if *profile {
+		profiler = simulator.NewProfiler(*budget)
+	}
+	pprofs, err := startPprof(*cpuProfile)
+	if err != nil {
+		return err
+	}
+	defer pprofs.stop()
+	newEntrants := func(seed int64) ([]simulator.Entrant, error) {
+		entrants, err := newSimEntrants(names, *seats, seed, library)
+		if err != nil {
+			return nil, err
+		}
+		for i, entrant := range entrants {
+			if traceable, ok := entrant.Maker.(holdem_ai.ITraceable); ok && (traces != nil || profiler != nil) {
+				traceable.SetTraceSink(traceSink(traces, profiler, entrant.Name))
+			}
+			if registry != nil {
+				entrants[i].Maker = holdem_ai.NewInstrumentedDecisionMaker(entrant.Name, entrant.Maker, registry)
+			}
+		}
+		return entrants, nil
+	}
+	if *cash {
+		limits := session.Limits{StopLoss: *stopLoss * simBigBlind, StopWin: *stopWin * simBigBlind, Duration: *timeLimit}
+		game := simCashGame(*hands, *exploitAfter, limits)
+		if sweep != nil {
+			err = runSimulateSweep(out, batch, newEntrants, *seats, game, *sweep, *sweepCSV)
+		} else {
+			err = runSimulateCash(out, batch, newEntrants, *seats, game)
+		}
+		if err != nil {
+			return err
+		}
+		if err := traces.close(out, *tracePath); err != nil {
+			return err
+		}
+		if err := printProfiles(out, profiler); err != nil {
+			return err
+		}
+		if err := exportSimulation(out, recorder, *jsonPath, *csvPrefix, *report); err != nil {
+			return err
+		}
+		return pprofs.finish(out, *memProfile, *flamePath)
+	}
+
+	structure, err := tournament.Structure(*structureName)
+	if err != nil {
+		return err
+	}
+	results, err := simulator.RunSitAndGos(context.Background(), batch, *seats, structure, *buyIn, newEntrants)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "%d x %d-max sit-and-go, %s blinds, buy-in %d, seed %d\n\n", *runs, *seats, structure.Name, *buyIn, *seed)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Bot\tEntries\tWins\tITM %\tAvg place\tROI %\t")
+	for _, bot := range simulator.SummarizeSitAndGos(results) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f\t%.2f\t%+.1f\t\n", bot.Name, bot.Entries, bot.Wins,
+			bot.ITM()*100, bot.AveragePlace(), bot.ROI(*buyIn)*100)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := traces.close(out, *tracePath); err != nil {
+		return err
+	}
+	if err := printProfiles(out, profiler); err != nil {
+		return err
+	}
+	if err := exportSimulation(out, recorder, *jsonPath, *csvPrefix, *report); err != nil {
+		return err
+	}
+	return pprofs.finish(out, *memProfile, *flamePath)
+}
+
+// pprofRun is the CPU profile of a simulation, nil when none was asked for
+type pprofRun struct {
+	path string
+	file *os.File // Open while profiling
+}
+
+// startPprof starts profiling the CPU into the file at path, when there is one
+func startPprof(path string) (*pprofRun, error) {
+	if path == "" {
+		return nil, nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := pprof.StartCPUProfile(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &pprofRun{path: path, file: file}, nil
+}
+
+// stop ends the CPU profile, if it is still running
+func (p *pprofRun) stop() error {
+	if p == nil || p.file == nil {
+		return nil
+	}
+	pprof.StopCPUProfile()
+	err := p.file.Close()
+	p.file = nil
+	return err
+}
+
+// finish ends the CPU profile and prints its hotspots, writes its folded
+// stacks to flamePath and a heap profile to memPath when they are set, and
+// prints the heap's hotspots
+func (p *pprofRun) finish(out io.Writer, memPath, flamePath string) error {
+	if err := p.stop(); err != nil {
+		return err
+	}
+	if p != nil {
+		profile, err := readPprof(p.path)
+		if err != nil {
+			return err
+		}
+		if err := printHotspots(out, "CPU", profile, "cpu", p.path); err != nil {
+			return err
+		}
+		if flamePath != "" {
+			if err := writeFolded(profile, "cpu", flamePath); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Wrote CPU flame graph stacks to %s\n", flamePath)
+		}
+	}
+	if memPath == "" {
+		return nil
+	}
+	file, err := os.Create(memPath)
+	if err != nil {
+		return err
+	}
+	runtime.GC() // Up to date with everything allocated
+	if err := pprof.Lookup("allocs").WriteTo(file, 0); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	profile, err := readPprof(memPath)
+	if err != nil {
+		return err
+	}
+	return printHotspots(out, "Allocation", profile, "alloc_space", memPath)
+}
+
+// readPprof parses the profile written to path
+func readPprof(path string) (*perf.Profile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	profile, err := perf.ParseProfile(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return profile, nil
+}
+
+// printHotspots prints the ten functions with the most of a sample type
+// spent in their own code, with their share of the total, like pprof's top
+func printHotspots(out io.Writer, title string, profile *perf.Profile, sampleType, path string) error {
+	value, ok := profile.Value(sampleType)
+	if !ok {
+		return fmt.Errorf("%s: no %s samples", path, sampleType)
+	}
+	unit, total := profile.SampleTypes[value].Unit, profile.Total(value)
+	fmt.Fprintf(out, "\n%s hotspots, %s in total (profile in %s)\n", title, formatPprofValue(total, unit), path)
+	if total == 0 {
+		fmt.Fprintln(out, "No samples, the run was too short to profile")
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Flat\tFlat %\tCum\tCum %\tFunction")
+	for _, spot := range profile.Hotspots(value, 10) {
+		fmt.Fprintf(w, "%s\t%.1f\t%s\t%.1f\t%s\n", formatPprofValue(spot.Flat, unit), float64(spot.Flat)/float64(total)*100,
+			formatPprofValue(spot.Cum, unit), float64(spot.Cum)/float64(total)*100, spot.Function)
+	}
+	return w.Flush()
+}
+
+// formatPprofValue writes a profile value in its unit, as durations for
+// nanoseconds and in MB for bytes
+func formatPprofValue(v int64, unit string) string {
+	switch unit {
+	case "nanoseconds":
+		return time.Duration(v).Round(time.Millisecond).String()
+	case "bytes":
+		return fmt.Sprintf("%.1fMB", float64(v)/(1<<20))
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// writeFolded writes a sample type of the profile as folded stacks
+func writeFolded(profile *perf.Profile, sampleType, path string) error {
+	value, ok := profile.Value(sampleType)
+	if !ok {
+		return fmt.Errorf("no %s samples to write to %s", sampleType, path)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := profile.WriteFolded(file, value); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// traceSink returns the sink of one bot's decisions, feeding the traces
+// file and the profiler when they are set
+func traceSink(traces *traceWriter, profiler *simulator.Profiler, bot string) func(holdem_ai.DecisionTrace) {
+	var sinks []func(holdem_ai.DecisionTrace)
+	if traces != nil {
+		sinks = append(sinks, traces.sink(bot))
+	}
+	if profiler != nil {
+		sinks = append(sinks, profiler.Sink(bot))
+	}
+	return func(trace holdem_ai.DecisionTrace) {
+		for _, sink := range sinks {
+			sink(trace)
+		}
+	}
+}
+
+// printProfiles prints where each bot's decision time went, on average
+// per decision, slowest bot first
+func printProfiles(out io.Writer, profiler *simulator.Profiler) error {
+	if profiler == nil {
+		return nil
+	}
+	summaries := profiler.Summaries()
+	sort.SliceStable(summaries, func(i, j int) bool { return summaries[i].Mean() > summaries[j].Mean() })
+	perDecision := func(total time.Duration, decisions int) string {
+		return fmt.Sprintf("%.1f", float64(total)/float64(decisions)/float64(time.Microsecond))
+	}
+	fmt.Fprintln(out, "\nDecision time, µs")
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	header := "Bot\tDecisions\tEval\tEquity\tSearch\tMean\tP95\tMax\t"
+	if profiler.Budget() > 0 {
+		header += fmt.Sprintf("Over %s\t", profiler.Budget())
+	}
+	fmt.Fprintln(w, header)
+	for _, bot := range summaries {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t", bot.Name, bot.Decisions,
+			perDecision(bot.Evaluation, bot.Decisions), perDecision(bot.Equity, bot.Decisions), perDecision(bot.Search, bot.Decisions),
+			perDecision(bot.Total, bot.Decisions), perDecision(bot.P95, 1), perDecision(bot.Max, 1))
+		if profiler.Budget() > 0 {
+			fmt.Fprintf(w, "%d\t", bot.OverBudget)
+		}
+		fmt.Fprintln(w)
+	}
+	return w.Flush()
+}
+
+// traceWriter writes the bots' decision traces as JSON lines. Games run in
+// parallel, so traces from different games interleave.
+type traceWriter struct {
+	mu     sync.Mutex
+	w      *bufio.Writer
+	enc    *json.Encoder
+	traces int
+	err    error
+}
+
+// simTrace is one line of the traces file
+type simTrace struct {
+	Bot string `json:"bot"`
+	holdem_ai.DecisionTrace
+}
+
+func newTraceWriter(w io.Writer) *traceWriter {
+	buffered := bufio.NewWriter(w)
+	return &traceWriter{w: buffered, enc: json.NewEncoder(buffered)}
+}
+
+// sink returns the trace sink of one bot
+func (t *traceWriter) sink(bot string) func(holdem_ai.DecisionTrace) {
+	return func(trace holdem_ai.DecisionTrace) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.err == nil {
+			t.err = t.enc.Encode(simTrace{Bot: bot, DecisionTrace: trace})
+			t.traces++
+		}
+	}
+}
+
+// close flushes the traces and says where they went
+func (t *traceWriter) close(out io.Writer, path string) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err == nil {
+		t.err = t.w.Flush()
+	}
+	if t.err != nil {
+		return fmt.Errorf("writing traces: %w", t.err)
+	}
+	fmt.Fprintf(out, "\nWrote %d decisions to %s\n", t.traces, path)
+	return nil
+}
+
+// printProgress returns a progress callback that keeps one status line up
+// to date, rewriting it at most once per percent and ending it once done
+func printProgress(out io.Writer, unit string) func(simulator.Progress) {
+	shown := -1
+	return func(p simulator.Progress) {
+		percent := int(p.Fraction() * 100)
+		if percent == shown {
+			return
+		}
+		shown = percent
+		fmt.Fprintf(out, "\r%3d%% · %d/%d %s, %d hands in %s", percent, p.Done, p.Total, unit, p.Hands, p.Elapsed.Round(time.Second))
+		if p.Done == p.Total {
+			fmt.Fprintln(out)
+		}
+	}
+}
+
+// exportSimulation writes the recorded hands to the files asked for, and
+// the frequency report when asked for
+func exportSimulation(out io.Writer, recorder *simulator.Recorder, jsonPath, csvPrefix string, report bool) error {
+	if recorder == nil {
+		return nil
+	}
+	export := recorder.Export()
+	fmt.Fprintln(out)
+	if report {
+		if err := printFrequencies(out, export); err != nil {
+			return err
+		}
+	}
+	if jsonPath != "" {
+		if err := export.SaveJSON(jsonPath); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Wrote %d hands to %s\n", len(export.Hands), jsonPath)
+	}
+	if csvPrefix != "" {
+		handsPath, botsPath := csvPrefix+"_hands.csv", csvPrefix+"_bots.csv"
+		if err := export.SaveCSV(handsPath, botsPath); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Wrote %d hands to %s and %d bots to %s\n", len(export.Hands), handsPath, len(export.Bots), botsPath)
+	}
+	return nil
+}
+
+// printFrequencies prints each bot's HUD statistics over every hand it
+// played, to check presets against the styles they are meant to play
+func printFrequencies(out io.Writer, export *simulator.Export) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Bot\tHands\tVPIP %\tPFR %\t3-bet %\tC-bet %\tWTSD %\tAF\tCold call %\tSqueeze %\tDonk %\tX/R %\t")
+	for _, bot := range export.Bots {
+		situations := bot.Frequencies.Situations
+		fmt.Fprintf(w, "%s\t%d\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%.2f\t%.1f\t%.1f\t%.1f\t%.1f\t\n", bot.Name, bot.Hands,
+			bot.VPIP()*100, bot.PFR()*100, bot.ThreeBet()*100, bot.CBet()*100, bot.WTSD()*100, bot.AF(),
+			situations.ColdCall()*100, situations.Squeeze()*100, situations.DonkBet()*100, situations.CheckRaise()*100)
+	}
+	return w.Flush()
+}
+
+// simBigBlind is the big blind of simulated cash games, which buy in for 100 big blinds
+const simBigBlind = 10
+
+// newSimEntrants creates one seeded bot per seat, cycling through the preset
+// names, chart files among them taking the library's ranges
+func newSimEntrants(names []string, seats int, seed int64, library *ranges.Library) ([]simulator.Entrant, error) {
+	entrants := []simulator.Entrant{}
+	for i := 0; i < seats; i++ {
+		name := strings.TrimSpace(names[i%len(names)])
+		maker, err := holdem_ai.CreateSeededBot(name, simulator.DeriveSeed(seed, i), ranges.WithLibrary(library))
+		if err != nil {
+			return nil, err
+		}
+		if bot, ok := maker.(*holdem_ai.BasicBotDecisionMaker); ok {
+			bot.SetThinkingTime(0, 0)
+		}
+		entrants = append(entrants, simulator.Entrant{Name: name, Maker: maker})
+	}
+	return entrants, nil
+}
+
+// serveMetrics serves the registry at /metrics on addr until stop is called
+func serveMetrics(addr string, registry *metrics.Registry) (stop func(), err error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("-metrics-addr: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", registry.Handler())
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	fmt.Fprintf(os.Stderr, "Serving metrics on http://%s/metrics\n", listener.Addr())
+	return func() { server.Close() }, nil
+}
+
+// simCashGame describes the simulated cash games, where every bot leaves
+// at the session limits
+func simCashGame(hands, exploitAfter int, limits session.Limits) simulator.CashGameConfig {
+	return simulator.CashGameConfig{
+		Game:         holdem.GameConfig{SmallBlind: simBigBlind / 2, BigBlind: simBigBlind},
+		BuyIn:        100 * simBigBlind,
+		Limits:       limits,
+		MaxHands:     hands,
+		ExploitAfter: exploitAfter,
+	}
+}
+
+// runSimulateCash plays cash games and prints each bot's results
+func runSimulateCash(out io.Writer, batch simulator.BatchConfig, entrants func(seed int64) ([]simulator.Entrant, error), seats int, game simulator.CashGameConfig) error {
+	results, err := simulator.RunCashGames(context.Background(), batch, game, entrants)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%d x %d-handed cash game, blinds %d/%d, seed %d\n\n", batch.Runs, seats, simBigBlind/2, simBigBlind, batch.Seed)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Bot\tSessions\tHands\tNet BB\tBB/100\tStop-loss\tStop-win\tTime\t")
+	for _, bot := range simulator.SummarizeCashGames(results) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%+d\t%+.1f\t%d\t%d\t%d\t\n", bot.Name, bot.Sessions, bot.Hands,
+			bot.Net/simBigBlind, bot.PerHundred(simBigBlind), bot.StopLosses, bot.StopWins, bot.TimeLimits)
+	}
+	return w.Flush()
+}
+
+// sweepParameterNames lists the parameters -sweep takes, for its usage
+func sweepParameterNames() string {
+	names := []string{}
+	for _, parameter := range simulator.SweepParameters() {
+		names = append(names, string(parameter))
+	}
+	return strings.Join(names, ", ")
+}
+
+// evaluatorNames lists the hand evaluator backends for the usage text
+func evaluatorNames() string {
+	names := []string{}
+	for _, backend := range holdem.EvaluatorBackends() {
+		names = append(names, string(backend))
+	}
+	return strings.Join(names, ", ")
+}
+
+// runSimulateSweep plays a batch of cash games at every value of the sweep
+// and prints each bot's bb/100 by value, a row per value and a column per
+// bot, the swept bot first. With csvPath it also writes every bot's results
+// by value in long format, a row per value and bot, for plotting.
+func runSimulateSweep(out io.Writer, batch simulator.BatchConfig, entrants func(seed int64) ([]simulator.Entrant, error), seats int, game simulator.CashGameConfig, sweep simulator.Sweep, csvPath string) error {
+	points, err := simulator.RunCashSweep(context.Background(), batch, game, sweep, entrants)
+	if err != nil {
+		return err
+	}
+	names := []string{}
+	for _, point := range points {
+		for _, bot := range point.Bots {
+			if !slices.Contains(names, bot.Name) {
+				names = append(names, bot.Name)
+			}
+		}
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		swept := strings.HasSuffix(names[i], simulator.SweptSuffix)
+		if swept != strings.HasSuffix(names[j], simulator.SweptSuffix) {
+			return swept
+		}
+		return names[i] < names[j]
+	})
+
+	fmt.Fprintf(out, "%s sweep over %d values: %d x %d-handed cash games each, blinds %d/%d, seed %d\n\n",
+		sweep.Parameter, len(points), batch.Runs, seats, simBigBlind/2, simBigBlind, batch.Seed)
+	fmt.Fprintln(out, "BB/100")
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "%s\t%s\t\n", sweep.Parameter, strings.Join(names, "\t"))
+	for _, point := range points {
+		row := []string{strconv.FormatFloat(point.Value, 'g', -1, 64)}
+		for _, name := range names {
+			cell := "-"
+			for _, bot := range point.Bots {
+				if bot.Name == name {
+					cell = fmt.Sprintf("%+.1f", bot.PerHundred(simBigBlind))
+				}
+			}
+			row = append(row, cell)
+		}
+		fmt.Fprintf(w, "%s\t\n", strings.Join(row, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if csvPath == "" {
+		return nil
+	}
+	if err := writeSweepCSV(csvPath, sweep.Parameter, points); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\nWrote %d values to %s\n", len(points), csvPath)
+	return nil
+}
+
+// writeSweepCSV writes a row per value and bot to the file at path
+func writeSweepCSV(path string, parameter simulator.SweepParameter, points []simulator.SweepPoint) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(file)
+	w.Write([]string{"parameter", "value", "bot", "sessions", "hands", "net_bb", "bb_per_100"})
+	for _, point := range points {
+		for _, bot := range point.Bots {
+			w.Write([]string{
+				string(parameter), strconv.FormatFloat(point.Value, 'g', -1, 64), bot.Name,
+				strconv.Itoa(bot.Sessions), strconv.Itoa(bot.Hands),
+				strconv.FormatFloat(float64(bot.Net)/simBigBlind, 'f', 1, 64),
+				strconv.FormatFloat(bot.PerHundred(simBigBlind), 'f', 2, 64),
+			})
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}